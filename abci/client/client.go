@@ -1,6 +1,7 @@
 package abcicli
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 
@@ -35,6 +36,14 @@ type Client interface {
 	InitChainAsync(types.RequestInitChain) *ReqRes
 	BeginBlockAsync(types.RequestBeginBlock) *ReqRes
 	EndBlockAsync(types.RequestEndBlock) *ReqRes
+	ListSnapshotsAsync(types.RequestListSnapshots) *ReqRes
+	OfferSnapshotAsync(types.RequestOfferSnapshot) *ReqRes
+	LoadSnapshotChunkAsync(types.RequestLoadSnapshotChunk) *ReqRes
+	ApplySnapshotChunkAsync(types.RequestApplySnapshotChunk) *ReqRes
+	ExtendVoteAsync(types.RequestExtendVote) *ReqRes
+	VerifyVoteExtensionAsync(types.RequestVerifyVoteExtension) *ReqRes
+	PrepareProposalAsync(types.RequestPrepareProposal) *ReqRes
+	ProcessProposalAsync(types.RequestProcessProposal) *ReqRes
 
 	FlushSync() error
 	EchoSync(msg string) (*types.ResponseEcho, error)
@@ -47,6 +56,45 @@ type Client interface {
 	InitChainSync(types.RequestInitChain) (*types.ResponseInitChain, error)
 	BeginBlockSync(types.RequestBeginBlock) (*types.ResponseBeginBlock, error)
 	EndBlockSync(types.RequestEndBlock) (*types.ResponseEndBlock, error)
+	ListSnapshotsSync(types.RequestListSnapshots) (*types.ResponseListSnapshots, error)
+	OfferSnapshotSync(types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error)
+	LoadSnapshotChunkSync(types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error)
+	ApplySnapshotChunkSync(types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error)
+	ExtendVoteSync(types.RequestExtendVote) (*types.ResponseExtendVote, error)
+	VerifyVoteExtensionSync(types.RequestVerifyVoteExtension) (*types.ResponseVerifyVoteExtension, error)
+	PrepareProposalSync(types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error)
+	ProcessProposalSync(types.RequestProcessProposal) (*types.ResponseProcessProposal, error)
+}
+
+// ConcurrentCheckTxClient is a Client which can run several CheckTx requests
+// concurrently. The callbacks are still called in the order of the requests.
+type ConcurrentCheckTxClient interface {
+	Client
+
+	// SetCheckTxConcurrency sets the maximum number of concurrent CheckTx
+	// requests.
+	SetCheckTxConcurrency(n int)
+}
+
+// ErrConnectionLost is the error of the calls of a ReconnectingClient which
+// were in flight when the connection to the app was lost, or made until the
+// client resumes.
+var ErrConnectionLost = errors.New("the connection to the ABCI app was lost")
+
+// ReconnectingClient is a Client which can reconnect to the app when the
+// connection to it is lost (e.g. the app restarted), rather than stopping.
+type ReconnectingClient interface {
+	Client
+
+	// SetReconnect makes the client reconnect when the connection is lost,
+	// retrying until it's stopped. onLost, if not nil, is called once the
+	// calls in flight failed, and onReconnect once it reconnected. The calls
+	// fail with ErrConnectionLost until Resume is called after reconnecting,
+	// so that the app can be brought back to the state of the caller first.
+	SetReconnect(onLost, onReconnect func())
+
+	// Resume lets the calls through again, if the client reconnected.
+	Resume()
 }
 
 //----------------------------------------
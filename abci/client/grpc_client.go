@@ -1,7 +1,10 @@
 package abcicli
 
 import (
+	"container/list"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"sync"
 	"time"
@@ -14,6 +17,11 @@ import (
 )
 
 var _ Client = (*grpcClient)(nil)
+var _ ConcurrentCheckTxClient = (*grpcClient)(nil)
+
+// deliverQueueSize is the number of calls whose callbacks can be pending
+// before the next async call blocks.
+const deliverQueueSize = 1000
 
 // A stripped copy of the remoteClient that makes
 // synchronous calls using grpc
@@ -24,16 +32,30 @@ type grpcClient struct {
 	client types.ABCIApplicationClient
 	conn   *grpc.ClientConn
 
-	mtx   sync.Mutex
-	addr  string
-	err   error
-	resCb func(*types.Request, *types.Response) // listens to all callbacks
+	mtx        sync.Mutex
+	addr       string
+	err        error
+	resCb      func(*types.Request, *types.Response) // listens to all callbacks
+	checkTxSem chan struct{}                         // limits the concurrent CheckTx calls, nil if synchronous
+
+	// The calls in the order they're made: their callbacks are called in
+	// this order, whatever the order of the responses.
+	deliverQueue chan *ReqRes
+
+	// The DeliverTx calls are pipelined on the stream, if the app supports
+	// it (nil otherwise).
+	stream       types.ABCIApplication_DeliverTxStreamClient
+	streamMtx    sync.Mutex // serializes the sends on the stream
+	streamSent   *list.List // the calls waiting for their response, protected by mtx
+	streamClosed bool       // protected by mtx
 }
 
 func NewGRPCClient(addr string, mustConnect bool) *grpcClient {
 	cli := &grpcClient{
-		addr:        addr,
-		mustConnect: mustConnect,
+		addr:         addr,
+		mustConnect:  mustConnect,
+		deliverQueue: make(chan *ReqRes, deliverQueueSize),
+		streamSent:   list.New(),
 	}
 	cli.BaseService = *cmn.NewBaseService(nil, "grpcClient", cli)
 	return cli
@@ -74,10 +96,33 @@ RETRY_LOOP:
 		}
 
 		cli.client = client
+		cli.openDeliverTxStream()
+		go cli.deliverRoutine()
 		return nil
 	}
 }
 
+// openDeliverTxStream opens the stream the DeliverTx calls are sent on,
+// unless the app doesn't implement DeliverTxStream.
+func (cli *grpcClient) openDeliverTxStream() {
+	// An empty stream ends right away if the app supports it.
+	probe, err := cli.client.DeliverTxStream(context.Background(), grpc.FailFast(true))
+	if err == nil {
+		err = probe.CloseSend()
+	}
+	if err == nil {
+		_, err = probe.Recv()
+	}
+	if err == io.EOF {
+		cli.stream, err = cli.client.DeliverTxStream(context.Background(), grpc.FailFast(true))
+	}
+	if cli.stream == nil {
+		cli.Logger.Info("DeliverTxStream unavailable, delivering the txs one at a time", "err", err)
+		return
+	}
+	go cli.recvDeliverTxRoutine(cli.stream)
+}
+
 func (cli *grpcClient) OnStop() {
 	cli.BaseService.OnStop()
 
@@ -87,11 +132,11 @@ func (cli *grpcClient) OnStop() {
 }
 
 func (cli *grpcClient) StopForError(err error) {
-	cli.mtx.Lock()
 	if !cli.IsRunning() {
 		return
 	}
 
+	cli.mtx.Lock()
 	if cli.err == nil {
 		cli.err = err
 	}
@@ -115,16 +160,34 @@ func (cli *grpcClient) SetResponseCallback(resCb Callback) {
 	cli.mtx.Unlock()
 }
 
+// SetCheckTxConcurrency lets up to n CheckTx calls run concurrently (the app
+// must support it), CheckTxAsync returning before the response then. The
+// callbacks are still called in the order of the calls.
+func (cli *grpcClient) SetCheckTxConcurrency(n int) {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	if n > 1 {
+		cli.checkTxSem = make(chan struct{}, n)
+	} else {
+		cli.checkTxSem = nil
+	}
+}
+
 //----------------------------------------
 // GRPC calls are synchronous, but some callbacks expect to be called asynchronously
 // (eg. the mempool expects to be able to lock to remove bad txs from cache).
-// To accommodate, we finish each call in its own go-routine,
-// which is expensive, but easy - if you want something better, use the socket protocol!
-// maybe one day, if people really want it, we use grpc streams,
-// but hopefully not :D
+// To accommodate, the callbacks are called by deliverRoutine, in the order
+// of the calls. CheckTx calls can also run concurrently, see
+// SetCheckTxConcurrency.
+//
+// DeliverTx calls are pipelined on the DeliverTxStream, like on the socket
+// connection: DeliverTxAsync returns before the response, and the other calls
+// wait for the responses of the previous DeliverTx calls, so the app gets the
+// requests in order.
 
 func (cli *grpcClient) EchoAsync(msg string) *ReqRes {
 	req := types.ToRequestEcho(msg)
+	cli.waitDeliverTxs()
 	res, err := cli.client.Echo(context.Background(), req.GetEcho(), grpc.FailFast(true))
 	if err != nil {
 		cli.StopForError(err)
@@ -132,17 +195,22 @@ func (cli *grpcClient) EchoAsync(msg string) *ReqRes {
 	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_Echo{Echo: res}})
 }
 
+// FlushAsync returns a ReqRes which is done once the previous calls are, like
+// on the socket connection: deliverRoutine makes the call when it gets to it.
 func (cli *grpcClient) FlushAsync() *ReqRes {
-	req := types.ToRequestFlush()
-	res, err := cli.client.Flush(context.Background(), req.GetFlush(), grpc.FailFast(true))
-	if err != nil {
-		cli.StopForError(err)
+	reqres := NewReqRes(types.ToRequestFlush())
+	select {
+	case cli.deliverQueue <- reqres:
+	case <-cli.Quit():
+		reqres.Response = &types.Response{Value: &types.Response_Flush{}}
+		reqres.Done()
 	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_Flush{Flush: res}})
+	return reqres
 }
 
 func (cli *grpcClient) InfoAsync(params types.RequestInfo) *ReqRes {
 	req := types.ToRequestInfo(params)
+	cli.waitDeliverTxs()
 	res, err := cli.client.Info(context.Background(), req.GetInfo(), grpc.FailFast(true))
 	if err != nil {
 		cli.StopForError(err)
@@ -152,6 +220,7 @@ func (cli *grpcClient) InfoAsync(params types.RequestInfo) *ReqRes {
 
 func (cli *grpcClient) SetOptionAsync(params types.RequestSetOption) *ReqRes {
 	req := types.ToRequestSetOption(params)
+	cli.waitDeliverTxs()
 	res, err := cli.client.SetOption(context.Background(), req.GetSetOption(), grpc.FailFast(true))
 	if err != nil {
 		cli.StopForError(err)
@@ -161,24 +230,64 @@ func (cli *grpcClient) SetOptionAsync(params types.RequestSetOption) *ReqRes {
 
 func (cli *grpcClient) DeliverTxAsync(tx []byte) *ReqRes {
 	req := types.ToRequestDeliverTx(tx)
-	res, err := cli.client.DeliverTx(context.Background(), req.GetDeliverTx(), grpc.FailFast(true))
-	if err != nil {
+	if cli.stream == nil {
+		res, err := cli.client.DeliverTx(context.Background(), req.GetDeliverTx(), grpc.FailFast(true))
+		if err != nil {
+			cli.StopForError(err)
+		}
+		return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_DeliverTx{DeliverTx: res}})
+	}
+
+	cli.streamMtx.Lock()
+	defer cli.streamMtx.Unlock()
+	reqres := cli.queueAsyncCall(req)
+	cli.mtx.Lock()
+	if cli.streamClosed {
+		cli.mtx.Unlock()
+		reqres.Response = &types.Response{Value: &types.Response_DeliverTx{}}
+		reqres.Done()
+		return reqres
+	}
+	cli.streamSent.PushBack(reqres)
+	cli.mtx.Unlock()
+	if err := cli.stream.Send(req.GetDeliverTx()); err != nil {
+		// recvDeliverTxRoutine releases the waiters once the stream is closed
 		cli.StopForError(err)
 	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_DeliverTx{DeliverTx: res}})
+	return reqres
 }
 
 func (cli *grpcClient) CheckTxAsync(tx []byte) *ReqRes {
 	req := types.ToRequestCheckTx(tx)
-	res, err := cli.client.CheckTx(context.Background(), req.GetCheckTx(), grpc.FailFast(true))
-	if err != nil {
-		cli.StopForError(err)
+	cli.waitDeliverTxs()
+	cli.mtx.Lock()
+	sem := cli.checkTxSem
+	cli.mtx.Unlock()
+	if sem == nil {
+		res, err := cli.client.CheckTx(context.Background(), req.GetCheckTx(), grpc.FailFast(true))
+		if err != nil {
+			cli.StopForError(err)
+		}
+		return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_CheckTx{CheckTx: res}})
 	}
-	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_CheckTx{CheckTx: res}})
+
+	reqres := cli.queueAsyncCall(req)
+	sem <- struct{}{}
+	go func() {
+		defer func() { <-sem }()
+		res, err := cli.client.CheckTx(context.Background(), req.GetCheckTx(), grpc.FailFast(true))
+		if err != nil {
+			cli.StopForError(err)
+		}
+		reqres.Response = &types.Response{Value: &types.Response_CheckTx{CheckTx: res}}
+		reqres.Done()
+	}()
+	return reqres
 }
 
 func (cli *grpcClient) QueryAsync(params types.RequestQuery) *ReqRes {
 	req := types.ToRequestQuery(params)
+	cli.waitDeliverTxs()
 	res, err := cli.client.Query(context.Background(), req.GetQuery(), grpc.FailFast(true))
 	if err != nil {
 		cli.StopForError(err)
@@ -188,6 +297,7 @@ func (cli *grpcClient) QueryAsync(params types.RequestQuery) *ReqRes {
 
 func (cli *grpcClient) CommitAsync() *ReqRes {
 	req := types.ToRequestCommit()
+	cli.waitDeliverTxs()
 	res, err := cli.client.Commit(context.Background(), req.GetCommit(), grpc.FailFast(true))
 	if err != nil {
 		cli.StopForError(err)
@@ -197,6 +307,7 @@ func (cli *grpcClient) CommitAsync() *ReqRes {
 
 func (cli *grpcClient) InitChainAsync(params types.RequestInitChain) *ReqRes {
 	req := types.ToRequestInitChain(params)
+	cli.waitDeliverTxs()
 	res, err := cli.client.InitChain(context.Background(), req.GetInitChain(), grpc.FailFast(true))
 	if err != nil {
 		cli.StopForError(err)
@@ -206,6 +317,7 @@ func (cli *grpcClient) InitChainAsync(params types.RequestInitChain) *ReqRes {
 
 func (cli *grpcClient) BeginBlockAsync(params types.RequestBeginBlock) *ReqRes {
 	req := types.ToRequestBeginBlock(params)
+	cli.waitDeliverTxs()
 	res, err := cli.client.BeginBlock(context.Background(), req.GetBeginBlock(), grpc.FailFast(true))
 	if err != nil {
 		cli.StopForError(err)
@@ -215,6 +327,7 @@ func (cli *grpcClient) BeginBlockAsync(params types.RequestBeginBlock) *ReqRes {
 
 func (cli *grpcClient) EndBlockAsync(params types.RequestEndBlock) *ReqRes {
 	req := types.ToRequestEndBlock(params)
+	cli.waitDeliverTxs()
 	res, err := cli.client.EndBlock(context.Background(), req.GetEndBlock(), grpc.FailFast(true))
 	if err != nil {
 		cli.StopForError(err)
@@ -222,31 +335,218 @@ func (cli *grpcClient) EndBlockAsync(params types.RequestEndBlock) *ReqRes {
 	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_EndBlock{EndBlock: res}})
 }
 
+func (cli *grpcClient) ListSnapshotsAsync(params types.RequestListSnapshots) *ReqRes {
+	req := types.ToRequestListSnapshots(params)
+	cli.waitDeliverTxs()
+	res, err := cli.client.ListSnapshots(context.Background(), req.GetListSnapshots(), grpc.FailFast(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_ListSnapshots{ListSnapshots: res}})
+}
+
+func (cli *grpcClient) OfferSnapshotAsync(params types.RequestOfferSnapshot) *ReqRes {
+	req := types.ToRequestOfferSnapshot(params)
+	cli.waitDeliverTxs()
+	res, err := cli.client.OfferSnapshot(context.Background(), req.GetOfferSnapshot(), grpc.FailFast(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_OfferSnapshot{OfferSnapshot: res}})
+}
+
+func (cli *grpcClient) LoadSnapshotChunkAsync(params types.RequestLoadSnapshotChunk) *ReqRes {
+	req := types.ToRequestLoadSnapshotChunk(params)
+	cli.waitDeliverTxs()
+	res, err := cli.client.LoadSnapshotChunk(context.Background(), req.GetLoadSnapshotChunk(), grpc.FailFast(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_LoadSnapshotChunk{LoadSnapshotChunk: res}})
+}
+
+func (cli *grpcClient) ApplySnapshotChunkAsync(params types.RequestApplySnapshotChunk) *ReqRes {
+	req := types.ToRequestApplySnapshotChunk(params)
+	cli.waitDeliverTxs()
+	res, err := cli.client.ApplySnapshotChunk(context.Background(), req.GetApplySnapshotChunk(), grpc.FailFast(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_ApplySnapshotChunk{ApplySnapshotChunk: res}})
+}
+
+func (cli *grpcClient) ExtendVoteAsync(params types.RequestExtendVote) *ReqRes {
+	req := types.ToRequestExtendVote(params)
+	cli.waitDeliverTxs()
+	res, err := cli.client.ExtendVote(context.Background(), req.GetExtendVote(), grpc.FailFast(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_ExtendVote{ExtendVote: res}})
+}
+
+func (cli *grpcClient) VerifyVoteExtensionAsync(params types.RequestVerifyVoteExtension) *ReqRes {
+	req := types.ToRequestVerifyVoteExtension(params)
+	cli.waitDeliverTxs()
+	res, err := cli.client.VerifyVoteExtension(context.Background(), req.GetVerifyVoteExtension(), grpc.FailFast(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_VerifyVoteExtension{VerifyVoteExtension: res}})
+}
+
+func (cli *grpcClient) PrepareProposalAsync(params types.RequestPrepareProposal) *ReqRes {
+	req := types.ToRequestPrepareProposal(params)
+	cli.waitDeliverTxs()
+	res, err := cli.client.PrepareProposal(context.Background(), req.GetPrepareProposal(), grpc.FailFast(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_PrepareProposal{PrepareProposal: res}})
+}
+
+func (cli *grpcClient) ProcessProposalAsync(params types.RequestProcessProposal) *ReqRes {
+	req := types.ToRequestProcessProposal(params)
+	cli.waitDeliverTxs()
+	res, err := cli.client.ProcessProposal(context.Background(), req.GetProcessProposal(), grpc.FailFast(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	return cli.finishAsyncCall(req, &types.Response{Value: &types.Response_ProcessProposal{ProcessProposal: res}})
+}
+
 func (cli *grpcClient) finishAsyncCall(req *types.Request, res *types.Response) *ReqRes {
-	reqres := NewReqRes(req)
+	reqres := cli.queueAsyncCall(req)
 	reqres.Response = res // Set response
 	reqres.Done()         // Release waiters
-	reqres.SetDone()      // so reqRes.SetCallback will run the callback
+	return reqres
+}
 
-	// go routine for callbacks
-	go func() {
-		// Notify reqRes listener if set
-		if cb := reqres.GetCallback(); cb != nil {
-			cb(res)
+// waitDeliverTxs waits for the responses of the DeliverTx calls sent on the
+// stream.
+func (cli *grpcClient) waitDeliverTxs() {
+	cli.mtx.Lock()
+	last := cli.streamSent.Back()
+	cli.mtx.Unlock()
+	if last != nil {
+		// the responses are received in order
+		last.Value.(*ReqRes).Wait()
+	}
+}
+
+// recvDeliverTxRoutine matches the responses received on the stream with the
+// DeliverTx calls, in order.
+func (cli *grpcClient) recvDeliverTxRoutine(stream types.ABCIApplication_DeliverTxStreamClient) {
+	defer cli.closeDeliverTxStream()
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			err = errors.New("DeliverTxStream closed by the app")
+		}
+		if err != nil {
+			cli.StopForError(err)
+			return
 		}
 
-		// Notify client listener if set
-		if cli.resCb != nil {
-			cli.resCb(reqres.Request, res)
+		cli.mtx.Lock()
+		next := cli.streamSent.Front()
+		if next != nil {
+			cli.streamSent.Remove(next)
 		}
-	}()
+		cli.mtx.Unlock()
+		if next == nil {
+			cli.StopForError(errors.New("unexpected DeliverTx response when nothing expected"))
+			return
+		}
+
+		reqres := next.Value.(*ReqRes)
+		reqres.Response = &types.Response{Value: &types.Response_DeliverTx{DeliverTx: res}}
+		reqres.Done()
+	}
+}
+
+// closeDeliverTxStream releases the waiters of the DeliverTx calls without a
+// response (they will get cli.Error()), and of those made afterwards.
+func (cli *grpcClient) closeDeliverTxStream() {
+	cli.mtx.Lock()
+	cli.streamClosed = true
+	sent := cli.streamSent
+	cli.streamSent = list.New()
+	cli.mtx.Unlock()
+
+	for e := sent.Front(); e != nil; e = e.Next() {
+		reqres := e.Value.(*ReqRes)
+		reqres.Response = &types.Response{Value: &types.Response_DeliverTx{}}
+		reqres.Done()
+	}
+}
+
+// queueAsyncCall returns the ReqRes of the call, whose callbacks are called
+// by deliverRoutine once it's done, after those of the previous calls.
+func (cli *grpcClient) queueAsyncCall(req *types.Request) *ReqRes {
+	reqres := NewReqRes(req)
+	select {
+	case cli.deliverQueue <- reqres:
+	case <-cli.Quit(): // the callbacks won't be called
+	}
 	return reqres
 }
 
+// deliverRoutine calls the callbacks of the calls, in the order they're made.
+func (cli *grpcClient) deliverRoutine() {
+	for {
+		select {
+		case reqres := <-cli.deliverQueue:
+			if _, ok := reqres.Request.Value.(*types.Request_Flush); ok {
+				// the previous calls are done
+				cli.flush(reqres)
+			}
+			reqres.Wait()
+
+			// so reqRes.SetCallback will run the callback if it's not set yet
+			reqres.mtx.Lock()
+			reqres.done = true
+			cb := reqres.cb
+			reqres.mtx.Unlock()
+
+			// Notify reqRes listener if set
+			if cb != nil {
+				cb(reqres.Response)
+			}
+
+			// Notify client listener if set
+			cli.mtx.Lock()
+			resCb := cli.resCb
+			cli.mtx.Unlock()
+			if resCb != nil {
+				resCb(reqres.Request, reqres.Response)
+			}
+		case <-cli.Quit():
+			return
+		}
+	}
+}
+
+func (cli *grpcClient) flush(reqres *ReqRes) {
+	res, err := cli.client.Flush(context.Background(), reqres.Request.GetFlush(), grpc.FailFast(true))
+	if err != nil {
+		cli.StopForError(err)
+	}
+	reqres.Response = &types.Response{Value: &types.Response_Flush{Flush: res}}
+	reqres.Done()
+}
+
 //----------------------------------------
 
+// FlushSync waits for the callbacks of the previous calls to be called.
 func (cli *grpcClient) FlushSync() error {
-	return nil
+	delivered := make(chan struct{})
+	cli.FlushAsync().SetCallback(func(*types.Response) { close(delivered) })
+	select {
+	case <-delivered:
+	case <-cli.Quit():
+	}
+	return cli.Error()
 }
 
 func (cli *grpcClient) EchoSync(msg string) (*types.ResponseEcho, error) {
@@ -267,11 +567,13 @@ func (cli *grpcClient) SetOptionSync(req types.RequestSetOption) (*types.Respons
 
 func (cli *grpcClient) DeliverTxSync(tx []byte) (*types.ResponseDeliverTx, error) {
 	reqres := cli.DeliverTxAsync(tx)
+	reqres.Wait()
 	return reqres.Response.GetDeliverTx(), cli.Error()
 }
 
 func (cli *grpcClient) CheckTxSync(tx []byte) (*types.ResponseCheckTx, error) {
 	reqres := cli.CheckTxAsync(tx)
+	reqres.Wait()
 	return reqres.Response.GetCheckTx(), cli.Error()
 }
 
@@ -299,3 +601,43 @@ func (cli *grpcClient) EndBlockSync(params types.RequestEndBlock) (*types.Respon
 	reqres := cli.EndBlockAsync(params)
 	return reqres.Response.GetEndBlock(), cli.Error()
 }
+
+func (cli *grpcClient) ListSnapshotsSync(params types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
+	reqres := cli.ListSnapshotsAsync(params)
+	return reqres.Response.GetListSnapshots(), cli.Error()
+}
+
+func (cli *grpcClient) OfferSnapshotSync(params types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
+	reqres := cli.OfferSnapshotAsync(params)
+	return reqres.Response.GetOfferSnapshot(), cli.Error()
+}
+
+func (cli *grpcClient) LoadSnapshotChunkSync(params types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
+	reqres := cli.LoadSnapshotChunkAsync(params)
+	return reqres.Response.GetLoadSnapshotChunk(), cli.Error()
+}
+
+func (cli *grpcClient) ApplySnapshotChunkSync(params types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
+	reqres := cli.ApplySnapshotChunkAsync(params)
+	return reqres.Response.GetApplySnapshotChunk(), cli.Error()
+}
+
+func (cli *grpcClient) ExtendVoteSync(params types.RequestExtendVote) (*types.ResponseExtendVote, error) {
+	reqres := cli.ExtendVoteAsync(params)
+	return reqres.Response.GetExtendVote(), cli.Error()
+}
+
+func (cli *grpcClient) VerifyVoteExtensionSync(params types.RequestVerifyVoteExtension) (*types.ResponseVerifyVoteExtension, error) {
+	reqres := cli.VerifyVoteExtensionAsync(params)
+	return reqres.Response.GetVerifyVoteExtension(), cli.Error()
+}
+
+func (cli *grpcClient) PrepareProposalSync(params types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error) {
+	reqres := cli.PrepareProposalAsync(params)
+	return reqres.Response.GetPrepareProposal(), cli.Error()
+}
+
+func (cli *grpcClient) ProcessProposalSync(params types.RequestProcessProposal) (*types.ResponseProcessProposal, error) {
+	reqres := cli.ProcessProposalAsync(params)
+	return reqres.Response.GetProcessProposal(), cli.Error()
+}
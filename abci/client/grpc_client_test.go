@@ -0,0 +1,205 @@
+package abcicli_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/server"
+	"github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+)
+
+func startGRPCClient(t *testing.T, app types.ABCIApplicationServer) (abcicli.Client, func()) {
+	port := 20000 + cmn.RandInt32()%10000
+	addr := fmt.Sprintf("tcp://localhost:%d", port)
+
+	s := server.NewGRPCServer(addr, app)
+	require.NoError(t, s.Start())
+
+	c := abcicli.NewGRPCClient(addr, true)
+	require.NoError(t, c.Start())
+	return c, func() {
+		c.Stop()
+		s.Stop()
+	}
+}
+
+func TestGRPCClientDeliverTxStream(t *testing.T) {
+	for name, streaming := range map[string]bool{"stream": true, "unary": false} {
+		streaming := streaming
+		t.Run(name, func(t *testing.T) {
+			app := &deliverTxApp{release: make(chan struct{})}
+			var srv types.ABCIApplicationServer = types.NewGRPCApplication(app)
+			if !streaming {
+				srv = noStreamApp{srv}
+				close(app.release)
+			}
+			c, stop := startGRPCClient(t, srv)
+			defer stop()
+
+			var (
+				mtx      sync.Mutex
+				received []byte
+			)
+			c.SetResponseCallback(func(req *types.Request, res *types.Response) {
+				if r, ok := res.Value.(*types.Response_DeliverTx); ok {
+					mtx.Lock()
+					received = append(received, r.DeliverTx.Data[0])
+					mtx.Unlock()
+				}
+			})
+
+			const n = 8
+			reqres := make([]*abcicli.ReqRes, n)
+			for i := byte(0); i < n; i++ {
+				reqres[i] = c.DeliverTxAsync([]byte{i})
+			}
+			if streaming {
+				// The txs are pipelined, DeliverTxAsync doesn't wait for the app.
+				close(app.release)
+			}
+
+			// EndBlock gets to the app after the txs.
+			res, err := c.EndBlockSync(types.RequestEndBlock{Height: 1})
+			require.NoError(t, err)
+			assert.EqualValues(t, n, res.Tags[0].Value[0])
+			for i, rr := range reqres {
+				assert.Equal(t, []byte{byte(i)}, rr.Response.GetDeliverTx().Data)
+			}
+
+			require.NoError(t, c.FlushSync())
+			mtx.Lock()
+			assert.Equal(t, []byte{0, 1, 2, 3, 4, 5, 6, 7}, received)
+			mtx.Unlock()
+
+			dres, err := c.DeliverTxSync([]byte{n})
+			require.NoError(t, err)
+			assert.Equal(t, []byte{n}, dres.Data)
+		})
+	}
+}
+
+func TestGRPCClientFlush(t *testing.T) {
+	app := &concurrentApp{}
+	c, stop := startGRPCClient(t, types.NewGRPCApplication(app))
+	defer stop()
+	c.(abcicli.ConcurrentCheckTxClient).SetCheckTxConcurrency(4)
+
+	reqres := make([]*abcicli.ReqRes, 4)
+	for i := range reqres {
+		reqres[i] = c.CheckTxAsync([]byte{byte(i)})
+	}
+
+	// The flush is done once the previous calls are.
+	flush := c.FlushAsync()
+	flush.Wait()
+	require.NotNil(t, flush.Response.GetFlush())
+	for _, rr := range reqres {
+		assert.NotNil(t, rr.Response.GetCheckTx())
+	}
+}
+
+func TestGRPCClientConcurrentCheckTx(t *testing.T) {
+	app := &concurrentApp{}
+	port := 20000 + cmn.RandInt32()%10000
+	addr := fmt.Sprintf("tcp://localhost:%d", port)
+
+	s, err := server.NewServer(addr, "grpc", app)
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+	defer s.Stop()
+
+	c := abcicli.NewGRPCClient(addr, true)
+	require.NoError(t, c.Start())
+	defer c.Stop()
+	c.SetCheckTxConcurrency(4)
+
+	var (
+		mtx      sync.Mutex
+		received []byte
+	)
+	c.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		if r, ok := req.Value.(*types.Request_CheckTx); ok {
+			mtx.Lock()
+			received = append(received, r.CheckTx.Tx[0])
+			mtx.Unlock()
+		}
+	})
+
+	// The first txs take the longest, but the callbacks are in order
+	const n = 8
+	for i := byte(0); i < n; i++ {
+		c.CheckTxAsync([]byte{i})
+	}
+	require.NoError(t, c.FlushSync())
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.Equal(t, []byte{0, 1, 2, 3, 4, 5, 6, 7}, received)
+	assert.Equal(t, 4, app.maxRunning)
+
+	// CheckTxSync waits for the response
+	res, err := c.CheckTxSync([]byte{0})
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, res.Code)
+}
+
+type concurrentApp struct {
+	types.BaseApplication
+
+	mtx        sync.Mutex
+	running    int
+	maxRunning int
+}
+
+func (app *concurrentApp) CheckTx(tx []byte) types.ResponseCheckTx {
+	app.mtx.Lock()
+	app.running++
+	if app.running > app.maxRunning {
+		app.maxRunning = app.running
+	}
+	app.mtx.Unlock()
+
+	time.Sleep(time.Duration(10-tx[0]) * 20 * time.Millisecond)
+
+	app.mtx.Lock()
+	app.running--
+	app.mtx.Unlock()
+	return types.ResponseCheckTx{}
+}
+
+// deliverTxApp echoes the txs, and tags their number at EndBlock. The first
+// DeliverTx waits for release.
+type deliverTxApp struct {
+	types.BaseApplication
+
+	release   chan struct{}
+	delivered int
+}
+
+func (app *deliverTxApp) DeliverTx(tx []byte) types.ResponseDeliverTx {
+	<-app.release
+	app.delivered++
+	return types.ResponseDeliverTx{Data: tx}
+}
+
+func (app *deliverTxApp) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
+	return types.ResponseEndBlock{Tags: cmn.KVPairs{{Key: []byte("delivered"), Value: []byte{byte(app.delivered)}}}}
+}
+
+// noStreamApp is an app which doesn't implement DeliverTxStream.
+type noStreamApp struct {
+	types.ABCIApplicationServer
+}
+
+func (noStreamApp) DeliverTxStream(types.ABCIApplication_DeliverTxStreamServer) error {
+	return status.Error(codes.Unimplemented, "DeliverTxStream not implemented")
+}
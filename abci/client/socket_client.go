@@ -18,7 +18,7 @@ const reqQueueSize = 256 // TODO make configurable
 // const maxResponseSize = 1048576 // 1MB TODO make configurable
 const flushThrottleMS = 20 // Don't wait longer than...
 
-var _ Client = (*socketClient)(nil)
+var _ ReconnectingClient = (*socketClient)(nil)
 
 // This is goroutine-safe, but users should beware that
 // the application in general is not meant to be interfaced
@@ -26,22 +26,35 @@ var _ Client = (*socketClient)(nil)
 type socketClient struct {
 	cmn.BaseService
 
-	reqQueue    chan *ReqRes
+	reqQueue    chan queuedReq
 	flushTimer  *cmn.ThrottleTimer
 	mustConnect bool
 
 	mtx     sync.Mutex
 	addr    string
-	conn    net.Conn
+	conn    net.Conn // nil while reconnecting
+	gen     uint64   // incremented when the connection is lost
 	err     error
 	reqSent *list.List
 	resCb   func(*types.Request, *types.Response) // listens to all callbacks
 
+	// see SetReconnect
+	reconnect   bool
+	lost        bool // the calls fail, until resumed
+	onLost      func()
+	onReconnect func()
+}
+
+// queuedReq is a request queued while the generation of the connection was
+// gen. It's only sent on that connection.
+type queuedReq struct {
+	reqres *ReqRes
+	gen    uint64
 }
 
 func NewSocketClient(addr string, mustConnect bool) *socketClient {
 	cli := &socketClient{
-		reqQueue:    make(chan *ReqRes, reqQueueSize),
+		reqQueue:    make(chan queuedReq, reqQueueSize),
 		flushTimer:  cmn.NewThrottleTimer("socketClient", flushThrottleMS),
 		mustConnect: mustConnect,
 
@@ -73,7 +86,7 @@ RETRY_LOOP:
 		}
 		cli.conn = conn
 
-		go cli.sendRequestsRoutine(conn)
+		go cli.sendRequestsRoutine()
 		go cli.recvResponseRoutine(conn)
 
 		return nil
@@ -111,9 +124,45 @@ func (cli *socketClient) StopForError(err error) {
 func (cli *socketClient) Error() error {
 	cli.mtx.Lock()
 	defer cli.mtx.Unlock()
+	if cli.err == nil && cli.lost {
+		return ErrConnectionLost
+	}
 	return cli.err
 }
 
+// resError returns the error of a sync call: the error of the client, or
+// ErrConnectionLost if the request was lost with the connection.
+func (cli *socketClient) resError(reqres *ReqRes) error {
+	if err := cli.Error(); err != nil {
+		return err
+	}
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	if cli.reconnect && reqres.Response == nil {
+		return ErrConnectionLost
+	}
+	return nil
+}
+
+// SetReconnect implements ReconnectingClient. It must be called before the
+// client is started.
+func (cli *socketClient) SetReconnect(onLost, onReconnect func()) {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	cli.reconnect = true
+	cli.onLost = onLost
+	cli.onReconnect = onReconnect
+}
+
+// Resume implements ReconnectingClient.
+func (cli *socketClient) Resume() {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	if cli.conn != nil {
+		cli.lost = false
+	}
+}
+
 // Set listener for all responses
 // NOTE: callback may get internally generated flush responses.
 func (cli *socketClient) SetResponseCallback(resCb Callback) {
@@ -124,32 +173,51 @@ func (cli *socketClient) SetResponseCallback(resCb Callback) {
 
 //----------------------------------------
 
-func (cli *socketClient) sendRequestsRoutine(conn net.Conn) {
-
-	w := bufio.NewWriter(conn)
+// sendRequestsRoutine writes the requests on the current connection, for the
+// life of the client.
+func (cli *socketClient) sendRequestsRoutine() {
+	var (
+		conn net.Conn
+		w    *bufio.Writer
+	)
 	for {
 		select {
 		case <-cli.flushTimer.Ch:
+			cli.mtx.Lock()
+			gen := cli.gen
+			cli.mtx.Unlock()
 			select {
-			case cli.reqQueue <- NewReqRes(types.ToRequestFlush()):
+			case cli.reqQueue <- queuedReq{NewReqRes(types.ToRequestFlush()), gen}:
 			default:
 				// Probably will fill the buffer, or retry later.
 			}
 		case <-cli.Quit():
 			return
-		case reqres := <-cli.reqQueue:
-			cli.willSendReq(reqres)
+		case q := <-cli.reqQueue:
+			reqres := q.reqres
+			c := cli.willSendReq(q)
+			if c == nil {
+				// Queued on a lost connection.
+				reqres.Done()
+				continue
+			}
+			if c != conn {
+				conn, w = c, bufio.NewWriter(c)
+			}
 			err := types.WriteMessage(reqres.Request, w)
 			if err != nil {
-				cli.StopForError(fmt.Errorf("Error writing msg: %v", err))
-				return
+				if !cli.connectionLost(conn, fmt.Errorf("Error writing msg: %v", err)) {
+					return
+				}
+				continue
 			}
 			// cli.Logger.Debug("Sent request", "requestType", reflect.TypeOf(reqres.Request), "request", reqres.Request)
 			if _, ok := reqres.Request.Value.(*types.Request_Flush); ok {
 				err = w.Flush()
 				if err != nil {
-					cli.StopForError(fmt.Errorf("Error flushing writer: %v", err))
-					return
+					if !cli.connectionLost(conn, fmt.Errorf("Error flushing writer: %v", err)) {
+						return
+					}
 				}
 			}
 		}
@@ -163,7 +231,7 @@ func (cli *socketClient) recvResponseRoutine(conn net.Conn) {
 		var res = &types.Response{}
 		err := types.ReadMessage(r, res)
 		if err != nil {
-			cli.StopForError(err)
+			cli.connectionLost(conn, err)
 			return
 		}
 		switch r := res.Value.(type) {
@@ -173,7 +241,7 @@ func (cli *socketClient) recvResponseRoutine(conn net.Conn) {
 			return
 		default:
 			// cli.Logger.Debug("Received response", "responseType", reflect.TypeOf(res), "response", res)
-			err := cli.didRecvResponse(res)
+			err := cli.didRecvResponse(conn, res)
 			if err != nil {
 				cli.StopForError(err)
 				return
@@ -182,16 +250,27 @@ func (cli *socketClient) recvResponseRoutine(conn net.Conn) {
 	}
 }
 
-func (cli *socketClient) willSendReq(reqres *ReqRes) {
+// willSendReq returns the connection to send the request on, or nil if it was
+// queued on a lost connection.
+func (cli *socketClient) willSendReq(q queuedReq) net.Conn {
 	cli.mtx.Lock()
 	defer cli.mtx.Unlock()
-	cli.reqSent.PushBack(reqres)
+	if q.gen != cli.gen || cli.conn == nil {
+		return nil
+	}
+	cli.reqSent.PushBack(q.reqres)
+	return cli.conn
 }
 
-func (cli *socketClient) didRecvResponse(res *types.Response) error {
+func (cli *socketClient) didRecvResponse(conn net.Conn, res *types.Response) error {
 	cli.mtx.Lock()
 	defer cli.mtx.Unlock()
 
+	if conn != cli.conn {
+		// A response left on a lost connection.
+		return nil
+	}
+
 	// Get the first ReqRes
 	next := cli.reqSent.Front()
 	if next == nil {
@@ -220,6 +299,70 @@ func (cli *socketClient) didRecvResponse(res *types.Response) error {
 	return nil
 }
 
+// connectionLost stops the client for the error on the connection, unless it
+// reconnects (see SetReconnect). Then the calls in flight fail, and it
+// reconnects in the background. It returns whether the client reconnects.
+func (cli *socketClient) connectionLost(conn net.Conn, err error) bool {
+	cli.mtx.Lock()
+	if !cli.reconnect {
+		cli.mtx.Unlock()
+		cli.StopForError(err)
+		return false
+	}
+	if conn != cli.conn || !cli.IsRunning() {
+		// Already lost, or stopped.
+		cli.mtx.Unlock()
+		return true
+	}
+	conn.Close()
+	cli.conn = nil
+	cli.gen++
+	cli.lost = true
+	cli.flushQueue()
+	cli.reqSent = list.New()
+	onLost := cli.onLost
+	cli.mtx.Unlock()
+
+	cli.Logger.Error("Lost the connection to the app, reconnecting", "addr", cli.addr, "err", err)
+	if onLost != nil {
+		onLost()
+	}
+	go cli.reconnectRoutine()
+	return true
+}
+
+func (cli *socketClient) reconnectRoutine() {
+	for {
+		conn, err := cmn.Connect(cli.addr)
+		if err != nil {
+			cli.Logger.Error(fmt.Sprintf("abci.socketClient failed to reconnect to %v.  Retrying...", cli.addr), "err", err)
+			select {
+			case <-time.After(time.Second * dialRetryIntervalSeconds):
+				continue
+			case <-cli.Quit():
+				return
+			}
+		}
+
+		cli.mtx.Lock()
+		if !cli.IsRunning() {
+			cli.mtx.Unlock()
+			conn.Close()
+			return
+		}
+		cli.conn = conn
+		onReconnect := cli.onReconnect
+		cli.mtx.Unlock()
+
+		go cli.recvResponseRoutine(conn)
+		cli.Logger.Info("Reconnected to the app", "addr", cli.addr)
+		if onReconnect != nil {
+			onReconnect()
+		}
+		return
+	}
+}
+
 //----------------------------------------
 
 func (cli *socketClient) EchoAsync(msg string) *ReqRes {
@@ -266,6 +409,38 @@ func (cli *socketClient) EndBlockAsync(req types.RequestEndBlock) *ReqRes {
 	return cli.queueRequest(types.ToRequestEndBlock(req))
 }
 
+func (cli *socketClient) ListSnapshotsAsync(req types.RequestListSnapshots) *ReqRes {
+	return cli.queueRequest(types.ToRequestListSnapshots(req))
+}
+
+func (cli *socketClient) OfferSnapshotAsync(req types.RequestOfferSnapshot) *ReqRes {
+	return cli.queueRequest(types.ToRequestOfferSnapshot(req))
+}
+
+func (cli *socketClient) LoadSnapshotChunkAsync(req types.RequestLoadSnapshotChunk) *ReqRes {
+	return cli.queueRequest(types.ToRequestLoadSnapshotChunk(req))
+}
+
+func (cli *socketClient) ApplySnapshotChunkAsync(req types.RequestApplySnapshotChunk) *ReqRes {
+	return cli.queueRequest(types.ToRequestApplySnapshotChunk(req))
+}
+
+func (cli *socketClient) ExtendVoteAsync(req types.RequestExtendVote) *ReqRes {
+	return cli.queueRequest(types.ToRequestExtendVote(req))
+}
+
+func (cli *socketClient) VerifyVoteExtensionAsync(req types.RequestVerifyVoteExtension) *ReqRes {
+	return cli.queueRequest(types.ToRequestVerifyVoteExtension(req))
+}
+
+func (cli *socketClient) PrepareProposalAsync(req types.RequestPrepareProposal) *ReqRes {
+	return cli.queueRequest(types.ToRequestPrepareProposal(req))
+}
+
+func (cli *socketClient) ProcessProposalAsync(req types.RequestProcessProposal) *ReqRes {
+	return cli.queueRequest(types.ToRequestProcessProposal(req))
+}
+
 //----------------------------------------
 
 func (cli *socketClient) FlushSync() error {
@@ -274,67 +449,115 @@ func (cli *socketClient) FlushSync() error {
 		return err
 	}
 	reqRes.Wait() // NOTE: if we don't flush the queue, its possible to get stuck here
-	return cli.Error()
+	return cli.resError(reqRes)
 }
 
 func (cli *socketClient) EchoSync(msg string) (*types.ResponseEcho, error) {
 	reqres := cli.queueRequest(types.ToRequestEcho(msg))
 	cli.FlushSync()
-	return reqres.Response.GetEcho(), cli.Error()
+	return reqres.Response.GetEcho(), cli.resError(reqres)
 }
 
 func (cli *socketClient) InfoSync(req types.RequestInfo) (*types.ResponseInfo, error) {
 	reqres := cli.queueRequest(types.ToRequestInfo(req))
 	cli.FlushSync()
-	return reqres.Response.GetInfo(), cli.Error()
+	return reqres.Response.GetInfo(), cli.resError(reqres)
 }
 
 func (cli *socketClient) SetOptionSync(req types.RequestSetOption) (*types.ResponseSetOption, error) {
 	reqres := cli.queueRequest(types.ToRequestSetOption(req))
 	cli.FlushSync()
-	return reqres.Response.GetSetOption(), cli.Error()
+	return reqres.Response.GetSetOption(), cli.resError(reqres)
 }
 
 func (cli *socketClient) DeliverTxSync(tx []byte) (*types.ResponseDeliverTx, error) {
 	reqres := cli.queueRequest(types.ToRequestDeliverTx(tx))
 	cli.FlushSync()
-	return reqres.Response.GetDeliverTx(), cli.Error()
+	return reqres.Response.GetDeliverTx(), cli.resError(reqres)
 }
 
 func (cli *socketClient) CheckTxSync(tx []byte) (*types.ResponseCheckTx, error) {
 	reqres := cli.queueRequest(types.ToRequestCheckTx(tx))
 	cli.FlushSync()
-	return reqres.Response.GetCheckTx(), cli.Error()
+	return reqres.Response.GetCheckTx(), cli.resError(reqres)
 }
 
 func (cli *socketClient) QuerySync(req types.RequestQuery) (*types.ResponseQuery, error) {
 	reqres := cli.queueRequest(types.ToRequestQuery(req))
 	cli.FlushSync()
-	return reqres.Response.GetQuery(), cli.Error()
+	return reqres.Response.GetQuery(), cli.resError(reqres)
 }
 
 func (cli *socketClient) CommitSync() (*types.ResponseCommit, error) {
 	reqres := cli.queueRequest(types.ToRequestCommit())
 	cli.FlushSync()
-	return reqres.Response.GetCommit(), cli.Error()
+	return reqres.Response.GetCommit(), cli.resError(reqres)
 }
 
 func (cli *socketClient) InitChainSync(req types.RequestInitChain) (*types.ResponseInitChain, error) {
 	reqres := cli.queueRequest(types.ToRequestInitChain(req))
 	cli.FlushSync()
-	return reqres.Response.GetInitChain(), cli.Error()
+	return reqres.Response.GetInitChain(), cli.resError(reqres)
 }
 
 func (cli *socketClient) BeginBlockSync(req types.RequestBeginBlock) (*types.ResponseBeginBlock, error) {
 	reqres := cli.queueRequest(types.ToRequestBeginBlock(req))
 	cli.FlushSync()
-	return reqres.Response.GetBeginBlock(), cli.Error()
+	return reqres.Response.GetBeginBlock(), cli.resError(reqres)
 }
 
 func (cli *socketClient) EndBlockSync(req types.RequestEndBlock) (*types.ResponseEndBlock, error) {
 	reqres := cli.queueRequest(types.ToRequestEndBlock(req))
 	cli.FlushSync()
-	return reqres.Response.GetEndBlock(), cli.Error()
+	return reqres.Response.GetEndBlock(), cli.resError(reqres)
+}
+
+func (cli *socketClient) ListSnapshotsSync(req types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
+	reqres := cli.queueRequest(types.ToRequestListSnapshots(req))
+	cli.FlushSync()
+	return reqres.Response.GetListSnapshots(), cli.resError(reqres)
+}
+
+func (cli *socketClient) OfferSnapshotSync(req types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
+	reqres := cli.queueRequest(types.ToRequestOfferSnapshot(req))
+	cli.FlushSync()
+	return reqres.Response.GetOfferSnapshot(), cli.resError(reqres)
+}
+
+func (cli *socketClient) LoadSnapshotChunkSync(req types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
+	reqres := cli.queueRequest(types.ToRequestLoadSnapshotChunk(req))
+	cli.FlushSync()
+	return reqres.Response.GetLoadSnapshotChunk(), cli.resError(reqres)
+}
+
+func (cli *socketClient) ApplySnapshotChunkSync(req types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
+	reqres := cli.queueRequest(types.ToRequestApplySnapshotChunk(req))
+	cli.FlushSync()
+	return reqres.Response.GetApplySnapshotChunk(), cli.resError(reqres)
+}
+
+func (cli *socketClient) ExtendVoteSync(req types.RequestExtendVote) (*types.ResponseExtendVote, error) {
+	reqres := cli.queueRequest(types.ToRequestExtendVote(req))
+	cli.FlushSync()
+	return reqres.Response.GetExtendVote(), cli.resError(reqres)
+}
+
+func (cli *socketClient) VerifyVoteExtensionSync(req types.RequestVerifyVoteExtension) (*types.ResponseVerifyVoteExtension, error) {
+	reqres := cli.queueRequest(types.ToRequestVerifyVoteExtension(req))
+	cli.FlushSync()
+	return reqres.Response.GetVerifyVoteExtension(), cli.resError(reqres)
+}
+
+func (cli *socketClient) PrepareProposalSync(req types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error) {
+	reqres := cli.queueRequest(types.ToRequestPrepareProposal(req))
+	cli.FlushSync()
+	return reqres.Response.GetPrepareProposal(), cli.resError(reqres)
+}
+
+func (cli *socketClient) ProcessProposalSync(req types.RequestProcessProposal) (*types.ResponseProcessProposal, error) {
+	reqres := cli.queueRequest(types.ToRequestProcessProposal(req))
+	cli.FlushSync()
+	return reqres.Response.GetProcessProposal(), cli.resError(reqres)
 }
 
 //----------------------------------------
@@ -342,8 +565,22 @@ func (cli *socketClient) EndBlockSync(req types.RequestEndBlock) (*types.Respons
 func (cli *socketClient) queueRequest(req *types.Request) *ReqRes {
 	reqres := NewReqRes(req)
 
-	// TODO: set cli.err if reqQueue times out
-	cli.reqQueue <- reqres
+	cli.mtx.Lock()
+	lost, gen := cli.lost, cli.gen
+	cli.mtx.Unlock()
+	if lost {
+		// Fail right away, until resumed.
+		reqres.Done()
+		return reqres
+	}
+
+	select {
+	case cli.reqQueue <- queuedReq{reqres, gen}:
+	case <-cli.Quit():
+		// Stopped: the request will never be sent, release the waiters.
+		reqres.Done()
+		return reqres
+	}
 
 	// Maybe auto-flush, or unset auto-flush
 	switch req.Value.(type) {
@@ -367,8 +604,8 @@ func (cli *socketClient) flushQueue() {
 LOOP:
 	for {
 		select {
-		case reqres := <-cli.reqQueue:
-			reqres.Done()
+		case q := <-cli.reqQueue:
+			q.reqres.Done()
 		default:
 			break LOOP
 		}
@@ -401,6 +638,22 @@ func resMatchesReq(req *types.Request, res *types.Response) (ok bool) {
 		_, ok = res.Value.(*types.Response_BeginBlock)
 	case *types.Request_EndBlock:
 		_, ok = res.Value.(*types.Response_EndBlock)
+	case *types.Request_ListSnapshots:
+		_, ok = res.Value.(*types.Response_ListSnapshots)
+	case *types.Request_OfferSnapshot:
+		_, ok = res.Value.(*types.Response_OfferSnapshot)
+	case *types.Request_LoadSnapshotChunk:
+		_, ok = res.Value.(*types.Response_LoadSnapshotChunk)
+	case *types.Request_ApplySnapshotChunk:
+		_, ok = res.Value.(*types.Response_ApplySnapshotChunk)
+	case *types.Request_ExtendVote:
+		_, ok = res.Value.(*types.Response_ExtendVote)
+	case *types.Request_VerifyVoteExtension:
+		_, ok = res.Value.(*types.Response_VerifyVoteExtension)
+	case *types.Request_PrepareProposal:
+		_, ok = res.Value.(*types.Response_PrepareProposal)
+	case *types.Request_ProcessProposal:
+		_, ok = res.Value.(*types.Response_ProcessProposal)
 	}
 	return ok
 }
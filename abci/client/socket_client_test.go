@@ -119,3 +119,50 @@ func (slowApp) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginBlock
 	time.Sleep(200 * time.Millisecond)
 	return types.ResponseBeginBlock{}
 }
+
+func TestSocketClientReconnect(t *testing.T) {
+	port := 20000 + cmn.RandInt32()%10000
+	addr := fmt.Sprintf("localhost:%d", port)
+
+	s, err := server.NewServer(addr, "socket", types.NewBaseApplication())
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+
+	lost, reconnected := make(chan struct{}, 1), make(chan struct{}, 1)
+	c := abcicli.NewSocketClient(addr, true)
+	c.SetReconnect(func() { lost <- struct{}{} }, func() { reconnected <- struct{}{} })
+	require.NoError(t, c.Start())
+	defer c.Stop()
+
+	_, err = c.EchoSync("hello")
+	require.NoError(t, err)
+
+	// The app restarts.
+	s.Stop()
+	select {
+	case <-lost:
+	case <-time.After(time.Second):
+		t.Fatal("expected the connection to be lost")
+	}
+	_, err = c.EchoSync("hello")
+	assert.Equal(t, abcicli.ErrConnectionLost, err)
+
+	s, err = server.NewServer(addr, "socket", types.NewBaseApplication())
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+	defer s.Stop()
+	select {
+	case <-reconnected:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the client to reconnect")
+	}
+
+	// The calls fail until resumed.
+	_, err = c.EchoSync("hello")
+	assert.Equal(t, abcicli.ErrConnectionLost, err)
+	c.Resume()
+	res, err := c.EchoSync("hello")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", res.Message)
+	assert.NoError(t, c.Error())
+}
@@ -8,6 +8,7 @@ import golang_proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import _ "github.com/gogo/protobuf/gogoproto"
+import _ "github.com/golang/protobuf/ptypes/duration"
 import _ "github.com/golang/protobuf/ptypes/timestamp"
 import merkle "github.com/tendermint/tendermint/crypto/merkle"
 import common "github.com/tendermint/tendermint/libs/common"
@@ -38,6 +39,128 @@ var _ = time.Kitchen
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+type ResponseOfferSnapshot_Result int32
+
+const (
+	ResponseOfferSnapshot_UNKNOWN       ResponseOfferSnapshot_Result = 0
+	ResponseOfferSnapshot_ACCEPT        ResponseOfferSnapshot_Result = 1
+	ResponseOfferSnapshot_ABORT         ResponseOfferSnapshot_Result = 2
+	ResponseOfferSnapshot_REJECT        ResponseOfferSnapshot_Result = 3
+	ResponseOfferSnapshot_REJECT_FORMAT ResponseOfferSnapshot_Result = 4
+	ResponseOfferSnapshot_REJECT_SENDER ResponseOfferSnapshot_Result = 5
+)
+
+var ResponseOfferSnapshot_Result_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ACCEPT",
+	2: "ABORT",
+	3: "REJECT",
+	4: "REJECT_FORMAT",
+	5: "REJECT_SENDER",
+}
+var ResponseOfferSnapshot_Result_value = map[string]int32{
+	"UNKNOWN":       0,
+	"ACCEPT":        1,
+	"ABORT":         2,
+	"REJECT":        3,
+	"REJECT_FORMAT": 4,
+	"REJECT_SENDER": 5,
+}
+
+func (x ResponseOfferSnapshot_Result) String() string {
+	return proto.EnumName(ResponseOfferSnapshot_Result_name, int32(x))
+}
+func (ResponseOfferSnapshot_Result) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{34, 0}
+}
+
+type ResponseApplySnapshotChunk_Result int32
+
+const (
+	ResponseApplySnapshotChunk_UNKNOWN         ResponseApplySnapshotChunk_Result = 0
+	ResponseApplySnapshotChunk_ACCEPT          ResponseApplySnapshotChunk_Result = 1
+	ResponseApplySnapshotChunk_ABORT           ResponseApplySnapshotChunk_Result = 2
+	ResponseApplySnapshotChunk_RETRY           ResponseApplySnapshotChunk_Result = 3
+	ResponseApplySnapshotChunk_RETRY_SNAPSHOT  ResponseApplySnapshotChunk_Result = 4
+	ResponseApplySnapshotChunk_REJECT_SNAPSHOT ResponseApplySnapshotChunk_Result = 5
+)
+
+var ResponseApplySnapshotChunk_Result_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ACCEPT",
+	2: "ABORT",
+	3: "RETRY",
+	4: "RETRY_SNAPSHOT",
+	5: "REJECT_SNAPSHOT",
+}
+var ResponseApplySnapshotChunk_Result_value = map[string]int32{
+	"UNKNOWN":         0,
+	"ACCEPT":          1,
+	"ABORT":           2,
+	"RETRY":           3,
+	"RETRY_SNAPSHOT":  4,
+	"REJECT_SNAPSHOT": 5,
+}
+
+func (x ResponseApplySnapshotChunk_Result) String() string {
+	return proto.EnumName(ResponseApplySnapshotChunk_Result_name, int32(x))
+}
+func (ResponseApplySnapshotChunk_Result) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{36, 0}
+}
+
+type ResponseVerifyVoteExtension_Result int32
+
+const (
+	ResponseVerifyVoteExtension_UNKNOWN ResponseVerifyVoteExtension_Result = 0
+	ResponseVerifyVoteExtension_ACCEPT  ResponseVerifyVoteExtension_Result = 1
+	ResponseVerifyVoteExtension_REJECT  ResponseVerifyVoteExtension_Result = 2
+)
+
+var ResponseVerifyVoteExtension_Result_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ACCEPT",
+	2: "REJECT",
+}
+var ResponseVerifyVoteExtension_Result_value = map[string]int32{
+	"UNKNOWN": 0,
+	"ACCEPT":  1,
+	"REJECT":  2,
+}
+
+func (x ResponseVerifyVoteExtension_Result) String() string {
+	return proto.EnumName(ResponseVerifyVoteExtension_Result_name, int32(x))
+}
+func (ResponseVerifyVoteExtension_Result) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{38, 0}
+}
+
+type ResponseProcessProposal_Status int32
+
+const (
+	ResponseProcessProposal_UNKNOWN ResponseProcessProposal_Status = 0
+	ResponseProcessProposal_ACCEPT  ResponseProcessProposal_Status = 1
+	ResponseProcessProposal_REJECT  ResponseProcessProposal_Status = 2
+)
+
+var ResponseProcessProposal_Status_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ACCEPT",
+	2: "REJECT",
+}
+var ResponseProcessProposal_Status_value = map[string]int32{
+	"UNKNOWN": 0,
+	"ACCEPT":  1,
+	"REJECT":  2,
+}
+
+func (x ResponseProcessProposal_Status) String() string {
+	return proto.EnumName(ResponseProcessProposal_Status_name, int32(x))
+}
+func (ResponseProcessProposal_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{40, 0}
+}
+
 type Request struct {
 	// Types that are valid to be assigned to Value:
 	//	*Request_Echo
@@ -51,6 +174,14 @@ type Request struct {
 	//	*Request_DeliverTx
 	//	*Request_EndBlock
 	//	*Request_Commit
+	//	*Request_ListSnapshots
+	//	*Request_OfferSnapshot
+	//	*Request_LoadSnapshotChunk
+	//	*Request_ApplySnapshotChunk
+	//	*Request_ExtendVote
+	//	*Request_VerifyVoteExtension
+	//	*Request_PrepareProposal
+	//	*Request_ProcessProposal
 	Value                isRequest_Value `protobuf_oneof:"value"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
@@ -61,7 +192,7 @@ func (m *Request) Reset()         { *m = Request{} }
 func (m *Request) String() string { return proto.CompactTextString(m) }
 func (*Request) ProtoMessage()    {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{0}
+	return fileDescriptor_types_a70d46dbc1a61099, []int{0}
 }
 func (m *Request) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -130,18 +261,50 @@ type Request_EndBlock struct {
 type Request_Commit struct {
 	Commit *RequestCommit `protobuf:"bytes,12,opt,name=commit,oneof"`
 }
+type Request_ListSnapshots struct {
+	ListSnapshots *RequestListSnapshots `protobuf:"bytes,13,opt,name=list_snapshots,json=listSnapshots,oneof"`
+}
+type Request_OfferSnapshot struct {
+	OfferSnapshot *RequestOfferSnapshot `protobuf:"bytes,14,opt,name=offer_snapshot,json=offerSnapshot,oneof"`
+}
+type Request_LoadSnapshotChunk struct {
+	LoadSnapshotChunk *RequestLoadSnapshotChunk `protobuf:"bytes,15,opt,name=load_snapshot_chunk,json=loadSnapshotChunk,oneof"`
+}
+type Request_ApplySnapshotChunk struct {
+	ApplySnapshotChunk *RequestApplySnapshotChunk `protobuf:"bytes,16,opt,name=apply_snapshot_chunk,json=applySnapshotChunk,oneof"`
+}
+type Request_ExtendVote struct {
+	ExtendVote *RequestExtendVote `protobuf:"bytes,17,opt,name=extend_vote,json=extendVote,oneof"`
+}
+type Request_VerifyVoteExtension struct {
+	VerifyVoteExtension *RequestVerifyVoteExtension `protobuf:"bytes,18,opt,name=verify_vote_extension,json=verifyVoteExtension,oneof"`
+}
+type Request_PrepareProposal struct {
+	PrepareProposal *RequestPrepareProposal `protobuf:"bytes,20,opt,name=prepare_proposal,json=prepareProposal,oneof"`
+}
+type Request_ProcessProposal struct {
+	ProcessProposal *RequestProcessProposal `protobuf:"bytes,21,opt,name=process_proposal,json=processProposal,oneof"`
+}
 
-func (*Request_Echo) isRequest_Value()       {}
-func (*Request_Flush) isRequest_Value()      {}
-func (*Request_Info) isRequest_Value()       {}
-func (*Request_SetOption) isRequest_Value()  {}
-func (*Request_InitChain) isRequest_Value()  {}
-func (*Request_Query) isRequest_Value()      {}
-func (*Request_BeginBlock) isRequest_Value() {}
-func (*Request_CheckTx) isRequest_Value()    {}
-func (*Request_DeliverTx) isRequest_Value()  {}
-func (*Request_EndBlock) isRequest_Value()   {}
-func (*Request_Commit) isRequest_Value()     {}
+func (*Request_Echo) isRequest_Value()                {}
+func (*Request_Flush) isRequest_Value()               {}
+func (*Request_Info) isRequest_Value()                {}
+func (*Request_SetOption) isRequest_Value()           {}
+func (*Request_InitChain) isRequest_Value()           {}
+func (*Request_Query) isRequest_Value()               {}
+func (*Request_BeginBlock) isRequest_Value()          {}
+func (*Request_CheckTx) isRequest_Value()             {}
+func (*Request_DeliverTx) isRequest_Value()           {}
+func (*Request_EndBlock) isRequest_Value()            {}
+func (*Request_Commit) isRequest_Value()              {}
+func (*Request_ListSnapshots) isRequest_Value()       {}
+func (*Request_OfferSnapshot) isRequest_Value()       {}
+func (*Request_LoadSnapshotChunk) isRequest_Value()   {}
+func (*Request_ApplySnapshotChunk) isRequest_Value()  {}
+func (*Request_ExtendVote) isRequest_Value()          {}
+func (*Request_VerifyVoteExtension) isRequest_Value() {}
+func (*Request_PrepareProposal) isRequest_Value()     {}
+func (*Request_ProcessProposal) isRequest_Value()     {}
 
 func (m *Request) GetValue() isRequest_Value {
 	if m != nil {
@@ -227,6 +390,62 @@ func (m *Request) GetCommit() *RequestCommit {
 	return nil
 }
 
+func (m *Request) GetListSnapshots() *RequestListSnapshots {
+	if x, ok := m.GetValue().(*Request_ListSnapshots); ok {
+		return x.ListSnapshots
+	}
+	return nil
+}
+
+func (m *Request) GetOfferSnapshot() *RequestOfferSnapshot {
+	if x, ok := m.GetValue().(*Request_OfferSnapshot); ok {
+		return x.OfferSnapshot
+	}
+	return nil
+}
+
+func (m *Request) GetLoadSnapshotChunk() *RequestLoadSnapshotChunk {
+	if x, ok := m.GetValue().(*Request_LoadSnapshotChunk); ok {
+		return x.LoadSnapshotChunk
+	}
+	return nil
+}
+
+func (m *Request) GetApplySnapshotChunk() *RequestApplySnapshotChunk {
+	if x, ok := m.GetValue().(*Request_ApplySnapshotChunk); ok {
+		return x.ApplySnapshotChunk
+	}
+	return nil
+}
+
+func (m *Request) GetExtendVote() *RequestExtendVote {
+	if x, ok := m.GetValue().(*Request_ExtendVote); ok {
+		return x.ExtendVote
+	}
+	return nil
+}
+
+func (m *Request) GetVerifyVoteExtension() *RequestVerifyVoteExtension {
+	if x, ok := m.GetValue().(*Request_VerifyVoteExtension); ok {
+		return x.VerifyVoteExtension
+	}
+	return nil
+}
+
+func (m *Request) GetPrepareProposal() *RequestPrepareProposal {
+	if x, ok := m.GetValue().(*Request_PrepareProposal); ok {
+		return x.PrepareProposal
+	}
+	return nil
+}
+
+func (m *Request) GetProcessProposal() *RequestProcessProposal {
+	if x, ok := m.GetValue().(*Request_ProcessProposal); ok {
+		return x.ProcessProposal
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Request) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Request_OneofMarshaler, _Request_OneofUnmarshaler, _Request_OneofSizer, []interface{}{
@@ -241,6 +460,14 @@ func (*Request) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error
 		(*Request_DeliverTx)(nil),
 		(*Request_EndBlock)(nil),
 		(*Request_Commit)(nil),
+		(*Request_ListSnapshots)(nil),
+		(*Request_OfferSnapshot)(nil),
+		(*Request_LoadSnapshotChunk)(nil),
+		(*Request_ApplySnapshotChunk)(nil),
+		(*Request_ExtendVote)(nil),
+		(*Request_VerifyVoteExtension)(nil),
+		(*Request_PrepareProposal)(nil),
+		(*Request_ProcessProposal)(nil),
 	}
 }
 
@@ -303,6 +530,46 @@ func _Request_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Commit); err != nil {
 			return err
 		}
+	case *Request_ListSnapshots:
+		_ = b.EncodeVarint(13<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ListSnapshots); err != nil {
+			return err
+		}
+	case *Request_OfferSnapshot:
+		_ = b.EncodeVarint(14<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OfferSnapshot); err != nil {
+			return err
+		}
+	case *Request_LoadSnapshotChunk:
+		_ = b.EncodeVarint(15<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.LoadSnapshotChunk); err != nil {
+			return err
+		}
+	case *Request_ApplySnapshotChunk:
+		_ = b.EncodeVarint(16<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ApplySnapshotChunk); err != nil {
+			return err
+		}
+	case *Request_ExtendVote:
+		_ = b.EncodeVarint(17<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ExtendVote); err != nil {
+			return err
+		}
+	case *Request_VerifyVoteExtension:
+		_ = b.EncodeVarint(18<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.VerifyVoteExtension); err != nil {
+			return err
+		}
+	case *Request_PrepareProposal:
+		_ = b.EncodeVarint(20<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PrepareProposal); err != nil {
+			return err
+		}
+	case *Request_ProcessProposal:
+		_ = b.EncodeVarint(21<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ProcessProposal); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Request.Value has unexpected type %T", x)
@@ -401,6 +668,70 @@ func _Request_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer
 		err := b.DecodeMessage(msg)
 		m.Value = &Request_Commit{msg}
 		return true, err
+	case 13: // value.list_snapshots
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RequestListSnapshots)
+		err := b.DecodeMessage(msg)
+		m.Value = &Request_ListSnapshots{msg}
+		return true, err
+	case 14: // value.offer_snapshot
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RequestOfferSnapshot)
+		err := b.DecodeMessage(msg)
+		m.Value = &Request_OfferSnapshot{msg}
+		return true, err
+	case 15: // value.load_snapshot_chunk
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RequestLoadSnapshotChunk)
+		err := b.DecodeMessage(msg)
+		m.Value = &Request_LoadSnapshotChunk{msg}
+		return true, err
+	case 16: // value.apply_snapshot_chunk
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RequestApplySnapshotChunk)
+		err := b.DecodeMessage(msg)
+		m.Value = &Request_ApplySnapshotChunk{msg}
+		return true, err
+	case 17: // value.extend_vote
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RequestExtendVote)
+		err := b.DecodeMessage(msg)
+		m.Value = &Request_ExtendVote{msg}
+		return true, err
+	case 18: // value.verify_vote_extension
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RequestVerifyVoteExtension)
+		err := b.DecodeMessage(msg)
+		m.Value = &Request_VerifyVoteExtension{msg}
+		return true, err
+	case 20: // value.prepare_proposal
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RequestPrepareProposal)
+		err := b.DecodeMessage(msg)
+		m.Value = &Request_PrepareProposal{msg}
+		return true, err
+	case 21: // value.process_proposal
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RequestProcessProposal)
+		err := b.DecodeMessage(msg)
+		m.Value = &Request_ProcessProposal{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -465,6 +796,46 @@ func _Request_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Request_ListSnapshots:
+		s := proto.Size(x.ListSnapshots)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_OfferSnapshot:
+		s := proto.Size(x.OfferSnapshot)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_LoadSnapshotChunk:
+		s := proto.Size(x.LoadSnapshotChunk)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_ApplySnapshotChunk:
+		s := proto.Size(x.ApplySnapshotChunk)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_ExtendVote:
+		s := proto.Size(x.ExtendVote)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_VerifyVoteExtension:
+		s := proto.Size(x.VerifyVoteExtension)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_PrepareProposal:
+		s := proto.Size(x.PrepareProposal)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Request_ProcessProposal:
+		s := proto.Size(x.ProcessProposal)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -483,7 +854,7 @@ func (m *RequestEcho) Reset()         { *m = RequestEcho{} }
 func (m *RequestEcho) String() string { return proto.CompactTextString(m) }
 func (*RequestEcho) ProtoMessage()    {}
 func (*RequestEcho) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{1}
+	return fileDescriptor_types_a70d46dbc1a61099, []int{1}
 }
 func (m *RequestEcho) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -529,7 +900,7 @@ func (m *RequestFlush) Reset()         { *m = RequestFlush{} }
 func (m *RequestFlush) String() string { return proto.CompactTextString(m) }
 func (*RequestFlush) ProtoMessage()    {}
 func (*RequestFlush) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{2}
+	return fileDescriptor_types_a70d46dbc1a61099, []int{2}
 }
 func (m *RequestFlush) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -559,19 +930,22 @@ func (m *RequestFlush) XXX_DiscardUnknown() {
 var xxx_messageInfo_RequestFlush proto.InternalMessageInfo
 
 type RequestInfo struct {
-	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
-	BlockVersion         uint64   `protobuf:"varint,2,opt,name=block_version,json=blockVersion,proto3" json:"block_version,omitempty"`
-	P2PVersion           uint64   `protobuf:"varint,3,opt,name=p2p_version,json=p2pVersion,proto3" json:"p2p_version,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Version      string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	BlockVersion uint64 `protobuf:"varint,2,opt,name=block_version,json=blockVersion,proto3" json:"block_version,omitempty"`
+	P2PVersion   uint64 `protobuf:"varint,3,opt,name=p2p_version,json=p2pVersion,proto3" json:"p2p_version,omitempty"`
+	// The current consensus params of the node (the genesis ones if the chain
+	// didn't start yet). Only set by the handshake.
+	ConsensusParams      *ConsensusParams `protobuf:"bytes,4,opt,name=consensus_params,json=consensusParams" json:"consensus_params,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
 func (m *RequestInfo) Reset()         { *m = RequestInfo{} }
 func (m *RequestInfo) String() string { return proto.CompactTextString(m) }
 func (*RequestInfo) ProtoMessage()    {}
 func (*RequestInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{3}
+	return fileDescriptor_types_a70d46dbc1a61099, []int{3}
 }
 func (m *RequestInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -621,6 +995,13 @@ func (m *RequestInfo) GetP2PVersion() uint64 {
 	return 0
 }
 
+func (m *RequestInfo) GetConsensusParams() *ConsensusParams {
+	if m != nil {
+		return m.ConsensusParams
+	}
+	return nil
+}
+
 // nondeterministic
 type RequestSetOption struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -634,7 +1015,7 @@ func (m *RequestSetOption) Reset()         { *m = RequestSetOption{} }
 func (m *RequestSetOption) String() string { return proto.CompactTextString(m) }
 func (*RequestSetOption) ProtoMessage()    {}
 func (*RequestSetOption) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{4}
+	return fileDescriptor_types_a70d46dbc1a61099, []int{4}
 }
 func (m *RequestSetOption) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -692,7 +1073,7 @@ func (m *RequestInitChain) Reset()         { *m = RequestInitChain{} }
 func (m *RequestInitChain) String() string { return proto.CompactTextString(m) }
 func (*RequestInitChain) ProtoMessage()    {}
 func (*RequestInitChain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{5}
+	return fileDescriptor_types_a70d46dbc1a61099, []int{5}
 }
 func (m *RequestInitChain) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -770,7 +1151,7 @@ func (m *RequestQuery) Reset()         { *m = RequestQuery{} }
 func (m *RequestQuery) String() string { return proto.CompactTextString(m) }
 func (*RequestQuery) ProtoMessage()    {}
 func (*RequestQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{6}
+	return fileDescriptor_types_a70d46dbc1a61099, []int{6}
 }
 func (m *RequestQuery) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -841,7 +1222,7 @@ func (m *RequestBeginBlock) Reset()         { *m = RequestBeginBlock{} }
 func (m *RequestBeginBlock) String() string { return proto.CompactTextString(m) }
 func (*RequestBeginBlock) ProtoMessage()    {}
 func (*RequestBeginBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{7}
+	return fileDescriptor_types_a70d46dbc1a61099, []int{7}
 }
 func (m *RequestBeginBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -909,7 +1290,7 @@ func (m *RequestCheckTx) Reset()         { *m = RequestCheckTx{} }
 func (m *RequestCheckTx) String() string { return proto.CompactTextString(m) }
 func (*RequestCheckTx) ProtoMessage()    {}
 func (*RequestCheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{8}
+	return fileDescriptor_types_a70d46dbc1a61099, []int{8}
 }
 func (m *RequestCheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -956,7 +1337,7 @@ func (m *RequestDeliverTx) Reset()         { *m = RequestDeliverTx{} }
 func (m *RequestDeliverTx) String() string { return proto.CompactTextString(m) }
 func (*RequestDeliverTx) ProtoMessage()    {}
 func (*RequestDeliverTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{9}
+	return fileDescriptor_types_a70d46dbc1a61099, []int{9}
 }
 func (m *RequestDeliverTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1003,7 +1384,7 @@ func (m *RequestEndBlock) Reset()         { *m = RequestEndBlock{} }
 func (m *RequestEndBlock) String() string { return proto.CompactTextString(m) }
 func (*RequestEndBlock) ProtoMessage()    {}
 func (*RequestEndBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{10}
+	return fileDescriptor_types_a70d46dbc1a61099, []int{10}
 }
 func (m *RequestEndBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1049,7 +1430,7 @@ func (m *RequestCommit) Reset()         { *m = RequestCommit{} }
 func (m *RequestCommit) String() string { return proto.CompactTextString(m) }
 func (*RequestCommit) ProtoMessage()    {}
 func (*RequestCommit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{11}
+	return fileDescriptor_types_a70d46dbc1a61099, []int{11}
 }
 func (m *RequestCommit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1078,38 +1459,25 @@ func (m *RequestCommit) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_RequestCommit proto.InternalMessageInfo
 
-type Response struct {
-	// Types that are valid to be assigned to Value:
-	//	*Response_Exception
-	//	*Response_Echo
-	//	*Response_Flush
-	//	*Response_Info
-	//	*Response_SetOption
-	//	*Response_InitChain
-	//	*Response_Query
-	//	*Response_BeginBlock
-	//	*Response_CheckTx
-	//	*Response_DeliverTx
-	//	*Response_EndBlock
-	//	*Response_Commit
-	Value                isResponse_Value `protobuf_oneof:"value"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+// lists available snapshots
+type RequestListSnapshots struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Response) Reset()         { *m = Response{} }
-func (m *Response) String() string { return proto.CompactTextString(m) }
-func (*Response) ProtoMessage()    {}
-func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{12}
+func (m *RequestListSnapshots) Reset()         { *m = RequestListSnapshots{} }
+func (m *RequestListSnapshots) String() string { return proto.CompactTextString(m) }
+func (*RequestListSnapshots) ProtoMessage()    {}
+func (*RequestListSnapshots) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{12}
 }
-func (m *Response) XXX_Unmarshal(b []byte) error {
+func (m *RequestListSnapshots) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Response) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RequestListSnapshots) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Response.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RequestListSnapshots.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -1119,450 +1487,353 @@ func (m *Response) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (dst *Response) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Response.Merge(dst, src)
+func (dst *RequestListSnapshots) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestListSnapshots.Merge(dst, src)
 }
-func (m *Response) XXX_Size() int {
+func (m *RequestListSnapshots) XXX_Size() int {
 	return m.Size()
 }
-func (m *Response) XXX_DiscardUnknown() {
-	xxx_messageInfo_Response.DiscardUnknown(m)
+func (m *RequestListSnapshots) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestListSnapshots.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Response proto.InternalMessageInfo
+var xxx_messageInfo_RequestListSnapshots proto.InternalMessageInfo
 
-type isResponse_Value interface {
-	isResponse_Value()
-	Equal(interface{}) bool
-	MarshalTo([]byte) (int, error)
-	Size() int
+// offers a snapshot to the application
+type RequestOfferSnapshot struct {
+	Snapshot             *Snapshot `protobuf:"bytes,1,opt,name=snapshot" json:"snapshot,omitempty"`
+	AppHash              []byte    `protobuf:"bytes,2,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-type Response_Exception struct {
-	Exception *ResponseException `protobuf:"bytes,1,opt,name=exception,oneof"`
-}
-type Response_Echo struct {
-	Echo *ResponseEcho `protobuf:"bytes,2,opt,name=echo,oneof"`
-}
-type Response_Flush struct {
-	Flush *ResponseFlush `protobuf:"bytes,3,opt,name=flush,oneof"`
-}
-type Response_Info struct {
-	Info *ResponseInfo `protobuf:"bytes,4,opt,name=info,oneof"`
-}
-type Response_SetOption struct {
-	SetOption *ResponseSetOption `protobuf:"bytes,5,opt,name=set_option,json=setOption,oneof"`
+func (m *RequestOfferSnapshot) Reset()         { *m = RequestOfferSnapshot{} }
+func (m *RequestOfferSnapshot) String() string { return proto.CompactTextString(m) }
+func (*RequestOfferSnapshot) ProtoMessage()    {}
+func (*RequestOfferSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{13}
 }
-type Response_InitChain struct {
-	InitChain *ResponseInitChain `protobuf:"bytes,6,opt,name=init_chain,json=initChain,oneof"`
-}
-type Response_Query struct {
-	Query *ResponseQuery `protobuf:"bytes,7,opt,name=query,oneof"`
-}
-type Response_BeginBlock struct {
-	BeginBlock *ResponseBeginBlock `protobuf:"bytes,8,opt,name=begin_block,json=beginBlock,oneof"`
+func (m *RequestOfferSnapshot) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-type Response_CheckTx struct {
-	CheckTx *ResponseCheckTx `protobuf:"bytes,9,opt,name=check_tx,json=checkTx,oneof"`
+func (m *RequestOfferSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestOfferSnapshot.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-type Response_DeliverTx struct {
-	DeliverTx *ResponseDeliverTx `protobuf:"bytes,10,opt,name=deliver_tx,json=deliverTx,oneof"`
+func (dst *RequestOfferSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestOfferSnapshot.Merge(dst, src)
 }
-type Response_EndBlock struct {
-	EndBlock *ResponseEndBlock `protobuf:"bytes,11,opt,name=end_block,json=endBlock,oneof"`
+func (m *RequestOfferSnapshot) XXX_Size() int {
+	return m.Size()
 }
-type Response_Commit struct {
-	Commit *ResponseCommit `protobuf:"bytes,12,opt,name=commit,oneof"`
+func (m *RequestOfferSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestOfferSnapshot.DiscardUnknown(m)
 }
 
-func (*Response_Exception) isResponse_Value()  {}
-func (*Response_Echo) isResponse_Value()       {}
-func (*Response_Flush) isResponse_Value()      {}
-func (*Response_Info) isResponse_Value()       {}
-func (*Response_SetOption) isResponse_Value()  {}
-func (*Response_InitChain) isResponse_Value()  {}
-func (*Response_Query) isResponse_Value()      {}
-func (*Response_BeginBlock) isResponse_Value() {}
-func (*Response_CheckTx) isResponse_Value()    {}
-func (*Response_DeliverTx) isResponse_Value()  {}
-func (*Response_EndBlock) isResponse_Value()   {}
-func (*Response_Commit) isResponse_Value()     {}
+var xxx_messageInfo_RequestOfferSnapshot proto.InternalMessageInfo
 
-func (m *Response) GetValue() isResponse_Value {
+func (m *RequestOfferSnapshot) GetSnapshot() *Snapshot {
 	if m != nil {
-		return m.Value
+		return m.Snapshot
 	}
 	return nil
 }
 
-func (m *Response) GetException() *ResponseException {
-	if x, ok := m.GetValue().(*Response_Exception); ok {
-		return x.Exception
+func (m *RequestOfferSnapshot) GetAppHash() []byte {
+	if m != nil {
+		return m.AppHash
 	}
 	return nil
 }
 
-func (m *Response) GetEcho() *ResponseEcho {
-	if x, ok := m.GetValue().(*Response_Echo); ok {
-		return x.Echo
+// loads a snapshot chunk
+type RequestLoadSnapshotChunk struct {
+	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Format               uint32   `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
+	Chunk                uint32   `protobuf:"varint,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestLoadSnapshotChunk) Reset()         { *m = RequestLoadSnapshotChunk{} }
+func (m *RequestLoadSnapshotChunk) String() string { return proto.CompactTextString(m) }
+func (*RequestLoadSnapshotChunk) ProtoMessage()    {}
+func (*RequestLoadSnapshotChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{14}
+}
+func (m *RequestLoadSnapshotChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestLoadSnapshotChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestLoadSnapshotChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
+}
+func (dst *RequestLoadSnapshotChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestLoadSnapshotChunk.Merge(dst, src)
+}
+func (m *RequestLoadSnapshotChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestLoadSnapshotChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestLoadSnapshotChunk.DiscardUnknown(m)
 }
 
-func (m *Response) GetFlush() *ResponseFlush {
-	if x, ok := m.GetValue().(*Response_Flush); ok {
-		return x.Flush
+var xxx_messageInfo_RequestLoadSnapshotChunk proto.InternalMessageInfo
+
+func (m *RequestLoadSnapshotChunk) GetHeight() int64 {
+	if m != nil {
+		return m.Height
 	}
-	return nil
+	return 0
 }
 
-func (m *Response) GetInfo() *ResponseInfo {
-	if x, ok := m.GetValue().(*Response_Info); ok {
-		return x.Info
+func (m *RequestLoadSnapshotChunk) GetFormat() uint32 {
+	if m != nil {
+		return m.Format
 	}
-	return nil
+	return 0
 }
 
-func (m *Response) GetSetOption() *ResponseSetOption {
-	if x, ok := m.GetValue().(*Response_SetOption); ok {
-		return x.SetOption
+func (m *RequestLoadSnapshotChunk) GetChunk() uint32 {
+	if m != nil {
+		return m.Chunk
 	}
-	return nil
+	return 0
 }
 
-func (m *Response) GetInitChain() *ResponseInitChain {
-	if x, ok := m.GetValue().(*Response_InitChain); ok {
-		return x.InitChain
+// applies a snapshot chunk
+type RequestApplySnapshotChunk struct {
+	Index                uint32   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Chunk                []byte   `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Sender               string   `protobuf:"bytes,3,opt,name=sender,proto3" json:"sender,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestApplySnapshotChunk) Reset()         { *m = RequestApplySnapshotChunk{} }
+func (m *RequestApplySnapshotChunk) String() string { return proto.CompactTextString(m) }
+func (*RequestApplySnapshotChunk) ProtoMessage()    {}
+func (*RequestApplySnapshotChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{15}
+}
+func (m *RequestApplySnapshotChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestApplySnapshotChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestApplySnapshotChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
+}
+func (dst *RequestApplySnapshotChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestApplySnapshotChunk.Merge(dst, src)
+}
+func (m *RequestApplySnapshotChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestApplySnapshotChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestApplySnapshotChunk.DiscardUnknown(m)
 }
 
-func (m *Response) GetQuery() *ResponseQuery {
-	if x, ok := m.GetValue().(*Response_Query); ok {
-		return x.Query
+var xxx_messageInfo_RequestApplySnapshotChunk proto.InternalMessageInfo
+
+func (m *RequestApplySnapshotChunk) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
 	}
-	return nil
+	return 0
 }
 
-func (m *Response) GetBeginBlock() *ResponseBeginBlock {
-	if x, ok := m.GetValue().(*Response_BeginBlock); ok {
-		return x.BeginBlock
+func (m *RequestApplySnapshotChunk) GetChunk() []byte {
+	if m != nil {
+		return m.Chunk
 	}
 	return nil
 }
 
-func (m *Response) GetCheckTx() *ResponseCheckTx {
-	if x, ok := m.GetValue().(*Response_CheckTx); ok {
-		return x.CheckTx
+func (m *RequestApplySnapshotChunk) GetSender() string {
+	if m != nil {
+		return m.Sender
 	}
-	return nil
+	return ""
 }
 
-func (m *Response) GetDeliverTx() *ResponseDeliverTx {
-	if x, ok := m.GetValue().(*Response_DeliverTx); ok {
-		return x.DeliverTx
+// Extends the precommit of the node for a block with application data
+type RequestExtendVote struct {
+	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Height               int64    `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestExtendVote) Reset()         { *m = RequestExtendVote{} }
+func (m *RequestExtendVote) String() string { return proto.CompactTextString(m) }
+func (*RequestExtendVote) ProtoMessage()    {}
+func (*RequestExtendVote) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{16}
+}
+func (m *RequestExtendVote) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestExtendVote) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestExtendVote.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *RequestExtendVote) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestExtendVote.Merge(dst, src)
+}
+func (m *RequestExtendVote) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestExtendVote) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestExtendVote.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestExtendVote proto.InternalMessageInfo
+
+func (m *RequestExtendVote) GetHash() []byte {
+	if m != nil {
+		return m.Hash
 	}
 	return nil
 }
 
-func (m *Response) GetEndBlock() *ResponseEndBlock {
-	if x, ok := m.GetValue().(*Response_EndBlock); ok {
-		return x.EndBlock
+func (m *RequestExtendVote) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// Verifies the vote extension of a precommit of another validator
+type RequestVerifyVoteExtension struct {
+	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	ValidatorAddress     []byte   `protobuf:"bytes,2,opt,name=validator_address,json=validatorAddress,proto3" json:"validator_address,omitempty"`
+	Height               int64    `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	VoteExtension        []byte   `protobuf:"bytes,4,opt,name=vote_extension,json=voteExtension,proto3" json:"vote_extension,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestVerifyVoteExtension) Reset()         { *m = RequestVerifyVoteExtension{} }
+func (m *RequestVerifyVoteExtension) String() string { return proto.CompactTextString(m) }
+func (*RequestVerifyVoteExtension) ProtoMessage()    {}
+func (*RequestVerifyVoteExtension) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{17}
+}
+func (m *RequestVerifyVoteExtension) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestVerifyVoteExtension) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestVerifyVoteExtension.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *RequestVerifyVoteExtension) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestVerifyVoteExtension.Merge(dst, src)
+}
+func (m *RequestVerifyVoteExtension) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestVerifyVoteExtension) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestVerifyVoteExtension.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestVerifyVoteExtension proto.InternalMessageInfo
+
+func (m *RequestVerifyVoteExtension) GetHash() []byte {
+	if m != nil {
+		return m.Hash
 	}
 	return nil
 }
 
-func (m *Response) GetCommit() *ResponseCommit {
-	if x, ok := m.GetValue().(*Response_Commit); ok {
-		return x.Commit
+func (m *RequestVerifyVoteExtension) GetValidatorAddress() []byte {
+	if m != nil {
+		return m.ValidatorAddress
 	}
 	return nil
 }
 
-// XXX_OneofFuncs is for the internal use of the proto package.
-func (*Response) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
-	return _Response_OneofMarshaler, _Response_OneofUnmarshaler, _Response_OneofSizer, []interface{}{
-		(*Response_Exception)(nil),
-		(*Response_Echo)(nil),
-		(*Response_Flush)(nil),
-		(*Response_Info)(nil),
-		(*Response_SetOption)(nil),
-		(*Response_InitChain)(nil),
-		(*Response_Query)(nil),
-		(*Response_BeginBlock)(nil),
-		(*Response_CheckTx)(nil),
-		(*Response_DeliverTx)(nil),
-		(*Response_EndBlock)(nil),
-		(*Response_Commit)(nil),
+func (m *RequestVerifyVoteExtension) GetHeight() int64 {
+	if m != nil {
+		return m.Height
 	}
+	return 0
 }
 
-func _Response_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
-	m := msg.(*Response)
-	// value
-	switch x := m.Value.(type) {
-	case *Response_Exception:
-		_ = b.EncodeVarint(1<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.Exception); err != nil {
-			return err
-		}
-	case *Response_Echo:
-		_ = b.EncodeVarint(2<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.Echo); err != nil {
-			return err
-		}
-	case *Response_Flush:
-		_ = b.EncodeVarint(3<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.Flush); err != nil {
-			return err
-		}
-	case *Response_Info:
-		_ = b.EncodeVarint(4<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.Info); err != nil {
-			return err
-		}
-	case *Response_SetOption:
-		_ = b.EncodeVarint(5<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.SetOption); err != nil {
-			return err
-		}
-	case *Response_InitChain:
-		_ = b.EncodeVarint(6<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.InitChain); err != nil {
-			return err
-		}
-	case *Response_Query:
-		_ = b.EncodeVarint(7<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.Query); err != nil {
-			return err
-		}
-	case *Response_BeginBlock:
-		_ = b.EncodeVarint(8<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.BeginBlock); err != nil {
-			return err
-		}
-	case *Response_CheckTx:
-		_ = b.EncodeVarint(9<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.CheckTx); err != nil {
-			return err
-		}
-	case *Response_DeliverTx:
-		_ = b.EncodeVarint(10<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.DeliverTx); err != nil {
-			return err
-		}
-	case *Response_EndBlock:
-		_ = b.EncodeVarint(11<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.EndBlock); err != nil {
-			return err
-		}
-	case *Response_Commit:
-		_ = b.EncodeVarint(12<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.Commit); err != nil {
-			return err
-		}
-	case nil:
-	default:
-		return fmt.Errorf("Response.Value has unexpected type %T", x)
+func (m *RequestVerifyVoteExtension) GetVoteExtension() []byte {
+	if m != nil {
+		return m.VoteExtension
 	}
 	return nil
 }
 
-func _Response_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
-	m := msg.(*Response)
-	switch tag {
-	case 1: // value.exception
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ResponseException)
-		err := b.DecodeMessage(msg)
-		m.Value = &Response_Exception{msg}
-		return true, err
-	case 2: // value.echo
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ResponseEcho)
-		err := b.DecodeMessage(msg)
-		m.Value = &Response_Echo{msg}
-		return true, err
-	case 3: // value.flush
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ResponseFlush)
-		err := b.DecodeMessage(msg)
-		m.Value = &Response_Flush{msg}
-		return true, err
-	case 4: // value.info
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ResponseInfo)
-		err := b.DecodeMessage(msg)
-		m.Value = &Response_Info{msg}
-		return true, err
-	case 5: // value.set_option
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ResponseSetOption)
-		err := b.DecodeMessage(msg)
-		m.Value = &Response_SetOption{msg}
-		return true, err
-	case 6: // value.init_chain
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ResponseInitChain)
-		err := b.DecodeMessage(msg)
-		m.Value = &Response_InitChain{msg}
-		return true, err
-	case 7: // value.query
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ResponseQuery)
-		err := b.DecodeMessage(msg)
-		m.Value = &Response_Query{msg}
-		return true, err
-	case 8: // value.begin_block
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ResponseBeginBlock)
-		err := b.DecodeMessage(msg)
-		m.Value = &Response_BeginBlock{msg}
-		return true, err
-	case 9: // value.check_tx
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ResponseCheckTx)
-		err := b.DecodeMessage(msg)
-		m.Value = &Response_CheckTx{msg}
-		return true, err
-	case 10: // value.deliver_tx
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ResponseDeliverTx)
-		err := b.DecodeMessage(msg)
-		m.Value = &Response_DeliverTx{msg}
-		return true, err
-	case 11: // value.end_block
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ResponseEndBlock)
-		err := b.DecodeMessage(msg)
-		m.Value = &Response_EndBlock{msg}
-		return true, err
-	case 12: // value.commit
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ResponseCommit)
-		err := b.DecodeMessage(msg)
-		m.Value = &Response_Commit{msg}
-		return true, err
-	default:
-		return false, nil
-	}
-}
-
-func _Response_OneofSizer(msg proto.Message) (n int) {
-	m := msg.(*Response)
-	// value
-	switch x := m.Value.(type) {
-	case *Response_Exception:
-		s := proto.Size(x.Exception)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *Response_Echo:
-		s := proto.Size(x.Echo)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *Response_Flush:
-		s := proto.Size(x.Flush)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *Response_Info:
-		s := proto.Size(x.Info)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *Response_SetOption:
-		s := proto.Size(x.SetOption)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *Response_InitChain:
-		s := proto.Size(x.InitChain)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *Response_Query:
-		s := proto.Size(x.Query)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *Response_BeginBlock:
-		s := proto.Size(x.BeginBlock)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *Response_CheckTx:
-		s := proto.Size(x.CheckTx)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *Response_DeliverTx:
-		s := proto.Size(x.DeliverTx)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *Response_EndBlock:
-		s := proto.Size(x.EndBlock)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *Response_Commit:
-		s := proto.Size(x.Commit)
-		n += 1 // tag and wire
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case nil:
-	default:
-		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
-	}
-	return n
-}
-
-// nondeterministic
-type ResponseException struct {
-	Error                string   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// Asks the proposer for the application transactions of its proposal
+type RequestPrepareProposal struct {
+	Height               int64              `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	LocalLastCommit      ExtendedCommitInfo `protobuf:"bytes,2,opt,name=local_last_commit,json=localLastCommit" json:"local_last_commit"`
+	Txs                  [][]byte           `protobuf:"bytes,3,rep,name=txs" json:"txs,omitempty"`
+	MaxTxBytes           int64              `protobuf:"varint,4,opt,name=max_tx_bytes,json=maxTxBytes,proto3" json:"max_tx_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
-func (m *ResponseException) Reset()         { *m = ResponseException{} }
-func (m *ResponseException) String() string { return proto.CompactTextString(m) }
-func (*ResponseException) ProtoMessage()    {}
-func (*ResponseException) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{13}
+func (m *RequestPrepareProposal) Reset()         { *m = RequestPrepareProposal{} }
+func (m *RequestPrepareProposal) String() string { return proto.CompactTextString(m) }
+func (*RequestPrepareProposal) ProtoMessage()    {}
+func (*RequestPrepareProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{18}
 }
-func (m *ResponseException) XXX_Unmarshal(b []byte) error {
+func (m *RequestPrepareProposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ResponseException) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RequestPrepareProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ResponseException.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RequestPrepareProposal.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -1572,90 +1843,69 @@ func (m *ResponseException) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (dst *ResponseException) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResponseException.Merge(dst, src)
+func (dst *RequestPrepareProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestPrepareProposal.Merge(dst, src)
 }
-func (m *ResponseException) XXX_Size() int {
+func (m *RequestPrepareProposal) XXX_Size() int {
 	return m.Size()
 }
-func (m *ResponseException) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResponseException.DiscardUnknown(m)
+func (m *RequestPrepareProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestPrepareProposal.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ResponseException proto.InternalMessageInfo
+var xxx_messageInfo_RequestPrepareProposal proto.InternalMessageInfo
 
-func (m *ResponseException) GetError() string {
+func (m *RequestPrepareProposal) GetHeight() int64 {
 	if m != nil {
-		return m.Error
+		return m.Height
 	}
-	return ""
+	return 0
 }
 
-type ResponseEcho struct {
-	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *RequestPrepareProposal) GetLocalLastCommit() ExtendedCommitInfo {
+	if m != nil {
+		return m.LocalLastCommit
+	}
+	return ExtendedCommitInfo{}
 }
 
-func (m *ResponseEcho) Reset()         { *m = ResponseEcho{} }
-func (m *ResponseEcho) String() string { return proto.CompactTextString(m) }
-func (*ResponseEcho) ProtoMessage()    {}
-func (*ResponseEcho) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{14}
-}
-func (m *ResponseEcho) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *ResponseEcho) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ResponseEcho.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *RequestPrepareProposal) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
 	}
+	return nil
 }
-func (dst *ResponseEcho) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResponseEcho.Merge(dst, src)
-}
-func (m *ResponseEcho) XXX_Size() int {
-	return m.Size()
-}
-func (m *ResponseEcho) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResponseEcho.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_ResponseEcho proto.InternalMessageInfo
 
-func (m *ResponseEcho) GetMessage() string {
+func (m *RequestPrepareProposal) GetMaxTxBytes() int64 {
 	if m != nil {
-		return m.Message
+		return m.MaxTxBytes
 	}
-	return ""
+	return 0
 }
 
-type ResponseFlush struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type RequestProcessProposal struct {
+	Hash                 []byte         `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Header               Header         `protobuf:"bytes,2,opt,name=header" json:"header"`
+	Txs                  [][]byte       `protobuf:"bytes,3,rep,name=txs" json:"txs,omitempty"`
+	ProposedLastCommit   LastCommitInfo `protobuf:"bytes,4,opt,name=proposed_last_commit,json=proposedLastCommit" json:"proposed_last_commit"`
+	ByzantineValidators  []Evidence     `protobuf:"bytes,5,rep,name=byzantine_validators,json=byzantineValidators" json:"byzantine_validators"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *ResponseFlush) Reset()         { *m = ResponseFlush{} }
-func (m *ResponseFlush) String() string { return proto.CompactTextString(m) }
-func (*ResponseFlush) ProtoMessage()    {}
-func (*ResponseFlush) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{15}
+func (m *RequestProcessProposal) Reset()         { *m = RequestProcessProposal{} }
+func (m *RequestProcessProposal) String() string { return proto.CompactTextString(m) }
+func (*RequestProcessProposal) ProtoMessage()    {}
+func (*RequestProcessProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{19}
 }
-func (m *ResponseFlush) XXX_Unmarshal(b []byte) error {
+func (m *RequestProcessProposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ResponseFlush) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RequestProcessProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ResponseFlush.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RequestProcessProposal.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -1665,120 +1915,93 @@ func (m *ResponseFlush) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (dst *ResponseFlush) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResponseFlush.Merge(dst, src)
+func (dst *RequestProcessProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestProcessProposal.Merge(dst, src)
 }
-func (m *ResponseFlush) XXX_Size() int {
+func (m *RequestProcessProposal) XXX_Size() int {
 	return m.Size()
 }
-func (m *ResponseFlush) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResponseFlush.DiscardUnknown(m)
+func (m *RequestProcessProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestProcessProposal.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ResponseFlush proto.InternalMessageInfo
+var xxx_messageInfo_RequestProcessProposal proto.InternalMessageInfo
 
-type ResponseInfo struct {
-	Data                 string   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	Version              string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	AppVersion           uint64   `protobuf:"varint,3,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
-	LastBlockHeight      int64    `protobuf:"varint,4,opt,name=last_block_height,json=lastBlockHeight,proto3" json:"last_block_height,omitempty"`
-	LastBlockAppHash     []byte   `protobuf:"bytes,5,opt,name=last_block_app_hash,json=lastBlockAppHash,proto3" json:"last_block_app_hash,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *ResponseInfo) Reset()         { *m = ResponseInfo{} }
-func (m *ResponseInfo) String() string { return proto.CompactTextString(m) }
-func (*ResponseInfo) ProtoMessage()    {}
-func (*ResponseInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{16}
-}
-func (m *ResponseInfo) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *ResponseInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ResponseInfo.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
-	}
-}
-func (dst *ResponseInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResponseInfo.Merge(dst, src)
-}
-func (m *ResponseInfo) XXX_Size() int {
-	return m.Size()
-}
-func (m *ResponseInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResponseInfo.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_ResponseInfo proto.InternalMessageInfo
-
-func (m *ResponseInfo) GetData() string {
+func (m *RequestProcessProposal) GetHash() []byte {
 	if m != nil {
-		return m.Data
+		return m.Hash
 	}
-	return ""
+	return nil
 }
 
-func (m *ResponseInfo) GetVersion() string {
+func (m *RequestProcessProposal) GetHeader() Header {
 	if m != nil {
-		return m.Version
+		return m.Header
 	}
-	return ""
+	return Header{}
 }
 
-func (m *ResponseInfo) GetAppVersion() uint64 {
+func (m *RequestProcessProposal) GetTxs() [][]byte {
 	if m != nil {
-		return m.AppVersion
+		return m.Txs
 	}
-	return 0
+	return nil
 }
 
-func (m *ResponseInfo) GetLastBlockHeight() int64 {
+func (m *RequestProcessProposal) GetProposedLastCommit() LastCommitInfo {
 	if m != nil {
-		return m.LastBlockHeight
+		return m.ProposedLastCommit
 	}
-	return 0
+	return LastCommitInfo{}
 }
 
-func (m *ResponseInfo) GetLastBlockAppHash() []byte {
+func (m *RequestProcessProposal) GetByzantineValidators() []Evidence {
 	if m != nil {
-		return m.LastBlockAppHash
+		return m.ByzantineValidators
 	}
 	return nil
 }
 
-// nondeterministic
-type ResponseSetOption struct {
-	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	// bytes data = 2;
-	Log                  string   `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
-	Info                 string   `protobuf:"bytes,4,opt,name=info,proto3" json:"info,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type Response struct {
+	// Types that are valid to be assigned to Value:
+	//	*Response_Exception
+	//	*Response_Echo
+	//	*Response_Flush
+	//	*Response_Info
+	//	*Response_SetOption
+	//	*Response_InitChain
+	//	*Response_Query
+	//	*Response_BeginBlock
+	//	*Response_CheckTx
+	//	*Response_DeliverTx
+	//	*Response_EndBlock
+	//	*Response_Commit
+	//	*Response_ListSnapshots
+	//	*Response_OfferSnapshot
+	//	*Response_LoadSnapshotChunk
+	//	*Response_ApplySnapshotChunk
+	//	*Response_ExtendVote
+	//	*Response_VerifyVoteExtension
+	//	*Response_PrepareProposal
+	//	*Response_ProcessProposal
+	Value                isResponse_Value `protobuf_oneof:"value"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *ResponseSetOption) Reset()         { *m = ResponseSetOption{} }
-func (m *ResponseSetOption) String() string { return proto.CompactTextString(m) }
-func (*ResponseSetOption) ProtoMessage()    {}
-func (*ResponseSetOption) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{17}
+func (m *Response) Reset()         { *m = Response{} }
+func (m *Response) String() string { return proto.CompactTextString(m) }
+func (*Response) ProtoMessage()    {}
+func (*Response) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{20}
 }
-func (m *ResponseSetOption) XXX_Unmarshal(b []byte) error {
+func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ResponseSetOption) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Response) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ResponseSetOption.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Response.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -1788,658 +2011,690 @@ func (m *ResponseSetOption) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (dst *ResponseSetOption) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResponseSetOption.Merge(dst, src)
+func (dst *Response) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Response.Merge(dst, src)
 }
-func (m *ResponseSetOption) XXX_Size() int {
+func (m *Response) XXX_Size() int {
 	return m.Size()
 }
-func (m *ResponseSetOption) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResponseSetOption.DiscardUnknown(m)
+func (m *Response) XXX_DiscardUnknown() {
+	xxx_messageInfo_Response.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ResponseSetOption proto.InternalMessageInfo
+var xxx_messageInfo_Response proto.InternalMessageInfo
 
-func (m *ResponseSetOption) GetCode() uint32 {
-	if m != nil {
-		return m.Code
-	}
-	return 0
+type isResponse_Value interface {
+	isResponse_Value()
+	Equal(interface{}) bool
+	MarshalTo([]byte) (int, error)
+	Size() int
 }
 
-func (m *ResponseSetOption) GetLog() string {
-	if m != nil {
-		return m.Log
-	}
-	return ""
+type Response_Exception struct {
+	Exception *ResponseException `protobuf:"bytes,1,opt,name=exception,oneof"`
 }
-
-func (m *ResponseSetOption) GetInfo() string {
-	if m != nil {
-		return m.Info
-	}
-	return ""
+type Response_Echo struct {
+	Echo *ResponseEcho `protobuf:"bytes,2,opt,name=echo,oneof"`
 }
-
-type ResponseInitChain struct {
-	ConsensusParams      *ConsensusParams  `protobuf:"bytes,1,opt,name=consensus_params,json=consensusParams" json:"consensus_params,omitempty"`
-	Validators           []ValidatorUpdate `protobuf:"bytes,2,rep,name=validators" json:"validators"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+type Response_Flush struct {
+	Flush *ResponseFlush `protobuf:"bytes,3,opt,name=flush,oneof"`
 }
-
-func (m *ResponseInitChain) Reset()         { *m = ResponseInitChain{} }
-func (m *ResponseInitChain) String() string { return proto.CompactTextString(m) }
-func (*ResponseInitChain) ProtoMessage()    {}
-func (*ResponseInitChain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{18}
+type Response_Info struct {
+	Info *ResponseInfo `protobuf:"bytes,4,opt,name=info,oneof"`
 }
-func (m *ResponseInitChain) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
+type Response_SetOption struct {
+	SetOption *ResponseSetOption `protobuf:"bytes,5,opt,name=set_option,json=setOption,oneof"`
 }
-func (m *ResponseInitChain) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ResponseInitChain.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
-	}
+type Response_InitChain struct {
+	InitChain *ResponseInitChain `protobuf:"bytes,6,opt,name=init_chain,json=initChain,oneof"`
 }
-func (dst *ResponseInitChain) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResponseInitChain.Merge(dst, src)
+type Response_Query struct {
+	Query *ResponseQuery `protobuf:"bytes,7,opt,name=query,oneof"`
 }
-func (m *ResponseInitChain) XXX_Size() int {
-	return m.Size()
+type Response_BeginBlock struct {
+	BeginBlock *ResponseBeginBlock `protobuf:"bytes,8,opt,name=begin_block,json=beginBlock,oneof"`
 }
-func (m *ResponseInitChain) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResponseInitChain.DiscardUnknown(m)
+type Response_CheckTx struct {
+	CheckTx *ResponseCheckTx `protobuf:"bytes,9,opt,name=check_tx,json=checkTx,oneof"`
 }
-
-var xxx_messageInfo_ResponseInitChain proto.InternalMessageInfo
-
-func (m *ResponseInitChain) GetConsensusParams() *ConsensusParams {
-	if m != nil {
-		return m.ConsensusParams
-	}
-	return nil
+type Response_DeliverTx struct {
+	DeliverTx *ResponseDeliverTx `protobuf:"bytes,10,opt,name=deliver_tx,json=deliverTx,oneof"`
 }
-
-func (m *ResponseInitChain) GetValidators() []ValidatorUpdate {
-	if m != nil {
-		return m.Validators
-	}
-	return nil
+type Response_EndBlock struct {
+	EndBlock *ResponseEndBlock `protobuf:"bytes,11,opt,name=end_block,json=endBlock,oneof"`
 }
-
-type ResponseQuery struct {
-	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	// bytes data = 2; // use "value" instead.
-	Log                  string        `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
-	Info                 string        `protobuf:"bytes,4,opt,name=info,proto3" json:"info,omitempty"`
-	Index                int64         `protobuf:"varint,5,opt,name=index,proto3" json:"index,omitempty"`
-	Key                  []byte        `protobuf:"bytes,6,opt,name=key,proto3" json:"key,omitempty"`
-	Value                []byte        `protobuf:"bytes,7,opt,name=value,proto3" json:"value,omitempty"`
-	Proof                *merkle.Proof `protobuf:"bytes,8,opt,name=proof" json:"proof,omitempty"`
-	Height               int64         `protobuf:"varint,9,opt,name=height,proto3" json:"height,omitempty"`
-	Codespace            string        `protobuf:"bytes,10,opt,name=codespace,proto3" json:"codespace,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+type Response_Commit struct {
+	Commit *ResponseCommit `protobuf:"bytes,12,opt,name=commit,oneof"`
 }
-
-func (m *ResponseQuery) Reset()         { *m = ResponseQuery{} }
-func (m *ResponseQuery) String() string { return proto.CompactTextString(m) }
-func (*ResponseQuery) ProtoMessage()    {}
-func (*ResponseQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{19}
+type Response_ListSnapshots struct {
+	ListSnapshots *ResponseListSnapshots `protobuf:"bytes,13,opt,name=list_snapshots,json=listSnapshots,oneof"`
 }
-func (m *ResponseQuery) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
+type Response_OfferSnapshot struct {
+	OfferSnapshot *ResponseOfferSnapshot `protobuf:"bytes,14,opt,name=offer_snapshot,json=offerSnapshot,oneof"`
 }
-func (m *ResponseQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ResponseQuery.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
-	}
+type Response_LoadSnapshotChunk struct {
+	LoadSnapshotChunk *ResponseLoadSnapshotChunk `protobuf:"bytes,15,opt,name=load_snapshot_chunk,json=loadSnapshotChunk,oneof"`
 }
-func (dst *ResponseQuery) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResponseQuery.Merge(dst, src)
+type Response_ApplySnapshotChunk struct {
+	ApplySnapshotChunk *ResponseApplySnapshotChunk `protobuf:"bytes,16,opt,name=apply_snapshot_chunk,json=applySnapshotChunk,oneof"`
 }
-func (m *ResponseQuery) XXX_Size() int {
-	return m.Size()
+type Response_ExtendVote struct {
+	ExtendVote *ResponseExtendVote `protobuf:"bytes,17,opt,name=extend_vote,json=extendVote,oneof"`
 }
-func (m *ResponseQuery) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResponseQuery.DiscardUnknown(m)
+type Response_VerifyVoteExtension struct {
+	VerifyVoteExtension *ResponseVerifyVoteExtension `protobuf:"bytes,18,opt,name=verify_vote_extension,json=verifyVoteExtension,oneof"`
+}
+type Response_PrepareProposal struct {
+	PrepareProposal *ResponsePrepareProposal `protobuf:"bytes,19,opt,name=prepare_proposal,json=prepareProposal,oneof"`
+}
+type Response_ProcessProposal struct {
+	ProcessProposal *ResponseProcessProposal `protobuf:"bytes,20,opt,name=process_proposal,json=processProposal,oneof"`
 }
 
-var xxx_messageInfo_ResponseQuery proto.InternalMessageInfo
+func (*Response_Exception) isResponse_Value()           {}
+func (*Response_Echo) isResponse_Value()                {}
+func (*Response_Flush) isResponse_Value()               {}
+func (*Response_Info) isResponse_Value()                {}
+func (*Response_SetOption) isResponse_Value()           {}
+func (*Response_InitChain) isResponse_Value()           {}
+func (*Response_Query) isResponse_Value()               {}
+func (*Response_BeginBlock) isResponse_Value()          {}
+func (*Response_CheckTx) isResponse_Value()             {}
+func (*Response_DeliverTx) isResponse_Value()           {}
+func (*Response_EndBlock) isResponse_Value()            {}
+func (*Response_Commit) isResponse_Value()              {}
+func (*Response_ListSnapshots) isResponse_Value()       {}
+func (*Response_OfferSnapshot) isResponse_Value()       {}
+func (*Response_LoadSnapshotChunk) isResponse_Value()   {}
+func (*Response_ApplySnapshotChunk) isResponse_Value()  {}
+func (*Response_ExtendVote) isResponse_Value()          {}
+func (*Response_VerifyVoteExtension) isResponse_Value() {}
+func (*Response_PrepareProposal) isResponse_Value()     {}
+func (*Response_ProcessProposal) isResponse_Value()     {}
 
-func (m *ResponseQuery) GetCode() uint32 {
+func (m *Response) GetValue() isResponse_Value {
 	if m != nil {
-		return m.Code
+		return m.Value
 	}
-	return 0
+	return nil
 }
 
-func (m *ResponseQuery) GetLog() string {
-	if m != nil {
-		return m.Log
+func (m *Response) GetException() *ResponseException {
+	if x, ok := m.GetValue().(*Response_Exception); ok {
+		return x.Exception
 	}
-	return ""
+	return nil
 }
 
-func (m *ResponseQuery) GetInfo() string {
-	if m != nil {
-		return m.Info
-	}
-	return ""
-}
-
-func (m *ResponseQuery) GetIndex() int64 {
-	if m != nil {
-		return m.Index
-	}
-	return 0
-}
-
-func (m *ResponseQuery) GetKey() []byte {
-	if m != nil {
-		return m.Key
+func (m *Response) GetEcho() *ResponseEcho {
+	if x, ok := m.GetValue().(*Response_Echo); ok {
+		return x.Echo
 	}
 	return nil
 }
 
-func (m *ResponseQuery) GetValue() []byte {
-	if m != nil {
-		return m.Value
+func (m *Response) GetFlush() *ResponseFlush {
+	if x, ok := m.GetValue().(*Response_Flush); ok {
+		return x.Flush
 	}
 	return nil
 }
 
-func (m *ResponseQuery) GetProof() *merkle.Proof {
-	if m != nil {
-		return m.Proof
+func (m *Response) GetInfo() *ResponseInfo {
+	if x, ok := m.GetValue().(*Response_Info); ok {
+		return x.Info
 	}
 	return nil
 }
 
-func (m *ResponseQuery) GetHeight() int64 {
-	if m != nil {
-		return m.Height
+func (m *Response) GetSetOption() *ResponseSetOption {
+	if x, ok := m.GetValue().(*Response_SetOption); ok {
+		return x.SetOption
 	}
-	return 0
+	return nil
 }
 
-func (m *ResponseQuery) GetCodespace() string {
-	if m != nil {
-		return m.Codespace
+func (m *Response) GetInitChain() *ResponseInitChain {
+	if x, ok := m.GetValue().(*Response_InitChain); ok {
+		return x.InitChain
 	}
-	return ""
-}
-
-type ResponseBeginBlock struct {
-	Tags                 []common.KVPair `protobuf:"bytes,1,rep,name=tags" json:"tags,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	return nil
 }
 
-func (m *ResponseBeginBlock) Reset()         { *m = ResponseBeginBlock{} }
-func (m *ResponseBeginBlock) String() string { return proto.CompactTextString(m) }
-func (*ResponseBeginBlock) ProtoMessage()    {}
-func (*ResponseBeginBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{20}
-}
-func (m *ResponseBeginBlock) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *ResponseBeginBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ResponseBeginBlock.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *Response) GetQuery() *ResponseQuery {
+	if x, ok := m.GetValue().(*Response_Query); ok {
+		return x.Query
 	}
+	return nil
 }
-func (dst *ResponseBeginBlock) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResponseBeginBlock.Merge(dst, src)
-}
-func (m *ResponseBeginBlock) XXX_Size() int {
-	return m.Size()
-}
-func (m *ResponseBeginBlock) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResponseBeginBlock.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_ResponseBeginBlock proto.InternalMessageInfo
 
-func (m *ResponseBeginBlock) GetTags() []common.KVPair {
-	if m != nil {
-		return m.Tags
+func (m *Response) GetBeginBlock() *ResponseBeginBlock {
+	if x, ok := m.GetValue().(*Response_BeginBlock); ok {
+		return x.BeginBlock
 	}
 	return nil
 }
 
-type ResponseCheckTx struct {
-	Code                 uint32          `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Data                 []byte          `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	Log                  string          `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
-	Info                 string          `protobuf:"bytes,4,opt,name=info,proto3" json:"info,omitempty"`
-	GasWanted            int64           `protobuf:"varint,5,opt,name=gas_wanted,json=gasWanted,proto3" json:"gas_wanted,omitempty"`
-	GasUsed              int64           `protobuf:"varint,6,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
-	Tags                 []common.KVPair `protobuf:"bytes,7,rep,name=tags" json:"tags,omitempty"`
-	Codespace            string          `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
-}
-
-func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
-func (m *ResponseCheckTx) String() string { return proto.CompactTextString(m) }
-func (*ResponseCheckTx) ProtoMessage()    {}
-func (*ResponseCheckTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{21}
-}
-func (m *ResponseCheckTx) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *ResponseCheckTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ResponseCheckTx.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *Response) GetCheckTx() *ResponseCheckTx {
+	if x, ok := m.GetValue().(*Response_CheckTx); ok {
+		return x.CheckTx
 	}
-}
-func (dst *ResponseCheckTx) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResponseCheckTx.Merge(dst, src)
-}
-func (m *ResponseCheckTx) XXX_Size() int {
-	return m.Size()
-}
-func (m *ResponseCheckTx) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResponseCheckTx.DiscardUnknown(m)
+	return nil
 }
 
-var xxx_messageInfo_ResponseCheckTx proto.InternalMessageInfo
-
-func (m *ResponseCheckTx) GetCode() uint32 {
-	if m != nil {
-		return m.Code
+func (m *Response) GetDeliverTx() *ResponseDeliverTx {
+	if x, ok := m.GetValue().(*Response_DeliverTx); ok {
+		return x.DeliverTx
 	}
-	return 0
+	return nil
 }
 
-func (m *ResponseCheckTx) GetData() []byte {
-	if m != nil {
-		return m.Data
+func (m *Response) GetEndBlock() *ResponseEndBlock {
+	if x, ok := m.GetValue().(*Response_EndBlock); ok {
+		return x.EndBlock
 	}
 	return nil
 }
 
-func (m *ResponseCheckTx) GetLog() string {
-	if m != nil {
-		return m.Log
+func (m *Response) GetCommit() *ResponseCommit {
+	if x, ok := m.GetValue().(*Response_Commit); ok {
+		return x.Commit
 	}
-	return ""
+	return nil
 }
 
-func (m *ResponseCheckTx) GetInfo() string {
-	if m != nil {
-		return m.Info
+func (m *Response) GetListSnapshots() *ResponseListSnapshots {
+	if x, ok := m.GetValue().(*Response_ListSnapshots); ok {
+		return x.ListSnapshots
 	}
-	return ""
+	return nil
 }
 
-func (m *ResponseCheckTx) GetGasWanted() int64 {
-	if m != nil {
-		return m.GasWanted
+func (m *Response) GetOfferSnapshot() *ResponseOfferSnapshot {
+	if x, ok := m.GetValue().(*Response_OfferSnapshot); ok {
+		return x.OfferSnapshot
 	}
-	return 0
+	return nil
 }
 
-func (m *ResponseCheckTx) GetGasUsed() int64 {
-	if m != nil {
-		return m.GasUsed
+func (m *Response) GetLoadSnapshotChunk() *ResponseLoadSnapshotChunk {
+	if x, ok := m.GetValue().(*Response_LoadSnapshotChunk); ok {
+		return x.LoadSnapshotChunk
 	}
-	return 0
+	return nil
 }
 
-func (m *ResponseCheckTx) GetTags() []common.KVPair {
-	if m != nil {
-		return m.Tags
+func (m *Response) GetApplySnapshotChunk() *ResponseApplySnapshotChunk {
+	if x, ok := m.GetValue().(*Response_ApplySnapshotChunk); ok {
+		return x.ApplySnapshotChunk
 	}
 	return nil
 }
 
-func (m *ResponseCheckTx) GetCodespace() string {
-	if m != nil {
-		return m.Codespace
+func (m *Response) GetExtendVote() *ResponseExtendVote {
+	if x, ok := m.GetValue().(*Response_ExtendVote); ok {
+		return x.ExtendVote
 	}
-	return ""
-}
-
-type ResponseDeliverTx struct {
-	Code                 uint32          `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Data                 []byte          `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	Log                  string          `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
-	Info                 string          `protobuf:"bytes,4,opt,name=info,proto3" json:"info,omitempty"`
-	GasWanted            int64           `protobuf:"varint,5,opt,name=gas_wanted,json=gasWanted,proto3" json:"gas_wanted,omitempty"`
-	GasUsed              int64           `protobuf:"varint,6,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
-	Tags                 []common.KVPair `protobuf:"bytes,7,rep,name=tags" json:"tags,omitempty"`
-	Codespace            string          `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	return nil
 }
 
-func (m *ResponseDeliverTx) Reset()         { *m = ResponseDeliverTx{} }
-func (m *ResponseDeliverTx) String() string { return proto.CompactTextString(m) }
-func (*ResponseDeliverTx) ProtoMessage()    {}
-func (*ResponseDeliverTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{22}
-}
-func (m *ResponseDeliverTx) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *ResponseDeliverTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ResponseDeliverTx.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *Response) GetVerifyVoteExtension() *ResponseVerifyVoteExtension {
+	if x, ok := m.GetValue().(*Response_VerifyVoteExtension); ok {
+		return x.VerifyVoteExtension
 	}
+	return nil
 }
-func (dst *ResponseDeliverTx) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResponseDeliverTx.Merge(dst, src)
-}
-func (m *ResponseDeliverTx) XXX_Size() int {
-	return m.Size()
-}
-func (m *ResponseDeliverTx) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResponseDeliverTx.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_ResponseDeliverTx proto.InternalMessageInfo
 
-func (m *ResponseDeliverTx) GetCode() uint32 {
-	if m != nil {
-		return m.Code
+func (m *Response) GetPrepareProposal() *ResponsePrepareProposal {
+	if x, ok := m.GetValue().(*Response_PrepareProposal); ok {
+		return x.PrepareProposal
 	}
-	return 0
+	return nil
 }
 
-func (m *ResponseDeliverTx) GetData() []byte {
-	if m != nil {
-		return m.Data
+func (m *Response) GetProcessProposal() *ResponseProcessProposal {
+	if x, ok := m.GetValue().(*Response_ProcessProposal); ok {
+		return x.ProcessProposal
 	}
 	return nil
 }
 
-func (m *ResponseDeliverTx) GetLog() string {
-	if m != nil {
-		return m.Log
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Response) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Response_OneofMarshaler, _Response_OneofUnmarshaler, _Response_OneofSizer, []interface{}{
+		(*Response_Exception)(nil),
+		(*Response_Echo)(nil),
+		(*Response_Flush)(nil),
+		(*Response_Info)(nil),
+		(*Response_SetOption)(nil),
+		(*Response_InitChain)(nil),
+		(*Response_Query)(nil),
+		(*Response_BeginBlock)(nil),
+		(*Response_CheckTx)(nil),
+		(*Response_DeliverTx)(nil),
+		(*Response_EndBlock)(nil),
+		(*Response_Commit)(nil),
+		(*Response_ListSnapshots)(nil),
+		(*Response_OfferSnapshot)(nil),
+		(*Response_LoadSnapshotChunk)(nil),
+		(*Response_ApplySnapshotChunk)(nil),
+		(*Response_ExtendVote)(nil),
+		(*Response_VerifyVoteExtension)(nil),
+		(*Response_PrepareProposal)(nil),
+		(*Response_ProcessProposal)(nil),
 	}
-	return ""
 }
 
-func (m *ResponseDeliverTx) GetInfo() string {
-	if m != nil {
-		return m.Info
-	}
-	return ""
-}
-
-func (m *ResponseDeliverTx) GetGasWanted() int64 {
-	if m != nil {
-		return m.GasWanted
-	}
-	return 0
-}
-
-func (m *ResponseDeliverTx) GetGasUsed() int64 {
-	if m != nil {
-		return m.GasUsed
-	}
-	return 0
-}
-
-func (m *ResponseDeliverTx) GetTags() []common.KVPair {
-	if m != nil {
-		return m.Tags
-	}
-	return nil
-}
-
-func (m *ResponseDeliverTx) GetCodespace() string {
-	if m != nil {
-		return m.Codespace
-	}
-	return ""
-}
-
-type ResponseEndBlock struct {
-	ValidatorUpdates      []ValidatorUpdate `protobuf:"bytes,1,rep,name=validator_updates,json=validatorUpdates" json:"validator_updates"`
-	ConsensusParamUpdates *ConsensusParams  `protobuf:"bytes,2,opt,name=consensus_param_updates,json=consensusParamUpdates" json:"consensus_param_updates,omitempty"`
-	Tags                  []common.KVPair   `protobuf:"bytes,3,rep,name=tags" json:"tags,omitempty"`
-	XXX_NoUnkeyedLiteral  struct{}          `json:"-"`
-	XXX_unrecognized      []byte            `json:"-"`
-	XXX_sizecache         int32             `json:"-"`
-}
-
-func (m *ResponseEndBlock) Reset()         { *m = ResponseEndBlock{} }
-func (m *ResponseEndBlock) String() string { return proto.CompactTextString(m) }
-func (*ResponseEndBlock) ProtoMessage()    {}
-func (*ResponseEndBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{23}
-}
-func (m *ResponseEndBlock) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *ResponseEndBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ResponseEndBlock.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
+func _Response_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Response)
+	// value
+	switch x := m.Value.(type) {
+	case *Response_Exception:
+		_ = b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Exception); err != nil {
+			return err
 		}
-		return b[:n], nil
-	}
-}
-func (dst *ResponseEndBlock) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResponseEndBlock.Merge(dst, src)
-}
-func (m *ResponseEndBlock) XXX_Size() int {
-	return m.Size()
-}
-func (m *ResponseEndBlock) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResponseEndBlock.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_ResponseEndBlock proto.InternalMessageInfo
-
-func (m *ResponseEndBlock) GetValidatorUpdates() []ValidatorUpdate {
-	if m != nil {
-		return m.ValidatorUpdates
-	}
-	return nil
-}
-
-func (m *ResponseEndBlock) GetConsensusParamUpdates() *ConsensusParams {
-	if m != nil {
-		return m.ConsensusParamUpdates
-	}
-	return nil
-}
-
-func (m *ResponseEndBlock) GetTags() []common.KVPair {
-	if m != nil {
-		return m.Tags
-	}
-	return nil
-}
-
-type ResponseCommit struct {
-	// reserve 1
-	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *ResponseCommit) Reset()         { *m = ResponseCommit{} }
-func (m *ResponseCommit) String() string { return proto.CompactTextString(m) }
-func (*ResponseCommit) ProtoMessage()    {}
-func (*ResponseCommit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{24}
-}
-func (m *ResponseCommit) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *ResponseCommit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ResponseCommit.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
+	case *Response_Echo:
+		_ = b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Echo); err != nil {
+			return err
 		}
-		return b[:n], nil
-	}
-}
-func (dst *ResponseCommit) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResponseCommit.Merge(dst, src)
-}
-func (m *ResponseCommit) XXX_Size() int {
-	return m.Size()
-}
-func (m *ResponseCommit) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResponseCommit.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_ResponseCommit proto.InternalMessageInfo
-
-func (m *ResponseCommit) GetData() []byte {
-	if m != nil {
-		return m.Data
+	case *Response_Flush:
+		_ = b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Flush); err != nil {
+			return err
+		}
+	case *Response_Info:
+		_ = b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Info); err != nil {
+			return err
+		}
+	case *Response_SetOption:
+		_ = b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.SetOption); err != nil {
+			return err
+		}
+	case *Response_InitChain:
+		_ = b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.InitChain); err != nil {
+			return err
+		}
+	case *Response_Query:
+		_ = b.EncodeVarint(7<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Query); err != nil {
+			return err
+		}
+	case *Response_BeginBlock:
+		_ = b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.BeginBlock); err != nil {
+			return err
+		}
+	case *Response_CheckTx:
+		_ = b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.CheckTx); err != nil {
+			return err
+		}
+	case *Response_DeliverTx:
+		_ = b.EncodeVarint(10<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.DeliverTx); err != nil {
+			return err
+		}
+	case *Response_EndBlock:
+		_ = b.EncodeVarint(11<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.EndBlock); err != nil {
+			return err
+		}
+	case *Response_Commit:
+		_ = b.EncodeVarint(12<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Commit); err != nil {
+			return err
+		}
+	case *Response_ListSnapshots:
+		_ = b.EncodeVarint(13<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ListSnapshots); err != nil {
+			return err
+		}
+	case *Response_OfferSnapshot:
+		_ = b.EncodeVarint(14<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OfferSnapshot); err != nil {
+			return err
+		}
+	case *Response_LoadSnapshotChunk:
+		_ = b.EncodeVarint(15<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.LoadSnapshotChunk); err != nil {
+			return err
+		}
+	case *Response_ApplySnapshotChunk:
+		_ = b.EncodeVarint(16<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ApplySnapshotChunk); err != nil {
+			return err
+		}
+	case *Response_ExtendVote:
+		_ = b.EncodeVarint(17<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ExtendVote); err != nil {
+			return err
+		}
+	case *Response_VerifyVoteExtension:
+		_ = b.EncodeVarint(18<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.VerifyVoteExtension); err != nil {
+			return err
+		}
+	case *Response_PrepareProposal:
+		_ = b.EncodeVarint(19<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PrepareProposal); err != nil {
+			return err
+		}
+	case *Response_ProcessProposal:
+		_ = b.EncodeVarint(20<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ProcessProposal); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("Response.Value has unexpected type %T", x)
 	}
 	return nil
 }
 
-// ConsensusParams contains all consensus-relevant parameters
-// that can be adjusted by the abci app
-type ConsensusParams struct {
-	Block                *BlockParams     `protobuf:"bytes,1,opt,name=block" json:"block,omitempty"`
-	Evidence             *EvidenceParams  `protobuf:"bytes,2,opt,name=evidence" json:"evidence,omitempty"`
-	Validator            *ValidatorParams `protobuf:"bytes,3,opt,name=validator" json:"validator,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
-}
-
-func (m *ConsensusParams) Reset()         { *m = ConsensusParams{} }
-func (m *ConsensusParams) String() string { return proto.CompactTextString(m) }
-func (*ConsensusParams) ProtoMessage()    {}
-func (*ConsensusParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{25}
-}
-func (m *ConsensusParams) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *ConsensusParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ConsensusParams.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
+func _Response_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Response)
+	switch tag {
+	case 1: // value.exception
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
 		}
-		return b[:n], nil
-	}
-}
-func (dst *ConsensusParams) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ConsensusParams.Merge(dst, src)
-}
-func (m *ConsensusParams) XXX_Size() int {
-	return m.Size()
-}
-func (m *ConsensusParams) XXX_DiscardUnknown() {
-	xxx_messageInfo_ConsensusParams.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_ConsensusParams proto.InternalMessageInfo
-
-func (m *ConsensusParams) GetBlock() *BlockParams {
-	if m != nil {
-		return m.Block
+		msg := new(ResponseException)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_Exception{msg}
+		return true, err
+	case 2: // value.echo
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseEcho)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_Echo{msg}
+		return true, err
+	case 3: // value.flush
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseFlush)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_Flush{msg}
+		return true, err
+	case 4: // value.info
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseInfo)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_Info{msg}
+		return true, err
+	case 5: // value.set_option
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseSetOption)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_SetOption{msg}
+		return true, err
+	case 6: // value.init_chain
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseInitChain)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_InitChain{msg}
+		return true, err
+	case 7: // value.query
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseQuery)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_Query{msg}
+		return true, err
+	case 8: // value.begin_block
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseBeginBlock)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_BeginBlock{msg}
+		return true, err
+	case 9: // value.check_tx
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseCheckTx)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_CheckTx{msg}
+		return true, err
+	case 10: // value.deliver_tx
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseDeliverTx)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_DeliverTx{msg}
+		return true, err
+	case 11: // value.end_block
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseEndBlock)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_EndBlock{msg}
+		return true, err
+	case 12: // value.commit
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseCommit)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_Commit{msg}
+		return true, err
+	case 13: // value.list_snapshots
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseListSnapshots)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_ListSnapshots{msg}
+		return true, err
+	case 14: // value.offer_snapshot
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseOfferSnapshot)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_OfferSnapshot{msg}
+		return true, err
+	case 15: // value.load_snapshot_chunk
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseLoadSnapshotChunk)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_LoadSnapshotChunk{msg}
+		return true, err
+	case 16: // value.apply_snapshot_chunk
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseApplySnapshotChunk)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_ApplySnapshotChunk{msg}
+		return true, err
+	case 17: // value.extend_vote
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseExtendVote)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_ExtendVote{msg}
+		return true, err
+	case 18: // value.verify_vote_extension
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseVerifyVoteExtension)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_VerifyVoteExtension{msg}
+		return true, err
+	case 19: // value.prepare_proposal
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponsePrepareProposal)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_PrepareProposal{msg}
+		return true, err
+	case 20: // value.process_proposal
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResponseProcessProposal)
+		err := b.DecodeMessage(msg)
+		m.Value = &Response_ProcessProposal{msg}
+		return true, err
+	default:
+		return false, nil
 	}
-	return nil
 }
 
-func (m *ConsensusParams) GetEvidence() *EvidenceParams {
-	if m != nil {
-		return m.Evidence
+func _Response_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Response)
+	// value
+	switch x := m.Value.(type) {
+	case *Response_Exception:
+		s := proto.Size(x.Exception)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_Echo:
+		s := proto.Size(x.Echo)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_Flush:
+		s := proto.Size(x.Flush)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_Info:
+		s := proto.Size(x.Info)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_SetOption:
+		s := proto.Size(x.SetOption)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_InitChain:
+		s := proto.Size(x.InitChain)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_Query:
+		s := proto.Size(x.Query)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_BeginBlock:
+		s := proto.Size(x.BeginBlock)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_CheckTx:
+		s := proto.Size(x.CheckTx)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_DeliverTx:
+		s := proto.Size(x.DeliverTx)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_EndBlock:
+		s := proto.Size(x.EndBlock)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_Commit:
+		s := proto.Size(x.Commit)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_ListSnapshots:
+		s := proto.Size(x.ListSnapshots)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_OfferSnapshot:
+		s := proto.Size(x.OfferSnapshot)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_LoadSnapshotChunk:
+		s := proto.Size(x.LoadSnapshotChunk)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_ApplySnapshotChunk:
+		s := proto.Size(x.ApplySnapshotChunk)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_ExtendVote:
+		s := proto.Size(x.ExtendVote)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_VerifyVoteExtension:
+		s := proto.Size(x.VerifyVoteExtension)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_PrepareProposal:
+		s := proto.Size(x.PrepareProposal)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Response_ProcessProposal:
+		s := proto.Size(x.ProcessProposal)
+		n += 2 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
 	}
-	return nil
+	return n
 }
 
-func (m *ConsensusParams) GetValidator() *ValidatorParams {
-	if m != nil {
-		return m.Validator
-	}
-	return nil
+// nondeterministic
+type ResponseException struct {
+	Error                string   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// BlockParams contains limits on the block size and timestamp.
-type BlockParams struct {
-	// Note: must be greater than 0
-	MaxBytes int64 `protobuf:"varint,1,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
-	// Note: must be greater or equal to -1
-	MaxGas               int64    `protobuf:"varint,2,opt,name=max_gas,json=maxGas,proto3" json:"max_gas,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *BlockParams) Reset()         { *m = BlockParams{} }
-func (m *BlockParams) String() string { return proto.CompactTextString(m) }
-func (*BlockParams) ProtoMessage()    {}
-func (*BlockParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{26}
+func (m *ResponseException) Reset()         { *m = ResponseException{} }
+func (m *ResponseException) String() string { return proto.CompactTextString(m) }
+func (*ResponseException) ProtoMessage()    {}
+func (*ResponseException) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{21}
 }
-func (m *BlockParams) XXX_Unmarshal(b []byte) error {
+func (m *ResponseException) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *BlockParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResponseException) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_BlockParams.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResponseException.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2449,53 +2704,44 @@ func (m *BlockParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (dst *BlockParams) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BlockParams.Merge(dst, src)
+func (dst *ResponseException) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseException.Merge(dst, src)
 }
-func (m *BlockParams) XXX_Size() int {
+func (m *ResponseException) XXX_Size() int {
 	return m.Size()
 }
-func (m *BlockParams) XXX_DiscardUnknown() {
-	xxx_messageInfo_BlockParams.DiscardUnknown(m)
+func (m *ResponseException) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseException.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BlockParams proto.InternalMessageInfo
-
-func (m *BlockParams) GetMaxBytes() int64 {
-	if m != nil {
-		return m.MaxBytes
-	}
-	return 0
-}
+var xxx_messageInfo_ResponseException proto.InternalMessageInfo
 
-func (m *BlockParams) GetMaxGas() int64 {
+func (m *ResponseException) GetError() string {
 	if m != nil {
-		return m.MaxGas
+		return m.Error
 	}
-	return 0
+	return ""
 }
 
-// EvidenceParams contains limits on the evidence.
-type EvidenceParams struct {
-	// Note: must be greater than 0
-	MaxAge               int64    `protobuf:"varint,1,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+type ResponseEcho struct {
+	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *EvidenceParams) Reset()         { *m = EvidenceParams{} }
-func (m *EvidenceParams) String() string { return proto.CompactTextString(m) }
-func (*EvidenceParams) ProtoMessage()    {}
-func (*EvidenceParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{27}
+func (m *ResponseEcho) Reset()         { *m = ResponseEcho{} }
+func (m *ResponseEcho) String() string { return proto.CompactTextString(m) }
+func (*ResponseEcho) ProtoMessage()    {}
+func (*ResponseEcho) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{22}
 }
-func (m *EvidenceParams) XXX_Unmarshal(b []byte) error {
+func (m *ResponseEcho) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *EvidenceParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResponseEcho) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_EvidenceParams.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResponseEcho.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2505,45 +2751,43 @@ func (m *EvidenceParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (dst *EvidenceParams) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EvidenceParams.Merge(dst, src)
+func (dst *ResponseEcho) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseEcho.Merge(dst, src)
 }
-func (m *EvidenceParams) XXX_Size() int {
+func (m *ResponseEcho) XXX_Size() int {
 	return m.Size()
 }
-func (m *EvidenceParams) XXX_DiscardUnknown() {
-	xxx_messageInfo_EvidenceParams.DiscardUnknown(m)
+func (m *ResponseEcho) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseEcho.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EvidenceParams proto.InternalMessageInfo
+var xxx_messageInfo_ResponseEcho proto.InternalMessageInfo
 
-func (m *EvidenceParams) GetMaxAge() int64 {
+func (m *ResponseEcho) GetMessage() string {
 	if m != nil {
-		return m.MaxAge
+		return m.Message
 	}
-	return 0
+	return ""
 }
 
-// ValidatorParams contains limits on validators.
-type ValidatorParams struct {
-	PubKeyTypes          []string `protobuf:"bytes,1,rep,name=pub_key_types,json=pubKeyTypes" json:"pub_key_types,omitempty"`
+type ResponseFlush struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ValidatorParams) Reset()         { *m = ValidatorParams{} }
-func (m *ValidatorParams) String() string { return proto.CompactTextString(m) }
-func (*ValidatorParams) ProtoMessage()    {}
-func (*ValidatorParams) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{28}
+func (m *ResponseFlush) Reset()         { *m = ResponseFlush{} }
+func (m *ResponseFlush) String() string { return proto.CompactTextString(m) }
+func (*ResponseFlush) ProtoMessage()    {}
+func (*ResponseFlush) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{23}
 }
-func (m *ValidatorParams) XXX_Unmarshal(b []byte) error {
+func (m *ResponseFlush) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ValidatorParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResponseFlush) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ValidatorParams.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResponseFlush.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2553,45 +2797,45 @@ func (m *ValidatorParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (dst *ValidatorParams) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ValidatorParams.Merge(dst, src)
+func (dst *ResponseFlush) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseFlush.Merge(dst, src)
 }
-func (m *ValidatorParams) XXX_Size() int {
+func (m *ResponseFlush) XXX_Size() int {
 	return m.Size()
 }
-func (m *ValidatorParams) XXX_DiscardUnknown() {
-	xxx_messageInfo_ValidatorParams.DiscardUnknown(m)
+func (m *ResponseFlush) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseFlush.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ValidatorParams proto.InternalMessageInfo
-
-func (m *ValidatorParams) GetPubKeyTypes() []string {
-	if m != nil {
-		return m.PubKeyTypes
-	}
-	return nil
-}
+var xxx_messageInfo_ResponseFlush proto.InternalMessageInfo
 
-type LastCommitInfo struct {
-	Round                int32      `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
-	Votes                []VoteInfo `protobuf:"bytes,2,rep,name=votes" json:"votes"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+type ResponseInfo struct {
+	Data             string `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Version          string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	AppVersion       uint64 `protobuf:"varint,3,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
+	LastBlockHeight  int64  `protobuf:"varint,4,opt,name=last_block_height,json=lastBlockHeight,proto3" json:"last_block_height,omitempty"`
+	LastBlockAppHash []byte `protobuf:"bytes,5,opt,name=last_block_app_hash,json=lastBlockAppHash,proto3" json:"last_block_app_hash,omitempty"`
+	// Overrides of the consensus params of the request, only applied at
+	// genesis, before InitChain. After genesis, they must not change the params
+	// (use EndBlock instead).
+	ConsensusParams      *ConsensusParams `protobuf:"bytes,6,opt,name=consensus_params,json=consensusParams" json:"consensus_params,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *LastCommitInfo) Reset()         { *m = LastCommitInfo{} }
-func (m *LastCommitInfo) String() string { return proto.CompactTextString(m) }
-func (*LastCommitInfo) ProtoMessage()    {}
-func (*LastCommitInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{29}
+func (m *ResponseInfo) Reset()         { *m = ResponseInfo{} }
+func (m *ResponseInfo) String() string { return proto.CompactTextString(m) }
+func (*ResponseInfo) ProtoMessage()    {}
+func (*ResponseInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{24}
 }
-func (m *LastCommitInfo) XXX_Unmarshal(b []byte) error {
+func (m *ResponseInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LastCommitInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResponseInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LastCommitInfo.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResponseInfo.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2601,71 +2845,83 @@ func (m *LastCommitInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (dst *LastCommitInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LastCommitInfo.Merge(dst, src)
+func (dst *ResponseInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseInfo.Merge(dst, src)
 }
-func (m *LastCommitInfo) XXX_Size() int {
+func (m *ResponseInfo) XXX_Size() int {
 	return m.Size()
 }
-func (m *LastCommitInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_LastCommitInfo.DiscardUnknown(m)
+func (m *ResponseInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseInfo.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LastCommitInfo proto.InternalMessageInfo
+var xxx_messageInfo_ResponseInfo proto.InternalMessageInfo
 
-func (m *LastCommitInfo) GetRound() int32 {
+func (m *ResponseInfo) GetData() string {
 	if m != nil {
-		return m.Round
+		return m.Data
+	}
+	return ""
+}
+
+func (m *ResponseInfo) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ResponseInfo) GetAppVersion() uint64 {
+	if m != nil {
+		return m.AppVersion
 	}
 	return 0
 }
 
-func (m *LastCommitInfo) GetVotes() []VoteInfo {
+func (m *ResponseInfo) GetLastBlockHeight() int64 {
 	if m != nil {
-		return m.Votes
+		return m.LastBlockHeight
+	}
+	return 0
+}
+
+func (m *ResponseInfo) GetLastBlockAppHash() []byte {
+	if m != nil {
+		return m.LastBlockAppHash
 	}
 	return nil
 }
 
-type Header struct {
-	// basic block info
-	Version  Version   `protobuf:"bytes,1,opt,name=version" json:"version"`
-	ChainID  string    `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	Height   int64     `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
-	Time     time.Time `protobuf:"bytes,4,opt,name=time,stdtime" json:"time"`
-	NumTxs   int64     `protobuf:"varint,5,opt,name=num_txs,json=numTxs,proto3" json:"num_txs,omitempty"`
-	TotalTxs int64     `protobuf:"varint,6,opt,name=total_txs,json=totalTxs,proto3" json:"total_txs,omitempty"`
-	// prev block info
-	LastBlockId BlockID `protobuf:"bytes,7,opt,name=last_block_id,json=lastBlockId" json:"last_block_id"`
-	// hashes of block data
-	LastCommitHash []byte `protobuf:"bytes,8,opt,name=last_commit_hash,json=lastCommitHash,proto3" json:"last_commit_hash,omitempty"`
-	DataHash       []byte `protobuf:"bytes,9,opt,name=data_hash,json=dataHash,proto3" json:"data_hash,omitempty"`
-	// hashes from the app output from the prev block
-	ValidatorsHash     []byte `protobuf:"bytes,10,opt,name=validators_hash,json=validatorsHash,proto3" json:"validators_hash,omitempty"`
-	NextValidatorsHash []byte `protobuf:"bytes,11,opt,name=next_validators_hash,json=nextValidatorsHash,proto3" json:"next_validators_hash,omitempty"`
-	ConsensusHash      []byte `protobuf:"bytes,12,opt,name=consensus_hash,json=consensusHash,proto3" json:"consensus_hash,omitempty"`
-	AppHash            []byte `protobuf:"bytes,13,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
-	LastResultsHash    []byte `protobuf:"bytes,14,opt,name=last_results_hash,json=lastResultsHash,proto3" json:"last_results_hash,omitempty"`
-	// consensus info
-	EvidenceHash         []byte   `protobuf:"bytes,15,opt,name=evidence_hash,json=evidenceHash,proto3" json:"evidence_hash,omitempty"`
-	ProposerAddress      []byte   `protobuf:"bytes,16,opt,name=proposer_address,json=proposerAddress,proto3" json:"proposer_address,omitempty"`
+func (m *ResponseInfo) GetConsensusParams() *ConsensusParams {
+	if m != nil {
+		return m.ConsensusParams
+	}
+	return nil
+}
+
+// nondeterministic
+type ResponseSetOption struct {
+	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// bytes data = 2;
+	Log                  string   `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
+	Info                 string   `protobuf:"bytes,4,opt,name=info,proto3" json:"info,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Header) Reset()         { *m = Header{} }
-func (m *Header) String() string { return proto.CompactTextString(m) }
-func (*Header) ProtoMessage()    {}
-func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{30}
+func (m *ResponseSetOption) Reset()         { *m = ResponseSetOption{} }
+func (m *ResponseSetOption) String() string { return proto.CompactTextString(m) }
+func (*ResponseSetOption) ProtoMessage()    {}
+func (*ResponseSetOption) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{25}
 }
-func (m *Header) XXX_Unmarshal(b []byte) error {
+func (m *ResponseSetOption) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Header) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResponseSetOption) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Header.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResponseSetOption.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2675,150 +2931,225 @@ func (m *Header) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (dst *Header) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Header.Merge(dst, src)
+func (dst *ResponseSetOption) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseSetOption.Merge(dst, src)
 }
-func (m *Header) XXX_Size() int {
+func (m *ResponseSetOption) XXX_Size() int {
 	return m.Size()
 }
-func (m *Header) XXX_DiscardUnknown() {
-	xxx_messageInfo_Header.DiscardUnknown(m)
+func (m *ResponseSetOption) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseSetOption.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Header proto.InternalMessageInfo
+var xxx_messageInfo_ResponseSetOption proto.InternalMessageInfo
 
-func (m *Header) GetVersion() Version {
+func (m *ResponseSetOption) GetCode() uint32 {
 	if m != nil {
-		return m.Version
+		return m.Code
 	}
-	return Version{}
+	return 0
 }
 
-func (m *Header) GetChainID() string {
+func (m *ResponseSetOption) GetLog() string {
 	if m != nil {
-		return m.ChainID
+		return m.Log
 	}
 	return ""
 }
 
-func (m *Header) GetHeight() int64 {
+func (m *ResponseSetOption) GetInfo() string {
 	if m != nil {
-		return m.Height
+		return m.Info
 	}
-	return 0
+	return ""
 }
 
-func (m *Header) GetTime() time.Time {
-	if m != nil {
-		return m.Time
+type ResponseInitChain struct {
+	ConsensusParams      *ConsensusParams  `protobuf:"bytes,1,opt,name=consensus_params,json=consensusParams" json:"consensus_params,omitempty"`
+	Validators           []ValidatorUpdate `protobuf:"bytes,2,rep,name=validators" json:"validators"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ResponseInitChain) Reset()         { *m = ResponseInitChain{} }
+func (m *ResponseInitChain) String() string { return proto.CompactTextString(m) }
+func (*ResponseInitChain) ProtoMessage()    {}
+func (*ResponseInitChain) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{26}
+}
+func (m *ResponseInitChain) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseInitChain) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseInitChain.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return time.Time{}
+}
+func (dst *ResponseInitChain) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseInitChain.Merge(dst, src)
+}
+func (m *ResponseInitChain) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseInitChain) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseInitChain.DiscardUnknown(m)
 }
 
-func (m *Header) GetNumTxs() int64 {
+var xxx_messageInfo_ResponseInitChain proto.InternalMessageInfo
+
+func (m *ResponseInitChain) GetConsensusParams() *ConsensusParams {
 	if m != nil {
-		return m.NumTxs
+		return m.ConsensusParams
 	}
-	return 0
+	return nil
 }
 
-func (m *Header) GetTotalTxs() int64 {
+func (m *ResponseInitChain) GetValidators() []ValidatorUpdate {
 	if m != nil {
-		return m.TotalTxs
+		return m.Validators
 	}
-	return 0
+	return nil
 }
 
-func (m *Header) GetLastBlockId() BlockID {
-	if m != nil {
-		return m.LastBlockId
+type ResponseQuery struct {
+	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// bytes data = 2; // use "value" instead.
+	Log                  string        `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
+	Info                 string        `protobuf:"bytes,4,opt,name=info,proto3" json:"info,omitempty"`
+	Index                int64         `protobuf:"varint,5,opt,name=index,proto3" json:"index,omitempty"`
+	Key                  []byte        `protobuf:"bytes,6,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte        `protobuf:"bytes,7,opt,name=value,proto3" json:"value,omitempty"`
+	Proof                *merkle.Proof `protobuf:"bytes,8,opt,name=proof" json:"proof,omitempty"`
+	Height               int64         `protobuf:"varint,9,opt,name=height,proto3" json:"height,omitempty"`
+	Codespace            string        `protobuf:"bytes,10,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ResponseQuery) Reset()         { *m = ResponseQuery{} }
+func (m *ResponseQuery) String() string { return proto.CompactTextString(m) }
+func (*ResponseQuery) ProtoMessage()    {}
+func (*ResponseQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{27}
+}
+func (m *ResponseQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return BlockID{}
+}
+func (dst *ResponseQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseQuery.Merge(dst, src)
+}
+func (m *ResponseQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseQuery.DiscardUnknown(m)
 }
 
-func (m *Header) GetLastCommitHash() []byte {
+var xxx_messageInfo_ResponseQuery proto.InternalMessageInfo
+
+func (m *ResponseQuery) GetCode() uint32 {
 	if m != nil {
-		return m.LastCommitHash
+		return m.Code
 	}
-	return nil
+	return 0
 }
 
-func (m *Header) GetDataHash() []byte {
+func (m *ResponseQuery) GetLog() string {
 	if m != nil {
-		return m.DataHash
+		return m.Log
 	}
-	return nil
+	return ""
 }
 
-func (m *Header) GetValidatorsHash() []byte {
+func (m *ResponseQuery) GetInfo() string {
 	if m != nil {
-		return m.ValidatorsHash
+		return m.Info
 	}
-	return nil
+	return ""
 }
 
-func (m *Header) GetNextValidatorsHash() []byte {
+func (m *ResponseQuery) GetIndex() int64 {
 	if m != nil {
-		return m.NextValidatorsHash
+		return m.Index
 	}
-	return nil
+	return 0
 }
 
-func (m *Header) GetConsensusHash() []byte {
+func (m *ResponseQuery) GetKey() []byte {
 	if m != nil {
-		return m.ConsensusHash
+		return m.Key
 	}
 	return nil
 }
 
-func (m *Header) GetAppHash() []byte {
+func (m *ResponseQuery) GetValue() []byte {
 	if m != nil {
-		return m.AppHash
+		return m.Value
 	}
 	return nil
 }
 
-func (m *Header) GetLastResultsHash() []byte {
+func (m *ResponseQuery) GetProof() *merkle.Proof {
 	if m != nil {
-		return m.LastResultsHash
+		return m.Proof
 	}
 	return nil
 }
 
-func (m *Header) GetEvidenceHash() []byte {
+func (m *ResponseQuery) GetHeight() int64 {
 	if m != nil {
-		return m.EvidenceHash
+		return m.Height
 	}
-	return nil
+	return 0
 }
 
-func (m *Header) GetProposerAddress() []byte {
+func (m *ResponseQuery) GetCodespace() string {
 	if m != nil {
-		return m.ProposerAddress
+		return m.Codespace
 	}
-	return nil
+	return ""
 }
 
-type Version struct {
-	Block                uint64   `protobuf:"varint,1,opt,name=Block,proto3" json:"Block,omitempty"`
-	App                  uint64   `protobuf:"varint,2,opt,name=App,proto3" json:"App,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type ResponseBeginBlock struct {
+	Tags                 []common.KVPair `protobuf:"bytes,1,rep,name=tags" json:"tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *Version) Reset()         { *m = Version{} }
-func (m *Version) String() string { return proto.CompactTextString(m) }
-func (*Version) ProtoMessage()    {}
-func (*Version) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{31}
+func (m *ResponseBeginBlock) Reset()         { *m = ResponseBeginBlock{} }
+func (m *ResponseBeginBlock) String() string { return proto.CompactTextString(m) }
+func (*ResponseBeginBlock) ProtoMessage()    {}
+func (*ResponseBeginBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{28}
 }
-func (m *Version) XXX_Unmarshal(b []byte) error {
+func (m *ResponseBeginBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Version) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResponseBeginBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Version.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResponseBeginBlock.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2828,52 +3159,58 @@ func (m *Version) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (dst *Version) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Version.Merge(dst, src)
+func (dst *ResponseBeginBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseBeginBlock.Merge(dst, src)
 }
-func (m *Version) XXX_Size() int {
+func (m *ResponseBeginBlock) XXX_Size() int {
 	return m.Size()
 }
-func (m *Version) XXX_DiscardUnknown() {
-	xxx_messageInfo_Version.DiscardUnknown(m)
+func (m *ResponseBeginBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseBeginBlock.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Version proto.InternalMessageInfo
-
-func (m *Version) GetBlock() uint64 {
-	if m != nil {
-		return m.Block
-	}
-	return 0
-}
+var xxx_messageInfo_ResponseBeginBlock proto.InternalMessageInfo
 
-func (m *Version) GetApp() uint64 {
+func (m *ResponseBeginBlock) GetTags() []common.KVPair {
 	if m != nil {
-		return m.App
+		return m.Tags
 	}
-	return 0
+	return nil
 }
 
-type BlockID struct {
-	Hash                 []byte        `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	PartsHeader          PartSetHeader `protobuf:"bytes,2,opt,name=parts_header,json=partsHeader" json:"parts_header"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+type ResponseCheckTx struct {
+	Code      uint32          `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Data      []byte          `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Log       string          `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
+	Info      string          `protobuf:"bytes,4,opt,name=info,proto3" json:"info,omitempty"`
+	GasWanted int64           `protobuf:"varint,5,opt,name=gas_wanted,json=gasWanted,proto3" json:"gas_wanted,omitempty"`
+	GasUsed   int64           `protobuf:"varint,6,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	Tags      []common.KVPair `protobuf:"bytes,7,rep,name=tags" json:"tags,omitempty"`
+	Codespace string          `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	// the txs with the highest priority are proposed first, and evict the txs
+	// with a lower priority from a full mempool
+	Priority int64 `protobuf:"varint,9,opt,name=priority,proto3" json:"priority,omitempty"`
+	// the mempool keeps a single tx per (non-empty) sender
+	Sender string `protobuf:"bytes,10,opt,name=sender,proto3" json:"sender,omitempty"`
+	// set by the mempool when it rejects a valid tx
+	MempoolError         string   `protobuf:"bytes,11,opt,name=mempool_error,json=mempoolError,proto3" json:"mempool_error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *BlockID) Reset()         { *m = BlockID{} }
-func (m *BlockID) String() string { return proto.CompactTextString(m) }
-func (*BlockID) ProtoMessage()    {}
-func (*BlockID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{32}
+func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
+func (m *ResponseCheckTx) String() string { return proto.CompactTextString(m) }
+func (*ResponseCheckTx) ProtoMessage()    {}
+func (*ResponseCheckTx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{29}
 }
-func (m *BlockID) XXX_Unmarshal(b []byte) error {
+func (m *ResponseCheckTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *BlockID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResponseCheckTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_BlockID.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResponseCheckTx.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2883,109 +3220,121 @@ func (m *BlockID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (dst *BlockID) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BlockID.Merge(dst, src)
+func (dst *ResponseCheckTx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseCheckTx.Merge(dst, src)
 }
-func (m *BlockID) XXX_Size() int {
+func (m *ResponseCheckTx) XXX_Size() int {
 	return m.Size()
 }
-func (m *BlockID) XXX_DiscardUnknown() {
-	xxx_messageInfo_BlockID.DiscardUnknown(m)
+func (m *ResponseCheckTx) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseCheckTx.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BlockID proto.InternalMessageInfo
+var xxx_messageInfo_ResponseCheckTx proto.InternalMessageInfo
 
-func (m *BlockID) GetHash() []byte {
+func (m *ResponseCheckTx) GetCode() uint32 {
 	if m != nil {
-		return m.Hash
+		return m.Code
 	}
-	return nil
+	return 0
 }
 
-func (m *BlockID) GetPartsHeader() PartSetHeader {
+func (m *ResponseCheckTx) GetData() []byte {
 	if m != nil {
-		return m.PartsHeader
+		return m.Data
 	}
-	return PartSetHeader{}
+	return nil
 }
 
-type PartSetHeader struct {
-	Total                int32    `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
-	Hash                 []byte   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ResponseCheckTx) GetLog() string {
+	if m != nil {
+		return m.Log
+	}
+	return ""
 }
 
-func (m *PartSetHeader) Reset()         { *m = PartSetHeader{} }
-func (m *PartSetHeader) String() string { return proto.CompactTextString(m) }
-func (*PartSetHeader) ProtoMessage()    {}
-func (*PartSetHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{33}
-}
-func (m *PartSetHeader) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *PartSetHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_PartSetHeader.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *ResponseCheckTx) GetInfo() string {
+	if m != nil {
+		return m.Info
 	}
+	return ""
 }
-func (dst *PartSetHeader) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PartSetHeader.Merge(dst, src)
+
+func (m *ResponseCheckTx) GetGasWanted() int64 {
+	if m != nil {
+		return m.GasWanted
+	}
+	return 0
 }
-func (m *PartSetHeader) XXX_Size() int {
-	return m.Size()
+
+func (m *ResponseCheckTx) GetGasUsed() int64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
 }
-func (m *PartSetHeader) XXX_DiscardUnknown() {
-	xxx_messageInfo_PartSetHeader.DiscardUnknown(m)
+
+func (m *ResponseCheckTx) GetTags() []common.KVPair {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
 }
 
-var xxx_messageInfo_PartSetHeader proto.InternalMessageInfo
+func (m *ResponseCheckTx) GetCodespace() string {
+	if m != nil {
+		return m.Codespace
+	}
+	return ""
+}
 
-func (m *PartSetHeader) GetTotal() int32 {
+func (m *ResponseCheckTx) GetPriority() int64 {
 	if m != nil {
-		return m.Total
+		return m.Priority
 	}
 	return 0
 }
 
-func (m *PartSetHeader) GetHash() []byte {
+func (m *ResponseCheckTx) GetSender() string {
 	if m != nil {
-		return m.Hash
+		return m.Sender
 	}
-	return nil
+	return ""
 }
 
-// Validator
-type Validator struct {
-	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	// PubKey pub_key = 2 [(gogoproto.nullable)=false];
-	Power                int64    `protobuf:"varint,3,opt,name=power,proto3" json:"power,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ResponseCheckTx) GetMempoolError() string {
+	if m != nil {
+		return m.MempoolError
+	}
+	return ""
 }
 
-func (m *Validator) Reset()         { *m = Validator{} }
-func (m *Validator) String() string { return proto.CompactTextString(m) }
-func (*Validator) ProtoMessage()    {}
-func (*Validator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{34}
+type ResponseDeliverTx struct {
+	Code                 uint32          `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Data                 []byte          `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Log                  string          `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
+	Info                 string          `protobuf:"bytes,4,opt,name=info,proto3" json:"info,omitempty"`
+	GasWanted            int64           `protobuf:"varint,5,opt,name=gas_wanted,json=gasWanted,proto3" json:"gas_wanted,omitempty"`
+	GasUsed              int64           `protobuf:"varint,6,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	Tags                 []common.KVPair `protobuf:"bytes,7,rep,name=tags" json:"tags,omitempty"`
+	Codespace            string          `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
-func (m *Validator) XXX_Unmarshal(b []byte) error {
+
+func (m *ResponseDeliverTx) Reset()         { *m = ResponseDeliverTx{} }
+func (m *ResponseDeliverTx) String() string { return proto.CompactTextString(m) }
+func (*ResponseDeliverTx) ProtoMessage()    {}
+func (*ResponseDeliverTx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{30}
+}
+func (m *ResponseDeliverTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Validator) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResponseDeliverTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Validator.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResponseDeliverTx.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2995,109 +3344,96 @@ func (m *Validator) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (dst *Validator) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Validator.Merge(dst, src)
+func (dst *ResponseDeliverTx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseDeliverTx.Merge(dst, src)
 }
-func (m *Validator) XXX_Size() int {
+func (m *ResponseDeliverTx) XXX_Size() int {
 	return m.Size()
 }
-func (m *Validator) XXX_DiscardUnknown() {
-	xxx_messageInfo_Validator.DiscardUnknown(m)
+func (m *ResponseDeliverTx) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseDeliverTx.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Validator proto.InternalMessageInfo
+var xxx_messageInfo_ResponseDeliverTx proto.InternalMessageInfo
 
-func (m *Validator) GetAddress() []byte {
+func (m *ResponseDeliverTx) GetCode() uint32 {
 	if m != nil {
-		return m.Address
+		return m.Code
 	}
-	return nil
+	return 0
 }
 
-func (m *Validator) GetPower() int64 {
+func (m *ResponseDeliverTx) GetData() []byte {
 	if m != nil {
-		return m.Power
+		return m.Data
 	}
-	return 0
+	return nil
 }
 
-// ValidatorUpdate
-type ValidatorUpdate struct {
-	PubKey               PubKey   `protobuf:"bytes,1,opt,name=pub_key,json=pubKey" json:"pub_key"`
-	Power                int64    `protobuf:"varint,2,opt,name=power,proto3" json:"power,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ResponseDeliverTx) GetLog() string {
+	if m != nil {
+		return m.Log
+	}
+	return ""
 }
 
-func (m *ValidatorUpdate) Reset()         { *m = ValidatorUpdate{} }
-func (m *ValidatorUpdate) String() string { return proto.CompactTextString(m) }
-func (*ValidatorUpdate) ProtoMessage()    {}
-func (*ValidatorUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{35}
-}
-func (m *ValidatorUpdate) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *ValidatorUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ValidatorUpdate.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *ResponseDeliverTx) GetInfo() string {
+	if m != nil {
+		return m.Info
 	}
+	return ""
 }
-func (dst *ValidatorUpdate) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ValidatorUpdate.Merge(dst, src)
-}
-func (m *ValidatorUpdate) XXX_Size() int {
-	return m.Size()
-}
-func (m *ValidatorUpdate) XXX_DiscardUnknown() {
-	xxx_messageInfo_ValidatorUpdate.DiscardUnknown(m)
+
+func (m *ResponseDeliverTx) GetGasWanted() int64 {
+	if m != nil {
+		return m.GasWanted
+	}
+	return 0
 }
 
-var xxx_messageInfo_ValidatorUpdate proto.InternalMessageInfo
+func (m *ResponseDeliverTx) GetGasUsed() int64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
+}
 
-func (m *ValidatorUpdate) GetPubKey() PubKey {
+func (m *ResponseDeliverTx) GetTags() []common.KVPair {
 	if m != nil {
-		return m.PubKey
+		return m.Tags
 	}
-	return PubKey{}
+	return nil
 }
 
-func (m *ValidatorUpdate) GetPower() int64 {
+func (m *ResponseDeliverTx) GetCodespace() string {
 	if m != nil {
-		return m.Power
+		return m.Codespace
 	}
-	return 0
+	return ""
 }
 
-// VoteInfo
-type VoteInfo struct {
-	Validator            Validator `protobuf:"bytes,1,opt,name=validator" json:"validator"`
-	SignedLastBlock      bool      `protobuf:"varint,2,opt,name=signed_last_block,json=signedLastBlock,proto3" json:"signed_last_block,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type ResponseEndBlock struct {
+	ValidatorUpdates      []ValidatorUpdate `protobuf:"bytes,1,rep,name=validator_updates,json=validatorUpdates" json:"validator_updates"`
+	ConsensusParamUpdates *ConsensusParams  `protobuf:"bytes,2,opt,name=consensus_param_updates,json=consensusParamUpdates" json:"consensus_param_updates,omitempty"`
+	Tags                  []common.KVPair   `protobuf:"bytes,3,rep,name=tags" json:"tags,omitempty"`
+	PendingState          bool              `protobuf:"varint,4,opt,name=pending_state,json=pendingState,proto3" json:"pending_state,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}          `json:"-"`
+	XXX_unrecognized      []byte            `json:"-"`
+	XXX_sizecache         int32             `json:"-"`
 }
 
-func (m *VoteInfo) Reset()         { *m = VoteInfo{} }
-func (m *VoteInfo) String() string { return proto.CompactTextString(m) }
-func (*VoteInfo) ProtoMessage()    {}
-func (*VoteInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{36}
+func (m *ResponseEndBlock) Reset()         { *m = ResponseEndBlock{} }
+func (m *ResponseEndBlock) String() string { return proto.CompactTextString(m) }
+func (*ResponseEndBlock) ProtoMessage()    {}
+func (*ResponseEndBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{31}
 }
-func (m *VoteInfo) XXX_Unmarshal(b []byte) error {
+func (m *ResponseEndBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *VoteInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResponseEndBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_VoteInfo.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResponseEndBlock.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3107,52 +3443,66 @@ func (m *VoteInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (dst *VoteInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_VoteInfo.Merge(dst, src)
+func (dst *ResponseEndBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseEndBlock.Merge(dst, src)
 }
-func (m *VoteInfo) XXX_Size() int {
+func (m *ResponseEndBlock) XXX_Size() int {
 	return m.Size()
 }
-func (m *VoteInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_VoteInfo.DiscardUnknown(m)
+func (m *ResponseEndBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseEndBlock.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_VoteInfo proto.InternalMessageInfo
+var xxx_messageInfo_ResponseEndBlock proto.InternalMessageInfo
 
-func (m *VoteInfo) GetValidator() Validator {
+func (m *ResponseEndBlock) GetValidatorUpdates() []ValidatorUpdate {
 	if m != nil {
-		return m.Validator
+		return m.ValidatorUpdates
 	}
-	return Validator{}
+	return nil
 }
 
-func (m *VoteInfo) GetSignedLastBlock() bool {
+func (m *ResponseEndBlock) GetConsensusParamUpdates() *ConsensusParams {
 	if m != nil {
-		return m.SignedLastBlock
+		return m.ConsensusParamUpdates
 	}
-	return false
+	return nil
 }
 
-type PubKey struct {
-	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+func (m *ResponseEndBlock) GetTags() []common.KVPair {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *ResponseEndBlock) GetPendingState() bool {
+	if m != nil {
+		return m.PendingState
+	}
+	return false
+}
+
+type ResponseCommit struct {
+	// reserve 1
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PubKey) Reset()         { *m = PubKey{} }
-func (m *PubKey) String() string { return proto.CompactTextString(m) }
-func (*PubKey) ProtoMessage()    {}
-func (*PubKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{37}
+func (m *ResponseCommit) Reset()         { *m = ResponseCommit{} }
+func (m *ResponseCommit) String() string { return proto.CompactTextString(m) }
+func (*ResponseCommit) ProtoMessage()    {}
+func (*ResponseCommit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{32}
 }
-func (m *PubKey) XXX_Unmarshal(b []byte) error {
+func (m *ResponseCommit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PubKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResponseCommit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PubKey.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResponseCommit.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3162,55 +3512,91 @@ func (m *PubKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (dst *PubKey) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PubKey.Merge(dst, src)
+func (dst *ResponseCommit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseCommit.Merge(dst, src)
 }
-func (m *PubKey) XXX_Size() int {
+func (m *ResponseCommit) XXX_Size() int {
 	return m.Size()
 }
-func (m *PubKey) XXX_DiscardUnknown() {
-	xxx_messageInfo_PubKey.DiscardUnknown(m)
+func (m *ResponseCommit) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseCommit.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PubKey proto.InternalMessageInfo
+var xxx_messageInfo_ResponseCommit proto.InternalMessageInfo
 
-func (m *PubKey) GetType() string {
+func (m *ResponseCommit) GetData() []byte {
 	if m != nil {
-		return m.Type
+		return m.Data
 	}
-	return ""
+	return nil
 }
 
-func (m *PubKey) GetData() []byte {
+type ResponseListSnapshots struct {
+	Snapshots            []*Snapshot `protobuf:"bytes,1,rep,name=snapshots" json:"snapshots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ResponseListSnapshots) Reset()         { *m = ResponseListSnapshots{} }
+func (m *ResponseListSnapshots) String() string { return proto.CompactTextString(m) }
+func (*ResponseListSnapshots) ProtoMessage()    {}
+func (*ResponseListSnapshots) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{33}
+}
+func (m *ResponseListSnapshots) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseListSnapshots) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseListSnapshots.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ResponseListSnapshots) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseListSnapshots.Merge(dst, src)
+}
+func (m *ResponseListSnapshots) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseListSnapshots) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseListSnapshots.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseListSnapshots proto.InternalMessageInfo
+
+func (m *ResponseListSnapshots) GetSnapshots() []*Snapshot {
 	if m != nil {
-		return m.Data
+		return m.Snapshots
 	}
 	return nil
 }
 
-type Evidence struct {
-	Type                 string    `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Validator            Validator `protobuf:"bytes,2,opt,name=validator" json:"validator"`
-	Height               int64     `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
-	Time                 time.Time `protobuf:"bytes,4,opt,name=time,stdtime" json:"time"`
-	TotalVotingPower     int64     `protobuf:"varint,5,opt,name=total_voting_power,json=totalVotingPower,proto3" json:"total_voting_power,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type ResponseOfferSnapshot struct {
+	Result               ResponseOfferSnapshot_Result `protobuf:"varint,1,opt,name=result,proto3,enum=types.ResponseOfferSnapshot_Result" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
 }
 
-func (m *Evidence) Reset()         { *m = Evidence{} }
-func (m *Evidence) String() string { return proto.CompactTextString(m) }
-func (*Evidence) ProtoMessage()    {}
-func (*Evidence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_types_a177e47fab90f91d, []int{38}
+func (m *ResponseOfferSnapshot) Reset()         { *m = ResponseOfferSnapshot{} }
+func (m *ResponseOfferSnapshot) String() string { return proto.CompactTextString(m) }
+func (*ResponseOfferSnapshot) ProtoMessage()    {}
+func (*ResponseOfferSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{34}
 }
-func (m *Evidence) XXX_Unmarshal(b []byte) error {
+func (m *ResponseOfferSnapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Evidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ResponseOfferSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Evidence.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ResponseOfferSnapshot.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3220,1420 +3606,1665 @@ func (m *Evidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (dst *Evidence) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Evidence.Merge(dst, src)
+func (dst *ResponseOfferSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseOfferSnapshot.Merge(dst, src)
 }
-func (m *Evidence) XXX_Size() int {
+func (m *ResponseOfferSnapshot) XXX_Size() int {
 	return m.Size()
 }
-func (m *Evidence) XXX_DiscardUnknown() {
-	xxx_messageInfo_Evidence.DiscardUnknown(m)
+func (m *ResponseOfferSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseOfferSnapshot.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Evidence proto.InternalMessageInfo
+var xxx_messageInfo_ResponseOfferSnapshot proto.InternalMessageInfo
 
-func (m *Evidence) GetType() string {
+func (m *ResponseOfferSnapshot) GetResult() ResponseOfferSnapshot_Result {
 	if m != nil {
-		return m.Type
+		return m.Result
 	}
-	return ""
+	return ResponseOfferSnapshot_UNKNOWN
 }
 
-func (m *Evidence) GetValidator() Validator {
+type ResponseLoadSnapshotChunk struct {
+	Chunk                []byte   `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResponseLoadSnapshotChunk) Reset()         { *m = ResponseLoadSnapshotChunk{} }
+func (m *ResponseLoadSnapshotChunk) String() string { return proto.CompactTextString(m) }
+func (*ResponseLoadSnapshotChunk) ProtoMessage()    {}
+func (*ResponseLoadSnapshotChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{35}
+}
+func (m *ResponseLoadSnapshotChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseLoadSnapshotChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseLoadSnapshotChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ResponseLoadSnapshotChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseLoadSnapshotChunk.Merge(dst, src)
+}
+func (m *ResponseLoadSnapshotChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseLoadSnapshotChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseLoadSnapshotChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseLoadSnapshotChunk proto.InternalMessageInfo
+
+func (m *ResponseLoadSnapshotChunk) GetChunk() []byte {
 	if m != nil {
-		return m.Validator
+		return m.Chunk
 	}
-	return Validator{}
+	return nil
 }
 
-func (m *Evidence) GetHeight() int64 {
+type ResponseApplySnapshotChunk struct {
+	Result               ResponseApplySnapshotChunk_Result `protobuf:"varint,1,opt,name=result,proto3,enum=types.ResponseApplySnapshotChunk_Result" json:"result,omitempty"`
+	RefetchChunks        []uint32                          `protobuf:"varint,2,rep,packed,name=refetch_chunks,json=refetchChunks" json:"refetch_chunks,omitempty"`
+	RejectSenders        []string                          `protobuf:"bytes,3,rep,name=reject_senders,json=rejectSenders" json:"reject_senders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *ResponseApplySnapshotChunk) Reset()         { *m = ResponseApplySnapshotChunk{} }
+func (m *ResponseApplySnapshotChunk) String() string { return proto.CompactTextString(m) }
+func (*ResponseApplySnapshotChunk) ProtoMessage()    {}
+func (*ResponseApplySnapshotChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{36}
+}
+func (m *ResponseApplySnapshotChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseApplySnapshotChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseApplySnapshotChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ResponseApplySnapshotChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseApplySnapshotChunk.Merge(dst, src)
+}
+func (m *ResponseApplySnapshotChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseApplySnapshotChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseApplySnapshotChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseApplySnapshotChunk proto.InternalMessageInfo
+
+func (m *ResponseApplySnapshotChunk) GetResult() ResponseApplySnapshotChunk_Result {
 	if m != nil {
-		return m.Height
+		return m.Result
 	}
-	return 0
+	return ResponseApplySnapshotChunk_UNKNOWN
 }
 
-func (m *Evidence) GetTime() time.Time {
+func (m *ResponseApplySnapshotChunk) GetRefetchChunks() []uint32 {
 	if m != nil {
-		return m.Time
+		return m.RefetchChunks
 	}
-	return time.Time{}
+	return nil
 }
 
-func (m *Evidence) GetTotalVotingPower() int64 {
+func (m *ResponseApplySnapshotChunk) GetRejectSenders() []string {
 	if m != nil {
-		return m.TotalVotingPower
+		return m.RejectSenders
 	}
-	return 0
+	return nil
 }
 
-func init() {
-	proto.RegisterType((*Request)(nil), "types.Request")
-	golang_proto.RegisterType((*Request)(nil), "types.Request")
-	proto.RegisterType((*RequestEcho)(nil), "types.RequestEcho")
-	golang_proto.RegisterType((*RequestEcho)(nil), "types.RequestEcho")
-	proto.RegisterType((*RequestFlush)(nil), "types.RequestFlush")
-	golang_proto.RegisterType((*RequestFlush)(nil), "types.RequestFlush")
-	proto.RegisterType((*RequestInfo)(nil), "types.RequestInfo")
-	golang_proto.RegisterType((*RequestInfo)(nil), "types.RequestInfo")
-	proto.RegisterType((*RequestSetOption)(nil), "types.RequestSetOption")
-	golang_proto.RegisterType((*RequestSetOption)(nil), "types.RequestSetOption")
-	proto.RegisterType((*RequestInitChain)(nil), "types.RequestInitChain")
-	golang_proto.RegisterType((*RequestInitChain)(nil), "types.RequestInitChain")
-	proto.RegisterType((*RequestQuery)(nil), "types.RequestQuery")
-	golang_proto.RegisterType((*RequestQuery)(nil), "types.RequestQuery")
-	proto.RegisterType((*RequestBeginBlock)(nil), "types.RequestBeginBlock")
-	golang_proto.RegisterType((*RequestBeginBlock)(nil), "types.RequestBeginBlock")
-	proto.RegisterType((*RequestCheckTx)(nil), "types.RequestCheckTx")
-	golang_proto.RegisterType((*RequestCheckTx)(nil), "types.RequestCheckTx")
-	proto.RegisterType((*RequestDeliverTx)(nil), "types.RequestDeliverTx")
-	golang_proto.RegisterType((*RequestDeliverTx)(nil), "types.RequestDeliverTx")
-	proto.RegisterType((*RequestEndBlock)(nil), "types.RequestEndBlock")
-	golang_proto.RegisterType((*RequestEndBlock)(nil), "types.RequestEndBlock")
-	proto.RegisterType((*RequestCommit)(nil), "types.RequestCommit")
-	golang_proto.RegisterType((*RequestCommit)(nil), "types.RequestCommit")
-	proto.RegisterType((*Response)(nil), "types.Response")
-	golang_proto.RegisterType((*Response)(nil), "types.Response")
-	proto.RegisterType((*ResponseException)(nil), "types.ResponseException")
-	golang_proto.RegisterType((*ResponseException)(nil), "types.ResponseException")
-	proto.RegisterType((*ResponseEcho)(nil), "types.ResponseEcho")
-	golang_proto.RegisterType((*ResponseEcho)(nil), "types.ResponseEcho")
-	proto.RegisterType((*ResponseFlush)(nil), "types.ResponseFlush")
-	golang_proto.RegisterType((*ResponseFlush)(nil), "types.ResponseFlush")
-	proto.RegisterType((*ResponseInfo)(nil), "types.ResponseInfo")
-	golang_proto.RegisterType((*ResponseInfo)(nil), "types.ResponseInfo")
-	proto.RegisterType((*ResponseSetOption)(nil), "types.ResponseSetOption")
-	golang_proto.RegisterType((*ResponseSetOption)(nil), "types.ResponseSetOption")
-	proto.RegisterType((*ResponseInitChain)(nil), "types.ResponseInitChain")
-	golang_proto.RegisterType((*ResponseInitChain)(nil), "types.ResponseInitChain")
-	proto.RegisterType((*ResponseQuery)(nil), "types.ResponseQuery")
-	golang_proto.RegisterType((*ResponseQuery)(nil), "types.ResponseQuery")
-	proto.RegisterType((*ResponseBeginBlock)(nil), "types.ResponseBeginBlock")
-	golang_proto.RegisterType((*ResponseBeginBlock)(nil), "types.ResponseBeginBlock")
-	proto.RegisterType((*ResponseCheckTx)(nil), "types.ResponseCheckTx")
-	golang_proto.RegisterType((*ResponseCheckTx)(nil), "types.ResponseCheckTx")
-	proto.RegisterType((*ResponseDeliverTx)(nil), "types.ResponseDeliverTx")
-	golang_proto.RegisterType((*ResponseDeliverTx)(nil), "types.ResponseDeliverTx")
-	proto.RegisterType((*ResponseEndBlock)(nil), "types.ResponseEndBlock")
-	golang_proto.RegisterType((*ResponseEndBlock)(nil), "types.ResponseEndBlock")
-	proto.RegisterType((*ResponseCommit)(nil), "types.ResponseCommit")
-	golang_proto.RegisterType((*ResponseCommit)(nil), "types.ResponseCommit")
-	proto.RegisterType((*ConsensusParams)(nil), "types.ConsensusParams")
-	golang_proto.RegisterType((*ConsensusParams)(nil), "types.ConsensusParams")
-	proto.RegisterType((*BlockParams)(nil), "types.BlockParams")
-	golang_proto.RegisterType((*BlockParams)(nil), "types.BlockParams")
-	proto.RegisterType((*EvidenceParams)(nil), "types.EvidenceParams")
-	golang_proto.RegisterType((*EvidenceParams)(nil), "types.EvidenceParams")
-	proto.RegisterType((*ValidatorParams)(nil), "types.ValidatorParams")
-	golang_proto.RegisterType((*ValidatorParams)(nil), "types.ValidatorParams")
-	proto.RegisterType((*LastCommitInfo)(nil), "types.LastCommitInfo")
-	golang_proto.RegisterType((*LastCommitInfo)(nil), "types.LastCommitInfo")
-	proto.RegisterType((*Header)(nil), "types.Header")
-	golang_proto.RegisterType((*Header)(nil), "types.Header")
-	proto.RegisterType((*Version)(nil), "types.Version")
-	golang_proto.RegisterType((*Version)(nil), "types.Version")
-	proto.RegisterType((*BlockID)(nil), "types.BlockID")
-	golang_proto.RegisterType((*BlockID)(nil), "types.BlockID")
-	proto.RegisterType((*PartSetHeader)(nil), "types.PartSetHeader")
-	golang_proto.RegisterType((*PartSetHeader)(nil), "types.PartSetHeader")
-	proto.RegisterType((*Validator)(nil), "types.Validator")
-	golang_proto.RegisterType((*Validator)(nil), "types.Validator")
-	proto.RegisterType((*ValidatorUpdate)(nil), "types.ValidatorUpdate")
-	golang_proto.RegisterType((*ValidatorUpdate)(nil), "types.ValidatorUpdate")
-	proto.RegisterType((*VoteInfo)(nil), "types.VoteInfo")
-	golang_proto.RegisterType((*VoteInfo)(nil), "types.VoteInfo")
-	proto.RegisterType((*PubKey)(nil), "types.PubKey")
-	golang_proto.RegisterType((*PubKey)(nil), "types.PubKey")
-	proto.RegisterType((*Evidence)(nil), "types.Evidence")
-	golang_proto.RegisterType((*Evidence)(nil), "types.Evidence")
+type ResponseExtendVote struct {
+	VoteExtension        []byte   `protobuf:"bytes,1,opt,name=vote_extension,json=voteExtension,proto3" json:"vote_extension,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (this *Request) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Request)
-	if !ok {
-		that2, ok := that.(Request)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if that1.Value == nil {
-		if this.Value != nil {
-			return false
+func (m *ResponseExtendVote) Reset()         { *m = ResponseExtendVote{} }
+func (m *ResponseExtendVote) String() string { return proto.CompactTextString(m) }
+func (*ResponseExtendVote) ProtoMessage()    {}
+func (*ResponseExtendVote) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{37}
+}
+func (m *ResponseExtendVote) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseExtendVote) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseExtendVote.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
-	} else if this.Value == nil {
-		return false
-	} else if !this.Value.Equal(that1.Value) {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
+		return b[:n], nil
 	}
-	return true
 }
-func (this *Request_Echo) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (dst *ResponseExtendVote) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseExtendVote.Merge(dst, src)
+}
+func (m *ResponseExtendVote) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseExtendVote) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseExtendVote.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*Request_Echo)
-	if !ok {
-		that2, ok := that.(Request_Echo)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Echo.Equal(that1.Echo) {
-		return false
+var xxx_messageInfo_ResponseExtendVote proto.InternalMessageInfo
+
+func (m *ResponseExtendVote) GetVoteExtension() []byte {
+	if m != nil {
+		return m.VoteExtension
 	}
-	return true
+	return nil
 }
-func (this *Request_Flush) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Request_Flush)
-	if !ok {
-		that2, ok := that.(Request_Flush)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Flush.Equal(that1.Flush) {
-		return false
-	}
-	return true
+type ResponseVerifyVoteExtension struct {
+	Result               ResponseVerifyVoteExtension_Result `protobuf:"varint,1,opt,name=result,proto3,enum=types.ResponseVerifyVoteExtension_Result" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
 }
-func (this *Request_Info) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Request_Info)
-	if !ok {
-		that2, ok := that.(Request_Info)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+func (m *ResponseVerifyVoteExtension) Reset()         { *m = ResponseVerifyVoteExtension{} }
+func (m *ResponseVerifyVoteExtension) String() string { return proto.CompactTextString(m) }
+func (*ResponseVerifyVoteExtension) ProtoMessage()    {}
+func (*ResponseVerifyVoteExtension) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{38}
+}
+func (m *ResponseVerifyVoteExtension) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseVerifyVoteExtension) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseVerifyVoteExtension.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Info.Equal(that1.Info) {
-		return false
-	}
-	return true
 }
-func (this *Request_SetOption) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (dst *ResponseVerifyVoteExtension) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseVerifyVoteExtension.Merge(dst, src)
+}
+func (m *ResponseVerifyVoteExtension) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseVerifyVoteExtension) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseVerifyVoteExtension.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*Request_SetOption)
-	if !ok {
-		that2, ok := that.(Request_SetOption)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.SetOption.Equal(that1.SetOption) {
-		return false
+var xxx_messageInfo_ResponseVerifyVoteExtension proto.InternalMessageInfo
+
+func (m *ResponseVerifyVoteExtension) GetResult() ResponseVerifyVoteExtension_Result {
+	if m != nil {
+		return m.Result
 	}
-	return true
+	return ResponseVerifyVoteExtension_UNKNOWN
 }
-func (this *Request_InitChain) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Request_InitChain)
-	if !ok {
-		that2, ok := that.(Request_InitChain)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.InitChain.Equal(that1.InitChain) {
-		return false
-	}
-	return true
+type ResponsePrepareProposal struct {
+	Txs                  [][]byte `protobuf:"bytes,1,rep,name=txs" json:"txs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (this *Request_Query) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Request_Query)
-	if !ok {
-		that2, ok := that.(Request_Query)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+func (m *ResponsePrepareProposal) Reset()         { *m = ResponsePrepareProposal{} }
+func (m *ResponsePrepareProposal) String() string { return proto.CompactTextString(m) }
+func (*ResponsePrepareProposal) ProtoMessage()    {}
+func (*ResponsePrepareProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{39}
+}
+func (m *ResponsePrepareProposal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponsePrepareProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponsePrepareProposal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Query.Equal(that1.Query) {
-		return false
-	}
-	return true
 }
-func (this *Request_BeginBlock) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (dst *ResponsePrepareProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponsePrepareProposal.Merge(dst, src)
+}
+func (m *ResponsePrepareProposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponsePrepareProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponsePrepareProposal.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*Request_BeginBlock)
-	if !ok {
-		that2, ok := that.(Request_BeginBlock)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.BeginBlock.Equal(that1.BeginBlock) {
-		return false
+var xxx_messageInfo_ResponsePrepareProposal proto.InternalMessageInfo
+
+func (m *ResponsePrepareProposal) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
 	}
-	return true
+	return nil
 }
-func (this *Request_CheckTx) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Request_CheckTx)
-	if !ok {
-		that2, ok := that.(Request_CheckTx)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type ResponseProcessProposal struct {
+	Status               ResponseProcessProposal_Status `protobuf:"varint,1,opt,name=status,proto3,enum=types.ResponseProcessProposal_Status" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *ResponseProcessProposal) Reset()         { *m = ResponseProcessProposal{} }
+func (m *ResponseProcessProposal) String() string { return proto.CompactTextString(m) }
+func (*ResponseProcessProposal) ProtoMessage()    {}
+func (*ResponseProcessProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{40}
+}
+func (m *ResponseProcessProposal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseProcessProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseProcessProposal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.CheckTx.Equal(that1.CheckTx) {
-		return false
-	}
-	return true
 }
-func (this *Request_DeliverTx) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (dst *ResponseProcessProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseProcessProposal.Merge(dst, src)
+}
+func (m *ResponseProcessProposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseProcessProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseProcessProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseProcessProposal proto.InternalMessageInfo
+
+func (m *ResponseProcessProposal) GetStatus() ResponseProcessProposal_Status {
+	if m != nil {
+		return m.Status
 	}
+	return ResponseProcessProposal_UNKNOWN
+}
 
-	that1, ok := that.(*Request_DeliverTx)
-	if !ok {
-		that2, ok := that.(Request_DeliverTx)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.DeliverTx.Equal(that1.DeliverTx) {
-		return false
-	}
-	return true
+// ConsensusParams contains all consensus-relevant parameters
+// that can be adjusted by the abci app
+type ConsensusParams struct {
+	Block                *BlockParams     `protobuf:"bytes,1,opt,name=block" json:"block,omitempty"`
+	Evidence             *EvidenceParams  `protobuf:"bytes,2,opt,name=evidence" json:"evidence,omitempty"`
+	Validator            *ValidatorParams `protobuf:"bytes,3,opt,name=validator" json:"validator,omitempty"`
+	Timeout              *TimeoutParams   `protobuf:"bytes,4,opt,name=timeout" json:"timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
-func (this *Request_EndBlock) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Request_EndBlock)
-	if !ok {
-		that2, ok := that.(Request_EndBlock)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+func (m *ConsensusParams) Reset()         { *m = ConsensusParams{} }
+func (m *ConsensusParams) String() string { return proto.CompactTextString(m) }
+func (*ConsensusParams) ProtoMessage()    {}
+func (*ConsensusParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{41}
+}
+func (m *ConsensusParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConsensusParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConsensusParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.EndBlock.Equal(that1.EndBlock) {
-		return false
-	}
-	return true
 }
-func (this *Request_Commit) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (dst *ConsensusParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsensusParams.Merge(dst, src)
+}
+func (m *ConsensusParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConsensusParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsensusParams.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*Request_Commit)
-	if !ok {
-		that2, ok := that.(Request_Commit)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+var xxx_messageInfo_ConsensusParams proto.InternalMessageInfo
+
+func (m *ConsensusParams) GetBlock() *BlockParams {
+	if m != nil {
+		return m.Block
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return nil
+}
+
+func (m *ConsensusParams) GetEvidence() *EvidenceParams {
+	if m != nil {
+		return m.Evidence
 	}
-	if !this.Commit.Equal(that1.Commit) {
-		return false
+	return nil
+}
+
+func (m *ConsensusParams) GetValidator() *ValidatorParams {
+	if m != nil {
+		return m.Validator
 	}
-	return true
+	return nil
 }
-func (this *RequestEcho) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *ConsensusParams) GetTimeout() *TimeoutParams {
+	if m != nil {
+		return m.Timeout
 	}
+	return nil
+}
 
-	that1, ok := that.(*RequestEcho)
-	if !ok {
-		that2, ok := that.(RequestEcho)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+// BlockParams contains limits on the block size and timestamp.
+type BlockParams struct {
+	// Note: must be greater than 0
+	MaxBytes int64 `protobuf:"varint,1,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	// Note: must be greater or equal to -1
+	MaxGas               int64    `protobuf:"varint,2,opt,name=max_gas,json=maxGas,proto3" json:"max_gas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockParams) Reset()         { *m = BlockParams{} }
+func (m *BlockParams) String() string { return proto.CompactTextString(m) }
+func (*BlockParams) ProtoMessage()    {}
+func (*BlockParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{42}
+}
+func (m *BlockParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Message != that1.Message {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
+}
+func (dst *BlockParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockParams.Merge(dst, src)
+}
+func (m *BlockParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockParams proto.InternalMessageInfo
+
+func (m *BlockParams) GetMaxBytes() int64 {
+	if m != nil {
+		return m.MaxBytes
 	}
-	return true
+	return 0
 }
-func (this *RequestFlush) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *BlockParams) GetMaxGas() int64 {
+	if m != nil {
+		return m.MaxGas
 	}
+	return 0
+}
 
-	that1, ok := that.(*RequestFlush)
-	if !ok {
-		that2, ok := that.(RequestFlush)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+// EvidenceParams contains limits on the evidence.
+type EvidenceParams struct {
+	// Note: must be greater than 0
+	MaxAge int64 `protobuf:"varint,1,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	// Evidence is only expired once it's older than both max_age blocks and
+	// max_age_duration
+	MaxAgeDuration time.Duration `protobuf:"bytes,2,opt,name=max_age_duration,json=maxAgeDuration,stdduration" json:"max_age_duration"`
+	// Maximum total size of the evidence of a block, in bytes
+	MaxBytes             int64    `protobuf:"varint,3,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvidenceParams) Reset()         { *m = EvidenceParams{} }
+func (m *EvidenceParams) String() string { return proto.CompactTextString(m) }
+func (*EvidenceParams) ProtoMessage()    {}
+func (*EvidenceParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{43}
+}
+func (m *EvidenceParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EvidenceParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EvidenceParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+}
+func (dst *EvidenceParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvidenceParams.Merge(dst, src)
+}
+func (m *EvidenceParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *EvidenceParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvidenceParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvidenceParams proto.InternalMessageInfo
+
+func (m *EvidenceParams) GetMaxAge() int64 {
+	if m != nil {
+		return m.MaxAge
 	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
+	return 0
+}
+
+func (m *EvidenceParams) GetMaxAgeDuration() time.Duration {
+	if m != nil {
+		return m.MaxAgeDuration
 	}
-	return true
+	return 0
 }
-func (this *RequestInfo) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *EvidenceParams) GetMaxBytes() int64 {
+	if m != nil {
+		return m.MaxBytes
 	}
+	return 0
+}
 
-	that1, ok := that.(*RequestInfo)
-	if !ok {
-		that2, ok := that.(RequestInfo)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Version != that1.Version {
-		return false
-	}
-	if this.BlockVersion != that1.BlockVersion {
-		return false
-	}
-	if this.P2PVersion != that1.P2PVersion {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
-	}
-	return true
+// ValidatorParams contains limits on validators.
+type ValidatorParams struct {
+	PubKeyTypes          []string `protobuf:"bytes,1,rep,name=pub_key_types,json=pubKeyTypes" json:"pub_key_types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (this *RequestSetOption) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*RequestSetOption)
-	if !ok {
-		that2, ok := that.(RequestSetOption)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+func (m *ValidatorParams) Reset()         { *m = ValidatorParams{} }
+func (m *ValidatorParams) String() string { return proto.CompactTextString(m) }
+func (*ValidatorParams) ProtoMessage()    {}
+func (*ValidatorParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{44}
+}
+func (m *ValidatorParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidatorParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidatorParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Key != that1.Key {
-		return false
-	}
-	if this.Value != that1.Value {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
-	}
-	return true
 }
-func (this *RequestInitChain) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (dst *ValidatorParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorParams.Merge(dst, src)
+}
+func (m *ValidatorParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidatorParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorParams proto.InternalMessageInfo
+
+func (m *ValidatorParams) GetPubKeyTypes() []string {
+	if m != nil {
+		return m.PubKeyTypes
 	}
+	return nil
+}
 
-	that1, ok := that.(*RequestInitChain)
-	if !ok {
-		that2, ok := that.(RequestInitChain)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+// TimeoutParams contains the increase of the consensus timeouts with every
+// round.
+type TimeoutParams struct {
+	// Note: must not be negative
+	ProposeDelta         time.Duration `protobuf:"bytes,1,opt,name=propose_delta,json=proposeDelta,stdduration" json:"propose_delta"`
+	PrevoteDelta         time.Duration `protobuf:"bytes,2,opt,name=prevote_delta,json=prevoteDelta,stdduration" json:"prevote_delta"`
+	PrecommitDelta       time.Duration `protobuf:"bytes,3,opt,name=precommit_delta,json=precommitDelta,stdduration" json:"precommit_delta"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *TimeoutParams) Reset()         { *m = TimeoutParams{} }
+func (m *TimeoutParams) String() string { return proto.CompactTextString(m) }
+func (*TimeoutParams) ProtoMessage()    {}
+func (*TimeoutParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{45}
+}
+func (m *TimeoutParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TimeoutParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TimeoutParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Time.Equal(that1.Time) {
-		return false
-	}
-	if this.ChainId != that1.ChainId {
-		return false
+}
+func (dst *TimeoutParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeoutParams.Merge(dst, src)
+}
+func (m *TimeoutParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *TimeoutParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeoutParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeoutParams proto.InternalMessageInfo
+
+func (m *TimeoutParams) GetProposeDelta() time.Duration {
+	if m != nil {
+		return m.ProposeDelta
 	}
-	if !this.ConsensusParams.Equal(that1.ConsensusParams) {
-		return false
+	return 0
+}
+
+func (m *TimeoutParams) GetPrevoteDelta() time.Duration {
+	if m != nil {
+		return m.PrevoteDelta
 	}
-	if len(this.Validators) != len(that1.Validators) {
-		return false
+	return 0
+}
+
+func (m *TimeoutParams) GetPrecommitDelta() time.Duration {
+	if m != nil {
+		return m.PrecommitDelta
 	}
-	for i := range this.Validators {
-		if !this.Validators[i].Equal(&that1.Validators[i]) {
-			return false
+	return 0
+}
+
+type LastCommitInfo struct {
+	Round                int32      `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Votes                []VoteInfo `protobuf:"bytes,2,rep,name=votes" json:"votes"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *LastCommitInfo) Reset()         { *m = LastCommitInfo{} }
+func (m *LastCommitInfo) String() string { return proto.CompactTextString(m) }
+func (*LastCommitInfo) ProtoMessage()    {}
+func (*LastCommitInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{46}
+}
+func (m *LastCommitInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LastCommitInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LastCommitInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if !bytes.Equal(this.AppStateBytes, that1.AppStateBytes) {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
+}
+func (dst *LastCommitInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LastCommitInfo.Merge(dst, src)
+}
+func (m *LastCommitInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *LastCommitInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_LastCommitInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LastCommitInfo proto.InternalMessageInfo
+
+func (m *LastCommitInfo) GetRound() int32 {
+	if m != nil {
+		return m.Round
 	}
-	return true
+	return 0
 }
-func (this *RequestQuery) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *LastCommitInfo) GetVotes() []VoteInfo {
+	if m != nil {
+		return m.Votes
 	}
+	return nil
+}
 
-	that1, ok := that.(*RequestQuery)
-	if !ok {
-		that2, ok := that.(RequestQuery)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type ExtendedCommitInfo struct {
+	Round                int32              `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Votes                []ExtendedVoteInfo `protobuf:"bytes,2,rep,name=votes" json:"votes"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ExtendedCommitInfo) Reset()         { *m = ExtendedCommitInfo{} }
+func (m *ExtendedCommitInfo) String() string { return proto.CompactTextString(m) }
+func (*ExtendedCommitInfo) ProtoMessage()    {}
+func (*ExtendedCommitInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{47}
+}
+func (m *ExtendedCommitInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExtendedCommitInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExtendedCommitInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !bytes.Equal(this.Data, that1.Data) {
-		return false
-	}
-	if this.Path != that1.Path {
-		return false
+}
+func (dst *ExtendedCommitInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExtendedCommitInfo.Merge(dst, src)
+}
+func (m *ExtendedCommitInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExtendedCommitInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExtendedCommitInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExtendedCommitInfo proto.InternalMessageInfo
+
+func (m *ExtendedCommitInfo) GetRound() int32 {
+	if m != nil {
+		return m.Round
 	}
-	if this.Height != that1.Height {
-		return false
+	return 0
+}
+
+func (m *ExtendedCommitInfo) GetVotes() []ExtendedVoteInfo {
+	if m != nil {
+		return m.Votes
 	}
-	if this.Prove != that1.Prove {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
-	}
-	return true
+	return nil
 }
-func (this *RequestBeginBlock) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*RequestBeginBlock)
-	if !ok {
-		that2, ok := that.(RequestBeginBlock)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !bytes.Equal(this.Hash, that1.Hash) {
-		return false
-	}
-	if !this.Header.Equal(&that1.Header) {
-		return false
-	}
-	if !this.LastCommitInfo.Equal(&that1.LastCommitInfo) {
-		return false
-	}
-	if len(this.ByzantineValidators) != len(that1.ByzantineValidators) {
-		return false
-	}
-	for i := range this.ByzantineValidators {
-		if !this.ByzantineValidators[i].Equal(&that1.ByzantineValidators[i]) {
-			return false
+type Header struct {
+	// basic block info
+	Version  Version   `protobuf:"bytes,1,opt,name=version" json:"version"`
+	ChainID  string    `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Height   int64     `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Time     time.Time `protobuf:"bytes,4,opt,name=time,stdtime" json:"time"`
+	NumTxs   int64     `protobuf:"varint,5,opt,name=num_txs,json=numTxs,proto3" json:"num_txs,omitempty"`
+	TotalTxs int64     `protobuf:"varint,6,opt,name=total_txs,json=totalTxs,proto3" json:"total_txs,omitempty"`
+	// prev block info
+	LastBlockId BlockID `protobuf:"bytes,7,opt,name=last_block_id,json=lastBlockId" json:"last_block_id"`
+	// hashes of block data
+	LastCommitHash []byte `protobuf:"bytes,8,opt,name=last_commit_hash,json=lastCommitHash,proto3" json:"last_commit_hash,omitempty"`
+	DataHash       []byte `protobuf:"bytes,9,opt,name=data_hash,json=dataHash,proto3" json:"data_hash,omitempty"`
+	// hashes from the app output from the prev block
+	ValidatorsHash     []byte `protobuf:"bytes,10,opt,name=validators_hash,json=validatorsHash,proto3" json:"validators_hash,omitempty"`
+	NextValidatorsHash []byte `protobuf:"bytes,11,opt,name=next_validators_hash,json=nextValidatorsHash,proto3" json:"next_validators_hash,omitempty"`
+	ConsensusHash      []byte `protobuf:"bytes,12,opt,name=consensus_hash,json=consensusHash,proto3" json:"consensus_hash,omitempty"`
+	AppHash            []byte `protobuf:"bytes,13,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	LastResultsHash    []byte `protobuf:"bytes,14,opt,name=last_results_hash,json=lastResultsHash,proto3" json:"last_results_hash,omitempty"`
+	// consensus info
+	EvidenceHash         []byte   `protobuf:"bytes,15,opt,name=evidence_hash,json=evidenceHash,proto3" json:"evidence_hash,omitempty"`
+	ProposerAddress      []byte   `protobuf:"bytes,16,opt,name=proposer_address,json=proposerAddress,proto3" json:"proposer_address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Header) Reset()         { *m = Header{} }
+func (m *Header) String() string { return proto.CompactTextString(m) }
+func (*Header) ProtoMessage()    {}
+func (*Header) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{48}
+}
+func (m *Header) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Header) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Header.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
-	}
-	return true
 }
-func (this *RequestCheckTx) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (dst *Header) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Header.Merge(dst, src)
+}
+func (m *Header) XXX_Size() int {
+	return m.Size()
+}
+func (m *Header) XXX_DiscardUnknown() {
+	xxx_messageInfo_Header.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Header proto.InternalMessageInfo
+
+func (m *Header) GetVersion() Version {
+	if m != nil {
+		return m.Version
 	}
+	return Version{}
+}
 
-	that1, ok := that.(*RequestCheckTx)
-	if !ok {
-		that2, ok := that.(RequestCheckTx)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *Header) GetChainID() string {
+	if m != nil {
+		return m.ChainID
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return ""
+}
+
+func (m *Header) GetHeight() int64 {
+	if m != nil {
+		return m.Height
 	}
-	if !bytes.Equal(this.Tx, that1.Tx) {
-		return false
+	return 0
+}
+
+func (m *Header) GetTime() time.Time {
+	if m != nil {
+		return m.Time
 	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
+	return time.Time{}
+}
+
+func (m *Header) GetNumTxs() int64 {
+	if m != nil {
+		return m.NumTxs
 	}
-	return true
+	return 0
 }
-func (this *RequestDeliverTx) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *Header) GetTotalTxs() int64 {
+	if m != nil {
+		return m.TotalTxs
 	}
+	return 0
+}
 
-	that1, ok := that.(*RequestDeliverTx)
-	if !ok {
-		that2, ok := that.(RequestDeliverTx)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *Header) GetLastBlockId() BlockID {
+	if m != nil {
+		return m.LastBlockId
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return BlockID{}
+}
+
+func (m *Header) GetLastCommitHash() []byte {
+	if m != nil {
+		return m.LastCommitHash
 	}
-	if !bytes.Equal(this.Tx, that1.Tx) {
-		return false
+	return nil
+}
+
+func (m *Header) GetDataHash() []byte {
+	if m != nil {
+		return m.DataHash
 	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
+	return nil
+}
+
+func (m *Header) GetValidatorsHash() []byte {
+	if m != nil {
+		return m.ValidatorsHash
 	}
-	return true
+	return nil
 }
-func (this *RequestEndBlock) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *Header) GetNextValidatorsHash() []byte {
+	if m != nil {
+		return m.NextValidatorsHash
 	}
+	return nil
+}
 
-	that1, ok := that.(*RequestEndBlock)
-	if !ok {
-		that2, ok := that.(RequestEndBlock)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *Header) GetConsensusHash() []byte {
+	if m != nil {
+		return m.ConsensusHash
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return nil
+}
+
+func (m *Header) GetAppHash() []byte {
+	if m != nil {
+		return m.AppHash
 	}
-	if this.Height != that1.Height {
-		return false
+	return nil
+}
+
+func (m *Header) GetLastResultsHash() []byte {
+	if m != nil {
+		return m.LastResultsHash
 	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
+	return nil
+}
+
+func (m *Header) GetEvidenceHash() []byte {
+	if m != nil {
+		return m.EvidenceHash
 	}
-	return true
+	return nil
 }
-func (this *RequestCommit) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *Header) GetProposerAddress() []byte {
+	if m != nil {
+		return m.ProposerAddress
 	}
+	return nil
+}
 
-	that1, ok := that.(*RequestCommit)
-	if !ok {
-		that2, ok := that.(RequestCommit)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
-	}
-	return true
+type Version struct {
+	Block                uint64   `protobuf:"varint,1,opt,name=Block,proto3" json:"Block,omitempty"`
+	App                  uint64   `protobuf:"varint,2,opt,name=App,proto3" json:"App,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (this *Response) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Response)
-	if !ok {
-		that2, ok := that.(Response)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if that1.Value == nil {
-		if this.Value != nil {
-			return false
+func (m *Version) Reset()         { *m = Version{} }
+func (m *Version) String() string { return proto.CompactTextString(m) }
+func (*Version) ProtoMessage()    {}
+func (*Version) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{49}
+}
+func (m *Version) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Version) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Version.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
-	} else if this.Value == nil {
-		return false
-	} else if !this.Value.Equal(that1.Value) {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
+		return b[:n], nil
 	}
-	return true
 }
-func (this *Response_Exception) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (dst *Version) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Version.Merge(dst, src)
+}
+func (m *Version) XXX_Size() int {
+	return m.Size()
+}
+func (m *Version) XXX_DiscardUnknown() {
+	xxx_messageInfo_Version.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*Response_Exception)
-	if !ok {
-		that2, ok := that.(Response_Exception)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Exception.Equal(that1.Exception) {
-		return false
+var xxx_messageInfo_Version proto.InternalMessageInfo
+
+func (m *Version) GetBlock() uint64 {
+	if m != nil {
+		return m.Block
 	}
-	return true
+	return 0
 }
-func (this *Response_Echo) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Response_Echo)
-	if !ok {
-		that2, ok := that.(Response_Echo)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Echo.Equal(that1.Echo) {
-		return false
+func (m *Version) GetApp() uint64 {
+	if m != nil {
+		return m.App
 	}
-	return true
+	return 0
 }
-func (this *Response_Flush) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Response_Flush)
-	if !ok {
-		that2, ok := that.(Response_Flush)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Flush.Equal(that1.Flush) {
-		return false
-	}
-	return true
+type BlockID struct {
+	Hash                 []byte        `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	PartsHeader          PartSetHeader `protobuf:"bytes,2,opt,name=parts_header,json=partsHeader" json:"parts_header"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
-func (this *Response_Info) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Response_Info)
-	if !ok {
-		that2, ok := that.(Response_Info)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+func (m *BlockID) Reset()         { *m = BlockID{} }
+func (m *BlockID) String() string { return proto.CompactTextString(m) }
+func (*BlockID) ProtoMessage()    {}
+func (*BlockID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{50}
+}
+func (m *BlockID) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockID.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Info.Equal(that1.Info) {
-		return false
-	}
-	return true
 }
-func (this *Response_SetOption) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (dst *BlockID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockID.Merge(dst, src)
+}
+func (m *BlockID) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockID) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockID.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*Response_SetOption)
-	if !ok {
-		that2, ok := that.(Response_SetOption)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.SetOption.Equal(that1.SetOption) {
-		return false
+var xxx_messageInfo_BlockID proto.InternalMessageInfo
+
+func (m *BlockID) GetHash() []byte {
+	if m != nil {
+		return m.Hash
 	}
-	return true
+	return nil
 }
-func (this *Response_InitChain) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Response_InitChain)
-	if !ok {
-		that2, ok := that.(Response_InitChain)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.InitChain.Equal(that1.InitChain) {
-		return false
+func (m *BlockID) GetPartsHeader() PartSetHeader {
+	if m != nil {
+		return m.PartsHeader
 	}
-	return true
+	return PartSetHeader{}
 }
-func (this *Response_Query) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Response_Query)
-	if !ok {
-		that2, ok := that.(Response_Query)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Query.Equal(that1.Query) {
-		return false
-	}
-	return true
+type PartSetHeader struct {
+	Total                int32    `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Hash                 []byte   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (this *Response_BeginBlock) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Response_BeginBlock)
-	if !ok {
-		that2, ok := that.(Response_BeginBlock)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+func (m *PartSetHeader) Reset()         { *m = PartSetHeader{} }
+func (m *PartSetHeader) String() string { return proto.CompactTextString(m) }
+func (*PartSetHeader) ProtoMessage()    {}
+func (*PartSetHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{51}
+}
+func (m *PartSetHeader) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PartSetHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PartSetHeader.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.BeginBlock.Equal(that1.BeginBlock) {
-		return false
-	}
-	return true
 }
-func (this *Response_CheckTx) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (dst *PartSetHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartSetHeader.Merge(dst, src)
+}
+func (m *PartSetHeader) XXX_Size() int {
+	return m.Size()
+}
+func (m *PartSetHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartSetHeader.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*Response_CheckTx)
-	if !ok {
-		that2, ok := that.(Response_CheckTx)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.CheckTx.Equal(that1.CheckTx) {
-		return false
+var xxx_messageInfo_PartSetHeader proto.InternalMessageInfo
+
+func (m *PartSetHeader) GetTotal() int32 {
+	if m != nil {
+		return m.Total
 	}
-	return true
+	return 0
 }
-func (this *Response_DeliverTx) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Response_DeliverTx)
-	if !ok {
-		that2, ok := that.(Response_DeliverTx)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.DeliverTx.Equal(that1.DeliverTx) {
-		return false
+func (m *PartSetHeader) GetHash() []byte {
+	if m != nil {
+		return m.Hash
 	}
-	return true
+	return nil
 }
-func (this *Response_EndBlock) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Response_EndBlock)
-	if !ok {
-		that2, ok := that.(Response_EndBlock)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.EndBlock.Equal(that1.EndBlock) {
-		return false
-	}
-	return true
+// Validator
+type Validator struct {
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// PubKey pub_key = 2 [(gogoproto.nullable)=false];
+	Power                int64    `protobuf:"varint,3,opt,name=power,proto3" json:"power,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (this *Response_Commit) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*Response_Commit)
-	if !ok {
-		that2, ok := that.(Response_Commit)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+func (m *Validator) Reset()         { *m = Validator{} }
+func (m *Validator) String() string { return proto.CompactTextString(m) }
+func (*Validator) ProtoMessage()    {}
+func (*Validator) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{52}
+}
+func (m *Validator) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Validator) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Validator.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Commit.Equal(that1.Commit) {
-		return false
-	}
-	return true
 }
-func (this *ResponseException) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (dst *Validator) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Validator.Merge(dst, src)
+}
+func (m *Validator) XXX_Size() int {
+	return m.Size()
+}
+func (m *Validator) XXX_DiscardUnknown() {
+	xxx_messageInfo_Validator.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*ResponseException)
-	if !ok {
-		that2, ok := that.(ResponseException)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Error != that1.Error {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
+var xxx_messageInfo_Validator proto.InternalMessageInfo
+
+func (m *Validator) GetAddress() []byte {
+	if m != nil {
+		return m.Address
 	}
-	return true
+	return nil
 }
-func (this *ResponseEcho) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*ResponseEcho)
-	if !ok {
-		that2, ok := that.(ResponseEcho)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Message != that1.Message {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
+func (m *Validator) GetPower() int64 {
+	if m != nil {
+		return m.Power
 	}
-	return true
+	return 0
 }
-func (this *ResponseFlush) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*ResponseFlush)
-	if !ok {
-		that2, ok := that.(ResponseFlush)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
-	}
-	return true
+// ValidatorUpdate
+type ValidatorUpdate struct {
+	PubKey               PubKey   `protobuf:"bytes,1,opt,name=pub_key,json=pubKey" json:"pub_key"`
+	Power                int64    `protobuf:"varint,2,opt,name=power,proto3" json:"power,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (this *ResponseInfo) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*ResponseInfo)
-	if !ok {
-		that2, ok := that.(ResponseInfo)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+func (m *ValidatorUpdate) Reset()         { *m = ValidatorUpdate{} }
+func (m *ValidatorUpdate) String() string { return proto.CompactTextString(m) }
+func (*ValidatorUpdate) ProtoMessage()    {}
+func (*ValidatorUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{53}
+}
+func (m *ValidatorUpdate) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidatorUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidatorUpdate.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Data != that1.Data {
-		return false
-	}
-	if this.Version != that1.Version {
-		return false
-	}
-	if this.AppVersion != that1.AppVersion {
-		return false
-	}
-	if this.LastBlockHeight != that1.LastBlockHeight {
-		return false
-	}
-	if !bytes.Equal(this.LastBlockAppHash, that1.LastBlockAppHash) {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
-	}
-	return true
 }
-func (this *ResponseSetOption) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (dst *ValidatorUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorUpdate.Merge(dst, src)
+}
+func (m *ValidatorUpdate) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidatorUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorUpdate.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*ResponseSetOption)
-	if !ok {
-		that2, ok := that.(ResponseSetOption)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Code != that1.Code {
-		return false
-	}
-	if this.Log != that1.Log {
-		return false
-	}
-	if this.Info != that1.Info {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
+var xxx_messageInfo_ValidatorUpdate proto.InternalMessageInfo
+
+func (m *ValidatorUpdate) GetPubKey() PubKey {
+	if m != nil {
+		return m.PubKey
 	}
-	return true
+	return PubKey{}
 }
-func (this *ResponseInitChain) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *ValidatorUpdate) GetPower() int64 {
+	if m != nil {
+		return m.Power
 	}
+	return 0
+}
 
-	that1, ok := that.(*ResponseInitChain)
-	if !ok {
-		that2, ok := that.(ResponseInitChain)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+// VoteInfo
+type VoteInfo struct {
+	Validator            Validator `protobuf:"bytes,1,opt,name=validator" json:"validator"`
+	SignedLastBlock      bool      `protobuf:"varint,2,opt,name=signed_last_block,json=signedLastBlock,proto3" json:"signed_last_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *VoteInfo) Reset()         { *m = VoteInfo{} }
+func (m *VoteInfo) String() string { return proto.CompactTextString(m) }
+func (*VoteInfo) ProtoMessage()    {}
+func (*VoteInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{54}
+}
+func (m *VoteInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VoteInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VoteInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.ConsensusParams.Equal(that1.ConsensusParams) {
-		return false
+}
+func (dst *VoteInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VoteInfo.Merge(dst, src)
+}
+func (m *VoteInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *VoteInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_VoteInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VoteInfo proto.InternalMessageInfo
+
+func (m *VoteInfo) GetValidator() Validator {
+	if m != nil {
+		return m.Validator
 	}
-	if len(this.Validators) != len(that1.Validators) {
-		return false
+	return Validator{}
+}
+
+func (m *VoteInfo) GetSignedLastBlock() bool {
+	if m != nil {
+		return m.SignedLastBlock
 	}
-	for i := range this.Validators {
-		if !this.Validators[i].Equal(&that1.Validators[i]) {
-			return false
+	return false
+}
+
+// ExtendedVoteInfo
+type ExtendedVoteInfo struct {
+	Validator            Validator `protobuf:"bytes,1,opt,name=validator" json:"validator"`
+	SignedLastBlock      bool      `protobuf:"varint,2,opt,name=signed_last_block,json=signedLastBlock,proto3" json:"signed_last_block,omitempty"`
+	VoteExtension        []byte    `protobuf:"bytes,3,opt,name=vote_extension,json=voteExtension,proto3" json:"vote_extension,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ExtendedVoteInfo) Reset()         { *m = ExtendedVoteInfo{} }
+func (m *ExtendedVoteInfo) String() string { return proto.CompactTextString(m) }
+func (*ExtendedVoteInfo) ProtoMessage()    {}
+func (*ExtendedVoteInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{55}
+}
+func (m *ExtendedVoteInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExtendedVoteInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExtendedVoteInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
+}
+func (dst *ExtendedVoteInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExtendedVoteInfo.Merge(dst, src)
+}
+func (m *ExtendedVoteInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExtendedVoteInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExtendedVoteInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExtendedVoteInfo proto.InternalMessageInfo
+
+func (m *ExtendedVoteInfo) GetValidator() Validator {
+	if m != nil {
+		return m.Validator
 	}
-	return true
+	return Validator{}
 }
-func (this *ResponseQuery) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *ExtendedVoteInfo) GetSignedLastBlock() bool {
+	if m != nil {
+		return m.SignedLastBlock
 	}
+	return false
+}
 
-	that1, ok := that.(*ResponseQuery)
-	if !ok {
-		that2, ok := that.(ResponseQuery)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *ExtendedVoteInfo) GetVoteExtension() []byte {
+	if m != nil {
+		return m.VoteExtension
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Code != that1.Code {
-		return false
-	}
-	if this.Log != that1.Log {
-		return false
-	}
-	if this.Info != that1.Info {
-		return false
-	}
-	if this.Index != that1.Index {
-		return false
-	}
-	if !bytes.Equal(this.Key, that1.Key) {
-		return false
-	}
-	if !bytes.Equal(this.Value, that1.Value) {
-		return false
-	}
-	if !this.Proof.Equal(that1.Proof) {
-		return false
-	}
-	if this.Height != that1.Height {
-		return false
-	}
-	if this.Codespace != that1.Codespace {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
-	}
-	return true
+	return nil
 }
-func (this *ResponseBeginBlock) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*ResponseBeginBlock)
-	if !ok {
-		that2, ok := that.(ResponseBeginBlock)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Tags) != len(that1.Tags) {
-		return false
-	}
-	for i := range this.Tags {
-		if !this.Tags[i].Equal(&that1.Tags[i]) {
-			return false
+type PubKey struct {
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PubKey) Reset()         { *m = PubKey{} }
+func (m *PubKey) String() string { return proto.CompactTextString(m) }
+func (*PubKey) ProtoMessage()    {}
+func (*PubKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{56}
+}
+func (m *PubKey) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PubKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PubKey.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
+}
+func (dst *PubKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PubKey.Merge(dst, src)
+}
+func (m *PubKey) XXX_Size() int {
+	return m.Size()
+}
+func (m *PubKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_PubKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PubKey proto.InternalMessageInfo
+
+func (m *PubKey) GetType() string {
+	if m != nil {
+		return m.Type
 	}
-	return true
+	return ""
 }
-func (this *ResponseCheckTx) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *PubKey) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
+	return nil
+}
 
-	that1, ok := that.(*ResponseCheckTx)
-	if !ok {
-		that2, ok := that.(ResponseCheckTx)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type Evidence struct {
+	Type                 string    `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Validator            Validator `protobuf:"bytes,2,opt,name=validator" json:"validator"`
+	Height               int64     `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Time                 time.Time `protobuf:"bytes,4,opt,name=time,stdtime" json:"time"`
+	TotalVotingPower     int64     `protobuf:"varint,5,opt,name=total_voting_power,json=totalVotingPower,proto3" json:"total_voting_power,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Evidence) Reset()         { *m = Evidence{} }
+func (m *Evidence) String() string { return proto.CompactTextString(m) }
+func (*Evidence) ProtoMessage()    {}
+func (*Evidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{57}
+}
+func (m *Evidence) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Evidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Evidence.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Code != that1.Code {
-		return false
-	}
-	if !bytes.Equal(this.Data, that1.Data) {
-		return false
-	}
-	if this.Log != that1.Log {
-		return false
+}
+func (dst *Evidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Evidence.Merge(dst, src)
+}
+func (m *Evidence) XXX_Size() int {
+	return m.Size()
+}
+func (m *Evidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_Evidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Evidence proto.InternalMessageInfo
+
+func (m *Evidence) GetType() string {
+	if m != nil {
+		return m.Type
 	}
-	if this.Info != that1.Info {
-		return false
+	return ""
+}
+
+func (m *Evidence) GetValidator() Validator {
+	if m != nil {
+		return m.Validator
 	}
-	if this.GasWanted != that1.GasWanted {
-		return false
+	return Validator{}
+}
+
+func (m *Evidence) GetHeight() int64 {
+	if m != nil {
+		return m.Height
 	}
-	if this.GasUsed != that1.GasUsed {
-		return false
+	return 0
+}
+
+func (m *Evidence) GetTime() time.Time {
+	if m != nil {
+		return m.Time
 	}
-	if len(this.Tags) != len(that1.Tags) {
-		return false
+	return time.Time{}
+}
+
+func (m *Evidence) GetTotalVotingPower() int64 {
+	if m != nil {
+		return m.TotalVotingPower
 	}
-	for i := range this.Tags {
-		if !this.Tags[i].Equal(&that1.Tags[i]) {
-			return false
+	return 0
+}
+
+type Snapshot struct {
+	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Format               uint32   `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"`
+	Chunks               uint32   `protobuf:"varint,3,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	Hash                 []byte   `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	Metadata             []byte   `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Snapshot) Reset()         { *m = Snapshot{} }
+func (m *Snapshot) String() string { return proto.CompactTextString(m) }
+func (*Snapshot) ProtoMessage()    {}
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_types_a70d46dbc1a61099, []int{58}
+}
+func (m *Snapshot) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Snapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Snapshot.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if this.Codespace != that1.Codespace {
-		return false
+}
+func (dst *Snapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Snapshot.Merge(dst, src)
+}
+func (m *Snapshot) XXX_Size() int {
+	return m.Size()
+}
+func (m *Snapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_Snapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Snapshot proto.InternalMessageInfo
+
+func (m *Snapshot) GetHeight() int64 {
+	if m != nil {
+		return m.Height
 	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
-		return false
+	return 0
+}
+
+func (m *Snapshot) GetFormat() uint32 {
+	if m != nil {
+		return m.Format
 	}
-	return true
+	return 0
 }
-func (this *ResponseDeliverTx) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *Snapshot) GetChunks() uint32 {
+	if m != nil {
+		return m.Chunks
 	}
+	return 0
+}
 
-	that1, ok := that.(*ResponseDeliverTx)
+func (m *Snapshot) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *Snapshot) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Request)(nil), "types.Request")
+	golang_proto.RegisterType((*Request)(nil), "types.Request")
+	proto.RegisterType((*RequestEcho)(nil), "types.RequestEcho")
+	golang_proto.RegisterType((*RequestEcho)(nil), "types.RequestEcho")
+	proto.RegisterType((*RequestFlush)(nil), "types.RequestFlush")
+	golang_proto.RegisterType((*RequestFlush)(nil), "types.RequestFlush")
+	proto.RegisterType((*RequestInfo)(nil), "types.RequestInfo")
+	golang_proto.RegisterType((*RequestInfo)(nil), "types.RequestInfo")
+	proto.RegisterType((*RequestSetOption)(nil), "types.RequestSetOption")
+	golang_proto.RegisterType((*RequestSetOption)(nil), "types.RequestSetOption")
+	proto.RegisterType((*RequestInitChain)(nil), "types.RequestInitChain")
+	golang_proto.RegisterType((*RequestInitChain)(nil), "types.RequestInitChain")
+	proto.RegisterType((*RequestQuery)(nil), "types.RequestQuery")
+	golang_proto.RegisterType((*RequestQuery)(nil), "types.RequestQuery")
+	proto.RegisterType((*RequestBeginBlock)(nil), "types.RequestBeginBlock")
+	golang_proto.RegisterType((*RequestBeginBlock)(nil), "types.RequestBeginBlock")
+	proto.RegisterType((*RequestCheckTx)(nil), "types.RequestCheckTx")
+	golang_proto.RegisterType((*RequestCheckTx)(nil), "types.RequestCheckTx")
+	proto.RegisterType((*RequestDeliverTx)(nil), "types.RequestDeliverTx")
+	golang_proto.RegisterType((*RequestDeliverTx)(nil), "types.RequestDeliverTx")
+	proto.RegisterType((*RequestEndBlock)(nil), "types.RequestEndBlock")
+	golang_proto.RegisterType((*RequestEndBlock)(nil), "types.RequestEndBlock")
+	proto.RegisterType((*RequestCommit)(nil), "types.RequestCommit")
+	golang_proto.RegisterType((*RequestCommit)(nil), "types.RequestCommit")
+	proto.RegisterType((*RequestListSnapshots)(nil), "types.RequestListSnapshots")
+	golang_proto.RegisterType((*RequestListSnapshots)(nil), "types.RequestListSnapshots")
+	proto.RegisterType((*RequestOfferSnapshot)(nil), "types.RequestOfferSnapshot")
+	golang_proto.RegisterType((*RequestOfferSnapshot)(nil), "types.RequestOfferSnapshot")
+	proto.RegisterType((*RequestLoadSnapshotChunk)(nil), "types.RequestLoadSnapshotChunk")
+	golang_proto.RegisterType((*RequestLoadSnapshotChunk)(nil), "types.RequestLoadSnapshotChunk")
+	proto.RegisterType((*RequestApplySnapshotChunk)(nil), "types.RequestApplySnapshotChunk")
+	golang_proto.RegisterType((*RequestApplySnapshotChunk)(nil), "types.RequestApplySnapshotChunk")
+	proto.RegisterType((*RequestExtendVote)(nil), "types.RequestExtendVote")
+	golang_proto.RegisterType((*RequestExtendVote)(nil), "types.RequestExtendVote")
+	proto.RegisterType((*RequestVerifyVoteExtension)(nil), "types.RequestVerifyVoteExtension")
+	golang_proto.RegisterType((*RequestVerifyVoteExtension)(nil), "types.RequestVerifyVoteExtension")
+	proto.RegisterType((*RequestPrepareProposal)(nil), "types.RequestPrepareProposal")
+	golang_proto.RegisterType((*RequestPrepareProposal)(nil), "types.RequestPrepareProposal")
+	proto.RegisterType((*RequestProcessProposal)(nil), "types.RequestProcessProposal")
+	golang_proto.RegisterType((*RequestProcessProposal)(nil), "types.RequestProcessProposal")
+	proto.RegisterType((*Response)(nil), "types.Response")
+	golang_proto.RegisterType((*Response)(nil), "types.Response")
+	proto.RegisterType((*ResponseException)(nil), "types.ResponseException")
+	golang_proto.RegisterType((*ResponseException)(nil), "types.ResponseException")
+	proto.RegisterType((*ResponseEcho)(nil), "types.ResponseEcho")
+	golang_proto.RegisterType((*ResponseEcho)(nil), "types.ResponseEcho")
+	proto.RegisterType((*ResponseFlush)(nil), "types.ResponseFlush")
+	golang_proto.RegisterType((*ResponseFlush)(nil), "types.ResponseFlush")
+	proto.RegisterType((*ResponseInfo)(nil), "types.ResponseInfo")
+	golang_proto.RegisterType((*ResponseInfo)(nil), "types.ResponseInfo")
+	proto.RegisterType((*ResponseSetOption)(nil), "types.ResponseSetOption")
+	golang_proto.RegisterType((*ResponseSetOption)(nil), "types.ResponseSetOption")
+	proto.RegisterType((*ResponseInitChain)(nil), "types.ResponseInitChain")
+	golang_proto.RegisterType((*ResponseInitChain)(nil), "types.ResponseInitChain")
+	proto.RegisterType((*ResponseQuery)(nil), "types.ResponseQuery")
+	golang_proto.RegisterType((*ResponseQuery)(nil), "types.ResponseQuery")
+	proto.RegisterType((*ResponseBeginBlock)(nil), "types.ResponseBeginBlock")
+	golang_proto.RegisterType((*ResponseBeginBlock)(nil), "types.ResponseBeginBlock")
+	proto.RegisterType((*ResponseCheckTx)(nil), "types.ResponseCheckTx")
+	golang_proto.RegisterType((*ResponseCheckTx)(nil), "types.ResponseCheckTx")
+	proto.RegisterType((*ResponseDeliverTx)(nil), "types.ResponseDeliverTx")
+	golang_proto.RegisterType((*ResponseDeliverTx)(nil), "types.ResponseDeliverTx")
+	proto.RegisterType((*ResponseEndBlock)(nil), "types.ResponseEndBlock")
+	golang_proto.RegisterType((*ResponseEndBlock)(nil), "types.ResponseEndBlock")
+	proto.RegisterType((*ResponseCommit)(nil), "types.ResponseCommit")
+	golang_proto.RegisterType((*ResponseCommit)(nil), "types.ResponseCommit")
+	proto.RegisterType((*ResponseListSnapshots)(nil), "types.ResponseListSnapshots")
+	golang_proto.RegisterType((*ResponseListSnapshots)(nil), "types.ResponseListSnapshots")
+	proto.RegisterType((*ResponseOfferSnapshot)(nil), "types.ResponseOfferSnapshot")
+	golang_proto.RegisterType((*ResponseOfferSnapshot)(nil), "types.ResponseOfferSnapshot")
+	proto.RegisterType((*ResponseLoadSnapshotChunk)(nil), "types.ResponseLoadSnapshotChunk")
+	golang_proto.RegisterType((*ResponseLoadSnapshotChunk)(nil), "types.ResponseLoadSnapshotChunk")
+	proto.RegisterType((*ResponseApplySnapshotChunk)(nil), "types.ResponseApplySnapshotChunk")
+	golang_proto.RegisterType((*ResponseApplySnapshotChunk)(nil), "types.ResponseApplySnapshotChunk")
+	proto.RegisterType((*ResponseExtendVote)(nil), "types.ResponseExtendVote")
+	golang_proto.RegisterType((*ResponseExtendVote)(nil), "types.ResponseExtendVote")
+	proto.RegisterType((*ResponseVerifyVoteExtension)(nil), "types.ResponseVerifyVoteExtension")
+	golang_proto.RegisterType((*ResponseVerifyVoteExtension)(nil), "types.ResponseVerifyVoteExtension")
+	proto.RegisterType((*ResponsePrepareProposal)(nil), "types.ResponsePrepareProposal")
+	golang_proto.RegisterType((*ResponsePrepareProposal)(nil), "types.ResponsePrepareProposal")
+	proto.RegisterType((*ResponseProcessProposal)(nil), "types.ResponseProcessProposal")
+	golang_proto.RegisterType((*ResponseProcessProposal)(nil), "types.ResponseProcessProposal")
+	proto.RegisterType((*ConsensusParams)(nil), "types.ConsensusParams")
+	golang_proto.RegisterType((*ConsensusParams)(nil), "types.ConsensusParams")
+	proto.RegisterType((*BlockParams)(nil), "types.BlockParams")
+	golang_proto.RegisterType((*BlockParams)(nil), "types.BlockParams")
+	proto.RegisterType((*EvidenceParams)(nil), "types.EvidenceParams")
+	golang_proto.RegisterType((*EvidenceParams)(nil), "types.EvidenceParams")
+	proto.RegisterType((*ValidatorParams)(nil), "types.ValidatorParams")
+	golang_proto.RegisterType((*ValidatorParams)(nil), "types.ValidatorParams")
+	proto.RegisterType((*TimeoutParams)(nil), "types.TimeoutParams")
+	golang_proto.RegisterType((*TimeoutParams)(nil), "types.TimeoutParams")
+	proto.RegisterType((*LastCommitInfo)(nil), "types.LastCommitInfo")
+	golang_proto.RegisterType((*LastCommitInfo)(nil), "types.LastCommitInfo")
+	proto.RegisterType((*ExtendedCommitInfo)(nil), "types.ExtendedCommitInfo")
+	golang_proto.RegisterType((*ExtendedCommitInfo)(nil), "types.ExtendedCommitInfo")
+	proto.RegisterType((*Header)(nil), "types.Header")
+	golang_proto.RegisterType((*Header)(nil), "types.Header")
+	proto.RegisterType((*Version)(nil), "types.Version")
+	golang_proto.RegisterType((*Version)(nil), "types.Version")
+	proto.RegisterType((*BlockID)(nil), "types.BlockID")
+	golang_proto.RegisterType((*BlockID)(nil), "types.BlockID")
+	proto.RegisterType((*PartSetHeader)(nil), "types.PartSetHeader")
+	golang_proto.RegisterType((*PartSetHeader)(nil), "types.PartSetHeader")
+	proto.RegisterType((*Validator)(nil), "types.Validator")
+	golang_proto.RegisterType((*Validator)(nil), "types.Validator")
+	proto.RegisterType((*ValidatorUpdate)(nil), "types.ValidatorUpdate")
+	golang_proto.RegisterType((*ValidatorUpdate)(nil), "types.ValidatorUpdate")
+	proto.RegisterType((*VoteInfo)(nil), "types.VoteInfo")
+	golang_proto.RegisterType((*VoteInfo)(nil), "types.VoteInfo")
+	proto.RegisterType((*ExtendedVoteInfo)(nil), "types.ExtendedVoteInfo")
+	golang_proto.RegisterType((*ExtendedVoteInfo)(nil), "types.ExtendedVoteInfo")
+	proto.RegisterType((*PubKey)(nil), "types.PubKey")
+	golang_proto.RegisterType((*PubKey)(nil), "types.PubKey")
+	proto.RegisterType((*Evidence)(nil), "types.Evidence")
+	golang_proto.RegisterType((*Evidence)(nil), "types.Evidence")
+	proto.RegisterType((*Snapshot)(nil), "types.Snapshot")
+	golang_proto.RegisterType((*Snapshot)(nil), "types.Snapshot")
+	proto.RegisterEnum("types.ResponseOfferSnapshot_Result", ResponseOfferSnapshot_Result_name, ResponseOfferSnapshot_Result_value)
+	golang_proto.RegisterEnum("types.ResponseOfferSnapshot_Result", ResponseOfferSnapshot_Result_name, ResponseOfferSnapshot_Result_value)
+	proto.RegisterEnum("types.ResponseApplySnapshotChunk_Result", ResponseApplySnapshotChunk_Result_name, ResponseApplySnapshotChunk_Result_value)
+	golang_proto.RegisterEnum("types.ResponseApplySnapshotChunk_Result", ResponseApplySnapshotChunk_Result_name, ResponseApplySnapshotChunk_Result_value)
+	proto.RegisterEnum("types.ResponseVerifyVoteExtension_Result", ResponseVerifyVoteExtension_Result_name, ResponseVerifyVoteExtension_Result_value)
+	golang_proto.RegisterEnum("types.ResponseVerifyVoteExtension_Result", ResponseVerifyVoteExtension_Result_name, ResponseVerifyVoteExtension_Result_value)
+	proto.RegisterEnum("types.ResponseProcessProposal_Status", ResponseProcessProposal_Status_name, ResponseProcessProposal_Status_value)
+	golang_proto.RegisterEnum("types.ResponseProcessProposal_Status", ResponseProcessProposal_Status_name, ResponseProcessProposal_Status_value)
+}
+func (this *Request) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Request)
 	if !ok {
-		that2, ok := that.(ResponseDeliverTx)
+		that2, ok := that.(Request)
 		if ok {
 			that1 = &that2
 		} else {
@@ -4645,33 +5276,13 @@ func (this *ResponseDeliverTx) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Code != that1.Code {
-		return false
-	}
-	if !bytes.Equal(this.Data, that1.Data) {
-		return false
-	}
-	if this.Log != that1.Log {
-		return false
-	}
-	if this.Info != that1.Info {
-		return false
-	}
-	if this.GasWanted != that1.GasWanted {
-		return false
-	}
-	if this.GasUsed != that1.GasUsed {
-		return false
-	}
-	if len(this.Tags) != len(that1.Tags) {
-		return false
-	}
-	for i := range this.Tags {
-		if !this.Tags[i].Equal(&that1.Tags[i]) {
+	if that1.Value == nil {
+		if this.Value != nil {
 			return false
 		}
-	}
-	if this.Codespace != that1.Codespace {
+	} else if this.Value == nil {
+		return false
+	} else if !this.Value.Equal(that1.Value) {
 		return false
 	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
@@ -4679,14 +5290,14 @@ func (this *ResponseDeliverTx) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *ResponseEndBlock) Equal(that interface{}) bool {
+func (this *Request_Echo) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ResponseEndBlock)
+	that1, ok := that.(*Request_Echo)
 	if !ok {
-		that2, ok := that.(ResponseEndBlock)
+		that2, ok := that.(Request_Echo)
 		if ok {
 			that1 = &that2
 		} else {
@@ -4698,38 +5309,19 @@ func (this *ResponseEndBlock) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.ValidatorUpdates) != len(that1.ValidatorUpdates) {
-		return false
-	}
-	for i := range this.ValidatorUpdates {
-		if !this.ValidatorUpdates[i].Equal(&that1.ValidatorUpdates[i]) {
-			return false
-		}
-	}
-	if !this.ConsensusParamUpdates.Equal(that1.ConsensusParamUpdates) {
-		return false
-	}
-	if len(this.Tags) != len(that1.Tags) {
-		return false
-	}
-	for i := range this.Tags {
-		if !this.Tags[i].Equal(&that1.Tags[i]) {
-			return false
-		}
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+	if !this.Echo.Equal(that1.Echo) {
 		return false
 	}
 	return true
 }
-func (this *ResponseCommit) Equal(that interface{}) bool {
+func (this *Request_Flush) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ResponseCommit)
+	that1, ok := that.(*Request_Flush)
 	if !ok {
-		that2, ok := that.(ResponseCommit)
+		that2, ok := that.(Request_Flush)
 		if ok {
 			that1 = &that2
 		} else {
@@ -4741,22 +5333,19 @@ func (this *ResponseCommit) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.Data, that1.Data) {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+	if !this.Flush.Equal(that1.Flush) {
 		return false
 	}
 	return true
 }
-func (this *ConsensusParams) Equal(that interface{}) bool {
+func (this *Request_Info) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ConsensusParams)
+	that1, ok := that.(*Request_Info)
 	if !ok {
-		that2, ok := that.(ConsensusParams)
+		that2, ok := that.(Request_Info)
 		if ok {
 			that1 = &that2
 		} else {
@@ -4768,28 +5357,19 @@ func (this *ConsensusParams) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Block.Equal(that1.Block) {
-		return false
-	}
-	if !this.Evidence.Equal(that1.Evidence) {
-		return false
-	}
-	if !this.Validator.Equal(that1.Validator) {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+	if !this.Info.Equal(that1.Info) {
 		return false
 	}
 	return true
 }
-func (this *BlockParams) Equal(that interface{}) bool {
+func (this *Request_SetOption) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*BlockParams)
+	that1, ok := that.(*Request_SetOption)
 	if !ok {
-		that2, ok := that.(BlockParams)
+		that2, ok := that.(Request_SetOption)
 		if ok {
 			that1 = &that2
 		} else {
@@ -4801,25 +5381,19 @@ func (this *BlockParams) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.MaxBytes != that1.MaxBytes {
-		return false
-	}
-	if this.MaxGas != that1.MaxGas {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+	if !this.SetOption.Equal(that1.SetOption) {
 		return false
 	}
 	return true
 }
-func (this *EvidenceParams) Equal(that interface{}) bool {
+func (this *Request_InitChain) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*EvidenceParams)
+	that1, ok := that.(*Request_InitChain)
 	if !ok {
-		that2, ok := that.(EvidenceParams)
+		that2, ok := that.(Request_InitChain)
 		if ok {
 			that1 = &that2
 		} else {
@@ -4831,22 +5405,19 @@ func (this *EvidenceParams) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.MaxAge != that1.MaxAge {
-		return false
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+	if !this.InitChain.Equal(that1.InitChain) {
 		return false
 	}
 	return true
 }
-func (this *ValidatorParams) Equal(that interface{}) bool {
+func (this *Request_Query) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ValidatorParams)
+	that1, ok := that.(*Request_Query)
 	if !ok {
-		that2, ok := that.(ValidatorParams)
+		that2, ok := that.(Request_Query)
 		if ok {
 			that1 = &that2
 		} else {
@@ -4858,27 +5429,19 @@ func (this *ValidatorParams) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.PubKeyTypes) != len(that1.PubKeyTypes) {
-		return false
-	}
-	for i := range this.PubKeyTypes {
-		if this.PubKeyTypes[i] != that1.PubKeyTypes[i] {
-			return false
-		}
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+	if !this.Query.Equal(that1.Query) {
 		return false
 	}
 	return true
 }
-func (this *LastCommitInfo) Equal(that interface{}) bool {
+func (this *Request_BeginBlock) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LastCommitInfo)
+	that1, ok := that.(*Request_BeginBlock)
 	if !ok {
-		that2, ok := that.(LastCommitInfo)
+		that2, ok := that.(Request_BeginBlock)
 		if ok {
 			that1 = &that2
 		} else {
@@ -4890,30 +5453,19 @@ func (this *LastCommitInfo) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Round != that1.Round {
-		return false
-	}
-	if len(this.Votes) != len(that1.Votes) {
-		return false
-	}
-	for i := range this.Votes {
-		if !this.Votes[i].Equal(&that1.Votes[i]) {
-			return false
-		}
-	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+	if !this.BeginBlock.Equal(that1.BeginBlock) {
 		return false
 	}
 	return true
 }
-func (this *Header) Equal(that interface{}) bool {
+func (this *Request_CheckTx) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Header)
+	that1, ok := that.(*Request_CheckTx)
 	if !ok {
-		that2, ok := that.(Header)
+		that2, ok := that.(Request_CheckTx)
 		if ok {
 			that1 = &that2
 		} else {
@@ -4925,67 +5477,115 @@ func (this *Header) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Version.Equal(&that1.Version) {
-		return false
-	}
-	if this.ChainID != that1.ChainID {
+	if !this.CheckTx.Equal(that1.CheckTx) {
 		return false
 	}
-	if this.Height != that1.Height {
-		return false
+	return true
+}
+func (this *Request_DeliverTx) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if !this.Time.Equal(that1.Time) {
-		return false
+
+	that1, ok := that.(*Request_DeliverTx)
+	if !ok {
+		that2, ok := that.(Request_DeliverTx)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if this.NumTxs != that1.NumTxs {
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	if this.TotalTxs != that1.TotalTxs {
+	if !this.DeliverTx.Equal(that1.DeliverTx) {
 		return false
 	}
-	if !this.LastBlockId.Equal(&that1.LastBlockId) {
-		return false
+	return true
+}
+func (this *Request_EndBlock) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if !bytes.Equal(this.LastCommitHash, that1.LastCommitHash) {
-		return false
+
+	that1, ok := that.(*Request_EndBlock)
+	if !ok {
+		that2, ok := that.(Request_EndBlock)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if !bytes.Equal(this.DataHash, that1.DataHash) {
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.ValidatorsHash, that1.ValidatorsHash) {
+	if !this.EndBlock.Equal(that1.EndBlock) {
 		return false
 	}
-	if !bytes.Equal(this.NextValidatorsHash, that1.NextValidatorsHash) {
-		return false
+	return true
+}
+func (this *Request_Commit) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if !bytes.Equal(this.ConsensusHash, that1.ConsensusHash) {
-		return false
+
+	that1, ok := that.(*Request_Commit)
+	if !ok {
+		that2, ok := that.(Request_Commit)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if !bytes.Equal(this.AppHash, that1.AppHash) {
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.LastResultsHash, that1.LastResultsHash) {
+	if !this.Commit.Equal(that1.Commit) {
 		return false
 	}
-	if !bytes.Equal(this.EvidenceHash, that1.EvidenceHash) {
-		return false
+	return true
+}
+func (this *Request_ListSnapshots) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if !bytes.Equal(this.ProposerAddress, that1.ProposerAddress) {
+
+	that1, ok := that.(*Request_ListSnapshots)
+	if !ok {
+		that2, ok := that.(Request_ListSnapshots)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+	if !this.ListSnapshots.Equal(that1.ListSnapshots) {
 		return false
 	}
 	return true
 }
-func (this *Version) Equal(that interface{}) bool {
+func (this *Request_OfferSnapshot) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Version)
+	that1, ok := that.(*Request_OfferSnapshot)
 	if !ok {
-		that2, ok := that.(Version)
+		that2, ok := that.(Request_OfferSnapshot)
 		if ok {
 			that1 = &that2
 		} else {
@@ -4997,25 +5597,43 @@ func (this *Version) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Block != that1.Block {
+	if !this.OfferSnapshot.Equal(that1.OfferSnapshot) {
 		return false
 	}
-	if this.App != that1.App {
+	return true
+}
+func (this *Request_LoadSnapshotChunk) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Request_LoadSnapshotChunk)
+	if !ok {
+		that2, ok := that.(Request_LoadSnapshotChunk)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+	if !this.LoadSnapshotChunk.Equal(that1.LoadSnapshotChunk) {
 		return false
 	}
 	return true
 }
-func (this *BlockID) Equal(that interface{}) bool {
+func (this *Request_ApplySnapshotChunk) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*BlockID)
+	that1, ok := that.(*Request_ApplySnapshotChunk)
 	if !ok {
-		that2, ok := that.(BlockID)
+		that2, ok := that.(Request_ApplySnapshotChunk)
 		if ok {
 			that1 = &that2
 		} else {
@@ -5027,25 +5645,43 @@ func (this *BlockID) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.Hash, that1.Hash) {
+	if !this.ApplySnapshotChunk.Equal(that1.ApplySnapshotChunk) {
 		return false
 	}
-	if !this.PartsHeader.Equal(&that1.PartsHeader) {
+	return true
+}
+func (this *Request_ExtendVote) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Request_ExtendVote)
+	if !ok {
+		that2, ok := that.(Request_ExtendVote)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+	if !this.ExtendVote.Equal(that1.ExtendVote) {
 		return false
 	}
 	return true
 }
-func (this *PartSetHeader) Equal(that interface{}) bool {
+func (this *Request_VerifyVoteExtension) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*PartSetHeader)
+	that1, ok := that.(*Request_VerifyVoteExtension)
 	if !ok {
-		that2, ok := that.(PartSetHeader)
+		that2, ok := that.(Request_VerifyVoteExtension)
 		if ok {
 			that1 = &that2
 		} else {
@@ -5057,25 +5693,43 @@ func (this *PartSetHeader) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Total != that1.Total {
+	if !this.VerifyVoteExtension.Equal(that1.VerifyVoteExtension) {
 		return false
 	}
-	if !bytes.Equal(this.Hash, that1.Hash) {
+	return true
+}
+func (this *Request_PrepareProposal) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Request_PrepareProposal)
+	if !ok {
+		that2, ok := that.(Request_PrepareProposal)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+	if !this.PrepareProposal.Equal(that1.PrepareProposal) {
 		return false
 	}
 	return true
 }
-func (this *Validator) Equal(that interface{}) bool {
+func (this *Request_ProcessProposal) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Validator)
+	that1, ok := that.(*Request_ProcessProposal)
 	if !ok {
-		that2, ok := that.(Validator)
+		that2, ok := that.(Request_ProcessProposal)
 		if ok {
 			that1 = &that2
 		} else {
@@ -5087,10 +5741,31 @@ func (this *Validator) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.Address, that1.Address) {
+	if !this.ProcessProposal.Equal(that1.ProcessProposal) {
 		return false
 	}
-	if this.Power != that1.Power {
+	return true
+}
+func (this *RequestEcho) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RequestEcho)
+	if !ok {
+		that2, ok := that.(RequestEcho)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Message != that1.Message {
 		return false
 	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
@@ -5098,14 +5773,14 @@ func (this *Validator) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *ValidatorUpdate) Equal(that interface{}) bool {
+func (this *RequestFlush) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ValidatorUpdate)
+	that1, ok := that.(*RequestFlush)
 	if !ok {
-		that2, ok := that.(ValidatorUpdate)
+		that2, ok := that.(RequestFlush)
 		if ok {
 			that1 = &that2
 		} else {
@@ -5117,25 +5792,19 @@ func (this *ValidatorUpdate) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.PubKey.Equal(&that1.PubKey) {
-		return false
-	}
-	if this.Power != that1.Power {
-		return false
-	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
 	return true
 }
-func (this *VoteInfo) Equal(that interface{}) bool {
+func (this *RequestInfo) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*VoteInfo)
+	that1, ok := that.(*RequestInfo)
 	if !ok {
-		that2, ok := that.(VoteInfo)
+		that2, ok := that.(RequestInfo)
 		if ok {
 			that1 = &that2
 		} else {
@@ -5147,10 +5816,16 @@ func (this *VoteInfo) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Validator.Equal(&that1.Validator) {
+	if this.Version != that1.Version {
 		return false
 	}
-	if this.SignedLastBlock != that1.SignedLastBlock {
+	if this.BlockVersion != that1.BlockVersion {
+		return false
+	}
+	if this.P2PVersion != that1.P2PVersion {
+		return false
+	}
+	if !this.ConsensusParams.Equal(that1.ConsensusParams) {
 		return false
 	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
@@ -5158,14 +5833,14 @@ func (this *VoteInfo) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *PubKey) Equal(that interface{}) bool {
+func (this *RequestSetOption) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*PubKey)
+	that1, ok := that.(*RequestSetOption)
 	if !ok {
-		that2, ok := that.(PubKey)
+		that2, ok := that.(RequestSetOption)
 		if ok {
 			that1 = &that2
 		} else {
@@ -5177,10 +5852,10 @@ func (this *PubKey) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Type != that1.Type {
+	if this.Key != that1.Key {
 		return false
 	}
-	if !bytes.Equal(this.Data, that1.Data) {
+	if this.Value != that1.Value {
 		return false
 	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
@@ -5188,14 +5863,14 @@ func (this *PubKey) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *Evidence) Equal(that interface{}) bool {
+func (this *RequestInitChain) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Evidence)
+	that1, ok := that.(*RequestInitChain)
 	if !ok {
-		that2, ok := that.(Evidence)
+		that2, ok := that.(RequestInitChain)
 		if ok {
 			that1 = &that2
 		} else {
@@ -5207,19 +5882,24 @@ func (this *Evidence) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Type != that1.Type {
+	if !this.Time.Equal(that1.Time) {
 		return false
 	}
-	if !this.Validator.Equal(&that1.Validator) {
+	if this.ChainId != that1.ChainId {
 		return false
 	}
-	if this.Height != that1.Height {
+	if !this.ConsensusParams.Equal(that1.ConsensusParams) {
 		return false
 	}
-	if !this.Time.Equal(that1.Time) {
+	if len(this.Validators) != len(that1.Validators) {
 		return false
 	}
-	if this.TotalVotingPower != that1.TotalVotingPower {
+	for i := range this.Validators {
+		if !this.Validators[i].Equal(&that1.Validators[i]) {
+			return false
+		}
+	}
+	if !bytes.Equal(this.AppStateBytes, that1.AppStateBytes) {
 		return false
 	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
@@ -5227,4388 +5907,11552 @@ func (this *Evidence) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *RequestQuery) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// ABCIApplicationClient is the client API for ABCIApplication service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ABCIApplicationClient interface {
-	Echo(ctx context.Context, in *RequestEcho, opts ...grpc.CallOption) (*ResponseEcho, error)
-	Flush(ctx context.Context, in *RequestFlush, opts ...grpc.CallOption) (*ResponseFlush, error)
-	Info(ctx context.Context, in *RequestInfo, opts ...grpc.CallOption) (*ResponseInfo, error)
-	SetOption(ctx context.Context, in *RequestSetOption, opts ...grpc.CallOption) (*ResponseSetOption, error)
-	DeliverTx(ctx context.Context, in *RequestDeliverTx, opts ...grpc.CallOption) (*ResponseDeliverTx, error)
-	CheckTx(ctx context.Context, in *RequestCheckTx, opts ...grpc.CallOption) (*ResponseCheckTx, error)
-	Query(ctx context.Context, in *RequestQuery, opts ...grpc.CallOption) (*ResponseQuery, error)
-	Commit(ctx context.Context, in *RequestCommit, opts ...grpc.CallOption) (*ResponseCommit, error)
-	InitChain(ctx context.Context, in *RequestInitChain, opts ...grpc.CallOption) (*ResponseInitChain, error)
-	BeginBlock(ctx context.Context, in *RequestBeginBlock, opts ...grpc.CallOption) (*ResponseBeginBlock, error)
-	EndBlock(ctx context.Context, in *RequestEndBlock, opts ...grpc.CallOption) (*ResponseEndBlock, error)
-}
-
-type aBCIApplicationClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewABCIApplicationClient(cc *grpc.ClientConn) ABCIApplicationClient {
-	return &aBCIApplicationClient{cc}
-}
-
-func (c *aBCIApplicationClient) Echo(ctx context.Context, in *RequestEcho, opts ...grpc.CallOption) (*ResponseEcho, error) {
-	out := new(ResponseEcho)
-	err := c.cc.Invoke(ctx, "/types.ABCIApplication/Echo", in, out, opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*RequestQuery)
+	if !ok {
+		that2, ok := that.(RequestQuery)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return out, nil
-}
-
-func (c *aBCIApplicationClient) Flush(ctx context.Context, in *RequestFlush, opts ...grpc.CallOption) (*ResponseFlush, error) {
-	out := new(ResponseFlush)
-	err := c.cc.Invoke(ctx, "/types.ABCIApplication/Flush", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return out, nil
-}
-
-func (c *aBCIApplicationClient) Info(ctx context.Context, in *RequestInfo, opts ...grpc.CallOption) (*ResponseInfo, error) {
-	out := new(ResponseInfo)
-	err := c.cc.Invoke(ctx, "/types.ABCIApplication/Info", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
 	}
-	return out, nil
-}
-
-func (c *aBCIApplicationClient) SetOption(ctx context.Context, in *RequestSetOption, opts ...grpc.CallOption) (*ResponseSetOption, error) {
-	out := new(ResponseSetOption)
-	err := c.cc.Invoke(ctx, "/types.ABCIApplication/SetOption", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if this.Path != that1.Path {
+		return false
 	}
-	return out, nil
-}
-
-func (c *aBCIApplicationClient) DeliverTx(ctx context.Context, in *RequestDeliverTx, opts ...grpc.CallOption) (*ResponseDeliverTx, error) {
-	out := new(ResponseDeliverTx)
-	err := c.cc.Invoke(ctx, "/types.ABCIApplication/DeliverTx", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if this.Height != that1.Height {
+		return false
 	}
-	return out, nil
-}
-
-func (c *aBCIApplicationClient) CheckTx(ctx context.Context, in *RequestCheckTx, opts ...grpc.CallOption) (*ResponseCheckTx, error) {
-	out := new(ResponseCheckTx)
-	err := c.cc.Invoke(ctx, "/types.ABCIApplication/CheckTx", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if this.Prove != that1.Prove {
+		return false
 	}
-	return out, nil
-}
-
-func (c *aBCIApplicationClient) Query(ctx context.Context, in *RequestQuery, opts ...grpc.CallOption) (*ResponseQuery, error) {
-	out := new(ResponseQuery)
-	err := c.cc.Invoke(ctx, "/types.ABCIApplication/Query", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return out, nil
+	return true
 }
-
-func (c *aBCIApplicationClient) Commit(ctx context.Context, in *RequestCommit, opts ...grpc.CallOption) (*ResponseCommit, error) {
-	out := new(ResponseCommit)
-	err := c.cc.Invoke(ctx, "/types.ABCIApplication/Commit", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *RequestBeginBlock) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return out, nil
-}
 
-func (c *aBCIApplicationClient) InitChain(ctx context.Context, in *RequestInitChain, opts ...grpc.CallOption) (*ResponseInitChain, error) {
-	out := new(ResponseInitChain)
-	err := c.cc.Invoke(ctx, "/types.ABCIApplication/InitChain", in, out, opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*RequestBeginBlock)
+	if !ok {
+		that2, ok := that.(RequestBeginBlock)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return out, nil
-}
-
-func (c *aBCIApplicationClient) BeginBlock(ctx context.Context, in *RequestBeginBlock, opts ...grpc.CallOption) (*ResponseBeginBlock, error) {
-	out := new(ResponseBeginBlock)
-	err := c.cc.Invoke(ctx, "/types.ABCIApplication/BeginBlock", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return out, nil
-}
-
-func (c *aBCIApplicationClient) EndBlock(ctx context.Context, in *RequestEndBlock, opts ...grpc.CallOption) (*ResponseEndBlock, error) {
-	out := new(ResponseEndBlock)
-	err := c.cc.Invoke(ctx, "/types.ABCIApplication/EndBlock", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if !bytes.Equal(this.Hash, that1.Hash) {
+		return false
 	}
-	return out, nil
-}
-
-// ABCIApplicationServer is the server API for ABCIApplication service.
-type ABCIApplicationServer interface {
-	Echo(context.Context, *RequestEcho) (*ResponseEcho, error)
-	Flush(context.Context, *RequestFlush) (*ResponseFlush, error)
-	Info(context.Context, *RequestInfo) (*ResponseInfo, error)
-	SetOption(context.Context, *RequestSetOption) (*ResponseSetOption, error)
-	DeliverTx(context.Context, *RequestDeliverTx) (*ResponseDeliverTx, error)
-	CheckTx(context.Context, *RequestCheckTx) (*ResponseCheckTx, error)
-	Query(context.Context, *RequestQuery) (*ResponseQuery, error)
-	Commit(context.Context, *RequestCommit) (*ResponseCommit, error)
-	InitChain(context.Context, *RequestInitChain) (*ResponseInitChain, error)
-	BeginBlock(context.Context, *RequestBeginBlock) (*ResponseBeginBlock, error)
-	EndBlock(context.Context, *RequestEndBlock) (*ResponseEndBlock, error)
-}
-
-func RegisterABCIApplicationServer(s *grpc.Server, srv ABCIApplicationServer) {
-	s.RegisterService(&_ABCIApplication_serviceDesc, srv)
-}
-
-func _ABCIApplication_Echo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RequestEcho)
-	if err := dec(in); err != nil {
-		return nil, err
+	if !this.Header.Equal(&that1.Header) {
+		return false
 	}
-	if interceptor == nil {
-		return srv.(ABCIApplicationServer).Echo(ctx, in)
+	if !this.LastCommitInfo.Equal(&that1.LastCommitInfo) {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.ABCIApplication/Echo",
+	if len(this.ByzantineValidators) != len(that1.ByzantineValidators) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ABCIApplicationServer).Echo(ctx, req.(*RequestEcho))
+	for i := range this.ByzantineValidators {
+		if !this.ByzantineValidators[i].Equal(&that1.ByzantineValidators[i]) {
+			return false
+		}
 	}
-	return interceptor(ctx, in, info, handler)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *RequestCheckTx) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _ABCIApplication_Flush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RequestFlush)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*RequestCheckTx)
+	if !ok {
+		that2, ok := that.(RequestCheckTx)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ABCIApplicationServer).Flush(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.ABCIApplication/Flush",
+	if !bytes.Equal(this.Tx, that1.Tx) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ABCIApplicationServer).Flush(ctx, req.(*RequestFlush))
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
+func (this *RequestDeliverTx) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _ABCIApplication_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RequestInfo)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*RequestDeliverTx)
+	if !ok {
+		that2, ok := that.(RequestDeliverTx)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ABCIApplicationServer).Info(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.ABCIApplication/Info",
+	if !bytes.Equal(this.Tx, that1.Tx) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ABCIApplicationServer).Info(ctx, req.(*RequestInfo))
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
+func (this *RequestEndBlock) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _ABCIApplication_SetOption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RequestSetOption)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*RequestEndBlock)
+	if !ok {
+		that2, ok := that.(RequestEndBlock)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ABCIApplicationServer).SetOption(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.ABCIApplication/SetOption",
+	if this.Height != that1.Height {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ABCIApplicationServer).SetOption(ctx, req.(*RequestSetOption))
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
-
-func _ABCIApplication_DeliverTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RequestDeliverTx)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *RequestCommit) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if interceptor == nil {
-		return srv.(ABCIApplicationServer).DeliverTx(ctx, in)
+
+	that1, ok := that.(*RequestCommit)
+	if !ok {
+		that2, ok := that.(RequestCommit)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.ABCIApplication/DeliverTx",
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ABCIApplicationServer).DeliverTx(ctx, req.(*RequestDeliverTx))
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
-
-func _ABCIApplication_CheckTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RequestCheckTx)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *RequestListSnapshots) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if interceptor == nil {
-		return srv.(ABCIApplicationServer).CheckTx(ctx, in)
+
+	that1, ok := that.(*RequestListSnapshots)
+	if !ok {
+		that2, ok := that.(RequestListSnapshots)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.ABCIApplication/CheckTx",
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ABCIApplicationServer).CheckTx(ctx, req.(*RequestCheckTx))
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
+func (this *RequestOfferSnapshot) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _ABCIApplication_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RequestQuery)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*RequestOfferSnapshot)
+	if !ok {
+		that2, ok := that.(RequestOfferSnapshot)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ABCIApplicationServer).Query(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.ABCIApplication/Query",
+	if !this.Snapshot.Equal(that1.Snapshot) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ABCIApplicationServer).Query(ctx, req.(*RequestQuery))
+	if !bytes.Equal(this.AppHash, that1.AppHash) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *RequestLoadSnapshotChunk) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _ABCIApplication_Commit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RequestCommit)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*RequestLoadSnapshotChunk)
+	if !ok {
+		that2, ok := that.(RequestLoadSnapshotChunk)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ABCIApplicationServer).Commit(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.ABCIApplication/Commit",
+	if this.Height != that1.Height {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ABCIApplicationServer).Commit(ctx, req.(*RequestCommit))
+	if this.Format != that1.Format {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	if this.Chunk != that1.Chunk {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *RequestApplySnapshotChunk) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _ABCIApplication_InitChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RequestInitChain)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*RequestApplySnapshotChunk)
+	if !ok {
+		that2, ok := that.(RequestApplySnapshotChunk)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ABCIApplicationServer).InitChain(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.ABCIApplication/InitChain",
+	if this.Index != that1.Index {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ABCIApplicationServer).InitChain(ctx, req.(*RequestInitChain))
+	if !bytes.Equal(this.Chunk, that1.Chunk) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	if this.Sender != that1.Sender {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *RequestExtendVote) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _ABCIApplication_BeginBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RequestBeginBlock)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*RequestExtendVote)
+	if !ok {
+		that2, ok := that.(RequestExtendVote)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ABCIApplicationServer).BeginBlock(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.ABCIApplication/BeginBlock",
+	if !bytes.Equal(this.Hash, that1.Hash) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ABCIApplicationServer).BeginBlock(ctx, req.(*RequestBeginBlock))
+	if this.Height != that1.Height {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *RequestVerifyVoteExtension) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _ABCIApplication_EndBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RequestEndBlock)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*RequestVerifyVoteExtension)
+	if !ok {
+		that2, ok := that.(RequestVerifyVoteExtension)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(ABCIApplicationServer).EndBlock(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/types.ABCIApplication/EndBlock",
+	if !bytes.Equal(this.Hash, that1.Hash) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ABCIApplicationServer).EndBlock(ctx, req.(*RequestEndBlock))
+	if !bytes.Equal(this.ValidatorAddress, that1.ValidatorAddress) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "types.ABCIApplication",
-	HandlerType: (*ABCIApplicationServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Echo",
-			Handler:    _ABCIApplication_Echo_Handler,
-		},
-		{
-			MethodName: "Flush",
-			Handler:    _ABCIApplication_Flush_Handler,
-		},
-		{
-			MethodName: "Info",
-			Handler:    _ABCIApplication_Info_Handler,
-		},
-		{
-			MethodName: "SetOption",
-			Handler:    _ABCIApplication_SetOption_Handler,
-		},
-		{
-			MethodName: "DeliverTx",
-			Handler:    _ABCIApplication_DeliverTx_Handler,
-		},
-		{
-			MethodName: "CheckTx",
-			Handler:    _ABCIApplication_CheckTx_Handler,
-		},
-		{
-			MethodName: "Query",
-			Handler:    _ABCIApplication_Query_Handler,
-		},
-		{
-			MethodName: "Commit",
-			Handler:    _ABCIApplication_Commit_Handler,
-		},
-		{
-			MethodName: "InitChain",
-			Handler:    _ABCIApplication_InitChain_Handler,
-		},
-		{
-			MethodName: "BeginBlock",
-			Handler:    _ABCIApplication_BeginBlock_Handler,
-		},
-		{
-			MethodName: "EndBlock",
-			Handler:    _ABCIApplication_EndBlock_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "abci/types/types.proto",
-}
-
-func (m *Request) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if this.Height != that1.Height {
+		return false
 	}
-	return dAtA[:n], nil
+	if !bytes.Equal(this.VoteExtension, that1.VoteExtension) {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *RequestPrepareProposal) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Request) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Value != nil {
-		nn1, err := m.Value.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	that1, ok := that.(*RequestPrepareProposal)
+	if !ok {
+		that2, ok := that.(RequestPrepareProposal)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += nn1
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
+	if this.Height != that1.Height {
+		return false
+	}
+	if !this.LocalLastCommit.Equal(&that1.LocalLastCommit) {
+		return false
+	}
+	if len(this.Txs) != len(that1.Txs) {
+		return false
+	}
+	for i := range this.Txs {
+		if !bytes.Equal(this.Txs[i], that1.Txs[i]) {
+			return false
+		}
+	}
+	if this.MaxTxBytes != that1.MaxTxBytes {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *RequestProcessProposal) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Request_Echo) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Echo != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Echo.Size()))
-		n2, err := m.Echo.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	that1, ok := that.(*RequestProcessProposal)
+	if !ok {
+		that2, ok := that.(RequestProcessProposal)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += n2
 	}
-	return i, nil
-}
-func (m *Request_Flush) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Flush != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Flush.Size()))
-		n3, err := m.Flush.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.Hash, that1.Hash) {
+		return false
+	}
+	if !this.Header.Equal(&that1.Header) {
+		return false
+	}
+	if len(this.Txs) != len(that1.Txs) {
+		return false
+	}
+	for i := range this.Txs {
+		if !bytes.Equal(this.Txs[i], that1.Txs[i]) {
+			return false
 		}
-		i += n3
 	}
-	return i, nil
-}
-func (m *Request_Info) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Info != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Info.Size()))
-		n4, err := m.Info.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if !this.ProposedLastCommit.Equal(&that1.ProposedLastCommit) {
+		return false
+	}
+	if len(this.ByzantineValidators) != len(that1.ByzantineValidators) {
+		return false
+	}
+	for i := range this.ByzantineValidators {
+		if !this.ByzantineValidators[i].Equal(&that1.ByzantineValidators[i]) {
+			return false
 		}
-		i += n4
 	}
-	return i, nil
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
-func (m *Request_SetOption) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.SetOption != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.SetOption.Size()))
-		n5, err := m.SetOption.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+func (this *Response) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Response)
+	if !ok {
+		that2, ok := that.(Response)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += n5
 	}
-	return i, nil
-}
-func (m *Request_InitChain) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.InitChain != nil {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.InitChain.Size()))
-		n6, err := m.InitChain.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if that1.Value == nil {
+		if this.Value != nil {
+			return false
 		}
-		i += n6
+	} else if this.Value == nil {
+		return false
+	} else if !this.Value.Equal(that1.Value) {
+		return false
 	}
-	return i, nil
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
-func (m *Request_Query) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Query != nil {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Query.Size()))
-		n7, err := m.Query.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+func (this *Response_Exception) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Response_Exception)
+	if !ok {
+		that2, ok := that.(Response_Exception)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += n7
 	}
-	return i, nil
-}
-func (m *Request_BeginBlock) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.BeginBlock != nil {
-		dAtA[i] = 0x42
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.BeginBlock.Size()))
-		n8, err := m.BeginBlock.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n8
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
-}
-func (m *Request_CheckTx) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.CheckTx != nil {
-		dAtA[i] = 0x4a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.CheckTx.Size()))
-		n9, err := m.CheckTx.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n9
+	if !this.Exception.Equal(that1.Exception) {
+		return false
 	}
-	return i, nil
+	return true
 }
-func (m *Request_EndBlock) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.EndBlock != nil {
-		dAtA[i] = 0x5a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.EndBlock.Size()))
-		n10, err := m.EndBlock.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n10
+func (this *Response_Echo) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return i, nil
-}
-func (m *Request_Commit) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Commit != nil {
-		dAtA[i] = 0x62
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Commit.Size()))
-		n11, err := m.Commit.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+
+	that1, ok := that.(*Response_Echo)
+	if !ok {
+		that2, ok := that.(Response_Echo)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += n11
 	}
-	return i, nil
-}
-func (m *Request_DeliverTx) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.DeliverTx != nil {
-		dAtA[i] = 0x9a
-		i++
-		dAtA[i] = 0x1
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.DeliverTx.Size()))
-		n12, err := m.DeliverTx.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n12
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
-}
-func (m *RequestEcho) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if !this.Echo.Equal(that1.Echo) {
+		return false
 	}
-	return dAtA[:n], nil
+	return true
 }
-
-func (m *RequestEcho) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Message) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Message)))
-		i += copy(dAtA[i:], m.Message)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (this *Response_Flush) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return i, nil
-}
 
-func (m *RequestFlush) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*Response_Flush)
+	if !ok {
+		that2, ok := that.(Response_Flush)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *RequestFlush) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
-}
-
-func (m *RequestInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if !this.Flush.Equal(that1.Flush) {
+		return false
 	}
-	return dAtA[:n], nil
+	return true
 }
-
-func (m *RequestInfo) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Version) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Version)))
-		i += copy(dAtA[i:], m.Version)
+func (this *Response_Info) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if m.BlockVersion != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.BlockVersion))
+
+	that1, ok := that.(*Response_Info)
+	if !ok {
+		that2, ok := that.(Response_Info)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.P2PVersion != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.P2PVersion))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if !this.Info.Equal(that1.Info) {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *RequestSetOption) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *Response_SetOption) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *RequestSetOption) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Key) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Key)))
-		i += copy(dAtA[i:], m.Key)
+	that1, ok := that.(*Response_SetOption)
+	if !ok {
+		that2, ok := that.(Response_SetOption)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.Value) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Value)))
-		i += copy(dAtA[i:], m.Value)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if !this.SetOption.Equal(that1.SetOption) {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *RequestInitChain) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *Response_InitChain) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *RequestInitChain) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintTypes(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)))
-	n13, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i:])
-	if err != nil {
-		return 0, err
+	that1, ok := that.(*Response_InitChain)
+	if !ok {
+		that2, ok := that.(Response_InitChain)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	i += n13
-	if len(m.ChainId) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.ChainId)))
-		i += copy(dAtA[i:], m.ChainId)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.ConsensusParams != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.ConsensusParams.Size()))
-		n14, err := m.ConsensusParams.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n14
+	if !this.InitChain.Equal(that1.InitChain) {
+		return false
 	}
-	if len(m.Validators) > 0 {
-		for _, msg := range m.Validators {
-			dAtA[i] = 0x22
-			i++
-			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	return true
+}
+func (this *Response_Query) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Response_Query)
+	if !ok {
+		that2, ok := that.(Response_Query)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if len(m.AppStateBytes) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.AppStateBytes)))
-		i += copy(dAtA[i:], m.AppStateBytes)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if !this.Query.Equal(that1.Query) {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *RequestQuery) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *Response_BeginBlock) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *RequestQuery) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Data) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Data)))
-		i += copy(dAtA[i:], m.Data)
+	that1, ok := that.(*Response_BeginBlock)
+	if !ok {
+		that2, ok := that.(Response_BeginBlock)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.Path) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Path)))
-		i += copy(dAtA[i:], m.Path)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Height != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+	if !this.BeginBlock.Equal(that1.BeginBlock) {
+		return false
 	}
-	if m.Prove {
-		dAtA[i] = 0x20
-		i++
-		if m.Prove {
-			dAtA[i] = 1
+	return true
+}
+func (this *Response_CheckTx) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Response_CheckTx)
+	if !ok {
+		that2, ok := that.(Response_CheckTx)
+		if ok {
+			that1 = &that2
 		} else {
-			dAtA[i] = 0
+			return false
 		}
-		i++
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
+	if !this.CheckTx.Equal(that1.CheckTx) {
+		return false
+	}
+	return true
 }
+func (this *Response_DeliverTx) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *RequestBeginBlock) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*Response_DeliverTx)
+	if !ok {
+		that2, ok := that.(Response_DeliverTx)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.DeliverTx.Equal(that1.DeliverTx) {
+		return false
+	}
+	return true
 }
+func (this *Response_EndBlock) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *RequestBeginBlock) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Hash) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
-		i += copy(dAtA[i:], m.Hash)
+	that1, ok := that.(*Response_EndBlock)
+	if !ok {
+		that2, ok := that.(Response_EndBlock)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintTypes(dAtA, i, uint64(m.Header.Size()))
-	n15, err := m.Header.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	i += n15
-	dAtA[i] = 0x1a
-	i++
-	i = encodeVarintTypes(dAtA, i, uint64(m.LastCommitInfo.Size()))
-	n16, err := m.LastCommitInfo.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if !this.EndBlock.Equal(that1.EndBlock) {
+		return false
 	}
-	i += n16
-	if len(m.ByzantineValidators) > 0 {
-		for _, msg := range m.ByzantineValidators {
-			dAtA[i] = 0x22
-			i++
-			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	return true
+}
+func (this *Response_Commit) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Response_Commit)
+	if !ok {
+		that2, ok := that.(Response_Commit)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
+	if !this.Commit.Equal(that1.Commit) {
+		return false
+	}
+	return true
 }
+func (this *Response_ListSnapshots) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *RequestCheckTx) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*Response_ListSnapshots)
+	if !ok {
+		that2, ok := that.(Response_ListSnapshots)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *RequestCheckTx) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Tx) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Tx)))
-		i += copy(dAtA[i:], m.Tx)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if !this.ListSnapshots.Equal(that1.ListSnapshots) {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *RequestDeliverTx) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *Response_OfferSnapshot) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *RequestDeliverTx) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Tx) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Tx)))
-		i += copy(dAtA[i:], m.Tx)
+	that1, ok := that.(*Response_OfferSnapshot)
+	if !ok {
+		that2, ok := that.(Response_OfferSnapshot)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
-}
-
-func (m *RequestEndBlock) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if !this.OfferSnapshot.Equal(that1.OfferSnapshot) {
+		return false
 	}
-	return dAtA[:n], nil
+	return true
 }
+func (this *Response_LoadSnapshotChunk) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *RequestEndBlock) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Height != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+	that1, ok := that.(*Response_LoadSnapshotChunk)
+	if !ok {
+		that2, ok := that.(Response_LoadSnapshotChunk)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
-}
-
-func (m *RequestCommit) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if !this.LoadSnapshotChunk.Equal(that1.LoadSnapshotChunk) {
+		return false
 	}
-	return dAtA[:n], nil
+	return true
 }
-
-func (m *RequestCommit) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (this *Response_ApplySnapshotChunk) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return i, nil
-}
 
-func (m *Response) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*Response_ApplySnapshotChunk)
+	if !ok {
+		that2, ok := that.(Response_ApplySnapshotChunk)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.ApplySnapshotChunk.Equal(that1.ApplySnapshotChunk) {
+		return false
+	}
+	return true
 }
+func (this *Response_ExtendVote) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Response) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Value != nil {
-		nn17, err := m.Value.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	that1, ok := that.(*Response_ExtendVote)
+	if !ok {
+		that2, ok := that.(Response_ExtendVote)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += nn17
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
+	if !this.ExtendVote.Equal(that1.ExtendVote) {
+		return false
+	}
+	return true
 }
+func (this *Response_VerifyVoteExtension) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Response_Exception) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Exception != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Exception.Size()))
-		n18, err := m.Exception.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	that1, ok := that.(*Response_VerifyVoteExtension)
+	if !ok {
+		that2, ok := that.(Response_VerifyVoteExtension)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += n18
 	}
-	return i, nil
-}
-func (m *Response_Echo) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Echo != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Echo.Size()))
-		n19, err := m.Echo.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n19
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
+	if !this.VerifyVoteExtension.Equal(that1.VerifyVoteExtension) {
+		return false
+	}
+	return true
 }
-func (m *Response_Flush) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Flush != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Flush.Size()))
-		n20, err := m.Flush.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+func (this *Response_PrepareProposal) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Response_PrepareProposal)
+	if !ok {
+		that2, ok := that.(Response_PrepareProposal)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += n20
 	}
-	return i, nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.PrepareProposal.Equal(that1.PrepareProposal) {
+		return false
+	}
+	return true
 }
-func (m *Response_Info) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Info != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Info.Size()))
-		n21, err := m.Info.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+func (this *Response_ProcessProposal) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Response_ProcessProposal)
+	if !ok {
+		that2, ok := that.(Response_ProcessProposal)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += n21
 	}
-	return i, nil
-}
-func (m *Response_SetOption) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.SetOption != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.SetOption.Size()))
-		n22, err := m.SetOption.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n22
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
-}
-func (m *Response_InitChain) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.InitChain != nil {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.InitChain.Size()))
-		n23, err := m.InitChain.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n23
+	if !this.ProcessProposal.Equal(that1.ProcessProposal) {
+		return false
 	}
-	return i, nil
+	return true
 }
-func (m *Response_Query) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Query != nil {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Query.Size()))
-		n24, err := m.Query.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n24
+func (this *ResponseException) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return i, nil
-}
-func (m *Response_BeginBlock) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.BeginBlock != nil {
-		dAtA[i] = 0x42
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.BeginBlock.Size()))
-		n25, err := m.BeginBlock.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+
+	that1, ok := that.(*ResponseException)
+	if !ok {
+		that2, ok := that.(ResponseException)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += n25
 	}
-	return i, nil
-}
-func (m *Response_CheckTx) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.CheckTx != nil {
-		dAtA[i] = 0x4a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.CheckTx.Size()))
-		n26, err := m.CheckTx.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n26
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
-}
-func (m *Response_DeliverTx) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.DeliverTx != nil {
-		dAtA[i] = 0x52
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.DeliverTx.Size()))
-		n27, err := m.DeliverTx.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n27
+	if this.Error != that1.Error {
+		return false
 	}
-	return i, nil
-}
-func (m *Response_EndBlock) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.EndBlock != nil {
-		dAtA[i] = 0x5a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.EndBlock.Size()))
-		n28, err := m.EndBlock.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n28
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return i, nil
+	return true
 }
-func (m *Response_Commit) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Commit != nil {
-		dAtA[i] = 0x62
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Commit.Size()))
-		n29, err := m.Commit.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+func (this *ResponseEcho) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ResponseEcho)
+	if !ok {
+		that2, ok := that.(ResponseEcho)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += n29
 	}
-	return i, nil
-}
-func (m *ResponseException) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ResponseException) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Error) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Error)))
-		i += copy(dAtA[i:], m.Error)
+	if this.Message != that1.Message {
+		return false
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *ResponseEcho) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *ResponseFlush) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *ResponseEcho) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Message) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Message)))
-		i += copy(dAtA[i:], m.Message)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	that1, ok := that.(*ResponseFlush)
+	if !ok {
+		that2, ok := that.(ResponseFlush)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return i, nil
-}
-
-func (m *ResponseFlush) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ResponseFlush) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *ResponseInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *ResponseInfo) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *ResponseInfo) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Data) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Data)))
-		i += copy(dAtA[i:], m.Data)
+	that1, ok := that.(*ResponseInfo)
+	if !ok {
+		that2, ok := that.(ResponseInfo)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.Version) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Version)))
-		i += copy(dAtA[i:], m.Version)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.AppVersion != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.AppVersion))
+	if this.Data != that1.Data {
+		return false
 	}
-	if m.LastBlockHeight != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.LastBlockHeight))
+	if this.Version != that1.Version {
+		return false
 	}
-	if len(m.LastBlockAppHash) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.LastBlockAppHash)))
-		i += copy(dAtA[i:], m.LastBlockAppHash)
+	if this.AppVersion != that1.AppVersion {
+		return false
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if this.LastBlockHeight != that1.LastBlockHeight {
+		return false
 	}
-	return i, nil
-}
-
-func (m *ResponseSetOption) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if !bytes.Equal(this.LastBlockAppHash, that1.LastBlockAppHash) {
+		return false
 	}
-	return dAtA[:n], nil
+	if !this.ConsensusParams.Equal(that1.ConsensusParams) {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *ResponseSetOption) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ResponseSetOption) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Code != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Code))
+	that1, ok := that.(*ResponseSetOption)
+	if !ok {
+		that2, ok := that.(ResponseSetOption)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.Log) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Log)))
-		i += copy(dAtA[i:], m.Log)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.Info) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Info)))
-		i += copy(dAtA[i:], m.Info)
+	if this.Code != that1.Code {
+		return false
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if this.Log != that1.Log {
+		return false
 	}
-	return i, nil
-}
-
-func (m *ResponseInitChain) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if this.Info != that1.Info {
+		return false
 	}
-	return dAtA[:n], nil
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *ResponseInitChain) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ResponseInitChain) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ConsensusParams != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.ConsensusParams.Size()))
-		n30, err := m.ConsensusParams.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	that1, ok := that.(*ResponseInitChain)
+	if !ok {
+		that2, ok := that.(ResponseInitChain)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += n30
 	}
-	if len(m.Validators) > 0 {
-		for _, msg := range m.Validators {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.ConsensusParams.Equal(that1.ConsensusParams) {
+		return false
+	}
+	if len(this.Validators) != len(that1.Validators) {
+		return false
+	}
+	for i := range this.Validators {
+		if !this.Validators[i].Equal(&that1.Validators[i]) {
+			return false
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *ResponseQuery) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *ResponseQuery) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *ResponseQuery) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Code != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Code))
+	that1, ok := that.(*ResponseQuery)
+	if !ok {
+		that2, ok := that.(ResponseQuery)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.Log) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Log)))
-		i += copy(dAtA[i:], m.Log)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.Info) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Info)))
-		i += copy(dAtA[i:], m.Info)
+	if this.Code != that1.Code {
+		return false
 	}
-	if m.Index != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Index))
+	if this.Log != that1.Log {
+		return false
 	}
-	if len(m.Key) > 0 {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Key)))
-		i += copy(dAtA[i:], m.Key)
+	if this.Info != that1.Info {
+		return false
 	}
-	if len(m.Value) > 0 {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Value)))
-		i += copy(dAtA[i:], m.Value)
+	if this.Index != that1.Index {
+		return false
 	}
-	if m.Proof != nil {
-		dAtA[i] = 0x42
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Proof.Size()))
-		n31, err := m.Proof.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n31
+	if !bytes.Equal(this.Key, that1.Key) {
+		return false
 	}
-	if m.Height != 0 {
-		dAtA[i] = 0x48
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+	if !bytes.Equal(this.Value, that1.Value) {
+		return false
 	}
-	if len(m.Codespace) > 0 {
-		dAtA[i] = 0x52
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Codespace)))
-		i += copy(dAtA[i:], m.Codespace)
+	if !this.Proof.Equal(that1.Proof) {
+		return false
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if this.Height != that1.Height {
+		return false
 	}
-	return i, nil
+	if this.Codespace != that1.Codespace {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *ResponseBeginBlock) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ResponseBeginBlock) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*ResponseBeginBlock)
+	if !ok {
+		that2, ok := that.(ResponseBeginBlock)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ResponseBeginBlock) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Tags) > 0 {
-		for _, msg := range m.Tags {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Tags) != len(that1.Tags) {
+		return false
+	}
+	for i := range this.Tags {
+		if !this.Tags[i].Equal(&that1.Tags[i]) {
+			return false
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *ResponseCheckTx) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *ResponseCheckTx) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *ResponseCheckTx) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Code != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Code))
+	that1, ok := that.(*ResponseCheckTx)
+	if !ok {
+		that2, ok := that.(ResponseCheckTx)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.Data) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Data)))
-		i += copy(dAtA[i:], m.Data)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.Log) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Log)))
-		i += copy(dAtA[i:], m.Log)
+	if this.Code != that1.Code {
+		return false
 	}
-	if len(m.Info) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Info)))
-		i += copy(dAtA[i:], m.Info)
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
 	}
-	if m.GasWanted != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.GasWanted))
+	if this.Log != that1.Log {
+		return false
 	}
-	if m.GasUsed != 0 {
-		dAtA[i] = 0x30
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.GasUsed))
+	if this.Info != that1.Info {
+		return false
 	}
-	if len(m.Tags) > 0 {
-		for _, msg := range m.Tags {
-			dAtA[i] = 0x3a
-			i++
-			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	if this.GasWanted != that1.GasWanted {
+		return false
+	}
+	if this.GasUsed != that1.GasUsed {
+		return false
+	}
+	if len(this.Tags) != len(that1.Tags) {
+		return false
+	}
+	for i := range this.Tags {
+		if !this.Tags[i].Equal(&that1.Tags[i]) {
+			return false
 		}
 	}
-	if len(m.Codespace) > 0 {
-		dAtA[i] = 0x42
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Codespace)))
-		i += copy(dAtA[i:], m.Codespace)
+	if this.Codespace != that1.Codespace {
+		return false
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if this.Priority != that1.Priority {
+		return false
 	}
-	return i, nil
-}
-
-func (m *ResponseDeliverTx) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if this.Sender != that1.Sender {
+		return false
 	}
-	return dAtA[:n], nil
+	if this.MempoolError != that1.MempoolError {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *ResponseDeliverTx) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ResponseDeliverTx) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Code != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Code))
+	that1, ok := that.(*ResponseDeliverTx)
+	if !ok {
+		that2, ok := that.(ResponseDeliverTx)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.Data) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Data)))
-		i += copy(dAtA[i:], m.Data)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.Log) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Log)))
-		i += copy(dAtA[i:], m.Log)
+	if this.Code != that1.Code {
+		return false
 	}
-	if len(m.Info) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Info)))
-		i += copy(dAtA[i:], m.Info)
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
 	}
-	if m.GasWanted != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.GasWanted))
+	if this.Log != that1.Log {
+		return false
 	}
-	if m.GasUsed != 0 {
-		dAtA[i] = 0x30
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.GasUsed))
+	if this.Info != that1.Info {
+		return false
 	}
-	if len(m.Tags) > 0 {
-		for _, msg := range m.Tags {
-			dAtA[i] = 0x3a
-			i++
-			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	if this.GasWanted != that1.GasWanted {
+		return false
+	}
+	if this.GasUsed != that1.GasUsed {
+		return false
+	}
+	if len(this.Tags) != len(that1.Tags) {
+		return false
+	}
+	for i := range this.Tags {
+		if !this.Tags[i].Equal(&that1.Tags[i]) {
+			return false
 		}
 	}
-	if len(m.Codespace) > 0 {
-		dAtA[i] = 0x42
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Codespace)))
-		i += copy(dAtA[i:], m.Codespace)
+	if this.Codespace != that1.Codespace {
+		return false
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return i, nil
+	return true
 }
+func (this *ResponseEndBlock) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ResponseEndBlock) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *ResponseEndBlock) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.ValidatorUpdates) > 0 {
-		for _, msg := range m.ValidatorUpdates {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	that1, ok := that.(*ResponseEndBlock)
+	if !ok {
+		that2, ok := that.(ResponseEndBlock)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if m.ConsensusParamUpdates != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.ConsensusParamUpdates.Size()))
-		n32, err := m.ConsensusParamUpdates.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.ValidatorUpdates) != len(that1.ValidatorUpdates) {
+		return false
+	}
+	for i := range this.ValidatorUpdates {
+		if !this.ValidatorUpdates[i].Equal(&that1.ValidatorUpdates[i]) {
+			return false
 		}
-		i += n32
 	}
-	if len(m.Tags) > 0 {
-		for _, msg := range m.Tags {
-			dAtA[i] = 0x1a
-			i++
-			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	if !this.ConsensusParamUpdates.Equal(that1.ConsensusParamUpdates) {
+		return false
+	}
+	if len(this.Tags) != len(that1.Tags) {
+		return false
+	}
+	for i := range this.Tags {
+		if !this.Tags[i].Equal(&that1.Tags[i]) {
+			return false
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if this.PendingState != that1.PendingState {
+		return false
 	}
-	return i, nil
-}
-
-func (m *ResponseCommit) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return dAtA[:n], nil
+	return true
 }
+func (this *ResponseCommit) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ResponseCommit) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Data) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Data)))
-		i += copy(dAtA[i:], m.Data)
+	that1, ok := that.(*ResponseCommit)
+	if !ok {
+		that2, ok := that.(ResponseCommit)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
-}
-
-func (m *ConsensusParams) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
 	}
-	return dAtA[:n], nil
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *ResponseListSnapshots) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ConsensusParams) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Block != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Block.Size()))
-		n33, err := m.Block.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	that1, ok := that.(*ResponseListSnapshots)
+	if !ok {
+		that2, ok := that.(ResponseListSnapshots)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i += n33
 	}
-	if m.Evidence != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Evidence.Size()))
-		n34, err := m.Evidence.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n34
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Validator != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Validator.Size()))
-		n35, err := m.Validator.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(this.Snapshots) != len(that1.Snapshots) {
+		return false
+	}
+	for i := range this.Snapshots {
+		if !this.Snapshots[i].Equal(that1.Snapshots[i]) {
+			return false
 		}
-		i += n35
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *BlockParams) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *ResponseOfferSnapshot) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *BlockParams) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.MaxBytes != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.MaxBytes))
+	that1, ok := that.(*ResponseOfferSnapshot)
+	if !ok {
+		that2, ok := that.(ResponseOfferSnapshot)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.MaxGas != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.MaxGas))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if this.Result != that1.Result {
+		return false
 	}
-	return i, nil
-}
-
-func (m *EvidenceParams) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return dAtA[:n], nil
+	return true
 }
-
-func (m *EvidenceParams) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.MaxAge != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.MaxAge))
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (this *ResponseLoadSnapshotChunk) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return i, nil
-}
 
-func (m *ValidatorParams) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*ResponseLoadSnapshotChunk)
+	if !ok {
+		that2, ok := that.(ResponseLoadSnapshotChunk)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.Chunk, that1.Chunk) {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *ResponseApplySnapshotChunk) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ValidatorParams) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.PubKeyTypes) > 0 {
-		for _, s := range m.PubKeyTypes {
-			dAtA[i] = 0xa
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+	that1, ok := that.(*ResponseApplySnapshotChunk)
+	if !ok {
+		that2, ok := that.(ResponseApplySnapshotChunk)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
-}
-
-func (m *LastCommitInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if this.Result != that1.Result {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *LastCommitInfo) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Round != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+	if len(this.RefetchChunks) != len(that1.RefetchChunks) {
+		return false
 	}
-	if len(m.Votes) > 0 {
-		for _, msg := range m.Votes {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	for i := range this.RefetchChunks {
+		if this.RefetchChunks[i] != that1.RefetchChunks[i] {
+			return false
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if len(this.RejectSenders) != len(that1.RejectSenders) {
+		return false
 	}
-	return i, nil
-}
-
-func (m *Header) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	for i := range this.RejectSenders {
+		if this.RejectSenders[i] != that1.RejectSenders[i] {
+			return false
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *Header) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintTypes(dAtA, i, uint64(m.Version.Size()))
-	n36, err := m.Version.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	i += n36
-	if len(m.ChainID) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.ChainID)))
-		i += copy(dAtA[i:], m.ChainID)
+	return true
+}
+func (this *ResponseExtendVote) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if m.Height != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+
+	that1, ok := that.(*ResponseExtendVote)
+	if !ok {
+		that2, ok := that.(ResponseExtendVote)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	dAtA[i] = 0x22
-	i++
-	i = encodeVarintTypes(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)))
-	n37, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i:])
-	if err != nil {
-		return 0, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	i += n37
-	if m.NumTxs != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.NumTxs))
+	if !bytes.Equal(this.VoteExtension, that1.VoteExtension) {
+		return false
 	}
-	if m.TotalTxs != 0 {
-		dAtA[i] = 0x30
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.TotalTxs))
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	dAtA[i] = 0x3a
-	i++
-	i = encodeVarintTypes(dAtA, i, uint64(m.LastBlockId.Size()))
-	n38, err := m.LastBlockId.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return true
+}
+func (this *ResponseVerifyVoteExtension) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	i += n38
-	if len(m.LastCommitHash) > 0 {
-		dAtA[i] = 0x42
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.LastCommitHash)))
-		i += copy(dAtA[i:], m.LastCommitHash)
+
+	that1, ok := that.(*ResponseVerifyVoteExtension)
+	if !ok {
+		that2, ok := that.(ResponseVerifyVoteExtension)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.DataHash) > 0 {
-		dAtA[i] = 0x4a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.DataHash)))
-		i += copy(dAtA[i:], m.DataHash)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.ValidatorsHash) > 0 {
-		dAtA[i] = 0x52
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.ValidatorsHash)))
-		i += copy(dAtA[i:], m.ValidatorsHash)
+	if this.Result != that1.Result {
+		return false
 	}
-	if len(m.NextValidatorsHash) > 0 {
-		dAtA[i] = 0x5a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.NextValidatorsHash)))
-		i += copy(dAtA[i:], m.NextValidatorsHash)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	if len(m.ConsensusHash) > 0 {
-		dAtA[i] = 0x62
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.ConsensusHash)))
-		i += copy(dAtA[i:], m.ConsensusHash)
+	return true
+}
+func (this *ResponsePrepareProposal) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if len(m.AppHash) > 0 {
-		dAtA[i] = 0x6a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.AppHash)))
-		i += copy(dAtA[i:], m.AppHash)
+
+	that1, ok := that.(*ResponsePrepareProposal)
+	if !ok {
+		that2, ok := that.(ResponsePrepareProposal)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.LastResultsHash) > 0 {
-		dAtA[i] = 0x72
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.LastResultsHash)))
-		i += copy(dAtA[i:], m.LastResultsHash)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.EvidenceHash) > 0 {
-		dAtA[i] = 0x7a
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.EvidenceHash)))
-		i += copy(dAtA[i:], m.EvidenceHash)
+	if len(this.Txs) != len(that1.Txs) {
+		return false
 	}
-	if len(m.ProposerAddress) > 0 {
-		dAtA[i] = 0x82
-		i++
-		dAtA[i] = 0x1
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.ProposerAddress)))
-		i += copy(dAtA[i:], m.ProposerAddress)
+	for i := range this.Txs {
+		if !bytes.Equal(this.Txs[i], that1.Txs[i]) {
+			return false
+		}
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *Version) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *ResponseProcessProposal) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *Version) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Block != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Block))
+	that1, ok := that.(*ResponseProcessProposal)
+	if !ok {
+		that2, ok := that.(ResponseProcessProposal)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.App != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.App))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if this.Status != that1.Status {
+		return false
 	}
-	return i, nil
-}
-
-func (m *BlockID) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return dAtA[:n], nil
+	return true
 }
+func (this *ConsensusParams) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *BlockID) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Hash) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
-		i += copy(dAtA[i:], m.Hash)
+	that1, ok := that.(*ConsensusParams)
+	if !ok {
+		that2, ok := that.(ConsensusParams)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintTypes(dAtA, i, uint64(m.PartsHeader.Size()))
-	n39, err := m.PartsHeader.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	i += n39
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if !this.Block.Equal(that1.Block) {
+		return false
 	}
-	return i, nil
-}
-
-func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if !this.Evidence.Equal(that1.Evidence) {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *PartSetHeader) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Total != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Total))
+	if !this.Validator.Equal(that1.Validator) {
+		return false
 	}
-	if len(m.Hash) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
-		i += copy(dAtA[i:], m.Hash)
+	if !this.Timeout.Equal(that1.Timeout) {
+		return false
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return i, nil
+	return true
 }
-
-func (m *Validator) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (this *BlockParams) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *Validator) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Address) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Address)))
-		i += copy(dAtA[i:], m.Address)
+	that1, ok := that.(*BlockParams)
+	if !ok {
+		that2, ok := that.(BlockParams)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.Power != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Power))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if this.MaxBytes != that1.MaxBytes {
+		return false
 	}
-	return i, nil
-}
-
-func (m *ValidatorUpdate) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if this.MaxGas != that1.MaxGas {
+		return false
 	}
-	return dAtA[:n], nil
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *EvidenceParams) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ValidatorUpdate) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintTypes(dAtA, i, uint64(m.PubKey.Size()))
-	n40, err := m.PubKey.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	that1, ok := that.(*EvidenceParams)
+	if !ok {
+		that2, ok := that.(EvidenceParams)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	i += n40
-	if m.Power != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Power))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if this.MaxAge != that1.MaxAge {
+		return false
 	}
-	return i, nil
-}
-
-func (m *VoteInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if this.MaxAgeDuration != that1.MaxAgeDuration {
+		return false
 	}
-	return dAtA[:n], nil
+	if this.MaxBytes != that1.MaxBytes {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
-
-func (m *VoteInfo) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintTypes(dAtA, i, uint64(m.Validator.Size()))
-	n41, err := m.Validator.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+func (this *ValidatorParams) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	i += n41
-	if m.SignedLastBlock {
-		dAtA[i] = 0x10
-		i++
-		if m.SignedLastBlock {
-			dAtA[i] = 1
+
+	that1, ok := that.(*ValidatorParams)
+	if !ok {
+		that2, ok := that.(ValidatorParams)
+		if ok {
+			that1 = &that2
 		} else {
-			dAtA[i] = 0
+			return false
 		}
-		i++
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return i, nil
-}
-
-func (m *PubKey) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if len(this.PubKeyTypes) != len(that1.PubKeyTypes) {
+		return false
 	}
-	return dAtA[:n], nil
+	for i := range this.PubKeyTypes {
+		if this.PubKeyTypes[i] != that1.PubKeyTypes[i] {
+			return false
+		}
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *TimeoutParams) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *PubKey) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Type) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Type)))
-		i += copy(dAtA[i:], m.Type)
+	that1, ok := that.(*TimeoutParams)
+	if !ok {
+		that2, ok := that.(TimeoutParams)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.Data) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Data)))
-		i += copy(dAtA[i:], m.Data)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if this.ProposeDelta != that1.ProposeDelta {
+		return false
 	}
-	return i, nil
-}
-
-func (m *Evidence) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if this.PrevoteDelta != that1.PrevoteDelta {
+		return false
 	}
-	return dAtA[:n], nil
+	if this.PrecommitDelta != that1.PrecommitDelta {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *LastCommitInfo) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Evidence) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Type) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Type)))
-		i += copy(dAtA[i:], m.Type)
+	that1, ok := that.(*LastCommitInfo)
+	if !ok {
+		that2, ok := that.(LastCommitInfo)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintTypes(dAtA, i, uint64(m.Validator.Size()))
-	n42, err := m.Validator.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	i += n42
-	if m.Height != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+	if this.Round != that1.Round {
+		return false
 	}
-	dAtA[i] = 0x22
-	i++
-	i = encodeVarintTypes(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)))
-	n43, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(this.Votes) != len(that1.Votes) {
+		return false
 	}
-	i += n43
-	if m.TotalVotingPower != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintTypes(dAtA, i, uint64(m.TotalVotingPower))
+	for i := range this.Votes {
+		if !this.Votes[i].Equal(&that1.Votes[i]) {
+			return false
+		}
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return i, nil
+	return true
 }
+func (this *ExtendedCommitInfo) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+	that1, ok := that.(*ExtendedCommitInfo)
+	if !ok {
+		that2, ok := that.(ExtendedCommitInfo)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
-}
-func NewPopulatedRequest(r randyTypes, easy bool) *Request {
-	this := &Request{}
-	oneofNumber_Value := []int32{2, 3, 4, 5, 6, 7, 8, 9, 11, 12, 19}[r.Intn(11)]
-	switch oneofNumber_Value {
-	case 2:
-		this.Value = NewPopulatedRequest_Echo(r, easy)
-	case 3:
-		this.Value = NewPopulatedRequest_Flush(r, easy)
-	case 4:
-		this.Value = NewPopulatedRequest_Info(r, easy)
-	case 5:
-		this.Value = NewPopulatedRequest_SetOption(r, easy)
-	case 6:
-		this.Value = NewPopulatedRequest_InitChain(r, easy)
-	case 7:
-		this.Value = NewPopulatedRequest_Query(r, easy)
-	case 8:
-		this.Value = NewPopulatedRequest_BeginBlock(r, easy)
-	case 9:
-		this.Value = NewPopulatedRequest_CheckTx(r, easy)
-	case 11:
-		this.Value = NewPopulatedRequest_EndBlock(r, easy)
-	case 12:
-		this.Value = NewPopulatedRequest_Commit(r, easy)
-	case 19:
-		this.Value = NewPopulatedRequest_DeliverTx(r, easy)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 20)
+	if this.Round != that1.Round {
+		return false
 	}
-	return this
-}
-
-func NewPopulatedRequest_Echo(r randyTypes, easy bool) *Request_Echo {
-	this := &Request_Echo{}
-	this.Echo = NewPopulatedRequestEcho(r, easy)
-	return this
-}
-func NewPopulatedRequest_Flush(r randyTypes, easy bool) *Request_Flush {
-	this := &Request_Flush{}
-	this.Flush = NewPopulatedRequestFlush(r, easy)
-	return this
-}
-func NewPopulatedRequest_Info(r randyTypes, easy bool) *Request_Info {
-	this := &Request_Info{}
-	this.Info = NewPopulatedRequestInfo(r, easy)
-	return this
-}
-func NewPopulatedRequest_SetOption(r randyTypes, easy bool) *Request_SetOption {
-	this := &Request_SetOption{}
-	this.SetOption = NewPopulatedRequestSetOption(r, easy)
-	return this
-}
-func NewPopulatedRequest_InitChain(r randyTypes, easy bool) *Request_InitChain {
-	this := &Request_InitChain{}
-	this.InitChain = NewPopulatedRequestInitChain(r, easy)
-	return this
-}
-func NewPopulatedRequest_Query(r randyTypes, easy bool) *Request_Query {
-	this := &Request_Query{}
-	this.Query = NewPopulatedRequestQuery(r, easy)
-	return this
-}
-func NewPopulatedRequest_BeginBlock(r randyTypes, easy bool) *Request_BeginBlock {
-	this := &Request_BeginBlock{}
-	this.BeginBlock = NewPopulatedRequestBeginBlock(r, easy)
-	return this
-}
-func NewPopulatedRequest_CheckTx(r randyTypes, easy bool) *Request_CheckTx {
-	this := &Request_CheckTx{}
-	this.CheckTx = NewPopulatedRequestCheckTx(r, easy)
-	return this
-}
-func NewPopulatedRequest_EndBlock(r randyTypes, easy bool) *Request_EndBlock {
-	this := &Request_EndBlock{}
-	this.EndBlock = NewPopulatedRequestEndBlock(r, easy)
-	return this
-}
-func NewPopulatedRequest_Commit(r randyTypes, easy bool) *Request_Commit {
-	this := &Request_Commit{}
-	this.Commit = NewPopulatedRequestCommit(r, easy)
-	return this
-}
-func NewPopulatedRequest_DeliverTx(r randyTypes, easy bool) *Request_DeliverTx {
-	this := &Request_DeliverTx{}
-	this.DeliverTx = NewPopulatedRequestDeliverTx(r, easy)
-	return this
-}
-func NewPopulatedRequestEcho(r randyTypes, easy bool) *RequestEcho {
-	this := &RequestEcho{}
-	this.Message = string(randStringTypes(r))
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	if len(this.Votes) != len(that1.Votes) {
+		return false
 	}
-	return this
-}
-
-func NewPopulatedRequestFlush(r randyTypes, easy bool) *RequestFlush {
-	this := &RequestFlush{}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 1)
+	for i := range this.Votes {
+		if !this.Votes[i].Equal(&that1.Votes[i]) {
+			return false
+		}
 	}
-	return this
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *Header) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func NewPopulatedRequestInfo(r randyTypes, easy bool) *RequestInfo {
-	this := &RequestInfo{}
-	this.Version = string(randStringTypes(r))
-	this.BlockVersion = uint64(uint64(r.Uint32()))
-	this.P2PVersion = uint64(uint64(r.Uint32()))
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 4)
+	that1, ok := that.(*Header)
+	if !ok {
+		that2, ok := that.(Header)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return this
-}
-
-func NewPopulatedRequestSetOption(r randyTypes, easy bool) *RequestSetOption {
-	this := &RequestSetOption{}
-	this.Key = string(randStringTypes(r))
-	this.Value = string(randStringTypes(r))
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return this
-}
-
-func NewPopulatedRequestInitChain(r randyTypes, easy bool) *RequestInitChain {
-	this := &RequestInitChain{}
-	v1 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
-	this.Time = *v1
-	this.ChainId = string(randStringTypes(r))
-	if r.Intn(10) != 0 {
-		this.ConsensusParams = NewPopulatedConsensusParams(r, easy)
+	if !this.Version.Equal(&that1.Version) {
+		return false
 	}
-	if r.Intn(10) != 0 {
-		v2 := r.Intn(5)
-		this.Validators = make([]ValidatorUpdate, v2)
-		for i := 0; i < v2; i++ {
-			v3 := NewPopulatedValidatorUpdate(r, easy)
-			this.Validators[i] = *v3
-		}
+	if this.ChainID != that1.ChainID {
+		return false
 	}
-	v4 := r.Intn(100)
-	this.AppStateBytes = make([]byte, v4)
-	for i := 0; i < v4; i++ {
-		this.AppStateBytes[i] = byte(r.Intn(256))
+	if this.Height != that1.Height {
+		return false
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 6)
+	if !this.Time.Equal(that1.Time) {
+		return false
 	}
-	return this
-}
-
-func NewPopulatedRequestQuery(r randyTypes, easy bool) *RequestQuery {
-	this := &RequestQuery{}
-	v5 := r.Intn(100)
-	this.Data = make([]byte, v5)
-	for i := 0; i < v5; i++ {
-		this.Data[i] = byte(r.Intn(256))
+	if this.NumTxs != that1.NumTxs {
+		return false
 	}
-	this.Path = string(randStringTypes(r))
-	this.Height = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.Height *= -1
+	if this.TotalTxs != that1.TotalTxs {
+		return false
 	}
-	this.Prove = bool(bool(r.Intn(2) == 0))
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 5)
+	if !this.LastBlockId.Equal(&that1.LastBlockId) {
+		return false
 	}
-	return this
-}
-
-func NewPopulatedRequestBeginBlock(r randyTypes, easy bool) *RequestBeginBlock {
-	this := &RequestBeginBlock{}
-	v6 := r.Intn(100)
-	this.Hash = make([]byte, v6)
-	for i := 0; i < v6; i++ {
-		this.Hash[i] = byte(r.Intn(256))
+	if !bytes.Equal(this.LastCommitHash, that1.LastCommitHash) {
+		return false
 	}
-	v7 := NewPopulatedHeader(r, easy)
-	this.Header = *v7
-	v8 := NewPopulatedLastCommitInfo(r, easy)
-	this.LastCommitInfo = *v8
-	if r.Intn(10) != 0 {
-		v9 := r.Intn(5)
-		this.ByzantineValidators = make([]Evidence, v9)
-		for i := 0; i < v9; i++ {
-			v10 := NewPopulatedEvidence(r, easy)
-			this.ByzantineValidators[i] = *v10
-		}
+	if !bytes.Equal(this.DataHash, that1.DataHash) {
+		return false
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 5)
+	if !bytes.Equal(this.ValidatorsHash, that1.ValidatorsHash) {
+		return false
 	}
-	return this
-}
-
-func NewPopulatedRequestCheckTx(r randyTypes, easy bool) *RequestCheckTx {
-	this := &RequestCheckTx{}
-	v11 := r.Intn(100)
-	this.Tx = make([]byte, v11)
-	for i := 0; i < v11; i++ {
-		this.Tx[i] = byte(r.Intn(256))
+	if !bytes.Equal(this.NextValidatorsHash, that1.NextValidatorsHash) {
+		return false
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	if !bytes.Equal(this.ConsensusHash, that1.ConsensusHash) {
+		return false
 	}
-	return this
-}
-
-func NewPopulatedRequestDeliverTx(r randyTypes, easy bool) *RequestDeliverTx {
-	this := &RequestDeliverTx{}
-	v12 := r.Intn(100)
-	this.Tx = make([]byte, v12)
-	for i := 0; i < v12; i++ {
-		this.Tx[i] = byte(r.Intn(256))
+	if !bytes.Equal(this.AppHash, that1.AppHash) {
+		return false
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	if !bytes.Equal(this.LastResultsHash, that1.LastResultsHash) {
+		return false
 	}
-	return this
-}
-
-func NewPopulatedRequestEndBlock(r randyTypes, easy bool) *RequestEndBlock {
-	this := &RequestEndBlock{}
-	this.Height = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.Height *= -1
+	if !bytes.Equal(this.EvidenceHash, that1.EvidenceHash) {
+		return false
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	if !bytes.Equal(this.ProposerAddress, that1.ProposerAddress) {
+		return false
 	}
-	return this
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *Version) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func NewPopulatedRequestCommit(r randyTypes, easy bool) *RequestCommit {
-	this := &RequestCommit{}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 1)
+	that1, ok := that.(*Version)
+	if !ok {
+		that2, ok := that.(Version)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return this
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Block != that1.Block {
+		return false
+	}
+	if this.App != that1.App {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *BlockID) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func NewPopulatedResponse(r randyTypes, easy bool) *Response {
-	this := &Response{}
-	oneofNumber_Value := []int32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}[r.Intn(12)]
-	switch oneofNumber_Value {
-	case 1:
-		this.Value = NewPopulatedResponse_Exception(r, easy)
-	case 2:
-		this.Value = NewPopulatedResponse_Echo(r, easy)
-	case 3:
-		this.Value = NewPopulatedResponse_Flush(r, easy)
-	case 4:
-		this.Value = NewPopulatedResponse_Info(r, easy)
-	case 5:
-		this.Value = NewPopulatedResponse_SetOption(r, easy)
-	case 6:
-		this.Value = NewPopulatedResponse_InitChain(r, easy)
-	case 7:
-		this.Value = NewPopulatedResponse_Query(r, easy)
-	case 8:
-		this.Value = NewPopulatedResponse_BeginBlock(r, easy)
-	case 9:
-		this.Value = NewPopulatedResponse_CheckTx(r, easy)
-	case 10:
-		this.Value = NewPopulatedResponse_DeliverTx(r, easy)
-	case 11:
-		this.Value = NewPopulatedResponse_EndBlock(r, easy)
-	case 12:
-		this.Value = NewPopulatedResponse_Commit(r, easy)
+	that1, ok := that.(*BlockID)
+	if !ok {
+		that2, ok := that.(BlockID)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 13)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return this
+	if !bytes.Equal(this.Hash, that1.Hash) {
+		return false
+	}
+	if !this.PartsHeader.Equal(&that1.PartsHeader) {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *PartSetHeader) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func NewPopulatedResponse_Exception(r randyTypes, easy bool) *Response_Exception {
-	this := &Response_Exception{}
-	this.Exception = NewPopulatedResponseException(r, easy)
-	return this
-}
-func NewPopulatedResponse_Echo(r randyTypes, easy bool) *Response_Echo {
-	this := &Response_Echo{}
-	this.Echo = NewPopulatedResponseEcho(r, easy)
-	return this
-}
-func NewPopulatedResponse_Flush(r randyTypes, easy bool) *Response_Flush {
-	this := &Response_Flush{}
-	this.Flush = NewPopulatedResponseFlush(r, easy)
-	return this
-}
-func NewPopulatedResponse_Info(r randyTypes, easy bool) *Response_Info {
-	this := &Response_Info{}
-	this.Info = NewPopulatedResponseInfo(r, easy)
-	return this
-}
-func NewPopulatedResponse_SetOption(r randyTypes, easy bool) *Response_SetOption {
-	this := &Response_SetOption{}
-	this.SetOption = NewPopulatedResponseSetOption(r, easy)
-	return this
-}
-func NewPopulatedResponse_InitChain(r randyTypes, easy bool) *Response_InitChain {
-	this := &Response_InitChain{}
-	this.InitChain = NewPopulatedResponseInitChain(r, easy)
-	return this
-}
-func NewPopulatedResponse_Query(r randyTypes, easy bool) *Response_Query {
-	this := &Response_Query{}
-	this.Query = NewPopulatedResponseQuery(r, easy)
-	return this
-}
-func NewPopulatedResponse_BeginBlock(r randyTypes, easy bool) *Response_BeginBlock {
-	this := &Response_BeginBlock{}
-	this.BeginBlock = NewPopulatedResponseBeginBlock(r, easy)
-	return this
-}
-func NewPopulatedResponse_CheckTx(r randyTypes, easy bool) *Response_CheckTx {
-	this := &Response_CheckTx{}
-	this.CheckTx = NewPopulatedResponseCheckTx(r, easy)
-	return this
-}
-func NewPopulatedResponse_DeliverTx(r randyTypes, easy bool) *Response_DeliverTx {
-	this := &Response_DeliverTx{}
-	this.DeliverTx = NewPopulatedResponseDeliverTx(r, easy)
-	return this
-}
-func NewPopulatedResponse_EndBlock(r randyTypes, easy bool) *Response_EndBlock {
-	this := &Response_EndBlock{}
-	this.EndBlock = NewPopulatedResponseEndBlock(r, easy)
-	return this
-}
-func NewPopulatedResponse_Commit(r randyTypes, easy bool) *Response_Commit {
-	this := &Response_Commit{}
-	this.Commit = NewPopulatedResponseCommit(r, easy)
-	return this
-}
-func NewPopulatedResponseException(r randyTypes, easy bool) *ResponseException {
-	this := &ResponseException{}
-	this.Error = string(randStringTypes(r))
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
-	}
-	return this
-}
-
-func NewPopulatedResponseEcho(r randyTypes, easy bool) *ResponseEcho {
-	this := &ResponseEcho{}
-	this.Message = string(randStringTypes(r))
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	that1, ok := that.(*PartSetHeader)
+	if !ok {
+		that2, ok := that.(PartSetHeader)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return this
-}
-
-func NewPopulatedResponseFlush(r randyTypes, easy bool) *ResponseFlush {
-	this := &ResponseFlush{}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 1)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return this
-}
-
-func NewPopulatedResponseInfo(r randyTypes, easy bool) *ResponseInfo {
-	this := &ResponseInfo{}
-	this.Data = string(randStringTypes(r))
-	this.Version = string(randStringTypes(r))
-	this.AppVersion = uint64(uint64(r.Uint32()))
-	this.LastBlockHeight = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.LastBlockHeight *= -1
+	if this.Total != that1.Total {
+		return false
 	}
-	v13 := r.Intn(100)
-	this.LastBlockAppHash = make([]byte, v13)
-	for i := 0; i < v13; i++ {
-		this.LastBlockAppHash[i] = byte(r.Intn(256))
+	if !bytes.Equal(this.Hash, that1.Hash) {
+		return false
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 6)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return this
+	return true
 }
-
-func NewPopulatedResponseSetOption(r randyTypes, easy bool) *ResponseSetOption {
-	this := &ResponseSetOption{}
-	this.Code = uint32(r.Uint32())
-	this.Log = string(randStringTypes(r))
-	this.Info = string(randStringTypes(r))
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 5)
+func (this *Validator) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return this
-}
 
-func NewPopulatedResponseInitChain(r randyTypes, easy bool) *ResponseInitChain {
-	this := &ResponseInitChain{}
-	if r.Intn(10) != 0 {
-		this.ConsensusParams = NewPopulatedConsensusParams(r, easy)
-	}
-	if r.Intn(10) != 0 {
-		v14 := r.Intn(5)
-		this.Validators = make([]ValidatorUpdate, v14)
-		for i := 0; i < v14; i++ {
-			v15 := NewPopulatedValidatorUpdate(r, easy)
-			this.Validators[i] = *v15
+	that1, ok := that.(*Validator)
+	if !ok {
+		that2, ok := that.(Validator)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
-	}
-	return this
-}
-
-func NewPopulatedResponseQuery(r randyTypes, easy bool) *ResponseQuery {
-	this := &ResponseQuery{}
-	this.Code = uint32(r.Uint32())
-	this.Log = string(randStringTypes(r))
-	this.Info = string(randStringTypes(r))
-	this.Index = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.Index *= -1
-	}
-	v16 := r.Intn(100)
-	this.Key = make([]byte, v16)
-	for i := 0; i < v16; i++ {
-		this.Key[i] = byte(r.Intn(256))
-	}
-	v17 := r.Intn(100)
-	this.Value = make([]byte, v17)
-	for i := 0; i < v17; i++ {
-		this.Value[i] = byte(r.Intn(256))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if r.Intn(10) != 0 {
-		this.Proof = merkle.NewPopulatedProof(r, easy)
+	if !bytes.Equal(this.Address, that1.Address) {
+		return false
 	}
-	this.Height = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.Height *= -1
+	if this.Power != that1.Power {
+		return false
 	}
-	this.Codespace = string(randStringTypes(r))
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 11)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return this
+	return true
 }
+func (this *ValidatorUpdate) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func NewPopulatedResponseBeginBlock(r randyTypes, easy bool) *ResponseBeginBlock {
-	this := &ResponseBeginBlock{}
-	if r.Intn(10) != 0 {
-		v18 := r.Intn(5)
-		this.Tags = make([]common.KVPair, v18)
-		for i := 0; i < v18; i++ {
-			v19 := common.NewPopulatedKVPair(r, easy)
-			this.Tags[i] = *v19
+	that1, ok := that.(*ValidatorUpdate)
+	if !ok {
+		that2, ok := that.(ValidatorUpdate)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return this
+	if !this.PubKey.Equal(&that1.PubKey) {
+		return false
+	}
+	if this.Power != that1.Power {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
+func (this *VoteInfo) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func NewPopulatedResponseCheckTx(r randyTypes, easy bool) *ResponseCheckTx {
-	this := &ResponseCheckTx{}
-	this.Code = uint32(r.Uint32())
-	v20 := r.Intn(100)
-	this.Data = make([]byte, v20)
-	for i := 0; i < v20; i++ {
-		this.Data[i] = byte(r.Intn(256))
+	that1, ok := that.(*VoteInfo)
+	if !ok {
+		that2, ok := that.(VoteInfo)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	this.Log = string(randStringTypes(r))
-	this.Info = string(randStringTypes(r))
-	this.GasWanted = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.GasWanted *= -1
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	this.GasUsed = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.GasUsed *= -1
+	if !this.Validator.Equal(&that1.Validator) {
+		return false
 	}
-	if r.Intn(10) != 0 {
-		v21 := r.Intn(5)
-		this.Tags = make([]common.KVPair, v21)
-		for i := 0; i < v21; i++ {
-			v22 := common.NewPopulatedKVPair(r, easy)
-			this.Tags[i] = *v22
-		}
+	if this.SignedLastBlock != that1.SignedLastBlock {
+		return false
 	}
-	this.Codespace = string(randStringTypes(r))
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 9)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return this
+	return true
 }
+func (this *ExtendedVoteInfo) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func NewPopulatedResponseDeliverTx(r randyTypes, easy bool) *ResponseDeliverTx {
-	this := &ResponseDeliverTx{}
-	this.Code = uint32(r.Uint32())
-	v23 := r.Intn(100)
-	this.Data = make([]byte, v23)
-	for i := 0; i < v23; i++ {
-		this.Data[i] = byte(r.Intn(256))
-	}
-	this.Log = string(randStringTypes(r))
-	this.Info = string(randStringTypes(r))
-	this.GasWanted = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.GasWanted *= -1
-	}
-	this.GasUsed = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.GasUsed *= -1
-	}
-	if r.Intn(10) != 0 {
-		v24 := r.Intn(5)
-		this.Tags = make([]common.KVPair, v24)
-		for i := 0; i < v24; i++ {
-			v25 := common.NewPopulatedKVPair(r, easy)
-			this.Tags[i] = *v25
+	that1, ok := that.(*ExtendedVoteInfo)
+	if !ok {
+		that2, ok := that.(ExtendedVoteInfo)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	this.Codespace = string(randStringTypes(r))
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 9)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return this
-}
-
-func NewPopulatedResponseEndBlock(r randyTypes, easy bool) *ResponseEndBlock {
-	this := &ResponseEndBlock{}
-	if r.Intn(10) != 0 {
-		v26 := r.Intn(5)
-		this.ValidatorUpdates = make([]ValidatorUpdate, v26)
-		for i := 0; i < v26; i++ {
-			v27 := NewPopulatedValidatorUpdate(r, easy)
-			this.ValidatorUpdates[i] = *v27
-		}
+	if !this.Validator.Equal(&that1.Validator) {
+		return false
 	}
-	if r.Intn(10) != 0 {
-		this.ConsensusParamUpdates = NewPopulatedConsensusParams(r, easy)
+	if this.SignedLastBlock != that1.SignedLastBlock {
+		return false
 	}
-	if r.Intn(10) != 0 {
-		v28 := r.Intn(5)
-		this.Tags = make([]common.KVPair, v28)
-		for i := 0; i < v28; i++ {
-			v29 := common.NewPopulatedKVPair(r, easy)
-			this.Tags[i] = *v29
-		}
+	if !bytes.Equal(this.VoteExtension, that1.VoteExtension) {
+		return false
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 4)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return this
+	return true
 }
-
-func NewPopulatedResponseCommit(r randyTypes, easy bool) *ResponseCommit {
-	this := &ResponseCommit{}
-	v30 := r.Intn(100)
-	this.Data = make([]byte, v30)
-	for i := 0; i < v30; i++ {
-		this.Data[i] = byte(r.Intn(256))
-	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+func (this *PubKey) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return this
-}
 
-func NewPopulatedConsensusParams(r randyTypes, easy bool) *ConsensusParams {
-	this := &ConsensusParams{}
-	if r.Intn(10) != 0 {
-		this.Block = NewPopulatedBlockParams(r, easy)
-	}
-	if r.Intn(10) != 0 {
-		this.Evidence = NewPopulatedEvidenceParams(r, easy)
-	}
-	if r.Intn(10) != 0 {
-		this.Validator = NewPopulatedValidatorParams(r, easy)
+	that1, ok := that.(*PubKey)
+	if !ok {
+		that2, ok := that.(PubKey)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 4)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return this
-}
-
-func NewPopulatedBlockParams(r randyTypes, easy bool) *BlockParams {
-	this := &BlockParams{}
-	this.MaxBytes = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.MaxBytes *= -1
+	if this.Type != that1.Type {
+		return false
 	}
-	this.MaxGas = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.MaxGas *= -1
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return this
+	return true
 }
-
-func NewPopulatedEvidenceParams(r randyTypes, easy bool) *EvidenceParams {
-	this := &EvidenceParams{}
-	this.MaxAge = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.MaxAge *= -1
-	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+func (this *Evidence) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return this
-}
 
-func NewPopulatedValidatorParams(r randyTypes, easy bool) *ValidatorParams {
-	this := &ValidatorParams{}
-	v31 := r.Intn(10)
-	this.PubKeyTypes = make([]string, v31)
-	for i := 0; i < v31; i++ {
-		this.PubKeyTypes[i] = string(randStringTypes(r))
-	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	that1, ok := that.(*Evidence)
+	if !ok {
+		that2, ok := that.(Evidence)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return this
-}
-
-func NewPopulatedLastCommitInfo(r randyTypes, easy bool) *LastCommitInfo {
-	this := &LastCommitInfo{}
-	this.Round = int32(r.Int31())
-	if r.Intn(2) == 0 {
-		this.Round *= -1
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if r.Intn(10) != 0 {
-		v32 := r.Intn(5)
-		this.Votes = make([]VoteInfo, v32)
-		for i := 0; i < v32; i++ {
-			v33 := NewPopulatedVoteInfo(r, easy)
-			this.Votes[i] = *v33
-		}
+	if this.Type != that1.Type {
+		return false
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	if !this.Validator.Equal(&that1.Validator) {
+		return false
 	}
-	return this
-}
-
-func NewPopulatedHeader(r randyTypes, easy bool) *Header {
-	this := &Header{}
-	v34 := NewPopulatedVersion(r, easy)
-	this.Version = *v34
-	this.ChainID = string(randStringTypes(r))
-	this.Height = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.Height *= -1
+	if this.Height != that1.Height {
+		return false
 	}
-	v35 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
-	this.Time = *v35
-	this.NumTxs = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.NumTxs *= -1
+	if !this.Time.Equal(that1.Time) {
+		return false
 	}
-	this.TotalTxs = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.TotalTxs *= -1
+	if this.TotalVotingPower != that1.TotalVotingPower {
+		return false
 	}
-	v36 := NewPopulatedBlockID(r, easy)
-	this.LastBlockId = *v36
-	v37 := r.Intn(100)
-	this.LastCommitHash = make([]byte, v37)
-	for i := 0; i < v37; i++ {
-		this.LastCommitHash[i] = byte(r.Intn(256))
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	v38 := r.Intn(100)
-	this.DataHash = make([]byte, v38)
-	for i := 0; i < v38; i++ {
-		this.DataHash[i] = byte(r.Intn(256))
+	return true
+}
+func (this *Snapshot) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	v39 := r.Intn(100)
-	this.ValidatorsHash = make([]byte, v39)
-	for i := 0; i < v39; i++ {
-		this.ValidatorsHash[i] = byte(r.Intn(256))
+
+	that1, ok := that.(*Snapshot)
+	if !ok {
+		that2, ok := that.(Snapshot)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	v40 := r.Intn(100)
-	this.NextValidatorsHash = make([]byte, v40)
-	for i := 0; i < v40; i++ {
-		this.NextValidatorsHash[i] = byte(r.Intn(256))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	v41 := r.Intn(100)
-	this.ConsensusHash = make([]byte, v41)
-	for i := 0; i < v41; i++ {
-		this.ConsensusHash[i] = byte(r.Intn(256))
+	if this.Height != that1.Height {
+		return false
 	}
-	v42 := r.Intn(100)
-	this.AppHash = make([]byte, v42)
-	for i := 0; i < v42; i++ {
-		this.AppHash[i] = byte(r.Intn(256))
+	if this.Format != that1.Format {
+		return false
 	}
-	v43 := r.Intn(100)
-	this.LastResultsHash = make([]byte, v43)
-	for i := 0; i < v43; i++ {
-		this.LastResultsHash[i] = byte(r.Intn(256))
+	if this.Chunks != that1.Chunks {
+		return false
 	}
-	v44 := r.Intn(100)
-	this.EvidenceHash = make([]byte, v44)
-	for i := 0; i < v44; i++ {
-		this.EvidenceHash[i] = byte(r.Intn(256))
+	if !bytes.Equal(this.Hash, that1.Hash) {
+		return false
 	}
-	v45 := r.Intn(100)
-	this.ProposerAddress = make([]byte, v45)
-	for i := 0; i < v45; i++ {
-		this.ProposerAddress[i] = byte(r.Intn(256))
+	if !bytes.Equal(this.Metadata, that1.Metadata) {
+		return false
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 17)
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
 	}
-	return this
+	return true
 }
 
-func NewPopulatedVersion(r randyTypes, easy bool) *Version {
-	this := &Version{}
-	this.Block = uint64(uint64(r.Uint32()))
-	this.App = uint64(uint64(r.Uint32()))
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
-	}
-	return this
-}
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
 
-func NewPopulatedBlockID(r randyTypes, easy bool) *BlockID {
-	this := &BlockID{}
-	v46 := r.Intn(100)
-	this.Hash = make([]byte, v46)
-	for i := 0; i < v46; i++ {
-		this.Hash[i] = byte(r.Intn(256))
-	}
-	v47 := NewPopulatedPartSetHeader(r, easy)
-	this.PartsHeader = *v47
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
-	}
-	return this
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ABCIApplicationClient is the client API for ABCIApplication service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ABCIApplicationClient interface {
+	Echo(ctx context.Context, in *RequestEcho, opts ...grpc.CallOption) (*ResponseEcho, error)
+	Flush(ctx context.Context, in *RequestFlush, opts ...grpc.CallOption) (*ResponseFlush, error)
+	Info(ctx context.Context, in *RequestInfo, opts ...grpc.CallOption) (*ResponseInfo, error)
+	SetOption(ctx context.Context, in *RequestSetOption, opts ...grpc.CallOption) (*ResponseSetOption, error)
+	DeliverTx(ctx context.Context, in *RequestDeliverTx, opts ...grpc.CallOption) (*ResponseDeliverTx, error)
+	// DeliverTxStream delivers the txs in order, as they're received, and
+	// streams back the responses in the same order.
+	DeliverTxStream(ctx context.Context, opts ...grpc.CallOption) (ABCIApplication_DeliverTxStreamClient, error)
+	CheckTx(ctx context.Context, in *RequestCheckTx, opts ...grpc.CallOption) (*ResponseCheckTx, error)
+	Query(ctx context.Context, in *RequestQuery, opts ...grpc.CallOption) (*ResponseQuery, error)
+	Commit(ctx context.Context, in *RequestCommit, opts ...grpc.CallOption) (*ResponseCommit, error)
+	InitChain(ctx context.Context, in *RequestInitChain, opts ...grpc.CallOption) (*ResponseInitChain, error)
+	BeginBlock(ctx context.Context, in *RequestBeginBlock, opts ...grpc.CallOption) (*ResponseBeginBlock, error)
+	EndBlock(ctx context.Context, in *RequestEndBlock, opts ...grpc.CallOption) (*ResponseEndBlock, error)
+	ListSnapshots(ctx context.Context, in *RequestListSnapshots, opts ...grpc.CallOption) (*ResponseListSnapshots, error)
+	OfferSnapshot(ctx context.Context, in *RequestOfferSnapshot, opts ...grpc.CallOption) (*ResponseOfferSnapshot, error)
+	LoadSnapshotChunk(ctx context.Context, in *RequestLoadSnapshotChunk, opts ...grpc.CallOption) (*ResponseLoadSnapshotChunk, error)
+	ApplySnapshotChunk(ctx context.Context, in *RequestApplySnapshotChunk, opts ...grpc.CallOption) (*ResponseApplySnapshotChunk, error)
+	ExtendVote(ctx context.Context, in *RequestExtendVote, opts ...grpc.CallOption) (*ResponseExtendVote, error)
+	VerifyVoteExtension(ctx context.Context, in *RequestVerifyVoteExtension, opts ...grpc.CallOption) (*ResponseVerifyVoteExtension, error)
+	PrepareProposal(ctx context.Context, in *RequestPrepareProposal, opts ...grpc.CallOption) (*ResponsePrepareProposal, error)
+	ProcessProposal(ctx context.Context, in *RequestProcessProposal, opts ...grpc.CallOption) (*ResponseProcessProposal, error)
 }
 
-func NewPopulatedPartSetHeader(r randyTypes, easy bool) *PartSetHeader {
-	this := &PartSetHeader{}
-	this.Total = int32(r.Int31())
-	if r.Intn(2) == 0 {
-		this.Total *= -1
-	}
-	v48 := r.Intn(100)
-	this.Hash = make([]byte, v48)
-	for i := 0; i < v48; i++ {
-		this.Hash[i] = byte(r.Intn(256))
-	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
-	}
-	return this
+type aBCIApplicationClient struct {
+	cc *grpc.ClientConn
 }
 
-func NewPopulatedValidator(r randyTypes, easy bool) *Validator {
-	this := &Validator{}
-	v49 := r.Intn(100)
-	this.Address = make([]byte, v49)
-	for i := 0; i < v49; i++ {
-		this.Address[i] = byte(r.Intn(256))
-	}
-	this.Power = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.Power *= -1
-	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 4)
-	}
-	return this
+func NewABCIApplicationClient(cc *grpc.ClientConn) ABCIApplicationClient {
+	return &aBCIApplicationClient{cc}
 }
 
-func NewPopulatedValidatorUpdate(r randyTypes, easy bool) *ValidatorUpdate {
-	this := &ValidatorUpdate{}
-	v50 := NewPopulatedPubKey(r, easy)
-	this.PubKey = *v50
-	this.Power = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.Power *= -1
-	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+func (c *aBCIApplicationClient) Echo(ctx context.Context, in *RequestEcho, opts ...grpc.CallOption) (*ResponseEcho, error) {
+	out := new(ResponseEcho)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/Echo", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return out, nil
 }
 
-func NewPopulatedVoteInfo(r randyTypes, easy bool) *VoteInfo {
-	this := &VoteInfo{}
-	v51 := NewPopulatedValidator(r, easy)
-	this.Validator = *v51
-	this.SignedLastBlock = bool(bool(r.Intn(2) == 0))
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+func (c *aBCIApplicationClient) Flush(ctx context.Context, in *RequestFlush, opts ...grpc.CallOption) (*ResponseFlush, error) {
+	out := new(ResponseFlush)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/Flush", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return out, nil
 }
 
-func NewPopulatedPubKey(r randyTypes, easy bool) *PubKey {
-	this := &PubKey{}
-	this.Type = string(randStringTypes(r))
-	v52 := r.Intn(100)
-	this.Data = make([]byte, v52)
-	for i := 0; i < v52; i++ {
-		this.Data[i] = byte(r.Intn(256))
-	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+func (c *aBCIApplicationClient) Info(ctx context.Context, in *RequestInfo, opts ...grpc.CallOption) (*ResponseInfo, error) {
+	out := new(ResponseInfo)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/Info", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return out, nil
 }
 
-func NewPopulatedEvidence(r randyTypes, easy bool) *Evidence {
-	this := &Evidence{}
-	this.Type = string(randStringTypes(r))
-	v53 := NewPopulatedValidator(r, easy)
-	this.Validator = *v53
-	this.Height = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.Height *= -1
+func (c *aBCIApplicationClient) SetOption(ctx context.Context, in *RequestSetOption, opts ...grpc.CallOption) (*ResponseSetOption, error) {
+	out := new(ResponseSetOption)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/SetOption", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	v54 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
-	this.Time = *v54
-	this.TotalVotingPower = int64(r.Int63())
-	if r.Intn(2) == 0 {
-		this.TotalVotingPower *= -1
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) DeliverTx(ctx context.Context, in *RequestDeliverTx, opts ...grpc.CallOption) (*ResponseDeliverTx, error) {
+	out := new(ResponseDeliverTx)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/DeliverTx", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 6)
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) DeliverTxStream(ctx context.Context, opts ...grpc.CallOption) (ABCIApplication_DeliverTxStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ABCIApplication_serviceDesc.Streams[0], "/types.ABCIApplication/DeliverTxStream", opts...)
+	if err != nil {
+		return nil, err
 	}
-	return this
+	x := &aBCIApplicationDeliverTxStreamClient{stream}
+	return x, nil
 }
 
-type randyTypes interface {
-	Float32() float32
-	Float64() float64
-	Int63() int64
-	Int31() int32
-	Uint32() uint32
-	Intn(n int) int
+type ABCIApplication_DeliverTxStreamClient interface {
+	Send(*RequestDeliverTx) error
+	Recv() (*ResponseDeliverTx, error)
+	grpc.ClientStream
 }
 
-func randUTF8RuneTypes(r randyTypes) rune {
-	ru := r.Intn(62)
-	if ru < 10 {
-		return rune(ru + 48)
-	} else if ru < 36 {
-		return rune(ru + 55)
+type aBCIApplicationDeliverTxStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *aBCIApplicationDeliverTxStreamClient) Send(m *RequestDeliverTx) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *aBCIApplicationDeliverTxStreamClient) Recv() (*ResponseDeliverTx, error) {
+	m := new(ResponseDeliverTx)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return rune(ru + 61)
+	return m, nil
 }
-func randStringTypes(r randyTypes) string {
-	v55 := r.Intn(100)
-	tmps := make([]rune, v55)
-	for i := 0; i < v55; i++ {
-		tmps[i] = randUTF8RuneTypes(r)
+
+func (c *aBCIApplicationClient) CheckTx(ctx context.Context, in *RequestCheckTx, opts ...grpc.CallOption) (*ResponseCheckTx, error) {
+	out := new(ResponseCheckTx)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/CheckTx", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return string(tmps)
+	return out, nil
 }
-func randUnrecognizedTypes(r randyTypes, maxFieldNumber int) (dAtA []byte) {
-	l := r.Intn(5)
-	for i := 0; i < l; i++ {
-		wire := r.Intn(4)
-		if wire == 3 {
-			wire = 5
-		}
-		fieldNumber := maxFieldNumber + r.Intn(100)
-		dAtA = randFieldTypes(dAtA, r, fieldNumber, wire)
+
+func (c *aBCIApplicationClient) Query(ctx context.Context, in *RequestQuery, opts ...grpc.CallOption) (*ResponseQuery, error) {
+	out := new(ResponseQuery)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/Query", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return dAtA
+	return out, nil
 }
-func randFieldTypes(dAtA []byte, r randyTypes, fieldNumber int, wire int) []byte {
-	key := uint32(fieldNumber)<<3 | uint32(wire)
-	switch wire {
-	case 0:
-		dAtA = encodeVarintPopulateTypes(dAtA, uint64(key))
-		v56 := r.Int63()
-		if r.Intn(2) == 0 {
-			v56 *= -1
-		}
-		dAtA = encodeVarintPopulateTypes(dAtA, uint64(v56))
-	case 1:
-		dAtA = encodeVarintPopulateTypes(dAtA, uint64(key))
-		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
-	case 2:
-		dAtA = encodeVarintPopulateTypes(dAtA, uint64(key))
-		ll := r.Intn(100)
-		dAtA = encodeVarintPopulateTypes(dAtA, uint64(ll))
-		for j := 0; j < ll; j++ {
-			dAtA = append(dAtA, byte(r.Intn(256)))
-		}
-	default:
-		dAtA = encodeVarintPopulateTypes(dAtA, uint64(key))
-		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+
+func (c *aBCIApplicationClient) Commit(ctx context.Context, in *RequestCommit, opts ...grpc.CallOption) (*ResponseCommit, error) {
+	out := new(ResponseCommit)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/Commit", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return dAtA
+	return out, nil
 }
-func encodeVarintPopulateTypes(dAtA []byte, v uint64) []byte {
-	for v >= 1<<7 {
-		dAtA = append(dAtA, uint8(uint64(v)&0x7f|0x80))
-		v >>= 7
+
+func (c *aBCIApplicationClient) InitChain(ctx context.Context, in *RequestInitChain, opts ...grpc.CallOption) (*ResponseInitChain, error) {
+	out := new(ResponseInitChain)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/InitChain", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	dAtA = append(dAtA, uint8(v))
-	return dAtA
+	return out, nil
 }
-func (m *Request) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (c *aBCIApplicationClient) BeginBlock(ctx context.Context, in *RequestBeginBlock, opts ...grpc.CallOption) (*ResponseBeginBlock, error) {
+	out := new(ResponseBeginBlock)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/BeginBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Value != nil {
-		n += m.Value.Size()
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) EndBlock(ctx context.Context, in *RequestEndBlock, opts ...grpc.CallOption) (*ResponseEndBlock, error) {
+	out := new(ResponseEndBlock)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/EndBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) ListSnapshots(ctx context.Context, in *RequestListSnapshots, opts ...grpc.CallOption) (*ResponseListSnapshots, error) {
+	out := new(ResponseListSnapshots)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/ListSnapshots", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func (m *Request_Echo) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *aBCIApplicationClient) OfferSnapshot(ctx context.Context, in *RequestOfferSnapshot, opts ...grpc.CallOption) (*ResponseOfferSnapshot, error) {
+	out := new(ResponseOfferSnapshot)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/OfferSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Echo != nil {
-		l = m.Echo.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) LoadSnapshotChunk(ctx context.Context, in *RequestLoadSnapshotChunk, opts ...grpc.CallOption) (*ResponseLoadSnapshotChunk, error) {
+	out := new(ResponseLoadSnapshotChunk)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/LoadSnapshotChunk", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
-func (m *Request_Flush) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (c *aBCIApplicationClient) ApplySnapshotChunk(ctx context.Context, in *RequestApplySnapshotChunk, opts ...grpc.CallOption) (*ResponseApplySnapshotChunk, error) {
+	out := new(ResponseApplySnapshotChunk)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/ApplySnapshotChunk", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Flush != nil {
-		l = m.Flush.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	return out, nil
+}
+
+func (c *aBCIApplicationClient) ExtendVote(ctx context.Context, in *RequestExtendVote, opts ...grpc.CallOption) (*ResponseExtendVote, error) {
+	out := new(ResponseExtendVote)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/ExtendVote", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
-func (m *Request_Info) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Info != nil {
-		l = m.Info.Size()
-		n += 1 + l + sovTypes(uint64(l))
+
+func (c *aBCIApplicationClient) VerifyVoteExtension(ctx context.Context, in *RequestVerifyVoteExtension, opts ...grpc.CallOption) (*ResponseVerifyVoteExtension, error) {
+	out := new(ResponseVerifyVoteExtension)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/VerifyVoteExtension", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
-func (m *Request_SetOption) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.SetOption != nil {
-		l = m.SetOption.Size()
-		n += 1 + l + sovTypes(uint64(l))
+
+func (c *aBCIApplicationClient) PrepareProposal(ctx context.Context, in *RequestPrepareProposal, opts ...grpc.CallOption) (*ResponsePrepareProposal, error) {
+	out := new(ResponsePrepareProposal)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/PrepareProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
-func (m *Request_InitChain) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.InitChain != nil {
-		l = m.InitChain.Size()
-		n += 1 + l + sovTypes(uint64(l))
+
+func (c *aBCIApplicationClient) ProcessProposal(ctx context.Context, in *RequestProcessProposal, opts ...grpc.CallOption) (*ResponseProcessProposal, error) {
+	out := new(ResponseProcessProposal)
+	err := c.cc.Invoke(ctx, "/types.ABCIApplication/ProcessProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
-func (m *Request_Query) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Query != nil {
-		l = m.Query.Size()
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	return n
+
+// ABCIApplicationServer is the server API for ABCIApplication service.
+type ABCIApplicationServer interface {
+	Echo(context.Context, *RequestEcho) (*ResponseEcho, error)
+	Flush(context.Context, *RequestFlush) (*ResponseFlush, error)
+	Info(context.Context, *RequestInfo) (*ResponseInfo, error)
+	SetOption(context.Context, *RequestSetOption) (*ResponseSetOption, error)
+	DeliverTx(context.Context, *RequestDeliverTx) (*ResponseDeliverTx, error)
+	// DeliverTxStream delivers the txs in order, as they're received, and
+	// streams back the responses in the same order.
+	DeliverTxStream(ABCIApplication_DeliverTxStreamServer) error
+	CheckTx(context.Context, *RequestCheckTx) (*ResponseCheckTx, error)
+	Query(context.Context, *RequestQuery) (*ResponseQuery, error)
+	Commit(context.Context, *RequestCommit) (*ResponseCommit, error)
+	InitChain(context.Context, *RequestInitChain) (*ResponseInitChain, error)
+	BeginBlock(context.Context, *RequestBeginBlock) (*ResponseBeginBlock, error)
+	EndBlock(context.Context, *RequestEndBlock) (*ResponseEndBlock, error)
+	ListSnapshots(context.Context, *RequestListSnapshots) (*ResponseListSnapshots, error)
+	OfferSnapshot(context.Context, *RequestOfferSnapshot) (*ResponseOfferSnapshot, error)
+	LoadSnapshotChunk(context.Context, *RequestLoadSnapshotChunk) (*ResponseLoadSnapshotChunk, error)
+	ApplySnapshotChunk(context.Context, *RequestApplySnapshotChunk) (*ResponseApplySnapshotChunk, error)
+	ExtendVote(context.Context, *RequestExtendVote) (*ResponseExtendVote, error)
+	VerifyVoteExtension(context.Context, *RequestVerifyVoteExtension) (*ResponseVerifyVoteExtension, error)
+	PrepareProposal(context.Context, *RequestPrepareProposal) (*ResponsePrepareProposal, error)
+	ProcessProposal(context.Context, *RequestProcessProposal) (*ResponseProcessProposal, error)
 }
-func (m *Request_BeginBlock) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.BeginBlock != nil {
-		l = m.BeginBlock.Size()
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	return n
+
+func RegisterABCIApplicationServer(s *grpc.Server, srv ABCIApplicationServer) {
+	s.RegisterService(&_ABCIApplication_serviceDesc, srv)
 }
-func (m *Request_CheckTx) Size() (n int) {
-	if m == nil {
-		return 0
+
+func _ABCIApplication_Echo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEcho)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.CheckTx != nil {
-		l = m.CheckTx.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).Echo(ctx, in)
 	}
-	return n
-}
-func (m *Request_EndBlock) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/Echo",
 	}
-	var l int
-	_ = l
-	if m.EndBlock != nil {
-		l = m.EndBlock.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).Echo(ctx, req.(*RequestEcho))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
-func (m *Request_Commit) Size() (n int) {
-	if m == nil {
-		return 0
+
+func _ABCIApplication_Flush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestFlush)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Commit != nil {
-		l = m.Commit.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).Flush(ctx, in)
 	}
-	return n
-}
-func (m *Request_DeliverTx) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/Flush",
 	}
-	var l int
-	_ = l
-	if m.DeliverTx != nil {
-		l = m.DeliverTx.Size()
-		n += 2 + l + sovTypes(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).Flush(ctx, req.(*RequestFlush))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
-func (m *RequestEcho) Size() (n int) {
-	if m == nil {
-		return 0
+
+func _ABCIApplication_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestInfo)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).Info(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/Info",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).Info(ctx, req.(*RequestInfo))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *RequestFlush) Size() (n int) {
-	if m == nil {
-		return 0
+func _ABCIApplication_SetOption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestSetOption)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).SetOption(ctx, in)
 	}
-	return n
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/SetOption",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).SetOption(ctx, req.(*RequestSetOption))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *RequestInfo) Size() (n int) {
-	if m == nil {
-		return 0
+func _ABCIApplication_DeliverTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestDeliverTx)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Version)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).DeliverTx(ctx, in)
 	}
-	if m.BlockVersion != 0 {
-		n += 1 + sovTypes(uint64(m.BlockVersion))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/DeliverTx",
 	}
-	if m.P2PVersion != 0 {
-		n += 1 + sovTypes(uint64(m.P2PVersion))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).DeliverTx(ctx, req.(*RequestDeliverTx))
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ABCIApplication_DeliverTxStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ABCIApplicationServer).DeliverTxStream(&aBCIApplicationDeliverTxStreamServer{stream})
+}
+
+type ABCIApplication_DeliverTxStreamServer interface {
+	Send(*ResponseDeliverTx) error
+	Recv() (*RequestDeliverTx, error)
+	grpc.ServerStream
+}
+
+type aBCIApplicationDeliverTxStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *aBCIApplicationDeliverTxStreamServer) Send(m *ResponseDeliverTx) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *aBCIApplicationDeliverTxStreamServer) Recv() (*RequestDeliverTx, error) {
+	m := new(RequestDeliverTx)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return n
+	return m, nil
 }
 
-func (m *RequestSetOption) Size() (n int) {
-	if m == nil {
-		return 0
+func _ABCIApplication_CheckTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestCheckTx)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).CheckTx(ctx, in)
 	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/CheckTx",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).CheckTx(ctx, req.(*RequestCheckTx))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *RequestInitChain) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)
-	n += 1 + l + sovTypes(uint64(l))
-	l = len(m.ChainId)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	if m.ConsensusParams != nil {
-		l = m.ConsensusParams.Size()
-		n += 1 + l + sovTypes(uint64(l))
+func _ABCIApplication_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestQuery)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.Validators) > 0 {
-		for _, e := range m.Validators {
-			l = e.Size()
-			n += 1 + l + sovTypes(uint64(l))
-		}
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).Query(ctx, in)
 	}
-	l = len(m.AppStateBytes)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/Query",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).Query(ctx, req.(*RequestQuery))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *RequestQuery) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = len(m.Path)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+func _ABCIApplication_Commit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestCommit)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.Height != 0 {
-		n += 1 + sovTypes(uint64(m.Height))
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).Commit(ctx, in)
 	}
-	if m.Prove {
-		n += 2
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/Commit",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).Commit(ctx, req.(*RequestCommit))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *RequestBeginBlock) Size() (n int) {
-	if m == nil {
-		return 0
+func _ABCIApplication_InitChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestInitChain)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).InitChain(ctx, in)
 	}
-	l = m.Header.Size()
-	n += 1 + l + sovTypes(uint64(l))
-	l = m.LastCommitInfo.Size()
-	n += 1 + l + sovTypes(uint64(l))
-	if len(m.ByzantineValidators) > 0 {
-		for _, e := range m.ByzantineValidators {
-			l = e.Size()
-			n += 1 + l + sovTypes(uint64(l))
-		}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/InitChain",
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).InitChain(ctx, req.(*RequestInitChain))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *RequestCheckTx) Size() (n int) {
-	if m == nil {
-		return 0
+func _ABCIApplication_BeginBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestBeginBlock)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Tx)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).BeginBlock(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/BeginBlock",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).BeginBlock(ctx, req.(*RequestBeginBlock))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *RequestDeliverTx) Size() (n int) {
-	if m == nil {
-		return 0
+func _ABCIApplication_EndBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEndBlock)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Tx)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).EndBlock(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/EndBlock",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).EndBlock(ctx, req.(*RequestEndBlock))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *RequestEndBlock) Size() (n int) {
-	if m == nil {
-		return 0
+func _ABCIApplication_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestListSnapshots)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Height != 0 {
-		n += 1 + sovTypes(uint64(m.Height))
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).ListSnapshots(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/ListSnapshots",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).ListSnapshots(ctx, req.(*RequestListSnapshots))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *RequestCommit) Size() (n int) {
-	if m == nil {
-		return 0
+func _ABCIApplication_OfferSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestOfferSnapshot)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).OfferSnapshot(ctx, in)
 	}
-	return n
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/OfferSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).OfferSnapshot(ctx, req.(*RequestOfferSnapshot))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *Response) Size() (n int) {
-	if m == nil {
-		return 0
+func _ABCIApplication_LoadSnapshotChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestLoadSnapshotChunk)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Value != nil {
-		n += m.Value.Size()
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).LoadSnapshotChunk(ctx, in)
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/LoadSnapshotChunk",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).LoadSnapshotChunk(ctx, req.(*RequestLoadSnapshotChunk))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *Response_Exception) Size() (n int) {
-	if m == nil {
-		return 0
+func _ABCIApplication_ApplySnapshotChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestApplySnapshotChunk)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Exception != nil {
-		l = m.Exception.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).ApplySnapshotChunk(ctx, in)
 	}
-	return n
-}
-func (m *Response_Echo) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/ApplySnapshotChunk",
 	}
-	var l int
-	_ = l
-	if m.Echo != nil {
-		l = m.Echo.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).ApplySnapshotChunk(ctx, req.(*RequestApplySnapshotChunk))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
-func (m *Response_Flush) Size() (n int) {
-	if m == nil {
-		return 0
+
+func _ABCIApplication_ExtendVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestExtendVote)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.Flush != nil {
-		l = m.Flush.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).ExtendVote(ctx, in)
 	}
-	return n
-}
-func (m *Response_Info) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/ExtendVote",
 	}
-	var l int
-	_ = l
-	if m.Info != nil {
-		l = m.Info.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).ExtendVote(ctx, req.(*RequestExtendVote))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
-func (m *Response_SetOption) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.SetOption != nil {
-		l = m.SetOption.Size()
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	return n
-}
-func (m *Response_InitChain) Size() (n int) {
-	if m == nil {
-		return 0
+
+func _ABCIApplication_VerifyVoteExtension_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestVerifyVoteExtension)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.InitChain != nil {
-		l = m.InitChain.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).VerifyVoteExtension(ctx, in)
 	}
-	return n
-}
-func (m *Response_Query) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/VerifyVoteExtension",
 	}
-	var l int
-	_ = l
-	if m.Query != nil {
-		l = m.Query.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).VerifyVoteExtension(ctx, req.(*RequestVerifyVoteExtension))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
-func (m *Response_BeginBlock) Size() (n int) {
-	if m == nil {
-		return 0
+
+func _ABCIApplication_PrepareProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPrepareProposal)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.BeginBlock != nil {
-		l = m.BeginBlock.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).PrepareProposal(ctx, in)
 	}
-	return n
-}
-func (m *Response_CheckTx) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/PrepareProposal",
 	}
-	var l int
-	_ = l
-	if m.CheckTx != nil {
-		l = m.CheckTx.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).PrepareProposal(ctx, req.(*RequestPrepareProposal))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
-func (m *Response_DeliverTx) Size() (n int) {
-	if m == nil {
-		return 0
+
+func _ABCIApplication_ProcessProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestProcessProposal)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.DeliverTx != nil {
-		l = m.DeliverTx.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if interceptor == nil {
+		return srv.(ABCIApplicationServer).ProcessProposal(ctx, in)
 	}
-	return n
-}
-func (m *Response_EndBlock) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.ABCIApplication/ProcessProposal",
 	}
-	var l int
-	_ = l
-	if m.EndBlock != nil {
-		l = m.EndBlock.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ABCIApplicationServer).ProcessProposal(ctx, req.(*RequestProcessProposal))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
-func (m *Response_Commit) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Commit != nil {
-		l = m.Commit.Size()
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	return n
+
+var _ABCIApplication_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.ABCIApplication",
+	HandlerType: (*ABCIApplicationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Echo",
+			Handler:    _ABCIApplication_Echo_Handler,
+		},
+		{
+			MethodName: "Flush",
+			Handler:    _ABCIApplication_Flush_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _ABCIApplication_Info_Handler,
+		},
+		{
+			MethodName: "SetOption",
+			Handler:    _ABCIApplication_SetOption_Handler,
+		},
+		{
+			MethodName: "DeliverTx",
+			Handler:    _ABCIApplication_DeliverTx_Handler,
+		},
+		{
+			MethodName: "CheckTx",
+			Handler:    _ABCIApplication_CheckTx_Handler,
+		},
+		{
+			MethodName: "Query",
+			Handler:    _ABCIApplication_Query_Handler,
+		},
+		{
+			MethodName: "Commit",
+			Handler:    _ABCIApplication_Commit_Handler,
+		},
+		{
+			MethodName: "InitChain",
+			Handler:    _ABCIApplication_InitChain_Handler,
+		},
+		{
+			MethodName: "BeginBlock",
+			Handler:    _ABCIApplication_BeginBlock_Handler,
+		},
+		{
+			MethodName: "EndBlock",
+			Handler:    _ABCIApplication_EndBlock_Handler,
+		},
+		{
+			MethodName: "ListSnapshots",
+			Handler:    _ABCIApplication_ListSnapshots_Handler,
+		},
+		{
+			MethodName: "OfferSnapshot",
+			Handler:    _ABCIApplication_OfferSnapshot_Handler,
+		},
+		{
+			MethodName: "LoadSnapshotChunk",
+			Handler:    _ABCIApplication_LoadSnapshotChunk_Handler,
+		},
+		{
+			MethodName: "ApplySnapshotChunk",
+			Handler:    _ABCIApplication_ApplySnapshotChunk_Handler,
+		},
+		{
+			MethodName: "ExtendVote",
+			Handler:    _ABCIApplication_ExtendVote_Handler,
+		},
+		{
+			MethodName: "VerifyVoteExtension",
+			Handler:    _ABCIApplication_VerifyVoteExtension_Handler,
+		},
+		{
+			MethodName: "PrepareProposal",
+			Handler:    _ABCIApplication_PrepareProposal_Handler,
+		},
+		{
+			MethodName: "ProcessProposal",
+			Handler:    _ABCIApplication_ProcessProposal_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DeliverTxStream",
+			Handler:       _ABCIApplication_DeliverTxStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "abci/types/types.proto",
 }
-func (m *ResponseException) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Error)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+
+func (m *Request) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ResponseEcho) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *Request) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if m.Value != nil {
+		nn1, err := m.Value.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn1
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ResponseFlush) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *Request_Echo) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Echo != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Echo.Size()))
+		n2, err := m.Echo.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
 	}
-	return n
+	return i, nil
 }
-
-func (m *ResponseInfo) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Request_Flush) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Flush != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Flush.Size()))
+		n3, err := m.Flush.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
 	}
-	var l int
-	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	return i, nil
+}
+func (m *Request_Info) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Info != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Info.Size()))
+		n4, err := m.Info.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
 	}
-	l = len(m.Version)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	return i, nil
+}
+func (m *Request_SetOption) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.SetOption != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.SetOption.Size()))
+		n5, err := m.SetOption.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
 	}
-	if m.AppVersion != 0 {
-		n += 1 + sovTypes(uint64(m.AppVersion))
+	return i, nil
+}
+func (m *Request_InitChain) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.InitChain != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.InitChain.Size()))
+		n6, err := m.InitChain.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
 	}
-	if m.LastBlockHeight != 0 {
-		n += 1 + sovTypes(uint64(m.LastBlockHeight))
+	return i, nil
+}
+func (m *Request_Query) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Query != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Query.Size()))
+		n7, err := m.Query.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
 	}
-	l = len(m.LastBlockAppHash)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	return i, nil
+}
+func (m *Request_BeginBlock) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.BeginBlock != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.BeginBlock.Size()))
+		n8, err := m.BeginBlock.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return i, nil
+}
+func (m *Request_CheckTx) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.CheckTx != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.CheckTx.Size()))
+		n9, err := m.CheckTx.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
 	}
-	return n
+	return i, nil
 }
-
-func (m *ResponseSetOption) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Request_EndBlock) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.EndBlock != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.EndBlock.Size()))
+		n10, err := m.EndBlock.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
 	}
-	var l int
-	_ = l
-	if m.Code != 0 {
-		n += 1 + sovTypes(uint64(m.Code))
+	return i, nil
+}
+func (m *Request_Commit) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Commit != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Commit.Size()))
+		n11, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
 	}
-	l = len(m.Log)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	return i, nil
+}
+func (m *Request_ListSnapshots) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ListSnapshots != nil {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.ListSnapshots.Size()))
+		n12, err := m.ListSnapshots.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
 	}
-	l = len(m.Info)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	return i, nil
+}
+func (m *Request_OfferSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.OfferSnapshot != nil {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.OfferSnapshot.Size()))
+		n13, err := m.OfferSnapshot.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return i, nil
+}
+func (m *Request_LoadSnapshotChunk) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.LoadSnapshotChunk != nil {
+		dAtA[i] = 0x7a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.LoadSnapshotChunk.Size()))
+		n14, err := m.LoadSnapshotChunk.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
 	}
-	return n
+	return i, nil
 }
-
-func (m *ResponseInitChain) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Request_ApplySnapshotChunk) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ApplySnapshotChunk != nil {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.ApplySnapshotChunk.Size()))
+		n15, err := m.ApplySnapshotChunk.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
 	}
-	var l int
-	_ = l
-	if m.ConsensusParams != nil {
-		l = m.ConsensusParams.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	return i, nil
+}
+func (m *Request_ExtendVote) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ExtendVote != nil {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.ExtendVote.Size()))
+		n16, err := m.ExtendVote.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
 	}
-	if len(m.Validators) > 0 {
-		for _, e := range m.Validators {
-			l = e.Size()
-			n += 1 + l + sovTypes(uint64(l))
+	return i, nil
+}
+func (m *Request_VerifyVoteExtension) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.VerifyVoteExtension != nil {
+		dAtA[i] = 0x92
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.VerifyVoteExtension.Size()))
+		n17, err := m.VerifyVoteExtension.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n17
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return i, nil
+}
+func (m *Request_DeliverTx) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.DeliverTx != nil {
+		dAtA[i] = 0x9a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.DeliverTx.Size()))
+		n18, err := m.DeliverTx.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
 	}
-	return n
+	return i, nil
 }
-
-func (m *ResponseQuery) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Request_PrepareProposal) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.PrepareProposal != nil {
+		dAtA[i] = 0xa2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.PrepareProposal.Size()))
+		n19, err := m.PrepareProposal.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
 	}
-	var l int
-	_ = l
-	if m.Code != 0 {
-		n += 1 + sovTypes(uint64(m.Code))
+	return i, nil
+}
+func (m *Request_ProcessProposal) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ProcessProposal != nil {
+		dAtA[i] = 0xaa
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.ProcessProposal.Size()))
+		n20, err := m.ProcessProposal.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n20
 	}
-	l = len(m.Log)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	return i, nil
+}
+func (m *RequestEcho) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Info)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *RequestEcho) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Message) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Message)))
+		i += copy(dAtA[i:], m.Message)
 	}
-	if m.Index != 0 {
-		n += 1 + sovTypes(uint64(m.Index))
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	return i, nil
+}
+
+func (m *RequestFlush) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *RequestFlush) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Proof != nil {
-		l = m.Proof.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	return i, nil
+}
+
+func (m *RequestInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Version) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Version)))
+		i += copy(dAtA[i:], m.Version)
+	}
+	if m.BlockVersion != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.BlockVersion))
+	}
+	if m.P2PVersion != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.P2PVersion))
+	}
+	if m.ConsensusParams != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.ConsensusParams.Size()))
+		n21, err := m.ConsensusParams.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RequestSetOption) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestSetOption) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Key) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RequestInitChain) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestInitChain) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)))
+	n22, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n22
+	if len(m.ChainId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ChainId)))
+		i += copy(dAtA[i:], m.ChainId)
+	}
+	if m.ConsensusParams != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.ConsensusParams.Size()))
+		n23, err := m.ConsensusParams.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
+	}
+	if len(m.Validators) > 0 {
+		for _, msg := range m.Validators {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.AppStateBytes) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.AppStateBytes)))
+		i += copy(dAtA[i:], m.AppStateBytes)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RequestQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestQuery) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	if len(m.Path) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
 	}
 	if m.Height != 0 {
-		n += 1 + sovTypes(uint64(m.Height))
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
 	}
-	l = len(m.Codespace)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if m.Prove {
+		dAtA[i] = 0x20
+		i++
+		if m.Prove {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ResponseBeginBlock) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *RequestBeginBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestBeginBlock) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Tags) > 0 {
-		for _, e := range m.Tags {
-			l = e.Size()
-			n += 1 + l + sovTypes(uint64(l))
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(m.Header.Size()))
+	n24, err := m.Header.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n24
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(m.LastCommitInfo.Size()))
+	n25, err := m.LastCommitInfo.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n25
+	if len(m.ByzantineValidators) > 0 {
+		for _, msg := range m.ByzantineValidators {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RequestCheckTx) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestCheckTx) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Tx) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Tx)))
+		i += copy(dAtA[i:], m.Tx)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RequestDeliverTx) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestDeliverTx) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Tx) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Tx)))
+		i += copy(dAtA[i:], m.Tx)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RequestEndBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestEndBlock) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RequestCommit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestCommit) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RequestListSnapshots) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestListSnapshots) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RequestOfferSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestOfferSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Snapshot != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Snapshot.Size()))
+		n26, err := m.Snapshot.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n26
+	}
+	if len(m.AppHash) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.AppHash)))
+		i += copy(dAtA[i:], m.AppHash)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RequestLoadSnapshotChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestLoadSnapshotChunk) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+	}
+	if m.Format != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Format))
+	}
+	if m.Chunk != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Chunk))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RequestApplySnapshotChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestApplySnapshotChunk) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Index))
+	}
+	if len(m.Chunk) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Chunk)))
+		i += copy(dAtA[i:], m.Chunk)
+	}
+	if len(m.Sender) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Sender)))
+		i += copy(dAtA[i:], m.Sender)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RequestExtendVote) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestExtendVote) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
+	}
+	if m.Height != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RequestVerifyVoteExtension) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestVerifyVoteExtension) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
+	}
+	if len(m.ValidatorAddress) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ValidatorAddress)))
+		i += copy(dAtA[i:], m.ValidatorAddress)
+	}
+	if m.Height != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+	}
+	if len(m.VoteExtension) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.VoteExtension)))
+		i += copy(dAtA[i:], m.VoteExtension)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RequestPrepareProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestPrepareProposal) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(m.LocalLastCommit.Size()))
+	n27, err := m.LocalLastCommit.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n27
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if m.MaxTxBytes != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxTxBytes))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RequestProcessProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestProcessProposal) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(m.Header.Size()))
+	n28, err := m.Header.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n28
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(m.ProposedLastCommit.Size()))
+	n29, err := m.ProposedLastCommit.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n29
+	if len(m.ByzantineValidators) > 0 {
+		for _, msg := range m.ByzantineValidators {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Response) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Value != nil {
+		nn30, err := m.Value.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn30
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Response_Exception) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Exception != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Exception.Size()))
+		n31, err := m.Exception.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n31
+	}
+	return i, nil
+}
+func (m *Response_Echo) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Echo != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Echo.Size()))
+		n32, err := m.Echo.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n32
+	}
+	return i, nil
+}
+func (m *Response_Flush) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Flush != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Flush.Size()))
+		n33, err := m.Flush.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n33
+	}
+	return i, nil
+}
+func (m *Response_Info) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Info != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Info.Size()))
+		n34, err := m.Info.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n34
+	}
+	return i, nil
+}
+func (m *Response_SetOption) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.SetOption != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.SetOption.Size()))
+		n35, err := m.SetOption.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n35
+	}
+	return i, nil
+}
+func (m *Response_InitChain) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.InitChain != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.InitChain.Size()))
+		n36, err := m.InitChain.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n36
+	}
+	return i, nil
+}
+func (m *Response_Query) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Query != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Query.Size()))
+		n37, err := m.Query.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n37
+	}
+	return i, nil
+}
+func (m *Response_BeginBlock) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.BeginBlock != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.BeginBlock.Size()))
+		n38, err := m.BeginBlock.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n38
+	}
+	return i, nil
+}
+func (m *Response_CheckTx) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.CheckTx != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.CheckTx.Size()))
+		n39, err := m.CheckTx.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n39
+	}
+	return i, nil
+}
+func (m *Response_DeliverTx) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.DeliverTx != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.DeliverTx.Size()))
+		n40, err := m.DeliverTx.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n40
+	}
+	return i, nil
+}
+func (m *Response_EndBlock) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.EndBlock != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.EndBlock.Size()))
+		n41, err := m.EndBlock.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n41
+	}
+	return i, nil
+}
+func (m *Response_Commit) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Commit != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Commit.Size()))
+		n42, err := m.Commit.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n42
+	}
+	return i, nil
+}
+func (m *Response_ListSnapshots) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ListSnapshots != nil {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.ListSnapshots.Size()))
+		n43, err := m.ListSnapshots.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n43
+	}
+	return i, nil
+}
+func (m *Response_OfferSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.OfferSnapshot != nil {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.OfferSnapshot.Size()))
+		n44, err := m.OfferSnapshot.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n44
+	}
+	return i, nil
+}
+func (m *Response_LoadSnapshotChunk) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.LoadSnapshotChunk != nil {
+		dAtA[i] = 0x7a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.LoadSnapshotChunk.Size()))
+		n45, err := m.LoadSnapshotChunk.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n45
+	}
+	return i, nil
+}
+func (m *Response_ApplySnapshotChunk) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ApplySnapshotChunk != nil {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.ApplySnapshotChunk.Size()))
+		n46, err := m.ApplySnapshotChunk.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n46
+	}
+	return i, nil
+}
+func (m *Response_ExtendVote) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ExtendVote != nil {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.ExtendVote.Size()))
+		n47, err := m.ExtendVote.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n47
+	}
+	return i, nil
+}
+func (m *Response_VerifyVoteExtension) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.VerifyVoteExtension != nil {
+		dAtA[i] = 0x92
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.VerifyVoteExtension.Size()))
+		n48, err := m.VerifyVoteExtension.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n48
+	}
+	return i, nil
+}
+func (m *Response_PrepareProposal) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.PrepareProposal != nil {
+		dAtA[i] = 0x9a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.PrepareProposal.Size()))
+		n49, err := m.PrepareProposal.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n49
+	}
+	return i, nil
+}
+func (m *Response_ProcessProposal) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ProcessProposal != nil {
+		dAtA[i] = 0xa2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.ProcessProposal.Size()))
+		n50, err := m.ProcessProposal.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n50
+	}
+	return i, nil
+}
+func (m *ResponseException) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseException) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Error) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseEcho) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseEcho) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Message) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Message)))
+		i += copy(dAtA[i:], m.Message)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseFlush) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseFlush) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	if len(m.Version) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Version)))
+		i += copy(dAtA[i:], m.Version)
+	}
+	if m.AppVersion != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.AppVersion))
+	}
+	if m.LastBlockHeight != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.LastBlockHeight))
+	}
+	if len(m.LastBlockAppHash) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.LastBlockAppHash)))
+		i += copy(dAtA[i:], m.LastBlockAppHash)
+	}
+	if m.ConsensusParams != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.ConsensusParams.Size()))
+		n51, err := m.ConsensusParams.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n51
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseSetOption) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseSetOption) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Code))
+	}
+	if len(m.Log) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Log)))
+		i += copy(dAtA[i:], m.Log)
+	}
+	if len(m.Info) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Info)))
+		i += copy(dAtA[i:], m.Info)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseInitChain) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseInitChain) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ConsensusParams != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.ConsensusParams.Size()))
+		n52, err := m.ConsensusParams.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n52
+	}
+	if len(m.Validators) > 0 {
+		for _, msg := range m.Validators {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseQuery) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Code))
+	}
+	if len(m.Log) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Log)))
+		i += copy(dAtA[i:], m.Log)
+	}
+	if len(m.Info) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Info)))
+		i += copy(dAtA[i:], m.Info)
+	}
+	if m.Index != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Index))
+	}
+	if len(m.Key) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	if m.Proof != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Proof.Size()))
+		n53, err := m.Proof.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n53
+	}
+	if m.Height != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+	}
+	if len(m.Codespace) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Codespace)))
+		i += copy(dAtA[i:], m.Codespace)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseBeginBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseBeginBlock) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Tags) > 0 {
+		for _, msg := range m.Tags {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseCheckTx) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseCheckTx) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Code))
+	}
+	if len(m.Data) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	if len(m.Log) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Log)))
+		i += copy(dAtA[i:], m.Log)
+	}
+	if len(m.Info) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Info)))
+		i += copy(dAtA[i:], m.Info)
+	}
+	if m.GasWanted != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.GasWanted))
+	}
+	if m.GasUsed != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.GasUsed))
+	}
+	if len(m.Tags) > 0 {
+		for _, msg := range m.Tags {
+			dAtA[i] = 0x3a
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Codespace) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Codespace)))
+		i += copy(dAtA[i:], m.Codespace)
+	}
+	if m.Priority != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Priority))
+	}
+	if len(m.Sender) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Sender)))
+		i += copy(dAtA[i:], m.Sender)
+	}
+	if len(m.MempoolError) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.MempoolError)))
+		i += copy(dAtA[i:], m.MempoolError)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseDeliverTx) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseDeliverTx) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Code))
+	}
+	if len(m.Data) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	if len(m.Log) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Log)))
+		i += copy(dAtA[i:], m.Log)
+	}
+	if len(m.Info) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Info)))
+		i += copy(dAtA[i:], m.Info)
+	}
+	if m.GasWanted != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.GasWanted))
+	}
+	if m.GasUsed != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.GasUsed))
+	}
+	if len(m.Tags) > 0 {
+		for _, msg := range m.Tags {
+			dAtA[i] = 0x3a
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Codespace) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Codespace)))
+		i += copy(dAtA[i:], m.Codespace)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseEndBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseEndBlock) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.ValidatorUpdates) > 0 {
+		for _, msg := range m.ValidatorUpdates {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.ConsensusParamUpdates != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.ConsensusParamUpdates.Size()))
+		n54, err := m.ConsensusParamUpdates.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n54
+	}
+	if len(m.Tags) > 0 {
+		for _, msg := range m.Tags {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.PendingState {
+		dAtA[i] = 0x20
+		i++
+		if m.PendingState {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseCommit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseCommit) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseListSnapshots) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseListSnapshots) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Snapshots) > 0 {
+		for _, msg := range m.Snapshots {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseOfferSnapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseOfferSnapshot) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Result != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Result))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseLoadSnapshotChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseLoadSnapshotChunk) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Chunk) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Chunk)))
+		i += copy(dAtA[i:], m.Chunk)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseApplySnapshotChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseApplySnapshotChunk) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Result != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Result))
+	}
+	if len(m.RefetchChunks) > 0 {
+		dAtA56 := make([]byte, len(m.RefetchChunks)*10)
+		var j55 int
+		for _, num := range m.RefetchChunks {
+			for num >= 1<<7 {
+				dAtA56[j55] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j55++
+			}
+			dAtA56[j55] = uint8(num)
+			j55++
+		}
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(j55))
+		i += copy(dAtA[i:], dAtA56[:j55])
+	}
+	if len(m.RejectSenders) > 0 {
+		for _, s := range m.RejectSenders {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseExtendVote) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseExtendVote) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.VoteExtension) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.VoteExtension)))
+		i += copy(dAtA[i:], m.VoteExtension)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseVerifyVoteExtension) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseVerifyVoteExtension) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Result != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Result))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponsePrepareProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponsePrepareProposal) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ResponseProcessProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseProcessProposal) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Status != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Status))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ConsensusParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConsensusParams) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Block != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Block.Size()))
+		n57, err := m.Block.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n57
+	}
+	if m.Evidence != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Evidence.Size()))
+		n58, err := m.Evidence.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n58
+	}
+	if m.Validator != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Validator.Size()))
+		n59, err := m.Validator.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n59
+	}
+	if m.Timeout != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Timeout.Size()))
+		n60, err := m.Timeout.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n60
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *BlockParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockParams) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MaxBytes != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxBytes))
+	}
+	if m.MaxGas != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxGas))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *EvidenceParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EvidenceParams) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MaxAge != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxAge))
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxAgeDuration)))
+	n61, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.MaxAgeDuration, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n61
+	if m.MaxBytes != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxBytes))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ValidatorParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidatorParams) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.PubKeyTypes) > 0 {
+		for _, s := range m.PubKeyTypes {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TimeoutParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TimeoutParams) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.ProposeDelta)))
+	n62, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.ProposeDelta, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n62
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.PrevoteDelta)))
+	n63, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.PrevoteDelta, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n63
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdDuration(m.PrecommitDelta)))
+	n64, err := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.PrecommitDelta, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n64
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *LastCommitInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LastCommitInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+	}
+	if len(m.Votes) > 0 {
+		for _, msg := range m.Votes {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ExtendedCommitInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExtendedCommitInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Round != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+	}
+	if len(m.Votes) > 0 {
+		for _, msg := range m.Votes {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintTypes(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Header) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Header) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(m.Version.Size()))
+	n65, err := m.Version.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n65
+	if len(m.ChainID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ChainID)))
+		i += copy(dAtA[i:], m.ChainID)
+	}
+	if m.Height != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+	}
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)))
+	n66, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n66
+	if m.NumTxs != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.NumTxs))
+	}
+	if m.TotalTxs != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.TotalTxs))
+	}
+	dAtA[i] = 0x3a
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(m.LastBlockId.Size()))
+	n67, err := m.LastBlockId.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n67
+	if len(m.LastCommitHash) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.LastCommitHash)))
+		i += copy(dAtA[i:], m.LastCommitHash)
+	}
+	if len(m.DataHash) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.DataHash)))
+		i += copy(dAtA[i:], m.DataHash)
+	}
+	if len(m.ValidatorsHash) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ValidatorsHash)))
+		i += copy(dAtA[i:], m.ValidatorsHash)
+	}
+	if len(m.NextValidatorsHash) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.NextValidatorsHash)))
+		i += copy(dAtA[i:], m.NextValidatorsHash)
+	}
+	if len(m.ConsensusHash) > 0 {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ConsensusHash)))
+		i += copy(dAtA[i:], m.ConsensusHash)
+	}
+	if len(m.AppHash) > 0 {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.AppHash)))
+		i += copy(dAtA[i:], m.AppHash)
+	}
+	if len(m.LastResultsHash) > 0 {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.LastResultsHash)))
+		i += copy(dAtA[i:], m.LastResultsHash)
+	}
+	if len(m.EvidenceHash) > 0 {
+		dAtA[i] = 0x7a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.EvidenceHash)))
+		i += copy(dAtA[i:], m.EvidenceHash)
+	}
+	if len(m.ProposerAddress) > 0 {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ProposerAddress)))
+		i += copy(dAtA[i:], m.ProposerAddress)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Version) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Version) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Block != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Block))
+	}
+	if m.App != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.App))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *BlockID) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockID) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(m.PartsHeader.Size()))
+	n68, err := m.PartsHeader.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n68
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PartSetHeader) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Total != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Total))
+	}
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Validator) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Validator) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Address) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Address)))
+		i += copy(dAtA[i:], m.Address)
+	}
+	if m.Power != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Power))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ValidatorUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidatorUpdate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(m.PubKey.Size()))
+	n69, err := m.PubKey.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n69
+	if m.Power != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Power))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *VoteInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VoteInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(m.Validator.Size()))
+	n70, err := m.Validator.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n70
+	if m.SignedLastBlock {
+		dAtA[i] = 0x10
+		i++
+		if m.SignedLastBlock {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ExtendedVoteInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExtendedVoteInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(m.Validator.Size()))
+	n71, err := m.Validator.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n71
+	if m.SignedLastBlock {
+		dAtA[i] = 0x10
+		i++
+		if m.SignedLastBlock {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.VoteExtension) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.VoteExtension)))
+		i += copy(dAtA[i:], m.VoteExtension)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *PubKey) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PubKey) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Type) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Type)))
+		i += copy(dAtA[i:], m.Type)
+	}
+	if len(m.Data) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Evidence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Evidence) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Type) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Type)))
+		i += copy(dAtA[i:], m.Type)
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(m.Validator.Size()))
+	n72, err := m.Validator.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n72
+	if m.Height != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+	}
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintTypes(dAtA, i, uint64(github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)))
+	n73, err := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n73
+	if m.TotalVotingPower != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.TotalVotingPower))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Snapshot) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Snapshot) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+	}
+	if m.Format != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Format))
+	}
+	if m.Chunks != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Chunks))
+	}
+	if len(m.Hash) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Hash)))
+		i += copy(dAtA[i:], m.Hash)
+	}
+	if len(m.Metadata) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Metadata)))
+		i += copy(dAtA[i:], m.Metadata)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func NewPopulatedRequest(r randyTypes, easy bool) *Request {
+	this := &Request{}
+	oneofNumber_Value := []int32{2, 3, 4, 5, 6, 7, 8, 9, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21}[r.Intn(19)]
+	switch oneofNumber_Value {
+	case 2:
+		this.Value = NewPopulatedRequest_Echo(r, easy)
+	case 3:
+		this.Value = NewPopulatedRequest_Flush(r, easy)
+	case 4:
+		this.Value = NewPopulatedRequest_Info(r, easy)
+	case 5:
+		this.Value = NewPopulatedRequest_SetOption(r, easy)
+	case 6:
+		this.Value = NewPopulatedRequest_InitChain(r, easy)
+	case 7:
+		this.Value = NewPopulatedRequest_Query(r, easy)
+	case 8:
+		this.Value = NewPopulatedRequest_BeginBlock(r, easy)
+	case 9:
+		this.Value = NewPopulatedRequest_CheckTx(r, easy)
+	case 11:
+		this.Value = NewPopulatedRequest_EndBlock(r, easy)
+	case 12:
+		this.Value = NewPopulatedRequest_Commit(r, easy)
+	case 13:
+		this.Value = NewPopulatedRequest_ListSnapshots(r, easy)
+	case 14:
+		this.Value = NewPopulatedRequest_OfferSnapshot(r, easy)
+	case 15:
+		this.Value = NewPopulatedRequest_LoadSnapshotChunk(r, easy)
+	case 16:
+		this.Value = NewPopulatedRequest_ApplySnapshotChunk(r, easy)
+	case 17:
+		this.Value = NewPopulatedRequest_ExtendVote(r, easy)
+	case 18:
+		this.Value = NewPopulatedRequest_VerifyVoteExtension(r, easy)
+	case 19:
+		this.Value = NewPopulatedRequest_DeliverTx(r, easy)
+	case 20:
+		this.Value = NewPopulatedRequest_PrepareProposal(r, easy)
+	case 21:
+		this.Value = NewPopulatedRequest_ProcessProposal(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 22)
+	}
+	return this
+}
+
+func NewPopulatedRequest_Echo(r randyTypes, easy bool) *Request_Echo {
+	this := &Request_Echo{}
+	this.Echo = NewPopulatedRequestEcho(r, easy)
+	return this
+}
+func NewPopulatedRequest_Flush(r randyTypes, easy bool) *Request_Flush {
+	this := &Request_Flush{}
+	this.Flush = NewPopulatedRequestFlush(r, easy)
+	return this
+}
+func NewPopulatedRequest_Info(r randyTypes, easy bool) *Request_Info {
+	this := &Request_Info{}
+	this.Info = NewPopulatedRequestInfo(r, easy)
+	return this
+}
+func NewPopulatedRequest_SetOption(r randyTypes, easy bool) *Request_SetOption {
+	this := &Request_SetOption{}
+	this.SetOption = NewPopulatedRequestSetOption(r, easy)
+	return this
+}
+func NewPopulatedRequest_InitChain(r randyTypes, easy bool) *Request_InitChain {
+	this := &Request_InitChain{}
+	this.InitChain = NewPopulatedRequestInitChain(r, easy)
+	return this
+}
+func NewPopulatedRequest_Query(r randyTypes, easy bool) *Request_Query {
+	this := &Request_Query{}
+	this.Query = NewPopulatedRequestQuery(r, easy)
+	return this
+}
+func NewPopulatedRequest_BeginBlock(r randyTypes, easy bool) *Request_BeginBlock {
+	this := &Request_BeginBlock{}
+	this.BeginBlock = NewPopulatedRequestBeginBlock(r, easy)
+	return this
+}
+func NewPopulatedRequest_CheckTx(r randyTypes, easy bool) *Request_CheckTx {
+	this := &Request_CheckTx{}
+	this.CheckTx = NewPopulatedRequestCheckTx(r, easy)
+	return this
+}
+func NewPopulatedRequest_EndBlock(r randyTypes, easy bool) *Request_EndBlock {
+	this := &Request_EndBlock{}
+	this.EndBlock = NewPopulatedRequestEndBlock(r, easy)
+	return this
+}
+func NewPopulatedRequest_Commit(r randyTypes, easy bool) *Request_Commit {
+	this := &Request_Commit{}
+	this.Commit = NewPopulatedRequestCommit(r, easy)
+	return this
+}
+func NewPopulatedRequest_ListSnapshots(r randyTypes, easy bool) *Request_ListSnapshots {
+	this := &Request_ListSnapshots{}
+	this.ListSnapshots = NewPopulatedRequestListSnapshots(r, easy)
+	return this
+}
+func NewPopulatedRequest_OfferSnapshot(r randyTypes, easy bool) *Request_OfferSnapshot {
+	this := &Request_OfferSnapshot{}
+	this.OfferSnapshot = NewPopulatedRequestOfferSnapshot(r, easy)
+	return this
+}
+func NewPopulatedRequest_LoadSnapshotChunk(r randyTypes, easy bool) *Request_LoadSnapshotChunk {
+	this := &Request_LoadSnapshotChunk{}
+	this.LoadSnapshotChunk = NewPopulatedRequestLoadSnapshotChunk(r, easy)
+	return this
+}
+func NewPopulatedRequest_ApplySnapshotChunk(r randyTypes, easy bool) *Request_ApplySnapshotChunk {
+	this := &Request_ApplySnapshotChunk{}
+	this.ApplySnapshotChunk = NewPopulatedRequestApplySnapshotChunk(r, easy)
+	return this
+}
+func NewPopulatedRequest_ExtendVote(r randyTypes, easy bool) *Request_ExtendVote {
+	this := &Request_ExtendVote{}
+	this.ExtendVote = NewPopulatedRequestExtendVote(r, easy)
+	return this
+}
+func NewPopulatedRequest_VerifyVoteExtension(r randyTypes, easy bool) *Request_VerifyVoteExtension {
+	this := &Request_VerifyVoteExtension{}
+	this.VerifyVoteExtension = NewPopulatedRequestVerifyVoteExtension(r, easy)
+	return this
+}
+func NewPopulatedRequest_DeliverTx(r randyTypes, easy bool) *Request_DeliverTx {
+	this := &Request_DeliverTx{}
+	this.DeliverTx = NewPopulatedRequestDeliverTx(r, easy)
+	return this
+}
+func NewPopulatedRequest_PrepareProposal(r randyTypes, easy bool) *Request_PrepareProposal {
+	this := &Request_PrepareProposal{}
+	this.PrepareProposal = NewPopulatedRequestPrepareProposal(r, easy)
+	return this
+}
+func NewPopulatedRequest_ProcessProposal(r randyTypes, easy bool) *Request_ProcessProposal {
+	this := &Request_ProcessProposal{}
+	this.ProcessProposal = NewPopulatedRequestProcessProposal(r, easy)
+	return this
+}
+func NewPopulatedRequestEcho(r randyTypes, easy bool) *RequestEcho {
+	this := &RequestEcho{}
+	this.Message = string(randStringTypes(r))
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	}
+	return this
+}
+
+func NewPopulatedRequestFlush(r randyTypes, easy bool) *RequestFlush {
+	this := &RequestFlush{}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 1)
+	}
+	return this
+}
+
+func NewPopulatedRequestInfo(r randyTypes, easy bool) *RequestInfo {
+	this := &RequestInfo{}
+	this.Version = string(randStringTypes(r))
+	this.BlockVersion = uint64(uint64(r.Uint32()))
+	this.P2PVersion = uint64(uint64(r.Uint32()))
+	if r.Intn(10) != 0 {
+		this.ConsensusParams = NewPopulatedConsensusParams(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 5)
+	}
+	return this
+}
+
+func NewPopulatedRequestSetOption(r randyTypes, easy bool) *RequestSetOption {
+	this := &RequestSetOption{}
+	this.Key = string(randStringTypes(r))
+	this.Value = string(randStringTypes(r))
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedRequestInitChain(r randyTypes, easy bool) *RequestInitChain {
+	this := &RequestInitChain{}
+	v1 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
+	this.Time = *v1
+	this.ChainId = string(randStringTypes(r))
+	if r.Intn(10) != 0 {
+		this.ConsensusParams = NewPopulatedConsensusParams(r, easy)
+	}
+	if r.Intn(10) != 0 {
+		v2 := r.Intn(5)
+		this.Validators = make([]ValidatorUpdate, v2)
+		for i := 0; i < v2; i++ {
+			v3 := NewPopulatedValidatorUpdate(r, easy)
+			this.Validators[i] = *v3
+		}
+	}
+	v4 := r.Intn(100)
+	this.AppStateBytes = make([]byte, v4)
+	for i := 0; i < v4; i++ {
+		this.AppStateBytes[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 6)
+	}
+	return this
+}
+
+func NewPopulatedRequestQuery(r randyTypes, easy bool) *RequestQuery {
+	this := &RequestQuery{}
+	v5 := r.Intn(100)
+	this.Data = make([]byte, v5)
+	for i := 0; i < v5; i++ {
+		this.Data[i] = byte(r.Intn(256))
+	}
+	this.Path = string(randStringTypes(r))
+	this.Height = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Height *= -1
+	}
+	this.Prove = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 5)
+	}
+	return this
+}
+
+func NewPopulatedRequestBeginBlock(r randyTypes, easy bool) *RequestBeginBlock {
+	this := &RequestBeginBlock{}
+	v6 := r.Intn(100)
+	this.Hash = make([]byte, v6)
+	for i := 0; i < v6; i++ {
+		this.Hash[i] = byte(r.Intn(256))
+	}
+	v7 := NewPopulatedHeader(r, easy)
+	this.Header = *v7
+	v8 := NewPopulatedLastCommitInfo(r, easy)
+	this.LastCommitInfo = *v8
+	if r.Intn(10) != 0 {
+		v9 := r.Intn(5)
+		this.ByzantineValidators = make([]Evidence, v9)
+		for i := 0; i < v9; i++ {
+			v10 := NewPopulatedEvidence(r, easy)
+			this.ByzantineValidators[i] = *v10
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 5)
+	}
+	return this
+}
+
+func NewPopulatedRequestCheckTx(r randyTypes, easy bool) *RequestCheckTx {
+	this := &RequestCheckTx{}
+	v11 := r.Intn(100)
+	this.Tx = make([]byte, v11)
+	for i := 0; i < v11; i++ {
+		this.Tx[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	}
+	return this
+}
+
+func NewPopulatedRequestDeliverTx(r randyTypes, easy bool) *RequestDeliverTx {
+	this := &RequestDeliverTx{}
+	v12 := r.Intn(100)
+	this.Tx = make([]byte, v12)
+	for i := 0; i < v12; i++ {
+		this.Tx[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	}
+	return this
+}
+
+func NewPopulatedRequestEndBlock(r randyTypes, easy bool) *RequestEndBlock {
+	this := &RequestEndBlock{}
+	this.Height = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Height *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	}
+	return this
+}
+
+func NewPopulatedRequestCommit(r randyTypes, easy bool) *RequestCommit {
+	this := &RequestCommit{}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 1)
+	}
+	return this
+}
+
+func NewPopulatedRequestListSnapshots(r randyTypes, easy bool) *RequestListSnapshots {
+	this := &RequestListSnapshots{}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 1)
+	}
+	return this
+}
+
+func NewPopulatedRequestOfferSnapshot(r randyTypes, easy bool) *RequestOfferSnapshot {
+	this := &RequestOfferSnapshot{}
+	if r.Intn(10) != 0 {
+		this.Snapshot = NewPopulatedSnapshot(r, easy)
+	}
+	v13 := r.Intn(100)
+	this.AppHash = make([]byte, v13)
+	for i := 0; i < v13; i++ {
+		this.AppHash[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedRequestLoadSnapshotChunk(r randyTypes, easy bool) *RequestLoadSnapshotChunk {
+	this := &RequestLoadSnapshotChunk{}
+	this.Height = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Height *= -1
+	}
+	this.Format = uint32(r.Uint32())
+	this.Chunk = uint32(r.Uint32())
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 4)
+	}
+	return this
+}
+
+func NewPopulatedRequestApplySnapshotChunk(r randyTypes, easy bool) *RequestApplySnapshotChunk {
+	this := &RequestApplySnapshotChunk{}
+	this.Index = uint32(r.Uint32())
+	v14 := r.Intn(100)
+	this.Chunk = make([]byte, v14)
+	for i := 0; i < v14; i++ {
+		this.Chunk[i] = byte(r.Intn(256))
+	}
+	this.Sender = string(randStringTypes(r))
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 4)
+	}
+	return this
+}
+
+func NewPopulatedRequestExtendVote(r randyTypes, easy bool) *RequestExtendVote {
+	this := &RequestExtendVote{}
+	v15 := r.Intn(100)
+	this.Hash = make([]byte, v15)
+	for i := 0; i < v15; i++ {
+		this.Hash[i] = byte(r.Intn(256))
+	}
+	this.Height = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Height *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedRequestVerifyVoteExtension(r randyTypes, easy bool) *RequestVerifyVoteExtension {
+	this := &RequestVerifyVoteExtension{}
+	v16 := r.Intn(100)
+	this.Hash = make([]byte, v16)
+	for i := 0; i < v16; i++ {
+		this.Hash[i] = byte(r.Intn(256))
+	}
+	v17 := r.Intn(100)
+	this.ValidatorAddress = make([]byte, v17)
+	for i := 0; i < v17; i++ {
+		this.ValidatorAddress[i] = byte(r.Intn(256))
+	}
+	this.Height = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Height *= -1
+	}
+	v18 := r.Intn(100)
+	this.VoteExtension = make([]byte, v18)
+	for i := 0; i < v18; i++ {
+		this.VoteExtension[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 5)
+	}
+	return this
+}
+
+func NewPopulatedRequestPrepareProposal(r randyTypes, easy bool) *RequestPrepareProposal {
+	this := &RequestPrepareProposal{}
+	this.Height = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Height *= -1
+	}
+	v19 := NewPopulatedExtendedCommitInfo(r, easy)
+	this.LocalLastCommit = *v19
+	v20 := r.Intn(10)
+	this.Txs = make([][]byte, v20)
+	for i := 0; i < v20; i++ {
+		v21 := r.Intn(100)
+		this.Txs[i] = make([]byte, v21)
+		for j := 0; j < v21; j++ {
+			this.Txs[i][j] = byte(r.Intn(256))
+		}
+	}
+	this.MaxTxBytes = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.MaxTxBytes *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 5)
+	}
+	return this
+}
+
+func NewPopulatedRequestProcessProposal(r randyTypes, easy bool) *RequestProcessProposal {
+	this := &RequestProcessProposal{}
+	v22 := r.Intn(100)
+	this.Hash = make([]byte, v22)
+	for i := 0; i < v22; i++ {
+		this.Hash[i] = byte(r.Intn(256))
+	}
+	v23 := NewPopulatedHeader(r, easy)
+	this.Header = *v23
+	v24 := r.Intn(10)
+	this.Txs = make([][]byte, v24)
+	for i := 0; i < v24; i++ {
+		v25 := r.Intn(100)
+		this.Txs[i] = make([]byte, v25)
+		for j := 0; j < v25; j++ {
+			this.Txs[i][j] = byte(r.Intn(256))
+		}
+	}
+	v26 := NewPopulatedLastCommitInfo(r, easy)
+	this.ProposedLastCommit = *v26
+	if r.Intn(10) != 0 {
+		v27 := r.Intn(5)
+		this.ByzantineValidators = make([]Evidence, v27)
+		for i := 0; i < v27; i++ {
+			v28 := NewPopulatedEvidence(r, easy)
+			this.ByzantineValidators[i] = *v28
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 6)
+	}
+	return this
+}
+
+func NewPopulatedResponse(r randyTypes, easy bool) *Response {
+	this := &Response{}
+	oneofNumber_Value := []int32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}[r.Intn(20)]
+	switch oneofNumber_Value {
+	case 1:
+		this.Value = NewPopulatedResponse_Exception(r, easy)
+	case 2:
+		this.Value = NewPopulatedResponse_Echo(r, easy)
+	case 3:
+		this.Value = NewPopulatedResponse_Flush(r, easy)
+	case 4:
+		this.Value = NewPopulatedResponse_Info(r, easy)
+	case 5:
+		this.Value = NewPopulatedResponse_SetOption(r, easy)
+	case 6:
+		this.Value = NewPopulatedResponse_InitChain(r, easy)
+	case 7:
+		this.Value = NewPopulatedResponse_Query(r, easy)
+	case 8:
+		this.Value = NewPopulatedResponse_BeginBlock(r, easy)
+	case 9:
+		this.Value = NewPopulatedResponse_CheckTx(r, easy)
+	case 10:
+		this.Value = NewPopulatedResponse_DeliverTx(r, easy)
+	case 11:
+		this.Value = NewPopulatedResponse_EndBlock(r, easy)
+	case 12:
+		this.Value = NewPopulatedResponse_Commit(r, easy)
+	case 13:
+		this.Value = NewPopulatedResponse_ListSnapshots(r, easy)
+	case 14:
+		this.Value = NewPopulatedResponse_OfferSnapshot(r, easy)
+	case 15:
+		this.Value = NewPopulatedResponse_LoadSnapshotChunk(r, easy)
+	case 16:
+		this.Value = NewPopulatedResponse_ApplySnapshotChunk(r, easy)
+	case 17:
+		this.Value = NewPopulatedResponse_ExtendVote(r, easy)
+	case 18:
+		this.Value = NewPopulatedResponse_VerifyVoteExtension(r, easy)
+	case 19:
+		this.Value = NewPopulatedResponse_PrepareProposal(r, easy)
+	case 20:
+		this.Value = NewPopulatedResponse_ProcessProposal(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 21)
+	}
+	return this
+}
+
+func NewPopulatedResponse_Exception(r randyTypes, easy bool) *Response_Exception {
+	this := &Response_Exception{}
+	this.Exception = NewPopulatedResponseException(r, easy)
+	return this
+}
+func NewPopulatedResponse_Echo(r randyTypes, easy bool) *Response_Echo {
+	this := &Response_Echo{}
+	this.Echo = NewPopulatedResponseEcho(r, easy)
+	return this
+}
+func NewPopulatedResponse_Flush(r randyTypes, easy bool) *Response_Flush {
+	this := &Response_Flush{}
+	this.Flush = NewPopulatedResponseFlush(r, easy)
+	return this
+}
+func NewPopulatedResponse_Info(r randyTypes, easy bool) *Response_Info {
+	this := &Response_Info{}
+	this.Info = NewPopulatedResponseInfo(r, easy)
+	return this
+}
+func NewPopulatedResponse_SetOption(r randyTypes, easy bool) *Response_SetOption {
+	this := &Response_SetOption{}
+	this.SetOption = NewPopulatedResponseSetOption(r, easy)
+	return this
+}
+func NewPopulatedResponse_InitChain(r randyTypes, easy bool) *Response_InitChain {
+	this := &Response_InitChain{}
+	this.InitChain = NewPopulatedResponseInitChain(r, easy)
+	return this
+}
+func NewPopulatedResponse_Query(r randyTypes, easy bool) *Response_Query {
+	this := &Response_Query{}
+	this.Query = NewPopulatedResponseQuery(r, easy)
+	return this
+}
+func NewPopulatedResponse_BeginBlock(r randyTypes, easy bool) *Response_BeginBlock {
+	this := &Response_BeginBlock{}
+	this.BeginBlock = NewPopulatedResponseBeginBlock(r, easy)
+	return this
+}
+func NewPopulatedResponse_CheckTx(r randyTypes, easy bool) *Response_CheckTx {
+	this := &Response_CheckTx{}
+	this.CheckTx = NewPopulatedResponseCheckTx(r, easy)
+	return this
+}
+func NewPopulatedResponse_DeliverTx(r randyTypes, easy bool) *Response_DeliverTx {
+	this := &Response_DeliverTx{}
+	this.DeliverTx = NewPopulatedResponseDeliverTx(r, easy)
+	return this
+}
+func NewPopulatedResponse_EndBlock(r randyTypes, easy bool) *Response_EndBlock {
+	this := &Response_EndBlock{}
+	this.EndBlock = NewPopulatedResponseEndBlock(r, easy)
+	return this
+}
+func NewPopulatedResponse_Commit(r randyTypes, easy bool) *Response_Commit {
+	this := &Response_Commit{}
+	this.Commit = NewPopulatedResponseCommit(r, easy)
+	return this
+}
+func NewPopulatedResponse_ListSnapshots(r randyTypes, easy bool) *Response_ListSnapshots {
+	this := &Response_ListSnapshots{}
+	this.ListSnapshots = NewPopulatedResponseListSnapshots(r, easy)
+	return this
+}
+func NewPopulatedResponse_OfferSnapshot(r randyTypes, easy bool) *Response_OfferSnapshot {
+	this := &Response_OfferSnapshot{}
+	this.OfferSnapshot = NewPopulatedResponseOfferSnapshot(r, easy)
+	return this
+}
+func NewPopulatedResponse_LoadSnapshotChunk(r randyTypes, easy bool) *Response_LoadSnapshotChunk {
+	this := &Response_LoadSnapshotChunk{}
+	this.LoadSnapshotChunk = NewPopulatedResponseLoadSnapshotChunk(r, easy)
+	return this
+}
+func NewPopulatedResponse_ApplySnapshotChunk(r randyTypes, easy bool) *Response_ApplySnapshotChunk {
+	this := &Response_ApplySnapshotChunk{}
+	this.ApplySnapshotChunk = NewPopulatedResponseApplySnapshotChunk(r, easy)
+	return this
+}
+func NewPopulatedResponse_ExtendVote(r randyTypes, easy bool) *Response_ExtendVote {
+	this := &Response_ExtendVote{}
+	this.ExtendVote = NewPopulatedResponseExtendVote(r, easy)
+	return this
+}
+func NewPopulatedResponse_VerifyVoteExtension(r randyTypes, easy bool) *Response_VerifyVoteExtension {
+	this := &Response_VerifyVoteExtension{}
+	this.VerifyVoteExtension = NewPopulatedResponseVerifyVoteExtension(r, easy)
+	return this
+}
+func NewPopulatedResponse_PrepareProposal(r randyTypes, easy bool) *Response_PrepareProposal {
+	this := &Response_PrepareProposal{}
+	this.PrepareProposal = NewPopulatedResponsePrepareProposal(r, easy)
+	return this
+}
+func NewPopulatedResponse_ProcessProposal(r randyTypes, easy bool) *Response_ProcessProposal {
+	this := &Response_ProcessProposal{}
+	this.ProcessProposal = NewPopulatedResponseProcessProposal(r, easy)
+	return this
+}
+func NewPopulatedResponseException(r randyTypes, easy bool) *ResponseException {
+	this := &ResponseException{}
+	this.Error = string(randStringTypes(r))
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	}
+	return this
+}
+
+func NewPopulatedResponseEcho(r randyTypes, easy bool) *ResponseEcho {
+	this := &ResponseEcho{}
+	this.Message = string(randStringTypes(r))
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	}
+	return this
+}
+
+func NewPopulatedResponseFlush(r randyTypes, easy bool) *ResponseFlush {
+	this := &ResponseFlush{}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 1)
+	}
+	return this
+}
+
+func NewPopulatedResponseInfo(r randyTypes, easy bool) *ResponseInfo {
+	this := &ResponseInfo{}
+	this.Data = string(randStringTypes(r))
+	this.Version = string(randStringTypes(r))
+	this.AppVersion = uint64(uint64(r.Uint32()))
+	this.LastBlockHeight = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.LastBlockHeight *= -1
+	}
+	v29 := r.Intn(100)
+	this.LastBlockAppHash = make([]byte, v29)
+	for i := 0; i < v29; i++ {
+		this.LastBlockAppHash[i] = byte(r.Intn(256))
+	}
+	if r.Intn(10) != 0 {
+		this.ConsensusParams = NewPopulatedConsensusParams(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 7)
+	}
+	return this
+}
+
+func NewPopulatedResponseSetOption(r randyTypes, easy bool) *ResponseSetOption {
+	this := &ResponseSetOption{}
+	this.Code = uint32(r.Uint32())
+	this.Log = string(randStringTypes(r))
+	this.Info = string(randStringTypes(r))
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 5)
+	}
+	return this
+}
+
+func NewPopulatedResponseInitChain(r randyTypes, easy bool) *ResponseInitChain {
+	this := &ResponseInitChain{}
+	if r.Intn(10) != 0 {
+		this.ConsensusParams = NewPopulatedConsensusParams(r, easy)
+	}
+	if r.Intn(10) != 0 {
+		v30 := r.Intn(5)
+		this.Validators = make([]ValidatorUpdate, v30)
+		for i := 0; i < v30; i++ {
+			v31 := NewPopulatedValidatorUpdate(r, easy)
+			this.Validators[i] = *v31
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedResponseQuery(r randyTypes, easy bool) *ResponseQuery {
+	this := &ResponseQuery{}
+	this.Code = uint32(r.Uint32())
+	this.Log = string(randStringTypes(r))
+	this.Info = string(randStringTypes(r))
+	this.Index = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Index *= -1
+	}
+	v32 := r.Intn(100)
+	this.Key = make([]byte, v32)
+	for i := 0; i < v32; i++ {
+		this.Key[i] = byte(r.Intn(256))
+	}
+	v33 := r.Intn(100)
+	this.Value = make([]byte, v33)
+	for i := 0; i < v33; i++ {
+		this.Value[i] = byte(r.Intn(256))
+	}
+	if r.Intn(10) != 0 {
+		this.Proof = merkle.NewPopulatedProof(r, easy)
+	}
+	this.Height = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Height *= -1
+	}
+	this.Codespace = string(randStringTypes(r))
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 11)
+	}
+	return this
+}
+
+func NewPopulatedResponseBeginBlock(r randyTypes, easy bool) *ResponseBeginBlock {
+	this := &ResponseBeginBlock{}
+	if r.Intn(10) != 0 {
+		v34 := r.Intn(5)
+		this.Tags = make([]common.KVPair, v34)
+		for i := 0; i < v34; i++ {
+			v35 := common.NewPopulatedKVPair(r, easy)
+			this.Tags[i] = *v35
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	}
+	return this
+}
+
+func NewPopulatedResponseCheckTx(r randyTypes, easy bool) *ResponseCheckTx {
+	this := &ResponseCheckTx{}
+	this.Code = uint32(r.Uint32())
+	v36 := r.Intn(100)
+	this.Data = make([]byte, v36)
+	for i := 0; i < v36; i++ {
+		this.Data[i] = byte(r.Intn(256))
+	}
+	this.Log = string(randStringTypes(r))
+	this.Info = string(randStringTypes(r))
+	this.GasWanted = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.GasWanted *= -1
+	}
+	this.GasUsed = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.GasUsed *= -1
+	}
+	if r.Intn(10) != 0 {
+		v37 := r.Intn(5)
+		this.Tags = make([]common.KVPair, v37)
+		for i := 0; i < v37; i++ {
+			v38 := common.NewPopulatedKVPair(r, easy)
+			this.Tags[i] = *v38
+		}
+	}
+	this.Codespace = string(randStringTypes(r))
+	this.Priority = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Priority *= -1
+	}
+	this.Sender = string(randStringTypes(r))
+	this.MempoolError = string(randStringTypes(r))
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 12)
+	}
+	return this
+}
+
+func NewPopulatedResponseDeliverTx(r randyTypes, easy bool) *ResponseDeliverTx {
+	this := &ResponseDeliverTx{}
+	this.Code = uint32(r.Uint32())
+	v39 := r.Intn(100)
+	this.Data = make([]byte, v39)
+	for i := 0; i < v39; i++ {
+		this.Data[i] = byte(r.Intn(256))
+	}
+	this.Log = string(randStringTypes(r))
+	this.Info = string(randStringTypes(r))
+	this.GasWanted = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.GasWanted *= -1
+	}
+	this.GasUsed = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.GasUsed *= -1
+	}
+	if r.Intn(10) != 0 {
+		v40 := r.Intn(5)
+		this.Tags = make([]common.KVPair, v40)
+		for i := 0; i < v40; i++ {
+			v41 := common.NewPopulatedKVPair(r, easy)
+			this.Tags[i] = *v41
+		}
+	}
+	this.Codespace = string(randStringTypes(r))
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 9)
+	}
+	return this
+}
+
+func NewPopulatedResponseEndBlock(r randyTypes, easy bool) *ResponseEndBlock {
+	this := &ResponseEndBlock{}
+	if r.Intn(10) != 0 {
+		v42 := r.Intn(5)
+		this.ValidatorUpdates = make([]ValidatorUpdate, v42)
+		for i := 0; i < v42; i++ {
+			v43 := NewPopulatedValidatorUpdate(r, easy)
+			this.ValidatorUpdates[i] = *v43
+		}
+	}
+	if r.Intn(10) != 0 {
+		this.ConsensusParamUpdates = NewPopulatedConsensusParams(r, easy)
+	}
+	if r.Intn(10) != 0 {
+		v44 := r.Intn(5)
+		this.Tags = make([]common.KVPair, v44)
+		for i := 0; i < v44; i++ {
+			v45 := common.NewPopulatedKVPair(r, easy)
+			this.Tags[i] = *v45
+		}
+	}
+	this.PendingState = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 5)
+	}
+	return this
+}
+
+func NewPopulatedResponseCommit(r randyTypes, easy bool) *ResponseCommit {
+	this := &ResponseCommit{}
+	v46 := r.Intn(100)
+	this.Data = make([]byte, v46)
+	for i := 0; i < v46; i++ {
+		this.Data[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedResponseListSnapshots(r randyTypes, easy bool) *ResponseListSnapshots {
+	this := &ResponseListSnapshots{}
+	if r.Intn(10) != 0 {
+		v47 := r.Intn(5)
+		this.Snapshots = make([]*Snapshot, v47)
+		for i := 0; i < v47; i++ {
+			this.Snapshots[i] = NewPopulatedSnapshot(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	}
+	return this
+}
+
+func NewPopulatedResponseOfferSnapshot(r randyTypes, easy bool) *ResponseOfferSnapshot {
+	this := &ResponseOfferSnapshot{}
+	this.Result = ResponseOfferSnapshot_Result([]int32{0, 1, 2, 3, 4, 5}[r.Intn(6)])
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	}
+	return this
+}
+
+func NewPopulatedResponseLoadSnapshotChunk(r randyTypes, easy bool) *ResponseLoadSnapshotChunk {
+	this := &ResponseLoadSnapshotChunk{}
+	v48 := r.Intn(100)
+	this.Chunk = make([]byte, v48)
+	for i := 0; i < v48; i++ {
+		this.Chunk[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	}
+	return this
+}
+
+func NewPopulatedResponseApplySnapshotChunk(r randyTypes, easy bool) *ResponseApplySnapshotChunk {
+	this := &ResponseApplySnapshotChunk{}
+	this.Result = ResponseApplySnapshotChunk_Result([]int32{0, 1, 2, 3, 4, 5}[r.Intn(6)])
+	v49 := r.Intn(10)
+	this.RefetchChunks = make([]uint32, v49)
+	for i := 0; i < v49; i++ {
+		this.RefetchChunks[i] = uint32(r.Uint32())
+	}
+	v50 := r.Intn(10)
+	this.RejectSenders = make([]string, v50)
+	for i := 0; i < v50; i++ {
+		this.RejectSenders[i] = string(randStringTypes(r))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 4)
+	}
+	return this
+}
+
+func NewPopulatedResponseExtendVote(r randyTypes, easy bool) *ResponseExtendVote {
+	this := &ResponseExtendVote{}
+	v51 := r.Intn(100)
+	this.VoteExtension = make([]byte, v51)
+	for i := 0; i < v51; i++ {
+		this.VoteExtension[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	}
+	return this
+}
+
+func NewPopulatedResponseVerifyVoteExtension(r randyTypes, easy bool) *ResponseVerifyVoteExtension {
+	this := &ResponseVerifyVoteExtension{}
+	this.Result = ResponseVerifyVoteExtension_Result([]int32{0, 1, 2}[r.Intn(3)])
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	}
+	return this
+}
+
+func NewPopulatedResponsePrepareProposal(r randyTypes, easy bool) *ResponsePrepareProposal {
+	this := &ResponsePrepareProposal{}
+	v52 := r.Intn(10)
+	this.Txs = make([][]byte, v52)
+	for i := 0; i < v52; i++ {
+		v53 := r.Intn(100)
+		this.Txs[i] = make([]byte, v53)
+		for j := 0; j < v53; j++ {
+			this.Txs[i][j] = byte(r.Intn(256))
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	}
+	return this
+}
+
+func NewPopulatedResponseProcessProposal(r randyTypes, easy bool) *ResponseProcessProposal {
+	this := &ResponseProcessProposal{}
+	this.Status = ResponseProcessProposal_Status([]int32{0, 1, 2}[r.Intn(3)])
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	}
+	return this
+}
+
+func NewPopulatedConsensusParams(r randyTypes, easy bool) *ConsensusParams {
+	this := &ConsensusParams{}
+	if r.Intn(10) != 0 {
+		this.Block = NewPopulatedBlockParams(r, easy)
+	}
+	if r.Intn(10) != 0 {
+		this.Evidence = NewPopulatedEvidenceParams(r, easy)
+	}
+	if r.Intn(10) != 0 {
+		this.Validator = NewPopulatedValidatorParams(r, easy)
+	}
+	if r.Intn(10) != 0 {
+		this.Timeout = NewPopulatedTimeoutParams(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 5)
+	}
+	return this
+}
+
+func NewPopulatedBlockParams(r randyTypes, easy bool) *BlockParams {
+	this := &BlockParams{}
+	this.MaxBytes = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.MaxBytes *= -1
+	}
+	this.MaxGas = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.MaxGas *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedEvidenceParams(r randyTypes, easy bool) *EvidenceParams {
+	this := &EvidenceParams{}
+	this.MaxAge = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.MaxAge *= -1
+	}
+	v54 := github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	this.MaxAgeDuration = *v54
+	this.MaxBytes = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.MaxBytes *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 4)
+	}
+	return this
+}
+
+func NewPopulatedValidatorParams(r randyTypes, easy bool) *ValidatorParams {
+	this := &ValidatorParams{}
+	v55 := r.Intn(10)
+	this.PubKeyTypes = make([]string, v55)
+	for i := 0; i < v55; i++ {
+		this.PubKeyTypes[i] = string(randStringTypes(r))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+	}
+	return this
+}
+
+func NewPopulatedTimeoutParams(r randyTypes, easy bool) *TimeoutParams {
+	this := &TimeoutParams{}
+	v56 := github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	this.ProposeDelta = *v56
+	v57 := github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	this.PrevoteDelta = *v57
+	v58 := github_com_gogo_protobuf_types.NewPopulatedStdDuration(r, easy)
+	this.PrecommitDelta = *v58
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 4)
+	}
+	return this
+}
+
+func NewPopulatedLastCommitInfo(r randyTypes, easy bool) *LastCommitInfo {
+	this := &LastCommitInfo{}
+	this.Round = int32(r.Int31())
+	if r.Intn(2) == 0 {
+		this.Round *= -1
+	}
+	if r.Intn(10) != 0 {
+		v59 := r.Intn(5)
+		this.Votes = make([]VoteInfo, v59)
+		for i := 0; i < v59; i++ {
+			v60 := NewPopulatedVoteInfo(r, easy)
+			this.Votes[i] = *v60
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedExtendedCommitInfo(r randyTypes, easy bool) *ExtendedCommitInfo {
+	this := &ExtendedCommitInfo{}
+	this.Round = int32(r.Int31())
+	if r.Intn(2) == 0 {
+		this.Round *= -1
+	}
+	if r.Intn(10) != 0 {
+		v61 := r.Intn(5)
+		this.Votes = make([]ExtendedVoteInfo, v61)
+		for i := 0; i < v61; i++ {
+			v62 := NewPopulatedExtendedVoteInfo(r, easy)
+			this.Votes[i] = *v62
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedHeader(r randyTypes, easy bool) *Header {
+	this := &Header{}
+	v63 := NewPopulatedVersion(r, easy)
+	this.Version = *v63
+	this.ChainID = string(randStringTypes(r))
+	this.Height = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Height *= -1
+	}
+	v64 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
+	this.Time = *v64
+	this.NumTxs = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.NumTxs *= -1
+	}
+	this.TotalTxs = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.TotalTxs *= -1
+	}
+	v65 := NewPopulatedBlockID(r, easy)
+	this.LastBlockId = *v65
+	v66 := r.Intn(100)
+	this.LastCommitHash = make([]byte, v66)
+	for i := 0; i < v66; i++ {
+		this.LastCommitHash[i] = byte(r.Intn(256))
+	}
+	v67 := r.Intn(100)
+	this.DataHash = make([]byte, v67)
+	for i := 0; i < v67; i++ {
+		this.DataHash[i] = byte(r.Intn(256))
+	}
+	v68 := r.Intn(100)
+	this.ValidatorsHash = make([]byte, v68)
+	for i := 0; i < v68; i++ {
+		this.ValidatorsHash[i] = byte(r.Intn(256))
+	}
+	v69 := r.Intn(100)
+	this.NextValidatorsHash = make([]byte, v69)
+	for i := 0; i < v69; i++ {
+		this.NextValidatorsHash[i] = byte(r.Intn(256))
+	}
+	v70 := r.Intn(100)
+	this.ConsensusHash = make([]byte, v70)
+	for i := 0; i < v70; i++ {
+		this.ConsensusHash[i] = byte(r.Intn(256))
+	}
+	v71 := r.Intn(100)
+	this.AppHash = make([]byte, v71)
+	for i := 0; i < v71; i++ {
+		this.AppHash[i] = byte(r.Intn(256))
+	}
+	v72 := r.Intn(100)
+	this.LastResultsHash = make([]byte, v72)
+	for i := 0; i < v72; i++ {
+		this.LastResultsHash[i] = byte(r.Intn(256))
+	}
+	v73 := r.Intn(100)
+	this.EvidenceHash = make([]byte, v73)
+	for i := 0; i < v73; i++ {
+		this.EvidenceHash[i] = byte(r.Intn(256))
+	}
+	v74 := r.Intn(100)
+	this.ProposerAddress = make([]byte, v74)
+	for i := 0; i < v74; i++ {
+		this.ProposerAddress[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 17)
+	}
+	return this
+}
+
+func NewPopulatedVersion(r randyTypes, easy bool) *Version {
+	this := &Version{}
+	this.Block = uint64(uint64(r.Uint32()))
+	this.App = uint64(uint64(r.Uint32()))
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedBlockID(r randyTypes, easy bool) *BlockID {
+	this := &BlockID{}
+	v75 := r.Intn(100)
+	this.Hash = make([]byte, v75)
+	for i := 0; i < v75; i++ {
+		this.Hash[i] = byte(r.Intn(256))
+	}
+	v76 := NewPopulatedPartSetHeader(r, easy)
+	this.PartsHeader = *v76
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedPartSetHeader(r randyTypes, easy bool) *PartSetHeader {
+	this := &PartSetHeader{}
+	this.Total = int32(r.Int31())
+	if r.Intn(2) == 0 {
+		this.Total *= -1
+	}
+	v77 := r.Intn(100)
+	this.Hash = make([]byte, v77)
+	for i := 0; i < v77; i++ {
+		this.Hash[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedValidator(r randyTypes, easy bool) *Validator {
+	this := &Validator{}
+	v78 := r.Intn(100)
+	this.Address = make([]byte, v78)
+	for i := 0; i < v78; i++ {
+		this.Address[i] = byte(r.Intn(256))
+	}
+	this.Power = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Power *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 4)
+	}
+	return this
+}
+
+func NewPopulatedValidatorUpdate(r randyTypes, easy bool) *ValidatorUpdate {
+	this := &ValidatorUpdate{}
+	v79 := NewPopulatedPubKey(r, easy)
+	this.PubKey = *v79
+	this.Power = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Power *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedVoteInfo(r randyTypes, easy bool) *VoteInfo {
+	this := &VoteInfo{}
+	v80 := NewPopulatedValidator(r, easy)
+	this.Validator = *v80
+	this.SignedLastBlock = bool(bool(r.Intn(2) == 0))
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedExtendedVoteInfo(r randyTypes, easy bool) *ExtendedVoteInfo {
+	this := &ExtendedVoteInfo{}
+	v81 := NewPopulatedValidator(r, easy)
+	this.Validator = *v81
+	this.SignedLastBlock = bool(bool(r.Intn(2) == 0))
+	v82 := r.Intn(100)
+	this.VoteExtension = make([]byte, v82)
+	for i := 0; i < v82; i++ {
+		this.VoteExtension[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 4)
+	}
+	return this
+}
+
+func NewPopulatedPubKey(r randyTypes, easy bool) *PubKey {
+	this := &PubKey{}
+	this.Type = string(randStringTypes(r))
+	v83 := r.Intn(100)
+	this.Data = make([]byte, v83)
+	for i := 0; i < v83; i++ {
+		this.Data[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedEvidence(r randyTypes, easy bool) *Evidence {
+	this := &Evidence{}
+	this.Type = string(randStringTypes(r))
+	v84 := NewPopulatedValidator(r, easy)
+	this.Validator = *v84
+	this.Height = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Height *= -1
+	}
+	v85 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
+	this.Time = *v85
+	this.TotalVotingPower = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.TotalVotingPower *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 6)
+	}
+	return this
+}
+
+func NewPopulatedSnapshot(r randyTypes, easy bool) *Snapshot {
+	this := &Snapshot{}
+	this.Height = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Height *= -1
+	}
+	this.Format = uint32(r.Uint32())
+	this.Chunks = uint32(r.Uint32())
+	v86 := r.Intn(100)
+	this.Hash = make([]byte, v86)
+	for i := 0; i < v86; i++ {
+		this.Hash[i] = byte(r.Intn(256))
+	}
+	v87 := r.Intn(100)
+	this.Metadata = make([]byte, v87)
+	for i := 0; i < v87; i++ {
+		this.Metadata[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 6)
+	}
+	return this
+}
+
+type randyTypes interface {
+	Float32() float32
+	Float64() float64
+	Int63() int64
+	Int31() int32
+	Uint32() uint32
+	Intn(n int) int
+}
+
+func randUTF8RuneTypes(r randyTypes) rune {
+	ru := r.Intn(62)
+	if ru < 10 {
+		return rune(ru + 48)
+	} else if ru < 36 {
+		return rune(ru + 55)
+	}
+	return rune(ru + 61)
+}
+func randStringTypes(r randyTypes) string {
+	v88 := r.Intn(100)
+	tmps := make([]rune, v88)
+	for i := 0; i < v88; i++ {
+		tmps[i] = randUTF8RuneTypes(r)
+	}
+	return string(tmps)
+}
+func randUnrecognizedTypes(r randyTypes, maxFieldNumber int) (dAtA []byte) {
+	l := r.Intn(5)
+	for i := 0; i < l; i++ {
+		wire := r.Intn(4)
+		if wire == 3 {
+			wire = 5
+		}
+		fieldNumber := maxFieldNumber + r.Intn(100)
+		dAtA = randFieldTypes(dAtA, r, fieldNumber, wire)
+	}
+	return dAtA
+}
+func randFieldTypes(dAtA []byte, r randyTypes, fieldNumber int, wire int) []byte {
+	key := uint32(fieldNumber)<<3 | uint32(wire)
+	switch wire {
+	case 0:
+		dAtA = encodeVarintPopulateTypes(dAtA, uint64(key))
+		v89 := r.Int63()
+		if r.Intn(2) == 0 {
+			v89 *= -1
+		}
+		dAtA = encodeVarintPopulateTypes(dAtA, uint64(v89))
+	case 1:
+		dAtA = encodeVarintPopulateTypes(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	case 2:
+		dAtA = encodeVarintPopulateTypes(dAtA, uint64(key))
+		ll := r.Intn(100)
+		dAtA = encodeVarintPopulateTypes(dAtA, uint64(ll))
+		for j := 0; j < ll; j++ {
+			dAtA = append(dAtA, byte(r.Intn(256)))
+		}
+	default:
+		dAtA = encodeVarintPopulateTypes(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	}
+	return dAtA
+}
+func encodeVarintPopulateTypes(dAtA []byte, v uint64) []byte {
+	for v >= 1<<7 {
+		dAtA = append(dAtA, uint8(uint64(v)&0x7f|0x80))
+		v >>= 7
+	}
+	dAtA = append(dAtA, uint8(v))
+	return dAtA
+}
+func (m *Request) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Value != nil {
+		n += m.Value.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Request_Echo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Echo != nil {
+		l = m.Echo.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_Flush) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Flush != nil {
+		l = m.Flush.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_Info) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Info != nil {
+		l = m.Info.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_SetOption) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SetOption != nil {
+		l = m.SetOption.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_InitChain) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.InitChain != nil {
+		l = m.InitChain.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_Query) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Query != nil {
+		l = m.Query.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_BeginBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BeginBlock != nil {
+		l = m.BeginBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_CheckTx) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CheckTx != nil {
+		l = m.CheckTx.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_EndBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.EndBlock != nil {
+		l = m.EndBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_Commit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_ListSnapshots) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ListSnapshots != nil {
+		l = m.ListSnapshots.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_OfferSnapshot) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OfferSnapshot != nil {
+		l = m.OfferSnapshot.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_LoadSnapshotChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LoadSnapshotChunk != nil {
+		l = m.LoadSnapshotChunk.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_ApplySnapshotChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ApplySnapshotChunk != nil {
+		l = m.ApplySnapshotChunk.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_ExtendVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ExtendVote != nil {
+		l = m.ExtendVote.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_VerifyVoteExtension) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.VerifyVoteExtension != nil {
+		l = m.VerifyVoteExtension.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_DeliverTx) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DeliverTx != nil {
+		l = m.DeliverTx.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_PrepareProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PrepareProposal != nil {
+		l = m.PrepareProposal.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Request_ProcessProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ProcessProposal != nil {
+		l = m.ProcessProposal.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *RequestEcho) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestFlush) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.BlockVersion != 0 {
+		n += 1 + sovTypes(uint64(m.BlockVersion))
+	}
+	if m.P2PVersion != 0 {
+		n += 1 + sovTypes(uint64(m.P2PVersion))
+	}
+	if m.ConsensusParams != nil {
+		l = m.ConsensusParams.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestSetOption) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestInitChain) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)
+	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.ConsensusParams != nil {
+		l = m.ConsensusParams.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Validators) > 0 {
+		for _, e := range m.Validators {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	l = len(m.AppStateBytes)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Prove {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestBeginBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = m.Header.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = m.LastCommitInfo.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.ByzantineValidators) > 0 {
+		for _, e := range m.ByzantineValidators {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestCheckTx) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Tx)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestDeliverTx) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Tx)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestEndBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestCommit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestListSnapshots) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestOfferSnapshot) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Snapshot != nil {
+		l = m.Snapshot.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.AppHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestLoadSnapshotChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Format != 0 {
+		n += 1 + sovTypes(uint64(m.Format))
+	}
+	if m.Chunk != 0 {
+		n += 1 + sovTypes(uint64(m.Chunk))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestApplySnapshotChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovTypes(uint64(m.Index))
+	}
+	l = len(m.Chunk)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestExtendVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestVerifyVoteExtension) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ValidatorAddress)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	l = len(m.VoteExtension)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestPrepareProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	l = m.LocalLastCommit.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.MaxTxBytes != 0 {
+		n += 1 + sovTypes(uint64(m.MaxTxBytes))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestProcessProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = m.Header.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	l = m.ProposedLastCommit.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.ByzantineValidators) > 0 {
+		for _, e := range m.ByzantineValidators {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Response) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Value != nil {
+		n += m.Value.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Response_Exception) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Exception != nil {
+		l = m.Exception.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_Echo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Echo != nil {
+		l = m.Echo.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_Flush) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Flush != nil {
+		l = m.Flush.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_Info) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Info != nil {
+		l = m.Info.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_SetOption) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SetOption != nil {
+		l = m.SetOption.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_InitChain) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.InitChain != nil {
+		l = m.InitChain.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_Query) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Query != nil {
+		l = m.Query.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_BeginBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BeginBlock != nil {
+		l = m.BeginBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_CheckTx) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CheckTx != nil {
+		l = m.CheckTx.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_DeliverTx) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DeliverTx != nil {
+		l = m.DeliverTx.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_EndBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.EndBlock != nil {
+		l = m.EndBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_Commit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_ListSnapshots) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ListSnapshots != nil {
+		l = m.ListSnapshots.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_OfferSnapshot) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OfferSnapshot != nil {
+		l = m.OfferSnapshot.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_LoadSnapshotChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LoadSnapshotChunk != nil {
+		l = m.LoadSnapshotChunk.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_ApplySnapshotChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ApplySnapshotChunk != nil {
+		l = m.ApplySnapshotChunk.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_ExtendVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ExtendVote != nil {
+		l = m.ExtendVote.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_VerifyVoteExtension) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.VerifyVoteExtension != nil {
+		l = m.VerifyVoteExtension.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_PrepareProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PrepareProposal != nil {
+		l = m.PrepareProposal.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Response_ProcessProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ProcessProposal != nil {
+		l = m.ProcessProposal.Size()
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *ResponseException) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseEcho) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseFlush) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.AppVersion != 0 {
+		n += 1 + sovTypes(uint64(m.AppVersion))
+	}
+	if m.LastBlockHeight != 0 {
+		n += 1 + sovTypes(uint64(m.LastBlockHeight))
+	}
+	l = len(m.LastBlockAppHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.ConsensusParams != nil {
+		l = m.ConsensusParams.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseSetOption) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovTypes(uint64(m.Code))
+	}
+	l = len(m.Log)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Info)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseInitChain) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ConsensusParams != nil {
+		l = m.ConsensusParams.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Validators) > 0 {
+		for _, e := range m.Validators {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovTypes(uint64(m.Code))
+	}
+	l = len(m.Log)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Info)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovTypes(uint64(m.Index))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	l = len(m.Codespace)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseBeginBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Tags) > 0 {
+		for _, e := range m.Tags {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseCheckTx) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovTypes(uint64(m.Code))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Log)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Info)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.GasWanted != 0 {
+		n += 1 + sovTypes(uint64(m.GasWanted))
+	}
+	if m.GasUsed != 0 {
+		n += 1 + sovTypes(uint64(m.GasUsed))
+	}
+	if len(m.Tags) > 0 {
+		for _, e := range m.Tags {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	l = len(m.Codespace)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Priority != 0 {
+		n += 1 + sovTypes(uint64(m.Priority))
+	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.MempoolError)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseDeliverTx) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovTypes(uint64(m.Code))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Log)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Info)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.GasWanted != 0 {
+		n += 1 + sovTypes(uint64(m.GasWanted))
+	}
+	if m.GasUsed != 0 {
+		n += 1 + sovTypes(uint64(m.GasUsed))
+	}
+	if len(m.Tags) > 0 {
+		for _, e := range m.Tags {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	l = len(m.Codespace)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseEndBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.ValidatorUpdates) > 0 {
+		for _, e := range m.ValidatorUpdates {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.ConsensusParamUpdates != nil {
+		l = m.ConsensusParamUpdates.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for _, e := range m.Tags {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.PendingState {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseCommit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseListSnapshots) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Snapshots) > 0 {
+		for _, e := range m.Snapshots {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseOfferSnapshot) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Result != 0 {
+		n += 1 + sovTypes(uint64(m.Result))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseLoadSnapshotChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Chunk)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseApplySnapshotChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Result != 0 {
+		n += 1 + sovTypes(uint64(m.Result))
+	}
+	if len(m.RefetchChunks) > 0 {
+		l = 0
+		for _, e := range m.RefetchChunks {
+			l += sovTypes(uint64(e))
+		}
+		n += 1 + sovTypes(uint64(l)) + l
+	}
+	if len(m.RejectSenders) > 0 {
+		for _, s := range m.RejectSenders {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseExtendVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.VoteExtension)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseVerifyVoteExtension) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Result != 0 {
+		n += 1 + sovTypes(uint64(m.Result))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponsePrepareProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseProcessProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Status != 0 {
+		n += 1 + sovTypes(uint64(m.Status))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConsensusParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Block != nil {
+		l = m.Block.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Evidence != nil {
+		l = m.Evidence.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Validator != nil {
+		l = m.Validator.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Timeout != nil {
+		l = m.Timeout.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BlockParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MaxBytes != 0 {
+		n += 1 + sovTypes(uint64(m.MaxBytes))
+	}
+	if m.MaxGas != 0 {
+		n += 1 + sovTypes(uint64(m.MaxGas))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *EvidenceParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MaxAge != 0 {
+		n += 1 + sovTypes(uint64(m.MaxAge))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxAgeDuration)
+	n += 1 + l + sovTypes(uint64(l))
+	if m.MaxBytes != 0 {
+		n += 1 + sovTypes(uint64(m.MaxBytes))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ValidatorParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.PubKeyTypes) > 0 {
+		for _, s := range m.PubKeyTypes {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TimeoutParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.ProposeDelta)
+	n += 1 + l + sovTypes(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.PrevoteDelta)
+	n += 1 + l + sovTypes(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.PrecommitDelta)
+	n += 1 + l + sovTypes(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LastCommitInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	if len(m.Votes) > 0 {
+		for _, e := range m.Votes {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ExtendedCommitInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	if len(m.Votes) > 0 {
+		for _, e := range m.Votes {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Header) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Version.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.ChainID)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)
+	n += 1 + l + sovTypes(uint64(l))
+	if m.NumTxs != 0 {
+		n += 1 + sovTypes(uint64(m.NumTxs))
+	}
+	if m.TotalTxs != 0 {
+		n += 1 + sovTypes(uint64(m.TotalTxs))
+	}
+	l = m.LastBlockId.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.LastCommitHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.DataHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ValidatorsHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.NextValidatorsHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ConsensusHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.AppHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.LastResultsHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.EvidenceHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ProposerAddress)
+	if l > 0 {
+		n += 2 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Version) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Block != 0 {
+		n += 1 + sovTypes(uint64(m.Block))
+	}
+	if m.App != 0 {
+		n += 1 + sovTypes(uint64(m.App))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BlockID) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = m.PartsHeader.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PartSetHeader) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Total != 0 {
+		n += 1 + sovTypes(uint64(m.Total))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Validator) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Power != 0 {
+		n += 1 + sovTypes(uint64(m.Power))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ValidatorUpdate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.PubKey.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.Power != 0 {
+		n += 1 + sovTypes(uint64(m.Power))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *VoteInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Validator.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.SignedLastBlock {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ExtendedVoteInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Validator.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.SignedLastBlock {
+		n += 2
+	}
+	l = len(m.VoteExtension)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PubKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Evidence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = m.Validator.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)
+	n += 1 + l + sovTypes(uint64(l))
+	if m.TotalVotingPower != 0 {
+		n += 1 + sovTypes(uint64(m.TotalVotingPower))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Snapshot) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Format != 0 {
+		n += 1 + sovTypes(uint64(m.Format))
+	}
+	if m.Chunks != 0 {
+		n += 1 + sovTypes(uint64(m.Chunks))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Metadata)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovTypes(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozTypes(x uint64) (n int) {
+	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Request) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Request: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Echo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestEcho{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_Echo{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flush", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestFlush{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_Flush{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestInfo{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_Info{v}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SetOption", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestSetOption{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_SetOption{v}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitChain", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestInitChain{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_InitChain{v}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestQuery{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_Query{v}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestBeginBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_BeginBlock{v}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckTx", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestCheckTx{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_CheckTx{v}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestEndBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_EndBlock{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestCommit{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_Commit{v}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ListSnapshots", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestListSnapshots{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_ListSnapshots{v}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OfferSnapshot", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestOfferSnapshot{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_OfferSnapshot{v}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LoadSnapshotChunk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestLoadSnapshotChunk{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_LoadSnapshotChunk{v}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplySnapshotChunk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestApplySnapshotChunk{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_ApplySnapshotChunk{v}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtendVote", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestExtendVote{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_ExtendVote{v}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VerifyVoteExtension", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestVerifyVoteExtension{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_VerifyVoteExtension{v}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTx", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestDeliverTx{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_DeliverTx{v}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrepareProposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestPrepareProposal{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_PrepareProposal{v}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessProposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &RequestProcessProposal{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Request_ProcessProposal{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestEcho) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestEcho: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestEcho: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestFlush) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestFlush: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestFlush: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockVersion", wireType)
+			}
+			m.BlockVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockVersion |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field P2PVersion", wireType)
+			}
+			m.P2PVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.P2PVersion |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParams", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConsensusParams == nil {
+				m.ConsensusParams = &ConsensusParams{}
+			}
+			if err := m.ConsensusParams.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestSetOption) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestSetOption: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestSetOption: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestInitChain) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestInitChain: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestInitChain: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParams", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConsensusParams == nil {
+				m.ConsensusParams = &ConsensusParams{}
+			}
+			if err := m.ConsensusParams.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validators", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Validators = append(m.Validators, ValidatorUpdate{})
+			if err := m.Validators[len(m.Validators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppStateBytes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppStateBytes = append(m.AppStateBytes[:0], dAtA[iNdEx:postIndex]...)
+			if m.AppStateBytes == nil {
+				m.AppStateBytes = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prove", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Prove = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestBeginBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestBeginBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestBeginBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastCommitInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.LastCommitInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ByzantineValidators", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ByzantineValidators = append(m.ByzantineValidators, Evidence{})
+			if err := m.ByzantineValidators[len(m.ByzantineValidators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
 
-func (m *ResponseCheckTx) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Code != 0 {
-		n += 1 + sovTypes(uint64(m.Code))
-	}
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = len(m.Log)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = len(m.Info)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	if m.GasWanted != 0 {
-		n += 1 + sovTypes(uint64(m.GasWanted))
-	}
-	if m.GasUsed != 0 {
-		n += 1 + sovTypes(uint64(m.GasUsed))
-	}
-	if len(m.Tags) > 0 {
-		for _, e := range m.Tags {
-			l = e.Size()
-			n += 1 + l + sovTypes(uint64(l))
-		}
-	}
-	l = len(m.Codespace)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
-
-func (m *ResponseDeliverTx) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Code != 0 {
-		n += 1 + sovTypes(uint64(m.Code))
-	}
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = len(m.Log)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = len(m.Info)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	if m.GasWanted != 0 {
-		n += 1 + sovTypes(uint64(m.GasWanted))
-	}
-	if m.GasUsed != 0 {
-		n += 1 + sovTypes(uint64(m.GasUsed))
-	}
-	if len(m.Tags) > 0 {
-		for _, e := range m.Tags {
-			l = e.Size()
-			n += 1 + l + sovTypes(uint64(l))
+func (m *RequestCheckTx) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
 		}
-	}
-	l = len(m.Codespace)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *ResponseEndBlock) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.ValidatorUpdates) > 0 {
-		for _, e := range m.ValidatorUpdates {
-			l = e.Size()
-			n += 1 + l + sovTypes(uint64(l))
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestCheckTx: wiretype end group for non-group")
 		}
-	}
-	if m.ConsensusParamUpdates != nil {
-		l = m.ConsensusParamUpdates.Size()
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	if len(m.Tags) > 0 {
-		for _, e := range m.Tags {
-			l = e.Size()
-			n += 1 + l + sovTypes(uint64(l))
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestCheckTx: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tx", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tx = append(m.Tx[:0], dAtA[iNdEx:postIndex]...)
+			if m.Tx == nil {
+				m.Tx = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *ResponseCommit) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *ConsensusParams) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Block != nil {
-		l = m.Block.Size()
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	if m.Evidence != nil {
-		l = m.Evidence.Size()
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	if m.Validator != nil {
-		l = m.Validator.Size()
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *BlockParams) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.MaxBytes != 0 {
-		n += 1 + sovTypes(uint64(m.MaxBytes))
-	}
-	if m.MaxGas != 0 {
-		n += 1 + sovTypes(uint64(m.MaxGas))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
 
-func (m *EvidenceParams) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.MaxAge != 0 {
-		n += 1 + sovTypes(uint64(m.MaxAge))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
-
-func (m *ValidatorParams) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.PubKeyTypes) > 0 {
-		for _, s := range m.PubKeyTypes {
-			l = len(s)
-			n += 1 + l + sovTypes(uint64(l))
+func (m *RequestDeliverTx) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
 		}
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
-
-func (m *LastCommitInfo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Round != 0 {
-		n += 1 + sovTypes(uint64(m.Round))
-	}
-	if len(m.Votes) > 0 {
-		for _, e := range m.Votes {
-			l = e.Size()
-			n += 1 + l + sovTypes(uint64(l))
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestDeliverTx: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestDeliverTx: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tx", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tx = append(m.Tx[:0], dAtA[iNdEx:postIndex]...)
+			if m.Tx == nil {
+				m.Tx = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
-}
 
-func (m *Header) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.Version.Size()
-	n += 1 + l + sovTypes(uint64(l))
-	l = len(m.ChainID)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	if m.Height != 0 {
-		n += 1 + sovTypes(uint64(m.Height))
-	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)
-	n += 1 + l + sovTypes(uint64(l))
-	if m.NumTxs != 0 {
-		n += 1 + sovTypes(uint64(m.NumTxs))
-	}
-	if m.TotalTxs != 0 {
-		n += 1 + sovTypes(uint64(m.TotalTxs))
-	}
-	l = m.LastBlockId.Size()
-	n += 1 + l + sovTypes(uint64(l))
-	l = len(m.LastCommitHash)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = len(m.DataHash)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = len(m.ValidatorsHash)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = len(m.NextValidatorsHash)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = len(m.ConsensusHash)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = len(m.AppHash)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = len(m.LastResultsHash)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = len(m.EvidenceHash)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = len(m.ProposerAddress)
-	if l > 0 {
-		n += 2 + l + sovTypes(uint64(l))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+func (m *RequestEndBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestEndBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestEndBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *Version) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Block != 0 {
-		n += 1 + sovTypes(uint64(m.Block))
-	}
-	if m.App != 0 {
-		n += 1 + sovTypes(uint64(m.App))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
-
-func (m *BlockID) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = m.PartsHeader.Size()
-	n += 1 + l + sovTypes(uint64(l))
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *RequestCommit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestCommit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestCommit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *PartSetHeader) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Total != 0 {
-		n += 1 + sovTypes(uint64(m.Total))
-	}
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
-
-func (m *Validator) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Address)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+func (m *RequestListSnapshots) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestListSnapshots: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestListSnapshots: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	if m.Power != 0 {
-		n += 1 + sovTypes(uint64(m.Power))
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+func (m *RequestOfferSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestOfferSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestOfferSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshot", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Snapshot == nil {
+				m.Snapshot = &Snapshot{}
+			}
+			if err := m.Snapshot.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.AppHash == nil {
+				m.AppHash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *ValidatorUpdate) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.PubKey.Size()
-	n += 1 + l + sovTypes(uint64(l))
-	if m.Power != 0 {
-		n += 1 + sovTypes(uint64(m.Power))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
-
-func (m *VoteInfo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.Validator.Size()
-	n += 1 + l + sovTypes(uint64(l))
-	if m.SignedLastBlock {
-		n += 2
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *RequestLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestLoadSnapshotChunk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestLoadSnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
+			}
+			m.Format = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Format |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunk", wireType)
+			}
+			m.Chunk = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Chunk |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *PubKey) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+func (m *RequestApplySnapshotChunk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestApplySnapshotChunk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestApplySnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunk", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Chunk = append(m.Chunk[:0], dAtA[iNdEx:postIndex]...)
+			if m.Chunk == nil {
+				m.Chunk = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *Evidence) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Type)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
-	}
-	l = m.Validator.Size()
-	n += 1 + l + sovTypes(uint64(l))
-	if m.Height != 0 {
-		n += 1 + sovTypes(uint64(m.Height))
-	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)
-	n += 1 + l + sovTypes(uint64(l))
-	if m.TotalVotingPower != 0 {
-		n += 1 + sovTypes(uint64(m.TotalVotingPower))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
+	return nil
 }
-
-func sovTypes(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
+func (m *RequestExtendVote) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestExtendVote: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestExtendVote: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
 	}
-	return n
-}
-func sozTypes(x uint64) (n int) {
-	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *Request) Unmarshal(dAtA []byte) error {
+func (m *RequestVerifyVoteExtension) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9631,17 +17475,17 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Request: wiretype end group for non-group")
+			return fmt.Errorf("proto: RequestVerifyVoteExtension: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RequestVerifyVoteExtension: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 2:
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Echo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -9651,29 +17495,28 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &RequestEcho{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
-			m.Value = &Request_Echo{v}
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flush", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorAddress", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -9683,29 +17526,47 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &RequestFlush{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.ValidatorAddress = append(m.ValidatorAddress[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValidatorAddress == nil {
+				m.ValidatorAddress = []byte{}
 			}
-			m.Value = &Request_Flush{v}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field VoteExtension", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -9715,29 +17576,79 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &RequestInfo{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.VoteExtension = append(m.VoteExtension[:0], dAtA[iNdEx:postIndex]...)
+			if m.VoteExtension == nil {
+				m.VoteExtension = []byte{}
 			}
-			m.Value = &Request_Info{v}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SetOption", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestPrepareProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestPrepareProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestPrepareProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			var msglen int
+			m.Height = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -9747,27 +17658,14 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Height |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &RequestSetOption{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &Request_SetOption{v}
-			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InitChain", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LocalLastCommit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9791,17 +17689,15 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &RequestInitChain{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.LocalLastCommit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Request_InitChain{v}
 			iNdEx = postIndex
-		case 7:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -9811,29 +17707,26 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &RequestQuery{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &Request_Query{v}
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxTxBytes", wireType)
 			}
-			var msglen int
+			m.MaxTxBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -9843,29 +17736,67 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.MaxTxBytes |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &RequestBeginBlock{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestProcessProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
 			}
-			m.Value = &Request_BeginBlock{v}
-			iNdEx = postIndex
-		case 9:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestProcessProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestProcessProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckTx", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -9875,27 +17806,26 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &RequestCheckTx{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
-			m.Value = &Request_CheckTx{v}
 			iNdEx = postIndex
-		case 11:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9919,17 +17849,15 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &RequestEndBlock{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Request_EndBlock{v}
 			iNdEx = postIndex
-		case 12:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -9939,27 +17867,24 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &RequestCommit{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &Request_Commit{v}
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 19:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTx", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposedLastCommit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9983,68 +17908,15 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &RequestDeliverTx{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ProposedLastCommit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Request_DeliverTx{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RequestEcho) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RequestEcho: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RequestEcho: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ByzantineValidators", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10054,72 +17926,23 @@ func (m *RequestEcho) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RequestFlush) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			m.ByzantineValidators = append(m.ByzantineValidators, Evidence{})
+			if err := m.ByzantineValidators[len(m.ByzantineValidators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RequestFlush: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RequestFlush: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -10142,7 +17965,7 @@ func (m *RequestFlush) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RequestInfo) Unmarshal(dAtA []byte) error {
+func (m *Response) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10165,17 +17988,17 @@ func (m *RequestInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RequestInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RequestInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Exception", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10185,26 +18008,29 @@ func (m *RequestInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Version = string(dAtA[iNdEx:postIndex])
+			v := &ResponseException{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_Exception{v}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockVersion", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Echo", wireType)
 			}
-			m.BlockVersion = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10214,16 +18040,29 @@ func (m *RequestInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BlockVersion |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseEcho{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_Echo{v}
+			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field P2PVersion", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flush", wireType)
 			}
-			m.P2PVersion = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10233,67 +18072,61 @@ func (m *RequestInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.P2PVersion |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RequestSetOption) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
+			v := &ResponseFlush{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			if iNdEx >= l {
+			m.Value = &Response_Flush{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			v := &ResponseInfo{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RequestSetOption: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RequestSetOption: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Value = &Response_Info{v}
+			iNdEx = postIndex
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SetOption", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10303,26 +18136,29 @@ func (m *RequestSetOption) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = string(dAtA[iNdEx:postIndex])
+			v := &ResponseSetOption{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_SetOption{v}
 			iNdEx = postIndex
-		case 2:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InitChain", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10332,75 +18168,59 @@ func (m *RequestSetOption) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
+			v := &ResponseInitChain{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthTypes
+			m.Value = &Response_InitChain{v}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RequestInitChain) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			if iNdEx >= l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RequestInitChain: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RequestInitChain: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			v := &ResponseQuery{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_Query{v}
+			iNdEx = postIndex
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10424,15 +18244,17 @@ func (m *RequestInitChain) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
+			v := &ResponseBeginBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Value = &Response_BeginBlock{v}
 			iNdEx = postIndex
-		case 2:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckTx", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10442,24 +18264,27 @@ func (m *RequestInitChain) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ChainId = string(dAtA[iNdEx:postIndex])
+			v := &ResponseCheckTx{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_CheckTx{v}
 			iNdEx = postIndex
-		case 3:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParams", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTx", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10483,16 +18308,15 @@ func (m *RequestInitChain) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConsensusParams == nil {
-				m.ConsensusParams = &ConsensusParams{}
-			}
-			if err := m.ConsensusParams.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &ResponseDeliverTx{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Value = &Response_DeliverTx{v}
 			iNdEx = postIndex
-		case 4:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validators", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10516,16 +18340,17 @@ func (m *RequestInitChain) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Validators = append(m.Validators, ValidatorUpdate{})
-			if err := m.Validators[len(m.Validators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &ResponseEndBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Value = &Response_EndBlock{v}
 			iNdEx = postIndex
-		case 5:
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppStateBytes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10535,79 +18360,61 @@ func (m *RequestInitChain) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AppStateBytes = append(m.AppStateBytes[:0], dAtA[iNdEx:postIndex]...)
-			if m.AppStateBytes == nil {
-				m.AppStateBytes = []byte{}
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
+			v := &ResponseCommit{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthTypes
+			m.Value = &Response_Commit{v}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ListSnapshots", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RequestQuery) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			if iNdEx >= l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			v := &ResponseListSnapshots{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RequestQuery: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RequestQuery: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Value = &Response_ListSnapshots{v}
+			iNdEx = postIndex
+		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OfferSnapshot", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10617,28 +18424,29 @@ func (m *RequestQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			v := &ResponseOfferSnapshot{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			m.Value = &Response_OfferSnapshot{v}
 			iNdEx = postIndex
-		case 2:
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LoadSnapshotChunk", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10648,26 +18456,29 @@ func (m *RequestQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Path = string(dAtA[iNdEx:postIndex])
+			v := &ResponseLoadSnapshotChunk{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_LoadSnapshotChunk{v}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplySnapshotChunk", wireType)
 			}
-			m.Height = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10677,16 +18488,29 @@ func (m *RequestQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Prove", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			var v int
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ResponseApplySnapshotChunk{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &Response_ApplySnapshotChunk{v}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtendVote", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10696,68 +18520,29 @@ func (m *RequestQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Prove = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RequestBeginBlock) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			v := &ResponseExtendVote{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RequestBeginBlock: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RequestBeginBlock: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Value = &Response_ExtendVote{v}
+			iNdEx = postIndex
+		case 18:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field VerifyVoteExtension", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10767,26 +18552,27 @@ func (m *RequestBeginBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
-			if m.Hash == nil {
-				m.Hash = []byte{}
+			v := &ResponseVerifyVoteExtension{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			m.Value = &Response_VerifyVoteExtension{v}
 			iNdEx = postIndex
-		case 2:
+		case 19:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PrepareProposal", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10810,13 +18596,15 @@ func (m *RequestBeginBlock) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &ResponsePrepareProposal{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Value = &Response_PrepareProposal{v}
 			iNdEx = postIndex
-		case 3:
+		case 20:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastCommitInfo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessProposal", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10840,15 +18628,68 @@ func (m *RequestBeginBlock) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.LastCommitInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &ResponseProcessProposal{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Value = &Response_ProcessProposal{v}
 			iNdEx = postIndex
-		case 4:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseException) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseException: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseException: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ByzantineValidators", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10858,22 +18699,20 @@ func (m *RequestBeginBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ByzantineValidators = append(m.ByzantineValidators, Evidence{})
-			if err := m.ByzantineValidators[len(m.ByzantineValidators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10897,7 +18736,7 @@ func (m *RequestBeginBlock) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RequestCheckTx) Unmarshal(dAtA []byte) error {
+func (m *ResponseEcho) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10920,17 +18759,17 @@ func (m *RequestCheckTx) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RequestCheckTx: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseEcho: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RequestCheckTx: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseEcho: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tx", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -10940,22 +18779,20 @@ func (m *RequestCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tx = append(m.Tx[:0], dAtA[iNdEx:postIndex]...)
-			if m.Tx == nil {
-				m.Tx = []byte{}
-			}
+			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10979,7 +18816,58 @@ func (m *RequestCheckTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RequestDeliverTx) Unmarshal(dAtA []byte) error {
+func (m *ResponseFlush) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseFlush: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseFlush: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10998,19 +18886,115 @@ func (m *RequestDeliverTx) Unmarshal(dAtA []byte) error {
 			if b < 0x80 {
 				break
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RequestDeliverTx: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RequestDeliverTx: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppVersion", wireType)
+			}
+			m.AppVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppVersion |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastBlockHeight", wireType)
+			}
+			m.LastBlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastBlockHeight |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tx", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LastBlockAppHash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -11034,67 +19018,16 @@ func (m *RequestDeliverTx) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tx = append(m.Tx[:0], dAtA[iNdEx:postIndex]...)
-			if m.Tx == nil {
-				m.Tx = []byte{}
+			m.LastBlockAppHash = append(m.LastBlockAppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.LastBlockAppHash == nil {
+				m.LastBlockAppHash = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RequestEndBlock) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RequestEndBlock: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RequestEndBlock: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParams", wireType)
 			}
-			m.Height = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11104,62 +19037,25 @@ func (m *RequestEndBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RequestCommit) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.ConsensusParams == nil {
+				m.ConsensusParams = &ConsensusParams{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.ConsensusParams.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RequestCommit: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RequestCommit: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -11182,7 +19078,7 @@ func (m *RequestCommit) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Response) Unmarshal(dAtA []byte) error {
+func (m *ResponseSetOption) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11205,17 +19101,17 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseSetOption: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseSetOption: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Exception", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			var msglen int
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11225,29 +19121,16 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Code |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &ResponseException{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &Response_Exception{v}
-			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Echo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11257,29 +19140,26 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseEcho{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &Response_Echo{v}
+			m.Log = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flush", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11289,27 +19169,75 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseFlush{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Info = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			m.Value = &Response_Flush{v}
-			iNdEx = postIndex
-		case 4:
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseInitChain: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseInitChain: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParams", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11333,15 +19261,16 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseInfo{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.ConsensusParams == nil {
+				m.ConsensusParams = &ConsensusParams{}
+			}
+			if err := m.ConsensusParams.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_Info{v}
 			iNdEx = postIndex
-		case 5:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SetOption", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Validators", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11365,17 +19294,67 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseSetOption{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Validators = append(m.Validators, ValidatorUpdate{})
+			if err := m.Validators[len(m.Validators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Value = &Response_SetOption{v}
 			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InitChain", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var msglen int
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11385,29 +19364,16 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Code |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &ResponseInitChain{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &Response_InitChain{v}
-			iNdEx = postIndex
-		case 7:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11417,29 +19383,26 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseQuery{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &Response_Query{v}
+			m.Log = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11449,29 +19412,26 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseBeginBlock{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &Response_BeginBlock{v}
+			m.Info = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 9:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckTx", wireType)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var msglen int
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11481,29 +19441,16 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Index |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &ResponseCheckTx{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Value = &Response_CheckTx{v}
-			iNdEx = postIndex
-		case 10:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTx", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11513,29 +19460,28 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseDeliverTx{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
 			}
-			m.Value = &Response_DeliverTx{v}
 			iNdEx = postIndex
-		case 11:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11545,27 +19491,26 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseEndBlock{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
-			m.Value = &Response_EndBlock{v}
 			iNdEx = postIndex
-		case 12:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11589,66 +19534,35 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ResponseCommit{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if m.Proof == nil {
+				m.Proof = &merkle.Proof{}
 			}
-			m.Value = &Response_Commit{v}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseException) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseException: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseException: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11673,7 +19587,7 @@ func (m *ResponseException) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Error = string(dAtA[iNdEx:postIndex])
+			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11697,7 +19611,7 @@ func (m *ResponseException) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseEcho) Unmarshal(dAtA []byte) error {
+func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11720,17 +19634,17 @@ func (m *ResponseEcho) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseEcho: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseBeginBlock: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseEcho: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseBeginBlock: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11740,20 +19654,22 @@ func (m *ResponseEcho) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			m.Tags = append(m.Tags, common.KVPair{})
+			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11777,7 +19693,7 @@ func (m *ResponseEcho) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseFlush) Unmarshal(dAtA []byte) error {
+func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11800,66 +19716,65 @@ func (m *ResponseFlush) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseFlush: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseCheckTx: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseFlush: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseCheckTx: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthTypes
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseInfo: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseInfo: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11884,11 +19799,11 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = string(dAtA[iNdEx:postIndex])
+			m.Log = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11913,13 +19828,13 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Version = string(dAtA[iNdEx:postIndex])
+			m.Info = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AppVersion", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GasWanted", wireType)
 			}
-			m.AppVersion = 0
+			m.GasWanted = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11929,16 +19844,16 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.AppVersion |= (uint64(b) & 0x7F) << shift
+				m.GasWanted |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastBlockHeight", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GasUsed", wireType)
 			}
-			m.LastBlockHeight = 0
+			m.GasUsed = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11948,16 +19863,16 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LastBlockHeight |= (int64(b) & 0x7F) << shift
+				m.GasUsed |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 5:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastBlockAppHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -11967,79 +19882,57 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LastBlockAppHash = append(m.LastBlockAppHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.LastBlockAppHash == nil {
-				m.LastBlockAppHash = []byte{}
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
+			m.Tags = append(m.Tags, common.KVPair{})
+			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthTypes
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseSetOption) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			if iNdEx >= l {
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseSetOption: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseSetOption: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Codespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
 			}
-			m.Code = 0
+			m.Priority = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12049,14 +19942,14 @@ func (m *ResponseSetOption) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Code |= (uint32(b) & 0x7F) << shift
+				m.Priority |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12081,11 +19974,11 @@ func (m *ResponseSetOption) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Log = string(dAtA[iNdEx:postIndex])
+			m.Sender = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MempoolError", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12110,7 +20003,7 @@ func (m *ResponseSetOption) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Info = string(dAtA[iNdEx:postIndex])
+			m.MempoolError = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12134,7 +20027,7 @@ func (m *ResponseSetOption) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
+func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12157,17 +20050,17 @@ func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseInitChain: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseDeliverTx: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseInitChain: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseDeliverTx: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParams", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			var msglen int
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12177,30 +20070,16 @@ func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Code |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ConsensusParams == nil {
-				m.ConsensusParams = &ConsensusParams{}
-			}
-			if err := m.ConsensusParams.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validators", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12210,93 +20089,23 @@ func (m *ResponseInitChain) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Validators = append(m.Validators, ValidatorUpdate{})
-			if err := m.Validators[len(m.Validators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseQuery: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseQuery: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
-			}
-			m.Code = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Code |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		case 3:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
@@ -12357,9 +20166,9 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GasWanted", wireType)
 			}
-			m.Index = 0
+			m.GasWanted = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12369,16 +20178,35 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= (int64(b) & 0x7F) << shift
+				m.GasWanted |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasUsed", wireType)
+			}
+			m.GasUsed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasUsed |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12388,28 +20216,28 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
+			m.Tags = append(m.Tags, common.KVPair{})
+			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 7:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12419,26 +20247,106 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Codespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseEndBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseEndBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorUpdates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.ValidatorUpdates = append(m.ValidatorUpdates, ValidatorUpdate{})
+			if err := m.ValidatorUpdates[len(m.ValidatorUpdates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 8:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParamUpdates", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12462,18 +20370,18 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Proof == nil {
-				m.Proof = &merkle.Proof{}
+			if m.ConsensusParamUpdates == nil {
+				m.ConsensusParamUpdates = &ConsensusParams{}
 			}
-			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ConsensusParamUpdates.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
 			}
-			m.Height = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12483,16 +20391,28 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 10:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			var stringLen uint64
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, common.KVPair{})
+			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingState", wireType)
+			}
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12502,21 +20422,12 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Codespace = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			m.PendingState = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -12539,7 +20450,7 @@ func (m *ResponseQuery) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
+func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12562,17 +20473,17 @@ func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseBeginBlock: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseCommit: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseBeginBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseCommit: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12582,21 +20493,21 @@ func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tags = append(m.Tags, common.KVPair{})
-			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -12621,7 +20532,7 @@ func (m *ResponseBeginBlock) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
+func (m *ResponseListSnapshots) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12644,36 +20555,17 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseCheckTx: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseListSnapshots: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseCheckTx: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseListSnapshots: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
-			}
-			m.Code = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Code |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Snapshots", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12683,105 +20575,79 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			m.Snapshots = append(m.Snapshots, &Snapshot{})
+			if err := m.Snapshots[len(m.Snapshots)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Log = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseOfferSnapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Info = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GasWanted", wireType)
-			}
-			m.GasWanted = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.GasWanted |= (int64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-		case 6:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseOfferSnapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseOfferSnapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GasUsed", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
 			}
-			m.GasUsed = 0
+			m.Result = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12791,47 +20657,67 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GasUsed |= (int64(b) & 0x7F) << shift
+				m.Result |= (ResponseOfferSnapshot_Result(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tags = append(m.Tags, common.KVPair{})
-			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseLoadSnapshotChunk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
 			}
-			iNdEx = postIndex
-		case 8:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseLoadSnapshotChunk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseLoadSnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunk", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12841,20 +20727,22 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Codespace = string(dAtA[iNdEx:postIndex])
+			m.Chunk = append(m.Chunk[:0], dAtA[iNdEx:postIndex]...)
+			if m.Chunk == nil {
+				m.Chunk = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12878,7 +20766,7 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
+func (m *ResponseApplySnapshotChunk) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12901,17 +20789,17 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseDeliverTx: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseApplySnapshotChunk: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseDeliverTx: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseApplySnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
 			}
-			m.Code = 0
+			m.Result = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -12921,45 +20809,87 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Code |= (uint32(b) & 0x7F) << shift
+				m.Result |= (ResponseApplySnapshotChunk_Result(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint32(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
+				m.RefetchChunks = append(m.RefetchChunks, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthTypes
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				var count int
+				for _, integer := range dAtA {
+					if integer < 128 {
+						count++
+					}
 				}
+				elementCount = count
+				if elementCount != 0 && len(m.RefetchChunks) == 0 {
+					m.RefetchChunks = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowTypes
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint32(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RefetchChunks = append(m.RefetchChunks, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefetchChunks", wireType)
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Log", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RejectSenders", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12984,13 +20914,64 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Log = string(dAtA[iNdEx:postIndex])
+			m.RejectSenders = append(m.RejectSenders, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 4:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseExtendVote) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseExtendVote: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseExtendVote: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field VoteExtension", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13000,26 +20981,79 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Info = string(dAtA[iNdEx:postIndex])
+			m.VoteExtension = append(m.VoteExtension[:0], dAtA[iNdEx:postIndex]...)
+			if m.VoteExtension == nil {
+				m.VoteExtension = []byte{}
+			}
 			iNdEx = postIndex
-		case 5:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseVerifyVoteExtension) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseVerifyVoteExtension: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseVerifyVoteExtension: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GasWanted", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
 			}
-			m.GasWanted = 0
+			m.Result = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13029,35 +21063,67 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GasWanted |= (int64(b) & 0x7F) << shift
+				m.Result |= (ResponseVerifyVoteExtension_Result(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GasUsed", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponsePrepareProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			m.GasUsed = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.GasUsed |= (int64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-		case 7:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponsePrepareProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponsePrepareProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13067,28 +21133,77 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tags = append(m.Tags, common.KVPair{})
-			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
 			}
-			var stringLen uint64
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseProcessProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseProcessProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseProcessProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13098,21 +21213,11 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Status |= (ResponseProcessProposal_Status(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Codespace = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13135,7 +21240,7 @@ func (m *ResponseDeliverTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
+func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13158,15 +21263,15 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseEndBlock: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConsensusParams: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseEndBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConsensusParams: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorUpdates", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13190,14 +21295,16 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ValidatorUpdates = append(m.ValidatorUpdates, ValidatorUpdate{})
-			if err := m.ValidatorUpdates[len(m.ValidatorUpdates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Block == nil {
+				m.Block = &BlockParams{}
+			}
+			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParamUpdates", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13221,16 +21328,16 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConsensusParamUpdates == nil {
-				m.ConsensusParamUpdates = &ConsensusParams{}
+			if m.Evidence == nil {
+				m.Evidence = &EvidenceParams{}
 			}
-			if err := m.ConsensusParamUpdates.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Evidence.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13254,8 +21361,43 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tags = append(m.Tags, common.KVPair{})
-			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Validator == nil {
+				m.Validator = &ValidatorParams{}
+			}
+			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Timeout == nil {
+				m.Timeout = &TimeoutParams{}
+			}
+			if err := m.Timeout.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -13281,7 +21423,7 @@ func (m *ResponseEndBlock) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
+func (m *BlockParams) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13304,17 +21446,17 @@ func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseCommit: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockParams: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseCommit: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockParams: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBytes", wireType)
 			}
-			var byteLen int
+			m.MaxBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13324,23 +21466,30 @@ func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				m.MaxBytes |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxGas", wireType)
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			m.MaxGas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxGas |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13363,7 +21512,7 @@ func (m *ResponseCommit) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
+func (m *EvidenceParams) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13386,17 +21535,17 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConsensusParams: wiretype end group for non-group")
+			return fmt.Errorf("proto: EvidenceParams: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConsensusParams: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EvidenceParams: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAge", wireType)
 			}
-			var msglen int
+			m.MaxAge = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13406,28 +21555,14 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.MaxAge |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Block == nil {
-				m.Block = &BlockParams{}
-			}
-			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAgeDuration", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13451,18 +21586,15 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Evidence == nil {
-				m.Evidence = &EvidenceParams{}
-			}
-			if err := m.Evidence.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.MaxAgeDuration, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBytes", wireType)
 			}
-			var msglen int
+			m.MaxBytes = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13472,25 +21604,11 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.MaxBytes |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Validator == nil {
-				m.Validator = &ValidatorParams{}
-			}
-			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13513,7 +21631,7 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BlockParams) Unmarshal(dAtA []byte) error {
+func (m *ValidatorParams) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13536,17 +21654,17 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BlockParams: wiretype end group for non-group")
+			return fmt.Errorf("proto: ValidatorParams: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BlockParams: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ValidatorParams: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxBytes", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKeyTypes", wireType)
 			}
-			m.MaxBytes = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13556,30 +21674,21 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxBytes |= (int64(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxGas", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
 			}
-			m.MaxGas = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.MaxGas |= (int64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.PubKeyTypes = append(m.PubKeyTypes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13602,7 +21711,7 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *EvidenceParams) Unmarshal(dAtA []byte) error {
+func (m *TimeoutParams) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13625,17 +21734,17 @@ func (m *EvidenceParams) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: EvidenceParams: wiretype end group for non-group")
+			return fmt.Errorf("proto: TimeoutParams: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EvidenceParams: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TimeoutParams: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxAge", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposeDelta", wireType)
 			}
-			m.MaxAge = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13645,11 +21754,82 @@ func (m *EvidenceParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxAge |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.ProposeDelta, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrevoteDelta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.PrevoteDelta, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrecommitDelta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.PrecommitDelta, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13672,7 +21852,7 @@ func (m *EvidenceParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ValidatorParams) Unmarshal(dAtA []byte) error {
+func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13695,17 +21875,36 @@ func (m *ValidatorParams) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ValidatorParams: wiretype end group for non-group")
+			return fmt.Errorf("proto: LastCommitInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ValidatorParams: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LastCommitInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PubKeyTypes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Votes", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -13715,20 +21914,22 @@ func (m *ValidatorParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PubKeyTypes = append(m.PubKeyTypes, string(dAtA[iNdEx:postIndex]))
+			m.Votes = append(m.Votes, VoteInfo{})
+			if err := m.Votes[len(m.Votes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13752,7 +21953,7 @@ func (m *ValidatorParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
+func (m *ExtendedCommitInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13775,10 +21976,10 @@ func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LastCommitInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExtendedCommitInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LastCommitInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExtendedCommitInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -13826,7 +22027,7 @@ func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Votes = append(m.Votes, VoteInfo{})
+			m.Votes = append(m.Votes, ExtendedVoteInfo{})
 			if err := m.Votes[len(m.Votes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
@@ -14610,7 +22811,89 @@ func (m *PartSetHeader) Unmarshal(dAtA []byte) error {
 			}
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Validator) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Validator: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Validator: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -14634,11 +22917,30 @@ func (m *PartSetHeader) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
-			if m.Hash == nil {
-				m.Hash = []byte{}
+			m.Address = append(m.Address[:0], dAtA[iNdEx:postIndex]...)
+			if m.Address == nil {
+				m.Address = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
+			}
+			m.Power = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Power |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -14661,7 +22963,7 @@ func (m *PartSetHeader) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Validator) Unmarshal(dAtA []byte) error {
+func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14684,17 +22986,17 @@ func (m *Validator) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Validator: wiretype end group for non-group")
+			return fmt.Errorf("proto: ValidatorUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Validator: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ValidatorUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14704,24 +23006,23 @@ func (m *Validator) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Address = append(m.Address[:0], dAtA[iNdEx:postIndex]...)
-			if m.Address == nil {
-				m.Address = []byte{}
+			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
 			}
@@ -14762,7 +23063,7 @@ func (m *Validator) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
+func (m *VoteInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14785,15 +23086,15 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ValidatorUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: VoteInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ValidatorUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VoteInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Validator", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14817,15 +23118,15 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Validator.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedLastBlock", wireType)
 			}
-			m.Power = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -14835,11 +23136,12 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Power |= (int64(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.SignedLastBlock = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -14862,7 +23164,7 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *VoteInfo) Unmarshal(dAtA []byte) error {
+func (m *ExtendedVoteInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14885,10 +23187,10 @@ func (m *VoteInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: VoteInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExtendedVoteInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: VoteInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExtendedVoteInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -14941,6 +23243,37 @@ func (m *VoteInfo) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.SignedLastBlock = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VoteExtension", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VoteExtension = append(m.VoteExtension[:0], dAtA[iNdEx:postIndex]...)
+			if m.VoteExtension == nil {
+				m.VoteExtension = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -15252,6 +23585,176 @@ func (m *Evidence) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Snapshot) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Snapshot: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Snapshot: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
+			}
+			m.Format = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Format |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunks", wireType)
+			}
+			m.Chunks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Chunks |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Metadata = append(m.Metadata[:0], dAtA[iNdEx:postIndex]...)
+			if m.Metadata == nil {
+				m.Metadata = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -15357,149 +23860,226 @@ var (
 	ErrIntOverflowTypes   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("abci/types/types.proto", fileDescriptor_types_a177e47fab90f91d) }
+func init() { proto.RegisterFile("abci/types/types.proto", fileDescriptor_types_a70d46dbc1a61099) }
 func init() {
-	golang_proto.RegisterFile("abci/types/types.proto", fileDescriptor_types_a177e47fab90f91d)
-}
-
-var fileDescriptor_types_a177e47fab90f91d = []byte{
-	// 2203 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x58, 0xcf, 0x73, 0x1c, 0x47,
-	0xf5, 0xd7, 0xec, 0xef, 0x79, 0xab, 0xfd, 0xe1, 0xb6, 0x6c, 0xaf, 0xf7, 0x9b, 0xaf, 0xe4, 0x1a,
-	0x43, 0x22, 0x11, 0x67, 0x95, 0x28, 0x98, 0x92, 0xe3, 0x40, 0x95, 0x56, 0x36, 0x48, 0x95, 0x00,
-	0x62, 0x6c, 0x8b, 0x0b, 0x55, 0x53, 0xbd, 0x3b, 0xad, 0xdd, 0x29, 0xed, 0xce, 0x4c, 0x66, 0x7a,
-	0x95, 0x15, 0x47, 0xce, 0x39, 0xe4, 0xc0, 0x9f, 0xc0, 0x81, 0x3f, 0x21, 0x47, 0x4e, 0x54, 0x8e,
-	0x1c, 0x38, 0x1b, 0x10, 0xc5, 0x01, 0xae, 0x14, 0x55, 0x1c, 0xa9, 0x7e, 0xdd, 0xf3, 0x53, 0xb3,
-	0x26, 0x0e, 0x9c, 0xb8, 0x48, 0xd3, 0xfd, 0x3e, 0xaf, 0x7f, 0xbc, 0x7d, 0xef, 0x7d, 0xde, 0x6b,
-	0xb8, 0x4d, 0x47, 0x63, 0x67, 0x97, 0x5f, 0xfa, 0x2c, 0x94, 0x7f, 0x07, 0x7e, 0xe0, 0x71, 0x8f,
-	0x54, 0x71, 0xd0, 0x7f, 0x67, 0xe2, 0xf0, 0xe9, 0x62, 0x34, 0x18, 0x7b, 0xf3, 0xdd, 0x89, 0x37,
-	0xf1, 0x76, 0x51, 0x3a, 0x5a, 0x9c, 0xe1, 0x08, 0x07, 0xf8, 0x25, 0xb5, 0xfa, 0x8f, 0x53, 0x70,
-	0xce, 0x5c, 0x9b, 0x05, 0x73, 0xc7, 0xe5, 0xe9, 0xcf, 0x71, 0x70, 0xe9, 0x73, 0x6f, 0x77, 0xce,
-	0x82, 0xf3, 0x19, 0x53, 0xff, 0x94, 0xf2, 0xfe, 0xbf, 0x55, 0x9e, 0x39, 0xa3, 0x70, 0x77, 0xec,
-	0xcd, 0xe7, 0x9e, 0x9b, 0x3e, 0x6c, 0x7f, 0x6b, 0xe2, 0x79, 0x93, 0x19, 0x4b, 0x0e, 0xc7, 0x9d,
-	0x39, 0x0b, 0x39, 0x9d, 0xfb, 0x12, 0x60, 0xfc, 0xb6, 0x02, 0x75, 0x93, 0x7d, 0xb2, 0x60, 0x21,
-	0x27, 0xdb, 0x50, 0x61, 0xe3, 0xa9, 0xd7, 0x2b, 0xdd, 0xd3, 0xb6, 0x9b, 0x7b, 0x64, 0x20, 0x17,
-	0x52, 0xd2, 0xa7, 0xe3, 0xa9, 0x77, 0xb4, 0x66, 0x22, 0x82, 0xbc, 0x0d, 0xd5, 0xb3, 0xd9, 0x22,
-	0x9c, 0xf6, 0xca, 0x08, 0xbd, 0x99, 0x85, 0x7e, 0x5f, 0x88, 0x8e, 0xd6, 0x4c, 0x89, 0x11, 0xcb,
-	0x3a, 0xee, 0x99, 0xd7, 0xab, 0x14, 0x2d, 0x7b, 0xec, 0x9e, 0xe1, 0xb2, 0x02, 0x41, 0xf6, 0x01,
-	0x42, 0xc6, 0x2d, 0xcf, 0xe7, 0x8e, 0xe7, 0xf6, 0xaa, 0x88, 0xbf, 0x93, 0xc5, 0x3f, 0x63, 0xfc,
-	0xc7, 0x28, 0x3e, 0x5a, 0x33, 0xf5, 0x30, 0x1a, 0x08, 0x4d, 0xc7, 0x75, 0xb8, 0x35, 0x9e, 0x52,
-	0xc7, 0xed, 0xd5, 0x8a, 0x34, 0x8f, 0x5d, 0x87, 0x1f, 0x0a, 0xb1, 0xd0, 0x74, 0xa2, 0x81, 0xb8,
-	0xca, 0x27, 0x0b, 0x16, 0x5c, 0xf6, 0xea, 0x45, 0x57, 0xf9, 0x89, 0x10, 0x89, 0xab, 0x20, 0x86,
-	0x3c, 0x86, 0xe6, 0x88, 0x4d, 0x1c, 0xd7, 0x1a, 0xcd, 0xbc, 0xf1, 0x79, 0xaf, 0x81, 0x2a, 0xbd,
-	0xac, 0xca, 0x50, 0x00, 0x86, 0x42, 0x7e, 0xb4, 0x66, 0xc2, 0x28, 0x1e, 0x91, 0x3d, 0x68, 0x8c,
-	0xa7, 0x6c, 0x7c, 0x6e, 0xf1, 0x65, 0x4f, 0x47, 0xcd, 0x5b, 0x59, 0xcd, 0x43, 0x21, 0x7d, 0xbe,
-	0x3c, 0x5a, 0x33, 0xeb, 0x63, 0xf9, 0x49, 0x1e, 0x82, 0xce, 0x5c, 0x5b, 0x6d, 0xd7, 0x44, 0xa5,
-	0xdb, 0xb9, 0xdf, 0xc5, 0xb5, 0xa3, 0xcd, 0x1a, 0x4c, 0x7d, 0x93, 0x01, 0xd4, 0x84, 0x33, 0x38,
-	0xbc, 0xb7, 0x8e, 0x3a, 0x1b, 0xb9, 0x8d, 0x50, 0x76, 0xb4, 0x66, 0x2a, 0x94, 0x30, 0x9f, 0xcd,
-	0x66, 0xce, 0x05, 0x0b, 0xc4, 0xe1, 0x6e, 0x16, 0x99, 0xef, 0x89, 0x94, 0xe3, 0xf1, 0x74, 0x3b,
-	0x1a, 0x0c, 0xeb, 0x50, 0xbd, 0xa0, 0xb3, 0x05, 0x33, 0xde, 0x82, 0x66, 0xca, 0x53, 0x48, 0x0f,
-	0xea, 0x73, 0x16, 0x86, 0x74, 0xc2, 0x7a, 0xda, 0x3d, 0x6d, 0x5b, 0x37, 0xa3, 0xa1, 0xd1, 0x86,
-	0xf5, 0xb4, 0x9f, 0x18, 0xf3, 0x58, 0x51, 0xf8, 0x82, 0x50, 0xbc, 0x60, 0x41, 0x28, 0x1c, 0x40,
-	0x29, 0xaa, 0x21, 0xb9, 0x0f, 0x2d, 0xb4, 0x83, 0x15, 0xc9, 0x85, 0x9f, 0x56, 0xcc, 0x75, 0x9c,
-	0x3c, 0x55, 0xa0, 0x2d, 0x68, 0xfa, 0x7b, 0x7e, 0x0c, 0x29, 0x23, 0x04, 0xfc, 0x3d, 0x5f, 0x01,
-	0x8c, 0x0f, 0xa0, 0x9b, 0x77, 0x25, 0xd2, 0x85, 0xf2, 0x39, 0xbb, 0x54, 0xfb, 0x89, 0x4f, 0xb2,
-	0xa1, 0xae, 0x85, 0x7b, 0xe8, 0xa6, 0xba, 0xe3, 0xe7, 0xa5, 0x58, 0x39, 0xf6, 0x26, 0xb2, 0x0f,
-	0x15, 0x11, 0x54, 0xa8, 0xdd, 0xdc, 0xeb, 0x0f, 0x64, 0xc4, 0x0d, 0xa2, 0x88, 0x1b, 0x3c, 0x8f,
-	0x22, 0x6e, 0xd8, 0xf8, 0xf2, 0xe5, 0xd6, 0xda, 0xe7, 0x7f, 0xd8, 0xd2, 0x4c, 0xd4, 0x20, 0x77,
-	0x85, 0x43, 0x50, 0xc7, 0xb5, 0x1c, 0x5b, 0xed, 0x53, 0xc7, 0xf1, 0xb1, 0x4d, 0x0e, 0xa0, 0x3b,
-	0xf6, 0xdc, 0x90, 0xb9, 0xe1, 0x22, 0xb4, 0x7c, 0x1a, 0xd0, 0x79, 0xa8, 0x62, 0x2d, 0xfa, 0xf9,
-	0x0f, 0x23, 0xf1, 0x09, 0x4a, 0xcd, 0xce, 0x38, 0x3b, 0x41, 0x3e, 0x04, 0xb8, 0xa0, 0x33, 0xc7,
-	0xa6, 0xdc, 0x0b, 0xc2, 0x5e, 0xe5, 0x5e, 0x39, 0xa5, 0x7c, 0x1a, 0x09, 0x5e, 0xf8, 0x36, 0xe5,
-	0x6c, 0x58, 0x11, 0x27, 0x33, 0x53, 0x78, 0xf2, 0x26, 0x74, 0xa8, 0xef, 0x5b, 0x21, 0xa7, 0x9c,
-	0x59, 0xa3, 0x4b, 0xce, 0x42, 0x8c, 0xc7, 0x75, 0xb3, 0x45, 0x7d, 0xff, 0x99, 0x98, 0x1d, 0x8a,
-	0x49, 0xc3, 0x8e, 0x7f, 0x4d, 0x0c, 0x15, 0x42, 0xa0, 0x62, 0x53, 0x4e, 0xd1, 0x1a, 0xeb, 0x26,
-	0x7e, 0x8b, 0x39, 0x9f, 0xf2, 0xa9, 0xba, 0x23, 0x7e, 0x93, 0xdb, 0x50, 0x9b, 0x32, 0x67, 0x32,
-	0xe5, 0x78, 0xad, 0xb2, 0xa9, 0x46, 0xc2, 0xf0, 0x7e, 0xe0, 0x5d, 0x30, 0xcc, 0x16, 0x0d, 0x53,
-	0x0e, 0x8c, 0xbf, 0x68, 0x70, 0xe3, 0x5a, 0x78, 0x89, 0x75, 0xa7, 0x34, 0x9c, 0x46, 0x7b, 0x89,
-	0x6f, 0xf2, 0xb6, 0x58, 0x97, 0xda, 0x2c, 0x50, 0x59, 0xac, 0xa5, 0x6e, 0x7c, 0x84, 0x93, 0xea,
-	0xa2, 0x0a, 0x42, 0x9e, 0x42, 0x77, 0x46, 0x43, 0x6e, 0xc9, 0x28, 0xb0, 0x30, 0x4b, 0x95, 0x33,
-	0x91, 0xf9, 0x31, 0x8d, 0xa2, 0x45, 0x38, 0xa7, 0x52, 0x6f, 0xcf, 0x32, 0xb3, 0xe4, 0x08, 0x36,
-	0x46, 0x97, 0x3f, 0xa7, 0x2e, 0x77, 0x5c, 0x66, 0x5d, 0xb3, 0x79, 0x47, 0x2d, 0xf5, 0xf4, 0xc2,
-	0xb1, 0x99, 0x3b, 0x8e, 0x8c, 0x7d, 0x33, 0x56, 0x89, 0x7f, 0x8c, 0xd0, 0xb8, 0x07, 0xed, 0x6c,
-	0x2e, 0x20, 0x6d, 0x28, 0xf1, 0xa5, 0xba, 0x61, 0x89, 0x2f, 0x0d, 0x23, 0xf6, 0xc0, 0x38, 0x20,
-	0xaf, 0x61, 0x76, 0xa0, 0x93, 0x4b, 0x0e, 0x29, 0x73, 0x6b, 0x69, 0x73, 0x1b, 0x1d, 0x68, 0x65,
-	0x72, 0x82, 0xf1, 0x59, 0x15, 0x1a, 0x26, 0x0b, 0x7d, 0xe1, 0x4c, 0x64, 0x1f, 0x74, 0xb6, 0x1c,
-	0x33, 0x99, 0x8e, 0xb5, 0x5c, 0xb2, 0x93, 0x98, 0xa7, 0x91, 0x5c, 0xa4, 0x85, 0x18, 0x4c, 0x76,
-	0x32, 0x54, 0x72, 0x33, 0xaf, 0x94, 0xe6, 0x92, 0x07, 0x59, 0x2e, 0xd9, 0xc8, 0x61, 0x73, 0x64,
-	0xb2, 0x93, 0x21, 0x93, 0xfc, 0xc2, 0x19, 0x36, 0x79, 0x54, 0xc0, 0x26, 0xf9, 0xe3, 0xaf, 0xa0,
-	0x93, 0x47, 0x05, 0x74, 0xd2, 0xbb, 0xb6, 0x57, 0x21, 0x9f, 0x3c, 0xc8, 0xf2, 0x49, 0xfe, 0x3a,
-	0x39, 0x42, 0xf9, 0xb0, 0x88, 0x50, 0xee, 0xe6, 0x74, 0x56, 0x32, 0xca, 0xfb, 0xd7, 0x18, 0xe5,
-	0x76, 0x4e, 0xb5, 0x80, 0x52, 0x1e, 0x65, 0x72, 0x3d, 0x14, 0xde, 0xad, 0x38, 0xd9, 0x93, 0xef,
-	0x5c, 0x67, 0xa3, 0x3b, 0xf9, 0x9f, 0xb6, 0x88, 0x8e, 0x76, 0x73, 0x74, 0x74, 0x2b, 0x7f, 0xca,
-	0x1c, 0x1f, 0x25, 0xac, 0xb2, 0x23, 0xe2, 0x3e, 0xe7, 0x69, 0x22, 0x47, 0xb0, 0x20, 0xf0, 0x02,
-	0x95, 0xb0, 0xe5, 0xc0, 0xd8, 0x16, 0x99, 0x28, 0xf1, 0xaf, 0x57, 0x30, 0x10, 0x3a, 0x7d, 0xca,
-	0xbb, 0x8c, 0x2f, 0xb4, 0x44, 0x17, 0x23, 0x3a, 0x9d, 0xc5, 0x74, 0x95, 0xc5, 0x52, 0xc4, 0x54,
-	0xca, 0x12, 0xd3, 0x16, 0x34, 0x45, 0xae, 0xcc, 0x71, 0x0e, 0xf5, 0x23, 0xce, 0x21, 0xdf, 0x82,
-	0x1b, 0x98, 0x67, 0x24, 0x7d, 0xa9, 0x40, 0xac, 0x60, 0x20, 0x76, 0x84, 0x40, 0x5a, 0x4c, 0x26,
-	0xc0, 0x77, 0xe0, 0x66, 0x0a, 0x2b, 0xd6, 0xc5, 0x1c, 0x27, 0x93, 0x6f, 0x37, 0x46, 0x1f, 0xf8,
-	0xfe, 0x11, 0x0d, 0xa7, 0xc6, 0x0f, 0x13, 0x03, 0x25, 0x7c, 0x46, 0xa0, 0x32, 0xf6, 0x6c, 0x79,
-	0xef, 0x96, 0x89, 0xdf, 0x82, 0xe3, 0x66, 0xde, 0x04, 0x0f, 0xa7, 0x9b, 0xe2, 0x53, 0xa0, 0xe2,
-	0x50, 0xd2, 0x65, 0xcc, 0x18, 0xbf, 0xd4, 0x92, 0xf5, 0x12, 0x8a, 0x2b, 0x62, 0x23, 0xed, 0x3f,
-	0x61, 0xa3, 0xd2, 0xeb, 0xb1, 0x91, 0x71, 0xa5, 0x25, 0x3f, 0x59, 0xcc, 0x33, 0x5f, 0xef, 0x8a,
-	0xc2, 0x7b, 0x1c, 0xd7, 0x66, 0x4b, 0x34, 0x69, 0xd9, 0x94, 0x83, 0xa8, 0x04, 0xa8, 0xa1, 0x99,
-	0xb3, 0x25, 0x40, 0x1d, 0xe7, 0xe4, 0x80, 0xdc, 0x47, 0x7e, 0xf2, 0xce, 0x54, 0xa8, 0xb6, 0x06,
-	0xaa, 0x50, 0x3f, 0x11, 0x93, 0xa6, 0x94, 0xa5, 0xb2, 0xad, 0x9e, 0x21, 0xb7, 0x37, 0x40, 0x17,
-	0x07, 0x0d, 0x7d, 0x3a, 0x66, 0x18, 0x79, 0xba, 0x99, 0x4c, 0x18, 0x27, 0x40, 0xae, 0x47, 0x3c,
-	0xf9, 0x00, 0x2a, 0x9c, 0x4e, 0x84, 0xbd, 0x85, 0xc9, 0xda, 0x03, 0x59, 0xe4, 0x0f, 0x3e, 0x3a,
-	0x3d, 0xa1, 0x4e, 0x30, 0xbc, 0x2d, 0x4c, 0xf5, 0xb7, 0x97, 0x5b, 0x6d, 0x81, 0x79, 0xe0, 0xcd,
-	0x1d, 0xce, 0xe6, 0x3e, 0xbf, 0x34, 0x51, 0xc7, 0xf8, 0xbb, 0x26, 0x98, 0x20, 0x93, 0x09, 0x0a,
-	0x0d, 0x17, 0xb9, 0x7b, 0x29, 0x45, 0xda, 0x5f, 0xcd, 0x98, 0xff, 0x0f, 0x30, 0xa1, 0xa1, 0xf5,
-	0x29, 0x75, 0x39, 0xb3, 0x95, 0x45, 0xf5, 0x09, 0x0d, 0x7f, 0x8a, 0x13, 0xa2, 0xc2, 0x11, 0xe2,
-	0x45, 0xc8, 0x6c, 0x34, 0x6d, 0xd9, 0xac, 0x4f, 0x68, 0xf8, 0x22, 0x64, 0x76, 0x7c, 0xaf, 0xfa,
-	0xeb, 0xdf, 0x2b, 0x6b, 0xc7, 0x46, 0xde, 0x8e, 0xff, 0x48, 0xf9, 0x70, 0x42, 0x92, 0xff, 0xfb,
-	0xf7, 0xfe, 0xab, 0x26, 0x6a, 0x83, 0x6c, 0x1a, 0x26, 0xc7, 0x70, 0x23, 0x8e, 0x23, 0x6b, 0x81,
-	0xf1, 0x15, 0xf9, 0xd2, 0xab, 0xc3, 0xaf, 0x7b, 0x91, 0x9d, 0x0e, 0xc9, 0x8f, 0xe0, 0x4e, 0x2e,
-	0x0b, 0xc4, 0x0b, 0x96, 0x5e, 0x99, 0x0c, 0x6e, 0x65, 0x93, 0x41, 0xb4, 0x5e, 0x64, 0x89, 0xf2,
-	0xd7, 0xf0, 0xec, 0x6f, 0x88, 0x42, 0x29, 0x4d, 0x1e, 0x45, 0xbf, 0xa5, 0xf1, 0x2b, 0x0d, 0x3a,
-	0xb9, 0xc3, 0x90, 0x6d, 0xa8, 0x4a, 0xfe, 0xd2, 0x32, 0xed, 0x28, 0x5a, 0x4b, 0x9d, 0x57, 0x02,
-	0xc8, 0x7b, 0xd0, 0x60, 0xaa, 0x66, 0x53, 0x17, 0xbc, 0x95, 0x2b, 0xe5, 0x14, 0x3e, 0x86, 0x91,
-	0x6f, 0x83, 0x1e, 0x9b, 0x2d, 0x57, 0xaf, 0xc7, 0x56, 0x56, 0x4a, 0x09, 0xd0, 0x38, 0x84, 0x66,
-	0x6a, 0x7b, 0xf2, 0x7f, 0xa0, 0xcf, 0xe9, 0x52, 0x15, 0xdd, 0xb2, 0x5c, 0x6b, 0xcc, 0xe9, 0x12,
-	0xeb, 0x6d, 0x72, 0x07, 0xea, 0x42, 0x38, 0xa1, 0xd2, 0xe8, 0x65, 0xb3, 0x36, 0xa7, 0xcb, 0x1f,
-	0xd0, 0xd0, 0xd8, 0x81, 0x76, 0xf6, 0x58, 0x11, 0x34, 0x22, 0x40, 0x09, 0x3d, 0x98, 0x30, 0xe3,
-	0x21, 0x74, 0x72, 0xa7, 0x21, 0x06, 0xb4, 0xfc, 0xc5, 0xc8, 0x3a, 0x67, 0x97, 0x16, 0x1e, 0x17,
-	0x5d, 0x44, 0x37, 0x9b, 0xfe, 0x62, 0xf4, 0x11, 0xbb, 0x7c, 0x2e, 0xa6, 0x8c, 0x67, 0xd0, 0xce,
-	0x96, 0xc3, 0x22, 0x45, 0x06, 0xde, 0xc2, 0xb5, 0x71, 0xfd, 0xaa, 0x29, 0x07, 0xa2, 0xa3, 0xbe,
-	0xf0, 0xa4, 0x57, 0xa4, 0xeb, 0xdf, 0x53, 0x8f, 0xb3, 0x54, 0x11, 0x2d, 0x31, 0xc6, 0x2f, 0xaa,
-	0x50, 0x93, 0xb5, 0x39, 0x19, 0x64, 0x3b, 0x3f, 0xe1, 0x12, 0x4a, 0x53, 0xce, 0x2a, 0xc5, 0x98,
-	0x76, 0xdf, 0xcc, 0xb7, 0x4f, 0xc3, 0xe6, 0xd5, 0xcb, 0xad, 0x3a, 0x52, 0xd6, 0xf1, 0x93, 0xa4,
-	0x97, 0x5a, 0xd5, 0x6a, 0x44, 0x8d, 0x5b, 0xe5, 0xb5, 0x1b, 0xb7, 0x3b, 0x50, 0x77, 0x17, 0x73,
-	0x8b, 0x2f, 0x43, 0x15, 0xfa, 0x35, 0x77, 0x31, 0x7f, 0xbe, 0xc4, 0x9f, 0x8e, 0x7b, 0x9c, 0xce,
-	0x50, 0x24, 0x03, 0xbf, 0x81, 0x13, 0x42, 0xb8, 0x0f, 0xad, 0x14, 0xb3, 0x3b, 0xb6, 0xaa, 0x10,
-	0xdb, 0x69, 0x0f, 0x3c, 0x7e, 0xa2, 0x6e, 0xd9, 0x8c, 0x99, 0xfe, 0xd8, 0x26, 0xdb, 0xd9, 0x3e,
-	0x05, 0x0b, 0x82, 0x06, 0xfa, 0x79, 0xaa, 0x15, 0x11, 0xe5, 0x80, 0x38, 0x80, 0xf0, 0x7c, 0x09,
-	0xd1, 0x11, 0xd2, 0x10, 0x13, 0x28, 0x7c, 0x0b, 0x3a, 0x09, 0xa7, 0x4a, 0x08, 0xc8, 0x55, 0x92,
-	0x69, 0x04, 0xbe, 0x0b, 0x1b, 0x2e, 0x5b, 0x72, 0x2b, 0x8f, 0x6e, 0x22, 0x9a, 0x08, 0xd9, 0x69,
-	0x56, 0xe3, 0x9b, 0xd0, 0x4e, 0x72, 0x03, 0x62, 0xd7, 0x65, 0xb7, 0x18, 0xcf, 0x22, 0xec, 0x2e,
-	0x34, 0xe2, 0x8a, 0xa6, 0x85, 0x80, 0x3a, 0x95, 0x85, 0x4c, 0x5c, 0x23, 0x05, 0x2c, 0x5c, 0xcc,
-	0xb8, 0x5a, 0xa4, 0x8d, 0x18, 0xac, 0x91, 0x4c, 0x39, 0x8f, 0xd8, 0xfb, 0xd0, 0x8a, 0x42, 0x4e,
-	0xe2, 0x3a, 0x88, 0x5b, 0x8f, 0x26, 0x11, 0xb4, 0x03, 0x5d, 0x3f, 0xf0, 0x7c, 0x2f, 0x64, 0x81,
-	0x45, 0x6d, 0x3b, 0x60, 0x61, 0xd8, 0xeb, 0xca, 0xf5, 0xa2, 0xf9, 0x03, 0x39, 0x6d, 0xbc, 0x07,
-	0xf5, 0xa8, 0x54, 0xdb, 0x80, 0xea, 0x30, 0x4e, 0x0f, 0x15, 0x53, 0x0e, 0x04, 0x29, 0x1c, 0xf8,
-	0xbe, 0x7a, 0x70, 0x10, 0x9f, 0xc6, 0xcf, 0xa0, 0xae, 0x7e, 0xb0, 0xc2, 0x36, 0xf4, 0xbb, 0xb0,
-	0xee, 0xd3, 0x40, 0x5c, 0x23, 0xdd, 0x8c, 0x46, 0xcd, 0xc0, 0x09, 0x0d, 0xf8, 0x33, 0xc6, 0x33,
-	0x3d, 0x69, 0x13, 0xf1, 0x72, 0xca, 0x78, 0x04, 0xad, 0x0c, 0x46, 0x1c, 0x0b, 0xfd, 0x28, 0x8a,
-	0x34, 0x1c, 0xc4, 0x3b, 0x97, 0x92, 0x9d, 0x8d, 0xc7, 0xa0, 0xc7, 0xbf, 0x8d, 0xa8, 0x59, 0xa3,
-	0xab, 0x6b, 0xca, 0xdc, 0x72, 0x88, 0x7d, 0xb6, 0xf7, 0x29, 0x0b, 0x54, 0x4c, 0xc8, 0x81, 0xf1,
-	0x22, 0x95, 0x19, 0x64, 0x9a, 0x26, 0x0f, 0xa0, 0xae, 0x32, 0x83, 0x8a, 0xca, 0xa8, 0xa3, 0x3e,
-	0xc1, 0xd4, 0x10, 0x75, 0xd4, 0x32, 0x51, 0x24, 0xcb, 0x96, 0xd2, 0xcb, 0xce, 0xa0, 0x11, 0x45,
-	0x7f, 0x36, 0x45, 0xca, 0x15, 0xbb, 0xf9, 0x14, 0xa9, 0x16, 0x4d, 0x80, 0xc2, 0x3b, 0x42, 0x67,
-	0xe2, 0x32, 0xdb, 0x4a, 0x42, 0x08, 0xf7, 0x68, 0x98, 0x1d, 0x29, 0xf8, 0x38, 0x8a, 0x17, 0xe3,
-	0x5d, 0xa8, 0xc9, 0xb3, 0x09, 0xfb, 0x88, 0x95, 0xa3, 0x32, 0x5e, 0x7c, 0x17, 0xf2, 0xc4, 0xef,
-	0x35, 0x68, 0x44, 0xc9, 0xb3, 0x50, 0x29, 0x73, 0xe8, 0xd2, 0x57, 0x3d, 0xf4, 0x7f, 0x3f, 0xf1,
-	0x3c, 0x00, 0x22, 0xf3, 0xcb, 0x85, 0xc7, 0x1d, 0x77, 0x62, 0x49, 0x5b, 0xcb, 0x1c, 0xd4, 0x45,
-	0xc9, 0x29, 0x0a, 0x4e, 0xc4, 0xfc, 0xde, 0x67, 0x55, 0xe8, 0x1c, 0x0c, 0x0f, 0x8f, 0x0f, 0x7c,
-	0x7f, 0xe6, 0x8c, 0x29, 0xb6, 0x06, 0xbb, 0x50, 0xc1, 0xee, 0xa8, 0xe0, 0x75, 0xb7, 0x5f, 0xd4,
-	0xa6, 0x93, 0x3d, 0xa8, 0x62, 0x93, 0x44, 0x8a, 0x1e, 0x79, 0xfb, 0x85, 0xdd, 0xba, 0xd8, 0x44,
-	0xb6, 0x51, 0xd7, 0xdf, 0x7a, 0xfb, 0x45, 0x2d, 0x3b, 0xf9, 0x1e, 0xe8, 0x49, 0xf7, 0xb2, 0xea,
-	0xc5, 0xb7, 0xbf, 0xb2, 0x79, 0x17, 0xfa, 0x49, 0xa5, 0xb7, 0xea, 0xe1, 0xb2, 0xbf, 0xb2, 0xcb,
-	0x25, 0xfb, 0x50, 0x8f, 0xea, 0xe3, 0xe2, 0x37, 0xd9, 0xfe, 0x8a, 0xc6, 0x5a, 0x98, 0x47, 0x36,
-	0x24, 0x45, 0x0f, 0xc7, 0xfd, 0xc2, 0xee, 0x9f, 0x3c, 0x84, 0x9a, 0x2a, 0x5a, 0x0a, 0xdf, 0x65,
-	0xfb, 0xc5, 0xed, 0xb1, 0xb8, 0x64, 0xd2, 0x92, 0xad, 0x7a, 0xdc, 0xee, 0xaf, 0x7c, 0xa6, 0x20,
-	0x07, 0x00, 0xa9, 0xbe, 0x62, 0xe5, 0xab, 0x75, 0x7f, 0xf5, 0xf3, 0x03, 0x79, 0x0c, 0x8d, 0xe4,
-	0x49, 0xa9, 0xf8, 0x1d, 0xba, 0xbf, 0xea, 0x45, 0x60, 0xf8, 0xc6, 0x3f, 0xff, 0xb4, 0xa9, 0xfd,
-	0xfa, 0x6a, 0x53, 0xfb, 0xe2, 0x6a, 0x53, 0xfb, 0xf2, 0x6a, 0x53, 0xfb, 0xdd, 0xd5, 0xa6, 0xf6,
-	0xc7, 0xab, 0x4d, 0xed, 0x37, 0x7f, 0xde, 0xd4, 0x46, 0x35, 0x74, 0xff, 0xf7, 0xff, 0x15, 0x00,
-	0x00, 0xff, 0xff, 0x38, 0x2d, 0x52, 0x86, 0x77, 0x19, 0x00, 0x00,
+	golang_proto.RegisterFile("abci/types/types.proto", fileDescriptor_types_a70d46dbc1a61099)
+}
+
+var fileDescriptor_types_a70d46dbc1a61099 = []byte{
+	// 3435 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x5a, 0xcd, 0x73, 0xdc, 0xd6,
+	0x91, 0x27, 0xe6, 0x7b, 0x7a, 0x3e, 0xf9, 0x48, 0x51, 0xa3, 0x91, 0x4d, 0xca, 0xd0, 0xda, 0xa6,
+	0x56, 0x12, 0x65, 0xd3, 0xeb, 0x2d, 0xc9, 0xb2, 0x77, 0x97, 0xa4, 0xc6, 0x3b, 0xb4, 0xbe, 0x68,
+	0x90, 0xa2, 0xd7, 0x55, 0x2e, 0xc3, 0xe0, 0xe0, 0x71, 0x06, 0xab, 0x19, 0x00, 0x06, 0x30, 0x34,
+	0x99, 0xa3, 0x2b, 0x55, 0xb9, 0x25, 0x3e, 0x24, 0x55, 0xa9, 0x4a, 0xfe, 0x80, 0xe4, 0x96, 0x54,
+	0xe5, 0x90, 0x63, 0x8e, 0x3e, 0xe4, 0x90, 0x83, 0xcf, 0x4e, 0xa2, 0x54, 0x72, 0xc8, 0x2d, 0x87,
+	0x54, 0x52, 0x95, 0x4b, 0xea, 0x7d, 0x61, 0xf0, 0x30, 0xc0, 0x90, 0xb4, 0x93, 0x4b, 0x2e, 0x24,
+	0x5e, 0xbf, 0xee, 0xc6, 0x7b, 0x3d, 0xef, 0x75, 0xf7, 0xaf, 0x1b, 0xb0, 0x64, 0x1c, 0xf4, 0xac,
+	0x5b, 0xc1, 0x89, 0x8b, 0x7d, 0xf6, 0x77, 0xcd, 0xf5, 0x9c, 0xc0, 0x41, 0x79, 0x3a, 0x68, 0xdf,
+	0xec, 0x5b, 0xc1, 0x60, 0x7c, 0xb0, 0xd6, 0x73, 0x46, 0xb7, 0xfa, 0x4e, 0xdf, 0xb9, 0x45, 0x67,
+	0x0f, 0xc6, 0x87, 0x74, 0x44, 0x07, 0xf4, 0x89, 0x49, 0xb5, 0xef, 0x46, 0xd8, 0x03, 0x6c, 0x9b,
+	0xd8, 0x1b, 0x59, 0x76, 0x10, 0x7d, 0xec, 0x79, 0x27, 0x6e, 0xe0, 0xdc, 0x1a, 0x61, 0xef, 0xe9,
+	0x10, 0xf3, 0x7f, 0x5c, 0xf8, 0xf6, 0xa9, 0xc2, 0x43, 0xeb, 0xc0, 0xbf, 0xd5, 0x73, 0x46, 0x23,
+	0xc7, 0x8e, 0x2e, 0xb6, 0xbd, 0xd2, 0x77, 0x9c, 0xfe, 0x10, 0x4f, 0x16, 0x17, 0x58, 0x23, 0xec,
+	0x07, 0xc6, 0xc8, 0xe5, 0x0c, 0xcb, 0x71, 0x06, 0x73, 0xec, 0x19, 0x81, 0xe5, 0xd8, 0x6c, 0x5e,
+	0xfd, 0x71, 0x19, 0x8a, 0x1a, 0xfe, 0x78, 0x8c, 0xfd, 0x00, 0xad, 0x42, 0x0e, 0xf7, 0x06, 0x4e,
+	0x2b, 0x73, 0x45, 0x59, 0xad, 0xac, 0xa3, 0x35, 0xf6, 0x22, 0x3e, 0xdb, 0xe9, 0x0d, 0x9c, 0xee,
+	0x9c, 0x46, 0x39, 0xd0, 0x75, 0xc8, 0x1f, 0x0e, 0xc7, 0xfe, 0xa0, 0x95, 0xa5, 0xac, 0x0b, 0x32,
+	0xeb, 0xdb, 0x64, 0xaa, 0x3b, 0xa7, 0x31, 0x1e, 0xa2, 0xd6, 0xb2, 0x0f, 0x9d, 0x56, 0x2e, 0x49,
+	0xed, 0xb6, 0x7d, 0x48, 0xd5, 0x12, 0x0e, 0x74, 0x1b, 0xc0, 0xc7, 0x81, 0xee, 0xb8, 0x64, 0x81,
+	0xad, 0x3c, 0xe5, 0xbf, 0x28, 0xf3, 0xef, 0xe2, 0xe0, 0x31, 0x9d, 0xee, 0xce, 0x69, 0x65, 0x5f,
+	0x0c, 0x88, 0xa4, 0x65, 0x5b, 0x81, 0xde, 0x1b, 0x18, 0x96, 0xdd, 0x2a, 0x24, 0x49, 0x6e, 0xdb,
+	0x56, 0xb0, 0x45, 0xa6, 0x89, 0xa4, 0x25, 0x06, 0x64, 0x2b, 0x1f, 0x8f, 0xb1, 0x77, 0xd2, 0x2a,
+	0x26, 0x6d, 0xe5, 0x5d, 0x32, 0x45, 0xb6, 0x42, 0x79, 0xd0, 0x5d, 0xa8, 0x1c, 0xe0, 0xbe, 0x65,
+	0xeb, 0x07, 0x43, 0xa7, 0xf7, 0xb4, 0x55, 0xa2, 0x22, 0x2d, 0x59, 0x64, 0x93, 0x30, 0x6c, 0x92,
+	0xf9, 0xee, 0x9c, 0x06, 0x07, 0xe1, 0x08, 0xad, 0x43, 0xa9, 0x37, 0xc0, 0xbd, 0xa7, 0x7a, 0x70,
+	0xdc, 0x2a, 0x53, 0xc9, 0x0b, 0xb2, 0xe4, 0x16, 0x99, 0xdd, 0x3b, 0xee, 0xce, 0x69, 0xc5, 0x1e,
+	0x7b, 0x44, 0xaf, 0x43, 0x19, 0xdb, 0x26, 0x7f, 0x5d, 0x85, 0x0a, 0x2d, 0xc5, 0x7e, 0x17, 0xdb,
+	0x14, 0x2f, 0x2b, 0x61, 0xfe, 0x8c, 0xd6, 0xa0, 0x40, 0x0e, 0x8b, 0x15, 0xb4, 0xaa, 0x54, 0x66,
+	0x31, 0xf6, 0x22, 0x3a, 0xd7, 0x9d, 0xd3, 0x38, 0x17, 0xba, 0x07, 0xf5, 0xa1, 0xe5, 0x07, 0xba,
+	0x6f, 0x1b, 0xae, 0x3f, 0x70, 0x02, 0xbf, 0x55, 0xa3, 0x72, 0x97, 0x65, 0xb9, 0x07, 0x96, 0x1f,
+	0xec, 0x0a, 0x96, 0xee, 0x9c, 0x56, 0x1b, 0x46, 0x09, 0x44, 0x8b, 0x73, 0x78, 0x88, 0xbd, 0x50,
+	0x4d, 0xab, 0x9e, 0xa4, 0xe5, 0x31, 0xe1, 0x11, 0x52, 0x44, 0x8b, 0x13, 0x25, 0xa0, 0x77, 0x61,
+	0x61, 0xe8, 0x18, 0x66, 0xa8, 0x44, 0xef, 0x0d, 0xc6, 0xf6, 0xd3, 0x56, 0x83, 0xaa, 0x5a, 0x89,
+	0x2d, 0xc8, 0x31, 0x4c, 0x21, 0xb8, 0x45, 0xd8, 0xba, 0x73, 0xda, 0xfc, 0x30, 0x4e, 0x44, 0x7b,
+	0xb0, 0x68, 0xb8, 0xee, 0xf0, 0x24, 0xae, 0xb3, 0x49, 0x75, 0x5e, 0x91, 0x75, 0x6e, 0x10, 0xce,
+	0xb8, 0x52, 0x64, 0x4c, 0x51, 0xc9, 0x61, 0xc0, 0xc7, 0xe4, 0x8e, 0xea, 0x47, 0x4e, 0x80, 0x5b,
+	0xf3, 0x49, 0x87, 0xa1, 0x43, 0x19, 0xf6, 0x9d, 0x00, 0x93, 0xc3, 0x80, 0xc3, 0x11, 0x7a, 0x0f,
+	0x2e, 0x1c, 0x61, 0xcf, 0x3a, 0x3c, 0xa1, 0xc2, 0x3a, 0x9d, 0xf1, 0xc9, 0xa9, 0x47, 0x54, 0xcd,
+	0x0b, 0xb2, 0x9a, 0x7d, 0xca, 0x4a, 0x04, 0x3b, 0x82, 0xb1, 0x3b, 0xa7, 0x2d, 0x1c, 0x4d, 0x93,
+	0xc9, 0x4d, 0x30, 0xf1, 0xd0, 0x3a, 0xc2, 0x1e, 0x39, 0x67, 0x0b, 0x49, 0x37, 0xe1, 0x1e, 0x9b,
+	0xa7, 0x27, 0xad, 0x6c, 0x8a, 0x01, 0x7a, 0x07, 0x9a, 0xae, 0x87, 0x5d, 0xc3, 0xc3, 0xba, 0xeb,
+	0x39, 0xae, 0xe3, 0x1b, 0xc3, 0xd6, 0x22, 0x95, 0x7f, 0x5e, 0x96, 0xdf, 0x61, 0x5c, 0x3b, 0x9c,
+	0xa9, 0x3b, 0xa7, 0x35, 0x5c, 0x99, 0xc4, 0x74, 0x39, 0x3d, 0xec, 0xfb, 0x13, 0x5d, 0x17, 0x92,
+	0x75, 0x51, 0x2e, 0x59, 0x97, 0x44, 0xda, 0x2c, 0x42, 0xfe, 0xc8, 0x18, 0x8e, 0xb1, 0xfa, 0x32,
+	0x54, 0x22, 0xce, 0x08, 0xb5, 0xa0, 0x38, 0xc2, 0xbe, 0x6f, 0xf4, 0x71, 0x4b, 0xb9, 0xa2, 0xac,
+	0x96, 0x35, 0x31, 0x54, 0xeb, 0x50, 0x8d, 0xba, 0x22, 0xf5, 0x27, 0x0a, 0x54, 0x22, 0xfe, 0x86,
+	0x48, 0x1e, 0x61, 0x8f, 0x9a, 0x9b, 0x4b, 0xf2, 0x21, 0xba, 0x0a, 0x35, 0x7a, 0xd7, 0x74, 0x31,
+	0x4f, 0x7c, 0x61, 0x4e, 0xab, 0x52, 0xe2, 0x3e, 0x67, 0x5a, 0x81, 0x8a, 0xbb, 0xee, 0x86, 0x2c,
+	0x59, 0xca, 0x02, 0xee, 0xba, 0x2b, 0x18, 0x36, 0xa0, 0xd9, 0x73, 0x6c, 0x1f, 0xdb, 0xfe, 0xd8,
+	0xd7, 0x5d, 0xc3, 0x33, 0x46, 0x7e, 0x2b, 0x27, 0x5d, 0xde, 0x2d, 0x31, 0xbd, 0x43, 0x67, 0xb5,
+	0x46, 0x4f, 0x26, 0xa8, 0x6f, 0x40, 0x33, 0xee, 0xf1, 0x50, 0x13, 0xb2, 0x4f, 0xf1, 0x09, 0x5f,
+	0x32, 0x79, 0x44, 0x8b, 0xdc, 0x34, 0x74, 0x99, 0x65, 0x8d, 0xdb, 0xe9, 0xb3, 0x0c, 0x34, 0xe3,
+	0x4e, 0x0f, 0xdd, 0x86, 0x1c, 0x89, 0x0d, 0x54, 0xba, 0xb2, 0xde, 0x5e, 0x63, 0x71, 0x61, 0x4d,
+	0xc4, 0x85, 0xb5, 0x3d, 0x11, 0x38, 0x36, 0x4b, 0x9f, 0x7f, 0xb9, 0x32, 0xf7, 0xd9, 0xaf, 0x57,
+	0x14, 0x8d, 0x4a, 0xa0, 0x4b, 0xc4, 0x6f, 0x19, 0x96, 0xad, 0x5b, 0x26, 0x7f, 0x4f, 0x91, 0x8e,
+	0xb7, 0xcd, 0xc4, 0x8d, 0x66, 0xcf, 0xb5, 0x51, 0xf4, 0x26, 0xc0, 0x91, 0x31, 0xb4, 0x4c, 0x23,
+	0x70, 0x3c, 0x62, 0xa5, 0x6c, 0x44, 0x78, 0x5f, 0x4c, 0x3c, 0x71, 0x4d, 0x23, 0xc0, 0x9b, 0x39,
+	0xb2, 0x32, 0x2d, 0xc2, 0x8f, 0x5e, 0x82, 0x86, 0xe1, 0xba, 0xba, 0x1f, 0x18, 0x01, 0xd6, 0x0f,
+	0x4e, 0x02, 0xec, 0xd3, 0xb0, 0x51, 0xd5, 0x6a, 0x86, 0xeb, 0xee, 0x12, 0xea, 0x26, 0x21, 0xaa,
+	0x26, 0x54, 0xa3, 0x1e, 0x1d, 0x21, 0xc8, 0x99, 0x46, 0x60, 0x50, 0x6b, 0x54, 0x35, 0xfa, 0x4c,
+	0x68, 0xae, 0x11, 0x0c, 0xf8, 0x1e, 0xe9, 0x33, 0x5a, 0x82, 0xc2, 0x00, 0x5b, 0xfd, 0x41, 0x40,
+	0xb7, 0x95, 0xd5, 0xf8, 0x88, 0x18, 0xde, 0xf5, 0x9c, 0x23, 0x4c, 0x7f, 0xd6, 0x92, 0xc6, 0x06,
+	0xea, 0xef, 0x15, 0x98, 0x9f, 0x8a, 0x02, 0x44, 0xef, 0xc0, 0xf0, 0x07, 0xe2, 0x5d, 0xe4, 0x19,
+	0x5d, 0x27, 0x7a, 0x0d, 0x13, 0x7b, 0x3c, 0xd8, 0xd6, 0xf8, 0x8e, 0xbb, 0x94, 0xc8, 0x37, 0xca,
+	0x59, 0x50, 0x07, 0x9a, 0x43, 0xc3, 0x0f, 0x74, 0xe6, 0xac, 0x75, 0x1a, 0x4c, 0xb3, 0x52, 0x00,
+	0x79, 0x60, 0x08, 0xa7, 0x4e, 0xce, 0x37, 0x17, 0xaf, 0x0f, 0x25, 0x2a, 0xea, 0xc2, 0xe2, 0xc1,
+	0xc9, 0x37, 0x0c, 0x3b, 0xb0, 0x6c, 0xac, 0x4f, 0xd9, 0xbc, 0xc1, 0x55, 0x75, 0x8e, 0x2c, 0x13,
+	0xdb, 0x3d, 0x61, 0xec, 0x85, 0x50, 0x24, 0xfc, 0x31, 0x7c, 0xf5, 0x0a, 0xd4, 0xe5, 0x90, 0x85,
+	0xea, 0x90, 0x09, 0x8e, 0xf9, 0x0e, 0x33, 0xc1, 0xb1, 0xaa, 0x42, 0x33, 0xee, 0x6c, 0xa6, 0x78,
+	0xae, 0x41, 0x23, 0x16, 0xc3, 0x22, 0xe6, 0x56, 0xa2, 0xe6, 0x56, 0x1b, 0x50, 0x93, 0x42, 0x97,
+	0xba, 0x04, 0x8b, 0x49, 0x31, 0x49, 0xfd, 0x10, 0x16, 0x93, 0xa2, 0x0c, 0xba, 0x0e, 0xa5, 0x30,
+	0x28, 0xb1, 0x1b, 0x20, 0xf6, 0x2b, 0x58, 0xb4, 0x90, 0x81, 0x1c, 0x78, 0x72, 0xa8, 0xe8, 0x8f,
+	0x96, 0xa1, 0xcb, 0x2d, 0x1a, 0xae, 0xdb, 0x35, 0xfc, 0x81, 0xfa, 0x11, 0xb4, 0xd2, 0x42, 0x4f,
+	0xda, 0xe2, 0x09, 0xfd, 0xd0, 0xf1, 0x46, 0x46, 0x40, 0x95, 0xd5, 0x34, 0x3e, 0x22, 0x67, 0x88,
+	0x85, 0xa1, 0x2c, 0x25, 0xb3, 0x81, 0xaa, 0xc3, 0xa5, 0xd4, 0x40, 0x44, 0x44, 0x2c, 0xdb, 0xc4,
+	0xcc, 0x8a, 0x35, 0x8d, 0x0d, 0x26, 0x8a, 0xd8, 0x62, 0xd9, 0x80, 0xbc, 0xd6, 0xa7, 0x09, 0x24,
+	0xd5, 0x5f, 0xd6, 0xf8, 0x48, 0xfd, 0xef, 0xf0, 0x8c, 0x4e, 0x82, 0x53, 0xe2, 0x19, 0x9d, 0xec,
+	0x27, 0x23, 0xfd, 0x18, 0x3f, 0x54, 0xa0, 0x9d, 0x1e, 0x97, 0x52, 0x8e, 0xfb, 0x7c, 0x78, 0xe0,
+	0x74, 0xc3, 0x34, 0x3d, 0xec, 0xfb, 0x7c, 0xb5, 0xcd, 0x70, 0x62, 0x83, 0xd1, 0x53, 0xef, 0xdc,
+	0x8b, 0x50, 0x8f, 0xc5, 0xca, 0x1c, 0xbb, 0xea, 0x47, 0xd1, 0xf7, 0xab, 0x3f, 0x55, 0x60, 0x29,
+	0x39, 0x50, 0xa5, 0xfe, 0x42, 0xf7, 0x61, 0x7e, 0xe8, 0xf4, 0x8c, 0xa1, 0x1e, 0xb9, 0x66, 0xfc,
+	0x62, 0x5e, 0x12, 0xd7, 0x82, 0xda, 0x0a, 0x9b, 0x53, 0xb7, 0xac, 0x41, 0x25, 0x27, 0x17, 0x90,
+	0x78, 0xe9, 0xe0, 0x98, 0xb8, 0xc1, 0xec, 0x6a, 0x55, 0x23, 0x8f, 0xe8, 0x0a, 0x54, 0x47, 0xc6,
+	0xb1, 0x1e, 0x1c, 0x73, 0x0f, 0x95, 0xa3, 0x2f, 0x87, 0x91, 0x71, 0xbc, 0x77, 0xcc, 0xdc, 0xd3,
+	0x37, 0x33, 0x91, 0x35, 0x4b, 0xd1, 0xef, 0xeb, 0x7b, 0x8f, 0xe9, 0xf5, 0x3c, 0x84, 0x45, 0x16,
+	0x94, 0xb1, 0x29, 0xed, 0x38, 0x77, 0xba, 0x4f, 0x41, 0x42, 0x30, 0xb2, 0xe1, 0x34, 0xbf, 0x92,
+	0x3f, 0xb7, 0x5f, 0xf9, 0x4b, 0x19, 0x4a, 0x1a, 0xf6, 0x5d, 0x12, 0x22, 0xd0, 0x6d, 0x28, 0xe3,
+	0xe3, 0x1e, 0x66, 0x58, 0x40, 0x89, 0x25, 0x57, 0x8c, 0xa7, 0x23, 0xe6, 0x49, 0x22, 0x13, 0x32,
+	0xa3, 0x6b, 0x12, 0x8e, 0x59, 0x88, 0x0b, 0x45, 0x81, 0xcc, 0x0d, 0x19, 0xc8, 0x2c, 0xc6, 0x78,
+	0x63, 0x48, 0xe6, 0x9a, 0x84, 0x64, 0xe2, 0x8a, 0x25, 0x28, 0x73, 0x27, 0x01, 0xca, 0xc4, 0x97,
+	0x9f, 0x82, 0x65, 0xee, 0x24, 0x60, 0x99, 0xd6, 0xd4, 0xbb, 0x12, 0xc1, 0xcc, 0x0d, 0x19, 0xcc,
+	0xc4, 0xb7, 0x13, 0x43, 0x33, 0x6f, 0x26, 0xa1, 0x99, 0x4b, 0x31, 0x99, 0x54, 0x38, 0xf3, 0xda,
+	0x14, 0x9c, 0x59, 0x8a, 0x89, 0x26, 0xe0, 0x99, 0x3b, 0x52, 0x76, 0x0a, 0x89, 0x7b, 0x4b, 0x49,
+	0x4f, 0xff, 0x73, 0x1a, 0x0a, 0x5d, 0x8c, 0xff, 0xb4, 0x49, 0x58, 0xe8, 0x56, 0x0c, 0x0b, 0x5d,
+	0x88, 0xaf, 0x32, 0x0e, 0x86, 0x3a, 0x29, 0x60, 0xe8, 0xb9, 0x98, 0xe0, 0x29, 0x68, 0xa8, 0x93,
+	0x82, 0x86, 0xe2, 0x6a, 0x4e, 0x81, 0x43, 0xda, 0x2c, 0x38, 0x74, 0x25, 0xbe, 0xa4, 0xb3, 0xe1,
+	0xa1, 0x27, 0x33, 0xf1, 0xd0, 0x0b, 0x31, 0xa5, 0x67, 0x06, 0x44, 0x6f, 0x26, 0x01, 0xa2, 0x4b,
+	0x53, 0x77, 0x36, 0x05, 0x11, 0xfd, 0xdf, 0x6c, 0x44, 0xa4, 0xc6, 0xf4, 0x9c, 0x03, 0x12, 0xdd,
+	0x4f, 0x00, 0x36, 0x0c, 0x18, 0x2d, 0xc7, 0x94, 0x9e, 0x01, 0xd9, 0xdc, 0x4f, 0x40, 0x36, 0x8b,
+	0x29, 0xca, 0xce, 0x0e, 0x6d, 0xae, 0xc1, 0xbc, 0x10, 0x0b, 0x9d, 0x1a, 0x89, 0xeb, 0xd8, 0xf3,
+	0x1c, 0x8f, 0x67, 0xfc, 0x6c, 0xa0, 0xae, 0x42, 0x35, 0x64, 0x9d, 0x0d, 0x83, 0x68, 0xd6, 0x14,
+	0x71, 0x64, 0xea, 0xdf, 0x14, 0xa8, 0x46, 0xbd, 0x95, 0x94, 0x06, 0x97, 0x79, 0x1a, 0x1c, 0x01,
+	0x47, 0x19, 0x19, 0x1c, 0xad, 0x40, 0x85, 0xe4, 0x45, 0x31, 0xdc, 0x63, 0xb8, 0x21, 0xee, 0xf9,
+	0x77, 0x98, 0xa7, 0xf1, 0x84, 0x41, 0x28, 0x1e, 0x6a, 0x59, 0xb4, 0x6b, 0x90, 0x09, 0x76, 0x39,
+	0x29, 0x19, 0xdd, 0x84, 0x85, 0x08, 0x6f, 0x98, 0x6f, 0xb1, 0xec, 0xbd, 0x19, 0x72, 0x6f, 0xb0,
+	0xc4, 0x2b, 0x11, 0x69, 0x14, 0xce, 0x07, 0xa9, 0x1e, 0xc2, 0xfc, 0x94, 0xe7, 0x25, 0x16, 0xe8,
+	0x39, 0x26, 0xe6, 0x09, 0x15, 0x7d, 0x26, 0x11, 0x73, 0xe8, 0xf4, 0x79, 0xda, 0x44, 0x1e, 0x09,
+	0x57, 0xe8, 0xf8, 0xcb, 0xcc, 0xc3, 0xab, 0xdf, 0x55, 0x60, 0x7e, 0xca, 0x1d, 0x27, 0xae, 0x53,
+	0xf9, 0x3a, 0x88, 0x28, 0x73, 0x3e, 0x44, 0xa4, 0x3e, 0x53, 0xa0, 0x26, 0xf9, 0xfb, 0xaf, 0xbe,
+	0xc5, 0x49, 0xba, 0x99, 0xa7, 0xbf, 0x21, 0x1b, 0x08, 0x18, 0x5a, 0xa0, 0xbf, 0x94, 0x0c, 0x43,
+	0x8b, 0x94, 0xc6, 0x06, 0xe8, 0x2a, 0xc5, 0x48, 0xce, 0x21, 0x0f, 0x2c, 0xb5, 0x35, 0x5e, 0xf3,
+	0xdc, 0x21, 0x44, 0x8d, 0xcd, 0x45, 0x52, 0xb2, 0xb2, 0x94, 0x92, 0x3d, 0x07, 0x65, 0xb2, 0x50,
+	0xdf, 0x35, 0x7a, 0x98, 0xc6, 0x89, 0xb2, 0x36, 0x21, 0xa8, 0x3b, 0x80, 0xa6, 0xe3, 0x13, 0x7a,
+	0x03, 0x72, 0x81, 0xd1, 0x27, 0xf6, 0x26, 0x26, 0xab, 0xaf, 0xb1, 0x7a, 0xe9, 0xda, 0xfd, 0xfd,
+	0x1d, 0xc3, 0xf2, 0x36, 0x97, 0x88, 0xa9, 0xfe, 0xf8, 0xe5, 0x4a, 0x9d, 0xf0, 0xdc, 0x70, 0x46,
+	0x56, 0x80, 0x47, 0x6e, 0x70, 0xa2, 0x51, 0x19, 0xf5, 0x97, 0x19, 0x68, 0x08, 0x95, 0x02, 0xd4,
+	0x24, 0x19, 0x4e, 0xdc, 0x98, 0x4c, 0x04, 0x38, 0x9e, 0xcd, 0x98, 0xcf, 0x03, 0xf4, 0x0d, 0x5f,
+	0xff, 0xc4, 0xb0, 0x03, 0x6c, 0x72, 0x8b, 0x96, 0xfb, 0x86, 0xff, 0x1e, 0x25, 0x10, 0xd0, 0x41,
+	0xa6, 0xc7, 0x3e, 0x36, 0xa9, 0x69, 0xb3, 0x5a, 0xb1, 0x6f, 0xf8, 0x4f, 0x7c, 0x6c, 0x86, 0xfb,
+	0x2a, 0x9e, 0x7f, 0x5f, 0xb2, 0x1d, 0x4b, 0x31, 0x3b, 0xa2, 0x36, 0x94, 0x5c, 0xcf, 0x72, 0x3c,
+	0x2b, 0x38, 0xe1, 0xf6, 0x0f, 0xc7, 0x11, 0xfc, 0x00, 0x51, 0xfc, 0x40, 0x4a, 0x24, 0x23, 0x3c,
+	0x72, 0x1d, 0x67, 0xa8, 0x33, 0xef, 0x54, 0xa1, 0xd3, 0x55, 0x4e, 0xec, 0x50, 0x27, 0xf5, 0xe7,
+	0xc8, 0xe5, 0x98, 0x20, 0xc0, 0x7f, 0x79, 0x83, 0xaa, 0xdf, 0xa6, 0xa5, 0x17, 0x39, 0x1b, 0x41,
+	0xdb, 0x51, 0xf4, 0x33, 0xa6, 0x17, 0x57, 0x1c, 0xd2, 0xd9, 0xf7, 0xba, 0x79, 0x24, 0x93, 0x7d,
+	0xf4, 0x08, 0x2e, 0xc6, 0xdc, 0x4b, 0xa8, 0x30, 0x33, 0xd3, 0xcb, 0x5c, 0x90, 0xbd, 0x8c, 0xd0,
+	0x27, 0x2c, 0x91, 0xfd, 0x0a, 0x96, 0xb8, 0x0a, 0x35, 0x17, 0xdb, 0xa6, 0x65, 0xf7, 0x59, 0xfd,
+	0x85, 0xd7, 0x42, 0xaa, 0x9c, 0x48, 0xab, 0x2f, 0xea, 0xbf, 0x41, 0x5d, 0xd8, 0x83, 0xc3, 0x85,
+	0x84, 0x1f, 0x5c, 0x7d, 0x1b, 0x2e, 0x24, 0x66, 0x55, 0xe8, 0x26, 0x94, 0x27, 0x69, 0x98, 0x22,
+	0x01, 0x0a, 0xc1, 0xa4, 0x4d, 0x38, 0xd4, 0x9f, 0x29, 0x70, 0x21, 0x31, 0xaf, 0x42, 0x77, 0xa1,
+	0xe0, 0x61, 0x7f, 0x3c, 0x64, 0xd0, 0xaf, 0xbe, 0x7e, 0x75, 0x56, 0x16, 0x46, 0xa8, 0xe3, 0x61,
+	0xa0, 0x71, 0x11, 0xf5, 0x43, 0x28, 0x30, 0x0a, 0xaa, 0x40, 0xf1, 0xc9, 0xa3, 0xfb, 0x8f, 0x1e,
+	0xbf, 0xf7, 0xa8, 0x39, 0x87, 0x00, 0x0a, 0x1b, 0x5b, 0x5b, 0x9d, 0x9d, 0xbd, 0xa6, 0x82, 0xca,
+	0x90, 0xdf, 0xd8, 0x7c, 0xac, 0xed, 0x35, 0x33, 0x84, 0xac, 0x75, 0xde, 0xe9, 0x6c, 0xed, 0x35,
+	0xb3, 0x68, 0x1e, 0x6a, 0xec, 0x59, 0x7f, 0xfb, 0xb1, 0xf6, 0x70, 0x63, 0xaf, 0x99, 0x8b, 0x90,
+	0x76, 0x3b, 0x8f, 0xee, 0x75, 0xb4, 0x66, 0x5e, 0x7d, 0x15, 0x2e, 0x89, 0x75, 0x4c, 0x97, 0x15,
+	0x42, 0x74, 0xaf, 0x44, 0xd0, 0x3d, 0x39, 0x68, 0xed, 0xf4, 0x04, 0x0d, 0xfd, 0x4f, 0x6c, 0xbb,
+	0xab, 0xa7, 0xe6, 0x74, 0xb1, 0x3d, 0x13, 0xb4, 0xed, 0xe1, 0x43, 0x1c, 0xf4, 0x06, 0x2c, 0x39,
+	0x64, 0x91, 0xa8, 0xa6, 0xd5, 0x38, 0x95, 0x0a, 0xf9, 0x8c, 0xed, 0xff, 0x71, 0x2f, 0xd0, 0x99,
+	0x7b, 0x60, 0x47, 0xa9, 0xac, 0xd5, 0x18, 0x75, 0x97, 0x11, 0xd5, 0x8f, 0xce, 0x65, 0xc1, 0x32,
+	0xe4, 0xb5, 0xce, 0x9e, 0xf6, 0x7e, 0x33, 0x8b, 0x10, 0xd4, 0xe9, 0xa3, 0xbe, 0xfb, 0x68, 0x63,
+	0x67, 0xb7, 0xfb, 0x98, 0x58, 0x70, 0x01, 0x1a, 0xc2, 0x82, 0x82, 0x98, 0x57, 0xef, 0x4e, 0x42,
+	0x42, 0xa4, 0xae, 0x31, 0x5d, 0x33, 0x50, 0x92, 0x6a, 0x06, 0xdf, 0x51, 0xe0, 0xf2, 0x8c, 0xc4,
+	0x12, 0x6d, 0xc4, 0xcc, 0x79, 0xed, 0xf4, 0x64, 0x34, 0x7e, 0x86, 0x6e, 0x9e, 0x6e, 0x81, 0xc9,
+	0xc1, 0xc9, 0xa8, 0xd7, 0xe1, 0x62, 0x4a, 0x52, 0x2a, 0x00, 0xbd, 0x12, 0x02, 0x7a, 0xf5, 0x5b,
+	0x4a, 0x94, 0x5b, 0xae, 0x1f, 0xbc, 0x05, 0x05, 0x72, 0x3b, 0xc7, 0x3e, 0x5f, 0xfa, 0x8b, 0xb3,
+	0xb3, 0xd4, 0xb5, 0x5d, 0xca, 0xac, 0x71, 0x21, 0xb2, 0x6c, 0x46, 0x39, 0xdb, 0xb2, 0xbf, 0x50,
+	0xa0, 0x11, 0x73, 0x3d, 0x68, 0x15, 0xf2, 0x0c, 0xb4, 0x29, 0x52, 0x03, 0x90, 0xfa, 0x46, 0xc6,
+	0xa2, 0x31, 0x06, 0xf4, 0x2a, 0x94, 0x30, 0x2f, 0x13, 0xb4, 0x32, 0x12, 0x58, 0x13, 0xd5, 0x03,
+	0xce, 0x1f, 0xb2, 0xa1, 0xff, 0x80, 0x72, 0xe8, 0x24, 0x63, 0xa5, 0xe7, 0xd0, 0xa7, 0x72, 0xa1,
+	0x09, 0x23, 0x5a, 0x83, 0x22, 0x29, 0x6d, 0x3b, 0x63, 0x51, 0xf4, 0x10, 0x48, 0x79, 0x8f, 0x51,
+	0xb9, 0x84, 0x60, 0x52, 0xb7, 0xa0, 0x12, 0x59, 0x2e, 0xba, 0x0c, 0xe5, 0x91, 0x21, 0xaa, 0x39,
+	0xac, 0x94, 0x54, 0x1a, 0x19, 0xac, 0x96, 0x83, 0x2e, 0x42, 0x91, 0x4c, 0xf6, 0x0d, 0x5f, 0xd4,
+	0xcd, 0x46, 0xc6, 0xf1, 0xff, 0x1a, 0xbe, 0xfa, 0x3d, 0x05, 0xea, 0xf2, 0x3e, 0x04, 0xaf, 0xc8,
+	0xdd, 0x19, 0xef, 0x46, 0x1f, 0xa3, 0x87, 0xd0, 0xe4, 0x13, 0xba, 0x68, 0xd8, 0x86, 0x05, 0xa9,
+	0x78, 0xe5, 0xfe, 0x1e, 0x67, 0x60, 0x85, 0xfb, 0xef, 0x93, 0xc2, 0x7d, 0x9d, 0xa9, 0x11, 0x33,
+	0xf2, 0x82, 0xb3, 0xf2, 0x82, 0xd5, 0xd7, 0xa1, 0x11, 0x33, 0x15, 0x52, 0xa1, 0xe6, 0x8e, 0x0f,
+	0xf4, 0xa7, 0xf8, 0x44, 0xa7, 0x76, 0xa1, 0x87, 0xad, 0xac, 0x55, 0xdc, 0xf1, 0xc1, 0x7d, 0x7c,
+	0xb2, 0x47, 0x48, 0xea, 0x9f, 0x14, 0xa8, 0x49, 0xe6, 0x42, 0x5d, 0xa8, 0xf1, 0xf2, 0x90, 0x6e,
+	0xe2, 0x21, 0x87, 0x15, 0x67, 0x5c, 0x71, 0x95, 0x4b, 0xde, 0x23, 0x82, 0x4c, 0x13, 0xa6, 0x37,
+	0x97, 0x69, 0xca, 0x9c, 0x4b, 0x13, 0x95, 0x64, 0x9a, 0x1e, 0x00, 0x41, 0x70, 0xbc, 0x72, 0xce,
+	0x74, 0x65, 0xcf, 0x61, 0xc7, 0x50, 0x96, 0x6a, 0x53, 0x77, 0xa1, 0x2e, 0x97, 0xc5, 0x88, 0x77,
+	0xf6, 0x9c, 0xb1, 0x6d, 0xd2, 0xbd, 0xe6, 0x35, 0x36, 0x20, 0x4d, 0x65, 0xb2, 0x04, 0x91, 0xbd,
+	0x8b, 0x90, 0x45, 0x1c, 0x44, 0xa4, 0x98, 0xc6, 0x78, 0x54, 0x1d, 0xd0, 0x74, 0x75, 0x31, 0x45,
+	0xf1, 0x6b, 0xb2, 0xe2, 0x8b, 0xb1, 0xea, 0x64, 0xf2, 0x0b, 0x3e, 0xcd, 0x43, 0x81, 0x95, 0x06,
+	0xc9, 0xc1, 0x8f, 0x76, 0xbe, 0x48, 0xc8, 0xe7, 0x4b, 0x63, 0x54, 0x2e, 0x28, 0x98, 0xd0, 0x4b,
+	0xf1, 0xde, 0xcf, 0x66, 0xe5, 0xd9, 0x97, 0x2b, 0x45, 0x8a, 0x75, 0xb6, 0xef, 0x4d, 0x1a, 0x41,
+	0x69, 0x35, 0x5b, 0xd1, 0x75, 0xca, 0x9d, 0xbb, 0xeb, 0x74, 0x11, 0x8a, 0xf6, 0x78, 0xa4, 0x13,
+	0x4f, 0xc7, 0x52, 0xbb, 0x82, 0x3d, 0x1e, 0xed, 0x1d, 0xd3, 0xcb, 0x17, 0x38, 0x81, 0x31, 0xa4,
+	0x53, 0x2c, 0xb1, 0x2b, 0x51, 0x02, 0x99, 0xbc, 0x0d, 0xb5, 0x08, 0xaa, 0xb4, 0xcc, 0x56, 0x51,
+	0xda, 0x25, 0xbd, 0xc4, 0xdb, 0xf7, 0xf8, 0x2e, 0x2b, 0x21, 0xca, 0xdc, 0x36, 0xd1, 0xaa, 0xdc,
+	0x64, 0xa1, 0x60, 0xb4, 0x44, 0x63, 0x45, 0xa4, 0x8f, 0x42, 0xa1, 0xe8, 0x65, 0x28, 0x93, 0xa4,
+	0x85, 0xb1, 0x94, 0x29, 0x4b, 0x89, 0x10, 0xe8, 0xe4, 0xcb, 0xd0, 0x98, 0x80, 0x31, 0xc6, 0x02,
+	0x4c, 0xcb, 0x84, 0x4c, 0x19, 0x5f, 0x81, 0x45, 0x1b, 0x1f, 0x07, 0x7a, 0x9c, 0xbb, 0x42, 0xb9,
+	0x11, 0x99, 0xdb, 0x97, 0x25, 0x5e, 0x84, 0xfa, 0x24, 0xf7, 0xa3, 0xbc, 0x55, 0x16, 0xcb, 0x42,
+	0x2a, 0x65, 0x8b, 0x76, 0x2f, 0x6a, 0x52, 0xf7, 0x22, 0xc4, 0xe7, 0x2c, 0x24, 0x71, 0x25, 0x75,
+	0xca, 0x43, 0xf1, 0x39, 0x0b, 0x50, 0x4c, 0xcd, 0x55, 0xa8, 0x09, 0x27, 0xcb, 0xf8, 0x1a, 0x94,
+	0xaf, 0x2a, 0x88, 0x94, 0xe9, 0x1a, 0x34, 0xf9, 0xbd, 0x9d, 0x94, 0xf5, 0x9b, 0x4c, 0x9f, 0xa0,
+	0xf3, 0xaa, 0xbe, 0xfa, 0x2a, 0x14, 0x45, 0x99, 0x60, 0x11, 0xf2, 0x9b, 0x61, 0x40, 0xc8, 0x69,
+	0x6c, 0x40, 0xc2, 0xda, 0x86, 0xeb, 0xf2, 0x86, 0x2b, 0x79, 0x54, 0x3f, 0x80, 0x22, 0xff, 0xc1,
+	0x12, 0xab, 0xe0, 0x6f, 0x41, 0xd5, 0x35, 0x3c, 0xb2, 0x8d, 0x68, 0x2d, 0x5c, 0x78, 0xf2, 0x1d,
+	0xc3, 0x23, 0xad, 0x53, 0xa9, 0x24, 0x5e, 0xa1, 0xfc, 0x8c, 0xa4, 0xde, 0x81, 0x9a, 0xc4, 0x43,
+	0x96, 0x45, 0xcf, 0x91, 0xb8, 0x71, 0x74, 0x10, 0xbe, 0x39, 0x33, 0x79, 0xb3, 0x7a, 0x17, 0xca,
+	0xe1, 0x6f, 0x43, 0xea, 0x25, 0x62, 0xeb, 0x0a, 0x37, 0x37, 0x1b, 0x12, 0x85, 0xae, 0xf3, 0x09,
+	0x6f, 0xc0, 0x64, 0x35, 0x36, 0x50, 0x9f, 0x44, 0xdc, 0x2d, 0x4b, 0xc3, 0xd1, 0x0d, 0x28, 0x72,
+	0x77, 0xdb, 0x52, 0xa4, 0x82, 0xfe, 0x0e, 0xf5, 0xb7, 0xa2, 0xa0, 0xcf, 0xbc, 0xef, 0x44, 0x6d,
+	0x26, 0xaa, 0x76, 0x08, 0x25, 0x71, 0xfb, 0xe5, 0xa0, 0xc8, 0x34, 0x36, 0xe3, 0x41, 0x91, 0x2b,
+	0x9d, 0x30, 0x92, 0xd3, 0xe1, 0x5b, 0x7d, 0x5b, 0x34, 0x05, 0x58, 0xcc, 0xce, 0xd0, 0x9c, 0xbe,
+	0xc1, 0x26, 0x1e, 0x88, 0xfb, 0xa2, 0xfe, 0x40, 0x81, 0x66, 0xdc, 0xe9, 0xfc, 0xf3, 0x5f, 0x9b,
+	0x90, 0xce, 0x65, 0x93, 0xd2, 0xb9, 0x57, 0xa0, 0xc0, 0x2c, 0x47, 0x7e, 0x3d, 0xb2, 0x00, 0x51,
+	0xe0, 0x22, 0xcf, 0x89, 0x00, 0xe4, 0x0b, 0x05, 0x4a, 0x22, 0x36, 0x27, 0x0a, 0x49, 0x7b, 0xcb,
+	0x9c, 0x75, 0x6f, 0xff, 0x78, 0xb7, 0x78, 0x03, 0x10, 0xf3, 0x7e, 0x47, 0x4e, 0x40, 0x90, 0x17,
+	0x3b, 0x09, 0xcc, 0x43, 0x36, 0xe9, 0xcc, 0x3e, 0x9d, 0xd8, 0xa1, 0x87, 0xe2, 0x53, 0x05, 0x4a,
+	0x21, 0x04, 0x3a, 0x6f, 0x7f, 0x72, 0x09, 0x0a, 0x3c, 0xf3, 0x67, 0x0d, 0x4a, 0x3e, 0x0a, 0x6f,
+	0x44, 0x2e, 0x72, 0x17, 0xdb, 0x50, 0x1a, 0xe1, 0xc0, 0xa0, 0x76, 0x65, 0x25, 0xbc, 0x70, 0xbc,
+	0xfe, 0x07, 0x80, 0xc6, 0xc6, 0xe6, 0xd6, 0x36, 0xc1, 0x1c, 0x56, 0x8f, 0x25, 0x24, 0xb7, 0x20,
+	0x47, 0x8b, 0x97, 0x09, 0x1f, 0x99, 0xb5, 0x93, 0x1a, 0x36, 0x68, 0x1d, 0xf2, 0xb4, 0x86, 0x89,
+	0x92, 0xbe, 0x35, 0x6b, 0x27, 0xf6, 0x6d, 0xc8, 0x4b, 0x58, 0x95, 0x73, 0xfa, 0x93, 0xb3, 0x76,
+	0x52, 0xf3, 0x06, 0xfd, 0x17, 0x94, 0x27, 0x95, 0xc1, 0xb4, 0x0f, 0xcf, 0xda, 0xa9, 0x6d, 0x1c,
+	0x22, 0x3f, 0x29, 0x76, 0xa4, 0x7d, 0x74, 0xd3, 0x4e, 0xed, 0x77, 0xa0, 0x2e, 0x34, 0xc2, 0xc1,
+	0x6e, 0xe0, 0x61, 0x63, 0xf4, 0x15, 0xb4, 0xac, 0x2a, 0xaf, 0x28, 0xe8, 0x36, 0x14, 0x45, 0x15,
+	0x2b, 0xf9, 0x23, 0xb3, 0x76, 0x4a, 0xb3, 0x86, 0x18, 0x9a, 0x95, 0x0d, 0x93, 0xbe, 0x84, 0x6b,
+	0x27, 0x76, 0x94, 0xd0, 0xeb, 0x50, 0xe0, 0xe0, 0x3e, 0xf1, 0x43, 0xb3, 0x76, 0x72, 0xcb, 0x85,
+	0x98, 0x6b, 0x52, 0x38, 0x4d, 0xfb, 0x5a, 0xaf, 0x9d, 0xda, 0xfa, 0x42, 0x1b, 0x00, 0x91, 0xea,
+	0x5f, 0xea, 0x67, 0x78, 0xed, 0xf4, 0x96, 0x16, 0xba, 0x0b, 0xa5, 0xc9, 0xc7, 0x07, 0xc9, 0x1f,
+	0xd6, 0xb5, 0xd3, 0xba, 0x4c, 0xe8, 0x1d, 0xa8, 0xc9, 0xd5, 0x8a, 0x59, 0x9f, 0xcb, 0xb5, 0x67,
+	0xb6, 0x8f, 0x88, 0x2e, 0xb9, 0x60, 0x31, 0xeb, 0xa3, 0xb9, 0xf6, 0xcc, 0x1e, 0x12, 0xda, 0x87,
+	0xf9, 0xe9, 0x32, 0xc2, 0x69, 0x5f, 0xce, 0xb5, 0x4f, 0xed, 0x25, 0xa1, 0xf7, 0x01, 0x25, 0x94,
+	0x1a, 0x4e, 0xfd, 0x7c, 0xae, 0x7d, 0x7a, 0x43, 0x89, 0xfc, 0x94, 0x11, 0xd4, 0x9e, 0xfa, 0x11,
+	0x5d, 0x3b, 0xbd, 0x9b, 0x84, 0x3e, 0x80, 0x85, 0x24, 0xe8, 0x7e, 0xfa, 0x97, 0x74, 0xed, 0x33,
+	0xb4, 0x96, 0xd0, 0x0e, 0x34, 0xe2, 0x38, 0x7c, 0xf6, 0x57, 0x71, 0xed, 0x53, 0x7a, 0x4b, 0x4c,
+	0xa3, 0x8c, 0xd5, 0x67, 0x7f, 0x1b, 0xd7, 0x3e, 0xa5, 0xc1, 0xb4, 0xf9, 0xdc, 0x5f, 0x7f, 0xbb,
+	0xac, 0xfc, 0xe8, 0xd9, 0xb2, 0xf2, 0xf3, 0x67, 0xcb, 0xca, 0xe7, 0xcf, 0x96, 0x95, 0x5f, 0x3d,
+	0x5b, 0x56, 0x7e, 0xf3, 0x6c, 0x59, 0xf9, 0xc5, 0xef, 0x96, 0x95, 0x83, 0x02, 0x8d, 0x2e, 0xaf,
+	0xfd, 0x7d, 0x00, 0x93, 0x5a, 0xc9, 0x92, 0xf8, 0x2c, 0x00, 0x00,
 }
@@ -0,0 +1,100 @@
+package blockchain
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// maxArchivedBlockSize is the maximum size of an archived block with its
+// commit, which is smaller than the block.
+const maxArchivedBlockSize = 2 * types.MaxBlockSizeBytes
+
+// ArchivedBlock is a block with the commit for it, as written by ExportBlocks.
+// Every block is amino encoded, which is protobuf compatible, and prefixed
+// with its length as a uvarint.
+type ArchivedBlock struct {
+	Block  *types.Block  `json:"block"`
+	Commit *types.Commit `json:"commit"`
+}
+
+// ExportBlocks writes the blocks of the store from the given height to the
+// given height, both included, with their commits. The commit of the last
+// block of the store is the one seen locally. Returns the number of blocks
+// written.
+func ExportBlocks(store *BlockStore, w io.Writer, from, to int64) (int64, error) {
+	if from < store.Base() || to > store.Height() || from > to {
+		return 0, fmt.Errorf("can't export blocks %v to %v from a store with blocks %v to %v",
+			from, to, store.Base(), store.Height())
+	}
+	bw := bufio.NewWriter(w)
+	for height := from; height <= to; height++ {
+		commit := store.LoadBlockCommit(height)
+		if commit == nil {
+			commit = store.LoadSeenCommit(height)
+		}
+		bz, err := cdc.MarshalBinaryLengthPrefixed(ArchivedBlock{
+			Block:  store.LoadBlock(height),
+			Commit: commit,
+		})
+		if err != nil {
+			return height - from, err
+		}
+		if _, err := bw.Write(bz); err != nil {
+			return height - from, err
+		}
+	}
+	return to - from + 1, bw.Flush()
+}
+
+// ImportBlocks reads the blocks written by ExportBlocks and saves them to the
+// store, which has to end just below the first of them. Every block has to
+// link to the one before it by its last block ID, and its commit has to be for
+// it. The commits aren't verified against the validators, as they aren't known
+// until the blocks are executed. Returns the number of blocks saved.
+func ImportBlocks(store *BlockStore, r io.Reader) (int64, error) {
+	br := bufio.NewReader(r)
+	var lastBlockID *types.BlockID
+	if meta := store.LoadBlockMeta(store.Height()); meta != nil {
+		lastBlockID = &meta.BlockID
+	}
+
+	n := int64(0)
+	for {
+		var archived ArchivedBlock
+		read, err := cdc.UnmarshalBinaryLengthPrefixedReader(br, &archived, maxArchivedBlockSize)
+		if err == io.EOF && read == 0 {
+			return n, nil
+		}
+		if err != nil {
+			return n, fmt.Errorf("error reading block %d: %v", n, err)
+		}
+		block, commit := archived.Block, archived.Commit
+		if block == nil || commit == nil {
+			return n, fmt.Errorf("block %d is missing its block or commit", n)
+		}
+
+		if next := store.nextHeight(); block.Height != next {
+			return n, fmt.Errorf("expected block at height %v, got %v", next, block.Height)
+		}
+		if err := block.ValidateBasic(); err != nil {
+			return n, fmt.Errorf("invalid block at height %v: %v", block.Height, err)
+		}
+		if lastBlockID != nil && !block.LastBlockID.Equals(*lastBlockID) {
+			return n, fmt.Errorf("block at height %v links to %v instead of %v",
+				block.Height, block.LastBlockID, *lastBlockID)
+		}
+		parts := block.MakePartSet(types.BlockPartSizeBytes)
+		blockID := types.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+		if commit.Height() != block.Height || !commit.BlockID.Equals(blockID) {
+			return n, fmt.Errorf("commit for block %v at height %v is for %v at height %v",
+				blockID, block.Height, commit.BlockID, commit.Height())
+		}
+
+		store.SaveBlock(block, parts, commit)
+		lastBlockID = &blockID
+		n++
+	}
+}
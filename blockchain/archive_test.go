@@ -0,0 +1,66 @@
+package blockchain
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestExportImportBlocks(t *testing.T) {
+	config = cfg.ResetTestRoot("blockchain_archive_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	pair := newBlockchainReactor(log.TestingLogger(), genDoc, privVals, 20)
+	defer pair.app.Stop()
+	store := pair.reactor.store
+
+	_, err := ExportBlocks(store, new(bytes.Buffer), 0, 20)
+	assert.Error(t, err)
+	_, err = ExportBlocks(store, new(bytes.Buffer), 1, 21)
+	assert.Error(t, err)
+
+	var buf bytes.Buffer
+	n, err := ExportBlocks(store, &buf, 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), n)
+
+	imported := NewBlockStore(dbm.NewMemDB())
+	n, err = ImportBlocks(imported, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), n)
+	assert.Equal(t, int64(20), imported.Height())
+	for height := int64(1); height <= 20; height++ {
+		assert.Equal(t, store.LoadBlockMeta(height).BlockID, imported.LoadBlockMeta(height).BlockID)
+	}
+	assert.NotNil(t, imported.LoadSeenCommit(20))
+
+	// The blocks have to follow the store.
+	_, err = ImportBlocks(imported, bytes.NewReader(buf.Bytes()))
+	assert.Error(t, err)
+
+	// The blocks have to link to each other.
+	var gap bytes.Buffer
+	_, err = ExportBlocks(store, &gap, 1, 3)
+	require.NoError(t, err)
+	_, err = ExportBlocks(store, &gap, 5, 6)
+	require.NoError(t, err)
+	imported = NewBlockStore(dbm.NewMemDB())
+	n, err = ImportBlocks(imported, &gap)
+	assert.Error(t, err)
+	assert.Equal(t, int64(3), n)
+	assert.Equal(t, int64(3), imported.Height())
+
+	// Truncated files are rejected.
+	imported = NewBlockStore(dbm.NewMemDB())
+	_, err = ImportBlocks(imported, bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+	assert.Error(t, err)
+	assert.Equal(t, int64(19), imported.Height())
+}
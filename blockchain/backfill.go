@@ -0,0 +1,152 @@
+package blockchain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/types"
+)
+
+// how long a peer has to send a block below the base of the store
+const backfillTimeout = 10 * time.Second
+
+// how long to wait before asking the peers again once none of them sent the
+// block below the base
+var backfillRetryInterval = 10 * time.Second // not const so we can override with tests
+
+// backfillResponse is the response of a peer to a request for a block below
+// the base of the store. The block is nil if the peer doesn't have it.
+type backfillResponse struct {
+	peerID p2p.ID
+	height int64
+	block  *types.Block
+}
+
+// SetBackfillHeight makes the reactor fetch the blocks below the base of the
+// store, down to the given height, once the store has a block. It's for nodes
+// which fast synced from a trusted block, so they can serve the blocks before
+// it. 0 doesn't fetch any. Must be called before the reactor is started.
+func (bcR *BlockchainReactor) SetBackfillHeight(height int64) {
+	bcR.backfillHeight = height
+}
+
+// backfillRoutine fetches the blocks below the base of the store from the
+// newest to the oldest, one at a time. Every block is verified against the
+// last block ID of the block above it, so the blocks are linked to the trusted
+// block by their hashes. It asks the peers in turn until one sends the block.
+func (bcR *BlockchainReactor) backfillRoutine() {
+	tried := make(map[p2p.ID]bool)
+	for bcR.IsRunning() && bcR.store.Base() > bcR.backfillHeight {
+		// The block above the missing one is needed to verify it.
+		if bcR.store.Height() == 0 {
+			if !bcR.waitBackfillRetry() {
+				return
+			}
+			continue
+		}
+
+		height := bcR.store.Base() - 1
+		peer := bcR.pickBackfillPeer(tried)
+		if peer == nil {
+			bcR.Logger.Debug("No peer sent the block below the base, retrying later", "height", height)
+			tried = make(map[p2p.ID]bool)
+			if !bcR.waitBackfillRetry() {
+				return
+			}
+			continue
+		}
+		tried[peer.ID()] = true
+
+		msgBytes := cdc.MustMarshalBinaryBare(&bcBlockRequestMessage{height})
+		if !peer.TrySend(BlockchainChannel, msgBytes) {
+			continue
+		}
+		block := bcR.awaitBackfill(peer.ID(), height)
+		if block == nil {
+			continue
+		}
+		if err := bcR.saveBackfilled(block); err != nil {
+			bcR.Logger.Error("Error in backfill validation", "peer", peer.ID(), "height", height, "err", err)
+			bcR.reportError(peer, p2p.ErrorPeerBehaviourBadBlock, height, err)
+			continue
+		}
+		tried = make(map[p2p.ID]bool)
+	}
+	if bcR.IsRunning() {
+		bcR.Logger.Info("Backfilled blocks", "base", bcR.store.Base())
+	}
+}
+
+// waitBackfillRetry waits for backfillRetryInterval and returns false if the
+// reactor stopped meanwhile.
+func (bcR *BlockchainReactor) waitBackfillRetry() bool {
+	select {
+	case <-time.After(backfillRetryInterval):
+		return true
+	case <-bcR.Quit():
+		return false
+	}
+}
+
+// pickBackfillPeer returns a peer which wasn't asked for the block yet, or
+// nil if all were.
+func (bcR *BlockchainReactor) pickBackfillPeer(tried map[p2p.ID]bool) p2p.Peer {
+	for _, peer := range bcR.Switch.Peers().List() {
+		if !tried[peer.ID()] {
+			return peer
+		}
+	}
+	return nil
+}
+
+// awaitBackfill waits for the peer to respond to the request for the block at
+// the given height. It returns nil if the peer doesn't have the block, timed
+// out or the reactor stopped.
+func (bcR *BlockchainReactor) awaitBackfill(peerID p2p.ID, height int64) *types.Block {
+	timeout := time.NewTimer(backfillTimeout)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case resp := <-bcR.backfillCh:
+			// Ignore late responses to earlier requests.
+			if resp.peerID == peerID && resp.height == height {
+				return resp.block
+			}
+		case <-timeout.C:
+			bcR.Logger.Debug("Peer didn't send the block below the base", "peer", peerID, "height", height)
+			return nil
+		case <-bcR.Quit():
+			return nil
+		}
+	}
+}
+
+// receiveBackfill passes the response of a peer to the backfillRoutine,
+// dropping it if the routine isn't waiting for one.
+func (bcR *BlockchainReactor) receiveBackfill(peerID p2p.ID, height int64, block *types.Block) {
+	select {
+	case bcR.backfillCh <- backfillResponse{peerID, height, block}:
+	default:
+	}
+}
+
+// saveBackfilled verifies and saves the block below the base of the store.
+func (bcR *BlockchainReactor) saveBackfilled(block *types.Block) error {
+	base := bcR.store.Base()
+	if block.Height != base-1 {
+		return fmt.Errorf("expected the block at height %v, got %v", base-1, block.Height)
+	}
+	if err := block.ValidateBasic(); err != nil {
+		return err
+	}
+	parts := block.MakePartSet(types.BlockPartSizeBytes)
+	blockID := types.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+	expected := bcR.store.LoadBlockMeta(base).Header.LastBlockID
+	if !blockID.Equals(expected) {
+		return fmt.Errorf("expected block %v below the base, got %v", expected, blockID)
+	}
+	bcR.store.SaveBlockBelowBase(block, parts)
+	return nil
+}
@@ -4,8 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	cmn "github.com/tendermint/tendermint/libs/common"
@@ -29,11 +29,21 @@ eg, L = latency = 0.1s
 */
 
 const (
-	requestIntervalMS         = 2
-	maxTotalRequesters        = 600
-	maxPendingRequests        = maxTotalRequesters
+	// The window of heights requested ahead of pool.height is sized to hold
+	// the blocks verified in windowLookahead, measured over the last
+	// verifyRatePeriod, but at least minWindowSize and at most
+	// maxTotalRequesters.
+	minWindowSize      = 2 * maxPendingRequestsPerPeer
+	maxTotalRequesters = 600
+	windowLookahead    = 5 * time.Second
+	verifyRatePeriod   = 10 * time.Second
+
 	maxPendingRequestsPerPeer = 20
 
+	// Requests are made whenever a peer, block or window slot becomes
+	// available, and at least this often, to check for timed out peers.
+	requestInterval = 100 * time.Millisecond
+
 	// Minimum recv rate to ensure we're receiving blocks from a peer fast
 	// enough. If a peer is not sending us data at at least that rate, we
 	// consider them to have timedout and we disconnect.
@@ -50,93 +60,179 @@ var peerTimeout = 15 * time.Second // not const so we can override with tests
 
 /*
 	Peers self report their heights when we join the block pool.
-	Starting from our latest pool.height, we request blocks
-	in sequence from peers that reported higher heights than ours.
+	Starting from our latest pool.height, we request the blocks of a sliding
+	window of heights from peers that reported higher heights than ours, in
+	parallel, with at most maxPendingRequestsPerPeer requests outstanding per
+	peer. The window grows while blocks are verified quickly and shrinks while
+	verification is the bottleneck, so we don't buffer blocks we can't verify.
 	Every so often we ask peers what height they're on so we can keep going.
 
-	Requests are continuously made for blocks of higher heights until
-	the limit is reached. If most of the requests have no available peers, and we
-	are not at peer limits, we can probably switch to consensus reactor
+	If most of the requests have no available peers, and we are not at peer
+	limits, we can probably switch to consensus reactor
 */
 
 type BlockPool struct {
 	cmn.BaseService
-	startTime time.Time
+	startTime   time.Time
+	startHeight int64
 
 	mtx sync.Mutex
 	// block requests
-	requesters map[int64]*bpRequester
-	height     int64 // the lowest key in requesters.
+	requests map[int64]*bpRequest
+	height   int64 // the lowest key in requests.
 	// peers
 	peers         map[p2p.ID]*bpPeer
 	maxPeerHeight int64
 
-	// atomic
-	numPending int32 // number of requests pending assignment or block response
+	// times blocks were popped in the last verifyRatePeriod
+	popTimes []time.Time
 
 	requestsCh chan<- BlockRequest
 	errorsCh   chan<- peerError
+	wakeCh     chan struct{} // a request can be made
+
+	now func() time.Time // overridden in tests
 }
 
 func NewBlockPool(start int64, requestsCh chan<- BlockRequest, errorsCh chan<- peerError) *BlockPool {
 	bp := &BlockPool{
 		peers: make(map[p2p.ID]*bpPeer),
 
-		requesters: make(map[int64]*bpRequester),
-		height:     start,
-		numPending: 0,
+		requests:    make(map[int64]*bpRequest),
+		height:      start,
+		startTime:   time.Now(),
+		startHeight: start,
 
 		requestsCh: requestsCh,
 		errorsCh:   errorsCh,
+		wakeCh:     make(chan struct{}, 1),
+
+		now: time.Now,
 	}
 	bp.BaseService = *cmn.NewBaseService(nil, "BlockPool", bp)
 	return bp
 }
 
 func (pool *BlockPool) OnStart() error {
-	go pool.makeRequestersRoutine()
-	pool.startTime = time.Now()
+	pool.mtx.Lock()
+	pool.startTime = pool.now()
+	pool.mtx.Unlock()
+	go pool.makeRequestsRoutine()
 	return nil
 }
 
 func (pool *BlockPool) OnStop() {}
 
-// Run spawns requesters as needed.
-func (pool *BlockPool) makeRequestersRoutine() {
+// setStartHeight moves the start of the pool, which isn't running yet.
+func (pool *BlockPool) setStartHeight(height int64) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	pool.height = height
+	pool.startHeight = height
+}
+
+// wake makes the makeRequestsRoutine look for requests to make.
+func (pool *BlockPool) wake() {
+	select {
+	case pool.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// makeRequestsRoutine makes requests whenever woken up or requestInterval
+// passed.
+func (pool *BlockPool) makeRequestsRoutine() {
+	ticker := time.NewTicker(requestInterval)
+	defer ticker.Stop()
+
 	for {
-		if !pool.IsRunning() {
-			break
+		for _, request := range pool.makeRequests() {
+			pool.sendRequest(request.Height, request.PeerID)
 		}
 
-		_, numPending, lenRequesters := pool.GetStatus()
-		if numPending >= maxPendingRequests {
-			// sleep for a bit.
-			time.Sleep(requestIntervalMS * time.Millisecond)
-			// check for timed out peers
-			pool.removeTimedoutPeers()
-		} else if lenRequesters >= maxTotalRequesters {
-			// sleep for a bit.
-			time.Sleep(requestIntervalMS * time.Millisecond)
-			// check for timed out peers
-			pool.removeTimedoutPeers()
-		} else {
-			// request for more blocks.
-			pool.makeNextRequester()
+		select {
+		case <-pool.wakeCh:
+		case <-ticker.C:
+		case <-pool.Quit():
+			return
 		}
 	}
 }
 
-func (pool *BlockPool) removeTimedoutPeers() {
+// makeRequests assigns the unrequested heights of the window to peers and
+// returns the requests to send.
+func (pool *BlockPool) makeRequests() []BlockRequest {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
+	pool.removeTimedoutPeers()
+
+	var requests []BlockRequest
+	end := pool.height + pool.windowSize()
+	for height := pool.height; height < end && height <= pool.maxPeerHeight; height++ {
+		request := pool.requests[height]
+		if request == nil {
+			request = &bpRequest{}
+			pool.requests[height] = request
+		}
+		if request.peerID != "" {
+			continue
+		}
+		peer := pool.pickPeer(height)
+		if peer == nil {
+			// No peer has capacity left at this height, so none has above.
+			break
+		}
+		request.peerID = peer.id
+		requests = append(requests, BlockRequest{height, peer.id})
+	}
+	return requests
+}
+
+// windowSize returns the number of heights to request ahead of pool.height,
+// based on how many blocks were verified recently.
+func (pool *BlockPool) windowSize() int64 {
+	window := int64(pool.verifyRate() * windowLookahead.Seconds())
+	if window < minWindowSize {
+		return minWindowSize
+	}
+	if window > maxTotalRequesters {
+		return maxTotalRequesters
+	}
+	return window
+}
+
+// verifyRate returns the number of blocks verified per second over the last
+// verifyRatePeriod, or since the pool started if that's more recent.
+// NOTE: requires pool.mtx.
+func (pool *BlockPool) verifyRate() float64 {
+	now := pool.now()
+	i := 0
+	for i < len(pool.popTimes) && now.Sub(pool.popTimes[i]) > verifyRatePeriod {
+		i++
+	}
+	pool.popTimes = pool.popTimes[i:]
+
+	period := verifyRatePeriod
+	if elapsed := now.Sub(pool.startTime); elapsed < period {
+		period = elapsed
+	}
+	if period <= 0 {
+		return 0
+	}
+	return float64(len(pool.popTimes)) / period.Seconds()
+}
+
+// removeTimedoutPeers removes the peers which timed out or are too slow.
+// NOTE: requires pool.mtx.
+func (pool *BlockPool) removeTimedoutPeers() {
 	for _, peer := range pool.peers {
 		if !peer.didTimeout && peer.numPending > 0 {
 			curRate := peer.recvMonitor.Status().CurRate
 			// curRate can be 0 on start
 			if curRate != 0 && curRate < minRecvRate {
 				err := errors.New("peer is not sending us data fast enough")
-				pool.sendError(err, peer.id)
+				pool.sendError(p2p.ErrorPeerBehaviourBlockTimeout, err, peer.id)
 				pool.Logger.Error("SendTimeout", "peer", peer.id,
 					"reason", err,
 					"curRate", fmt.Sprintf("%d KB/s", curRate/1024),
@@ -150,11 +246,52 @@ func (pool *BlockPool) removeTimedoutPeers() {
 	}
 }
 
+// GetStatus returns the pool's height, the number of requested blocks that
+// haven't been received yet and the size of the window.
 func (pool *BlockPool) GetStatus() (height int64, numPending int32, lenRequesters int) {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
-	return pool.height, atomic.LoadInt32(&pool.numPending), len(pool.requesters)
+	for _, request := range pool.requests {
+		if request.block == nil {
+			numPending++
+		}
+	}
+	return pool.height, numPending, len(pool.requests)
+}
+
+// SyncInfo returns the progress of the pool and what each of its peers
+// contributes. It's syncing while the pool runs. The ETA is zero while the rate is unknown or the pool is at the
+// highest height reported by a peer.
+func (pool *BlockPool) SyncInfo() SyncInfo {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	info := SyncInfo{
+		Syncing:         pool.IsRunning(),
+		StartHeight:     pool.startHeight,
+		Height:          pool.height - 1,
+		TargetHeight:    pool.maxPeerHeight,
+		BlocksPerSecond: pool.verifyRate(),
+		Peers:           make([]PeerSyncInfo, 0, len(pool.peers)),
+	}
+	if remaining := info.TargetHeight - info.Height; remaining > 0 && info.BlocksPerSecond > 0 {
+		info.ETA = time.Duration(float64(remaining) / info.BlocksPerSecond * float64(time.Second))
+	}
+	for _, peer := range pool.peers {
+		peerInfo := PeerSyncInfo{
+			ID:             peer.id,
+			Height:         peer.height,
+			NumPending:     peer.numPending,
+			BlocksReceived: peer.blocksReceived,
+		}
+		if peer.recvMonitor != nil {
+			peerInfo.RecvRate = peer.recvMonitor.Status().CurRate
+		}
+		info.Peers = append(info.Peers, peerInfo)
+	}
+	sort.Slice(info.Peers, func(i, j int) bool { return info.Peers[i].ID < info.Peers[j].ID })
+	return info
 }
 
 // TODO: relax conditions, prevent abuse.
@@ -185,33 +322,31 @@ func (pool *BlockPool) PeekTwoBlocks() (first *types.Block, second *types.Block)
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
-	if r := pool.requesters[pool.height]; r != nil {
-		first = r.getBlock()
+	if r := pool.requests[pool.height]; r != nil {
+		first = r.block
 	}
-	if r := pool.requesters[pool.height+1]; r != nil {
-		second = r.getBlock()
+	if r := pool.requests[pool.height+1]; r != nil {
+		second = r.block
 	}
 	return
 }
 
 // Pop the first block at pool.height
 // It must have been validated by 'second'.Commit from PeekTwoBlocks().
-func (pool *BlockPool) PopRequest() {
+// Returns the ID of the peer which sent the block.
+func (pool *BlockPool) PopRequest() p2p.ID {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
-	if r := pool.requesters[pool.height]; r != nil {
-		/*  The block can disappear at any time, due to removePeer().
-		if r := pool.requesters[pool.height]; r == nil || r.block == nil {
-			PanicSanity("PopRequest() requires a valid block")
-		}
-		*/
-		r.Stop()
-		delete(pool.requesters, pool.height)
-		pool.height++
-	} else {
-		panic(fmt.Sprintf("Expected requester to pop, got nothing at height %v", pool.height))
+	r := pool.requests[pool.height]
+	if r == nil {
+		panic(fmt.Sprintf("Expected request to pop, got nothing at height %v", pool.height))
 	}
+	delete(pool.requests, pool.height)
+	pool.height++
+	pool.popTimes = append(pool.popTimes, pool.now())
+	pool.wake()
+	return r.peerID
 }
 
 // Invalidates the block at pool.height,
@@ -221,10 +356,13 @@ func (pool *BlockPool) RedoRequest(height int64) p2p.ID {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
-	request := pool.requesters[height]
-	peerID := request.getPeerID()
+	request := pool.requests[height]
+	if request == nil {
+		return ""
+	}
+	peerID := request.peerID
 	if peerID != p2p.ID("") {
-		// RemovePeer will redo all requesters associated with this peer.
+		// RemovePeer will redo all requests associated with this peer.
 		pool.removePeer(peerID)
 	}
 	return peerID
@@ -235,28 +373,30 @@ func (pool *BlockPool) AddBlock(peerID p2p.ID, block *types.Block, blockSize int
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
-	requester := pool.requesters[block.Height]
-	if requester == nil {
+	request := pool.requests[block.Height]
+	if request == nil {
 		pool.Logger.Info("peer sent us a block we didn't expect", "peer", peerID, "curHeight", pool.height, "blockHeight", block.Height)
 		diff := pool.height - block.Height
 		if diff < 0 {
 			diff *= -1
 		}
 		if diff > maxDiffBetweenCurrentAndReceivedBlockHeight {
-			pool.sendError(errors.New("peer sent us a block we didn't expect with a height too far ahead/behind"), peerID)
+			pool.sendError(p2p.ErrorPeerBehaviourBadMessage,
+				errors.New("peer sent us a block we didn't expect with a height too far ahead/behind"), peerID)
 		}
 		return
 	}
 
-	if requester.setBlock(block, peerID) {
-		atomic.AddInt32(&pool.numPending, -1)
-		peer := pool.peers[peerID]
-		if peer != nil {
+	if request.block == nil && request.peerID == peerID {
+		request.block = block
+		if peer := pool.peers[peerID]; peer != nil {
 			peer.decrPending(blockSize)
+			peer.blocksReceived++
 		}
+		pool.wake()
 	} else {
 		pool.Logger.Info("invalid peer", "peer", peerID, "blockHeight", block.Height)
-		pool.sendError(errors.New("invalid peer"), peerID)
+		pool.sendError(p2p.ErrorPeerBehaviourMessageOutOfOrder, errors.New("invalid peer"), peerID)
 	}
 }
 
@@ -284,6 +424,18 @@ func (pool *BlockPool) SetPeerHeight(peerID p2p.ID, height int64) {
 	if height > pool.maxPeerHeight {
 		pool.maxPeerHeight = height
 	}
+	pool.wake()
+}
+
+// SetPeerLatency sets the ping round trip time of the peer, which is used to
+// prefer low-latency peers when requesting blocks. Unknown peers are ignored.
+func (pool *BlockPool) SetPeerLatency(peerID p2p.ID, latency time.Duration) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	if peer := pool.peers[peerID]; peer != nil {
+		peer.latency = latency
+	}
 }
 
 func (pool *BlockPool) RemovePeer(peerID p2p.ID) {
@@ -293,16 +445,20 @@ func (pool *BlockPool) RemovePeer(peerID p2p.ID) {
 	pool.removePeer(peerID)
 }
 
+// removePeer removes the peer and unassigns all its requests, including the
+// ones it sent the block for already, so they are requested from others.
 func (pool *BlockPool) removePeer(peerID p2p.ID) {
-	for _, requester := range pool.requesters {
-		if requester.getPeerID() == peerID {
-			requester.redo(peerID)
+	for _, request := range pool.requests {
+		if request.peerID == peerID {
+			request.peerID = ""
+			request.block = nil
 		}
 	}
 	if p, exist := pool.peers[peerID]; exist && p.timeout != nil {
 		p.timeout.Stop()
 	}
 	delete(pool.peers, peerID)
+	pool.wake()
 }
 
 // Pick an available peer with at least the given minHeight.
@@ -311,6 +467,15 @@ func (pool *BlockPool) pickIncrAvailablePeer(minHeight int64) *bpPeer {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
+	return pool.pickPeer(minHeight)
+}
+
+// pickPeer picks the fastest peer with at least the given minHeight which
+// has fewer than maxPendingRequestsPerPeer requests pending, and increments
+// its pending requests. Returns nil if no peer is available.
+// NOTE: requires pool.mtx.
+func (pool *BlockPool) pickPeer(minHeight int64) *bpPeer {
+	var best *bpPeer
 	for _, peer := range pool.peers {
 		if peer.didTimeout {
 			pool.removePeer(peer.id)
@@ -322,34 +487,14 @@ func (pool *BlockPool) pickIncrAvailablePeer(minHeight int64) *bpPeer {
 		if peer.height < minHeight {
 			continue
 		}
-		peer.incrPending()
-		return peer
-	}
-	return nil
-}
-
-func (pool *BlockPool) makeNextRequester() {
-	pool.mtx.Lock()
-	defer pool.mtx.Unlock()
-
-	nextHeight := pool.height + pool.requestersLen()
-	if nextHeight > pool.maxPeerHeight {
-		return
+		if best == nil || peer.fasterThan(best) {
+			best = peer
+		}
 	}
-
-	request := newBPRequester(pool, nextHeight)
-
-	pool.requesters[nextHeight] = request
-	atomic.AddInt32(&pool.numPending, 1)
-
-	err := request.Start()
-	if err != nil {
-		request.Logger.Error("Error starting request", "err", err)
+	if best != nil {
+		best.incrPending()
 	}
-}
-
-func (pool *BlockPool) requestersLen() int64 {
-	return int64(len(pool.requesters))
+	return best
 }
 
 func (pool *BlockPool) sendRequest(height int64, peerID p2p.ID) {
@@ -359,11 +504,11 @@ func (pool *BlockPool) sendRequest(height int64, peerID p2p.ID) {
 	pool.requestsCh <- BlockRequest{height, peerID}
 }
 
-func (pool *BlockPool) sendError(err error, peerID p2p.ID) {
+func (pool *BlockPool) sendError(reason p2p.ErrorPeerBehaviour, err error, peerID p2p.ID) {
 	if !pool.IsRunning() {
 		return
 	}
-	pool.errorsCh <- peerError{err, peerID}
+	pool.errorsCh <- peerError{reason, err, peerID}
 }
 
 // for debugging purposes
@@ -373,13 +518,13 @@ func (pool *BlockPool) debug() string {
 	defer pool.mtx.Unlock()
 
 	str := ""
-	nextHeight := pool.height + pool.requestersLen()
+	nextHeight := pool.height + int64(len(pool.requests))
 	for h := pool.height; h < nextHeight; h++ {
-		if pool.requesters[h] == nil {
+		if pool.requests[h] == nil {
 			str += fmt.Sprintf("H(%v):X ", h)
 		} else {
 			str += fmt.Sprintf("H(%v):", h)
-			str += fmt.Sprintf("B?(%v) ", pool.requesters[h].block != nil)
+			str += fmt.Sprintf("B?(%v) ", pool.requests[h].block != nil)
 		}
 	}
 	return str
@@ -392,10 +537,12 @@ type bpPeer struct {
 	id          p2p.ID
 	recvMonitor *flow.Monitor
 
-	height     int64
-	numPending int32
-	timeout    *time.Timer
-	didTimeout bool
+	height         int64
+	latency        time.Duration // ping round trip time, 0 if unknown
+	numPending     int32
+	blocksReceived int64
+	timeout        *time.Timer
+	didTimeout     bool
 
 	logger log.Logger
 }
@@ -411,6 +558,15 @@ func newBPPeer(pool *BlockPool, peerID p2p.ID, height int64) *bpPeer {
 	return peer
 }
 
+// fasterThan returns true if the peer has a lower latency than other. Peers
+// with unknown latency are slower than all others.
+func (peer *bpPeer) fasterThan(other *bpPeer) bool {
+	if peer.latency == 0 {
+		return false
+	}
+	return other.latency == 0 || peer.latency < other.latency
+}
+
 func (peer *bpPeer) setLogger(l log.Logger) {
 	peer.logger = l
 }
@@ -452,144 +608,45 @@ func (peer *bpPeer) onTimeout() {
 	defer peer.pool.mtx.Unlock()
 
 	err := errors.New("peer did not send us anything")
-	peer.pool.sendError(err, peer.id)
+	peer.pool.sendError(p2p.ErrorPeerBehaviourBlockTimeout, err, peer.id)
 	peer.logger.Error("SendTimeout", "reason", err, "timeout", peerTimeout)
 	peer.didTimeout = true
+	peer.pool.wake()
 }
 
 //-------------------------------------
 
-type bpRequester struct {
-	cmn.BaseService
-	pool       *BlockPool
-	height     int64
-	gotBlockCh chan struct{}
-	redoCh     chan p2p.ID //redo may send multitime, add peerId to identify repeat
-
-	mtx    sync.Mutex
+// bpRequest is the request for the block at a height of the window. It's
+// unassigned while peerID is empty.
+type bpRequest struct {
 	peerID p2p.ID
 	block  *types.Block
 }
 
-func newBPRequester(pool *BlockPool, height int64) *bpRequester {
-	bpr := &bpRequester{
-		pool:       pool,
-		height:     height,
-		gotBlockCh: make(chan struct{}, 1),
-		redoCh:     make(chan p2p.ID, 1),
-
-		peerID: "",
-		block:  nil,
-	}
-	bpr.BaseService = *cmn.NewBaseService(nil, "bpRequester", bpr)
-	return bpr
-}
-
-func (bpr *bpRequester) OnStart() error {
-	go bpr.requestRoutine()
-	return nil
-}
-
-// Returns true if the peer matches and block doesn't already exist.
-func (bpr *bpRequester) setBlock(block *types.Block, peerID p2p.ID) bool {
-	bpr.mtx.Lock()
-	if bpr.block != nil || bpr.peerID != peerID {
-		bpr.mtx.Unlock()
-		return false
-	}
-	bpr.block = block
-	bpr.mtx.Unlock()
-
-	select {
-	case bpr.gotBlockCh <- struct{}{}:
-	default:
-	}
-	return true
-}
-
-func (bpr *bpRequester) getBlock() *types.Block {
-	bpr.mtx.Lock()
-	defer bpr.mtx.Unlock()
-	return bpr.block
-}
-
-func (bpr *bpRequester) getPeerID() p2p.ID {
-	bpr.mtx.Lock()
-	defer bpr.mtx.Unlock()
-	return bpr.peerID
-}
-
-// This is called from the requestRoutine, upon redo().
-func (bpr *bpRequester) reset() {
-	bpr.mtx.Lock()
-	defer bpr.mtx.Unlock()
-
-	if bpr.block != nil {
-		atomic.AddInt32(&bpr.pool.numPending, 1)
-	}
-
-	bpr.peerID = ""
-	bpr.block = nil
-}
-
-// Tells bpRequester to pick another peer and try again.
-// NOTE: Nonblocking, and does nothing if another redo
-// was already requested.
-func (bpr *bpRequester) redo(peerId p2p.ID) {
-	select {
-	case bpr.redoCh <- peerId:
-	default:
-	}
-}
+//-------------------------------------
 
-// Responsible for making more requests as necessary
-// Returns only when a block is found (e.g. AddBlock() is called)
-func (bpr *bpRequester) requestRoutine() {
-OUTER_LOOP:
-	for {
-		// Pick a peer to send request to.
-		var peer *bpPeer
-	PICK_PEER_LOOP:
-		for {
-			if !bpr.IsRunning() || !bpr.pool.IsRunning() {
-				return
-			}
-			peer = bpr.pool.pickIncrAvailablePeer(bpr.height)
-			if peer == nil {
-				//log.Info("No peers available", "height", height)
-				time.Sleep(requestIntervalMS * time.Millisecond)
-				continue PICK_PEER_LOOP
-			}
-			break PICK_PEER_LOOP
-		}
-		bpr.mtx.Lock()
-		bpr.peerID = peer.id
-		bpr.mtx.Unlock()
-
-		// Send request and wait.
-		bpr.pool.sendRequest(bpr.height, peer.id)
-	WAIT_LOOP:
-		for {
-			select {
-			case <-bpr.pool.Quit():
-				bpr.Stop()
-				return
-			case <-bpr.Quit():
-				return
-			case peerID := <-bpr.redoCh:
-				if peerID == bpr.peerID {
-					bpr.reset()
-					continue OUTER_LOOP
-				} else {
-					continue WAIT_LOOP
-				}
-			case <-bpr.gotBlockCh:
-				// We got a block!
-				// Continue the for-loop and wait til Quit.
-				continue WAIT_LOOP
-			}
-		}
-	}
+// SyncInfo is the progress of fast sync. Height is the last synced block and
+// TargetHeight the highest height reported by a peer. BlocksPerSecond is
+// measured over the last verifyRatePeriod.
+type SyncInfo struct {
+	Syncing         bool
+	StartHeight     int64
+	Height          int64
+	TargetHeight    int64
+	BlocksPerSecond float64
+	ETA             time.Duration
+	Peers           []PeerSyncInfo
+}
+
+// PeerSyncInfo is what a peer contributes to fast sync. BlocksReceived counts
+// the blocks the peer sent which we requested from it, RecvRate is in bytes
+// per second.
+type PeerSyncInfo struct {
+	ID             p2p.ID
+	Height         int64
+	NumPending     int32
+	BlocksReceived int64
+	RecvRate       int64
 }
 
 //-------------------------------------
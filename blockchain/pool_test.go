@@ -13,6 +13,7 @@ import (
 
 func init() {
 	peerTimeout = 2 * time.Second
+	backfillRetryInterval = 100 * time.Millisecond
 }
 
 type testPeer struct {
@@ -180,3 +181,158 @@ func TestTimeout(t *testing.T) {
 		}
 	}
 }
+
+func TestPickLowLatencyPeer(t *testing.T) {
+	pool := NewBlockPool(1, make(chan BlockRequest), make(chan peerError))
+	pool.SetLogger(log.TestingLogger())
+
+	pool.SetPeerHeight("unknown", 10)
+	pool.SetPeerHeight("slow", 10)
+	pool.SetPeerLatency("slow", 200*time.Millisecond)
+	pool.SetPeerHeight("fast", 10)
+	pool.SetPeerLatency("fast", 20*time.Millisecond)
+	pool.SetPeerHeight("short", 5)
+	pool.SetPeerLatency("short", time.Millisecond)
+
+	peer := pool.pickIncrAvailablePeer(10)
+	if peer == nil || peer.id != "fast" {
+		t.Fatalf("expected fast peer, got %v", peer)
+	}
+
+	// Peers with too many pending requests are skipped.
+	for i := 1; i < maxPendingRequestsPerPeer; i++ {
+		pool.pickIncrAvailablePeer(10)
+	}
+	peer = pool.pickIncrAvailablePeer(10)
+	if peer == nil || peer.id != "slow" {
+		t.Fatalf("expected slow peer, got %v", peer)
+	}
+}
+
+func TestRequestsInParallel(t *testing.T) {
+	start := int64(1)
+	peers := makePeers(4, 1000, 1001)
+	requestsCh := make(chan BlockRequest, maxTotalRequesters)
+	pool := NewBlockPool(start, requestsCh, make(chan peerError, 1000))
+	pool.SetLogger(log.TestingLogger())
+	if err := pool.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Stop()
+
+	for _, peer := range peers {
+		pool.SetPeerHeight(peer.id, peer.height)
+	}
+
+	// Without responses, the whole window is requested at once, spread over
+	// the peers.
+	perPeer := make(map[p2p.ID]int)
+	heights := make(map[int64]bool)
+	for i := 0; i < minWindowSize; i++ {
+		select {
+		case request := <-requestsCh:
+			perPeer[request.PeerID]++
+			heights[request.Height] = true
+		case <-time.After(time.Second):
+			t.Fatalf("got %d requests, expected %d", i, minWindowSize)
+		}
+	}
+	for h := start; h < start+minWindowSize; h++ {
+		if !heights[h] {
+			t.Errorf("height %d not requested", h)
+		}
+	}
+	if len(perPeer) < minWindowSize/maxPendingRequestsPerPeer {
+		t.Errorf("requests sent to %d peers only", len(perPeer))
+	}
+	for peerID, n := range perPeer {
+		if n > maxPendingRequestsPerPeer {
+			t.Errorf("%d requests pending for peer %v", n, peerID)
+		}
+	}
+
+	// Nothing beyond the window is requested.
+	select {
+	case request := <-requestsCh:
+		t.Errorf("unexpected request %+v", request)
+	case <-time.After(2 * requestInterval):
+	}
+}
+
+func TestWindowSize(t *testing.T) {
+	pool := NewBlockPool(1, make(chan BlockRequest), make(chan peerError))
+	now := time.Now()
+	pool.now = func() time.Time { return now }
+	pool.startTime = now
+
+	if w := pool.windowSize(); w != minWindowSize {
+		t.Errorf("expected window %d before any verification, got %d", minWindowSize, w)
+	}
+
+	// 20 blocks verified per second fill a window of 100.
+	for i := 0; i < 40; i++ {
+		now = now.Add(50 * time.Millisecond)
+		pool.popTimes = append(pool.popTimes, now)
+	}
+	if w := pool.windowSize(); w != 20*int64(windowLookahead.Seconds()) {
+		t.Errorf("expected window %d, got %d", 20*int64(windowLookahead.Seconds()), w)
+	}
+
+	// Fast verification is capped.
+	for i := 0; i < 10000; i++ {
+		pool.popTimes = append(pool.popTimes, now)
+	}
+	if w := pool.windowSize(); w != maxTotalRequesters {
+		t.Errorf("expected window %d, got %d", maxTotalRequesters, w)
+	}
+
+	// The window shrinks once verification stalls.
+	now = now.Add(verifyRatePeriod + time.Second)
+	if w := pool.windowSize(); w != minWindowSize {
+		t.Errorf("expected window %d after stalling, got %d", minWindowSize, w)
+	}
+}
+
+func TestSyncInfo(t *testing.T) {
+	pool := NewBlockPool(1, make(chan BlockRequest), make(chan peerError))
+	pool.SetLogger(log.TestingLogger())
+	now := time.Now()
+	pool.now = func() time.Time { return now }
+	pool.startTime = now.Add(-verifyRatePeriod)
+
+	pool.SetPeerHeight("a", 101)
+	pool.SetPeerHeight("b", 50)
+	requests := pool.makeRequests()
+	for _, request := range requests {
+		block := &types.Block{Header: types.Header{Height: request.Height}}
+		pool.AddBlock(request.PeerID, block, 123)
+	}
+	// 20 blocks verified in the last verifyRatePeriod.
+	for i := 0; i < 20; i++ {
+		pool.PopRequest()
+	}
+
+	info := pool.SyncInfo()
+	if info.Syncing {
+		t.Error("expected the stopped pool not to be syncing")
+	}
+	if info.StartHeight != 1 || info.Height != 20 || info.TargetHeight != 101 {
+		t.Errorf("unexpected heights %+v", info)
+	}
+	if info.BlocksPerSecond != 2 {
+		t.Errorf("expected 2 blocks/s, got %v", info.BlocksPerSecond)
+	}
+	if expected := 81 * time.Second / 2; info.ETA != expected {
+		t.Errorf("expected ETA %v, got %v", expected, info.ETA)
+	}
+	if len(info.Peers) != 2 || info.Peers[0].ID != "a" || info.Peers[1].ID != "b" {
+		t.Fatalf("unexpected peers %+v", info.Peers)
+	}
+	received := info.Peers[0].BlocksReceived + info.Peers[1].BlocksReceived
+	if received != int64(len(requests)) {
+		t.Errorf("expected %d blocks received, got %d", len(requests), received)
+	}
+	if info.Peers[0].NumPending != 0 || info.Peers[1].NumPending != 0 {
+		t.Errorf("expected no pending requests, got %+v", info.Peers)
+	}
+}
@@ -20,6 +20,10 @@ const (
 
 	trySyncIntervalMS = 10
 
+	// name the reactor is added to the Switch with, reported with peer
+	// behaviour
+	reactorName = "BLOCKCHAIN"
+
 	// stop syncing when last block's time is
 	// within this much of the system time.
 	// stopSyncingDurationMinutes = 10
@@ -44,6 +48,7 @@ type consensusReactor interface {
 }
 
 type peerError struct {
+	reason p2p.ErrorPeerBehaviour
 	err    error
 	peerID p2p.ID
 }
@@ -66,13 +71,24 @@ type BlockchainReactor struct {
 
 	requestsCh <-chan BlockRequest
 	errorsCh   <-chan peerError
+
+	backfillHeight int64
+	backfillCh     chan backfillResponse
+
+	appHashMismatchFile string
 }
 
-// NewBlockchainReactor returns new reactor instance.
+// NewBlockchainReactor returns new reactor instance. If the store is empty
+// and its base is the height of the state, the state is a trusted snapshot and
+// fast sync starts from the block at that height, whose hash has to match the
+// state's last block ID.
 func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *BlockStore,
 	fastSync bool) *BlockchainReactor {
 
-	if state.LastBlockHeight != store.Height() {
+	startHeight := store.Height() + 1
+	if state.LastBlockHeight > 0 && store.Height() == 0 && store.Base() == state.LastBlockHeight {
+		startHeight = state.LastBlockHeight
+	} else if state.LastBlockHeight != store.Height() {
 		panic(fmt.Sprintf("state (%v) and store (%v) height mismatch", state.LastBlockHeight,
 			store.Height()))
 	}
@@ -83,7 +99,7 @@ func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *Bl
 	errorsCh := make(chan peerError, capacity) // so we don't block in #Receive#pool.AddBlock
 
 	pool := NewBlockPool(
-		store.Height()+1,
+		startHeight,
 		requestsCh,
 		errorsCh,
 	)
@@ -96,6 +112,7 @@ func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *Bl
 		fastSync:     fastSync,
 		requestsCh:   requestsCh,
 		errorsCh:     errorsCh,
+		backfillCh:   make(chan backfillResponse, 1),
 	}
 	bcR.BaseReactor = *p2p.NewBaseReactor("BlockchainReactor", bcR)
 	return bcR
@@ -107,6 +124,13 @@ func (bcR *BlockchainReactor) SetLogger(l log.Logger) {
 	bcR.pool.Logger = l
 }
 
+// SetAppHashMismatchFile sets the file the diagnostic report of an app hash
+// mismatch is written to, if the app disagrees with the chain while fast
+// syncing (see sm.AppHashMismatch). Fast sync then halts.
+func (bcR *BlockchainReactor) SetAppHashMismatchFile(path string) {
+	bcR.appHashMismatchFile = path
+}
+
 // OnStart implements cmn.Service.
 func (bcR *BlockchainReactor) OnStart() error {
 	if bcR.fastSync {
@@ -116,6 +140,9 @@ func (bcR *BlockchainReactor) OnStart() error {
 		}
 		go bcR.poolRoutine()
 	}
+	if bcR.backfillHeight > 0 {
+		go bcR.backfillRoutine()
+	}
 	return nil
 }
 
@@ -124,6 +151,34 @@ func (bcR *BlockchainReactor) OnStop() {
 	bcR.pool.Stop()
 }
 
+// SwitchToFastSync starts fast sync from the given state, which was restored
+// from a snapshot by state sync, once the reactor was started without it. The
+// store must start at the state's height, so the trusted block is fetched
+// first.
+func (bcR *BlockchainReactor) SwitchToFastSync(state sm.State) error {
+	if bcR.fastSync {
+		return errors.New("already fast syncing")
+	}
+	if bcR.store.Height() != 0 || bcR.store.Base() != state.LastBlockHeight {
+		return fmt.Errorf("store with blocks %v to %v doesn't start at the state (%v)",
+			bcR.store.Base(), bcR.store.Height(), state.LastBlockHeight)
+	}
+	bcR.fastSync = true
+	bcR.initialState = state
+	bcR.pool.setStartHeight(state.LastBlockHeight)
+	if err := bcR.pool.Start(); err != nil {
+		return err
+	}
+	go bcR.poolRoutine()
+	return nil
+}
+
+// SyncInfo returns the progress of fast sync. It's not syncing if fast sync
+// is disabled or the reactor switched to consensus.
+func (bcR *BlockchainReactor) SyncInfo() SyncInfo {
+	return bcR.pool.SyncInfo()
+}
+
 // GetChannels implements Reactor
 func (bcR *BlockchainReactor) GetChannels() []*p2p.ChannelDescriptor {
 	return []*p2p.ChannelDescriptor{
@@ -176,13 +231,13 @@ func (bcR *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 	msg, err := decodeMsg(msgBytes)
 	if err != nil {
 		bcR.Logger.Error("Error decoding message", "src", src, "chId", chID, "msg", msg, "err", err, "bytes", msgBytes)
-		bcR.Switch.StopPeerForError(src, err)
+		bcR.reportError(src, p2p.ErrorPeerBehaviourBadMessage, 0, err)
 		return
 	}
 
 	if err = msg.ValidateBasic(); err != nil {
 		bcR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
-		bcR.Switch.StopPeerForError(src, err)
+		bcR.reportError(src, p2p.ErrorPeerBehaviourBadMessage, 0, err)
 		return
 	}
 
@@ -194,7 +249,15 @@ func (bcR *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 			// Unfortunately not queued since the queue is full.
 		}
 	case *bcBlockResponseMessage:
-		bcR.pool.AddBlock(src.ID(), msg.Block, len(msgBytes))
+		if msg.Block.Height < bcR.store.Base() {
+			bcR.receiveBackfill(src.ID(), msg.Block.Height, msg.Block)
+		} else {
+			bcR.pool.AddBlock(src.ID(), msg.Block, len(msgBytes))
+		}
+	case *bcNoBlockResponseMessage:
+		if msg.Height < bcR.store.Base() {
+			bcR.receiveBackfill(src.ID(), msg.Height, nil)
+		}
 	case *bcStatusRequestMessage:
 		// Send peer our state.
 		msgBytes := cdc.MustMarshalBinaryBare(&bcStatusResponseMessage{bcR.store.Height()})
@@ -205,6 +268,7 @@ func (bcR *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 	case *bcStatusResponseMessage:
 		// Got a peer status. Unverified.
 		bcR.pool.SetPeerHeight(src.ID(), msg.Height)
+		bcR.pool.SetPeerLatency(src.ID(), src.Latency())
 	default:
 		bcR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 	}
@@ -247,7 +311,7 @@ FOR_LOOP:
 		case err := <-bcR.errorsCh:
 			peer := bcR.Switch.Peers().Get(err.peerID)
 			if peer != nil {
-				bcR.Switch.StopPeerForError(peer, err)
+				bcR.reportError(peer, err.reason, 0, err.err)
 			}
 
 		case <-statusUpdateTicker.C:
@@ -259,7 +323,9 @@ FOR_LOOP:
 			outbound, inbound, _ := bcR.Switch.NumPeers()
 			bcR.Logger.Debug("Consensus ticker", "numPending", numPending, "total", lenRequesters,
 				"outbound", outbound, "inbound", inbound)
-			if bcR.pool.IsCaughtUp() {
+			// Without the trusted block the commit of the state's last
+			// block is missing, so consensus can't start yet.
+			if bcR.pool.IsCaughtUp() && bcR.store.Height() >= state.LastBlockHeight {
 				bcR.Logger.Info("Time to switch to consensus reactor!", "height", height)
 				bcR.pool.Stop()
 
@@ -306,8 +372,16 @@ FOR_LOOP:
 			// NOTE: we can probably make this more efficient, but note that calling
 			// first.Hash() doesn't verify the tx contents, so MakePartSet() is
 			// currently necessary.
-			err := state.Validators.VerifyCommit(
-				chainID, firstID, first.Height, second.LastCommit)
+			// The trusted block is already reflected in the state, so it's only
+			// stored, for its commit, rather than executed.
+			trusted := first.Height == state.LastBlockHeight
+			var err error
+			if trusted {
+				err = verifyTrustedBlock(chainID, state, firstID, second.LastCommit)
+			} else {
+				err = state.Validators.VerifyCommit(
+					chainID, firstID, first.Height, second.LastCommit)
+			}
 			if err != nil {
 				bcR.Logger.Error("Error in validation", "err", err)
 				peerID := bcR.pool.RedoRequest(first.Height)
@@ -315,21 +389,41 @@ FOR_LOOP:
 				if peer != nil {
 					// NOTE: we've already removed the peer's request, but we
 					// still need to clean up the rest.
-					bcR.Switch.StopPeerForError(peer, fmt.Errorf("BlockchainReactor validation error: %v", err))
+					bcR.reportError(peer, p2p.ErrorPeerBehaviourBadBlock, first.Height, err)
 				}
 				peerID2 := bcR.pool.RedoRequest(second.Height)
 				peer2 := bcR.Switch.Peers().Get(peerID2)
 				if peer2 != nil && peer2 != peer {
 					// NOTE: we've already removed the peer's request, but we
 					// still need to clean up the rest.
-					bcR.Switch.StopPeerForError(peer2, fmt.Errorf("BlockchainReactor validation error: %v", err))
+					bcR.reportError(peer2, p2p.ErrorPeerBehaviourBadBlock, second.Height, err)
 				}
 				continue FOR_LOOP
 			} else {
-				bcR.pool.PopRequest()
+				peerID := bcR.pool.PopRequest()
+				if peer := bcR.Switch.Peers().Get(peerID); peer != nil {
+					bcR.Switch.PeerBehaviour().Behaved(peer, p2p.GoodBehaviourReport{
+						Reason:  p2p.GoodPeerBehaviourBlockResponse,
+						Reactor: reactorName,
+						Height:  first.Height,
+					})
+				}
+
+				// The committed block has the app hash the chain agreed on
+				// after the state's last block.
+				if !trusted {
+					if m := sm.CheckAppHash(state, first); m != nil {
+						bcR.haltOnAppHashMismatch(m)
+						break FOR_LOOP
+					}
+				}
 
 				// TODO: batch saves so we dont persist to disk every block
 				bcR.store.SaveBlock(first, firstParts, second.LastCommit)
+				if trusted {
+					bcR.Logger.Info("Fast syncing from trusted block", "height", first.Height, "hash", first.Hash())
+					continue FOR_LOOP
+				}
 
 				// TODO: same thing for app - but we would need a way to
 				// get the hash without persisting the state
@@ -356,6 +450,43 @@ FOR_LOOP:
 	}
 }
 
+// haltOnAppHashMismatch writes the report of the app diverging from the
+// chain, and stops fast syncing, rather than applying the next block.
+func (bcR *BlockchainReactor) haltOnAppHashMismatch(m *sm.AppHashMismatch) {
+	m.Time = time.Now()
+	m.Source = sm.AppHashMismatchFastSync
+	bcR.Logger.Error("App hash mismatch, halting fast sync", "height", m.Height, "appHash", m.AppHash,
+		"expected", m.ExpectedAppHash, "lastAgreedHeight", m.LastAgreedHeight)
+	if bcR.appHashMismatchFile != "" {
+		if err := sm.SaveAppHashMismatch(bcR.appHashMismatchFile, m); err != nil {
+			bcR.Logger.Error("Failed to write the app hash mismatch report", "err", err)
+		} else {
+			bcR.Logger.Error("Wrote the app hash mismatch report", "file", bcR.appHashMismatchFile)
+		}
+	}
+	bcR.pool.Stop()
+}
+
+// verifyTrustedBlock verifies the block with the given ID is the last block of
+// the state, committed by its last validators.
+func verifyTrustedBlock(chainID string, state sm.State, blockID types.BlockID, commit *types.Commit) error {
+	if !blockID.Equals(state.LastBlockID) {
+		return fmt.Errorf("expected trusted block %v, got %v", state.LastBlockID, blockID)
+	}
+	return state.LastValidators.VerifyCommit(chainID, blockID, state.LastBlockHeight, commit)
+}
+
+// reportError reports the misbehaviour of a peer to the PeerBehaviour of the
+// Switch, which decides whether to stop the peer.
+func (bcR *BlockchainReactor) reportError(peer p2p.Peer, reason p2p.ErrorPeerBehaviour, height int64, err error) {
+	bcR.Switch.PeerBehaviour().Errored(peer, p2p.ErrorBehaviourReport{
+		Reason:  reason,
+		Reactor: reactorName,
+		Height:  height,
+		Detail:  err.Error(),
+	})
+}
+
 // BroadcastStatusRequest broadcasts `BlockStore` height.
 func (bcR *BlockchainReactor) BroadcastStatusRequest() error {
 	msgBytes := cdc.MustMarshalBinaryBare(&bcStatusRequestMessage{bcR.store.Height()})
@@ -102,20 +102,22 @@ func newBlockchainReactor(logger log.Logger, genDoc *types.GenesisDoc, privVals
 			lastBlock := blockStore.LoadBlock(blockHeight - 1)
 
 			vote := makeVote(&lastBlock.Header, lastBlockMeta.BlockID, state.Validators, privVals[0]).CommitSig()
-			lastCommit = types.NewCommit(lastBlockMeta.BlockID, []*types.CommitSig{vote})
+			lastCommit = types.NewCommit(lastBlockMeta.BlockID, []*types.CommitSig{vote}).WithoutExtensions()
 		}
 
 		thisBlock := makeBlock(blockHeight, state, lastCommit)
 
 		thisParts := thisBlock.MakePartSet(types.BlockPartSizeBytes)
 		blockID := types.BlockID{thisBlock.Hash(), thisParts.Header()}
+		vote := makeVote(&thisBlock.Header, blockID, state.Validators, privVals[0]).CommitSig()
+		seenCommit := types.NewCommit(blockID, []*types.CommitSig{vote})
 
 		state, err = blockExec.ApplyBlock(state, blockID, thisBlock)
 		if err != nil {
 			panic(cmn.ErrorWrap(err, "error apply block"))
 		}
 
-		blockStore.SaveBlock(thisBlock, thisParts, lastCommit)
+		blockStore.SaveBlock(thisBlock, thisParts, seenCommit)
 	}
 
 	bcReactor := NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
@@ -136,8 +138,12 @@ func TestNoBlockResponse(t *testing.T) {
 	reactorPairs[0] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, maxBlockHeight)
 	reactorPairs[1] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, 0)
 
-	p2p.MakeConnectedSwitches(config.P2P, 2, func(i int, s *p2p.Switch) *p2p.Switch {
+	peerBehaviour := p2p.NewStorePeerBehaviour()
+	switches := p2p.MakeConnectedSwitches(config.P2P, 2, func(i int, s *p2p.Switch) *p2p.Switch {
 		s.AddReactor("BLOCKCHAIN", reactorPairs[i].reactor)
+		if i == 1 {
+			s.SetPeerBehaviour(peerBehaviour)
+		}
 		return s
 
 	}, p2p.Connect2Switches)
@@ -177,6 +183,189 @@ func TestNoBlockResponse(t *testing.T) {
 			assert.True(t, block == nil)
 		}
 	}
+
+	// Every synced block was reported as a good response of the peer.
+	peerID := switches[0].NodeInfo().ID()
+	assert.Empty(t, peerBehaviour.GetErrored(peerID))
+	behaved := peerBehaviour.GetBehaved(peerID)
+	assert.Len(t, behaved, int(reactorPairs[1].reactor.store.Height()))
+	for _, report := range behaved {
+		assert.Equal(t, p2p.GoodPeerBehaviourBlockResponse, report.Reason)
+	}
+}
+
+// newSnapshotReactor returns a reactor with the state and app of the chain
+// in store at trustedHeight, but none of its blocks, as if both were restored
+// from a snapshot.
+func newSnapshotReactor(logger log.Logger, genDoc *types.GenesisDoc, store *BlockStore,
+	trustedHeight int64) BlockchainReactorPair {
+
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(&testApp{}))
+	err := proxyApp.Start()
+	if err != nil {
+		panic(cmn.ErrorWrap(err, "error start app"))
+	}
+
+	state, err := sm.LoadStateFromDBOrGenesisDoc(dbm.NewMemDB(), genDoc)
+	if err != nil {
+		panic(cmn.ErrorWrap(err, "error constructing state from genesis file"))
+	}
+	blockExec := sm.NewBlockExecutor(dbm.NewMemDB(), log.TestingLogger(), proxyApp.Consensus(),
+		sm.MockMempool{}, sm.MockEvidencePool{})
+	for height := int64(1); height <= trustedHeight; height++ {
+		state, err = blockExec.ApplyBlock(state, store.LoadBlockMeta(height).BlockID, store.LoadBlock(height))
+		if err != nil {
+			panic(cmn.ErrorWrap(err, "error apply block"))
+		}
+	}
+
+	blockStore := NewBlockStore(dbm.NewMemDB())
+	if err := blockStore.SetBase(trustedHeight); err != nil {
+		panic(err)
+	}
+	bcReactor := NewBlockchainReactor(state.Copy(), blockExec, blockStore, true)
+	bcReactor.SetLogger(logger.With("module", "blockchain"))
+
+	return BlockchainReactorPair{bcReactor, proxyApp}
+}
+
+func TestFastSyncFromTrustedBlock(t *testing.T) {
+	config = cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	maxBlockHeight := int64(65)
+	trustedHeight := int64(30)
+
+	reactorPairs := make([]BlockchainReactorPair, 2)
+	reactorPairs[0] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, maxBlockHeight)
+	reactorPairs[1] = newSnapshotReactor(log.TestingLogger(), genDoc, reactorPairs[0].reactor.store, trustedHeight)
+
+	trustedBlockID := reactorPairs[0].reactor.store.LoadBlockMeta(trustedHeight).BlockID
+	assert.Equal(t, trustedBlockID, reactorPairs[1].reactor.initialState.LastBlockID)
+
+	p2p.MakeConnectedSwitches(config.P2P, 2, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("BLOCKCHAIN", reactorPairs[i].reactor)
+		return s
+	}, p2p.Connect2Switches)
+
+	defer func() {
+		for _, r := range reactorPairs {
+			r.reactor.Stop()
+			r.app.Stop()
+		}
+	}()
+
+	store := reactorPairs[1].reactor.store
+	timeout := time.After(10 * time.Second)
+	for store.Height() < maxBlockHeight-1 {
+		select {
+		case <-timeout:
+			t.Fatalf("timed out syncing from the trusted block, at height %d", store.Height())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	assert.Equal(t, trustedHeight, store.Base())
+	assert.Nil(t, store.LoadBlock(trustedHeight-1))
+	assert.Equal(t, trustedBlockID, store.LoadBlockMeta(trustedHeight).BlockID)
+	assert.NotNil(t, store.LoadSeenCommit(trustedHeight))
+}
+
+func TestSwitchToFastSync(t *testing.T) {
+	config = cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	maxBlockHeight := int64(65)
+	trustedHeight := int64(30)
+
+	reactorPairs := make([]BlockchainReactorPair, 2)
+	reactorPairs[0] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, maxBlockHeight)
+	snapshot := newSnapshotReactor(log.TestingLogger(), genDoc, reactorPairs[0].reactor.store, trustedHeight)
+
+	// The node starts at genesis without fast sync while it restores the
+	// snapshot.
+	genesisState, err := sm.MakeGenesisState(genDoc)
+	assert.NoError(t, err)
+	store := NewBlockStore(dbm.NewMemDB())
+	bcR := NewBlockchainReactor(genesisState, snapshot.reactor.blockExec, store, false)
+	bcR.SetLogger(log.TestingLogger())
+	reactorPairs[1] = BlockchainReactorPair{bcR, snapshot.app}
+
+	p2p.MakeConnectedSwitches(config.P2P, 2, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("BLOCKCHAIN", reactorPairs[i].reactor)
+		return s
+	}, p2p.Connect2Switches)
+
+	defer func() {
+		for _, r := range reactorPairs {
+			r.reactor.Stop()
+			r.app.Stop()
+		}
+	}()
+
+	state := snapshot.reactor.initialState
+	assert.Error(t, bcR.SwitchToFastSync(state), "store doesn't start at the snapshot")
+	assert.NoError(t, store.SetBase(trustedHeight))
+	assert.NoError(t, bcR.SwitchToFastSync(state))
+	assert.Error(t, bcR.SwitchToFastSync(state), "already fast syncing")
+
+	timeout := time.After(10 * time.Second)
+	for store.Height() < maxBlockHeight-1 {
+		select {
+		case <-timeout:
+			t.Fatalf("timed out syncing from the snapshot, at height %d", store.Height())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	assert.Equal(t, trustedHeight, store.Base())
+	assert.Equal(t, state.LastBlockID, store.LoadBlockMeta(trustedHeight).BlockID)
+}
+
+func TestBackfillBelowTrustedBlock(t *testing.T) {
+	config = cfg.ResetTestRoot("blockchain_reactor_test")
+	defer os.RemoveAll(config.RootDir)
+	genDoc, privVals := randGenesisDoc(1, false, 30)
+
+	maxBlockHeight := int64(65)
+	trustedHeight := int64(30)
+	backfillHeight := int64(10)
+
+	reactorPairs := make([]BlockchainReactorPair, 2)
+	reactorPairs[0] = newBlockchainReactor(log.TestingLogger(), genDoc, privVals, maxBlockHeight)
+	reactorPairs[1] = newSnapshotReactor(log.TestingLogger(), genDoc, reactorPairs[0].reactor.store, trustedHeight)
+	reactorPairs[1].reactor.SetBackfillHeight(backfillHeight)
+
+	p2p.MakeConnectedSwitches(config.P2P, 2, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("BLOCKCHAIN", reactorPairs[i].reactor)
+		return s
+	}, p2p.Connect2Switches)
+
+	defer func() {
+		for _, r := range reactorPairs {
+			r.reactor.Stop()
+			r.app.Stop()
+		}
+	}()
+
+	store := reactorPairs[1].reactor.store
+	timeout := time.After(20 * time.Second)
+	for store.Base() > backfillHeight {
+		select {
+		case <-timeout:
+			t.Fatalf("timed out backfilling, at base %d", store.Base())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	assert.Equal(t, backfillHeight, store.Base())
+	assert.Nil(t, store.LoadBlock(backfillHeight-1))
+	for height := backfillHeight; height < trustedHeight; height++ {
+		expected := reactorPairs[0].reactor.store.LoadBlockMeta(height).BlockID
+		assert.Equal(t, expected, store.LoadBlockMeta(height).BlockID)
+		assert.NotNil(t, store.LoadBlockCommit(height))
+	}
 }
 
 // NOTE: This is too hard to test without
@@ -234,8 +423,11 @@ func TestBadBlockStopsPeer(t *testing.T) {
 	lastReactorPair := newBlockchainReactor(log.TestingLogger(), genDoc, privVals, 0)
 	reactorPairs = append(reactorPairs, lastReactorPair)
 
+	var peerBehaviour *p2p.PeerBehaviourHistory
 	switches = append(switches, p2p.MakeConnectedSwitches(config.P2P, 1, func(i int, s *p2p.Switch) *p2p.Switch {
 		s.AddReactor("BLOCKCHAIN", reactorPairs[len(reactorPairs)-1].reactor)
+		peerBehaviour = p2p.NewPeerBehaviourHistory(p2p.NewSwitchPeerBehaviour(s), 10, 10)
+		s.SetPeerBehaviour(peerBehaviour)
 		return s
 
 	}, p2p.Connect2Switches)...)
@@ -253,6 +445,10 @@ func TestBadBlockStopsPeer(t *testing.T) {
 	}
 
 	assert.True(t, lastReactorPair.reactor.Switch.Peers().Size() < len(reactorPairs)-1)
+
+	// The peer serving blocks of the other chain was reported.
+	summary, _ := peerBehaviour.Summary(switches[3].NodeInfo().ID())
+	assert.NotZero(t, summary.ErrorCounts[p2p.ErrorPeerBehaviourBadBlock])
 }
 
 //----------------------------------------------
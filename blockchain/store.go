@@ -29,6 +29,7 @@ type BlockStore struct {
 	db dbm.DB
 
 	mtx    sync.RWMutex
+	base   int64
 	height int64
 }
 
@@ -37,6 +38,7 @@ type BlockStore struct {
 func NewBlockStore(db dbm.DB) *BlockStore {
 	bsjson := LoadBlockStoreStateJSON(db)
 	return &BlockStore{
+		base:   bsjson.Base,
 		height: bsjson.Height,
 		db:     db,
 	}
@@ -49,6 +51,33 @@ func (bs *BlockStore) Height() int64 {
 	return bs.height
 }
 
+// Base returns the height of the first block in the store, or 0 if it's
+// empty and doesn't start at a trusted height.
+func (bs *BlockStore) Base() int64 {
+	bs.mtx.RLock()
+	defer bs.mtx.RUnlock()
+	if bs.base == 0 && bs.height > 0 {
+		return 1 // stores saved before the base was tracked
+	}
+	return bs.base
+}
+
+// SetBase makes the empty store start at the given height instead of 1, so it
+// can be filled from a trusted block onwards without the blocks before it.
+func (bs *BlockStore) SetBase(base int64) error {
+	if base <= 0 {
+		return fmt.Errorf("base must be positive, got %v", base)
+	}
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	if bs.height != 0 {
+		return fmt.Errorf("can't set the base of a store with blocks up to height %v", bs.height)
+	}
+	BlockStoreStateJSON{Base: base}.Save(bs.db)
+	bs.base = base
+	return nil
+}
+
 // LoadBlock returns the block with the given height.
 // If no block is found for that height, it returns nil.
 func (bs *BlockStore) LoadBlock(height int64) *types.Block {
@@ -147,7 +176,7 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 		cmn.PanicSanity("BlockStore can only save a non-nil block")
 	}
 	height := block.Height
-	if g, w := height, bs.Height()+1; g != w {
+	if g, w := height, bs.nextHeight(); g != w {
 		cmn.PanicSanity(fmt.Sprintf("BlockStore can only save contiguous blocks. Wanted %v, got %v", w, g))
 	}
 	if !blockParts.IsComplete() {
@@ -175,10 +204,13 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	bs.db.Set(calcSeenCommitKey(height), seenCommitBytes)
 
 	// Save new BlockStoreStateJSON descriptor
-	BlockStoreStateJSON{Height: height}.Save(bs.db)
+	bs.mtx.Lock()
+	if bs.base == 0 {
+		bs.base = height
+	}
+	BlockStoreStateJSON{Base: bs.base, Height: height}.Save(bs.db)
 
 	// Done!
-	bs.mtx.Lock()
 	bs.height = height
 	bs.mtx.Unlock()
 
@@ -186,14 +218,62 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	bs.db.SetSync(nil, nil)
 }
 
+// SaveBlockBelowBase persists the given block, whose height has to be just
+// below the base of the store, and makes it the new base. The caller has to
+// verify the block is the one the block at the base links to. The block's
+// commit is the last commit of the block at the old base, saved with it.
+func (bs *BlockStore) SaveBlockBelowBase(block *types.Block, blockParts *types.PartSet) {
+	if block == nil {
+		cmn.PanicSanity("BlockStore can only save a non-nil block")
+	}
+	height := block.Height
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	if bs.height == 0 || bs.base <= 1 {
+		cmn.PanicSanity(fmt.Sprintf("BlockStore has no blocks missing below its base %v", bs.base))
+	}
+	if w := bs.base - 1; height != w {
+		cmn.PanicSanity(fmt.Sprintf("BlockStore can only save the block below its base. Wanted %v, got %v", w, height))
+	}
+	if !blockParts.IsComplete() {
+		cmn.PanicSanity(fmt.Sprintf("BlockStore can only save complete block part sets"))
+	}
+
+	// Save the parts before the meta, as the meta implies the block exists.
+	for i := 0; i < blockParts.Total(); i++ {
+		partBytes := cdc.MustMarshalBinaryBare(blockParts.GetPart(i))
+		bs.db.Set(calcBlockPartKey(height, i), partBytes)
+	}
+	if height > 1 {
+		blockCommitBytes := cdc.MustMarshalBinaryBare(block.LastCommit)
+		bs.db.Set(calcBlockCommitKey(height-1), blockCommitBytes)
+	}
+	blockMeta := types.NewBlockMeta(block, blockParts)
+	bs.db.Set(calcBlockMetaKey(height), cdc.MustMarshalBinaryBare(blockMeta))
+
+	bs.base = height
+	BlockStoreStateJSON{Base: bs.base, Height: bs.height}.Save(bs.db)
+	bs.db.SetSync(nil, nil)
+}
+
 func (bs *BlockStore) saveBlockPart(height int64, index int, part *types.Part) {
-	if height != bs.Height()+1 {
-		cmn.PanicSanity(fmt.Sprintf("BlockStore can only save contiguous blocks. Wanted %v, got %v", bs.Height()+1, height))
+	if w := bs.nextHeight(); height != w {
+		cmn.PanicSanity(fmt.Sprintf("BlockStore can only save contiguous blocks. Wanted %v, got %v", w, height))
 	}
 	partBytes := cdc.MustMarshalBinaryBare(part)
 	bs.db.Set(calcBlockPartKey(height, index), partBytes)
 }
 
+// nextHeight returns the height of the next block to save.
+func (bs *BlockStore) nextHeight() int64 {
+	bs.mtx.RLock()
+	defer bs.mtx.RUnlock()
+	if bs.height == 0 && bs.base > 0 {
+		return bs.base
+	}
+	return bs.height + 1
+}
+
 //-----------------------------------------------------------------------------
 
 func calcBlockMetaKey(height int64) []byte {
@@ -217,6 +297,7 @@ func calcSeenCommitKey(height int64) []byte {
 var blockStoreKey = []byte("blockStore")
 
 type BlockStoreStateJSON struct {
+	Base   int64 `json:"base"`
 	Height int64 `json:"height"`
 }
 
@@ -86,6 +86,72 @@ func TestNewBlockStore(t *testing.T) {
 	assert.Equal(t, bs.Height(), int64(0), "expecting nil bytes to be unmarshaled alright")
 }
 
+func TestBlockStoreSetBase(t *testing.T) {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
+	defer cleanup()
+	require.Equal(t, int64(0), bs.Base())
+
+	require.Error(t, bs.SetBase(0))
+	require.NoError(t, bs.SetBase(5))
+	assert.Equal(t, int64(5), bs.Base())
+	assert.Equal(t, int64(0), bs.Height())
+
+	_, _, panicErr := doFn(func() (interface{}, error) {
+		bs.SaveBlock(block, partSet, seenCommit1) // at height 1
+		return nil, nil
+	})
+	require.NotNil(t, panicErr)
+	assert.Contains(t, fmt.Sprintf("%#v", panicErr), "only save contiguous blocks")
+
+	block5 := makeBlock(5, state, new(types.Commit))
+	bs.SaveBlock(block5, block5.MakePartSet(2), seenCommit1)
+	assert.Equal(t, int64(5), bs.Height())
+	assert.Error(t, bs.SetBase(1))
+
+	// The base is persisted.
+	bs = NewBlockStore(bs.db)
+	assert.Equal(t, int64(5), bs.Base())
+	assert.Equal(t, int64(5), bs.Height())
+}
+
+func TestBlockStoreSaveBlockBelowBase(t *testing.T) {
+	state, bs, cleanup := makeStateAndBlockStore(log.NewTMLogger(new(bytes.Buffer)))
+	defer cleanup()
+	require.NoError(t, bs.SetBase(5))
+
+	// Commits which aren't empty, so they're stored.
+	commit := &types.Commit{BlockID: types.BlockID{Hash: []byte("last_block")}}
+	block4 := makeBlock(4, state, commit)
+	_, _, panicErr := doFn(func() (interface{}, error) {
+		bs.SaveBlockBelowBase(block4, block4.MakePartSet(2)) // without the base block
+		return nil, nil
+	})
+	require.NotNil(t, panicErr)
+
+	block5 := makeBlock(5, state, commit)
+	bs.SaveBlock(block5, block5.MakePartSet(2), seenCommit1)
+
+	block3 := makeBlock(3, state, new(types.Commit))
+	_, _, panicErr = doFn(func() (interface{}, error) {
+		bs.SaveBlockBelowBase(block3, block3.MakePartSet(2)) // not contiguous
+		return nil, nil
+	})
+	require.NotNil(t, panicErr)
+	assert.Contains(t, fmt.Sprintf("%#v", panicErr), "only save the block below its base")
+
+	bs.SaveBlockBelowBase(block4, block4.MakePartSet(2))
+	assert.Equal(t, int64(4), bs.Base())
+	assert.Equal(t, int64(5), bs.Height())
+	assert.Equal(t, block4.Hash(), bs.LoadBlock(4).Hash())
+	assert.NotNil(t, bs.LoadBlockCommit(4))
+	assert.NotNil(t, bs.LoadBlockCommit(3))
+
+	// The base is persisted.
+	bs = NewBlockStore(bs.db)
+	assert.Equal(t, int64(4), bs.Base())
+	assert.Equal(t, int64(5), bs.Height())
+}
+
 func freshBlockStore() (*BlockStore, db.DB) {
 	db := db.NewMemDB()
 	return NewBlockStore(db), db
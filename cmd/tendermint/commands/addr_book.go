@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/pex"
+)
+
+// AddrBookCmd manages the address book of a stopped node. A running node
+// saves its address book periodically, overwriting changes made in the
+// meantime, so use the addr_book RPC endpoints instead.
+var AddrBookCmd = &cobra.Command{
+	Use:   "addr_book",
+	Short: "Export, import and mark entries of the address book of a stopped node",
+}
+
+var exportAddrBookCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export the address book to a JSON file, or to stdout",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  exportAddrBook,
+}
+
+var importAddrBookCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import the entries of an exported address book",
+	Args:  cobra.ExactArgs(1),
+	RunE:  importAddrBook,
+}
+
+var markAddrGoodCmd = &cobra.Command{
+	Use:   "mark_good <ID@host:port>",
+	Short: "Mark an address of the address book good",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return markAddr(args[0], pex.AddrBook.MarkGood)
+	},
+}
+
+var markAddrBadCmd = &cobra.Command{
+	Use:   "mark_bad <ID@host:port>",
+	Short: "Mark an address of the address book bad, removing it",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return markAddr(args[0], pex.AddrBook.MarkBad)
+	},
+}
+
+func init() {
+	AddrBookCmd.AddCommand(
+		exportAddrBookCmd,
+		importAddrBookCmd,
+		markAddrGoodCmd,
+		markAddrBadCmd,
+	)
+}
+
+// withAddrBook loads the address book, calls fn and saves the book.
+func withAddrBook(fn func(pex.AddrBook) error) error {
+	book := pex.NewAddrBook(config.P2P.AddrBookFile(), config.P2P.AddrBookStrict)
+	book.SetLogger(logger.With("module", "p2p"))
+	if err := book.Start(); err != nil {
+		return err
+	}
+	err := fn(book)
+	// Stopping saves the book.
+	book.Stop()
+	book.Wait()
+	return err
+}
+
+func exportAddrBook(cmd *cobra.Command, args []string) error {
+	return withAddrBook(func(book pex.AddrBook) error {
+		entries := book.Export()
+		if len(args) == 0 {
+			jsonBytes, err := json.MarshalIndent(entries, "", "\t")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(os.Stdout, string(jsonBytes))
+			return err
+		}
+		if err := pex.WriteAddrBookEntries(args[0], entries); err != nil {
+			return err
+		}
+		logger.Info("Exported address book", "file", args[0], "addresses", len(entries))
+		return nil
+	})
+}
+
+func importAddrBook(cmd *cobra.Command, args []string) error {
+	entries, err := pex.ReadAddrBookEntries(args[0])
+	if err != nil {
+		return err
+	}
+	return withAddrBook(func(book pex.AddrBook) error {
+		added, errs := book.Import(entries)
+		for _, err := range errs {
+			logger.Error("Skipped address book entry", "err", err)
+		}
+		logger.Info("Imported address book", "file", args[0], "added", added, "skipped", len(errs))
+		return nil
+	})
+}
+
+func markAddr(addr string, mark func(pex.AddrBook, *p2p.NetAddress)) error {
+	netAddr, err := p2p.NewNetAddressString(addr)
+	if err != nil {
+		return err
+	}
+	return withAddrBook(func(book pex.AddrBook) error {
+		if !book.HasAddress(netAddr) {
+			return fmt.Errorf("%v is not in the address book", netAddr)
+		}
+		mark(book, netAddr)
+		return nil
+	})
+}
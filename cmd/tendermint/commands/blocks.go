@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	bc "github.com/tendermint/tendermint/blockchain"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+var (
+	exportFromHeight int64
+	exportToHeight   int64
+)
+
+// ExportBlocksCmd writes the blocks of a stopped node and their commits to a
+// file, to import them into another node or to archive them.
+var ExportBlocksCmd = &cobra.Command{
+	Use:     "export_blocks <file>",
+	Aliases: []string{"export-blocks"},
+	Short:   "Export the blocks of a stopped node with their commits to a file",
+	Args:    cobra.ExactArgs(1),
+	RunE:    exportBlocks,
+}
+
+// ImportBlocksCmd saves the blocks of a file written by export_blocks to the
+// block store of a stopped node. They're executed when the node starts.
+var ImportBlocksCmd = &cobra.Command{
+	Use:     "import_blocks <file>",
+	Aliases: []string{"import-blocks"},
+	Short:   "Import the blocks of an exported file into the block store of a stopped node",
+	Args:    cobra.ExactArgs(1),
+	RunE:    importBlocks,
+}
+
+func init() {
+	ExportBlocksCmd.Flags().Int64Var(&exportFromHeight, "from", 0, "First height to export, the base of the store if 0")
+	ExportBlocksCmd.Flags().Int64Var(&exportToHeight, "to", 0, "Last height to export, the height of the store if 0")
+}
+
+func loadBlockStore() (*bc.BlockStore, dbm.DB) {
+	db := dbm.NewDB("blockstore", dbm.DBBackendType(config.DBBackend), config.DBDir())
+	return bc.NewBlockStore(db), db
+}
+
+func exportBlocks(cmd *cobra.Command, args []string) error {
+	store, db := loadBlockStore()
+	defer db.Close()
+	from, to := exportFromHeight, exportToHeight
+	if from == 0 {
+		from = store.Base()
+	}
+	if to == 0 {
+		to = store.Height()
+	}
+
+	file, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close() // nolint: errcheck
+	n, err := bc.ExportBlocks(store, file, from, to)
+	if err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		return err
+	}
+	logger.Info("Exported blocks", "file", args[0], "from", from, "to", to, "blocks", n)
+	return nil
+}
+
+func importBlocks(cmd *cobra.Command, args []string) error {
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close() // nolint: errcheck
+
+	store, db := loadBlockStore()
+	defer db.Close()
+	n, err := bc.ImportBlocks(store, file)
+	logger.Info("Imported blocks", "file", args[0], "blocks", n, "height", store.Height())
+	return err
+}
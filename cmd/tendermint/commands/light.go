@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/provider"
+	httpp "github.com/tendermint/tendermint/light/provider/http"
+	lproxy "github.com/tendermint/tendermint/light/proxy"
+	lrpc "github.com/tendermint/tendermint/light/rpc"
+	dbs "github.com/tendermint/tendermint/light/store/db"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	rpcserver "github.com/tendermint/tendermint/rpc/lib/server"
+)
+
+// LightCmd represents the base command when called without any subcommands
+var LightCmd = &cobra.Command{
+	Use:   "light [chainID]",
+	Short: "Run a light client proxy server, verifying Tendermint rpc",
+	Long: `Run a light client proxy server, verifying Tendermint rpc.
+
+All calls that can be tracked back to a block header by a proof
+will be verified before passing them back to the caller. Other than
+that, it will present the same interface as a full Tendermint node.
+
+The headers are fetched from the primary and verified with the light
+client, starting from the trusted header given by --height and --hash.
+The headers of the primary are cross-checked with the witnesses, which
+are also used when the primary fails.
+
+Example:
+
+$ tendermint light test-chain -p tcp://localhost:26657 -w tcp://witness1:26657,tcp://witness2:26657 \
+	--height 100 --hash 28B97BE9F6DE51AC69F70E0B7BFD7E5C9CD1A595B7DC31AFF27C50D4948020CD
+`,
+	RunE:         runLightProxy,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+}
+
+var (
+	lightListenAddr         string
+	lightPrimaryAddr        string
+	lightWitnessAddrsJoined string
+	lightHome               string
+	lightMaxOpenConnections int
+
+	lightSequential     bool
+	lightTrustingPeriod time.Duration
+	lightTrustedHeight  int64
+	lightTrustedHash    string
+)
+
+func init() {
+	LightCmd.Flags().StringVar(&lightListenAddr, "laddr", "tcp://localhost:8888",
+		"Serve the proxy on the given address")
+	LightCmd.Flags().StringVarP(&lightPrimaryAddr, "primary", "p", "tcp://localhost:26657",
+		"Connect to a Tendermint node at this address")
+	LightCmd.Flags().StringVarP(&lightWitnessAddrsJoined, "witnesses", "w", "",
+		"Tendermint nodes to take over when the primary fails (comma-separated)")
+	LightCmd.Flags().StringVar(&lightHome, "home-dir", ".tendermint-light",
+		"Specify the home directory")
+	LightCmd.Flags().IntVar(&lightMaxOpenConnections, "max-open-connections", 900,
+		"Maximum number of simultaneous connections (including WebSocket).")
+	LightCmd.Flags().DurationVar(&lightTrustingPeriod, "trusting-period", 168*time.Hour,
+		"Trusting period. Should be significantly less than the unbonding period")
+	LightCmd.Flags().Int64Var(&lightTrustedHeight, "height", 1, "Trusted header's height")
+	LightCmd.Flags().StringVar(&lightTrustedHash, "hash", "", "Trusted header's hash")
+	LightCmd.Flags().BoolVar(&lightSequential, "sequential", false,
+		"Verify every header sequentially instead of skipping headers")
+}
+
+func runLightProxy(cmd *cobra.Command, args []string) error {
+	chainID := args[0]
+
+	witnessAddrs := cmn.SplitAndTrim(lightWitnessAddrsJoined, ",", " ")
+	if len(witnessAddrs) == 0 {
+		return errors.New("at least one witness is required (--witnesses)")
+	}
+	trustedHash, err := hex.DecodeString(lightTrustedHash)
+	if err != nil {
+		return fmt.Errorf("invalid --hash: %v", err)
+	}
+
+	primaryAddr, err := EnsureAddrHasSchemeOrDefaultToTCP(lightPrimaryAddr)
+	if err != nil {
+		return err
+	}
+	listenAddr, err := EnsureAddrHasSchemeOrDefaultToTCP(lightListenAddr)
+	if err != nil {
+		return err
+	}
+
+	witnesses := make([]provider.Provider, len(witnessAddrs))
+	for i, addr := range witnessAddrs {
+		addr, err := EnsureAddrHasSchemeOrDefaultToTCP(addr)
+		if err != nil {
+			return err
+		}
+		witnesses[i] = httpp.New(chainID, addr)
+	}
+
+	db, err := dbm.NewGoLevelDB("light-client-db", lightHome)
+	if err != nil {
+		return cmn.ErrorWrap(err, "opening the trusted store")
+	}
+
+	options := []light.Option{light.Logger(logger)}
+	if lightSequential {
+		options = append(options, light.SequentialVerification())
+	}
+
+	logger.Info("Constructing the light client...")
+	node := rpcclient.NewHTTP(primaryAddr, "/websocket")
+	lc, err := light.NewClient(
+		chainID,
+		light.TrustOptions{
+			Period: lightTrustingPeriod,
+			Height: lightTrustedHeight,
+			Hash:   trustedHash,
+		},
+		httpp.NewWithClient(chainID, node),
+		witnesses,
+		dbs.New(db, chainID),
+		options...,
+	)
+	if err != nil {
+		return cmn.ErrorWrap(err, "constructing the light client")
+	}
+
+	config := rpcserver.DefaultConfig()
+	config.MaxOpenConnections = lightMaxOpenConnections
+	p := lproxy.NewProxy(lrpc.NewClient(node, lc), listenAddr, config, logger)
+
+	// Stop upon receiving SIGTERM or CTRL-C.
+	cmn.TrapSignal(logger, func() {
+		db.Close()
+	})
+
+	logger.Info("Starting proxy...", "laddr", listenAddr)
+	if err := p.ListenAndServe(); err != nil {
+		return cmn.ErrorWrap(err, "starting proxy")
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	nm "github.com/tendermint/tendermint/node"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	reindexFromHeight int64
+	reindexToHeight   int64
+	reindexIndexer    string
+)
+
+// ReindexEventsCmd indexes the stored blocks of a stopped node and their txs
+// again with the configured indexers, from their saved ABCI responses, e.g.
+// after enabling the indexing or adding an indexer.
+var ReindexEventsCmd = &cobra.Command{
+	Use:     "reindex_events",
+	Aliases: []string{"reindex-events"},
+	Short:   "Index the stored blocks of a stopped node and their txs again with the configured indexers",
+	Long: `Index the stored blocks of a stopped node and their txs again with the
+configured indexers (tx_index.indexer), or only the one of --indexer, with the
+results of their ABCI responses saved in the state, rather than by replaying
+the chain against the app. The ABCI responses of the heights before the last
+abci_responses_retain_heights may have been pruned, in which case those blocks
+can't be indexed.`,
+	Args: cobra.NoArgs,
+	RunE: reindexEvents,
+}
+
+func init() {
+	ReindexEventsCmd.Flags().Int64Var(&reindexFromHeight, "from", 0,
+		"First height to index, the lowest one whose ABCI responses are saved if 0")
+	ReindexEventsCmd.Flags().Int64Var(&reindexToHeight, "to", 0, "Last height to index, the height of the store if 0")
+	ReindexEventsCmd.Flags().StringVar(&reindexIndexer, "indexer", "",
+		"Indexer of tx_index.indexer to index with, all of them if empty")
+}
+
+func reindexEvents(cmd *cobra.Command, args []string) error {
+	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	if err != nil {
+		return err
+	}
+	sinks, err := nm.CreateTxIndexSinks(config, nm.DefaultDBProvider, genDoc.ChainID)
+	if err != nil {
+		return err
+	}
+	for _, sink := range sinks {
+		if closer, ok := sink.TxIndexer.(io.Closer); ok {
+			defer closer.Close() // nolint: errcheck
+		}
+	}
+	if reindexIndexer != "" {
+		sinks = selectSink(sinks, reindexIndexer)
+		if len(sinks) == 0 {
+			return fmt.Errorf("The indexer %q isn't listed in tx_index.indexer", reindexIndexer)
+		}
+	}
+	if len(sinks) == 0 {
+		return errors.New("Indexing is disabled (set tx_index.indexer)")
+	}
+
+	store, blockStoreDB := loadBlockStore()
+	defer blockStoreDB.Close()
+	stateDB, err := nm.DefaultDBProvider(&nm.DBContext{ID: "state", Config: config})
+	if err != nil {
+		return err
+	}
+	defer stateDB.Close()
+
+	from, to := reindexFromHeight, reindexToHeight
+	if from == 0 {
+		from = store.Base()
+		if base := sm.LoadABCIResponsesBase(stateDB); from < base {
+			from = base
+		}
+	}
+	if to == 0 {
+		to = store.Height()
+	}
+	for _, sink := range sinks {
+		if err := sm.ReindexEvents(sink, store, stateDB, from, to, logger.With("indexer", sink.Name)); err != nil {
+			return fmt.Errorf("Failed to reindex the blocks with the %s indexer: %v", sink.Name, err)
+		}
+		logger.Info("Reindexed blocks", "indexer", sink.Name, "from", from, "to", to)
+	}
+	return nil
+}
+
+func selectSink(sinks []txindex.Sink, name string) []txindex.Sink {
+	for _, sink := range sinks {
+		if sink.Name == name {
+			return []txindex.Sink{sink}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cs "github.com/tendermint/tendermint/consensus"
+)
+
+var (
+	replayBreakpoints []string
+	replayDumpFile    string
+)
+
+// ReplayConsensusCmd replays the consensus of a stopped node step by step,
+// for debugging halted networks.
+var ReplayConsensusCmd = &cobra.Command{
+	Use:     "replay_consensus",
+	Aliases: []string{"replay-consensus"},
+	Short:   "Replay the blocks and the consensus WAL against the app step by step, with breakpoints",
+	Args:    cobra.NoArgs,
+	RunE:    replayConsensus,
+}
+
+func init() {
+	ReplayConsensusCmd.Flags().StringSliceVar(&replayBreakpoints, "break", nil,
+		"Pause in a console when entering a height, or a round of a height (height or height/round)")
+	ReplayConsensusCmd.Flags().StringVar(&replayDumpFile, "dump", "",
+		"File to write the round state to as JSON at every step, \"-\" for stdout")
+}
+
+func replayConsensus(cmd *cobra.Command, args []string) error {
+	var options cs.ReplayConsensusOptions
+	for _, s := range replayBreakpoints {
+		bp, err := cs.ParseReplayBreakpoint(s)
+		if err != nil {
+			return err
+		}
+		options.Breakpoints = append(options.Breakpoints, bp)
+	}
+
+	switch replayDumpFile {
+	case "":
+	case "-":
+		options.Dump = os.Stdout
+	default:
+		f, err := os.Create(replayDumpFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		options.Dump = f
+	}
+
+	return cs.RunReplayConsensus(config.BaseConfig, config.Consensus, logger, options)
+}
@@ -30,12 +30,14 @@ var ResetPrivValidatorCmd = &cobra.Command{
 func resetAll(cmd *cobra.Command, args []string) {
 	ResetAll(config.DBDir(), config.P2P.AddrBookFile(), config.PrivValidatorKeyFile(),
 		config.PrivValidatorStateFile(), logger)
+	removeSignState(config.Consensus.SignStateFile(), logger)
 }
 
 // XXX: this is totally unsafe.
 // it's only suitable for testnets.
 func resetPrivValidator(cmd *cobra.Command, args []string) {
 	resetFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), logger)
+	removeSignState(config.Consensus.SignStateFile(), logger)
 }
 
 // ResetAll removes address book files plus all data, and resets the privValdiator data.
@@ -66,6 +68,19 @@ func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) {
 	}
 }
 
+// removeSignState removes the last state signed by consensus, which would
+// refuse to sign from genesis again otherwise.
+func removeSignState(signStateFile string, logger log.Logger) {
+	if signStateFile == "" {
+		return
+	}
+	if err := os.Remove(signStateFile); err == nil {
+		logger.Info("Removed the last signed state", "file", signStateFile)
+	} else if !os.IsNotExist(err) {
+		logger.Error("Error removing the last signed state", "file", signStateFile, "err", err)
+	}
+}
+
 func removeAddrBook(addrBookFile string, logger log.Logger) {
 	if err := os.Remove(addrBookFile); err == nil {
 		logger.Info("Removed existing address book", "file", addrBookFile)
@@ -2,11 +2,17 @@ package commands
 
 import (
 	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	cmn "github.com/tendermint/tendermint/libs/common"
 	nm "github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/proxy"
 )
 
 // AddNodeFlags exposes some common configuration options on the command-line
@@ -22,7 +28,9 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("fast_sync", config.FastSync, "Fast blockchain syncing")
 
 	// abci flags
-	cmd.Flags().String("proxy_app", config.ProxyApp, "Proxy app address, or one of: 'kvstore', 'persistent_kvstore', 'counter', 'counter_serial' or 'noop' for local testing.")
+	cmd.Flags().String("proxy_app", config.ProxyApp, fmt.Sprintf(
+		"Proxy app address, or the name of an in-process app registered with proxy.RegisterApp: '%s'",
+		strings.Join(proxy.RegisteredApps(), "', '")))
 	cmd.Flags().String("abci", config.ABCI, "Specify abci transport (socket | grpc)")
 
 	// rpc flags
@@ -34,7 +42,7 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().String("p2p.laddr", config.P2P.ListenAddress, "Node listen address. (0.0.0.0:0 means any interface, any port)")
 	cmd.Flags().String("p2p.seeds", config.P2P.Seeds, "Comma-delimited ID@host:port seed nodes")
 	cmd.Flags().String("p2p.persistent_peers", config.P2P.PersistentPeers, "Comma-delimited ID@host:port persistent peers")
-	cmd.Flags().Bool("p2p.upnp", config.P2P.UPNP, "Enable/disable UPNP port forwarding")
+	cmd.Flags().Bool("p2p.upnp", config.P2P.UPNP, "Enable/disable UPNP/NAT-PMP port forwarding")
 	cmd.Flags().Bool("p2p.pex", config.P2P.PexReactor, "Enable/disable Peer-Exchange")
 	cmd.Flags().Bool("p2p.seed_mode", config.P2P.SeedMode, "Enable/disable seed mode")
 	cmd.Flags().String("p2p.private_peer_ids", config.P2P.PrivatePeerIDs, "Comma-delimited private peer IDs")
@@ -67,6 +75,9 @@ func NewRunNodeCmd(nodeProvider nm.NodeProvider) *cobra.Command {
 			}
 			logger.Info("Started node", "nodeInfo", n.Switch().NodeInfo())
 
+			// Reload persistent_peers and private_peer_ids upon receiving SIGHUP.
+			go reloadPeersOnSIGHUP(n)
+
 			// Run forever.
 			select {}
 		},
@@ -75,3 +86,30 @@ func NewRunNodeCmd(nodeProvider nm.NodeProvider) *cobra.Command {
 	AddNodeFlags(cmd)
 	return cmd
 }
+
+// reloadPeersOnSIGHUP re-reads the config file and applies its
+// persistent_peers and private_peer_ids to the node whenever the process
+// receives SIGHUP. Values set with flags take precedence over the file, as
+// on startup.
+func reloadPeersOnSIGHUP(n *nm.Node) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+	for range c {
+		if err := viper.ReadInConfig(); err != nil {
+			logger.Error("Failed to re-read config file", "err", err)
+			continue
+		}
+		conf, err := ParseConfig()
+		if err != nil {
+			logger.Error("Failed to parse config file", "err", err)
+			continue
+		}
+		if err := n.ReloadPeers(conf.P2P); err != nil {
+			logger.Error("Failed to reload peers", "err", err)
+			continue
+		}
+		logger.Info("Reloaded peers",
+			"persistent_peers", conf.P2P.PersistentPeers,
+			"private_peer_ids", conf.P2P.PrivatePeerIDs)
+	}
+}
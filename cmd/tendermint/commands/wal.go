@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	amino "github.com/tendermint/go-amino"
+
+	cs "github.com/tendermint/tendermint/consensus"
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	walInspectHeight int64
+	walBackupDir     string
+)
+
+var walCdc = amino.NewCodec()
+
+func init() {
+	cs.RegisterConsensusMessages(walCdc)
+	cs.RegisterWALMessages(walCdc)
+	types.RegisterBlockAmino(walCdc)
+}
+
+// WALCmd inspects, verifies and repairs the consensus WAL of a stopped node.
+var WALCmd = &cobra.Command{
+	Use:   "wal",
+	Short: "Inspect, verify and repair the consensus WAL of a stopped node",
+}
+
+var inspectWALCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Print the messages of the WAL as JSON, one per line",
+	Args:  cobra.NoArgs,
+	RunE:  inspectWAL,
+}
+
+var verifyWALCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check the entries of the WAL files, failing if one is corrupted",
+	Args:  cobra.NoArgs,
+	RunE:  verifyWAL,
+}
+
+var repairWALCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Truncate the WAL before its first corrupted entry, backing up the original files",
+	Args:  cobra.NoArgs,
+	RunE:  repairWAL,
+}
+
+func init() {
+	inspectWALCmd.Flags().Int64Var(&walInspectHeight, "height", 0,
+		"Only print the messages from this height on, all the messages if 0")
+	repairWALCmd.Flags().StringVar(&walBackupDir, "backup-dir", "",
+		"Directory to move the original files to, the WAL directory with a .backup suffix if empty")
+	WALCmd.AddCommand(
+		inspectWALCmd,
+		verifyWALCmd,
+		repairWALCmd,
+	)
+}
+
+func inspectWAL(cmd *cobra.Command, args []string) error {
+	wal, err := cs.NewWAL(config.Consensus.WalFile())
+	if err != nil {
+		return err
+	}
+	wal.SetLogger(logger.With("module", "consensus"))
+	group := wal.Group()
+	defer group.Close()
+
+	var rd io.ReadCloser
+	if walInspectHeight > 0 {
+		var found bool
+		rd, found, err = wal.SearchForEndHeight(walInspectHeight-1, &cs.WALSearchOptions{})
+		if err != nil {
+			return err
+		} else if !found {
+			return fmt.Errorf("the WAL has no messages of height %d", walInspectHeight)
+		}
+	} else {
+		if rd, err = group.NewReader(group.MinIndex()); err != nil {
+			return err
+		}
+	}
+	defer rd.Close()
+
+	dec := cs.NewWALDecoder(rd)
+	for {
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		bz, err := walCdc.MarshalJSON(msg)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(os.Stdout, string(bz)); err != nil {
+			return err
+		}
+	}
+}
+
+func verifyWAL(cmd *cobra.Command, args []string) error {
+	infos, err := cs.CheckWAL(config.Consensus.WalFile())
+	if err != nil {
+		return err
+	}
+	corrupted := 0
+	for _, info := range infos {
+		if info.Corrupted() {
+			corrupted++
+			logger.Error("Corrupted WAL file", "file", info.Path, "size", info.Size, "messages", info.Messages,
+				"lastHeight", info.LastHeight, "offset", info.CorruptedAt, "err", info.Err)
+			continue
+		}
+		logger.Info("WAL file", "file", info.Path, "size", info.Size, "messages", info.Messages,
+			"lastHeight", info.LastHeight)
+	}
+	if corrupted > 0 {
+		return fmt.Errorf("%d corrupted WAL files, see `tendermint wal repair`", corrupted)
+	}
+	return nil
+}
+
+func repairWAL(cmd *cobra.Command, args []string) error {
+	walFile := config.Consensus.WalFile()
+	backupDir := walBackupDir
+	if backupDir == "" {
+		backupDir = filepath.Dir(walFile) + ".backup"
+	}
+	paths, err := cs.RepairWAL(walFile, backupDir)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		logger.Info("The WAL is not corrupted")
+		return nil
+	}
+	logger.Info("Repaired the WAL", "files", paths, "backupDir", backupDir)
+	return nil
+}
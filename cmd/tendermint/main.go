@@ -14,21 +14,31 @@ import (
 func main() {
 	rootCmd := cmd.RootCmd
 	rootCmd.AddCommand(
+		cmd.AddrBookCmd,
+		cmd.ExportBlocksCmd,
+		cmd.ImportBlocksCmd,
 		cmd.GenValidatorCmd,
 		cmd.InitFilesCmd,
 		cmd.ProbeUpnpCmd,
 		cmd.LiteCmd,
+		cmd.LightCmd,
 		cmd.ReplayCmd,
 		cmd.ReplayConsoleCmd,
+		cmd.ReplayConsensusCmd,
+		cmd.ReindexEventsCmd,
 		cmd.ResetAllCmd,
 		cmd.ResetPrivValidatorCmd,
 		cmd.ShowValidatorCmd,
 		cmd.TestnetFilesCmd,
 		cmd.ShowNodeIDCmd,
 		cmd.GenNodeKeyCmd,
+		cmd.WALCmd,
 		cmd.VersionCmd)
 
 	// NOTE:
+	// Users wishing to run an in-proc abci app can register it with
+	// proxy.RegisterApp in a package imported here, and start the node with
+	// --proxy_app=<name>.
 	// Users wishing to:
 	//	* Use an external signer for their validators
 	//	* Supply an in-proc abci app
@@ -1,13 +1,22 @@
 package consensus
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	"github.com/tendermint/tendermint/p2p"
 	"github.com/tendermint/tendermint/types"
@@ -54,6 +63,8 @@ func TestByzantine(t *testing.T) {
 			// NOTE: Now, test validators are MockPV, which by default doesn't
 			// do any safety checks.
 			css[i].privValidator.(*types.MockPV).DisableChecks()
+			// Nor does the node.
+			css[i].signGuard = nil
 			css[i].decideProposal = func(j int) func(int64, int) {
 				return func(height int64, round int) {
 					byzantineDecideProposalFunc(t, height, round, css[j], switches[j])
@@ -77,7 +88,7 @@ func TestByzantine(t *testing.T) {
 
 		// make first val byzantine
 		if i == 0 {
-			conRI = NewByzantineReactor(conR)
+			conRI = NewByzantineReactor(conR, false)
 		}
 
 		reactors[i] = conRI
@@ -166,6 +177,243 @@ func TestByzantine(t *testing.T) {
 	}
 }
 
+//-------------------------------
+// byzantine scenarios
+
+// Behaviors of the byzantine validators of the scenarios.
+const (
+	// The validator proposes two blocks, each to half of its peers, and votes
+	// for both.
+	byzantineConflictingProposals = "conflicting_proposals"
+	// The validator sends a vote conflicting with each of its votes to half of
+	// its peers.
+	byzantineEquivocatingVotes = "equivocating_votes"
+	// The validator proposes a block, but withholds its last part.
+	byzantineWithheldBlockParts = "withheld_block_parts"
+)
+
+// byzantineScenario is an in-process network where some validators misbehave,
+// read from a JSON file of testdata/byzantine. The honest validators must
+// still commit the heights and, if expected, detect the equivocations of all
+// the byzantine validators.
+type byzantineScenario struct {
+	Description    string               `json:"description"`
+	Validators     int                  `json:"validators"`
+	Byzantine      []byzantineValidator `json:"byzantine"`
+	Heights        int                  `json:"heights"`
+	ExpectEvidence bool                 `json:"expect_evidence"`
+}
+
+type byzantineValidator struct {
+	Index     int      `json:"index"`
+	Behaviors []string `json:"behaviors"`
+}
+
+func loadByzantineScenario(t *testing.T, file string) byzantineScenario {
+	bz, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+	var scenario byzantineScenario
+	require.NoError(t, json.Unmarshal(bz, &scenario), file)
+
+	require.True(t, scenario.Validators > 0, "%s: no validators", file)
+	require.True(t, scenario.Heights > 0, "%s: no heights", file)
+	for _, val := range scenario.Byzantine {
+		require.True(t, val.Index >= 0 && val.Index < scenario.Validators,
+			"%s: no validator #%d", file, val.Index)
+		for _, behavior := range val.Behaviors {
+			switch behavior {
+			case byzantineConflictingProposals, byzantineEquivocatingVotes, byzantineWithheldBlockParts:
+			default:
+				t.Fatalf("%s: unknown behavior %q", file, behavior)
+			}
+		}
+	}
+	return scenario
+}
+
+// recordingEvidencePool records the evidence of the equivocations detected by
+// the consensus state.
+type recordingEvidencePool struct {
+	mtx      sync.Mutex
+	evidence []types.Evidence
+}
+
+func (p *recordingEvidencePool) AddEvidence(ev types.Evidence) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.evidence = append(p.evidence, ev)
+	return nil
+}
+
+func (p *recordingEvidencePool) hasEvidenceOf(address []byte) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for _, ev := range p.evidence {
+		if bytes.Equal(ev.Address(), address) {
+			return true
+		}
+	}
+	return false
+}
+
+// Runs the scenarios of testdata/byzantine.
+func TestByzantineScenarios(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "byzantine", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		scenario := loadByzantineScenario(t, file)
+		name := strings.TrimSuffix(filepath.Base(file), ".json")
+		t.Run(name, func(t *testing.T) {
+			t.Log(scenario.Description)
+			runByzantineScenario(t, scenario)
+		})
+	}
+}
+
+func runByzantineScenario(t *testing.T, scenario byzantineScenario) {
+	N := scenario.Validators
+	logger := consensusLogger().With("test", "byzantine_scenario")
+	// the withheld proposals are only given up on when timing out, so the
+	// timeouts must be long enough for the honest ones to make it
+	css, cleanup := randConsensusNet(N, "consensus_byzantine_scenario_test", NewTimeoutTicker, newCounter,
+		func(c *cfg.Config) {
+			c.Consensus.TimeoutPropose = 200 * time.Millisecond
+		})
+	defer cleanup()
+
+	behaviors := make(map[int][]string)
+	for _, val := range scenario.Byzantine {
+		behaviors[val.Index] = append(behaviors[val.Index], val.Behaviors...)
+	}
+
+	switches := make([]*p2p.Switch, N)
+	p2pLogger := logger.With("module", "p2p")
+	for i := 0; i < N; i++ {
+		switches[i] = p2p.MakeSwitch(
+			config.P2P,
+			i,
+			"foo", "1.0.0",
+			func(i int, sw *p2p.Switch) *p2p.Switch {
+				return sw
+			})
+		switches[i].SetLogger(p2pLogger.With("validator", i))
+	}
+
+	evpools := make([]*recordingEvidencePool, N)
+	blocksSubs := make(map[int]types.Subscription)
+	reactors := make([]p2p.Reactor, N)
+	conRs := make([]*ConsensusReactor, N)
+	for i := 0; i < N; i++ {
+		evpools[i] = &recordingEvidencePool{}
+		css[i].evpool = evpools[i]
+
+		eventBus := css[i].eventBus
+		eventBus.SetLogger(logger.With("module", "events", "validator", i))
+
+		conRs[i] = NewConsensusReactor(css[i], true) // so we dont start the consensus states
+		conRs[i].SetLogger(logger.With("validator", i))
+		conRs[i].SetEventBus(eventBus)
+		reactors[i] = conRs[i]
+
+		if _, ok := behaviors[i]; !ok {
+			var err error
+			blocksSubs[i], err = eventBus.Subscribe(context.Background(), testSubscriber, types.EventQueryNewBlock)
+			require.NoError(t, err)
+			continue
+		}
+
+		// NOTE: Now, test validators are MockPV, which by default doesn't
+		// do any safety checks.
+		css[i].privValidator.(*types.MockPV).DisableChecks()
+		// Nor does the node.
+		css[i].signGuard = nil
+		for _, behavior := range behaviors[i] {
+			cs, sw := css[i], switches[i]
+			switch behavior {
+			case byzantineConflictingProposals:
+				cs.decideProposal = func(height int64, round int) {
+					byzantineDecideProposalFunc(t, height, round, cs, sw)
+				}
+			case byzantineWithheldBlockParts:
+				cs.decideProposal = func(height int64, round int) {
+					byzantineWithholdProposalFunc(t, height, round, cs, sw)
+				}
+			case byzantineEquivocatingVotes:
+				votesSub, err := eventBus.Subscribe(context.Background(), testSubscriber, types.EventQueryVote)
+				require.NoError(t, err)
+				chainID, address := cs.state.ChainID, cs.privValidator.GetPubKey().Address()
+				go func() {
+					for msg := range votesSub.Out() {
+						vote := msg.Data().(types.EventDataVote).Vote
+						if bytes.Equal(vote.ValidatorAddress, address) {
+							byzantineSendConflictingVote(t, chainID, vote, cs, sw)
+						}
+					}
+				}()
+			}
+		}
+		reactors[i] = NewByzantineReactor(conRs[i], true)
+	}
+
+	defer func() {
+		for _, conR := range conRs {
+			conR.Switch.Stop()
+		}
+	}()
+
+	p2p.MakeConnectedSwitches(config.P2P, N, func(i int, s *p2p.Switch) *p2p.Switch {
+		// ignore new switch s, we already made ours
+		switches[i].AddReactor("CONSENSUS", reactors[i])
+		return switches[i]
+	}, p2p.Connect2Switches)
+
+	for _, conR := range conRs {
+		conR.SwitchToConsensus(conR.conS.GetState(), 0)
+	}
+
+	// wait till the honest validators commit the heights
+	wg := new(sync.WaitGroup)
+	for _, blocksSub := range blocksSubs {
+		wg.Add(1)
+		go func(blocksSub types.Subscription) {
+			defer wg.Done()
+			for i := 0; i < scenario.Heights; i++ {
+				<-blocksSub.Out()
+			}
+		}(blocksSub)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Minute):
+		for i, conR := range conRs {
+			t.Logf("Consensus Reactor %v", i)
+			t.Logf("%v", conR)
+		}
+		t.Fatalf("Timed out waiting for the honest validators to commit %d blocks", scenario.Heights)
+	}
+
+	if !scenario.ExpectEvidence {
+		return
+	}
+	for i := range behaviors {
+		address := css[i].privValidator.GetPubKey().Address()
+		detected := false
+		for j, evpool := range evpools {
+			if _, ok := behaviors[j]; !ok && evpool.hasEvidenceOf(address) {
+				detected = true
+			}
+		}
+		assert.True(t, detected, "The equivocations of validator #%d weren't detected", i)
+	}
+}
+
 //-------------------------------
 // byzantine consensus functions
 
@@ -230,24 +478,87 @@ func sendProposalAndParts(height int64, round int, cs *ConsensusState, peer p2p.
 	peer.Send(VoteChannel, cdc.MustMarshalBinaryBare(&VoteMessage{precommit}))
 }
 
+// byzantineWithholdProposalFunc proposes a block, but withholds its last part
+// from the peers, so they can't get it.
+func byzantineWithholdProposalFunc(t *testing.T, height int64, round int, cs *ConsensusState, sw *p2p.Switch) {
+	block, blockParts := cs.createProposalBlock()
+	propBlockID := types.BlockID{Hash: block.Hash(), PartsHeader: blockParts.Header()}
+	proposal := types.NewProposal(height, round, cs.ValidRound, propBlockID)
+	if err := cs.privValidator.SignProposal(cs.state.ChainID, proposal); err != nil {
+		t.Error(err)
+	}
+
+	peers := sw.Peers().List()
+	t.Logf("Byzantine: broadcasting a proposal without its last part to %d peers", len(peers))
+	for _, peer := range peers {
+		peer.Send(DataChannel, cdc.MustMarshalBinaryBare(&ProposalMessage{Proposal: proposal}))
+		for i := 0; i < blockParts.Total()-1; i++ {
+			msg := &BlockPartMessage{
+				Height: height,
+				Round:  round,
+				Part:   blockParts.GetPart(i),
+			}
+			peer.Send(DataChannel, cdc.MustMarshalBinaryBare(msg))
+		}
+	}
+}
+
+// byzantineSendConflictingVote signs a vote conflicting with the given one of
+// the validator, for nil if it's for a block or for another block otherwise,
+// and sends it to every other peer, so they see the validator equivocate.
+func byzantineSendConflictingVote(t *testing.T, chainID string, vote *types.Vote, cs *ConsensusState, sw *p2p.Switch) {
+	conflicting := vote.Copy()
+	conflicting.Extension, conflicting.ExtensionSignature = nil, nil
+	if vote.BlockID.IsZero() {
+		conflicting.BlockID = types.BlockID{
+			Hash:        tmhash.Sum([]byte("byzantine")),
+			PartsHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("byzantine parts"))},
+		}
+	} else {
+		conflicting.BlockID = types.BlockID{}
+	}
+	conflicting.Signature = nil
+	if err := cs.privValidator.SignVote(chainID, conflicting); err != nil {
+		t.Error(err)
+		return
+	}
+
+	msg := cdc.MustMarshalBinaryBare(&VoteMessage{conflicting})
+	for i, peer := range sw.Peers().List() {
+		if i%2 == 0 {
+			peer.Send(VoteChannel, msg)
+		}
+	}
+}
+
 //----------------------------------------
 // byzantine consensus reactor
 
+// ByzantineReactor wraps the consensus reactor of a byzantine validator. It
+// handles the messages received like the ConsensusReactor, but only gossips
+// like an honest node if honestGossip is set: otherwise the byzantine
+// consensus functions send all the messages of the validator.
 type ByzantineReactor struct {
 	cmn.Service
-	reactor *ConsensusReactor
+	reactor      *ConsensusReactor
+	honestGossip bool
 }
 
-func NewByzantineReactor(conR *ConsensusReactor) *ByzantineReactor {
+func NewByzantineReactor(conR *ConsensusReactor, honestGossip bool) *ByzantineReactor {
 	return &ByzantineReactor{
-		Service: conR,
-		reactor: conR,
+		Service:      conR,
+		reactor:      conR,
+		honestGossip: honestGossip,
 	}
 }
 
 func (br *ByzantineReactor) SetSwitch(s *p2p.Switch)               { br.reactor.SetSwitch(s) }
 func (br *ByzantineReactor) GetChannels() []*p2p.ChannelDescriptor { return br.reactor.GetChannels() }
 func (br *ByzantineReactor) AddPeer(peer p2p.Peer) {
+	if br.honestGossip {
+		br.reactor.AddPeer(peer)
+		return
+	}
 	if !br.reactor.IsRunning() {
 		return
 	}
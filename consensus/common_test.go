@@ -645,10 +645,19 @@ func randGenesisDoc(numValidators int, randPower bool, minPower int64) (*types.G
 	}
 	sort.Sort(types.PrivValidatorsByAddress(privValidators))
 
+	// fast round escalation, like the test consensus config
+	consensusParams := types.DefaultConsensusParams()
+	consensusParams.Timeout = types.TimeoutParams{
+		ProposeDelta:   1 * time.Millisecond,
+		PrevoteDelta:   1 * time.Millisecond,
+		PrecommitDelta: 1 * time.Millisecond,
+	}
+
 	return &types.GenesisDoc{
-		GenesisTime: tmtime.Now(),
-		ChainID:     config.ChainID(),
-		Validators:  validators,
+		GenesisTime:     tmtime.Now(),
+		ChainID:         config.ChainID(),
+		ConsensusParams: consensusParams,
+		Validators:      validators,
 	}, privValidators
 }
 
@@ -92,6 +92,34 @@ func TestMempoolProgressInHigherRound(t *testing.T) {
 	ensureNewEventOnChannel(newBlockCh)       // now we can commit the block
 }
 
+func TestMempoolProgressWithAppPendingState(t *testing.T) {
+	config := ResetConfig("consensus_mempool_txs_available_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Consensus.CreateEmptyBlocks = false
+	state, privVals := randGenesisState(1, false, 10)
+	app := &pendingStateApplication{CounterApplication: NewCounterApplication(), pendingHeight: 1}
+	cs := newConsensusStateWithConfig(config, state, privVals[0], app)
+	assertMempool(cs.txNotifier).EnableTxsAvailable()
+	height, round := cs.Height, cs.Round
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(cs, height, round)
+
+	ensureNewEventOnChannel(newBlockCh)   // first block gets committed
+	ensureNewEventOnChannel(newBlockCh)   // the app reported state changes pending
+	ensureNoNewEventOnChannel(newBlockCh) // but not anymore
+}
+
+// pendingStateApplication reports state changes pending at the end of a block.
+type pendingStateApplication struct {
+	*CounterApplication
+
+	pendingHeight int64
+}
+
+func (app *pendingStateApplication) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
+	return abci.ResponseEndBlock{PendingState: req.Height == app.pendingHeight}
+}
+
 func deliverTxsRange(cs *ConsensusState, start, end int) {
 	// Deliver some txs.
 	for i := start; i < end; i++ {
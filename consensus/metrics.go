@@ -21,6 +21,20 @@ type Metrics struct {
 
 	// Number of rounds.
 	Rounds metrics.Gauge
+	// Number of rounds skipped without a commit, by reason.
+	RoundSkips metrics.Counter
+
+	// Number of heights by what made their first round propose a block:
+	// create_empty_blocks, create_empty_blocks_interval, txs, proof_block or
+	// app_pending_state.
+	ProposeTriggers metrics.Counter
+	// Whether or not the node is waiting for txs to propose a block. 1 if yes,
+	// 0 if no.
+	WaitingForTxs metrics.Gauge
+
+	// Number of proposals and votes the node refused to sign, as it could
+	// double sign, by reason.
+	SignRefusals metrics.Counter
 
 	// Number of validators.
 	Validators metrics.Gauge
@@ -74,6 +88,32 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "rounds",
 			Help:      "Number of rounds.",
 		}, labels).With(labelsAndValues...),
+		RoundSkips: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "round_skips",
+			Help:      "Number of rounds skipped without a commit, by reason.",
+		}, append(labels, "reason")).With(labelsAndValues...),
+
+		ProposeTriggers: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "propose_triggers",
+			Help:      "Number of heights by what made their first round propose a block.",
+		}, append(labels, "trigger")).With(labelsAndValues...),
+		WaitingForTxs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "waiting_for_txs",
+			Help:      "Whether or not the node is waiting for txs to propose a block. 1 if yes, 0 if no.",
+		}, labels).With(labelsAndValues...),
+
+		SignRefusals: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sign_refusals",
+			Help:      "Number of proposals and votes the node refused to sign, as it could double sign, by reason.",
+		}, append(labels, "reason")).With(labelsAndValues...),
 
 		Validators: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
@@ -163,7 +203,13 @@ func NopMetrics() *Metrics {
 	return &Metrics{
 		Height: discard.NewGauge(),
 
-		Rounds: discard.NewGauge(),
+		Rounds:     discard.NewGauge(),
+		RoundSkips: discard.NewCounter(),
+
+		ProposeTriggers: discard.NewCounter(),
+		WaitingForTxs:   discard.NewGauge(),
+
+		SignRefusals: discard.NewCounter(),
 
 		Validators:               discard.NewGauge(),
 		ValidatorsPower:          discard.NewGauge(),
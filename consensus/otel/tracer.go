@@ -0,0 +1,84 @@
+// +build otel
+
+// Package otel exports the trace events of the consensus state machine as
+// OpenTelemetry spans: a span per round, with the events of the round, which
+// ends with an error status when the round is skipped.
+package otel
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	cs "github.com/tendermint/tendermint/consensus"
+)
+
+// Tracer implements consensus.Tracer on top of an OpenTelemetry tracer.
+type Tracer struct {
+	tracer trace.Tracer
+
+	mtx  sync.Mutex
+	span trace.Span // of the current round, nil if none
+}
+
+var _ cs.Tracer = (*Tracer)(nil)
+
+// NewTracer returns a Tracer creating its spans with the given tracer.
+func NewTracer(tracer trace.Tracer) *Tracer {
+	return &Tracer{tracer: tracer}
+}
+
+// Trace implements consensus.Tracer.
+func (t *Tracer) Trace(ev cs.TraceEvent) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if ev.Type == cs.TraceEnterNewRound {
+		t.endSpan(ev)
+		_, t.span = t.tracer.Start(context.Background(), "consensus.round",
+			trace.WithTimestamp(ev.Time),
+			trace.WithAttributes(
+				attribute.Int64("height", ev.Height),
+				attribute.Int("round", ev.Round),
+			))
+	}
+	if t.span == nil {
+		return
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.Int64("height", ev.Height),
+		attribute.Int("round", ev.Round),
+	}
+	if ev.Reason != "" {
+		attrs = append(attrs, attribute.String("reason", ev.Reason))
+	}
+	switch ev.Type {
+	case cs.TraceEnterPropose:
+		attrs = append(attrs, attribute.String("proposer", ev.Proposer.String()))
+	case cs.TraceEnterPrecommit, cs.TracePolka:
+		attrs = append(attrs, attribute.String("block_id", ev.BlockID.String()))
+	case cs.TraceRoundSkip:
+		attrs = append(attrs, attribute.Int("from_round", ev.FromRound))
+	}
+	t.span.AddEvent(string(ev.Type), trace.WithTimestamp(ev.Time), trace.WithAttributes(attrs...))
+
+	switch ev.Type {
+	case cs.TraceRoundSkip:
+		t.span.SetStatus(codes.Error, ev.Reason)
+		t.endSpan(ev)
+	case cs.TraceEnterCommit:
+		t.endSpan(ev)
+	}
+}
+
+func (t *Tracer) endSpan(ev cs.TraceEvent) {
+	if t.span == nil {
+		return
+	}
+	t.span.End(trace.WithTimestamp(ev.Time))
+	t.span = nil
+}
@@ -0,0 +1,152 @@
+package consensus
+
+import (
+	"sort"
+	"time"
+
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+const (
+	// How long the peers which aren't seeders of our proposal wait for every
+	// part to be sent to a seeder, at most. Covers seeders which are slow or
+	// disconnected.
+	proposalFanoutTimeout = 500 * time.Millisecond
+)
+
+// fanoutPeer is a peer as ranked by the proposal fan-out.
+type fanoutPeer struct {
+	id      p2p.ID
+	tier    p2p.PeerTier
+	latency time.Duration // 0 if unknown
+}
+
+// rankFanoutPeers sorts the peers by tier, highest first (validators first),
+// then by latency, lowest first. Peers of unknown latency come last in their
+// tier.
+func rankFanoutPeers(peers []fanoutPeer) {
+	sort.SliceStable(peers, func(i, j int) bool {
+		pi, pj := peers[i], peers[j]
+		if pi.tier != pj.tier {
+			return pi.tier > pj.tier
+		}
+		if (pi.latency == 0) != (pj.latency == 0) {
+			return pj.latency == 0
+		}
+		return pi.latency < pj.latency
+	})
+}
+
+// proposalFanout schedules the parts of our own proposal among the peers.
+// Instead of sending every part to every peer in random order, which splits
+// our upload bandwidth evenly, the parts are first sent once each to the
+// seeders: the best ranked peers of the highest tier we are connected to, at
+// most one per part. Part i goes to the seeder of rank i mod #seeders, so the
+// seeders get distinct parts they relay to each other right away. The other
+// peers get parts once every part was sent to a seeder, or after
+// proposalFanoutTimeout.
+type proposalFanout struct {
+	height int64
+	round  int
+
+	seeders  map[p2p.ID]int // rank of the seeders
+	nSeeders int
+	total    int
+
+	sent  *cmn.BitArray // parts sent to, or relayed to, their seeder
+	start time.Time
+}
+
+func newProposalFanout(height int64, round int, total int, peers []fanoutPeer, now time.Time) *proposalFanout {
+	rankFanoutPeers(peers)
+	f := &proposalFanout{
+		height:  height,
+		round:   round,
+		seeders: make(map[p2p.ID]int),
+		total:   total,
+		sent:    cmn.NewBitArray(total),
+		start:   now,
+	}
+	for rank, peer := range peers {
+		if rank == total || peer.tier != peers[0].tier {
+			break
+		}
+		f.seeders[peer.id] = rank
+	}
+	f.nSeeders = len(f.seeders)
+	return f
+}
+
+// pick returns the part to send to the peer, out of the parts it's missing.
+// It returns false if the peer has to wait for the seeders.
+func (f *proposalFanout) pick(id p2p.ID, missing *cmn.BitArray, now time.Time) (int, bool) {
+	if rank, ok := f.seeders[id]; ok {
+		for i := rank; i < f.total; i += f.nSeeders {
+			if !missing.GetIndex(i) {
+				// The seeder has the part, it was relayed to it.
+				f.sent.SetIndex(i, true)
+				continue
+			}
+			return i, true
+		}
+	}
+	if !f.seeded(now) {
+		return 0, false
+	}
+	return missing.PickRandom()
+}
+
+// markSent records that the part was sent to a peer.
+func (f *proposalFanout) markSent(index int) {
+	f.sent.SetIndex(index, true)
+}
+
+// seeded returns true if every part was sent to its seeder, or if the
+// seeders had enough time.
+func (f *proposalFanout) seeded(now time.Time) bool {
+	return f.sent.IsFull() || now.Sub(f.start) >= proposalFanoutTimeout
+}
+
+//-----------------------------------------------------------------------------
+// ConsensusReactor proposal fan-out
+
+// pickProposalBlockPart returns the part of the proposal block to send to the
+// peer, if any. The parts of our own proposal are scheduled by the
+// proposalFanout, the others are picked at random.
+func (conR *ConsensusReactor) pickProposalBlockPart(rs *cstypes.RoundState, prs *cstypes.PeerRoundState,
+	peer p2p.Peer) (int, *proposalFanout, bool) {
+	missing := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy())
+	if !conR.conS.isOwnProposal(rs.Proposal) {
+		index, ok := missing.PickRandom()
+		return index, nil, ok
+	}
+	f := conR.proposalFanout(rs)
+	index, ok := f.pick(peer.ID(), missing, time.Now())
+	return index, f, ok
+}
+
+// proposalFanout returns the fan-out of our proposal of the round state,
+// ranking the peers the first time.
+func (conR *ConsensusReactor) proposalFanout(rs *cstypes.RoundState) *proposalFanout {
+	conR.fanoutMtx.Lock()
+	defer conR.fanoutMtx.Unlock()
+	f := conR.fanout
+	if f != nil && f.height == rs.Height && f.round == rs.Proposal.Round {
+		return f
+	}
+	var peers []fanoutPeer
+	for _, peer := range conR.Switch.Peers().List() {
+		peers = append(peers, fanoutPeer{
+			id:      peer.ID(),
+			tier:    conR.Switch.PeerTier(peer),
+			latency: peer.Latency(),
+		})
+	}
+	f = newProposalFanout(rs.Height, rs.Proposal.Round, rs.ProposalBlockParts.Total(), peers, time.Now())
+	conR.fanout = f
+	conR.Logger.Debug("Scheduled the fan-out of our proposal", "height", f.height, "round", f.round,
+		"parts", f.total, "seeders", f.nSeeders)
+	return f
+}
@@ -0,0 +1,115 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+func TestRankFanoutPeers(t *testing.T) {
+	peers := []fanoutPeer{
+		{id: "public-fast", tier: p2p.PeerTierPublic, latency: time.Millisecond},
+		{id: "validator-unknown", tier: p2p.PeerTierValidator},
+		{id: "validator-slow", tier: p2p.PeerTierValidator, latency: 100 * time.Millisecond},
+		{id: "sentry", tier: p2p.PeerTierSentry, latency: 50 * time.Millisecond},
+		{id: "validator-fast", tier: p2p.PeerTierValidator, latency: 10 * time.Millisecond},
+	}
+	rankFanoutPeers(peers)
+
+	ids := make([]p2p.ID, len(peers))
+	for i, peer := range peers {
+		ids[i] = peer.id
+	}
+	assert.Equal(t, []p2p.ID{"validator-fast", "validator-slow", "validator-unknown", "sentry", "public-fast"}, ids)
+}
+
+func TestProposalFanoutSeeders(t *testing.T) {
+	peers := []fanoutPeer{
+		{id: "a", tier: p2p.PeerTierPublic, latency: time.Millisecond},
+		{id: "b", tier: p2p.PeerTierValidator, latency: 20 * time.Millisecond},
+		{id: "c", tier: p2p.PeerTierValidator, latency: 10 * time.Millisecond},
+	}
+
+	// Only the peers of the highest tier are seeders.
+	f := newProposalFanout(1, 0, 5, peers, time.Now())
+	assert.Equal(t, map[p2p.ID]int{"c": 0, "b": 1}, f.seeders)
+
+	// At most one per part.
+	f = newProposalFanout(1, 0, 1, peers, time.Now())
+	assert.Equal(t, map[p2p.ID]int{"c": 0}, f.seeders)
+}
+
+func TestProposalFanoutPick(t *testing.T) {
+	peers := []fanoutPeer{
+		{id: "a", tier: p2p.PeerTierValidator, latency: 10 * time.Millisecond},
+		{id: "b", tier: p2p.PeerTierValidator, latency: 20 * time.Millisecond},
+		{id: "c", tier: p2p.PeerTierPublic, latency: time.Millisecond},
+	}
+	start := time.Now()
+	total := 5
+	f := newProposalFanout(1, 0, total, peers, start)
+
+	allMissing := func() *cmn.BitArray {
+		ba := cmn.NewBitArray(total)
+		for i := 0; i < total; i++ {
+			ba.SetIndex(i, true)
+		}
+		return ba
+	}
+
+	// The seeders get their own parts first, in order.
+	missingA, missingB := allMissing(), allMissing()
+	for _, want := range []int{0, 2, 4} {
+		index, ok := f.pick("a", missingA, start)
+		require.True(t, ok)
+		assert.Equal(t, want, index)
+		missingA.SetIndex(index, false)
+		f.markSent(index)
+	}
+
+	// Until every part was sent to its seeder, the others wait.
+	_, ok := f.pick("a", missingA, start)
+	assert.False(t, ok)
+	_, ok = f.pick("c", allMissing(), start)
+	assert.False(t, ok)
+
+	// Part 1 was relayed to b by a.
+	missingB.SetIndex(1, false)
+	index, ok := f.pick("b", missingB, start)
+	require.True(t, ok)
+	assert.Equal(t, 3, index)
+	f.markSent(index)
+	assert.True(t, f.seeded(start))
+
+	// Then the missing parts are picked at random.
+	index, ok = f.pick("c", allMissing(), start)
+	require.True(t, ok)
+	assert.True(t, index >= 0 && index < total)
+	index, ok = f.pick("a", missingA, start)
+	require.True(t, ok)
+	assert.Contains(t, []int{1, 3}, index)
+}
+
+func TestProposalFanoutTimeout(t *testing.T) {
+	peers := []fanoutPeer{
+		{id: "a", tier: p2p.PeerTierValidator},
+		{id: "b", tier: p2p.PeerTierPublic},
+	}
+	start := time.Now()
+	f := newProposalFanout(1, 0, 2, peers, start)
+
+	missing := cmn.NewBitArray(2)
+	missing.SetIndex(0, true)
+	missing.SetIndex(1, true)
+
+	// The seeder never got its parts.
+	_, ok := f.pick("b", missing, start)
+	assert.False(t, ok)
+	_, ok = f.pick("b", missing, start.Add(proposalFanoutTimeout))
+	assert.True(t, ok)
+}
@@ -27,6 +27,8 @@ const (
 
 	maxMsgSize = 1048576 // 1MB; NOTE/TODO: keep in sync with types.PartSet sizes.
 
+	maxVoteBatchSize = 100 // Number of votes sent at once in a VoteBatchMessage
+
 	blocksToContributeToBecomeGoodPeer = 10000
 	votesToContributeToBecomeGoodPeer  = 10000
 )
@@ -43,6 +45,10 @@ type ConsensusReactor struct {
 	fastSync bool
 	eventBus *types.EventBus
 
+	// schedule of the parts of our last proposal, see proposal_fanout.go
+	fanoutMtx sync.Mutex
+	fanout    *proposalFanout
+
 	metrics *Metrics
 }
 
@@ -222,6 +228,7 @@ func (conR *ConsensusReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 	if !ok {
 		panic(fmt.Sprintf("Peer %v has no state", src))
 	}
+	ps.RecordMessage()
 
 	switch chID {
 	case StateChannel:
@@ -304,6 +311,34 @@ func (conR *ConsensusReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 
 			cs.peerMsgQueue <- msgInfo{msg, src.ID()}
 
+		case *VoteBatchMessage:
+			cs := conR.conS
+			cs.mtx.RLock()
+			height, valSize, lastCommitSize := cs.Height, cs.Validators.Size(), cs.LastCommit.Size()
+			cs.mtx.RUnlock()
+			ps.EnsureVoteBitArrays(height, valSize)
+			ps.EnsureVoteBitArrays(height-1, lastCommitSize)
+			// the votes are handled one by one by the consensus state
+			for _, vote := range msg.Votes {
+				ps.SetHasVote(vote)
+				cs.peerMsgQueue <- msgInfo{&VoteMessage{vote}, src.ID()}
+			}
+
+		case *CommitMessage:
+			cs := conR.conS
+			cs.mtx.RLock()
+			height, valSize, lastCommitSize := cs.Height, cs.Validators.Size(), cs.LastCommit.Size()
+			cs.mtx.RUnlock()
+			ps.EnsureVoteBitArrays(height, valSize)
+			ps.EnsureVoteBitArrays(height-1, lastCommitSize)
+			ps.SetHasCommit(msg.Commit)
+			// only the commit of our height makes us catch up
+			if msg.Commit.Height() != height {
+				return
+			}
+			// the precommits are handled one by one by the consensus state
+			cs.peerMsgQueue <- msgInfo{msg, src.ID()}
+
 		default:
 			// don't punish (leave room for soft upgrades)
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
@@ -468,7 +503,7 @@ OUTER_LOOP:
 
 		// Send proposal Block parts?
 		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartsHeader) {
-			if index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
+			if index, fanout, ok := conR.pickProposalBlockPart(rs, prs, peer); ok {
 				part := rs.ProposalBlockParts.GetPart(index)
 				msg := &BlockPartMessage{
 					Height: rs.Height, // This tells peer that this part applies to us.
@@ -478,6 +513,9 @@ OUTER_LOOP:
 				logger.Debug("Sending block part", "height", prs.Height, "round", prs.Round)
 				if peer.Send(DataChannel, cdc.MustMarshalBinaryBare(msg)) {
 					ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
+					if fanout != nil {
+						fanout.markSent(index)
+					}
 				}
 				continue OUTER_LOOP
 			}
@@ -625,8 +663,14 @@ OUTER_LOOP:
 		}
 
 		// Special catchup logic.
-		// If peer is lagging by height 1, send LastCommit.
+		// If peer is lagging by height 1, send our seen commit at once if it
+		// supports it, then the rest of LastCommit.
 		if prs.Height != 0 && rs.Height == prs.Height+1 {
+			if prs.Step < cstypes.RoundStepCommit &&
+				ps.PickSendCommit(conR.conS.blockStore.LoadSeenCommit(prs.Height)) {
+				logger.Debug("Picked seen commit to send", "height", prs.Height)
+				continue OUTER_LOOP
+			}
 			if ps.PickSendVote(rs.LastCommit) {
 				logger.Debug("Picked rs.LastCommit to send", "height", prs.Height)
 				continue OUTER_LOOP
@@ -639,6 +683,10 @@ OUTER_LOOP:
 			// Load the block commit for prs.Height,
 			// which contains precommit signatures for prs.Height.
 			commit := conR.conS.blockStore.LoadBlockCommit(prs.Height)
+			if prs.Step < cstypes.RoundStepCommit && ps.PickSendCommit(commit) {
+				logger.Debug("Picked Catchup commit to send at once", "height", prs.Height)
+				continue OUTER_LOOP
+			}
 			if ps.PickSendVote(commit) {
 				logger.Debug("Picked Catchup commit to send", "height", prs.Height)
 				continue OUTER_LOOP
@@ -827,11 +875,11 @@ func (conR *ConsensusReactor) peerStatsRoutine() {
 			switch msg.Msg.(type) {
 			case *VoteMessage:
 				if numVotes := ps.RecordVote(); numVotes%votesToContributeToBecomeGoodPeer == 0 {
-					conR.Switch.MarkPeerAsGood(peer)
+					conR.markPeerAsGood(peer)
 				}
 			case *BlockPartMessage:
 				if numParts := ps.RecordBlockPart(); numParts%blocksToContributeToBecomeGoodPeer == 0 {
-					conR.Switch.MarkPeerAsGood(peer)
+					conR.markPeerAsGood(peer)
 				}
 			}
 		case <-conR.conS.Quit():
@@ -843,6 +891,14 @@ func (conR *ConsensusReactor) peerStatsRoutine() {
 	}
 }
 
+// markPeerAsGood marks a peer which contributed useful votes or block parts
+// as good and hints the Switch to move it to the sentry tier, so it isn't
+// competing with arbitrary public peers for slots on reconnect.
+func (conR *ConsensusReactor) markPeerAsGood(peer p2p.Peer) {
+	conR.Switch.MarkPeerAsGood(peer)
+	conR.Switch.SetPeerTierHint(peer.ID(), p2p.PeerTierSentry)
+}
+
 // String returns a string representation of the ConsensusReactor.
 // NOTE: For now, it is just a hard-coded string to avoid accessing unprotected shared variables.
 // TODO: improve!
@@ -903,13 +959,14 @@ type PeerState struct {
 
 // peerStateStats holds internal statistics for a peer.
 type peerStateStats struct {
-	Votes      int `json:"votes"`
-	BlockParts int `json:"block_parts"`
+	Votes           int       `json:"votes"`
+	BlockParts      int       `json:"block_parts"`
+	LastMessageTime time.Time `json:"last_message_time"`
 }
 
 func (pss peerStateStats) String() string {
-	return fmt.Sprintf("peerStateStats{votes: %d, blockParts: %d}",
-		pss.Votes, pss.BlockParts)
+	return fmt.Sprintf("peerStateStats{votes: %d, blockParts: %d, lastMessageTime: %v}",
+		pss.Votes, pss.BlockParts, pss.LastMessageTime)
 }
 
 // NewPeerState returns a new PeerState for the given Peer
@@ -1011,9 +1068,14 @@ func (ps *PeerState) SetHasProposalBlockPart(height int64, round int, index int)
 	ps.PRS.ProposalBlockParts.SetIndex(index, true)
 }
 
-// PickSendVote picks a vote and sends it to the peer.
+// PickSendVote picks a vote and sends it to the peer, or up to
+// maxVoteBatchSize votes in a VoteBatchMessage if the peer supports
+// p2p.FeatureVoteBatch.
 // Returns true if vote was sent.
 func (ps *PeerState) PickSendVote(votes types.VoteSetReader) bool {
+	if ps.peer.HasFeature(p2p.FeatureVoteBatch) {
+		return ps.pickSendVoteBatch(votes)
+	}
 	if vote, ok := ps.PickVoteToSend(votes); ok {
 		msg := &VoteMessage{vote}
 		ps.logger.Debug("Sending vote message", "ps", ps, "vote", vote)
@@ -1026,15 +1088,76 @@ func (ps *PeerState) PickSendVote(votes types.VoteSetReader) bool {
 	return false
 }
 
+// PickSendCommit sends the whole commit to the peer in a CommitMessage, if it
+// supports p2p.FeatureCatchupCommit and misses any of its precommits, rather
+// than one vote (or batch) at a time.
+// Returns true if the commit was sent.
+func (ps *PeerState) PickSendCommit(commit *types.Commit) bool {
+	if commit.Size() == 0 || !ps.peer.HasFeature(p2p.FeatureCatchupCommit) {
+		return false
+	}
+	height, round, size := commit.Height(), commit.Round(), commit.Size()
+
+	ps.mtx.Lock()
+	ps.ensureCatchupCommitRound(height, round, size)
+	ps.ensureVoteBitArrays(height, size)
+	psVotes := ps.getVoteBitArray(height, round, types.PrecommitType)
+	missing := psVotes != nil && !commit.BitArray().Sub(psVotes).IsEmpty()
+	ps.mtx.Unlock()
+	if !missing {
+		return false
+	}
+
+	ps.logger.Debug("Sending commit message", "ps", ps, "height", height, "round", round)
+	if ps.peer.Send(VoteChannel, cdc.MustMarshalBinaryBare(&CommitMessage{commit})) {
+		ps.SetHasCommit(commit)
+		return true
+	}
+	return false
+}
+
+// pickSendVoteBatch picks up to maxVoteBatchSize votes and sends them to the
+// peer, in a VoteMessage if there's a single one.
+// Returns true if the votes were sent.
+func (ps *PeerState) pickSendVoteBatch(votes types.VoteSetReader) bool {
+	picked := ps.PickVotesToSend(votes, maxVoteBatchSize)
+	var msg ConsensusMessage
+	switch len(picked) {
+	case 0:
+		return false
+	case 1:
+		msg = &VoteMessage{picked[0]}
+	default:
+		msg = &VoteBatchMessage{picked}
+	}
+	ps.logger.Debug("Sending vote batch message", "ps", ps, "votes", len(picked))
+	if ps.peer.Send(VoteChannel, cdc.MustMarshalBinaryBare(msg)) {
+		for _, vote := range picked {
+			ps.SetHasVote(vote)
+		}
+		return true
+	}
+	return false
+}
+
 // PickVoteToSend picks a vote to send to the peer.
 // Returns true if a vote was picked.
 // NOTE: `votes` must be the correct Size() for the Height().
 func (ps *PeerState) PickVoteToSend(votes types.VoteSetReader) (vote *types.Vote, ok bool) {
+	if picked := ps.PickVotesToSend(votes, 1); len(picked) > 0 {
+		return picked[0], true
+	}
+	return nil, false
+}
+
+// PickVotesToSend picks up to max random votes to send to the peer.
+// NOTE: `votes` must be the correct Size() for the Height().
+func (ps *PeerState) PickVotesToSend(votes types.VoteSetReader, max int) []*types.Vote {
 	ps.mtx.Lock()
 	defer ps.mtx.Unlock()
 
 	if votes.Size() == 0 {
-		return nil, false
+		return nil
 	}
 
 	height, round, type_, size := votes.Height(), votes.Round(), types.SignedMsgType(votes.Type()), votes.Size()
@@ -1047,12 +1170,19 @@ func (ps *PeerState) PickVoteToSend(votes types.VoteSetReader) (vote *types.Vote
 
 	psVotes := ps.getVoteBitArray(height, round, type_)
 	if psVotes == nil {
-		return nil, false // Not something worth sending
+		return nil // Not something worth sending
 	}
-	if index, ok := votes.BitArray().Sub(psVotes).PickRandom(); ok {
-		return votes.GetByIndex(index), true
+	var picked []*types.Vote
+	missing := votes.BitArray().Sub(psVotes)
+	for len(picked) < max {
+		index, ok := missing.PickRandom()
+		if !ok {
+			break
+		}
+		missing.SetIndex(index, false)
+		picked = append(picked, votes.GetByIndex(index))
 	}
-	return nil, false
+	return picked
 }
 
 func (ps *PeerState) getVoteBitArray(height int64, round int, type_ types.SignedMsgType) *cmn.BitArray {
@@ -1185,6 +1315,24 @@ func (ps *PeerState) RecordBlockPart() int {
 	return ps.Stats.BlockParts
 }
 
+// RecordMessage records the time of the last consensus message received from
+// the peer.
+func (ps *PeerState) RecordMessage() {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.Stats.LastMessageTime = tmtime.Now()
+}
+
+// LastMessageTime returns when the peer last sent us a consensus message, zero
+// if it never did.
+func (ps *PeerState) LastMessageTime() time.Time {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	return ps.Stats.LastMessageTime
+}
+
 // BlockPartsSent returns the number of useful block parts the peer has sent us.
 func (ps *PeerState) BlockPartsSent() int {
 	ps.mtx.Lock()
@@ -1201,6 +1349,19 @@ func (ps *PeerState) SetHasVote(vote *types.Vote) {
 	ps.setHasVote(vote.Height, vote.Round, vote.Type, vote.ValidatorIndex)
 }
 
+// SetHasCommit sets the given commit's precommits as known for the peer.
+func (ps *PeerState) SetHasCommit(commit *types.Commit) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	height, round := commit.Height(), commit.Round()
+	for i, precommit := range commit.Precommits {
+		if precommit != nil {
+			ps.setHasVote(height, round, types.PrecommitType, i)
+		}
+	}
+}
+
 func (ps *PeerState) setHasVote(height int64, round int, type_ types.SignedMsgType, index int) {
 	logger := ps.logger.With("peerH/R", fmt.Sprintf("%d/%d", ps.PRS.Height, ps.PRS.Round), "H/R", fmt.Sprintf("%d/%d", height, round))
 	logger.Debug("setHasVote", "type", type_, "index", index)
@@ -1369,6 +1530,8 @@ func RegisterConsensusMessages(cdc *amino.Codec) {
 	cdc.RegisterConcrete(&BlockPartMessage{}, "tendermint/BlockPart", nil)
 	cdc.RegisterConcrete(&VoteMessage{}, "tendermint/Vote", nil)
 	cdc.RegisterConcrete(&HasVoteMessage{}, "tendermint/HasVote", nil)
+	cdc.RegisterConcrete(&VoteBatchMessage{}, "tendermint/VoteBatch", nil)
+	cdc.RegisterConcrete(&CommitMessage{}, "tendermint/Commit", nil)
 	cdc.RegisterConcrete(&VoteSetMaj23Message{}, "tendermint/VoteSetMaj23", nil)
 	cdc.RegisterConcrete(&VoteSetBitsMessage{}, "tendermint/VoteSetBits", nil)
 }
@@ -1550,6 +1713,77 @@ func (m *VoteMessage) String() string {
 
 //-------------------------------------
 
+// VoteBatchMessage is sent instead of several VoteMessages to the peers which
+// support p2p.FeatureVoteBatch. The votes are from the same vote set.
+type VoteBatchMessage struct {
+	Votes []*types.Vote
+}
+
+// ValidateBasic performs basic validation.
+func (m *VoteBatchMessage) ValidateBasic() error {
+	if len(m.Votes) == 0 {
+		return errors.New("No votes")
+	}
+	if len(m.Votes) > maxVoteBatchSize {
+		return fmt.Errorf("Too many votes: %d, max: %d", len(m.Votes), maxVoteBatchSize)
+	}
+	first := m.Votes[0]
+	for i, vote := range m.Votes {
+		if vote == nil {
+			return fmt.Errorf("Nil vote #%d", i)
+		}
+		if err := vote.ValidateBasic(); err != nil {
+			return fmt.Errorf("Wrong vote #%d: %v", i, err)
+		}
+		if vote.Height != first.Height || vote.Round != first.Round || vote.Type != first.Type {
+			return fmt.Errorf("Vote #%d isn't from the vote set of the first vote", i)
+		}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *VoteBatchMessage) String() string {
+	return fmt.Sprintf("[VoteBatch %d votes]", len(m.Votes))
+}
+
+//-------------------------------------
+
+// CommitMessage is sent instead of the precommits of a commit to the peers
+// lagging behind at its height which support p2p.FeatureCatchupCommit.
+type CommitMessage struct {
+	Commit *types.Commit
+}
+
+// ValidateBasic performs basic validation.
+func (m *CommitMessage) ValidateBasic() error {
+	if m.Commit == nil {
+		return errors.New("Nil commit")
+	}
+	if err := m.Commit.ValidateBasic(); err != nil {
+		return fmt.Errorf("Wrong commit: %v", err)
+	}
+	for i, precommit := range m.Commit.Precommits {
+		if precommit == nil {
+			continue
+		}
+		if err := m.Commit.ToVote(precommit).ValidateBasic(); err != nil {
+			return fmt.Errorf("Wrong precommit #%d: %v", i, err)
+		}
+		if precommit.ValidatorIndex != i {
+			return fmt.Errorf("Precommit #%d has validator index %d", i, precommit.ValidatorIndex)
+		}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CommitMessage) String() string {
+	return fmt.Sprintf("[Commit H:%v R:%v]", m.Commit.Height(), m.Commit.Round())
+}
+
+//-------------------------------------
+
 // HasVoteMessage is sent to indicate that a particular vote has been received.
 type HasVoteMessage struct {
 	Height int64
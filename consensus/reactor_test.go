@@ -19,10 +19,13 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	bc "github.com/tendermint/tendermint/blockchain"
 	cfg "github.com/tendermint/tendermint/config"
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 	mempl "github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/dummy"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
 )
@@ -198,7 +201,7 @@ func newMockEvidencePool(val []byte) *mockEvidencePool {
 }
 
 // NOTE: maxBytes is ignored
-func (m *mockEvidencePool) PendingEvidence(maxBytes int64) []types.Evidence {
+func (m *mockEvidencePool) ReapMaxBytes(maxBytes int64) []types.Evidence {
 	if m.height > 0 {
 		return m.ev
 	}
@@ -214,6 +217,7 @@ func (m *mockEvidencePool) Update(block *types.Block, state sm.State) {
 	m.height++
 }
 func (m *mockEvidencePool) IsCommitted(types.Evidence) bool { return false }
+func (m *mockEvidencePool) Header(int64) *types.Header      { return nil }
 
 //------------------------------------
 
@@ -583,3 +587,188 @@ func capture() {
 	count := runtime.Stack(trace, true)
 	fmt.Printf("Stack of %d bytes: %s\n", count, trace)
 }
+
+func TestVoteBatchMessageValidateBasic(t *testing.T) {
+	newVote := func(index int, round int) *types.Vote {
+		return &types.Vote{
+			Type:             types.PrevoteType,
+			Height:           1,
+			Round:            round,
+			ValidatorAddress: make([]byte, 20),
+			ValidatorIndex:   index,
+			Signature:        []byte("signature"),
+		}
+	}
+	manyVotes := make([]*types.Vote, maxVoteBatchSize+1)
+	for i := range manyVotes {
+		manyVotes[i] = newVote(i, 0)
+	}
+
+	testCases := []struct {
+		name    string
+		votes   []*types.Vote
+		wantErr bool
+	}{
+		{"valid", []*types.Vote{newVote(0, 0), newVote(1, 0)}, false},
+		{"no votes", nil, true},
+		{"too many votes", manyVotes, true},
+		{"nil vote", []*types.Vote{newVote(0, 0), nil}, true},
+		{"invalid vote", []*types.Vote{newVote(-1, 0)}, true},
+		{"other vote set", []*types.Vote{newVote(0, 0), newVote(1, 1)}, true},
+	}
+	for _, tc := range testCases {
+		err := (&VoteBatchMessage{Votes: tc.votes}).ValidateBasic()
+		assert.Equal(t, tc.wantErr, err != nil, tc.name)
+	}
+}
+
+func TestCommitMessageValidateBasic(t *testing.T) {
+	valSet, privVals := types.RandValidatorSet(4, 10)
+	blockID := types.BlockID{Hash: tmhash.Sum([]byte("hash")), PartsHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))}}
+	makeCommit := func() *types.Commit {
+		voteSet := types.NewVoteSet("test_chain_id", 1, 0, types.PrecommitType, valSet)
+		commit, err := types.MakeCommit(blockID, 1, 0, voteSet, privVals)
+		require.NoError(t, err)
+		return commit
+	}
+
+	testCases := []struct {
+		name     string
+		malleate func(*types.Commit) *types.Commit
+		wantErr  bool
+	}{
+		{"valid", func(c *types.Commit) *types.Commit { return c }, false},
+		{"missing precommit", func(c *types.Commit) *types.Commit { c.Precommits[1] = nil; return c }, false},
+		{"nil commit", func(*types.Commit) *types.Commit { return nil }, true},
+		{"nil block", func(c *types.Commit) *types.Commit { c.BlockID = types.BlockID{}; return c }, true},
+		{"invalid precommit", func(c *types.Commit) *types.Commit { c.Precommits[1].Signature = nil; return c }, true},
+		{"wrong index", func(c *types.Commit) *types.Commit { c.Precommits[1].ValidatorIndex = 2; return c }, true},
+	}
+	for _, tc := range testCases {
+		err := (&CommitMessage{Commit: tc.malleate(makeCommit())}).ValidateBasic()
+		assert.Equal(t, tc.wantErr, err != nil, tc.name)
+	}
+}
+
+// laggingPeer is a peer lagging behind, which records the messages sent to
+// it.
+type laggingPeer struct {
+	p2p.Peer
+	catchupCommit bool
+	sent          chan ConsensusMessage
+}
+
+func newLaggingPeer(catchupCommit bool) *laggingPeer {
+	peer := &laggingPeer{
+		Peer:          dummy.NewPeer(),
+		catchupCommit: catchupCommit,
+		sent:          make(chan ConsensusMessage, 100),
+	}
+	peer.Start()
+	return peer
+}
+
+func (p *laggingPeer) HasFeature(feature string) bool {
+	return feature == p2p.FeatureCatchupCommit && p.catchupCommit
+}
+
+func (p *laggingPeer) Send(chID byte, msgBytes []byte) bool {
+	msg, err := decodeMsg(msgBytes)
+	if err != nil {
+		panic(err)
+	}
+	select {
+	case p.sent <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *laggingPeer) TrySend(chID byte, msgBytes []byte) bool {
+	return p.Send(chID, msgBytes)
+}
+
+// catchUp sets the peer at the height.
+func (p *laggingPeer) catchUp(ps *PeerState, height int64) {
+	ps.ApplyNewRoundStepMessage(&NewRoundStepMessage{
+		Height:          height,
+		Round:           0,
+		Step:            cstypes.RoundStepPropose,
+		LastCommitRound: -1,
+	})
+}
+
+func TestPeerStatePickSendCommit(t *testing.T) {
+	valSet, privVals := types.RandValidatorSet(4, 10)
+	blockID := types.BlockID{Hash: tmhash.Sum([]byte("hash")), PartsHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))}}
+	voteSet := types.NewVoteSet("test_chain_id", 1, 0, types.PrecommitType, valSet)
+	commit, err := types.MakeCommit(blockID, 1, 0, voteSet, privVals)
+	require.NoError(t, err)
+
+	// the peer gets the whole commit at once
+	peer := newLaggingPeer(true)
+	ps := NewPeerState(peer).SetLogger(log.TestingLogger())
+	peer.catchUp(ps, 1)
+	require.True(t, ps.PickSendCommit(commit))
+	msg := <-peer.sent
+	if assert.IsType(t, &CommitMessage{}, msg) {
+		assert.Equal(t, commit.Hash(), msg.(*CommitMessage).Commit.Hash())
+	}
+
+	// then it has all the precommits
+	assert.False(t, ps.PickSendCommit(commit))
+	assert.False(t, ps.PickSendVote(commit))
+	assert.Empty(t, peer.sent)
+
+	// but not the commit of another height
+	assert.False(t, ps.PickSendCommit(nil))
+
+	// older peers get the precommits one by one
+	peer = newLaggingPeer(false)
+	ps = NewPeerState(peer).SetLogger(log.TestingLogger())
+	peer.catchUp(ps, 1)
+	assert.False(t, ps.PickSendCommit(commit))
+	require.True(t, ps.PickSendVote(commit))
+	assert.IsType(t, &VoteMessage{}, <-peer.sent)
+}
+
+// Ensure a peer lagging behind gets the commits of the heights it misses at
+// once.
+func TestReactorSendsCatchupCommit(t *testing.T) {
+	N := 4
+	css, cleanup := randConsensusNet(N, "consensus_reactor_test", newMockTickerFunc(true), newCounter)
+	defer cleanup()
+	reactors, blocksSubs, eventBuses := startConsensusNet(t, css, N)
+	defer stopConsensusNet(log.TestingLogger(), reactors, eventBuses)
+
+	// wait till everyone makes the first 3 blocks
+	timeoutWaitGroup(t, N, func(j int) {
+		for i := 0; i < 3; i++ {
+			<-blocksSubs[j].Out()
+		}
+	}, css)
+
+	for _, height := range []int64{1, 2} {
+		peer := newLaggingPeer(true)
+		reactors[0].AddPeer(peer)
+		ps := peer.Get(types.PeerStateKey).(*PeerState)
+		peer.catchUp(ps, height)
+
+		timeout := time.After(5 * time.Second)
+	WAIT:
+		for {
+			select {
+			case msg := <-peer.sent:
+				if msg, ok := msg.(*CommitMessage); ok {
+					assert.Equal(t, height, msg.Commit.Height())
+					assert.NoError(t, msg.ValidateBasic())
+					break WAIT
+				}
+			case <-timeout:
+				t.Fatalf("Timed out waiting for the commit of height %d", height)
+			}
+		}
+		peer.Stop()
+	}
+}
@@ -11,6 +11,8 @@ import (
 	//"strings"
 	"time"
 
+	"github.com/pkg/errors"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	//auto "github.com/tendermint/tendermint/libs/autofile"
 	cmn "github.com/tendermint/tendermint/libs/common"
@@ -47,6 +49,7 @@ func (cs *ConsensusState) readReplayMessage(msg *TimedWALMessage, newStepSub typ
 	if _, ok := msg.Msg.(EndHeightMessage); ok {
 		return nil
 	}
+	cs.replayTime = msg.Time
 
 	// for logging
 	switch m := msg.Msg.(type) {
@@ -83,6 +86,10 @@ func (cs *ConsensusState) readReplayMessage(msg *TimedWALMessage, newStepSub typ
 			v := msg.Vote
 			cs.Logger.Info("Replay: Vote", "height", v.Height, "round", v.Round, "type", v.Type,
 				"blockID", v.BlockID, "peer", peerID)
+		case *CommitMessage:
+			c := msg.Commit
+			cs.Logger.Info("Replay: Commit", "height", c.Height(), "round", c.Round(),
+				"blockID", c.BlockID, "peer", peerID)
 		}
 
 		cs.handleMsg(m)
@@ -101,7 +108,10 @@ func (cs *ConsensusState) catchupReplay(csHeight int64) error {
 
 	// Set replayMode to true so we don't log signing errors.
 	cs.replayMode = true
-	defer func() { cs.replayMode = false }()
+	defer func() {
+		cs.replayMode = false
+		cs.replayTime = time.Time{}
+	}()
 
 	// Ensure that #ENDHEIGHT for this height doesn't exist.
 	// NOTE: This is just a sanity check. As far as we know things work fine
@@ -203,6 +213,9 @@ type Handshaker struct {
 	genDoc       *types.GenesisDoc
 	logger       log.Logger
 
+	// the diagnostic report of an app hash mismatch is written to this file
+	appHashMismatchFile string
+
 	nBlocks int // number of blocks applied to the state
 }
 
@@ -230,6 +243,13 @@ func (h *Handshaker) SetEventBus(eventBus types.BlockEventPublisher) {
 	h.eventBus = eventBus
 }
 
+// SetAppHashMismatchFile sets the file the diagnostic report of an app hash
+// mismatch during the replay is written to (see sm.AppHashMismatch).
+// If not called, it's only returned.
+func (h *Handshaker) SetAppHashMismatchFile(path string) {
+	h.appHashMismatchFile = path
+}
+
 func (h *Handshaker) NBlocks() int {
 	return h.nBlocks
 }
@@ -238,7 +258,7 @@ func (h *Handshaker) NBlocks() int {
 func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 
 	// Handshake is done via ABCI Info on the query conn.
-	res, err := proxyApp.Query().InfoSync(proxy.RequestInfo)
+	res, err := proxyApp.Query().InfoSync(h.requestInfo())
 	if err != nil {
 		return fmt.Errorf("Error calling Info: %v", err)
 	}
@@ -249,6 +269,10 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 	}
 	appHash := res.LastBlockAppHash
 
+	if err := h.applyInfoConsensusParams(blockHeight, res.ConsensusParams); err != nil {
+		return err
+	}
+
 	h.logger.Info("ABCI Handshake App Info",
 		"height", blockHeight,
 		"hash", fmt.Sprintf("%X", appHash),
@@ -263,7 +287,7 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 	// Replay blocks up to the latest in the blockstore.
 	_, err = h.ReplayBlocks(h.initialState, appHash, blockHeight, proxyApp)
 	if err != nil {
-		return fmt.Errorf("Error on replay: %v", err)
+		return errors.Wrap(err, "Error on replay")
 	}
 
 	h.logger.Info("Completed ABCI Handshake - Tendermint and App are synced",
@@ -274,6 +298,109 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 	return nil
 }
 
+// ResyncApp brings the app back to the state after it restarted, e.g. once
+// the connection to it was lost, replaying the blocks since its last
+// committed height. Unlike Handshake, the blocks after the state aren't
+// applied, and it fails if the app is ahead of the state.
+func (h *Handshaker) ResyncApp(proxyApp proxy.AppConns) error {
+	res, err := proxyApp.Query().InfoSync(h.requestInfo())
+	if err != nil {
+		return errors.Wrap(err, "Error calling Info")
+	}
+
+	stateBlockHeight := h.initialState.LastBlockHeight
+	blockHeight := int64(res.LastBlockHeight)
+	if blockHeight < 0 {
+		return fmt.Errorf("Got a negative last block height (%d) from the app", blockHeight)
+	}
+	if blockHeight > stateBlockHeight {
+		return sm.ErrAppBlockHeightTooHigh{CoreHeight: stateBlockHeight, AppHeight: blockHeight}
+	}
+	if err := h.applyInfoConsensusParams(blockHeight, res.ConsensusParams); err != nil {
+		return err
+	}
+	h.logger.Info("ABCI Resync App Info", "height", blockHeight, "hash", fmt.Sprintf("%X", res.LastBlockAppHash))
+
+	store := h.store
+	h.store = blockStoreAt{store, stateBlockHeight}
+	defer func() { h.store = store }()
+	_, err = h.ReplayBlocks(h.initialState, res.LastBlockAppHash, blockHeight, proxyApp)
+	if err != nil {
+		return errors.Wrap(err, "Error on replay")
+	}
+
+	h.logger.Info("Completed ABCI Resync - Tendermint and App are synced",
+		"appHeight", stateBlockHeight, "appHash", fmt.Sprintf("%X", h.initialState.AppHash))
+	return nil
+}
+
+// requestInfo returns the Info request of the handshake, with the consensus
+// params of the state.
+func (h *Handshaker) requestInfo() abci.RequestInfo {
+	req := proxy.RequestInfo
+	req.ConsensusParams = types.TM2PB.ConsensusParams(&h.initialState.ConsensusParams)
+	return req
+}
+
+// applyInfoConsensusParams applies the overrides of the consensus params the
+// app returned to Info, if Tendermint and the app are both at genesis (they're
+// then sent to InitChain). After genesis, the overrides must not change the
+// params, which only EndBlock updates, at the same height on every node.
+func (h *Handshaker) applyInfoConsensusParams(appBlockHeight int64, overrides *abci.ConsensusParams) error {
+	if overrides == nil {
+		return nil
+	}
+	state := h.initialState
+
+	if appBlockHeight == 0 && state.LastBlockHeight == 0 {
+		params, err := overrideConsensusParams(state.ConsensusParams, overrides, state.Validators)
+		if err != nil {
+			return errors.Wrap(err, "Error applying the consensus params of Info")
+		}
+		h.logger.Info("The app overrode the consensus params at genesis", "params", params)
+		h.initialState.ConsensusParams = params
+		return nil
+	}
+
+	if params := state.ConsensusParams.Update(overrides); !params.Equals(&state.ConsensusParams) {
+		return fmt.Errorf("The app can only override the consensus params at genesis, got %v at height %d",
+			overrides, appBlockHeight)
+	}
+	return nil
+}
+
+// overrideConsensusParams returns the params updated with the non-empty
+// fields of the overrides, if the result is valid and allows the key types of
+// the validators.
+func overrideConsensusParams(params types.ConsensusParams, overrides *abci.ConsensusParams,
+	vals *types.ValidatorSet) (types.ConsensusParams, error) {
+
+	res := params.Update(overrides)
+	if err := res.Validate(); err != nil {
+		return params, err
+	}
+	if vals == nil {
+		return res, nil
+	}
+	for _, val := range vals.Validators {
+		keyType := types.TM2PB.PubKey(val.PubKey).Type
+		if !res.Validator.IsValidPubkeyType(keyType) {
+			return params, fmt.Errorf("Validator %v is using pubkey %s, which the params don't allow", val, keyType)
+		}
+	}
+	return res, nil
+}
+
+// blockStoreAt is a block store without the blocks after the height.
+type blockStoreAt struct {
+	sm.BlockStore
+	height int64
+}
+
+func (bs blockStoreAt) Height() int64 {
+	return bs.height
+}
+
 // Replay all blocks since appBlockHeight and ensure the result matches the current state.
 // Returns the final AppHash or an error.
 func (h *Handshaker) ReplayBlocks(
@@ -295,6 +422,10 @@ func (h *Handshaker) ReplayBlocks(
 		validatorSet := types.NewValidatorSet(validators)
 		nextVals := types.TM2PB.ValidatorUpdates(validatorSet)
 		csParams := types.TM2PB.ConsensusParams(h.genDoc.ConsensusParams)
+		if stateBlockHeight == 0 {
+			// including the overrides of Info
+			csParams = types.TM2PB.ConsensusParams(&state.ConsensusParams)
+		}
 		req := abci.RequestInitChain{
 			Time:            h.genDoc.GenesisTime,
 			ChainId:         h.genDoc.ChainID,
@@ -324,7 +455,11 @@ func (h *Handshaker) ReplayBlocks(
 			}
 
 			if res.ConsensusParams != nil {
-				state.ConsensusParams = types.PB2TM.ConsensusParams(res.ConsensusParams, state.ConsensusParams.Block.TimeIotaMs)
+				params, err := overrideConsensusParams(state.ConsensusParams, res.ConsensusParams, state.Validators)
+				if err != nil {
+					return nil, errors.Wrap(err, "Error applying the consensus params of InitChain")
+				}
+				state.ConsensusParams = params
 			}
 			sm.SaveState(h.stateDB, state)
 		}
@@ -332,7 +467,7 @@ func (h *Handshaker) ReplayBlocks(
 
 	// First handle edge cases and constraints on the storeBlockHeight.
 	if storeBlockHeight == 0 {
-		return appHash, checkAppHash(state, appHash)
+		return appHash, h.checkAppHash(state, appHash, 0, nil)
 
 	} else if storeBlockHeight < appBlockHeight {
 		// the app should never be ahead of the store (but this is under app's control)
@@ -343,8 +478,14 @@ func (h *Handshaker) ReplayBlocks(
 		cmn.PanicSanity(fmt.Sprintf("StateBlockHeight (%d) > StoreBlockHeight (%d)", stateBlockHeight, storeBlockHeight))
 
 	} else if storeBlockHeight > stateBlockHeight+1 {
-		// store should be at most one ahead of the state (this is under tendermint's control)
-		cmn.PanicSanity(fmt.Sprintf("StoreBlockHeight (%d) > StateBlockHeight + 1 (%d)", storeBlockHeight, stateBlockHeight+1))
+		// Tendermint only saves one block ahead of the state, so the others
+		// were imported, see blockchain.ImportBlocks. They're executed if the
+		// app is at the state.
+		if appBlockHeight != stateBlockHeight {
+			cmn.PanicSanity(fmt.Sprintf("StoreBlockHeight (%d) > StateBlockHeight + 1 (%d) and AppBlockHeight (%d) != StateBlockHeight",
+				storeBlockHeight, stateBlockHeight+1, appBlockHeight))
+		}
+		return h.replayImportedBlocks(state, proxyApp, storeBlockHeight)
 	}
 
 	var err error
@@ -355,11 +496,11 @@ func (h *Handshaker) ReplayBlocks(
 		// Either the app is asking for replay, or we're all synced up.
 		if appBlockHeight < storeBlockHeight {
 			// the app is behind, so replay blocks, but no need to go through WAL (state is already synced to store)
-			return h.replayBlocks(state, proxyApp, appBlockHeight, storeBlockHeight, false)
+			return h.replayBlocks(state, proxyApp, appHash, appBlockHeight, storeBlockHeight, false)
 
 		} else if appBlockHeight == storeBlockHeight {
 			// We're good!
-			return appHash, checkAppHash(state, appHash)
+			return appHash, h.checkAppHash(state, appHash, 0, nil)
 		}
 
 	} else if storeBlockHeight == stateBlockHeight+1 {
@@ -368,7 +509,7 @@ func (h *Handshaker) ReplayBlocks(
 		if appBlockHeight < stateBlockHeight {
 			// the app is further behind than it should be, so replay blocks
 			// but leave the last block to go through the WAL
-			return h.replayBlocks(state, proxyApp, appBlockHeight, storeBlockHeight, true)
+			return h.replayBlocks(state, proxyApp, appHash, appBlockHeight, storeBlockHeight, true)
 
 		} else if appBlockHeight == stateBlockHeight {
 			// We haven't run Commit (both the state and app are one block behind),
@@ -397,7 +538,7 @@ func (h *Handshaker) ReplayBlocks(
 	return nil, nil
 }
 
-func (h *Handshaker) replayBlocks(state sm.State, proxyApp proxy.AppConns, appBlockHeight, storeBlockHeight int64, mutateState bool) ([]byte, error) {
+func (h *Handshaker) replayBlocks(state sm.State, proxyApp proxy.AppConns, appHash []byte, appBlockHeight, storeBlockHeight int64, mutateState bool) ([]byte, error) {
 	// App is further behind than it should be, so we need to replay blocks.
 	// We replay all blocks from appBlockHeight+1.
 	//
@@ -407,17 +548,44 @@ func (h *Handshaker) replayBlocks(state sm.State, proxyApp proxy.AppConns, appBl
 	// TODO: Load the historical information to fix this and just use state.ApplyBlock
 	//
 	// If mutateState == true, the final block is replayed with h.replayBlock()
+	//
+	// The app hash after each block is checked against the header of the
+	// next one, to report the first block the app diverged at.
+
+	var abciResponses *sm.ABCIResponses
+	var lastAgreedHeight int64
+	checkAppHashBefore := func(block *types.Block) error {
+		// The app hash before the first block is left to the app.
+		if block.Height == 1 {
+			return nil
+		}
+		if !bytes.Equal(appHash, block.AppHash) {
+			m := &sm.AppHashMismatch{
+				Height:              block.Height - 1,
+				LastAgreedHeight:    lastAgreedHeight,
+				AppHash:             appHash,
+				ExpectedAppHash:     block.AppHash,
+				ExpectedResultsHash: block.LastResultsHash,
+			}
+			return h.appHashMismatch(m, abciResponses)
+		}
+		lastAgreedHeight = block.Height - 1
+		return nil
+	}
 
-	var appHash []byte
 	var err error
 	finalBlock := storeBlockHeight
 	if mutateState {
 		finalBlock--
 	}
 	for i := appBlockHeight + 1; i <= finalBlock; i++ {
-		h.logger.Info("Applying block", "height", i)
 		block := h.store.LoadBlock(i)
-		appHash, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, h.logger, state.LastValidators, h.stateDB)
+		if err := checkAppHashBefore(block); err != nil {
+			return nil, err
+		}
+
+		h.logger.Info("Applying block", "height", i)
+		appHash, abciResponses, err = sm.ExecCommitBlockWithResponses(proxyApp.Consensus(), block, h.logger, state.LastValidators, h.stateDB)
 		if err != nil {
 			return nil, err
 		}
@@ -426,15 +594,32 @@ func (h *Handshaker) replayBlocks(state sm.State, proxyApp proxy.AppConns, appBl
 	}
 
 	if mutateState {
+		if err := checkAppHashBefore(h.store.LoadBlock(storeBlockHeight)); err != nil {
+			return nil, err
+		}
 		// sync the final block
 		state, err = h.replayBlock(state, storeBlockHeight, proxyApp.Consensus())
 		if err != nil {
 			return nil, err
 		}
-		appHash = state.AppHash
+		return state.AppHash, nil
 	}
 
-	return appHash, checkAppHash(state, appHash)
+	return appHash, h.checkAppHash(state, appHash, lastAgreedHeight, abciResponses)
+}
+
+// replayImportedBlocks applies the blocks after the state up to
+// storeBlockHeight on the proxyApp, validating them against the state.
+func (h *Handshaker) replayImportedBlocks(state sm.State, proxyApp proxy.AppConns, storeBlockHeight int64) ([]byte, error) {
+	h.logger.Info("Applying imported blocks", "from", state.LastBlockHeight+1, "to", storeBlockHeight)
+	var err error
+	for height := state.LastBlockHeight + 1; height <= storeBlockHeight; height++ {
+		state, err = h.replayBlock(state, height, proxyApp.Consensus())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return state.AppHash, nil
 }
 
 // ApplyBlock on the proxyApp with the last block.
@@ -442,6 +627,10 @@ func (h *Handshaker) replayBlock(state sm.State, height int64, proxyApp proxy.Ap
 	block := h.store.LoadBlock(height)
 	meta := h.store.LoadBlockMeta(height)
 
+	if m := sm.CheckAppHash(state, block); m != nil {
+		return sm.State{}, h.appHashMismatch(m, nil)
+	}
+
 	blockExec := sm.NewBlockExecutor(h.stateDB, h.logger, proxyApp, sm.MockMempool{}, sm.MockEvidencePool{})
 	blockExec.SetEventBus(h.eventBus)
 
@@ -456,13 +645,44 @@ func (h *Handshaker) replayBlock(state sm.State, height int64, proxyApp proxy.Ap
 	return state, nil
 }
 
-func checkAppHash(state sm.State, appHash []byte) error {
+// checkAppHash reports the app hash after the last block of the state
+// disagreeing with the state's. abciResponses are the responses of the app to
+// the block, if it was just replayed.
+func (h *Handshaker) checkAppHash(state sm.State, appHash []byte, lastAgreedHeight int64, abciResponses *sm.ABCIResponses) error {
 	if !bytes.Equal(state.AppHash, appHash) {
-		panic(fmt.Errorf("Tendermint state.AppHash does not match AppHash after replay. Got %X, expected %X", appHash, state.AppHash).Error())
+		m := &sm.AppHashMismatch{
+			Height:              state.LastBlockHeight,
+			LastAgreedHeight:    lastAgreedHeight,
+			AppHash:             appHash,
+			ExpectedAppHash:     state.AppHash,
+			ExpectedResultsHash: state.LastResultsHash,
+		}
+		return h.appHashMismatch(m, abciResponses)
 	}
 	return nil
 }
 
+// appHashMismatch completes the report of an app hash mismatch with the
+// responses of the app to the block, if known, writes it to the app hash
+// mismatch file and returns it.
+func (h *Handshaker) appHashMismatch(m *sm.AppHashMismatch, abciResponses *sm.ABCIResponses) error {
+	m.Time = time.Now()
+	m.Source = sm.AppHashMismatchReplay
+	if abciResponses != nil {
+		m.SetResponses(h.stateDB, abciResponses)
+	}
+	h.logger.Error("App hash mismatch", "height", m.Height, "appHash", m.AppHash,
+		"expected", m.ExpectedAppHash, "lastAgreedHeight", m.LastAgreedHeight)
+	if h.appHashMismatchFile != "" {
+		if err := sm.SaveAppHashMismatch(h.appHashMismatchFile, m); err != nil {
+			h.logger.Error("Failed to write the app hash mismatch report", "err", err)
+		} else {
+			h.logger.Error("Wrote the app hash mismatch report", "file", h.appHashMismatchFile)
+		}
+	}
+	return m
+}
+
 //--------------------------------------------------------------------------------
 // mockProxyApp uses ABCIResponses to give the right results
 // Useful because we don't want to call Commit() twice for the same block on the real app.
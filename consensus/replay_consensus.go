@@ -0,0 +1,388 @@
+package consensus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	bc "github.com/tendermint/tendermint/blockchain"
+	cfg "github.com/tendermint/tendermint/config"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+//--------------------------------------------------------
+// replay the consensus of the last heights step by step
+
+// ReplayBreakpoint pauses a consensus replay when it enters a height, or a
+// round of a height.
+type ReplayBreakpoint struct {
+	Height int64
+	Round  int // -1 for any round
+}
+
+// ParseReplayBreakpoint parses a breakpoint of the form "height" or
+// "height/round".
+func ParseReplayBreakpoint(s string) (ReplayBreakpoint, error) {
+	bp := ReplayBreakpoint{Round: -1}
+	parts := strings.Split(s, "/")
+	if len(parts) > 2 {
+		return bp, fmt.Errorf("invalid breakpoint %q, expected height or height/round", s)
+	}
+	height, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || height <= 0 {
+		return bp, fmt.Errorf("invalid breakpoint height %q", parts[0])
+	}
+	bp.Height = height
+	if len(parts) == 2 {
+		round, err := strconv.Atoi(parts[1])
+		if err != nil || round < 0 {
+			return bp, fmt.Errorf("invalid breakpoint round %q", parts[1])
+		}
+		bp.Round = round
+	}
+	return bp, nil
+}
+
+func (bp ReplayBreakpoint) String() string {
+	if bp.Round < 0 {
+		return fmt.Sprintf("%d", bp.Height)
+	}
+	return fmt.Sprintf("%d/%d", bp.Height, bp.Round)
+}
+
+func (bp ReplayBreakpoint) matches(height int64, round int) bool {
+	return bp.Height == height && (bp.Round < 0 || bp.Round == round)
+}
+
+// ReplayConsensusOptions configure RunReplayConsensus.
+type ReplayConsensusOptions struct {
+	// Breakpoints pause the replay in a console, once each.
+	Breakpoints []ReplayBreakpoint
+
+	// Dump, if not nil, is written the round state as JSON, one per line,
+	// every time the replay enters a new step.
+	Dump io.Writer
+}
+
+// RunReplayConsensus replays the blocks of the block store against the
+// application, as on start, then the consensus messages of the WAL from the
+// last committed height on, step by step. The WAL messages are handled in
+// order, timeouts included, and the proposals are received at the time they
+// were written, so the replay takes the same steps as the node did. The
+// replay pauses in a console at the breakpoints.
+//
+// NOTE: the node must be stopped. Like a restart, the replay commits the
+// heights the WAL has all the votes of.
+func RunReplayConsensus(config cfg.BaseConfig, csConfig *cfg.ConsensusConfig, logger log.Logger,
+	options ReplayConsensusOptions) error {
+	consensusState, err := newConsensusStateFromStore(config, csConfig, logger)
+	if err != nil {
+		return err
+	}
+	defer consensusState.eventBus.Stop() // nolint: errcheck
+
+	wal, err := NewWAL(csConfig.WalFile())
+	if err != nil {
+		return err
+	}
+	wal.SetLogger(consensusState.Logger.With("wal", csConfig.WalFile()))
+	defer wal.Group().Close() // nolint: errcheck
+
+	cr := &consensusReplayer{
+		cs:      consensusState,
+		options: options,
+		hit:     make(map[ReplayBreakpoint]bool),
+		in:      bufio.NewReader(os.Stdin),
+		out:     os.Stdout,
+	}
+	return cr.replay(wal)
+}
+
+// newConsensusStateFromStore creates a consensus state at the last committed
+// height, after the handshake with the application.
+func newConsensusStateFromStore(config cfg.BaseConfig, csConfig *cfg.ConsensusConfig,
+	logger log.Logger) (*ConsensusState, error) {
+	dbType := dbm.DBBackendType(config.DBBackend)
+	blockStoreDB := dbm.NewDB("blockstore", dbType, config.DBDir())
+	blockStore := bc.NewBlockStore(blockStoreDB)
+
+	stateDB := dbm.NewDB("state", dbType, config.DBDir())
+	gdoc, err := sm.MakeGenesisDocFromFile(config.GenesisFile())
+	if err != nil {
+		return nil, err
+	}
+	state, err := sm.LoadStateFromDBOrGenesisDoc(stateDB, gdoc)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCreator := proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir())
+	proxyApp := proxy.NewAppConns(clientCreator)
+	if err := proxyApp.Start(); err != nil {
+		return nil, errors.Wrap(err, "failed to start proxy app conns")
+	}
+
+	eventBus := types.NewEventBus()
+	if err := eventBus.Start(); err != nil {
+		return nil, errors.Wrap(err, "failed to start event bus")
+	}
+
+	handshaker := NewHandshaker(stateDB, state, blockStore, gdoc)
+	handshaker.SetLogger(logger.With("module", "consensus"))
+	handshaker.SetEventBus(eventBus)
+	if err := handshaker.Handshake(proxyApp); err != nil {
+		return nil, errors.Wrap(err, "error during handshake")
+	}
+	// The handshake may have replayed blocks.
+	state = sm.LoadState(stateDB)
+
+	mempool, evpool := sm.MockMempool{}, sm.MockEvidencePool{}
+	blockExec := sm.NewBlockExecutor(stateDB, logger.With("module", "state"), proxyApp.Consensus(), mempool, evpool)
+
+	consensusState := NewConsensusState(csConfig, state.Copy(), blockExec,
+		blockStore, mempool, evpool)
+	consensusState.SetLogger(logger.With("module", "consensus"))
+	consensusState.SetEventBus(eventBus)
+	consensusState.SetTimeoutTicker(replayTicker{})
+	return consensusState, nil
+}
+
+//------------------------------------------------
+// step by step replay
+
+// replayTicker drops the timeouts scheduled during a replay, as the WAL has
+// the timeouts which fired.
+type replayTicker struct{}
+
+var _ TimeoutTicker = replayTicker{}
+
+func (replayTicker) Start() error                   { return nil }
+func (replayTicker) Stop() error                    { return nil }
+func (replayTicker) Chan() <-chan timeoutInfo       { return nil }
+func (replayTicker) ScheduleTimeout(ti timeoutInfo) {}
+func (replayTicker) SetLogger(log.Logger)           {}
+
+// replayPause is when the replay pauses next, besides the breakpoints.
+type replayPause int
+
+const (
+	pauseNever   replayPause = iota // at the breakpoints only
+	pauseMessage                    // after the next n messages
+	pauseStep                       // at the next step
+)
+
+type consensusReplayer struct {
+	cs      *ConsensusState
+	options ReplayConsensusOptions
+
+	in  *bufio.Reader
+	out io.Writer
+
+	count   int                       // messages replayed
+	pause   replayPause               // when to pause next
+	nextN   int                       // messages to replay before pausing, for pauseMessage
+	hit     map[ReplayBreakpoint]bool // breakpoints already paused at
+	lastHRS string                    // height/round/step of the last step entered
+}
+
+func (cr *consensusReplayer) replay(wal *baseWAL) error {
+	cs := cr.cs
+	cs.replayMode = true
+	defer func() {
+		cs.replayMode = false
+		cs.replayTime = time.Time{}
+	}()
+
+	csHeight := cs.Height
+	gr, found, err := wal.SearchForEndHeight(csHeight-1, &WALSearchOptions{})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("cannot replay height %d, the WAL does not contain #ENDHEIGHT for %d", csHeight, csHeight-1)
+	}
+	defer gr.Close() // nolint: errcheck
+
+	cs.Logger.Info("Replaying consensus messages", "height", csHeight)
+	dec := NewWALDecoder(gr)
+	if quit, err := cr.afterMessage(); quit || err != nil {
+		return err
+	}
+	for {
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if err := cs.readReplayMessage(msg, nil); err != nil {
+			return err
+		}
+		cr.count++
+		if quit, err := cr.afterMessage(); quit || err != nil {
+			return err
+		}
+	}
+	rs := cs.GetRoundState()
+	cs.Logger.Info("Replay: Done", "messages", cr.count, "height", rs.Height, "round", rs.Round, "step", rs.Step)
+	return nil
+}
+
+// afterMessage dumps the round state if a new step was entered, and pauses
+// in the console if needed. It returns true if the replay is to stop.
+func (cr *consensusReplayer) afterMessage() (bool, error) {
+	rs := cr.cs.GetRoundState()
+	hrs := fmt.Sprintf("%v/%v/%v", rs.Height, rs.Round, rs.Step)
+	newStep := hrs != cr.lastHRS
+	cr.lastHRS = hrs
+
+	pause := false
+	if newStep {
+		if err := cr.dump(); err != nil {
+			return true, err
+		}
+		if cr.pause == pauseStep {
+			pause = true
+		}
+		for _, bp := range cr.options.Breakpoints {
+			if !cr.hit[bp] && bp.matches(rs.Height, rs.Round) {
+				cr.hit[bp] = true
+				fmt.Fprintf(cr.out, "Breakpoint %v\n", bp)
+				pause = true
+			}
+		}
+	}
+	if cr.pause == pauseMessage {
+		cr.nextN--
+		if cr.nextN <= 0 {
+			pause = true
+		}
+	}
+	if !pause {
+		return false, nil
+	}
+	fmt.Fprintf(cr.out, "%v (%d messages)\n", hrs, cr.count)
+	return cr.consoleLoop(), nil
+}
+
+func (cr *consensusReplayer) dump() error {
+	if cr.options.Dump == nil {
+		return nil
+	}
+	bz, err := cr.cs.GetRoundStateJSON()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(cr.options.Dump, string(bz))
+	return err
+}
+
+// consoleLoop reads commands until the replay is resumed. It returns true if
+// the replay is to stop.
+func (cr *consensusReplayer) consoleLoop() bool {
+	for {
+		fmt.Fprintf(cr.out, "> ")
+		line, err := cr.in.ReadString('\n')
+		if err != nil {
+			// No more input, replay the rest.
+			cr.pause = pauseNever
+			return false
+		}
+
+		tokens := strings.Fields(line)
+		if len(tokens) == 0 {
+			continue
+		}
+
+		switch tokens[0] {
+		case "next":
+			// "next" -> replay next message
+			// "next N" -> replay next N messages
+
+			n := 1
+			if len(tokens) > 1 {
+				if n, err = strconv.Atoi(tokens[1]); err != nil || n <= 0 {
+					fmt.Fprintln(cr.out, "next takes a positive integer argument")
+					continue
+				}
+			}
+			cr.pause, cr.nextN = pauseMessage, n
+			return false
+
+		case "step":
+			// "step" -> replay until the next step
+
+			cr.pause = pauseStep
+			return false
+
+		case "continue":
+			// "continue" -> replay until the next breakpoint
+
+			cr.pause = pauseNever
+			return false
+
+		case "quit":
+			return true
+
+		case "rs":
+			// "rs" -> print entire round state
+			// "rs short" -> print height/round/step
+			// "rs <field>" -> print another field of the round state
+
+			cr.printRoundState(tokens[1:])
+
+		case "dump":
+			bz, err := cr.cs.GetRoundStateJSON()
+			if err != nil {
+				fmt.Fprintln(cr.out, "failed to marshal the round state:", err)
+				continue
+			}
+			fmt.Fprintln(cr.out, string(bz))
+
+		case "n":
+			fmt.Fprintln(cr.out, cr.count)
+
+		default:
+			fmt.Fprintln(cr.out, "Unknown command", tokens[0],
+				"(next [N], step, continue, rs [field], dump, n, quit)")
+		}
+	}
+}
+
+// printRoundState prints the round state, or the field of it named by the
+// args of the "rs" console command.
+func (cr *consensusReplayer) printRoundState(args []string) {
+	rs := cr.cs.GetRoundState()
+	if len(args) == 0 {
+		fmt.Fprintln(cr.out, rs)
+		return
+	}
+	switch args[0] {
+	case "short":
+		fmt.Fprintf(cr.out, "%v/%v/%v\n", rs.Height, rs.Round, rs.Step)
+	case "validators":
+		fmt.Fprintln(cr.out, rs.Validators)
+	case "proposal":
+		fmt.Fprintln(cr.out, rs.Proposal)
+	case "proposal_block":
+		fmt.Fprintf(cr.out, "%v %v\n", rs.ProposalBlockParts.StringShort(), rs.ProposalBlock.StringShort())
+	case "locked_round":
+		fmt.Fprintln(cr.out, rs.LockedRound)
+	case "locked_block":
+		fmt.Fprintf(cr.out, "%v %v\n", rs.LockedBlockParts.StringShort(), rs.LockedBlock.StringShort())
+	case "votes":
+		fmt.Fprintln(cr.out, rs.Votes.StringIndented("  "))
+	default:
+		fmt.Fprintln(cr.out, "Unknown option", args[0])
+	}
+}
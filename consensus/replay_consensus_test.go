@@ -0,0 +1,72 @@
+package consensus
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReplayBreakpoint(t *testing.T) {
+	testCases := []struct {
+		s     string
+		bp    ReplayBreakpoint
+		valid bool
+	}{
+		{"12", ReplayBreakpoint{12, -1}, true},
+		{"12/0", ReplayBreakpoint{12, 0}, true},
+		{"12/3", ReplayBreakpoint{12, 3}, true},
+		{"", ReplayBreakpoint{}, false},
+		{"0", ReplayBreakpoint{}, false},
+		{"-1", ReplayBreakpoint{}, false},
+		{"12/", ReplayBreakpoint{}, false},
+		{"12/-1", ReplayBreakpoint{}, false},
+		{"12/3/4", ReplayBreakpoint{}, false},
+		{"a/3", ReplayBreakpoint{}, false},
+	}
+	for _, tc := range testCases {
+		bp, err := ParseReplayBreakpoint(tc.s)
+		if !tc.valid {
+			assert.Error(t, err, tc.s)
+			continue
+		}
+		require.NoError(t, err, tc.s)
+		assert.Equal(t, tc.bp, bp)
+		assert.Equal(t, tc.s, bp.String())
+	}
+
+	bp := ReplayBreakpoint{12, -1}
+	assert.True(t, bp.matches(12, 0))
+	assert.True(t, bp.matches(12, 5))
+	assert.False(t, bp.matches(11, 0))
+	bp = ReplayBreakpoint{12, 2}
+	assert.True(t, bp.matches(12, 2))
+	assert.False(t, bp.matches(12, 1))
+}
+
+func TestReplayConsoleLoop(t *testing.T) {
+	cr := &consensusReplayer{
+		in:  bufio.NewReader(strings.NewReader("\nbogus\nnext x\nnext 3\nstep\ncontinue\nquit\n")),
+		out: new(bytes.Buffer),
+	}
+
+	assert.False(t, cr.consoleLoop())
+	assert.Equal(t, pauseMessage, cr.pause)
+	assert.Equal(t, 3, cr.nextN)
+
+	assert.False(t, cr.consoleLoop())
+	assert.Equal(t, pauseStep, cr.pause)
+
+	assert.False(t, cr.consoleLoop())
+	assert.Equal(t, pauseNever, cr.pause)
+
+	assert.True(t, cr.consoleLoop())
+
+	// Without input, the replay goes on.
+	cr.pause = pauseStep
+	assert.False(t, cr.consoleLoop())
+	assert.Equal(t, pauseNever, cr.pause)
+}
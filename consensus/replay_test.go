@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -19,6 +20,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto"
+	cmn "github.com/tendermint/tendermint/libs/common"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/privval"
@@ -315,6 +317,190 @@ func TestHandshakeReplayNone(t *testing.T) {
 	}
 }
 
+// Sync the app and state with blocks imported into the store
+func TestHandshakeReplayImported(t *testing.T) {
+	config := ResetConfig(t.Name())
+	defer os.RemoveAll(config.RootDir)
+
+	walBody, err := WALWithNBlocks(t, NUM_BLOCKS)
+	require.NoError(t, err)
+	walFile := tempWALWithData(walBody)
+	config.Consensus.SetWalFile(walFile)
+
+	privVal := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+
+	wal, err := NewWAL(walFile)
+	require.NoError(t, err)
+	wal.SetLogger(log.TestingLogger())
+	err = wal.Start()
+	require.NoError(t, err)
+	defer wal.Stop()
+
+	chain, commits, err := makeBlockchainFromWAL(wal)
+	require.NoError(t, err)
+
+	// the state of a node which synced the whole chain
+	syncedStateDB, syncedState, _ := stateAndStore(config, privVal.GetPubKey(), kvstore.ProtocolVersion)
+	syncedState = buildTMStateFromChain(config, syncedStateDB, syncedState, chain, 0)
+
+	// a fresh node whose store has all blocks
+	stateDB, state, store := stateAndStore(config, privVal.GetPubKey(), kvstore.ProtocolVersion)
+	store.chain = chain
+	store.commits = commits
+
+	genDoc, _ := sm.MakeGenesisDocFromFile(config.GenesisFile())
+	handshaker := NewHandshaker(stateDB, state, store, genDoc)
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(
+		kvstore.NewPersistentKVStoreApplication(path.Join(config.DBDir(), "2"))))
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop()
+	require.NoError(t, handshaker.Handshake(proxyApp))
+
+	res, err := proxyApp.Query().InfoSync(abci.RequestInfo{Version: ""})
+	require.NoError(t, err)
+	assert.Equal(t, syncedState.AppHash, []byte(res.LastBlockAppHash))
+	assert.Equal(t, NUM_BLOCKS, handshaker.NBlocks())
+
+	state = sm.LoadState(stateDB)
+	assert.Equal(t, int64(NUM_BLOCKS), state.LastBlockHeight)
+	assert.Equal(t, syncedState.AppHash, state.AppHash)
+}
+
+// Resync an app which restarted from scratch, without the block after the state
+func TestHandshakerResyncApp(t *testing.T) {
+	config := ResetConfig(t.Name())
+	defer os.RemoveAll(config.RootDir)
+
+	walBody, err := WALWithNBlocks(t, NUM_BLOCKS)
+	require.NoError(t, err)
+	walFile := tempWALWithData(walBody)
+	config.Consensus.SetWalFile(walFile)
+
+	privVal := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+
+	wal, err := NewWAL(walFile)
+	require.NoError(t, err)
+	wal.SetLogger(log.TestingLogger())
+	err = wal.Start()
+	require.NoError(t, err)
+	defer wal.Stop()
+
+	chain, commits, err := makeBlockchainFromWAL(wal)
+	require.NoError(t, err)
+
+	// the last block is saved, but not applied
+	stateDB, state, store := stateAndStore(config, privVal.GetPubKey(), kvstore.ProtocolVersion)
+	store.chain = chain
+	store.commits = commits
+	state = buildTMStateFromChain(config, stateDB, state, chain, 1)
+
+	genDoc, _ := sm.MakeGenesisDocFromFile(config.GenesisFile())
+	handshaker := NewHandshaker(stateDB, state, store, genDoc)
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(
+		kvstore.NewPersistentKVStoreApplication(path.Join(config.DBDir(), "2"))))
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop()
+	require.NoError(t, handshaker.ResyncApp(proxyApp))
+
+	res, err := proxyApp.Query().InfoSync(abci.RequestInfo{Version: ""})
+	require.NoError(t, err)
+	assert.Equal(t, state.LastBlockHeight, res.LastBlockHeight)
+	assert.Equal(t, state.AppHash, []byte(res.LastBlockAppHash))
+	assert.Equal(t, int(state.LastBlockHeight), handshaker.NBlocks())
+
+	// An app ahead of the state can't be resynced.
+	state.LastBlockHeight--
+	err = NewHandshaker(stateDB, state, store, genDoc).ResyncApp(proxyApp)
+	assert.IsType(t, sm.ErrAppBlockHeightTooHigh{}, errors.Cause(err))
+}
+
+// divergingApp diverges from the chain at the height: its BeginBlock
+// response and app hash differ.
+type divergingApp struct {
+	*kvstore.PersistentKVStoreApplication
+	height, divergeHeight int64
+}
+
+func (app *divergingApp) BeginBlock(req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	app.height = req.Header.Height
+	res := app.PersistentKVStoreApplication.BeginBlock(req)
+	if app.height == app.divergeHeight {
+		res.Tags = append(res.Tags, cmn.KVPair{Key: []byte("diverged"), Value: []byte("true")})
+	}
+	return res
+}
+
+func (app *divergingApp) Commit() abci.ResponseCommit {
+	res := app.PersistentKVStoreApplication.Commit()
+	if app.height == app.divergeHeight {
+		res.Data = append([]byte("diverged"), res.Data...)
+	}
+	return res
+}
+
+// Replay the blocks on an app which diverges from the chain
+func TestHandshakeReplayAppHashMismatch(t *testing.T) {
+	config := ResetConfig(t.Name())
+	defer os.RemoveAll(config.RootDir)
+
+	walBody, err := WALWithNBlocks(t, NUM_BLOCKS)
+	require.NoError(t, err)
+	walFile := tempWALWithData(walBody)
+	config.Consensus.SetWalFile(walFile)
+
+	privVal := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+
+	wal, err := NewWAL(walFile)
+	require.NoError(t, err)
+	wal.SetLogger(log.TestingLogger())
+	err = wal.Start()
+	require.NoError(t, err)
+	defer wal.Stop()
+
+	chain, commits, err := makeBlockchainFromWAL(wal)
+	require.NoError(t, err)
+
+	stateDB, state, store := stateAndStore(config, privVal.GetPubKey(), kvstore.ProtocolVersion)
+	store.chain = chain
+	store.commits = commits
+	state = buildTMStateFromChain(config, stateDB, state, chain, 0)
+
+	genDoc, _ := sm.MakeGenesisDocFromFile(config.GenesisFile())
+	handshaker := NewHandshaker(stateDB, state, store, genDoc)
+	reportFile := path.Join(config.DBDir(), "app_hash_mismatch.json")
+	handshaker.SetAppHashMismatchFile(reportFile)
+	app := &divergingApp{
+		PersistentKVStoreApplication: kvstore.NewPersistentKVStoreApplication(path.Join(config.DBDir(), "2")),
+		divergeHeight:                3,
+	}
+	proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop()
+
+	// The app hash after the block is checked against the header of the next
+	// one, and its responses against the ones of the first execution.
+	err = handshaker.Handshake(proxyApp)
+	require.IsType(t, &sm.AppHashMismatch{}, errors.Cause(err))
+	report := errors.Cause(err).(*sm.AppHashMismatch)
+	assert.Equal(t, sm.AppHashMismatchReplay, report.Source)
+	assert.EqualValues(t, 3, report.Height)
+	assert.EqualValues(t, 2, report.LastAgreedHeight)
+	assert.Equal(t, chain[3].AppHash, report.ExpectedAppHash)
+	assert.NotEqual(t, report.ExpectedAppHash, report.AppHash)
+	assert.Equal(t, chain[3].LastResultsHash, report.ResultsHash)
+	if assert.Len(t, report.ResponsesDiff, 1) {
+		assert.Equal(t, "begin_block", report.ResponsesDiff[0].Method)
+		assert.Contains(t, report.ResponsesDiff[0].Got, "diverged")
+	}
+	assert.Equal(t, 3, handshaker.NBlocks())
+
+	saved, err := sm.LoadAppHashMismatch(reportFile)
+	require.NoError(t, err)
+	assert.Equal(t, report.Height, saved.Height)
+	assert.Equal(t, report.AppHash, saved.AppHash)
+	assert.Equal(t, report.ResponsesDiff, saved.ResponsesDiff)
+}
+
 func tempWALWithData(data []byte) string {
 	walFile, err := ioutil.TempFile("", "wal")
 	if err != nil {
@@ -687,3 +873,70 @@ func (ica *initChainApp) InitChain(req abci.RequestInitChain) abci.ResponseInitC
 		Validators: ica.vals,
 	}
 }
+
+func TestHandshakeConsensusParamsOverrides(t *testing.T) {
+	config := ResetConfig("handshake_params_test_")
+	defer os.RemoveAll(config.RootDir)
+	privVal := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	genDoc, _ := sm.MakeGenesisDocFromFile(config.GenesisFile())
+
+	handshake := func(app *paramsApp, lastBlockHeight int64) (sm.State, error) {
+		stateDB, state, store := stateAndStore(config, privVal.GetPubKey(), 0x0)
+		state.LastBlockHeight = lastBlockHeight
+		proxyApp := proxy.NewAppConns(proxy.NewLocalClientCreator(app))
+		require.NoError(t, proxyApp.Start())
+		defer proxyApp.Stop()
+		err := NewHandshaker(stateDB, state, store, genDoc).Handshake(proxyApp)
+		return sm.LoadState(stateDB), err
+	}
+
+	// At genesis, the overrides of Info are sent to InitChain, whose
+	// overrides are applied on top.
+	app := &paramsApp{
+		info:      &abci.ConsensusParams{Block: &abci.BlockParams{MaxBytes: 2 * 1024 * 1024, MaxGas: 10}},
+		initChain: &abci.ConsensusParams{Timeout: &abci.TimeoutParams{ProposeDelta: time.Second}},
+	}
+	state, err := handshake(app, 0)
+	require.NoError(t, err)
+	assert.Equal(t, types.TM2PB.ConsensusParams(genDoc.ConsensusParams), app.infoReq.ConsensusParams)
+	assert.EqualValues(t, 2*1024*1024, app.initChainReq.ConsensusParams.Block.MaxBytes)
+	assert.EqualValues(t, 2*1024*1024, state.ConsensusParams.Block.MaxBytes)
+	assert.EqualValues(t, 10, state.ConsensusParams.Block.MaxGas)
+	assert.Equal(t, time.Second, state.ConsensusParams.Timeout.ProposeDelta)
+	assert.Equal(t, genDoc.ConsensusParams.Evidence, state.ConsensusParams.Evidence)
+	assert.Equal(t, genDoc.ConsensusParams.Synchrony, state.ConsensusParams.Synchrony)
+
+	// Invalid overrides
+	_, err = handshake(&paramsApp{info: &abci.ConsensusParams{Block: &abci.BlockParams{MaxBytes: 0}}}, 0)
+	assert.Error(t, err)
+	_, err = handshake(&paramsApp{initChain: &abci.ConsensusParams{
+		Validator: &abci.ValidatorParams{PubKeyTypes: []string{types.ABCIPubKeyTypeSecp256k1}},
+	}}, 0)
+	assert.Error(t, err, "the validator's key type isn't allowed")
+
+	// After genesis, the params can't be overridden
+	_, err = handshake(&paramsApp{info: &abci.ConsensusParams{Block: &abci.BlockParams{MaxBytes: 2 * 1024 * 1024}}}, 1)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "only override the consensus params at genesis")
+	}
+}
+
+// overrides the consensus params on Info and InitChain
+type paramsApp struct {
+	abci.BaseApplication
+	info      *abci.ConsensusParams
+	initChain *abci.ConsensusParams
+
+	infoReq      abci.RequestInfo
+	initChainReq abci.RequestInitChain
+}
+
+func (app *paramsApp) Info(req abci.RequestInfo) abci.ResponseInfo {
+	app.infoReq = req
+	return abci.ResponseInfo{ConsensusParams: app.info}
+}
+
+func (app *paramsApp) InitChain(req abci.RequestInitChain) abci.ResponseInitChain {
+	app.initChainReq = req
+	return abci.ResponseInitChain{ConsensusParams: app.initChain}
+}
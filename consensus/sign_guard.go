@@ -0,0 +1,183 @@
+package consensus
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/tendermint/crypto"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Steps of the signed messages, as ordered within a round.
+const (
+	signStepPropose   int8 = 1
+	signStepPrevote   int8 = 2
+	signStepPrecommit int8 = 3
+)
+
+// Reasons for refusing to sign, see Metrics.SignRefusals.
+const (
+	signRefusalRegression   = "regression"
+	signRefusalConflict     = "conflict"
+	signRefusalBadSignature = "bad_signature"
+	signRefusalState        = "state"
+)
+
+func signStep(type_ types.SignedMsgType) int8 {
+	switch type_ {
+	case types.ProposalType:
+		return signStepPropose
+	case types.PrevoteType:
+		return signStepPrevote
+	case types.PrecommitType:
+		return signStepPrecommit
+	default:
+		panic(fmt.Sprintf("Unknown signed msg type %v", type_))
+	}
+}
+
+// signGuardError is returned by the signGuard when signing would make the
+// node double sign.
+type signGuardError struct {
+	reason string
+	err    error
+}
+
+func (e signGuardError) Error() string {
+	return fmt.Sprintf("refusing to sign (%s): %v", e.reason, e.err)
+}
+
+// lastSignedState is the last proposal or vote signed by the node.
+type lastSignedState struct {
+	Height    int64         `json:"height"`
+	Round     int           `json:"round"`
+	Step      int8          `json:"step"`
+	BlockID   types.BlockID `json:"block_id"`
+	Signature []byte        `json:"signature,omitempty"`
+}
+
+// signGuard keeps the node from signing conflicting proposals and votes,
+// independently of the protection of the PrivValidator, which may be a
+// remote signer we can't trust to have one. It persists the last height,
+// round and step signed, with the block signed, and refuses to sign:
+//
+// - at a lower height, round or step,
+// - a different block (or nil) at the same height, round and step.
+//
+// It also checks the signatures returned by the PrivValidator.
+//
+// The last signed state is only kept in memory if the file path is empty. A
+// nil signGuard signs anything.
+//
+// NOTE: Not thread safe. Only used by functions downstream of the
+// cs.receiveRoutine.
+type signGuard struct {
+	filePath string
+	loaded   bool
+	last     lastSignedState
+}
+
+func newSignGuard(filePath string) *signGuard {
+	return &signGuard{filePath: filePath}
+}
+
+func (sg *signGuard) load() error {
+	if sg.loaded {
+		return nil
+	}
+	if sg.filePath != "" {
+		bz, err := ioutil.ReadFile(sg.filePath)
+		switch {
+		case os.IsNotExist(err):
+		case err != nil:
+			return errors.Wrap(err, "failed to read the last signed state")
+		default:
+			if err := cdc.UnmarshalJSON(bz, &sg.last); err != nil {
+				return errors.Wrapf(err, "failed to parse the last signed state in %s", sg.filePath)
+			}
+		}
+	}
+	sg.loaded = true
+	return nil
+}
+
+// check returns an error if signing the block (or nil) at the height, round
+// and step would conflict with the last signed state.
+func (sg *signGuard) check(height int64, round int, step int8, blockID types.BlockID) error {
+	if sg == nil {
+		return nil
+	}
+	if err := sg.load(); err != nil {
+		return signGuardError{signRefusalState, err}
+	}
+	last := sg.last
+	switch cmp := compareSignHRS(height, round, step, last.Height, last.Round, last.Step); {
+	case cmp < 0:
+		return signGuardError{signRefusalRegression, fmt.Errorf(
+			"%v/%v/%v is before the last signed %v/%v/%v", height, round, step, last.Height, last.Round, last.Step)}
+	case cmp == 0 && !blockID.Equals(last.BlockID):
+		return signGuardError{signRefusalConflict, fmt.Errorf(
+			"already signed %v at %v/%v/%v, not signing %v", last.BlockID, height, round, step, blockID)}
+	}
+	return nil
+}
+
+// record checks the signature the PrivValidator returned for the sign bytes,
+// and persists the block signed at the height, round and step as the last
+// signed state. The message mustn't be sent if it fails.
+func (sg *signGuard) record(height int64, round int, step int8, blockID types.BlockID,
+	pubKey crypto.PubKey, signBytes, signature []byte) error {
+	if sg == nil {
+		return nil
+	}
+	if err := sg.check(height, round, step, blockID); err != nil {
+		return err
+	}
+	if !pubKey.VerifyBytes(signBytes, signature) {
+		return signGuardError{signRefusalBadSignature, fmt.Errorf(
+			"the signature of %v at %v/%v/%v doesn't match our public key", blockID, height, round, step)}
+	}
+	next := lastSignedState{
+		Height:    height,
+		Round:     round,
+		Step:      step,
+		BlockID:   blockID,
+		Signature: signature,
+	}
+	if sg.filePath != "" {
+		bz, err := cdc.MarshalJSONIndent(next, "", "  ")
+		if err != nil {
+			return signGuardError{signRefusalState, err}
+		}
+		if err := cmn.WriteFileAtomic(sg.filePath, bz, 0600); err != nil {
+			return signGuardError{signRefusalState, errors.Wrap(err, "failed to save the last signed state")}
+		}
+	}
+	sg.last = next
+	return nil
+}
+
+func compareSignHRS(h1 int64, r1 int, s1 int8, h2 int64, r2 int, s2 int8) int {
+	switch {
+	case h1 != h2:
+		if h1 < h2 {
+			return -1
+		}
+		return 1
+	case r1 != r2:
+		if r1 < r2 {
+			return -1
+		}
+		return 1
+	case s1 != s2:
+		if s1 < s2 {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,97 @@
+package consensus
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/types"
+)
+
+func testSignBlockID(s string) types.BlockID {
+	return types.BlockID{Hash: tmhash.Sum([]byte(s)), PartsHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte(s))}}
+}
+
+func assertSignRefusal(t *testing.T, reason string, err error) {
+	if assert.IsType(t, signGuardError{}, err) {
+		assert.Equal(t, reason, err.(signGuardError).reason)
+	}
+}
+
+func TestSignGuard(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	sign := func(sg *signGuard, height int64, round int, step int8, blockID types.BlockID) error {
+		signBytes := []byte(blockID.String())
+		sig, err := privKey.Sign(signBytes)
+		require.NoError(t, err)
+		return sg.record(height, round, step, blockID, privKey.PubKey(), signBytes, sig)
+	}
+	blockA, blockB := testSignBlockID("a"), testSignBlockID("b")
+
+	sg := newSignGuard("")
+	require.NoError(t, sign(sg, 2, 1, signStepPrevote, blockA))
+
+	// Signing the same block again is fine, e.g. on restart.
+	assert.NoError(t, sg.check(2, 1, signStepPrevote, blockA))
+
+	// But not another one, or nil.
+	assertSignRefusal(t, signRefusalConflict, sg.check(2, 1, signStepPrevote, blockB))
+	assertSignRefusal(t, signRefusalConflict, sg.check(2, 1, signStepPrevote, types.BlockID{}))
+
+	// Nor anything before.
+	assertSignRefusal(t, signRefusalRegression, sg.check(2, 1, signStepPropose, blockA))
+	assertSignRefusal(t, signRefusalRegression, sg.check(2, 0, signStepPrecommit, blockA))
+	assertSignRefusal(t, signRefusalRegression, sg.check(1, 5, signStepPrecommit, blockA))
+
+	// Anything after is fine.
+	assert.NoError(t, sg.check(2, 1, signStepPrecommit, blockB))
+	assert.NoError(t, sg.check(2, 2, signStepPropose, blockB))
+	assert.NoError(t, sg.check(3, 0, signStepPropose, blockB))
+
+	// A bad signature isn't recorded.
+	otherKey := ed25519.GenPrivKey()
+	sig, err := otherKey.Sign([]byte(blockB.String()))
+	require.NoError(t, err)
+	err = sg.record(2, 1, signStepPrecommit, blockB, privKey.PubKey(), []byte(blockB.String()), sig)
+	assertSignRefusal(t, signRefusalBadSignature, err)
+	assert.NoError(t, sg.check(2, 1, signStepPrecommit, types.BlockID{}))
+
+	// A nil guard signs anything.
+	var nilGuard *signGuard
+	assert.NoError(t, nilGuard.check(1, 0, signStepPrevote, blockA))
+	assert.NoError(t, nilGuard.record(1, 0, signStepPrevote, blockA, privKey.PubKey(), nil, nil))
+}
+
+func TestSignGuardPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sign_guard")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, "cs_sign_state.json")
+
+	privKey := ed25519.GenPrivKey()
+	blockA, blockB := testSignBlockID("a"), testSignBlockID("b")
+	signBytes := []byte(blockA.String())
+	sig, err := privKey.Sign(signBytes)
+	require.NoError(t, err)
+
+	sg := newSignGuard(filePath)
+	require.NoError(t, sg.check(5, 0, signStepPrecommit, blockA))
+	require.NoError(t, sg.record(5, 0, signStepPrecommit, blockA, privKey.PubKey(), signBytes, sig))
+
+	// After a restart.
+	sg = newSignGuard(filePath)
+	assertSignRefusal(t, signRefusalConflict, sg.check(5, 0, signStepPrecommit, blockB))
+	assertSignRefusal(t, signRefusalRegression, sg.check(5, 0, signStepPrevote, blockA))
+	assert.NoError(t, sg.check(5, 1, signStepPropose, blockB))
+
+	// A corrupted state refuses to sign.
+	require.NoError(t, ioutil.WriteFile(filePath, []byte("{"), 0600))
+	sg = newSignGuard(filePath)
+	assertSignRefusal(t, signRefusalState, sg.check(6, 0, signStepPropose, blockB))
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	"github.com/tendermint/tendermint/libs/fail"
 	"github.com/tendermint/tendermint/libs/log"
@@ -19,6 +20,7 @@ import (
 	cstypes "github.com/tendermint/tendermint/consensus/types"
 	tmevents "github.com/tendermint/tendermint/libs/events"
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/proxy"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
 )
@@ -37,6 +39,9 @@ var (
 
 var (
 	msgQueueSize = 1000
+
+	// how often to retry resyncing the app while the connection to it is lost
+	appResyncRetryInterval = time.Second
 )
 
 // msgs from the reactor which may update the state
@@ -77,6 +82,7 @@ type ConsensusState struct {
 	// config details
 	config        *cfg.ConsensusConfig
 	privValidator types.PrivValidator // for signing votes
+	signGuard     *signGuard          // so we don't double sign, even if the privValidator does
 
 	// store blocks and commits
 	blockStore sm.BlockStore
@@ -86,6 +92,8 @@ type ConsensusState struct {
 
 	// notify us if txs are available
 	txNotifier txNotifier
+	// txs became available before round 0 of the height started
+	txsAvailable bool
 
 	// add evidence to the pool
 	// when it's detected
@@ -102,6 +110,10 @@ type ConsensusState struct {
 	internalMsgQueue chan msgInfo
 	timeoutTicker    TimeoutTicker
 
+	// timeouts adapted to the observed step durations, nil unless
+	// config.AdaptiveTimeouts is set
+	adaptiveTimeouts *adaptiveTimeouts
+
 	// information about about added votes and block parts are written on this channel
 	// so statistics can be computed by reactor
 	statsMsgQueue chan msgInfo
@@ -113,8 +125,15 @@ type ConsensusState struct {
 	// a Write-Ahead Log ensures we can recover from any kind of crash
 	// and helps us avoid signing conflicting votes
 	wal          WAL
-	replayMode   bool // so we don't log signing errors during replay
-	doWALCatchup bool // determines if we even try to do the catchup
+	replayMode   bool      // so we don't log signing errors during replay
+	replayTime   time.Time // when the message being replayed was written to the WAL
+	doWALCatchup bool      // determines if we even try to do the catchup
+
+	// trace events of the state machine, see trace.go
+	tracers traceHub
+
+	// the proposal we signed at the current height, if any
+	ownProposal *types.Proposal
 
 	// for tests where we want to limit the number of transitions the state makes
 	nSteps int
@@ -133,6 +152,15 @@ type ConsensusState struct {
 
 	// for reporting metrics
 	metrics *Metrics
+
+	// if true, don't kill the process when the app is unresponsive, the node
+	// halts consensus instead
+	haltOnUnresponsiveApp bool
+
+	// resyncs the app with the state once it reconnected, nil unless the
+	// connections to the app reconnect
+	resyncApp      func(state sm.State) error
+	appReconnected <-chan struct{}
 }
 
 // StateOption sets an optional parameter on the ConsensusState.
@@ -169,11 +197,21 @@ func NewConsensusState(
 	cs.doPrevote = cs.defaultDoPrevote
 	cs.setProposal = cs.defaultSetProposal
 
+	if config.AdaptiveTimeouts {
+		cs.adaptiveTimeouts = newAdaptiveTimeouts(config, state.ConsensusParams.Timeout)
+	}
+	cs.signGuard = newSignGuard(config.SignStateFile())
+
 	cs.updateToState(state)
 
 	// Don't call scheduleRound0 yet.
 	// We do that upon Start().
-	cs.reconstructLastCommit(state)
+	// When fast syncing from a trusted snapshot the store doesn't have the
+	// commit of the state's last block yet. It's reconstructed on
+	// SwitchToConsensus.
+	if blockStore.Height() >= state.LastBlockHeight {
+		cs.reconstructLastCommit(state)
+	}
 	cs.BaseService = *cmn.NewBaseService(nil, "ConsensusState", cs)
 	for _, option := range options {
 		option(cs)
@@ -201,6 +239,26 @@ func StateMetrics(metrics *Metrics) StateOption {
 	return func(cs *ConsensusState) { cs.metrics = metrics }
 }
 
+// StateHaltOnUnresponsiveApp makes finalizeCommit return without killing the
+// process if the app doesn't respond to a call (see proxy.WithCallTimeout),
+// for the node to halt consensus.
+func StateHaltOnUnresponsiveApp() StateOption {
+	return func(cs *ConsensusState) { cs.haltOnUnresponsiveApp = true }
+}
+
+// StateResyncApp makes the ConsensusState resync the app with the state when
+// the connection to it reconnected (see proxy.WithReconnect), and retry the
+// block which was being applied when the connection was lost, rather than
+// killing the process. reconnected receives when a connection to the app
+// reconnected; resync brings the app back to the state (see
+// Handshaker.ResyncApp) and resumes the connections.
+func StateResyncApp(reconnected <-chan struct{}, resync func(state sm.State) error) StateOption {
+	return func(cs *ConsensusState) {
+		cs.appReconnected = reconnected
+		cs.resyncApp = resync
+	}
+}
+
 // String returns a string.
 func (cs *ConsensusState) String() string {
 	// better not to access shared variables
@@ -373,6 +431,8 @@ func (cs *ConsensusState) OpenWAL(walFile string) (WAL, error) {
 		return nil, err
 	}
 	wal.SetLogger(cs.Logger.With("wal", walFile))
+	wal.SetCompression(cs.config.WalCompression)
+	wal.SetRetainHeights(cs.config.WalRetainHeights)
 	if err := wal.Start(); err != nil {
 		return nil, err
 	}
@@ -449,6 +509,7 @@ func (cs *ConsensusState) updateHeight(height int64) {
 func (cs *ConsensusState) updateRoundStep(round int, step cstypes.RoundStepType) {
 	cs.Round = round
 	cs.Step = step
+	cs.metrics.WaitingForTxs.Set(0)
 }
 
 // enterNewRound(height, 0) at cs.StartTime.
@@ -458,6 +519,24 @@ func (cs *ConsensusState) scheduleRound0(rs *cstypes.RoundState) {
 	cs.scheduleTimeout(sleepDuration, rs.Height, 0, cstypes.RoundStepNewHeight)
 }
 
+// timeouts returns the adaptive timeouts if enabled, or the configured ones,
+// growing with every round by the deltas of the consensus params.
+func (cs *ConsensusState) timeouts() roundTimeouts {
+	if cs.adaptiveTimeouts != nil {
+		return cs.adaptiveTimeouts
+	}
+	return configTimeouts{cs.config, cs.state.ConsensusParams.Timeout}
+}
+
+// receiveTime returns when the message being handled was received: now, or
+// when it was written to the WAL if it's replayed.
+func (cs *ConsensusState) receiveTime() time.Time {
+	if cs.replayMode && !cs.replayTime.IsZero() {
+		return cs.replayTime
+	}
+	return tmtime.Now()
+}
+
 // Attempt to schedule a timeout (by sending timeoutInfo on the tickChan)
 func (cs *ConsensusState) scheduleTimeout(duration time.Duration, height int64, round int, step cstypes.RoundStepType) {
 	cs.timeoutTicker.ScheduleTimeout(timeoutInfo{duration, height, round, step})
@@ -554,6 +633,7 @@ func (cs *ConsensusState) updateToState(state sm.State) {
 
 	cs.Validators = validators
 	cs.Proposal = nil
+	cs.ProposalReceiveTime = time.Time{}
 	cs.ProposalBlock = nil
 	cs.ProposalBlockParts = nil
 	cs.LockedRound = -1
@@ -567,8 +647,11 @@ func (cs *ConsensusState) updateToState(state sm.State) {
 	cs.LastCommit = lastPrecommits
 	cs.LastValidators = state.LastValidators
 	cs.TriggeredTimeoutPrecommit = false
+	cs.txsAvailable = false
+	cs.ownProposal = nil
 
 	cs.state = state
+	cs.adaptiveTimeouts.setParams(state.ConsensusParams.Timeout)
 
 	// Finally, broadcast RoundState
 	cs.newStep()
@@ -658,6 +741,8 @@ func (cs *ConsensusState) receiveRoutine(maxSteps int) {
 			// if the timeout is relevant to the rs
 			// go to the next step
 			cs.handleTimeout(ti, rs)
+		case <-cs.appReconnected:
+			cs.handleAppReconnected()
 		case <-cs.Quit():
 			onExit(cs)
 			return
@@ -694,7 +779,7 @@ func (cs *ConsensusState) handleMsg(mi msgInfo) {
 	case *VoteMessage:
 		// attempt to add the vote and dupeout the validator if its a duplicate signature
 		// if the vote gives us a 2/3-any or 2/3-one, we transition
-		added, err = cs.tryAddVote(msg.Vote, peerID)
+		added, err = cs.tryAddVote(msg.Vote, peerID, false)
 		if added {
 			cs.statsMsgQueue <- mi
 		}
@@ -713,6 +798,18 @@ func (cs *ConsensusState) handleMsg(mi msgInfo) {
 		// TODO: If rs.Height == vote.Height && rs.Round < vote.Round,
 		// the peer is sending us CatchupCommit precommits.
 		// We could make note of this and help filter in broadcastHasVoteMessage().
+	case *CommitMessage:
+		// the precommits of the commit are added one by one, without requiring
+		// their extensions: the commits of the blocks don't have them
+		for i := range msg.Commit.Precommits {
+			vote := msg.Commit.GetByIndex(i)
+			if vote == nil {
+				continue
+			}
+			if added, _ := cs.tryAddVote(vote, peerID, true); added {
+				cs.statsMsgQueue <- msgInfo{&VoteMessage{vote}, peerID}
+			}
+		}
 	default:
 		cs.Logger.Error("Unknown msg type", "type", reflect.TypeOf(msg))
 		return
@@ -745,16 +842,21 @@ func (cs *ConsensusState) handleTimeout(ti timeoutInfo, rs cstypes.RoundState) {
 		// XXX: should we fire timeout here (for timeout commit)?
 		cs.enterNewRound(ti.Height, 0)
 	case cstypes.RoundStepNewRound:
-		cs.enterPropose(ti.Height, 0)
+		// create_empty_blocks_interval elapsed without txs
+		cs.proposeAfterWaiting(ti.Height, proposeTriggerEmptyBlocksInterval)
 	case cstypes.RoundStepPropose:
 		cs.eventBus.PublishEventTimeoutPropose(cs.RoundStateEvent())
+		cs.adaptiveTimeouts.timeoutStep(ti.Step)
 		cs.enterPrevote(ti.Height, ti.Round)
 	case cstypes.RoundStepPrevoteWait:
 		cs.eventBus.PublishEventTimeoutWait(cs.RoundStateEvent())
+		cs.adaptiveTimeouts.timeoutStep(ti.Step)
 		cs.enterPrecommit(ti.Height, ti.Round)
 	case cstypes.RoundStepPrecommitWait:
 		cs.eventBus.PublishEventTimeoutWait(cs.RoundStateEvent())
+		cs.adaptiveTimeouts.timeoutStep(ti.Step)
 		cs.enterPrecommit(ti.Height, ti.Round)
+		cs.traceRoundSkip(ti.Round+1, TraceReasonTimeoutPrecommit)
 		cs.enterNewRound(ti.Height, ti.Round+1)
 	default:
 		panic(fmt.Sprintf("Invalid timeout step: %v", ti.Step))
@@ -762,12 +864,58 @@ func (cs *ConsensusState) handleTimeout(ti timeoutInfo, rs cstypes.RoundState) {
 
 }
 
+// handleAppReconnected resyncs the app, which restarted, with the state.
+func (cs *ConsensusState) handleAppReconnected() {
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+
+	if err := cs.resyncAppWhenBack(); err != nil && cs.IsRunning() {
+		cs.Logger.Error("Error resyncing the application. Please restart tendermint", "err", err)
+		if err := cmn.Kill(); err != nil {
+			cs.Logger.Error("Failed to kill this process - please do so manually", "err", err)
+		}
+	}
+}
+
+// resyncAppWhenBack resyncs the app with the state, retrying while the
+// connection to it is lost. It returns cmn.ErrAlreadyStopped if consensus is
+// stopped first.
+func (cs *ConsensusState) resyncAppWhenBack() error {
+	for {
+		err := cs.resyncApp(cs.state)
+		if err == nil {
+			return nil
+		}
+		if errors.Cause(err) != abcicli.ErrConnectionLost {
+			return err
+		}
+		cs.Logger.Info("Waiting for the application to resync it", "err", err)
+		select {
+		case <-cs.appReconnected:
+		case <-time.After(appResyncRetryInterval):
+		case <-cs.Quit():
+			return cmn.ErrAlreadyStopped
+		}
+	}
+}
+
 func (cs *ConsensusState) handleTxsAvailable() {
 	cs.mtx.Lock()
 	defer cs.mtx.Unlock()
+
 	// we only need to do this for round 0
-	cs.enterNewRound(cs.Height, 0)
-	cs.enterPropose(cs.Height, 0)
+	if cs.Round != 0 {
+		return
+	}
+
+	switch cs.Step {
+	case cstypes.RoundStepNewHeight:
+		// Still in timeout_commit: don't wait for txs once round 0 starts, but
+		// don't start it early either.
+		cs.txsAvailable = true
+	case cstypes.RoundStepNewRound:
+		cs.proposeAfterWaiting(cs.Height, proposeTriggerTxs)
+	}
 }
 
 //-----------------------------------------------------------------------------
@@ -813,6 +961,7 @@ func (cs *ConsensusState) enterNewRound(height int64, round int) {
 	} else {
 		logger.Info("Resetting Proposal info")
 		cs.Proposal = nil
+		cs.ProposalReceiveTime = time.Time{}
 		cs.ProposalBlock = nil
 		cs.ProposalBlockParts = nil
 	}
@@ -821,19 +970,61 @@ func (cs *ConsensusState) enterNewRound(height int64, round int) {
 
 	cs.eventBus.PublishEventNewRound(cs.NewRoundEvent())
 	cs.metrics.Rounds.Set(float64(round))
+	cs.trace(TraceEvent{Type: TraceEnterNewRound, Round: round})
 
 	// Wait for txs to be available in the mempool
 	// before we enterPropose in round 0. If the last block changed the app hash,
-	// we may need an empty "proof" block, and enterPropose immediately.
-	waitForTxs := cs.config.WaitForTxs() && round == 0 && !cs.needProofBlock(height)
-	if waitForTxs {
-		if cs.config.CreateEmptyBlocksInterval > 0 {
-			cs.scheduleTimeout(cs.config.CreateEmptyBlocksInterval, height, round,
-				cstypes.RoundStepNewRound)
+	// we may need an empty "proof" block, and enterPropose immediately. Same if
+	// the app reported state changes pending.
+	if round == 0 {
+		trigger := cs.proposeTrigger(height)
+		if trigger == "" {
+			cs.metrics.WaitingForTxs.Set(1)
+			if cs.config.CreateEmptyBlocksInterval > 0 {
+				cs.scheduleTimeout(cs.config.CreateEmptyBlocksInterval, height, round,
+					cstypes.RoundStepNewRound)
+			}
+			return
 		}
-	} else {
-		cs.enterPropose(height, round)
+		cs.metrics.ProposeTriggers.With("trigger", trigger).Add(1)
 	}
+	cs.enterPropose(height, round)
+}
+
+// What makes round 0 of a height enter the propose step, see
+// Metrics.ProposeTriggers.
+const (
+	proposeTriggerEmptyBlocks         = "create_empty_blocks"
+	proposeTriggerEmptyBlocksInterval = "create_empty_blocks_interval"
+	proposeTriggerTxs                 = "txs"
+	proposeTriggerProofBlock          = "proof_block"
+	proposeTriggerAppPendingState     = "app_pending_state"
+)
+
+// proposeTrigger returns why round 0 of the height doesn't wait for txs to
+// enter the propose step, or "" if it does.
+func (cs *ConsensusState) proposeTrigger(height int64) string {
+	switch {
+	case !cs.config.WaitForTxs():
+		return proposeTriggerEmptyBlocks
+	case cs.needProofBlock(height):
+		return proposeTriggerProofBlock
+	case cs.state.AppPendingState:
+		return proposeTriggerAppPendingState
+	case cs.txsAvailable:
+		return proposeTriggerTxs
+	}
+	return ""
+}
+
+// proposeAfterWaiting enters the propose step of round 0, which was waiting
+// for txs.
+func (cs *ConsensusState) proposeAfterWaiting(height int64, trigger string) {
+	if cs.Height != height || cs.Round != 0 || cs.Step != cstypes.RoundStepNewRound {
+		return
+	}
+	cs.metrics.ProposeTriggers.With("trigger", trigger).Add(1)
+	cs.enterPropose(height, 0)
 }
 
 // needProofBlock returns true on the first height (so the genesis app hash is signed right away)
@@ -850,6 +1041,7 @@ func (cs *ConsensusState) needProofBlock(height int64) bool {
 // Enter (CreateEmptyBlocks): from enterNewRound(height,round)
 // Enter (CreateEmptyBlocks, CreateEmptyBlocksInterval > 0 ): after enterNewRound(height,round), after timeout of CreateEmptyBlocksInterval
 // Enter (!CreateEmptyBlocks) : after enterNewRound(height,round), once txs are in the mempool
+// Enter (!CreateEmptyBlocks, app pending state) : from enterNewRound(height,round)
 func (cs *ConsensusState) enterPropose(height int64, round int) {
 	logger := cs.Logger.With("height", height, "round", round)
 
@@ -872,8 +1064,11 @@ func (cs *ConsensusState) enterPropose(height int64, round int) {
 		}
 	}()
 
+	cs.trace(TraceEvent{Type: TraceEnterPropose, Round: round, Proposer: cs.Validators.GetProposer().Address})
+
 	// If we don't get the proposal and all block parts quick enough, enterPrevote
-	cs.scheduleTimeout(cs.config.Propose(round), height, round, cstypes.RoundStepPropose)
+	cs.adaptiveTimeouts.enterStep(cstypes.RoundStepPropose, tmtime.Now())
+	cs.scheduleTimeout(cs.timeouts().Propose(round), height, round, cstypes.RoundStepPropose)
 
 	// Nothing more to do if we're not a validator
 	if cs.privValidator == nil {
@@ -923,7 +1118,9 @@ func (cs *ConsensusState) defaultDecideProposal(height int64, round int) {
 	// Make proposal
 	propBlockId := types.BlockID{Hash: block.Hash(), PartsHeader: blockParts.Header()}
 	proposal := types.NewProposal(height, round, cs.ValidRound, propBlockId)
-	if err := cs.privValidator.SignProposal(cs.state.ChainID, proposal); err == nil {
+	err := cs.signProposal(proposal)
+	if err == nil {
+		cs.ownProposal = proposal
 
 		// send proposal and block parts on internal msg queue
 		cs.sendInternalMessage(msgInfo{&ProposalMessage{proposal}, ""})
@@ -933,6 +1130,8 @@ func (cs *ConsensusState) defaultDecideProposal(height int64, round int) {
 		}
 		cs.Logger.Info("Signed proposal", "height", height, "round", round, "proposal", proposal)
 		cs.Logger.Debug(fmt.Sprintf("Signed proposal block: %v", block))
+	} else if guardErr, ok := err.(signGuardError); ok {
+		cs.refusedToSign(guardErr, "proposal", proposal.BlockID)
 	} else {
 		if !cs.replayMode {
 			cs.Logger.Error("enterPropose: Error signing proposal", "height", height, "round", round, "err", err)
@@ -940,6 +1139,46 @@ func (cs *ConsensusState) defaultDecideProposal(height int64, round int) {
 	}
 }
 
+// signProposal signs the proposal, unless the signGuard refuses to.
+func (cs *ConsensusState) signProposal(proposal *types.Proposal) error {
+	height, round, blockID := proposal.Height, proposal.Round, proposal.BlockID
+	if err := cs.signGuard.check(height, round, signStepPropose, blockID); err != nil {
+		return err
+	}
+	if err := cs.privValidator.SignProposal(cs.state.ChainID, proposal); err != nil {
+		return err
+	}
+	if proposal.Height != height || proposal.Round != round || !proposal.BlockID.Equals(blockID) {
+		return signGuardError{signRefusalBadSignature, errors.New("the privValidator changed the proposal it signed")}
+	}
+	return cs.signGuard.record(height, round, signStepPropose, blockID,
+		cs.privValidator.GetPubKey(), proposal.SignBytes(cs.state.ChainID), proposal.Signature)
+}
+
+// refusedToSign logs and counts a proposal or vote the signGuard refused to
+// sign or send. Replaying the WAL re-enters the steps we already signed, so
+// the refusals of the replay are expected.
+func (cs *ConsensusState) refusedToSign(err signGuardError, what string, blockID types.BlockID) {
+	if cs.replayMode {
+		cs.Logger.Debug(fmt.Sprintf("Replay: Not signing %s again", what), "height", cs.Height,
+			"round", cs.Round, "blockID", blockID, "reason", err.reason, "err", err.err)
+		return
+	}
+	cs.metrics.SignRefusals.With("reason", err.reason).Add(1)
+	cs.Logger.Error(fmt.Sprintf("Refusing to sign %s, it could double sign", what), "height", cs.Height,
+		"round", cs.Round, "blockID", blockID, "reason", err.reason, "err", err.err)
+}
+
+// isOwnProposal returns true if we signed the proposal.
+func (cs *ConsensusState) isOwnProposal(proposal *types.Proposal) bool {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	own := cs.ownProposal
+	return proposal != nil && own != nil &&
+		proposal.Height == own.Height && proposal.Round == own.Round &&
+		proposal.BlockID.Equals(own.BlockID)
+}
+
 // Returns true if the proposal block is complete &&
 // (if POLRound was proposed, we have +2/3 prevotes from there).
 func (cs *ConsensusState) isProposalComplete() bool {
@@ -997,6 +1236,12 @@ func (cs *ConsensusState) enterPrevote(height int64, round int) {
 	}()
 
 	cs.Logger.Info(fmt.Sprintf("enterPrevote(%v/%v). Current: %v/%v/%v", height, round, cs.Height, cs.Round, cs.Step))
+	cs.adaptiveTimeouts.enterStep(cstypes.RoundStepPrevote, tmtime.Now())
+	reason := TraceReasonTimeoutPropose
+	if cs.isProposalComplete() {
+		reason = TraceReasonProposalComplete
+	}
+	cs.trace(TraceEvent{Type: TraceEnterPrevote, Round: round, Reason: reason})
 
 	// Sign and broadcast vote as necessary
 	cs.doPrevote(height, round)
@@ -1030,6 +1275,40 @@ func (cs *ConsensusState) defaultDoPrevote(height int64, round int) {
 		cs.signAddVote(types.PrevoteType, nil, types.PartSetHeader{})
 		return
 	}
+	if err := cs.blockExec.ValidateProposalGas(cs.state, cs.ProposalBlock); err != nil {
+		logger.Error("enterPrevote: ProposalBlock exceeds the max gas, prevoting nil", "err", err)
+		cs.signAddVote(types.PrevoteType, nil, types.PartSetHeader{})
+		return
+	}
+
+	// A block proposed for the first time must have been timely: its time
+	// must be close to the time we received the proposal. The block of the
+	// first height has the genesis time.
+	if cs.Proposal != nil && cs.Proposal.POLRound == -1 && height > 1 {
+		synchrony := cs.state.ConsensusParams.Synchrony
+		if !synchrony.IsTimely(cs.ProposalBlock.Time, cs.ProposalReceiveTime) {
+			logger.Info("enterPrevote: ProposalBlock is not timely, prevoting nil",
+				"blockTime", cs.ProposalBlock.Time, "receiveTime", cs.ProposalReceiveTime)
+			cs.signAddVote(types.PrevoteType, nil, types.PartSetHeader{})
+			return
+		}
+	}
+
+	// The app must accept the block, unless we proposed it.
+	if cs.privValidator == nil ||
+		!bytes.Equal(cs.ProposalBlock.ProposerAddress, cs.privValidator.GetPubKey().Address()) {
+		accepted, err := cs.blockExec.ProcessProposal(cs.state, cs.ProposalBlock)
+		if err != nil {
+			logger.Error("enterPrevote: Error processing the ProposalBlock, prevoting nil", "err", err)
+			cs.signAddVote(types.PrevoteType, nil, types.PartSetHeader{})
+			return
+		}
+		if !accepted {
+			logger.Info("enterPrevote: ProposalBlock was rejected by the app, prevoting nil")
+			cs.signAddVote(types.PrevoteType, nil, types.PartSetHeader{})
+			return
+		}
+	}
 
 	// Prevote cs.ProposalBlock
 	// NOTE: the proposal signature is validated when it is received,
@@ -1058,7 +1337,7 @@ func (cs *ConsensusState) enterPrevoteWait(height int64, round int) {
 	}()
 
 	// Wait for some more prevotes; enterPrecommit
-	cs.scheduleTimeout(cs.config.Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
+	cs.scheduleTimeout(cs.timeouts().Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
 }
 
 // Enter: `timeoutPrevote` after any +2/3 prevotes.
@@ -1076,6 +1355,7 @@ func (cs *ConsensusState) enterPrecommit(height int64, round int) {
 	}
 
 	logger.Info(fmt.Sprintf("enterPrecommit(%v/%v). Current: %v/%v/%v", height, round, cs.Height, cs.Round, cs.Step))
+	cs.adaptiveTimeouts.enterStep(cstypes.RoundStepPrecommit, tmtime.Now())
 
 	defer func() {
 		// Done enterPrecommit:
@@ -1086,6 +1366,14 @@ func (cs *ConsensusState) enterPrecommit(height int64, round int) {
 	// check for a polka
 	blockID, ok := cs.Votes.Prevotes(round).TwoThirdsMajority()
 
+	reason := TraceReasonTimeoutPrevote
+	if ok {
+		reason = TraceReasonPolka
+	} else if cs.Votes.Precommits(round).HasTwoThirdsAny() {
+		reason = TraceReasonPrecommits
+	}
+	cs.trace(TraceEvent{Type: TraceEnterPrecommit, Round: round, Reason: reason, BlockID: blockID})
+
 	// If we don't have a polka, we must precommit nil.
 	if !ok {
 		if cs.LockedBlock != nil {
@@ -1186,7 +1474,7 @@ func (cs *ConsensusState) enterPrecommitWait(height int64, round int) {
 	}()
 
 	// Wait for some more precommits; enterNewRound
-	cs.scheduleTimeout(cs.config.Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
+	cs.scheduleTimeout(cs.timeouts().Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
 
 }
 
@@ -1199,6 +1487,7 @@ func (cs *ConsensusState) enterCommit(height int64, commitRound int) {
 		return
 	}
 	logger.Info(fmt.Sprintf("enterCommit(%v/%v). Current: %v/%v/%v", height, commitRound, cs.Height, cs.Round, cs.Step))
+	cs.trace(TraceEvent{Type: TraceEnterCommit, Round: commitRound})
 
 	defer func() {
 		// Done enterCommit:
@@ -1333,7 +1622,20 @@ func (cs *ConsensusState) finalizeCommit(height int64) {
 	// NOTE The block.AppHash wont reflect these txs until the next block.
 	var err error
 	stateCopy, err = cs.blockExec.ApplyBlock(stateCopy, types.BlockID{Hash: block.Hash(), PartsHeader: blockParts.Header()}, block)
+	for err != nil && cs.resyncApp != nil && errors.Cause(err) == abcicli.ErrConnectionLost {
+		cs.Logger.Error("Lost the connection to the application, retrying the block once it's back", "height", height, "err", err)
+		if err = cs.resyncAppWhenBack(); err == nil {
+			stateCopy, err = cs.blockExec.ApplyBlock(cs.state.Copy(), types.BlockID{Hash: block.Hash(), PartsHeader: blockParts.Header()}, block)
+		}
+	}
 	if err != nil {
+		if !cs.IsRunning() {
+			return
+		}
+		if _, ok := err.(proxy.ErrAppUnresponsive); ok && cs.haltOnUnresponsiveApp {
+			cs.Logger.Error("Error on ApplyBlock, the application is unresponsive", "err", err)
+			return
+		}
 		cs.Logger.Error("Error on ApplyBlock. Did the application crash? Please restart tendermint", "err", err)
 		err := cmn.Kill()
 		if err != nil {
@@ -1382,6 +1684,12 @@ func (cs *ConsensusState) recordMetrics(height int64, block *types.Block) {
 	cs.metrics.ByzantineValidators.Set(float64(len(block.Evidence.Evidence)))
 	byzantineValidatorsPower := int64(0)
 	for _, ev := range block.Evidence.Evidence {
+		if cev, ok := ev.(types.CompositeEvidence); ok {
+			for _, val := range cev.ByzantineValidators(cs.Validators) {
+				byzantineValidatorsPower += val.VotingPower
+			}
+			continue
+		}
 		if _, val := cs.Validators.GetByAddress(ev.Address()); val != nil {
 			byzantineValidatorsPower += val.VotingPower
 		}
@@ -1428,6 +1736,7 @@ func (cs *ConsensusState) defaultSetProposal(proposal *types.Proposal) error {
 	}
 
 	cs.Proposal = proposal
+	cs.ProposalReceiveTime = cs.receiveTime()
 	// We don't update cs.ProposalBlockParts if it is already set.
 	// This happens if we're already in cstypes.RoundStepCommit or if there is a valid block in the current round.
 	// TODO: We can check if Proposal is for a different block as this is a sign of misbehavior!
@@ -1495,6 +1804,7 @@ func (cs *ConsensusState) addProposalBlockPart(msg *BlockPartMessage, peerID p2p
 		}
 
 		if cs.Step <= cstypes.RoundStepPropose && cs.isProposalComplete() {
+			cs.adaptiveTimeouts.completeStep(cstypes.RoundStepPropose, tmtime.Now())
 			// Move onto the next step
 			cs.enterPrevote(height, cs.Round)
 			if hasTwoThirds { // this is optimisation as this will be triggered when prevote is added
@@ -1510,8 +1820,8 @@ func (cs *ConsensusState) addProposalBlockPart(msg *BlockPartMessage, peerID p2p
 }
 
 // Attempt to add the vote. if its a duplicate signature, dupeout the validator
-func (cs *ConsensusState) tryAddVote(vote *types.Vote, peerID p2p.ID) (bool, error) {
-	added, err := cs.addVote(vote, peerID)
+func (cs *ConsensusState) tryAddVote(vote *types.Vote, peerID p2p.ID, fromCommit bool) (bool, error) {
+	added, err := cs.addVote(vote, peerID, fromCommit)
 	if err != nil {
 		// If the vote height is off, we'll just ignore it,
 		// But if it's a conflicting sig, add it to the cs.evpool.
@@ -1538,7 +1848,9 @@ func (cs *ConsensusState) tryAddVote(vote *types.Vote, peerID p2p.ID) (bool, err
 
 //-----------------------------------------------------------------------------
 
-func (cs *ConsensusState) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error) {
+// addVote adds the vote to the LastCommit or the votes of our height. The
+// precommits from a commit, sent to catch up, may not have their extensions.
+func (cs *ConsensusState) addVote(vote *types.Vote, peerID p2p.ID, fromCommit bool) (added bool, err error) {
 	cs.Logger.Debug("addVote", "voteHeight", vote.Height, "voteType", vote.Type, "valIndex", vote.ValidatorIndex, "csHeight", cs.Height)
 
 	// A precommit for the previous height?
@@ -1549,6 +1861,9 @@ func (cs *ConsensusState) addVote(vote *types.Vote, peerID p2p.ID) (added bool,
 			// fmt.Errorf("tryAddVote: Wrong height, not a LastCommit straggler commit.")
 			return added, ErrVoteHeightMismatch
 		}
+		if err := cs.verifyVoteExtension(vote, cs.LastCommit, cs.LastValidators, fromCommit); err != nil {
+			return false, err
+		}
 		added, err = cs.LastCommit.AddVote(vote)
 		if !added {
 			return added, err
@@ -1577,6 +1892,12 @@ func (cs *ConsensusState) addVote(vote *types.Vote, peerID p2p.ID) (added bool,
 	}
 
 	height := cs.Height
+	if vote.Type == types.PrecommitType {
+		if err := cs.verifyVoteExtension(vote, cs.Votes.Precommits(vote.Round), cs.Validators, fromCommit); err != nil {
+			return false, err
+		}
+	}
+	_, hadPolka := cs.Votes.Prevotes(vote.Round).TwoThirdsMajority()
 	added, err = cs.Votes.AddVote(vote, peerID)
 	if !added {
 		// Either duplicate, or error upon cs.Votes.AddByIndex()
@@ -1593,6 +1914,9 @@ func (cs *ConsensusState) addVote(vote *types.Vote, peerID p2p.ID) (added bool,
 
 		// If +2/3 prevotes for a block or nil for *any* round:
 		if blockID, ok := prevotes.TwoThirdsMajority(); ok {
+			if !hadPolka {
+				cs.trace(TraceEvent{Type: TracePolka, Round: vote.Round, BlockID: blockID})
+			}
 
 			// There was a polka!
 			// If we're locked but this is a recent polka, unlock.
@@ -1640,9 +1964,13 @@ func (cs *ConsensusState) addVote(vote *types.Vote, peerID p2p.ID) (added bool,
 		// If +2/3 prevotes for *anything* for future round:
 		if cs.Round < vote.Round && prevotes.HasTwoThirdsAny() {
 			// Round-skip if there is any 2/3+ of votes ahead of us
+			cs.traceRoundSkip(vote.Round, TraceReasonPrevotesAhead)
 			cs.enterNewRound(height, vote.Round)
 		} else if cs.Round == vote.Round && cstypes.RoundStepPrevote <= cs.Step { // current round
 			blockID, ok := prevotes.TwoThirdsMajority()
+			if ok {
+				cs.adaptiveTimeouts.completeStep(cstypes.RoundStepPrevote, tmtime.Now())
+			}
 			if ok && (cs.isProposalComplete() || len(blockID.Hash) == 0) {
 				cs.enterPrecommit(height, vote.Round)
 			} else if prevotes.HasTwoThirdsAny() {
@@ -1661,7 +1989,11 @@ func (cs *ConsensusState) addVote(vote *types.Vote, peerID p2p.ID) (added bool,
 
 		blockID, ok := precommits.TwoThirdsMajority()
 		if ok {
+			if vote.Round == cs.Round {
+				cs.adaptiveTimeouts.completeStep(cstypes.RoundStepPrecommit, tmtime.Now())
+			}
 			// Executed as TwoThirdsMajority could be from a higher round
+			cs.traceRoundSkip(vote.Round, TraceReasonPrecommitsAhead)
 			cs.enterNewRound(height, vote.Round)
 			cs.enterPrecommit(height, vote.Round)
 			if len(blockID.Hash) != 0 {
@@ -1673,6 +2005,7 @@ func (cs *ConsensusState) addVote(vote *types.Vote, peerID p2p.ID) (added bool,
 				cs.enterPrecommitWait(height, vote.Round)
 			}
 		} else if cs.Round <= vote.Round && precommits.HasTwoThirdsAny() {
+			cs.traceRoundSkip(vote.Round, TraceReasonPrecommitsAhead)
 			cs.enterNewRound(height, vote.Round)
 			cs.enterPrecommitWait(height, vote.Round)
 		}
@@ -1684,6 +2017,45 @@ func (cs *ConsensusState) addVote(vote *types.Vote, peerID p2p.ID) (added bool,
 	return
 }
 
+// verifyVoteExtension checks the extension signature of a precommit for a
+// block, and has the app verify the extension of the precommits of the other
+// validators. The extension isn't part of the sign bytes of the precommit, so
+// any peer could strip it: a precommit without one is only accepted from a
+// commit, as the commits of the blocks don't have the extensions.
+// Precommits the vote set already has aren't verified again, unless it has
+// them without their extension.
+func (cs *ConsensusState) verifyVoteExtension(vote *types.Vote, voteSet *types.VoteSet, valSet *types.ValidatorSet, fromCommit bool) error {
+	if vote.BlockID.IsZero() {
+		return nil
+	}
+	_, val := valSet.GetByIndex(vote.ValidatorIndex)
+	if val == nil {
+		// Rejected by the vote set.
+		return nil
+	}
+	if voteSet != nil {
+		if existing := voteSet.GetByIndex(vote.ValidatorIndex); existing != nil &&
+			bytes.Equal(existing.Signature, vote.Signature) &&
+			(len(existing.ExtensionSignature) != 0 || len(vote.ExtensionSignature) == 0) {
+			return nil
+		}
+	}
+	if fromCommit && len(vote.Extension) == 0 && len(vote.ExtensionSignature) == 0 {
+		return nil
+	}
+	if err := vote.VerifyExtension(cs.state.ChainID, val.PubKey); err != nil {
+		return err
+	}
+	if len(vote.Extension) == 0 ||
+		(cs.privValidator != nil && bytes.Equal(vote.ValidatorAddress, cs.privValidator.GetPubKey().Address())) {
+		return nil
+	}
+	if err := cs.blockExec.VerifyVoteExtension(vote); err != nil {
+		return errors.Wrapf(err, "failed to verify the extension of %v", vote)
+	}
+	return nil
+}
+
 func (cs *ConsensusState) signVote(type_ types.SignedMsgType, hash []byte, header types.PartSetHeader) (*types.Vote, error) {
 	// Flush the WAL. Otherwise, we may not recompute the same vote to sign, and the privValidator will refuse to sign anything.
 	cs.wal.FlushAndSync()
@@ -1691,6 +2063,12 @@ func (cs *ConsensusState) signVote(type_ types.SignedMsgType, hash []byte, heade
 	addr := cs.privValidator.GetPubKey().Address()
 	valIndex, _ := cs.Validators.GetByAddress(addr)
 
+	blockID := types.BlockID{Hash: hash, PartsHeader: header}
+	step := signStep(type_)
+	if err := cs.signGuard.check(cs.Height, cs.Round, step, blockID); err != nil {
+		return nil, err
+	}
+
 	vote := &types.Vote{
 		ValidatorAddress: addr,
 		ValidatorIndex:   valIndex,
@@ -1698,10 +2076,32 @@ func (cs *ConsensusState) signVote(type_ types.SignedMsgType, hash []byte, heade
 		Round:            cs.Round,
 		Timestamp:        cs.voteTime(),
 		Type:             type_,
-		BlockID:          types.BlockID{Hash: hash, PartsHeader: header},
+		BlockID:          blockID,
+	}
+	if type_ == types.PrecommitType && len(hash) != 0 {
+		extension, err := cs.blockExec.ExtendVote(vote)
+		if err != nil {
+			cs.Logger.Error("Error extending our precommit, precommitting with an empty extension", "err", err)
+		}
+		vote.Extension = extension
 	}
-	err := cs.privValidator.SignVote(cs.state.ChainID, vote)
-	return vote, err
+	extension := vote.Extension
+	if err := cs.privValidator.SignVote(cs.state.ChainID, vote); err != nil {
+		return vote, err
+	}
+	if vote.Height != cs.Height || vote.Round != cs.Round || vote.Type != type_ || !vote.BlockID.Equals(blockID) ||
+		!bytes.Equal(vote.Extension, extension) {
+		return nil, signGuardError{signRefusalBadSignature, errors.New("the privValidator changed the vote it signed")}
+	}
+	if err := vote.VerifyExtension(cs.state.ChainID, cs.privValidator.GetPubKey()); err != nil {
+		return nil, signGuardError{signRefusalBadSignature, err}
+	}
+	err := cs.signGuard.record(cs.Height, cs.Round, step, blockID,
+		cs.privValidator.GetPubKey(), vote.SignBytes(cs.state.ChainID), vote.Signature)
+	if err != nil {
+		return nil, err
+	}
+	return vote, nil
 }
 
 func (cs *ConsensusState) voteTime() time.Time {
@@ -1735,6 +2135,10 @@ func (cs *ConsensusState) signAddVote(type_ types.SignedMsgType, hash []byte, he
 		cs.Logger.Info("Signed and pushed vote", "height", cs.Height, "round", cs.Round, "vote", vote, "err", err)
 		return vote
 	}
+	if guardErr, ok := err.(signGuardError); ok {
+		cs.refusedToSign(guardErr, "vote", types.BlockID{Hash: hash, PartsHeader: header})
+		return nil
+	}
 	//if !cs.replayMode {
 	cs.Logger.Error("Error signing vote", "height", cs.Height, "round", cs.Round, "vote", vote, "err", err)
 	//}
@@ -7,15 +7,20 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	"github.com/tendermint/tendermint/libs/log"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	p2pdummy "github.com/tendermint/tendermint/p2p/dummy"
+	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
 /*
@@ -1619,6 +1624,125 @@ func TestStateOutputVoteStats(t *testing.T) {
 
 }
 
+// voteExtensionApp extends the precommits with the height, and rejects the
+// other extensions.
+type voteExtensionApp struct {
+	abci.BaseApplication
+}
+
+func (voteExtensionApp) ExtendVote(req abci.RequestExtendVote) abci.ResponseExtendVote {
+	return abci.ResponseExtendVote{VoteExtension: []byte(fmt.Sprintf("height %d", req.Height))}
+}
+
+func (voteExtensionApp) VerifyVoteExtension(req abci.RequestVerifyVoteExtension) abci.ResponseVerifyVoteExtension {
+	if !bytes.Equal(req.VoteExtension, []byte(fmt.Sprintf("height %d", req.Height))) {
+		return abci.ResponseVerifyVoteExtension{Result: abci.ResponseVerifyVoteExtension_REJECT}
+	}
+	return abci.ResponseVerifyVoteExtension{Result: abci.ResponseVerifyVoteExtension_ACCEPT}
+}
+
+func TestStateVoteExtensions(t *testing.T) {
+	state, privVals := randGenesisState(4, false, 10)
+	cs := newConsensusState(state, privVals[0], voteExtensionApp{})
+	vs2, vs3, vs4 := NewValidatorStub(privVals[1], 1), NewValidatorStub(privVals[2], 2), NewValidatorStub(privVals[3], 3)
+	incrementHeight(vs2, vs3, vs4)
+	hash, header := cmn.RandBytes(tmhash.Size), types.PartSetHeader{Total: 1, Hash: cmn.RandBytes(tmhash.Size)}
+
+	// Our precommit for a block is extended by the app.
+	vote, err := cs.signVote(types.PrecommitType, hash, header)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("height 1"), vote.Extension)
+	assert.NoError(t, vote.VerifyExtension(config.ChainID(), privVals[0].GetPubKey()))
+
+	extendedPrecommit := func(vs *validatorStub, extension string) *types.Vote {
+		vote := &types.Vote{
+			ValidatorIndex:   vs.Index,
+			ValidatorAddress: vs.GetPubKey().Address(),
+			Height:           vs.Height,
+			Round:            vs.Round,
+			Timestamp:        tmtime.Now(),
+			Type:             types.PrecommitType,
+			BlockID:          types.BlockID{Hash: hash, PartsHeader: header},
+			Extension:        []byte(extension),
+		}
+		require.NoError(t, vs.SignVote(config.ChainID(), vote))
+		return vote
+	}
+
+	// The app rejects the extension.
+	added, err := cs.addVote(extendedPrecommit(vs2, "height 2"), "peer", false)
+	assert.False(t, added)
+	assert.IsType(t, sm.ErrVoteExtensionRejected{}, errors.Cause(err))
+
+	// The extension was tampered with.
+	tampered := extendedPrecommit(vs2, "height 1")
+	tampered.Extension = []byte("height 2")
+	added, err = cs.addVote(tampered, "peer", false)
+	assert.False(t, added)
+	assert.Equal(t, types.ErrVoteInvalidExtensionSignature, err)
+
+	added, err = cs.addVote(extendedPrecommit(vs2, "height 1"), "peer", false)
+	assert.True(t, added)
+	assert.NoError(t, err)
+
+	// A precommit stripped of its extension is only accepted from a commit.
+	extended := extendedPrecommit(vs3, "height 1")
+	added, err = cs.addVote(extended.WithoutExtension(), "peer", false)
+	assert.False(t, added)
+	assert.Equal(t, types.ErrVoteMissingExtension, err)
+	added, err = cs.addVote(extended.WithoutExtension(), "peer", true)
+	assert.True(t, added)
+	assert.NoError(t, err)
+
+	// The extended precommit then replaces it.
+	added, err = cs.addVote(extended, "peer", false)
+	assert.False(t, added)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("height 1"), cs.Votes.Precommits(0).GetByIndex(2).Extension)
+
+	// A nil precommit has no extension.
+	added, err = cs.addVote(signVote(vs4, types.PrecommitType, nil, types.PartSetHeader{}), "peer", false)
+	assert.True(t, added)
+	assert.NoError(t, err)
+
+	// The extensions are kept for the commit.
+	assert.Equal(t, []byte("height 1"), cs.Votes.Precommits(0).GetByIndex(1).Extension)
+}
+
+// processProposalApp accepts or rejects all the proposals.
+type processProposalApp struct {
+	abci.BaseApplication
+	reject bool
+}
+
+func (app processProposalApp) ProcessProposal(req abci.RequestProcessProposal) abci.ResponseProcessProposal {
+	if app.reject {
+		return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}
+	}
+	return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}
+}
+
+func TestStateProcessProposal(t *testing.T) {
+	state, privVals := randGenesisState(2, false, 10)
+	for _, reject := range []bool{false, true} {
+		cs := newConsensusState(state, privVals[0], processProposalApp{reject: reject})
+		vs2 := NewValidatorStub(privVals[1], 1)
+
+		// A valid block proposed by vs2.
+		propBlock, propBlockParts := cs.state.MakeBlock(cs.Height, nil, new(types.Commit), nil, vs2.GetPubKey().Address())
+		cs.ProposalBlock, cs.ProposalBlockParts = propBlock, propBlockParts
+
+		cs.defaultDoPrevote(cs.Height, cs.Round)
+		mi := <-cs.internalMsgQueue
+		prevote := mi.Msg.(*VoteMessage).Vote
+		if reject {
+			assert.True(t, prevote.BlockID.IsZero(), "prevoted %v, but the app rejected it", prevote.BlockID)
+		} else {
+			assert.Equal(t, propBlock.Hash(), prevote.BlockID.Hash)
+		}
+	}
+}
+
 // subscribe subscribes test client to the given query and returns a channel with cap = 1.
 func subscribe(eventBus *types.EventBus, q tmpubsub.Query) <-chan tmpubsub.Message {
 	sub, err := eventBus.Subscribe(context.Background(), testSubscriber, q)
@@ -0,0 +1,203 @@
+package consensus
+
+import (
+	"time"
+
+	cfg "github.com/tendermint/tendermint/config"
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// The adapted timeout of a step is adaptiveTimeoutFactor times the moving
+	// average of the durations observed for it.
+	adaptiveTimeoutFactor = 2
+
+	// Weight of a new duration in the moving average, as 1/adaptiveTimeoutWeight.
+	adaptiveTimeoutWeight = 5
+)
+
+// roundTimeouts are the timeouts of the steps of a round, implemented by
+// configTimeouts and adaptiveTimeouts.
+type roundTimeouts interface {
+	Propose(round int) time.Duration
+	Prevote(round int) time.Duration
+	Precommit(round int) time.Duration
+}
+
+var _ roundTimeouts = configTimeouts{}
+var _ roundTimeouts = (*adaptiveTimeouts)(nil)
+
+// configTimeouts are timeout_propose, timeout_prevote and timeout_precommit,
+// growing by the deltas of the consensus params with every round.
+type configTimeouts struct {
+	config *cfg.ConsensusConfig
+	params types.TimeoutParams
+}
+
+// Propose returns the amount of time to wait for a proposal.
+func (ct configTimeouts) Propose(round int) time.Duration {
+	return ct.params.ProposeTimeout(ct.config.TimeoutPropose, round)
+}
+
+// Prevote returns the amount of time to wait for straggler votes after
+// receiving any +2/3 prevotes.
+func (ct configTimeouts) Prevote(round int) time.Duration {
+	return ct.params.PrevoteTimeout(ct.config.TimeoutPrevote, round)
+}
+
+// Precommit returns the amount of time to wait for straggler votes after
+// receiving any +2/3 precommits.
+func (ct configTimeouts) Precommit(round int) time.Duration {
+	return ct.params.PrecommitTimeout(ct.config.TimeoutPrecommit, round)
+}
+
+// stepTimeout estimates the timeout of a step from the durations observed for
+// it: from entering the step until it completes (a complete proposal, +2/3
+// prevotes or precommits for a block or nil).
+type stepTimeout struct {
+	avg   time.Duration // moving average of the observed durations
+	start time.Time     // when the step was entered, zero once observed
+}
+
+func newStepTimeout(timeout time.Duration) *stepTimeout {
+	return &stepTimeout{avg: timeout / adaptiveTimeoutFactor}
+}
+
+func (st *stepTimeout) enter(now time.Time) {
+	st.start = now
+}
+
+// observe adds the duration since the step was entered to the average, once
+// per step.
+func (st *stepTimeout) observe(now time.Time) {
+	if st.start.IsZero() {
+		return
+	}
+	st.avg += (now.Sub(st.start) - st.avg) / adaptiveTimeoutWeight
+	st.start = time.Time{}
+}
+
+// timedOut doubles the average, as the step took longer than expected.
+func (st *stepTimeout) timedOut(ceiling time.Duration) {
+	st.avg *= 2
+	if st.avg > ceiling {
+		st.avg = ceiling
+	}
+	st.start = time.Time{}
+}
+
+func (st *stepTimeout) timeout(floor, ceiling time.Duration) time.Duration {
+	timeout := st.avg * adaptiveTimeoutFactor
+	if timeout < floor {
+		return floor
+	}
+	if timeout > ceiling {
+		return ceiling
+	}
+	return timeout
+}
+
+// adaptiveTimeouts replaces timeout_propose, timeout_prevote and
+// timeout_precommit with timeouts adapted to the durations of the steps in
+// the previous rounds, within the configured floor and ceiling, so that
+// healthy networks commit faster without tuning. Like the configured ones, the
+// timeouts grow by the deltas of the consensus params with every round.
+//
+// A nil adaptiveTimeouts ignores the steps entered, completed and timed out,
+// and the params set.
+//
+// NOTE: Not thread safe. Only used by functions downstream of the
+// cs.receiveRoutine.
+type adaptiveTimeouts struct {
+	config *cfg.ConsensusConfig
+	params types.TimeoutParams
+
+	propose   *stepTimeout
+	prevote   *stepTimeout
+	precommit *stepTimeout
+}
+
+func newAdaptiveTimeouts(config *cfg.ConsensusConfig, params types.TimeoutParams) *adaptiveTimeouts {
+	return &adaptiveTimeouts{
+		config:    config,
+		params:    params,
+		propose:   newStepTimeout(config.TimeoutPropose),
+		prevote:   newStepTimeout(config.TimeoutPrevote),
+		precommit: newStepTimeout(config.TimeoutPrecommit),
+	}
+}
+
+// setParams sets the deltas of the timeouts, which the application may have
+// changed.
+func (at *adaptiveTimeouts) setParams(params types.TimeoutParams) {
+	if at == nil {
+		return
+	}
+	at.params = params
+}
+
+// Propose returns the amount of time to wait for a proposal.
+func (at *adaptiveTimeouts) Propose(round int) time.Duration {
+	return at.params.ProposeTimeout(at.timeout(at.propose), round)
+}
+
+// Prevote returns the amount of time to wait for straggler votes after
+// receiving any +2/3 prevotes.
+func (at *adaptiveTimeouts) Prevote(round int) time.Duration {
+	return at.params.PrevoteTimeout(at.timeout(at.prevote), round)
+}
+
+// Precommit returns the amount of time to wait for straggler votes after
+// receiving any +2/3 precommits.
+func (at *adaptiveTimeouts) Precommit(round int) time.Duration {
+	return at.params.PrecommitTimeout(at.timeout(at.precommit), round)
+}
+
+func (at *adaptiveTimeouts) timeout(st *stepTimeout) time.Duration {
+	return st.timeout(at.config.AdaptiveTimeoutFloor, at.config.AdaptiveTimeoutCeiling)
+}
+
+func (at *adaptiveTimeouts) stepTimeout(step cstypes.RoundStepType) *stepTimeout {
+	switch step {
+	case cstypes.RoundStepPropose:
+		return at.propose
+	case cstypes.RoundStepPrevote, cstypes.RoundStepPrevoteWait:
+		return at.prevote
+	case cstypes.RoundStepPrecommit, cstypes.RoundStepPrecommitWait:
+		return at.precommit
+	default:
+		return nil
+	}
+}
+
+// enterStep records the time the step was entered.
+func (at *adaptiveTimeouts) enterStep(step cstypes.RoundStepType, now time.Time) {
+	if at == nil {
+		return
+	}
+	if st := at.stepTimeout(step); st != nil {
+		st.enter(now)
+	}
+}
+
+// completeStep adapts the timeout of the step to the time it took to
+// complete.
+func (at *adaptiveTimeouts) completeStep(step cstypes.RoundStepType, now time.Time) {
+	if at == nil {
+		return
+	}
+	if st := at.stepTimeout(step); st != nil {
+		st.observe(now)
+	}
+}
+
+// timeoutStep increases the timeout of the step, which timed out.
+func (at *adaptiveTimeouts) timeoutStep(step cstypes.RoundStepType) {
+	if at == nil {
+		return
+	}
+	if st := at.stepTimeout(step); st != nil {
+		st.timedOut(at.config.AdaptiveTimeoutCeiling)
+	}
+}
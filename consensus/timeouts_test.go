@@ -0,0 +1,82 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	cfg "github.com/tendermint/tendermint/config"
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestAdaptiveTimeouts(t *testing.T) {
+	config := cfg.DefaultConsensusConfig()
+	config.TimeoutPropose = time.Second
+	config.AdaptiveTimeoutFloor = 100 * time.Millisecond
+	config.AdaptiveTimeoutCeiling = 2 * time.Second
+	params := types.DefaultTimeoutParams()
+	params.ProposeDelta = 100 * time.Millisecond
+	at := newAdaptiveTimeouts(config, params)
+
+	// starts from the configured timeout
+	assert.Equal(t, time.Second, at.Propose(0))
+	assert.Equal(t, 1200*time.Millisecond, at.Propose(2))
+
+	// fast proposals shorten the timeout down to the floor
+	now := time.Now()
+	for i := 0; i < 50; i++ {
+		at.enterStep(cstypes.RoundStepPropose, now)
+		at.completeStep(cstypes.RoundStepPropose, now.Add(10*time.Millisecond))
+	}
+	assert.Equal(t, config.AdaptiveTimeoutFloor, at.Propose(0))
+
+	// a step is only observed once
+	at.enterStep(cstypes.RoundStepPropose, now)
+	at.completeStep(cstypes.RoundStepPropose, now.Add(10*time.Millisecond))
+	timeout := at.Propose(0)
+	at.completeStep(cstypes.RoundStepPropose, now.Add(time.Hour))
+	assert.Equal(t, timeout, at.Propose(0))
+
+	// timeouts back off up to the ceiling
+	for i := 0; i < 3; i++ {
+		at.timeoutStep(cstypes.RoundStepPropose)
+	}
+	assert.True(t, at.Propose(0) > timeout)
+	for i := 0; i < 20; i++ {
+		at.timeoutStep(cstypes.RoundStepPropose)
+	}
+	assert.Equal(t, config.AdaptiveTimeoutCeiling, at.Propose(0))
+
+	// the other steps are unaffected
+	assert.Equal(t, config.TimeoutPrevote, at.Prevote(0))
+	assert.Equal(t, config.TimeoutPrecommit, at.Precommit(0))
+
+	// the application changes the deltas
+	params.ProposeDelta = time.Second
+	at.setParams(params)
+	assert.Equal(t, config.AdaptiveTimeoutCeiling+2*time.Second, at.Propose(2))
+
+	// a nil adaptiveTimeouts ignores the steps
+	var nilTimeouts *adaptiveTimeouts
+	nilTimeouts.enterStep(cstypes.RoundStepPropose, now)
+	nilTimeouts.completeStep(cstypes.RoundStepPropose, now)
+	nilTimeouts.timeoutStep(cstypes.RoundStepPropose)
+	nilTimeouts.setParams(params)
+}
+
+func TestConfigTimeouts(t *testing.T) {
+	config := cfg.DefaultConsensusConfig()
+	params := types.TimeoutParams{
+		ProposeDelta:   100 * time.Millisecond,
+		PrevoteDelta:   200 * time.Millisecond,
+		PrecommitDelta: 0,
+	}
+	ct := configTimeouts{config, params}
+
+	assert.Equal(t, config.TimeoutPropose, ct.Propose(0))
+	assert.Equal(t, config.TimeoutPropose+300*time.Millisecond, ct.Propose(3))
+	assert.Equal(t, config.TimeoutPrevote+600*time.Millisecond, ct.Prevote(3))
+	assert.Equal(t, config.TimeoutPrecommit, ct.Precommit(3))
+}
@@ -0,0 +1,194 @@
+package consensus
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+// TraceEventType is the type of a TraceEvent.
+type TraceEventType string
+
+// Types of the events traced by the consensus state machine.
+const (
+	TraceEnterNewRound  TraceEventType = "enter_new_round"
+	TraceEnterPropose   TraceEventType = "enter_propose"
+	TraceEnterPrevote   TraceEventType = "enter_prevote"
+	TraceEnterPrecommit TraceEventType = "enter_precommit"
+	TraceEnterCommit    TraceEventType = "enter_commit"
+
+	// TraceRoundSkip is traced when the state machine moves to a later round
+	// of the height without committing in the current one.
+	TraceRoundSkip TraceEventType = "round_skip"
+
+	// TracePolka is traced when +2/3 prevotes for a block or nil are first
+	// seen in a round.
+	TracePolka TraceEventType = "polka"
+)
+
+// Reasons of the traced events.
+const (
+	// enter_prevote
+	TraceReasonProposalComplete = "proposal_complete"
+	TraceReasonTimeoutPropose   = "timeout_propose"
+
+	// enter_precommit
+	TraceReasonPolka          = "polka"
+	TraceReasonTimeoutPrevote = "timeout_prevote"
+	TraceReasonPrecommits     = "precommits"
+
+	// round_skip
+	TraceReasonTimeoutPrecommit = "timeout_precommit"
+	TraceReasonPrevotesAhead    = "prevotes_ahead"
+	TraceReasonPrecommitsAhead  = "precommits_ahead"
+)
+
+// TraceEvent is a step of the consensus state machine, with what caused it.
+type TraceEvent struct {
+	Type   TraceEventType `json:"type"`
+	Time   time.Time      `json:"time"`
+	Height int64          `json:"height"`
+	Round  int            `json:"round"`
+
+	// Why the step was taken, see the TraceReason constants.
+	Reason string `json:"reason,omitempty"`
+
+	// The round a round skip is from.
+	FromRound int `json:"from_round"`
+
+	// The proposer of the round, for enter_propose.
+	Proposer types.Address `json:"proposer,omitempty"`
+
+	// The block of a polka, or of the polka the precommit step was entered
+	// on. Empty for nil.
+	BlockID types.BlockID `json:"block_id"`
+}
+
+// Tracer receives the TraceEvents of the consensus state machine.
+//
+// NOTE: Trace is called by the receiveRoutine, while the state is locked,
+// so it must not block.
+type Tracer interface {
+	Trace(ev TraceEvent)
+}
+
+// NopTracer ignores the events.
+type NopTracer struct{}
+
+var _ Tracer = NopTracer{}
+
+// Trace implements Tracer.
+func (NopTracer) Trace(ev TraceEvent) {}
+
+// StateTracer adds a tracer of the state machine, e.g. an exporter.
+func StateTracer(tracer Tracer) StateOption {
+	return func(cs *ConsensusState) { cs.tracers.add(tracer) }
+}
+
+// SubscribeTrace returns a subscription to the trace events of the state
+// machine. Events are dropped while the subscription has capacity events not
+// yet read.
+func (cs *ConsensusState) SubscribeTrace(capacity int) *TraceSubscription {
+	sub := &TraceSubscription{out: make(chan TraceEvent, capacity)}
+	cs.tracers.add(sub)
+	return sub
+}
+
+// UnsubscribeTrace cancels the subscription, closing its channel.
+func (cs *ConsensusState) UnsubscribeTrace(sub *TraceSubscription) {
+	if cs.tracers.remove(sub) {
+		close(sub.out)
+	}
+}
+
+// TraceSubscription is an in-process subscription to the trace events of the
+// state machine, see ConsensusState.SubscribeTrace.
+type TraceSubscription struct {
+	out     chan TraceEvent
+	dropped int64
+}
+
+var _ Tracer = (*TraceSubscription)(nil)
+
+// Out returns the channel of the events. It's closed on unsubscribe.
+func (sub *TraceSubscription) Out() <-chan TraceEvent {
+	return sub.out
+}
+
+// Dropped returns the number of events dropped as the subscription was full.
+func (sub *TraceSubscription) Dropped() int64 {
+	return atomic.LoadInt64(&sub.dropped)
+}
+
+// Trace implements Tracer.
+func (sub *TraceSubscription) Trace(ev TraceEvent) {
+	select {
+	case sub.out <- ev:
+	default:
+		atomic.AddInt64(&sub.dropped, 1)
+	}
+}
+
+// traceHub sends the events to the tracers and subscriptions of the state.
+type traceHub struct {
+	mtx     sync.RWMutex
+	tracers []Tracer
+}
+
+func (th *traceHub) add(tracer Tracer) {
+	th.mtx.Lock()
+	th.tracers = append(th.tracers, tracer)
+	th.mtx.Unlock()
+}
+
+func (th *traceHub) remove(tracer Tracer) bool {
+	th.mtx.Lock()
+	defer th.mtx.Unlock()
+	for i, t := range th.tracers {
+		if t == tracer {
+			th.tracers = append(th.tracers[:i:i], th.tracers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (th *traceHub) enabled() bool {
+	th.mtx.RLock()
+	defer th.mtx.RUnlock()
+	return len(th.tracers) > 0
+}
+
+func (th *traceHub) trace(ev TraceEvent) {
+	th.mtx.RLock()
+	defer th.mtx.RUnlock()
+	for _, tracer := range th.tracers {
+		tracer.Trace(ev)
+	}
+}
+
+//-----------------------------------------------------------------------------
+// ConsensusState tracing
+
+// trace sends an event of the current height to the tracers.
+func (cs *ConsensusState) trace(ev TraceEvent) {
+	if !cs.tracers.enabled() {
+		return
+	}
+	ev.Time = tmtime.Now()
+	ev.Height = cs.Height
+	cs.tracers.trace(ev)
+}
+
+// traceRoundSkip traces the state machine moving from the current round to
+// the given one, and counts it.
+func (cs *ConsensusState) traceRoundSkip(round int, reason string) {
+	if round <= cs.Round {
+		return
+	}
+	cs.metrics.RoundSkips.With("reason", reason).Add(1)
+	cs.trace(TraceEvent{Type: TraceRoundSkip, Round: round, FromRound: cs.Round, Reason: reason})
+}
@@ -0,0 +1,89 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ensureTraceEvent(t *testing.T, sub *TraceSubscription, typ TraceEventType, height int64, round int) TraceEvent {
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case ev := <-sub.Out():
+			if ev.Type != typ {
+				continue
+			}
+			require.Equal(t, height, ev.Height, "height of %v", typ)
+			require.Equal(t, round, ev.Round, "round of %v", typ)
+			return ev
+		case <-timeout:
+			t.Fatalf("timeout waiting for the %v trace event", typ)
+		}
+	}
+}
+
+func TestTraceFullRound(t *testing.T) {
+	cs1, _ := randConsensusState(1)
+	height, round := cs1.Height, cs1.Round
+
+	sub := cs1.SubscribeTrace(100)
+	defer cs1.UnsubscribeTrace(sub)
+
+	startTestRound(cs1, height, round)
+
+	ensureTraceEvent(t, sub, TraceEnterNewRound, height, round)
+	ev := ensureTraceEvent(t, sub, TraceEnterPropose, height, round)
+	assert.Equal(t, cs1.Validators.GetProposer().Address, ev.Proposer)
+	ev = ensureTraceEvent(t, sub, TraceEnterPrevote, height, round)
+	assert.Equal(t, TraceReasonProposalComplete, ev.Reason)
+	polka := ensureTraceEvent(t, sub, TracePolka, height, round)
+	assert.NotEmpty(t, polka.BlockID.Hash)
+	ev = ensureTraceEvent(t, sub, TraceEnterPrecommit, height, round)
+	assert.Equal(t, TraceReasonPolka, ev.Reason)
+	assert.Equal(t, polka.BlockID, ev.BlockID)
+	ensureTraceEvent(t, sub, TraceEnterCommit, height, round)
+	ensureTraceEvent(t, sub, TraceEnterNewRound, height+1, 0)
+}
+
+func TestTraceRoundSkip(t *testing.T) {
+	cs1, _ := randConsensusState(1)
+	height, round := cs1.Height, cs1.Round
+
+	sub := cs1.SubscribeTrace(100)
+	defer cs1.UnsubscribeTrace(sub)
+
+	// Without a proposal, prevote and precommit nil, and move to the next
+	// round once timeout_precommit fires.
+	cs1.enterPrevote(height, round)
+	cs1.startRoutines(0)
+
+	ev := ensureTraceEvent(t, sub, TraceEnterPrevote, height, round)
+	assert.Equal(t, TraceReasonTimeoutPropose, ev.Reason)
+	polka := ensureTraceEvent(t, sub, TracePolka, height, round)
+	assert.Empty(t, polka.BlockID.Hash)
+	ensureTraceEvent(t, sub, TraceEnterPrecommit, height, round)
+	ev = ensureTraceEvent(t, sub, TraceRoundSkip, height, round+1)
+	assert.Equal(t, TraceReasonTimeoutPrecommit, ev.Reason)
+	assert.Equal(t, round, ev.FromRound)
+	ensureTraceEvent(t, sub, TraceEnterNewRound, height, round+1)
+}
+
+func TestTraceSubscription(t *testing.T) {
+	var th traceHub
+	sub := &TraceSubscription{out: make(chan TraceEvent, 1)}
+	th.add(NopTracer{})
+	th.add(sub)
+
+	th.trace(TraceEvent{Type: TraceEnterNewRound})
+	th.trace(TraceEvent{Type: TraceEnterPropose})
+	assert.EqualValues(t, 1, sub.Dropped())
+	assert.Equal(t, TraceEnterNewRound, (<-sub.Out()).Type)
+
+	assert.True(t, th.remove(sub))
+	assert.False(t, th.remove(sub))
+	th.trace(TraceEvent{Type: TraceEnterPrevote})
+	assert.Len(t, sub.Out(), 0)
+}
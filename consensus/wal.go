@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/golang/snappy"
 	"github.com/pkg/errors"
 
 	amino "github.com/tendermint/go-amino"
@@ -24,6 +25,9 @@ const (
 
 	// how often the WAL should be sync'd during period sync'ing
 	walDefaultFlushInterval = 2 * time.Second
+
+	// set in the length of the compressed entries
+	walCompressedFlag = uint32(1) << 31
 )
 
 //--------------------------------------------------------
@@ -81,6 +85,17 @@ type baseWAL struct {
 
 	flushTicker   *time.Ticker
 	flushInterval time.Duration
+
+	// the files needed to replay the last retainHeights heights are kept, the
+	// older ones removed. 0 keeps all the files.
+	retainHeights int64
+	// group indexes of the last EndHeightMessages written
+	endHeights []walEndHeight
+}
+
+type walEndHeight struct {
+	height int64
+	index  int
 }
 
 var _ WAL = &baseWAL{}
@@ -111,6 +126,19 @@ func (wal *baseWAL) SetFlushInterval(i time.Duration) {
 	wal.flushInterval = i
 }
 
+// SetCompression enables or disables the snappy compression of the messages
+// written. Compressed and uncompressed messages can be read either way.
+func (wal *baseWAL) SetCompression(compress bool) {
+	wal.enc.compress = compress
+}
+
+// SetRetainHeights sets the number of heights whose messages are kept in the
+// WAL. Once a height ends, the files holding only older messages are removed.
+// 0 keeps all the files, within the size limit of the group.
+func (wal *baseWAL) SetRetainHeights(heights int64) {
+	wal.retainHeights = heights
+}
+
 func (wal *baseWAL) Group() *auto.Group {
 	return wal.group
 }
@@ -183,6 +211,25 @@ func (wal *baseWAL) Write(msg WALMessage) {
 	if err := wal.enc.Encode(&TimedWALMessage{tmtime.Now(), msg}); err != nil {
 		panic(fmt.Sprintf("Error writing msg to consensus wal: %v \n\nMessage: %v", err, msg))
 	}
+
+	if m, ok := msg.(EndHeightMessage); ok && wal.retainHeights > 0 {
+		wal.pruneBefore(m.Height)
+	}
+}
+
+// pruneBefore removes the files of the group which are not needed to replay
+// the last retainHeights heights, up to the given ending height. Replaying
+// height h starts from the EndHeightMessage of h-1, so the files before the
+// one holding it are removed.
+func (wal *baseWAL) pruneBefore(height int64) {
+	wal.endHeights = append(wal.endHeights, walEndHeight{height, wal.group.MaxIndex()})
+	if int64(len(wal.endHeights)) <= wal.retainHeights {
+		return
+	}
+	wal.endHeights = wal.endHeights[int64(len(wal.endHeights))-wal.retainHeights-1:]
+	if err := wal.group.RemoveFilesBefore(wal.endHeights[0].index); err != nil {
+		wal.Logger.Error("Failed to remove old WAL files", "err", err)
+	}
 }
 
 // WriteSync is called when we receive a msg from ourselves
@@ -266,14 +313,23 @@ func (wal *baseWAL) SearchForEndHeight(height int64, options *WALSearchOptions)
 
 // A WALEncoder writes custom-encoded WAL messages to an output stream.
 //
-// Format: 4 bytes CRC sum + 4 bytes length + arbitrary-length value (go-amino encoded)
+// Format: 4 bytes CRC sum + 4 bytes length + arbitrary-length value (go-amino
+// encoded). If the compression is enabled, the value is snappy-compressed
+// when it makes it smaller, and the highest bit of the length is set.
 type WALEncoder struct {
-	wr io.Writer
+	wr       io.Writer
+	compress bool
 }
 
 // NewWALEncoder returns a new encoder that writes to wr.
 func NewWALEncoder(wr io.Writer) *WALEncoder {
-	return &WALEncoder{wr}
+	return &WALEncoder{wr: wr}
+}
+
+// NewCompressedWALEncoder returns a new encoder that writes to wr, compressing
+// the messages.
+func NewCompressedWALEncoder(wr io.Writer) *WALEncoder {
+	return &WALEncoder{wr: wr, compress: true}
 }
 
 // Encode writes the custom encoding of v to the stream. It returns an error if
@@ -282,16 +338,24 @@ func NewWALEncoder(wr io.Writer) *WALEncoder {
 func (enc *WALEncoder) Encode(v *TimedWALMessage) error {
 	data := cdc.MustMarshalBinaryBare(v)
 
+	if len(data) > maxMsgSizeBytes {
+		return fmt.Errorf("Msg is too big: %d bytes, max: %d bytes", len(data), maxMsgSizeBytes)
+	}
+	flag := uint32(0)
+	if enc.compress {
+		if compressed := snappy.Encode(nil, data); len(compressed) < len(data) {
+			data = compressed
+			flag = walCompressedFlag
+		}
+	}
+
 	crc := crc32.Checksum(data, crc32c)
 	length := uint32(len(data))
-	if length > maxMsgSizeBytes {
-		return fmt.Errorf("Msg is too big: %d bytes, max: %d bytes", length, maxMsgSizeBytes)
-	}
 	totalLength := 8 + int(length)
 
 	msg := make([]byte, totalLength)
 	binary.BigEndian.PutUint32(msg[0:4], crc)
-	binary.BigEndian.PutUint32(msg[4:8], length)
+	binary.BigEndian.PutUint32(msg[4:8], length|flag)
 	copy(msg[8:], data)
 
 	_, err := enc.wr.Write(msg)
@@ -353,6 +417,8 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 		return nil, DataCorruptionError{fmt.Errorf("failed to read length: %v", err)}
 	}
 	length := binary.BigEndian.Uint32(b)
+	compressed := length&walCompressedFlag != 0
+	length &^= walCompressedFlag
 
 	if length > maxMsgSizeBytes {
 		return nil, DataCorruptionError{fmt.Errorf("length %d exceeded maximum possible value of %d bytes", length, maxMsgSizeBytes)}
@@ -370,6 +436,15 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 		return nil, DataCorruptionError{fmt.Errorf("checksums do not match: read: %v, actual: %v", crc, actualCRC)}
 	}
 
+	if compressed {
+		if n, err := snappy.DecodedLen(data); err != nil || n > maxMsgSizeBytes {
+			return nil, DataCorruptionError{fmt.Errorf("invalid compressed data (length: %d, err: %v)", n, err)}
+		}
+		if data, err = snappy.Decode(nil, data); err != nil {
+			return nil, DataCorruptionError{fmt.Errorf("failed to decompress data: %v", err)}
+		}
+	}
+
 	var res = new(TimedWALMessage) // nolint: gosimple
 	err = cdc.UnmarshalBinaryBare(data, res)
 	if err != nil {
@@ -0,0 +1,161 @@
+package consensus
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	auto "github.com/tendermint/tendermint/libs/autofile"
+	cmn "github.com/tendermint/tendermint/libs/common"
+)
+
+// WALFileInfo describes a file of a consensus WAL, as read by CheckWAL.
+type WALFileInfo struct {
+	Path     string
+	Size     int64
+	Messages int
+	// Height of the last EndHeightMessage of the file, -1 if none.
+	LastHeight int64
+	// Offset of the first corrupted entry of the file, -1 if none.
+	CorruptedAt int64
+	// Error reading the corrupted entry.
+	Err error
+}
+
+// Corrupted returns true if the file has a corrupted entry.
+func (info WALFileInfo) Corrupted() bool {
+	return info.CorruptedAt >= 0
+}
+
+// countingReader counts the bytes read from rd.
+type countingReader struct {
+	rd io.Reader
+	n  int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.rd.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// CheckWAL reads the files of the WAL with the given head file, oldest first,
+// and describes them. The entries of a file are read up to the first
+// corrupted one, if any.
+func CheckWAL(walFile string) ([]WALFileInfo, error) {
+	group, err := auto.OpenGroup(walFile)
+	if err != nil {
+		return nil, err
+	}
+	defer group.Close()
+
+	var infos []WALFileInfo
+	for index := group.MinIndex(); index <= group.MaxIndex(); index++ {
+		info, err := checkWALFile(group.FilePath(index))
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func checkWALFile(path string) (WALFileInfo, error) {
+	info := WALFileInfo{Path: path, LastHeight: -1, CorruptedAt: -1}
+	f, err := os.Open(path)
+	if err != nil {
+		return info, err
+	}
+	defer f.Close()
+	fInfo, err := f.Stat()
+	if err != nil {
+		return info, err
+	}
+	info.Size = fInfo.Size()
+
+	cr := &countingReader{rd: f}
+	dec := NewWALDecoder(cr)
+	for {
+		offset := cr.n
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			return info, nil
+		} else if err != nil {
+			info.CorruptedAt = offset
+			info.Err = err
+			return info, nil
+		}
+		info.Messages++
+		if m, ok := msg.Msg.(EndHeightMessage); ok {
+			info.LastHeight = m.Height
+		}
+	}
+}
+
+// RepairWAL truncates the WAL with the given head file before its first
+// corrupted entry, and removes the files written after it, as the messages
+// they hold can't be replayed in order anymore. The original files are moved
+// to backupDir first. It returns the files repaired or removed, none if the
+// WAL isn't corrupted.
+//
+// NOTE: the node must be stopped.
+func RepairWAL(walFile, backupDir string) ([]string, error) {
+	infos, err := CheckWAL(walFile)
+	if err != nil {
+		return nil, err
+	}
+	first := -1
+	for i, info := range infos {
+		if info.Corrupted() {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return nil, nil
+	}
+
+	if err := cmn.EnsureDir(backupDir, 0700); err != nil {
+		return nil, errors.Wrap(err, "failed to create the backup directory")
+	}
+	var paths []string
+	for i := len(infos) - 1; i >= first; i-- {
+		path := infos[i].Path
+		backup := filepath.Join(backupDir, filepath.Base(path))
+		if err := os.Rename(path, backup); err != nil {
+			return paths, errors.Wrapf(err, "failed to back up %s", path)
+		}
+		paths = append(paths, path)
+		if i > first {
+			continue
+		}
+		// Keep the entries before the corrupted one.
+		if err := copyFilePrefix(backup, path, infos[i].CorruptedAt); err != nil {
+			return paths, errors.Wrapf(err, "failed to truncate %s", path)
+		}
+	}
+	return paths, nil
+}
+
+func copyFilePrefix(src, dst string, size int64) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.CopyN(out, in, size); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -3,9 +3,11 @@ package consensus
 import (
 	"bytes"
 	"crypto/rand"
+	"encoding/binary"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	// "sync"
 	"testing"
@@ -101,6 +103,107 @@ func TestWALEncoderDecoder(t *testing.T) {
 	}
 }
 
+func TestWALEncoderDecoderCompressed(t *testing.T) {
+	now := tmtime.Now()
+	msgs := []struct {
+		msg        TimedWALMessage
+		compressed bool
+	}{
+		{TimedWALMessage{Time: now, Msg: EndHeightMessage{0}}, false},
+		{TimedWALMessage{Time: now, Msg: tmtypes.EventDataRoundState{Height: 1, Step: strings.Repeat("a", 1000)}}, true},
+	}
+
+	b := new(bytes.Buffer)
+
+	for _, tc := range msgs {
+		b.Reset()
+
+		enc := NewCompressedWALEncoder(b)
+		err := enc.Encode(&tc.msg)
+		require.NoError(t, err)
+
+		length := binary.BigEndian.Uint32(b.Bytes()[4:8])
+		assert.Equal(t, tc.compressed, length&walCompressedFlag != 0)
+
+		dec := NewWALDecoder(b)
+		decoded, err := dec.Decode()
+		require.NoError(t, err)
+
+		assert.Equal(t, tc.msg.Time.UTC(), decoded.Time)
+		assert.Equal(t, tc.msg.Msg, decoded.Msg)
+	}
+}
+
+func TestWALRetainHeights(t *testing.T) {
+	walDir, err := ioutil.TempDir("", "wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(walDir)
+	walFile := filepath.Join(walDir, "wal")
+
+	wal, err := NewWAL(walFile)
+	require.NoError(t, err)
+	wal.SetLogger(log.TestingLogger())
+	wal.SetRetainHeights(2)
+	defer wal.Group().Close()
+
+	// one height per file
+	for height := int64(1); height <= 5; height++ {
+		wal.WriteSync(EndHeightMessage{height})
+		wal.Group().RotateFile()
+	}
+
+	// the files needed to replay heights 4 and 5, from the end of height 3,
+	// are kept
+	assert.Equal(t, 2, wal.Group().MinIndex())
+	for h, found := range map[int64]bool{2: false, 3: true, 5: true} {
+		gr, ok, err := wal.SearchForEndHeight(h, &WALSearchOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, found, ok, "height %d", h)
+		if gr != nil {
+			gr.Close()
+		}
+	}
+}
+
+func TestCheckAndRepairWAL(t *testing.T) {
+	walBody, err := WALWithNBlocks(t, 3)
+	require.NoError(t, err)
+	walDir, err := ioutil.TempDir("", "wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(walDir)
+	walFile := filepath.Join(walDir, "wal")
+
+	// a partially written entry at the end
+	require.NoError(t, ioutil.WriteFile(walFile, append(walBody, 0x01, 0x02, 0x03, 0x04, 0x00), 0600))
+
+	infos, err := CheckWAL(walFile)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.True(t, infos[0].Corrupted())
+	assert.Equal(t, int64(len(walBody)), infos[0].CorruptedAt)
+	assert.EqualValues(t, 2, infos[0].LastHeight) // the last end height is excluded
+
+	backupDir := filepath.Join(walDir, "backup")
+	repaired, err := RepairWAL(walFile, backupDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{walFile}, repaired)
+
+	infos, err = CheckWAL(walFile)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.False(t, infos[0].Corrupted())
+	assert.Equal(t, int64(len(walBody)), infos[0].Size)
+
+	backup, err := ioutil.ReadFile(filepath.Join(backupDir, "wal"))
+	require.NoError(t, err)
+	assert.Len(t, backup, len(walBody)+5)
+
+	// nothing to repair
+	repaired, err = RepairWAL(walFile, backupDir)
+	require.NoError(t, err)
+	assert.Empty(t, repaired)
+}
+
 func TestWALWritePanicsIfMsgIsTooBig(t *testing.T) {
 	walDir, err := ioutil.TempDir("", "wal")
 	require.NoError(t, err)
@@ -0,0 +1,599 @@
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+
+	"golang.org/x/crypto/ripemd160"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+)
+
+// The ICS-23 CommitmentProofs (see commitment.proto) are verified against a
+// ProofSpec, which describes the structure of the tree.
+
+var (
+	// IavlSpec is the ProofSpec of the IAVL trees.
+	IavlSpec = &ProofSpec{
+		LeafSpec: &LeafOp{
+			Prefix:       []byte{0},
+			Hash:         HashOp_SHA256,
+			PrehashKey:   HashOp_NO_HASH,
+			PrehashValue: HashOp_SHA256,
+			Length:       LengthOp_VAR_PROTO,
+		},
+		InnerSpec: &InnerSpec{
+			ChildOrder:      []int32{0, 1},
+			MinPrefixLength: 4,
+			MaxPrefixLength: 12,
+			ChildSize:       33, // including the length prefix
+			Hash:            HashOp_SHA256,
+		},
+	}
+
+	// SimpleSpec is the ProofSpec of the simple Merkle trees built by
+	// SimpleHashFromMap (see SimpleExistenceProof).
+	SimpleSpec = &ProofSpec{
+		LeafSpec: &LeafOp{
+			Prefix:       leafPrefix,
+			Hash:         HashOp_SHA256,
+			PrehashKey:   HashOp_NO_HASH,
+			PrehashValue: HashOp_SHA256,
+			Length:       LengthOp_VAR_PROTO,
+		},
+		InnerSpec: &InnerSpec{
+			ChildOrder:      []int32{0, 1},
+			MinPrefixLength: 1,
+			MaxPrefixLength: 1,
+			ChildSize:       32,
+			Hash:            HashOp_SHA256,
+		},
+	}
+)
+
+//----------------------------------------
+// CommitmentProof
+
+// Calculate returns the root hash the proof commits to.
+func (p *CommitmentProof) Calculate() ([]byte, error) {
+	switch {
+	case p.GetExist() != nil:
+		return p.GetExist().Calculate()
+	case p.GetNonexist() != nil:
+		return p.GetNonexist().Calculate()
+	case p.GetBatch() != nil:
+		entries := p.GetBatch().Entries
+		if len(entries) == 0 || entries[0] == nil {
+			return nil, cmn.NewError("batch proof has no entries")
+		}
+		if exist := entries[0].GetExist(); exist != nil {
+			return exist.Calculate()
+		}
+		if nonexist := entries[0].GetNonexist(); nonexist != nil {
+			return nonexist.Calculate()
+		}
+		return nil, cmn.NewError("batch proof has an empty entry")
+	default:
+		return nil, cmn.NewError("empty commitment proof")
+	}
+}
+
+// VerifyMembership returns nil if the proof proves that the key/value pair is
+// in the tree of the given root. The proof is either an ExistenceProof, or a
+// BatchProof holding the ExistenceProof of the key.
+func VerifyMembership(spec *ProofSpec, root []byte, proof *CommitmentProof, key, value []byte) error {
+	exist := getExistProofForKey(proof, key)
+	if exist == nil {
+		return cmn.NewError("no existence proof for key %X", key)
+	}
+	return exist.Verify(spec, root, key, value)
+}
+
+// VerifyNonMembership returns nil if the proof proves that the key isn't in
+// the tree of the given root. The proof is either a NonExistenceProof, or a
+// BatchProof holding the NonExistenceProof of the key.
+func VerifyNonMembership(spec *ProofSpec, root []byte, proof *CommitmentProof, key []byte) error {
+	nonexist := getNonExistProofForKey(proof, key)
+	if nonexist == nil {
+		return cmn.NewError("no non-existence proof for key %X", key)
+	}
+	return nonexist.Verify(spec, root, key)
+}
+
+// BatchVerifyMembership returns nil if the BatchProof proves that all the
+// key/value pairs are in the tree of the given root.
+func BatchVerifyMembership(spec *ProofSpec, root []byte, proof *CommitmentProof, items map[string][]byte) error {
+	for key, value := range items {
+		if err := VerifyMembership(spec, root, proof, []byte(key), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BatchVerifyNonMembership returns nil if the BatchProof proves that none of
+// the keys are in the tree of the given root.
+func BatchVerifyNonMembership(spec *ProofSpec, root []byte, proof *CommitmentProof, keys [][]byte) error {
+	for _, key := range keys {
+		if err := VerifyNonMembership(spec, root, proof, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getExistProofForKey(proof *CommitmentProof, key []byte) *ExistenceProof {
+	if proof == nil {
+		return nil
+	}
+	if exist := proof.GetExist(); exist != nil {
+		if bytes.Equal(exist.Key, key) {
+			return exist
+		}
+		return nil
+	}
+	if batch := proof.GetBatch(); batch != nil {
+		for _, entry := range batch.Entries {
+			if exist := entry.GetExist(); exist != nil && bytes.Equal(exist.Key, key) {
+				return exist
+			}
+		}
+	}
+	return nil
+}
+
+func getNonExistProofForKey(proof *CommitmentProof, key []byte) *NonExistenceProof {
+	if proof == nil {
+		return nil
+	}
+	if nonexist := proof.GetNonexist(); nonexist != nil {
+		if bytes.Equal(nonexist.Key, key) {
+			return nonexist
+		}
+		return nil
+	}
+	if batch := proof.GetBatch(); batch != nil {
+		for _, entry := range batch.Entries {
+			if nonexist := entry.GetNonexist(); nonexist != nil && bytes.Equal(nonexist.Key, key) {
+				return nonexist
+			}
+		}
+	}
+	return nil
+}
+
+// BatchCommitmentProof combines the existence and non-existence proofs of
+// many keys into a single CommitmentProof.
+func BatchCommitmentProof(proofs ...*CommitmentProof) (*CommitmentProof, error) {
+	entries := make([]*BatchEntry, 0, len(proofs))
+	for i, p := range proofs {
+		switch {
+		case p.GetExist() != nil:
+			entries = append(entries, &BatchEntry{Proof: &BatchEntry_Exist{Exist: p.GetExist()}})
+		case p.GetNonexist() != nil:
+			entries = append(entries, &BatchEntry{Proof: &BatchEntry_Nonexist{Nonexist: p.GetNonexist()}})
+		case p.GetBatch() != nil:
+			entries = append(entries, p.GetBatch().Entries...)
+		default:
+			return nil, cmn.NewError("empty commitment proof #%d", i)
+		}
+	}
+	return &CommitmentProof{Proof: &CommitmentProof_Batch{Batch: &BatchProof{Entries: entries}}}, nil
+}
+
+//----------------------------------------
+// ExistenceProof
+
+// Calculate returns the root hash of the tree holding the key/value pair.
+func (p *ExistenceProof) Calculate() ([]byte, error) {
+	if p.Leaf == nil {
+		return nil, cmn.NewError("existence proof must have a leaf")
+	}
+	res, err := p.Leaf.Apply(p.Key, p.Value)
+	if err != nil {
+		return nil, cmn.ErrorWrap(err, "leaf")
+	}
+	for i, step := range p.Path {
+		res, err = step.Apply(res)
+		if err != nil {
+			return nil, cmn.ErrorWrap(err, "inner #%d", i)
+		}
+	}
+	return res, nil
+}
+
+// Verify returns nil if the proof, which matches the spec, proves the key/value
+// pair is in the tree of the given root.
+func (p *ExistenceProof) Verify(spec *ProofSpec, root, key, value []byte) error {
+	if err := p.CheckAgainstSpec(spec); err != nil {
+		return err
+	}
+	if !bytes.Equal(key, p.Key) {
+		return cmn.NewError("provided key doesn't match proof: expected %X but got %X", key, p.Key)
+	}
+	if !bytes.Equal(value, p.Value) {
+		return cmn.NewError("provided value doesn't match proof: expected %X but got %X", value, p.Value)
+	}
+	calc, err := p.Calculate()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(root, calc) {
+		return cmn.NewError("calculated root hash is invalid: expected %X but got %X", root, calc)
+	}
+	return nil
+}
+
+// CheckAgainstSpec returns nil if the leaf and the inner nodes of the proof
+// are shaped as the spec requires.
+func (p *ExistenceProof) CheckAgainstSpec(spec *ProofSpec) error {
+	if spec == nil || spec.LeafSpec == nil || spec.InnerSpec == nil {
+		return cmn.NewError("incomplete proof spec")
+	}
+	if p.Leaf == nil {
+		return cmn.NewError("existence proof must have a leaf")
+	}
+	if err := p.Leaf.CheckAgainstSpec(spec); err != nil {
+		return cmn.ErrorWrap(err, "leaf")
+	}
+	if spec.MinDepth > 0 && len(p.Path) < int(spec.MinDepth) {
+		return cmn.NewError("path too short: %d < %d", len(p.Path), spec.MinDepth)
+	}
+	if spec.MaxDepth > 0 && len(p.Path) > int(spec.MaxDepth) {
+		return cmn.NewError("path too long: %d > %d", len(p.Path), spec.MaxDepth)
+	}
+	for i, step := range p.Path {
+		if err := step.CheckAgainstSpec(spec); err != nil {
+			return cmn.ErrorWrap(err, "inner #%d", i)
+		}
+	}
+	return nil
+}
+
+//----------------------------------------
+// NonExistenceProof
+
+// Calculate returns the root hash of the tree holding the neighbours.
+func (p *NonExistenceProof) Calculate() ([]byte, error) {
+	switch {
+	case p.Left != nil:
+		return p.Left.Calculate()
+	case p.Right != nil:
+		return p.Right.Calculate()
+	default:
+		return nil, cmn.NewError("non-existence proof must have a left or a right proof")
+	}
+}
+
+// Verify returns nil if the proof, which matches the spec, proves the key
+// isn't in the tree of the given root: its neighbours are in the tree, and
+// they're next to each other.
+func (p *NonExistenceProof) Verify(spec *ProofSpec, root, key []byte) error {
+	if !bytes.Equal(key, p.Key) {
+		return cmn.NewError("provided key doesn't match proof: expected %X but got %X", key, p.Key)
+	}
+	if p.Left == nil && p.Right == nil {
+		return cmn.NewError("non-existence proof must have a left or a right proof")
+	}
+	if p.Left != nil {
+		if err := p.Left.Verify(spec, root, p.Left.Key, p.Left.Value); err != nil {
+			return cmn.ErrorWrap(err, "left proof")
+		}
+		if bytes.Compare(p.Left.Key, key) >= 0 {
+			return cmn.NewError("left key (%X) isn't before the key (%X)", p.Left.Key, key)
+		}
+	}
+	if p.Right != nil {
+		if err := p.Right.Verify(spec, root, p.Right.Key, p.Right.Value); err != nil {
+			return cmn.ErrorWrap(err, "right proof")
+		}
+		if bytes.Compare(key, p.Right.Key) >= 0 {
+			return cmn.NewError("right key (%X) isn't after the key (%X)", p.Right.Key, key)
+		}
+	}
+
+	switch {
+	case p.Left == nil:
+		if !isLeftMost(spec.InnerSpec, p.Right.Path) {
+			return cmn.NewError("left proof missing, but the right proof isn't the left-most")
+		}
+	case p.Right == nil:
+		if !isRightMost(spec.InnerSpec, p.Left.Path) {
+			return cmn.NewError("right proof missing, but the left proof isn't the right-most")
+		}
+	default:
+		if !isLeftNeighbor(spec.InnerSpec, p.Left.Path, p.Right.Path) {
+			return cmn.NewError("left and right proofs aren't neighbours")
+		}
+	}
+	return nil
+}
+
+// isLeftMost returns true if the path is the one of the left-most leaf, i.e.
+// every step is the left-most child.
+func isLeftMost(spec *InnerSpec, path []*InnerOp) bool {
+	minPrefix, maxPrefix, suffix := getPadding(spec, 0)
+	for _, step := range path {
+		if !hasPadding(step, minPrefix, maxPrefix, suffix) {
+			return false
+		}
+	}
+	return true
+}
+
+// isRightMost returns true if the path is the one of the right-most leaf, i.e.
+// every step is the right-most child.
+func isRightMost(spec *InnerSpec, path []*InnerOp) bool {
+	minPrefix, maxPrefix, suffix := getPadding(spec, int32(len(spec.ChildOrder)-1))
+	for _, step := range path {
+		if !hasPadding(step, minPrefix, maxPrefix, suffix) {
+			return false
+		}
+	}
+	return true
+}
+
+// isLeftNeighbor returns true if the leaves of both paths are next to each
+// other: below the node where the paths split, left is the right-most path of
+// a child and right is the left-most path of the next child.
+func isLeftNeighbor(spec *InnerSpec, left, right []*InnerOp) bool {
+	// Remove the common steps, from the root down
+	top := func(path []*InnerOp) *InnerOp { return path[len(path)-1] }
+	for len(left) > 0 && len(right) > 0 &&
+		bytes.Equal(top(left).Prefix, top(right).Prefix) && bytes.Equal(top(left).Suffix, top(right).Suffix) {
+		left, right = left[:len(left)-1], right[:len(right)-1]
+	}
+	if len(left) == 0 || len(right) == 0 {
+		return false
+	}
+
+	topLeft, topRight := top(left), top(right)
+	left, right = left[:len(left)-1], right[:len(right)-1]
+
+	leftBranch, err := orderFromPadding(spec, topLeft)
+	if err != nil {
+		return false
+	}
+	rightBranch, err := orderFromPadding(spec, topRight)
+	if err != nil {
+		return false
+	}
+	return rightBranch == leftBranch+1 && isRightMost(spec, left) && isLeftMost(spec, right)
+}
+
+// getPadding returns the prefix and suffix lengths of an inner node, whose
+// child at the branch is the one proved.
+func getPadding(spec *InnerSpec, branch int32) (minPrefix, maxPrefix, suffix int) {
+	idx := getPosition(spec.ChildOrder, branch)
+	prefix := idx * int(spec.ChildSize)
+	minPrefix = prefix + int(spec.MinPrefixLength)
+	maxPrefix = prefix + int(spec.MaxPrefixLength)
+	suffix = (len(spec.ChildOrder) - 1 - idx) * int(spec.ChildSize)
+	return
+}
+
+func getPosition(order []int32, branch int32) int {
+	for i, b := range order {
+		if b == branch {
+			return i
+		}
+	}
+	return -1
+}
+
+func hasPadding(op *InnerOp, minPrefix, maxPrefix, suffix int) bool {
+	return len(op.Prefix) >= minPrefix && len(op.Prefix) <= maxPrefix && len(op.Suffix) == suffix
+}
+
+// orderFromPadding returns the branch of the child proved by the inner node.
+func orderFromPadding(spec *InnerSpec, op *InnerOp) (int32, error) {
+	for branch := int32(0); branch < int32(len(spec.ChildOrder)); branch++ {
+		minPrefix, maxPrefix, suffix := getPadding(spec, branch)
+		if hasPadding(op, minPrefix, maxPrefix, suffix) {
+			return branch, nil
+		}
+	}
+	return 0, cmn.NewError("cannot find any valid spacing for this node")
+}
+
+//----------------------------------------
+// LeafOp and InnerOp
+
+// Apply returns the hash of the leaf holding the key/value pair.
+func (op *LeafOp) Apply(key, value []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, cmn.NewError("leaf op needs key")
+	}
+	if len(value) == 0 {
+		return nil, cmn.NewError("leaf op needs value")
+	}
+	pkey, err := prepareLeafData(op.PrehashKey, op.Length, key)
+	if err != nil {
+		return nil, cmn.ErrorWrap(err, "prehash key")
+	}
+	pvalue, err := prepareLeafData(op.PrehashValue, op.Length, value)
+	if err != nil {
+		return nil, cmn.ErrorWrap(err, "prehash value")
+	}
+	data := append(append(append([]byte{}, op.Prefix...), pkey...), pvalue...)
+	return doHash(op.Hash, data)
+}
+
+// CheckAgainstSpec returns nil if the leaf is hashed as the spec requires.
+func (op *LeafOp) CheckAgainstSpec(spec *ProofSpec) error {
+	lspec := spec.LeafSpec
+	if op.Hash != lspec.Hash {
+		return cmn.NewError("unexpected hash op: %v", op.Hash)
+	}
+	if op.PrehashKey != lspec.PrehashKey {
+		return cmn.NewError("unexpected prehash key op: %v", op.PrehashKey)
+	}
+	if op.PrehashValue != lspec.PrehashValue {
+		return cmn.NewError("unexpected prehash value op: %v", op.PrehashValue)
+	}
+	if op.Length != lspec.Length {
+		return cmn.NewError("unexpected length op: %v", op.Length)
+	}
+	if !bytes.HasPrefix(op.Prefix, lspec.Prefix) {
+		return cmn.NewError("leaf prefix doesn't start with %X", lspec.Prefix)
+	}
+	return nil
+}
+
+// Apply returns the hash of the inner node holding the child.
+func (op *InnerOp) Apply(child []byte) ([]byte, error) {
+	if len(child) == 0 {
+		return nil, cmn.NewError("inner op needs child value")
+	}
+	preimage := append(append(append([]byte{}, op.Prefix...), child...), op.Suffix...)
+	return doHash(op.Hash, preimage)
+}
+
+// CheckAgainstSpec returns nil if the inner node is hashed as the spec
+// requires. Its prefix can't be taken for the one of a leaf, so a leaf can't
+// be passed off as an inner node, or vice versa.
+func (op *InnerOp) CheckAgainstSpec(spec *ProofSpec) error {
+	if op.Hash != spec.InnerSpec.Hash {
+		return cmn.NewError("unexpected hash op: %v", op.Hash)
+	}
+	if bytes.HasPrefix(op.Prefix, spec.LeafSpec.Prefix) {
+		return cmn.NewError("inner prefix starts with %X", spec.LeafSpec.Prefix)
+	}
+	if len(op.Prefix) < int(spec.InnerSpec.MinPrefixLength) {
+		return cmn.NewError("inner prefix too short: %d", len(op.Prefix))
+	}
+	maxLeftChildBytes := (len(spec.InnerSpec.ChildOrder) - 1) * int(spec.InnerSpec.ChildSize)
+	if len(op.Prefix) > int(spec.InnerSpec.MaxPrefixLength)+maxLeftChildBytes {
+		return cmn.NewError("inner prefix too long: %d", len(op.Prefix))
+	}
+	return nil
+}
+
+func prepareLeafData(hashOp HashOp, lengthOp LengthOp, data []byte) ([]byte, error) {
+	hdata := data
+	if hashOp != HashOp_NO_HASH {
+		var err error
+		hdata, err = doHash(hashOp, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return doLengthOp(lengthOp, hdata)
+}
+
+func doHash(hashOp HashOp, preimage []byte) ([]byte, error) {
+	switch hashOp {
+	case HashOp_NO_HASH:
+		return preimage, nil
+	case HashOp_SHA256:
+		hash := sha256.Sum256(preimage)
+		return hash[:], nil
+	case HashOp_SHA512:
+		hash := sha512.Sum512(preimage)
+		return hash[:], nil
+	case HashOp_RIPEMD160:
+		hasher := ripemd160.New()
+		hasher.Write(preimage) // does not error
+		return hasher.Sum(nil), nil
+	case HashOp_BITCOIN:
+		hash := sha256.Sum256(preimage)
+		hasher := ripemd160.New()
+		hasher.Write(hash[:]) // does not error
+		return hasher.Sum(nil), nil
+	default:
+		return nil, cmn.NewError("unsupported hash op: %v", hashOp)
+	}
+}
+
+func doLengthOp(lengthOp LengthOp, data []byte) ([]byte, error) {
+	switch lengthOp {
+	case LengthOp_NO_PREFIX:
+		return data, nil
+	case LengthOp_VAR_PROTO:
+		buf := make([]byte, binary.MaxVarintLen64)
+		n := binary.PutUvarint(buf, uint64(len(data)))
+		return append(buf[:n], data...), nil
+	case LengthOp_REQUIRE_32_BYTES:
+		if len(data) != 32 {
+			return nil, cmn.NewError("data was %d bytes, not 32", len(data))
+		}
+		return data, nil
+	case LengthOp_REQUIRE_64_BYTES:
+		if len(data) != 64 {
+			return nil, cmn.NewError("data was %d bytes, not 64", len(data))
+		}
+		return data, nil
+	case LengthOp_FIXED32_BIG:
+		buf := make([]byte, 4)
+		binary.BigEndian.PutUint32(buf, uint32(len(data)))
+		return append(buf, data...), nil
+	case LengthOp_FIXED32_LITTLE:
+		buf := make([]byte, 4)
+		binary.LittleEndian.PutUint32(buf, uint32(len(data)))
+		return append(buf, data...), nil
+	case LengthOp_FIXED64_BIG:
+		buf := make([]byte, 8)
+		binary.BigEndian.PutUint64(buf, uint64(len(data)))
+		return append(buf, data...), nil
+	case LengthOp_FIXED64_LITTLE:
+		buf := make([]byte, 8)
+		binary.LittleEndian.PutUint64(buf, uint64(len(data)))
+		return append(buf, data...), nil
+	default:
+		return nil, cmn.NewError("unsupported length op: %v", lengthOp)
+	}
+}
+
+//----------------------------------------
+// Simple Merkle trees
+
+// SimpleExistenceProof converts the SimpleProof of a key/value pair of a
+// simple Merkle map (see SimpleProofsFromMap) into an ICS-23 ExistenceProof,
+// which matches SimpleSpec.
+func SimpleExistenceProof(key, value []byte, proof *SimpleProof) (*ExistenceProof, error) {
+	path, err := innerOpsFromAunts(proof.Index, proof.Total, proof.Aunts)
+	if err != nil {
+		return nil, err
+	}
+	return &ExistenceProof{
+		Key:   key,
+		Value: value,
+		Leaf:  SimpleSpec.LeafSpec,
+		Path:  path,
+	}, nil
+}
+
+// innerOpsFromAunts returns the inner nodes from the leaf up to the root, as
+// computeHashFromAunts hashes them.
+func innerOpsFromAunts(index, total int, aunts [][]byte) ([]*InnerOp, error) {
+	if index >= total || index < 0 || total <= 0 {
+		return nil, cmn.NewError("invalid index %d of %d", index, total)
+	}
+	if total == 1 {
+		if len(aunts) != 0 {
+			return nil, cmn.NewError("unexpected aunts")
+		}
+		return nil, nil
+	}
+	if len(aunts) == 0 {
+		return nil, cmn.NewError("missing aunts")
+	}
+
+	numLeft := getSplitPoint(total)
+	aunt := aunts[len(aunts)-1]
+	if index < numLeft {
+		path, err := innerOpsFromAunts(index, numLeft, aunts[:len(aunts)-1])
+		if err != nil {
+			return nil, err
+		}
+		return append(path, &InnerOp{Hash: HashOp_SHA256, Prefix: innerPrefix, Suffix: aunt}), nil
+	}
+	path, err := innerOpsFromAunts(index-numLeft, total-numLeft, aunts[:len(aunts)-1])
+	if err != nil {
+		return nil, err
+	}
+	prefix := append(append([]byte{}, innerPrefix...), aunt...)
+	return append(path, &InnerOp{Hash: HashOp_SHA256, Prefix: prefix}), nil
+}
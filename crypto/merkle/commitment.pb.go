@@ -0,0 +1,3961 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: crypto/merkle/commitment.proto
+
+package merkle
+
+import proto "github.com/gogo/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import _ "github.com/gogo/protobuf/gogoproto"
+
+import bytes "bytes"
+
+import io "io"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+// HashOp is the hashing algorithm used by a LeafOp or an InnerOp.
+type HashOp int32
+
+const (
+	HashOp_NO_HASH   HashOp = 0
+	HashOp_SHA256    HashOp = 1
+	HashOp_SHA512    HashOp = 2
+	HashOp_KECCAK    HashOp = 3
+	HashOp_RIPEMD160 HashOp = 4
+	HashOp_BITCOIN   HashOp = 5
+)
+
+var HashOp_name = map[int32]string{
+	0: "NO_HASH",
+	1: "SHA256",
+	2: "SHA512",
+	3: "KECCAK",
+	4: "RIPEMD160",
+	5: "BITCOIN",
+}
+var HashOp_value = map[string]int32{
+	"NO_HASH":   0,
+	"SHA256":    1,
+	"SHA512":    2,
+	"KECCAK":    3,
+	"RIPEMD160": 4,
+	"BITCOIN":   5,
+}
+
+func (x HashOp) String() string {
+	return proto.EnumName(HashOp_name, int32(x))
+}
+func (HashOp) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_commitment_578a0f16959dc732, []int{0}
+}
+
+// LengthOp is how the length of the key and the value of a leaf is encoded.
+type LengthOp int32
+
+const (
+	LengthOp_NO_PREFIX        LengthOp = 0
+	LengthOp_VAR_PROTO        LengthOp = 1
+	LengthOp_VAR_RLP          LengthOp = 2
+	LengthOp_FIXED32_BIG      LengthOp = 3
+	LengthOp_FIXED32_LITTLE   LengthOp = 4
+	LengthOp_FIXED64_BIG      LengthOp = 5
+	LengthOp_FIXED64_LITTLE   LengthOp = 6
+	LengthOp_REQUIRE_32_BYTES LengthOp = 7
+	LengthOp_REQUIRE_64_BYTES LengthOp = 8
+)
+
+var LengthOp_name = map[int32]string{
+	0: "NO_PREFIX",
+	1: "VAR_PROTO",
+	2: "VAR_RLP",
+	3: "FIXED32_BIG",
+	4: "FIXED32_LITTLE",
+	5: "FIXED64_BIG",
+	6: "FIXED64_LITTLE",
+	7: "REQUIRE_32_BYTES",
+	8: "REQUIRE_64_BYTES",
+}
+var LengthOp_value = map[string]int32{
+	"NO_PREFIX":        0,
+	"VAR_PROTO":        1,
+	"VAR_RLP":          2,
+	"FIXED32_BIG":      3,
+	"FIXED32_LITTLE":   4,
+	"FIXED64_BIG":      5,
+	"FIXED64_LITTLE":   6,
+	"REQUIRE_32_BYTES": 7,
+	"REQUIRE_64_BYTES": 8,
+}
+
+func (x LengthOp) String() string {
+	return proto.EnumName(LengthOp_name, int32(x))
+}
+func (LengthOp) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_commitment_578a0f16959dc732, []int{1}
+}
+
+// ExistenceProof proves that a key/value pair is in the tree: the leaf is
+// hashed with leaf, then hashed with each step of the path, up to the root.
+type ExistenceProof struct {
+	Key                  []byte     `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte     `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Leaf                 *LeafOp    `protobuf:"bytes,3,opt,name=leaf" json:"leaf,omitempty"`
+	Path                 []*InnerOp `protobuf:"bytes,4,rep,name=path" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ExistenceProof) Reset()         { *m = ExistenceProof{} }
+func (m *ExistenceProof) String() string { return proto.CompactTextString(m) }
+func (*ExistenceProof) ProtoMessage()    {}
+func (*ExistenceProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_commitment_578a0f16959dc732, []int{0}
+}
+func (m *ExistenceProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExistenceProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExistenceProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ExistenceProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExistenceProof.Merge(dst, src)
+}
+func (m *ExistenceProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExistenceProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExistenceProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExistenceProof proto.InternalMessageInfo
+
+func (m *ExistenceProof) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *ExistenceProof) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *ExistenceProof) GetLeaf() *LeafOp {
+	if m != nil {
+		return m.Leaf
+	}
+	return nil
+}
+
+func (m *ExistenceProof) GetPath() []*InnerOp {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+// NonExistenceProof proves that a key isn't in the tree, with the existence
+// proofs of its neighbours (left < key < right). One of them may be missing if
+// the key is beyond the first or the last key.
+type NonExistenceProof struct {
+	Key                  []byte          `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Left                 *ExistenceProof `protobuf:"bytes,2,opt,name=left" json:"left,omitempty"`
+	Right                *ExistenceProof `protobuf:"bytes,3,opt,name=right" json:"right,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *NonExistenceProof) Reset()         { *m = NonExistenceProof{} }
+func (m *NonExistenceProof) String() string { return proto.CompactTextString(m) }
+func (*NonExistenceProof) ProtoMessage()    {}
+func (*NonExistenceProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_commitment_578a0f16959dc732, []int{1}
+}
+func (m *NonExistenceProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NonExistenceProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NonExistenceProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *NonExistenceProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NonExistenceProof.Merge(dst, src)
+}
+func (m *NonExistenceProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *NonExistenceProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_NonExistenceProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NonExistenceProof proto.InternalMessageInfo
+
+func (m *NonExistenceProof) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *NonExistenceProof) GetLeft() *ExistenceProof {
+	if m != nil {
+		return m.Left
+	}
+	return nil
+}
+
+func (m *NonExistenceProof) GetRight() *ExistenceProof {
+	if m != nil {
+		return m.Right
+	}
+	return nil
+}
+
+// CommitmentProof is either an ExistenceProof, a NonExistenceProof or a
+// BatchProof.
+type CommitmentProof struct {
+	// Types that are valid to be assigned to Proof:
+	//	*CommitmentProof_Exist
+	//	*CommitmentProof_Nonexist
+	//	*CommitmentProof_Batch
+	Proof                isCommitmentProof_Proof `protobuf_oneof:"proof"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *CommitmentProof) Reset()         { *m = CommitmentProof{} }
+func (m *CommitmentProof) String() string { return proto.CompactTextString(m) }
+func (*CommitmentProof) ProtoMessage()    {}
+func (*CommitmentProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_commitment_578a0f16959dc732, []int{2}
+}
+func (m *CommitmentProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CommitmentProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CommitmentProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *CommitmentProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitmentProof.Merge(dst, src)
+}
+func (m *CommitmentProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *CommitmentProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitmentProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitmentProof proto.InternalMessageInfo
+
+type isCommitmentProof_Proof interface {
+	isCommitmentProof_Proof()
+	Equal(interface{}) bool
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type CommitmentProof_Exist struct {
+	Exist *ExistenceProof `protobuf:"bytes,1,opt,name=exist,oneof"`
+}
+type CommitmentProof_Nonexist struct {
+	Nonexist *NonExistenceProof `protobuf:"bytes,2,opt,name=nonexist,oneof"`
+}
+type CommitmentProof_Batch struct {
+	Batch *BatchProof `protobuf:"bytes,3,opt,name=batch,oneof"`
+}
+
+func (*CommitmentProof_Exist) isCommitmentProof_Proof()    {}
+func (*CommitmentProof_Nonexist) isCommitmentProof_Proof() {}
+func (*CommitmentProof_Batch) isCommitmentProof_Proof()    {}
+
+func (m *CommitmentProof) GetProof() isCommitmentProof_Proof {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+func (m *CommitmentProof) GetExist() *ExistenceProof {
+	if x, ok := m.GetProof().(*CommitmentProof_Exist); ok {
+		return x.Exist
+	}
+	return nil
+}
+
+func (m *CommitmentProof) GetNonexist() *NonExistenceProof {
+	if x, ok := m.GetProof().(*CommitmentProof_Nonexist); ok {
+		return x.Nonexist
+	}
+	return nil
+}
+
+func (m *CommitmentProof) GetBatch() *BatchProof {
+	if x, ok := m.GetProof().(*CommitmentProof_Batch); ok {
+		return x.Batch
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*CommitmentProof) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _CommitmentProof_OneofMarshaler, _CommitmentProof_OneofUnmarshaler, _CommitmentProof_OneofSizer, []interface{}{
+		(*CommitmentProof_Exist)(nil),
+		(*CommitmentProof_Nonexist)(nil),
+		(*CommitmentProof_Batch)(nil),
+	}
+}
+
+func _CommitmentProof_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*CommitmentProof)
+	// proof
+	switch x := m.Proof.(type) {
+	case *CommitmentProof_Exist:
+		_ = b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Exist); err != nil {
+			return err
+		}
+	case *CommitmentProof_Nonexist:
+		_ = b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Nonexist); err != nil {
+			return err
+		}
+	case *CommitmentProof_Batch:
+		_ = b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Batch); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("CommitmentProof.Proof has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _CommitmentProof_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*CommitmentProof)
+	switch tag {
+	case 1: // proof.exist
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ExistenceProof)
+		err := b.DecodeMessage(msg)
+		m.Proof = &CommitmentProof_Exist{msg}
+		return true, err
+	case 2: // proof.nonexist
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(NonExistenceProof)
+		err := b.DecodeMessage(msg)
+		m.Proof = &CommitmentProof_Nonexist{msg}
+		return true, err
+	case 3: // proof.batch
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(BatchProof)
+		err := b.DecodeMessage(msg)
+		m.Proof = &CommitmentProof_Batch{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _CommitmentProof_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*CommitmentProof)
+	// proof
+	switch x := m.Proof.(type) {
+	case *CommitmentProof_Exist:
+		s := proto.Size(x.Exist)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CommitmentProof_Nonexist:
+		s := proto.Size(x.Nonexist)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CommitmentProof_Batch:
+		s := proto.Size(x.Batch)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// LeafOp is how a leaf is hashed:
+// hash(prefix || length(prehash_key(key)) || length(prehash_value(value)))
+type LeafOp struct {
+	Hash                 HashOp   `protobuf:"varint,1,opt,name=hash,proto3,enum=merkle.HashOp" json:"hash,omitempty"`
+	PrehashKey           HashOp   `protobuf:"varint,2,opt,name=prehash_key,json=prehashKey,proto3,enum=merkle.HashOp" json:"prehash_key,omitempty"`
+	PrehashValue         HashOp   `protobuf:"varint,3,opt,name=prehash_value,json=prehashValue,proto3,enum=merkle.HashOp" json:"prehash_value,omitempty"`
+	Length               LengthOp `protobuf:"varint,4,opt,name=length,proto3,enum=merkle.LengthOp" json:"length,omitempty"`
+	Prefix               []byte   `protobuf:"bytes,5,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeafOp) Reset()         { *m = LeafOp{} }
+func (m *LeafOp) String() string { return proto.CompactTextString(m) }
+func (*LeafOp) ProtoMessage()    {}
+func (*LeafOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_commitment_578a0f16959dc732, []int{3}
+}
+func (m *LeafOp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LeafOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LeafOp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *LeafOp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeafOp.Merge(dst, src)
+}
+func (m *LeafOp) XXX_Size() int {
+	return m.Size()
+}
+func (m *LeafOp) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeafOp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeafOp proto.InternalMessageInfo
+
+func (m *LeafOp) GetHash() HashOp {
+	if m != nil {
+		return m.Hash
+	}
+	return HashOp_NO_HASH
+}
+
+func (m *LeafOp) GetPrehashKey() HashOp {
+	if m != nil {
+		return m.PrehashKey
+	}
+	return HashOp_NO_HASH
+}
+
+func (m *LeafOp) GetPrehashValue() HashOp {
+	if m != nil {
+		return m.PrehashValue
+	}
+	return HashOp_NO_HASH
+}
+
+func (m *LeafOp) GetLength() LengthOp {
+	if m != nil {
+		return m.Length
+	}
+	return LengthOp_NO_PREFIX
+}
+
+func (m *LeafOp) GetPrefix() []byte {
+	if m != nil {
+		return m.Prefix
+	}
+	return nil
+}
+
+// InnerOp is how an inner node is hashed: hash(prefix || child || suffix),
+// where prefix and suffix hold the other children.
+type InnerOp struct {
+	Hash                 HashOp   `protobuf:"varint,1,opt,name=hash,proto3,enum=merkle.HashOp" json:"hash,omitempty"`
+	Prefix               []byte   `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Suffix               []byte   `protobuf:"bytes,3,opt,name=suffix,proto3" json:"suffix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InnerOp) Reset()         { *m = InnerOp{} }
+func (m *InnerOp) String() string { return proto.CompactTextString(m) }
+func (*InnerOp) ProtoMessage()    {}
+func (*InnerOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_commitment_578a0f16959dc732, []int{4}
+}
+func (m *InnerOp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InnerOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InnerOp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *InnerOp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InnerOp.Merge(dst, src)
+}
+func (m *InnerOp) XXX_Size() int {
+	return m.Size()
+}
+func (m *InnerOp) XXX_DiscardUnknown() {
+	xxx_messageInfo_InnerOp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InnerOp proto.InternalMessageInfo
+
+func (m *InnerOp) GetHash() HashOp {
+	if m != nil {
+		return m.Hash
+	}
+	return HashOp_NO_HASH
+}
+
+func (m *InnerOp) GetPrefix() []byte {
+	if m != nil {
+		return m.Prefix
+	}
+	return nil
+}
+
+func (m *InnerOp) GetSuffix() []byte {
+	if m != nil {
+		return m.Suffix
+	}
+	return nil
+}
+
+// ProofSpec is the structure of a tree, which existence and non-existence
+// proofs are checked against.
+type ProofSpec struct {
+	LeafSpec  *LeafOp    `protobuf:"bytes,1,opt,name=leaf_spec,json=leafSpec" json:"leaf_spec,omitempty"`
+	InnerSpec *InnerSpec `protobuf:"bytes,2,opt,name=inner_spec,json=innerSpec" json:"inner_spec,omitempty"`
+	// 0 means no limit
+	MaxDepth             int32    `protobuf:"varint,3,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+	MinDepth             int32    `protobuf:"varint,4,opt,name=min_depth,json=minDepth,proto3" json:"min_depth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProofSpec) Reset()         { *m = ProofSpec{} }
+func (m *ProofSpec) String() string { return proto.CompactTextString(m) }
+func (*ProofSpec) ProtoMessage()    {}
+func (*ProofSpec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_commitment_578a0f16959dc732, []int{5}
+}
+func (m *ProofSpec) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProofSpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProofSpec.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ProofSpec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProofSpec.Merge(dst, src)
+}
+func (m *ProofSpec) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProofSpec) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProofSpec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProofSpec proto.InternalMessageInfo
+
+func (m *ProofSpec) GetLeafSpec() *LeafOp {
+	if m != nil {
+		return m.LeafSpec
+	}
+	return nil
+}
+
+func (m *ProofSpec) GetInnerSpec() *InnerSpec {
+	if m != nil {
+		return m.InnerSpec
+	}
+	return nil
+}
+
+func (m *ProofSpec) GetMaxDepth() int32 {
+	if m != nil {
+		return m.MaxDepth
+	}
+	return 0
+}
+
+func (m *ProofSpec) GetMinDepth() int32 {
+	if m != nil {
+		return m.MinDepth
+	}
+	return 0
+}
+
+// InnerSpec is the structure of the inner nodes of a tree.
+type InnerSpec struct {
+	// The order of the children in the preimage of an inner node, e.g. [0, 1]
+	// for a binary tree.
+	ChildOrder      []int32 `protobuf:"varint,1,rep,packed,name=child_order,json=childOrder" json:"child_order,omitempty"`
+	ChildSize       int32   `protobuf:"varint,2,opt,name=child_size,json=childSize,proto3" json:"child_size,omitempty"`
+	MinPrefixLength int32   `protobuf:"varint,3,opt,name=min_prefix_length,json=minPrefixLength,proto3" json:"min_prefix_length,omitempty"`
+	MaxPrefixLength int32   `protobuf:"varint,4,opt,name=max_prefix_length,json=maxPrefixLength,proto3" json:"max_prefix_length,omitempty"`
+	// The value of an empty child, if any
+	EmptyChild           []byte   `protobuf:"bytes,5,opt,name=empty_child,json=emptyChild,proto3" json:"empty_child,omitempty"`
+	Hash                 HashOp   `protobuf:"varint,6,opt,name=hash,proto3,enum=merkle.HashOp" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InnerSpec) Reset()         { *m = InnerSpec{} }
+func (m *InnerSpec) String() string { return proto.CompactTextString(m) }
+func (*InnerSpec) ProtoMessage()    {}
+func (*InnerSpec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_commitment_578a0f16959dc732, []int{6}
+}
+func (m *InnerSpec) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InnerSpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InnerSpec.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *InnerSpec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InnerSpec.Merge(dst, src)
+}
+func (m *InnerSpec) XXX_Size() int {
+	return m.Size()
+}
+func (m *InnerSpec) XXX_DiscardUnknown() {
+	xxx_messageInfo_InnerSpec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InnerSpec proto.InternalMessageInfo
+
+func (m *InnerSpec) GetChildOrder() []int32 {
+	if m != nil {
+		return m.ChildOrder
+	}
+	return nil
+}
+
+func (m *InnerSpec) GetChildSize() int32 {
+	if m != nil {
+		return m.ChildSize
+	}
+	return 0
+}
+
+func (m *InnerSpec) GetMinPrefixLength() int32 {
+	if m != nil {
+		return m.MinPrefixLength
+	}
+	return 0
+}
+
+func (m *InnerSpec) GetMaxPrefixLength() int32 {
+	if m != nil {
+		return m.MaxPrefixLength
+	}
+	return 0
+}
+
+func (m *InnerSpec) GetEmptyChild() []byte {
+	if m != nil {
+		return m.EmptyChild
+	}
+	return nil
+}
+
+func (m *InnerSpec) GetHash() HashOp {
+	if m != nil {
+		return m.Hash
+	}
+	return HashOp_NO_HASH
+}
+
+// BatchProof holds the proofs of many keys.
+type BatchProof struct {
+	Entries              []*BatchEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BatchProof) Reset()         { *m = BatchProof{} }
+func (m *BatchProof) String() string { return proto.CompactTextString(m) }
+func (*BatchProof) ProtoMessage()    {}
+func (*BatchProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_commitment_578a0f16959dc732, []int{7}
+}
+func (m *BatchProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *BatchProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchProof.Merge(dst, src)
+}
+func (m *BatchProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchProof proto.InternalMessageInfo
+
+func (m *BatchProof) GetEntries() []*BatchEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+// BatchEntry is either an ExistenceProof or a NonExistenceProof.
+type BatchEntry struct {
+	// Types that are valid to be assigned to Proof:
+	//	*BatchEntry_Exist
+	//	*BatchEntry_Nonexist
+	Proof                isBatchEntry_Proof `protobuf_oneof:"proof"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *BatchEntry) Reset()         { *m = BatchEntry{} }
+func (m *BatchEntry) String() string { return proto.CompactTextString(m) }
+func (*BatchEntry) ProtoMessage()    {}
+func (*BatchEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_commitment_578a0f16959dc732, []int{8}
+}
+func (m *BatchEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *BatchEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchEntry.Merge(dst, src)
+}
+func (m *BatchEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchEntry proto.InternalMessageInfo
+
+type isBatchEntry_Proof interface {
+	isBatchEntry_Proof()
+	Equal(interface{}) bool
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type BatchEntry_Exist struct {
+	Exist *ExistenceProof `protobuf:"bytes,1,opt,name=exist,oneof"`
+}
+type BatchEntry_Nonexist struct {
+	Nonexist *NonExistenceProof `protobuf:"bytes,2,opt,name=nonexist,oneof"`
+}
+
+func (*BatchEntry_Exist) isBatchEntry_Proof()    {}
+func (*BatchEntry_Nonexist) isBatchEntry_Proof() {}
+
+func (m *BatchEntry) GetProof() isBatchEntry_Proof {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+func (m *BatchEntry) GetExist() *ExistenceProof {
+	if x, ok := m.GetProof().(*BatchEntry_Exist); ok {
+		return x.Exist
+	}
+	return nil
+}
+
+func (m *BatchEntry) GetNonexist() *NonExistenceProof {
+	if x, ok := m.GetProof().(*BatchEntry_Nonexist); ok {
+		return x.Nonexist
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*BatchEntry) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _BatchEntry_OneofMarshaler, _BatchEntry_OneofUnmarshaler, _BatchEntry_OneofSizer, []interface{}{
+		(*BatchEntry_Exist)(nil),
+		(*BatchEntry_Nonexist)(nil),
+	}
+}
+
+func _BatchEntry_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*BatchEntry)
+	// proof
+	switch x := m.Proof.(type) {
+	case *BatchEntry_Exist:
+		_ = b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Exist); err != nil {
+			return err
+		}
+	case *BatchEntry_Nonexist:
+		_ = b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Nonexist); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("BatchEntry.Proof has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _BatchEntry_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*BatchEntry)
+	switch tag {
+	case 1: // proof.exist
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ExistenceProof)
+		err := b.DecodeMessage(msg)
+		m.Proof = &BatchEntry_Exist{msg}
+		return true, err
+	case 2: // proof.nonexist
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(NonExistenceProof)
+		err := b.DecodeMessage(msg)
+		m.Proof = &BatchEntry_Nonexist{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _BatchEntry_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*BatchEntry)
+	// proof
+	switch x := m.Proof.(type) {
+	case *BatchEntry_Exist:
+		s := proto.Size(x.Exist)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *BatchEntry_Nonexist:
+		s := proto.Size(x.Nonexist)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+func init() {
+	proto.RegisterType((*ExistenceProof)(nil), "merkle.ExistenceProof")
+	proto.RegisterType((*NonExistenceProof)(nil), "merkle.NonExistenceProof")
+	proto.RegisterType((*CommitmentProof)(nil), "merkle.CommitmentProof")
+	proto.RegisterType((*LeafOp)(nil), "merkle.LeafOp")
+	proto.RegisterType((*InnerOp)(nil), "merkle.InnerOp")
+	proto.RegisterType((*ProofSpec)(nil), "merkle.ProofSpec")
+	proto.RegisterType((*InnerSpec)(nil), "merkle.InnerSpec")
+	proto.RegisterType((*BatchProof)(nil), "merkle.BatchProof")
+	proto.RegisterType((*BatchEntry)(nil), "merkle.BatchEntry")
+	proto.RegisterEnum("merkle.HashOp", HashOp_name, HashOp_value)
+	proto.RegisterEnum("merkle.LengthOp", LengthOp_name, LengthOp_value)
+}
+func (this *ExistenceProof) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ExistenceProof)
+	if !ok {
+		that2, ok := that.(ExistenceProof)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.Key, that1.Key) {
+		return false
+	}
+	if !bytes.Equal(this.Value, that1.Value) {
+		return false
+	}
+	if !this.Leaf.Equal(that1.Leaf) {
+		return false
+	}
+	if len(this.Path) != len(that1.Path) {
+		return false
+	}
+	for i := range this.Path {
+		if !this.Path[i].Equal(that1.Path[i]) {
+			return false
+		}
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *NonExistenceProof) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*NonExistenceProof)
+	if !ok {
+		that2, ok := that.(NonExistenceProof)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.Key, that1.Key) {
+		return false
+	}
+	if !this.Left.Equal(that1.Left) {
+		return false
+	}
+	if !this.Right.Equal(that1.Right) {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *CommitmentProof) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*CommitmentProof)
+	if !ok {
+		that2, ok := that.(CommitmentProof)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if that1.Proof == nil {
+		if this.Proof != nil {
+			return false
+		}
+	} else if this.Proof == nil {
+		return false
+	} else if !this.Proof.Equal(that1.Proof) {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *CommitmentProof_Exist) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*CommitmentProof_Exist)
+	if !ok {
+		that2, ok := that.(CommitmentProof_Exist)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Exist.Equal(that1.Exist) {
+		return false
+	}
+	return true
+}
+func (this *CommitmentProof_Nonexist) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*CommitmentProof_Nonexist)
+	if !ok {
+		that2, ok := that.(CommitmentProof_Nonexist)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Nonexist.Equal(that1.Nonexist) {
+		return false
+	}
+	return true
+}
+func (this *CommitmentProof_Batch) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*CommitmentProof_Batch)
+	if !ok {
+		that2, ok := that.(CommitmentProof_Batch)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Batch.Equal(that1.Batch) {
+		return false
+	}
+	return true
+}
+func (this *LeafOp) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LeafOp)
+	if !ok {
+		that2, ok := that.(LeafOp)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Hash != that1.Hash {
+		return false
+	}
+	if this.PrehashKey != that1.PrehashKey {
+		return false
+	}
+	if this.PrehashValue != that1.PrehashValue {
+		return false
+	}
+	if this.Length != that1.Length {
+		return false
+	}
+	if !bytes.Equal(this.Prefix, that1.Prefix) {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *InnerOp) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*InnerOp)
+	if !ok {
+		that2, ok := that.(InnerOp)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Hash != that1.Hash {
+		return false
+	}
+	if !bytes.Equal(this.Prefix, that1.Prefix) {
+		return false
+	}
+	if !bytes.Equal(this.Suffix, that1.Suffix) {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *ProofSpec) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ProofSpec)
+	if !ok {
+		that2, ok := that.(ProofSpec)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.LeafSpec.Equal(that1.LeafSpec) {
+		return false
+	}
+	if !this.InnerSpec.Equal(that1.InnerSpec) {
+		return false
+	}
+	if this.MaxDepth != that1.MaxDepth {
+		return false
+	}
+	if this.MinDepth != that1.MinDepth {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *InnerSpec) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*InnerSpec)
+	if !ok {
+		that2, ok := that.(InnerSpec)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.ChildOrder) != len(that1.ChildOrder) {
+		return false
+	}
+	for i := range this.ChildOrder {
+		if this.ChildOrder[i] != that1.ChildOrder[i] {
+			return false
+		}
+	}
+	if this.ChildSize != that1.ChildSize {
+		return false
+	}
+	if this.MinPrefixLength != that1.MinPrefixLength {
+		return false
+	}
+	if this.MaxPrefixLength != that1.MaxPrefixLength {
+		return false
+	}
+	if !bytes.Equal(this.EmptyChild, that1.EmptyChild) {
+		return false
+	}
+	if this.Hash != that1.Hash {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *BatchProof) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*BatchProof)
+	if !ok {
+		that2, ok := that.(BatchProof)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Entries) != len(that1.Entries) {
+		return false
+	}
+	for i := range this.Entries {
+		if !this.Entries[i].Equal(that1.Entries[i]) {
+			return false
+		}
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *BatchEntry) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*BatchEntry)
+	if !ok {
+		that2, ok := that.(BatchEntry)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if that1.Proof == nil {
+		if this.Proof != nil {
+			return false
+		}
+	} else if this.Proof == nil {
+		return false
+	} else if !this.Proof.Equal(that1.Proof) {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *BatchEntry_Exist) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*BatchEntry_Exist)
+	if !ok {
+		that2, ok := that.(BatchEntry_Exist)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Exist.Equal(that1.Exist) {
+		return false
+	}
+	return true
+}
+func (this *BatchEntry_Nonexist) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*BatchEntry_Nonexist)
+	if !ok {
+		that2, ok := that.(BatchEntry_Nonexist)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Nonexist.Equal(that1.Nonexist) {
+		return false
+	}
+	return true
+}
+func (m *ExistenceProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExistenceProof) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Key) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	if m.Leaf != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.Leaf.Size()))
+		n1, err := m.Leaf.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	if len(m.Path) > 0 {
+		for _, msg := range m.Path {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintCommitment(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *NonExistenceProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NonExistenceProof) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Key) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	if m.Left != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.Left.Size()))
+		n2, err := m.Left.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if m.Right != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.Right.Size()))
+		n3, err := m.Right.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *CommitmentProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommitmentProof) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Proof != nil {
+		nn4, err := m.Proof.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn4
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *CommitmentProof_Exist) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Exist != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.Exist.Size()))
+		n5, err := m.Exist.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	return i, nil
+}
+func (m *CommitmentProof_Nonexist) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Nonexist != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.Nonexist.Size()))
+		n6, err := m.Nonexist.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	return i, nil
+}
+func (m *CommitmentProof_Batch) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Batch != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.Batch.Size()))
+		n7, err := m.Batch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	return i, nil
+}
+func (m *LeafOp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LeafOp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Hash != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.Hash))
+	}
+	if m.PrehashKey != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.PrehashKey))
+	}
+	if m.PrehashValue != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.PrehashValue))
+	}
+	if m.Length != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.Length))
+	}
+	if len(m.Prefix) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(len(m.Prefix)))
+		i += copy(dAtA[i:], m.Prefix)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *InnerOp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InnerOp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Hash != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.Hash))
+	}
+	if len(m.Prefix) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(len(m.Prefix)))
+		i += copy(dAtA[i:], m.Prefix)
+	}
+	if len(m.Suffix) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(len(m.Suffix)))
+		i += copy(dAtA[i:], m.Suffix)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ProofSpec) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProofSpec) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.LeafSpec != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.LeafSpec.Size()))
+		n8, err := m.LeafSpec.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	if m.InnerSpec != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.InnerSpec.Size()))
+		n9, err := m.InnerSpec.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	if m.MaxDepth != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.MaxDepth))
+	}
+	if m.MinDepth != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.MinDepth))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *InnerSpec) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InnerSpec) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChildOrder) > 0 {
+		dAtA11 := make([]byte, len(m.ChildOrder)*10)
+		var j10 int
+		for _, num1 := range m.ChildOrder {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA11[j10] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j10++
+			}
+			dAtA11[j10] = uint8(num)
+			j10++
+		}
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(j10))
+		i += copy(dAtA[i:], dAtA11[:j10])
+	}
+	if m.ChildSize != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.ChildSize))
+	}
+	if m.MinPrefixLength != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.MinPrefixLength))
+	}
+	if m.MaxPrefixLength != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.MaxPrefixLength))
+	}
+	if len(m.EmptyChild) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(len(m.EmptyChild)))
+		i += copy(dAtA[i:], m.EmptyChild)
+	}
+	if m.Hash != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.Hash))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *BatchProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchProof) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Entries) > 0 {
+		for _, msg := range m.Entries {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintCommitment(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *BatchEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchEntry) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Proof != nil {
+		nn12, err := m.Proof.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn12
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *BatchEntry_Exist) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Exist != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.Exist.Size()))
+		n13, err := m.Exist.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	return i, nil
+}
+func (m *BatchEntry_Nonexist) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Nonexist != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintCommitment(dAtA, i, uint64(m.Nonexist.Size()))
+		n14, err := m.Nonexist.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	return i, nil
+}
+func encodeVarintCommitment(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func NewPopulatedExistenceProof(r randyCommitment, easy bool) *ExistenceProof {
+	this := &ExistenceProof{}
+	v1 := r.Intn(100)
+	this.Key = make([]byte, v1)
+	for i := 0; i < v1; i++ {
+		this.Key[i] = byte(r.Intn(256))
+	}
+	v2 := r.Intn(100)
+	this.Value = make([]byte, v2)
+	for i := 0; i < v2; i++ {
+		this.Value[i] = byte(r.Intn(256))
+	}
+	if r.Intn(10) != 0 {
+		this.Leaf = NewPopulatedLeafOp(r, easy)
+	}
+	if r.Intn(10) != 0 {
+		v3 := r.Intn(5)
+		this.Path = make([]*InnerOp, v3)
+		for i := 0; i < v3; i++ {
+			this.Path[i] = NewPopulatedInnerOp(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedCommitment(r, 5)
+	}
+	return this
+}
+
+func NewPopulatedNonExistenceProof(r randyCommitment, easy bool) *NonExistenceProof {
+	this := &NonExistenceProof{}
+	v4 := r.Intn(100)
+	this.Key = make([]byte, v4)
+	for i := 0; i < v4; i++ {
+		this.Key[i] = byte(r.Intn(256))
+	}
+	if r.Intn(10) != 0 {
+		this.Left = NewPopulatedExistenceProof(r, easy)
+	}
+	if r.Intn(10) != 0 {
+		this.Right = NewPopulatedExistenceProof(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedCommitment(r, 4)
+	}
+	return this
+}
+
+func NewPopulatedCommitmentProof(r randyCommitment, easy bool) *CommitmentProof {
+	this := &CommitmentProof{}
+	oneofNumber_Proof := []int32{1, 2, 3}[r.Intn(3)]
+	switch oneofNumber_Proof {
+	case 1:
+		this.Proof = NewPopulatedCommitmentProof_Exist(r, easy)
+	case 2:
+		this.Proof = NewPopulatedCommitmentProof_Nonexist(r, easy)
+	case 3:
+		this.Proof = NewPopulatedCommitmentProof_Batch(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedCommitment(r, 4)
+	}
+	return this
+}
+
+func NewPopulatedCommitmentProof_Exist(r randyCommitment, easy bool) *CommitmentProof_Exist {
+	this := &CommitmentProof_Exist{}
+	this.Exist = NewPopulatedExistenceProof(r, easy)
+	return this
+}
+func NewPopulatedCommitmentProof_Nonexist(r randyCommitment, easy bool) *CommitmentProof_Nonexist {
+	this := &CommitmentProof_Nonexist{}
+	this.Nonexist = NewPopulatedNonExistenceProof(r, easy)
+	return this
+}
+func NewPopulatedCommitmentProof_Batch(r randyCommitment, easy bool) *CommitmentProof_Batch {
+	this := &CommitmentProof_Batch{}
+	this.Batch = NewPopulatedBatchProof(r, easy)
+	return this
+}
+func NewPopulatedLeafOp(r randyCommitment, easy bool) *LeafOp {
+	this := &LeafOp{}
+	this.Hash = HashOp([]int32{0, 1, 2, 3, 4, 5}[r.Intn(6)])
+	this.PrehashKey = HashOp([]int32{0, 1, 2, 3, 4, 5}[r.Intn(6)])
+	this.PrehashValue = HashOp([]int32{0, 1, 2, 3, 4, 5}[r.Intn(6)])
+	this.Length = LengthOp([]int32{0, 1, 2, 3, 4, 5, 6, 7, 8}[r.Intn(9)])
+	v5 := r.Intn(100)
+	this.Prefix = make([]byte, v5)
+	for i := 0; i < v5; i++ {
+		this.Prefix[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedCommitment(r, 6)
+	}
+	return this
+}
+
+func NewPopulatedInnerOp(r randyCommitment, easy bool) *InnerOp {
+	this := &InnerOp{}
+	this.Hash = HashOp([]int32{0, 1, 2, 3, 4, 5}[r.Intn(6)])
+	v6 := r.Intn(100)
+	this.Prefix = make([]byte, v6)
+	for i := 0; i < v6; i++ {
+		this.Prefix[i] = byte(r.Intn(256))
+	}
+	v7 := r.Intn(100)
+	this.Suffix = make([]byte, v7)
+	for i := 0; i < v7; i++ {
+		this.Suffix[i] = byte(r.Intn(256))
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedCommitment(r, 4)
+	}
+	return this
+}
+
+func NewPopulatedProofSpec(r randyCommitment, easy bool) *ProofSpec {
+	this := &ProofSpec{}
+	if r.Intn(10) != 0 {
+		this.LeafSpec = NewPopulatedLeafOp(r, easy)
+	}
+	if r.Intn(10) != 0 {
+		this.InnerSpec = NewPopulatedInnerSpec(r, easy)
+	}
+	this.MaxDepth = int32(r.Int31())
+	if r.Intn(2) == 0 {
+		this.MaxDepth *= -1
+	}
+	this.MinDepth = int32(r.Int31())
+	if r.Intn(2) == 0 {
+		this.MinDepth *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedCommitment(r, 5)
+	}
+	return this
+}
+
+func NewPopulatedInnerSpec(r randyCommitment, easy bool) *InnerSpec {
+	this := &InnerSpec{}
+	v8 := r.Intn(10)
+	this.ChildOrder = make([]int32, v8)
+	for i := 0; i < v8; i++ {
+		this.ChildOrder[i] = int32(r.Int31())
+		if r.Intn(2) == 0 {
+			this.ChildOrder[i] *= -1
+		}
+	}
+	this.ChildSize = int32(r.Int31())
+	if r.Intn(2) == 0 {
+		this.ChildSize *= -1
+	}
+	this.MinPrefixLength = int32(r.Int31())
+	if r.Intn(2) == 0 {
+		this.MinPrefixLength *= -1
+	}
+	this.MaxPrefixLength = int32(r.Int31())
+	if r.Intn(2) == 0 {
+		this.MaxPrefixLength *= -1
+	}
+	v9 := r.Intn(100)
+	this.EmptyChild = make([]byte, v9)
+	for i := 0; i < v9; i++ {
+		this.EmptyChild[i] = byte(r.Intn(256))
+	}
+	this.Hash = HashOp([]int32{0, 1, 2, 3, 4, 5}[r.Intn(6)])
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedCommitment(r, 7)
+	}
+	return this
+}
+
+func NewPopulatedBatchProof(r randyCommitment, easy bool) *BatchProof {
+	this := &BatchProof{}
+	if r.Intn(10) != 0 {
+		v10 := r.Intn(5)
+		this.Entries = make([]*BatchEntry, v10)
+		for i := 0; i < v10; i++ {
+			this.Entries[i] = NewPopulatedBatchEntry(r, easy)
+		}
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedCommitment(r, 2)
+	}
+	return this
+}
+
+func NewPopulatedBatchEntry(r randyCommitment, easy bool) *BatchEntry {
+	this := &BatchEntry{}
+	oneofNumber_Proof := []int32{1, 2}[r.Intn(2)]
+	switch oneofNumber_Proof {
+	case 1:
+		this.Proof = NewPopulatedBatchEntry_Exist(r, easy)
+	case 2:
+		this.Proof = NewPopulatedBatchEntry_Nonexist(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedCommitment(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedBatchEntry_Exist(r randyCommitment, easy bool) *BatchEntry_Exist {
+	this := &BatchEntry_Exist{}
+	this.Exist = NewPopulatedExistenceProof(r, easy)
+	return this
+}
+func NewPopulatedBatchEntry_Nonexist(r randyCommitment, easy bool) *BatchEntry_Nonexist {
+	this := &BatchEntry_Nonexist{}
+	this.Nonexist = NewPopulatedNonExistenceProof(r, easy)
+	return this
+}
+
+type randyCommitment interface {
+	Float32() float32
+	Float64() float64
+	Int63() int64
+	Int31() int32
+	Uint32() uint32
+	Intn(n int) int
+}
+
+func randUTF8RuneCommitment(r randyCommitment) rune {
+	ru := r.Intn(62)
+	if ru < 10 {
+		return rune(ru + 48)
+	} else if ru < 36 {
+		return rune(ru + 55)
+	}
+	return rune(ru + 61)
+}
+func randStringCommitment(r randyCommitment) string {
+	v11 := r.Intn(100)
+	tmps := make([]rune, v11)
+	for i := 0; i < v11; i++ {
+		tmps[i] = randUTF8RuneCommitment(r)
+	}
+	return string(tmps)
+}
+func randUnrecognizedCommitment(r randyCommitment, maxFieldNumber int) (dAtA []byte) {
+	l := r.Intn(5)
+	for i := 0; i < l; i++ {
+		wire := r.Intn(4)
+		if wire == 3 {
+			wire = 5
+		}
+		fieldNumber := maxFieldNumber + r.Intn(100)
+		dAtA = randFieldCommitment(dAtA, r, fieldNumber, wire)
+	}
+	return dAtA
+}
+func randFieldCommitment(dAtA []byte, r randyCommitment, fieldNumber int, wire int) []byte {
+	key := uint32(fieldNumber)<<3 | uint32(wire)
+	switch wire {
+	case 0:
+		dAtA = encodeVarintPopulateCommitment(dAtA, uint64(key))
+		v12 := r.Int63()
+		if r.Intn(2) == 0 {
+			v12 *= -1
+		}
+		dAtA = encodeVarintPopulateCommitment(dAtA, uint64(v12))
+	case 1:
+		dAtA = encodeVarintPopulateCommitment(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	case 2:
+		dAtA = encodeVarintPopulateCommitment(dAtA, uint64(key))
+		ll := r.Intn(100)
+		dAtA = encodeVarintPopulateCommitment(dAtA, uint64(ll))
+		for j := 0; j < ll; j++ {
+			dAtA = append(dAtA, byte(r.Intn(256)))
+		}
+	default:
+		dAtA = encodeVarintPopulateCommitment(dAtA, uint64(key))
+		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
+	}
+	return dAtA
+}
+func encodeVarintPopulateCommitment(dAtA []byte, v uint64) []byte {
+	for v >= 1<<7 {
+		dAtA = append(dAtA, uint8(uint64(v)&0x7f|0x80))
+		v >>= 7
+	}
+	dAtA = append(dAtA, uint8(v))
+	return dAtA
+}
+func (m *ExistenceProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	if m.Leaf != nil {
+		l = m.Leaf.Size()
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	if len(m.Path) > 0 {
+		for _, e := range m.Path {
+			l = e.Size()
+			n += 1 + l + sovCommitment(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *NonExistenceProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	if m.Left != nil {
+		l = m.Left.Size()
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	if m.Right != nil {
+		l = m.Right.Size()
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CommitmentProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Proof != nil {
+		n += m.Proof.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CommitmentProof_Exist) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Exist != nil {
+		l = m.Exist.Size()
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	return n
+}
+func (m *CommitmentProof_Nonexist) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Nonexist != nil {
+		l = m.Nonexist.Size()
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	return n
+}
+func (m *CommitmentProof_Batch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Batch != nil {
+		l = m.Batch.Size()
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	return n
+}
+func (m *LeafOp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Hash != 0 {
+		n += 1 + sovCommitment(uint64(m.Hash))
+	}
+	if m.PrehashKey != 0 {
+		n += 1 + sovCommitment(uint64(m.PrehashKey))
+	}
+	if m.PrehashValue != 0 {
+		n += 1 + sovCommitment(uint64(m.PrehashValue))
+	}
+	if m.Length != 0 {
+		n += 1 + sovCommitment(uint64(m.Length))
+	}
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InnerOp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Hash != 0 {
+		n += 1 + sovCommitment(uint64(m.Hash))
+	}
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	l = len(m.Suffix)
+	if l > 0 {
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ProofSpec) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LeafSpec != nil {
+		l = m.LeafSpec.Size()
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	if m.InnerSpec != nil {
+		l = m.InnerSpec.Size()
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	if m.MaxDepth != 0 {
+		n += 1 + sovCommitment(uint64(m.MaxDepth))
+	}
+	if m.MinDepth != 0 {
+		n += 1 + sovCommitment(uint64(m.MinDepth))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InnerSpec) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.ChildOrder) > 0 {
+		l = 0
+		for _, e := range m.ChildOrder {
+			l += sovCommitment(uint64(e))
+		}
+		n += 1 + sovCommitment(uint64(l)) + l
+	}
+	if m.ChildSize != 0 {
+		n += 1 + sovCommitment(uint64(m.ChildSize))
+	}
+	if m.MinPrefixLength != 0 {
+		n += 1 + sovCommitment(uint64(m.MinPrefixLength))
+	}
+	if m.MaxPrefixLength != 0 {
+		n += 1 + sovCommitment(uint64(m.MaxPrefixLength))
+	}
+	l = len(m.EmptyChild)
+	if l > 0 {
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	if m.Hash != 0 {
+		n += 1 + sovCommitment(uint64(m.Hash))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BatchProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.Size()
+			n += 1 + l + sovCommitment(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BatchEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Proof != nil {
+		n += m.Proof.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BatchEntry_Exist) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Exist != nil {
+		l = m.Exist.Size()
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	return n
+}
+func (m *BatchEntry_Nonexist) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Nonexist != nil {
+		l = m.Nonexist.Size()
+		n += 1 + l + sovCommitment(uint64(l))
+	}
+	return n
+}
+
+func sovCommitment(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozCommitment(x uint64) (n int) {
+	return sovCommitment(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ExistenceProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommitment
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExistenceProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExistenceProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leaf", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Leaf == nil {
+				m.Leaf = &LeafOp{}
+			}
+			if err := m.Leaf.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = append(m.Path, &InnerOp{})
+			if err := m.Path[len(m.Path)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommitment(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NonExistenceProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommitment
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NonExistenceProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NonExistenceProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Left", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Left == nil {
+				m.Left = &ExistenceProof{}
+			}
+			if err := m.Left.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Right", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Right == nil {
+				m.Right = &ExistenceProof{}
+			}
+			if err := m.Right.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommitment(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CommitmentProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommitment
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CommitmentProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CommitmentProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exist", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ExistenceProof{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Proof = &CommitmentProof_Exist{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nonexist", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &NonExistenceProof{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Proof = &CommitmentProof_Nonexist{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Batch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &BatchProof{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Proof = &CommitmentProof_Batch{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommitment(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeafOp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommitment
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeafOp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeafOp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			m.Hash = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Hash |= (HashOp(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrehashKey", wireType)
+			}
+			m.PrehashKey = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PrehashKey |= (HashOp(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrehashValue", wireType)
+			}
+			m.PrehashValue = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PrehashValue |= (HashOp(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Length", wireType)
+			}
+			m.Length = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Length |= (LengthOp(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefix = append(m.Prefix[:0], dAtA[iNdEx:postIndex]...)
+			if m.Prefix == nil {
+				m.Prefix = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommitment(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InnerOp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommitment
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InnerOp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InnerOp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			m.Hash = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Hash |= (HashOp(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefix = append(m.Prefix[:0], dAtA[iNdEx:postIndex]...)
+			if m.Prefix == nil {
+				m.Prefix = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suffix", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Suffix = append(m.Suffix[:0], dAtA[iNdEx:postIndex]...)
+			if m.Suffix == nil {
+				m.Suffix = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommitment(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProofSpec) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommitment
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProofSpec: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProofSpec: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeafSpec", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LeafSpec == nil {
+				m.LeafSpec = &LeafOp{}
+			}
+			if err := m.LeafSpec.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InnerSpec", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InnerSpec == nil {
+				m.InnerSpec = &InnerSpec{}
+			}
+			if err := m.InnerSpec.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxDepth", wireType)
+			}
+			m.MaxDepth = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxDepth |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinDepth", wireType)
+			}
+			m.MinDepth = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinDepth |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommitment(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InnerSpec) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommitment
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InnerSpec: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InnerSpec: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v int32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCommitment
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (int32(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ChildOrder = append(m.ChildOrder, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCommitment
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthCommitment
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.ChildOrder) == 0 {
+					m.ChildOrder = make([]int32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCommitment
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (int32(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ChildOrder = append(m.ChildOrder, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChildOrder", wireType)
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChildSize", wireType)
+			}
+			m.ChildSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChildSize |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinPrefixLength", wireType)
+			}
+			m.MinPrefixLength = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinPrefixLength |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPrefixLength", wireType)
+			}
+			m.MaxPrefixLength = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxPrefixLength |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EmptyChild", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EmptyChild = append(m.EmptyChild[:0], dAtA[iNdEx:postIndex]...)
+			if m.EmptyChild == nil {
+				m.EmptyChild = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			m.Hash = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Hash |= (HashOp(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommitment(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BatchProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommitment
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BatchProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BatchProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Entries = append(m.Entries, &BatchEntry{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommitment(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BatchEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCommitment
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BatchEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BatchEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exist", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ExistenceProof{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Proof = &BatchEntry_Exist{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nonexist", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &NonExistenceProof{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Proof = &BatchEntry_Nonexist{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCommitment(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCommitment
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipCommitment(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowCommitment
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCommitment
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			iNdEx += length
+			if length < 0 {
+				return 0, ErrInvalidLengthCommitment
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowCommitment
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipCommitment(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthCommitment = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowCommitment   = fmt.Errorf("proto: integer overflow")
+)
+
+func init() {
+	proto.RegisterFile("crypto/merkle/commitment.proto", fileDescriptor_commitment_578a0f16959dc732)
+}
+
+var fileDescriptor_commitment_578a0f16959dc732 = []byte{
+	// 835 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x95, 0xcf, 0x6f, 0xe3, 0x44,
+	0x14, 0xc7, 0x3b, 0x71, 0xec, 0x24, 0xcf, 0xbb, 0xad, 0x3b, 0xaa, 0x56, 0x06, 0x44, 0xb6, 0x32,
+	0x97, 0x28, 0x2c, 0xc9, 0x6e, 0xda, 0x0d, 0x12, 0xb7, 0x26, 0xf5, 0x12, 0xab, 0xa5, 0x0e, 0x93,
+	0xec, 0x6a, 0x39, 0x20, 0xcb, 0x75, 0x27, 0xb1, 0xb5, 0xf1, 0x0f, 0x39, 0x2e, 0x4a, 0xf6, 0x00,
+	0xe2, 0x7f, 0xe1, 0x80, 0xc4, 0x81, 0x7f, 0x83, 0x23, 0x07, 0xfe, 0x00, 0xc8, 0x89, 0x3f, 0x81,
+	0x23, 0x9a, 0x19, 0xdb, 0xd9, 0x96, 0xa5, 0x70, 0xda, 0xdb, 0xbc, 0xef, 0xfb, 0xbc, 0x99, 0xe7,
+	0xf9, 0xbe, 0x91, 0xa1, 0xe9, 0xa5, 0xeb, 0x24, 0x8b, 0xbb, 0x21, 0x4d, 0x5f, 0x2d, 0x68, 0xd7,
+	0x8b, 0xc3, 0x30, 0xc8, 0x42, 0x1a, 0x65, 0x9d, 0x24, 0x8d, 0xb3, 0x18, 0x2b, 0x22, 0xf1, 0xfe,
+	0x27, 0xf3, 0x20, 0xf3, 0xaf, 0x2f, 0x3b, 0x5e, 0x1c, 0x76, 0xe7, 0xf1, 0x3c, 0xee, 0xf2, 0xf4,
+	0xe5, 0xf5, 0x8c, 0x47, 0x3c, 0xe0, 0x2b, 0x51, 0x66, 0x7c, 0x8f, 0x60, 0xd7, 0x5c, 0x05, 0xcb,
+	0x8c, 0x46, 0x1e, 0x1d, 0xa7, 0x71, 0x3c, 0xc3, 0x1a, 0x48, 0xaf, 0xe8, 0x5a, 0x47, 0x87, 0xa8,
+	0x75, 0x8f, 0xb0, 0x25, 0x3e, 0x00, 0xf9, 0x1b, 0x77, 0x71, 0x4d, 0xf5, 0x0a, 0xd7, 0x44, 0x80,
+	0x0d, 0xa8, 0x2e, 0xa8, 0x3b, 0xd3, 0xa5, 0x43, 0xd4, 0x52, 0x7b, 0xbb, 0x1d, 0xd1, 0x40, 0xe7,
+	0x9c, 0xba, 0x33, 0x3b, 0x21, 0x3c, 0x87, 0x3f, 0x82, 0x6a, 0xe2, 0x66, 0xbe, 0x5e, 0x3d, 0x94,
+	0x5a, 0x6a, 0x6f, 0xaf, 0x60, 0xac, 0x28, 0xa2, 0x29, 0x83, 0x58, 0xd2, 0xf8, 0x0e, 0xf6, 0x2f,
+	0xe2, 0xe8, 0x3f, 0xbb, 0x68, 0xb3, 0xf3, 0x66, 0x19, 0x6f, 0x42, 0xed, 0x3d, 0x28, 0xf6, 0xba,
+	0x59, 0x47, 0x38, 0x83, 0x1f, 0x81, 0x9c, 0x06, 0x73, 0x3f, 0xd3, 0xa5, 0x3b, 0x61, 0x01, 0x19,
+	0x3f, 0x23, 0xd8, 0x1b, 0x96, 0x17, 0x2a, 0xce, 0xef, 0x80, 0x4c, 0x19, 0xac, 0xa3, 0xbb, 0x76,
+	0x18, 0xed, 0x10, 0x81, 0xe1, 0x4f, 0xa1, 0x1e, 0xc5, 0x91, 0x28, 0x11, 0x1d, 0xbe, 0x57, 0x94,
+	0xfc, 0xe3, 0xe3, 0x46, 0x3b, 0xa4, 0x84, 0x71, 0x1b, 0xe4, 0x4b, 0x37, 0xf3, 0xfc, 0xbc, 0x55,
+	0x5c, 0x54, 0x0d, 0x98, 0x58, 0x1e, 0xc2, 0x91, 0x41, 0x0d, 0xe4, 0x84, 0x29, 0xc6, 0x6f, 0x08,
+	0x14, 0x71, 0xd1, 0xcc, 0x06, 0xdf, 0x5d, 0xfa, 0xbc, 0xcf, 0xdd, 0xad, 0x0d, 0x23, 0x77, 0xe9,
+	0xb3, 0x1b, 0x66, 0x39, 0xdc, 0x05, 0x35, 0x49, 0x29, 0x5b, 0x3a, 0xec, 0x52, 0x2b, 0x6f, 0x45,
+	0x21, 0x47, 0xce, 0xe8, 0x1a, 0x1f, 0xc1, 0xfd, 0xa2, 0x40, 0x38, 0x2f, 0xbd, 0xb5, 0xe4, 0x5e,
+	0x0e, 0xbd, 0xe0, 0x03, 0xd1, 0x02, 0x65, 0x41, 0xa3, 0x39, 0xb7, 0x9b, 0xd1, 0xda, 0x76, 0x24,
+	0x98, 0x6a, 0x27, 0x24, 0xcf, 0xe3, 0x07, 0xa0, 0x24, 0x29, 0x9d, 0x05, 0x2b, 0x5d, 0xe6, 0xfe,
+	0xe6, 0x91, 0xf1, 0x35, 0xd4, 0xf2, 0xd1, 0xf8, 0x5f, 0x9f, 0xb5, 0xdd, 0xa6, 0xf2, 0xe6, 0x36,
+	0x4c, 0x5f, 0x5e, 0xcf, 0x98, 0x2e, 0x09, 0x5d, 0x44, 0xc6, 0x0f, 0x08, 0x1a, 0xfc, 0x46, 0x27,
+	0x09, 0xf5, 0xf0, 0xc7, 0xd0, 0x60, 0x33, 0xea, 0x2c, 0x13, 0xea, 0xe5, 0x2e, 0xdf, 0x1e, 0xe2,
+	0x3a, 0x03, 0x38, 0xfc, 0x18, 0x20, 0x60, 0x9d, 0x09, 0x5a, 0x18, 0xbc, 0x7f, 0x63, 0x9c, 0x19,
+	0x46, 0x1a, 0x41, 0xb1, 0xc4, 0x1f, 0x40, 0x23, 0x74, 0x57, 0xce, 0x15, 0x4d, 0x32, 0xe1, 0xad,
+	0x4c, 0xea, 0xa1, 0xbb, 0x3a, 0x65, 0x31, 0x4f, 0x06, 0x51, 0x9e, 0xac, 0xe6, 0xc9, 0x20, 0xe2,
+	0x49, 0xe3, 0x4f, 0x04, 0x8d, 0x72, 0x4b, 0xfc, 0x10, 0x54, 0xcf, 0x0f, 0x16, 0x57, 0x4e, 0x9c,
+	0x5e, 0xd1, 0x54, 0x47, 0x87, 0x52, 0x4b, 0x26, 0xc0, 0x25, 0x9b, 0x29, 0xf8, 0x43, 0x10, 0x91,
+	0xb3, 0x0c, 0x5e, 0x8b, 0x27, 0x2a, 0x93, 0x06, 0x57, 0x26, 0xc1, 0x6b, 0x8a, 0xdb, 0xb0, 0xcf,
+	0x8e, 0x12, 0x57, 0xe3, 0xe4, 0x06, 0x89, 0x7e, 0xf6, 0xc2, 0x20, 0x1a, 0x73, 0x5d, 0x38, 0xc4,
+	0x59, 0x77, 0x75, 0x8b, 0xad, 0xe6, 0xac, 0xbb, 0xba, 0xc1, 0x3e, 0x04, 0x95, 0x86, 0x49, 0xb6,
+	0x76, 0xf8, 0x51, 0xb9, 0x91, 0xc0, 0xa5, 0x21, 0x53, 0x4a, 0x07, 0x95, 0x7f, 0x77, 0xd0, 0xf8,
+	0x0c, 0x60, 0x3b, 0xe7, 0xf8, 0x11, 0xd4, 0x68, 0x94, 0xa5, 0x01, 0x5d, 0xf2, 0xcf, 0xbc, 0xfd,
+	0x18, 0xcc, 0x28, 0x4b, 0xd7, 0xa4, 0x40, 0x8c, 0x6f, 0x01, 0xb6, 0xf2, 0x3b, 0x7b, 0xaf, 0xe5,
+	0x1b, 0x6c, 0x3f, 0x07, 0x45, 0x7c, 0x0b, 0x56, 0xa1, 0x76, 0x61, 0x3b, 0xa3, 0x93, 0xc9, 0x48,
+	0xdb, 0xc1, 0x00, 0xca, 0x64, 0x74, 0xd2, 0x7b, 0xda, 0xd7, 0x50, 0xbe, 0x7e, 0xfa, 0xa4, 0xa7,
+	0x55, 0xd8, 0xfa, 0xcc, 0x1c, 0x0e, 0x4f, 0xce, 0x34, 0x09, 0xdf, 0x87, 0x06, 0xb1, 0xc6, 0xe6,
+	0x17, 0xa7, 0x4f, 0xfa, 0x8f, 0xb5, 0x2a, 0xab, 0x1f, 0x58, 0xd3, 0xa1, 0x6d, 0x5d, 0x68, 0x72,
+	0xfb, 0x27, 0x04, 0xf5, 0xe2, 0xc1, 0x30, 0xf0, 0xc2, 0x76, 0xc6, 0xc4, 0x7c, 0x66, 0xbd, 0xd4,
+	0x76, 0x58, 0xf8, 0xe2, 0x84, 0x38, 0x63, 0x62, 0x4f, 0x6d, 0x0d, 0xb1, 0x3a, 0x16, 0x92, 0xf3,
+	0xb1, 0x56, 0xc1, 0x7b, 0xa0, 0x3e, 0xb3, 0x5e, 0x9a, 0xa7, 0x47, 0x3d, 0x67, 0x60, 0x7d, 0xae,
+	0x49, 0x18, 0xc3, 0x6e, 0x21, 0x9c, 0x5b, 0xd3, 0xe9, 0xb9, 0xa9, 0x55, 0x4b, 0xa8, 0x7f, 0xcc,
+	0x21, 0xb9, 0x84, 0xfa, 0xc7, 0x05, 0xa4, 0xe0, 0x03, 0xd0, 0x88, 0xf9, 0xe5, 0x73, 0x8b, 0x98,
+	0x0e, 0xdb, 0xec, 0xab, 0xa9, 0x39, 0xd1, 0x6a, 0x6f, 0xaa, 0xfd, 0xe3, 0x5c, 0xad, 0x0f, 0x0e,
+	0xfe, 0xfa, 0xa3, 0x89, 0x7e, 0xdc, 0x34, 0xd1, 0x2f, 0x9b, 0x26, 0xfa, 0x75, 0xd3, 0x44, 0xbf,
+	0x6f, 0x9a, 0xe8, 0x52, 0xe1, 0x3f, 0x97, 0xa3, 0xbf, 0x07, 0x00, 0x0d, 0x94, 0x39, 0xbf, 0xb5,
+	0x06, 0x00, 0x00,
+}
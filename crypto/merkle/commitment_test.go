@@ -0,0 +1,134 @@
+package merkle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var commitmentTestMap = map[string][]byte{"a": []byte("1"), "c": []byte("2"), "e": []byte("3")}
+
+func existenceProofs(t *testing.T, m map[string][]byte) ([]byte, map[string]*ExistenceProof) {
+	root, proofs, _ := SimpleProofsFromMap(m)
+	exist := make(map[string]*ExistenceProof, len(proofs))
+	for k, proof := range proofs {
+		p, err := SimpleExistenceProof([]byte(k), m[k], proof)
+		require.NoError(t, err)
+		exist[k] = p
+	}
+	return root, exist
+}
+
+func existProof(p *ExistenceProof) *CommitmentProof {
+	return &CommitmentProof{Proof: &CommitmentProof_Exist{Exist: p}}
+}
+
+func nonExistProof(key string, left, right *ExistenceProof) *CommitmentProof {
+	return &CommitmentProof{Proof: &CommitmentProof_Nonexist{
+		Nonexist: &NonExistenceProof{Key: []byte(key), Left: left, Right: right},
+	}}
+}
+
+func TestCommitmentProofExistence(t *testing.T) {
+	root, exist := existenceProofs(t, commitmentTestMap)
+
+	for k, v := range commitmentTestMap {
+		proof := existProof(exist[k])
+		calc, err := proof.Calculate()
+		require.NoError(t, err)
+		assert.Equal(t, root, calc)
+		assert.NoError(t, VerifyMembership(SimpleSpec, root, proof, []byte(k), v), k)
+
+		assert.Error(t, VerifyMembership(SimpleSpec, root, proof, []byte(k), []byte("other")), k)
+		assert.Error(t, VerifyMembership(SimpleSpec, []byte("root"), proof, []byte(k), v), k)
+	}
+	assert.Error(t, VerifyMembership(SimpleSpec, root, existProof(exist["a"]), []byte("c"), []byte("2")))
+
+	// The inner nodes of a are too short for the spec of IAVL trees
+	assert.Error(t, VerifyMembership(IavlSpec, root, existProof(exist["a"]), []byte("a"), []byte("1")))
+}
+
+func TestCommitmentProofNonExistence(t *testing.T) {
+	root, exist := existenceProofs(t, commitmentTestMap)
+
+	testCases := []struct {
+		key         string
+		left, right *ExistenceProof
+		valid       bool
+	}{
+		{"0", nil, exist["a"], true},
+		{"b", exist["a"], exist["c"], true},
+		{"d", exist["c"], exist["e"], true},
+		{"f", exist["e"], nil, true},
+		// Not neighbours
+		{"b", exist["a"], exist["e"], false},
+		{"0", nil, exist["c"], false},
+		{"f", exist["c"], nil, false},
+		// Not in between
+		{"b", exist["c"], exist["e"], false},
+		// The key exists
+		{"c", exist["a"], exist["e"], false},
+		{"b", nil, nil, false},
+	}
+	for i, tc := range testCases {
+		err := VerifyNonMembership(SimpleSpec, root, nonExistProof(tc.key, tc.left, tc.right), []byte(tc.key))
+		if tc.valid {
+			assert.NoError(t, err, "#%d", i)
+		} else {
+			assert.Error(t, err, "#%d", i)
+		}
+	}
+}
+
+func TestCommitmentProofBatch(t *testing.T) {
+	root, exist := existenceProofs(t, commitmentTestMap)
+
+	batch, err := BatchCommitmentProof(
+		existProof(exist["a"]),
+		existProof(exist["e"]),
+		nonExistProof("b", exist["a"], exist["c"]),
+	)
+	require.NoError(t, err)
+
+	// The batch survives the encoding
+	bz, err := batch.Marshal()
+	require.NoError(t, err)
+	decoded := &CommitmentProof{}
+	require.NoError(t, decoded.Unmarshal(bz))
+	assert.True(t, batch.Equal(decoded))
+
+	assert.NoError(t, BatchVerifyMembership(SimpleSpec, root, decoded,
+		map[string][]byte{"a": []byte("1"), "e": []byte("3")}))
+	assert.NoError(t, BatchVerifyNonMembership(SimpleSpec, root, decoded, [][]byte{[]byte("b")}))
+
+	// Not in the batch
+	assert.Error(t, BatchVerifyMembership(SimpleSpec, root, decoded, map[string][]byte{"c": []byte("2")}))
+	assert.Error(t, BatchVerifyNonMembership(SimpleSpec, root, decoded, [][]byte{[]byte("d")}))
+}
+
+func TestCommitmentOp(t *testing.T) {
+	// The root commits to the store "main", which commits to a=1
+	storeRoot, storeExist := existenceProofs(t, commitmentTestMap)
+	root, exist := existenceProofs(t, map[string][]byte{"main": storeRoot, "other": []byte("4")})
+
+	proof := &Proof{Ops: []ProofOp{
+		NewSimpleCommitmentOp([]byte("a"), existProof(storeExist["a"])).ProofOp(),
+		NewSimpleCommitmentOp([]byte("main"), existProof(exist["main"])).ProofOp(),
+	}}
+	prt := DefaultProofRuntime()
+	assert.NoError(t, prt.VerifyValue(proof, root, "/main/a", []byte("1")))
+	assert.Error(t, prt.VerifyValue(proof, root, "/main/a", []byte("2")))
+	assert.Error(t, prt.VerifyValue(proof, root, "/other/a", []byte("1")))
+
+	// b isn't in the store "main"
+	absence := &Proof{Ops: []ProofOp{
+		NewSimpleCommitmentOp([]byte("b"), nonExistProof("b", storeExist["a"], storeExist["c"])).ProofOp(),
+		NewSimpleCommitmentOp([]byte("main"), existProof(exist["main"])).ProofOp(),
+	}}
+	assert.NoError(t, prt.VerifyAbsence(absence, root, "/main/b"))
+	assert.Error(t, prt.VerifyValue(absence, root, "/main/b", []byte("1")))
+
+	// The CommitmentOps are only known by the runtimes they're registered in
+	assert.Error(t, NewProofRuntime().VerifyValue(proof, root, "/main/a", []byte("1")))
+}
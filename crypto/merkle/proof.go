@@ -71,6 +71,22 @@ func (poz ProofOperators) Verify(root []byte, keypath string, args [][]byte) (er
 
 type OpDecoder func(ProofOp) (ProofOperator, error)
 
+// opDecoders is the registry of the proof operators known by the
+// DefaultProofRuntime.
+var opDecoders = map[string]OpDecoder{
+	ProofOpSimpleValue: SimpleValueOpDecoder,
+}
+
+// RegisterOpDecoder registers the decoder of a type of proof operator, so
+// every DefaultProofRuntime created afterwards knows about it. It must be
+// called at init time, e.g. by the package defining the operator.
+func RegisterOpDecoder(typ string, dec OpDecoder) {
+	if _, ok := opDecoders[typ]; ok {
+		panic("already registered for type " + typ)
+	}
+	opDecoders[typ] = dec
+}
+
 type ProofRuntime struct {
 	decoders map[string]OpDecoder
 }
@@ -127,12 +143,14 @@ func (prt *ProofRuntime) Verify(proof *Proof, root []byte, keypath string, args
 	return poz.Verify(root, keypath, args)
 }
 
-// DefaultProofRuntime only knows about Simple value
-// proofs.
-// To use e.g. IAVL proofs, register op-decoders as
-// defined in the IAVL package.
+// DefaultProofRuntime knows about the registered proof operators: Simple
+// value proofs and ICS-23 commitment proofs of IAVL and simple Merkle trees
+// (see CommitmentOp). To use other proofs, register their op-decoders with
+// RegisterOpDecoder.
 func DefaultProofRuntime() (prt *ProofRuntime) {
 	prt = NewProofRuntime()
-	prt.RegisterOpDecoder(ProofOpSimpleValue, SimpleValueOpDecoder)
+	for typ, dec := range opDecoders {
+		prt.RegisterOpDecoder(typ, dec)
+	}
 	return
 }
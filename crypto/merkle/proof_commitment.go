@@ -0,0 +1,110 @@
+package merkle
+
+import (
+	"fmt"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+)
+
+const (
+	// ProofOpICS23IAVL is the type of the CommitmentOps of IAVL trees.
+	ProofOpICS23IAVL = "ics23:iavl"
+	// ProofOpICS23Simple is the type of the CommitmentOps of simple Merkle
+	// trees.
+	ProofOpICS23Simple = "ics23:simple"
+)
+
+func init() {
+	RegisterOpDecoder(ProofOpICS23IAVL, CommitmentOpDecoder)
+	RegisterOpDecoder(ProofOpICS23Simple, CommitmentOpDecoder)
+}
+
+// CommitmentOp is a ProofOperator wrapping an ICS-23 CommitmentProof, so the
+// proofs of any tree with a known ProofSpec can be chained with other proof
+// operators (e.g. the proof of a key in an IAVL store, followed by the proof
+// of the store in the multistore).
+//
+// Run takes the value of the key (or no value to prove the key is absent)
+// and produces the root hash.
+type CommitmentOp struct {
+	Type  string
+	Spec  *ProofSpec
+	Key   []byte
+	Proof *CommitmentProof
+}
+
+var _ ProofOperator = CommitmentOp{}
+
+// NewIavlCommitmentOp returns the CommitmentOp of a key of an IAVL tree.
+func NewIavlCommitmentOp(key []byte, proof *CommitmentProof) CommitmentOp {
+	return CommitmentOp{Type: ProofOpICS23IAVL, Spec: IavlSpec, Key: key, Proof: proof}
+}
+
+// NewSimpleCommitmentOp returns the CommitmentOp of a key of a simple Merkle
+// tree.
+func NewSimpleCommitmentOp(key []byte, proof *CommitmentProof) CommitmentOp {
+	return CommitmentOp{Type: ProofOpICS23Simple, Spec: SimpleSpec, Key: key, Proof: proof}
+}
+
+// CommitmentOpDecoder decodes the CommitmentOps of the IAVL and simple Merkle
+// trees. ProofOp.Data is the protobuf encoded CommitmentProof.
+func CommitmentOpDecoder(pop ProofOp) (ProofOperator, error) {
+	var spec *ProofSpec
+	switch pop.Type {
+	case ProofOpICS23IAVL:
+		spec = IavlSpec
+	case ProofOpICS23Simple:
+		spec = SimpleSpec
+	default:
+		return nil, cmn.NewError("unexpected ProofOp.Type; got %v, want %v or %v",
+			pop.Type, ProofOpICS23IAVL, ProofOpICS23Simple)
+	}
+
+	proof := &CommitmentProof{}
+	if err := proof.Unmarshal(pop.Data); err != nil {
+		return nil, cmn.ErrorWrap(err, "decoding ProofOp.Data into CommitmentProof")
+	}
+	return CommitmentOp{Type: pop.Type, Spec: spec, Key: pop.Key, Proof: proof}, nil
+}
+
+func (op CommitmentOp) ProofOp() ProofOp {
+	bz, err := op.Proof.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return ProofOp{
+		Type: op.Type,
+		Key:  op.Key,
+		Data: bz,
+	}
+}
+
+func (op CommitmentOp) String() string {
+	return fmt.Sprintf("CommitmentOp{%v %v}", op.Type, op.GetKey())
+}
+
+func (op CommitmentOp) Run(args [][]byte) ([][]byte, error) {
+	root, err := op.Proof.Calculate()
+	if err != nil {
+		return nil, cmn.ErrorWrap(err, "calculating the root hash")
+	}
+
+	switch len(args) {
+	case 0:
+		if err := VerifyNonMembership(op.Spec, root, op.Proof, op.Key); err != nil {
+			return nil, cmn.ErrorWrap(err, "verifying the absence")
+		}
+	case 1:
+		if err := VerifyMembership(op.Spec, root, op.Proof, op.Key, args[0]); err != nil {
+			return nil, cmn.ErrorWrap(err, "verifying the existence")
+		}
+	default:
+		return nil, cmn.NewError("expected 0 or 1 arg, got %v", len(args))
+	}
+
+	return [][]byte{root}, nil
+}
+
+func (op CommitmentOp) GetKey() []byte {
+	return op.Key
+}
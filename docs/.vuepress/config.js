@@ -116,6 +116,7 @@ module.exports = {
           "/spec/reactors/mempool/reactor",
           "/spec/reactors/pex/pex",
           "/spec/reactors/pex/reactor",
+          "/spec/reactors/state_sync/reactor",
 	]
       },
       {
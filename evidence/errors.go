@@ -0,0 +1,34 @@
+package evidence
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrEvidenceAlreadyCommitted is returned when the evidence was already
+	// committed in a block, so it's discarded.
+	ErrEvidenceAlreadyCommitted = errors.New("evidence was already committed")
+)
+
+// ErrEvidenceExpired is returned when the evidence is older than both
+// ConsensusParams.Evidence.MaxAge blocks and MaxAgeDuration.
+type ErrEvidenceExpired struct {
+	Height int64
+	Time   time.Time
+}
+
+func (e ErrEvidenceExpired) Error() string {
+	return fmt.Sprintf("evidence from height %d (%v) has expired", e.Height, e.Time)
+}
+
+// ErrInvalidEvidence is returned when the evidence fails to verify, e.g. its
+// signatures are invalid or it's not from a validator.
+type ErrInvalidEvidence struct {
+	Reason error
+}
+
+func (e ErrInvalidEvidence) Error() string {
+	return fmt.Sprintf("invalid evidence: %v", e.Reason)
+}
@@ -0,0 +1,72 @@
+package evidence
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "evidence"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of pending evidence.
+	NumPending metrics.Gauge
+	// Number of evidence added to the pool.
+	AddedEvidence metrics.Counter
+	// Number of evidence committed in blocks.
+	CommittedEvidence metrics.Counter
+	// Number of pending evidence which expired before being committed.
+	ExpiredEvidence metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		NumPending: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "num_pending",
+			Help:      "Number of pending (uncommitted) evidence.",
+		}, labels).With(labelsAndValues...),
+		AddedEvidence: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "added_evidence",
+			Help:      "Number of evidence verified and added to the pool.",
+		}, labels).With(labelsAndValues...),
+		CommittedEvidence: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "committed_evidence",
+			Help:      "Number of evidence committed in blocks.",
+		}, labels).With(labelsAndValues...),
+		ExpiredEvidence: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "expired_evidence",
+			Help:      "Number of pending evidence which expired before being committed.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		NumPending:        discard.NewGauge(),
+		AddedEvidence:     discard.NewCounter(),
+		CommittedEvidence: discard.NewCounter(),
+		ExpiredEvidence:   discard.NewCounter(),
+	}
+}
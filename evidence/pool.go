@@ -2,6 +2,7 @@ package evidence
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	clist "github.com/tendermint/tendermint/libs/clist"
@@ -22,24 +23,77 @@ type EvidencePool struct {
 
 	// needed to load validators to verify evidence
 	stateDB dbm.DB
+	// needed to load the headers composite evidence conflicts with
+	blockStore BlockStore
 
 	// latest state
 	mtx   sync.Mutex
 	state sm.State
+
+	metrics *Metrics
+}
+
+// BlockStore is the block store interface used by the EvidencePool.
+type BlockStore interface {
+	LoadBlockMeta(height int64) *types.BlockMeta
+	LoadBlock(height int64) *types.Block
 }
 
-func NewEvidencePool(stateDB, evidenceDB dbm.DB) *EvidencePool {
+// EvidencePoolOption sets an optional parameter on the EvidencePool.
+type EvidencePoolOption func(*EvidencePool)
+
+// NewEvidencePool returns a new EvidencePool, with the pending evidence of
+// the evidenceDB. See recover.
+func NewEvidencePool(stateDB, evidenceDB dbm.DB, blockStore BlockStore, options ...EvidencePoolOption) *EvidencePool {
 	evidenceStore := NewEvidenceStore(evidenceDB)
 	evpool := &EvidencePool{
 		stateDB:       stateDB,
+		blockStore:    blockStore,
 		state:         sm.LoadState(stateDB),
 		logger:        log.NewNopLogger(),
 		evidenceStore: evidenceStore,
 		evidenceList:  clist.New(),
+		metrics:       NopMetrics(),
 	}
+	for _, option := range options {
+		option(evpool)
+	}
+	evpool.recover()
 	return evpool
 }
 
+// WithMetrics sets the metrics.
+func WithMetrics(metrics *Metrics) EvidencePoolOption {
+	return func(evpool *EvidencePool) { evpool.metrics = metrics }
+}
+
+// recover brings the evidence up to date with the state after a restart: the
+// evidence committed in the blocks executed without the pool (i.e. replayed
+// on the handshake) is marked committed, the expired evidence is pruned, and
+// the pending evidence is gossiped again.
+func (evpool *EvidencePool) recover() {
+	state := evpool.state
+	if height := evpool.evidenceStore.Height(); height > 0 {
+		for h := height + 1; h <= state.LastBlockHeight; h++ {
+			block := evpool.blockStore.LoadBlock(h)
+			if block == nil {
+				// e.g. pruned, or restored from a snapshot
+				continue
+			}
+			for _, ev := range block.Evidence.Evidence {
+				evpool.evidenceStore.MarkEvidenceAsCommitted(ev, h)
+			}
+		}
+	}
+	evpool.evidenceStore.SetHeight(state.LastBlockHeight)
+
+	evpool.pruneExpiredEvidence(state)
+	for _, ev := range evpool.evidenceStore.PendingEvidence(-1) {
+		evpool.evidenceList.PushBack(ev)
+	}
+	evpool.metrics.NumPending.Set(float64(evpool.evidenceList.Len()))
+}
+
 func (evpool *EvidencePool) EvidenceFront() *clist.CElement {
 	return evpool.evidenceList.Front()
 }
@@ -64,6 +118,36 @@ func (evpool *EvidencePool) PendingEvidence(maxNum int64) []types.Evidence {
 	return evpool.evidenceStore.PendingEvidence(maxNum)
 }
 
+// ReapMaxBytes returns the uncommitted evidence to propose, up to maxBytes in
+// total (see types.EvidenceList.ByteSize). The oldest evidence goes first, then
+// the evidence of the most powerful validators, so all the proposers agree on
+// the evidence to include. Evidence which doesn't fit is skipped.
+func (evpool *EvidencePool) ReapMaxBytes(maxBytes int64) []types.Evidence {
+	infos := evpool.evidenceStore.pendingEvidenceInfo()
+	// infos are sorted by height, then by hash
+	sort.SliceStable(infos, func(i, j int) bool {
+		hi, hj := infos[i].Evidence.Height(), infos[j].Evidence.Height()
+		if hi != hj {
+			return hi < hj
+		}
+		return infos[i].Priority > infos[j].Priority
+	})
+
+	var (
+		evidence []types.Evidence
+		size     int64
+	)
+	for _, ei := range infos {
+		evSize := types.EvidenceByteSize(ei.Evidence)
+		if size+evSize > maxBytes {
+			continue
+		}
+		size += evSize
+		evidence = append(evidence, ei.Evidence)
+	}
+	return evidence
+}
+
 // State returns the current state of the evpool.
 func (evpool *EvidencePool) State() sm.State {
 	evpool.mtx.Lock()
@@ -86,25 +170,75 @@ func (evpool *EvidencePool) Update(block *types.Block, state sm.State) {
 
 	// remove evidence from pending and mark committed
 	evpool.MarkEvidenceAsCommitted(block.Height, block.Evidence.Evidence)
+	evpool.metrics.CommittedEvidence.Add(float64(len(block.Evidence.Evidence)))
+
+	// forget the evidence which expired
+	evpool.pruneExpiredEvidence(state)
+
+	evpool.evidenceStore.SetHeight(block.Height)
+	evpool.metrics.NumPending.Set(float64(evpool.evidenceList.Len()))
 }
 
-// AddEvidence checks the evidence is valid and adds it to the pool.
+// AddEvidence checks the evidence is valid and adds it to the pool. Evidence
+// already in the pool is ignored.
+//
+// It returns ErrEvidenceAlreadyCommitted if the evidence was already
+// committed, ErrEvidenceExpired if it expired and ErrInvalidEvidence if it
+// fails to verify.
 func (evpool *EvidencePool) AddEvidence(evidence types.Evidence) (err error) {
 
 	// TODO: check if we already have evidence for this
 	// validator at this height so we dont get spammed
 
-	if err := sm.VerifyEvidence(evpool.stateDB, evpool.State(), evidence); err != nil {
-		return err
+	if evpool.IsCommitted(evidence) {
+		return ErrEvidenceAlreadyCommitted
+	}
+
+	state := evpool.State()
+	if sm.IsEvidenceExpired(state, evidence) {
+		return ErrEvidenceExpired{evidence.Height(), evidence.Time()}
+	}
+
+	if evpool.IsPending(evidence) {
+		// evidence already known, just ignore
+		return nil
+	}
+
+	// the validators are needed to verify the evidence; missing validators
+	// don't make the evidence invalid (e.g. we're behind)
+	valset, err := sm.LoadValidators(evpool.stateDB, evidence.Height())
+	if err != nil {
+		return fmt.Errorf("can't verify evidence from height %d: %v", evidence.Height(), err)
+	}
+
+	// so is the header composite evidence conflicts with
+	var committedHeader *types.Header
+	cev, isComposite := evidence.(types.CompositeEvidence)
+	if isComposite {
+		if committedHeader = evpool.Header(cev.ConflictingHeight()); committedHeader == nil {
+			return fmt.Errorf("can't verify evidence conflicting with height %d: no header committed yet",
+				cev.ConflictingHeight())
+		}
+	}
+
+	if err := sm.VerifyEvidence(evpool.stateDB, state, evidence, committedHeader); err != nil {
+		return ErrInvalidEvidence{err}
 	}
 
-	// fetch the validator and return its voting power as its priority
+	// fetch the validator and return its voting power as its priority, or the
+	// voting power of all the byzantine validators of composite evidence
 	// TODO: something better ?
-	valset, _ := sm.LoadValidators(evpool.stateDB, evidence.Height())
-	_, val := valset.GetByAddress(evidence.Address())
-	priority := val.VotingPower
+	var priority int64
+	if isComposite {
+		for _, val := range cev.ByzantineValidators(valset) {
+			priority += val.VotingPower
+		}
+	} else {
+		_, val := valset.GetByAddress(evidence.Address())
+		priority = val.VotingPower
+	}
 
-	added := evpool.evidenceStore.AddNewEvidence(evidence, priority)
+	added := evpool.evidenceStore.AddNewEvidence(evidence, priority, state.LastBlockHeight)
 	if !added {
 		// evidence already known, just ignore
 		return
@@ -114,23 +248,24 @@ func (evpool *EvidencePool) AddEvidence(evidence types.Evidence) (err error) {
 
 	// add evidence to clist
 	evpool.evidenceList.PushBack(evidence)
+	evpool.metrics.AddedEvidence.Add(1)
+	evpool.metrics.NumPending.Set(float64(evpool.evidenceList.Len()))
 
 	return nil
 }
 
-// MarkEvidenceAsCommitted marks all the evidence as committed and removes it from the queue.
+// MarkEvidenceAsCommitted marks all the evidence as committed in the block at
+// the height and removes it from the queue.
 func (evpool *EvidencePool) MarkEvidenceAsCommitted(height int64, evidence []types.Evidence) {
 	// make a map of committed evidence to remove from the clist
 	blockEvidenceMap := make(map[string]struct{})
 	for _, ev := range evidence {
-		evpool.evidenceStore.MarkEvidenceAsCommitted(ev)
+		evpool.evidenceStore.MarkEvidenceAsCommitted(ev, height)
 		blockEvidenceMap[evMapKey(ev)] = struct{}{}
 	}
 
 	// remove committed evidence from the clist
-	maxAge := evpool.State().ConsensusParams.Evidence.MaxAge
-	evpool.removeEvidence(height, maxAge, blockEvidenceMap)
-
+	evpool.removeEvidence(evpool.State(), blockEvidenceMap)
 }
 
 // IsCommitted returns true if we have already seen this exact evidence and it is already marked as committed.
@@ -139,14 +274,47 @@ func (evpool *EvidencePool) IsCommitted(evidence types.Evidence) bool {
 	return ei.Evidence != nil && ei.Committed
 }
 
-func (evpool *EvidencePool) removeEvidence(height, maxAge int64, blockEvidenceMap map[string]struct{}) {
+// Header returns the header committed at the height, nil if we don't have it.
+func (evpool *EvidencePool) Header(height int64) *types.Header {
+	blockMeta := evpool.blockStore.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil
+	}
+	return &blockMeta.Header
+}
+
+// EvidenceInfo returns the EvidenceInfo of the evidence with the hash, nil if
+// we haven't seen it or it expired.
+func (evpool *EvidencePool) EvidenceInfo(hash []byte) *EvidenceInfo {
+	ei := evpool.evidenceStore.GetEvidenceInfoByHash(hash)
+	if ei.Evidence == nil {
+		return nil
+	}
+	return &ei
+}
+
+// NumPending returns the number of pending evidence.
+func (evpool *EvidencePool) NumPending() int {
+	return evpool.evidenceList.Len()
+}
+
+// IsPending returns true if we have already seen this exact evidence and it
+// isn't committed yet.
+func (evpool *EvidencePool) IsPending(evidence types.Evidence) bool {
+	ei := evpool.evidenceStore.getEvidenceInfo(evidence)
+	return ei.Evidence != nil && !ei.Committed
+}
+
+// removeEvidence removes the evidence which is in the block, or which
+// expired, from the clist.
+func (evpool *EvidencePool) removeEvidence(state sm.State, blockEvidenceMap map[string]struct{}) {
 	for e := evpool.evidenceList.Front(); e != nil; e = e.Next() {
 		ev := e.Value.(types.Evidence)
 
 		// Remove the evidence if it's already in a block
 		// or if it's now too old.
 		if _, ok := blockEvidenceMap[evMapKey(ev)]; ok ||
-			ev.Height() < height-maxAge {
+			sm.IsEvidenceExpired(state, ev) {
 
 			// remove from clist
 			evpool.evidenceList.Remove(e)
@@ -155,6 +323,22 @@ func (evpool *EvidencePool) removeEvidence(height, maxAge int64, blockEvidenceMa
 	}
 }
 
+// pruneExpiredEvidence removes the evidence which expired, committed or not,
+// from the store. Only the evidence older than MaxAge blocks may have
+// expired, so the evidence is looked up by height.
+func (evpool *EvidencePool) pruneExpiredEvidence(state sm.State) {
+	minHeight := state.LastBlockHeight - state.ConsensusParams.Evidence.MaxAge
+	for _, ei := range evpool.evidenceStore.listEvidenceInfoBelow(minHeight) {
+		if sm.IsEvidenceExpired(state, ei.Evidence) {
+			evpool.evidenceStore.RemoveEvidence(ei.Evidence)
+			if !ei.Committed {
+				evpool.metrics.ExpiredEvidence.Add(1)
+			}
+			evpool.logger.Debug("Removed expired evidence", "evidence", ei.Evidence, "committed", ei.Committed)
+		}
+	}
+}
+
 func evMapKey(ev types.Evidence) string {
 	return string(ev.Hash())
 }
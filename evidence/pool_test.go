@@ -4,8 +4,10 @@ import (
 	"os"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	dbm "github.com/tendermint/tendermint/libs/db"
 	sm "github.com/tendermint/tendermint/state"
@@ -20,15 +22,33 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
-func initializeValidatorState(valAddr []byte, height int64) dbm.DB {
-	stateDB := dbm.NewMemDB()
+// mockBlockStore serves the blocks it's given.
+type mockBlockStore map[int64]*types.Block
+
+func (bs mockBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	block, ok := bs[height]
+	if !ok {
+		return nil
+	}
+	return &types.BlockMeta{Header: block.Header}
+}
+
+func (bs mockBlockStore) LoadBlock(height int64) *types.Block {
+	return bs[height]
+}
 
+func initializeValidatorState(valAddr []byte, height int64) dbm.DB {
 	// create validator set and state
 	valSet := &types.ValidatorSet{
 		Validators: []*types.Validator{
 			{Address: valAddr},
 		},
 	}
+	return initializeStateFromValidatorSet(valSet, height)
+}
+
+func initializeStateFromValidatorSet(valSet *types.ValidatorSet, height int64) dbm.DB {
+	stateDB := dbm.NewMemDB()
 	state := sm.State{
 		LastBlockHeight:             0,
 		LastBlockTime:               tmtime.Now(),
@@ -57,7 +77,7 @@ func TestEvidencePool(t *testing.T) {
 	height := int64(5)
 	stateDB := initializeValidatorState(valAddr, height)
 	evidenceDB := dbm.NewMemDB()
-	pool := NewEvidencePool(stateDB, evidenceDB)
+	pool := NewEvidencePool(stateDB, evidenceDB, mockBlockStore{})
 
 	goodEvidence := types.NewMockGoodEvidence(height, 0, valAddr)
 	badEvidence := types.MockBadEvidence{goodEvidence}
@@ -91,7 +111,7 @@ func TestEvidencePoolIsCommitted(t *testing.T) {
 	height := int64(42)
 	stateDB := initializeValidatorState(valAddr, height)
 	evidenceDB := dbm.NewMemDB()
-	pool := NewEvidencePool(stateDB, evidenceDB)
+	pool := NewEvidencePool(stateDB, evidenceDB, mockBlockStore{})
 
 	// evidence not seen yet:
 	evidence := types.NewMockGoodEvidence(height, 0, valAddr)
@@ -105,3 +125,188 @@ func TestEvidencePoolIsCommitted(t *testing.T) {
 	pool.MarkEvidenceAsCommitted(height, []types.Evidence{evidence})
 	assert.True(t, pool.IsCommitted(evidence))
 }
+
+func TestEvidencePoolErrors(t *testing.T) {
+	valAddr := []byte("validator_address")
+	height := int64(10)
+	stateDB := initializeValidatorState(valAddr, height)
+	evidenceDB := dbm.NewMemDB()
+	pool := NewEvidencePool(stateDB, evidenceDB, mockBlockStore{})
+
+	evidence := types.NewMockGoodEvidence(height-1, 0, valAddr)
+	require.NoError(t, pool.AddEvidence(evidence))
+
+	// invalid evidence
+	err := pool.AddEvidence(types.MockBadEvidence{MockGoodEvidence: types.NewMockGoodEvidence(height-2, 0, valAddr)})
+	assert.IsType(t, ErrInvalidEvidence{}, err)
+
+	// committed evidence
+	committed := types.NewMockGoodEvidence(height-3, 0, valAddr)
+	pool.MarkEvidenceAsCommitted(height, []types.Evidence{committed})
+	assert.Equal(t, ErrEvidenceAlreadyCommitted, pool.AddEvidence(committed))
+
+	// the evidence expires once it's older than both MaxAge blocks and
+	// MaxAgeDuration
+	state := pool.State()
+	state.LastBlockHeight = height + 3
+	state.ConsensusParams.Evidence.MaxAge = 2
+	state.ConsensusParams.Evidence.MaxAgeDuration = time.Hour
+	sm.SaveState(stateDB, state)
+	pool.Update(&types.Block{Header: types.Header{Height: state.LastBlockHeight}}, state)
+
+	assert.Equal(t, 0, pool.evidenceList.Len())
+	assert.False(t, pool.IsPending(evidence))
+	assert.False(t, pool.IsCommitted(committed))
+	assert.IsType(t, ErrEvidenceExpired{}, pool.AddEvidence(evidence))
+
+	// not expired yet by time
+	state.ConsensusParams.Evidence.MaxAgeDuration = time.Since(evidence.Time()) + time.Hour
+	pool.Update(&types.Block{Header: types.Header{Height: state.LastBlockHeight}}, state)
+	assert.NoError(t, pool.AddEvidence(evidence))
+	assert.Equal(t, 1, pool.evidenceList.Len())
+}
+
+// makeSignedHeader returns a header at the height committed by all the
+// privVals, who must be the validators of vals.
+func makeSignedHeader(t *testing.T, height int64, appHash []byte,
+	vals *types.ValidatorSet, privVals []types.PrivValidator) *types.SignedHeader {
+
+	header := &types.Header{
+		Height:             height,
+		Time:               tmtime.Now(),
+		ValidatorsHash:     vals.Hash(),
+		NextValidatorsHash: vals.Hash(),
+		AppHash:            appHash,
+	}
+	sorted := make([]types.PrivValidator, len(privVals))
+	for _, pv := range privVals {
+		idx, _ := vals.GetByAddress(pv.GetPubKey().Address())
+		sorted[idx] = pv
+	}
+	blockID := types.BlockID{Hash: header.Hash()}
+	voteSet := types.NewVoteSet(header.ChainID, height, 0, types.PrecommitType, vals)
+	commit, err := types.MakeCommit(blockID, height, 0, voteSet, sorted)
+	require.NoError(t, err)
+	return &types.SignedHeader{Header: header, Commit: commit}
+}
+
+func TestEvidencePoolLightClientAttack(t *testing.T) {
+	height := int64(10)
+	vals, privVals := types.RandValidatorSet(4, 10)
+	stateDB := initializeStateFromValidatorSet(vals, height)
+	blockStore := mockBlockStore{}
+	pool := NewEvidencePool(stateDB, dbm.NewMemDB(), blockStore)
+
+	// Half of the validators signed another header at height - 1
+	committed := makeSignedHeader(t, height-1, []byte("app_hash"), vals, privVals)
+	byzVals := types.NewValidatorSet([]*types.Validator{vals.Validators[0].Copy(), vals.Validators[1].Copy()})
+	var byzPrivVals []types.PrivValidator
+	for _, pv := range privVals {
+		if byzVals.HasAddress(pv.GetPubKey().Address()) {
+			byzPrivVals = append(byzPrivVals, pv)
+		}
+	}
+	ev := &types.LightClientAttackEvidence{
+		ConflictingHeader: makeSignedHeader(t, height-1, []byte("other_app_hash"), byzVals, byzPrivVals),
+		ConflictingVals:   byzVals,
+		CommonHeight:      height - 1,
+	}
+
+	// We don't have the header it conflicts with yet, which isn't the fault
+	// of the evidence
+	err := pool.AddEvidence(ev)
+	require.Error(t, err)
+	_, invalid := err.(ErrInvalidEvidence)
+	assert.False(t, invalid)
+
+	blockStore[height-1] = &types.Block{Header: *committed.Header}
+	require.NoError(t, pool.AddEvidence(ev))
+	assert.True(t, pool.IsPending(ev))
+	ei := pool.evidenceStore.GetEvidenceInfo(ev.Height(), ev.Hash())
+	assert.EqualValues(t, 20, ei.Priority)
+
+	// The committed header isn't an attack
+	err = pool.AddEvidence(&types.LightClientAttackEvidence{
+		ConflictingHeader: committed,
+		ConflictingVals:   vals,
+		CommonHeight:      height - 1,
+	})
+	assert.IsType(t, ErrInvalidEvidence{}, err)
+}
+
+func TestEvidencePoolRecover(t *testing.T) {
+	valAddr := []byte("val1")
+	height := int64(5)
+	stateDB := initializeValidatorState(valAddr, height)
+	evidenceDB := dbm.NewMemDB()
+	blockStore := mockBlockStore{}
+	pool := NewEvidencePool(stateDB, evidenceDB, blockStore)
+
+	committedEv := types.NewMockGoodEvidence(height-1, 0, valAddr)
+	pendingEv := types.NewMockGoodEvidence(height-2, 0, valAddr)
+	require.NoError(t, pool.AddEvidence(committedEv))
+	require.NoError(t, pool.AddEvidence(pendingEv))
+
+	// Crash, then the block committing the evidence is replayed without the
+	// pool on the handshake
+	state := sm.LoadState(stateDB)
+	state.LastBlockHeight = height
+	sm.SaveState(stateDB, state)
+	blockStore[height] = &types.Block{
+		Header:   types.Header{Height: height},
+		Evidence: types.EvidenceData{Evidence: []types.Evidence{committedEv}},
+	}
+
+	pool = NewEvidencePool(stateDB, evidenceDB, blockStore)
+	assert.True(t, pool.IsCommitted(committedEv))
+	assert.True(t, pool.IsPending(pendingEv))
+	assert.Equal(t, 1, pool.NumPending())
+	require.NotNil(t, pool.EvidenceFront())
+	assert.Equal(t, pendingEv, pool.EvidenceFront().Value)
+	assert.EqualValues(t, height, pool.evidenceStore.Height())
+
+	ei := pool.EvidenceInfo(committedEv.Hash())
+	require.NotNil(t, ei)
+	assert.True(t, ei.Committed)
+	assert.EqualValues(t, height-1, ei.VerifiedHeight)
+	assert.EqualValues(t, height, ei.CommittedHeight)
+
+	ei = pool.EvidenceInfo(pendingEv.Hash())
+	require.NotNil(t, ei)
+	assert.False(t, ei.Committed)
+	assert.EqualValues(t, 0, ei.CommittedHeight)
+
+	assert.Nil(t, pool.EvidenceInfo([]byte("unknown")))
+}
+
+func TestEvidencePoolReapMaxBytes(t *testing.T) {
+	height := int64(10)
+	val1, val2 := []byte("val1"), []byte("val2")
+	val3 := []byte("validator_with_a_longer_address")
+	valSet := &types.ValidatorSet{
+		Validators: []*types.Validator{
+			{Address: val1, VotingPower: 10},
+			{Address: val2, VotingPower: 20},
+			{Address: val3, VotingPower: 5},
+		},
+	}
+	stateDB := initializeStateFromValidatorSet(valSet, height)
+	pool := NewEvidencePool(stateDB, dbm.NewMemDB(), mockBlockStore{})
+
+	// the oldest evidence is the biggest
+	old := types.NewMockGoodEvidence(height-2, 0, val3)
+	lowPower := types.NewMockGoodEvidence(height-1, 0, val1)
+	highPower := types.NewMockGoodEvidence(height-1, 0, val2)
+	for _, ev := range []types.Evidence{lowPower, highPower, old} {
+		require.NoError(t, pool.AddEvidence(ev))
+	}
+	oldSize, mockSize := types.EvidenceByteSize(old), types.EvidenceByteSize(lowPower)
+	require.True(t, oldSize > mockSize)
+
+	// by age, then by voting power
+	assert.Equal(t, []types.Evidence{old, highPower, lowPower}, pool.ReapMaxBytes(oldSize+2*mockSize))
+	assert.Equal(t, []types.Evidence{old, highPower}, pool.ReapMaxBytes(oldSize+2*mockSize-1))
+	// the evidence which doesn't fit is skipped
+	assert.Equal(t, []types.Evidence{highPower, lowPower}, pool.ReapMaxBytes(2*mockSize))
+	assert.Empty(t, pool.ReapMaxBytes(0))
+}
@@ -10,6 +10,7 @@ import (
 	clist "github.com/tendermint/tendermint/libs/clist"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/p2p"
+	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -20,6 +21,14 @@ const (
 
 	broadcastEvidenceIntervalS = 60  // broadcast uncommitted evidence this often
 	peerCatchupSleepIntervalMS = 100 // If peer is behind, sleep this amount
+
+	// If the peer is more than peerFarBehindBlocks behind the evidence, sleep
+	// peerFarBehindSleepIntervalMS before checking again, since it won't catch
+	// up soon.
+	peerFarBehindBlocks          = 100
+	peerFarBehindSleepIntervalMS = 5000
+
+	reactorName = "EVIDENCE"
 )
 
 // EvidenceReactor handles evpool evidence broadcasting amongst peers.
@@ -71,13 +80,13 @@ func (evR *EvidenceReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	msg, err := decodeMsg(msgBytes)
 	if err != nil {
 		evR.Logger.Error("Error decoding message", "src", src, "chId", chID, "msg", msg, "err", err, "bytes", msgBytes)
-		evR.Switch.StopPeerForError(src, err)
+		evR.reportError(src, p2p.ErrorPeerBehaviourBadMessage, 0, err)
 		return
 	}
 
 	if err = msg.ValidateBasic(); err != nil {
 		evR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
-		evR.Switch.StopPeerForError(src, err)
+		evR.reportError(src, p2p.ErrorPeerBehaviourBadEvidence, 0, err)
 		return
 	}
 
@@ -87,10 +96,18 @@ func (evR *EvidenceReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 	case *EvidenceListMessage:
 		for _, ev := range msg.Evidence {
 			err := evR.evpool.AddEvidence(ev)
-			if err != nil {
-				evR.Logger.Info("Evidence is not valid", "evidence", msg.Evidence, "err", err)
-				// punish peer
-				evR.Switch.StopPeerForError(src, err)
+			switch err.(type) {
+			case nil:
+			case ErrInvalidEvidence:
+				evR.Logger.Info("Evidence is not valid", "evidence", ev, "err", err)
+				evR.reportError(src, p2p.ErrorPeerBehaviourBadEvidence, ev.Height(), err)
+			case ErrEvidenceExpired:
+				evR.Logger.Debug("Evidence has expired", "evidence", ev, "err", err)
+				evR.reportError(src, p2p.ErrorPeerBehaviourExpiredEvidence, ev.Height(), err)
+			default:
+				// The evidence was committed in the meantime, or we can't
+				// verify it yet. Either way it's not the peer's fault.
+				evR.Logger.Debug("Evidence was not added", "evidence", ev, "err", err)
 			}
 		}
 	default:
@@ -131,12 +148,13 @@ func (evR *EvidenceReactor) broadcastEvidenceRoutine(peer p2p.Peer) {
 		ev := next.Value.(types.Evidence)
 		msg, retry := evR.checkSendEvidenceMessage(peer, ev)
 		if msg != nil {
-			success := peer.Send(EvidenceChannel, cdc.MustMarshalBinaryBare(msg))
-			retry = !success
+			if success := peer.Send(EvidenceChannel, cdc.MustMarshalBinaryBare(msg)); !success {
+				retry = peerCatchupSleepIntervalMS * time.Millisecond
+			}
 		}
 
-		if retry {
-			time.Sleep(peerCatchupSleepIntervalMS * time.Millisecond)
+		if retry > 0 {
+			time.Sleep(retry)
 			continue
 		}
 
@@ -158,8 +176,9 @@ func (evR *EvidenceReactor) broadcastEvidenceRoutine(peer p2p.Peer) {
 }
 
 // Returns the message to send the peer, or nil if the evidence is invalid for the peer.
-// If message is nil, return true if we should sleep and try again.
-func (evR EvidenceReactor) checkSendEvidenceMessage(peer p2p.Peer, ev types.Evidence) (msg EvidenceMessage, retry bool) {
+// If message is nil, return how long we should sleep before trying again, or 0
+// to skip the evidence.
+func (evR EvidenceReactor) checkSendEvidenceMessage(peer p2p.Peer, ev types.Evidence) (msg EvidenceMessage, retry time.Duration) {
 	// make sure the peer is up to date
 	evHeight := ev.Height()
 	peerState, ok := peer.Get(types.PeerStateKey).(PeerState)
@@ -169,27 +188,49 @@ func (evR EvidenceReactor) checkSendEvidenceMessage(peer p2p.Peer, ev types.Evid
 		// different every time due to us using a map. Sometimes other reactors
 		// will be initialized before the consensus reactor. We should wait a few
 		// milliseconds and retry.
-		return nil, true
+		return nil, peerCatchupSleepIntervalMS * time.Millisecond
+	}
+
+	// the evidence might have been committed or expired since it was added to
+	// the list, in which case the peer won't accept it
+	state := evR.evpool.State()
+	if evR.evpool.IsCommitted(ev) || sm.IsEvidenceExpired(state, ev) {
+		return nil, 0
 	}
 
 	// NOTE: We only send evidence to peers where
 	// peerHeight - maxAge < evidenceHeight < peerHeight
-	maxAge := evR.evpool.State().ConsensusParams.Evidence.MaxAge
+	maxAge := state.ConsensusParams.Evidence.MaxAge
 	peerHeight := peerState.GetHeight()
 	if peerHeight < evHeight {
-		// peer is behind. sleep while he catches up
-		return nil, true
+		// peer is behind. sleep while he catches up, for longer if he's far
+		// behind
+		if evHeight-peerHeight > peerFarBehindBlocks {
+			return nil, peerFarBehindSleepIntervalMS * time.Millisecond
+		}
+		return nil, peerCatchupSleepIntervalMS * time.Millisecond
 	} else if peerHeight > evHeight+maxAge {
 		// evidence is too old, skip
 		// NOTE: if evidence is too old for an honest peer,
 		// then we're behind and either it already got committed or it never will!
 		evR.Logger.Info("Not sending peer old evidence", "peerHeight", peerHeight, "evHeight", evHeight, "maxAge", maxAge, "peer", peer)
-		return nil, false
+		return nil, 0
 	}
 
 	// send evidence
 	msg = &EvidenceListMessage{[]types.Evidence{ev}}
-	return msg, false
+	return msg, 0
+}
+
+// reportError reports the misbehaviour of a peer to the PeerBehaviour of the
+// Switch, which decides whether to stop the peer.
+func (evR *EvidenceReactor) reportError(peer p2p.Peer, reason p2p.ErrorPeerBehaviour, height int64, err error) {
+	evR.Switch.PeerBehaviour().Errored(peer, p2p.ErrorBehaviourReport{
+		Reason:  reason,
+		Reactor: reactorName,
+		Height:  height,
+		Detail:  err.Error(),
+	})
 }
 
 // PeerState describes the state of a peer.
@@ -17,24 +17,37 @@ Impl:
 	- First commit atomically in outqueue, pending, lookup.
 	- Once broadcast, remove from outqueue. No need to sync
 	- Once committed, atomically remove from pending and update lookup.
+	- Keep the height of the last block the evidence was updated with, to
+	  recover after a crash.
 
 Schema for indexing evidence (note you need both height and hash to find a piece of evidence):
 
 "evidence-lookup"/<evidence-height>/<evidence-hash> -> EvidenceInfo
 "evidence-outqueue"/<priority>/<evidence-height>/<evidence-hash> -> EvidenceInfo
 "evidence-pending"/<evidence-height>/<evidence-hash> -> EvidenceInfo
+"evidence-hash"/<evidence-hash> -> <evidence-height>
+"evidence-height" -> <height>
 */
 
 type EvidenceInfo struct {
 	Committed bool
 	Priority  int64
 	Evidence  types.Evidence
+
+	// height of the state the evidence was verified against, 0 if it was
+	// only seen committed
+	VerifiedHeight int64
+	// height of the block the evidence was committed in
+	CommittedHeight int64
 }
 
 const (
 	baseKeyLookup   = "evidence-lookup"   // all evidence
 	baseKeyOutqueue = "evidence-outqueue" // not-yet broadcast
 	baseKeyPending  = "evidence-pending"  // broadcast but not committed
+	baseKeyHash     = "evidence-hash"     // height of all evidence by hash
+
+	keyHeight = "evidence-height"
 )
 
 func keyLookup(evidence types.Evidence) []byte {
@@ -42,6 +55,10 @@ func keyLookup(evidence types.Evidence) []byte {
 }
 
 // big endian padded hex
+func keyHash(hash []byte) []byte {
+	return _key("%s/%X", baseKeyHash, hash)
+}
+
 func bE(h int64) string {
 	return fmt.Sprintf("%0.16X", h)
 }
@@ -92,6 +109,22 @@ func (store *EvidenceStore) PendingEvidence(maxNum int64) (evidence []types.Evid
 	return store.listEvidence(baseKeyPending, maxNum)
 }
 
+// pendingEvidenceInfo lists the EvidenceInfo of the uncommitted evidence, from
+// the lowest height.
+func (store *EvidenceStore) pendingEvidenceInfo() (infos []EvidenceInfo) {
+	iter := dbm.IteratePrefix(store.db, []byte(baseKeyPending))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var ei EvidenceInfo
+		err := cdc.UnmarshalBinaryBare(iter.Value(), &ei)
+		if err != nil {
+			panic(err)
+		}
+		infos = append(infos, ei)
+	}
+	return infos
+}
+
 // listEvidence lists up to maxNum pieces of evidence for the given prefix key.
 // It is wrapped by PriorityEvidence and PendingEvidence for convenience.
 // If maxNum is -1, there's no cap on the size of returned evidence.
@@ -117,6 +150,24 @@ func (store *EvidenceStore) listEvidence(prefixKey string, maxNum int64) (eviden
 	return evidence
 }
 
+// listEvidenceInfoBelow lists the EvidenceInfo of all the evidence, committed
+// or not, below the given height, from the lowest height.
+func (store *EvidenceStore) listEvidenceInfoBelow(height int64) (infos []EvidenceInfo) {
+	start := []byte(baseKeyLookup + "/")
+	end := _key("%s/%s", baseKeyLookup, bE(height))
+	iter := store.db.Iterator(start, end)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var ei EvidenceInfo
+		err := cdc.UnmarshalBinaryBare(iter.Value(), &ei)
+		if err != nil {
+			panic(err)
+		}
+		infos = append(infos, ei)
+	}
+	return infos
+}
+
 // GetEvidenceInfo fetches the EvidenceInfo with the given height and hash.
 // If not found, ei.Evidence is nil.
 func (store *EvidenceStore) GetEvidenceInfo(height int64, hash []byte) EvidenceInfo {
@@ -134,9 +185,22 @@ func (store *EvidenceStore) GetEvidenceInfo(height int64, hash []byte) EvidenceI
 	return ei
 }
 
-// AddNewEvidence adds the given evidence to the database.
+// GetEvidenceInfoByHash fetches the EvidenceInfo with the given hash.
+// If not found, ei.Evidence is nil.
+func (store *EvidenceStore) GetEvidenceInfoByHash(hash []byte) EvidenceInfo {
+	val := store.db.Get(keyHash(hash))
+	if len(val) == 0 {
+		return EvidenceInfo{}
+	}
+	var height int64
+	cdc.MustUnmarshalBinaryBare(val, &height)
+	return store.GetEvidenceInfo(height, hash)
+}
+
+// AddNewEvidence adds the given evidence, verified against the state at
+// verifiedHeight, to the database.
 // It returns false if the evidence is already stored.
-func (store *EvidenceStore) AddNewEvidence(evidence types.Evidence, priority int64) bool {
+func (store *EvidenceStore) AddNewEvidence(evidence types.Evidence, priority, verifiedHeight int64) bool {
 	// check if we already have seen it
 	ei := store.getEvidenceInfo(evidence)
 	if ei.Evidence != nil {
@@ -144,12 +208,15 @@ func (store *EvidenceStore) AddNewEvidence(evidence types.Evidence, priority int
 	}
 
 	ei = EvidenceInfo{
-		Committed: false,
-		Priority:  priority,
-		Evidence:  evidence,
+		Committed:      false,
+		Priority:       priority,
+		Evidence:       evidence,
+		VerifiedHeight: verifiedHeight,
 	}
 	eiBytes := cdc.MustMarshalBinaryBare(ei)
 
+	store.db.Set(keyHash(evidence.Hash()), cdc.MustMarshalBinaryBare(evidence.Height()))
+
 	// add it to the store
 	key := keyOutqueue(evidence, priority)
 	store.db.Set(key, eiBytes)
@@ -175,8 +242,9 @@ func (store *EvidenceStore) MarkEvidenceAsBroadcasted(evidence types.Evidence) {
 	store.db.Delete(key)
 }
 
-// MarkEvidenceAsCommitted removes evidence from pending and outqueue and sets the state to committed.
-func (store *EvidenceStore) MarkEvidenceAsCommitted(evidence types.Evidence) {
+// MarkEvidenceAsCommitted removes evidence from pending and outqueue and sets the state to committed,
+// in the block at the given height.
+func (store *EvidenceStore) MarkEvidenceAsCommitted(evidence types.Evidence, height int64) {
 	// if its committed, its been broadcast
 	store.MarkEvidenceAsBroadcasted(evidence)
 
@@ -185,15 +253,50 @@ func (store *EvidenceStore) MarkEvidenceAsCommitted(evidence types.Evidence) {
 
 	// committed EvidenceInfo doens't need priority
 	ei := EvidenceInfo{
-		Committed: true,
-		Evidence:  evidence,
-		Priority:  0,
+		Committed:       true,
+		Evidence:        evidence,
+		Priority:        0,
+		VerifiedHeight:  store.getEvidenceInfo(evidence).VerifiedHeight,
+		CommittedHeight: height,
 	}
 
+	store.db.Set(keyHash(evidence.Hash()), cdc.MustMarshalBinaryBare(evidence.Height()))
 	lookupKey := keyLookup(evidence)
 	store.db.SetSync(lookupKey, cdc.MustMarshalBinaryBare(ei))
 }
 
+// RemoveEvidence removes the evidence from the store, whether it's committed
+// or not, e.g. once it expired.
+func (store *EvidenceStore) RemoveEvidence(evidence types.Evidence) {
+	ei := store.getEvidenceInfo(evidence)
+	if ei.Evidence == nil {
+		return
+	}
+	if !ei.Committed {
+		store.db.Delete(keyOutqueue(evidence, ei.Priority))
+		store.db.Delete(keyPending(evidence))
+	}
+	store.db.Delete(keyHash(evidence.Hash()))
+	store.db.DeleteSync(keyLookup(evidence))
+}
+
+// Height returns the height of the last block the evidence was updated with,
+// 0 if none.
+func (store *EvidenceStore) Height() int64 {
+	val := store.db.Get([]byte(keyHeight))
+	if len(val) == 0 {
+		return 0
+	}
+	var height int64
+	cdc.MustUnmarshalBinaryBare(val, &height)
+	return height
+}
+
+// SetHeight sets the height of the last block the evidence was updated with.
+func (store *EvidenceStore) SetHeight(height int64) {
+	store.db.SetSync([]byte(keyHeight), cdc.MustMarshalBinaryBare(height))
+}
+
 //---------------------------------------------------
 // utils
 
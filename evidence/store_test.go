@@ -19,11 +19,11 @@ func TestStoreAddDuplicate(t *testing.T) {
 	priority := int64(10)
 	ev := types.NewMockGoodEvidence(2, 1, []byte("val1"))
 
-	added := store.AddNewEvidence(ev, priority)
+	added := store.AddNewEvidence(ev, priority, 1)
 	assert.True(added)
 
 	// cant add twice
-	added = store.AddNewEvidence(ev, priority)
+	added = store.AddNewEvidence(ev, priority, 1)
 	assert.False(added)
 }
 
@@ -36,12 +36,44 @@ func TestStoreCommitDuplicate(t *testing.T) {
 	priority := int64(10)
 	ev := types.NewMockGoodEvidence(2, 1, []byte("val1"))
 
-	store.MarkEvidenceAsCommitted(ev)
+	store.MarkEvidenceAsCommitted(ev, 3)
 
-	added := store.AddNewEvidence(ev, priority)
+	added := store.AddNewEvidence(ev, priority, 1)
 	assert.False(added)
 }
 
+func TestStoreRemove(t *testing.T) {
+	assert := assert.New(t)
+
+	db := dbm.NewMemDB()
+	store := NewEvidenceStore(db)
+
+	pending := types.NewMockGoodEvidence(1, 1, []byte("val1"))
+	committed := types.NewMockGoodEvidence(2, 1, []byte("val1"))
+	recent := types.NewMockGoodEvidence(3, 1, []byte("val1"))
+	store.AddNewEvidence(pending, 10, 1)
+	store.MarkEvidenceAsCommitted(committed, 3)
+	store.AddNewEvidence(recent, 10, 1)
+
+	infos := store.listEvidenceInfoBelow(3)
+	if assert.Len(infos, 2) {
+		assert.Equal(pending, infos[0].Evidence)
+		assert.Equal(committed, infos[1].Evidence)
+		assert.True(infos[1].Committed)
+	}
+
+	store.RemoveEvidence(pending)
+	store.RemoveEvidence(committed)
+	assert.Nil(store.GetEvidenceInfo(1, pending.Hash()).Evidence)
+	assert.Nil(store.GetEvidenceInfo(2, committed.Hash()).Evidence)
+	assert.Nil(store.GetEvidenceInfoByHash(pending.Hash()).Evidence)
+	assert.Equal([]types.Evidence{recent}, store.PendingEvidence(-1))
+	assert.Equal([]types.Evidence{recent}, store.PriorityEvidence())
+
+	// committed evidence which was removed can be added again
+	assert.True(store.AddNewEvidence(committed, 10, 1))
+}
+
 func TestStoreMark(t *testing.T) {
 	assert := assert.New(t)
 
@@ -57,7 +89,7 @@ func TestStoreMark(t *testing.T) {
 	priority := int64(10)
 	ev := types.NewMockGoodEvidence(2, 1, []byte("val1"))
 
-	added := store.AddNewEvidence(ev, priority)
+	added := store.AddNewEvidence(ev, priority, 1)
 	assert.True(added)
 
 	// get the evidence. verify. should be uncommitted
@@ -80,7 +112,7 @@ func TestStoreMark(t *testing.T) {
 	assert.Equal(1, len(pendingEv))
 
 	// priority and pending are now empty
-	store.MarkEvidenceAsCommitted(ev)
+	store.MarkEvidenceAsCommitted(ev, 3)
 	priorityEv = store.PriorityEvidence()
 	pendingEv = store.PendingEvidence(-1)
 	assert.Equal(0, len(priorityEv))
@@ -92,6 +124,24 @@ func TestStoreMark(t *testing.T) {
 	assert.Equal(ev, ei.Evidence)
 	assert.Equal(newPriority, ei.Priority)
 	assert.True(ei.Committed)
+	assert.EqualValues(1, ei.VerifiedHeight)
+	assert.EqualValues(3, ei.CommittedHeight)
+
+	// the evidence can be looked up by its hash only
+	assert.Equal(ei, store.GetEvidenceInfoByHash(ev.Hash()))
+	assert.Nil(store.GetEvidenceInfoByHash([]byte("unknown")).Evidence)
+}
+
+func TestStoreHeight(t *testing.T) {
+	assert := assert.New(t)
+
+	db := dbm.NewMemDB()
+	store := NewEvidenceStore(db)
+	assert.EqualValues(0, store.Height())
+
+	store.SetHeight(5)
+	assert.EqualValues(5, store.Height())
+	assert.EqualValues(5, NewEvidenceStore(db).Height())
 }
 
 func TestStorePriority(t *testing.T) {
@@ -114,7 +164,7 @@ func TestStorePriority(t *testing.T) {
 	}
 
 	for _, c := range cases {
-		added := store.AddNewEvidence(c.ev, c.priority)
+		added := store.AddNewEvidence(c.ev, c.priority, 1)
 		assert.True(added)
 	}
 
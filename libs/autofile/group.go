@@ -320,6 +320,28 @@ func (g *Group) RotateFile() {
 	g.maxIndex++
 }
 
+// RemoveFilesBefore removes the files of the group with an index lower than
+// index. The head is never removed.
+func (g *Group) RemoveFilesBefore(index int) error {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	for ; g.minIndex < index && g.minIndex < g.maxIndex; g.minIndex++ {
+		path := filePathForIndex(g.Head.Path, g.minIndex, g.maxIndex)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// FilePath returns the path of the file of the group with the given index.
+func (g *Group) FilePath(index int) string {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return filePathForIndex(g.Head.Path, index, g.maxIndex)
+}
+
 // NewReader returns a new group reader.
 // CONTRACT: Caller must close the returned GroupReader.
 func (g *Group) NewReader(index int) (*GroupReader, error) {
@@ -237,6 +237,34 @@ func TestRotateFile(t *testing.T) {
 	destroyTestGroup(t, g)
 }
 
+func TestRemoveFilesBefore(t *testing.T) {
+	g := createTestGroupWithHeadSizeLimit(t, 0)
+	for i := 0; i < 3; i++ {
+		g.WriteLine(fmt.Sprintf("Line %d", i))
+		g.FlushAndSync()
+		g.RotateFile()
+	}
+	g.WriteLine("Line 3")
+	g.FlushAndSync()
+	assertGroupInfo(t, g.ReadGroupInfo(), 0, 3, 28, 7)
+
+	require.NoError(t, g.RemoveFilesBefore(2))
+	assert.Equal(t, 2, g.MinIndex())
+	assertGroupInfo(t, g.ReadGroupInfo(), 2, 3, 14, 7)
+	_, err := os.Stat(g.FilePath(1))
+	assert.True(t, os.IsNotExist(err))
+
+	// the head is never removed
+	require.NoError(t, g.RemoveFilesBefore(10))
+	assert.Equal(t, 3, g.MinIndex())
+	assert.Equal(t, g.Head.Path, g.FilePath(3))
+	_, err = os.Stat(g.Head.Path)
+	assert.NoError(t, err)
+
+	// Cleanup
+	destroyTestGroup(t, g)
+}
+
 func TestFindLast1(t *testing.T) {
 	g := createTestGroupWithHeadSizeLimit(t, 0)
 
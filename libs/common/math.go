@@ -1,5 +1,7 @@
 package common
 
+import "fmt"
+
 func MaxInt64(a, b int64) int64 {
 	if a > b {
 		return a
@@ -29,3 +31,15 @@ func MinInt(a, b int) int {
 	}
 	return b
 }
+
+//-----------------------------------------------------------------------------
+
+// Fraction defines a fraction, like the trust level of the light client.
+type Fraction struct {
+	Numerator   int64 `json:"numerator"`
+	Denominator int64 `json:"denominator"`
+}
+
+func (fr Fraction) String() string {
+	return fmt.Sprintf("%d/%d", fr.Numerator, fr.Denominator)
+}
@@ -0,0 +1,170 @@
+// Package eventlog keeps the events published recently, each with a cursor,
+// so that a client can read the events after the last one it got, e.g. after
+// it reconnected, rather than missing them.
+package eventlog
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Cursor identifies an event of a log. It is the time the event was added, in
+// Unix nanoseconds, made unique, so the cursors of the events increase, also
+// across the restarts of the node.
+type Cursor int64
+
+// String returns the cursor as a decimal number.
+func (c Cursor) String() string {
+	return strconv.FormatInt(int64(c), 10)
+}
+
+// ParseCursor parses a cursor returned by String.
+func ParseCursor(s string) (Cursor, error) {
+	c, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || c < 0 {
+		return 0, fmt.Errorf("invalid cursor %q", s)
+	}
+	return Cursor(c), nil
+}
+
+// Item is an event of a log.
+type Item struct {
+	Cursor Cursor
+	Data   interface{}
+	Tags   map[string]string
+}
+
+// Result is the events of a log after a cursor.
+type Result struct {
+	// The events, oldest first.
+	Items []*Item
+	// Whether there are more events matching after the last item.
+	More bool
+	// Whether events after the cursor may have been pruned (or published
+	// before the log was created), in which case they can't be read anymore.
+	Truncated bool
+	// The cursors of the oldest and the newest event of the log, 0 if empty.
+	Oldest, Newest Cursor
+}
+
+// Log keeps the events added in the last window of time, up to a maximum
+// number of events. It is safe for concurrent use.
+type Log struct {
+	windowSize time.Duration
+	maxItems   int
+
+	mtx   sync.Mutex
+	items []*Item // oldest first
+	// the log has all the events added after this cursor: the last one pruned
+	// or the creation of the log
+	since Cursor
+	last  Cursor
+	added chan struct{} // closed (and replaced) when an event is added
+}
+
+// NewLog returns a log keeping the events of the last windowSize, up to
+// maxItems events (or any number of events if 0).
+func NewLog(windowSize time.Duration, maxItems int) *Log {
+	now := Cursor(time.Now().UnixNano())
+	return &Log{
+		windowSize: windowSize,
+		maxItems:   maxItems,
+		since:      now,
+		last:       now,
+		added:      make(chan struct{}),
+	}
+}
+
+// Add adds the event and its tags, which must not be modified afterwards, and
+// returns its cursor. The events out of the window are pruned.
+func (lg *Log) Add(data interface{}, tags map[string]string) Cursor {
+	now := time.Now()
+
+	lg.mtx.Lock()
+	defer lg.mtx.Unlock()
+
+	cursor := Cursor(now.UnixNano())
+	if cursor <= lg.last {
+		cursor = lg.last + 1
+	}
+	lg.last = cursor
+	lg.items = append(lg.items, &Item{Cursor: cursor, Data: data, Tags: tags})
+	lg.prune(now)
+
+	close(lg.added)
+	lg.added = make(chan struct{})
+	return cursor
+}
+
+// After returns up to maxItems events (or all of them if 0) after the cursor
+// whose tags match (all of them if match is nil). The cursor 0 is before the
+// oldest event of the log.
+func (lg *Log) After(after Cursor, match func(tags map[string]string) bool, maxItems int) Result {
+	lg.mtx.Lock()
+	defer lg.mtx.Unlock()
+	return lg.after(after, match, maxItems)
+}
+
+// WaitAfter does the same as After, except it waits for an event to be added
+// if none matches, until the context is done.
+func (lg *Log) WaitAfter(ctx context.Context, after Cursor, match func(tags map[string]string) bool, maxItems int) Result {
+	for {
+		lg.mtx.Lock()
+		res := lg.after(after, match, maxItems)
+		added := lg.added
+		lg.mtx.Unlock()
+
+		if len(res.Items) > 0 {
+			return res
+		}
+		select {
+		case <-added:
+		case <-ctx.Done():
+			return res
+		}
+	}
+}
+
+// Len returns the number of events of the log.
+func (lg *Log) Len() int {
+	lg.mtx.Lock()
+	defer lg.mtx.Unlock()
+	return len(lg.items)
+}
+
+func (lg *Log) after(after Cursor, match func(tags map[string]string) bool, maxItems int) Result {
+	lg.prune(time.Now())
+
+	res := Result{Truncated: after != 0 && after < lg.since}
+	if len(lg.items) > 0 {
+		res.Oldest, res.Newest = lg.items[0].Cursor, lg.items[len(lg.items)-1].Cursor
+	}
+	for _, item := range lg.items {
+		if item.Cursor <= after || (match != nil && !match(item.Tags)) {
+			continue
+		}
+		if maxItems > 0 && len(res.Items) == maxItems {
+			res.More = true
+			break
+		}
+		res.Items = append(res.Items, item)
+	}
+	return res
+}
+
+// prune removes the events out of the window, and the oldest ones beyond
+// maxItems.
+func (lg *Log) prune(now time.Time) {
+	oldest := Cursor(now.Add(-lg.windowSize).UnixNano())
+	n := 0
+	for n < len(lg.items) &&
+		(lg.items[n].Cursor < oldest || (lg.maxItems > 0 && len(lg.items)-n > lg.maxItems)) {
+		lg.since = lg.items[n].Cursor
+		lg.items[n] = nil // for the GC
+		n++
+	}
+	lg.items = lg.items[n:]
+}
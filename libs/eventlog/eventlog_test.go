@@ -0,0 +1,111 @@
+package eventlog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	c, err := ParseCursor(Cursor(1563389219146482000).String())
+	require.NoError(t, err)
+	assert.Equal(t, Cursor(1563389219146482000), c)
+
+	for _, s := range []string{"", "abc", "-1", "1.5"} {
+		_, err := ParseCursor(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestLogAfter(t *testing.T) {
+	lg := NewLog(time.Minute, 0)
+	var cursors []Cursor
+	for i := 0; i < 5; i++ {
+		tags := map[string]string{"tm.event": "Tx"}
+		if i%2 == 1 {
+			tags["tm.event"] = "NewBlock"
+		}
+		cursors = append(cursors, lg.Add(i, tags))
+	}
+	for i := 1; i < len(cursors); i++ {
+		assert.True(t, cursors[i] > cursors[i-1], "cursors must increase")
+	}
+	assert.Equal(t, 5, lg.Len())
+
+	res := lg.After(0, nil, 0)
+	assert.Len(t, res.Items, 5)
+	assert.False(t, res.More)
+	assert.False(t, res.Truncated)
+	assert.Equal(t, cursors[0], res.Oldest)
+	assert.Equal(t, cursors[4], res.Newest)
+
+	res = lg.After(cursors[1], nil, 2)
+	require.Len(t, res.Items, 2)
+	assert.Equal(t, 2, res.Items[0].Data)
+	assert.Equal(t, 3, res.Items[1].Data)
+	assert.True(t, res.More)
+
+	isTx := func(tags map[string]string) bool { return tags["tm.event"] == "Tx" }
+	res = lg.After(cursors[0], isTx, 0)
+	require.Len(t, res.Items, 2)
+	assert.Equal(t, 2, res.Items[0].Data)
+	assert.Equal(t, 4, res.Items[1].Data)
+	assert.False(t, res.More)
+
+	res = lg.After(cursors[4], nil, 0)
+	assert.Empty(t, res.Items)
+	assert.False(t, res.Truncated)
+
+	// a cursor from before the creation of the log
+	res = lg.After(cursors[0]-Cursor(time.Hour), nil, 0)
+	assert.Len(t, res.Items, 5)
+	assert.True(t, res.Truncated)
+}
+
+func TestLogPrunes(t *testing.T) {
+	lg := NewLog(time.Minute, 3)
+	var cursors []Cursor
+	for i := 0; i < 5; i++ {
+		cursors = append(cursors, lg.Add(i, nil))
+	}
+	assert.Equal(t, 3, lg.Len())
+
+	res := lg.After(cursors[0], nil, 0)
+	require.Len(t, res.Items, 3)
+	assert.Equal(t, 2, res.Items[0].Data)
+	assert.True(t, res.Truncated, "the event after the cursor was pruned")
+
+	res = lg.After(cursors[1], nil, 0)
+	assert.Len(t, res.Items, 3)
+	assert.False(t, res.Truncated)
+
+	lg = NewLog(50*time.Millisecond, 0)
+	lg.Add(0, nil)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, Cursor(0), lg.After(0, nil, 0).Oldest)
+	lg.Add(1, nil)
+	assert.Equal(t, 1, lg.Len())
+}
+
+func TestLogWaitAfter(t *testing.T) {
+	lg := NewLog(time.Minute, 0)
+	first := lg.Add(0, nil)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		lg.Add(1, nil)
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	res := lg.WaitAfter(ctx, first, nil, 0)
+	require.Len(t, res.Items, 1)
+	assert.Equal(t, 1, res.Items[0].Data)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	res = lg.WaitAfter(ctx, res.Newest, nil, 0)
+	assert.Empty(t, res.Items)
+}
@@ -38,6 +38,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 
 	cmn "github.com/tendermint/tendermint/libs/common"
 )
@@ -150,17 +151,27 @@ func (s *Server) Subscribe(ctx context.Context, clientID string, query Query, ou
 		outCap = outCapacity[0]
 	}
 
-	return s.subscribe(ctx, clientID, query, outCap)
+	return s.subscribe(ctx, clientID, query, outCap, OverflowCancel)
+}
+
+// SubscribeWithOverflow does the same as Subscribe, except the policy tells
+// what happens when a message is published while the channel of the
+// subscription is full. Panics if outCapacity is less than or equal to zero.
+func (s *Server) SubscribeWithOverflow(ctx context.Context, clientID string, query Query, outCapacity int, policy OverflowPolicy) (*Subscription, error) {
+	if outCapacity <= 0 {
+		panic("Negative or zero capacity. Use SubscribeUnbuffered if you want an unbuffered channel")
+	}
+	return s.subscribe(ctx, clientID, query, outCapacity, policy)
 }
 
 // SubscribeUnbuffered does the same as Subscribe, except it returns a
 // subscription with unbuffered channel. Use with caution as it can freeze the
 // server.
 func (s *Server) SubscribeUnbuffered(ctx context.Context, clientID string, query Query) (*Subscription, error) {
-	return s.subscribe(ctx, clientID, query, 0)
+	return s.subscribe(ctx, clientID, query, 0, OverflowCancel)
 }
 
-func (s *Server) subscribe(ctx context.Context, clientID string, query Query, outCapacity int) (*Subscription, error) {
+func (s *Server) subscribe(ctx context.Context, clientID string, query Query, outCapacity int, policy OverflowPolicy) (*Subscription, error) {
 	s.mtx.RLock()
 	clientSubscriptions, ok := s.subscriptions[clientID]
 	if ok {
@@ -172,6 +183,7 @@ func (s *Server) subscribe(ctx context.Context, clientID string, query Query, ou
 	}
 
 	subscription := NewSubscription(outCapacity)
+	subscription.overflow = policy
 	select {
 	case s.cmds <- cmd{op: sub, clientID: clientID, query: query, subscription: subscription}:
 		s.mtx.Lock()
@@ -405,6 +417,10 @@ func (state *state) send(msg interface{}, tags map[string]string) {
 					select {
 					case subscription.out <- Message{msg, tags}:
 					default:
+						if subscription.overflow == OverflowDrop {
+							atomic.AddUint64(&subscription.dropped, 1)
+							continue
+						}
 						state.remove(clientID, qStr, ErrOutOfCapacity)
 					}
 				}
@@ -124,6 +124,35 @@ func TestSlowClientIsRemovedWithErrOutOfCapacity(t *testing.T) {
 	assertCancelled(t, subscription, pubsub.ErrOutOfCapacity)
 }
 
+func TestSlowClientWithOverflowDropKeepsSubscription(t *testing.T) {
+	s := pubsub.NewServer()
+	s.SetLogger(log.TestingLogger())
+	s.Start()
+	defer s.Stop()
+
+	ctx := context.Background()
+	q := query.MustParse("tm.events.type='NewBlock'")
+	assert.Panics(t, func() {
+		s.SubscribeWithOverflow(ctx, clientID, q, 0, pubsub.OverflowDrop)
+	})
+	subscription, err := s.SubscribeWithOverflow(ctx, clientID, q, 1, pubsub.OverflowDrop)
+	require.NoError(t, err)
+	for _, msg := range []string{"Fat Cobra", "Viper", "Black Mamba"} {
+		err = s.PublishWithTags(ctx, msg, map[string]string{"tm.events.type": "NewBlock"})
+		require.NoError(t, err)
+	}
+	// the server is done with the messages above once it accepted the next one
+	err = s.PublishWithTags(ctx, "Mongoose", map[string]string{"tm.events.type": "Tx"})
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 2, subscription.Dropped())
+	assert.NoError(t, subscription.Err())
+	assertReceive(t, "Fat Cobra", subscription.Out())
+	err = s.PublishWithTags(ctx, "Copperhead", map[string]string{"tm.events.type": "NewBlock"})
+	require.NoError(t, err)
+	assertReceive(t, "Copperhead", subscription.Out())
+}
+
 func TestDifferentClients(t *testing.T) {
 	s := pubsub.NewServer()
 	s.SetLogger(log.TestingLogger())
@@ -0,0 +1,386 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The grammar of the queries:
+//
+//	query     <- or
+//	or        <- and ( "OR" and )*
+//	and       <- not ( "AND" not )*
+//	not       <- "NOT" not / primary
+//	primary   <- "(" or ")" / condition
+//	condition <- tag "EXISTS"
+//	           / tag ( "<=" / ">=" / "<" / ">" ) ( number / time / date )
+//	           / tag "=" ( number / time / date / value )
+//	           / tag "CONTAINS" value
+//
+// The keywords are case sensitive, and can't be used as tags. A tag is made of
+// any characters but whitespace and \()"'=><. A value is quoted with single
+// quotes and can't contain any quote.
+//
+// The legacy grammar, prior to OR, NOT, parentheses and EXISTS, is:
+//
+//	query     <- condition ( "AND" condition )*
+//	condition <- tag ( "<=" / ">=" / "<" / ">" ) ( number / time / date )
+//	           / tag "=" ( number / time / date / value )
+//	           / tag "CONTAINS" value
+//
+// in which the keywords are case insensitive, and can be used as tags.
+
+const (
+	keywordAnd      = "AND"
+	keywordOr       = "OR"
+	keywordNot      = "NOT"
+	keywordExists   = "EXISTS"
+	keywordContains = "CONTAINS"
+	keywordDate     = "DATE"
+	keywordTime     = "TIME"
+)
+
+var (
+	numberRe = regexp.MustCompile(`^(0|[1-9][0-9]*)(\.[0-9]*)?$`)
+	dateRe   = regexp.MustCompile(`^[12][0-9]{3}-[01][0-9]-[0-3][0-9]$`)
+	timeRe   = regexp.MustCompile(`^[12][0-9]{3}-[01][0-9]-[0-3][0-9]T[0-9]{2}:[0-9]{2}:[0-9]{2}([-+][0-9]{2}:[0-9]{2}|Z)$`)
+)
+
+type tokenType uint8
+
+const (
+	tokenEOF tokenType = iota
+	tokenWord
+	tokenValue
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	typ tokenType
+	str string // unquoted, for a value
+	pos int
+}
+
+func (t token) String() string {
+	switch t.typ {
+	case tokenEOF:
+		return "end of query"
+	case tokenValue:
+		return fmt.Sprintf("'%s'", t.str)
+	default:
+		return t.str
+	}
+}
+
+func (t token) isKeyword(keyword string) bool {
+	return t.typ == tokenWord && t.str == keyword
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isWordChar(c byte) bool {
+	return !isSpace(c) && !strings.ContainsRune(`\()"'=><`, rune(c))
+}
+
+// tokenize splits the query into words (tags, keywords, numbers, dates and
+// times), quoted values, operators and parentheses.
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case isSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokenLeftParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokenRightParen, ")", i})
+			i++
+		case c == '<' || c == '>':
+			if i+1 < len(s) && s[i+1] == '=' {
+				tokens = append(tokens, token{tokenOperator, s[i : i+2], i})
+				i += 2
+			} else {
+				tokens = append(tokens, token{tokenOperator, s[i : i+1], i})
+				i++
+			}
+		case c == '=':
+			tokens = append(tokens, token{tokenOperator, "=", i})
+			i++
+		case c == '\'':
+			end := strings.IndexAny(s[i+1:], `'"`)
+			if end == -1 || s[i+1+end] != '\'' {
+				return nil, fmt.Errorf("unterminated value at position %d", i)
+			}
+			tokens = append(tokens, token{tokenValue, s[i+1 : i+1+end], i})
+			i += end + 2
+		case isWordChar(c):
+			start := i
+			for i < len(s) && isWordChar(s[i]) {
+				i++
+			}
+			tokens = append(tokens, token{tokenWord, s[start:i], start})
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(s)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	legacy bool
+}
+
+// parse returns the expression of the query.
+func parse(s string) (expr, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.typ != tokenEOF {
+		return nil, unexpected(t, "AND, OR or end of query")
+	}
+	return e, nil
+}
+
+// parseLegacy returns the expression of the query in the legacy grammar.
+func parseLegacy(s string) (expr, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, legacy: true}
+	e, err := p.parseCondition()
+	if err != nil {
+		return nil, err
+	}
+	and := andExpr{e}
+	for p.isKeyword(p.peek(), keywordAnd) {
+		p.next()
+		e, err := p.parseCondition()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, e)
+	}
+	if t := p.peek(); t.typ != tokenEOF {
+		return nil, unexpected(t, "AND or end of query")
+	}
+	if len(and) == 1 {
+		return and[0], nil
+	}
+	return and, nil
+}
+
+func unexpected(t token, expected string) error {
+	return fmt.Errorf("unexpected %s at position %d, expected %s", t, t.pos, expected)
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.typ != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// isKeyword returns true if the token is the keyword, in any case in the
+// legacy grammar.
+func (p *parser) isKeyword(t token, keyword string) bool {
+	if p.legacy {
+		return t.typ == tokenWord && strings.EqualFold(t.str, keyword)
+	}
+	return t.isKeyword(keyword)
+}
+
+func (p *parser) parseOr() (expr, error) {
+	e, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	or := orExpr{e}
+	for p.peek().isKeyword(keywordOr) {
+		p.next()
+		e, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, e)
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	e, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	and := andExpr{e}
+	for p.peek().isKeyword(keywordAnd) {
+		p.next()
+		e, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, e)
+	}
+	if len(and) == 1 {
+		return and[0], nil
+	}
+	return and, nil
+}
+
+func (p *parser) parseNot() (expr, error) {
+	if !p.peek().isKeyword(keywordNot) {
+		return p.parsePrimary()
+	}
+	p.next()
+	e, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	return notExpr{e}, nil
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	if p.peek().typ != tokenLeftParen {
+		return p.parseCondition()
+	}
+	p.next()
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.next(); t.typ != tokenRightParen {
+		return nil, unexpected(t, "AND, OR or )")
+	}
+	return e, nil
+}
+
+func (p *parser) parseCondition() (expr, error) {
+	t := p.next()
+	if t.typ != tokenWord || (!p.legacy && isKeyword(t.str)) {
+		return nil, unexpected(t, "a tag, NOT or (")
+	}
+	c := Condition{Tag: t.str}
+
+	t = p.next()
+	switch {
+	case !p.legacy && t.isKeyword(keywordExists):
+		c.Op = OpExists
+		return c, nil
+	case p.isKeyword(t, keywordContains):
+		c.Op = OpContains
+		v := p.next()
+		if v.typ != tokenValue {
+			return nil, unexpected(v, "a value")
+		}
+		c.Operand = v.str
+		return c, nil
+	case t.typ == tokenOperator:
+		c.Op = operators[t.str]
+		operand, err := p.parseOperand(c.Op == OpEqual)
+		if err != nil {
+			return nil, err
+		}
+		c.Operand = operand
+		return c, nil
+	default:
+		return nil, unexpected(t, "an operator, CONTAINS or EXISTS")
+	}
+}
+
+// parseOperand returns the number (int64 or float64), time, date or, if
+// allowed, value compared to the tag.
+func (p *parser) parseOperand(valueAllowed bool) (interface{}, error) {
+	t := p.next()
+	switch {
+	case t.typ == tokenValue && valueAllowed:
+		return t.str, nil
+	case p.isKeyword(t, keywordDate), p.isKeyword(t, keywordTime):
+		// the keyword must be followed by a single space
+		v := p.next()
+		if v.typ != tokenWord || v.pos != t.pos+len(t.str)+1 {
+			return nil, unexpected(v, "a "+strings.ToLower(t.str))
+		}
+		if p.isKeyword(t, keywordDate) {
+			return parseDate(v)
+		}
+		return parseTime(v)
+	case t.typ == tokenWord && numberRe.MatchString(t.str):
+		return parseNumber(t)
+	default:
+		if valueAllowed {
+			return nil, unexpected(t, "a number, time, date or value")
+		}
+		return nil, unexpected(t, "a number, time or date")
+	}
+}
+
+func parseNumber(t token) (interface{}, error) {
+	if strings.ContainsAny(t.str, ".") { // if it looks like a floating-point number
+		value, err := strconv.ParseFloat(t.str, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s at position %d: %v", t.str, t.pos, err)
+		}
+		return value, nil
+	}
+	value, err := strconv.ParseInt(t.str, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %s at position %d: %v", t.str, t.pos, err)
+	}
+	return value, nil
+}
+
+func parseDate(t token) (interface{}, error) {
+	if !dateRe.MatchString(t.str) {
+		return nil, fmt.Errorf("invalid date %s at position %d", t.str, t.pos)
+	}
+	value, err := time.Parse(DateLayout, t.str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %s at position %d: %v", t.str, t.pos, err)
+	}
+	return value, nil
+}
+
+func parseTime(t token) (interface{}, error) {
+	if !timeRe.MatchString(t.str) {
+		return nil, fmt.Errorf("invalid time %s at position %d", t.str, t.pos)
+	}
+	value, err := time.Parse(TimeLayout, t.str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time %s at position %d: %v", t.str, t.pos, err)
+	}
+	return value, nil
+}
+
+func isKeyword(s string) bool {
+	switch s {
+	case keywordAnd, keywordOr, keywordNot, keywordExists, keywordContains, keywordDate, keywordTime:
+		return true
+	default:
+		return false
+	}
+}
@@ -79,6 +79,25 @@ func TestParser(t *testing.T) {
 
 		{"hash='136E18F7E4C348B780CF873A0BF43922E5BAFA63'", true},
 		{"hash=136E18F7E4C348B780CF873A0BF43922E5BAFA63", false},
+
+		{"tm.events.type='NewBlock' OR tm.events.type='Tx'", true},
+		{"tm.events.type='NewBlock' OR", false},
+		{"OR tm.events.type='NewBlock'", false},
+		{"NOT tm.events.type='NewBlock'", true},
+		{"NOT NOT tm.events.type='NewBlock'", true},
+		{"tm.events.type='NewBlock' NOT", false},
+		{"tm.events.type NOT 'NewBlock'", false},
+		{"(tm.events.type='NewBlock')", true},
+		{"tx.gas > 7 AND (tx.fee < 3 OR NOT (tx.memo CONTAINS 'a' AND tx.memo EXISTS))", true},
+		{"(tm.events.type='NewBlock'", false},
+		{"tm.events.type='NewBlock')", false},
+		{"()", false},
+		{"tx.gas (> 7)", false},
+
+		{"tx.memo EXISTS", true},
+		{"tx.memo EXISTS 'a'", false},
+		{"EXISTS", false},
+		{"AND='NewBlock'", false},
 	}
 
 	for _, c := range cases {
@@ -90,3 +109,44 @@ func TestParser(t *testing.T) {
 		}
 	}
 }
+
+func TestParserCompat(t *testing.T) {
+	cases := []struct {
+		query string
+		valid bool
+		tags  map[string]string
+		match bool
+	}{
+		// valid in the grammar
+		{"tm.events.type='NewBlock' OR tm.events.type='Tx'", true, map[string]string{"tm.events.type": "Tx"}, true},
+		{"tx.memo EXISTS", true, map[string]string{"tx.memo": "a"}, true},
+		// valid in the legacy grammar only
+		{"tm.events.type='NewBlock' and tx.gas > 7", true, map[string]string{"tm.events.type": "NewBlock", "tx.gas": "8"}, true},
+		{"tm.events.type='NewBlock' and tx.gas > 7", true, map[string]string{"tm.events.type": "NewBlock", "tx.gas": "6"}, false},
+		{"AND='NewBlock' AND OR CONTAINS 'a'", true, map[string]string{"AND": "NewBlock", "OR": "bar"}, true},
+		{"EXISTS=3", true, map[string]string{"EXISTS": "3"}, true},
+		{"tx.memo contains 'a'", true, map[string]string{"tx.memo": "a"}, true},
+		{"tx.date >= date 2013-05-03", true, map[string]string{"tx.date": "2013-05-04"}, true},
+		// invalid in both
+		{"OR EXISTS", false, nil, false},
+		{"tm.events.type='NewBlock' or", false, nil, false},
+		{"(AND='NewBlock')", false, nil, false},
+	}
+
+	for _, c := range cases {
+		q, err := query.NewCompat(c.query)
+		if !c.valid {
+			assert.Errorf(t, err, "Query was '%s'", c.query)
+			continue
+		}
+		if assert.NoErrorf(t, err, "Query was '%s'", c.query) {
+			assert.Equal(t, c.query, q.String())
+			assert.Equalf(t, c.match, q.Matches(c.tags), "Query was '%s'", c.query)
+		}
+	}
+
+	// the error is the one of the grammar
+	_, err := query.New("OR EXISTS")
+	_, compatErr := query.NewCompat("OR EXISTS")
+	assert.Equal(t, err, compatErr)
+}
@@ -1,11 +1,14 @@
 // Package query provides a parser for a custom query format:
 //
-//		abci.invoice.number=22 AND abci.invoice.owner=Ivan
+//		abci.invoice.number=22 AND abci.invoice.owner='Ivan'
+//		tm.event='Tx' AND (transfer.amount > 100 OR NOT transfer.sender EXISTS)
 //
-// See query.peg for the grammar, which is a https://en.wikipedia.org/wiki/Parsing_expression_grammar.
-// More: https://github.com/PhilippeSigaud/Pegged/wiki/PEG-Basics
+// See parser.go for the grammar. Conditions are combined with AND, OR and NOT
+// (in this order of precedence) and grouped with parentheses.
 //
 // It has a support for numbers (integer and floating point), dates and times.
+// Comparing a number, date or time to a tag whose value isn't one doesn't
+// match.
 package query
 
 import (
@@ -16,14 +19,15 @@ import (
 	"time"
 )
 
-// Query holds the query string and the query parser.
+// Query holds the query string and its parsed expression.
 type Query struct {
-	str    string
-	parser *QueryParser
+	str  string
+	expr expr
 }
 
 // Condition represents a single condition within a query and consists of tag
-// (e.g. "tx.gas"), operator (e.g. "=") and operand (e.g. "7").
+// (e.g. "tx.gas"), operator (e.g. "=") and operand (e.g. "7"). The operand of
+// an EXISTS condition is nil.
 type Condition struct {
 	Tag     string
 	Op      Operator
@@ -33,12 +37,27 @@ type Condition struct {
 // New parses the given string and returns a query or error if the string is
 // invalid.
 func New(s string) (*Query, error) {
-	p := &QueryParser{Buffer: fmt.Sprintf(`"%s"`, s)}
-	p.Init()
-	if err := p.Parse(); err != nil {
+	e, err := parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return &Query{str: s, expr: e}, nil
+}
+
+// NewCompat parses the given string like New or, if it's invalid, in the legacy
+// grammar prior to OR, NOT, parentheses and EXISTS (see parser.go), so that the
+// queries which used to be valid still are. If it's invalid in both, the error
+// is the one of New.
+func NewCompat(s string) (*Query, error) {
+	q, err := New(s)
+	if err == nil {
+		return q, nil
+	}
+	e, legacyErr := parseLegacy(s)
+	if legacyErr != nil {
 		return nil, err
 	}
-	return &Query{str: s, parser: p}, nil
+	return &Query{str: s, expr: e}, nil
 }
 
 // MustParse turns the given string into a query or panics; for tests or others
@@ -73,8 +92,18 @@ const (
 	OpEqual
 	// "CONTAINS"; used to check if a string contains a certain sub string.
 	OpContains
+	// "EXISTS"; used to check if a tag is set, whatever its value.
+	OpExists
 )
 
+var operators = map[string]Operator{
+	"<=": OpLessEqual,
+	">=": OpGreaterEqual,
+	"<":  OpLess,
+	">":  OpGreater,
+	"=":  OpEqual,
+}
+
 const (
 	// DateLayout defines a layout for all dates (`DATE date`)
 	DateLayout = "2006-01-02"
@@ -82,154 +111,95 @@ const (
 	TimeLayout = time.RFC3339
 )
 
-// Conditions returns a list of conditions.
-func (q *Query) Conditions() []Condition {
-	conditions := make([]Condition, 0)
+// expr is a node of the expression of a query: a Condition, or the AND, OR or
+// NOT of other expressions.
+type expr interface {
+	matches(tags map[string]string) bool
+}
 
-	buffer, begin, end := q.parser.Buffer, 0, 0
+type andExpr []expr
 
-	var tag string
-	var op Operator
+func (e andExpr) matches(tags map[string]string) bool {
+	for _, x := range e {
+		if !x.matches(tags) {
+			return false
+		}
+	}
+	return true
+}
 
-	// tokens must be in the following order: tag ("tx.gas") -> operator ("=") -> operand ("7")
-	for _, token := range q.parser.Tokens() {
-		switch token.pegRule {
+type orExpr []expr
 
-		case rulePegText:
-			begin, end = int(token.begin), int(token.end)
-		case ruletag:
-			tag = buffer[begin:end]
-		case rulele:
-			op = OpLessEqual
-		case rulege:
-			op = OpGreaterEqual
-		case rulel:
-			op = OpLess
-		case ruleg:
-			op = OpGreater
-		case ruleequal:
-			op = OpEqual
-		case rulecontains:
-			op = OpContains
-		case rulevalue:
-			// strip single quotes from value (i.e. "'NewBlock'" -> "NewBlock")
-			valueWithoutSingleQuotes := buffer[begin+1 : end-1]
-			conditions = append(conditions, Condition{tag, op, valueWithoutSingleQuotes})
-		case rulenumber:
-			number := buffer[begin:end]
-			if strings.ContainsAny(number, ".") { // if it looks like a floating-point number
-				value, err := strconv.ParseFloat(number, 64)
-				if err != nil {
-					panic(fmt.Sprintf("got %v while trying to parse %s as float64 (should never happen if the grammar is correct)", err, number))
-				}
-				conditions = append(conditions, Condition{tag, op, value})
-			} else {
-				value, err := strconv.ParseInt(number, 10, 64)
-				if err != nil {
-					panic(fmt.Sprintf("got %v while trying to parse %s as int64 (should never happen if the grammar is correct)", err, number))
-				}
-				conditions = append(conditions, Condition{tag, op, value})
-			}
-		case ruletime:
-			value, err := time.Parse(TimeLayout, buffer[begin:end])
-			if err != nil {
-				panic(fmt.Sprintf("got %v while trying to parse %s as time.Time / RFC3339 (should never happen if the grammar is correct)", err, buffer[begin:end]))
-			}
-			conditions = append(conditions, Condition{tag, op, value})
-		case ruledate:
-			value, err := time.Parse("2006-01-02", buffer[begin:end])
-			if err != nil {
-				panic(fmt.Sprintf("got %v while trying to parse %s as time.Time / '2006-01-02' (should never happen if the grammar is correct)", err, buffer[begin:end]))
-			}
-			conditions = append(conditions, Condition{tag, op, value})
+func (e orExpr) matches(tags map[string]string) bool {
+	for _, x := range e {
+		if x.matches(tags) {
+			return true
 		}
 	}
+	return false
+}
 
-	return conditions
+type notExpr struct {
+	expr
 }
 
-// Matches returns true if the query matches the given set of tags, false otherwise.
-//
-// For example, query "name=John" matches tags = {"name": "John"}. More
-// examples could be found in parser_test.go and query_test.go.
-func (q *Query) Matches(tags map[string]string) bool {
-	if len(tags) == 0 {
-		return false
-	}
+func (e notExpr) matches(tags map[string]string) bool {
+	return !e.expr.matches(tags)
+}
 
-	buffer, begin, end := q.parser.Buffer, 0, 0
+func (c Condition) matches(tags map[string]string) bool {
+	return match(c.Tag, c.Op, reflect.ValueOf(c.Operand), tags)
+}
 
-	var tag string
-	var op Operator
+// Conditions returns the conditions ANDed at the top level of the query,
+// which all the matching sets of tags satisfy (e.g. "tx.height > 5" of
+// "tx.height > 5 AND (tx.gas > 7 OR tx.fee > 3)"). If the query is a
+// conjunction, they're the whole query.
+func (q *Query) Conditions() []Condition {
+	return conditions(q.expr, make([]Condition, 0))
+}
 
-	// tokens must be in the following order: tag ("tx.gas") -> operator ("=") -> operand ("7")
-	for _, token := range q.parser.Tokens() {
-		switch token.pegRule {
+func conditions(e expr, conds []Condition) []Condition {
+	switch e := e.(type) {
+	case Condition:
+		return append(conds, e)
+	case andExpr:
+		for _, x := range e {
+			conds = conditions(x, conds)
+		}
+	}
+	return conds
+}
 
-		case rulePegText:
-			begin, end = int(token.begin), int(token.end)
-		case ruletag:
-			tag = buffer[begin:end]
-		case rulele:
-			op = OpLessEqual
-		case rulege:
-			op = OpGreaterEqual
-		case rulel:
-			op = OpLess
-		case ruleg:
-			op = OpGreater
-		case ruleequal:
-			op = OpEqual
-		case rulecontains:
-			op = OpContains
-		case rulevalue:
-			// strip single quotes from value (i.e. "'NewBlock'" -> "NewBlock")
-			valueWithoutSingleQuotes := buffer[begin+1 : end-1]
+// IsConjunction returns true if the query is only made of conditions joined
+// with AND (as the queries prior to OR, NOT and parentheses), and so all of it
+// is returned by Conditions.
+func (q *Query) IsConjunction() bool {
+	return isConjunction(q.expr)
+}
 
-			// see if the triplet (tag, operator, operand) matches any tag
-			// "tx.gas", "=", "7", { "tx.gas": 7, "tx.ID": "4AE393495334" }
-			if !match(tag, op, reflect.ValueOf(valueWithoutSingleQuotes), tags) {
-				return false
-			}
-		case rulenumber:
-			number := buffer[begin:end]
-			if strings.ContainsAny(number, ".") { // if it looks like a floating-point number
-				value, err := strconv.ParseFloat(number, 64)
-				if err != nil {
-					panic(fmt.Sprintf("got %v while trying to parse %s as float64 (should never happen if the grammar is correct)", err, number))
-				}
-				if !match(tag, op, reflect.ValueOf(value), tags) {
-					return false
-				}
-			} else {
-				value, err := strconv.ParseInt(number, 10, 64)
-				if err != nil {
-					panic(fmt.Sprintf("got %v while trying to parse %s as int64 (should never happen if the grammar is correct)", err, number))
-				}
-				if !match(tag, op, reflect.ValueOf(value), tags) {
-					return false
-				}
-			}
-		case ruletime:
-			value, err := time.Parse(TimeLayout, buffer[begin:end])
-			if err != nil {
-				panic(fmt.Sprintf("got %v while trying to parse %s as time.Time / RFC3339 (should never happen if the grammar is correct)", err, buffer[begin:end]))
-			}
-			if !match(tag, op, reflect.ValueOf(value), tags) {
-				return false
-			}
-		case ruledate:
-			value, err := time.Parse("2006-01-02", buffer[begin:end])
-			if err != nil {
-				panic(fmt.Sprintf("got %v while trying to parse %s as time.Time / '2006-01-02' (should never happen if the grammar is correct)", err, buffer[begin:end]))
-			}
-			if !match(tag, op, reflect.ValueOf(value), tags) {
+func isConjunction(e expr) bool {
+	switch e := e.(type) {
+	case Condition:
+		return true
+	case andExpr:
+		for _, x := range e {
+			if !isConjunction(x) {
 				return false
 			}
 		}
+		return true
+	default:
+		return false
 	}
+}
 
-	return true
+// Matches returns true if the query matches the given set of tags, false otherwise.
+//
+// For example, query "name=John" matches tags = {"name": "John"}. More
+// examples could be found in parser_test.go and query_test.go.
+func (q *Query) Matches(tags map[string]string) bool {
+	return q.expr.matches(tags)
 }
 
 // match returns true if the given triplet (tag, operator, operand) matches any tag.
@@ -238,12 +208,18 @@ func (q *Query) Matches(tags map[string]string) bool {
 // value from it to the operand using the operator.
 //
 // "tx.gas", "=", "7", { "tx.gas": 7, "tx.ID": "4AE393495334" }
+//
+// If the operand is a number, date or time and the value of the tag can't be
+// converted to one, it doesn't match.
 func match(tag string, op Operator, operand reflect.Value, tags map[string]string) bool {
 	// look up the tag from the query in tags
 	value, ok := tags[tag]
 	if !ok {
 		return false
 	}
+	if op == OpExists {
+		return true
+	}
 	switch operand.Kind() {
 	case reflect.Struct: // time
 		operandAsTime := operand.Interface().(time.Time)
@@ -258,7 +234,7 @@ func match(tag string, op Operator, operand reflect.Value, tags map[string]strin
 			v, err = time.Parse(DateLayout, value)
 		}
 		if err != nil {
-			panic(fmt.Sprintf("Failed to convert value %v from tag to time.Time: %v", value, err))
+			return false
 		}
 		switch op {
 		case OpLessEqual:
@@ -278,7 +254,7 @@ func match(tag string, op Operator, operand reflect.Value, tags map[string]strin
 		// try our best to convert value from tags to float64
 		v, err := strconv.ParseFloat(value, 64)
 		if err != nil {
-			panic(fmt.Sprintf("Failed to convert value %v from tag to float64: %v", value, err))
+			return false
 		}
 		switch op {
 		case OpLessEqual:
@@ -294,21 +270,14 @@ func match(tag string, op Operator, operand reflect.Value, tags map[string]strin
 		}
 	case reflect.Int64:
 		operandInt := operand.Interface().(int64)
-		var v int64
-		// if value looks like float, we try to parse it as float
+		// if value looks like float, we compare it to the operand as a float
 		if strings.ContainsAny(value, ".") {
-			v1, err := strconv.ParseFloat(value, 64)
-			if err != nil {
-				panic(fmt.Sprintf("Failed to convert value %v from tag to float64: %v", value, err))
-			}
-			v = int64(v1)
-		} else {
-			var err error
-			// try our best to convert value from tags to int64
-			v, err = strconv.ParseInt(value, 10, 64)
-			if err != nil {
-				panic(fmt.Sprintf("Failed to convert value %v from tag to int64: %v", value, err))
-			}
+			return match(tag, op, reflect.ValueOf(float64(operandInt)), tags)
+		}
+		// try our best to convert value from tags to int64
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
 		}
 		switch op {
 		case OpLessEqual:
@@ -43,6 +43,21 @@ func TestMatches(t *testing.T) {
 
 		{"abci.owner.name CONTAINS 'Igor'", map[string]string{"abci.owner.name": "Igor,Ivan"}, false, true},
 		{"abci.owner.name CONTAINS 'Igor'", map[string]string{"abci.owner.name": "Pavel,Ivan"}, false, false},
+
+		{"tx.gas > 7", map[string]string{"tx.gas": "7.5"}, false, true},
+		{"tx.gas > 7", map[string]string{"tx.gas": "many"}, false, false},
+		{"tx.date > DATE 2017-01-01", map[string]string{"tx.date": "today"}, false, false},
+
+		{"tx.gas > 7 OR tx.fee > 3", map[string]string{"tx.gas": "2", "tx.fee": "4"}, false, true},
+		{"tx.gas > 7 OR tx.fee > 3", map[string]string{"tx.gas": "2", "tx.fee": "1"}, false, false},
+		{"NOT tx.gas > 7", map[string]string{"tx.gas": "2"}, false, true},
+		{"NOT tx.gas > 7", map[string]string{}, false, true},
+		{"tx.memo EXISTS", map[string]string{"tx.memo": ""}, false, true},
+		{"tx.memo EXISTS", map[string]string{"tx.gas": "2"}, false, false},
+		// AND takes precedence over OR
+		{"tx.gas > 7 AND tx.fee > 3 OR tx.memo EXISTS", map[string]string{"tx.memo": "a"}, false, true},
+		{"tx.gas > 7 AND (tx.fee > 3 OR tx.memo EXISTS)", map[string]string{"tx.memo": "a"}, false, false},
+		{"tx.gas > 7 AND (tx.fee > 3 OR tx.memo EXISTS)", map[string]string{"tx.gas": "8", "tx.memo": "a"}, false, true},
 	}
 
 	for _, tc := range testCases {
@@ -69,12 +84,17 @@ func TestConditions(t *testing.T) {
 	require.NoError(t, err)
 
 	testCases := []struct {
-		s          string
-		conditions []query.Condition
+		s           string
+		conditions  []query.Condition
+		conjunction bool
 	}{
-		{s: "tm.events.type='NewBlock'", conditions: []query.Condition{{Tag: "tm.events.type", Op: query.OpEqual, Operand: "NewBlock"}}},
-		{s: "tx.gas > 7 AND tx.gas < 9", conditions: []query.Condition{{Tag: "tx.gas", Op: query.OpGreater, Operand: int64(7)}, {Tag: "tx.gas", Op: query.OpLess, Operand: int64(9)}}},
-		{s: "tx.time >= TIME 2013-05-03T14:45:00Z", conditions: []query.Condition{{Tag: "tx.time", Op: query.OpGreaterEqual, Operand: txTime}}},
+		{s: "tm.events.type='NewBlock'", conditions: []query.Condition{{Tag: "tm.events.type", Op: query.OpEqual, Operand: "NewBlock"}}, conjunction: true},
+		{s: "tx.gas > 7 AND tx.gas < 9", conditions: []query.Condition{{Tag: "tx.gas", Op: query.OpGreater, Operand: int64(7)}, {Tag: "tx.gas", Op: query.OpLess, Operand: int64(9)}}, conjunction: true},
+		{s: "tx.time >= TIME 2013-05-03T14:45:00Z", conditions: []query.Condition{{Tag: "tx.time", Op: query.OpGreaterEqual, Operand: txTime}}, conjunction: true},
+		{s: "tx.memo EXISTS AND (tx.gas > 7 AND tx.gas < 9)", conditions: []query.Condition{{Tag: "tx.memo", Op: query.OpExists}, {Tag: "tx.gas", Op: query.OpGreater, Operand: int64(7)}, {Tag: "tx.gas", Op: query.OpLess, Operand: int64(9)}}, conjunction: true},
+		{s: "tx.gas > 7 AND (tx.fee > 3 OR tx.memo EXISTS)", conditions: []query.Condition{{Tag: "tx.gas", Op: query.OpGreater, Operand: int64(7)}}},
+		{s: "tx.gas > 7 AND NOT tx.fee > 3", conditions: []query.Condition{{Tag: "tx.gas", Op: query.OpGreater, Operand: int64(7)}}},
+		{s: "tx.gas > 7 OR tx.fee > 3", conditions: []query.Condition{}},
 	}
 
 	for _, tc := range testCases {
@@ -82,5 +102,6 @@ func TestConditions(t *testing.T) {
 		require.Nil(t, err)
 
 		assert.Equal(t, tc.conditions, q.Conditions())
+		assert.Equal(t, tc.conjunction, q.IsConjunction(), tc.s)
 	}
 }
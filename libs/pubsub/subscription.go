@@ -3,6 +3,7 @@ package pubsub
 import (
 	"errors"
 	"sync"
+	"sync/atomic"
 )
 
 var (
@@ -14,13 +15,29 @@ var (
 	ErrOutOfCapacity = errors.New("client is not pulling messages fast enough")
 )
 
+// OverflowPolicy tells what happens when a message is published to a buffered
+// subscription whose channel is full.
+type OverflowPolicy int
+
+const (
+	// OverflowCancel cancels the subscription with ErrOutOfCapacity. This is
+	// the policy of Subscribe.
+	OverflowCancel OverflowPolicy = iota
+	// OverflowDrop drops the message, counted by Subscription#Dropped, and
+	// keeps the subscription.
+	OverflowDrop
+)
+
 // A Subscription represents a client subscription for a particular query and
 // consists of three things:
 // 1) channel onto which messages and tags are published
 // 2) channel which is closed if a client is too slow or choose to unsubscribe
 // 3) err indicating the reason for (2)
 type Subscription struct {
-	out chan Message
+	dropped uint64 // atomic, first for its 64-bit alignment
+
+	out      chan Message
+	overflow OverflowPolicy
 
 	cancelled chan struct{}
 	mtx       sync.RWMutex
@@ -42,6 +59,12 @@ func (s *Subscription) Out() <-chan Message {
 	return s.out
 }
 
+// Dropped returns the number of messages dropped as the channel returned by
+// Out was full, with the OverflowDrop policy.
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
 // Cancelled returns a channel that's closed when the subscription is
 // terminated and supposed to be used in a select statement.
 func (s *Subscription) Cancelled() <-chan struct{} {
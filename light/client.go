@@ -0,0 +1,889 @@
+package light
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/light/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+type mode byte
+
+const (
+	sequential mode = iota + 1
+	skipping
+
+	// DefaultMaxClockDrift is how far in the future the time of a header may
+	// be, to allow for the clocks of the light client and the chain to drift.
+	DefaultMaxClockDrift = 10 * time.Second
+
+	// DefaultPruningSize is the maximum number of trusted headers kept in the
+	// trusted store.
+	DefaultPruningSize = 1000
+
+	// how many times the primary is replaced by a witness before giving up
+	// on a request
+	maxRetryAttempts = 10
+)
+
+// TrustOptions are the trust parameters needed when a new light client
+// connects to the network or when an existing light client that has been
+// offline for longer than the trusting period connects to the network.
+//
+// The expectation is the user will get this information from a trusted source
+// like a validator, a friend, or a secure website. A more user friendly
+// solution with trust tradeoffs is that we establish an https based protocol
+// with a default end point that populates this information. Also an on-chain
+// registry of roots-of-trust (e.g. on the Cosmos Hub) seems likely in the
+// future.
+type TrustOptions struct {
+	// tp: trusting period.
+	//
+	// Should be significantly less than the unbonding period (e.g. unbonding
+	// period = 3 weeks, trusting period = 2 weeks).
+	//
+	// More specifically, trusting period + time needed to check headers + time
+	// needed to report and punish misbehavior should be less than the unbonding
+	// period.
+	Period time.Duration
+
+	// Header's Height and Hash must both be provided to force the trusting of a
+	// particular header.
+	Height int64
+	Hash   []byte
+}
+
+// ValidateBasic performs basic validation.
+func (opts TrustOptions) ValidateBasic() error {
+	if opts.Period <= 0 {
+		return errors.New("negative or zero period")
+	}
+	if opts.Height <= 0 {
+		return errors.New("negative or zero height")
+	}
+	if len(opts.Hash) != 32 {
+		return fmt.Errorf("expected hash size to be 32 bytes, got %d bytes", len(opts.Hash))
+	}
+	return nil
+}
+
+// Option sets a parameter for the light client.
+type Option func(*Client)
+
+// SequentialVerification option configures the light client to sequentially
+// check the headers (every header, in ascending height order). Note this is
+// much slower than SkippingVerification, albeit more secure.
+func SequentialVerification() Option {
+	return func(c *Client) {
+		c.verificationMode = sequential
+	}
+}
+
+// SkippingVerification option configures the light client to skip headers as
+// long as {trustLevel} of the old validator set signed the new header. The
+// bisection algorithm from the specification is used for finding the minimal
+// "trust path".
+//
+// trustLevel - fraction of the old validator set (in terms of voting power),
+// which must sign the new header in order for us to trust it. NOTE this only
+// applies to non-adjacent headers. For adjacent headers, sequential
+// verification is used.
+func SkippingVerification(trustLevel cmn.Fraction) Option {
+	return func(c *Client) {
+		c.verificationMode = skipping
+		c.trustLevel = trustLevel
+	}
+}
+
+// Logger option can be used to set a logger for the client.
+func Logger(l log.Logger) Option {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// MaxClockDrift defines how much new (untrusted) header's Time can drift into
+// the future. Default: 10s.
+func MaxClockDrift(d time.Duration) Option {
+	return func(c *Client) {
+		c.maxClockDrift = d
+	}
+}
+
+// PruningSize option sets the maximum number of trusted headers kept in the
+// trusted store. The headers which expired according to the trusting period
+// are pruned anyway, except the latest one. 0 means no limit. Default: 1000.
+func PruningSize(n int) Option {
+	return func(c *Client) {
+		c.pruningSize = n
+	}
+}
+
+// Client represents a light client, connected to a single chain, which gets
+// headers from a primary provider, verifies them either sequentially or by
+// skipping some and stores them in a trusted store (usually, a local FS).
+//
+// The witnesses stand in for the primary when it fails to provide a header
+// or a validator set. Every new header is cross-checked with the headers of
+// the witnesses to detect forks (see ErrConflictingHeaders).
+//
+// Default verification: SkippingVerification(DefaultTrustLevel)
+type Client struct {
+	chainID          string
+	trustingPeriod   time.Duration // see TrustOptions.Period
+	verificationMode mode
+	trustLevel       cmn.Fraction
+	maxClockDrift    time.Duration
+	pruningSize      int
+
+	// Mutex for locking during changes of the light client's providers
+	providerMutex sync.Mutex
+	// Primary provider of new headers.
+	primary provider.Provider
+	// See Witnesses option
+	witnesses []provider.Provider
+
+	// Where trusted headers are stored.
+	trustedStore store.Store
+	// Highest trusted header from the store (height=H).
+	latestTrustedHeader *types.SignedHeader
+	// Highest validator set from the store (height=H).
+	latestTrustedVals *types.ValidatorSet
+
+	logger log.Logger
+}
+
+// NewClient returns a new light client. It returns an error if it fails to
+// obtain the header & vals from the primary or they are invalid (e.g. trust
+// hash does not match with the one from the header).
+//
+// Witnesses are providers, which are used to cross-check the headers of the
+// primary, and when the primary fails.
+//
+// See all Option(s) for the additional configuration.
+func NewClient(
+	chainID string,
+	trustOptions TrustOptions,
+	primary provider.Provider,
+	witnesses []provider.Provider,
+	trustedStore store.Store,
+	options ...Option) (*Client, error) {
+
+	if err := trustOptions.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid TrustOptions: %v", err)
+	}
+
+	c := &Client{
+		chainID:          chainID,
+		trustingPeriod:   trustOptions.Period,
+		verificationMode: skipping,
+		trustLevel:       DefaultTrustLevel,
+		maxClockDrift:    DefaultMaxClockDrift,
+		pruningSize:      DefaultPruningSize,
+		primary:          primary,
+		witnesses:        witnesses,
+		trustedStore:     trustedStore,
+		logger:           log.NewNopLogger(),
+	}
+
+	for _, o := range options {
+		o(c)
+	}
+
+	// Validate the number of witnesses.
+	if len(c.witnesses) < 1 {
+		return nil, errors.New("expected at least one witness")
+	}
+
+	// Verify witnesses are all on the same chain.
+	for i, w := range witnesses {
+		if w.ChainID() != chainID {
+			return nil, fmt.Errorf("witness #%d: %v is on another chain %s, expected %s",
+				i, w, w.ChainID(), chainID)
+		}
+	}
+
+	// Validate trust level.
+	if err := ValidateTrustLevel(c.trustLevel); err != nil {
+		return nil, err
+	}
+
+	if err := c.restoreTrustedHeaderAndVals(); err != nil {
+		return nil, err
+	}
+
+	if err := c.checkTrustedHeaderUsingOptions(trustOptions); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// restoreTrustedHeaderAndVals loads trustedHeader and trustedVals from
+// trustedStore.
+func (c *Client) restoreTrustedHeaderAndVals() error {
+	lastHeight, err := c.trustedStore.LastSignedHeaderHeight()
+	if err != nil {
+		return fmt.Errorf("can't get last trusted header height: %v", err)
+	}
+
+	if lastHeight > 0 {
+		trustedHeader, err := c.trustedStore.SignedHeader(lastHeight)
+		if err != nil {
+			return fmt.Errorf("can't get last trusted header: %v", err)
+		}
+
+		trustedVals, err := c.trustedStore.ValidatorSet(lastHeight)
+		if err != nil {
+			return fmt.Errorf("can't get last trusted validators: %v", err)
+		}
+
+		c.latestTrustedHeader = trustedHeader
+		c.latestTrustedVals = trustedVals
+
+		c.logger.Info("Restored trusted header and vals", "height", lastHeight)
+	}
+
+	return nil
+}
+
+// checkTrustedHeaderUsingOptions initializes the client from the trust
+// options, unless the store already has trusted headers. A stored header at
+// the trusted height must match the trusted hash, otherwise the store belongs
+// to another chain or fork and has to be cleaned up first.
+func (c *Client) checkTrustedHeaderUsingOptions(options TrustOptions) error {
+	if c.latestTrustedHeader == nil {
+		return c.initializeWithTrustOptions(options)
+	}
+
+	sh, err := c.trustedStore.SignedHeader(options.Height)
+	switch err {
+	case nil:
+		if !bytes.Equal(sh.Hash(), options.Hash) {
+			return fmt.Errorf("stored header at height %d has hash %X, but trust options expect %X; "+
+				"call Cleanup to reset the trusted store", options.Height, sh.Hash(), options.Hash)
+		}
+	case store.ErrSignedHeaderNotFound:
+		// A header of the trusted height might have been pruned, or skipped
+		// over. If the trusted height is above the latest trusted header, trust
+		// the options instead.
+		if options.Height > c.latestTrustedHeader.Height {
+			return c.initializeWithTrustOptions(options)
+		}
+	default:
+		return err
+	}
+	return nil
+}
+
+// initializeWithTrustOptions fetches the header and validators at the height
+// of the options from the primary, checks them against the trusted hash and
+// saves them.
+func (c *Client) initializeWithTrustOptions(options TrustOptions) error {
+	// 1) Fetch and verify the header.
+	h, err := c.signedHeaderFromPrimary(options.Height)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: - Verify Header: trust is the header of the options, so there's
+	// nothing to verify it against, except its hash.
+	if err := h.ValidateBasic(c.chainID); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(h.Hash(), options.Hash) {
+		return fmt.Errorf("expected header's hash %X, but got %X", options.Hash, h.Hash())
+	}
+
+	// 2) Fetch and verify the vals.
+	vals, err := c.validatorSetFromPrimary(options.Height)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(h.ValidatorsHash, vals.Hash()) {
+		return fmt.Errorf("expected header's validators (%X) to match those that were supplied (%X)",
+			h.ValidatorsHash,
+			vals.Hash(),
+		)
+	}
+
+	// Ensure that +2/3 of validators signed correctly.
+	err = vals.VerifyCommit(c.chainID, h.Commit.BlockID, h.Height, h.Commit)
+	if err != nil {
+		return fmt.Errorf("invalid commit: %v", err)
+	}
+
+	// 3) Persist both of them and continue.
+	return c.updateTrustedHeaderAndVals(h, vals)
+}
+
+// TrustedHeader returns a trusted header at the given height (0 - the latest).
+//
+// The header isn't checked for expiry, the caller can do so with
+// HeaderExpired.
+//
+// height must be >= 0.
+//
+// It returns an error if:
+//   - there are some issues with the trusted store, although that should not
+//     happen normally;
+//   - negative height is passed;
+//   - header has not been verified yet and is therefore not in the store
+//
+// Safe for concurrent use by multiple goroutines.
+func (c *Client) TrustedHeader(height int64) (*types.SignedHeader, error) {
+	height, err := c.compareWithLatestHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return c.trustedStore.SignedHeader(height)
+}
+
+// TrustedValidatorSet returns a trusted validator set at the given height (0 -
+// latest). The second return parameter is the height used (useful if 0 was
+// passed; otherwise can be ignored).
+//
+// height must be >= 0.
+//
+// The validator set isn't checked for expiry, like in TrustedHeader.
+//
+// Function returns an error if:
+//   - there are some issues with the trusted store, although that should not
+//     happen normally;
+//   - negative height is passed;
+//   - header signed by that validator set has not been verified yet
+//
+// Safe for concurrent use by multiple goroutines.
+func (c *Client) TrustedValidatorSet(height int64) (valSet *types.ValidatorSet, heightUsed int64, err error) {
+	heightUsed, err = c.compareWithLatestHeight(height)
+	if err != nil {
+		return nil, heightUsed, err
+	}
+	valSet, err = c.trustedStore.ValidatorSet(heightUsed)
+	if err != nil {
+		return nil, heightUsed, err
+	}
+	return valSet, heightUsed, err
+}
+
+func (c *Client) compareWithLatestHeight(height int64) (int64, error) {
+	latestHeight, err := c.LastTrustedHeight()
+	if err != nil {
+		return 0, fmt.Errorf("can't get last trusted height: %v", err)
+	}
+	if latestHeight == -1 {
+		return 0, errors.New("no headers exist")
+	}
+
+	switch {
+	case height > latestHeight:
+		return 0, fmt.Errorf("unverified header/valset requested (latest: %d)", latestHeight)
+	case height == 0:
+		return latestHeight, nil
+	case height < 0:
+		return 0, errors.New("negative height")
+	}
+
+	return height, nil
+}
+
+// LastTrustedHeight returns a last trusted height. -1 and nil are returned if
+// there are no trusted headers.
+//
+// Safe for concurrent use by multiple goroutines.
+func (c *Client) LastTrustedHeight() (int64, error) {
+	return c.trustedStore.LastSignedHeaderHeight()
+}
+
+// FirstTrustedHeight returns a first trusted height. -1 and nil are returned if
+// there are no trusted headers.
+//
+// Safe for concurrent use by multiple goroutines.
+func (c *Client) FirstTrustedHeight() (int64, error) {
+	return c.trustedStore.FirstSignedHeaderHeight()
+}
+
+// ChainID returns the chain ID the light client was configured with.
+//
+// Safe for concurrent use by multiple goroutines.
+func (c *Client) ChainID() string {
+	return c.chainID
+}
+
+// Primary returns the primary provider.
+//
+// NOTE: provider may be not safe for concurrent access.
+func (c *Client) Primary() provider.Provider {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+	return c.primary
+}
+
+// Witnesses returns the witness providers.
+//
+// NOTE: providers may be not safe for concurrent access.
+func (c *Client) Witnesses() []provider.Provider {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+	return c.witnesses
+}
+
+// Update attempts to advance the state by downloading the latest header and
+// comparing it with the existing one. It returns a new header on a successful
+// update. Otherwise, it returns nil (plus an error, if any).
+func (c *Client) Update(now time.Time) (*types.SignedHeader, error) {
+	lastTrustedHeight, err := c.LastTrustedHeight()
+	if err != nil {
+		return nil, fmt.Errorf("can't get last trusted height: %v", err)
+	}
+
+	if lastTrustedHeight == -1 {
+		// no headers yet => wait
+		return nil, nil
+	}
+
+	latestHeader, latestVals, err := c.fetchHeaderAndValsAtHeight(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if latestHeader.Height > lastTrustedHeight {
+		err = c.VerifyHeader(latestHeader, latestVals, now)
+		if err != nil {
+			return nil, err
+		}
+		c.logger.Info("Advanced to new state", "height", latestHeader.Height, "hash", latestHeader.Hash())
+		return latestHeader, nil
+	}
+
+	return nil, nil
+}
+
+// VerifyHeaderAtHeight fetches header and validators at the given height
+// and calls VerifyHeader. It returns header immediately if such exists in
+// trustedStore (no verification is needed).
+//
+// height must be > 0.
+//
+// It returns provider.ErrSignedHeaderNotFound if header is not found by
+// primary.
+func (c *Client) VerifyHeaderAtHeight(height int64, now time.Time) (*types.SignedHeader, error) {
+	if height <= 0 {
+		return nil, errors.New("negative or zero height")
+	}
+
+	// Check if header already verified.
+	h, err := c.TrustedHeader(height)
+	if err == nil {
+		c.logger.Info("Header has already been verified", "height", height, "hash", h.Hash())
+		// Return already trusted header
+		return h, nil
+	}
+
+	// Request the header and the vals.
+	newHeader, newVals, err := c.fetchHeaderAndValsAtHeight(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return newHeader, c.verifyHeader(newHeader, newVals, now)
+}
+
+// VerifyHeader verifies new header against the trusted state. It returns
+// immediately if newHeader exists in trustedStore (no verification is
+// needed). Else it performs one of the two types of verification:
+//
+// SequentialVerification: verifies that 2/3 of the trusted validator set has
+// signed the new header. If the headers are not adjacent, **all** intermediate
+// headers will be requested.
+//
+// SkippingVerification(trustLevel): verifies that {trustLevel} of the trusted
+// validator set has signed the new header. If it's not the case and the
+// headers are not adjacent, bisection is performed and necessary (not all)
+// intermediate headers will be requested. See the specification for details.
+//
+// If the header is below the latest trusted header, it is verified backwards
+// from the latest trusted header by following the LastBlockID hashes.
+//
+// NOTE: newVals must be provided (i.e. they aren't fetched from the primary).
+// It returns ErrOldHeaderExpired if the latest trusted header expired.
+func (c *Client) VerifyHeader(newHeader *types.SignedHeader, newVals *types.ValidatorSet, now time.Time) error {
+	if newHeader.Height <= 0 {
+		return errors.New("negative or zero height")
+	}
+
+	// Check if newHeader already verified.
+	h, err := c.TrustedHeader(newHeader.Height)
+	if err == nil {
+		// Make sure it's the same header.
+		if !bytes.Equal(h.Hash(), newHeader.Hash()) {
+			return fmt.Errorf("existing trusted header %X does not match newHeader %X", h.Hash(), newHeader.Hash())
+		}
+		c.logger.Info("Header has already been verified",
+			"height", newHeader.Height, "hash", newHeader.Hash())
+		return nil
+	}
+
+	return c.verifyHeader(newHeader, newVals, now)
+}
+
+func (c *Client) verifyHeader(newHeader *types.SignedHeader, newVals *types.ValidatorSet, now time.Time) error {
+	c.logger.Info("VerifyHeader", "height", newHeader.Height, "hash", newHeader.Hash(),
+		"vals", newVals.Hash())
+
+	var err error
+
+	// 1) If going forward, perform either bisection or sequential verification.
+	if newHeader.Height >= c.latestTrustedHeader.Height {
+		switch c.verificationMode {
+		case sequential:
+			err = c.sequence(c.latestTrustedHeader, newHeader, newVals, now)
+		case skipping:
+			err = c.bisection(c.latestTrustedHeader, c.latestTrustedVals, newHeader, newVals, now)
+		default:
+			panic(fmt.Sprintf("Unknown verification mode: %v", c.verificationMode))
+		}
+	} else {
+		// 2) If verifying before the latest trusted header, perform backwards
+		// verification.
+		err = c.backwards(c.latestTrustedHeader, newHeader, newVals, now)
+	}
+	if err != nil {
+		c.logger.Error("Can't verify", "err", err)
+		return err
+	}
+
+	// 3) Cross-check the new header with the witnesses before trusting it.
+	if err := c.compareNewHeaderWithWitnesses(newHeader, newVals); err != nil {
+		return err
+	}
+
+	if err := c.updateTrustedHeaderAndVals(newHeader, newVals); err != nil {
+		return err
+	}
+
+	// 4) Prune the headers which are no longer needed.
+	return c.pruneTrustedHeaders(now)
+}
+
+// sequence verifies every header from trustedHeader up to newHeader, fetching
+// the headers in between from the primary.
+func (c *Client) sequence(
+	trustedHeader *types.SignedHeader,
+	newHeader *types.SignedHeader,
+	newVals *types.ValidatorSet,
+	now time.Time) error {
+
+	var (
+		verifiedHeader = trustedHeader
+
+		interimHeader *types.SignedHeader
+		interimVals   *types.ValidatorSet
+
+		err error
+	)
+
+	for height := trustedHeader.Height + 1; height <= newHeader.Height; height++ {
+		// 1) Fetch interim headers and vals if needed.
+		if height == newHeader.Height { // last header
+			interimHeader, interimVals = newHeader, newVals
+		} else { // intermediate headers
+			interimHeader, interimVals, err = c.fetchHeaderAndValsAtHeight(height)
+			if err != nil {
+				return err
+			}
+		}
+
+		// 2) Verify them
+		c.logger.Debug("Verify newHeader against verifiedHeader",
+			"trustedHeight", verifiedHeader.Height,
+			"trustedHash", verifiedHeader.Hash(),
+			"newHeight", interimHeader.Height,
+			"newHash", interimHeader.Hash())
+
+		err = VerifyAdjacent(c.chainID, verifiedHeader, interimHeader, interimVals,
+			c.trustingPeriod, now, c.maxClockDrift)
+		if err != nil {
+			return fmt.Errorf("verify adjacent from #%d to #%d failed: %v",
+				verifiedHeader.Height, interimHeader.Height, err)
+		}
+
+		// 3) Update verifiedHeader
+		verifiedHeader = interimHeader
+	}
+
+	return nil
+}
+
+// see VerifyHeader
+//
+// bisection verifies newHeader directly against trustedHeader if enough of
+// its validators signed it, otherwise it verifies a header halfway first and
+// repeats from there.
+func (c *Client) bisection(
+	initiallyTrustedHeader *types.SignedHeader,
+	initiallyTrustedVals *types.ValidatorSet,
+	newHeader *types.SignedHeader,
+	newVals *types.ValidatorSet,
+	now time.Time) error {
+
+	type headerAndVals struct {
+		sh   *types.SignedHeader
+		vals *types.ValidatorSet
+	}
+
+	var (
+		headerCache = []headerAndVals{{newHeader, newVals}}
+		depth       = 0
+
+		trustedHeader = initiallyTrustedHeader
+		trustedVals   = initiallyTrustedVals
+	)
+
+	for {
+		c.logger.Debug("Verify newHeader against trustedHeader",
+			"trustedHeight", trustedHeader.Height,
+			"trustedHash", trustedHeader.Hash(),
+			"newHeight", headerCache[depth].sh.Height,
+			"newHash", headerCache[depth].sh.Hash())
+
+		err := Verify(c.chainID, trustedHeader, trustedVals, headerCache[depth].sh, headerCache[depth].vals,
+			c.trustingPeriod, now, c.maxClockDrift, c.trustLevel)
+		switch err.(type) {
+		case nil:
+			// Have we verified the last header
+			if depth == 0 {
+				return nil
+			}
+			// If not, update the lower bound to the previous upper bound
+			trustedHeader, trustedVals = headerCache[depth].sh, headerCache[depth].vals
+			// Remove the untrusted header at the lower bound in the header cache - it's no longer useful
+			headerCache = headerCache[:depth]
+			// Reset the cache depth so that we start from the upper bound again
+			depth = 0
+
+		case ErrNewValSetCantBeTrusted:
+			// do add another header to the end of the cache
+			if depth == len(headerCache)-1 {
+				pivotHeight := (headerCache[depth].sh.Height + trustedHeader.Height) / 2
+				interimHeader, interimVals, err := c.fetchHeaderAndValsAtHeight(pivotHeight)
+				if err != nil {
+					return err
+				}
+				headerCache = append(headerCache, headerAndVals{interimHeader, interimVals})
+			}
+			depth++
+
+		default:
+			return fmt.Errorf("verify from #%d to #%d failed: %v",
+				trustedHeader.Height, headerCache[depth].sh.Height, err)
+		}
+	}
+}
+
+// backwards verifies a header below the latest trusted header by following
+// the hashes of the previous blocks, which every header includes, from the
+// trusted header down to the new one.
+func (c *Client) backwards(
+	trustedHeader *types.SignedHeader,
+	newHeader *types.SignedHeader,
+	newVals *types.ValidatorSet,
+	now time.Time) error {
+
+	if HeaderExpired(trustedHeader, c.trustingPeriod, now) {
+		return ErrOldHeaderExpired{trustedHeader.Time.Add(c.trustingPeriod), now}
+	}
+
+	var (
+		verifiedHeader = trustedHeader
+		interimHeader  *types.SignedHeader
+		err            error
+	)
+
+	for verifiedHeader.Height > newHeader.Height {
+		if verifiedHeader.Height == newHeader.Height+1 {
+			interimHeader = newHeader
+		} else {
+			interimHeader, err = c.signedHeaderFromPrimary(verifiedHeader.Height - 1)
+			if err != nil {
+				return fmt.Errorf("failed to obtain the header at height #%d: %v", verifiedHeader.Height-1, err)
+			}
+		}
+		if err := verifyBackwards(c.chainID, interimHeader, verifiedHeader); err != nil {
+			return ErrInvalidHeader{err}
+		}
+		verifiedHeader = interimHeader
+	}
+
+	if !bytes.Equal(newHeader.ValidatorsHash, newVals.Hash()) {
+		return ErrInvalidHeader{fmt.Errorf("expected new header validators (%X) to match those that were supplied (%X)",
+			newHeader.ValidatorsHash, newVals.Hash())}
+	}
+	return nil
+}
+
+// verifyBackwards checks untrustedHeader is the block before trustedHeader.
+func verifyBackwards(chainID string, untrustedHeader, trustedHeader *types.SignedHeader) error {
+	if err := untrustedHeader.ValidateBasic(chainID); err != nil {
+		return fmt.Errorf("untrustedHeader.ValidateBasic failed: %v", err)
+	}
+
+	if !untrustedHeader.Time.Before(trustedHeader.Time) {
+		return fmt.Errorf("expected older header time %v to be before new header time %v",
+			untrustedHeader.Time,
+			trustedHeader.Time)
+	}
+
+	if !bytes.Equal(untrustedHeader.Hash(), trustedHeader.LastBlockID.Hash) {
+		return fmt.Errorf("older header hash %X does not match trusted header's last block %X",
+			untrustedHeader.Hash(),
+			trustedHeader.LastBlockID.Hash)
+	}
+
+	return nil
+}
+
+// Cleanup removes all the data (headers and validator sets) stored. Note: the
+// client must be stopped at this point.
+func (c *Client) Cleanup() error {
+	c.logger.Info("Removing all the data")
+	c.latestTrustedHeader = nil
+	c.latestTrustedVals = nil
+
+	for {
+		h, err := c.trustedStore.LastSignedHeaderHeight()
+		if err != nil {
+			return err
+		}
+		if h == -1 {
+			return nil
+		}
+		if err := c.trustedStore.DeleteSignedHeaderAndValidatorSet(h); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) updateTrustedHeaderAndVals(h *types.SignedHeader, vals *types.ValidatorSet) error {
+	if !bytes.Equal(h.ValidatorsHash, vals.Hash()) {
+		return fmt.Errorf("expected validator's hash %X, but got %X", h.ValidatorsHash, vals.Hash())
+	}
+
+	if err := c.trustedStore.SaveSignedHeaderAndValidatorSet(h, vals); err != nil {
+		return fmt.Errorf("failed to save trusted header: %v", err)
+	}
+
+	if c.latestTrustedHeader == nil || h.Height > c.latestTrustedHeader.Height {
+		c.latestTrustedHeader = h
+		c.latestTrustedVals = vals
+	}
+
+	return nil
+}
+
+// pruneTrustedHeaders removes the expired headers, except the latest one,
+// then the oldest headers above the pruning size.
+func (c *Client) pruneTrustedHeaders(now time.Time) error {
+	for {
+		h, err := c.trustedStore.FirstSignedHeaderHeight()
+		if err != nil {
+			return err
+		}
+		if h == -1 || h >= c.latestTrustedHeader.Height {
+			break
+		}
+		sh, err := c.trustedStore.SignedHeader(h)
+		if err != nil {
+			return err
+		}
+		if !HeaderExpired(sh, c.trustingPeriod, now) {
+			break
+		}
+		if err := c.trustedStore.DeleteSignedHeaderAndValidatorSet(h); err != nil {
+			return fmt.Errorf("failed to prune expired header #%d: %v", h, err)
+		}
+	}
+
+	if c.pruningSize > 0 {
+		if err := c.trustedStore.Prune(c.pruningSize); err != nil {
+			return fmt.Errorf("failed to prune trusted headers: %v", err)
+		}
+	}
+	return nil
+}
+
+// fetchHeaderAndValsAtHeight fetches the header and the validator set at the
+// height from the primary, checking the validators are those of the header.
+func (c *Client) fetchHeaderAndValsAtHeight(height int64) (*types.SignedHeader, *types.ValidatorSet, error) {
+	h, err := c.signedHeaderFromPrimary(height)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to obtain the header #%d: %v", height, err)
+	}
+	vals, err := c.validatorSetFromPrimary(h.Height)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to obtain the vals #%d: %v", h.Height, err)
+	}
+	return h, vals, nil
+}
+
+// signedHeaderFromPrimary retrieves the SignedHeader from the primary
+// provider at the specified height. It replaces the primary with a witness if
+// the primary fails to respond, but not if it doesn't have the header.
+func (c *Client) signedHeaderFromPrimary(height int64) (*types.SignedHeader, error) {
+	for attempt := 1; attempt <= maxRetryAttempts; attempt++ {
+		c.providerMutex.Lock()
+		h, err := c.primary.SignedHeader(height)
+		c.providerMutex.Unlock()
+		if err == nil || err == provider.ErrSignedHeaderNotFound {
+			return h, err
+		}
+		c.logger.Error("Failed to get signed header from primary", "attempt", attempt, "err", err)
+		if err := c.replacePrimaryProvider(); err != nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("failed to get signed header from primary")
+}
+
+// validatorSetFromPrimary retrieves the ValidatorSet from the primary
+// provider at the specified height, like signedHeaderFromPrimary.
+func (c *Client) validatorSetFromPrimary(height int64) (*types.ValidatorSet, error) {
+	for attempt := 1; attempt <= maxRetryAttempts; attempt++ {
+		c.providerMutex.Lock()
+		vals, err := c.primary.ValidatorSet(height)
+		c.providerMutex.Unlock()
+		if err == nil || err == provider.ErrValidatorSetNotFound {
+			return vals, err
+		}
+		c.logger.Error("Failed to get validator set from primary", "attempt", attempt, "err", err)
+		if err := c.replacePrimaryProvider(); err != nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("failed to get validator set from primary")
+}
+
+// replacePrimaryProvider replaces the primary with the first witness, and
+// removes the witness.
+func (c *Client) replacePrimaryProvider() error {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+
+	if len(c.witnesses) == 0 {
+		return errors.New("no witnesses left to replace the primary")
+	}
+	c.primary = c.witnesses[0]
+	c.witnesses = c.witnesses[1:]
+	c.logger.Info("New primary", "p", c.primary)
+
+	return nil
+}
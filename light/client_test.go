@@ -0,0 +1,252 @@
+package light
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/light/provider"
+	mockp "github.com/tendermint/tendermint/light/provider/mock"
+	dbs "github.com/tendermint/tendermint/light/store/db"
+	"github.com/tendermint/tendermint/types"
+)
+
+// countingProvider counts the headers requested from the provider.
+type countingProvider struct {
+	provider.Provider
+	requested []int64
+}
+
+func (p *countingProvider) SignedHeader(height int64) (*types.SignedHeader, error) {
+	p.requested = append(p.requested, height)
+	return p.Provider.SignedHeader(height)
+}
+
+func newTestClient(t *testing.T, headers map[int64]*types.SignedHeader, vals map[int64]*types.ValidatorSet,
+	trustHeight int64, options ...Option) (*Client, *countingProvider) {
+
+	primary := &countingProvider{Provider: mockp.New(chainID, headers, vals)}
+	options = append(options, Logger(log.TestingLogger()))
+	c, err := NewClient(
+		chainID,
+		TrustOptions{Period: trustPeriod, Height: trustHeight, Hash: headers[trustHeight].Hash()},
+		primary,
+		[]provider.Provider{mockp.New(chainID, headers, vals)},
+		dbs.New(dbm.NewMemDB(), chainID),
+		options...,
+	)
+	require.NoError(t, err)
+	primary.requested = nil
+	return c, primary
+}
+
+func TestClient_SequentialVerification(t *testing.T) {
+	keys := genPrivKeys(4)
+	headers, vals := genChain(chainID, sameKeys(keys, 5), bTime)
+	now := bTime.Add(time.Hour)
+
+	c, primary := newTestClient(t, headers, vals, 1, SequentialVerification())
+	h, err := c.VerifyHeaderAtHeight(5, now)
+	require.NoError(t, err)
+	assert.Equal(t, headers[5].Hash(), h.Hash())
+
+	// Every header in between is fetched
+	assert.Equal(t, []int64{5, 2, 3, 4}, primary.requested)
+
+	// The verified header is trusted from now on
+	trusted, err := c.TrustedHeader(0)
+	require.NoError(t, err)
+	assert.Equal(t, headers[5].Hash(), trusted.Hash())
+	trustedVals, height, err := c.TrustedValidatorSet(0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, height)
+	assert.Equal(t, vals[5].Hash(), trustedVals.Hash())
+
+	// A header in between which isn't signed by its validators fails the
+	// verification
+	badHeaders, badVals := genChain(chainID, sameKeys(keys, 5), bTime)
+	badHeaders[3] = keys.genSignedHeader(chainID, 3, bTime.Add(3*time.Minute), types.BlockID{},
+		badVals[3], badVals[4], 0, 1)
+	c, _ = newTestClient(t, badHeaders, badVals, 1, SequentialVerification())
+	_, err = c.VerifyHeaderAtHeight(5, now)
+	assert.Error(t, err)
+	_, err = c.TrustedHeader(5)
+	assert.Error(t, err)
+}
+
+func TestClient_SkippingVerification(t *testing.T) {
+	keys := genPrivKeys(4)
+	now := bTime.Add(time.Hour)
+
+	// Without validator changes, the last header is verified directly
+	headers, vals := genChain(chainID, sameKeys(keys, 5), bTime)
+	c, primary := newTestClient(t, headers, vals, 1)
+	_, err := c.VerifyHeaderAtHeight(5, now)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{5}, primary.requested)
+
+	// If the validators of height 5 are all new, less than 1/3 of the trusted
+	// validators signed it, so the client bisects down to the header of
+	// height 4, which was signed by both sets
+	newKeys := genPrivKeys(4)
+	changed := append(sameKeys(keys, 4), newKeys)
+	headers, vals = genChain(chainID, changed, bTime)
+	c, primary = newTestClient(t, headers, vals, 1)
+	_, err = c.VerifyHeaderAtHeight(5, now)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{5, 3, 4}, primary.requested)
+	trusted, err := c.TrustedHeader(0)
+	require.NoError(t, err)
+	assert.Equal(t, headers[5].Hash(), trusted.Hash())
+
+	// A valid header which doesn't link to the trusted chain still fails
+	forged := newKeys.genSignedHeader(chainID, 6, bTime.Add(6*time.Minute), types.BlockID{},
+		vals[5], vals[5], 0, 1)
+	err = c.VerifyHeader(forged, vals[5], now)
+	assert.Error(t, err)
+}
+
+func TestClient_Backwards(t *testing.T) {
+	keys := genPrivKeys(4)
+	headers, vals := genChain(chainID, sameKeys(keys, 5), bTime)
+	now := bTime.Add(time.Hour)
+
+	c, primary := newTestClient(t, headers, vals, 4)
+	h, err := c.VerifyHeaderAtHeight(2, now)
+	require.NoError(t, err)
+	assert.Equal(t, headers[2].Hash(), h.Hash())
+	assert.Equal(t, []int64{2, 3}, primary.requested)
+
+	// A header that doesn't link to the trusted one is rejected
+	forged := keys.genSignedHeader(chainID, 1, bTime.Add(30*time.Second), types.BlockID{}, vals[1], vals[2], 0, len(keys))
+	err = c.VerifyHeader(forged, vals[1], now)
+	assert.Error(t, err)
+}
+
+func TestClient_TrustedHeaderExpired(t *testing.T) {
+	keys := genPrivKeys(4)
+	headers, vals := genChain(chainID, sameKeys(keys, 3), bTime)
+
+	c, _ := newTestClient(t, headers, vals, 1)
+	_, err := c.VerifyHeaderAtHeight(3, bTime.Add(2*trustPeriod))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "old header has expired")
+}
+
+func TestClient_Update(t *testing.T) {
+	keys := genPrivKeys(4)
+	headers, vals := genChain(chainID, sameKeys(keys, 3), bTime)
+
+	c, _ := newTestClient(t, headers, vals, 1)
+	h, err := c.Update(bTime.Add(time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, h)
+	assert.EqualValues(t, 3, h.Height)
+
+	// Nothing new
+	h, err = c.Update(bTime.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Nil(t, h)
+}
+
+func TestClient_RestoreFromStore(t *testing.T) {
+	keys := genPrivKeys(4)
+	headers, vals := genChain(chainID, sameKeys(keys, 3), bTime)
+	trustedStore := dbs.New(dbm.NewMemDB(), chainID)
+	require.NoError(t, trustedStore.SaveSignedHeaderAndValidatorSet(headers[1], vals[1]))
+	require.NoError(t, trustedStore.SaveSignedHeaderAndValidatorSet(headers[2], vals[2]))
+
+	// The client resumes from the latest stored header, without fetching the
+	// trusted header
+	dead := mockp.NewDeadMock(chainID)
+	c, err := NewClient(chainID, TrustOptions{Period: trustPeriod, Height: 1, Hash: headers[1].Hash()},
+		dead, []provider.Provider{dead}, trustedStore)
+	require.NoError(t, err)
+	h, err := c.TrustedHeader(0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, h.Height)
+
+	// A stored header which doesn't match the trust options is an error
+	_, err = NewClient(chainID, TrustOptions{Period: trustPeriod, Height: 1, Hash: headers[2].Hash()},
+		dead, []provider.Provider{dead}, trustedStore)
+	assert.Error(t, err)
+
+	// Until the store is cleaned up
+	require.NoError(t, c.Cleanup())
+	height, err := trustedStore.LastSignedHeaderHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, -1, height)
+}
+
+func TestClient_PrunesTrustedHeaders(t *testing.T) {
+	keys := genPrivKeys(4)
+	headers, vals := genChain(chainID, sameKeys(keys, 5), bTime)
+
+	// The oldest headers above the pruning size are pruned
+	c, _ := newTestClient(t, headers, vals, 1, PruningSize(2))
+	for _, height := range []int64{2, 3, 4} {
+		_, err := c.VerifyHeaderAtHeight(height, bTime.Add(time.Hour))
+		require.NoError(t, err)
+	}
+	height, err := c.FirstTrustedHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, height)
+	height, err = c.LastTrustedHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, height)
+
+	// The expired headers are pruned, whatever the pruning size
+	c, _ = newTestClient(t, headers, vals, 1)
+	_, err = c.VerifyHeaderAtHeight(2, bTime.Add(time.Hour))
+	require.NoError(t, err)
+	_, err = c.VerifyHeaderAtHeight(3, bTime.Add(trustPeriod+90*time.Second))
+	require.NoError(t, err)
+	height, err = c.FirstTrustedHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, height)
+}
+
+func TestClient_NewClient_InvalidTrustOptions(t *testing.T) {
+	keys := genPrivKeys(4)
+	headers, vals := genChain(chainID, sameKeys(keys, 3), bTime)
+	primary := mockp.New(chainID, headers, vals)
+
+	testCases := map[string]TrustOptions{
+		"no period":  {Height: 1, Hash: headers[1].Hash()},
+		"no height":  {Period: trustPeriod, Hash: headers[1].Hash()},
+		"short hash": {Period: trustPeriod, Height: 1, Hash: []byte{1}},
+		"wrong hash": {Period: trustPeriod, Height: 1, Hash: headers[2].Hash()},
+	}
+	for name, opts := range testCases {
+		opts := opts
+		t.Run(name, func(t *testing.T) {
+			_, err := NewClient(chainID, opts, primary, []provider.Provider{primary},
+				dbs.New(dbm.NewMemDB(), chainID))
+			assert.Error(t, err)
+		})
+	}
+
+	// No witnesses
+	_, err := NewClient(chainID, TrustOptions{Period: trustPeriod, Height: 1, Hash: headers[1].Hash()},
+		primary, nil, dbs.New(dbm.NewMemDB(), chainID))
+	assert.Error(t, err)
+}
+
+func TestClient_ReplacesPrimary(t *testing.T) {
+	keys := genPrivKeys(4)
+	headers, vals := genChain(chainID, sameKeys(keys, 3), bTime)
+	witness := mockp.New(chainID, headers, vals)
+
+	c, err := NewClient(chainID, TrustOptions{Period: trustPeriod, Height: 1, Hash: headers[1].Hash()},
+		mockp.NewDeadMock(chainID), []provider.Provider{witness}, dbs.New(dbm.NewMemDB(), chainID))
+	require.NoError(t, err)
+	assert.Equal(t, witness, c.Primary())
+	assert.Empty(t, c.Witnesses())
+
+	_, err = c.VerifyHeaderAtHeight(3, bTime.Add(time.Hour))
+	require.NoError(t, err)
+}
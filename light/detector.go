@@ -0,0 +1,181 @@
+package light
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/types"
+)
+
+// compareNewHeaderWithWitnesses cross-checks the new header, verified with
+// the headers of the primary, against the headers of the witnesses at the
+// same height.
+//
+// A witness which fails to respond, or responds with a header which isn't
+// signed by its validators or by enough of the validators of the new header,
+// is misbehaving, so it's removed. A witness which doesn't have the header
+// yet is skipped.
+//
+// A conflicting header signed by enough of the validators of the new header
+// means there's a fork: the evidence of the validators which signed both
+// headers is reported to the primary and the witnesses, and
+// ErrConflictingHeaders is returned. So is the evidence of the light client
+// attack of each chain on the other.
+func (c *Client) compareNewHeaderWithWitnesses(h *types.SignedHeader, vals *types.ValidatorSet) error {
+	var (
+		primary   = c.Primary()
+		witnesses = c.Witnesses()
+		faulty    []provider.Provider
+	)
+	defer func() { c.removeWitnesses(faulty) }()
+
+	for _, w := range witnesses {
+		altH, err := w.SignedHeader(h.Height)
+		switch {
+		case err == provider.ErrSignedHeaderNotFound:
+			continue
+		case err != nil:
+			c.logger.Error("Failed to get signed header from witness", "witness", w, "height", h.Height, "err", err)
+			faulty = append(faulty, w)
+			continue
+		}
+
+		if bytes.Equal(h.Hash(), altH.Hash()) {
+			continue
+		}
+
+		altVals, err := w.ValidatorSet(h.Height)
+		if err != nil {
+			c.logger.Error("Failed to get validator set from witness", "witness", w, "height", h.Height, "err", err)
+			faulty = append(faulty, w)
+			continue
+		}
+		if err := c.verifyConflictingHeader(altH, altVals, vals); err != nil {
+			c.logger.Error("Witness sent an invalid header", "witness", w, "height", h.Height, "err", err)
+			faulty = append(faulty, w)
+			continue
+		}
+
+		c.logger.Error("Conflicting headers, the chain has forked", "height", h.Height,
+			"primary", primary, "hash", h.Hash(), "witness", w, "witnessHash", altH.Hash())
+		evidence := duplicateVoteEvidence(c.chainID, vals, h, altH)
+		evidence = append(evidence, c.lightClientAttackEvidence(h, vals, altH, altVals)...)
+		c.reportEvidence(evidence, append([]provider.Provider{primary}, witnesses...))
+		return ErrConflictingHeaders{H1: h, Primary: primary, H2: altH, Witness: w}
+	}
+
+	return nil
+}
+
+// verifyConflictingHeader checks the conflicting header altH is signed by +2/3
+// of its validators, and by at least the trust level of vals, the validators
+// of the header it conflicts with. Otherwise, the witness could have made it
+// up without any of the trusted validators.
+func (c *Client) verifyConflictingHeader(altH *types.SignedHeader, altVals, vals *types.ValidatorSet) error {
+	if err := altH.ValidateBasic(c.chainID); err != nil {
+		return fmt.Errorf("altH.ValidateBasic failed: %v", err)
+	}
+	if !bytes.Equal(altH.ValidatorsHash, altVals.Hash()) {
+		return fmt.Errorf("expected header validators (%X) to match those that were supplied (%X)",
+			altH.ValidatorsHash, altVals.Hash())
+	}
+	if err := altVals.VerifyCommit(c.chainID, altH.Commit.BlockID, altH.Height, altH.Commit); err != nil {
+		return err
+	}
+	return vals.VerifyCommitTrusting(c.chainID, altH.Commit.BlockID, altH.Height, altH.Commit, c.trustLevel)
+}
+
+// duplicateVoteEvidence returns the evidence of the validators which
+// precommitted both conflicting headers in the same round. The votes are only
+// comparable by index if both headers have the same validators, vals.
+func duplicateVoteEvidence(chainID string, vals *types.ValidatorSet, h1, h2 *types.SignedHeader) []types.Evidence {
+	if !bytes.Equal(h1.ValidatorsHash, vals.Hash()) || !bytes.Equal(h2.ValidatorsHash, vals.Hash()) {
+		return nil
+	}
+
+	var evidence []types.Evidence
+	for i, val := range vals.Validators {
+		if i >= h1.Commit.Size() || i >= h2.Commit.Size() {
+			break
+		}
+		voteA, voteB := h1.Commit.GetByIndex(i), h2.Commit.GetByIndex(i)
+		if voteA == nil || voteB == nil ||
+			!voteA.BlockID.Equals(h1.Commit.BlockID) || !voteB.BlockID.Equals(h2.Commit.BlockID) {
+			continue
+		}
+		ev := &types.DuplicateVoteEvidence{PubKey: val.PubKey, VoteA: voteA, VoteB: voteB}
+		if err := ev.Verify(chainID, val.PubKey); err != nil {
+			// e.g. the votes are from different rounds
+			continue
+		}
+		evidence = append(evidence, ev)
+	}
+	return evidence
+}
+
+// lightClientAttackEvidence returns the evidence of the attack of each chain on
+// the other: the header of the witness, altH, signed by enough of vals as
+// checked by verifyConflictingHeader, and the header of the primary, h, if
+// enough of altVals signed it. The common height is the height of the
+// headers, which the validators signing both were bonded at.
+func (c *Client) lightClientAttackEvidence(h *types.SignedHeader, vals *types.ValidatorSet,
+	altH *types.SignedHeader, altVals *types.ValidatorSet) []types.Evidence {
+
+	evidence := []types.Evidence{&types.LightClientAttackEvidence{
+		ConflictingHeader: altH,
+		ConflictingVals:   altVals,
+		CommonHeight:      h.Height,
+	}}
+	if err := altVals.VerifyCommitTrusting(c.chainID, h.Commit.BlockID, h.Height, h.Commit, c.trustLevel); err == nil {
+		evidence = append(evidence, &types.LightClientAttackEvidence{
+			ConflictingHeader: h,
+			ConflictingVals:   vals,
+			CommonHeight:      h.Height,
+		})
+	}
+	return evidence
+}
+
+// reportEvidence reports all the evidence to the providers, logging the
+// providers which fail to accept it.
+func (c *Client) reportEvidence(evidence []types.Evidence, providers []provider.Provider) {
+	for _, ev := range evidence {
+		for _, p := range providers {
+			if err := p.ReportEvidence(ev); err != nil {
+				c.logger.Error("Failed to report evidence", "provider", p, "evidence", ev, "err", err)
+			}
+		}
+	}
+}
+
+// removeWitnesses removes the faulty witnesses.
+func (c *Client) removeWitnesses(faulty []provider.Provider) {
+	if len(faulty) == 0 {
+		return
+	}
+
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+
+	var witnesses []provider.Provider
+	for _, w := range c.witnesses {
+		removed := false
+		for _, f := range faulty {
+			if w == f {
+				removed = true
+				break
+			}
+		}
+		if removed {
+			c.logger.Info("Removed faulty witness", "witness", w)
+			continue
+		}
+		witnesses = append(witnesses, w)
+	}
+	c.witnesses = witnesses
+
+	if len(c.witnesses) == 0 {
+		c.logger.Error("No witnesses left, the headers of the primary are no longer cross-checked")
+	}
+}
@@ -0,0 +1,99 @@
+package light
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/light/provider"
+	mockp "github.com/tendermint/tendermint/light/provider/mock"
+	dbs "github.com/tendermint/tendermint/light/store/db"
+	"github.com/tendermint/tendermint/types"
+)
+
+type evidenceRecorder interface {
+	HasEvidence(ev types.Evidence) bool
+}
+
+func TestClient_DetectsFork(t *testing.T) {
+	keys := genPrivKeys(4)
+	headers, vals := genChain(chainID, sameKeys(keys, 3), bTime)
+
+	// The same validators signed another header at height 3
+	forkedHeaders := map[int64]*types.SignedHeader{1: headers[1], 2: headers[2]}
+	forkedHeaders[3] = keys.genSignedHeader(chainID, 3, bTime.Add(3*time.Minute+time.Second),
+		types.BlockID{Hash: headers[2].Hash()}, vals[3], vals[3], 0, len(keys))
+
+	primary := mockp.New(chainID, headers, vals)
+	witness := mockp.New(chainID, forkedHeaders, vals)
+	c, err := NewClient(chainID, TrustOptions{Period: trustPeriod, Height: 1, Hash: headers[1].Hash()},
+		primary, []provider.Provider{witness}, dbs.New(dbm.NewMemDB(), chainID), Logger(log.TestingLogger()))
+	require.NoError(t, err)
+
+	_, err = c.VerifyHeaderAtHeight(3, bTime.Add(time.Hour))
+	require.IsType(t, ErrConflictingHeaders{}, err)
+	assert.Equal(t, headers[3].Hash(), err.(ErrConflictingHeaders).H1.Hash())
+	assert.Equal(t, forkedHeaders[3].Hash(), err.(ErrConflictingHeaders).H2.Hash())
+
+	// The header isn't trusted
+	_, err = c.TrustedHeader(3)
+	assert.Error(t, err)
+
+	// Every validator double signed, which is reported to all the providers
+	evidence := duplicateVoteEvidence(chainID, vals[3], headers[3], forkedHeaders[3])
+	require.Len(t, evidence, len(keys))
+	for _, ev := range evidence {
+		assert.True(t, primary.(evidenceRecorder).HasEvidence(ev))
+		assert.True(t, witness.(evidenceRecorder).HasEvidence(ev))
+	}
+
+	// So did the light client attack of each chain on the other
+	for _, ev := range []types.Evidence{
+		&types.LightClientAttackEvidence{ConflictingHeader: forkedHeaders[3], ConflictingVals: vals[3], CommonHeight: 3},
+		&types.LightClientAttackEvidence{ConflictingHeader: headers[3], ConflictingVals: vals[3], CommonHeight: 3},
+	} {
+		assert.True(t, primary.(evidenceRecorder).HasEvidence(ev))
+		assert.True(t, witness.(evidenceRecorder).HasEvidence(ev))
+	}
+
+	// The witness isn't removed, as it's unknown which provider is honest
+	assert.Len(t, c.Witnesses(), 1)
+}
+
+func TestClient_RemovesFaultyWitnesses(t *testing.T) {
+	keys := genPrivKeys(4)
+	headers, vals := genChain(chainID, sameKeys(keys, 3), bTime)
+
+	// A witness which signed its own header at height 3
+	otherKeys := genPrivKeys(4)
+	otherVals := otherKeys.ToValidators(10, 0)
+	madeUpHeaders := map[int64]*types.SignedHeader{1: headers[1], 2: headers[2]}
+	madeUpHeaders[3] = otherKeys.genSignedHeader(chainID, 3, bTime.Add(3*time.Minute),
+		types.BlockID{Hash: headers[2].Hash()}, otherVals, otherVals, 0, len(otherKeys))
+	madeUpVals := map[int64]*types.ValidatorSet{1: vals[1], 2: vals[2], 3: otherVals}
+
+	// A witness which is behind
+	behindHeaders := map[int64]*types.SignedHeader{1: headers[1], 2: headers[2]}
+
+	var (
+		primary = mockp.New(chainID, headers, vals)
+		madeUp  = mockp.New(chainID, madeUpHeaders, madeUpVals)
+		behind  = mockp.New(chainID, behindHeaders, vals)
+		dead    = mockp.NewDeadMock(chainID)
+	)
+	c, err := NewClient(chainID, TrustOptions{Period: trustPeriod, Height: 1, Hash: headers[1].Hash()},
+		primary, []provider.Provider{madeUp, behind, dead}, dbs.New(dbm.NewMemDB(), chainID),
+		Logger(log.TestingLogger()))
+	require.NoError(t, err)
+
+	h, err := c.VerifyHeaderAtHeight(3, bTime.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, headers[3].Hash(), h.Hash())
+
+	// Only the witness which is behind is kept
+	assert.Equal(t, []provider.Provider{behind}, c.Witnesses())
+}
@@ -0,0 +1,121 @@
+/*
+Package light provides a light client implementation.
+
+The concept of light clients was introduced in the Bitcoin white paper. It
+describes a watcher of distributed consensus process that only validates the
+consensus algorithm and not the state machine transactions within.
+
+Tendermint light clients allow bandwidth & compute-constrained devices, such as
+smartphones, low-power embedded chips, or other blockchains to efficiently
+verify the consensus of a Tendermint blockchain. This forms the basis of safe
+and efficient state synchronization for new network nodes and inter-blockchain
+communication (where a light client of one Tendermint instance runs in another
+chain's state machine).
+
+In a network that is expected to reliably punish validators for misbehavior by
+slashing bonded stake and where the validator set changes infrequently, clients
+can take advantage of this assumption to safely synchronize a light client
+without downloading the intervening headers.
+
+Light clients (and full nodes) operating in the Proof Of Stake context need a
+trusted block height from a trusted source that is no older than 1 unbonding
+window plus a configurable evidence submission synchrony bound. This is called
+weak subjectivity.
+
+Weak subjectivity is required in Proof of Stake blockchains because it is
+costless for an attacker to buy up voting keys that are no longer bonded and
+fork the network at some point in its prior history. See Vitalik's post
+"Proof of Stake: How I Learned to Love Weak Subjectivity".
+
+NOTE: Tendermint provides a somewhat different (stronger) light client model
+than Bitcoin under eclipse, since the eclipsing node(s) can only fool the light
+client if they have two-thirds of the private keys from the last root-of-trust.
+
+Common structures
+
+* SignedHeader
+
+SignedHeader is a block header along with a commit -- enough validator
+precommit-vote signatures to prove its validity (> 2/3 of the voting power)
+given the validator set responsible for signing that header.
+
+The hash of the next validator set is included and signed in the SignedHeader.
+This lets the light client keep track of arbitrary changes to the validator
+set, as every change to the validator set must be approved by inclusion in the
+header and signed in the commit.
+
+In the worst case, with every block changing the validators around completely,
+a light client can sync up with every block header to verify each validator set
+change on the chain. In practice, most applications will not have frequent
+drastic updates to the validator set, so the logic defined in this package for
+light client syncing is optimized to use intelligent bisection.
+
+What this package provides
+
+This package provides two major things:
+
+1. Client implementation (see client.go)
+2. Pure functions to verify a new header (see verifier.go)
+
+1. Client
+
+Client connects to a primary provider, which provides the headers and
+validator sets, and witnesses, which take over when the primary fails. Every
+new header is cross-checked with the headers of the witnesses: a witness with
+a conflicting header, signed by enough of the trusted validators, means the
+chain has forked, so the evidence of the validators which signed both headers
+is reported to the providers. Witnesses which fail to respond or send invalid
+headers are removed. The trusted headers are saved in a trusted store, in
+memory (see store/mem) or in a DB (see store/db), so the client can resume
+from them after a restart. The store is pruned of the expired headers and of
+the oldest headers above the pruning size (see PruningSize option), and can be
+backed up with store.Export and store.Import.
+
+Example usage:
+
+	db, err := dbm.NewGoLevelDB("light-client-db", dbDir)
+	if err != nil {
+		// handle error
+	}
+
+	c, err := light.NewClient(
+		chainID,
+		light.TrustOptions{
+			Period: 504 * time.Hour, // 21 days
+			Height: 100,
+			Hash:   header.Hash(),
+		},
+		httpp.New(chainID, "tcp://localhost:26657"),
+		[]provider.Provider{httpp.New(chainID, "tcp://witness1:26657")},
+		dbs.New(db, chainID),
+	)
+	if err != nil {
+		// handle error
+	}
+
+	h, err := c.TrustedHeader(100)
+	if err != nil {
+		// handle error
+	}
+	fmt.Println("header", h)
+
+2. Pure functions to verify a new header (see verifier.go)
+
+Verify function verifies a new header against some trusted header. See
+docs/spec/consensus/light-client.md for details.
+
+There are two methods of verification: sequential and bisection
+
+Sequential uses the headers hashes and the validator sets to verify each adjacent header until
+it reaches the target header.
+
+Bisection finds the middle header between a trusted and new header, reiterating the action until it
+verifies a header. A cache of headers requested by the primary is kept such that when a
+verification is made, and the light client tries again to verify the new header in the middle,
+the light client does not need to ask for all the same headers again.
+
+Headers below the latest trusted header are verified backwards: every header
+includes the hash of the previous block, so they're linked back from the
+trusted header.
+*/
+package light
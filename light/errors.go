@@ -0,0 +1,59 @@
+package light
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ErrOldHeaderExpired means the old (trusted) header has expired according to
+// the given trustingPeriod and current time. If so, the light client must be
+// reset subjectively.
+type ErrOldHeaderExpired struct {
+	At  time.Time
+	Now time.Time
+}
+
+func (e ErrOldHeaderExpired) Error() string {
+	return fmt.Sprintf("old header has expired at %v (now: %v)", e.At, e.Now)
+}
+
+// ErrNewValSetCantBeTrusted means the new validator set cannot be trusted
+// because less than the trust level of the old validator set has signed the
+// new header. The light client tries a header in between when bisecting.
+type ErrNewValSetCantBeTrusted struct {
+	Reason error
+}
+
+func (e ErrNewValSetCantBeTrusted) Error() string {
+	return fmt.Sprintf("can't trust new val set: %v", e.Reason)
+}
+
+// ErrInvalidHeader means the header either failed the basic validation or
+// the commit is not signed by +2/3 of its validator set.
+type ErrInvalidHeader struct {
+	Reason error
+}
+
+func (e ErrInvalidHeader) Error() string {
+	return fmt.Sprintf("invalid header: %v", e.Reason)
+}
+
+// ErrConflictingHeaders is returned when two conflicting headers, both signed
+// by enough of the trusted validators, are found: the one of the primary and
+// the one of a witness. It means there's a fork, whose evidence was reported
+// to the providers. The light client must be reset subjectively.
+type ErrConflictingHeaders struct {
+	H1      *types.SignedHeader
+	Primary provider.Provider
+
+	H2      *types.SignedHeader
+	Witness provider.Provider
+}
+
+func (e ErrConflictingHeaders) Error() string {
+	return fmt.Sprintf("header hash %X from primary %v does not match the one %X from witness %v",
+		e.H1.Hash(), e.Primary, e.H2.Hash(), e.Witness)
+}
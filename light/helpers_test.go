@@ -0,0 +1,135 @@
+package light
+
+import (
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/types"
+)
+
+// privKeys is a helper type for testing.
+//
+// It lets us simulate signing with many keys. The main use case is to create
+// a set, and call genSignedHeader to get properly signed header for testing.
+type privKeys []crypto.PrivKey
+
+// genPrivKeys produces an array of private keys to generate commits.
+func genPrivKeys(n int) privKeys {
+	res := make(privKeys, n)
+	for i := range res {
+		res[i] = ed25519.GenPrivKey()
+	}
+	return res
+}
+
+// Extend adds n more keys (to remove, just take a slice).
+func (pkz privKeys) Extend(n int) privKeys {
+	extra := genPrivKeys(n)
+	return append(pkz, extra...)
+}
+
+// ToValidators produces a valset from the set of keys. The first key has
+// weight init and it increases by inc every step.
+func (pkz privKeys) ToValidators(init, inc int64) *types.ValidatorSet {
+	res := make([]*types.Validator, len(pkz))
+	for i, k := range pkz {
+		res[i] = types.NewValidator(k.PubKey(), init+int64(i)*inc)
+	}
+	return types.NewValidatorSet(res)
+}
+
+// signHeader properly signs the header with all keys from first to last
+// exclusive.
+func (pkz privKeys) signHeader(header *types.Header, first, last int) *types.Commit {
+	commitSigs := make([]*types.CommitSig, len(pkz))
+
+	// We need this list to keep the ordering.
+	vset := pkz.ToValidators(1, 0)
+
+	// Fill in the votes we want.
+	for i := first; i < last && i < len(pkz); i++ {
+		vote := makeVote(header, vset, pkz[i])
+		commitSigs[vote.ValidatorIndex] = vote.CommitSig()
+	}
+	blockID := types.BlockID{Hash: header.Hash()}
+	return types.NewCommit(blockID, commitSigs)
+}
+
+func makeVote(header *types.Header, valset *types.ValidatorSet, key crypto.PrivKey) *types.Vote {
+	addr := key.PubKey().Address()
+	idx, _ := valset.GetByAddress(addr)
+	vote := &types.Vote{
+		ValidatorAddress: addr,
+		ValidatorIndex:   idx,
+		Height:           header.Height,
+		Round:            1,
+		Timestamp:        header.Time,
+		Type:             types.PrecommitType,
+		BlockID:          types.BlockID{Hash: header.Hash()},
+	}
+	sig, err := key.Sign(vote.SignBytes(header.ChainID))
+	if err != nil {
+		panic(err)
+	}
+	vote.Signature = sig
+	return vote
+}
+
+// genSignedHeader generates a header at height with the time bTime, following
+// the lastBlockID, signed by the keys from first to last exclusive.
+func (pkz privKeys) genSignedHeader(chainID string, height int64, bTime time.Time, lastBlockID types.BlockID,
+	valset, nextValset *types.ValidatorSet, first, last int) *types.SignedHeader {
+
+	header := &types.Header{
+		ChainID:            chainID,
+		Height:             height,
+		Time:               bTime,
+		LastBlockID:        lastBlockID,
+		ValidatorsHash:     valset.Hash(),
+		NextValidatorsHash: nextValset.Hash(),
+		AppHash:            []byte("app_hash"),
+		ConsensusHash:      []byte("consensus_hash"),
+		LastResultsHash:    []byte("results_hash"),
+	}
+	return &types.SignedHeader{
+		Header: header,
+		Commit: pkz.signHeader(header, first, last),
+	}
+}
+
+// genChain generates a chain of headers 1 to len(keys) signed by all the keys
+// of their height, keys[h-1], one minute apart from bTime on.
+func genChain(chainID string, keys []privKeys, bTime time.Time) (
+	map[int64]*types.SignedHeader, map[int64]*types.ValidatorSet) {
+
+	var (
+		headers     = make(map[int64]*types.SignedHeader, len(keys))
+		vals        = make(map[int64]*types.ValidatorSet, len(keys)+1)
+		lastBlockID types.BlockID
+	)
+	for i, k := range keys {
+		height := int64(i + 1)
+		vals[height] = k.ToValidators(10, 0)
+	}
+	vals[int64(len(keys)+1)] = keys[len(keys)-1].ToValidators(10, 0)
+
+	for i, k := range keys {
+		height := int64(i + 1)
+		header := k.genSignedHeader(chainID, height, bTime.Add(time.Duration(height)*time.Minute),
+			lastBlockID, vals[height], vals[height+1], 0, len(k))
+		headers[height] = header
+		lastBlockID = types.BlockID{Hash: header.Hash()}
+	}
+	delete(vals, int64(len(keys)+1))
+	return headers, vals
+}
+
+// sameKeys returns n times the keys, for a chain without validator changes.
+func sameKeys(keys privKeys, n int) []privKeys {
+	res := make([]privKeys, n)
+	for i := range res {
+		res[i] = keys
+	}
+	return res
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tendermint/tendermint/light/provider"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	"github.com/tendermint/tendermint/types"
+)
+
+// the error the RPC server returns for heights above its latest block
+const errHeightTooHigh = "must be less than or equal to the current blockchain height"
+
+// SignEvidenceClient is the part of the RPC client the provider needs: the
+// headers and validators to obtain, and the evidence to report.
+type SignEvidenceClient interface {
+	rpcclient.SignClient
+	rpcclient.EvidenceClient
+}
+
+// http provider uses an RPC client (or SignEvidenceClient more generally) to
+// obtain the necessary information.
+type http struct {
+	chainID string
+	client  SignEvidenceClient
+}
+
+// New creates a HTTP provider, which is using the rpcclient.HTTP client under
+// the hood.
+func New(chainID, remote string) provider.Provider {
+	return NewWithClient(chainID, rpcclient.NewHTTP(remote, "/websocket"))
+}
+
+// NewWithClient allows you to provide a custom SignEvidenceClient.
+func NewWithClient(chainID string, client SignEvidenceClient) provider.Provider {
+	return &http{
+		chainID: chainID,
+		client:  client,
+	}
+}
+
+// ChainID returns the chain ID this provider was configured with.
+func (p *http) ChainID() string {
+	return p.chainID
+}
+
+func (p *http) String() string {
+	return fmt.Sprintf("http{%s}", p.chainID)
+}
+
+// SignedHeader fetches a SignedHeader at the given height and checks the
+// chainID matches.
+func (p *http) SignedHeader(height int64) (*types.SignedHeader, error) {
+	h, err := validateHeight(height)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := p.client.Commit(h)
+	if err != nil {
+		if strings.Contains(err.Error(), errHeightTooHigh) {
+			return nil, provider.ErrSignedHeaderNotFound
+		}
+		return nil, err
+	}
+
+	if commit.Header == nil {
+		return nil, fmt.Errorf("header is nil")
+	}
+
+	// Verify we're still on the same chain.
+	if p.chainID != commit.Header.ChainID {
+		return nil, fmt.Errorf("expected chainID %s, got %s", p.chainID, commit.Header.ChainID)
+	}
+
+	return &commit.SignedHeader, nil
+}
+
+// ValidatorSet fetches a ValidatorSet at the given height.
+func (p *http) ValidatorSet(height int64) (*types.ValidatorSet, error) {
+	h, err := validateHeight(height)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.client.Validators(h)
+	if err != nil {
+		if strings.Contains(err.Error(), errHeightTooHigh) {
+			return nil, provider.ErrValidatorSetNotFound
+		}
+		return nil, err
+	}
+
+	return types.NewValidatorSet(res.Validators), nil
+}
+
+// ReportEvidence broadcasts the evidence to the full node.
+func (p *http) ReportEvidence(ev types.Evidence) error {
+	_, err := p.client.BroadcastEvidence(ev)
+	return err
+}
+
+func validateHeight(height int64) (*int64, error) {
+	if height < 0 {
+		return nil, fmt.Errorf("expected height >= 0, got height %d", height)
+	}
+
+	h := &height
+	if height == 0 {
+		h = nil
+	}
+	return h, nil
+}
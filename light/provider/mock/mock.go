@@ -0,0 +1,114 @@
+package mock
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/types"
+)
+
+type mock struct {
+	chainID  string
+	headers  map[int64]*types.SignedHeader
+	vals     map[int64]*types.ValidatorSet
+	evidence map[string]types.Evidence // hash => evidence
+}
+
+// New creates a mock provider with the given set of headers and validator
+// sets.
+func New(chainID string, headers map[int64]*types.SignedHeader, vals map[int64]*types.ValidatorSet) provider.Provider {
+	return &mock{
+		chainID:  chainID,
+		headers:  headers,
+		vals:     vals,
+		evidence: make(map[string]types.Evidence),
+	}
+}
+
+func (p *mock) ChainID() string {
+	return p.chainID
+}
+
+func (p *mock) String() string {
+	var headers strings.Builder
+	for _, h := range p.headers {
+		fmt.Fprintf(&headers, " %d:%X", h.Height, h.Hash())
+	}
+
+	var vals strings.Builder
+	for _, v := range p.vals {
+		fmt.Fprintf(&vals, " %X", v.Hash())
+	}
+
+	return fmt.Sprintf("mock{headers: %s, vals: %v}", headers.String(), vals.String())
+}
+
+func (p *mock) SignedHeader(height int64) (*types.SignedHeader, error) {
+	if height == 0 {
+		for h := range p.headers {
+			if h > height {
+				height = h
+			}
+		}
+	}
+	if _, ok := p.headers[height]; ok {
+		return p.headers[height], nil
+	}
+	return nil, provider.ErrSignedHeaderNotFound
+}
+
+func (p *mock) ValidatorSet(height int64) (*types.ValidatorSet, error) {
+	if height == 0 {
+		for h := range p.vals {
+			if h > height {
+				height = h
+			}
+		}
+	}
+	if _, ok := p.vals[height]; ok {
+		return p.vals[height], nil
+	}
+	return nil, provider.ErrValidatorSetNotFound
+}
+
+// ReportEvidence records the evidence, see HasEvidence.
+func (p *mock) ReportEvidence(ev types.Evidence) error {
+	p.evidence[string(ev.Hash())] = ev
+	return nil
+}
+
+// HasEvidence returns true if the evidence was reported to the provider.
+func (p *mock) HasEvidence(ev types.Evidence) bool {
+	_, ok := p.evidence[string(ev.Hash())]
+	return ok
+}
+
+type deadMock struct {
+	chainID string
+}
+
+// NewDeadMock creates a mock provider that always errors.
+func NewDeadMock(chainID string) provider.Provider {
+	return &deadMock{chainID: chainID}
+}
+
+func (p *deadMock) ChainID() string {
+	return p.chainID
+}
+
+func (p *deadMock) String() string {
+	return "deadMock"
+}
+
+func (p *deadMock) SignedHeader(height int64) (*types.SignedHeader, error) {
+	return nil, fmt.Errorf("dead mock provider")
+}
+
+func (p *deadMock) ValidatorSet(height int64) (*types.ValidatorSet, error) {
+	return nil, fmt.Errorf("dead mock provider")
+}
+
+func (p *deadMock) ReportEvidence(ev types.Evidence) error {
+	return fmt.Errorf("dead mock provider")
+}
@@ -0,0 +1,74 @@
+package proxy
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	amino "github.com/tendermint/go-amino"
+
+	"github.com/tendermint/tendermint/libs/log"
+	lrpc "github.com/tendermint/tendermint/light/rpc"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcserver "github.com/tendermint/tendermint/rpc/lib/server"
+)
+
+const (
+	wsEndpoint = "/websocket"
+)
+
+// Proxy is an RPC server, which serves the responses of a full node after
+// verifying them with the light client.
+type Proxy struct {
+	Addr     string // TCP address to listen on, like "tcp://127.0.0.1:8888"
+	Config   *rpcserver.Config
+	Client   *lrpc.Client
+	Logger   log.Logger
+	Listener net.Listener
+}
+
+// NewProxy returns a new proxy, which listens on addr and serves the verified
+// responses of c.
+func NewProxy(c *lrpc.Client, addr string, config *rpcserver.Config, logger log.Logger) *Proxy {
+	return &Proxy{
+		Addr:   addr,
+		Config: config,
+		Client: c,
+		Logger: logger,
+	}
+}
+
+// ListenAndServe starts the underlying client and listens on p.Addr for
+// requests, which it serves until the listener is closed.
+//
+// NOTE: This function blocks - you may want to call it in a go-routine.
+func (p *Proxy) ListenAndServe() error {
+	if err := p.Client.Start(); err != nil {
+		return err
+	}
+
+	cdc := amino.NewCodec()
+	ctypes.RegisterAmino(cdc)
+	r := RPCRoutes(p.Client)
+
+	// build the handler...
+	mux := http.NewServeMux()
+	rpcserver.RegisterRPCFuncs(mux, r, cdc, p.Logger)
+
+	unsubscribeFromAllEvents := func(remoteAddr string) {
+		if err := p.Client.UnsubscribeAll(context.Background(), remoteAddr); err != nil {
+			p.Logger.Error("Failed to unsubscribe from events", "err", err)
+		}
+	}
+	wm := rpcserver.NewWebsocketManager(r, cdc, rpcserver.OnDisconnect(unsubscribeFromAllEvents))
+	wm.SetLogger(p.Logger)
+	mux.HandleFunc(wsEndpoint, wm.WebsocketHandler)
+
+	listener, err := rpcserver.Listen(p.Addr, p.Config)
+	if err != nil {
+		return err
+	}
+	p.Listener = listener
+
+	return rpcserver.StartHTTPServer(listener, mux, p.Logger, p.Config)
+}
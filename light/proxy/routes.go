@@ -0,0 +1,208 @@
+package proxy
+
+import (
+	cmn "github.com/tendermint/tendermint/libs/common"
+	lrpc "github.com/tendermint/tendermint/light/rpc"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcserver "github.com/tendermint/tendermint/rpc/lib/server"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// RPCRoutes routes the requests to the light client RPC client, which
+// verifies the responses of the full node when possible.
+func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
+	return map[string]*rpcserver.RPCFunc{
+		// Subscribe/unsubscribe are reserved for websocket events.
+		"subscribe":       rpcserver.NewWSRPCFunc(c.SubscribeWS, "query"),
+		"unsubscribe":     rpcserver.NewWSRPCFunc(c.UnsubscribeWS, "query"),
+		"unsubscribe_all": rpcserver.NewWSRPCFunc(c.UnsubscribeAllWS, ""),
+
+		// info API
+		"status":           rpcserver.NewRPCFunc(makeStatusFunc(c), ""),
+		"blockchain":       rpcserver.NewRPCFunc(makeBlockchainInfoFunc(c), "minHeight,maxHeight"),
+		"genesis":          rpcserver.NewRPCFunc(makeGenesisFunc(c), ""),
+		"block":            rpcserver.NewRPCFunc(makeBlockFunc(c), "height"),
+		"block_results":    rpcserver.NewRPCFunc(makeBlockResultsFunc(c), "height"),
+		"commit":           rpcserver.NewRPCFunc(makeCommitFunc(c), "height"),
+		"tx":               rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove"),
+		"tx_search":        rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,page,per_page,order_by,cursor,skip_count"),
+		"validators":       rpcserver.NewRPCFunc(makeValidatorsFunc(c), "height"),
+		"consensus_params": rpcserver.NewRPCFunc(makeConsensusParamsFunc(c), "height"),
+
+		// broadcast API
+		"broadcast_tx_commit": rpcserver.NewRPCFunc(makeBroadcastTxCommitFunc(c), "tx"),
+		"broadcast_tx_sync":   rpcserver.NewRPCFunc(makeBroadcastTxSyncFunc(c), "tx"),
+		"broadcast_tx_async":  rpcserver.NewRPCFunc(makeBroadcastTxAsyncFunc(c), "tx"),
+		"broadcast_tx_batch":  rpcserver.NewRPCFunc(makeBroadcastTxBatchFunc(c), "txs"),
+
+		// abci API
+		"abci_query":       rpcserver.NewRPCFunc(makeABCIQueryFunc(c), "path,data,height,prove"),
+		"abci_query_batch": rpcserver.NewRPCFunc(makeABCIQueryBatchFunc(c), "queries"),
+		"abci_info":        rpcserver.NewRPCFunc(makeABCIInfoFunc(c), ""),
+
+		// evidence API
+		"broadcast_evidence": rpcserver.NewRPCFunc(makeBroadcastEvidenceFunc(c), "evidence"),
+	}
+}
+
+// The RPC server passes the request context as the first argument of the
+// route functions, so the methods of the client are wrapped below.
+
+type rpcStatusFunc func(ctx *rpctypes.Context) (*ctypes.ResultStatus, error)
+
+func makeStatusFunc(c *lrpc.Client) rpcStatusFunc {
+	return func(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
+		return c.Status()
+	}
+}
+
+type rpcBlockchainInfoFunc func(ctx *rpctypes.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error)
+
+func makeBlockchainInfoFunc(c *lrpc.Client) rpcBlockchainInfoFunc {
+	return func(ctx *rpctypes.Context, minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
+		return c.BlockchainInfo(minHeight, maxHeight)
+	}
+}
+
+type rpcGenesisFunc func(ctx *rpctypes.Context) (*ctypes.ResultGenesis, error)
+
+func makeGenesisFunc(c *lrpc.Client) rpcGenesisFunc {
+	return func(ctx *rpctypes.Context) (*ctypes.ResultGenesis, error) {
+		return c.Genesis()
+	}
+}
+
+type rpcBlockFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultBlock, error)
+
+func makeBlockFunc(c *lrpc.Client) rpcBlockFunc {
+	return func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultBlock, error) {
+		return c.Block(height)
+	}
+}
+
+type rpcBlockResultsFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultBlockResults, error)
+
+func makeBlockResultsFunc(c *lrpc.Client) rpcBlockResultsFunc {
+	return func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+		return c.BlockResults(height)
+	}
+}
+
+type rpcCommitFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultCommit, error)
+
+func makeCommitFunc(c *lrpc.Client) rpcCommitFunc {
+	return func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultCommit, error) {
+		return c.Commit(height)
+	}
+}
+
+type rpcTxFunc func(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error)
+
+func makeTxFunc(c *lrpc.Client) rpcTxFunc {
+	return func(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
+		return c.Tx(hash, prove)
+	}
+}
+
+type rpcTxSearchFunc func(ctx *rpctypes.Context, query string, prove bool,
+	page, perPage int, orderBy, cursor string, skipCount bool) (*ctypes.ResultTxSearch, error)
+
+func makeTxSearchFunc(c *lrpc.Client) rpcTxSearchFunc {
+	return func(ctx *rpctypes.Context, query string, prove bool,
+		page, perPage int, orderBy, cursor string, skipCount bool) (*ctypes.ResultTxSearch, error) {
+		return c.TxSearchWithOptions(query, prove, rpcclient.TxSearchOptions{
+			Page:      page,
+			PerPage:   perPage,
+			OrderBy:   orderBy,
+			Cursor:    cursor,
+			SkipCount: skipCount,
+		})
+	}
+}
+
+type rpcValidatorsFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultValidators, error)
+
+func makeValidatorsFunc(c *lrpc.Client) rpcValidatorsFunc {
+	return func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultValidators, error) {
+		return c.Validators(height)
+	}
+}
+
+type rpcConsensusParamsFunc func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultConsensusParams, error)
+
+func makeConsensusParamsFunc(c *lrpc.Client) rpcConsensusParamsFunc {
+	return func(ctx *rpctypes.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
+		return c.ConsensusParams(height)
+	}
+}
+
+type rpcBroadcastTxCommitFunc func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error)
+
+func makeBroadcastTxCommitFunc(c *lrpc.Client) rpcBroadcastTxCommitFunc {
+	return func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+		return c.BroadcastTxCommit(tx)
+	}
+}
+
+type rpcBroadcastTxSyncFunc func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error)
+
+func makeBroadcastTxSyncFunc(c *lrpc.Client) rpcBroadcastTxSyncFunc {
+	return func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+		return c.BroadcastTxSync(tx)
+	}
+}
+
+type rpcBroadcastTxAsyncFunc func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error)
+
+func makeBroadcastTxAsyncFunc(c *lrpc.Client) rpcBroadcastTxAsyncFunc {
+	return func(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+		return c.BroadcastTxAsync(tx)
+	}
+}
+
+type rpcBroadcastTxBatchFunc func(ctx *rpctypes.Context, txs []types.Tx) (*ctypes.ResultBroadcastTxBatch, error)
+
+func makeBroadcastTxBatchFunc(c *lrpc.Client) rpcBroadcastTxBatchFunc {
+	return func(ctx *rpctypes.Context, txs []types.Tx) (*ctypes.ResultBroadcastTxBatch, error) {
+		return c.BroadcastTxBatch(txs)
+	}
+}
+
+type rpcABCIQueryFunc func(ctx *rpctypes.Context, path string, data cmn.HexBytes,
+	height int64, prove bool) (*ctypes.ResultABCIQuery, error)
+
+// The proof of the value is always requested and verified against the trusted
+// header, whatever prove is.
+func makeABCIQueryFunc(c *lrpc.Client) rpcABCIQueryFunc {
+	return func(ctx *rpctypes.Context, path string, data cmn.HexBytes, height int64,
+		prove bool) (*ctypes.ResultABCIQuery, error) {
+		return c.ABCIQueryWithOptions(path, data, rpcclient.ABCIQueryOptions{Height: height, Prove: true})
+	}
+}
+
+type rpcABCIQueryBatchFunc func(ctx *rpctypes.Context,
+	queries []ctypes.RequestABCIQuery) (*ctypes.ResultABCIQueryBatch, error)
+
+func makeABCIQueryBatchFunc(c *lrpc.Client) rpcABCIQueryBatchFunc {
+	return func(ctx *rpctypes.Context, queries []ctypes.RequestABCIQuery) (*ctypes.ResultABCIQueryBatch, error) {
+		return c.ABCIQueryBatch(queries)
+	}
+}
+
+type rpcABCIInfoFunc func(ctx *rpctypes.Context) (*ctypes.ResultABCIInfo, error)
+
+func makeABCIInfoFunc(c *lrpc.Client) rpcABCIInfoFunc {
+	return func(ctx *rpctypes.Context) (*ctypes.ResultABCIInfo, error) {
+		return c.ABCIInfo()
+	}
+}
+
+type rpcBroadcastEvidenceFunc func(ctx *rpctypes.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error)
+
+func makeBroadcastEvidenceFunc(c *lrpc.Client) rpcBroadcastEvidenceFunc {
+	return func(ctx *rpctypes.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
+		return c.BroadcastEvidence(ev)
+	}
+}
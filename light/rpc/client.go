@@ -0,0 +1,456 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/light"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+var _ rpcclient.Client = (*Client)(nil)
+
+// KeyPathFunc builds the merkle key path of the value with the given key,
+// queried at the given path, so its proof can be verified.
+type KeyPathFunc func(path string, key []byte) (merkle.KeyPath, error)
+
+// Option sets a parameter for the light client RPC client.
+type Option func(*Client)
+
+// KeyPathFn sets the function used to build the key paths of the proofs
+// returned by /abci_query. The default one expects the path to be of the form
+// /store/<storeName>/key.
+func KeyPathFn(fn KeyPathFunc) Option {
+	return func(c *Client) {
+		c.keyPathFn = fn
+	}
+}
+
+// Client is an RPC client, which uses the light client to verify the
+// responses of the underlying client before passing them along. Responses
+// which can't be verified (e.g. /status) are passed along as is.
+type Client struct {
+	rpcclient.Client
+
+	lc        *light.Client
+	prt       *merkle.ProofRuntime
+	keyPathFn KeyPathFunc
+}
+
+// NewClient returns a new client, which verifies the responses of next with
+// the light client lc.
+func NewClient(next rpcclient.Client, lc *light.Client, options ...Option) *Client {
+	c := &Client{
+		Client:    next,
+		lc:        lc,
+		prt:       merkle.DefaultProofRuntime(),
+		keyPathFn: defaultKeyPathFn,
+	}
+	for _, o := range options {
+		o(c)
+	}
+	return c
+}
+
+// RegisterOpDecoder registers a decoder for the proof operators of type typ,
+// so the proofs using them can be verified.
+func (c *Client) RegisterOpDecoder(typ string, dec merkle.OpDecoder) {
+	c.prt.RegisterOpDecoder(typ, dec)
+}
+
+// ABCIQuery requests a proof of the value and verifies it, using the default
+// options.
+func (c *Client) ABCIQuery(path string, data cmn.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	return c.ABCIQueryWithOptions(path, data, rpcclient.DefaultABCIQueryOptions)
+}
+
+// ABCIQueryWithOptions requests a proof of the value (whatever opts.Prove is)
+// and verifies it against the AppHash of the next header.
+func (c *Client) ABCIQueryWithOptions(path string, data cmn.HexBytes,
+	opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+
+	opts.Prove = true
+	res, err := c.Client.ABCIQueryWithOptions(path, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verifyQuery(path, opts.Height, res.Response); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ABCIQueryBatch requests a proof of the value of each query (whatever its
+// Prove is) and verifies it against the AppHash of the next header. The
+// responses which can't be verified have an error instead.
+func (c *Client) ABCIQueryBatch(queries []ctypes.RequestABCIQuery) (*ctypes.ResultABCIQueryBatch, error) {
+	proven := make([]ctypes.RequestABCIQuery, len(queries))
+	for i, q := range queries {
+		q.Prove = true
+		proven[i] = q
+	}
+	res, err := c.Client.ABCIQueryBatch(proven)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Responses) != len(queries) {
+		return nil, fmt.Errorf("expected %d responses, got %d", len(queries), len(res.Responses))
+	}
+
+	for i, r := range res.Responses {
+		if r.Error != "" {
+			continue
+		}
+		if err := c.verifyQuery(queries[i].Path, queries[i].Height, r.Response); err != nil {
+			res.Responses[i] = ctypes.ResultBatchQuery{Error: err.Error()}
+		}
+	}
+	return res, nil
+}
+
+// verifyQuery verifies the proof of the response to the query at the given
+// path and height (0 for the latest) against the trusted header.
+func (c *Client) verifyQuery(path string, height int64, resp abci.ResponseQuery) error {
+	// Validate the response.
+	if resp.IsErr() {
+		return fmt.Errorf("err response code: %v", resp.Code)
+	}
+	if len(resp.Key) == 0 || resp.Proof == nil {
+		return errors.New("empty tree")
+	}
+	if resp.Height <= 0 {
+		return errors.New("negative or zero height")
+	}
+	if height > 0 && resp.Height != height {
+		return fmt.Errorf("expected height %d, got %d", height, resp.Height)
+	}
+
+	// The AppHash for height H is in the header H+1.
+	h, err := c.verifyHeaderAtHeight(resp.Height + 1)
+	if err != nil {
+		return err
+	}
+
+	if resp.Value != nil {
+		kp, err := c.keyPathFn(path, resp.Key)
+		if err != nil {
+			return fmt.Errorf("can't build merkle key path: %v", err)
+		}
+		if err := c.prt.VerifyValue(resp.Proof, h.AppHash, kp.String(), resp.Value); err != nil {
+			return fmt.Errorf("verify value proof: %v", err)
+		}
+		return nil
+	}
+
+	if err := c.prt.VerifyAbsence(resp.Proof, h.AppHash, string(resp.Key)); err != nil {
+		return fmt.Errorf("verify absence proof: %v", err)
+	}
+	return nil
+}
+
+// BlockchainInfo verifies the headers of all the returned block metas.
+// Rather expensive.
+func (c *Client) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
+	res, err := c.Client.BlockchainInfo(minHeight, maxHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, meta := range res.BlockMetas {
+		if meta == nil {
+			return nil, errors.New("nil block meta")
+		}
+		if err := meta.BlockID.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("invalid block meta: %v", err)
+		}
+		h, err := c.verifyHeaderAtHeight(meta.Header.Height)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateHeader(&meta.Header, h); err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(meta.BlockID.Hash, h.Hash()) {
+			return nil, fmt.Errorf("block meta id %X does not match the trusted header %X",
+				meta.BlockID.Hash, h.Hash())
+		}
+	}
+
+	return res, nil
+}
+
+// Block returns the block at the given height, after checking it matches
+// the trusted header.
+func (c *Client) Block(height *int64) (*ctypes.ResultBlock, error) {
+	res, err := c.Client.Block(height)
+	if err != nil {
+		return nil, err
+	}
+	if res.Block == nil || res.BlockMeta == nil {
+		return nil, errors.New("nil block or block meta")
+	}
+	if err := res.Block.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid block: %v", err)
+	}
+
+	h, err := c.verifyHeaderAtHeight(res.Block.Height)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateHeader(&res.Block.Header, h); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(res.BlockMeta.BlockID.Hash, h.Hash()) {
+		return nil, fmt.Errorf("block meta id %X does not match the trusted header %X",
+			res.BlockMeta.BlockID.Hash, h.Hash())
+	}
+	return res, nil
+}
+
+// BlockResults returns the results of the block at the given height, after
+// checking them against the LastResultsHash of the next trusted header.
+func (c *Client) BlockResults(height *int64) (*ctypes.ResultBlockResults, error) {
+	res, err := c.Client.BlockResults(height)
+	if err != nil {
+		return nil, err
+	}
+	if res.Height <= 0 {
+		return nil, errors.New("negative or zero height")
+	}
+	if res.Results == nil {
+		return nil, errors.New("nil results")
+	}
+
+	// The results of height H are committed in the header H+1.
+	h, err := c.verifyHeaderAtHeight(res.Height + 1)
+	if err != nil {
+		return nil, err
+	}
+	if rH := res.Results.ResultsHash(); !bytes.Equal(rH, h.LastResultsHash) {
+		return nil, fmt.Errorf("last results hash %X does not match the trusted header %X",
+			rH, h.LastResultsHash)
+	}
+	return res, nil
+}
+
+// Commit returns the trusted header at the given height, after verifying the
+// one of the underlying client with the light client.
+func (c *Client) Commit(height *int64) (*ctypes.ResultCommit, error) {
+	res, err := c.Client.Commit(height)
+	if err != nil {
+		return nil, err
+	}
+	if err := res.SignedHeader.ValidateBasic(c.lc.ChainID()); err != nil {
+		return nil, err
+	}
+
+	h, err := c.verifyHeaderAtHeight(res.Height)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(h.Hash(), res.Hash()) {
+		return nil, fmt.Errorf("header %X does not match the trusted header %X", res.Hash(), h.Hash())
+	}
+	return res, nil
+}
+
+// ConsensusParams returns the consensus params at the given height, after
+// checking them against the ConsensusHash of the trusted header.
+func (c *Client) ConsensusParams(height *int64) (*ctypes.ResultConsensusParams, error) {
+	res, err := c.Client.ConsensusParams(height)
+	if err != nil {
+		return nil, err
+	}
+	if err := res.ConsensusParams.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid consensus params: %v", err)
+	}
+	if res.BlockHeight <= 0 {
+		return nil, errors.New("negative or zero block height")
+	}
+
+	h, err := c.verifyHeaderAtHeight(res.BlockHeight)
+	if err != nil {
+		return nil, err
+	}
+	if cH := res.ConsensusParams.Hash(); !bytes.Equal(cH, h.ConsensusHash) {
+		return nil, fmt.Errorf("consensus params hash %X does not match the trusted header %X",
+			cH, h.ConsensusHash)
+	}
+	return res, nil
+}
+
+// Tx returns the transaction with the given hash. If prove is true, its proof
+// is verified against the DataHash of the trusted header.
+func (c *Client) Tx(hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	res, err := c.Client.Tx(hash, prove)
+	if err != nil || !prove {
+		return res, err
+	}
+	if res.Height <= 0 {
+		return nil, errors.New("negative or zero height")
+	}
+
+	h, err := c.verifyHeaderAtHeight(res.Height)
+	if err != nil {
+		return nil, err
+	}
+	if err := res.Proof.Validate(h.DataHash); err != nil {
+		return nil, fmt.Errorf("invalid tx proof: %v", err)
+	}
+	return res, nil
+}
+
+// TxSearch returns the transactions matching the query. If prove is true,
+// their proofs are verified against the DataHash of the trusted headers.
+func (c *Client) TxSearch(query string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error) {
+	return c.TxSearchWithOptions(query, prove, rpcclient.TxSearchOptions{Page: page, PerPage: perPage})
+}
+
+// TxSearchWithOptions is TxSearch with the options of the page.
+func (c *Client) TxSearchWithOptions(query string, prove bool,
+	opts rpcclient.TxSearchOptions) (*ctypes.ResultTxSearch, error) {
+	res, err := c.Client.TxSearchWithOptions(query, prove, opts)
+	if err != nil || !prove {
+		return res, err
+	}
+
+	for _, tx := range res.Txs {
+		h, err := c.verifyHeaderAtHeight(tx.Height)
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.Proof.Validate(h.DataHash); err != nil {
+			return nil, fmt.Errorf("invalid proof of tx %X: %v", tx.Hash, err)
+		}
+	}
+	return res, nil
+}
+
+// Validators returns the validators at the given height, after checking
+// them against the ValidatorsHash of the trusted header.
+func (c *Client) Validators(height *int64) (*ctypes.ResultValidators, error) {
+	res, err := c.Client.Validators(height)
+	if err != nil {
+		return nil, err
+	}
+	if res.BlockHeight <= 0 {
+		return nil, errors.New("negative or zero block height")
+	}
+
+	h, err := c.verifyHeaderAtHeight(res.BlockHeight)
+	if err != nil {
+		return nil, err
+	}
+	vals := types.NewValidatorSet(res.Validators)
+	if !bytes.Equal(vals.Hash(), h.ValidatorsHash) {
+		return nil, fmt.Errorf("validators hash %X does not match the trusted header %X",
+			vals.Hash(), h.ValidatorsHash)
+	}
+	return res, nil
+}
+
+// SubscribeWS subscribes for events using the given query and remote address
+// as a subscriber, but does not verify the events (UNSAFE)!
+func (c *Client) SubscribeWS(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+	out, err := c.Client.Subscribe(context.Background(), ctx.RemoteAddr(), query)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for {
+			select {
+			case resultEvent := <-out:
+				ctx.WSConn.TryWriteRPCResponse(
+					rpctypes.NewRPCSuccessResponse(
+						ctx.WSConn.Codec(),
+						rpctypes.JSONRPCStringID(fmt.Sprintf("%v#event", ctx.JSONReq.ID)),
+						resultEvent,
+					))
+			case <-c.Client.Quit():
+				return
+			}
+		}
+	}()
+
+	return &ctypes.ResultSubscribe{}, nil
+}
+
+// UnsubscribeWS calls the underlying client's Unsubscribe using the remote
+// address as a subscriber.
+func (c *Client) UnsubscribeWS(ctx *rpctypes.Context, query string) (*ctypes.ResultUnsubscribe, error) {
+	err := c.Client.Unsubscribe(context.Background(), ctx.RemoteAddr(), query)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultUnsubscribe{}, nil
+}
+
+// UnsubscribeAllWS calls the underlying client's UnsubscribeAll using the
+// remote address as a subscriber.
+func (c *Client) UnsubscribeAllWS(ctx *rpctypes.Context) (*ctypes.ResultUnsubscribe, error) {
+	err := c.Client.UnsubscribeAll(context.Background(), ctx.RemoteAddr())
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultUnsubscribe{}, nil
+}
+
+func (c *Client) verifyHeaderAtHeight(height int64) (*types.SignedHeader, error) {
+	h, err := c.lc.VerifyHeaderAtHeight(height, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify the header at height %d: %v", height, err)
+	}
+	return h, nil
+}
+
+func validateHeader(head *types.Header, trusted *types.SignedHeader) error {
+	if head.Height != trusted.Height {
+		return fmt.Errorf("expected header of height %d, got %d", trusted.Height, head.Height)
+	}
+	if !bytes.Equal(head.Hash(), trusted.Hash()) {
+		return fmt.Errorf("header %X does not match the trusted header %X", head.Hash(), trusted.Hash())
+	}
+	return nil
+}
+
+// defaultKeyPathFn builds the key path /<storeName>/<key> of the values
+// queried at /store/<storeName>/key.
+func defaultKeyPathFn(path string, key []byte) (merkle.KeyPath, error) {
+	storeName, err := parseQueryStorePath(path)
+	if err != nil {
+		return nil, err
+	}
+	kp := merkle.KeyPath{}
+	kp = kp.AppendKey([]byte(storeName), merkle.KeyEncodingURL)
+	kp = kp.AppendKey(key, merkle.KeyEncodingURL)
+	return kp, nil
+}
+
+func parseQueryStorePath(path string) (storeName string, err error) {
+	if !strings.HasPrefix(path, "/") {
+		return "", errors.New("expected path to start with /")
+	}
+
+	paths := strings.SplitN(path[1:], "/", 3)
+	switch {
+	case len(paths) != 3:
+		return "", errors.New("expected format like /store/<storeName>/key")
+	case paths[0] != "store":
+		return "", errors.New("expected format like /store/<storeName>/key")
+	case paths[2] != "key":
+		return "", errors.New("expected format like /store/<storeName>/key")
+	}
+
+	return paths[1], nil
+}
@@ -0,0 +1,208 @@
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/provider"
+	mockp "github.com/tendermint/tendermint/light/provider/mock"
+	dbs "github.com/tendermint/tendermint/light/store/db"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+const chainID = "light-rpc-chain"
+
+// fullNode serves the headers, validators and query responses it's given,
+// which may not match those of the light client's providers.
+type fullNode struct {
+	rpcclient.Client
+	headers map[int64]*types.SignedHeader
+	vals    map[int64]*types.ValidatorSet
+	query   abci.ResponseQuery
+}
+
+func (n *fullNode) Commit(height *int64) (*ctypes.ResultCommit, error) {
+	return ctypes.NewResultCommit(n.headers[*height].Header, n.headers[*height].Commit, true), nil
+}
+
+func (n *fullNode) Validators(height *int64) (*ctypes.ResultValidators, error) {
+	return &ctypes.ResultValidators{BlockHeight: *height, Validators: n.vals[*height].Validators}, nil
+}
+
+func (n *fullNode) ABCIQueryWithOptions(path string, data cmn.HexBytes,
+	opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	return &ctypes.ResultABCIQuery{Response: n.query}, nil
+}
+
+func (n *fullNode) ABCIQueryBatch(queries []ctypes.RequestABCIQuery) (*ctypes.ResultABCIQueryBatch, error) {
+	res := &ctypes.ResultABCIQueryBatch{}
+	for range queries {
+		res.Responses = append(res.Responses, ctypes.ResultBatchQuery{Response: n.query})
+	}
+	return res, nil
+}
+
+// genChain generates a chain of n headers signed by all the validators,
+// whose last header commits to the app hash.
+func genChain(t *testing.T, n int, appHash []byte) (map[int64]*types.SignedHeader, map[int64]*types.ValidatorSet) {
+	vals, privVals := types.RandValidatorSet(4, 10)
+	var (
+		headers     = make(map[int64]*types.SignedHeader, n)
+		valsMap     = make(map[int64]*types.ValidatorSet, n)
+		lastBlockID types.BlockID
+		bTime       = time.Now().Add(-time.Hour)
+	)
+	for height := int64(1); height <= int64(n); height++ {
+		header := &types.Header{
+			ChainID:            chainID,
+			Height:             height,
+			Time:               bTime.Add(time.Duration(height) * time.Minute),
+			LastBlockID:        lastBlockID,
+			ValidatorsHash:     vals.Hash(),
+			NextValidatorsHash: vals.Hash(),
+			AppHash:            appHash,
+		}
+		blockID := types.BlockID{Hash: header.Hash()}
+		voteSet := types.NewVoteSet(chainID, height, 0, types.PrecommitType, vals)
+		commit, err := types.MakeCommit(blockID, height, 0, voteSet, privVals)
+		require.NoError(t, err)
+
+		headers[height] = &types.SignedHeader{Header: header, Commit: commit}
+		valsMap[height] = vals
+		lastBlockID = blockID
+	}
+	return headers, valsMap
+}
+
+func newLightClient(t *testing.T, headers map[int64]*types.SignedHeader,
+	vals map[int64]*types.ValidatorSet) *light.Client {
+
+	lc, err := light.NewClient(
+		chainID,
+		light.TrustOptions{Period: 4 * time.Hour, Height: 1, Hash: headers[1].Hash()},
+		mockp.New(chainID, headers, vals),
+		[]provider.Provider{mockp.New(chainID, headers, vals)},
+		dbs.New(dbm.NewMemDB(), chainID),
+	)
+	require.NoError(t, err)
+	return lc
+}
+
+func TestClient_Commit(t *testing.T) {
+	headers, vals := genChain(t, 3, []byte("app_hash"))
+	node := &fullNode{headers: headers, vals: vals}
+	c := NewClient(node, newLightClient(t, headers, vals))
+
+	height := int64(3)
+	res, err := c.Commit(&height)
+	require.NoError(t, err)
+	assert.Equal(t, headers[3].Hash(), res.Hash())
+
+	// A full node serving another chain, signed by other validators
+	forged, forgedVals := genChain(t, 3, []byte("app_hash"))
+	node.headers = forged
+	node.vals = forgedVals
+	_, err = c.Commit(&height)
+	assert.Error(t, err)
+	_, err = c.Validators(&height)
+	assert.Error(t, err)
+
+	node.vals = vals
+	res2, err := c.Validators(&height)
+	require.NoError(t, err)
+	assert.Len(t, res2.Validators, 4)
+}
+
+func TestClient_ABCIQuery(t *testing.T) {
+	// The app hash commits to the store "main", which holds foo=bar
+	storeHash, storeProofs, _ := merkle.SimpleProofsFromMap(map[string][]byte{"foo": []byte("bar"), "baz": nil})
+	appHash, appProofs, _ := merkle.SimpleProofsFromMap(map[string][]byte{"main": storeHash, "other": nil})
+	proof := &merkle.Proof{Ops: []merkle.ProofOp{
+		merkle.NewSimpleValueOp([]byte("foo"), storeProofs["foo"]).ProofOp(),
+		merkle.NewSimpleValueOp([]byte("main"), appProofs["main"]).ProofOp(),
+	}}
+
+	// The app hash of height 2 is in the header 3
+	headers, vals := genChain(t, 3, appHash)
+	node := &fullNode{headers: headers, vals: vals}
+	c := NewClient(node, newLightClient(t, headers, vals))
+
+	node.query = abci.ResponseQuery{Key: []byte("foo"), Value: []byte("bar"), Proof: proof, Height: 2}
+	res, err := c.ABCIQuery("/store/main/key", []byte("foo"))
+	require.NoError(t, err)
+	assert.EqualValues(t, "bar", res.Response.Value)
+
+	// Only store paths are supported by the default key path function
+	_, err = c.ABCIQuery("/key", []byte("foo"))
+	assert.Error(t, err)
+
+	// The value doesn't match the proof
+	node.query.Value = []byte("qux")
+	_, err = c.ABCIQuery("/store/main/key", []byte("foo"))
+	assert.Error(t, err)
+
+	// No proof
+	node.query = abci.ResponseQuery{Key: []byte("foo"), Value: []byte("bar"), Height: 2}
+	_, err = c.ABCIQuery("/store/main/key", []byte("foo"))
+	assert.Error(t, err)
+
+	// The same proof, in the ICS-23 format
+	storeExist, err := merkle.SimpleExistenceProof([]byte("foo"), []byte("bar"), storeProofs["foo"])
+	require.NoError(t, err)
+	appExist, err := merkle.SimpleExistenceProof([]byte("main"), storeHash, appProofs["main"])
+	require.NoError(t, err)
+	node.query.Proof = &merkle.Proof{Ops: []merkle.ProofOp{
+		merkle.NewSimpleCommitmentOp([]byte("foo"),
+			&merkle.CommitmentProof{Proof: &merkle.CommitmentProof_Exist{Exist: storeExist}}).ProofOp(),
+		merkle.NewSimpleCommitmentOp([]byte("main"),
+			&merkle.CommitmentProof{Proof: &merkle.CommitmentProof_Exist{Exist: appExist}}).ProofOp(),
+	}}
+	res, err = c.ABCIQuery("/store/main/key", []byte("foo"))
+	require.NoError(t, err)
+	assert.EqualValues(t, "bar", res.Response.Value)
+}
+
+func TestClient_ABCIQueryBatch(t *testing.T) {
+	storeHash, storeProofs, _ := merkle.SimpleProofsFromMap(map[string][]byte{"foo": []byte("bar"), "baz": nil})
+	appHash, appProofs, _ := merkle.SimpleProofsFromMap(map[string][]byte{"main": storeHash, "other": nil})
+	proof := &merkle.Proof{Ops: []merkle.ProofOp{
+		merkle.NewSimpleValueOp([]byte("foo"), storeProofs["foo"]).ProofOp(),
+		merkle.NewSimpleValueOp([]byte("main"), appProofs["main"]).ProofOp(),
+	}}
+
+	headers, vals := genChain(t, 3, appHash)
+	node := &fullNode{headers: headers, vals: vals}
+	c := NewClient(node, newLightClient(t, headers, vals))
+	node.query = abci.ResponseQuery{Key: []byte("foo"), Value: []byte("bar"), Proof: proof, Height: 2}
+
+	res, err := c.ABCIQueryBatch([]ctypes.RequestABCIQuery{
+		{Path: "/store/main/key", Data: []byte("foo")},
+		// Only store paths are supported by the default key path function
+		{Path: "/key", Data: []byte("foo")},
+		// The response isn't at the requested height
+		{Path: "/store/main/key", Data: []byte("foo"), Height: 1},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Responses, 3)
+	assert.Empty(t, res.Responses[0].Error)
+	assert.EqualValues(t, "bar", res.Responses[0].Response.Value)
+	assert.NotEmpty(t, res.Responses[1].Error)
+	assert.Nil(t, res.Responses[1].Response.Value)
+	assert.NotEmpty(t, res.Responses[2].Error)
+
+	// The value doesn't match the proof
+	node.query.Value = []byte("qux")
+	res, err = c.ABCIQueryBatch([]ctypes.RequestABCIQuery{{Path: "/store/main/key", Data: []byte("foo")}})
+	require.NoError(t, err)
+	assert.NotEmpty(t, res.Responses[0].Error)
+}
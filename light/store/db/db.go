@@ -0,0 +1,273 @@
+package db
+
+import (
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+
+	amino "github.com/tendermint/go-amino"
+
+	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/light/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	signedHeaderStart = []byte("sh/")
+	signedHeaderEnd   = []byte("sh0") // the key after all the signed header keys
+
+	sizeKey = []byte("size")
+)
+
+type dbs struct {
+	db  dbm.DB
+	cdc *amino.Codec
+
+	mtx  sync.RWMutex
+	size int
+}
+
+// New returns a Store that wraps any DB (with an optional prefix in case you
+// want to use one DB with many light clients).
+func New(db dbm.DB, prefix string) store.Store {
+	cdc := amino.NewCodec()
+	cryptoAmino.RegisterAmino(cdc)
+
+	pdb := dbm.NewPrefixDB(db, []byte(prefix+"/"))
+	size := 0
+	if bz := pdb.Get(sizeKey); len(bz) == 8 {
+		size = int(binary.BigEndian.Uint64(bz))
+	}
+	return &dbs{db: pdb, cdc: cdc, size: size}
+}
+
+// SaveSignedHeaderAndValidatorSet persists SignedHeader and ValidatorSet to
+// the db.
+func (s *dbs) SaveSignedHeaderAndValidatorSet(sh *types.SignedHeader, valSet *types.ValidatorSet) error {
+	if sh.Height <= 0 {
+		panic("negative or zero height")
+	}
+
+	shBz, err := s.cdc.MarshalBinaryLengthPrefixed(sh)
+	if err != nil {
+		return fmt.Errorf("marshalling header: %v", err)
+	}
+
+	valSetBz, err := s.cdc.MarshalBinaryLengthPrefixed(valSet)
+	if err != nil {
+		return fmt.Errorf("marshalling validator set: %v", err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	size := s.size
+	if !s.db.Has(s.shKey(sh.Height)) {
+		size++
+	}
+
+	b := s.db.NewBatch()
+	defer b.Close()
+	b.Set(s.shKey(sh.Height), shBz)
+	b.Set(s.vsKey(sh.Height), valSetBz)
+	b.Set(sizeKey, marshalSize(size))
+	b.WriteSync()
+	s.size = size
+	return nil
+}
+
+// DeleteSignedHeaderAndValidatorSet deletes SignedHeader and ValidatorSet from
+// the db.
+func (s *dbs) DeleteSignedHeaderAndValidatorSet(height int64) error {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if !s.db.Has(s.shKey(height)) {
+		return nil
+	}
+
+	b := s.db.NewBatch()
+	defer b.Close()
+	b.Delete(s.shKey(height))
+	b.Delete(s.vsKey(height))
+	b.Set(sizeKey, marshalSize(s.size-1))
+	b.WriteSync()
+	s.size--
+	return nil
+}
+
+// SignedHeader loads SignedHeader at the given height.
+func (s *dbs) SignedHeader(height int64) (*types.SignedHeader, error) {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	bz := s.db.Get(s.shKey(height))
+	if bz == nil {
+		return nil, store.ErrSignedHeaderNotFound
+	}
+
+	var signedHeader *types.SignedHeader
+	err := s.cdc.UnmarshalBinaryLengthPrefixed(bz, &signedHeader)
+	return signedHeader, err
+}
+
+// ValidatorSet loads ValidatorSet at the given height.
+func (s *dbs) ValidatorSet(height int64) (*types.ValidatorSet, error) {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	bz := s.db.Get(s.vsKey(height))
+	if bz == nil {
+		return nil, store.ErrValidatorSetNotFound
+	}
+
+	var valSet *types.ValidatorSet
+	err := s.cdc.UnmarshalBinaryLengthPrefixed(bz, &valSet)
+	return valSet, err
+}
+
+// LastSignedHeaderHeight returns the last SignedHeader height stored.
+func (s *dbs) LastSignedHeaderHeight() (int64, error) {
+	itr := s.db.ReverseIterator(signedHeaderStart, signedHeaderEnd)
+	defer itr.Close()
+
+	for itr.Valid() {
+		key := itr.Key()
+		height, ok := parseShKey(key)
+		if ok {
+			return height, nil
+		}
+		itr.Next()
+	}
+
+	return -1, nil
+}
+
+// FirstSignedHeaderHeight returns the first SignedHeader height stored.
+func (s *dbs) FirstSignedHeaderHeight() (int64, error) {
+	itr := s.db.Iterator(signedHeaderStart, signedHeaderEnd)
+	defer itr.Close()
+
+	for itr.Valid() {
+		key := itr.Key()
+		height, ok := parseShKey(key)
+		if ok {
+			return height, nil
+		}
+		itr.Next()
+	}
+
+	return -1, nil
+}
+
+// SignedHeaderAfter loads the SignedHeader with the lowest height above the
+// given one.
+func (s *dbs) SignedHeaderAfter(height int64) (*types.SignedHeader, error) {
+	if height < 0 {
+		panic("negative height")
+	}
+
+	itr := s.db.Iterator(s.shKey(height+1), signedHeaderEnd)
+	defer itr.Close()
+
+	for itr.Valid() {
+		if _, ok := parseShKey(itr.Key()); ok {
+			var signedHeader *types.SignedHeader
+			err := s.cdc.UnmarshalBinaryLengthPrefixed(itr.Value(), &signedHeader)
+			return signedHeader, err
+		}
+		itr.Next()
+	}
+
+	return nil, store.ErrSignedHeaderNotFound
+}
+
+// Prune deletes the oldest SignedHeaders and ValidatorSets, so at most size
+// of them remain.
+func (s *dbs) Prune(size int) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	toPrune := s.size - size
+	if toPrune <= 0 {
+		return nil
+	}
+
+	// Collect the oldest heights first, the iterator must be closed before
+	// writing.
+	heights := make([]int64, 0, toPrune)
+	itr := s.db.Iterator(signedHeaderStart, signedHeaderEnd)
+	for itr.Valid() && len(heights) < toPrune {
+		if height, ok := parseShKey(itr.Key()); ok {
+			heights = append(heights, height)
+		}
+		itr.Next()
+	}
+	itr.Close()
+
+	b := s.db.NewBatch()
+	defer b.Close()
+	for _, height := range heights {
+		b.Delete(s.shKey(height))
+		b.Delete(s.vsKey(height))
+	}
+	b.Set(sizeKey, marshalSize(s.size-len(heights)))
+	b.WriteSync()
+	s.size -= len(heights)
+	return nil
+}
+
+// Size returns the number of stored SignedHeaders.
+func (s *dbs) Size() int {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.size
+}
+
+func (s *dbs) shKey(height int64) []byte {
+	return []byte(fmt.Sprintf("sh/%020d", height))
+}
+
+func (s *dbs) vsKey(height int64) []byte {
+	return []byte(fmt.Sprintf("vs/%020d", height))
+}
+
+var keyPattern = regexp.MustCompile(`^(sh|vs)/([0-9]+)$`)
+
+func parseKey(key []byte) (part string, height int64, ok bool) {
+	submatch := keyPattern.FindSubmatch(key)
+	if submatch == nil {
+		return "", 0, false
+	}
+	part = string(submatch[1])
+	height, err := strconv.ParseInt(string(submatch[2]), 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	ok = true // good!
+	return
+}
+
+func parseShKey(key []byte) (height int64, ok bool) {
+	var part string
+	part, height, ok = parseKey(key)
+	if part != "sh" {
+		return 0, false
+	}
+	return
+}
+
+func marshalSize(size int) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(size))
+	return bz
+}
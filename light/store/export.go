@@ -0,0 +1,96 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	amino "github.com/tendermint/go-amino"
+
+	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
+	"github.com/tendermint/tendermint/types"
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	cryptoAmino.RegisterAmino(cdc)
+}
+
+// maxEntrySize is the maximum size of an exported SignedHeader and its
+// ValidatorSet.
+const maxEntrySize = 10 * 1024 * 1024 // 10MB
+
+// entry is a SignedHeader and its ValidatorSet, as exported.
+type entry struct {
+	SignedHeader *types.SignedHeader `json:"signed_header"`
+	ValidatorSet *types.ValidatorSet `json:"validator_set"`
+}
+
+// Export writes all the SignedHeaders and ValidatorSets of s to w, from the
+// oldest to the newest, so they can be imported later (e.g. to restore a
+// backup).
+func Export(s Store, w io.Writer) error {
+	height, err := s.FirstSignedHeaderHeight()
+	if err != nil {
+		return err
+	}
+	if height == -1 {
+		return nil
+	}
+
+	for {
+		sh, err := s.SignedHeader(height)
+		if err != nil {
+			return fmt.Errorf("failed to get signed header #%d: %v", height, err)
+		}
+		vals, err := s.ValidatorSet(height)
+		if err != nil {
+			return fmt.Errorf("failed to get validator set #%d: %v", height, err)
+		}
+		if _, err := cdc.MarshalBinaryLengthPrefixedWriter(w, entry{sh, vals}); err != nil {
+			return fmt.Errorf("failed to write #%d: %v", height, err)
+		}
+
+		next, err := s.SignedHeaderAfter(height)
+		switch {
+		case err == ErrSignedHeaderNotFound:
+			return nil
+		case err != nil:
+			return err
+		}
+		height = next.Height
+	}
+}
+
+// Import reads the SignedHeaders and ValidatorSets written by Export from r,
+// and saves them to s. Each SignedHeader must be signed by +2/3 of its
+// ValidatorSet, but the headers aren't verified against each other, so only
+// import a trusted backup.
+func Import(s Store, r io.Reader) error {
+	for {
+		var e entry
+		_, err := cdc.UnmarshalBinaryLengthPrefixedReader(r, &e, maxEntrySize)
+		switch {
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return fmt.Errorf("failed to read: %v", err)
+		}
+
+		sh, vals := e.SignedHeader, e.ValidatorSet
+		if sh == nil || sh.Header == nil || sh.Commit == nil || vals == nil {
+			return fmt.Errorf("incomplete entry %v", e)
+		}
+		if !bytes.Equal(sh.ValidatorsHash, vals.Hash()) {
+			return fmt.Errorf("expected header #%d validators (%X) to match those that were supplied (%X)",
+				sh.Height, sh.ValidatorsHash, vals.Hash())
+		}
+		if err := vals.VerifyCommit(sh.ChainID, sh.Commit.BlockID, sh.Height, sh.Commit); err != nil {
+			return fmt.Errorf("invalid commit #%d: %v", sh.Height, err)
+		}
+		if err := s.SaveSignedHeaderAndValidatorSet(sh, vals); err != nil {
+			return fmt.Errorf("failed to save #%d: %v", sh.Height, err)
+		}
+	}
+}
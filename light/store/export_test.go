@@ -0,0 +1,70 @@
+package store_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/light/store"
+	"github.com/tendermint/tendermint/light/store/mem"
+	"github.com/tendermint/tendermint/types"
+)
+
+func newSignedHeader(t *testing.T, height int64, vals *types.ValidatorSet,
+	privVals []types.PrivValidator) *types.SignedHeader {
+
+	header := &types.Header{
+		ChainID:            "chain",
+		Height:             height,
+		Time:               time.Now(),
+		ValidatorsHash:     vals.Hash(),
+		NextValidatorsHash: vals.Hash(),
+	}
+	blockID := types.BlockID{Hash: header.Hash()}
+	voteSet := types.NewVoteSet("chain", height, 0, types.PrecommitType, vals)
+	commit, err := types.MakeCommit(blockID, height, 0, voteSet, privVals)
+	require.NoError(t, err)
+	return &types.SignedHeader{Header: header, Commit: commit}
+}
+
+func TestExportImport(t *testing.T) {
+	vals, privVals := types.RandValidatorSet(4, 10)
+	s := mem.New()
+	for _, h := range []int64{1, 5, 3} {
+		require.NoError(t, s.SaveSignedHeaderAndValidatorSet(newSignedHeader(t, h, vals, privVals), vals))
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, store.Export(s, &buf))
+	backup := buf.Bytes()
+
+	s2 := mem.New()
+	require.NoError(t, store.Import(s2, bytes.NewReader(backup)))
+	assert.Equal(t, 3, s2.Size())
+	for _, h := range []int64{1, 3, 5} {
+		sh, err := s.SignedHeader(h)
+		require.NoError(t, err)
+		sh2, err := s2.SignedHeader(h)
+		require.NoError(t, err)
+		assert.Equal(t, sh.Hash(), sh2.Hash())
+		vals2, err := s2.ValidatorSet(h)
+		require.NoError(t, err)
+		assert.Equal(t, vals.Hash(), vals2.Hash())
+	}
+
+	// An empty store exports nothing
+	buf.Reset()
+	require.NoError(t, store.Export(mem.New(), &buf))
+	assert.Zero(t, buf.Len())
+
+	// Headers which aren't signed by their validators aren't imported
+	otherVals, _ := types.RandValidatorSet(4, 10)
+	s3 := mem.New()
+	require.NoError(t, s3.SaveSignedHeaderAndValidatorSet(newSignedHeader(t, 1, vals, privVals), otherVals))
+	buf.Reset()
+	require.NoError(t, store.Export(s3, &buf))
+	assert.Error(t, store.Import(mem.New(), &buf))
+}
@@ -0,0 +1,158 @@
+package mem
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/tendermint/tendermint/light/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+type entry struct {
+	sh     *types.SignedHeader
+	valSet *types.ValidatorSet
+}
+
+type mem struct {
+	mtx     sync.RWMutex
+	entries map[int64]entry
+	heights []int64 // sorted, ascending
+}
+
+// New returns a Store that keeps the headers in memory, so they're lost when
+// the light client stops.
+func New() store.Store {
+	return &mem{entries: make(map[int64]entry)}
+}
+
+// SaveSignedHeaderAndValidatorSet keeps SignedHeader and ValidatorSet.
+func (s *mem) SaveSignedHeaderAndValidatorSet(sh *types.SignedHeader, valSet *types.ValidatorSet) error {
+	if sh.Height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.entries[sh.Height]; !ok {
+		i := sort.Search(len(s.heights), func(i int) bool { return s.heights[i] > sh.Height })
+		s.heights = append(s.heights, 0)
+		copy(s.heights[i+1:], s.heights[i:])
+		s.heights[i] = sh.Height
+	}
+	s.entries[sh.Height] = entry{sh, valSet}
+	return nil
+}
+
+// DeleteSignedHeaderAndValidatorSet deletes SignedHeader and ValidatorSet.
+func (s *mem) DeleteSignedHeaderAndValidatorSet(height int64) error {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.entries[height]; !ok {
+		return nil
+	}
+	delete(s.entries, height)
+	i := sort.Search(len(s.heights), func(i int) bool { return s.heights[i] >= height })
+	s.heights = append(s.heights[:i], s.heights[i+1:]...)
+	return nil
+}
+
+// SignedHeader returns SignedHeader at the given height.
+func (s *mem) SignedHeader(height int64) (*types.SignedHeader, error) {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	e, ok := s.entries[height]
+	if !ok {
+		return nil, store.ErrSignedHeaderNotFound
+	}
+	return e.sh, nil
+}
+
+// ValidatorSet returns ValidatorSet at the given height.
+func (s *mem) ValidatorSet(height int64) (*types.ValidatorSet, error) {
+	if height <= 0 {
+		panic("negative or zero height")
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	e, ok := s.entries[height]
+	if !ok {
+		return nil, store.ErrValidatorSetNotFound
+	}
+	return e.valSet, nil
+}
+
+// LastSignedHeaderHeight returns the last SignedHeader height kept.
+func (s *mem) LastSignedHeaderHeight() (int64, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if len(s.heights) == 0 {
+		return -1, nil
+	}
+	return s.heights[len(s.heights)-1], nil
+}
+
+// FirstSignedHeaderHeight returns the first SignedHeader height kept.
+func (s *mem) FirstSignedHeaderHeight() (int64, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if len(s.heights) == 0 {
+		return -1, nil
+	}
+	return s.heights[0], nil
+}
+
+// SignedHeaderAfter returns the SignedHeader with the lowest height above the
+// given one.
+func (s *mem) SignedHeaderAfter(height int64) (*types.SignedHeader, error) {
+	if height < 0 {
+		panic("negative height")
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	i := sort.Search(len(s.heights), func(i int) bool { return s.heights[i] > height })
+	if i == len(s.heights) {
+		return nil, store.ErrSignedHeaderNotFound
+	}
+	return s.entries[s.heights[i]].sh, nil
+}
+
+// Prune deletes the oldest SignedHeaders and ValidatorSets, so at most size
+// of them remain.
+func (s *mem) Prune(size int) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	toPrune := len(s.heights) - size
+	if toPrune <= 0 {
+		return nil
+	}
+	for _, height := range s.heights[:toPrune] {
+		delete(s.entries, height)
+	}
+	s.heights = append([]int64(nil), s.heights[toPrune:]...)
+	return nil
+}
+
+// Size returns the number of SignedHeaders kept.
+func (s *mem) Size() int {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return len(s.heights)
+}
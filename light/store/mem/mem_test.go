@@ -0,0 +1,74 @@
+package mem
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/light/store"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestStore(t *testing.T) {
+	s := New()
+
+	// Empty store
+	height, err := s.LastSignedHeaderHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, -1, height)
+	height, err = s.FirstSignedHeaderHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, -1, height)
+
+	_, err = s.SignedHeader(1)
+	assert.Equal(t, store.ErrSignedHeaderNotFound, err)
+	_, err = s.ValidatorSet(1)
+	assert.Equal(t, store.ErrValidatorSetNotFound, err)
+
+	// Save some headers
+	vals, _ := types.RandValidatorSet(2, 10)
+	for _, h := range []int64{1, 10, 2} {
+		sh := &types.SignedHeader{Header: &types.Header{ChainID: "chain", Height: h}, Commit: &types.Commit{}}
+		require.NoError(t, s.SaveSignedHeaderAndValidatorSet(sh, vals))
+	}
+
+	height, err = s.LastSignedHeaderHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, height)
+	height, err = s.FirstSignedHeaderHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, height)
+
+	sh, err := s.SignedHeader(2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, sh.Height)
+	valSet, err := s.ValidatorSet(2)
+	require.NoError(t, err)
+	assert.Equal(t, vals.Hash(), valSet.Hash())
+
+	assert.Equal(t, 3, s.Size())
+	sh, err = s.SignedHeaderAfter(2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, sh.Height)
+	_, err = s.SignedHeaderAfter(10)
+	assert.Equal(t, store.ErrSignedHeaderNotFound, err)
+
+	// Delete them
+	require.NoError(t, s.DeleteSignedHeaderAndValidatorSet(10))
+	assert.Equal(t, 2, s.Size())
+	height, err = s.LastSignedHeaderHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, height)
+	_, err = s.SignedHeader(10)
+	assert.Equal(t, store.ErrSignedHeaderNotFound, err)
+	_, err = s.ValidatorSet(10)
+	assert.Equal(t, store.ErrValidatorSetNotFound, err)
+
+	// Prune the oldest
+	require.NoError(t, s.Prune(1))
+	assert.Equal(t, 1, s.Size())
+	height, err = s.FirstSignedHeaderHeight()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, height)
+}
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"errors"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	// ErrSignedHeaderNotFound is returned when a store does not have the
+	// requested header.
+	ErrSignedHeaderNotFound = errors.New("signed header not found")
+	// ErrValidatorSetNotFound is returned when a store does not have the
+	// requested validator set.
+	ErrValidatorSetNotFound = errors.New("validator set not found")
+)
+
+// Store is anything that can store the trusted headers, persistently (see the
+// db package) or not (see the mem package).
+type Store interface {
+	// SaveSignedHeaderAndValidatorSet saves a SignedHeader (h: sh.Height) and a
+	// ValidatorSet (h: sh.Height).
+	//
+	// height must be > 0.
+	SaveSignedHeaderAndValidatorSet(sh *types.SignedHeader, valSet *types.ValidatorSet) error
+
+	// DeleteSignedHeaderAndValidatorSet deletes SignedHeader (h: height) and
+	// ValidatorSet (h: height).
+	//
+	// height must be > 0.
+	DeleteSignedHeaderAndValidatorSet(height int64) error
+
+	// SignedHeader returns the SignedHeader that corresponds to the given
+	// height.
+	//
+	// height must be > 0.
+	//
+	// If SignedHeader is not found, ErrSignedHeaderNotFound is returned.
+	SignedHeader(height int64) (*types.SignedHeader, error)
+
+	// ValidatorSet returns the ValidatorSet that corresponds to height.
+	//
+	// height must be > 0.
+	//
+	// If ValidatorSet is not found, ErrValidatorSetNotFound is returned.
+	ValidatorSet(height int64) (*types.ValidatorSet, error)
+
+	// LastSignedHeaderHeight returns the last (newest) SignedHeader height.
+	//
+	// If the store is empty, -1 and nil error are returned.
+	LastSignedHeaderHeight() (int64, error)
+
+	// FirstSignedHeaderHeight returns the first (oldest) SignedHeader height.
+	//
+	// If the store is empty, -1 and nil error are returned.
+	FirstSignedHeaderHeight() (int64, error)
+
+	// SignedHeaderAfter returns the SignedHeader with the lowest height above
+	// the given one.
+	//
+	// height must be >= 0.
+	//
+	// If there's no such SignedHeader, ErrSignedHeaderNotFound is returned.
+	SignedHeaderAfter(height int64) (*types.SignedHeader, error)
+
+	// Prune removes the oldest SignedHeaders (and their ValidatorSets), so
+	// at most size of them remain.
+	Prune(size int) error
+
+	// Size returns the number of stored SignedHeaders.
+	Size() int
+}
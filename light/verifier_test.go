@@ -0,0 +1,258 @@
+package light
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	chainID       = "light-chain"
+	trustPeriod   = 4 * time.Hour
+	maxClockDrift = 10 * time.Second
+)
+
+var bTime = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestVerifyAdjacentHeaders(t *testing.T) {
+	var (
+		keys       = genPrivKeys(4)
+		vals       = keys.ToValidators(20, 10)
+		header     = keys.genSignedHeader(chainID, 1, bTime, types.BlockID{}, vals, vals, 0, len(keys))
+		otherKeys  = genPrivKeys(4)
+		otherVals  = otherKeys.ToValidators(10, 0)
+		now        = bTime.Add(time.Hour)
+		lastBlock  = types.BlockID{Hash: header.Hash()}
+		nextHeight = int64(2)
+	)
+
+	testCases := []struct {
+		newHeader      *types.SignedHeader
+		newVals        *types.ValidatorSet
+		trustingPeriod time.Duration
+		now            time.Time
+		expErr         error
+		expErrText     string
+	}{
+		// same height -> error
+		0: {
+			header,
+			vals,
+			trustPeriod,
+			now,
+			nil,
+			"headers must be adjacent in height",
+		},
+		// different chainID -> error
+		1: {
+			keys.genSignedHeader("different-chainID", nextHeight, bTime.Add(time.Minute), lastBlock,
+				vals, vals, 0, len(keys)),
+			vals,
+			trustPeriod,
+			now,
+			nil,
+			"untrustedHeader.ValidateBasic failed",
+		},
+		// new header's time is before old header's time -> error
+		2: {
+			keys.genSignedHeader(chainID, nextHeight, bTime.Add(-time.Minute), lastBlock, vals, vals, 0, len(keys)),
+			vals,
+			trustPeriod,
+			now,
+			nil,
+			"to be after old header time",
+		},
+		// new header's time is from the future -> error
+		3: {
+			keys.genSignedHeader(chainID, nextHeight, now.Add(time.Minute), lastBlock, vals, vals, 0, len(keys)),
+			vals,
+			trustPeriod,
+			now,
+			nil,
+			"new header has a time from the future",
+		},
+		// 3/3 signed -> no error
+		4: {
+			keys.genSignedHeader(chainID, nextHeight, bTime.Add(time.Minute), lastBlock, vals, vals, 0, len(keys)),
+			vals,
+			trustPeriod,
+			now,
+			nil,
+			"",
+		},
+		// 2/3 signed -> no error
+		5: {
+			keys.genSignedHeader(chainID, nextHeight, bTime.Add(time.Minute), lastBlock, vals, vals, 1, len(keys)),
+			vals,
+			trustPeriod,
+			now,
+			nil,
+			"",
+		},
+		// less than 2/3 signed -> error
+		6: {
+			keys.genSignedHeader(chainID, nextHeight, bTime.Add(time.Minute), lastBlock, vals, vals, len(keys)-1, len(keys)),
+			vals,
+			trustPeriod,
+			now,
+			ErrInvalidHeader{},
+			"",
+		},
+		// vals does not match with what we have -> error
+		7: {
+			otherKeys.genSignedHeader(chainID, nextHeight, bTime.Add(time.Minute), lastBlock,
+				otherVals, otherVals, 0, len(otherKeys)),
+			otherVals,
+			trustPeriod,
+			now,
+			nil,
+			"to match those from new header",
+		},
+		// vals are inconsistent with newHeader -> error
+		8: {
+			keys.genSignedHeader(chainID, nextHeight, bTime.Add(time.Minute), lastBlock, vals, vals, 0, len(keys)),
+			otherVals,
+			trustPeriod,
+			now,
+			nil,
+			"to match those that were supplied",
+		},
+		// old header has expired -> error
+		9: {
+			keys.genSignedHeader(chainID, nextHeight, bTime.Add(time.Minute), lastBlock, vals, vals, 0, len(keys)),
+			vals,
+			time.Minute,
+			now,
+			ErrOldHeaderExpired{},
+			"",
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			err := VerifyAdjacent(chainID, header, tc.newHeader, tc.newVals, tc.trustingPeriod, tc.now, maxClockDrift)
+			switch {
+			case tc.expErr != nil:
+				assert.IsType(t, tc.expErr, err)
+			case tc.expErrText != "":
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expErrText)
+				}
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyNonAdjacentHeaders(t *testing.T) {
+	var (
+		keys   = genPrivKeys(4)
+		vals   = keys.ToValidators(20, 10)
+		header = keys.genSignedHeader(chainID, 1, bTime, types.BlockID{}, vals, vals, 0, len(keys))
+		now    = bTime.Add(time.Hour)
+
+		// 3 of the 4 old validators are replaced, keeping less than 1/3 of
+		// the old voting power: 20/140
+		lessThanOneThirdKeys = append(privKeys{keys[0]}, genPrivKeys(3)...)
+		lessThanOneThirdVals = lessThanOneThirdKeys.ToValidators(20, 10)
+
+		// 2 of the 4 old validators are replaced, keeping more than 1/3 of
+		// the old voting power: 90/140
+		moreThanOneThirdKeys = append(privKeys{keys[2], keys[3]}, genPrivKeys(2)...)
+		moreThanOneThirdVals = moreThanOneThirdKeys.ToValidators(20, 10)
+	)
+
+	testCases := []struct {
+		newHeader *types.SignedHeader
+		newVals   *types.ValidatorSet
+		expErr    error
+	}{
+		// same validators, 3/3 signed -> no error
+		0: {
+			keys.genSignedHeader(chainID, 3, bTime.Add(time.Minute), types.BlockID{}, vals, vals, 0, len(keys)),
+			vals,
+			nil,
+		},
+		// same validators, less than 2/3 signed -> error
+		1: {
+			keys.genSignedHeader(chainID, 3, bTime.Add(time.Minute), types.BlockID{}, vals, vals, 3, len(keys)),
+			vals,
+			ErrInvalidHeader{},
+		},
+		// more than 1/3 of the old validators remain -> no error
+		2: {
+			moreThanOneThirdKeys.genSignedHeader(chainID, 3, bTime.Add(time.Minute), types.BlockID{},
+				moreThanOneThirdVals, moreThanOneThirdVals, 0, len(moreThanOneThirdKeys)),
+			moreThanOneThirdVals,
+			nil,
+		},
+		// less than 1/3 of the old validators remain -> can't be trusted
+		3: {
+			lessThanOneThirdKeys.genSignedHeader(chainID, 3, bTime.Add(time.Minute), types.BlockID{},
+				lessThanOneThirdVals, lessThanOneThirdVals, 0, len(lessThanOneThirdKeys)),
+			lessThanOneThirdVals,
+			ErrNewValSetCantBeTrusted{},
+		},
+		// adjacent header -> error
+		4: {
+			keys.genSignedHeader(chainID, 2, bTime.Add(time.Minute), types.BlockID{}, vals, vals, 0, len(keys)),
+			vals,
+			fmt.Errorf(""),
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			err := VerifyNonAdjacent(chainID, header, vals, tc.newHeader, tc.newVals, trustPeriod, now, maxClockDrift,
+				DefaultTrustLevel)
+			if tc.expErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.IsType(t, tc.expErr, err)
+			}
+		})
+	}
+
+	// The old header has expired -> error
+	newHeader := keys.genSignedHeader(chainID, 3, bTime.Add(time.Minute), types.BlockID{}, vals, vals, 0, len(keys))
+	err := Verify(chainID, header, vals, newHeader, vals, time.Minute, now, maxClockDrift, DefaultTrustLevel)
+	assert.IsType(t, ErrOldHeaderExpired{}, err)
+}
+
+func TestValidateTrustLevel(t *testing.T) {
+	testCases := []struct {
+		lvl   cmn.Fraction
+		valid bool
+	}{
+		// valid
+		0: {cmn.Fraction{Numerator: 1, Denominator: 1}, true},
+		1: {cmn.Fraction{Numerator: 1, Denominator: 3}, true},
+		2: {cmn.Fraction{Numerator: 2, Denominator: 3}, true},
+		3: {cmn.Fraction{Numerator: 3, Denominator: 3}, true},
+		4: {cmn.Fraction{Numerator: 9, Denominator: 10}, true},
+
+		// invalid
+		5: {cmn.Fraction{Numerator: 6, Denominator: 5}, false},
+		6: {cmn.Fraction{Numerator: 0, Denominator: 1}, false},
+		7: {cmn.Fraction{Numerator: 0, Denominator: 0}, false},
+		8: {cmn.Fraction{Numerator: 1, Denominator: 0}, false},
+		9: {cmn.Fraction{Numerator: 1, Denominator: 4}, false},
+	}
+
+	for _, tc := range testCases {
+		err := ValidateTrustLevel(tc.lvl)
+		if !tc.valid {
+			assert.Error(t, err, tc.lvl)
+		} else {
+			assert.NoError(t, err, tc.lvl)
+		}
+	}
+}
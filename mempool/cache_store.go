@@ -0,0 +1,94 @@
+package mempool
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+var committedTxsCountKey = []byte("committedTxsCount")
+
+func committedTxKey(n int64) []byte {
+	return []byte(fmt.Sprintf("committedTx:%v", n))
+}
+
+// committedTxsStore persists the hashes of the last size committed txs, so
+// they can be put back in the cache after a restart.
+//
+// The n-th committed tx is stored under committedTxKey(n), and the older
+// hashes are deleted as new ones are saved.
+type committedTxsStore struct {
+	db    dbm.DB
+	size  int64
+	count int64 // number of hashes ever saved
+}
+
+// newCommittedTxsStore returns the store of the last size committed txs in db.
+func newCommittedTxsStore(db dbm.DB, size int) *committedTxsStore {
+	store := &committedTxsStore{db: db, size: int64(size)}
+	if bz := db.Get(committedTxsCountKey); len(bz) == 8 {
+		store.count = int64(binary.BigEndian.Uint64(bz))
+	}
+	return store
+}
+
+// Hashes returns the stored hashes, from the oldest.
+func (store *committedTxsStore) Hashes() [][sha256.Size]byte {
+	var hashes [][sha256.Size]byte
+	for n := store.first(); n < store.count; n++ {
+		bz := store.db.Get(committedTxKey(n))
+		if len(bz) != sha256.Size {
+			continue
+		}
+		var hash [sha256.Size]byte
+		copy(hash[:], bz)
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+// Save saves the hashes of the committed txs, deleting the oldest hashes.
+func (store *committedTxsStore) Save(hashes [][sha256.Size]byte) {
+	if len(hashes) == 0 {
+		return
+	}
+	batch := store.db.NewBatch()
+	defer batch.Close()
+	for _, hash := range hashes {
+		hash := hash
+		batch.Set(committedTxKey(store.count), hash[:])
+		if old := store.count - store.size; old >= 0 {
+			batch.Delete(committedTxKey(old))
+		}
+		store.count++
+	}
+	batch.Set(committedTxsCountKey, store.countBytes())
+	batch.Write()
+}
+
+// Reset deletes the stored hashes.
+func (store *committedTxsStore) Reset() {
+	batch := store.db.NewBatch()
+	defer batch.Close()
+	for n := store.first(); n < store.count; n++ {
+		batch.Delete(committedTxKey(n))
+	}
+	store.count = 0
+	batch.Set(committedTxsCountKey, store.countBytes())
+	batch.Write()
+}
+
+func (store *committedTxsStore) first() int64 {
+	if store.count > store.size {
+		return store.count - store.size
+	}
+	return 0
+}
+
+func (store *committedTxsStore) countBytes() []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(store.count))
+	return bz
+}
@@ -2,9 +2,11 @@ package mempool
 
 import (
 	"bytes"
+	"container/heap"
 	"container/list"
 	"crypto/sha256"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -13,9 +15,9 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
-	auto "github.com/tendermint/tendermint/libs/autofile"
 	"github.com/tendermint/tendermint/libs/clist"
 	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/proxy"
 	"github.com/tendermint/tendermint/types"
@@ -35,7 +37,18 @@ type PostCheckFunc func(types.Tx, *abci.ResponseCheckTx) error
 
 The mempool pushes new txs onto the proxyAppConn.
 It gets a stream of (req, res) tuples from the proxy.
-The mempool stores good txs in a concurrent linked-list.
+The mempool stores good txs in a concurrent linked-list, in the order they
+arrived, which is the order they're gossiped in. The txs are reaped from the
+highest priority (ResponseCheckTx.Priority), and a full mempool evicts the txs
+with the lowest priority to make room for a tx with a higher priority. The txs
+of a sender (ResponseCheckTx.Sender) are reaped in the order they arrived, and
+the oldest txs of a sender are evicted once it has too many txs. A tx evicted
+to make room is evicted along with the later txs of its sender, so that there's
+no gap in the txs of a sender. The txs which
+aren't committed within MempoolConfig.TTLNumBlocks blocks or TTLDuration are
+evicted too. An EventTxEvicted is published for each evicted tx (and each tx
+which fails to be rechecked), and the last evicted txs are remembered, see
+Mempool.EvictedTx.
 
 Multiple concurrent go-routines can traverse this linked-list
 safely by calling .NextWait() on each element.
@@ -59,6 +72,17 @@ TODO: Better handle abci client errors. (make it automatically handle connection
 
 */
 
+// The reasons of the evictions of txs, see types.EventDataTxEvicted.
+const (
+	evictedByTTL         = "expired"
+	evictedByPriority    = "priority"
+	evictedBySenderLimit = "sender_limit"
+	evictedByRecheck     = "recheck_failed"
+)
+
+// evictedCacheSize is the number of evicted txs the mempool remembers.
+const evictedCacheSize = 1000
+
 var (
 	// ErrTxInCache is returned to the client if we saw tx earlier
 	ErrTxInCache = errors.New("Tx already exists in cache")
@@ -67,7 +91,19 @@ var (
 	ErrTxTooLarge = fmt.Errorf("Tx too large. Max size is %d", maxTxSize)
 )
 
-// ErrMempoolIsFull means Tendermint & an application can't handle that much load
+// ErrSenderTxTooLarge means the tx is bigger than all the txs of its sender
+// (ResponseCheckTx.Sender) can be, see MempoolConfig.MaxTxsBytesPerSender.
+type ErrSenderTxTooLarge struct {
+	Sender      string
+	MaxTxsBytes int64
+}
+
+func (e ErrSenderTxTooLarge) Error() string {
+	return fmt.Sprintf("Tx too large. Max size of the txs of sender %s is %d", e.Sender, e.MaxTxsBytes)
+}
+
+// ErrMempoolIsFull means Tendermint & an application can't handle that much
+// load: the mempool has no tx with a lower priority to evict.
 type ErrMempoolIsFull struct {
 	numTxs int
 	maxTxs int
@@ -150,15 +186,20 @@ type Mempool struct {
 
 	proxyMtx             sync.Mutex
 	proxyAppConn         proxy.AppConnMempool
-	txs                  *clist.CList    // concurrent linked-list of good txs
-	height               int64           // the last block Update()'d to
-	rechecking           int32           // for re-checking filtered txs on Update()
-	recheckCursor        *clist.CElement // next expected response
-	recheckEnd           *clist.CElement // re-checking stops here
+	txs                  *clist.CList // concurrent linked-list of good txs
+	txsMap               sync.Map     // sha256(tx) ([sha256.Size]byte) -> *clist.CElement
+	evicted              *evictedTxCache
+	sendersMtx           sync.Mutex
+	senders              map[string]*senderTxs // sender -> txs of the sender
+	height               int64                 // the last block Update()'d to, see Height
+	rechecking           int32                 // for re-checking filtered txs on Update()
+	recheckCursor        *clist.CElement       // next expected response
+	recheckEnd           *clist.CElement       // re-checking stops here
 	notifiedTxsAvailable bool
 	txsAvailable         chan struct{} // fires once for each height, when the mempool is not empty
 	preCheck             PreCheckFunc
 	postCheck            PostCheckFunc
+	eventBus             types.TxEvictedEventPublisher
 
 	// Atomic integers
 	txsBytes int64 // see TxsBytes
@@ -166,9 +207,11 @@ type Mempool struct {
 	// Keep a cache of already-seen txs.
 	// This reduces the pressure on the proxyApp.
 	cache txCache
+	// The committed txs of the cache, persisted if PersistCache is set.
+	committedTxs *committedTxsStore
 
-	// A log of mempool txs
-	wal *auto.AutoFile
+	// A log of mempool txs, see InitWAL
+	wal *mempoolWAL
 
 	logger log.Logger
 
@@ -189,19 +232,24 @@ func NewMempool(
 		config:        config,
 		proxyAppConn:  proxyAppConn,
 		txs:           clist.New(),
+		senders:       make(map[string]*senderTxs),
+		evicted:       newEvictedTxCache(evictedCacheSize),
 		height:        height,
 		rechecking:    0,
 		recheckCursor: nil,
 		recheckEnd:    nil,
+		eventBus:      types.NopEventBus{},
 		logger:        log.NewNopLogger(),
 		metrics:       NopMetrics(),
 	}
 	if config.CacheSize > 0 {
-		mempool.cache = newMapTxCache(config.CacheSize)
+		cache := newMapTxCache(config.CacheSize)
+		cache.fifo = config.CacheEvictionPolicy == cfg.MempoolCacheFIFO
+		mempool.cache = cache
 	} else {
 		mempool.cache = nopTxCache{}
 	}
-	proxyAppConn.SetResponseCallback(mempool.resCb)
+	proxyAppConn.SetResponseCallback(mempool.globalCb)
 	for _, option := range options {
 		option(mempool)
 	}
@@ -220,6 +268,12 @@ func (mem *Mempool) SetLogger(l log.Logger) {
 	mem.logger = l
 }
 
+// SetEventBus sets the event bus the evicted txs are published on. If not
+// called, it defaults to types.NopEventBus.
+func (mem *Mempool) SetEventBus(eventBus types.TxEvictedEventPublisher) {
+	mem.eventBus = eventBus
+}
+
 // WithPreCheck sets a filter for the mempool to reject a tx if f(tx) returns
 // false. This is ran before CheckTx.
 func WithPreCheck(f PreCheckFunc) MempoolOption {
@@ -237,21 +291,69 @@ func WithMetrics(metrics *Metrics) MempoolOption {
 	return func(mem *Mempool) { mem.metrics = metrics }
 }
 
-// InitWAL creates a directory for the WAL file and opens a file itself.
+// WithCacheDB persists the hashes of the last committed txs of the cache in
+// db, and puts the hashes saved before the restart back in the cache.
+func WithCacheDB(db dbm.DB) MempoolOption {
+	return func(mem *Mempool) {
+		cache, ok := mem.cache.(*mapTxCache)
+		if !ok {
+			return // no cache
+		}
+		mem.committedTxs = newCommittedTxsStore(db, mem.config.CacheSize)
+		for _, hash := range mem.committedTxs.Hashes() {
+			cache.pushHash(hash)
+		}
+	}
+}
+
+// InitWAL creates a directory for the WAL and opens its journal, see
+// ReplayWAL to add the txs of the WAL back to the mempool.
 //
 // *panics* if can't create directory or open file.
 // *not thread safe*
 func (mem *Mempool) InitWAL() {
-	walDir := mem.config.WalDir()
-	err := cmn.EnsureDir(walDir, 0700)
+	wal, err := openWAL(mem.config.WalDir())
 	if err != nil {
-		panic(errors.Wrap(err, "Error ensuring Mempool WAL dir"))
+		panic(err)
+	}
+	mem.wal = wal
+}
+
+// ReplayWAL runs CheckTx again for the txs of the WAL, e.g. after a restart,
+// so the txs still valid are added back to the mempool, then compacts the
+// WAL into a snapshot of the mempool. The txs of a corrupted end of the WAL
+// are lost, which is logged but isn't an error.
+func (mem *Mempool) ReplayWAL() error {
+	if mem.wal == nil {
+		return nil
 	}
-	af, err := auto.OpenAutoFile(walDir + "/wal")
+	txs, err := mem.wal.Load()
 	if err != nil {
-		panic(errors.Wrap(err, "Error opening Mempool WAL file"))
+		mem.logger.Error("Error loading the mempool WAL", "err", err)
+	}
+	for _, tx := range txs {
+		if err := mem.CheckTx(tx, nil); err != nil {
+			mem.logger.Debug("Replayed tx rejected", "tx", TxID(tx), "err", err)
+		}
+	}
+	if err := mem.FlushAppConn(); err != nil {
+		return err
+	}
+	mem.logger.Info("Replayed the mempool WAL", "txs", len(txs), "added", mem.Size())
+
+	mem.proxyMtx.Lock()
+	defer mem.proxyMtx.Unlock()
+	return mem.snapshotWAL()
+}
+
+// snapshotWAL writes the txs of the mempool to the WAL snapshot, which
+// truncates the journal.
+func (mem *Mempool) snapshotWAL() error {
+	txs := make([]types.Tx, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		txs = append(txs, e.Value.(*mempoolTx).tx)
 	}
-	mem.wal = af
+	return mem.wal.Snapshot(txs)
 }
 
 // CloseWAL closes and discards the underlying WAL file.
@@ -281,6 +383,11 @@ func (mem *Mempool) Size() int {
 	return mem.txs.Len()
 }
 
+// Height returns the height of the last block the mempool was updated to.
+func (mem *Mempool) Height() int64 {
+	return atomic.LoadInt64(&mem.height)
+}
+
 // TxsBytes returns the total size of all txs in the mempool.
 func (mem *Mempool) TxsBytes() int64 {
 	return atomic.LoadInt64(&mem.txsBytes)
@@ -298,10 +405,12 @@ func (mem *Mempool) Flush() {
 	defer mem.proxyMtx.Unlock()
 
 	mem.cache.Reset()
+	if mem.committedTxs != nil {
+		mem.committedTxs.Reset()
+	}
 
 	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		mem.txs.Remove(e)
-		e.DetachPrev()
+		mem.removeTx(e, false)
 	}
 
 	_ = atomic.SwapInt64(&mem.txsBytes, 0)
@@ -325,22 +434,42 @@ func (mem *Mempool) TxsWaitChan() <-chan struct{} {
 // It blocks if we're waiting on Update() or Reap().
 // cb: A callback from the CheckTx command.
 //     It gets called from another goroutine.
+//     ResponseCheckTx.MempoolError is set if the tx is valid, but the mempool
+//     rejects it (e.g. ErrMempoolIsFull).
 // CONTRACT: Either cb will get called, or err returned.
 func (mem *Mempool) CheckTx(tx types.Tx, cb func(*abci.Response)) (err error) {
 	mem.proxyMtx.Lock()
 	// use defer to unlock mutex because application (*local client*) might panic
 	defer mem.proxyMtx.Unlock()
 
-	var (
-		memSize  = mem.Size()
-		txsBytes = mem.TxsBytes()
-	)
-	if memSize >= mem.config.Size ||
-		int64(len(tx))+txsBytes > mem.config.MaxTxsBytes {
-		return ErrMempoolIsFull{
-			memSize, mem.config.Size,
-			txsBytes, mem.config.MaxTxsBytes}
+	return mem.checkTx(tx, cb)
+}
+
+// CheckTxs is CheckTx for a batch of txs, which are all sent to the
+// application while holding the lock once (i.e. no block is reaped or
+// committed in between). It returns the error of each tx, and cb is called
+// with the index of the tx for each tx without error.
+func (mem *Mempool) CheckTxs(txs []types.Tx, cb func(int, *abci.Response)) []error {
+	mem.proxyMtx.Lock()
+	// use defer to unlock mutex because application (*local client*) might panic
+	defer mem.proxyMtx.Unlock()
+
+	errs := make([]error, len(txs))
+	for i, tx := range txs {
+		i := i
+		errs[i] = mem.checkTx(tx, func(res *abci.Response) {
+			if cb != nil {
+				cb(i, res)
+			}
+		})
 	}
+	return errs
+}
+
+// checkTx is CheckTx, the lock being held.
+func (mem *Mempool) checkTx(tx types.Tx, cb func(*abci.Response)) (err error) {
+	// NOTE: a full mempool still checks the tx, as its priority may be high
+	// enough to evict other txs.
 
 	// The size of the corresponding amino-encoded TxMessage
 	// can't be larger than the maxMsgSize, otherwise we can't
@@ -357,51 +486,63 @@ func (mem *Mempool) CheckTx(tx types.Tx, cb func(*abci.Response)) (err error) {
 
 	// CACHE
 	if !mem.cache.Push(tx) {
+		mem.metrics.CacheHits.Add(1)
 		return ErrTxInCache
 	}
+	mem.metrics.CacheMisses.Add(1)
 	// END CACHE
 
-	// WAL
-	if mem.wal != nil {
-		// TODO: Notify administrators when WAL fails
-		_, err := mem.wal.Write([]byte(tx))
-		if err != nil {
-			mem.logger.Error("Error writing to WAL", "err", err)
-		}
-		_, err = mem.wal.Write([]byte("\n"))
-		if err != nil {
-			mem.logger.Error("Error writing to WAL", "err", err)
-		}
-	}
-	// END WAL
-
 	// NOTE: proxyAppConn may error if tx buffer is full
 	if err = mem.proxyAppConn.Error(); err != nil {
+		// e.g. the connection to the app is lost, the tx can be sent again
+		mem.cache.Remove(tx)
 		return err
 	}
 	reqRes := mem.proxyAppConn.CheckTxAsync(tx)
-	if cb != nil {
-		reqRes.SetCallback(cb)
-	}
+	reqRes.SetCallback(mem.reqResCb(tx, cb))
 
 	return nil
 }
 
-// ABCI callback function
-func (mem *Mempool) resCb(req *abci.Request, res *abci.Response) {
+// Global callback, which is called for all the responses of the app. Only the
+// responses of the rechecked txs are handled here, see reqResCb.
+func (mem *Mempool) globalCb(req *abci.Request, res *abci.Response) {
 	if mem.recheckCursor == nil {
-		mem.resCbNormal(req, res)
-	} else {
-		mem.metrics.RecheckTimes.Add(1)
-		mem.resCbRecheck(req, res)
+		return
 	}
+	mem.metrics.RecheckTimes.Add(1)
+	mem.resCbRecheck(req, res)
 	mem.metrics.Size.Set(float64(mem.Size()))
 }
 
-func (mem *Mempool) resCbNormal(req *abci.Request, res *abci.Response) {
+// reqResCb returns the callback of the CheckTx request of a new tx, which
+// adds the tx to the mempool before calling the callback of CheckTx.
+func (mem *Mempool) reqResCb(tx types.Tx, externalCb func(*abci.Response)) func(*abci.Response) {
+	return func(res *abci.Response) {
+		if res == nil {
+			// The request failed without a response (e.g. the connection to
+			// the app was lost), the tx can be sent again.
+			mem.cache.Remove(tx)
+			if externalCb != nil {
+				externalCb(abci.ToResponseCheckTx(abci.ResponseCheckTx{MempoolError: "the app didn't respond to CheckTx"}))
+			}
+			return
+		}
+		if mem.recheckCursor != nil {
+			// the new txs are checked after the rechecked txs
+			panic("recheck cursor is not nil in reqResCb")
+		}
+		mem.resCbFirstTime(tx, res)
+		mem.metrics.Size.Set(float64(mem.Size()))
+		if externalCb != nil {
+			externalCb(res)
+		}
+	}
+}
+
+func (mem *Mempool) resCbFirstTime(tx types.Tx, res *abci.Response) {
 	switch r := res.Value.(type) {
 	case *abci.Response_CheckTx:
-		tx := req.GetCheckTx().Tx
 		var postCheckErr error
 		if mem.postCheck != nil {
 			postCheckErr = mem.postCheck(tx, r.CheckTx)
@@ -410,10 +551,25 @@ func (mem *Mempool) resCbNormal(req *abci.Request, res *abci.Response) {
 			memTx := &mempoolTx{
 				height:    mem.height,
 				gasWanted: r.CheckTx.GasWanted,
+				priority:  r.CheckTx.Priority,
+				sender:    r.CheckTx.Sender,
+				timestamp: time.Now(),
 				tx:        tx,
 			}
-			mem.txs.PushBack(memTx)
-			atomic.AddInt64(&mem.txsBytes, int64(len(tx)))
+			if err := mem.addTx(memTx); err != nil {
+				mem.logger.Info("Rejected good transaction", "tx", TxID(tx), "res", r, "err", err)
+				r.CheckTx.MempoolError = err.Error()
+				mem.metrics.RejectedTxs.Add(1)
+				// remove from cache (it might be good later)
+				mem.cache.Remove(tx)
+				return
+			}
+			if mem.wal != nil {
+				// TODO: Notify administrators when WAL fails
+				if err := mem.wal.Write(tx); err != nil {
+					mem.logger.Error("Error writing to WAL", "err", err)
+				}
+			}
 			mem.logger.Info("Added good transaction",
 				"tx", TxID(tx),
 				"res", r,
@@ -454,12 +610,9 @@ func (mem *Mempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 		} else {
 			// Tx became invalidated due to newly committed block.
 			mem.logger.Info("Tx is no longer valid", "tx", TxID(tx), "res", r, "err", postCheckErr)
-			mem.txs.Remove(mem.recheckCursor)
-			atomic.AddInt64(&mem.txsBytes, int64(-len(tx)))
-			mem.recheckCursor.DetachPrev()
-
 			// remove from cache (it might be good later)
-			mem.cache.Remove(tx)
+			mem.removeTx(mem.recheckCursor, true)
+			mem.publishEviction(tx, evictedByRecheck)
 		}
 		if mem.recheckCursor == mem.recheckEnd {
 			mem.recheckCursor = nil
@@ -481,6 +634,260 @@ func (mem *Mempool) resCbRecheck(req *abci.Request, res *abci.Response) {
 	}
 }
 
+// addTx adds the tx to the mempool, after evicting the oldest txs of its
+// sender if it has too many txs, and the txs with a lower priority if the
+// mempool is full. It fails if it can't make enough room.
+func (mem *Mempool) addTx(memTx *mempoolTx) error {
+	senderEvicted, err := mem.senderEvictions(memTx)
+	if err != nil {
+		return err
+	}
+	evicted, err := mem.makeRoom(memTx, senderEvicted)
+	if err != nil {
+		return err
+	}
+	// remove from cache (it might be good later)
+	for _, e := range senderEvicted {
+		mem.evictTx(e, evictedBySenderLimit, true)
+	}
+	for _, e := range evicted {
+		mem.evictTx(e, evictedByPriority, true)
+	}
+
+	e := mem.txs.PushBack(memTx)
+	mem.txsMap.Store(sha256.Sum256(memTx.tx), e)
+	mem.evicted.Remove(memTx.tx)
+	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.tx)))
+	if memTx.sender != "" {
+		mem.sendersMtx.Lock()
+		st, ok := mem.senders[memTx.sender]
+		if !ok {
+			st = &senderTxs{}
+			mem.senders[memTx.sender] = st
+		}
+		st.elements = append(st.elements, e)
+		st.bytes += int64(len(memTx.tx))
+		mem.sendersMtx.Unlock()
+	}
+	return nil
+}
+
+// senderEvictions returns the oldest txs of the sender of memTx to evict, so
+// that the sender doesn't exceed MaxTxsPerSender and MaxTxsBytesPerSender
+// with memTx. It returns ErrSenderTxTooLarge if memTx alone exceeds the
+// latter.
+func (mem *Mempool) senderEvictions(memTx *mempoolTx) ([]*clist.CElement, error) {
+	if memTx.sender == "" {
+		return nil, nil
+	}
+	var (
+		maxTxs      = mem.config.MaxTxsPerSender
+		maxTxsBytes = mem.config.MaxTxsBytesPerSender
+		txSize      = int64(len(memTx.tx))
+	)
+	if maxTxsBytes > 0 && txSize > maxTxsBytes {
+		return nil, ErrSenderTxTooLarge{memTx.sender, maxTxsBytes}
+	}
+
+	mem.sendersMtx.Lock()
+	defer mem.sendersMtx.Unlock()
+	st, ok := mem.senders[memTx.sender]
+	if !ok {
+		return nil, nil
+	}
+	var (
+		numTxs   = len(st.elements)
+		txsBytes = st.bytes
+	)
+	n := 0
+	for ; n < len(st.elements); n++ {
+		if (maxTxs <= 0 || numTxs < maxTxs) && (maxTxsBytes <= 0 || txsBytes+txSize <= maxTxsBytes) {
+			break
+		}
+		numTxs--
+		txsBytes -= int64(len(st.elements[n].Value.(*mempoolTx).tx))
+	}
+	return append([]*clist.CElement(nil), st.elements[:n]...), nil
+}
+
+// makeRoom returns the txs to evict so that memTx fits in the mempool, once
+// the given txs are evicted: the txs with a lower priority than memTx, from
+// the lowest priority and the most recent. A tx is evicted along with the
+// later txs of its sender, so that the sender's txs have no gap, and only if
+// they have a lower priority than memTx too. It returns ErrMempoolIsFull if
+// there's not enough of them.
+func (mem *Mempool) makeRoom(memTx *mempoolTx, evicted []*clist.CElement) ([]*clist.CElement, error) {
+	var (
+		numTxs   = mem.Size() - len(evicted)
+		txsBytes = mem.TxsBytes()
+		txSize   = int64(len(memTx.tx))
+	)
+	isEvicted := make(map[*clist.CElement]bool, len(evicted))
+	for _, e := range evicted {
+		isEvicted[e] = true
+		txsBytes -= int64(len(e.Value.(*mempoolTx).tx))
+	}
+	isFull := func() bool {
+		return numTxs >= mem.config.Size || txsBytes+txSize > mem.config.MaxTxsBytes
+	}
+	if !isFull() {
+		return nil, nil
+	}
+
+	var evictable []*clist.CElement
+	for e := mem.txs.Back(); e != nil; e = e.Prev() {
+		tx := e.Value.(*mempoolTx)
+		// evicting an earlier tx of its sender would leave memTx after a gap
+		if !isEvicted[e] && tx.priority < memTx.priority &&
+			(tx.sender == "" || tx.sender != memTx.sender) {
+			evictable = append(evictable, e)
+		}
+	}
+	sort.SliceStable(evictable, func(i, j int) bool {
+		return evictable[i].Value.(*mempoolTx).priority < evictable[j].Value.(*mempoolTx).priority
+	})
+
+	mem.sendersMtx.Lock()
+	defer mem.sendersMtx.Unlock()
+	var toEvict []*clist.CElement
+	for _, e := range evictable {
+		if !isFull() {
+			break
+		}
+		if isEvicted[e] {
+			continue
+		}
+		group, ok := mem.senderLaterTxs(e, memTx.priority, isEvicted)
+		if !ok {
+			continue
+		}
+		for _, ge := range group {
+			isEvicted[ge] = true
+			numTxs--
+			txsBytes -= int64(len(ge.Value.(*mempoolTx).tx))
+		}
+		toEvict = append(toEvict, group...)
+	}
+	if isFull() {
+		return nil, ErrMempoolIsFull{
+			mem.Size(), mem.config.Size,
+			mem.TxsBytes(), mem.config.MaxTxsBytes}
+	}
+	return toEvict, nil
+}
+
+// senderLaterTxs returns the element and the later txs of its sender which
+// aren't evicted yet, from the most recent. It returns false if one of them
+// doesn't have a lower priority than the given one. sendersMtx must be held.
+func (mem *Mempool) senderLaterTxs(e *clist.CElement, priority int64,
+	isEvicted map[*clist.CElement]bool) ([]*clist.CElement, bool) {
+	st, ok := mem.senders[e.Value.(*mempoolTx).sender]
+	if !ok {
+		return []*clist.CElement{e}, true
+	}
+	var group []*clist.CElement
+	for i := len(st.elements) - 1; i >= 0; i-- {
+		se := st.elements[i]
+		if !isEvicted[se] {
+			if se.Value.(*mempoolTx).priority >= priority {
+				return nil, false
+			}
+			group = append(group, se)
+		}
+		if se == e {
+			return group, true
+		}
+	}
+	return []*clist.CElement{e}, true
+}
+
+// evictTx removes the tx of the element before it's committed, and
+// publishes its eviction, see types.EventDataTxEvicted.
+func (mem *Mempool) evictTx(e *clist.CElement, reason string, removeFromCache bool) {
+	memTx := e.Value.(*mempoolTx)
+	mem.logger.Info("Evicted transaction",
+		"tx", TxID(memTx.tx),
+		"priority", memTx.priority,
+		"sender", memTx.sender,
+		"reason", reason,
+	)
+	mem.removeTx(e, removeFromCache)
+	mem.metrics.EvictedTxs.Add(1)
+	mem.publishEviction(memTx.tx, reason)
+}
+
+// publishEviction remembers the reason why the tx was removed before being
+// committed, and publishes it.
+func (mem *Mempool) publishEviction(tx types.Tx, reason string) {
+	mem.evicted.Push(tx, &EvictedTx{Reason: reason, Height: mem.height})
+	err := mem.eventBus.PublishEventTxEvicted(types.EventDataTxEvicted{Tx: tx, Reason: reason})
+	if err != nil {
+		mem.logger.Error("Error publishing the evicted tx", "tx", TxID(tx), "err", err)
+	}
+}
+
+// removeTx removes the tx of the element from the mempool, and from the cache
+// if removeFromCache.
+func (mem *Mempool) removeTx(e *clist.CElement, removeFromCache bool) {
+	memTx := e.Value.(*mempoolTx)
+	mem.txs.Remove(e)
+	e.DetachPrev()
+	mem.txsMap.Delete(sha256.Sum256(memTx.tx))
+	atomic.AddInt64(&mem.txsBytes, int64(-len(memTx.tx)))
+	if memTx.sender != "" {
+		mem.sendersMtx.Lock()
+		if st, ok := mem.senders[memTx.sender]; ok {
+			for i, se := range st.elements {
+				if se == e {
+					st.elements = append(st.elements[:i], st.elements[i+1:]...)
+					st.bytes -= int64(len(memTx.tx))
+					break
+				}
+			}
+			if len(st.elements) == 0 {
+				delete(mem.senders, memTx.sender)
+			}
+		}
+		mem.sendersMtx.Unlock()
+	}
+	if removeFromCache {
+		mem.cache.Remove(memTx.tx)
+	}
+}
+
+// HasTx returns true if the tx with the given hash is in the mempool.
+func (mem *Mempool) HasTx(hash []byte) bool {
+	var key [sha256.Size]byte
+	if len(hash) != len(key) {
+		return false
+	}
+	copy(key[:], hash)
+	_, ok := mem.txsMap.Load(key)
+	return ok
+}
+
+// GetTx returns the tx with the given hash, or nil if it's not in the
+// mempool.
+func (mem *Mempool) GetTx(hash []byte) types.Tx {
+	var key [sha256.Size]byte
+	if len(hash) != len(key) {
+		return nil
+	}
+	copy(key[:], hash)
+	e, ok := mem.txsMap.Load(key)
+	if !ok {
+		return nil
+	}
+	return e.(*clist.CElement).Value.(*mempoolTx).tx
+}
+
+// EvictedTx returns why the tx with the given hash was evicted from the
+// mempool (or failed to be rechecked), or nil if it's not one of the last
+// evicted txs.
+func (mem *Mempool) EvictedTx(hash []byte) *EvictedTx {
+	return mem.evicted.Get(hash)
+}
+
 // TxsAvailable returns a channel which fires once for every height,
 // and only when transactions are available in the mempool.
 // NOTE: the returned channel may be nil if EnableTxsAvailable was not called.
@@ -502,10 +909,56 @@ func (mem *Mempool) notifyTxsAvailable() {
 	}
 }
 
-// ReapMaxBytesMaxGas reaps transactions from the mempool up to maxBytes bytes total
-// with the condition that the total gasWanted must be less than maxGas.
+// byPriority returns the txs of the mempool from the highest priority (per
+// unit of gas wanted if perGas), and in the order they arrived for the same
+// priority. The txs of a sender stay in the order they arrived (e.g. by
+// nonce) whatever their priorities: a tx comes after the earlier txs of its
+// sender, so there's no gap in the sequence of txs of a sender.
+func (mem *Mempool) byPriority(perGas bool) []*mempoolTx {
+	var (
+		queues   = txQueues{perGas: perGas}
+		bySender = make(map[string]*txQueue)
+	)
+	seq := 0
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if q, ok := bySender[memTx.sender]; ok {
+			q.txs = append(q.txs, memTx)
+			q.seqs = append(q.seqs, seq)
+		} else {
+			q = &txQueue{txs: []*mempoolTx{memTx}, seqs: []int{seq}}
+			queues.queues = append(queues.queues, q)
+			if memTx.sender != "" {
+				bySender[memTx.sender] = q
+			}
+		}
+		seq++
+	}
+
+	memTxs := make([]*mempoolTx, 0, seq)
+	heap.Init(&queues)
+	for queues.Len() > 0 {
+		q := queues.queues[0]
+		memTxs = append(memTxs, q.txs[0])
+		q.txs, q.seqs = q.txs[1:], q.seqs[1:]
+		if len(q.txs) == 0 {
+			heap.Pop(&queues)
+		} else {
+			heap.Fix(&queues, 0)
+		}
+	}
+	return memTxs
+}
+
+// ReapMaxBytesMaxGas reaps transactions from the mempool, from the highest
+// priority, up to maxBytes bytes total with the condition that the total
+// gasWanted must be less than maxGas.
 // If both maxes are negative, there is no cap on the size of all returned
 // transactions (~ all available transactions).
+//
+// With a max gas, the txs are reaped from the highest priority per unit of
+// gas wanted, and the txs which don't fit in the remaining bytes or gas are
+// skipped in favor of the next ones, with the later txs of their sender.
 func (mem *Mempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	mem.proxyMtx.Lock()
 	defer mem.proxyMtx.Unlock()
@@ -517,33 +970,57 @@ func (mem *Mempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 
 	var totalBytes int64
 	var totalGas int64
+	skippedSenders := make(map[string]bool)
 	// TODO: we will get a performance boost if we have a good estimate of avg
 	// size per tx, and set the initial capacity based off of that.
 	// txs := make([]types.Tx, 0, cmn.MinInt(mem.txs.Len(), max/mem.avgTxSize))
 	txs := make([]types.Tx, 0, mem.txs.Len())
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
-		// Check total size requirement
-		aminoOverhead := types.ComputeAminoOverhead(memTx.tx, 1)
-		if maxBytes > -1 && totalBytes+int64(len(memTx.tx))+aminoOverhead > maxBytes {
-			return txs
+	for _, memTx := range mem.byPriority(maxGas > -1) {
+		if memTx.sender != "" && skippedSenders[memTx.sender] {
+			continue
 		}
-		totalBytes += int64(len(memTx.tx)) + aminoOverhead
+		// Check total size requirement
+		txBytes := int64(len(memTx.tx)) + types.ComputeAminoOverhead(memTx.tx, 1)
+		fits := maxBytes < 0 || totalBytes+txBytes <= maxBytes
 		// Check total gas requirement.
 		// If maxGas is negative, skip this check.
 		// Since newTotalGas < masGas, which
 		// must be non-negative, it follows that this won't overflow.
 		newTotalGas := totalGas + memTx.gasWanted
-		if maxGas > -1 && newTotalGas > maxGas {
-			return txs
+		fits = fits && (maxGas < 0 || newTotalGas <= maxGas)
+		if !fits {
+			if maxGas < 0 {
+				// by priority only, the next txs must wait
+				return txs
+			}
+			if memTx.sender != "" {
+				skippedSenders[memTx.sender] = true
+			}
+			continue
 		}
+		totalBytes += txBytes
 		totalGas = newTotalGas
 		txs = append(txs, memTx.tx)
 	}
 	return txs
 }
 
-// ReapMaxTxs reaps up to max transactions from the mempool.
+// GasWanted returns the total gas wanted by the txs in the mempool, as
+// returned by CheckTx, and the number of txs which aren't in the mempool.
+func (mem *Mempool) GasWanted(txs types.Txs) (gasWanted int64, unknown int) {
+	for _, tx := range txs {
+		e, ok := mem.txsMap.Load(sha256.Sum256(tx))
+		if !ok {
+			unknown++
+			continue
+		}
+		gasWanted += e.(*clist.CElement).Value.(*mempoolTx).gasWanted
+	}
+	return gasWanted, unknown
+}
+
+// ReapMaxTxs reaps up to max transactions from the mempool, from the highest
+// priority.
 // If max is negative, there is no cap on the size of all returned
 // transactions (~ all available transactions).
 func (mem *Mempool) ReapMaxTxs(max int) types.Txs {
@@ -560,8 +1037,10 @@ func (mem *Mempool) ReapMaxTxs(max int) types.Txs {
 	}
 
 	txs := make([]types.Tx, 0, cmn.MinInt(mem.txs.Len(), max))
-	for e := mem.txs.Front(); e != nil && len(txs) <= max; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
+	for _, memTx := range mem.byPriority(false) {
+		if len(txs) > max {
+			break
+		}
 		txs = append(txs, memTx.tx)
 	}
 	return txs
@@ -577,7 +1056,7 @@ func (mem *Mempool) Update(
 	postCheck PostCheckFunc,
 ) error {
 	// Set height
-	mem.height = height
+	atomic.StoreInt64(&mem.height, height)
 	mem.notifiedTxsAvailable = false
 
 	if preCheck != nil {
@@ -591,14 +1070,43 @@ func (mem *Mempool) Update(
 	for _, tx := range txs {
 		_ = mem.cache.Push(tx)
 	}
+	if mem.committedTxs != nil {
+		hashes := make([][sha256.Size]byte, len(txs))
+		for i, tx := range txs {
+			hashes[i] = sha256.Sum256(tx)
+		}
+		mem.committedTxs.Save(hashes)
+	}
 
 	// Remove committed transactions.
 	txsLeft := mem.removeTxs(txs)
 
+	// Evict the transactions which weren't committed in time.
+	if mem.config.TTLNumBlocks > 0 || mem.config.TTLDuration > 0 {
+		txsLeft = mem.purgeExpiredTxs(height)
+	}
+
+	// Compact the WAL, forgetting the removed transactions. The rechecked
+	// transactions which turn out to be invalid are forgotten next time.
+	if mem.wal != nil && mem.config.WalSnapshotInterval > 0 && height%mem.config.WalSnapshotInterval == 0 {
+		if err := mem.snapshotWAL(); err != nil {
+			mem.logger.Error("Error writing the WAL snapshot", "err", err)
+		}
+	}
+
 	// Either recheck non-committed txs to see if they became invalid
 	// or just notify there're some txs left.
 	if len(txsLeft) > 0 {
-		if mem.config.Recheck {
+		if mem.config.Recheck && mem.config.RecheckAsync {
+			mem.logger.Info("Recheck txs asynchronously", "numtxs", len(txsLeft), "height", height)
+			elements := make([]*clist.CElement, 0, len(txsLeft))
+			for e := mem.txs.Front(); e != nil; e = e.Next() {
+				elements = append(elements, e)
+			}
+			go mem.recheckTxsAsync(elements, height)
+			// The txs are reaped before they're rechecked.
+			mem.notifyTxsAvailable()
+		} else if mem.config.Recheck {
 			mem.logger.Info("Recheck txs", "numtxs", len(txsLeft), "height", height)
 			mem.recheckTxs(txsLeft)
 			// At this point, mem.txs are being rechecked.
@@ -627,12 +1135,27 @@ func (mem *Mempool) removeTxs(txs types.Txs) []types.Tx {
 		memTx := e.Value.(*mempoolTx)
 		// Remove the tx if it's already in a block.
 		if _, ok := txsMap[string(memTx.tx)]; ok {
-			// remove from clist
-			mem.txs.Remove(e)
-			atomic.AddInt64(&mem.txsBytes, int64(-len(memTx.tx)))
-			e.DetachPrev()
-
 			// NOTE: we don't remove committed txs from the cache.
+			mem.removeTx(e, false)
+			continue
+		}
+		txsLeft = append(txsLeft, memTx.tx)
+	}
+	return txsLeft
+}
+
+// purgeExpiredTxs evicts the txs which have been in the mempool for more than
+// TTLNumBlocks blocks, or TTLDuration, and returns the txs left. The expired
+// txs stay in the cache, so they aren't added back as soon as a peer sends
+// them again.
+func (mem *Mempool) purgeExpiredTxs(height int64) []types.Tx {
+	now := time.Now()
+	txsLeft := make([]types.Tx, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if (mem.config.TTLNumBlocks > 0 && height-memTx.Height() > mem.config.TTLNumBlocks) ||
+			(mem.config.TTLDuration > 0 && now.Sub(memTx.timestamp) > mem.config.TTLDuration) {
+			mem.evictTx(e, evictedByTTL, false)
 			continue
 		}
 		txsLeft = append(txsLeft, memTx.tx)
@@ -657,13 +1180,156 @@ func (mem *Mempool) recheckTxs(txs []types.Tx) {
 	mem.proxyAppConn.FlushAsync()
 }
 
+// recheckTxsAsync rechecks the txs of the elements in the background, with at
+// most RecheckBatchSize CheckTx calls in flight, so that Update doesn't wait
+// for the app. The txs which are no longer valid are removed once the
+// responses of their batch arrive. It stops if the mempool is updated to
+// another height in the meantime, as the txs left are rechecked again.
+func (mem *Mempool) recheckTxsAsync(elements []*clist.CElement, height int64) {
+	batchSize := mem.config.RecheckBatchSize
+	if batchSize <= 0 {
+		batchSize = len(elements)
+	}
+	for len(elements) > 0 {
+		n := cmn.MinInt(batchSize, len(elements))
+		if !mem.recheckBatch(elements[:n], height) {
+			return
+		}
+		elements = elements[n:]
+	}
+	mem.logger.Info("Done rechecking txs", "height", height)
+}
+
+// recheckBatch rechecks the txs of the elements, and removes those which are
+// no longer valid. It returns false if the mempool was updated to another
+// height, or the app connection failed.
+func (mem *Mempool) recheckBatch(batch []*clist.CElement, height int64) bool {
+	var (
+		invalidMtx sync.Mutex
+		invalid    []*clist.CElement
+	)
+
+	mem.proxyMtx.Lock()
+	if mem.Height() != height {
+		mem.proxyMtx.Unlock()
+		return false
+	}
+	postCheck := mem.postCheck
+	for _, e := range batch {
+		if e.Removed() {
+			continue // committed or evicted
+		}
+		e := e
+		tx := e.Value.(*mempoolTx).tx
+		reqRes := mem.proxyAppConn.CheckTxAsync(tx)
+		reqRes.SetCallback(func(res *abci.Response) {
+			mem.metrics.RecheckTimes.Add(1)
+			r := res.GetCheckTx()
+			if r == nil {
+				return
+			}
+			var postCheckErr error
+			if postCheck != nil {
+				postCheckErr = postCheck(tx, r)
+			}
+			if r.Code != abci.CodeTypeOK || postCheckErr != nil {
+				mem.logger.Info("Tx is no longer valid", "tx", TxID(tx), "res", r, "err", postCheckErr)
+				invalidMtx.Lock()
+				invalid = append(invalid, e)
+				invalidMtx.Unlock()
+			}
+		})
+	}
+	mem.proxyMtx.Unlock()
+
+	// Wait for the responses without blocking CheckTx, Reap or Update.
+	if err := mem.proxyAppConn.FlushSync(); err != nil {
+		mem.logger.Error("Error rechecking txs", "err", err)
+		return false
+	}
+
+	mem.proxyMtx.Lock()
+	defer mem.proxyMtx.Unlock()
+	invalidMtx.Lock()
+	defer invalidMtx.Unlock()
+	for _, e := range invalid {
+		if e.Removed() {
+			continue // committed or evicted since
+		}
+		tx := e.Value.(*mempoolTx).tx
+		// remove from cache (it might be good later)
+		mem.removeTx(e, true)
+		mem.publishEviction(tx, evictedByRecheck)
+	}
+	mem.metrics.Size.Set(float64(mem.Size()))
+	return true
+}
+
 //--------------------------------------------------------------------------------
 
 // mempoolTx is a transaction that successfully ran
 type mempoolTx struct {
-	height    int64    // height that this tx had been validated in
-	gasWanted int64    // amount of gas this tx states it will require
-	tx        types.Tx //
+	height    int64     // height that this tx had been validated in
+	gasWanted int64     // amount of gas this tx states it will require
+	priority  int64     // priority of this tx, see ResponseCheckTx.Priority
+	sender    string    // sender of this tx, see ResponseCheckTx.Sender
+	timestamp time.Time // time this tx was added to the mempool
+	tx        types.Tx  //
+}
+
+// EvictedTx is a tx which was evicted from the mempool, see Mempool.EvictedTx.
+type EvictedTx struct {
+	Reason string // see types.EventDataTxEvicted
+	Height int64  // height of the last block when the tx was evicted
+}
+
+// senderTxs are the txs of a sender in the mempool, in the order they arrived.
+type senderTxs struct {
+	elements []*clist.CElement
+	bytes    int64 // total size of the txs
+}
+
+// txQueue is a sequence of txs which must be reaped in order: the txs of a
+// sender, or a tx without sender. seqs are the positions of the txs in the
+// mempool.
+type txQueue struct {
+	txs  []*mempoolTx
+	seqs []int
+}
+
+// txQueues is a heap of txQueues, by the priority of their first tx (per
+// unit of gas wanted if perGas), then by the order it arrived.
+type txQueues struct {
+	queues []*txQueue
+	perGas bool
+}
+
+func (qs *txQueues) Len() int { return len(qs.queues) }
+
+func (qs *txQueues) Less(i, j int) bool {
+	txi, txj := qs.queues[i].txs[0], qs.queues[j].txs[0]
+	if qs.perGas {
+		// p_i/g_i > p_j/g_j, with at least 1 gas per tx
+		gi, gj := float64(cmn.MaxInt64(txi.gasWanted, 1)), float64(cmn.MaxInt64(txj.gasWanted, 1))
+		if ri, rj := float64(txi.priority)*gj, float64(txj.priority)*gi; ri != rj {
+			return ri > rj
+		}
+	}
+	if txi.priority != txj.priority {
+		return txi.priority > txj.priority
+	}
+	return qs.queues[i].seqs[0] < qs.queues[j].seqs[0]
+}
+
+func (qs *txQueues) Swap(i, j int) { qs.queues[i], qs.queues[j] = qs.queues[j], qs.queues[i] }
+
+func (qs *txQueues) Push(x interface{}) { qs.queues = append(qs.queues, x.(*txQueue)) }
+
+func (qs *txQueues) Pop() interface{} {
+	old := qs.queues
+	q := old[len(old)-1]
+	qs.queues = old[:len(old)-1]
+	return q
 }
 
 // Height returns the height for this transaction
@@ -684,6 +1350,7 @@ type txCache interface {
 type mapTxCache struct {
 	mtx  sync.Mutex
 	size int
+	fifo bool // don't move the txs seen again to the back
 	map_ map[[sha256.Size]byte]*list.Element
 	list *list.List // to remove oldest tx when cache gets too big
 }
@@ -710,13 +1377,18 @@ func (cache *mapTxCache) Reset() {
 // Push adds the given tx to the cache and returns true. It returns false if tx
 // is already in the cache.
 func (cache *mapTxCache) Push(tx types.Tx) bool {
+	// Use the tx hash in the cache
+	return cache.pushHash(sha256.Sum256(tx))
+}
+
+func (cache *mapTxCache) pushHash(txHash [sha256.Size]byte) bool {
 	cache.mtx.Lock()
 	defer cache.mtx.Unlock()
 
-	// Use the tx hash in the cache
-	txHash := sha256.Sum256(tx)
 	if moved, exists := cache.map_[txHash]; exists {
-		cache.list.MoveToBack(moved)
+		if !cache.fifo {
+			cache.list.MoveToBack(moved)
+		}
 		return false
 	}
 
@@ -753,3 +1425,119 @@ var _ txCache = (*nopTxCache)(nil)
 func (nopTxCache) Reset()             {}
 func (nopTxCache) Push(types.Tx) bool { return true }
 func (nopTxCache) Remove(types.Tx)    {}
+
+//--------------------------------------------------------------------------------
+
+// evictedTxCache remembers the last size evicted txs, by the hash of the tx.
+type evictedTxCache struct {
+	mtx  sync.Mutex
+	size int
+	map_ map[[sha256.Size]byte]*list.Element
+	list *list.List // *evictedTxEntry, from the oldest
+}
+
+type evictedTxEntry struct {
+	hash    [sha256.Size]byte
+	evicted *EvictedTx
+}
+
+func newEvictedTxCache(size int) *evictedTxCache {
+	return &evictedTxCache{
+		size: size,
+		map_: make(map[[sha256.Size]byte]*list.Element, size),
+		list: list.New(),
+	}
+}
+
+// Push remembers the eviction of the tx, forgetting the oldest eviction if
+// the cache is full.
+func (cache *evictedTxCache) Push(tx types.Tx, evicted *EvictedTx) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	txHash := sha256.Sum256(tx)
+	if e, exists := cache.map_[txHash]; exists {
+		e.Value.(*evictedTxEntry).evicted = evicted
+		cache.list.MoveToBack(e)
+		return
+	}
+
+	if cache.list.Len() >= cache.size {
+		popped := cache.list.Front()
+		delete(cache.map_, popped.Value.(*evictedTxEntry).hash)
+		cache.list.Remove(popped)
+	}
+	cache.map_[txHash] = cache.list.PushBack(&evictedTxEntry{txHash, evicted})
+}
+
+// Get returns the eviction of the tx with the given hash, or nil.
+func (cache *evictedTxCache) Get(hash []byte) *EvictedTx {
+	var key [sha256.Size]byte
+	if len(hash) != len(key) {
+		return nil
+	}
+	copy(key[:], hash)
+
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	if e, ok := cache.map_[key]; ok {
+		return e.Value.(*evictedTxEntry).evicted
+	}
+	return nil
+}
+
+// Remove forgets the eviction of the tx, e.g. once it's back in the mempool.
+func (cache *evictedTxCache) Remove(tx types.Tx) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	txHash := sha256.Sum256(tx)
+	if e, ok := cache.map_[txHash]; ok {
+		delete(cache.map_, txHash)
+		cache.list.Remove(e)
+	}
+}
+
+//--------------------------------------------------------------------------------
+
+// txHashCache remembers the last size tx hashes, e.g. those a peer has.
+type txHashCache struct {
+	mtx  sync.Mutex
+	size int
+	map_ map[[sha256.Size]byte]*list.Element
+	list *list.List // to remove the oldest hash when the cache gets too big
+}
+
+func newTxHashCache(size int) *txHashCache {
+	return &txHashCache{
+		size: size,
+		map_: make(map[[sha256.Size]byte]*list.Element, size),
+		list: list.New(),
+	}
+}
+
+// Push adds the hash to the cache, forgetting the oldest hash if the cache is
+// full.
+func (cache *txHashCache) Push(hash [sha256.Size]byte) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	if e, exists := cache.map_[hash]; exists {
+		cache.list.MoveToBack(e)
+		return
+	}
+
+	if cache.list.Len() >= cache.size {
+		popped := cache.list.Front()
+		delete(cache.map_, popped.Value.([sha256.Size]byte))
+		cache.list.Remove(popped)
+	}
+	cache.map_[hash] = cache.list.PushBack(hash)
+}
+
+// Has returns true if the hash is in the cache.
+func (cache *txHashCache) Has(hash [sha256.Size]byte) bool {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	_, ok := cache.map_[hash]
+	return ok
+}
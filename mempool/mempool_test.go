@@ -20,6 +20,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
 	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/proxy"
 	"github.com/tendermint/tendermint/types"
@@ -362,6 +363,49 @@ func TestCacheRemove(t *testing.T) {
 	}
 }
 
+func TestCacheEvictionPolicy(t *testing.T) {
+	for _, fifo := range []bool{false, true} {
+		cache := newMapTxCache(2)
+		cache.fifo = fifo
+		require.True(t, cache.Push([]byte{1}))
+		require.True(t, cache.Push([]byte{2}))
+		require.False(t, cache.Push([]byte{1}))
+		// LRU forgets 2, which was seen last a longer time ago, FIFO forgets 1
+		require.True(t, cache.Push([]byte{3}))
+		assert.Equal(t, fifo, cache.Push([]byte{1}), "fifo=%v", fifo)
+	}
+}
+
+func TestMempoolPersistCache(t *testing.T) {
+	app := kvstore.NewKVStoreApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	config := cfg.ResetTestRoot("mempool_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Mempool.CacheSize = 2
+	appConnMem, _ := cc.NewABCIClient()
+	require.NoError(t, appConnMem.Start())
+	defer appConnMem.Stop()
+	db := dbm.NewMemDB()
+
+	mempool := NewMempool(config.Mempool, appConnMem, 0, WithCacheDB(db))
+	txs := types.Txs{[]byte{1}, []byte{2}, []byte{3}}
+	for _, tx := range txs {
+		require.NoError(t, mempool.CheckTx(tx, nil))
+	}
+	require.NoError(t, mempool.Update(1, txs, nil, nil))
+
+	// After a restart, the last 2 committed txs are still in the cache
+	mempool = NewMempool(config.Mempool, appConnMem, 1, WithCacheDB(db))
+	assert.Equal(t, ErrTxInCache, mempool.CheckTx([]byte{2}, nil))
+	assert.Equal(t, ErrTxInCache, mempool.CheckTx([]byte{3}, nil))
+	assert.NoError(t, mempool.CheckTx([]byte{1}, nil))
+
+	// Flushing the mempool forgets them
+	mempool.Flush()
+	mempool = NewMempool(config.Mempool, appConnMem, 1, WithCacheDB(db))
+	assert.NoError(t, mempool.CheckTx([]byte{2}, nil))
+}
+
 func TestMempoolCloseWAL(t *testing.T) {
 	// 1. Create the temporary directory for mempool and WAL testing.
 	rootDir, err := ioutil.TempDir("", "mempool-test")
@@ -390,11 +434,11 @@ func TestMempoolCloseWAL(t *testing.T) {
 
 	// 5. Write some contents to the WAL
 	mempool.CheckTx(types.Tx([]byte("foo")), nil)
-	walFilepath := mempool.wal.Path
+	walFilepath := mempool.wal.journal.Name()
 	sum1 := checksumFile(walFilepath, t)
 
 	// 6. Sanity check to ensure that the written TX matches the expectation.
-	require.Equal(t, sum1, checksumIt([]byte("foo\n")), "foo with a newline should be written")
+	require.Equal(t, sum1, checksumIt(encodeWALRecord([]byte("foo"))), "foo should be written")
 
 	// 7. Invoke CloseWAL() and ensure it discards the
 	// WAL thus any other write won't go through.
@@ -409,6 +453,55 @@ func TestMempoolCloseWAL(t *testing.T) {
 	require.Equal(t, 1, len(m3), "expecting the wal match in")
 }
 
+func TestMempoolReplayWAL(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "mempool-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(rootDir)
+
+	wcfg := cfg.DefaultMempoolConfig()
+	wcfg.RootDir = rootDir
+	wcfg.WalSnapshotInterval = 2
+	app := kvstore.NewKVStoreApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	appConnMem, _ := cc.NewABCIClient()
+	mempool := NewMempool(wcfg, appConnMem, 0)
+	mempool.InitWAL()
+
+	// The committed tx is removed from the snapshot, the tx added after the
+	// snapshot is in the journal
+	for _, tx := range []types.Tx{[]byte("a=1"), []byte("b=2"), []byte("c=3")} {
+		require.NoError(t, mempool.CheckTx(tx, nil))
+	}
+	require.NoError(t, mempool.Update(1, types.Txs{[]byte("a=1")}, nil, nil))
+	require.NoError(t, mempool.Update(2, types.Txs{[]byte("b=2")}, nil, nil))
+	require.NoError(t, mempool.CheckTx([]byte("d=4"), nil))
+	txs, err := mempool.wal.Load()
+	require.NoError(t, err)
+	assert.Equal(t, []types.Tx{[]byte("c=3"), []byte("d=4")}, txs)
+	mempool.CloseWAL()
+
+	// A partial write at the end of the journal is dropped
+	f, err := os.OpenFile(filepath.Join(rootDir, walJournalFile), os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = f.Write(encodeWALRecord([]byte("e=5"))[:10])
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	mempool = NewMempool(wcfg, appConnMem, 2)
+	mempool.InitWAL()
+	defer mempool.CloseWAL()
+	require.NoError(t, mempool.ReplayWAL())
+	assert.Equal(t, types.Txs{[]byte("c=3"), []byte("d=4")}, mempool.ReapMaxTxs(-1))
+
+	// The WAL is compacted into the snapshot
+	journal, err := os.Stat(filepath.Join(rootDir, walJournalFile))
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, journal.Size())
+	txs, err = mempool.wal.Load()
+	require.NoError(t, err)
+	assert.Equal(t, []types.Tx{[]byte("c=3"), []byte("d=4")}, txs)
+}
+
 // Size of the amino encoded TxMessage is the length of the
 // encoded byte array, plus 1 for the struct field, plus 4
 // for the amino prefix.
@@ -492,13 +585,14 @@ func TestMempoolTxsBytes(t *testing.T) {
 	mempool.Flush()
 	assert.EqualValues(t, 0, mempool.TxsBytes())
 
-	// 5. ErrMempoolIsFull is returned when/if MaxTxsBytes limit is reached.
+	// 5. the tx is rejected when/if MaxTxsBytes limit is reached.
 	err = mempool.CheckTx([]byte{0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04}, nil)
 	require.NoError(t, err)
-	err = mempool.CheckTx([]byte{0x05}, nil)
-	if assert.Error(t, err) {
-		assert.IsType(t, ErrMempoolIsFull{}, err)
-	}
+	var checkTxRes *abci.Response
+	err = mempool.CheckTx([]byte{0x05}, func(r *abci.Response) { checkTxRes = r })
+	require.NoError(t, err)
+	assert.Contains(t, checkTxRes.GetCheckTx().MempoolError, "Mempool is full")
+	assert.EqualValues(t, 10, mempool.TxsBytes())
 
 	// 6. zero after tx is rechecked and removed due to not being valid anymore
 	app2 := counter.NewCounterApplication(true)
@@ -528,6 +622,272 @@ func TestMempoolTxsBytes(t *testing.T) {
 	// Pretend like we committed nothing so txBytes gets rechecked and removed.
 	mempool.Update(1, []types.Tx{}, nil, nil)
 	assert.EqualValues(t, 0, mempool.TxsBytes())
+	assert.Equal(t, &EvictedTx{Reason: "recheck_failed", Height: 1}, mempool.EvictedTx(types.Tx(txBytes).Hash()))
+}
+
+func TestMempoolRecheckAsync(t *testing.T) {
+	app := counter.NewCounterApplication(true)
+	cc := proxy.NewLocalClientCreator(app)
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.RecheckAsync = true
+	config.Mempool.RecheckBatchSize = 2
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	txs := make([]types.Tx, 5)
+	for i := range txs {
+		txs[i] = make([]byte, 8)
+		binary.BigEndian.PutUint64(txs[i], uint64(i))
+		require.NoError(t, mempool.CheckTx(txs[i], nil))
+	}
+
+	// Commit the first 3 txs, without telling the mempool
+	appConnCon, _ := cc.NewABCIClient()
+	require.NoError(t, appConnCon.Start())
+	defer appConnCon.Stop()
+	for _, tx := range txs[:3] {
+		res, err := appConnCon.DeliverTxSync(tx)
+		require.NoError(t, err)
+		require.EqualValues(t, abci.CodeTypeOK, res.Code)
+	}
+	_, err := appConnCon.CommitSync()
+	require.NoError(t, err)
+
+	// Update returns before the txs are rechecked
+	mempool.Lock()
+	require.NoError(t, mempool.Update(1, nil, nil, nil))
+	assert.Equal(t, 5, mempool.Size())
+	mempool.Unlock()
+
+	for i := 0; i < 100 && mempool.Size() > 2; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, types.Txs{txs[3], txs[4]}, mempool.ReapMaxTxs(-1))
+	assert.Equal(t, &EvictedTx{Reason: "recheck_failed", Height: 1}, mempool.EvictedTx(txs[0].Hash()))
+}
+
+// priorityApp accepts all the txs, the first byte of the tx being its
+// priority, and the second byte its sender (if any).
+type priorityApp struct {
+	abci.BaseApplication
+}
+
+func (priorityApp) CheckTx(tx []byte) abci.ResponseCheckTx {
+	res := abci.ResponseCheckTx{Priority: int64(tx[0])}
+	if len(tx) > 1 {
+		res.Sender = string(tx[1:2])
+	}
+	return res
+}
+
+// evictionRecorder records the txs evicted from the mempool.
+type evictionRecorder []types.EventDataTxEvicted
+
+func (r *evictionRecorder) PublishEventTxEvicted(data types.EventDataTxEvicted) error {
+	*r = append(*r, data)
+	return nil
+}
+
+func TestMempoolPriority(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(priorityApp{})
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.Size = 3
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+	evicted := &evictionRecorder{}
+	mempool.SetEventBus(evicted)
+
+	checkTx := func(tx types.Tx) string {
+		var res *abci.Response
+		require.NoError(t, mempool.CheckTx(tx, func(r *abci.Response) { res = r }))
+		return res.GetCheckTx().MempoolError
+	}
+
+	for _, tx := range []types.Tx{{1, 'a'}, {3, 'b'}, {2, 'c'}} {
+		require.Empty(t, checkTx(tx))
+	}
+	// from the highest priority
+	assert.Equal(t, types.Txs{{3, 'b'}, {2, 'c'}, {1, 'a'}}, mempool.ReapMaxBytesMaxGas(-1, -1))
+	assert.Equal(t, types.Txs{{3, 'b'}}, mempool.ReapMaxTxs(0))
+
+	// a tx with the same priority as the lowest can't get in, a tx with a
+	// higher priority evicts the lowest
+	assert.Contains(t, checkTx(types.Tx{1, 'd'}), "Mempool is full")
+	require.Empty(t, checkTx(types.Tx{4, 'e'}))
+	assert.Equal(t, types.Txs{{4, 'e'}, {3, 'b'}, {2, 'c'}}, mempool.ReapMaxBytesMaxGas(-1, -1))
+	assert.Equal(t, &evictionRecorder{{Tx: types.Tx{1, 'a'}, Reason: "priority"}}, evicted)
+
+	// the evicted tx can be checked again
+	assert.Contains(t, checkTx(types.Tx{1, 'a'}), "Mempool is full")
+
+}
+
+func TestMempoolPrioritySenderGaps(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(priorityApp{})
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.Size = 3
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+	evicted := &evictionRecorder{}
+	mempool.SetEventBus(evicted)
+
+	checkTx := func(tx types.Tx) string {
+		var res *abci.Response
+		require.NoError(t, mempool.CheckTx(tx, func(r *abci.Response) { res = r }))
+		return res.GetCheckTx().MempoolError
+	}
+
+	// a tx is evicted with the later txs of its sender, from the most recent
+	for _, tx := range []types.Tx{{1, 'a'}, {3, 'a'}, {2, 'b'}} {
+		require.Empty(t, checkTx(tx))
+	}
+	require.Empty(t, checkTx(types.Tx{4, 'c'}))
+	assert.Equal(t, types.Txs{{4, 'c'}, {2, 'b'}}, mempool.ReapMaxBytesMaxGas(-1, -1))
+	assert.Equal(t, &evictionRecorder{
+		{Tx: types.Tx{3, 'a'}, Reason: "priority"},
+		{Tx: types.Tx{1, 'a'}, Reason: "priority"},
+	}, evicted)
+
+	// nor the earlier txs of the sender of the new tx
+	*evicted = nil
+	require.Empty(t, checkTx(types.Tx{1, 'd'}))
+	require.Empty(t, checkTx(types.Tx{5, 'd'}))
+	assert.Equal(t, &evictionRecorder{{Tx: types.Tx{2, 'b'}, Reason: "priority"}}, evicted)
+
+	// but not if one of the later txs has a higher priority than the new tx
+	assert.Contains(t, checkTx(types.Tx{3, 'e'}), "Mempool is full")
+	*evicted = nil
+	require.Empty(t, checkTx(types.Tx{6, 'd'}))
+	assert.Equal(t, &evictionRecorder{{Tx: types.Tx{4, 'c'}, Reason: "priority"}}, evicted)
+}
+
+func TestMempoolSenderLimits(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(priorityApp{})
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.MaxTxsPerSender = 2
+	config.Mempool.MaxTxsBytesPerSender = 5
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+	evicted := &evictionRecorder{}
+	mempool.SetEventBus(evicted)
+
+	checkTx := func(tx types.Tx) string {
+		var res *abci.Response
+		require.NoError(t, mempool.CheckTx(tx, func(r *abci.Response) { res = r }))
+		return res.GetCheckTx().MempoolError
+	}
+
+	// the txs of a sender are reaped in the order they arrived
+	for _, tx := range []types.Tx{{1, 'a'}, {5, 'a'}, {3, 'b'}} {
+		require.Empty(t, checkTx(tx))
+	}
+	assert.Equal(t, types.Txs{{3, 'b'}, {1, 'a'}, {5, 'a'}}, mempool.ReapMaxBytesMaxGas(-1, -1))
+
+	// the oldest txs of a sender are evicted once it has too many txs, or
+	// too many bytes
+	require.Empty(t, checkTx(types.Tx{2, 'a'}))
+	assert.Equal(t, types.Txs{{5, 'a'}, {3, 'b'}, {2, 'a'}}, mempool.ReapMaxBytesMaxGas(-1, -1))
+	assert.Equal(t, &evictionRecorder{{Tx: types.Tx{1, 'a'}, Reason: "sender_limit"}}, evicted)
+	require.Empty(t, checkTx(types.Tx{1, 'a', 0, 0}))
+	assert.Equal(t, types.Txs{{3, 'b'}, {1, 'a', 0, 0}}, mempool.ReapMaxBytesMaxGas(-1, -1))
+	assert.Contains(t, checkTx(types.Tx{1, 'c', 0, 0, 0, 0}), "sender c")
+
+	// the evicted txs can be checked again
+	require.Empty(t, checkTx(types.Tx{5, 'a'}))
+	assert.Equal(t, types.Txs{{5, 'a'}, {3, 'b'}}, mempool.ReapMaxBytesMaxGas(-1, -1))
+
+	mempool.Update(1, types.Txs{{3, 'b'}, {5, 'a'}}, nil, nil)
+	assert.Zero(t, mempool.Size())
+	assert.Empty(t, mempool.senders)
+}
+
+// gasApp accepts all the txs, the first byte of the tx being its priority,
+// the second byte its gas wanted, and the third byte its sender (if any).
+type gasApp struct {
+	abci.BaseApplication
+}
+
+func (gasApp) CheckTx(tx []byte) abci.ResponseCheckTx {
+	res := abci.ResponseCheckTx{Priority: int64(tx[0]), GasWanted: int64(tx[1])}
+	if len(tx) > 2 {
+		res.Sender = string(tx[2:3])
+	}
+	return res
+}
+
+func TestReapMaxBytesMaxGasPerGas(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(gasApp{})
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	for _, tx := range []types.Tx{{6, 3}, {4, 1}, {5, 5}, {2, 2, 'a'}, {9, 1, 'a'}} {
+		require.NoError(t, mempool.CheckTx(tx, nil))
+	}
+
+	// without max gas, by priority
+	assert.Equal(t, types.Txs{{6, 3}, {5, 5}, {4, 1}, {2, 2, 'a'}, {9, 1, 'a'}},
+		mempool.ReapMaxBytesMaxGas(-1, -1))
+
+	// with max gas, by priority per gas, skipping the txs which don't fit,
+	// with the later txs of their sender
+	assert.Equal(t, types.Txs{{4, 1}, {6, 3}, {5, 5}}, mempool.ReapMaxBytesMaxGas(-1, 10))
+	assert.Equal(t, types.Txs{{4, 1}, {6, 3}, {2, 2, 'a'}}, mempool.ReapMaxBytesMaxGas(-1, 6))
+	assert.Equal(t, types.Txs{{4, 1}, {6, 3}}, mempool.ReapMaxBytesMaxGas(8, 10))
+
+	gasWanted, unknown := mempool.GasWanted(types.Txs{{4, 1}, {6, 3}, {7, 7}})
+	assert.EqualValues(t, 4, gasWanted)
+	assert.Equal(t, 1, unknown)
+}
+
+func TestMempoolCheckTxs(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(priorityApp{})
+	config := cfg.ResetTestRoot("mempool_test")
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+
+	priorities := make(map[int]int64)
+	errs := mempool.CheckTxs([]types.Tx{{1}, {2}, {1}}, func(i int, res *abci.Response) {
+		priorities[i] = res.GetCheckTx().Priority
+	})
+	assert.Equal(t, []error{nil, nil, ErrTxInCache}, errs)
+	assert.Equal(t, map[int]int64{0: 1, 1: 2}, priorities)
+	assert.Equal(t, 2, mempool.Size())
+}
+
+func TestMempoolTTL(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(priorityApp{})
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.TTLNumBlocks = 2
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+	evicted := &evictionRecorder{}
+	mempool.SetEventBus(evicted)
+
+	require.NoError(t, mempool.CheckTx(types.Tx{1}, nil))
+	mempool.Update(1, nil, nil, nil)
+	require.NoError(t, mempool.CheckTx(types.Tx{2}, nil))
+	mempool.Update(2, nil, nil, nil)
+	assert.Equal(t, 2, mempool.Size())
+
+	// the first tx isn't committed within 2 blocks
+	mempool.Update(3, nil, nil, nil)
+	assert.Equal(t, types.Txs{{2}}, mempool.ReapMaxTxs(-1))
+	assert.Equal(t, &evictionRecorder{{Tx: types.Tx{1}, Reason: "expired"}}, evicted)
+	// the expired tx stays in the cache
+	assert.Equal(t, ErrTxInCache, mempool.CheckTx(types.Tx{1}, nil))
+	assert.Equal(t, &EvictedTx{Reason: "expired", Height: 3}, mempool.EvictedTx(types.Tx{1}.Hash()))
+	assert.False(t, mempool.HasTx(types.Tx{1}.Hash()))
+	assert.True(t, mempool.HasTx(types.Tx{2}.Hash()))
+	assert.Nil(t, mempool.EvictedTx(types.Tx{2}.Hash()))
+
+	config.Mempool.TTLNumBlocks = 0
+	config.Mempool.TTLDuration = 10 * time.Millisecond
+	mempool.Update(4, nil, nil, nil)
+	assert.Equal(t, 1, mempool.Size())
+	time.Sleep(20 * time.Millisecond)
+	mempool.Update(5, nil, nil, nil)
+	assert.Zero(t, mempool.Size())
+	assert.Len(t, *evicted, 2)
 }
 
 func checksumIt(data []byte) string {
@@ -24,6 +24,15 @@ type Metrics struct {
 	FailedTxs metrics.Counter
 	// Number of times transactions are rechecked in the mempool.
 	RecheckTimes metrics.Counter
+	// Number of valid transactions rejected by the mempool (e.g. when full).
+	RejectedTxs metrics.Counter
+	// Number of transactions evicted before being committed (e.g. by
+	// transactions with a higher priority, or expired).
+	EvictedTxs metrics.Counter
+	// Number of transactions found in the cache, which aren't checked again.
+	CacheHits metrics.Counter
+	// Number of transactions not found in the cache.
+	CacheMisses metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -60,6 +69,30 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "recheck_times",
 			Help:      "Number of times transactions are rechecked in the mempool.",
 		}, labels).With(labelsAndValues...),
+		RejectedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rejected_txs",
+			Help:      "Number of valid transactions rejected by the mempool (e.g. when full).",
+		}, labels).With(labelsAndValues...),
+		EvictedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "evicted_txs",
+			Help:      "Number of transactions evicted before being committed (e.g. by transactions with a higher priority, or expired).",
+		}, labels).With(labelsAndValues...),
+		CacheHits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "cache_hits",
+			Help:      "Number of transactions found in the cache, which aren't checked again.",
+		}, labels).With(labelsAndValues...),
+		CacheMisses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "cache_misses",
+			Help:      "Number of transactions not found in the cache.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -70,5 +103,9 @@ func NopMetrics() *Metrics {
 		TxSizeBytes:  discard.NewHistogram(),
 		FailedTxs:    discard.NewCounter(),
 		RecheckTimes: discard.NewCounter(),
+		RejectedTxs:  discard.NewCounter(),
+		EvictedTxs:   discard.NewCounter(),
+		CacheHits:    discard.NewCounter(),
+		CacheMisses:  discard.NewCounter(),
 	}
 }
@@ -0,0 +1,47 @@
+package mempool
+
+import (
+	"github.com/pkg/errors"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ErrMempoolDisabled is returned for every tx by the NopMempool.
+var ErrMempoolDisabled = errors.New("Mempool is disabled (mempool.type is nop)")
+
+// NopMempool is the mempool of the chains whose application disseminates the
+// txs itself (mempool.type is nop): it rejects every tx, so no tx is gossiped
+// nor proposed.
+type NopMempool struct{}
+
+func (NopMempool) Lock()                                        {}
+func (NopMempool) Unlock()                                      {}
+func (NopMempool) Size() int                                    { return 0 }
+func (NopMempool) TxsBytes() int64                              { return 0 }
+func (NopMempool) CheckTx(types.Tx, func(*abci.Response)) error { return ErrMempoolDisabled }
+func (NopMempool) CheckTxs(txs []types.Tx, _ func(int, *abci.Response)) []error {
+	errs := make([]error, len(txs))
+	for i := range errs {
+		errs[i] = ErrMempoolDisabled
+	}
+	return errs
+}
+func (NopMempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
+func (NopMempool) GasWanted(txs types.Txs) (int64, int)    { return 0, len(txs) }
+func (NopMempool) ReapMaxTxs(int) types.Txs                { return types.Txs{} }
+func (NopMempool) Update(
+	_ int64,
+	_ types.Txs,
+	_ PreCheckFunc,
+	_ PostCheckFunc,
+) error {
+	return nil
+}
+func (NopMempool) Flush()                        {}
+func (NopMempool) FlushAppConn() error           { return nil }
+func (NopMempool) TxsAvailable() <-chan struct{} { return nil }
+func (NopMempool) EnableTxsAvailable()           {}
+func (NopMempool) HasTx([]byte) bool             { return false }
+func (NopMempool) GetTx([]byte) types.Tx         { return nil }
+func (NopMempool) EvictedTx([]byte) *EvictedTx   { return nil }
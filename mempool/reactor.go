@@ -1,8 +1,11 @@
 package mempool
 
 import (
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
 	amino "github.com/tendermint/go-amino"
@@ -21,20 +24,50 @@ const (
 	maxTxSize  = maxMsgSize - 8 // account for amino overhead of TxMessage
 
 	peerCatchupSleepIntervalMS = 100 // If peer is behind, sleep this amount
+
+	peerTxsCacheSize   = 10000           // Number of tx hashes remembered per peer
+	peerSendQueueSize  = 100             // Number of requests queued per peer
+	maxTxInvHashes     = 1000            // Number of tx hashes announced at once
+	txRequestTimeout   = 2 * time.Second // After which a tx is requested from another peer
+	txRequestsInterval = time.Second     // How often the timed out requests are retried
 )
 
 // MempoolReactor handles mempool tx broadcasting amongst peers.
+//
+// With the peers which advertised p2p.FeatureTxInventory, the reactor
+// announces the hashes of its txs (TxInvMessage), and only sends the txs they
+// request (TxRequestMessage). The other peers are sent the txs right away.
+// Either way, a peer isn't sent the txs it's known to have.
 type MempoolReactor struct {
 	p2p.BaseReactor
 	config  *cfg.MempoolConfig
 	Mempool *Mempool
+
+	mtx       sync.Mutex
+	peers     map[p2p.ID]*mempoolPeer
+	requested map[[sha256.Size]byte]*txRequest // the txs requested from a peer
+}
+
+// mempoolPeer is the state of a peer.
+type mempoolPeer struct {
+	txs      *txHashCache  // the txs the peer has
+	requests chan [][]byte // the txs to request from the peer
+	wanted   chan [][]byte // the txs the peer requested
+}
+
+// txRequest is a tx requested from a peer.
+type txRequest struct {
+	requestedAt time.Time
+	peers       []p2p.ID // the other peers which announced the tx
 }
 
 // NewMempoolReactor returns a new MempoolReactor with the given config and mempool.
 func NewMempoolReactor(config *cfg.MempoolConfig, mempool *Mempool) *MempoolReactor {
 	memR := &MempoolReactor{
-		config:  config,
-		Mempool: mempool,
+		config:    config,
+		Mempool:   mempool,
+		peers:     make(map[p2p.ID]*mempoolPeer),
+		requested: make(map[[sha256.Size]byte]*txRequest),
 	}
 	memR.BaseReactor = *p2p.NewBaseReactor("MempoolReactor", memR)
 	return memR
@@ -51,6 +84,7 @@ func (memR *MempoolReactor) OnStart() error {
 	if !memR.config.Broadcast {
 		memR.Logger.Info("Tx broadcasting is disabled")
 	}
+	go memR.retryRequestsRoutine()
 	return nil
 }
 
@@ -68,12 +102,33 @@ func (memR *MempoolReactor) GetChannels() []*p2p.ChannelDescriptor {
 // AddPeer implements Reactor.
 // It starts a broadcast routine ensuring all txs are forwarded to the given peer.
 func (memR *MempoolReactor) AddPeer(peer p2p.Peer) {
-	go memR.broadcastTxRoutine(peer)
+	ps := memR.getPeer(peer)
+	go memR.broadcastTxRoutine(peer, ps)
+	go memR.sendRoutine(peer, ps)
 }
 
 // RemovePeer implements Reactor.
 func (memR *MempoolReactor) RemovePeer(peer p2p.Peer, reason interface{}) {
 	// broadcast routine checks if peer is gone and returns
+	memR.mtx.Lock()
+	delete(memR.peers, peer.ID())
+	memR.mtx.Unlock()
+}
+
+// getPeer returns the state of the peer.
+func (memR *MempoolReactor) getPeer(peer p2p.Peer) *mempoolPeer {
+	memR.mtx.Lock()
+	defer memR.mtx.Unlock()
+	ps, ok := memR.peers[peer.ID()]
+	if !ok {
+		ps = &mempoolPeer{
+			txs:      newTxHashCache(peerTxsCacheSize),
+			requests: make(chan [][]byte, peerSendQueueSize),
+			wanted:   make(chan [][]byte, peerSendQueueSize),
+		}
+		memR.peers[peer.ID()] = ps
+	}
+	return ps
 }
 
 // Receive implements Reactor.
@@ -85,32 +140,158 @@ func (memR *MempoolReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 		memR.Switch.StopPeerForError(src, err)
 		return
 	}
+	if err = msg.ValidateBasic(); err != nil {
+		memR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
+		memR.Switch.StopPeerForError(src, err)
+		return
+	}
 	memR.Logger.Debug("Receive", "src", src, "chId", chID, "msg", msg)
 
+	ps := memR.getPeer(src)
 	switch msg := msg.(type) {
 	case *TxMessage:
+		hash := sha256.Sum256(msg.Tx)
+		ps.txs.Push(hash)
+		memR.mtx.Lock()
+		delete(memR.requested, hash)
+		memR.mtx.Unlock()
+
 		err := memR.Mempool.CheckTx(msg.Tx, nil)
 		if err != nil {
 			memR.Logger.Info("Could not check tx", "tx", TxID(msg.Tx), "err", err)
 		}
 		// broadcasting happens from go routines per peer
+	case *TxInvMessage:
+		memR.requestTxs(src, ps, msg.Hashes)
+	case *TxRequestMessage:
+		select {
+		case ps.wanted <- msg.Hashes:
+		default:
+			// the peer will request the txs from another peer
+			memR.Logger.Info("Dropping the txs request of the peer", "peer", src)
+		}
 	default:
 		memR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 	}
 }
 
+// requestTxs requests the announced txs which aren't in the mempool, unless
+// they're already requested from another peer.
+func (memR *MempoolReactor) requestTxs(src p2p.Peer, ps *mempoolPeer, hashes [][]byte) {
+	var request [][]byte
+
+	memR.mtx.Lock()
+	for _, hash := range hashes {
+		var key [sha256.Size]byte
+		copy(key[:], hash)
+		ps.txs.Push(key)
+		if memR.Mempool.HasTx(hash) {
+			continue
+		}
+		if req, ok := memR.requested[key]; ok {
+			req.peers = append(req.peers, src.ID()) // in case the request times out
+			continue
+		}
+		memR.requested[key] = &txRequest{requestedAt: time.Now()}
+		request = append(request, hash)
+	}
+	memR.mtx.Unlock()
+
+	if len(request) > 0 {
+		memR.sendRequest(ps, request)
+	}
+}
+
+// sendRequest queues the request to the peer. If the queue is full, the
+// request times out.
+func (memR *MempoolReactor) sendRequest(ps *mempoolPeer, hashes [][]byte) {
+	select {
+	case ps.requests <- hashes:
+	default:
+	}
+}
+
+// retryRequestsRoutine requests the txs which weren't received in time from
+// the next peers which announced them, or forgets them.
+func (memR *MempoolReactor) retryRequestsRoutine() {
+	ticker := time.NewTicker(txRequestsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-memR.Quit():
+			return
+		}
+
+		now := time.Now()
+		requests := make(map[*mempoolPeer][][]byte)
+		memR.mtx.Lock()
+		for key, req := range memR.requested {
+			if now.Sub(req.requestedAt) < txRequestTimeout {
+				continue
+			}
+			var ps *mempoolPeer
+			for ps == nil && len(req.peers) > 0 {
+				ps = memR.peers[req.peers[0]]
+				req.peers = req.peers[1:]
+			}
+			if ps == nil {
+				delete(memR.requested, key)
+				continue
+			}
+			req.requestedAt = now
+			hash := key
+			requests[ps] = append(requests[ps], hash[:])
+		}
+		memR.mtx.Unlock()
+
+		for ps, hashes := range requests {
+			memR.sendRequest(ps, hashes)
+		}
+	}
+}
+
+// sendRoutine sends our requests to the peer, and the txs it requested.
+func (memR *MempoolReactor) sendRoutine(peer p2p.Peer, ps *mempoolPeer) {
+	for {
+		select {
+		case hashes := <-ps.requests:
+			peer.Send(MempoolChannel, cdc.MustMarshalBinaryBare(&TxRequestMessage{Hashes: hashes}))
+		case hashes := <-ps.wanted:
+			for _, hash := range hashes {
+				tx := memR.Mempool.GetTx(hash)
+				if tx == nil {
+					continue // committed or evicted since it was announced
+				}
+				if peer.Send(MempoolChannel, cdc.MustMarshalBinaryBare(&TxMessage{Tx: tx})) {
+					ps.txs.Push(sha256.Sum256(tx))
+				}
+			}
+		case <-peer.Quit():
+			return
+		case <-memR.Quit():
+			return
+		}
+	}
+}
+
 // PeerState describes the state of a peer.
 type PeerState interface {
 	GetHeight() int64
 }
 
 // Send new mempool txs to peer.
-func (memR *MempoolReactor) broadcastTxRoutine(peer p2p.Peer) {
+func (memR *MempoolReactor) broadcastTxRoutine(peer p2p.Peer, ps *mempoolPeer) {
 	if !memR.config.Broadcast {
 		return
 	}
+	inventory := peer.HasFeature(p2p.FeatureTxInventory)
 
-	var next *clist.CElement
+	var (
+		next   *clist.CElement
+		paused bool // whether the peer is behind
+	)
 	for {
 		// This happens because the CElement we were looking at got garbage
 		// collected (removed). That is, .NextWait() returned nil. Go ahead and
@@ -141,19 +322,53 @@ func (memR *MempoolReactor) broadcastTxRoutine(peer p2p.Peer) {
 			time.Sleep(peerCatchupSleepIntervalMS * time.Millisecond)
 			continue
 		}
-		if peerState.GetHeight() < memTx.Height()-1 { // Allow for a lag of 1 block
+		// don't waste the bandwidth of a peer catching up (e.g. fast syncing)
+		if memR.isPeerBehind(peerState) {
+			if !paused {
+				memR.Logger.Info("Pausing tx gossip, the peer is behind", "peer", peer, "height", peerState.GetHeight())
+				paused = true
+			}
 			time.Sleep(peerCatchupSleepIntervalMS * time.Millisecond)
 			continue
 		}
-
-		// send memTx
-		msg := &TxMessage{Tx: memTx.tx}
-		success := peer.Send(MempoolChannel, cdc.MustMarshalBinaryBare(msg))
-		if !success {
+		if paused {
+			memR.Logger.Info("Resuming tx gossip, the peer caught up", "peer", peer, "height", peerState.GetHeight())
+			paused = false
+		}
+		if peerState.GetHeight() < memTx.Height()-1 { // Allow for a lag of 1 block
 			time.Sleep(peerCatchupSleepIntervalMS * time.Millisecond)
 			continue
 		}
 
+		if inventory {
+			// announce the hashes of memTx and the following txs, unless the
+			// peer has them
+			last, hashes := memR.txsToAnnounce(next, ps, peerState.GetHeight())
+			if len(hashes) > 0 {
+				msg := &TxInvMessage{Hashes: hashes}
+				success := peer.Send(MempoolChannel, cdc.MustMarshalBinaryBare(msg))
+				if !success {
+					time.Sleep(peerCatchupSleepIntervalMS * time.Millisecond)
+					continue
+				}
+				for _, hash := range hashes {
+					var key [sha256.Size]byte
+					copy(key[:], hash)
+					ps.txs.Push(key)
+				}
+			}
+			next = last
+		} else if hash := sha256.Sum256(memTx.tx); !ps.txs.Has(hash) {
+			// send memTx, unless the peer has it
+			msg := &TxMessage{Tx: memTx.tx}
+			success := peer.Send(MempoolChannel, cdc.MustMarshalBinaryBare(msg))
+			if !success {
+				time.Sleep(peerCatchupSleepIntervalMS * time.Millisecond)
+				continue
+			}
+			ps.txs.Push(hash)
+		}
+
 		select {
 		case <-next.NextWaitChan():
 			// see the start of the for loop for nil check
@@ -166,15 +381,46 @@ func (memR *MempoolReactor) broadcastTxRoutine(peer p2p.Peer) {
 	}
 }
 
+// isPeerBehind returns true if the peer is more than MaxPeerHeightLag blocks
+// behind the mempool. The height of a peer which is fast syncing stays 0, as
+// it only sends its consensus state once it's caught up.
+func (memR *MempoolReactor) isPeerBehind(peerState PeerState) bool {
+	maxLag := memR.config.MaxPeerHeightLag
+	return maxLag > 0 && peerState.GetHeight() < memR.Mempool.Height()-maxLag
+}
+
+// txsToAnnounce returns the hashes of the txs from e on (up to
+// maxTxInvHashes) which the peer at the given height doesn't have, and the
+// last of these txs.
+func (memR *MempoolReactor) txsToAnnounce(e *clist.CElement, ps *mempoolPeer,
+	peerHeight int64) (last *clist.CElement, hashes [][]byte) {
+
+	for {
+		hash := sha256.Sum256(e.Value.(*mempoolTx).tx)
+		if !ps.txs.Has(hash) {
+			hashes = append(hashes, hash[:])
+		}
+		last = e
+		if e = e.Next(); e == nil || len(hashes) >= maxTxInvHashes ||
+			peerHeight < e.Value.(*mempoolTx).Height()-1 {
+			return last, hashes
+		}
+	}
+}
+
 //-----------------------------------------------------------------------------
 // Messages
 
 // MempoolMessage is a message sent or received by the MempoolReactor.
-type MempoolMessage interface{}
+type MempoolMessage interface {
+	ValidateBasic() error
+}
 
 func RegisterMempoolMessages(cdc *amino.Codec) {
 	cdc.RegisterInterface((*MempoolMessage)(nil), nil)
 	cdc.RegisterConcrete(&TxMessage{}, "tendermint/mempool/TxMessage", nil)
+	cdc.RegisterConcrete(&TxInvMessage{}, "tendermint/mempool/TxInvMessage", nil)
+	cdc.RegisterConcrete(&TxRequestMessage{}, "tendermint/mempool/TxRequestMessage", nil)
 }
 
 func decodeMsg(bz []byte) (msg MempoolMessage, err error) {
@@ -192,7 +438,60 @@ type TxMessage struct {
 	Tx types.Tx
 }
 
+// ValidateBasic performs basic validation.
+func (m *TxMessage) ValidateBasic() error {
+	return nil
+}
+
 // String returns a string representation of the TxMessage.
 func (m *TxMessage) String() string {
 	return fmt.Sprintf("[TxMessage %v]", m.Tx)
 }
+
+//-------------------------------------
+
+// TxInvMessage is a MempoolMessage announcing the hashes of the txs a peer has,
+// which can then be requested with a TxRequestMessage.
+type TxInvMessage struct {
+	Hashes [][]byte
+}
+
+// ValidateBasic performs basic validation.
+func (m *TxInvMessage) ValidateBasic() error {
+	return validateTxHashes(m.Hashes)
+}
+
+// String returns a string representation of the TxInvMessage.
+func (m *TxInvMessage) String() string {
+	return fmt.Sprintf("[TxInvMessage %d txs]", len(m.Hashes))
+}
+
+//-------------------------------------
+
+// TxRequestMessage is a MempoolMessage requesting the txs with the given
+// hashes, which are sent back in TxMessages.
+type TxRequestMessage struct {
+	Hashes [][]byte
+}
+
+// ValidateBasic performs basic validation.
+func (m *TxRequestMessage) ValidateBasic() error {
+	return validateTxHashes(m.Hashes)
+}
+
+// String returns a string representation of the TxRequestMessage.
+func (m *TxRequestMessage) String() string {
+	return fmt.Sprintf("[TxRequestMessage %d txs]", len(m.Hashes))
+}
+
+func validateTxHashes(hashes [][]byte) error {
+	if len(hashes) == 0 {
+		return errors.New("no hashes")
+	}
+	for i, hash := range hashes {
+		if len(hash) != sha256.Size {
+			return fmt.Errorf("wrong hash #%d size: expected %d, got %d", i, sha256.Size, len(hash))
+		}
+	}
+	return nil
+}
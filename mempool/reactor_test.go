@@ -1,14 +1,17 @@
 package mempool
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/fortytw2/leaktest"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/go-kit/kit/log/term"
 
@@ -17,6 +20,7 @@ import (
 
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/dummy"
 	"github.com/tendermint/tendermint/proxy"
 	"github.com/tendermint/tendermint/types"
 )
@@ -169,3 +173,149 @@ func TestBroadcastTxForPeerStopsWhenReactorStops(t *testing.T) {
 	// i.e. broadcastTxRoutine finishes when reactor is stopped
 	leaktest.CheckTimeout(t, 10*time.Second)()
 }
+
+// recordingPeer records the messages it's sent.
+type recordingPeer struct {
+	p2p.Peer
+	id p2p.ID
+
+	mtx  sync.Mutex
+	msgs []MempoolMessage
+}
+
+func (p *recordingPeer) ID() p2p.ID { return p.id }
+
+func (p *recordingPeer) Send(chID byte, msgBytes []byte) bool {
+	msg, err := decodeMsg(msgBytes)
+	if err != nil {
+		panic(err)
+	}
+	p.mtx.Lock()
+	p.msgs = append(p.msgs, msg)
+	p.mtx.Unlock()
+	return true
+}
+
+// waitMsgs waits for n messages, and returns the messages sent.
+func (p *recordingPeer) waitMsgs(n int) []MempoolMessage {
+	for i := 0; i < 200 && len(p.sentMsgs()) < n; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	return p.sentMsgs()
+}
+
+func (p *recordingPeer) sentMsgs() []MempoolMessage {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return append([]MempoolMessage(nil), p.msgs...)
+}
+
+func TestReactorTxInventory(t *testing.T) {
+	config := cfg.TestConfig()
+	app := kvstore.NewKVStoreApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+	memR := NewMempoolReactor(config.Mempool, mempool)
+	memR.SetLogger(log.TestingLogger())
+
+	peer1 := &recordingPeer{Peer: dummy.NewPeer(), id: "peer1"}
+	peer2 := &recordingPeer{Peer: dummy.NewPeer(), id: "peer2"}
+	for _, peer := range []*recordingPeer{peer1, peer2} {
+		peer.Start()
+		defer peer.Stop()
+		go memR.sendRoutine(peer, memR.getPeer(peer))
+	}
+	tx := types.Tx("tx")
+	hash := tx.Hash()
+	inv := cdc.MustMarshalBinaryBare(&TxInvMessage{Hashes: [][]byte{hash}})
+
+	// The announced tx is only requested from the first peer
+	memR.Receive(MempoolChannel, peer1, inv)
+	assert.Equal(t, []MempoolMessage{&TxRequestMessage{Hashes: [][]byte{hash}}}, peer1.waitMsgs(1))
+	memR.Receive(MempoolChannel, peer2, inv)
+	assert.Empty(t, peer2.waitMsgs(1))
+
+	// If it times out, it's requested from the second peer
+	memR.mtx.Lock()
+	memR.requested[sha256.Sum256(tx)].requestedAt = time.Now().Add(-txRequestTimeout)
+	memR.mtx.Unlock()
+	memR.Start()
+	defer memR.Stop()
+	assert.Equal(t, []MempoolMessage{&TxRequestMessage{Hashes: [][]byte{hash}}},
+		peer2.waitMsgs(1))
+
+	// It isn't requested once it's in the mempool
+	memR.Receive(MempoolChannel, peer1, cdc.MustMarshalBinaryBare(&TxMessage{Tx: tx}))
+	assert.True(t, mempool.HasTx(hash))
+	memR.mtx.Lock()
+	assert.Empty(t, memR.requested)
+	memR.mtx.Unlock()
+	memR.Receive(MempoolChannel, peer2, inv)
+	assert.Len(t, peer2.waitMsgs(2), 1)
+
+	// The requested txs are sent
+	memR.Receive(MempoolChannel, peer2, cdc.MustMarshalBinaryBare(&TxRequestMessage{Hashes: [][]byte{hash}}))
+	msgs := peer2.waitMsgs(2)
+	if assert.Len(t, msgs, 2) {
+		assert.Equal(t, &TxMessage{Tx: tx}, msgs[1])
+	}
+
+	// Both peers have the tx
+	assert.True(t, memR.getPeer(peer1).txs.Has(sha256.Sum256(tx)))
+	assert.True(t, memR.getPeer(peer2).txs.Has(sha256.Sum256(tx)))
+	memR.RemovePeer(peer1, nil)
+	assert.False(t, memR.getPeer(peer1).txs.Has(sha256.Sum256(tx)))
+}
+
+// syncingPeerState is a PeerState whose height can change.
+type syncingPeerState struct {
+	height int64
+}
+
+func (ps *syncingPeerState) GetHeight() int64 {
+	return atomic.LoadInt64(&ps.height)
+}
+
+func TestReactorPausesGossipToPeerBehind(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Mempool.MaxPeerHeightLag = 10
+	app := kvstore.NewKVStoreApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+	memR := NewMempoolReactor(config.Mempool, mempool)
+	memR.SetLogger(log.TestingLogger())
+	memR.Start()
+	defer memR.Stop()
+
+	mempool.Lock()
+	require.NoError(t, mempool.Update(20, nil, nil, nil))
+	mempool.Unlock()
+	tx := types.Tx("tx")
+	require.NoError(t, mempool.CheckTx(tx, nil))
+
+	// The peer is fast syncing
+	ps := &syncingPeerState{}
+	peer := &recordingPeer{Peer: dummy.NewPeer(), id: "peer"}
+	peer.Set(types.PeerStateKey, ps)
+	peer.Start()
+	defer peer.Stop()
+	go memR.broadcastTxRoutine(peer, memR.getPeer(peer))
+	assert.Empty(t, peer.waitMsgs(1))
+
+	// Still too far behind
+	atomic.StoreInt64(&ps.height, 9)
+	assert.Empty(t, peer.waitMsgs(1))
+
+	// Caught up
+	atomic.StoreInt64(&ps.height, 19)
+	assert.Equal(t, []MempoolMessage{&TxMessage{Tx: tx}}, peer.waitMsgs(1))
+}
+
+func TestTxHashesValidateBasic(t *testing.T) {
+	hash := types.Tx("tx").Hash()
+	assert.NoError(t, (&TxInvMessage{Hashes: [][]byte{hash}}).ValidateBasic())
+	assert.Error(t, (&TxInvMessage{}).ValidateBasic())
+	assert.Error(t, (&TxRequestMessage{Hashes: [][]byte{hash, hash[:10]}}).ValidateBasic())
+}
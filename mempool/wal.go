@@ -0,0 +1,155 @@
+package mempool
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	walSnapshotFile = "snapshot"
+	walJournalFile  = "journal"
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// mempoolWAL is the write-ahead log of the mempool: a snapshot of the txs of
+// the mempool, and a journal of the txs added since the snapshot. Writing a
+// new snapshot truncates the journal, so the WAL doesn't grow much bigger
+// than the mempool itself.
+//
+// Both files are sequences of records: the crc32c checksum of the tx, its
+// length and its bytes.
+type mempoolWAL struct {
+	mtx     sync.Mutex
+	dir     string
+	journal *os.File
+}
+
+// openWAL opens the WAL in dir, creating the directory if needed.
+func openWAL(dir string) (*mempoolWAL, error) {
+	if err := cmn.EnsureDir(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "Error ensuring Mempool WAL dir")
+	}
+	journal, err := os.OpenFile(filepath.Join(dir, walJournalFile), os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error opening Mempool WAL journal")
+	}
+	return &mempoolWAL{dir: dir, journal: journal}, nil
+}
+
+// Write appends the tx to the journal.
+func (wal *mempoolWAL) Write(tx types.Tx) error {
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+	_, err := wal.journal.Write(encodeWALRecord(tx))
+	return err
+}
+
+// Snapshot replaces the snapshot with the given txs, and truncates the
+// journal. The snapshot is written atomically, and a crash before the journal
+// is truncated only leaves duplicate txs in the WAL.
+func (wal *mempoolWAL) Snapshot(txs []types.Tx) error {
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+
+	var buf bytes.Buffer
+	for _, tx := range txs {
+		buf.Write(encodeWALRecord(tx))
+	}
+	if err := cmn.WriteFileAtomic(filepath.Join(wal.dir, walSnapshotFile), buf.Bytes(), 0600); err != nil {
+		return errors.Wrap(err, "Error writing Mempool WAL snapshot")
+	}
+	if err := wal.journal.Truncate(0); err != nil {
+		return errors.Wrap(err, "Error truncating Mempool WAL journal")
+	}
+	return nil
+}
+
+// Load returns the txs of the snapshot, then those of the journal, without
+// duplicates. A corrupted record (e.g. a partial write before a crash) ends
+// the txs read from its file, and is returned as the error alongside the
+// txs read so far.
+func (wal *mempoolWAL) Load() ([]types.Tx, error) {
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+
+	var (
+		txs     []types.Tx
+		seen    = make(map[string]bool)
+		loadErr error
+	)
+	for _, name := range []string{walSnapshotFile, walJournalFile} {
+		f, err := os.Open(filepath.Join(wal.dir, name))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		fileTxs, err := decodeWALRecords(bufio.NewReader(f))
+		f.Close()
+		if err != nil && loadErr == nil {
+			loadErr = errors.Wrapf(err, "Mempool WAL %s is corrupted", name)
+		}
+		for _, tx := range fileTxs {
+			if !seen[string(tx)] {
+				seen[string(tx)] = true
+				txs = append(txs, tx)
+			}
+		}
+	}
+	return txs, loadErr
+}
+
+// Close closes the journal.
+func (wal *mempoolWAL) Close() error {
+	wal.mtx.Lock()
+	defer wal.mtx.Unlock()
+	return wal.journal.Close()
+}
+
+func encodeWALRecord(tx types.Tx) []byte {
+	bz := make([]byte, 8+len(tx))
+	binary.BigEndian.PutUint32(bz[0:4], crc32.Checksum(tx, crc32c))
+	binary.BigEndian.PutUint32(bz[4:8], uint32(len(tx)))
+	copy(bz[8:], tx)
+	return bz
+}
+
+// decodeWALRecords reads the txs of rd until EOF, or the first corrupted
+// record.
+func decodeWALRecords(rd io.Reader) ([]types.Tx, error) {
+	var txs []types.Tx
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(rd, header); err == io.EOF {
+			return txs, nil
+		} else if err != nil {
+			return txs, fmt.Errorf("failed to read header: %v", err)
+		}
+		crc := binary.BigEndian.Uint32(header[0:4])
+		length := binary.BigEndian.Uint32(header[4:8])
+		if length > maxTxSize {
+			return txs, fmt.Errorf("length %d exceeded maximum tx size of %d bytes", length, maxTxSize)
+		}
+		tx := make([]byte, length)
+		if _, err := io.ReadFull(rd, tx); err != nil {
+			return txs, fmt.Errorf("failed to read tx: %v", err)
+		}
+		if actualCRC := crc32.Checksum(tx, crc32c); actualCRC != crc {
+			return txs, fmt.Errorf("checksums do not match: read: %v, actual: %v", crc, actualCRC)
+		}
+		txs = append(txs, tx)
+	}
+}
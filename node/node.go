@@ -3,7 +3,11 @@ package node
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	_ "net/http/pprof"
@@ -15,6 +19,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
+	"golang.org/x/net/netutil"
 
 	amino "github.com/tendermint/go-amino"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -25,11 +30,17 @@ import (
 	"github.com/tendermint/tendermint/evidence"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/eventlog"
 	"github.com/tendermint/tendermint/libs/log"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	mempl "github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/dnsseed"
 	"github.com/tendermint/tendermint/p2p/pex"
+	"github.com/tendermint/tendermint/p2p/portmux"
+	dialproxy "github.com/tendermint/tendermint/p2p/proxy"
+	"github.com/tendermint/tendermint/p2p/upnp"
+	"github.com/tendermint/tendermint/p2p/ws"
 	"github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/proxy"
 	rpccore "github.com/tendermint/tendermint/rpc/core"
@@ -38,8 +49,8 @@ import (
 	rpcserver "github.com/tendermint/tendermint/rpc/lib/server"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/state/txindex"
-	"github.com/tendermint/tendermint/state/txindex/kv"
 	"github.com/tendermint/tendermint/state/txindex/null"
+	"github.com/tendermint/tendermint/statesync"
 	"github.com/tendermint/tendermint/types"
 	tmtime "github.com/tendermint/tendermint/types/time"
 	"github.com/tendermint/tendermint/version"
@@ -47,6 +58,15 @@ import (
 
 //------------------------------------------------------------------------------
 
+// newQUICNetwork creates the network of the QUIC transport. It's only set when
+// built with the quic tag, see quic.go.
+var newQUICNetwork func() (p2p.TransportNetwork, error)
+
+// newOTLPTracer creates the tracer exporting the consensus trace events to an
+// OTLP endpoint, and the function flushing and stopping it. It's only set when
+// built with the otel tag, see otel.go.
+var newOTLPTracer func(endpoint, chainID string) (cs.Tracer, func() error, error)
+
 // DBContext specifies config information for loading a new DB.
 type DBContext struct {
 	ID     string
@@ -117,20 +137,24 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 	)
 }
 
-// MetricsProvider returns a consensus, p2p and mempool Metrics.
-type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics)
+// MetricsProvider returns a consensus, p2p, mempool, state, evidence, proxy and txindex Metrics.
+type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *evidence.Metrics, *proxy.Metrics, *txindex.Metrics)
 
 // DefaultMetricsProvider returns Metrics build using Prometheus client library
 // if Prometheus is enabled. Otherwise, it returns no-op Metrics.
 func DefaultMetricsProvider(config *cfg.InstrumentationConfig) MetricsProvider {
-	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics) {
+	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *evidence.Metrics, *proxy.Metrics, *txindex.Metrics) {
 		if config.Prometheus {
 			return cs.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				p2p.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				mempl.PrometheusMetrics(config.Namespace, "chain_id", chainID),
-				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID)
+				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				evidence.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				proxy.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				txindex.PrometheusMetrics(config.Namespace, "chain_id", chainID)
 		}
-		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics()
+		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics(), evidence.NopMetrics(), proxy.NopMetrics(),
+			txindex.NopMetrics()
 	}
 }
 
@@ -147,27 +171,41 @@ type Node struct {
 	privValidator types.PrivValidator // local node's validator key
 
 	// network
-	transport   *p2p.MultiplexTransport
-	sw          *p2p.Switch  // p2p connections
-	addrBook    pex.AddrBook // known peers
-	nodeInfo    p2p.NodeInfo
-	nodeKey     *p2p.NodeKey // our node privkey
-	isListening bool
+	transport     *p2p.MultiplexTransport
+	sw            *p2p.Switch               // p2p connections
+	addrBook      pex.AddrBook              // known peers
+	pexReactor    *pex.PEXReactor           // nil if the peer-exchange reactor is disabled
+	peerBehaviour *p2p.PeerBehaviourHistory // reported peer behaviour
+	nodeInfo      p2p.NodeInfo
+	nodeKey       *p2p.NodeKey // our node privkey
+	portMapping   *upnp.PortMapping
+	rpcMux        *portmux.Network   // nil unless the RPC is served on the p2p port
+	wsNetwork     *ws.Network        // tunnels connections over WebSockets
+	dnsSeeds      *dnsseed.Discovery // nil if no DNS seeds are configured
+	isListening   bool
+
+	// nil if peer behaviour is reported synchronously
+	bufferedPeerBehaviour *p2p.BufferedPeerBehaviour
 
 	// services
 	eventBus         *types.EventBus // pub/sub for services
 	stateDB          dbm.DB
 	blockStore       *bc.BlockStore         // store the blockchain to disk
 	bcReactor        *bc.BlockchainReactor  // for fast-syncing
-	mempoolReactor   *mempl.MempoolReactor  // for gossipping transactions
+	stateSyncReactor *statesync.Reactor     // for restoring the app from snapshots
+	stateSync        bool                   // whether the node should state sync on startup
+	mempool          rpccore.Mempool        // a NopMempool if mempool.type is nop
+	mempoolReactor   *mempl.MempoolReactor  // for gossipping transactions, nil if mempool.type is nop
 	consensusState   *cs.ConsensusState     // latest consensus state
 	consensusReactor *cs.ConsensusReactor   // for participating in the consensus
 	evidencePool     *evidence.EvidencePool // tracking evidence
 	proxyApp         proxy.AppConns         // connection to the application
 	rpcListeners     []net.Listener         // rpc servers
 	txIndexer        txindex.TxIndexer
+	txIndexSinks     []txindex.Sink
 	indexerService   *txindex.IndexerService
 	prometheusSrv    *http.Server
+	stopOTLPTracer   func() error // nil if the consensus traces aren't exported
 }
 
 // NewNode returns a new, ready to go, Tendermint Node.
@@ -211,8 +249,17 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
+	csMetrics, p2pMetrics, memplMetrics, smMetrics, evMetrics, proxyMetrics, txIndexMetrics := metricsProvider(genDoc.ChainID)
+
 	// Create the proxyApp and establish connections to the ABCI app (consensus, mempool, query).
-	proxyApp := proxy.NewAppConns(clientCreator)
+	proxyOptions := []proxy.AppConnsOption{
+		proxy.WithMetrics(proxyMetrics),
+		proxy.WithCallTimeout(config.ABCICallTimeout),
+	}
+	if config.ABCIReconnect {
+		proxyOptions = append(proxyOptions, proxy.WithReconnect())
+	}
+	proxyApp := proxy.NewAppConns(clientCreator, proxyOptions...)
 	proxyApp.SetLogger(logger.With("module", "proxy"))
 	if err := proxyApp.Start(); err != nil {
 		return nil, fmt.Errorf("Error starting proxy app connections: %v", err)
@@ -224,6 +271,10 @@ func NewNode(config *cfg.Config,
 	// but before it indexed the txs, or, endblocker panicked)
 	eventBus := types.NewEventBus()
 	eventBus.SetLogger(logger.With("module", "events"))
+	if config.RPC.EventLogWindowSize > 0 {
+		// for /events
+		eventBus.SetEventLog(eventlog.NewLog(config.RPC.EventLogWindowSize, config.RPC.EventLogMaxItems))
+	}
 
 	err = eventBus.Start()
 	if err != nil {
@@ -231,25 +282,18 @@ func NewNode(config *cfg.Config,
 	}
 
 	// Transaction indexing
-	var txIndexer txindex.TxIndexer
-	switch config.TxIndex.Indexer {
-	case "kv":
-		store, err := dbProvider(&DBContext{"tx_index", config})
-		if err != nil {
-			return nil, err
-		}
-		if config.TxIndex.IndexTags != "" {
-			txIndexer = kv.NewTxIndex(store, kv.IndexTags(splitAndTrimEmpty(config.TxIndex.IndexTags, ",", " ")))
-		} else if config.TxIndex.IndexAllTags {
-			txIndexer = kv.NewTxIndex(store, kv.IndexAllTags())
-		} else {
-			txIndexer = kv.NewTxIndex(store)
-		}
-	default:
-		txIndexer = &null.TxIndex{}
+	txIndexSinks, err := CreateTxIndexSinks(config, dbProvider, genDoc.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	// the RPC searches the first indexer
+	var txIndexer txindex.TxIndexer = &null.TxIndex{}
+	if len(txIndexSinks) > 0 {
+		txIndexer = txIndexSinks[0].TxIndexer
 	}
 
-	indexerService := txindex.NewIndexerService(txIndexer, eventBus)
+	indexerService := txindex.NewIndexerService(txIndexSinks, eventBus,
+		txindex.WithMetrics(txIndexMetrics), txindex.WithSinkQueueSize(config.TxIndex.SinkQueueSize))
 	indexerService.SetLogger(logger.With("module", "txindex"))
 
 	err = indexerService.Start()
@@ -257,20 +301,27 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
+	// If an empty node state syncs, the app is restored from a snapshot, so
+	// it is neither initialized from genesis nor replayed by the handshaker.
+	stateSync := config.StateSync.Enable && state.LastBlockHeight == 0 && blockStore.Height() == 0
+
 	// Create the handshaker, which calls RequestInfo, sets the AppVersion on the state,
 	// and replays any blocks as necessary to sync tendermint with the app.
 	consensusLogger := logger.With("module", "consensus")
-	handshaker := cs.NewHandshaker(stateDB, state, blockStore, genDoc)
-	handshaker.SetLogger(consensusLogger)
-	handshaker.SetEventBus(eventBus)
-	if err := handshaker.Handshake(proxyApp); err != nil {
-		return nil, fmt.Errorf("Error during handshake: %v", err)
-	}
+	if !stateSync {
+		handshaker := cs.NewHandshaker(stateDB, state, blockStore, genDoc)
+		handshaker.SetLogger(consensusLogger)
+		handshaker.SetEventBus(eventBus)
+		handshaker.SetAppHashMismatchFile(config.AppHashMismatchFile())
+		if err := handshaker.Handshake(proxyApp); err != nil {
+			return nil, fmt.Errorf("Error during handshake: %v", err)
+		}
 
-	// Reload the state. It will have the Version.Consensus.App set by the
-	// Handshake, and may have other modifications as well (ie. depending on
-	// what happened during block replay).
-	state = sm.LoadState(stateDB)
+		// Reload the state. It will have the Version.Consensus.App set by the
+		// Handshake, and may have other modifications as well (ie. depending on
+		// what happened during block replay).
+		state = sm.LoadState(stateDB)
+	}
 
 	// Log the version info.
 	logger.Info("Version info",
@@ -308,6 +359,14 @@ func NewNode(config *cfg.Config,
 		}
 	}
 
+	if err := startFromTrustedBlock(config, state, blockStore, fastSync); err != nil {
+		return nil, err
+	}
+	// The blocks after the restored snapshot are fast synced.
+	if stateSync && !fastSync {
+		return nil, errors.New("State sync requires fast_sync")
+	}
+
 	pubKey := privValidator.GetPubKey()
 	addr := pubKey.Address()
 	// Log whether this node is a validator or an observer
@@ -317,24 +376,19 @@ func NewNode(config *cfg.Config,
 		consensusLogger.Info("This node is not a validator", "addr", addr, "pubKey", pubKey)
 	}
 
-	csMetrics, p2pMetrics, memplMetrics, smMetrics := metricsProvider(genDoc.ChainID)
-
-	// Make MempoolReactor
-	mempool := mempl.NewMempool(
-		config.Mempool,
-		proxyApp.Mempool(),
-		state.LastBlockHeight,
-		mempl.WithMetrics(memplMetrics),
-		mempl.WithPreCheck(sm.TxPreCheck(state)),
-		mempl.WithPostCheck(sm.TxPostCheck(state)),
+	// Make MempoolReactor, unless the app disseminates the txs itself
+	var (
+		mempool        rpccore.Mempool = mempl.NopMempool{}
+		mempoolReactor *mempl.MempoolReactor
 	)
-	mempoolLogger := logger.With("module", "mempool")
-	mempool.SetLogger(mempoolLogger)
-	if config.Mempool.WalEnabled() {
-		mempool.InitWAL() // no need to have the mempool wal during tests
+	if config.Mempool.Type != cfg.MempoolTypeNop {
+		clistMempool, reactor, err := createMempoolAndMempoolReactor(
+			config, proxyApp, state, memplMetrics, eventBus, dbProvider, logger)
+		if err != nil {
+			return nil, err
+		}
+		mempool, mempoolReactor = clistMempool, reactor
 	}
-	mempoolReactor := mempl.NewMempoolReactor(config.Mempool, mempool)
-	mempoolReactor.SetLogger(mempoolLogger)
 
 	if config.Consensus.WaitForTxs() {
 		mempool.EnableTxsAvailable()
@@ -346,12 +400,19 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 	evidenceLogger := logger.With("module", "evidence")
-	evidencePool := evidence.NewEvidencePool(stateDB, evidenceDB)
+	evidencePool := evidence.NewEvidencePool(stateDB, evidenceDB, blockStore, evidence.WithMetrics(evMetrics))
 	evidencePool.SetLogger(evidenceLogger)
 	evidenceReactor := evidence.NewEvidenceReactor(evidencePool)
 	evidenceReactor.SetLogger(evidenceLogger)
 
 	blockExecLogger := logger.With("module", "state")
+	blockExecOptions := []sm.BlockExecutorOption{
+		sm.BlockExecutorWithMetrics(smMetrics),
+		sm.BlockExecutorWithABCIResponsesRetainHeights(config.ABCIResponsesRetainHeights),
+	}
+	if config.TxIndex.ProvisionalTxEvents {
+		blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithProvisionalTxEvents())
+	}
 	// make block executor for consensus and blockchain reactors to execute blocks
 	blockExec := sm.NewBlockExecutor(
 		stateDB,
@@ -359,14 +420,48 @@ func NewNode(config *cfg.Config,
 		proxyApp.Consensus(),
 		mempool,
 		evidencePool,
-		sm.BlockExecutorWithMetrics(smMetrics),
+		blockExecOptions...,
 	)
 
-	// Make BlockchainReactor
-	bcReactor := bc.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync)
+	// Make BlockchainReactor. When state syncing, it switches to fast sync
+	// once the snapshot is restored.
+	bcReactor := bc.NewBlockchainReactor(state.Copy(), blockExec, blockStore, fastSync && !stateSync)
 	bcReactor.SetLogger(logger.With("module", "blockchain"))
+	bcReactor.SetBackfillHeight(config.FastSyncBackfillHeight)
+	bcReactor.SetAppHashMismatchFile(config.AppHashMismatchFile())
 
 	// Make ConsensusReactor
+	csOptions := []cs.StateOption{cs.StateMetrics(csMetrics)}
+	if config.ABCIHaltOnTimeout {
+		csOptions = append(csOptions, cs.StateHaltOnUnresponsiveApp())
+	}
+	if config.ABCIReconnect {
+		csOptions = append(csOptions, cs.StateResyncApp(proxyApp.Reconnected(), func(state sm.State) error {
+			// The mempool and snapshot connections are held until the app is
+			// back to the state.
+			proxyApp.ResumeConsensus()
+			handshaker := cs.NewHandshaker(stateDB, state, blockStore, genDoc)
+			handshaker.SetLogger(consensusLogger)
+			handshaker.SetAppHashMismatchFile(config.AppHashMismatchFile())
+			if err := handshaker.ResyncApp(proxyApp); err != nil {
+				return err
+			}
+			proxyApp.ResumeAll()
+			return nil
+		}))
+	}
+	var stopOTLPTracer func() error
+	if config.Instrumentation.OTLPEndpoint != "" {
+		if newOTLPTracer == nil {
+			return nil, errors.New("OTLP trace export is not available, build with the otel tag")
+		}
+		tracer, stop, err := newOTLPTracer(config.Instrumentation.OTLPEndpoint, genDoc.ChainID)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create OTLP tracer")
+		}
+		csOptions = append(csOptions, cs.StateTracer(tracer))
+		stopOTLPTracer = stop
+	}
 	consensusState := cs.NewConsensusState(
 		config.Consensus,
 		state.Copy(),
@@ -374,7 +469,7 @@ func NewNode(config *cfg.Config,
 		blockStore,
 		mempool,
 		evidencePool,
-		cs.StateMetrics(csMetrics),
+		csOptions...,
 	)
 	consensusState.SetLogger(consensusLogger)
 	if privValidator != nil {
@@ -387,9 +482,33 @@ func NewNode(config *cfg.Config,
 	// consensusReactor will set it on consensusState and blockExecutor
 	consensusReactor.SetEventBus(eventBus)
 
+	// Make StateSyncReactor. It serves the app's snapshots to peers, and
+	// restores the app from theirs if state syncing.
+	stateSyncReactor := statesync.NewReactor(config.StateSync, proxyApp.Snapshot(), proxyApp.Query())
+	stateSyncReactor.SetLogger(logger.With("module", "statesync"))
+
 	p2pLogger := logger.With("module", "p2p")
+
+	// Map the p2p port on the NAT gateway, so peers can dial us. The mapping
+	// is set up before the node info, which advertises the external address.
+	externalAddr := config.P2P.ExternalAddress
+	portMapping := createPortMapping(config.P2P, p2pLogger)
+	// The node stops the mapping in OnStop, so remove it if the node can't be
+	// created.
+	created := false
+	defer func() {
+		if portMapping != nil && !created {
+			portMapping.Stop()
+		}
+	}()
+	if portMapping != nil && externalAddr == "" {
+		ip, port := portMapping.ExternalAddress()
+		externalAddr = fmt.Sprintf("tcp://%v:%d", ip, port)
+	}
+
 	nodeInfo, err := makeNodeInfo(
 		config,
+		externalAddr,
 		nodeKey.ID(),
 		txIndexer,
 		genDoc.ChainID,
@@ -415,6 +534,17 @@ func NewNode(config *cfg.Config,
 		connFilters = append(connFilters, p2p.ConnDuplicateIPFilter())
 	}
 
+	// Refuse connections from banned IPs before the handshake.
+	var banList *p2p.BanList
+	if config.P2P.BanDuration > 0 {
+		banList, err = p2p.NewBanList(config.P2P.BanListFile())
+		if err != nil {
+			return nil, errors.Wrap(err, "could not load ban list")
+		}
+		banList.SetLogger(p2pLogger.With("banlist", config.P2P.BanListFile()))
+		connFilters = append(connFilters, banList.ConnFilter())
+	}
+
 	// Filter peers by addr or pubkey with an ABCI query.
 	// If the query return code is OK, add peer.
 	if config.FilterPeers {
@@ -456,6 +586,70 @@ func NewNode(config *cfg.Config,
 	}
 
 	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
+	p2p.MultiplexTransportInboundConnLimits(
+		config.P2P.MaxNumInboundConnsPerIP,
+		config.P2P.InboundConnRatePerIP,
+	)(transport)
+	p2p.MultiplexTransportDialTimeout(config.P2P.DialTimeout)(transport)
+	p2p.MultiplexTransportHandshakeTimeout(config.P2P.HandshakeTimeout)(transport)
+	p2p.MultiplexTransportDialNoise(
+		config.P2P.SecretConnHandshake == cfg.SecretConnHandshakeNoise,
+	)(transport)
+	p2p.MultiplexTransportSecretConnRekey(
+		config.P2P.SecretConnRekeyBytes,
+		config.P2P.SecretConnRekeyInterval,
+	)(transport)
+
+	var network p2p.TransportNetwork = p2p.TCPNetwork{}
+	if config.P2P.Transport == cfg.P2PTransportQUIC {
+		if newQUICNetwork == nil {
+			return nil, errors.New("QUIC transport is not available, build with the quic tag")
+		}
+		network, err = newQUICNetwork()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create QUIC network")
+		}
+	}
+
+	// Share the p2p port with the RPC, see OnStart.
+	var rpcMux *portmux.Network
+	if config.P2P.MultiplexRPC {
+		rpcMux = portmux.NewNetwork(network)
+		network = rpcMux
+	}
+
+	// Dial peers through the configured proxies.
+	if config.P2P.DialProxy != "" {
+		network, err = dialproxy.NewNetwork(network, config.P2P.DialProxy)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create dial proxy network")
+		}
+	}
+	p2p.MultiplexTransportNetwork(network)(transport)
+
+	peerProxies, err := config.P2P.PeerDialProxyOverrides()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid peer_dial_proxies")
+	}
+	for id, proxyURL := range peerProxies {
+		var peerNetwork p2p.TransportNetwork = p2p.TCPNetwork{}
+		if proxyURL != "" {
+			peerNetwork, err = dialproxy.NewNetwork(p2p.TCPNetwork{}, proxyURL)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not create dial proxy network for peer %s", id)
+			}
+		}
+		p2p.MultiplexTransportPeerNetwork(p2p.ID(id), peerNetwork)(transport)
+	}
+
+	// Tunnel connections to the configured persistent peers over WebSockets.
+	wsNetwork, err := newWebSocketNetwork(config.P2P)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create WebSocket network")
+	}
+	for _, id := range splitAndTrimEmpty(config.P2P.WebSocketPeers, ",", " ") {
+		p2p.MultiplexTransportPeerNetwork(p2p.ID(id), wsNetwork)(transport)
+	}
 
 	// Setup Switch.
 	sw := p2p.NewSwitch(
@@ -463,12 +657,18 @@ func NewNode(config *cfg.Config,
 		transport,
 		p2p.WithMetrics(p2pMetrics),
 		p2p.SwitchPeerFilters(peerFilters...),
+		p2p.SwitchBanList(banList),
+		p2p.SwitchPeerTier(p2p.PeerTierValidator, splitPeerIDs(config.P2P.ValidatorPeerIDs)...),
+		p2p.SwitchPeerTier(p2p.PeerTierSentry, splitPeerIDs(config.P2P.SentryPeerIDs)...),
 	)
 	sw.SetLogger(p2pLogger)
-	sw.AddReactor("MEMPOOL", mempoolReactor)
+	if mempoolReactor != nil {
+		sw.AddReactor("MEMPOOL", mempoolReactor)
+	}
 	sw.AddReactor("BLOCKCHAIN", bcReactor)
 	sw.AddReactor("CONSENSUS", consensusReactor)
 	sw.AddReactor("EVIDENCE", evidenceReactor)
+	sw.AddReactor("STATESYNC", stateSyncReactor)
 	sw.SetNodeInfo(nodeInfo)
 	sw.SetNodeKey(nodeKey)
 
@@ -487,17 +687,28 @@ func NewNode(config *cfg.Config,
 	// If PEX is on, it should handle dialing the seeds. Otherwise the switch does it.
 	// Note we currently use the addrBook regardless at least for AddOurAddress
 	addrBook := pex.NewAddrBook(config.P2P.AddrBookFile(), config.P2P.AddrBookStrict)
+	if config.P2P.ASNTable != "" {
+		asns, err := pex.LoadASNTable(config.P2P.ASNTableFile())
+		if err != nil {
+			return nil, errors.Wrap(err, "could not load ASN table")
+		}
+		addrBook.SetASNResolver(asns)
+	}
 
 	// Add ourselves to addrbook to prevent dialing ourselves
 	addrBook.AddOurAddress(nodeInfo.NetAddress())
 
 	addrBook.SetLogger(p2pLogger.With("book", config.P2P.AddrBookFile()))
+	var pexReactor *pex.PEXReactor
 	if config.P2P.PexReactor {
 		// TODO persistent peers ? so we can have their DNS addrs saved
-		pexReactor := pex.NewPEXReactor(addrBook,
+		pexReactor = pex.NewPEXReactor(addrBook,
 			&pex.PEXReactorConfig{
-				Seeds:    splitAndTrimEmpty(config.P2P.Seeds, ",", " "),
-				SeedMode: config.P2P.SeedMode,
+				Seeds:             splitAndTrimEmpty(config.P2P.Seeds, ",", " "),
+				SeedMode:          config.P2P.SeedMode,
+				CrawlPeersPeriod:  config.P2P.SeedCrawlPeriod,
+				CrawlPeerInterval: config.P2P.SeedRecrawlInterval,
+				MaxAddressAge:     config.P2P.SeedMaxAddressAge,
 			})
 		pexReactor.SetLogger(logger.With("module", "pex"))
 		sw.AddReactor("PEX", pexReactor)
@@ -505,6 +716,68 @@ func NewNode(config *cfg.Config,
 
 	sw.SetAddrBook(addrBook)
 
+	var dnsSeeds *dnsseed.Discovery
+	if config.P2P.DNSSeeds != "" {
+		dnsSeeds = dnsseed.NewDiscovery(
+			addrBook,
+			splitAndTrimEmpty(config.P2P.DNSSeeds, ",", " "),
+			dnsseed.WithRefreshPeriod(config.P2P.DNSSeedsRefreshPeriod),
+		)
+		dnsSeeds.SetLogger(logger.With("module", "dnsseed"))
+	}
+
+	// Keep a history of reported peer behaviour for the RPC and record bad
+	// messages in the address book. Greylisted peers are stopped on their first
+	// error, established peers are only stopped (and banned) once their score
+	// crosses the threshold.
+	scoreConfig := p2p.DefaultPeerScoreConfig()
+	scoreConfig.DecayHalfLife = config.P2P.PeerScoreDecayHalfLife
+	scoreConfig.StopThreshold = config.P2P.PeerScoreStopThreshold
+	scoreWeights, err := config.P2P.PeerScoreWeightOverrides()
+	if err != nil {
+		return nil, err
+	}
+	if err := scoreConfig.SetWeights(scoreWeights); err != nil {
+		return nil, errors.Wrap(err, "invalid peer_score_weights")
+	}
+	stopPeerBehaviour := p2p.NewSwitchPeerBehaviour(sw)
+	banPeerBehaviour := stopPeerBehaviour
+	if banList != nil {
+		banPeerBehaviour = p2p.NewBanPeerBehaviour(
+			stopPeerBehaviour,
+			banList,
+			config.P2P.BanDuration,
+			config.P2P.BanIP,
+			sw.IsBanExempt,
+		)
+	}
+	peerBehaviour := p2p.NewPeerBehaviourHistory(
+		pex.NewAddrBookPeerBehaviour(
+			p2p.NewGreylistPeerBehaviour(
+				p2p.NewScoredPeerBehaviour(banPeerBehaviour, scoreConfig),
+				stopPeerBehaviour,
+				sw.IsGreylisted,
+			),
+			addrBook,
+		),
+		p2p.DefaultPeerBehaviourHistoryRecent,
+		p2p.DefaultPeerBehaviourHistoryPeers,
+	)
+	// Reactors report from their receive routines, so the chain runs on its
+	// own goroutine rather than under the locks of the reporting reactor.
+	var bufferedPeerBehaviour *p2p.BufferedPeerBehaviour
+	if config.P2P.PeerBehaviourBufferSize > 0 {
+		bufferedPeerBehaviour = p2p.NewBufferedPeerBehaviour(
+			peerBehaviour,
+			config.P2P.PeerBehaviourBufferSize,
+			p2p.BufferedPeerBehaviourMetrics(p2pMetrics),
+		)
+		bufferedPeerBehaviour.SetLogger(p2pLogger)
+		sw.SetPeerBehaviour(bufferedPeerBehaviour)
+	} else {
+		sw.SetPeerBehaviour(peerBehaviour)
+	}
+
 	// run the profile server
 	profileHost := config.ProfListenAddress
 	if profileHost != "" {
@@ -518,28 +791,88 @@ func NewNode(config *cfg.Config,
 		genesisDoc:    genDoc,
 		privValidator: privValidator,
 
-		transport: transport,
-		sw:        sw,
-		addrBook:  addrBook,
-		nodeInfo:  nodeInfo,
-		nodeKey:   nodeKey,
+		transport:     transport,
+		sw:            sw,
+		addrBook:      addrBook,
+		pexReactor:    pexReactor,
+		peerBehaviour: peerBehaviour,
+		nodeInfo:      nodeInfo,
+		nodeKey:       nodeKey,
+		portMapping:   portMapping,
+		rpcMux:        rpcMux,
+		wsNetwork:     wsNetwork,
+		dnsSeeds:      dnsSeeds,
+
+		bufferedPeerBehaviour: bufferedPeerBehaviour,
 
 		stateDB:          stateDB,
 		blockStore:       blockStore,
 		bcReactor:        bcReactor,
+		stateSyncReactor: stateSyncReactor,
+		stateSync:        stateSync,
+		mempool:          mempool,
 		mempoolReactor:   mempoolReactor,
 		consensusState:   consensusState,
 		consensusReactor: consensusReactor,
 		evidencePool:     evidencePool,
 		proxyApp:         proxyApp,
 		txIndexer:        txIndexer,
+		txIndexSinks:     txIndexSinks,
 		indexerService:   indexerService,
 		eventBus:         eventBus,
+		stopOTLPTracer:   stopOTLPTracer,
 	}
 	node.BaseService = *cmn.NewBaseService(logger, "Node", node)
+	created = true
 	return node, nil
 }
 
+// createMempoolAndMempoolReactor creates the mempool (replaying its WAL, if
+// enabled) and the reactor gossiping its txs.
+func createMempoolAndMempoolReactor(
+	config *cfg.Config,
+	proxyApp proxy.AppConns,
+	state sm.State,
+	memplMetrics *mempl.Metrics,
+	eventBus *types.EventBus,
+	dbProvider DBProvider,
+	logger log.Logger,
+) (*mempl.Mempool, *mempl.MempoolReactor, error) {
+	if err := proxy.SetCheckTxConcurrency(proxyApp.Mempool(), config.Mempool.CheckTxConcurrency); err != nil {
+		return nil, nil, err
+	}
+	mempoolOptions := []mempl.MempoolOption{
+		mempl.WithMetrics(memplMetrics),
+		mempl.WithPreCheck(sm.TxPreCheck(state)),
+		mempl.WithPostCheck(sm.TxPostCheck(state)),
+	}
+	if config.Mempool.PersistCache {
+		mempoolCacheDB, err := dbProvider(&DBContext{"mempool_cache", config})
+		if err != nil {
+			return nil, nil, err
+		}
+		mempoolOptions = append(mempoolOptions, mempl.WithCacheDB(mempoolCacheDB))
+	}
+	mempool := mempl.NewMempool(
+		config.Mempool,
+		proxyApp.Mempool(),
+		state.LastBlockHeight,
+		mempoolOptions...,
+	)
+	mempoolLogger := logger.With("module", "mempool")
+	mempool.SetLogger(mempoolLogger)
+	mempool.SetEventBus(eventBus)
+	if config.Mempool.WalEnabled() {
+		mempool.InitWAL() // no need to have the mempool wal during tests
+		if err := mempool.ReplayWAL(); err != nil {
+			return nil, nil, errors.Wrap(err, "error replaying the mempool WAL")
+		}
+	}
+	mempoolReactor := mempl.NewMempoolReactor(config.Mempool, mempool)
+	mempoolReactor.SetLogger(mempoolLogger)
+	return mempool, mempoolReactor, nil
+}
+
 // OnStart starts the Node. It implements cmn.Service.
 func (n *Node) OnStart() error {
 	now := tmtime.Now()
@@ -550,11 +883,11 @@ func (n *Node) OnStart() error {
 	}
 
 	// Add private IDs to addrbook to block those peers being added
-	n.addrBook.AddPrivateIDs(splitAndTrimEmpty(n.config.P2P.PrivatePeerIDs, ",", " "))
+	n.sw.SetPrivatePeerIDs(splitAndTrimEmpty(n.config.P2P.PrivatePeerIDs, ",", " "))
 
 	// Start the RPC server before the P2P server
 	// so we can eg. receive txs for the first block
-	if n.config.RPC.ListenAddress != "" {
+	if n.config.RPC.ListenAddress != "" || n.rpcMux != nil {
 		listeners, err := n.startRPC()
 		if err != nil {
 			return err
@@ -575,9 +908,42 @@ func (n *Node) OnStart() error {
 	if err := n.transport.Listen(*addr); err != nil {
 		return err
 	}
+	if n.rpcMux != nil {
+		listener, err := n.startMultiplexedRPC()
+		if err != nil {
+			return err
+		}
+		n.rpcListeners = append(n.rpcListeners, listener)
+	}
+	if n.config.P2P.WebSocketListenAddress != "" {
+		wsAddr, err := p2p.NewNetAddressStringWithOptionalID(n.config.P2P.WebSocketListenAddress)
+		if err != nil {
+			return err
+		}
+		if err := n.transport.ListenNetwork(n.wsNetwork, *wsAddr); err != nil {
+			return err
+		}
+	}
 
 	n.isListening = true
 
+	// Resolve the DNS seeds before the peer exchange looks at the addrbook,
+	// which has to be loaded from disk first.
+	if n.dnsSeeds != nil {
+		if err := n.addrBook.Start(); err != nil {
+			return err
+		}
+		if err := n.dnsSeeds.Start(); err != nil {
+			return err
+		}
+	}
+
+	if n.bufferedPeerBehaviour != nil {
+		if err := n.bufferedPeerBehaviour.Start(); err != nil {
+			return err
+		}
+	}
+
 	// Start the switch (the P2P server).
 	err = n.sw.Start()
 	if err != nil {
@@ -592,9 +958,64 @@ func (n *Node) OnStart() error {
 		}
 	}
 
+	// Run state sync, once the peers are being dialed.
+	if n.stateSync {
+		go n.startStateSync()
+	}
+
+	if n.config.ABCIHaltOnTimeout {
+		go n.haltOnUnresponsiveApp()
+	}
+
 	return nil
 }
 
+// haltOnUnresponsiveApp stops participating in consensus once a call to the
+// app times out. The node keeps running, e.g. to serve the RPC requests.
+func (n *Node) haltOnUnresponsiveApp() {
+	select {
+	case err := <-n.proxyApp.Unresponsive():
+		n.Logger.Error("Halting consensus, the app is unresponsive", "err", err)
+		if err := n.consensusReactor.Stop(); err != nil {
+			n.Logger.Error("Error stopping the consensus reactor", "err", err)
+		}
+	case <-n.Quit():
+	}
+}
+
+// startStateSync restores the app from a snapshot of the peers, saves the
+// state at its height and switches to fast sync from there. The node keeps
+// running without syncing if it fails.
+func (n *Node) startStateSync() {
+	c := n.config.StateSync
+	trustHash, err := c.TrustHashBytes()
+	if err != nil {
+		n.Logger.Error("State sync failed", "err", err)
+		return
+	}
+	stateProvider, err := statesync.NewLightClientStateProvider(n.genesisDoc.ChainID, c.RPCServers,
+		c.TrustHeight, trustHash, n.Logger.With("module", "lite"))
+	if err != nil {
+		n.Logger.Error("State sync failed to set up light client", "err", err)
+		return
+	}
+
+	state, err := n.stateSyncReactor.Sync(stateProvider, c.DiscoveryTime)
+	if err != nil {
+		n.Logger.Error("State sync failed", "err", err)
+		return
+	}
+	sm.BootstrapState(n.stateDB, state)
+	if err := n.blockStore.SetBase(state.LastBlockHeight); err != nil {
+		n.Logger.Error("Failed to set the block store base", "err", err)
+		return
+	}
+	if err := n.bcReactor.SwitchToFastSync(state); err != nil {
+		n.Logger.Error("Failed to switch to fast sync", "err", err)
+		return
+	}
+}
+
 // OnStop stops the Node. It implements cmn.Service.
 func (n *Node) OnStop() {
 	n.BaseService.OnStop()
@@ -604,13 +1025,34 @@ func (n *Node) OnStop() {
 	// first stop the non-reactor services
 	n.eventBus.Stop()
 	n.indexerService.Stop()
+	for _, sink := range n.txIndexSinks {
+		if closer, ok := sink.TxIndexer.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				n.Logger.Error("Error closing the indexer", "indexer", sink.Name, "err", err)
+			}
+		}
+	}
+
+	if n.dnsSeeds != nil {
+		n.dnsSeeds.Stop()
+	}
 
 	// now stop the reactors
 	// TODO: gracefully disconnect from peers.
 	n.sw.Stop()
 
+	if n.bufferedPeerBehaviour != nil {
+		n.bufferedPeerBehaviour.Stop()
+	}
+
+	// The addrbook was started for the DNS seeds. The PEX reactor stops it,
+	// unless it is disabled.
+	if n.dnsSeeds != nil && !n.config.P2P.PexReactor {
+		n.addrBook.Stop()
+	}
+
 	// stop mempool WAL
-	if n.config.Mempool.WalEnabled() {
+	if n.mempoolReactor != nil && n.config.Mempool.WalEnabled() {
 		n.mempoolReactor.Mempool.CloseWAL()
 	}
 
@@ -620,6 +1062,10 @@ func (n *Node) OnStop() {
 
 	n.isListening = false
 
+	if n.portMapping != nil {
+		n.portMapping.Stop()
+	}
+
 	// finally stop the listeners / external services
 	for _, l := range n.rpcListeners {
 		n.Logger.Info("Closing rpc listener", "listener", l)
@@ -638,6 +1084,23 @@ func (n *Node) OnStop() {
 			n.Logger.Error("Prometheus HTTP server Shutdown", "err", err)
 		}
 	}
+
+	if n.stopOTLPTracer != nil {
+		if err := n.stopOTLPTracer(); err != nil {
+			n.Logger.Error("Error stopping the OTLP tracer", "err", err)
+		}
+	}
+}
+
+// ReloadPeers applies the persistent_peers and private_peer_ids of the
+// config to the running node. Persistent peers which are no longer in the
+// config stay connected, but aren't redialed anymore.
+func (n *Node) ReloadPeers(config *cfg.P2PConfig) error {
+	if err := n.sw.SetPersistentPeers(splitAndTrimEmpty(config.PersistentPeers, ",", " ")); err != nil {
+		return err
+	}
+	n.sw.SetPrivatePeerIDs(splitAndTrimEmpty(config.PrivatePeerIDs, ",", " "))
+	return nil
 }
 
 // ConfigureRPC sets all variables in rpccore so they will serve
@@ -646,58 +1109,46 @@ func (n *Node) ConfigureRPC() {
 	rpccore.SetStateDB(n.stateDB)
 	rpccore.SetBlockStore(n.blockStore)
 	rpccore.SetConsensusState(n.consensusState)
-	rpccore.SetMempool(n.mempoolReactor.Mempool)
+	rpccore.SetMempool(n.mempool)
 	rpccore.SetEvidencePool(n.evidencePool)
 	rpccore.SetP2PPeers(n.sw)
 	rpccore.SetP2PTransport(n)
+	rpccore.SetPeerBehaviour(n.peerBehaviour)
 	pubKey := n.privValidator.GetPubKey()
 	rpccore.SetPubKey(pubKey)
 	rpccore.SetGenesisDoc(n.genesisDoc)
 	rpccore.SetAddrBook(n.addrBook)
+	if n.pexReactor != nil && n.config.P2P.SeedMode {
+		rpccore.SetSeedCrawler(n.pexReactor)
+	}
 	rpccore.SetProxyAppQuery(n.proxyApp.Query())
 	rpccore.SetTxIndexer(n.txIndexer)
 	rpccore.SetConsensusReactor(n.consensusReactor)
+	rpccore.SetBlockchainReactor(n.bcReactor)
 	rpccore.SetEventBus(n.eventBus)
 	rpccore.SetLogger(n.Logger.With("module", "rpc"))
 	rpccore.SetConfig(*n.config.RPC)
+	rpccore.SetAppHashMismatchFile(n.config.AppHashMismatchFile())
 }
 
 func (n *Node) startRPC() ([]net.Listener, error) {
 	n.ConfigureRPC()
 	listenAddrs := splitAndTrimEmpty(n.config.RPC.ListenAddress, ",", " ")
-	coreCodec := amino.NewCodec()
-	ctypes.RegisterAmino(coreCodec)
 
 	if n.config.RPC.Unsafe {
 		rpccore.AddUnsafeRoutes()
 	}
+	if n.config.RPC.AdminToken != "" {
+		rpccore.AddAdminRoutes()
+	}
 
 	// we may expose the rpc over both a unix and tcp socket
 	listeners := make([]net.Listener, len(listenAddrs))
 	for i, listenAddr := range listenAddrs {
-		mux := http.NewServeMux()
-		rpcLogger := n.Logger.With("module", "rpc-server")
-		wmLogger := rpcLogger.With("protocol", "websocket")
-		wm := rpcserver.NewWebsocketManager(rpccore.Routes, coreCodec,
-			rpcserver.OnDisconnect(func(remoteAddr string) {
-				err := n.eventBus.UnsubscribeAll(context.Background(), remoteAddr)
-				if err != nil && err != tmpubsub.ErrSubscriptionNotFound {
-					wmLogger.Error("Failed to unsubscribe addr from events", "addr", remoteAddr, "err", err)
-				}
-			}))
-		wm.SetLogger(wmLogger)
-		mux.HandleFunc("/websocket", wm.WebsocketHandler)
-		rpcserver.RegisterRPCFuncs(mux, rpccore.Routes, coreCodec, rpcLogger)
-
-		config := rpcserver.DefaultConfig()
-		config.MaxOpenConnections = n.config.RPC.MaxOpenConnections
-		// If necessary adjust global WriteTimeout to ensure it's greater than
-		// TimeoutBroadcastTxCommit.
-		// See https://github.com/tendermint/tendermint/issues/3435
-		if config.WriteTimeout <= n.config.RPC.TimeoutBroadcastTxCommit {
-			config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
+		config, err := n.rpcServerConfig()
+		if err != nil {
+			return nil, err
 		}
-
 		listener, err := rpcserver.Listen(
 			listenAddr,
 			config,
@@ -706,22 +1157,7 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 			return nil, err
 		}
 
-		var rootHandler http.Handler = mux
-		if n.config.RPC.IsCorsEnabled() {
-			corsMiddleware := cors.New(cors.Options{
-				AllowedOrigins: n.config.RPC.CORSAllowedOrigins,
-				AllowedMethods: n.config.RPC.CORSAllowedMethods,
-				AllowedHeaders: n.config.RPC.CORSAllowedHeaders,
-			})
-			rootHandler = corsMiddleware.Handler(mux)
-		}
-
-		go rpcserver.StartHTTPServer(
-			listener,
-			rootHandler,
-			rpcLogger,
-			config,
-		)
+		go n.serveRPC(listener, config)
 		listeners[i] = listener
 	}
 
@@ -741,6 +1177,120 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 	return listeners, nil
 }
 
+// startMultiplexedRPC serves the RPC on the HTTP connections accepted on the
+// p2p port. It must be called after the transport started listening.
+func (n *Node) startMultiplexedRPC() (net.Listener, error) {
+	config, err := n.rpcServerConfig()
+	if err != nil {
+		return nil, err
+	}
+	listener := n.rpcMux.HTTPListener()
+	if config.MaxOpenConnections > 0 {
+		listener = netutil.LimitListener(listener, config.MaxOpenConnections)
+	}
+
+	go n.serveRPC(listener, config)
+	return listener, nil
+}
+
+func (n *Node) rpcServerConfig() (*rpcserver.Config, error) {
+	config := rpcserver.DefaultConfig()
+	config.MaxOpenConnections = n.config.RPC.MaxOpenConnections
+	// If necessary adjust global WriteTimeout to ensure it's greater than
+	// TimeoutBroadcastTxCommit.
+	// See https://github.com/tendermint/tendermint/issues/3435
+	if config.WriteTimeout <= n.config.RPC.TimeoutBroadcastTxCommit {
+		config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
+	}
+	// and greater than TimeoutBatch, for the responses to the batch requests
+	if config.WriteTimeout <= n.config.RPC.TimeoutBatch {
+		config.WriteTimeout = n.config.RPC.TimeoutBatch + 1*time.Second
+	}
+	// Verify the client certificates, if any, so rpccore.Authorize
+	// authenticates the clients which sent one. The clients without one can
+	// still call the public methods.
+	if n.config.RPC.TLSClientCAFile != "" {
+		caCerts, err := ioutil.ReadFile(n.config.RPC.ClientCAFile())
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read the client CA certificates")
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caCerts) {
+			return nil, errors.Errorf("no certificate found in %s", n.config.RPC.ClientCAFile())
+		}
+		config.TLSConfig = &tls.Config{
+			ClientAuth: tls.VerifyClientCertIfGiven,
+			ClientCAs:  clientCAs,
+		}
+	}
+	return config, nil
+}
+
+// serveRPC serves the RPC routes on listener. It blocks until the listener is
+// closed.
+func (n *Node) serveRPC(listener net.Listener, config *rpcserver.Config) {
+	coreCodec := amino.NewCodec()
+	ctypes.RegisterAmino(coreCodec)
+
+	batchLimits := rpcserver.BatchLimits{
+		MaxSize: n.config.RPC.MaxBatchSize,
+		Timeout: n.config.RPC.TimeoutBatch,
+	}
+
+	mux := http.NewServeMux()
+	rpcLogger := n.Logger.With("module", "rpc-server")
+	wmLogger := rpcLogger.With("protocol", "websocket")
+	wm := rpcserver.NewWebsocketManager(rpccore.Routes, coreCodec,
+		rpcserver.OnDisconnect(func(remoteAddr string) {
+			err := n.eventBus.UnsubscribeAll(context.Background(), remoteAddr)
+			if err != nil && err != tmpubsub.ErrSubscriptionNotFound {
+				wmLogger.Error("Failed to unsubscribe addr from events", "addr", remoteAddr, "err", err)
+			}
+		}),
+		rpcserver.WSBatchLimits(batchLimits))
+	wm.SetLogger(wmLogger)
+	wm.SetAuthorizer(rpccore.Authorize)
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	// the streams end before the write timeout, the clients then reconnect
+	eventStream := rpccore.EventStreamHandler(coreCodec, config.WriteTimeout-time.Second)
+	mux.HandleFunc("/event_stream", func(w http.ResponseWriter, r *http.Request) {
+		if err := rpccore.Authorize(r, "event_stream"); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		eventStream(w, r)
+	})
+	rpcserver.RegisterAuthorizedRPCFuncs(mux, rpccore.Routes, coreCodec, rpcLogger, batchLimits, rpccore.Authorize)
+
+	var rootHandler http.Handler = mux
+	if n.config.RPC.IsCorsEnabled() {
+		corsMiddleware := cors.New(cors.Options{
+			AllowedOrigins: n.config.RPC.CORSAllowedOrigins,
+			AllowedMethods: n.config.RPC.CORSAllowedMethods,
+			AllowedHeaders: n.config.RPC.CORSAllowedHeaders,
+		})
+		rootHandler = corsMiddleware.Handler(mux)
+	}
+
+	if n.config.RPC.IsTLSEnabled() {
+		rpcserver.StartHTTPAndTLSServer(
+			listener,
+			rootHandler,
+			n.config.RPC.CertFile(),
+			n.config.RPC.KeyFile(),
+			rpcLogger,
+			config,
+		)
+	} else {
+		rpcserver.StartHTTPServer(
+			listener,
+			rootHandler,
+			rpcLogger,
+			config,
+		)
+	}
+}
+
 // startPrometheusServer starts a Prometheus HTTP server, listening for metrics
 // collectors on addr.
 func (n *Node) startPrometheusServer(addr string) *http.Server {
@@ -782,7 +1332,8 @@ func (n *Node) ConsensusReactor() *cs.ConsensusReactor {
 	return n.consensusReactor
 }
 
-// MempoolReactor returns the Node's MempoolReactor.
+// MempoolReactor returns the Node's MempoolReactor, or nil if the mempool is
+// disabled (mempool.type is nop).
 func (n *Node) MempoolReactor() *mempl.MempoolReactor {
 	return n.mempoolReactor
 }
@@ -835,8 +1386,46 @@ func (n *Node) NodeInfo() p2p.NodeInfo {
 	return n.nodeInfo
 }
 
+// createPortMapping maps the p2p port on the NAT gateway using UPnP or
+// NAT-PMP if p2p.upnp is enabled. Failures aren't fatal, the node just can't
+// be dialed from outside of its network unless configured otherwise.
+func createPortMapping(config *cfg.P2PConfig, logger log.Logger) *upnp.PortMapping {
+	if !config.UPNP {
+		return nil
+	}
+
+	addr, err := p2p.NewNetAddressStringWithOptionalID(config.ListenAddress)
+	if err != nil {
+		logger.Error("Invalid p2p listen address, not mapping port", "err", err)
+		return nil
+	}
+
+	nat, err := upnp.DiscoverNAT()
+	if err != nil {
+		logger.Error("Failed to discover NAT gateway", "err", err)
+		return nil
+	}
+
+	protocol := "tcp"
+	if config.Transport == cfg.P2PTransportQUIC {
+		protocol = "udp"
+	}
+
+	portMapping := upnp.NewPortMapping(nat, protocol, int(addr.Port), upnp.DefaultPortMappingLease)
+	portMapping.SetLogger(logger)
+	if err := portMapping.Start(); err != nil {
+		logger.Error("Failed to map p2p port on NAT gateway", "err", err)
+		return nil
+	}
+
+	ip, port := portMapping.ExternalAddress()
+	logger.Info("Mapped p2p port on NAT gateway", "protocol", protocol, "external", fmt.Sprintf("%v:%d", ip, port))
+	return portMapping
+}
+
 func makeNodeInfo(
 	config *cfg.Config,
+	externalAddr string,
 	nodeID p2p.ID,
 	txIndexer txindex.TxIndexer,
 	chainID string,
@@ -854,7 +1443,6 @@ func makeNodeInfo(
 		Channels: []byte{
 			bc.BlockchainChannel,
 			cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
-			mempl.MempoolChannel,
 			evidence.EvidenceChannel,
 		},
 		Moniker: config.Moniker,
@@ -864,11 +1452,27 @@ func makeNodeInfo(
 		},
 	}
 
+	if config.Mempool.Type != cfg.MempoolTypeNop {
+		nodeInfo.Channels = append(nodeInfo.Channels, mempl.MempoolChannel)
+	}
+
 	if config.P2P.PexReactor {
 		nodeInfo.Channels = append(nodeInfo.Channels, pex.PexChannel)
 	}
 
-	lAddr := config.P2P.ExternalAddress
+	nodeInfo.Features = p2p.SupportedFeatures()
+	nodeInfo.Compression.Codecs = p2p.CompressionCodecs(config.P2P.Compression)
+	if config.P2P.Compression != "" {
+		compressChannels := map[string]byte{
+			"blockchain": bc.BlockchainChannel,
+			"mempool":    mempl.MempoolChannel,
+		}
+		for _, name := range config.P2P.CompressChannels {
+			nodeInfo.Compression.Channels = append(nodeInfo.Compression.Channels, compressChannels[name])
+		}
+	}
+
+	lAddr := externalAddr
 
 	if lAddr == "" {
 		lAddr = config.P2P.ListenAddress
@@ -909,6 +1513,33 @@ func saveGenesisDoc(db dbm.DB, genDoc *types.GenesisDoc) {
 	db.SetSync(genesisDocKey, bytes)
 }
 
+// startFromTrustedBlock makes the empty block store start at the trusted
+// block if the state was restored from a snapshot at its height, so the node
+// fast syncs from there rather than from genesis.
+func startFromTrustedBlock(config *cfg.Config, state sm.State, blockStore *bc.BlockStore, fastSync bool) error {
+	if blockStore.Height() != 0 || state.LastBlockHeight == 0 {
+		return nil
+	}
+	if config.FastSyncTrustedHeight == 0 {
+		return fmt.Errorf("State is at height %d, but the block store is empty. "+
+			"Set fast_sync_trusted_height and fast_sync_trusted_hash to fast sync from a snapshot",
+			state.LastBlockHeight)
+	}
+	if !fastSync {
+		return errors.New("Starting from a trusted snapshot requires fast_sync")
+	}
+	trustedHash, err := config.FastSyncTrustedHashBytes()
+	if err != nil {
+		return err
+	}
+	if state.LastBlockHeight != config.FastSyncTrustedHeight ||
+		!bytes.Equal(state.LastBlockID.Hash, trustedHash) {
+		return fmt.Errorf("State at height %d with last block %X doesn't match trusted block at height %d with hash %X",
+			state.LastBlockHeight, state.LastBlockID.Hash, config.FastSyncTrustedHeight, trustedHash)
+	}
+	return blockStore.SetBase(state.LastBlockHeight)
+}
+
 func createAndStartPrivValidatorSocketClient(
 	listenAddr string,
 	logger log.Logger,
@@ -942,6 +1573,36 @@ func createAndStartPrivValidatorSocketClient(
 	return pvsc, nil
 }
 
+// splitPeerIDs returns the IDs in a comma separated list of node IDs or
+// ID@host:port addresses.
+func splitPeerIDs(s string) []p2p.ID {
+	ids := []p2p.ID{}
+	for _, addr := range splitAndTrimEmpty(s, ",", " ") {
+		if i := strings.Index(addr, "://"); i >= 0 {
+			addr = addr[i+3:]
+		}
+		ids = append(ids, p2p.ID(strings.Split(addr, "@")[0]))
+	}
+	return ids
+}
+
+// newWebSocketNetwork returns the network of the websocket_peers and the
+// websocket_laddr, over TLS with websocket_tls.
+func newWebSocketNetwork(config *cfg.P2PConfig) (*ws.Network, error) {
+	if !config.WebSocketTLS {
+		return ws.NewNetwork(), nil
+	}
+	var cert *tls.Certificate
+	if config.WebSocketTLSCertFile != "" {
+		loaded, err := tls.LoadX509KeyPair(config.WebSocketCertFile(), config.WebSocketKeyFile())
+		if err != nil {
+			return nil, errors.Wrap(err, "could not load the WebSocket TLS certificate")
+		}
+		cert = &loaded
+	}
+	return ws.NewTLSNetwork(cert)
+}
+
 // splitAndTrimEmpty slices s into all subslices separated by sep and returns a
 // slice of the string s with all leading and trailing Unicode code points
 // contained in cutset removed. If sep is empty, SplitAndTrim splits after each
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"syscall"
 	"testing"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/abci/example/kvstore"
+	bc "github.com/tendermint/tendermint/blockchain"
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/evidence"
@@ -124,6 +126,42 @@ func TestNodeSetAppVersion(t *testing.T) {
 	assert.Equal(t, n.nodeInfo.(p2p.DefaultNodeInfo).ProtocolVersion.App, appVersion)
 }
 
+func TestNodeNopMempool(t *testing.T) {
+	config := cfg.ResetTestRoot("node_nop_mempool_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Mempool.Type = cfg.MempoolTypeNop
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+
+	// no mempool reactor, and the mempool channel isn't advertised
+	assert.Nil(t, n.MempoolReactor())
+	assert.Nil(t, n.Switch().Reactor("MEMPOOL"))
+	assert.NotContains(t, n.nodeInfo.(p2p.DefaultNodeInfo).Channels, mempl.MempoolChannel)
+	assert.Equal(t, mempl.ErrMempoolDisabled, n.mempool.CheckTx([]byte("tx"), nil))
+}
+
+func TestNodeMultiplexRPC(t *testing.T) {
+	config := cfg.ResetTestRoot("node_multiplex_rpc_test")
+	defer os.RemoveAll(config.RootDir)
+	config.RPC.ListenAddress = ""
+	config.RPC.GRPCListenAddress = ""
+	config.P2P.ListenAddress = "tcp://127.0.0.1:0"
+	config.P2P.MultiplexRPC = true
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer n.Stop()
+
+	// The RPC is served on the p2p port.
+	addr := n.rpcMux.HTTPListener().Addr().String()
+	res, err := http.Get("http://" + addr + "/health")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
 func TestNodeSetPrivValTCP(t *testing.T) {
 	addr := "tcp://" + testFreeAddr(t)
 
@@ -220,6 +258,8 @@ func TestCreateProposalBlock(t *testing.T) {
 	state, stateDB := state(1, height)
 	maxBytes := 16384
 	state.ConsensusParams.Block.MaxBytes = int64(maxBytes)
+	maxEvidenceBytes := maxBytes / 10
+	state.ConsensusParams.Evidence.MaxBytes = int64(maxEvidenceBytes)
 	proposerAddr, _ := state.Validators.GetByIndex(0)
 
 	// Make Mempool
@@ -238,13 +278,13 @@ func TestCreateProposalBlock(t *testing.T) {
 	types.RegisterMockEvidencesGlobal() // XXX!
 	evidence.RegisterMockEvidences()
 	evidenceDB := dbm.NewMemDB()
-	evidencePool := evidence.NewEvidencePool(stateDB, evidenceDB)
+	evidencePool := evidence.NewEvidencePool(stateDB, evidenceDB, bc.NewBlockStore(dbm.NewMemDB()))
 	evidencePool.SetLogger(logger)
 
 	// fill the evidence pool with more evidence
 	// than can fit in a block
 	minEvSize := 12
-	numEv := (maxBytes / types.MaxEvidenceBytesDenominator) / minEvSize
+	numEv := maxEvidenceBytes / minEvSize
 	for i := 0; i < numEv; i++ {
 		ev := types.NewMockRandomGoodEvidence(1, proposerAddr, cmn.RandBytes(minEvSize))
 		err := evidencePool.AddEvidence(ev)
@@ -277,6 +317,10 @@ func TestCreateProposalBlock(t *testing.T) {
 
 	err = blockExec.ValidateBlock(state, block)
 	assert.NoError(t, err)
+
+	// the evidence is limited by size
+	assert.True(t, len(block.Evidence.Evidence) < numEv)
+	assert.True(t, block.Evidence.Evidence.ByteSize() <= state.ConsensusParams.Evidence.MaxBytes)
 }
 
 func state(nVals int, height int64) (sm.State, dbm.DB) {
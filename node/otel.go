@@ -0,0 +1,35 @@
+// +build otel
+
+package node
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	cs "github.com/tendermint/tendermint/consensus"
+	"github.com/tendermint/tendermint/consensus/otel"
+)
+
+func init() {
+	newOTLPTracer = func(endpoint, chainID string) (cs.Tracer, func() error, error) {
+		exporter, err := otlptracegrpc.New(context.Background(), otlptracegrpc.WithEndpoint(endpoint))
+		if err != nil {
+			return nil, nil, err
+		}
+		provider := sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(resource.NewSchemaless(
+				attribute.String("service.name", "tendermint"),
+				attribute.String("chain_id", chainID),
+			)),
+		)
+		stop := func() error {
+			return provider.Shutdown(context.Background())
+		}
+		return otel.NewTracer(provider.Tracer("github.com/tendermint/tendermint/consensus")), stop, nil
+	}
+}
@@ -0,0 +1,18 @@
+// +build quic
+
+package node
+
+import (
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/quic"
+)
+
+func init() {
+	newQUICNetwork = func() (p2p.TransportNetwork, error) {
+		network, err := quic.NewNetwork()
+		if err != nil {
+			return nil, err
+		}
+		return network, nil
+	}
+}
@@ -0,0 +1,112 @@
+package node
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/state/txindex/kv"
+	"github.com/tendermint/tendermint/state/txindex/psql"
+)
+
+// TxIndexerCreator creates a tx indexer from the config of the node. The
+// rows of the psql indexer have the chain ID.
+type TxIndexerCreator func(config *cfg.Config, dbProvider DBProvider, chainID string) (txindex.TxIndexer, error)
+
+var (
+	txIndexersMtx sync.RWMutex
+	txIndexers    = map[string]TxIndexerCreator{}
+)
+
+func init() {
+	RegisterTxIndexer("kv", createKVTxIndexer)
+	RegisterTxIndexer("psql", createPsqlTxIndexer)
+}
+
+// RegisterTxIndexer registers a tx indexer under the name, so that
+// `tx_index.indexer` can list it (e.g. `indexer = "kv,<name>"`) to index the
+// blocks and txs with it too, e.g. to an external service. It is meant to be
+// called from the init function of a package compiled into the binary.
+//
+// It panics if the name is empty, "null" or already registered.
+func RegisterTxIndexer(name string, creator TxIndexerCreator) {
+	if name == "" || name == "null" {
+		panic(fmt.Sprintf("node: RegisterTxIndexer with the name %q", name))
+	}
+	if creator == nil {
+		panic(fmt.Sprintf("node: RegisterTxIndexer %q with a nil creator", name))
+	}
+
+	txIndexersMtx.Lock()
+	defer txIndexersMtx.Unlock()
+	if _, ok := txIndexers[name]; ok {
+		panic(fmt.Sprintf("node: tx indexer %q registered twice", name))
+	}
+	txIndexers[name] = creator
+}
+
+// RegisteredTxIndexers returns the names of the registered tx indexers,
+// sorted.
+func RegisteredTxIndexers() []string {
+	txIndexersMtx.RLock()
+	defer txIndexersMtx.RUnlock()
+	names := make([]string, 0, len(txIndexers))
+	for name := range txIndexers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CreateTxIndexSinks returns the indexers listed in the config
+// (tx_index.indexer), in order, or none if indexing is disabled.
+func CreateTxIndexSinks(config *cfg.Config, dbProvider DBProvider, chainID string) ([]txindex.Sink, error) {
+	var sinks []txindex.Sink
+	for _, name := range splitAndTrimEmpty(config.TxIndex.Indexer, ",", " ") {
+		if name == "null" {
+			continue
+		}
+		txIndexersMtx.RLock()
+		creator, ok := txIndexers[name]
+		txIndexersMtx.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("Unknown indexer %q, expected one of %v", name, RegisteredTxIndexers())
+		}
+		txIndexer, err := creator(config, dbProvider, chainID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create the %s indexer", name)
+		}
+		sinks = append(sinks, txindex.Sink{Name: name, TxIndexer: txIndexer})
+	}
+	return sinks, nil
+}
+
+func createKVTxIndexer(config *cfg.Config, dbProvider DBProvider, chainID string) (txindex.TxIndexer, error) {
+	store, err := dbProvider(&DBContext{"tx_index", config})
+	if err != nil {
+		return nil, err
+	}
+	options := []func(*kv.TxIndex){kv.MaxSearchResults(config.TxIndex.MaxSearchResults)}
+	if config.TxIndex.IndexTags != "" {
+		options = append(options, kv.IndexTags(splitAndTrimEmpty(config.TxIndex.IndexTags, ",", " ")))
+	} else if config.TxIndex.IndexAllTags {
+		options = append(options, kv.IndexAllTags())
+	}
+	if config.TxIndex.ExcludeTags != "" {
+		options = append(options, kv.ExcludeTags(splitAndTrimEmpty(config.TxIndex.ExcludeTags, ",", " ")))
+	}
+	return kv.NewTxIndex(store, options...), nil
+}
+
+func createPsqlTxIndexer(config *cfg.Config, dbProvider DBProvider, chainID string) (txindex.TxIndexer, error) {
+	txIndexer, err := psql.NewTxIndex(config.TxIndex.PsqlConn, chainID,
+		psql.ExcludeTags(splitAndTrimEmpty(config.TxIndex.ExcludeTags, ",", " ")))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to the psql indexer database")
+	}
+	return txIndexer, nil
+}
@@ -0,0 +1,60 @@
+package node
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/state/txindex/kv"
+	"github.com/tendermint/tendermint/state/txindex/null"
+)
+
+type registryTestTxIndexer struct {
+	null.TxIndex
+	chainID string
+}
+
+func TestCreateTxIndexSinks(t *testing.T) {
+	config := cfg.ResetTestRoot("node_txindex_test")
+	defer os.RemoveAll(config.RootDir)
+
+	RegisterTxIndexer("registry_test", func(config *cfg.Config, dbProvider DBProvider, chainID string) (txindex.TxIndexer, error) {
+		return &registryTestTxIndexer{chainID: chainID}, nil
+	})
+	assert.Panics(t, func() {
+		RegisterTxIndexer("registry_test", func(*cfg.Config, DBProvider, string) (txindex.TxIndexer, error) {
+			return &null.TxIndex{}, nil
+		})
+	})
+	assert.Panics(t, func() {
+		RegisterTxIndexer("null", func(*cfg.Config, DBProvider, string) (txindex.TxIndexer, error) {
+			return &null.TxIndex{}, nil
+		})
+	})
+	names := RegisteredTxIndexers()
+	for _, name := range []string{"kv", "psql", "registry_test"} {
+		assert.Contains(t, names, name)
+	}
+
+	config.TxIndex.Indexer = "kv, registry_test"
+	sinks, err := CreateTxIndexSinks(config, DefaultDBProvider, "test-chain")
+	require.NoError(t, err)
+	require.Len(t, sinks, 2)
+	assert.Equal(t, "kv", sinks[0].Name)
+	assert.IsType(t, &kv.TxIndex{}, sinks[0].TxIndexer)
+	assert.Equal(t, "registry_test", sinks[1].Name)
+	assert.Equal(t, &registryTestTxIndexer{chainID: "test-chain"}, sinks[1].TxIndexer)
+
+	config.TxIndex.Indexer = "null"
+	sinks, err = CreateTxIndexSinks(config, DefaultDBProvider, "test-chain")
+	require.NoError(t, err)
+	assert.Empty(t, sinks)
+
+	config.TxIndex.Indexer = "kv,unknown"
+	_, err = CreateTxIndexSinks(config, DefaultDBProvider, "test-chain")
+	assert.Error(t, err)
+}
@@ -0,0 +1,282 @@
+package p2p
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// BanList keeps track of banned peer IDs and IPs. Every ban expires after the
+// duration it was issued for. If the BanList was created with a file path,
+// it is saved to disk whenever a ban is added or lifted, so bans survive a
+// restart of the node.
+type BanList struct {
+	mtx      sync.Mutex
+	filePath string
+	ids      map[ID]time.Time     // ID -> ban expiry
+	ips      map[string]time.Time // IP -> ban expiry
+	logger   log.Logger
+
+	now func() time.Time // overridden in tests
+}
+
+type banJSON struct {
+	ID    ID        `json:"id,omitempty"`
+	IP    string    `json:"ip,omitempty"`
+	Until time.Time `json:"until"`
+}
+
+type banListJSON struct {
+	IDs []banJSON `json:"ids"`
+	IPs []banJSON `json:"ips"`
+}
+
+// NewBanList returns a BanList persisted at filePath. Bans already stored in
+// the file are loaded, unless they have expired. An empty filePath yields an
+// in-memory BanList.
+func NewBanList(filePath string) (*BanList, error) {
+	bl := &BanList{
+		filePath: filePath,
+		ids:      make(map[ID]time.Time),
+		ips:      make(map[string]time.Time),
+		logger:   log.NewNopLogger(),
+		now:      time.Now,
+	}
+	if filePath != "" {
+		if err := bl.loadFromFile(filePath); err != nil {
+			return nil, err
+		}
+	}
+	return bl, nil
+}
+
+// SetLogger sets the logger.
+func (bl *BanList) SetLogger(l log.Logger) {
+	bl.logger = l
+}
+
+// Ban bans both the ID and the remote IP of the peer for the given duration.
+func (bl *BanList) Ban(peer Peer, duration time.Duration) {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	bl.banID(peer.ID(), duration)
+	if ip := peer.RemoteIP(); ip != nil {
+		bl.banIP(ip, duration)
+	}
+	bl.save()
+}
+
+// BanID bans the ID for the given duration. If the ID is already banned for
+// longer, the ban is left untouched.
+func (bl *BanList) BanID(id ID, duration time.Duration) {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	bl.banID(id, duration)
+	bl.save()
+}
+
+// BanIP bans the IP for the given duration. If the IP is already banned for
+// longer, the ban is left untouched.
+func (bl *BanList) BanIP(ip net.IP, duration time.Duration) {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	bl.banIP(ip, duration)
+	bl.save()
+}
+
+// UnbanID lifts the ban on the ID.
+func (bl *BanList) UnbanID(id ID) {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	delete(bl.ids, id)
+	bl.save()
+}
+
+// UnbanIP lifts the ban on the IP.
+func (bl *BanList) UnbanIP(ip net.IP) {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	delete(bl.ips, ip.String())
+	bl.save()
+}
+
+// IsIDBanned returns true if the ID is currently banned.
+func (bl *BanList) IsIDBanned(id ID) bool {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	until, ok := bl.ids[id]
+	return ok && bl.now().Before(until)
+}
+
+// IsIPBanned returns true if the IP is currently banned.
+func (bl *BanList) IsIPBanned(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+	until, ok := bl.ips[ip.String()]
+	return ok && bl.now().Before(until)
+}
+
+// IsBanned returns true if either the ID or the IP is currently banned. ip
+// may be nil.
+func (bl *BanList) IsBanned(id ID, ip net.IP) bool {
+	return bl.IsIDBanned(id) || bl.IsIPBanned(ip)
+}
+
+// ConnFilter returns a ConnFilterFunc which refuses connections from banned
+// IPs before the handshake is performed.
+func (bl *BanList) ConnFilter() ConnFilterFunc {
+	return func(_ ConnSet, _ net.Conn, ips []net.IP) error {
+		for _, ip := range ips {
+			if bl.IsIPBanned(ip) {
+				return ErrSwitchBannedPeer{IP: ip}
+			}
+		}
+		return nil
+	}
+}
+
+// CONTRACT: bl.mtx must be held.
+func (bl *BanList) banID(id ID, duration time.Duration) {
+	until := bl.now().Add(duration)
+	if cur, ok := bl.ids[id]; !ok || cur.Before(until) {
+		bl.ids[id] = until
+	}
+}
+
+// CONTRACT: bl.mtx must be held.
+func (bl *BanList) banIP(ip net.IP, duration time.Duration) {
+	until := bl.now().Add(duration)
+	if cur, ok := bl.ips[ip.String()]; !ok || cur.Before(until) {
+		bl.ips[ip.String()] = until
+	}
+}
+
+// pruneExpired removes all expired bans.
+// CONTRACT: bl.mtx must be held.
+func (bl *BanList) pruneExpired() {
+	now := bl.now()
+	for id, until := range bl.ids {
+		if !now.Before(until) {
+			delete(bl.ids, id)
+		}
+	}
+	for ip, until := range bl.ips {
+		if !now.Before(until) {
+			delete(bl.ips, ip)
+		}
+	}
+}
+
+// save prunes expired bans and writes the remaining ones to disk. Errors are
+// logged, the in-memory bans stay in effect either way.
+// CONTRACT: bl.mtx must be held.
+func (bl *BanList) save() {
+	bl.pruneExpired()
+	if bl.filePath == "" {
+		return
+	}
+
+	blJSON := banListJSON{
+		IDs: make([]banJSON, 0, len(bl.ids)),
+		IPs: make([]banJSON, 0, len(bl.ips)),
+	}
+	for id, until := range bl.ids {
+		blJSON.IDs = append(blJSON.IDs, banJSON{ID: id, Until: until})
+	}
+	for ip, until := range bl.ips {
+		blJSON.IPs = append(blJSON.IPs, banJSON{IP: ip, Until: until})
+	}
+
+	jsonBytes, err := json.MarshalIndent(blJSON, "", "\t")
+	if err != nil {
+		bl.logger.Error("Failed to save BanList to file", "err", err)
+		return
+	}
+	if err := cmn.WriteFileAtomic(bl.filePath, jsonBytes, 0644); err != nil {
+		bl.logger.Error("Failed to save BanList to file", "file", bl.filePath, "err", err)
+	}
+}
+
+// loadFromFile loads the bans stored at filePath. A missing file is not an
+// error.
+func (bl *BanList) loadFromFile(filePath string) error {
+	jsonBytes, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	blJSON := banListJSON{}
+	if err := json.Unmarshal(jsonBytes, &blJSON); err != nil {
+		return err
+	}
+	for _, b := range blJSON.IDs {
+		bl.ids[b.ID] = b.Until
+	}
+	for _, b := range blJSON.IPs {
+		bl.ips[b.IP] = b.Until
+	}
+	bl.pruneExpired()
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+type banPeerBehaviour struct {
+	pb       PeerBehaviour
+	banList  *BanList
+	duration time.Duration
+	banIP    bool
+	isExempt func(Peer) bool
+}
+
+// NewBanPeerBehaviour returns a PeerBehaviour which bans every peer reported
+// as errored for the given duration before forwarding the report to pb. Only
+// the ID of the peer is banned, unless banIP is set. Peers for which isExempt
+// returns true are never banned. It is meant to be wrapped by a
+// ScoredPeerBehaviour, so only peers whose score crossed the threshold get
+// banned.
+func NewBanPeerBehaviour(
+	pb PeerBehaviour,
+	banList *BanList,
+	duration time.Duration,
+	banIP bool,
+	isExempt func(Peer) bool,
+) PeerBehaviour {
+	return &banPeerBehaviour{
+		pb:       pb,
+		banList:  banList,
+		duration: duration,
+		banIP:    banIP,
+		isExempt: isExempt,
+	}
+}
+
+// Errored bans the peer and forwards the report. The ban is issued first, so
+// the peer is refused if it reconnects right after being stopped.
+func (bpb *banPeerBehaviour) Errored(peer Peer, report ErrorBehaviourReport) {
+	switch {
+	case bpb.isExempt(peer):
+	case bpb.banIP:
+		bpb.banList.Ban(peer, bpb.duration)
+	default:
+		bpb.banList.BanID(peer.ID(), bpb.duration)
+	}
+	bpb.pb.Errored(peer, report)
+}
+
+// Behaved forwards the report.
+func (bpb *banPeerBehaviour) Behaved(peer Peer, report GoodBehaviourReport) {
+	bpb.pb.Behaved(peer, report)
+}
@@ -0,0 +1,122 @@
+package p2p
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBanListExpiry(t *testing.T) {
+	bl, err := NewBanList("")
+	require.NoError(t, err)
+	now := time.Now()
+	bl.now = func() time.Time { return now }
+
+	peer := newMockPeer(net.IP{1, 2, 3, 4})
+	bl.Ban(peer, time.Minute)
+	assert.True(t, bl.IsIDBanned(peer.ID()))
+	assert.True(t, bl.IsIPBanned(peer.RemoteIP()))
+	assert.True(t, bl.IsBanned("", peer.RemoteIP()))
+	assert.False(t, bl.IsBanned(newMockPeer(nil).ID(), nil))
+
+	// A shorter ban doesn't cut the existing one short.
+	bl.BanID(peer.ID(), time.Second)
+
+	now = now.Add(time.Minute - time.Second)
+	assert.True(t, bl.IsBanned(peer.ID(), nil))
+
+	now = now.Add(time.Second)
+	assert.False(t, bl.IsIDBanned(peer.ID()))
+	assert.False(t, bl.IsIPBanned(peer.RemoteIP()))
+}
+
+func TestBanListUnban(t *testing.T) {
+	bl, err := NewBanList("")
+	require.NoError(t, err)
+
+	peer := newMockPeer(net.IP{1, 2, 3, 4})
+	bl.Ban(peer, time.Hour)
+
+	bl.UnbanID(peer.ID())
+	assert.False(t, bl.IsIDBanned(peer.ID()))
+	assert.True(t, bl.IsIPBanned(peer.RemoteIP()))
+
+	bl.UnbanIP(peer.RemoteIP())
+	assert.False(t, bl.IsIPBanned(peer.RemoteIP()))
+}
+
+func TestBanListPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "banlist")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filePath := filepath.Join(dir, "banlist.json")
+
+	bl, err := NewBanList(filePath)
+	require.NoError(t, err)
+
+	banned := newMockPeer(net.IP{1, 2, 3, 4})
+	expired := newMockPeer(net.IP{5, 6, 7, 8})
+	bl.Ban(banned, time.Hour)
+	bl.Ban(expired, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	bl2, err := NewBanList(filePath)
+	require.NoError(t, err)
+	assert.True(t, bl2.IsIDBanned(banned.ID()))
+	assert.True(t, bl2.IsIPBanned(banned.RemoteIP()))
+	assert.False(t, bl2.IsIDBanned(expired.ID()))
+	assert.False(t, bl2.IsIPBanned(expired.RemoteIP()))
+
+	// Corrupt files are reported.
+	require.NoError(t, ioutil.WriteFile(filePath, []byte("{"), 0644))
+	_, err = NewBanList(filePath)
+	assert.Error(t, err)
+}
+
+func TestBanListConnFilter(t *testing.T) {
+	bl, err := NewBanList("")
+	require.NoError(t, err)
+	bl.BanIP(net.IP{1, 2, 3, 4}, time.Hour)
+
+	filter := bl.ConnFilter()
+	assert.NoError(t, filter(nil, nil, []net.IP{{5, 6, 7, 8}}))
+	err = filter(nil, nil, []net.IP{{5, 6, 7, 8}, {1, 2, 3, 4}})
+	if _, ok := err.(ErrSwitchBannedPeer); !ok {
+		t.Errorf("expected ErrSwitchBannedPeer, got %v", err)
+	}
+}
+
+func TestBanPeerBehaviour(t *testing.T) {
+	bl, err := NewBanList("")
+	require.NoError(t, err)
+	store := NewStorePeerBehaviour()
+	exempt := newMockPeer(net.IP{5, 6, 7, 8})
+	isExempt := func(p Peer) bool { return p.ID() == exempt.ID() }
+	pb := NewBanPeerBehaviour(store, bl, time.Hour, false, isExempt)
+	peer := newMockPeer(net.IP{1, 2, 3, 4})
+
+	pb.Behaved(peer, GoodBehaviourReport{Reason: GoodPeerBehaviourVote})
+	assert.False(t, bl.IsBanned(peer.ID(), peer.RemoteIP()))
+	assert.Len(t, store.GetBehaved(peer.ID()), 1)
+
+	// Only the ID is banned by default.
+	pb.Errored(peer, ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage})
+	assert.True(t, bl.IsIDBanned(peer.ID()))
+	assert.False(t, bl.IsIPBanned(peer.RemoteIP()))
+	assert.Len(t, store.GetErrored(peer.ID()), 1)
+
+	// Exempt peers are never banned, but the report is still forwarded.
+	pb.Errored(exempt, ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage})
+	assert.False(t, bl.IsBanned(exempt.ID(), exempt.RemoteIP()))
+	assert.Len(t, store.GetErrored(exempt.ID()), 1)
+
+	pb = NewBanPeerBehaviour(store, bl, time.Hour, true, isExempt)
+	pb.Errored(peer, ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage})
+	assert.True(t, bl.IsIPBanned(peer.RemoteIP()))
+}
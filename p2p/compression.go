@@ -0,0 +1,139 @@
+package p2p
+
+import (
+	"fmt"
+
+	"github.com/golang/snappy"
+)
+
+// CompressionSnappy is the name of the snappy compression codec.
+const CompressionSnappy = "snappy"
+
+// Every message on a compressed channel starts with one of these, as
+// messages which don't get smaller are sent uncompressed.
+const (
+	msgUncompressed byte = 0x00
+	msgCompressed   byte = 0x01
+)
+
+type compressionCodec struct {
+	encode     func(src []byte) []byte
+	decodedLen func(src []byte) (int, error)
+	decode     func(src []byte) ([]byte, error)
+}
+
+// names of the supported codecs, in order of preference
+var compressionCodecNames = []string{CompressionSnappy}
+
+var compressionCodecs = map[string]compressionCodec{
+	CompressionSnappy: {
+		encode:     func(src []byte) []byte { return snappy.Encode(nil, src) },
+		decodedLen: snappy.DecodedLen,
+		decode:     func(src []byte) ([]byte, error) { return snappy.Decode(nil, src) },
+	},
+}
+
+// IsCompressionCodec returns true if name is a supported compression codec.
+func IsCompressionCodec(name string) bool {
+	_, ok := compressionCodecs[name]
+	return ok
+}
+
+// CompressionCodecs returns the names of all supported compression codecs,
+// with preferred first, for advertising them in the NodeInfo.
+func CompressionCodecs(preferred string) []string {
+	names := make([]string, 0, len(compressionCodecs))
+	if IsCompressionCodec(preferred) {
+		names = append(names, preferred)
+	}
+	for _, name := range compressionCodecNames {
+		if name != preferred {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// peerCompression holds the codecs negotiated with a peer, by channel.
+type peerCompression struct {
+	send map[byte]string // channels we compress messages to the peer on
+	recv map[byte]string // channels the peer compresses messages to us on
+}
+
+// negotiateCompression returns the codecs to use with the peer with
+// theirInfo. Each side compresses the channels it lists in its NodeInfo, with
+// its most preferred codec the other side supports, so both sides agree on
+// the codec without another round trip.
+func negotiateCompression(ourInfo, theirInfo NodeInfo) peerCompression {
+	pc := peerCompression{send: make(map[byte]string), recv: make(map[byte]string)}
+	ours, ok := ourInfo.(DefaultNodeInfo)
+	if !ok {
+		return pc
+	}
+	theirs, ok := theirInfo.(DefaultNodeInfo)
+	if !ok {
+		return pc
+	}
+
+	if codec, ok := commonCodec(ours.Compression.Codecs, theirs.Compression.Codecs); ok {
+		for _, ch := range ours.Compression.Channels {
+			pc.send[ch] = codec
+		}
+	}
+	if codec, ok := commonCodec(theirs.Compression.Codecs, ours.Compression.Codecs); ok {
+		for _, ch := range theirs.Compression.Channels {
+			pc.recv[ch] = codec
+		}
+	}
+	return pc
+}
+
+// commonCodec returns the first codec in preferred which is also supported by
+// us and in other.
+func commonCodec(preferred, other []string) (string, bool) {
+	for _, codec := range preferred {
+		if !IsCompressionCodec(codec) {
+			continue
+		}
+		for _, o := range other {
+			if codec == o {
+				return codec, true
+			}
+		}
+	}
+	return "", false
+}
+
+// compressMsg returns msgBytes prefixed with msgCompressed and compressed
+// with codec, or prefixed with msgUncompressed if that isn't smaller.
+func compressMsg(codec string, msgBytes []byte) []byte {
+	compressed := compressionCodecs[codec].encode(msgBytes)
+	if len(compressed) >= len(msgBytes) {
+		return append([]byte{msgUncompressed}, msgBytes...)
+	}
+	return append([]byte{msgCompressed}, compressed...)
+}
+
+// decompressMsg reverses compressMsg. It errors if the message would
+// decompress to more than maxSize bytes.
+func decompressMsg(codec string, msgBytes []byte, maxSize int) ([]byte, error) {
+	if len(msgBytes) == 0 {
+		return nil, fmt.Errorf("empty message on compressed channel")
+	}
+	switch msgBytes[0] {
+	case msgUncompressed:
+		return msgBytes[1:], nil
+	case msgCompressed:
+		c := compressionCodecs[codec]
+		n, err := c.decodedLen(msgBytes[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n > maxSize {
+			return nil, fmt.Errorf("message decompresses to %d bytes, max is %d", n, maxSize)
+		}
+		return c.decode(msgBytes[1:])
+	default:
+		return nil, fmt.Errorf("unknown compression flag %X", msgBytes[0])
+	}
+}
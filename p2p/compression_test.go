@@ -0,0 +1,60 @@
+package p2p
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateCompression(t *testing.T) {
+	ours := DefaultNodeInfo{Compression: NodeInfoCompression{
+		Codecs:   []string{CompressionSnappy},
+		Channels: []byte{0x40, 0x30},
+	}}
+	theirs := DefaultNodeInfo{Compression: NodeInfoCompression{
+		Codecs:   []string{"unknown", CompressionSnappy},
+		Channels: []byte{0x30},
+	}}
+
+	pc := negotiateCompression(ours, theirs)
+	assert.Equal(t, map[byte]string{0x40: CompressionSnappy, 0x30: CompressionSnappy}, pc.send)
+	assert.Equal(t, map[byte]string{0x30: CompressionSnappy}, pc.recv)
+
+	// Nothing is compressed with peers which don't support any of our codecs,
+	// e.g. older nodes.
+	pc = negotiateCompression(ours, DefaultNodeInfo{})
+	assert.Empty(t, pc.send)
+	assert.Empty(t, pc.recv)
+}
+
+func TestCompressMsg(t *testing.T) {
+	compressible := bytes.Repeat([]byte("block"), 1000)
+	incompressible := []byte{0x01, 0x02, 0x03}
+
+	for _, msg := range [][]byte{compressible, incompressible} {
+		compressed := compressMsg(CompressionSnappy, msg)
+		decompressed, err := decompressMsg(CompressionSnappy, compressed, len(msg))
+		require.NoError(t, err)
+		assert.Equal(t, msg, decompressed)
+	}
+	assert.True(t, len(compressMsg(CompressionSnappy, compressible)) < len(compressible))
+	assert.Equal(t, msgUncompressed, compressMsg(CompressionSnappy, incompressible)[0])
+
+	// Messages which would decompress to more than the channel allows are
+	// rejected before decompressing them.
+	_, err := decompressMsg(CompressionSnappy, compressMsg(CompressionSnappy, compressible), 100)
+	assert.Error(t, err)
+
+	_, err = decompressMsg(CompressionSnappy, nil, 100)
+	assert.Error(t, err)
+	_, err = decompressMsg(CompressionSnappy, []byte{0xff}, 100)
+	assert.Error(t, err)
+}
+
+func TestCompressionCodecs(t *testing.T) {
+	assert.Equal(t, []string{CompressionSnappy}, CompressionCodecs(""))
+	assert.Equal(t, []string{CompressionSnappy}, CompressionCodecs(CompressionSnappy))
+	assert.Equal(t, []string{CompressionSnappy}, CompressionCodecs("unknown"))
+}
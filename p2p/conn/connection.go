@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"math"
 	"net"
 	"reflect"
 	"sync"
@@ -36,6 +35,7 @@ const (
 	defaultRecvMessageCapacity = 22020096      // 21MB
 	defaultSendRate            = int64(512000) // 500KB/s
 	defaultRecvRate            = int64(512000) // 500KB/s
+	defaultCappedRate          = int64(10240)  // 10KB/s
 	defaultSendTimeout         = 10 * time.Second
 	defaultPingInterval        = 60 * time.Second
 	defaultPongTimeout         = 45 * time.Second
@@ -43,6 +43,7 @@ const (
 
 type receiveCbFunc func(chID byte, msgBytes []byte)
 type errorCbFunc func(interface{})
+type rateLimitCbFunc func(ErrRateLimitExceeded)
 
 /*
 Each peer has one `MConnection` (multiplex connection) instance.
@@ -82,9 +83,17 @@ type MConnection struct {
 	channelsIdx   map[byte]*Channel
 	onReceive     receiveCbFunc
 	onError       errorCbFunc
+	onRateLimit   rateLimitCbFunc
 	errored       uint32
 	config        MConnConfig
 
+	// bytes written to and read from conn, including packet overhead
+	bytesSent     int64
+	bytesReceived int64
+
+	pingSent time.Time // time the last ping was sent, only used by sendRoutine
+	latency  int64     // round trip time of pings, decaying average in ns
+
 	// Closing quitSendRoutine will cause the sendRoutine to eventually quit.
 	// doneSendRoutine is closed when the sendRoutine actually quits.
 	quitSendRoutine chan struct{}
@@ -124,6 +133,27 @@ type MConnConfig struct {
 
 	// Maximum wait time for pongs
 	PongTimeout time.Duration `mapstructure:"pong_timeout"`
+
+	// Rate at which messages can be received on each channel, in
+	// messages/second. Channels may override it. 0 means unlimited
+	ChannelRecvMessageRate float64 `mapstructure:"channel_recv_message_rate"`
+
+	// Rate at which message bytes can be received on each channel, in
+	// bytes/second. Channels may override it. 0 means unlimited
+	ChannelRecvRate int64 `mapstructure:"channel_recv_rate"`
+
+	// Number of bytes sent and received after which the connection is
+	// limited to CappedRate in both directions. 0 means unlimited
+	BandwidthCap int64 `mapstructure:"bandwidth_cap"`
+
+	// Send and receive rate of connections over their BandwidthCap, in
+	// bytes/second
+	CappedRate int64 `mapstructure:"capped_rate"`
+
+	// Priorities and send bursts by channel ID, overriding the ones of the
+	// ChannelDescriptors. See nextSendChannel
+	ChannelPriorities map[byte]int `mapstructure:"channel_priorities"`
+	ChannelSendBursts map[byte]int `mapstructure:"channel_send_bursts"`
 }
 
 // DefaultMConnConfig returns the default config.
@@ -135,6 +165,7 @@ func DefaultMConnConfig() MConnConfig {
 		FlushThrottle:           defaultFlushThrottle,
 		PingInterval:            defaultPingInterval,
 		PongTimeout:             defaultPongTimeout,
+		CappedRate:              defaultCappedRate,
 	}
 }
 
@@ -148,8 +179,25 @@ func NewMConnection(conn net.Conn, chDescs []*ChannelDescriptor, onReceive recei
 		DefaultMConnConfig())
 }
 
+// MConnectionOption sets an optional parameter on the MConnection.
+type MConnectionOption func(*MConnection)
+
+// OnRateLimitExceeded sets a callback which is called whenever a message is
+// dropped because its channel exceeded the receive rate limit. The callback
+// runs on the receive routine and must not block.
+func OnRateLimitExceeded(cb func(ErrRateLimitExceeded)) MConnectionOption {
+	return func(c *MConnection) { c.onRateLimit = cb }
+}
+
 // NewMConnectionWithConfig wraps net.Conn and creates multiplex connection with a config
-func NewMConnectionWithConfig(conn net.Conn, chDescs []*ChannelDescriptor, onReceive receiveCbFunc, onError errorCbFunc, config MConnConfig) *MConnection {
+func NewMConnectionWithConfig(
+	conn net.Conn,
+	chDescs []*ChannelDescriptor,
+	onReceive receiveCbFunc,
+	onError errorCbFunc,
+	config MConnConfig,
+	options ...MConnectionOption,
+) *MConnection {
 	if config.PongTimeout >= config.PingInterval {
 		panic("pongTimeout must be less than pingInterval (otherwise, next ping will reset pong timer)")
 	}
@@ -168,6 +216,9 @@ func NewMConnectionWithConfig(conn net.Conn, chDescs []*ChannelDescriptor, onRec
 		created:       time.Now(),
 	}
 
+	// maxPacketMsgSize() is a bit heavy, so call just once
+	mconn._maxPacketMsgSize = mconn.maxPacketMsgSize()
+
 	// Create channels
 	var channelsIdx = map[byte]*Channel{}
 	var channels = []*Channel{}
@@ -182,8 +233,9 @@ func NewMConnectionWithConfig(conn net.Conn, chDescs []*ChannelDescriptor, onRec
 
 	mconn.BaseService = *cmn.NewBaseService(nil, "MConnection", mconn)
 
-	// maxPacketMsgSize() is a bit heavy, so call just once
-	mconn._maxPacketMsgSize = mconn.maxPacketMsgSize()
+	for _, option := range options {
+		option(mconn)
+	}
 
 	return mconn
 }
@@ -372,6 +424,16 @@ func (c *MConnection) TrySend(chID byte, msgBytes []byte) bool {
 	return ok
 }
 
+// SendQueueSize returns the number of messages queued to be sent on chID,
+// including the one being sent.
+func (c *MConnection) SendQueueSize(chID byte) int {
+	channel, ok := c.channelsIdx[chID]
+	if !ok {
+		return 0
+	}
+	return channel.loadSendQueueSize()
+}
+
 // CanSend returns true if you can send more data onto the chID, false
 // otherwise. Use only as a heuristic.
 func (c *MConnection) CanSend(chID byte) bool {
@@ -412,6 +474,8 @@ FOR_LOOP:
 				break SELECTION
 			}
 			c.sendMonitor.Update(int(_n))
+			atomic.AddInt64(&c.bytesSent, _n)
+			c.pingSent = time.Now()
 			c.Logger.Debug("Starting pong timer", "dur", c.config.PongTimeout)
 			c.pongTimer = time.AfterFunc(c.config.PongTimeout, func() {
 				select {
@@ -426,6 +490,11 @@ FOR_LOOP:
 				err = errors.New("pong timeout")
 			} else {
 				c.stopPongTimer()
+				// ignore pongs we didn't ping for
+				if !c.pingSent.IsZero() {
+					c.updateLatency(time.Since(c.pingSent))
+					c.pingSent = time.Time{}
+				}
 			}
 		case <-c.pong:
 			c.Logger.Debug("Send Pong")
@@ -434,6 +503,7 @@ FOR_LOOP:
 				break SELECTION
 			}
 			c.sendMonitor.Update(int(_n))
+			atomic.AddInt64(&c.bytesSent, _n)
 			c.flush()
 		case <-c.quitSendRoutine:
 			break FOR_LOOP
@@ -464,13 +534,46 @@ FOR_LOOP:
 	close(c.doneSendRoutine)
 }
 
+// updateLatency adds the round trip time of a ping to the latency average.
+func (c *MConnection) updateLatency(rtt time.Duration) {
+	latency := atomic.LoadInt64(&c.latency)
+	if latency == 0 {
+		latency = int64(rtt)
+	} else {
+		latency = int64(float64(latency)*0.8 + float64(rtt)*0.2)
+	}
+	atomic.StoreInt64(&c.latency, latency)
+}
+
+// Latency returns the average round trip time of pings to the other side, or
+// 0 if no pong was received yet.
+func (c *MConnection) Latency() time.Duration {
+	return time.Duration(atomic.LoadInt64(&c.latency))
+}
+
+// isCapped returns true if the connection sent and received more than its
+// BandwidthCap.
+func (c *MConnection) isCapped() bool {
+	return c.config.BandwidthCap > 0 &&
+		atomic.LoadInt64(&c.bytesSent)+atomic.LoadInt64(&c.bytesReceived) >= c.config.BandwidthCap
+}
+
+// rate returns the given send or receive rate, or the CappedRate if it is
+// lower and the connection is capped.
+func (c *MConnection) rate(rate int64) int64 {
+	if c.isCapped() && c.config.CappedRate < rate {
+		return c.config.CappedRate
+	}
+	return rate
+}
+
 // Returns true if messages from channels were exhausted.
 // Blocks in accordance to .sendMonitor throttling.
 func (c *MConnection) sendSomePacketMsgs() bool {
 	// Block until .sendMonitor says we can write.
 	// Once we're ready we send more than we asked for,
 	// but amortized it should even out.
-	c.sendMonitor.Limit(c._maxPacketMsgSize, atomic.LoadInt64(&c.config.SendRate), true)
+	c.sendMonitor.Limit(c._maxPacketMsgSize, c.rate(atomic.LoadInt64(&c.config.SendRate)), true)
 
 	// Now send some PacketMsgs.
 	for i := 0; i < numBatchPacketMsgs; i++ {
@@ -484,36 +587,22 @@ func (c *MConnection) sendSomePacketMsgs() bool {
 // Returns true if messages from channels were exhausted.
 func (c *MConnection) sendPacketMsg() bool {
 	// Choose a channel to create a PacketMsg from.
-	// The chosen channel will be the one whose recentlySent/priority is the least.
-	var leastRatio float32 = math.MaxFloat32
-	var leastChannel *Channel
-	for _, channel := range c.channels {
-		// If nothing to send, skip this channel
-		if !channel.isSendPending() {
-			continue
-		}
-		// Get ratio, and keep track of lowest ratio.
-		ratio := float32(channel.recentlySent) / float32(channel.desc.Priority)
-		if ratio < leastRatio {
-			leastRatio = ratio
-			leastChannel = channel
-		}
-	}
+	channel := c.nextSendChannel()
 
 	// Nothing to send?
-	if leastChannel == nil {
+	if channel == nil {
 		return true
 	}
-	// c.Logger.Info("Found a msgPacket to send")
 
 	// Make & send a PacketMsg from this channel
-	_n, err := leastChannel.writePacketMsgTo(c.bufConnWriter)
+	_n, err := channel.writePacketMsgTo(c.bufConnWriter)
 	if err != nil {
 		c.Logger.Error("Failed to write PacketMsg", "err", err)
 		c.stopForError(err)
 		return true
 	}
 	c.sendMonitor.Update(int(_n))
+	atomic.AddInt64(&c.bytesSent, _n)
 	c.flushTimer.Set()
 	return false
 }
@@ -528,7 +617,7 @@ func (c *MConnection) recvRoutine() {
 FOR_LOOP:
 	for {
 		// Block until .recvMonitor says we can read.
-		c.recvMonitor.Limit(c._maxPacketMsgSize, atomic.LoadInt64(&c.config.RecvRate), true)
+		c.recvMonitor.Limit(c._maxPacketMsgSize, c.rate(atomic.LoadInt64(&c.config.RecvRate)), true)
 
 		// Peek into bufConnReader for debugging
 		/*
@@ -550,6 +639,7 @@ FOR_LOOP:
 		var err error
 		_n, err = cdc.UnmarshalBinaryLengthPrefixedReader(c.bufConnReader, &packet, int64(c._maxPacketMsgSize))
 		c.recvMonitor.Update(int(_n))
+		atomic.AddInt64(&c.bytesReceived, _n)
 		if err != nil {
 			if c.IsRunning() {
 				c.Logger.Error("Connection failed @ recvRoutine (reading byte)", "conn", c, "err", err)
@@ -594,6 +684,13 @@ FOR_LOOP:
 				break FOR_LOOP
 			}
 			if msgBytes != nil {
+				if err := channel.checkRecvRate(len(msgBytes), time.Now()); err != nil {
+					c.Logger.Debug("Dropping message", "chID", pkt.ChannelID, "err", err)
+					if c.onRateLimit != nil {
+						c.onRateLimit(*err)
+					}
+					continue FOR_LOOP
+				}
 				c.Logger.Debug("Received bytes", "chID", pkt.ChannelID, "msgBytes", fmt.Sprintf("%X", msgBytes))
 				// NOTE: This means the reactor.Receive runs in the same thread as the p2p recv routine
 				c.onReceive(pkt.ChannelID, msgBytes)
@@ -632,10 +729,14 @@ func (c *MConnection) maxPacketMsgSize() int {
 }
 
 type ConnectionStatus struct {
-	Duration    time.Duration
-	SendMonitor flow.Status
-	RecvMonitor flow.Status
-	Channels    []ChannelStatus
+	Duration      time.Duration
+	SendMonitor   flow.Status
+	RecvMonitor   flow.Status
+	BytesSent     int64
+	BytesReceived int64
+	Capped        bool
+	Latency       time.Duration
+	Channels      []ChannelStatus
 }
 
 type ChannelStatus struct {
@@ -643,6 +744,9 @@ type ChannelStatus struct {
 	SendQueueCapacity int
 	SendQueueSize     int
 	Priority          int
+	SendBurst         int
+	SendQueuePolicy   SendQueuePolicy
+	SendQueueDropped  int64
 	RecentlySent      int64
 }
 
@@ -651,6 +755,10 @@ func (c *MConnection) Status() ConnectionStatus {
 	status.Duration = time.Since(c.created)
 	status.SendMonitor = c.sendMonitor.Status()
 	status.RecvMonitor = c.recvMonitor.Status()
+	status.BytesSent = atomic.LoadInt64(&c.bytesSent)
+	status.BytesReceived = atomic.LoadInt64(&c.bytesReceived)
+	status.Capped = c.isCapped()
+	status.Latency = c.Latency()
 	status.Channels = make([]ChannelStatus, len(c.channels))
 	for i, channel := range c.channels {
 		status.Channels[i] = ChannelStatus{
@@ -658,6 +766,9 @@ func (c *MConnection) Status() ConnectionStatus {
 			SendQueueCapacity: cap(channel.sendQueue),
 			SendQueueSize:     int(atomic.LoadInt32(&channel.sendQueueSize)),
 			Priority:          channel.desc.Priority,
+			SendBurst:         channel.desc.SendBurst,
+			SendQueuePolicy:   channel.desc.SendQueuePolicy,
+			SendQueueDropped:  atomic.LoadInt64(&channel.sendQueueDropped),
 			RecentlySent:      atomic.LoadInt64(&channel.recentlySent),
 		}
 	}
@@ -666,12 +777,52 @@ func (c *MConnection) Status() ConnectionStatus {
 
 //-----------------------------------------------------------------------------
 
+// SendQueuePolicy determines what happens to a message sent to a channel
+// whose send queue is full.
+type SendQueuePolicy int
+
+const (
+	// SendQueueBlock makes Send wait for room in the queue, up to
+	// defaultSendTimeout, and TrySend fail.
+	SendQueueBlock SendQueuePolicy = iota
+	// SendQueueDropOldest drops the oldest queued message to make room for
+	// the new one, so neither Send nor TrySend wait for a slow peer. Meant
+	// for gossip, where a newer message supersedes or repeats older ones.
+	SendQueueDropOldest
+)
+
+// String implements fmt.Stringer.
+func (p SendQueuePolicy) String() string {
+	switch p {
+	case SendQueueBlock:
+		return "block"
+	case SendQueueDropOldest:
+		return "drop-oldest"
+	default:
+		return fmt.Sprintf("SendQueuePolicy(%d)", int(p))
+	}
+}
+
 type ChannelDescriptor struct {
 	ID                  byte
 	Priority            int
 	SendQueueCapacity   int
 	RecvBufferCapacity  int
 	RecvMessageCapacity int
+
+	// Receive rate limits, in messages/second and bytes/second. If zero,
+	// the limits of the MConnConfig apply.
+	RecvMessageRate float64
+	RecvRate        int64
+
+	// Maximum number of bytes the channel may save up credit for while it has
+	// nothing to send, see nextSendChannel. Defaults to one quantum, i.e.
+	// Priority packets.
+	SendBurst int
+
+	// What to do with messages sent while the send queue is full. Defaults
+	// to SendQueueBlock.
+	SendQueuePolicy SendQueuePolicy
 }
 
 func (chDesc ChannelDescriptor) FillDefaults() (filled ChannelDescriptor) {
@@ -691,13 +842,23 @@ func (chDesc ChannelDescriptor) FillDefaults() (filled ChannelDescriptor) {
 // TODO: lowercase.
 // NOTE: not goroutine-safe.
 type Channel struct {
-	conn          *MConnection
-	desc          ChannelDescriptor
-	sendQueue     chan []byte
-	sendQueueSize int32 // atomic.
-	recving       []byte
-	sending       []byte
-	recentlySent  int64 // exponential moving average
+	conn             *MConnection
+	desc             ChannelDescriptor
+	sendQueue        chan []byte
+	sendQueueSize    int32 // atomic.
+	sendQueueDropped int64 // atomic, dropped by SendQueueDropOldest
+	recving          []byte
+	sending          []byte
+	recentlySent     int64 // exponential moving average
+
+	// Scheduling state, see nextSendChannel. Only used by the sendRoutine.
+	quantum int64 // credited every round, in bytes
+	burst   int64 // maximum credit, in bytes
+	deficit int64 // credit left, in bytes
+
+	// nil if unlimited
+	recvMessageLimiter *tokenBucket
+	recvByteLimiter    *tokenBucket
 
 	maxPacketMsgPayloadSize int
 
@@ -706,16 +867,44 @@ type Channel struct {
 
 func newChannel(conn *MConnection, desc ChannelDescriptor) *Channel {
 	desc = desc.FillDefaults()
+	if priority, ok := conn.config.ChannelPriorities[desc.ID]; ok {
+		desc.Priority = priority
+	}
+	if burst, ok := conn.config.ChannelSendBursts[desc.ID]; ok {
+		desc.SendBurst = burst
+	}
 	if desc.Priority <= 0 {
 		cmn.PanicSanity("Channel default priority must be a positive integer")
 	}
-	return &Channel{
+	quantum := int64(desc.Priority) * int64(conn._maxPacketMsgSize)
+	if desc.SendBurst <= 0 {
+		desc.SendBurst = int(quantum)
+	}
+	ch := &Channel{
 		conn:                    conn,
 		desc:                    desc,
 		sendQueue:               make(chan []byte, desc.SendQueueCapacity),
 		recving:                 make([]byte, 0, desc.RecvBufferCapacity),
 		maxPacketMsgPayloadSize: conn.config.MaxPacketMsgPayloadSize,
+		quantum:                 quantum,
+		burst:                   int64(desc.SendBurst),
+	}
+
+	messageRate, byteRate := desc.RecvMessageRate, desc.RecvRate
+	if messageRate == 0 {
+		messageRate = conn.config.ChannelRecvMessageRate
+	}
+	if byteRate == 0 {
+		byteRate = conn.config.ChannelRecvRate
 	}
+	if messageRate > 0 {
+		ch.recvMessageLimiter = newTokenBucket(messageRate)
+	}
+	if byteRate > 0 {
+		ch.recvByteLimiter = newTokenBucket(float64(byteRate))
+	}
+
+	return ch
 }
 
 func (ch *Channel) SetLogger(l log.Logger) {
@@ -726,6 +915,9 @@ func (ch *Channel) SetLogger(l log.Logger) {
 // Goroutine-safe
 // Times out (and returns false) after defaultSendTimeout
 func (ch *Channel) sendBytes(bytes []byte) bool {
+	if ch.desc.SendQueuePolicy == SendQueueDropOldest {
+		return ch.sendBytesDropOldest(bytes)
+	}
 	select {
 	case ch.sendQueue <- bytes:
 		atomic.AddInt32(&ch.sendQueueSize, 1)
@@ -739,6 +931,9 @@ func (ch *Channel) sendBytes(bytes []byte) bool {
 // Nonblocking, returns true if successful.
 // Goroutine-safe
 func (ch *Channel) trySendBytes(bytes []byte) bool {
+	if ch.desc.SendQueuePolicy == SendQueueDropOldest {
+		return ch.sendBytesDropOldest(bytes)
+	}
 	select {
 	case ch.sendQueue <- bytes:
 		atomic.AddInt32(&ch.sendQueueSize, 1)
@@ -748,6 +943,29 @@ func (ch *Channel) trySendBytes(bytes []byte) bool {
 	}
 }
 
+// Queues message to send to this channel, dropping the oldest queued
+// messages while the queue is full.
+// Nonblocking, always returns true.
+// Goroutine-safe
+func (ch *Channel) sendBytesDropOldest(bytes []byte) bool {
+	for {
+		select {
+		case ch.sendQueue <- bytes:
+			atomic.AddInt32(&ch.sendQueueSize, 1)
+			return true
+		default:
+		}
+
+		// The sendRoutine may empty the queue in the meantime, so don't wait.
+		select {
+		case <-ch.sendQueue:
+			atomic.AddInt32(&ch.sendQueueSize, -1)
+			atomic.AddInt64(&ch.sendQueueDropped, 1)
+		default:
+		}
+	}
+}
+
 // Goroutine-safe
 func (ch *Channel) loadSendQueueSize() (size int) {
 	return int(atomic.LoadInt32(&ch.sendQueueSize))
@@ -790,12 +1008,13 @@ func (ch *Channel) nextPacketMsg() PacketMsg {
 	return packet
 }
 
-// Writes next PacketMsg to w and updates c.recentlySent.
+// Writes next PacketMsg to w and updates c.recentlySent and c.deficit.
 // Not goroutine-safe
 func (ch *Channel) writePacketMsgTo(w io.Writer) (n int64, err error) {
 	var packet = ch.nextPacketMsg()
 	n, err = cdc.MarshalBinaryLengthPrefixedWriter(w, packet)
 	atomic.AddInt64(&ch.recentlySent, n)
+	ch.deficit -= n
 	return
 }
 
@@ -822,6 +1041,19 @@ func (ch *Channel) recvPacketMsg(packet PacketMsg) ([]byte, error) {
 	return nil, nil
 }
 
+// checkRecvRate accounts for a received message of n bytes and returns an
+// error if the message exceeds the receive rate limits of the channel.
+// Not goroutine-safe
+func (ch *Channel) checkRecvRate(n int, now time.Time) *ErrRateLimitExceeded {
+	if ch.recvMessageLimiter != nil && !ch.recvMessageLimiter.take(1, now) {
+		return &ErrRateLimitExceeded{ChannelID: ch.desc.ID, Rate: ch.recvMessageLimiter.rate, Unit: "messages/s"}
+	}
+	if ch.recvByteLimiter != nil && !ch.recvByteLimiter.take(float64(n), now) {
+		return &ErrRateLimitExceeded{ChannelID: ch.desc.ID, Rate: ch.recvByteLimiter.rate, Unit: "bytes/s"}
+	}
+	return nil
+}
+
 // Call this periodically to update stats for throttling purposes.
 // Not goroutine-safe
 func (ch *Channel) updateStats() {
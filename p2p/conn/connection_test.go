@@ -140,6 +140,65 @@ func TestMConnectionReceive(t *testing.T) {
 	}
 }
 
+func TestMConnectionRecvRateLimit(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close() // nolint: errcheck
+	defer client.Close() // nolint: errcheck
+
+	receivedCh := make(chan []byte, 10)
+	rateLimitedCh := make(chan ErrRateLimitExceeded, 10)
+	onReceive := func(chID byte, msgBytes []byte) {
+		receivedCh <- msgBytes
+	}
+	onError := func(r interface{}) {}
+
+	cfg := DefaultMConnConfig()
+	cfg.ChannelRecvMessageRate = 2
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1, SendQueueCapacity: 10},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 10, RecvMessageRate: 100},
+	}
+	mconn1 := NewMConnectionWithConfig(client, chDescs, onReceive, onError, cfg,
+		OnRateLimitExceeded(func(err ErrRateLimitExceeded) { rateLimitedCh <- err }))
+	mconn1.SetLogger(log.TestingLogger())
+	err := mconn1.Start()
+	require.Nil(t, err)
+	defer mconn1.Stop()
+
+	mconn2 := NewMConnection(server, chDescs, onReceive, onError)
+	mconn2.SetLogger(log.TestingLogger())
+	err = mconn2.Start()
+	require.Nil(t, err)
+	defer mconn2.Stop()
+
+	for i := 0; i < 4; i++ {
+		assert.True(t, mconn2.Send(0x01, []byte("Quicksilver")))
+	}
+	// The channel override applies.
+	for i := 0; i < 4; i++ {
+		assert.True(t, mconn2.Send(0x02, []byte("Quicksilver")))
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-rateLimitedCh:
+			assert.EqualValues(t, 0x01, err.ChannelID)
+		case <-time.After(time.Second):
+			t.Fatal("expected rate limit to be exceeded")
+		}
+	}
+	for i := 0; i < 6; i++ {
+		select {
+		case <-receivedCh:
+		case <-time.After(time.Second):
+			t.Fatalf("expected 6 messages, got %d", i)
+		}
+	}
+	assert.Empty(t, rateLimitedCh)
+	assert.Empty(t, receivedCh)
+	assert.True(t, mconn1.IsRunning(), "exceeding the rate limit must not stop the connection")
+}
+
 func TestMConnectionStatus(t *testing.T) {
 	server, client := NetPipe()
 	defer server.Close() // nolint: errcheck
@@ -155,6 +214,57 @@ func TestMConnectionStatus(t *testing.T) {
 	assert.Zero(t, status.Channels[0].SendQueueSize)
 }
 
+func TestMConnectionBandwidthCap(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close() // nolint: errcheck
+	defer client.Close() // nolint: errcheck
+
+	receivedCh := make(chan []byte, 10)
+	onReceive := func(chID byte, msgBytes []byte) {
+		receivedCh <- msgBytes
+	}
+	onError := func(r interface{}) {}
+
+	cfg := DefaultMConnConfig()
+	cfg.BandwidthCap = 100
+	cfg.CappedRate = 1024
+	chDescs := []*ChannelDescriptor{{ID: 0x01, Priority: 1, SendQueueCapacity: 10}}
+	mconn1 := NewMConnectionWithConfig(client, chDescs, onReceive, onError, cfg)
+	mconn1.SetLogger(log.TestingLogger())
+	err := mconn1.Start()
+	require.Nil(t, err)
+	defer mconn1.Stop()
+
+	mconn2 := NewMConnection(server, chDescs, onReceive, onError)
+	mconn2.SetLogger(log.TestingLogger())
+	err = mconn2.Start()
+	require.Nil(t, err)
+	defer mconn2.Stop()
+
+	status := mconn1.Status()
+	assert.False(t, status.Capped)
+	assert.EqualValues(t, cfg.SendRate, mconn1.rate(cfg.SendRate))
+
+	msg := bytes.Repeat([]byte{0xff}, 200)
+	assert.True(t, mconn2.Send(0x01, msg))
+	select {
+	case <-receivedCh:
+	case <-time.After(time.Second):
+		t.Fatal("expected message to be received")
+	}
+
+	// The receiver is capped, the sender isn't.
+	status = mconn1.Status()
+	assert.True(t, status.BytesReceived > int64(len(msg)))
+	assert.True(t, status.Capped)
+	assert.EqualValues(t, cfg.CappedRate, mconn1.rate(cfg.SendRate))
+	assert.EqualValues(t, 512, mconn1.rate(512), "lower rates are kept")
+
+	status = mconn2.Status()
+	assert.True(t, status.BytesSent > int64(len(msg)))
+	assert.False(t, status.Capped)
+}
+
 func TestMConnectionPongTimeoutResultsInError(t *testing.T) {
 	server, client := net.Pipe()
 	defer server.Close()
@@ -339,6 +449,41 @@ func TestMConnectionPingPongs(t *testing.T) {
 	}
 }
 
+func TestMConnectionLatency(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	mconn := createTestMConnection(client)
+	err := mconn.Start()
+	require.Nil(t, err)
+	defer mconn.Stop()
+	assert.Zero(t, mconn.Latency())
+
+	go func() {
+		// read ping, respond with pong after a delay
+		var pkt PacketPing
+		_, err := cdc.UnmarshalBinaryLengthPrefixedReader(server, &pkt, maxPingPongPacketSize)
+		require.Nil(t, err)
+		time.Sleep(10 * time.Millisecond)
+		_, err = server.Write(cdc.MustMarshalBinaryLengthPrefixed(PacketPong{}))
+		require.Nil(t, err)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for mconn.Latency() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	latency := mconn.Latency()
+	assert.True(t, latency >= 10*time.Millisecond, "latency %v", latency)
+	assert.True(t, latency < mconn.config.PongTimeout, "latency %v", latency)
+	assert.Equal(t, latency, mconn.Status().Latency)
+
+	// Later pings move the average.
+	mconn.updateLatency(latency + 100*time.Millisecond)
+	assert.Equal(t, latency+20*time.Millisecond, mconn.Latency())
+}
+
 func TestMConnectionStopsAndReturnsError(t *testing.T) {
 	server, client := NetPipe()
 	defer server.Close() // nolint: errcheck
@@ -527,3 +672,31 @@ func TestMConnectionTrySend(t *testing.T) {
 	assert.False(t, mconn.TrySend(0x01, msg))
 	assert.Equal(t, "TrySend", <-resultCh)
 }
+
+func TestMConnectionSendQueuePolicy(t *testing.T) {
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1, SendQueueCapacity: 2},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 2, SendQueuePolicy: SendQueueDropOldest},
+	}
+	mconn := NewMConnectionWithConfig(nil, chDescs, nil, nil, DefaultMConnConfig())
+	block, dropOldest := mconn.channelsIdx[0x01], mconn.channelsIdx[0x02]
+
+	for _, msg := range []string{"a", "b", "c"} {
+		block.trySendBytes([]byte(msg))
+		dropOldest.trySendBytes([]byte(msg))
+	}
+	// A full drop-oldest queue doesn't make Send wait.
+	assert.True(t, dropOldest.sendBytes([]byte("d")))
+
+	assert.Equal(t, 2, mconn.SendQueueSize(0x01))
+	assert.Equal(t, 2, mconn.SendQueueSize(0x02))
+	assert.Equal(t, "a", string(<-block.sendQueue))
+	assert.Equal(t, "c", string(<-dropOldest.sendQueue))
+
+	status := mconn.Status()
+	require.Len(t, status.Channels, 2)
+	assert.Equal(t, SendQueueBlock, status.Channels[0].SendQueuePolicy)
+	assert.EqualValues(t, 0, status.Channels[0].SendQueueDropped)
+	assert.Equal(t, SendQueueDropOldest, status.Channels[1].SendQueuePolicy)
+	assert.EqualValues(t, 2, status.Channels[1].SendQueueDropped)
+}
@@ -0,0 +1,383 @@
+package conn
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// The Noise handshake is an alternative to the STS handshake of
+// MakeSecretConnection, based on the Noise_XX_25519_ChaChaPoly_SHA256
+// protocol (see https://noiseprotocol.org/noise.html):
+//
+//   -> e
+//   <- e, ee, s, es
+//   -> s, se
+//
+// The static keys are X25519 keys generated for the connection, which each
+// side binds to its persistent key by signing it in the payload of its
+// message carrying s. The resulting connection uses the same frames as the
+// STS handshake, encrypted with the keys of the final Noise cipher states.
+//
+// The initiator starts with noiseMarker, which can't be the first byte of an
+// STS handshake, so AcceptSecretConnection can serve both handshakes.
+
+const (
+	noiseProtocolName = "Noise_XX_25519_ChaChaPoly_SHA256"
+	noisePrologue     = "TENDERMINT_NOISE_HANDSHAKE"
+	noiseSigPrefix    = "TENDERMINT_NOISE_STATIC_KEY:"
+
+	noiseMarker         byte = 0x00
+	noiseMaxMsgSize          = 1024
+	noiseDHLen               = 32
+	noiseEncryptedDHLen      = noiseDHLen + aeadSizeOverhead
+)
+
+// MakeNoiseSecretConnection performs the Noise handshake as the initiator and
+// returns a new authenticated SecretConnection. The other side must use
+// AcceptSecretConnection.
+// Caller should call conn.Close()
+func MakeNoiseSecretConnection(
+	conn io.ReadWriteCloser,
+	locPrivKey crypto.PrivKey,
+	options ...SecretConnectionOption,
+) (*SecretConnection, error) {
+	sc := &SecretConnection{conn: conn}
+	for _, option := range options {
+		option(sc)
+	}
+
+	if _, err := conn.Write([]byte{noiseMarker}); err != nil {
+		return nil, err
+	}
+	ns := newNoiseState()
+	locEphPub, locEphPriv := genEphKeys()
+	locStatPub, locStatPriv := genEphKeys()
+
+	// -> e
+	ns.mixHash(locEphPub[:])
+	msg := append(append([]byte(nil), locEphPub[:]...), ns.encryptAndHash(nil)...)
+	if err := writeNoiseMsg(conn, msg); err != nil {
+		return nil, err
+	}
+
+	// <- e, ee, s, es
+	msg, err := readNoiseMsg(conn)
+	if err != nil {
+		return nil, err
+	}
+	if len(msg) < noiseDHLen+noiseEncryptedDHLen {
+		return nil, errors.New("noise handshake message too short")
+	}
+	remEphPub, err := readNoisePubKey(msg[:noiseDHLen])
+	if err != nil {
+		return nil, err
+	}
+	ns.mixHash(remEphPub[:])
+	if err := ns.mixDH(locEphPriv, remEphPub); err != nil {
+		return nil, err
+	}
+	remStatPub, err := ns.decryptPubKey(msg[noiseDHLen : noiseDHLen+noiseEncryptedDHLen])
+	if err != nil {
+		return nil, err
+	}
+	if err := ns.mixDH(locEphPriv, remStatPub); err != nil {
+		return nil, err
+	}
+	payload, err := ns.decryptAndHash(msg[noiseDHLen+noiseEncryptedDHLen:])
+	if err != nil {
+		return nil, err
+	}
+	remAuth, err := openNoisePayload(payload, remStatPub)
+	if err != nil {
+		return nil, err
+	}
+
+	// -> s, se
+	msg = ns.encryptAndHash(locStatPub[:])
+	if err := ns.mixDH(locStatPriv, remEphPub); err != nil {
+		return nil, err
+	}
+	msg = append(msg, ns.encryptAndHash(sealNoisePayload(sc, locPrivKey, locStatPub))...)
+	if err := writeNoiseMsg(conn, msg); err != nil {
+		return nil, err
+	}
+
+	sc.sendSecret, sc.recvSecret = ns.split()
+	return finishNoiseSecretConnection(sc, remAuth), nil
+}
+
+// AcceptSecretConnection performs the handshake chosen by the dialer, either
+// the STS handshake of MakeSecretConnection or the Noise handshake of
+// MakeNoiseSecretConnection as the responder, and returns a new authenticated
+// SecretConnection.
+// Caller should call conn.Close()
+func AcceptSecretConnection(
+	conn net.Conn,
+	locPrivKey crypto.PrivKey,
+	options ...SecretConnectionOption,
+) (*SecretConnection, error) {
+	first := make([]byte, 1)
+	if _, err := io.ReadFull(conn, first); err != nil {
+		return nil, err
+	}
+	if first[0] != noiseMarker {
+		return MakeSecretConnection(&prefixConn{Conn: conn, prefix: first}, locPrivKey, options...)
+	}
+
+	sc := &SecretConnection{conn: conn}
+	for _, option := range options {
+		option(sc)
+	}
+	ns := newNoiseState()
+	locEphPub, locEphPriv := genEphKeys()
+	locStatPub, locStatPriv := genEphKeys()
+
+	// -> e
+	msg, err := readNoiseMsg(conn)
+	if err != nil {
+		return nil, err
+	}
+	if len(msg) < noiseDHLen {
+		return nil, errors.New("noise handshake message too short")
+	}
+	remEphPub, err := readNoisePubKey(msg[:noiseDHLen])
+	if err != nil {
+		return nil, err
+	}
+	ns.mixHash(remEphPub[:])
+	if _, err := ns.decryptAndHash(msg[noiseDHLen:]); err != nil {
+		return nil, err
+	}
+
+	// <- e, ee, s, es
+	ns.mixHash(locEphPub[:])
+	msg = append([]byte(nil), locEphPub[:]...)
+	if err := ns.mixDH(locEphPriv, remEphPub); err != nil {
+		return nil, err
+	}
+	msg = append(msg, ns.encryptAndHash(locStatPub[:])...)
+	if err := ns.mixDH(locStatPriv, remEphPub); err != nil {
+		return nil, err
+	}
+	msg = append(msg, ns.encryptAndHash(sealNoisePayload(sc, locPrivKey, locStatPub))...)
+	if err := writeNoiseMsg(conn, msg); err != nil {
+		return nil, err
+	}
+
+	// -> s, se
+	msg, err = readNoiseMsg(conn)
+	if err != nil {
+		return nil, err
+	}
+	if len(msg) < noiseEncryptedDHLen {
+		return nil, errors.New("noise handshake message too short")
+	}
+	remStatPub, err := ns.decryptPubKey(msg[:noiseEncryptedDHLen])
+	if err != nil {
+		return nil, err
+	}
+	if err := ns.mixDH(locEphPriv, remStatPub); err != nil {
+		return nil, err
+	}
+	payload, err := ns.decryptAndHash(msg[noiseEncryptedDHLen:])
+	if err != nil {
+		return nil, err
+	}
+	remAuth, err := openNoisePayload(payload, remStatPub)
+	if err != nil {
+		return nil, err
+	}
+
+	sc.recvSecret, sc.sendSecret = ns.split()
+	return finishNoiseSecretConnection(sc, remAuth), nil
+}
+
+func finishNoiseSecretConnection(sc *SecretConnection, remAuth authSigMessage) *SecretConnection {
+	sc.recvNonce = new([aeadNonceSize]byte)
+	sc.sendNonce = new([aeadNonceSize]byte)
+	sc.remPubKey = remAuth.Key
+	sc.rekey = sc.rekeySupported && remAuth.Rekey
+	sc.lastRekey = time.Now()
+	return sc
+}
+
+// sealNoisePayload returns the payload binding our static key to our
+// persistent key.
+func sealNoisePayload(sc *SecretConnection, locPrivKey crypto.PrivKey, locStatPub *[32]byte) []byte {
+	sig, err := locPrivKey.Sign(append([]byte(noiseSigPrefix), locStatPub[:]...))
+	if err != nil {
+		panic(err)
+	}
+	return cdc.MustMarshalBinaryBare(authSigMessage{locPrivKey.PubKey(), sig, sc.rekeySupported})
+}
+
+// openNoisePayload verifies the peer's static key was signed with its
+// persistent key.
+func openNoisePayload(payload []byte, remStatPub *[32]byte) (authSigMessage, error) {
+	var msg authSigMessage
+	if err := cdc.UnmarshalBinaryBare(payload, &msg); err != nil {
+		return msg, err
+	}
+	if msg.Key == nil || !msg.Key.VerifyBytes(append([]byte(noiseSigPrefix), remStatPub[:]...), msg.Sig) {
+		return msg, errors.New("Challenge verification failed")
+	}
+	return msg, nil
+}
+
+func readNoisePubKey(b []byte) (*[32]byte, error) {
+	var pubKey [32]byte
+	copy(pubKey[:], b)
+	if hasSmallOrder(pubKey) {
+		return nil, ErrSmallOrderRemotePubKey
+	}
+	return &pubKey, nil
+}
+
+// Handshake messages are prefixed with their length as a big endian uint16.
+func writeNoiseMsg(w io.Writer, msg []byte) error {
+	buf := make([]byte, 2+len(msg))
+	binary.BigEndian.PutUint16(buf, uint16(len(msg)))
+	copy(buf[2:], msg)
+	_, err := w.Write(buf)
+	return err
+}
+
+func readNoiseMsg(r io.Reader) ([]byte, error) {
+	var lenBuf [2]byte
+	if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint16(lenBuf[:])
+	if n > noiseMaxMsgSize {
+		return nil, fmt.Errorf("noise handshake message of %d bytes, max is %d", n, noiseMaxMsgSize)
+	}
+	msg := make([]byte, n)
+	_, err := io.ReadFull(r, msg)
+	return msg, err
+}
+
+// noiseState is the Noise SymmetricState.
+type noiseState struct {
+	ck [32]byte
+	h  [32]byte
+	k  *[aeadKeySize]byte // nil until the first mixKey
+	n  uint64
+}
+
+func newNoiseState() *noiseState {
+	ns := &noiseState{}
+	// The protocol name is exactly 32 bytes, so it is used as is.
+	copy(ns.h[:], noiseProtocolName)
+	ns.ck = ns.h
+	ns.mixHash([]byte(noisePrologue))
+	return ns
+}
+
+func (ns *noiseState) mixHash(data []byte) {
+	ns.h = sha256.Sum256(append(ns.h[:], data...))
+}
+
+func (ns *noiseState) mixKey(ikm []byte) {
+	out1, out2 := noiseHKDF(ns.ck[:], ikm)
+	ns.ck = *out1
+	ns.k = out2
+	ns.n = 0
+}
+
+func (ns *noiseState) mixDH(locPriv, remPub *[32]byte) error {
+	dhSecret, err := computeDHSecret(remPub, locPriv)
+	if err != nil {
+		return err
+	}
+	ns.mixKey(dhSecret[:])
+	return nil
+}
+
+func (ns *noiseState) encryptAndHash(plaintext []byte) []byte {
+	ciphertext := plaintext
+	if ns.k != nil {
+		aead, err := chacha20poly1305.New(ns.k[:])
+		if err != nil {
+			panic(err)
+		}
+		ciphertext = aead.Seal(nil, ns.nonce(), plaintext, ns.h[:])
+		ns.n++
+	}
+	ns.mixHash(ciphertext)
+	return ciphertext
+}
+
+func (ns *noiseState) decryptAndHash(ciphertext []byte) ([]byte, error) {
+	plaintext := ciphertext
+	if ns.k != nil {
+		aead, err := chacha20poly1305.New(ns.k[:])
+		if err != nil {
+			return nil, err
+		}
+		plaintext, err = aead.Open(nil, ns.nonce(), ciphertext, ns.h[:])
+		if err != nil {
+			return nil, errors.New("Failed to decrypt noise handshake message")
+		}
+		ns.n++
+	}
+	ns.mixHash(ciphertext)
+	return plaintext, nil
+}
+
+func (ns *noiseState) decryptPubKey(ciphertext []byte) (*[32]byte, error) {
+	plaintext, err := ns.decryptAndHash(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	return readNoisePubKey(plaintext)
+}
+
+func (ns *noiseState) nonce() []byte {
+	nonce := make([]byte, aeadNonceSize)
+	binary.LittleEndian.PutUint64(nonce[4:], ns.n)
+	return nonce
+}
+
+// split returns the keys for sending from the initiator to the responder and
+// vice versa.
+func (ns *noiseState) split() (initiatorKey, responderKey *[aeadKeySize]byte) {
+	return noiseHKDF(ns.ck[:], nil)
+}
+
+// noiseHKDF is HKDF as defined by Noise, with chainingKey as salt and no info.
+func noiseHKDF(chainingKey, ikm []byte) (out1, out2 *[32]byte) {
+	hkdf := hkdf.New(sha256.New, ikm, chainingKey, nil)
+	out1, out2 = new([32]byte), new([32]byte)
+	if _, err := io.ReadFull(hkdf, out1[:]); err != nil {
+		panic(err)
+	}
+	if _, err := io.ReadFull(hkdf, out2[:]); err != nil {
+		panic(err)
+	}
+	return out1, out2
+}
+
+// prefixConn replays prefix before reading from the Conn.
+type prefixConn struct {
+	net.Conn
+	prefix []byte
+}
+
+func (c *prefixConn) Read(b []byte) (int, error) {
+	if len(c.prefix) > 0 {
+		n := copy(b, c.prefix)
+		c.prefix = c.prefix[n:]
+		return n, nil
+	}
+	return c.Conn.Read(b)
+}
@@ -0,0 +1,130 @@
+package conn
+
+import (
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	cmn "github.com/tendermint/tendermint/libs/common"
+)
+
+func makeAcceptedSecretConnPair(
+	t *testing.T,
+	dial func(io.ReadWriteCloser, crypto.PrivKey, ...SecretConnectionOption) (*SecretConnection, error),
+	options ...SecretConnectionOption,
+) (dialer, acceptor *SecretConnection) {
+	dialerConn, acceptorConn := net.Pipe()
+	dialerPrivKey, acceptorPrivKey := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+
+	trs, ok := cmn.Parallel(
+		func(_ int) (val interface{}, err error, abort bool) {
+			dialer, err = dial(dialerConn, dialerPrivKey, options...)
+			return nil, err, err != nil
+		},
+		func(_ int) (val interface{}, err error, abort bool) {
+			acceptor, err = AcceptSecretConnection(acceptorConn, acceptorPrivKey, options...)
+			return nil, err, err != nil
+		},
+	)
+	require.NoError(t, trs.FirstError())
+	require.True(t, ok)
+
+	assert.True(t, dialer.RemotePubKey().Equals(acceptorPrivKey.PubKey()))
+	assert.True(t, acceptor.RemotePubKey().Equals(dialerPrivKey.PubKey()))
+	return dialer, acceptor
+}
+
+func assertSecretConnsConnected(t *testing.T, a, b *SecretConnection) {
+	for _, conns := range [][2]*SecretConnection{{a, b}, {b, a}} {
+		msg := []byte(cmn.RandStr(3 * dataMaxSize))
+		go func(w *SecretConnection) {
+			_, _ = w.Write(msg)
+		}(conns[0])
+		buf := make([]byte, len(msg))
+		_, err := io.ReadFull(conns[1], buf)
+		require.NoError(t, err)
+		assert.Equal(t, msg, buf)
+	}
+}
+
+func TestNoiseSecretConnection(t *testing.T) {
+	dialer, acceptor := makeAcceptedSecretConnPair(t, MakeNoiseSecretConnection)
+	defer dialer.Close()
+	defer acceptor.Close()
+	assertSecretConnsConnected(t, dialer, acceptor)
+	assert.Equal(t, *dialer.sendSecret, *acceptor.recvSecret)
+	assert.NotEqual(t, *dialer.sendSecret, *dialer.recvSecret)
+}
+
+func TestNoiseSecretConnectionRekey(t *testing.T) {
+	dialer, acceptor := makeAcceptedSecretConnPair(t, MakeNoiseSecretConnection, SecretConnectionRekey(dataMaxSize, 0))
+	defer dialer.Close()
+	defer acceptor.Close()
+	assert.True(t, dialer.rekey)
+	assert.True(t, acceptor.rekey)
+	assertSecretConnsConnected(t, dialer, acceptor)
+}
+
+func TestAcceptSTSSecretConnection(t *testing.T) {
+	dialer, acceptor := makeAcceptedSecretConnPair(t, MakeSecretConnection)
+	defer dialer.Close()
+	defer acceptor.Close()
+	assertSecretConnsConnected(t, dialer, acceptor)
+}
+
+func TestNoisePayload(t *testing.T) {
+	privKey := ed25519.GenPrivKey()
+	statPub, _ := genEphKeys()
+	otherStatPub, _ := genEphKeys()
+	payload := sealNoisePayload(&SecretConnection{rekeySupported: true}, privKey, statPub)
+
+	msg, err := openNoisePayload(payload, statPub)
+	require.NoError(t, err)
+	assert.True(t, msg.Key.Equals(privKey.PubKey()))
+	assert.True(t, msg.Rekey)
+
+	// The signature doesn't cover other static keys.
+	_, err = openNoisePayload(payload, otherStatPub)
+	assert.Error(t, err)
+}
+
+func TestNoiseState(t *testing.T) {
+	initiator, responder := newNoiseState(), newNoiseState()
+
+	// Without a key messages are sent in the clear, but still hashed.
+	msg := []byte("hello")
+	plaintext, err := responder.decryptAndHash(initiator.encryptAndHash(msg))
+	require.NoError(t, err)
+	assert.Equal(t, msg, plaintext)
+
+	initiator.mixKey([]byte("secret"))
+	responder.mixKey([]byte("secret"))
+	ciphertext := initiator.encryptAndHash(msg)
+	assert.NotEqual(t, msg, ciphertext)
+
+	// Tampering is detected.
+	tampered := append([]byte(nil), ciphertext...)
+	tampered[0] ^= 0xff
+	_, err = newNoiseStateCopy(responder).decryptAndHash(tampered)
+	assert.Error(t, err)
+
+	plaintext, err = responder.decryptAndHash(ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, msg, plaintext)
+	assert.Equal(t, initiator.h, responder.h)
+
+	i1, i2 := initiator.split()
+	r1, r2 := responder.split()
+	assert.Equal(t, i1, r1)
+	assert.Equal(t, i2, r2)
+}
+
+func newNoiseStateCopy(ns *noiseState) *noiseState {
+	c := *ns
+	return &c
+}
@@ -0,0 +1,56 @@
+package conn
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrRateLimitExceeded is passed to the OnRateLimitExceeded callback when the
+// remote end sends messages on a channel faster than the channel's receive
+// rate limit allows. The offending message is dropped.
+type ErrRateLimitExceeded struct {
+	ChannelID byte
+	Rate      float64
+	Unit      string
+}
+
+func (e ErrRateLimitExceeded) Error() string {
+	return fmt.Sprintf("channel %X exceeded receive rate of %v %s", e.ChannelID, e.Rate, e.Unit)
+}
+
+// tokenBucket limits the rate at which something is consumed. It holds up to
+// one second worth of tokens, so short bursts are tolerated. Takes larger than
+// the bucket overdraw it once it is full, so messages larger than the rate can
+// still pass now and then.
+// NOTE: not goroutine-safe.
+type tokenBucket struct {
+	rate    float64 // tokens per second
+	tokens  float64
+	updated time.Time
+}
+
+func newTokenBucket(rate float64) *tokenBucket {
+	return &tokenBucket{
+		rate:    rate,
+		tokens:  rate,
+		updated: time.Now(),
+	}
+}
+
+// take refills the bucket and takes n tokens from it. Returns false, without
+// taking anything, if there are not enough tokens.
+func (tb *tokenBucket) take(n float64, now time.Time) bool {
+	if elapsed := now.Sub(tb.updated); elapsed > 0 {
+		tb.tokens += tb.rate * elapsed.Seconds()
+		if tb.tokens > tb.rate {
+			tb.tokens = tb.rate
+		}
+		tb.updated = now
+	}
+	if tb.tokens < math.Min(n, tb.rate) {
+		return false
+	}
+	tb.tokens -= n
+	return true
+}
@@ -0,0 +1,37 @@
+package conn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	tb := newTokenBucket(10)
+	now := tb.updated
+
+	// Bursts of up to one second worth of tokens are fine.
+	for i := 0; i < 10; i++ {
+		assert.True(t, tb.take(1, now))
+	}
+	assert.False(t, tb.take(1, now))
+
+	now = now.Add(100 * time.Millisecond)
+	assert.True(t, tb.take(1, now))
+	assert.False(t, tb.take(1, now))
+
+	// The bucket never holds more than one second worth of tokens.
+	now = now.Add(time.Hour)
+	assert.True(t, tb.take(10, now))
+	assert.False(t, tb.take(1, now))
+
+	// Takes larger than the bucket need a full bucket and overdraw it.
+	now = now.Add(time.Second)
+	assert.False(t, tb.take(15, now.Add(-100*time.Millisecond)))
+	assert.True(t, tb.take(15, now))
+	now = now.Add(400 * time.Millisecond)
+	assert.False(t, tb.take(1, now))
+	now = now.Add(200 * time.Millisecond)
+	assert.True(t, tb.take(1, now))
+}
@@ -0,0 +1,60 @@
+package conn
+
+// The sendRoutine picks the channel to send the next PacketMsg from with a
+// weighted deficit round robin scheduler.
+//
+// Every round, each channel is credited a quantum of Priority full packets
+// worth of bytes, up to its SendBurst, and every packet sent is debited from
+// the channel's credit. The pending channel with the most credit left
+// relative to its quantum sends next, ties going to the higher priority. Once
+// no pending channel has credit left, the next round starts.
+//
+// Under load, channels thus share the bandwidth according to their
+// priorities. A channel which was quiet, e.g. consensus while blocks are
+// synced, keeps its credit, so its messages preempt a busy channel after a
+// single packet instead of queueing behind its backlog.
+
+// nextSendChannel returns the channel to send the next PacketMsg from, or nil
+// if no channel has anything to send.
+// Not goroutine-safe, only called by the sendRoutine.
+func (c *MConnection) nextSendChannel() *Channel {
+	for {
+		var (
+			next      *Channel
+			nextRatio float64
+			pending   bool
+		)
+		for _, ch := range c.channels {
+			if !ch.isSendPending() {
+				continue
+			}
+			pending = true
+			if ch.deficit <= 0 {
+				continue
+			}
+			ratio := float64(ch.deficit) / float64(ch.quantum)
+			if next == nil || ratio > nextRatio ||
+				(ratio == nextRatio && ch.desc.Priority > next.desc.Priority) {
+				next, nextRatio = ch, ratio
+			}
+		}
+
+		if next != nil || !pending {
+			return next
+		}
+
+		// Every pending channel used up its credit, start the next round.
+		for _, ch := range c.channels {
+			ch.credit()
+		}
+	}
+}
+
+// credit adds the channel's quantum to its credit, up to its burst.
+// Not goroutine-safe
+func (ch *Channel) credit() {
+	ch.deficit += ch.quantum
+	if ch.deficit > ch.burst {
+		ch.deficit = ch.burst
+	}
+}
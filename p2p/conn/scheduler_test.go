@@ -0,0 +1,162 @@
+package conn
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Channel IDs and default priorities of the consensus vote and blockchain
+// reactors.
+const (
+	testConsensusChannel  = byte(0x22)
+	testBlockchainChannel = byte(0x40)
+)
+
+func createSchedulerTestMConnection(config MConnConfig) *MConnection {
+	chDescs := []*ChannelDescriptor{
+		{ID: testBlockchainChannel, Priority: 10, SendQueueCapacity: 1000},
+		{ID: testConsensusChannel, Priority: 5, SendQueueCapacity: 1000},
+	}
+	return NewMConnectionWithConfig(nil, chDescs, nil, nil, config)
+}
+
+// sendNextPacket writes the next packet like the sendRoutine and returns the
+// ID of its channel, or false if nothing is pending.
+func sendNextPacket(t testing.TB, c *MConnection) (byte, bool) {
+	ch := c.nextSendChannel()
+	if ch == nil {
+		return 0, false
+	}
+	_, err := ch.writePacketMsgTo(ioutil.Discard)
+	require.NoError(t, err)
+	return ch.desc.ID, true
+}
+
+// fillQueue queues full packet messages on the channel until its queue holds
+// n messages.
+func fillQueue(c *MConnection, chID byte, n int) {
+	ch := c.channelsIdx[chID]
+	for len(ch.sendQueue) < n {
+		ch.trySendBytes(make([]byte, c.config.MaxPacketMsgPayloadSize))
+	}
+}
+
+func TestSchedulerSharesBandwidthByPriority(t *testing.T) {
+	c := createSchedulerTestMConnection(DefaultMConnConfig())
+
+	sent := make(map[byte]int)
+	for i := 0; i < 1500; i++ {
+		fillQueue(c, testBlockchainChannel, 10)
+		fillQueue(c, testConsensusChannel, 10)
+		id, ok := sendNextPacket(t, c)
+		require.True(t, ok)
+		sent[id]++
+	}
+
+	assert.Equal(t, 1000, sent[testBlockchainChannel])
+	assert.Equal(t, 500, sent[testConsensusChannel])
+}
+
+func TestSchedulerConsensusPreemptsBlockchain(t *testing.T) {
+	c := createSchedulerTestMConnection(DefaultMConnConfig())
+
+	// Block sync is saturating the connection.
+	for i := 0; i < 100; i++ {
+		fillQueue(c, testBlockchainChannel, 10)
+		_, ok := sendNextPacket(t, c)
+		require.True(t, ok)
+	}
+
+	// A consensus message is sent after at most one more blockchain packet,
+	// even though the blockchain channel has the higher priority.
+	for i := 0; i < 3; i++ {
+		c.channelsIdx[testConsensusChannel].trySendBytes([]byte("vote"))
+		ahead := 0
+		for {
+			fillQueue(c, testBlockchainChannel, 10)
+			id, ok := sendNextPacket(t, c)
+			require.True(t, ok)
+			if id == testConsensusChannel {
+				break
+			}
+			ahead++
+		}
+		assert.True(t, ahead <= 1, "%d blockchain packets sent before the consensus message", ahead)
+	}
+}
+
+func TestSchedulerNothingPending(t *testing.T) {
+	c := createSchedulerTestMConnection(DefaultMConnConfig())
+	_, ok := sendNextPacket(t, c)
+	assert.False(t, ok)
+}
+
+func TestSchedulerConfigOverrides(t *testing.T) {
+	config := DefaultMConnConfig()
+	config.ChannelPriorities = map[byte]int{testConsensusChannel: 20}
+	config.ChannelSendBursts = map[byte]int{testBlockchainChannel: 2 * config.MaxPacketMsgPayloadSize}
+	c := createSchedulerTestMConnection(config)
+
+	status := c.Status()
+	require.Len(t, status.Channels, 2)
+	assert.Equal(t, 10, status.Channels[0].Priority)
+	assert.Equal(t, 2*config.MaxPacketMsgPayloadSize, status.Channels[0].SendBurst)
+	assert.Equal(t, 20, status.Channels[1].Priority)
+	assert.Equal(t, 20*c._maxPacketMsgSize, status.Channels[1].SendBurst)
+
+	// Saturated, the blockchain channel can't send more than its burst of two
+	// packets per round.
+	sent := make(map[byte]int)
+	for i := 0; i < 230; i++ {
+		fillQueue(c, testBlockchainChannel, 10)
+		fillQueue(c, testConsensusChannel, 10)
+		id, ok := sendNextPacket(t, c)
+		require.True(t, ok)
+		sent[id]++
+	}
+	assert.Equal(t, 20, sent[testBlockchainChannel])
+	assert.Equal(t, 210, sent[testConsensusChannel])
+}
+
+// BenchmarkSchedulerConsensusDuringBlockSync measures getting a consensus
+// message out while block sync saturates the connection. It fails if the
+// consensus message doesn't preempt the blockchain messages.
+func BenchmarkSchedulerConsensusDuringBlockSync(b *testing.B) {
+	c := createSchedulerTestMConnection(DefaultMConnConfig())
+	for i := 0; i < 100; i++ {
+		fillQueue(c, testBlockchainChannel, 10)
+		sendNextPacket(b, c)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.channelsIdx[testConsensusChannel].trySendBytes([]byte("vote"))
+		ahead := 0
+		for {
+			fillQueue(c, testBlockchainChannel, 10)
+			if id, _ := sendNextPacket(b, c); id == testConsensusChannel {
+				break
+			}
+			ahead++
+		}
+		if ahead > 1 {
+			b.Fatalf("%d blockchain packets sent before the consensus message", ahead)
+		}
+	}
+}
+
+// BenchmarkSchedulerSaturated measures scheduling packets while both
+// channels are saturated.
+func BenchmarkSchedulerSaturated(b *testing.B) {
+	c := createSchedulerTestMConnection(DefaultMConnConfig())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fillQueue(c, testBlockchainChannel, 10)
+		fillQueue(c, testConsensusChannel, 10)
+		sendNextPacket(b, c)
+	}
+}
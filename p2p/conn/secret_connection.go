@@ -29,6 +29,10 @@ const aeadSizeOverhead = 16 // overhead of poly 1305 authentication tag
 const aeadKeySize = chacha20poly1305.KeySize
 const aeadNonceSize = chacha20poly1305.NonceSize
 
+// rekeyFlag is set in the length of the last frame sent with the current
+// keys, see SecretConnectionRekey.
+const rekeyFlag = 1 << 31
+
 var (
 	ErrSmallOrderRemotePubKey = errors.New("detected low order point from remote peer")
 	ErrSharedSecretIsZero     = errors.New("shared secret is all zeroes")
@@ -64,6 +68,32 @@ type SecretConnection struct {
 
 	sendMtx   sync.Mutex
 	sendNonce *[aeadNonceSize]byte
+
+	// Key rotation, see SecretConnectionRekey. sentSinceRekey and lastRekey
+	// are covered by sendMtx.
+	rekeySupported bool // we advertise rekeying
+	rekey          bool // both sides support rekeying
+	rekeyBytes     int64
+	rekeyInterval  time.Duration
+	sentSinceRekey int64
+	lastRekey      time.Time
+}
+
+// SecretConnectionOption sets an optional parameter on the SecretConnection.
+type SecretConnectionOption func(*SecretConnection)
+
+// SecretConnectionRekey advertises support for key rotation in the handshake.
+// If the peer supports it too, our sending key is rotated after every
+// rekeyBytes bytes sent or every rekeyInterval, whichever comes first, so a
+// long-lived connection doesn't use the same key forever. The peer rotates its
+// receiving key in lock step. Zero values disable the respective limit, so
+// the connection only follows the peer's rotations.
+func SecretConnectionRekey(rekeyBytes int64, rekeyInterval time.Duration) SecretConnectionOption {
+	return func(sc *SecretConnection) {
+		sc.rekeySupported = true
+		sc.rekeyBytes = rekeyBytes
+		sc.rekeyInterval = rekeyInterval
+	}
 }
 
 // MakeSecretConnection performs handshake and returns a new authenticated
@@ -71,7 +101,11 @@ type SecretConnection struct {
 // Returns nil if there is an error in handshake.
 // Caller should call conn.Close()
 // See docs/sts-final.pdf for more information.
-func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*SecretConnection, error) {
+func MakeSecretConnection(
+	conn io.ReadWriteCloser,
+	locPrivKey crypto.PrivKey,
+	options ...SecretConnectionOption,
+) (*SecretConnection, error) {
 	locPubKey := locPrivKey.PubKey()
 
 	// Generate ephemeral keys for perfect forward secrecy.
@@ -110,12 +144,15 @@ func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*
 		recvSecret: recvSecret,
 		sendSecret: sendSecret,
 	}
+	for _, option := range options {
+		option(sc)
+	}
 
 	// Sign the challenge bytes for authentication.
 	locSignature := signChallenge(challenge, locPrivKey)
 
 	// Share (in secret) each other's pubkey & challenge signature
-	authSigMsg, err := shareAuthSignature(sc, locPubKey, locSignature)
+	authSigMsg, err := shareAuthSignature(sc, authSigMessage{locPubKey, locSignature, sc.rekeySupported})
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +164,8 @@ func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*
 
 	// We've authorized.
 	sc.remPubKey = remPubKey
+	sc.rekey = sc.rekeySupported && authSigMsg.Rekey
+	sc.lastRekey = time.Now()
 	return sc, nil
 }
 
@@ -152,7 +191,12 @@ func (sc *SecretConnection) Write(data []byte) (n int, err error) {
 			data = nil
 		}
 		chunkLength := len(chunk)
-		binary.LittleEndian.PutUint32(frame, uint32(chunkLength))
+		rekey := sc.rekeyDue()
+		if rekey {
+			binary.LittleEndian.PutUint32(frame, uint32(chunkLength)|rekeyFlag)
+		} else {
+			binary.LittleEndian.PutUint32(frame, uint32(chunkLength))
+		}
 		copy(frame[dataLenSize:], chunk)
 
 		aead, err := chacha20poly1305.New(sc.sendSecret[:])
@@ -166,6 +210,15 @@ func (sc *SecretConnection) Write(data []byte) (n int, err error) {
 		incrNonce(sc.sendNonce)
 		// end encryption
 
+		// the frame was the last one sent with the old key
+		if rekey {
+			sc.sendSecret = rotateSecret(sc.sendSecret)
+			sc.sendNonce = new([aeadNonceSize]byte)
+			sc.sentSinceRekey = 0
+			sc.lastRekey = time.Now()
+		}
+		sc.sentSinceRekey += int64(chunkLength)
+
 		_, err = sc.conn.Write(sealedFrame)
 		if err != nil {
 			return n, err
@@ -212,6 +265,15 @@ func (sc *SecretConnection) Read(data []byte) (n int, err error) {
 	// copy checkLength worth into data,
 	// set recvBuffer to the rest.
 	var chunkLength = binary.LittleEndian.Uint32(frame) // read the first four bytes
+	if chunkLength&rekeyFlag != 0 {
+		if !sc.rekey {
+			return 0, errors.New("peer rotated keys without negotiating it")
+		}
+		// the frame was the last one received with the old key
+		chunkLength &^= rekeyFlag
+		sc.recvSecret = rotateSecret(sc.recvSecret)
+		sc.recvNonce = new([aeadNonceSize]byte)
+	}
 	if chunkLength > dataMaxSize {
 		return 0, errors.New("chunkLength is greater than dataMaxSize")
 	}
@@ -221,6 +283,18 @@ func (sc *SecretConnection) Read(data []byte) (n int, err error) {
 	return
 }
 
+// rekeyDue returns true if the sending key should be rotated after the next
+// frame. CONTRACT: caller holds sendMtx.
+func (sc *SecretConnection) rekeyDue() bool {
+	if !sc.rekey {
+		return false
+	}
+	if sc.rekeyBytes > 0 && sc.sentSinceRekey >= sc.rekeyBytes {
+		return true
+	}
+	return sc.rekeyInterval > 0 && time.Since(sc.lastRekey) >= sc.rekeyInterval
+}
+
 // Implements net.Conn
 // nolint
 func (sc *SecretConnection) Close() error                  { return sc.conn.Close() }
@@ -358,6 +432,17 @@ func deriveSecretAndChallenge(dhSecret *[32]byte, locIsLeast bool) (recvSecret,
 	return
 }
 
+// rotateSecret derives the key which replaces secret when rotating keys. The
+// derivation is one-way, so later keys don't reveal earlier ones.
+func rotateSecret(secret *[aeadKeySize]byte) *[aeadKeySize]byte {
+	hkdf := hkdf.New(sha256.New, secret[:], nil, []byte("TENDERMINT_SECRET_CONNECTION_REKEY"))
+	newSecret := new([aeadKeySize]byte)
+	if _, err := io.ReadFull(hkdf, newSecret[:]); err != nil {
+		panic(err)
+	}
+	return newSecret
+}
+
 // computeDHSecret computes a Diffie-Hellman shared secret key
 // from our own local private key and the other's public key.
 //
@@ -396,16 +481,17 @@ func signChallenge(challenge *[32]byte, locPrivKey crypto.PrivKey) (signature []
 }
 
 type authSigMessage struct {
-	Key crypto.PubKey
-	Sig []byte
+	Key   crypto.PubKey
+	Sig   []byte
+	Rekey bool // supports key rotation, omitted by older versions
 }
 
-func shareAuthSignature(sc *SecretConnection, pubKey crypto.PubKey, signature []byte) (recvMsg authSigMessage, err error) {
+func shareAuthSignature(sc *SecretConnection, msg authSigMessage) (recvMsg authSigMessage, err error) {
 
 	// Send our info and receive theirs in tandem.
 	var trs, _ = cmn.Parallel(
 		func(_ int) (val interface{}, err error, abort bool) {
-			var _, err1 = cdc.MarshalBinaryLengthPrefixedWriter(sc, authSigMessage{pubKey, signature})
+			var _, err1 = cdc.MarshalBinaryLengthPrefixedWriter(sc, msg)
 			if err1 != nil {
 				return nil, err1, true // abort
 			}
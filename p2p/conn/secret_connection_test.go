@@ -14,6 +14,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,6 +44,13 @@ func makeKVStoreConnPair() (fooConn, barConn kvstoreConn) {
 }
 
 func makeSecretConnPair(tb testing.TB) (fooSecConn, barSecConn *SecretConnection) {
+	return makeSecretConnPairWithOptions(tb, nil, nil)
+}
+
+func makeSecretConnPairWithOptions(
+	tb testing.TB,
+	fooOptions, barOptions []SecretConnectionOption,
+) (fooSecConn, barSecConn *SecretConnection) {
 
 	var fooConn, barConn = makeKVStoreConnPair()
 	var fooPrvKey = ed25519.GenPrivKey()
@@ -53,7 +61,7 @@ func makeSecretConnPair(tb testing.TB) (fooSecConn, barSecConn *SecretConnection
 	// Make connections from both sides in parallel.
 	var trs, ok = cmn.Parallel(
 		func(_ int) (val interface{}, err error, abort bool) {
-			fooSecConn, err = MakeSecretConnection(fooConn, fooPrvKey)
+			fooSecConn, err = MakeSecretConnection(fooConn, fooPrvKey, fooOptions...)
 			if err != nil {
 				tb.Errorf("Failed to establish SecretConnection for foo: %v", err)
 				return nil, err, true
@@ -68,7 +76,7 @@ func makeSecretConnPair(tb testing.TB) (fooSecConn, barSecConn *SecretConnection
 			return nil, nil, false
 		},
 		func(_ int) (val interface{}, err error, abort bool) {
-			barSecConn, err = MakeSecretConnection(barConn, barPrvKey)
+			barSecConn, err = MakeSecretConnection(barConn, barPrvKey, barOptions...)
 			if barSecConn == nil {
 				tb.Errorf("Failed to establish SecretConnection for bar: %v", err)
 				return nil, err, true
@@ -187,6 +195,56 @@ func TestConcurrentRead(t *testing.T) {
 	}
 }
 
+func TestSecretConnectionRekey(t *testing.T) {
+	testCases := []struct {
+		name       string
+		fooOptions []SecretConnectionOption
+		barOptions []SecretConnectionOption
+		rekey      bool
+	}{
+		{"bytes", []SecretConnectionOption{SecretConnectionRekey(2*dataMaxSize, 0)},
+			[]SecretConnectionOption{SecretConnectionRekey(0, 0)}, true},
+		{"interval", []SecretConnectionOption{SecretConnectionRekey(0, time.Nanosecond)},
+			[]SecretConnectionOption{SecretConnectionRekey(0, 0)}, true},
+		{"unsupported by peer", []SecretConnectionOption{SecretConnectionRekey(2*dataMaxSize, 0)}, nil, false},
+		{"disabled", nil, nil, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			fooSecConn, barSecConn := makeSecretConnPairWithOptions(t, tc.fooOptions, tc.barOptions)
+			defer fooSecConn.Close()
+			defer barSecConn.Close()
+			assert.Equal(t, tc.rekey, fooSecConn.rekey)
+			initialSecret := *fooSecConn.sendSecret
+
+			n := 10
+			msg := []byte(cmn.RandStr(dataMaxSize))
+			go func() {
+				for i := 0; i < n; i++ {
+					if _, err := fooSecConn.Write(msg); err != nil {
+						return
+					}
+				}
+			}()
+			buf := make([]byte, dataMaxSize)
+			for i := 0; i < n; i++ {
+				_, err := io.ReadFull(barSecConn, buf)
+				require.NoError(t, err)
+				require.Equal(t, msg, buf)
+			}
+
+			if tc.rekey {
+				assert.NotEqual(t, initialSecret, *fooSecConn.sendSecret)
+			} else {
+				assert.Equal(t, initialSecret, *fooSecConn.sendSecret)
+			}
+			assert.Equal(t, *fooSecConn.sendSecret, *barSecConn.recvSecret)
+		})
+	}
+}
+
 func writeLots(t *testing.T, wg *sync.WaitGroup, conn net.Conn, txt string, n int) {
 	defer wg.Done()
 	for i := 0; i < n; i++ {
@@ -0,0 +1,123 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// inboundConnBurst is the number of connections a single IP may open in
+	// quick succession before the rate limit kicks in.
+	inboundConnBurst = 5
+
+	connLimiterPruneInterval = time.Minute
+)
+
+// connLimiter limits the rate of inbound connections and the number of open
+// inbound connections per source IP. It is consulted in the accept loop, so
+// excess connections are dropped before any filter or handshake runs.
+type connLimiter struct {
+	mtx       sync.Mutex
+	rate      float64 // connections per second, 0 means unlimited
+	maxConns  int     // open connections, 0 means unlimited
+	ips       map[string]*ipConns
+	lastPrune time.Time
+
+	now func() time.Time // overridden in tests
+}
+
+type ipConns struct {
+	tokens float64
+	last   time.Time
+	open   int
+}
+
+func newConnLimiter(maxConns int, rate float64) *connLimiter {
+	return &connLimiter{
+		rate:     rate,
+		maxConns: maxConns,
+		ips:      make(map[string]*ipConns),
+		now:      time.Now,
+	}
+}
+
+// acquire accounts a new connection from ip. If neither limit is exceeded,
+// the returned func has to be called once the connection is closed.
+func (cl *connLimiter) acquire(ip string) (release func(), err error) {
+	cl.mtx.Lock()
+	defer cl.mtx.Unlock()
+
+	now := cl.now()
+	cl.prune(now)
+
+	s, ok := cl.ips[ip]
+	if !ok {
+		s = &ipConns{tokens: inboundConnBurst, last: now}
+		cl.ips[ip] = s
+	}
+
+	if cl.maxConns > 0 && s.open >= cl.maxConns {
+		return nil, fmt.Errorf("%d connections open already", s.open)
+	}
+	if cl.rate > 0 {
+		s.refill(now, cl.rate)
+		if s.tokens < 1 {
+			return nil, fmt.Errorf("more than %v connections per second", cl.rate)
+		}
+		s.tokens--
+	}
+
+	s.open++
+	var once sync.Once
+	return func() { once.Do(func() { cl.release(ip) }) }, nil
+}
+
+func (cl *connLimiter) release(ip string) {
+	cl.mtx.Lock()
+	defer cl.mtx.Unlock()
+	if s, ok := cl.ips[ip]; ok && s.open > 0 {
+		s.open--
+	}
+}
+
+// prune forgets IPs without open connections whose rate limit has fully
+// recovered, so the map doesn't grow with every IP ever seen.
+// CONTRACT: cl.mtx must be held.
+func (cl *connLimiter) prune(now time.Time) {
+	if now.Sub(cl.lastPrune) < connLimiterPruneInterval {
+		return
+	}
+	cl.lastPrune = now
+	for ip, s := range cl.ips {
+		if s.open > 0 {
+			continue
+		}
+		if cl.rate > 0 {
+			s.refill(now, cl.rate)
+		}
+		if cl.rate <= 0 || s.tokens >= inboundConnBurst {
+			delete(cl.ips, ip)
+		}
+	}
+}
+
+func (s *ipConns) refill(now time.Time, rate float64) {
+	s.tokens += now.Sub(s.last).Seconds() * rate
+	if s.tokens > inboundConnBurst {
+		s.tokens = inboundConnBurst
+	}
+	s.last = now
+}
+
+// limitedConn releases its slot in the connLimiter once closed.
+type limitedConn struct {
+	net.Conn
+	release func()
+}
+
+func (c *limitedConn) Close() error {
+	c.release()
+	return c.Conn.Close()
+}
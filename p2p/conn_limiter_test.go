@@ -0,0 +1,71 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnLimiterMaxConns(t *testing.T) {
+	cl := newConnLimiter(2, 0)
+
+	release1, err := cl.acquire("1.2.3.4")
+	require.NoError(t, err)
+	_, err = cl.acquire("1.2.3.4")
+	require.NoError(t, err)
+	_, err = cl.acquire("1.2.3.4")
+	assert.Error(t, err)
+
+	// Other IPs are unaffected.
+	_, err = cl.acquire("5.6.7.8")
+	assert.NoError(t, err)
+
+	// Releasing twice frees a single slot only.
+	release1()
+	release1()
+	_, err = cl.acquire("1.2.3.4")
+	require.NoError(t, err)
+	_, err = cl.acquire("1.2.3.4")
+	assert.Error(t, err)
+}
+
+func TestConnLimiterRate(t *testing.T) {
+	cl := newConnLimiter(0, 2)
+	now := time.Now()
+	cl.now = func() time.Time { return now }
+
+	for i := 0; i < inboundConnBurst; i++ {
+		release, err := cl.acquire("1.2.3.4")
+		require.NoError(t, err)
+		release()
+	}
+	_, err := cl.acquire("1.2.3.4")
+	assert.Error(t, err)
+
+	now = now.Add(500 * time.Millisecond)
+	_, err = cl.acquire("1.2.3.4")
+	assert.NoError(t, err)
+	_, err = cl.acquire("1.2.3.4")
+	assert.Error(t, err)
+}
+
+func TestConnLimiterPrune(t *testing.T) {
+	cl := newConnLimiter(0, 1)
+	now := time.Now()
+	cl.now = func() time.Time { return now }
+
+	release, err := cl.acquire("1.2.3.4")
+	require.NoError(t, err)
+	_, err = cl.acquire("5.6.7.8")
+	require.NoError(t, err)
+	release()
+
+	// Only IPs without open connections and a recovered rate are forgotten.
+	now = now.Add(connLimiterPruneInterval)
+	_, err = cl.acquire("9.9.9.9")
+	require.NoError(t, err)
+	assert.NotContains(t, cl.ips, "1.2.3.4")
+	assert.Contains(t, cl.ips, "5.6.7.8")
+}
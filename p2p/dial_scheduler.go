@@ -0,0 +1,297 @@
+package p2p
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+)
+
+// DialBackoff configures how the DialScheduler redials a persistent peer.
+type DialBackoff struct {
+	// Delay after the first failed dial. It doubles with every failure in a
+	// row, up to Max.
+	Base time.Duration
+	Max  time.Duration
+
+	// Fraction by which delays are randomly shortened, so peers which
+	// disconnected at once aren't redialed in lockstep.
+	Jitter float64
+
+	// Number of failures in a row after which the peer is given up on. 0
+	// never gives up.
+	MaxAttempts int
+
+	// Connections dropped before they lasted this long count as failures,
+	// so flapping peers are backed off too.
+	StablePeriod time.Duration
+}
+
+// DefaultDialBackoff returns the default DialBackoff.
+func DefaultDialBackoff() DialBackoff {
+	return DialBackoff{
+		Base:         1 * time.Second,
+		Max:          10 * time.Minute,
+		Jitter:       0.2,
+		MaxAttempts:  100,
+		StablePeriod: 1 * time.Minute,
+	}
+}
+
+// Delay returns the delay before redialing a peer after failures failed
+// dials in a row, without jitter.
+func (b DialBackoff) Delay(failures int) time.Duration {
+	if failures <= 0 {
+		return 0
+	}
+	d := b.Base
+	for i := 1; i < failures && d < b.Max; i++ {
+		d *= 2
+	}
+	if d > b.Max {
+		d = b.Max
+	}
+	return d
+}
+
+// DialStatus is the status of a peer in the DialScheduler.
+type DialStatus string
+
+// Statuses of peers in the DialScheduler.
+const (
+	DialStatusWaiting   DialStatus = "waiting"   // until NextDial
+	DialStatusDialing   DialStatus = "dialing"   // dial in progress
+	DialStatusConnected DialStatus = "connected" // since ConnectedAt
+	DialStatusGaveUp    DialStatus = "gave_up"   // after MaxAttempts failures
+)
+
+// DialState is the reconnect state of a persistent peer.
+type DialState struct {
+	ID          ID         `json:"id"`
+	Address     string     `json:"address"`
+	Status      DialStatus `json:"status"`
+	Failures    int        `json:"failures"` // in a row, including unstable connections
+	LastError   string     `json:"last_error,omitempty"`
+	NextDial    time.Time  `json:"next_dial"`
+	ConnectedAt time.Time  `json:"connected_at"`
+}
+
+var errConnectionNotStable = errors.New("connection dropped before it was stable")
+
+type dialState struct {
+	addr        *NetAddress
+	status      DialStatus
+	failures    int
+	lastErr     error
+	nextDial    time.Time
+	connectedAt time.Time
+}
+
+// DialScheduler redials persistent peers which disconnected or couldn't be
+// dialed, backing off exponentially while they keep failing. Peers are
+// scheduled by the Switch and dialed from a single routine, so there's at
+// most one dial in progress per peer.
+type DialScheduler struct {
+	cmn.BaseService
+
+	backoff DialBackoff
+	dial    func(*NetAddress) error // returns nil once connected
+
+	mtx   sync.Mutex
+	peers map[ID]*dialState
+	wake  chan struct{}
+
+	rng *cmn.Rand
+	now func() time.Time // overridden in tests
+}
+
+// NewDialScheduler returns a DialScheduler redialing peers with dial, which
+// has to return nil if we are connected to the peer afterwards.
+func NewDialScheduler(backoff DialBackoff, dial func(*NetAddress) error) *DialScheduler {
+	s := &DialScheduler{
+		backoff: backoff,
+		dial:    dial,
+		peers:   make(map[ID]*dialState),
+		wake:    make(chan struct{}, 1),
+		rng:     cmn.NewRand(),
+		now:     time.Now,
+	}
+	s.BaseService = *cmn.NewBaseService(nil, "DialScheduler", s)
+	return s
+}
+
+// OnStart implements BaseService.
+func (s *DialScheduler) OnStart() error {
+	go s.routine()
+	return nil
+}
+
+// Schedule redials the peer at addr. A peer which isn't known yet, was
+// connected for at least StablePeriod or was given up on is dialed right
+// away. A peer which dropped sooner counts as failed and is dialed after a
+// backoff. Peers already waiting or being dialed aren't affected.
+func (s *DialScheduler) Schedule(addr *NetAddress) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := s.now()
+	st, ok := s.peers[addr.ID]
+	if !ok {
+		st = &dialState{}
+		s.peers[addr.ID] = st
+	}
+	switch st.status {
+	case DialStatusWaiting, DialStatusDialing:
+		return
+	case DialStatusConnected:
+		if now.Sub(st.connectedAt) < s.backoff.StablePeriod {
+			st.failures++
+			st.lastErr = errConnectionNotStable
+		} else {
+			st.failures = 0
+		}
+	default:
+		st.failures = 0
+	}
+	st.addr = addr
+
+	if s.backoff.MaxAttempts > 0 && st.failures >= s.backoff.MaxAttempts {
+		st.status = DialStatusGaveUp
+		s.Logger.Error("Peer keeps disconnecting. Giving up", "addr", addr, "failures", st.failures)
+		return
+	}
+	st.status = DialStatusWaiting
+	st.nextDial = now.Add(s.delay(st.failures))
+	s.Logger.Info("Reconnecting to peer", "addr", addr, "at", st.nextDial)
+	s.wakeUp()
+}
+
+// Cancel stops redialing the peer with the given ID. A dial in progress is
+// not aborted, but its outcome is ignored.
+func (s *DialScheduler) Cancel(id ID) {
+	s.mtx.Lock()
+	delete(s.peers, id)
+	s.mtx.Unlock()
+}
+
+// States returns the reconnect states of all scheduled peers, sorted by ID.
+func (s *DialScheduler) States() []DialState {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	states := make([]DialState, 0, len(s.peers))
+	for id, st := range s.peers {
+		state := DialState{
+			ID:          id,
+			Address:     st.addr.String(),
+			Status:      st.status,
+			Failures:    st.failures,
+			ConnectedAt: st.connectedAt,
+		}
+		if st.status == DialStatusWaiting {
+			state.NextDial = st.nextDial
+		}
+		if st.lastErr != nil {
+			state.LastError = st.lastErr.Error()
+		}
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].ID < states[j].ID })
+	return states
+}
+
+// delay returns the jittered delay after failures failed dials.
+func (s *DialScheduler) delay(failures int) time.Duration {
+	d := s.backoff.Delay(failures)
+	return d - time.Duration(s.backoff.Jitter*s.rng.Float64()*float64(d))
+}
+
+func (s *DialScheduler) wakeUp() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// routine starts the due dials and sleeps until the next one is due or a
+// peer is scheduled.
+func (s *DialScheduler) routine() {
+	for {
+		var (
+			timer   *time.Timer
+			timeout <-chan time.Time
+		)
+		if next, ok := s.startDueDials(); ok {
+			timer = time.NewTimer(next.Sub(s.now()))
+			timeout = timer.C
+		}
+
+		select {
+		case <-timeout:
+		case <-s.wake:
+		case <-s.Quit():
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if !s.IsRunning() {
+			return
+		}
+	}
+}
+
+// startDueDials dials all waiting peers which are due. It returns when the
+// next waiting peer is due, if any.
+func (s *DialScheduler) startDueDials() (next time.Time, ok bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := s.now()
+	for _, st := range s.peers {
+		if st.status != DialStatusWaiting {
+			continue
+		}
+		if !st.nextDial.After(now) {
+			st.status = DialStatusDialing
+			go s.dialPeer(st.addr)
+			continue
+		}
+		if !ok || st.nextDial.Before(next) {
+			next, ok = st.nextDial, true
+		}
+	}
+	return next, ok
+}
+
+func (s *DialScheduler) dialPeer(addr *NetAddress) {
+	err := s.dial(addr)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	st, ok := s.peers[addr.ID]
+	if !ok || st.status != DialStatusDialing {
+		return // cancelled
+	}
+	if err == nil {
+		st.status = DialStatusConnected
+		st.connectedAt = s.now()
+		st.lastErr = nil
+		return
+	}
+
+	st.failures++
+	st.lastErr = err
+	if s.backoff.MaxAttempts > 0 && st.failures >= s.backoff.MaxAttempts {
+		st.status = DialStatusGaveUp
+		s.Logger.Error("Failed to reconnect to peer. Giving up", "addr", addr, "failures", st.failures)
+		return
+	}
+	st.status = DialStatusWaiting
+	st.nextDial = s.now().Add(s.delay(st.failures))
+	s.Logger.Info("Error reconnecting to peer. Trying again",
+		"addr", addr, "failures", st.failures, "at", st.nextDial, "err", err)
+	s.wakeUp()
+}
@@ -0,0 +1,179 @@
+package p2p
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// testDialer fails the first failures dials and records when dials happen.
+type testDialer struct {
+	mtx      sync.Mutex
+	failures int
+	dials    []time.Time
+}
+
+func (d *testDialer) dial(addr *NetAddress) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.dials = append(d.dials, time.Now())
+	if len(d.dials) <= d.failures {
+		return errors.New("dial failed")
+	}
+	return nil
+}
+
+func (d *testDialer) numDials() int {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return len(d.dials)
+}
+
+func newTestDialScheduler(t *testing.T, backoff DialBackoff, d *testDialer) *DialScheduler {
+	s := NewDialScheduler(backoff, d.dial)
+	s.SetLogger(log.TestingLogger())
+	require.NoError(t, s.Start())
+	return s
+}
+
+func testDialAddr(t *testing.T) *NetAddress {
+	addr, err := NewNetAddressString(IDAddressString(newTierMockPeer().ID(), "127.0.0.1:26656"))
+	require.NoError(t, err)
+	return addr
+}
+
+// waitForDialStatus waits until the only scheduled peer has the status.
+func waitForDialStatus(t *testing.T, s *DialScheduler, status DialStatus) DialState {
+	var states []DialState
+	for i := 0; i < 200; i++ {
+		states = s.States()
+		if len(states) == 1 && states[0].Status == status {
+			return states[0]
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	require.FailNow(t, "timed out waiting for dial status", "%v, got %v", status, states)
+	return DialState{}
+}
+
+func TestDialBackoffDelay(t *testing.T) {
+	b := DialBackoff{Base: time.Second, Max: 10 * time.Second}
+	for failures, delay := range []time.Duration{
+		0, time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second,
+	} {
+		assert.Equal(t, delay, b.Delay(failures), "%d failures", failures)
+	}
+	assert.Equal(t, 10*time.Second, b.Delay(1000))
+}
+
+func TestDialSchedulerBacksOff(t *testing.T) {
+	d := &testDialer{failures: 3}
+	s := newTestDialScheduler(t, DialBackoff{
+		Base:         20 * time.Millisecond,
+		Max:          50 * time.Millisecond,
+		StablePeriod: time.Hour,
+	}, d)
+	defer s.Stop()
+
+	addr := testDialAddr(t)
+	s.Schedule(addr)
+	state := waitForDialStatus(t, s, DialStatusConnected)
+	assert.Equal(t, addr.ID, state.ID)
+	assert.Equal(t, addr.String(), state.Address)
+	assert.Equal(t, 3, state.Failures)
+	assert.Empty(t, state.LastError)
+
+	d.mtx.Lock()
+	require.Len(t, d.dials, 4)
+	for i, min := range []time.Duration{20, 40, 50} {
+		assert.True(t, d.dials[i+1].Sub(d.dials[i]) >= min*time.Millisecond,
+			"delay after failure %d: %v", i+1, d.dials[i+1].Sub(d.dials[i]))
+	}
+	d.mtx.Unlock()
+}
+
+func TestDialSchedulerGivesUp(t *testing.T) {
+	d := &testDialer{failures: 1000}
+	s := newTestDialScheduler(t, DialBackoff{
+		Base:        time.Millisecond,
+		Max:         time.Millisecond,
+		MaxAttempts: 3,
+	}, d)
+	defer s.Stop()
+
+	addr := testDialAddr(t)
+	s.Schedule(addr)
+	state := waitForDialStatus(t, s, DialStatusGaveUp)
+	assert.Equal(t, 3, state.Failures)
+	assert.Equal(t, "dial failed", state.LastError)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, 3, d.numDials())
+
+	// Scheduling the peer again starts over.
+	s.Schedule(addr)
+	waitForDialStatus(t, s, DialStatusGaveUp)
+	assert.Equal(t, 6, d.numDials())
+}
+
+func TestDialSchedulerFlappingPeer(t *testing.T) {
+	d := &testDialer{}
+	s := newTestDialScheduler(t, DialBackoff{
+		Base:         10 * time.Millisecond,
+		Max:          time.Hour,
+		MaxAttempts:  4,
+		StablePeriod: time.Hour,
+	}, d)
+	defer s.Stop()
+
+	// The peer disconnects right after every dial, which is backed off like
+	// a failed dial.
+	addr := testDialAddr(t)
+	start := time.Now()
+	s.Schedule(addr)
+	for i := 0; i < 4; i++ {
+		state := waitForDialStatus(t, s, DialStatusConnected)
+		assert.Equal(t, i, state.Failures)
+		s.Schedule(addr)
+	}
+	state := waitForDialStatus(t, s, DialStatusGaveUp)
+	assert.Equal(t, 4, state.Failures)
+	assert.Equal(t, errConnectionNotStable.Error(), state.LastError)
+	assert.Equal(t, 4, d.numDials())
+	assert.True(t, time.Since(start) >= 70*time.Millisecond) // 10+20+40ms
+
+	// Connections lasting StablePeriod reset the failures.
+	s.Schedule(addr)
+	waitForDialStatus(t, s, DialStatusConnected)
+	s.mtx.Lock()
+	s.peers[addr.ID].connectedAt = time.Now().Add(-time.Hour)
+	s.mtx.Unlock()
+	s.Schedule(addr)
+	state = waitForDialStatus(t, s, DialStatusConnected)
+	assert.Equal(t, 0, state.Failures)
+}
+
+func TestDialSchedulerCancel(t *testing.T) {
+	d := &testDialer{failures: 1000}
+	s := newTestDialScheduler(t, DialBackoff{
+		Base: 10 * time.Millisecond,
+		Max:  10 * time.Millisecond,
+	}, d)
+	defer s.Stop()
+
+	addr := testDialAddr(t)
+	s.Schedule(addr)
+	waitForDialStatus(t, s, DialStatusWaiting)
+	s.Cancel(addr.ID)
+	assert.Empty(t, s.States())
+
+	dials := d.numDials()
+	time.Sleep(50 * time.Millisecond)
+	assert.True(t, d.numDials() <= dials+1, "cancelled peer redialed") // one may be in progress
+	assert.Empty(t, s.States())
+}
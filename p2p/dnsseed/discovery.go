@@ -0,0 +1,185 @@
+// Package dnsseed bootstraps peers from DNS records, so the seed
+// infrastructure can be changed without editing the config of every node.
+//
+// Every configured name is looked up as a TXT and as an SRV record:
+//
+//   - TXT records hold addresses in the usual ID@host:port form. A record may
+//     hold multiple addresses separated by commas or spaces.
+//   - SRV records point at hosts whose first label is the node ID, e.g.
+//     93529da3435c090d02251a050342b6a488d4ab56.seeds.example.com, on the port
+//     of the record.
+package dnsseed
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+const (
+	// DefaultRefreshPeriod is how often the names are resolved again.
+	DefaultRefreshPeriod = 10 * time.Minute
+
+	lookupTimeout = 10 * time.Second
+)
+
+// Resolver looks up DNS records. It is implemented by *net.Resolver.
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// Discovery periodically resolves DNS names to peer addresses and adds them
+// to the address book.
+type Discovery struct {
+	cmn.BaseService
+
+	book          p2p.AddrBook
+	names         []string
+	refreshPeriod time.Duration
+	resolver      Resolver
+}
+
+// DiscoveryOption sets an optional parameter on the Discovery.
+type DiscoveryOption func(*Discovery)
+
+// WithResolver sets the resolver used for lookups. Defaults to
+// net.DefaultResolver.
+func WithResolver(r Resolver) DiscoveryOption {
+	return func(d *Discovery) { d.resolver = r }
+}
+
+// WithRefreshPeriod sets how often the names are resolved again. Defaults to
+// DefaultRefreshPeriod.
+func WithRefreshPeriod(period time.Duration) DiscoveryOption {
+	return func(d *Discovery) { d.refreshPeriod = period }
+}
+
+// NewDiscovery returns a Discovery of the peers published under names.
+func NewDiscovery(book p2p.AddrBook, names []string, options ...DiscoveryOption) *Discovery {
+	d := &Discovery{
+		book:          book,
+		names:         names,
+		refreshPeriod: DefaultRefreshPeriod,
+		resolver:      net.DefaultResolver,
+	}
+	d.BaseService = *cmn.NewBaseService(nil, "DNSSeedDiscovery", d)
+	for _, option := range options {
+		option(d)
+	}
+	return d
+}
+
+// OnStart implements cmn.Service. The names are resolved once before it
+// returns, so the addresses are known when the peer exchange starts.
+func (d *Discovery) OnStart() error {
+	d.refresh()
+	go d.refreshRoutine()
+	return nil
+}
+
+func (d *Discovery) refreshRoutine() {
+	ticker := time.NewTicker(d.refreshPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.refresh()
+		case <-d.Quit():
+			return
+		}
+	}
+}
+
+// refresh resolves all names and adds the addresses to the address book.
+func (d *Discovery) refresh() {
+	for _, name := range d.names {
+		addrs, err := d.Resolve(name)
+		if err != nil {
+			d.Logger.Error("Failed to resolve DNS seed", "name", name, "err", err)
+			continue
+		}
+		added := 0
+		for _, addr := range addrs {
+			// Addresses are their own source, like the ones of inbound peers.
+			if err := d.book.AddAddress(addr, addr); err != nil {
+				d.Logger.Debug("Failed to add address", "addr", addr, "err", err)
+				continue
+			}
+			added++
+		}
+		d.Logger.Info("Resolved DNS seed", "name", name, "addrs", len(addrs), "added", added)
+	}
+	d.book.Save()
+}
+
+// Resolve returns the addresses published under name. Malformed records are
+// skipped. It errors only if neither lookup succeeded.
+func (d *Discovery) Resolve(name string) ([]*p2p.NetAddress, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+	defer cancel()
+
+	txts, txtErr := d.resolver.LookupTXT(ctx, name)
+	_, srvs, srvErr := d.resolver.LookupSRV(ctx, "", "", name)
+	if txtErr != nil && srvErr != nil {
+		return nil, fmt.Errorf("TXT lookup: %v, SRV lookup: %v", txtErr, srvErr)
+	}
+
+	var addrs []*p2p.NetAddress
+	for _, txt := range txts {
+		for _, s := range strings.FieldsFunc(txt, func(r rune) bool { return r == ',' || r == ' ' }) {
+			addr, err := d.parseAddress(ctx, s)
+			if err != nil {
+				d.Logger.Debug("Skipping TXT record", "name", name, "addr", s, "err", err)
+				continue
+			}
+			addrs = append(addrs, addr)
+		}
+	}
+	for _, srv := range srvs {
+		target := strings.TrimSuffix(srv.Target, ".")
+		id := strings.SplitN(target, ".", 2)[0]
+		addr, err := d.parseAddress(ctx, fmt.Sprintf("%s@%s:%d", id, target, srv.Port))
+		if err != nil {
+			d.Logger.Debug("Skipping SRV record", "name", name, "target", srv.Target, "err", err)
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// parseAddress parses an ID@host:port address, using the resolver for host
+// names.
+func (d *Discovery) parseAddress(ctx context.Context, s string) (*p2p.NetAddress, error) {
+	spl := strings.SplitN(s, "@", 2)
+	if len(spl) != 2 {
+		return nil, p2p.ErrNetAddressNoID{Addr: s}
+	}
+	host, port, err := net.SplitHostPort(spl[1])
+	if err != nil {
+		return nil, p2p.ErrNetAddressInvalid{Addr: s, Err: err}
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return nil, p2p.ErrNetAddressInvalid{Addr: s, Err: err}
+	}
+	if net.ParseIP(host) == nil {
+		ips, err := d.resolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, p2p.ErrNetAddressLookup{Addr: host, Err: err}
+		}
+		if len(ips) == 0 {
+			return nil, p2p.ErrNetAddressLookup{Addr: host, Err: fmt.Errorf("no IP addresses")}
+		}
+		host = ips[0].IP.String()
+	}
+	return p2p.NewNetAddressString(p2p.IDAddressString(p2p.ID(spl[0]), net.JoinHostPort(host, port)))
+}
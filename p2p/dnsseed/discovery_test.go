@@ -0,0 +1,110 @@
+package dnsseed
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+const (
+	id1 = "93529da3435c090d02251a050342b6a488d4ab56"
+	id2 = "5576458aef205977e18fd50b274e9b5d9014525a"
+	id3 = "ed3dfd27bfc4af18f67a49862f04cc100696e84d"
+)
+
+type testResolver struct {
+	txt map[string][]string
+	srv map[string][]*net.SRV
+	ip  map[string][]net.IPAddr
+}
+
+func (r testResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if txts, ok := r.txt[name]; ok {
+		return txts, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+func (r testResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	if srvs, ok := r.srv[name]; ok {
+		return name, srvs, nil
+	}
+	return "", nil, errors.New("no such host")
+}
+
+func (r testResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	if ips, ok := r.ip[host]; ok {
+		return ips, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+type testAddrBook struct {
+	p2p.AddrBook
+	mtx   sync.Mutex
+	addrs []*p2p.NetAddress
+}
+
+func (b *testAddrBook) AddAddress(addr *p2p.NetAddress, src *p2p.NetAddress) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.addrs = append(b.addrs, addr)
+	return nil
+}
+
+func (b *testAddrBook) Save() {}
+
+func TestDiscoveryResolve(t *testing.T) {
+	resolver := testResolver{
+		txt: map[string][]string{
+			"seeds.example.com": {
+				id1 + "@1.2.3.4:26656, " + id2 + "@node.example.com:26656",
+				"not-an-address",
+			},
+		},
+		srv: map[string][]*net.SRV{
+			"seeds.example.com": {
+				{Target: id3 + ".seeds.example.com.", Port: 26666},
+				{Target: "nodeid.seeds.example.com.", Port: 26666},
+			},
+		},
+		ip: map[string][]net.IPAddr{
+			"node.example.com":         {{IP: net.IPv4(5, 6, 7, 8)}},
+			id3 + ".seeds.example.com": {{IP: net.IPv4(9, 10, 11, 12)}},
+			"nodeid.seeds.example.com": {{IP: net.IPv4(9, 10, 11, 13)}},
+		},
+	}
+	book := &testAddrBook{}
+	d := NewDiscovery(book, []string{"seeds.example.com", "unknown.example.com"}, WithResolver(resolver))
+	d.SetLogger(log.TestingLogger())
+
+	addrs, err := d.Resolve("seeds.example.com")
+	require.NoError(t, err)
+	var strs []string
+	for _, addr := range addrs {
+		strs = append(strs, addr.String())
+	}
+	assert.Equal(t, []string{
+		id1 + "@1.2.3.4:26656",
+		id2 + "@5.6.7.8:26656",
+		id3 + "@9.10.11.12:26666",
+	}, strs)
+
+	_, err = d.Resolve("unknown.example.com")
+	assert.Error(t, err)
+
+	// Starting adds the addresses of all names which resolve.
+	require.NoError(t, d.Start())
+	defer d.Stop()
+	book.mtx.Lock()
+	assert.Len(t, book.addrs, 3)
+	book.mtx.Unlock()
+}
@@ -2,6 +2,7 @@ package dummy
 
 import (
 	"net"
+	"time"
 
 	cmn "github.com/tendermint/tendermint/libs/common"
 	p2p "github.com/tendermint/tendermint/p2p"
@@ -70,6 +71,16 @@ func (p *peer) Status() tmconn.ConnectionStatus {
 	return tmconn.ConnectionStatus{}
 }
 
+// HasFeature always returns false.
+func (p *peer) HasFeature(string) bool {
+	return false
+}
+
+// Latency always returns 0.
+func (p *peer) Latency() time.Duration {
+	return 0
+}
+
 // Send does not do anything and just returns true.
 func (p *peer) Send(byte, []byte) bool {
 	return true
@@ -80,6 +91,11 @@ func (p *peer) TrySend(byte, []byte) bool {
 	return true
 }
 
+// SendQueueSize always returns 0.
+func (p *peer) SendQueueSize(byte) int {
+	return 0
+}
+
 // Set records value under key specified in the map.
 func (p *peer) Set(key string, value interface{}) {
 	p.kv[key] = value
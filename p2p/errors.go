@@ -20,11 +20,16 @@ type ErrRejected struct {
 	err               error
 	id                ID
 	isAuthFailure     bool
+	isBanned          bool
 	isDuplicate       bool
 	isFiltered        bool
 	isIncompatible    bool
 	isNodeInfoInvalid bool
 	isSelf            bool
+	isThrottled       bool
+
+	// isNoiseFailure is set if the dialed peer failed the Noise handshake.
+	isNoiseFailure bool
 }
 
 // Addr returns the NetAddress for the rejected Peer.
@@ -37,6 +42,10 @@ func (e ErrRejected) Error() string {
 		return fmt.Sprintf("auth failure: %s", e.err)
 	}
 
+	if e.isBanned {
+		return fmt.Sprintf("banned ID<%v>", e.id)
+	}
+
 	if e.isDuplicate {
 		if e.conn != nil {
 			return fmt.Sprintf(
@@ -75,12 +84,23 @@ func (e ErrRejected) Error() string {
 		return fmt.Sprintf("self ID<%v>", e.id)
 	}
 
+	if e.isThrottled {
+		return fmt.Sprintf(
+			"throttled CONN<%s>: %s",
+			e.conn.RemoteAddr().String(),
+			e.err,
+		)
+	}
+
 	return fmt.Sprintf("%s", e.err)
 }
 
 // IsAuthFailure when Peer authentication was unsuccessful.
 func (e ErrRejected) IsAuthFailure() bool { return e.isAuthFailure }
 
+// IsBanned when Peer ID or IP is on the ban list.
+func (e ErrRejected) IsBanned() bool { return e.isBanned }
+
 // IsDuplicate when Peer ID or IP are present already.
 func (e ErrRejected) IsDuplicate() bool { return e.isDuplicate }
 
@@ -96,6 +116,9 @@ func (e ErrRejected) IsNodeInfoInvalid() bool { return e.isNodeInfoInvalid }
 // IsSelf when Peer is our own node.
 func (e ErrRejected) IsSelf() bool { return e.isSelf }
 
+// IsThrottled when too many connections came from the IP of the Peer.
+func (e ErrRejected) IsThrottled() bool { return e.isThrottled }
+
 // ErrSwitchDuplicatePeerID to be raised when a peer is connecting with a known
 // ID.
 type ErrSwitchDuplicatePeerID struct {
@@ -116,6 +139,20 @@ func (e ErrSwitchDuplicatePeerIP) Error() string {
 	return fmt.Sprintf("Duplicate peer IP %v", e.IP.String())
 }
 
+// ErrSwitchBannedPeer to be raised when connecting to or from a peer whose ID
+// or IP is banned.
+type ErrSwitchBannedPeer struct {
+	ID ID
+	IP net.IP
+}
+
+func (e ErrSwitchBannedPeer) Error() string {
+	if e.ID != "" {
+		return fmt.Sprintf("Banned peer ID %v", e.ID)
+	}
+	return fmt.Sprintf("Banned peer IP %v", e.IP.String())
+}
+
 // ErrSwitchConnectToSelf to be raised when trying to connect to itself.
 type ErrSwitchConnectToSelf struct {
 	Addr *NetAddress
@@ -138,6 +175,16 @@ func (e ErrSwitchAuthenticationFailure) Error() string {
 	)
 }
 
+// ErrCurrentlyDialingOrExistingAddress indicates that we're currently
+// dialing this address or it belongs to an existing peer.
+type ErrCurrentlyDialingOrExistingAddress struct {
+	Addr *NetAddress
+}
+
+func (e ErrCurrentlyDialingOrExistingAddress) Error() string {
+	return fmt.Sprintf("Connection with %v has been established or dialed", e.Addr)
+}
+
 // ErrTransportClosed is raised when the Transport has been closed.
 type ErrTransportClosed struct{}
 
@@ -0,0 +1,76 @@
+package p2p
+
+// Protocol features a node can advertise in its NodeInfo. A feature is only
+// used with a peer if both sides advertise it, so new wire features can be
+// rolled out gradually: nodes enable them for upgraded peers and fall back to
+// the old behaviour for all others. Reactors query them with Peer.HasFeature.
+const (
+	// FeatureCompression means the node understands NodeInfo.Compression and
+	// decompresses messages on the channels negotiated with it.
+	FeatureCompression = "compression"
+
+	// FeatureNoise means the node accepts connections authenticated with the
+	// Noise handshake, see conn.MakeNoiseSecretConnection.
+	FeatureNoise = "noise"
+
+	// FeatureTxInventory means the node's mempool reactor announces the hashes
+	// of its txs, and sends the txs its peers request, rather than pushing them.
+	FeatureTxInventory = "tx-inventory"
+
+	// FeatureVoteBatch means the node's consensus reactor understands several
+	// votes of a vote set sent in a single message.
+	FeatureVoteBatch = "vote-batch"
+
+	// FeatureCatchupCommit means the node's consensus reactor understands the
+	// commit of a height sent in a single message, to catch up with it.
+	FeatureCatchupCommit = "catchup-commit"
+)
+
+// supportedFeatures are the features this version implements.
+var supportedFeatures = []string{
+	FeatureCompression,
+	FeatureNoise,
+	FeatureTxInventory,
+	FeatureVoteBatch,
+	FeatureCatchupCommit,
+}
+
+// SupportedFeatures returns the protocol features this version implements,
+// for advertising them in the NodeInfo.
+func SupportedFeatures() []string {
+	return append([]string(nil), supportedFeatures...)
+}
+
+func hasFeature(features []string, feature string) bool {
+	for _, f := range features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// negotiateFeatures returns the features advertised by both ourInfo and
+// theirInfo.
+func negotiateFeatures(ourInfo, theirInfo NodeInfo) map[string]struct{} {
+	features := make(map[string]struct{})
+	ours, ok := ourInfo.(DefaultNodeInfo)
+	if !ok {
+		return features
+	}
+	theirs, ok := theirInfo.(DefaultNodeInfo)
+	if !ok {
+		return features
+	}
+
+	theirFeatures := make(map[string]struct{}, len(theirs.Features))
+	for _, feature := range theirs.Features {
+		theirFeatures[feature] = struct{}{}
+	}
+	for _, feature := range ours.Features {
+		if _, ok := theirFeatures[feature]; ok {
+			features[feature] = struct{}{}
+		}
+	}
+	return features
+}
@@ -0,0 +1,31 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateFeatures(t *testing.T) {
+	ours := DefaultNodeInfo{Features: []string{FeatureCompression, "batch-votes"}}
+	theirs := DefaultNodeInfo{Features: []string{"future", FeatureCompression}}
+	assert.Equal(t, map[string]struct{}{FeatureCompression: {}}, negotiateFeatures(ours, theirs))
+
+	// Older nodes don't advertise any features.
+	assert.Empty(t, negotiateFeatures(ours, DefaultNodeInfo{}))
+
+	p := &peer{nodeInfo: theirs}
+	PeerFeatures(ours)(p)
+	assert.True(t, p.HasFeature(FeatureCompression))
+	assert.False(t, p.HasFeature("batch-votes"))
+	assert.False(t, p.HasFeature("future"))
+}
+
+func TestSupportedFeatures(t *testing.T) {
+	features := SupportedFeatures()
+	assert.Contains(t, features, FeatureCompression)
+
+	// Callers can't modify our features.
+	features[0] = "modified"
+	assert.NotEqual(t, features, SupportedFeatures())
+}
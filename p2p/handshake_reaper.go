@@ -0,0 +1,81 @@
+package p2p
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+const handshakeReaperInterval = time.Second
+
+// handshakeReaper closes connections which are still being upgraded after
+// their deadline. The transport sets deadlines on the connections it
+// handshakes, but those are only as good as the TransportNetwork honouring
+// them, and the conn filters don't get one at all. Closing the connection
+// unblocks the goroutine upgrading it, so stuck handshakes don't accumulate.
+type handshakeReaper struct {
+	mtx   sync.Mutex
+	conns map[net.Conn]time.Time // deadline by connection
+
+	now func() time.Time // overridden in tests
+}
+
+func newHandshakeReaper() *handshakeReaper {
+	return &handshakeReaper{
+		conns: make(map[net.Conn]time.Time),
+		now:   time.Now,
+	}
+}
+
+// track closes c if it's still tracked after deadline. The returned func has
+// to be called once the handshake is done.
+func (r *handshakeReaper) track(c net.Conn, deadline time.Time) (done func()) {
+	r.mtx.Lock()
+	r.conns[c] = deadline
+	r.mtx.Unlock()
+
+	return func() {
+		r.mtx.Lock()
+		delete(r.conns, c)
+		r.mtx.Unlock()
+	}
+}
+
+// reap closes and forgets all connections past their deadline. It returns
+// the number of closed connections.
+func (r *handshakeReaper) reap() int {
+	var (
+		now     = r.now()
+		expired []net.Conn
+	)
+
+	r.mtx.Lock()
+	for c, deadline := range r.conns {
+		if now.After(deadline) {
+			expired = append(expired, c)
+			delete(r.conns, c)
+		}
+	}
+	r.mtx.Unlock()
+
+	for _, c := range expired {
+		_ = c.Close()
+	}
+
+	return len(expired)
+}
+
+// run reaps every interval until quit is closed.
+func (r *handshakeReaper) run(interval time.Duration, quit <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.reap()
+		case <-quit:
+			return
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package p2p
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandshakeReaper(t *testing.T) {
+	r := newHandshakeReaper()
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	stuck, stuckRemote := net.Pipe()
+	defer stuckRemote.Close()
+	done, doneRemote := net.Pipe()
+	defer doneRemote.Close()
+	pending, pendingRemote := net.Pipe()
+	defer pendingRemote.Close()
+
+	r.track(stuck, now.Add(time.Second))
+	r.track(done, now.Add(time.Second))()
+	r.track(pending, now.Add(time.Minute))
+
+	now = now.Add(2 * time.Second)
+	assert.Equal(t, 1, r.reap())
+
+	// Only the expired connection was closed.
+	_, err := stuck.Write([]byte{1})
+	assert.Error(t, err)
+	for _, c := range []net.Conn{done, pending} {
+		go func(c net.Conn) { _, _ = c.Write([]byte{1}) }(c)
+	}
+	buf := make([]byte, 1)
+	_, err = doneRemote.Read(buf)
+	assert.NoError(t, err)
+	_, err = pendingRemote.Read(buf)
+	assert.NoError(t, err)
+
+	// Reaped connections are forgotten.
+	assert.Equal(t, 0, r.reap())
+}
@@ -23,8 +23,27 @@ type Metrics struct {
 	PeerSendBytesTotal metrics.Counter
 	// Pending bytes to be sent to a given peer.
 	PeerPendingSendBytes metrics.Gauge
+	// Number of bytes sent to a given peer over the current connection,
+	// including packet overhead.
+	PeerConnectionSendBytes metrics.Gauge
+	// Number of bytes received from a given peer over the current
+	// connection, including packet overhead.
+	PeerConnectionReceiveBytes metrics.Gauge
+	// Number of messages queued to be sent to a given peer, by channel.
+	PeerSendQueueSize metrics.Gauge
+	// Number of queued messages dropped because the send queue of a given
+	// peer's channel was full.
+	PeerSendQueueDroppedTotal metrics.Counter
+	// Whether a given peer exceeded its bandwidth cap (1) or not (0).
+	PeerBandwidthCapped metrics.Gauge
 	// Number of transactions submitted by each peer.
 	NumTxs metrics.Gauge
+	// Number of peer behaviour reports dropped because the buffer was full.
+	PeerBehaviourReportsDropped metrics.Counter
+	// Number of message bytes on compressed channels, before compression.
+	CompressionRawBytesTotal metrics.Counter
+	// Number of message bytes on compressed channels, after compression.
+	CompressionCompressedBytesTotal metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -60,22 +79,78 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "peer_pending_send_bytes",
 			Help:      "Number of pending bytes to be sent to a given peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerConnectionSendBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_connection_send_bytes",
+			Help:      "Number of bytes sent to a given peer over the current connection.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerConnectionReceiveBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_connection_receive_bytes",
+			Help:      "Number of bytes received from a given peer over the current connection.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerSendQueueSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_send_queue_size",
+			Help:      "Number of messages queued to be sent to a given peer.",
+		}, append(labels, "peer_id", "ch_id")).With(labelsAndValues...),
+		PeerSendQueueDroppedTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_send_queue_dropped_total",
+			Help:      "Number of queued messages dropped because the send queue was full.",
+		}, append(labels, "peer_id", "ch_id")).With(labelsAndValues...),
+		PeerBandwidthCapped: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_bandwidth_capped",
+			Help:      "Whether a given peer exceeded its bandwidth cap.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
 		NumTxs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "num_txs",
 			Help:      "Number of transactions submitted by each peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		PeerBehaviourReportsDropped: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_behaviour_reports_dropped_total",
+			Help:      "Number of peer behaviour reports dropped because the buffer was full.",
+		}, labels).With(labelsAndValues...),
+		CompressionRawBytesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "compression_raw_bytes_total",
+			Help:      "Number of message bytes on compressed channels, before compression.",
+		}, append(labels, "ch_id", "direction")).With(labelsAndValues...),
+		CompressionCompressedBytesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "compression_compressed_bytes_total",
+			Help:      "Number of message bytes on compressed channels, after compression.",
+		}, append(labels, "ch_id", "direction")).With(labelsAndValues...),
 	}
 }
 
 // NopMetrics returns no-op Metrics.
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Peers:                 discard.NewGauge(),
-		PeerReceiveBytesTotal: discard.NewCounter(),
-		PeerSendBytesTotal:    discard.NewCounter(),
-		PeerPendingSendBytes:  discard.NewGauge(),
-		NumTxs:                discard.NewGauge(),
+		Peers:                           discard.NewGauge(),
+		PeerReceiveBytesTotal:           discard.NewCounter(),
+		PeerSendBytesTotal:              discard.NewCounter(),
+		PeerPendingSendBytes:            discard.NewGauge(),
+		PeerConnectionSendBytes:         discard.NewGauge(),
+		PeerConnectionReceiveBytes:      discard.NewGauge(),
+		PeerSendQueueSize:               discard.NewGauge(),
+		PeerSendQueueDroppedTotal:       discard.NewCounter(),
+		PeerBandwidthCapped:             discard.NewGauge(),
+		NumTxs:                          discard.NewGauge(),
+		PeerBehaviourReportsDropped:     discard.NewCounter(),
+		CompressionRawBytesTotal:        discard.NewCounter(),
+		CompressionCompressedBytesTotal: discard.NewCounter(),
 	}
 }
@@ -5,6 +5,7 @@
 package p2p
 
 import (
+	"encoding/base32"
 	"encoding/hex"
 	"flag"
 	"fmt"
@@ -39,24 +40,30 @@ func IDAddressString(id ID, protocolHostPort string) string {
 	return fmt.Sprintf("%s@%s", id, hostPort)
 }
 
-// NewNetAddress returns a new NetAddress using the provided TCP
-// address. When testing, other net.Addr (except TCP) will result in
-// using 0.0.0.0:0. When normal run, other net.Addr (except TCP) will
+// NewNetAddress returns a new NetAddress using the provided TCP or UDP
+// address. When testing, other net.Addr (except TCP and UDP) will result in
+// using 0.0.0.0:0. When normal run, other net.Addr (except TCP and UDP) will
 // panic.
 // TODO: socks proxies?
 func NewNetAddress(id ID, addr net.Addr) *NetAddress {
-	tcpAddr, ok := addr.(*net.TCPAddr)
-	if !ok {
+	var (
+		ip   net.IP
+		port uint16
+	)
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		ip, port = a.IP, uint16(a.Port)
+	case *net.UDPAddr: // QUIC
+		ip, port = a.IP, uint16(a.Port)
+	default:
 		if flag.Lookup("test.v") == nil { // normal run
-			cmn.PanicSanity(fmt.Sprintf("Only TCPAddrs are supported. Got: %v", addr))
+			cmn.PanicSanity(fmt.Sprintf("Only TCPAddrs and UDPAddrs are supported. Got: %v", addr))
 		} else { // in testing
 			netAddr := NewNetAddressIPPort(net.IP("0.0.0.0"), 0)
 			netAddr.ID = id
 			return netAddr
 		}
 	}
-	ip := tcpAddr.IP
-	port := uint16(tcpAddr.Port)
 	na := NewNetAddressIPPort(ip, port)
 	na.ID = id
 	return na
@@ -76,7 +83,8 @@ func NewNetAddressString(addr string) (*NetAddress, error) {
 
 // NewNetAddressStringWithOptionalID returns a new NetAddress using the
 // provided address in the form of "ID@IP:Port", where the ID is optional.
-// Also resolves the host if host is not an IP.
+// Also resolves the host if host is not an IP. Tor hidden service hosts
+// (.onion) are mapped to their OnionCat IP instead, see OnionCat.
 func NewNetAddressStringWithOptionalID(addr string) (*NetAddress, error) {
 	addrWithoutProtocol := removeProtocolIfDefined(addr)
 
@@ -108,6 +116,12 @@ func NewNetAddressStringWithOptionalID(addr string) (*NetAddress, error) {
 	}
 
 	ip := net.ParseIP(host)
+	if ip == nil && strings.HasSuffix(host, onionSuffix) {
+		ip, err = onionToIP(host)
+		if err != nil {
+			return nil, ErrNetAddressInvalid{addrWithoutProtocol, err}
+		}
+	}
 	if ip == nil {
 		ips, err := net.LookupIP(host)
 		if err != nil {
@@ -192,8 +206,12 @@ func (na *NetAddress) DialString() string {
 	if na == nil {
 		return "<nil-NetAddress>"
 	}
+	host := na.IP.String()
+	if na.OnionCat() {
+		host = ipToOnion(na.IP)
+	}
 	return net.JoinHostPort(
-		na.IP.String(),
+		host,
 		strconv.FormatUint(uint64(na.Port), 10),
 	)
 }
@@ -304,6 +322,7 @@ func (na *NetAddress) ReachabilityTo(o *NetAddress) int {
 // RFC4862: IPv6 Autoconfig (FE80::/64)
 // RFC6052: IPv6 well known prefix (64:FF9B::/96)
 // RFC6145: IPv6 IPv4 translated address ::FFFF:0:0:0/96
+// OnionCat: Tor hidden services (FD87:D87E:EB43::/48)
 var rfc1918_10 = net.IPNet{IP: net.ParseIP("10.0.0.0"), Mask: net.CIDRMask(8, 32)}
 var rfc1918_192 = net.IPNet{IP: net.ParseIP("192.168.0.0"), Mask: net.CIDRMask(16, 32)}
 var rfc1918_172 = net.IPNet{IP: net.ParseIP("172.16.0.0"), Mask: net.CIDRMask(12, 32)}
@@ -316,6 +335,7 @@ var rfc4843 = net.IPNet{IP: net.ParseIP("2001:10::"), Mask: net.CIDRMask(28, 128
 var rfc4862 = net.IPNet{IP: net.ParseIP("FE80::"), Mask: net.CIDRMask(64, 128)}
 var rfc6052 = net.IPNet{IP: net.ParseIP("64:FF9B::"), Mask: net.CIDRMask(96, 128)}
 var rfc6145 = net.IPNet{IP: net.ParseIP("::FFFF:0:0:0"), Mask: net.CIDRMask(96, 128)}
+var onionCat = net.IPNet{IP: net.ParseIP("FD87:D87E:EB43::"), Mask: net.CIDRMask(48, 128)}
 var zero4 = net.IPNet{IP: net.ParseIP("0.0.0.0"), Mask: net.CIDRMask(8, 32)}
 
 func (na *NetAddress) RFC1918() bool {
@@ -333,6 +353,34 @@ func (na *NetAddress) RFC4862() bool { return rfc4862.Contains(na.IP) }
 func (na *NetAddress) RFC6052() bool { return rfc6052.Contains(na.IP) }
 func (na *NetAddress) RFC6145() bool { return rfc6145.Contains(na.IP) }
 
+// OnionCat returns true if the address is the OnionCat IP of a Tor hidden
+// service. Such addresses can only be dialed through a Tor SOCKS5 proxy,
+// which is given the .onion host of DialString.
+func (na *NetAddress) OnionCat() bool { return onionCat.Contains(na.IP) }
+
+const (
+	onionSuffix       = ".onion"
+	onionCatPrefixLen = 6 // bytes
+)
+
+var onionEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// onionToIP maps the host of a (version 2) Tor hidden service, i.e. the
+// base32 encoding of 10 bytes, to an IP of the OnionCat range.
+func onionToIP(host string) (net.IP, error) {
+	name := strings.ToUpper(strings.TrimSuffix(host, onionSuffix))
+	b, err := onionEncoding.DecodeString(name)
+	if err != nil || len(b) != net.IPv6len-onionCatPrefixLen {
+		return nil, fmt.Errorf("invalid onion host %q (only version 2 hidden services are supported)", host)
+	}
+	return append(append(net.IP{}, onionCat.IP[:onionCatPrefixLen]...), b...), nil
+}
+
+// ipToOnion is the inverse of onionToIP.
+func ipToOnion(ip net.IP) string {
+	return strings.ToLower(onionEncoding.EncodeToString(ip.To16()[onionCatPrefixLen:])) + onionSuffix
+}
+
 func removeProtocolIfDefined(addr string) string {
 	if strings.Contains(addr, "://") {
 		return strings.Split(addr, "://")[1]
@@ -107,6 +107,24 @@ func TestNewNetAddressIPPort(t *testing.T) {
 	assert.Equal(t, "127.0.0.1:8080", addr.String())
 }
 
+func TestNetAddressOnion(t *testing.T) {
+	addr, err := NewNetAddressString("deadbeefdeadbeefdeadbeefdeadbeefdeadbeef@expyuzz4wqqyqhjn.onion:26656")
+	require.Nil(t, err)
+
+	assert.True(t, addr.OnionCat())
+	assert.Equal(t, "fd87:d87e:eb43:25df:8a67:3cb4:2188:1d2d", addr.IP.String())
+	assert.Equal(t, "expyuzz4wqqyqhjn.onion:26656", addr.DialString())
+	assert.Equal(t, "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef@expyuzz4wqqyqhjn.onion:26656", addr.String())
+	assert.True(t, addr.Valid())
+	assert.False(t, addr.Routable())
+
+	// Version 3 hidden services don't fit into an IP.
+	_, err = NewNetAddressStringWithOptionalID("vww6ybal4bd7szmgncyruucpgfkqahzddi37ktceo3ah7ngmcopnpyyd.onion:26656")
+	assert.Error(t, err)
+
+	assert.False(t, NewNetAddressIPPort(net.ParseIP("fd00::1"), 80).OnionCat())
+}
+
 func TestNetAddressProperties(t *testing.T) {
 	// TODO add more test cases
 	testCases := []struct {
@@ -9,8 +9,10 @@ import (
 )
 
 const (
-	maxNodeInfoSize = 10240 // 10KB
-	maxNumChannels  = 16    // plenty of room for upgrades, for now
+	maxNodeInfoSize         = 10240 // 10KB
+	maxNumChannels          = 16    // plenty of room for upgrades, for now
+	maxNumCompressionCodecs = 8
+	maxNumFeatures          = 32
 )
 
 // Max size of the NodeInfo struct
@@ -90,6 +92,16 @@ type DefaultNodeInfo struct {
 	// ASCIIText fields
 	Moniker string               `json:"moniker"` // arbitrary moniker
 	Other   DefaultNodeInfoOther `json:"other"`   // other application specific data
+
+	Compression NodeInfoCompression `json:"compression"` // message compression
+	Features    []string            `json:"features"`    // supported protocol features
+}
+
+// NodeInfoCompression advertises the compression codecs a node supports and
+// the channels it compresses messages on, see negotiateCompression.
+type NodeInfoCompression struct {
+	Codecs   []string     `json:"codecs"`   // codecs we can decompress, preferred first
+	Channels cmn.HexBytes `json:"channels"` // channels we compress messages on
 }
 
 // DefaultNodeInfoOther is the misc. applcation specific data
@@ -153,6 +165,37 @@ func (info DefaultNodeInfo) Validate() error {
 		return fmt.Errorf("info.Moniker must be valid non-empty ASCII text without tabs, but got %v", info.Moniker)
 	}
 
+	// Validate Compression.
+	if len(info.Compression.Codecs) > maxNumCompressionCodecs {
+		return fmt.Errorf("info.Compression.Codecs is too long (%v). Max is %v",
+			len(info.Compression.Codecs), maxNumCompressionCodecs)
+	}
+	for _, codec := range info.Compression.Codecs {
+		if !cmn.IsASCIIText(codec) || cmn.ASCIITrim(codec) == "" {
+			return fmt.Errorf("info.Compression.Codecs must be valid non-empty ASCII text without tabs, but got %v", codec)
+		}
+	}
+	for _, ch := range info.Compression.Channels {
+		if _, ok := channels[ch]; !ok {
+			return fmt.Errorf("info.Compression.Channels contains unknown channel id %v", ch)
+		}
+	}
+
+	// Validate Features - ensure max and check for duplicates.
+	if len(info.Features) > maxNumFeatures {
+		return fmt.Errorf("info.Features is too long (%v). Max is %v", len(info.Features), maxNumFeatures)
+	}
+	features := make(map[string]struct{})
+	for _, feature := range info.Features {
+		if !cmn.IsASCIIText(feature) || cmn.ASCIITrim(feature) == "" {
+			return fmt.Errorf("info.Features must be valid non-empty ASCII text without tabs, but got %v", feature)
+		}
+		if _, ok := features[feature]; ok {
+			return fmt.Errorf("info.Features contains duplicate feature %v", feature)
+		}
+		features[feature] = struct{}{}
+	}
+
 	// Validate Other.
 	other := info.Other
 	txIndex := other.TxIndex
@@ -60,6 +60,19 @@ func TestNodeInfoValidate(t *testing.T) {
 		{"Empty space RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = emptySpace }, true},
 		{"Empty RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = "" }, false},
 		{"Good RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = "0.0.0.0:26657" }, false},
+
+		{"Non-ASCII Compression Codec", func(ni *DefaultNodeInfo) { ni.Compression.Codecs = []string{nonAscii} }, true},
+		{"Too Many Compression Codecs", func(ni *DefaultNodeInfo) { ni.Compression.Codecs = make([]string, maxNumCompressionCodecs+1) }, true},
+		{"Unknown Compression Channel", func(ni *DefaultNodeInfo) { ni.Compression.Channels = []byte{byte(maxNumChannels)} }, true},
+		{"Good Compression", func(ni *DefaultNodeInfo) {
+			ni.Compression = NodeInfoCompression{Codecs: []string{"snappy", "future"}, Channels: channels[:2]}
+		}, false},
+
+		{"Non-ASCII Feature", func(ni *DefaultNodeInfo) { ni.Features = []string{nonAscii} }, true},
+		{"Empty Feature", func(ni *DefaultNodeInfo) { ni.Features = []string{emptySpace} }, true},
+		{"Duplicate Feature", func(ni *DefaultNodeInfo) { ni.Features = []string{"a", "a"} }, true},
+		{"Too Many Features", func(ni *DefaultNodeInfo) { ni.Features = make([]string, maxNumFeatures+1) }, true},
+		{"Good Features", func(ni *DefaultNodeInfo) { ni.Features = []string{FeatureCompression, "future"} }, false},
 	}
 
 	nodeKey := NodeKey{PrivKey: ed25519.GenPrivKey()}
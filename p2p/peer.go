@@ -29,10 +29,13 @@ type Peer interface {
 
 	NodeInfo() NodeInfo // peer's info
 	Status() tmconn.ConnectionStatus
+	Latency() time.Duration    // average ping round trip time, 0 if unknown
+	HasFeature(string) bool    // do both we and the peer support the protocol feature
 	OriginalAddr() *NetAddress // original address for outbound peers
 
 	Send(byte, []byte) bool
 	TrySend(byte, []byte) bool
+	SendQueueSize(byte) int // number of messages queued on the channel
 
 	Set(string, interface{})
 	Get(string) interface{}
@@ -114,6 +117,13 @@ type peer struct {
 
 	metrics       *Metrics
 	metricsTicker *time.Ticker
+
+	// called when a channel exceeds its receive rate limit
+	onRateLimitExceeded func(Peer, tmconn.ErrRateLimitExceeded)
+
+	// codecs negotiated with the peer
+	compression peerCompression
+	features    map[string]struct{} // protocol features supported by both sides
 }
 
 type PeerOption func(*peer)
@@ -237,6 +247,19 @@ func (p *peer) Status() tmconn.ConnectionStatus {
 	return p.mconn.Status()
 }
 
+// HasFeature returns true if both we and the peer advertised the protocol
+// feature in our NodeInfo.
+func (p *peer) HasFeature(feature string) bool {
+	_, ok := p.features[feature]
+	return ok
+}
+
+// Latency returns the average round trip time of pings to the peer, or 0 if
+// no ping has been answered yet.
+func (p *peer) Latency() time.Duration {
+	return p.mconn.Latency()
+}
+
 // Send msg bytes to the channel identified by chID byte. Returns false if the
 // send queue is full after timeout, specified by MConnection.
 func (p *peer) Send(chID byte, msgBytes []byte) bool {
@@ -247,7 +270,7 @@ func (p *peer) Send(chID byte, msgBytes []byte) bool {
 	} else if !p.hasChannel(chID) {
 		return false
 	}
-	res := p.mconn.Send(chID, msgBytes)
+	res := p.mconn.Send(chID, p.compress(chID, msgBytes))
 	if res {
 		p.metrics.PeerSendBytesTotal.With("peer_id", string(p.ID())).Add(float64(len(msgBytes)))
 	}
@@ -262,13 +285,45 @@ func (p *peer) TrySend(chID byte, msgBytes []byte) bool {
 	} else if !p.hasChannel(chID) {
 		return false
 	}
-	res := p.mconn.TrySend(chID, msgBytes)
+	res := p.mconn.TrySend(chID, p.compress(chID, msgBytes))
 	if res {
 		p.metrics.PeerSendBytesTotal.With("peer_id", string(p.ID())).Add(float64(len(msgBytes)))
 	}
 	return res
 }
 
+// compress compresses msgBytes if compression was negotiated for the channel.
+func (p *peer) compress(chID byte, msgBytes []byte) []byte {
+	codec, ok := p.compression.send[chID]
+	if !ok {
+		return msgBytes
+	}
+	compressed := compressMsg(codec, msgBytes)
+	p.compressionMetrics(chID, "send", len(msgBytes), len(compressed))
+	return compressed
+}
+
+// decompress decompresses msgBytes if the peer compresses messages on the
+// channel.
+func (p *peer) decompress(chID byte, msgBytes []byte, maxSize int) ([]byte, error) {
+	codec, ok := p.compression.recv[chID]
+	if !ok {
+		return msgBytes, nil
+	}
+	decompressed, err := decompressMsg(codec, msgBytes, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	p.compressionMetrics(chID, "receive", len(decompressed), len(msgBytes))
+	return decompressed, nil
+}
+
+func (p *peer) compressionMetrics(chID byte, direction string, raw, compressed int) {
+	labels := []string{"ch_id", fmt.Sprintf("%#x", chID), "direction", direction}
+	p.metrics.CompressionRawBytesTotal.With(labels...).Add(float64(raw))
+	p.metrics.CompressionCompressedBytesTotal.With(labels...).Add(float64(compressed))
+}
+
 // Get the data for a given key.
 func (p *peer) Get(key string) interface{} {
 	return p.Data.Get(key)
@@ -318,6 +373,12 @@ func (p *peer) RemoteAddr() net.Addr {
 	return p.peerConn.conn.RemoteAddr()
 }
 
+// SendQueueSize returns the number of messages queued to be sent to the peer
+// on the channel identified by chID.
+func (p *peer) SendQueueSize(chID byte) int {
+	return p.mconn.SendQueueSize(chID)
+}
+
 // CanSend returns true if the send queue is not full, false otherwise.
 func (p *peer) CanSend(chID byte) bool {
 	if !p.IsRunning() {
@@ -334,7 +395,34 @@ func PeerMetrics(metrics *Metrics) PeerOption {
 	}
 }
 
+// PeerCompression compresses messages on the channels negotiated between
+// ourInfo and the peer's NodeInfo.
+func PeerCompression(ourInfo NodeInfo) PeerOption {
+	return func(p *peer) {
+		p.compression = negotiateCompression(ourInfo, p.nodeInfo)
+	}
+}
+
+// PeerFeatures enables the protocol features advertised by both ourInfo and
+// the peer's NodeInfo.
+func PeerFeatures(ourInfo NodeInfo) PeerOption {
+	return func(p *peer) {
+		p.features = negotiateFeatures(ourInfo, p.nodeInfo)
+	}
+}
+
+// PeerOnRateLimitExceeded sets a callback which is called whenever a message
+// from the peer is dropped because it exceeded the receive rate limit of its
+// channel.
+func PeerOnRateLimitExceeded(cb func(Peer, tmconn.ErrRateLimitExceeded)) PeerOption {
+	return func(p *peer) {
+		p.onRateLimitExceeded = cb
+	}
+}
+
 func (p *peer) metricsReporter() {
+	// Dropped messages by channel as of the last report.
+	dropped := make(map[byte]int64)
 	for {
 		select {
 		case <-p.metricsTicker.C:
@@ -342,9 +430,23 @@ func (p *peer) metricsReporter() {
 			var sendQueueSize float64
 			for _, chStatus := range status.Channels {
 				sendQueueSize += float64(chStatus.SendQueueSize)
+
+				labels := []string{"peer_id", string(p.ID()), "ch_id", fmt.Sprintf("%#x", chStatus.ID)}
+				p.metrics.PeerSendQueueSize.With(labels...).Set(float64(chStatus.SendQueueSize))
+				if n := chStatus.SendQueueDropped - dropped[chStatus.ID]; n > 0 {
+					p.metrics.PeerSendQueueDroppedTotal.With(labels...).Add(float64(n))
+					dropped[chStatus.ID] = chStatus.SendQueueDropped
+				}
 			}
 
 			p.metrics.PeerPendingSendBytes.With("peer_id", string(p.ID())).Set(sendQueueSize)
+			p.metrics.PeerConnectionSendBytes.With("peer_id", string(p.ID())).Set(float64(status.BytesSent))
+			p.metrics.PeerConnectionReceiveBytes.With("peer_id", string(p.ID())).Set(float64(status.BytesReceived))
+			capped := 0.0
+			if status.Capped {
+				capped = 1
+			}
+			p.metrics.PeerBandwidthCapped.With("peer_id", string(p.ID())).Set(capped)
 		case <-p.Quit():
 			return
 		}
@@ -363,6 +465,11 @@ func createMConnection(
 	config tmconn.MConnConfig,
 ) *tmconn.MConnection {
 
+	maxMsgSizes := make(map[byte]int, len(chDescs))
+	for _, desc := range chDescs {
+		maxMsgSizes[desc.ID] = desc.FillDefaults().RecvMessageCapacity
+	}
+
 	onReceive := func(chID byte, msgBytes []byte) {
 		reactor := reactorsByCh[chID]
 		if reactor == nil {
@@ -370,6 +477,10 @@ func createMConnection(
 			// which does onPeerError.
 			panic(fmt.Sprintf("Unknown channel %X", chID))
 		}
+		msgBytes, err := p.decompress(chID, msgBytes, maxMsgSizes[chID])
+		if err != nil {
+			panic(fmt.Sprintf("Failed to decompress message on channel %X: %v", chID, err))
+		}
 		p.metrics.PeerReceiveBytesTotal.With("peer_id", string(p.ID())).Add(float64(len(msgBytes)))
 		reactor.Receive(chID, p, msgBytes)
 	}
@@ -378,11 +489,20 @@ func createMConnection(
 		onPeerError(p, r)
 	}
 
+	// NOTE: p.onRateLimitExceeded is set by the PeerOptions, which are applied
+	// after the MConnection was created.
+	onRateLimit := func(err tmconn.ErrRateLimitExceeded) {
+		if p.onRateLimitExceeded != nil {
+			p.onRateLimitExceeded(p, err)
+		}
+	}
+
 	return tmconn.NewMConnectionWithConfig(
 		conn,
 		chDescs,
 		onReceive,
 		onError,
 		config,
+		tmconn.OnRateLimitExceeded(onRateLimit),
 	)
 }
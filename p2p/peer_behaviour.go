@@ -0,0 +1,198 @@
+package p2p
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ErrorPeerBehaviour are types of reportable behaviours which indicate a peer
+// misbehaved.
+type ErrorPeerBehaviour int
+
+const (
+	ErrorPeerBehaviourUnknown ErrorPeerBehaviour = iota
+	ErrorPeerBehaviourBadMessage
+	ErrorPeerBehaviourMessageOutOfOrder
+	ErrorPeerBehaviourRateLimitExceeded
+	ErrorPeerBehaviourBlockTimeout
+	ErrorPeerBehaviourBadBlock
+	ErrorPeerBehaviourBadSnapshotChunk
+	ErrorPeerBehaviourBadEvidence
+	ErrorPeerBehaviourExpiredEvidence
+)
+
+func (epb ErrorPeerBehaviour) String() string {
+	switch epb {
+	case ErrorPeerBehaviourUnknown:
+		return "Unknown"
+	case ErrorPeerBehaviourBadMessage:
+		return "BadMessage"
+	case ErrorPeerBehaviourMessageOutOfOrder:
+		return "MessageOutOfOrder"
+	case ErrorPeerBehaviourRateLimitExceeded:
+		return "RateLimitExceeded"
+	case ErrorPeerBehaviourBlockTimeout:
+		return "BlockTimeout"
+	case ErrorPeerBehaviourBadBlock:
+		return "BadBlock"
+	case ErrorPeerBehaviourBadSnapshotChunk:
+		return "BadSnapshotChunk"
+	case ErrorPeerBehaviourBadEvidence:
+		return "BadEvidence"
+	case ErrorPeerBehaviourExpiredEvidence:
+		return "ExpiredEvidence"
+	default:
+		return fmt.Sprintf("ErrorPeerBehaviour(%d)", int(epb))
+	}
+}
+
+// GoodPeerBehaviour are types of reportable behaviours which indicate a peer
+// did something useful.
+type GoodPeerBehaviour int
+
+const (
+	GoodPeerBehaviourVote GoodPeerBehaviour = iota + 100
+	GoodPeerBehaviourBlockPart
+	GoodPeerBehaviourBlockResponse
+	GoodPeerBehaviourSnapshotChunk
+)
+
+func (gpb GoodPeerBehaviour) String() string {
+	switch gpb {
+	case GoodPeerBehaviourVote:
+		return "Vote"
+	case GoodPeerBehaviourBlockPart:
+		return "BlockPart"
+	case GoodPeerBehaviourBlockResponse:
+		return "BlockResponse"
+	case GoodPeerBehaviourSnapshotChunk:
+		return "SnapshotChunk"
+	default:
+		return fmt.Sprintf("GoodPeerBehaviour(%d)", int(gpb))
+	}
+}
+
+// ErrorBehaviourReport describes a misbehaviour of a peer. Besides the
+// numeric Reason it carries enough context for consumers to tell e.g. a bad
+// vote signature at height H apart from a malformed mempool tx.
+type ErrorBehaviourReport struct {
+	Reason  ErrorPeerBehaviour
+	Reactor string // name of the reporting reactor
+	Height  int64  // height the behaviour relates to, 0 if not applicable
+	Detail  string
+}
+
+func (r ErrorBehaviourReport) String() string {
+	return behaviourReportString(r.Reason.String(), r.Reactor, r.Height, r.Detail)
+}
+
+// GoodBehaviourReport describes a useful contribution of a peer.
+type GoodBehaviourReport struct {
+	Reason  GoodPeerBehaviour
+	Reactor string // name of the reporting reactor
+	Height  int64  // height the behaviour relates to, 0 if not applicable
+	Detail  string
+}
+
+func (r GoodBehaviourReport) String() string {
+	return behaviourReportString(r.Reason.String(), r.Reactor, r.Height, r.Detail)
+}
+
+func behaviourReportString(reason, reactor string, height int64, detail string) string {
+	s := reason
+	if reactor != "" {
+		s += fmt.Sprintf(" reactor=%s", reactor)
+	}
+	if height != 0 {
+		s += fmt.Sprintf(" height=%d", height)
+	}
+	if detail != "" {
+		s += ": " + detail
+	}
+	return s
+}
+
+// PeerBehaviour provides an interface for reactors to signal the behaviour
+// of peers synchronously to other components.
+type PeerBehaviour interface {
+	Behaved(peer Peer, report GoodBehaviourReport)
+	Errored(peer Peer, report ErrorBehaviourReport)
+}
+
+type switchPeerBehaviour struct {
+	sw *Switch
+}
+
+// Errored stops the peer for the reported reason.
+func (spb *switchPeerBehaviour) Errored(peer Peer, report ErrorBehaviourReport) {
+	spb.sw.StopPeerForError(peer, report)
+}
+
+// Behaved marks the peer as good in the address book.
+func (spb *switchPeerBehaviour) Behaved(peer Peer, report GoodBehaviourReport) {
+	spb.sw.MarkPeerAsGood(peer)
+}
+
+// NewSwitchPeerBehaviour returns a PeerBehaviour which reports directly to
+// the given Switch.
+func NewSwitchPeerBehaviour(sw *Switch) PeerBehaviour {
+	return &switchPeerBehaviour{
+		sw: sw,
+	}
+}
+
+// GettablePeerBehaviour is a PeerBehaviour which records the reported
+// behaviours so they can be inspected afterwards.
+type GettablePeerBehaviour interface {
+	PeerBehaviour
+	GetErrored(peerID ID) []ErrorBehaviourReport
+	GetBehaved(peerID ID) []GoodBehaviourReport
+}
+
+// storePeerBehaviour serves a mock concrete implementation of the
+// PeerBehaviour interface used in reactor tests to ensure reactors
+// produce the correct signals in manufactured scenarios.
+type storePeerBehaviour struct {
+	mtx sync.RWMutex
+	eb  map[ID][]ErrorBehaviourReport
+	gb  map[ID][]GoodBehaviourReport
+}
+
+// NewStorePeerBehaviour returns a GettablePeerBehaviour which keeps all
+// reported behaviours in memory.
+func NewStorePeerBehaviour() GettablePeerBehaviour {
+	return &storePeerBehaviour{
+		eb: make(map[ID][]ErrorBehaviourReport),
+		gb: make(map[ID][]GoodBehaviourReport),
+	}
+}
+
+func (spb *storePeerBehaviour) Errored(peer Peer, report ErrorBehaviourReport) {
+	spb.mtx.Lock()
+	defer spb.mtx.Unlock()
+	spb.eb[peer.ID()] = append(spb.eb[peer.ID()], report)
+}
+
+func (spb *storePeerBehaviour) Behaved(peer Peer, report GoodBehaviourReport) {
+	spb.mtx.Lock()
+	defer spb.mtx.Unlock()
+	spb.gb[peer.ID()] = append(spb.gb[peer.ID()], report)
+}
+
+// GetErrored returns a copy of the error behaviours reported for peerID.
+func (spb *storePeerBehaviour) GetErrored(peerID ID) []ErrorBehaviourReport {
+	spb.mtx.RLock()
+	defer spb.mtx.RUnlock()
+	reports := make([]ErrorBehaviourReport, len(spb.eb[peerID]))
+	copy(reports, spb.eb[peerID])
+	return reports
+}
+
+// GetBehaved returns a copy of the good behaviours reported for peerID.
+func (spb *storePeerBehaviour) GetBehaved(peerID ID) []GoodBehaviourReport {
+	spb.mtx.RLock()
+	defer spb.mtx.RUnlock()
+	reports := make([]GoodBehaviourReport, len(spb.gb[peerID]))
+	copy(reports, spb.gb[peerID])
+	return reports
+}
@@ -0,0 +1,104 @@
+package p2p
+
+import (
+	cmn "github.com/tendermint/tendermint/libs/common"
+)
+
+const (
+	// DefaultPeerBehaviourBufferSize is the number of reports a
+	// BufferedPeerBehaviour holds before it starts dropping them.
+	DefaultPeerBehaviourBufferSize = 1024
+)
+
+// queuedBehaviour holds exactly one of good or err.
+type queuedBehaviour struct {
+	peer Peer
+	good *GoodBehaviourReport
+	err  *ErrorBehaviourReport
+}
+
+// BufferedPeerBehaviour is a PeerBehaviour decorator which queues reports on
+// an internal buffer and hands them to the wrapped PeerBehaviour from its own
+// goroutine. Reactors on hot paths never block on it: if the buffer is full,
+// the report is dropped and counted in the PeerBehaviourReportsDropped
+// metric.
+type BufferedPeerBehaviour struct {
+	cmn.BaseService
+
+	pb      PeerBehaviour
+	queue   chan queuedBehaviour
+	metrics *Metrics
+}
+
+var _ PeerBehaviour = (*BufferedPeerBehaviour)(nil)
+
+// BufferedPeerBehaviourOption sets an optional parameter on the
+// BufferedPeerBehaviour.
+type BufferedPeerBehaviourOption func(*BufferedPeerBehaviour)
+
+// BufferedPeerBehaviourMetrics sets the metrics.
+func BufferedPeerBehaviourMetrics(metrics *Metrics) BufferedPeerBehaviourOption {
+	return func(bpb *BufferedPeerBehaviour) { bpb.metrics = metrics }
+}
+
+// NewBufferedPeerBehaviour returns a BufferedPeerBehaviour which forwards to
+// pb and buffers up to bufferSize reports. It has to be started before
+// reports are forwarded.
+func NewBufferedPeerBehaviour(
+	pb PeerBehaviour,
+	bufferSize int,
+	options ...BufferedPeerBehaviourOption,
+) *BufferedPeerBehaviour {
+	bpb := &BufferedPeerBehaviour{
+		pb:      pb,
+		queue:   make(chan queuedBehaviour, bufferSize),
+		metrics: NopMetrics(),
+	}
+	bpb.BaseService = *cmn.NewBaseService(nil, "BufferedPeerBehaviour", bpb)
+
+	for _, option := range options {
+		option(bpb)
+	}
+
+	return bpb
+}
+
+// OnStart implements cmn.Service by starting the flush routine.
+func (bpb *BufferedPeerBehaviour) OnStart() error {
+	go bpb.flushRoutine()
+	return nil
+}
+
+// Behaved queues the report without blocking.
+func (bpb *BufferedPeerBehaviour) Behaved(peer Peer, report GoodBehaviourReport) {
+	bpb.enqueue(queuedBehaviour{peer: peer, good: &report})
+}
+
+// Errored queues the report without blocking.
+func (bpb *BufferedPeerBehaviour) Errored(peer Peer, report ErrorBehaviourReport) {
+	bpb.enqueue(queuedBehaviour{peer: peer, err: &report})
+}
+
+func (bpb *BufferedPeerBehaviour) enqueue(qb queuedBehaviour) {
+	select {
+	case bpb.queue <- qb:
+	default:
+		bpb.metrics.PeerBehaviourReportsDropped.Add(1)
+		bpb.Logger.Debug("Dropping peer behaviour report, buffer is full", "peer", qb.peer)
+	}
+}
+
+func (bpb *BufferedPeerBehaviour) flushRoutine() {
+	for {
+		select {
+		case qb := <-bpb.queue:
+			if qb.err != nil {
+				bpb.pb.Errored(qb.peer, *qb.err)
+			} else {
+				bpb.pb.Behaved(qb.peer, *qb.good)
+			}
+		case <-bpb.Quit():
+			return
+		}
+	}
+}
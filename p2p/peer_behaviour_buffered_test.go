@@ -0,0 +1,121 @@
+package p2p
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingPeerBehaviour blocks every report until unblock is closed.
+type blockingPeerBehaviour struct {
+	GettablePeerBehaviour
+	unblock chan struct{}
+}
+
+func (b *blockingPeerBehaviour) Errored(peer Peer, report ErrorBehaviourReport) {
+	<-b.unblock
+	b.GettablePeerBehaviour.Errored(peer, report)
+}
+
+func (b *blockingPeerBehaviour) Behaved(peer Peer, report GoodBehaviourReport) {
+	<-b.unblock
+	b.GettablePeerBehaviour.Behaved(peer, report)
+}
+
+type testCounter struct {
+	mtx   sync.Mutex
+	value float64
+}
+
+func (c *testCounter) With(labelValues ...string) metrics.Counter { return c }
+func (c *testCounter) Add(delta float64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.value += delta
+}
+func (c *testCounter) Value() float64 {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.value
+}
+
+func waitForReports(t *testing.T, pb GettablePeerBehaviour, peerID ID, errored, behaved int) {
+	deadline := time.After(time.Second)
+	for {
+		if len(pb.GetErrored(peerID)) == errored && len(pb.GetBehaved(peerID)) == behaved {
+			return
+		}
+		select {
+		case <-deadline:
+			t.Fatalf(
+				"expected %d errored and %d behaved reports, got %d and %d",
+				errored, behaved, len(pb.GetErrored(peerID)), len(pb.GetBehaved(peerID)),
+			)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestBufferedPeerBehaviourForwards(t *testing.T) {
+	store := NewStorePeerBehaviour()
+	bpb := NewBufferedPeerBehaviour(store, DefaultPeerBehaviourBufferSize)
+	require.NoError(t, bpb.Start())
+	defer bpb.Stop()
+
+	peer := newMockPeer(nil)
+	bad := ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage, Reactor: "MEMPOOL"}
+	good := GoodBehaviourReport{Reason: GoodPeerBehaviourVote, Height: 2}
+	bpb.Errored(peer, bad)
+	bpb.Behaved(peer, good)
+
+	waitForReports(t, store, peer.ID(), 1, 1)
+	assert.Equal(t, []ErrorBehaviourReport{bad}, store.GetErrored(peer.ID()))
+	assert.Equal(t, []GoodBehaviourReport{good}, store.GetBehaved(peer.ID()))
+}
+
+func TestBufferedPeerBehaviourDropsWhenFull(t *testing.T) {
+	blocking := &blockingPeerBehaviour{
+		GettablePeerBehaviour: NewStorePeerBehaviour(),
+		unblock:               make(chan struct{}),
+	}
+	dropped := &testCounter{}
+	metrics := NopMetrics()
+	metrics.PeerBehaviourReportsDropped = dropped
+
+	bufferSize := 2
+	bpb := NewBufferedPeerBehaviour(blocking, bufferSize, BufferedPeerBehaviourMetrics(metrics))
+	require.NoError(t, bpb.Start())
+	defer bpb.Stop()
+
+	peer := newMockPeer(nil)
+	report := ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage}
+
+	// The first report is picked up by the flush routine, which then blocks.
+	bpb.Errored(peer, report)
+	deadline := time.Now().Add(time.Second)
+	for len(bpb.queue) != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Fill the buffer, then overflow it. None of these calls may block.
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < bufferSize+3; i++ {
+			bpb.Errored(peer, report)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("reporting blocked")
+	}
+	assert.Equal(t, float64(3), dropped.Value())
+
+	close(blocking.unblock)
+	waitForReports(t, blocking, peer.ID(), 1+bufferSize, 0)
+}
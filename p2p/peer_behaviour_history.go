@@ -0,0 +1,191 @@
+package p2p
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPeerBehaviourHistoryRecent is the number of recent reports
+	// retained per peer.
+	DefaultPeerBehaviourHistoryRecent = 20
+	// DefaultPeerBehaviourHistoryPeers is the number of peers a
+	// PeerBehaviourHistory keeps track of.
+	DefaultPeerBehaviourHistoryPeers = 1000
+)
+
+// PeerBehaviourEvent is a report received at a certain time. Exactly one of
+// Error and Good is set.
+type PeerBehaviourEvent struct {
+	Time  time.Time
+	Error *ErrorBehaviourReport
+	Good  *GoodBehaviourReport
+}
+
+// PeerBehaviourSummary holds the accumulated counts of all reports about a
+// peer and the most recent reports, oldest first.
+type PeerBehaviourSummary struct {
+	ErrorCounts map[ErrorPeerBehaviour]int
+	GoodCounts  map[GoodPeerBehaviour]int
+	Recent      []PeerBehaviourEvent
+}
+
+type peerHistory struct {
+	errorCounts map[ErrorPeerBehaviour]int
+	goodCounts  map[GoodPeerBehaviour]int
+	recent      []PeerBehaviourEvent
+	updated     time.Time
+}
+
+// PeerBehaviourHistory is a GettablePeerBehaviour which forwards reports to
+// the wrapped PeerBehaviour, while keeping per peer counts of all reported
+// reasons as well as the most recent reports. History is kept after a peer
+// disconnects, so operators can find out why a peer was dropped. Once more
+// than maxPeers are tracked, the least recently reported peer is forgotten.
+type PeerBehaviourHistory struct {
+	mtx       sync.RWMutex
+	pb        PeerBehaviour
+	maxRecent int
+	maxPeers  int
+	peers     map[ID]*peerHistory
+
+	now func() time.Time // overridden in tests
+}
+
+var _ GettablePeerBehaviour = (*PeerBehaviourHistory)(nil)
+
+// NewPeerBehaviourHistory returns a PeerBehaviourHistory which forwards to pb,
+// retains maxRecent reports per peer and tracks up to maxPeers peers.
+func NewPeerBehaviourHistory(pb PeerBehaviour, maxRecent, maxPeers int) *PeerBehaviourHistory {
+	return &PeerBehaviourHistory{
+		pb:        pb,
+		maxRecent: maxRecent,
+		maxPeers:  maxPeers,
+		peers:     make(map[ID]*peerHistory),
+		now:       time.Now,
+	}
+}
+
+// Errored records the report and forwards it.
+func (pbh *PeerBehaviourHistory) Errored(peer Peer, report ErrorBehaviourReport) {
+	pbh.mtx.Lock()
+	ph := pbh.peerHistory(peer.ID())
+	ph.errorCounts[report.Reason]++
+	pbh.record(ph, PeerBehaviourEvent{Time: ph.updated, Error: &report})
+	pbh.mtx.Unlock()
+
+	pbh.pb.Errored(peer, report)
+}
+
+// Behaved records the report and forwards it.
+func (pbh *PeerBehaviourHistory) Behaved(peer Peer, report GoodBehaviourReport) {
+	pbh.mtx.Lock()
+	ph := pbh.peerHistory(peer.ID())
+	ph.goodCounts[report.Reason]++
+	pbh.record(ph, PeerBehaviourEvent{Time: ph.updated, Good: &report})
+	pbh.mtx.Unlock()
+
+	pbh.pb.Behaved(peer, report)
+}
+
+// GetErrored returns the recent error reports about the peer.
+func (pbh *PeerBehaviourHistory) GetErrored(peerID ID) []ErrorBehaviourReport {
+	pbh.mtx.RLock()
+	defer pbh.mtx.RUnlock()
+	reports := []ErrorBehaviourReport{}
+	if ph, ok := pbh.peers[peerID]; ok {
+		for _, e := range ph.recent {
+			if e.Error != nil {
+				reports = append(reports, *e.Error)
+			}
+		}
+	}
+	return reports
+}
+
+// GetBehaved returns the recent good reports about the peer.
+func (pbh *PeerBehaviourHistory) GetBehaved(peerID ID) []GoodBehaviourReport {
+	pbh.mtx.RLock()
+	defer pbh.mtx.RUnlock()
+	reports := []GoodBehaviourReport{}
+	if ph, ok := pbh.peers[peerID]; ok {
+		for _, e := range ph.recent {
+			if e.Good != nil {
+				reports = append(reports, *e.Good)
+			}
+		}
+	}
+	return reports
+}
+
+// Summary returns the accumulated counts and the recent reports about the
+// peer. ok is false if nothing was reported about the peer.
+func (pbh *PeerBehaviourHistory) Summary(peerID ID) (summary PeerBehaviourSummary, ok bool) {
+	pbh.mtx.RLock()
+	defer pbh.mtx.RUnlock()
+	ph, ok := pbh.peers[peerID]
+	if !ok {
+		return PeerBehaviourSummary{}, false
+	}
+
+	summary = PeerBehaviourSummary{
+		ErrorCounts: make(map[ErrorPeerBehaviour]int, len(ph.errorCounts)),
+		GoodCounts:  make(map[GoodPeerBehaviour]int, len(ph.goodCounts)),
+		Recent:      make([]PeerBehaviourEvent, len(ph.recent)),
+	}
+	for reason, count := range ph.errorCounts {
+		summary.ErrorCounts[reason] = count
+	}
+	for reason, count := range ph.goodCounts {
+		summary.GoodCounts[reason] = count
+	}
+	copy(summary.Recent, ph.recent)
+	return summary, true
+}
+
+// peerHistory returns the history of the peer, creating it if necessary.
+// CONTRACT: pbh.mtx must be held.
+func (pbh *PeerBehaviourHistory) peerHistory(peerID ID) *peerHistory {
+	now := pbh.now()
+	ph, ok := pbh.peers[peerID]
+	if !ok {
+		if len(pbh.peers) >= pbh.maxPeers {
+			pbh.evictOldest()
+		}
+		ph = &peerHistory{
+			errorCounts: make(map[ErrorPeerBehaviour]int),
+			goodCounts:  make(map[GoodPeerBehaviour]int),
+		}
+		pbh.peers[peerID] = ph
+	}
+	ph.updated = now
+	return ph
+}
+
+// record appends e to the recent events of ph, dropping the oldest event if
+// there are more than maxRecent.
+// CONTRACT: pbh.mtx must be held.
+func (pbh *PeerBehaviourHistory) record(ph *peerHistory, e PeerBehaviourEvent) {
+	if pbh.maxRecent <= 0 {
+		return
+	}
+	if len(ph.recent) >= pbh.maxRecent {
+		copy(ph.recent, ph.recent[1:])
+		ph.recent = ph.recent[:len(ph.recent)-1]
+	}
+	ph.recent = append(ph.recent, e)
+}
+
+// CONTRACT: pbh.mtx must be held.
+func (pbh *PeerBehaviourHistory) evictOldest() {
+	var (
+		oldestID ID
+		oldest   time.Time
+	)
+	for id, ph := range pbh.peers {
+		if oldestID == "" || ph.updated.Before(oldest) {
+			oldestID, oldest = id, ph.updated
+		}
+	}
+	delete(pbh.peers, oldestID)
+}
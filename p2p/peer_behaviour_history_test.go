@@ -0,0 +1,63 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerBehaviourHistory(t *testing.T) {
+	store := NewStorePeerBehaviour()
+	pbh := NewPeerBehaviourHistory(store, 3, DefaultPeerBehaviourHistoryPeers)
+	peer := newMockPeer(nil)
+
+	_, ok := pbh.Summary(peer.ID())
+	assert.False(t, ok)
+
+	bad := ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage, Reactor: "MEMPOOL"}
+	good := GoodBehaviourReport{Reason: GoodPeerBehaviourVote, Reactor: "CONSENSUS"}
+	pbh.Errored(peer, bad)
+	for i := 0; i < 4; i++ {
+		pbh.Behaved(peer, good)
+	}
+
+	// All reports are forwarded.
+	assert.Len(t, store.GetErrored(peer.ID()), 1)
+	assert.Len(t, store.GetBehaved(peer.ID()), 4)
+
+	summary, ok := pbh.Summary(peer.ID())
+	require.True(t, ok)
+	assert.Equal(t, map[ErrorPeerBehaviour]int{ErrorPeerBehaviourBadMessage: 1}, summary.ErrorCounts)
+	assert.Equal(t, map[GoodPeerBehaviour]int{GoodPeerBehaviourVote: 4}, summary.GoodCounts)
+
+	// Only the 3 most recent reports are retained, which pushed out the error.
+	require.Len(t, summary.Recent, 3)
+	for _, e := range summary.Recent {
+		assert.Nil(t, e.Error)
+		assert.Equal(t, good, *e.Good)
+	}
+	assert.Empty(t, pbh.GetErrored(peer.ID()))
+	assert.Len(t, pbh.GetBehaved(peer.ID()), 3)
+}
+
+func TestPeerBehaviourHistoryEvictsOldestPeer(t *testing.T) {
+	pbh := NewPeerBehaviourHistory(NewStorePeerBehaviour(), 1, 2)
+	now := time.Now()
+	pbh.now = func() time.Time { return now }
+
+	peers := []*mockPeer{newMockPeer(nil), newMockPeer(nil), newMockPeer(nil)}
+	report := GoodBehaviourReport{Reason: GoodPeerBehaviourBlockPart}
+	for _, peer := range peers {
+		now = now.Add(time.Second)
+		pbh.Behaved(peer, report)
+	}
+
+	_, ok := pbh.Summary(peers[0].ID())
+	assert.False(t, ok, "oldest peer should have been evicted")
+	for _, peer := range peers[1:] {
+		_, ok := pbh.Summary(peer.ID())
+		assert.True(t, ok)
+	}
+}
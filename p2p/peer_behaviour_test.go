@@ -0,0 +1,57 @@
+package p2p
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBehaviourReportString(t *testing.T) {
+	testCases := []struct {
+		report fmt.Stringer
+		want   string
+	}{
+		{ErrorBehaviourReport{}, "Unknown"},
+		{
+			ErrorBehaviourReport{
+				Reason:  ErrorPeerBehaviourBadMessage,
+				Reactor: "CONSENSUS",
+				Height:  7,
+				Detail:  "bad vote signature",
+			},
+			"BadMessage reactor=CONSENSUS height=7: bad vote signature",
+		},
+		{
+			ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage, Reactor: "MEMPOOL", Detail: "malformed tx"},
+			"BadMessage reactor=MEMPOOL: malformed tx",
+		},
+		{GoodBehaviourReport{Reason: GoodPeerBehaviourBlockPart, Height: 3}, "BlockPart height=3"},
+		{ErrorBehaviourReport{Reason: ErrorPeerBehaviour(42)}, "ErrorPeerBehaviour(42)"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, tc.report.String())
+	}
+}
+
+func TestStorePeerBehaviour(t *testing.T) {
+	pb := NewStorePeerBehaviour()
+	peer := newMockPeer(nil)
+
+	assert.Empty(t, pb.GetErrored(peer.ID()))
+	assert.Empty(t, pb.GetBehaved(peer.ID()))
+
+	bad := ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage, Reactor: "MEMPOOL", Detail: "malformed tx"}
+	good := GoodBehaviourReport{Reason: GoodPeerBehaviourVote, Reactor: "CONSENSUS", Height: 1}
+	pb.Errored(peer, bad)
+	pb.Behaved(peer, good)
+	pb.Behaved(peer, good)
+
+	assert.Equal(t, []ErrorBehaviourReport{bad}, pb.GetErrored(peer.ID()))
+	assert.Equal(t, []GoodBehaviourReport{good, good}, pb.GetBehaved(peer.ID()))
+
+	// Returned slices are copies.
+	pb.GetErrored(peer.ID())[0].Detail = "changed"
+	assert.Equal(t, "malformed tx", pb.GetErrored(peer.ID())[0].Detail)
+}
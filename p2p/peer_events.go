@@ -0,0 +1,97 @@
+package p2p
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/libs/events"
+)
+
+// Peer lifecycle events fired by the Switch. Subscribe to them with
+// Switch.SubscribePeerEvent.
+const (
+	// EventPeerConnected is fired with EventDataPeerConnected once a peer was
+	// added and started.
+	EventPeerConnected = "PeerConnected"
+	// EventPeerDisconnected is fired with EventDataPeerDisconnected once a
+	// peer was stopped and removed.
+	EventPeerDisconnected = "PeerDisconnected"
+	// EventPeerErrored is fired with EventDataPeerErrored for every
+	// misbehaviour reported to the Switch's PeerBehaviour, whether or not the
+	// peer is disconnected for it.
+	EventPeerErrored = "PeerErrored"
+)
+
+// EventDataPeerConnected is the data of EventPeerConnected.
+type EventDataPeerConnected struct {
+	Peer Peer
+}
+
+// EventDataPeerDisconnected is the data of EventPeerDisconnected.
+type EventDataPeerDisconnected struct {
+	Peer   Peer
+	Reason interface{} // nil if the peer was stopped gracefully
+}
+
+// EventDataPeerErrored is the data of EventPeerErrored.
+type EventDataPeerErrored struct {
+	Peer   Peer
+	Report ErrorBehaviourReport
+}
+
+func isPeerEvent(event string) bool {
+	switch event {
+	case EventPeerConnected, EventPeerDisconnected, EventPeerErrored:
+		return true
+	default:
+		return false
+	}
+}
+
+// SubscribePeerEvent calls cb with the event's data every time the peer
+// lifecycle event occurs. Callbacks are called synchronously by the Switch, so
+// they must not block or call back into the Switch to add or remove peers.
+func (sw *Switch) SubscribePeerEvent(subscriber, event string, cb events.EventCallback) error {
+	if !isPeerEvent(event) {
+		return fmt.Errorf("unknown peer event %q", event)
+	}
+	return sw.peerEvents.AddListenerForEvent(subscriber, event, cb)
+}
+
+// UnsubscribePeerEvents removes all callbacks of the subscriber.
+func (sw *Switch) UnsubscribePeerEvents(subscriber string) {
+	sw.peerEvents.RemoveListener(subscriber)
+}
+
+// subscribeReactor makes the reactor's AddPeer and RemovePeer handle the peer
+// lifecycle events.
+func (sw *Switch) subscribeReactor(name string, reactor Reactor) {
+	subscriber := "reactor/" + name
+	err := sw.peerEvents.AddListenerForEvent(subscriber, EventPeerConnected, func(data events.EventData) {
+		reactor.AddPeer(data.(EventDataPeerConnected).Peer)
+	})
+	if err == nil {
+		err = sw.peerEvents.AddListenerForEvent(subscriber, EventPeerDisconnected, func(data events.EventData) {
+			ev := data.(EventDataPeerDisconnected)
+			reactor.RemovePeer(ev.Peer, ev.Reason)
+		})
+	}
+	if err != nil {
+		// Should never happen, reactors are only ever added once.
+		panic(fmt.Sprintf("failed to subscribe reactor %v: %v", name, err))
+	}
+}
+
+// eventPeerBehaviour fires EventPeerErrored for every reported misbehaviour
+// before passing the report on to the Switch's PeerBehaviour.
+type eventPeerBehaviour struct {
+	sw *Switch
+}
+
+func (epb *eventPeerBehaviour) Errored(peer Peer, report ErrorBehaviourReport) {
+	epb.sw.peerEvents.FireEvent(EventPeerErrored, EventDataPeerErrored{Peer: peer, Report: report})
+	epb.sw.peerBehaviour.Errored(peer, report)
+}
+
+func (epb *eventPeerBehaviour) Behaved(peer Peer, report GoodBehaviourReport) {
+	epb.sw.peerBehaviour.Behaved(peer, report)
+}
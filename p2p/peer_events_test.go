@@ -0,0 +1,107 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/events"
+)
+
+func TestSwitchPeerEvents(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+	store := NewStorePeerBehaviour()
+	sw.SetPeerBehaviour(store)
+	require.NoError(t, sw.Start())
+	defer sw.Stop()
+
+	connected := make(chan EventDataPeerConnected, 1)
+	disconnected := make(chan EventDataPeerDisconnected, 1)
+	errored := make(chan EventDataPeerErrored, 1)
+	require.NoError(t, sw.SubscribePeerEvent("test", EventPeerConnected, func(data events.EventData) {
+		connected <- data.(EventDataPeerConnected)
+	}))
+	require.NoError(t, sw.SubscribePeerEvent("test", EventPeerDisconnected, func(data events.EventData) {
+		disconnected <- data.(EventDataPeerDisconnected)
+	}))
+	require.NoError(t, sw.SubscribePeerEvent("test", EventPeerErrored, func(data events.EventData) {
+		errored <- data.(EventDataPeerErrored)
+	}))
+	assert.Error(t, sw.SubscribePeerEvent("test", "unknown", func(events.EventData) {}))
+
+	// simulate remote peer
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	defer rp.Stop()
+
+	p, err := sw.transport.Dial(*rp.Addr(), peerConfig{
+		chDescs:      sw.chDescs,
+		onPeerError:  sw.StopPeerForError,
+		reactorsByCh: sw.reactorsByCh,
+	})
+	require.NoError(t, err)
+	require.NoError(t, sw.addPeer(p))
+
+	select {
+	case ev := <-connected:
+		assert.Equal(t, rp.ID(), ev.Peer.ID())
+	case <-time.After(time.Second):
+		t.Fatal("expected PeerConnected event")
+	}
+
+	// Reported misbehaviour is passed on to the PeerBehaviour as well.
+	report := ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage, Reactor: "foo"}
+	sw.PeerBehaviour().Errored(p, report)
+	select {
+	case ev := <-errored:
+		assert.Equal(t, rp.ID(), ev.Peer.ID())
+		assert.Equal(t, report, ev.Report)
+	case <-time.After(time.Second):
+		t.Fatal("expected PeerErrored event")
+	}
+	assert.Equal(t, []ErrorBehaviourReport{report}, store.GetErrored(rp.ID()))
+
+	sw.StopPeerForError(p, "test")
+	select {
+	case ev := <-disconnected:
+		assert.Equal(t, rp.ID(), ev.Peer.ID())
+		assert.Equal(t, "test", ev.Reason)
+	case <-time.After(time.Second):
+		t.Fatal("expected PeerDisconnected event")
+	}
+
+	// No more events after unsubscribing.
+	sw.UnsubscribePeerEvents("test")
+	sw.PeerBehaviour().Errored(p, report)
+	select {
+	case <-errored:
+		t.Fatal("expected no PeerErrored event after unsubscribing")
+	default:
+	}
+}
+
+type peerEventsReactor struct {
+	BaseReactor
+	added, removed []ID
+}
+
+func (r *peerEventsReactor) AddPeer(peer Peer) { r.added = append(r.added, peer.ID()) }
+func (r *peerEventsReactor) RemovePeer(peer Peer, reason interface{}) {
+	r.removed = append(r.removed, peer.ID())
+}
+
+func TestSwitchReactorPeerEvents(t *testing.T) {
+	sw := newTierTestSwitch(cfg)
+	r := &peerEventsReactor{}
+	r.BaseReactor = *NewBaseReactor("peerEventsReactor", r)
+	sw.AddReactor("foo", r)
+
+	p := newMockPeer(nil)
+	sw.peerEvents.FireEvent(EventPeerConnected, EventDataPeerConnected{Peer: p})
+	sw.peerEvents.FireEvent(EventPeerDisconnected, EventDataPeerDisconnected{Peer: p})
+	assert.Equal(t, []ID{p.ID()}, r.added)
+	assert.Equal(t, []ID{p.ID()}, r.removed)
+}
@@ -0,0 +1,43 @@
+package p2p
+
+type greylistPeerBehaviour struct {
+	established  PeerBehaviour
+	greylisted   PeerBehaviour
+	isGreylisted func(Peer) bool
+}
+
+// NewGreylistPeerBehaviour returns a PeerBehaviour which puts newly connected
+// peers on probation. Errors of peers for which isGreylisted returns true,
+// usually Switch#IsGreylisted, are forwarded to greylisted, which should stop
+// the peer right away. Errors of established peers are forwarded to
+// established, usually a more lenient ScoredPeerBehaviour. This limits the
+// damage a churn of short-lived, misbehaving connections can do.
+//
+// Good behaviour is always forwarded to established, so peers build up their
+// score while on probation.
+func NewGreylistPeerBehaviour(
+	established PeerBehaviour,
+	greylisted PeerBehaviour,
+	isGreylisted func(Peer) bool,
+) PeerBehaviour {
+	return &greylistPeerBehaviour{
+		established:  established,
+		greylisted:   greylisted,
+		isGreylisted: isGreylisted,
+	}
+}
+
+// Errored forwards the report to greylisted if the peer is on probation and
+// to established otherwise.
+func (gpb *greylistPeerBehaviour) Errored(peer Peer, report ErrorBehaviourReport) {
+	if gpb.isGreylisted(peer) {
+		gpb.greylisted.Errored(peer, report)
+		return
+	}
+	gpb.established.Errored(peer, report)
+}
+
+// Behaved forwards the report to established.
+func (gpb *greylistPeerBehaviour) Behaved(peer Peer, report GoodBehaviourReport) {
+	gpb.established.Behaved(peer, report)
+}
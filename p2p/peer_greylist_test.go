@@ -0,0 +1,31 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGreylistPeerBehaviour(t *testing.T) {
+	var (
+		established = NewStorePeerBehaviour()
+		greylisted  = NewStorePeerBehaviour()
+		onProbation = true
+		pb          = NewGreylistPeerBehaviour(established, greylisted, func(Peer) bool { return onProbation })
+		peer        = newMockPeer(nil)
+		bad         = ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage}
+		good        = GoodBehaviourReport{Reason: GoodPeerBehaviourVote}
+	)
+
+	pb.Errored(peer, bad)
+	pb.Behaved(peer, good)
+	assert.Equal(t, []ErrorBehaviourReport{bad}, greylisted.GetErrored(peer.ID()))
+	assert.Empty(t, established.GetErrored(peer.ID()))
+	assert.Empty(t, greylisted.GetBehaved(peer.ID()))
+	assert.Equal(t, []GoodBehaviourReport{good}, established.GetBehaved(peer.ID()))
+
+	onProbation = false
+	pb.Errored(peer, bad)
+	assert.Len(t, greylisted.GetErrored(peer.ID()), 1)
+	assert.Equal(t, []ErrorBehaviourReport{bad}, established.GetErrored(peer.ID()))
+}
@@ -0,0 +1,227 @@
+package p2p
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Scores which decayed to within this distance of zero are forgotten.
+const peerScoreEpsilon = 0.01
+
+// PeerScoreConfig configures the weights and decay used by
+// ScoredPeerBehaviour.
+type PeerScoreConfig struct {
+	// Amount added to a peer's score per reported GoodPeerBehaviour.
+	// Reasons missing from the map use DefaultGoodWeight.
+	GoodWeights       map[GoodPeerBehaviour]float64
+	DefaultGoodWeight float64
+
+	// Amount subtracted from a peer's score per reported ErrorPeerBehaviour.
+	// Reasons missing from the map use DefaultErrorWeight.
+	ErrorWeights       map[ErrorPeerBehaviour]float64
+	DefaultErrorWeight float64
+
+	// Time it takes for a score to decay halfway towards zero. Zero disables
+	// decay.
+	DecayHalfLife time.Duration
+
+	// Upper bound of the score, so a peer can't bank unlimited credit which
+	// would then shield it from the consequences of misbehaving.
+	MaxScore float64
+
+	// A peer is stopped once its score drops to or below this value.
+	StopThreshold float64
+}
+
+// DefaultPeerScoreConfig returns a config which tolerates the occasional
+// error from an otherwise useful peer, but stops peers which err repeatedly
+// in a short period of time.
+func DefaultPeerScoreConfig() PeerScoreConfig {
+	return PeerScoreConfig{
+		GoodWeights: map[GoodPeerBehaviour]float64{
+			GoodPeerBehaviourVote:          1,
+			GoodPeerBehaviourBlockPart:     1,
+			GoodPeerBehaviourBlockResponse: 1,
+			GoodPeerBehaviourSnapshotChunk: 1,
+		},
+		DefaultGoodWeight: 1,
+		ErrorWeights: map[ErrorPeerBehaviour]float64{
+			ErrorPeerBehaviourBadMessage:        40,
+			ErrorPeerBehaviourMessageOutOfOrder: 10,
+			ErrorPeerBehaviourRateLimitExceeded: 5,
+			ErrorPeerBehaviourBlockTimeout:      20,
+			ErrorPeerBehaviourBadBlock:          100,
+			ErrorPeerBehaviourBadSnapshotChunk:  100,
+			ErrorPeerBehaviourBadEvidence:       100,
+			ErrorPeerBehaviourExpiredEvidence:   10,
+		},
+		DefaultErrorWeight: 20,
+		DecayHalfLife:      10 * time.Minute,
+		MaxScore:           100,
+		StopThreshold:      -100,
+	}
+}
+
+// SetWeights overrides the weights of the reasons with the given names, as
+// returned by their String methods.
+func (c *PeerScoreConfig) SetWeights(weights map[string]float64) error {
+	errorReasons := make(map[string]ErrorPeerBehaviour)
+	for r := ErrorPeerBehaviourUnknown; r <= ErrorPeerBehaviourExpiredEvidence; r++ {
+		errorReasons[r.String()] = r
+	}
+	goodReasons := make(map[string]GoodPeerBehaviour)
+	for r := GoodPeerBehaviourVote; r <= GoodPeerBehaviourSnapshotChunk; r++ {
+		goodReasons[r.String()] = r
+	}
+
+	for name, weight := range weights {
+		if r, ok := errorReasons[name]; ok {
+			if c.ErrorWeights == nil {
+				c.ErrorWeights = make(map[ErrorPeerBehaviour]float64)
+			}
+			c.ErrorWeights[r] = weight
+		} else if r, ok := goodReasons[name]; ok {
+			if c.GoodWeights == nil {
+				c.GoodWeights = make(map[GoodPeerBehaviour]float64)
+			}
+			c.GoodWeights[r] = weight
+		} else {
+			return fmt.Errorf("unknown peer behaviour %q", name)
+		}
+	}
+	return nil
+}
+
+type peerScore struct {
+	score   float64
+	updated time.Time
+}
+
+// ScoredPeerBehaviour is a PeerBehaviour which converts reports into a
+// numeric score per peer. Good behaviour is forwarded to the wrapped
+// PeerBehaviour right away, while error behaviour is only forwarded once the
+// score of the peer crosses the configured StopThreshold. Scores decay
+// exponentially towards zero over time, and are forgotten once they reach it.
+// Scores outlive disconnects, so a peer can't clear its score by reconnecting.
+type ScoredPeerBehaviour struct {
+	mtx    sync.Mutex
+	pb     PeerBehaviour
+	config PeerScoreConfig
+	scores map[ID]*peerScore
+
+	lastPrune time.Time
+	now       func() time.Time // overridden in tests
+}
+
+var _ PeerBehaviour = (*ScoredPeerBehaviour)(nil)
+
+// NewScoredPeerBehaviour returns a ScoredPeerBehaviour which forwards to pb,
+// usually the result of NewSwitchPeerBehaviour.
+func NewScoredPeerBehaviour(pb PeerBehaviour, config PeerScoreConfig) *ScoredPeerBehaviour {
+	return &ScoredPeerBehaviour{
+		pb:     pb,
+		config: config,
+		scores: make(map[ID]*peerScore),
+		now:    time.Now,
+	}
+}
+
+// Behaved increases the score of the peer and forwards the report.
+func (spb *ScoredPeerBehaviour) Behaved(peer Peer, report GoodBehaviourReport) {
+	weight, ok := spb.config.GoodWeights[report.Reason]
+	if !ok {
+		weight = spb.config.DefaultGoodWeight
+	}
+
+	spb.mtx.Lock()
+	spb.add(peer.ID(), weight)
+	spb.mtx.Unlock()
+
+	spb.pb.Behaved(peer, report)
+}
+
+// Errored decreases the score of the peer and forwards the report if the
+// score dropped to or below the StopThreshold.
+func (spb *ScoredPeerBehaviour) Errored(peer Peer, report ErrorBehaviourReport) {
+	weight, ok := spb.config.ErrorWeights[report.Reason]
+	if !ok {
+		weight = spb.config.DefaultErrorWeight
+	}
+
+	spb.mtx.Lock()
+	score := spb.add(peer.ID(), -weight)
+	crossed := score <= spb.config.StopThreshold
+	if crossed {
+		// The peer is about to be stopped, start from scratch if it ever
+		// comes back.
+		delete(spb.scores, peer.ID())
+	}
+	spb.mtx.Unlock()
+
+	if crossed {
+		spb.pb.Errored(peer, report)
+	}
+}
+
+// Score returns the current, decayed score of the peer.
+func (spb *ScoredPeerBehaviour) Score(peerID ID) float64 {
+	spb.mtx.Lock()
+	defer spb.mtx.Unlock()
+	ps, ok := spb.scores[peerID]
+	if !ok {
+		return 0
+	}
+	return spb.decay(ps, spb.now())
+}
+
+// Reset forgets the score of the peer.
+func (spb *ScoredPeerBehaviour) Reset(peerID ID) {
+	spb.mtx.Lock()
+	defer spb.mtx.Unlock()
+	delete(spb.scores, peerID)
+}
+
+// add applies the decay accumulated since the last update and adds delta to
+// the score of the peer. Returns the new score.
+// CONTRACT: spb.mtx must be held.
+func (spb *ScoredPeerBehaviour) add(peerID ID, delta float64) float64 {
+	now := spb.now()
+	spb.prune(now)
+	ps, ok := spb.scores[peerID]
+	if !ok {
+		ps = &peerScore{updated: now}
+		spb.scores[peerID] = ps
+	}
+	ps.score = math.Min(spb.decay(ps, now)+delta, spb.config.MaxScore)
+	ps.updated = now
+	return ps.score
+}
+
+// prune forgets the scores which decayed to about zero, at most once per
+// DecayHalfLife, so the scores of peers which are gone don't pile up.
+// CONTRACT: spb.mtx must be held.
+func (spb *ScoredPeerBehaviour) prune(now time.Time) {
+	if spb.config.DecayHalfLife <= 0 || now.Sub(spb.lastPrune) < spb.config.DecayHalfLife {
+		return
+	}
+	spb.lastPrune = now
+	for peerID, ps := range spb.scores {
+		if math.Abs(spb.decay(ps, now)) < peerScoreEpsilon {
+			delete(spb.scores, peerID)
+		}
+	}
+}
+
+// decay returns the score of ps decayed until now.
+func (spb *ScoredPeerBehaviour) decay(ps *peerScore, now time.Time) float64 {
+	if spb.config.DecayHalfLife <= 0 {
+		return ps.score
+	}
+	elapsed := now.Sub(ps.updated)
+	if elapsed <= 0 {
+		return ps.score
+	}
+	return ps.score * math.Pow(0.5, float64(elapsed)/float64(spb.config.DecayHalfLife))
+}
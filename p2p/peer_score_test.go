@@ -0,0 +1,135 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestScoredPeerBehaviour(config PeerScoreConfig) (*ScoredPeerBehaviour, GettablePeerBehaviour, *time.Time) {
+	store := NewStorePeerBehaviour()
+	spb := NewScoredPeerBehaviour(store, config)
+	now := time.Now()
+	spb.now = func() time.Time { return now }
+	return spb, store, &now
+}
+
+func TestScoredPeerBehaviourStopsOnlyPastThreshold(t *testing.T) {
+	spb, store, _ := newTestScoredPeerBehaviour(DefaultPeerScoreConfig())
+	peer := newMockPeer(nil)
+
+	// A single bad message must not stop the peer.
+	spb.Errored(peer, ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage})
+	assert.Empty(t, store.GetErrored(peer.ID()))
+	assert.Equal(t, float64(-40), spb.Score(peer.ID()))
+
+	spb.Errored(peer, ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage})
+	assert.Empty(t, store.GetErrored(peer.ID()))
+
+	spb.Errored(peer, ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage})
+	assert.Equal(t,
+		[]ErrorBehaviourReport{{Reason: ErrorPeerBehaviourBadMessage}},
+		store.GetErrored(peer.ID()),
+	)
+
+	// The score is reset once the peer was reported.
+	assert.Equal(t, float64(0), spb.Score(peer.ID()))
+}
+
+func TestScoredPeerBehaviourGoodBehaviour(t *testing.T) {
+	config := DefaultPeerScoreConfig()
+	spb, store, _ := newTestScoredPeerBehaviour(config)
+	peer := newMockPeer(nil)
+
+	for i := 0; i < 200; i++ {
+		spb.Behaved(peer, GoodBehaviourReport{Reason: GoodPeerBehaviourVote})
+	}
+	assert.Len(t, store.GetBehaved(peer.ID()), 200)
+	assert.Equal(t, config.MaxScore, spb.Score(peer.ID()), "score should be capped")
+
+	// A well behaving peer survives more errors.
+	for i := 0; i < 4; i++ {
+		spb.Errored(peer, ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage})
+	}
+	assert.Empty(t, store.GetErrored(peer.ID()))
+
+	spb.Errored(peer, ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage})
+	assert.Len(t, store.GetErrored(peer.ID()), 1)
+}
+
+func TestScoredPeerBehaviourDecay(t *testing.T) {
+	config := DefaultPeerScoreConfig()
+	spb, store, now := newTestScoredPeerBehaviour(config)
+	peer := newMockPeer(nil)
+
+	spb.Errored(peer, ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage})
+	spb.Errored(peer, ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage})
+	assert.Equal(t, float64(-80), spb.Score(peer.ID()))
+
+	*now = now.Add(config.DecayHalfLife)
+	assert.InDelta(t, -40, spb.Score(peer.ID()), 0.001)
+
+	// After decaying, two more errors are needed to stop the peer.
+	spb.Errored(peer, ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage})
+	assert.Empty(t, store.GetErrored(peer.ID()))
+	spb.Errored(peer, ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage})
+	assert.Len(t, store.GetErrored(peer.ID()), 1)
+}
+
+func TestScoredPeerBehaviourWeights(t *testing.T) {
+	config := PeerScoreConfig{
+		ErrorWeights: map[ErrorPeerBehaviour]float64{
+			ErrorPeerBehaviourBadMessage: 10,
+		},
+		DefaultErrorWeight: 1,
+		MaxScore:           10,
+		StopThreshold:      -10,
+	}
+	spb, store, _ := newTestScoredPeerBehaviour(config)
+	peer := newMockPeer(nil)
+
+	for i := 0; i < 9; i++ {
+		spb.Errored(peer, ErrorBehaviourReport{Reason: ErrorPeerBehaviourMessageOutOfOrder})
+	}
+	assert.Empty(t, store.GetErrored(peer.ID()))
+	assert.Equal(t, float64(-9), spb.Score(peer.ID()))
+
+	spb.Errored(peer, ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage})
+	assert.Equal(t,
+		[]ErrorBehaviourReport{{Reason: ErrorPeerBehaviourBadMessage}},
+		store.GetErrored(peer.ID()),
+	)
+
+	spb.Errored(peer, ErrorBehaviourReport{Reason: ErrorPeerBehaviourMessageOutOfOrder})
+	spb.Reset(peer.ID())
+	assert.Equal(t, float64(0), spb.Score(peer.ID()))
+}
+
+func TestScoredPeerBehaviourForgetsDecayedScores(t *testing.T) {
+	config := DefaultPeerScoreConfig()
+	spb, _, now := newTestScoredPeerBehaviour(config)
+	gone, active := newMockPeer(nil), newMockPeer(nil)
+
+	spb.Errored(gone, ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage})
+	*now = now.Add(20 * config.DecayHalfLife)
+	spb.Errored(active, ErrorBehaviourReport{Reason: ErrorPeerBehaviourBadMessage})
+
+	spb.mtx.Lock()
+	assert.NotContains(t, spb.scores, gone.ID())
+	assert.Contains(t, spb.scores, active.ID())
+	spb.mtx.Unlock()
+}
+
+func TestPeerScoreConfigSetWeights(t *testing.T) {
+	config := DefaultPeerScoreConfig()
+	assert.NoError(t, config.SetWeights(map[string]float64{"BadMessage": 5, "Vote": 2}))
+	assert.Equal(t, float64(5), config.ErrorWeights[ErrorPeerBehaviourBadMessage])
+	assert.Equal(t, float64(2), config.GoodWeights[GoodPeerBehaviourVote])
+	assert.Equal(t, float64(100), config.ErrorWeights[ErrorPeerBehaviourBadBlock])
+
+	assert.NoError(t, config.SetWeights(map[string]float64{"BadSnapshotChunk": 50}))
+	assert.Equal(t, float64(50), config.ErrorWeights[ErrorPeerBehaviourBadSnapshotChunk])
+
+	assert.Error(t, config.SetWeights(map[string]float64{"Unexpected": 1}))
+}
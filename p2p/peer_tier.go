@@ -0,0 +1,192 @@
+package p2p
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// maxPeerTierHints is the maximum number of peers the tier hints are kept
+// for. The hints of the least recently hinted peers are dropped first.
+const maxPeerTierHints = 1000
+
+// PeerTier is the priority class of a peer. Every tier has its own pool of
+// inbound slots, so peers of one tier never take the slots of another. Higher
+// tiers take precedence when tiers are combined, e.g. a persistent peer which
+// is also configured as a validator is in the validator tier.
+type PeerTier int
+
+const (
+	// PeerTierPublic is the tier of all peers not in any other tier. Its
+	// slots are limited by MaxNumInboundPeers. Once they are full, new
+	// public peers are refused.
+	PeerTierPublic PeerTier = iota
+	// PeerTierSentry is the tier of trusted relays: configured sentry nodes
+	// and peers which consensus found to be useful. Its slots are limited by
+	// MaxNumInboundSentryPeers. Once they are full, a configured sentry
+	// evicts a peer which is only in the tier due to a runtime hint.
+	PeerTierSentry
+	// PeerTierPersistent is the tier of persistent peers. It is unlimited.
+	PeerTierPersistent
+	// PeerTierValidator is the tier of configured validator peers. It is
+	// unlimited.
+	PeerTierValidator
+)
+
+func (t PeerTier) String() string {
+	switch t {
+	case PeerTierPublic:
+		return "public"
+	case PeerTierSentry:
+		return "sentry"
+	case PeerTierPersistent:
+		return "persistent"
+	case PeerTierValidator:
+		return "validator"
+	default:
+		return fmt.Sprintf("PeerTier(%d)", int(t))
+	}
+}
+
+// SwitchPeerTier assigns the peers with the given IDs to the tier. If a peer
+// is assigned to multiple tiers, the highest one is kept.
+func SwitchPeerTier(tier PeerTier, ids ...ID) SwitchOption {
+	return func(sw *Switch) {
+		for _, id := range ids {
+			if cur, ok := sw.peerTiers[id]; !ok || cur < tier {
+				sw.peerTiers[id] = tier
+			}
+		}
+	}
+}
+
+// PeerTier returns the tier of the peer, taking the configured tiers,
+// persistence and runtime hints into account.
+func (sw *Switch) PeerTier(peer Peer) PeerTier {
+	tier := sw.configuredPeerTier(peer)
+	if hint, ok := sw.peerTierHints.get(peer.ID()); ok && hint > tier {
+		tier = hint
+	}
+	return tier
+}
+
+// SetPeerTierHint raises the tier of the peer with the given ID at runtime,
+// e.g. because consensus found the peer to be useful. Hints never lower the
+// tier of a peer and are kept across reconnects, for the last
+// maxPeerTierHints peers hinted, unless the peer is stopped for an error. An
+// established peer moves to the pool of its new tier right away, which may
+// leave that pool over capacity until peers disconnect.
+func (sw *Switch) SetPeerTierHint(id ID, tier PeerTier) {
+	sw.peerTierHints.raise(id, tier)
+}
+
+func (sw *Switch) configuredPeerTier(peer Peer) PeerTier {
+	tier := sw.peerTiers[peer.ID()]
+	if sw.IsPeerPersistent(peer) && tier < PeerTierPersistent {
+		tier = PeerTierPersistent
+	}
+	return tier
+}
+
+// acquireInboundSlot checks whether the pool of the inbound peer's tier has a
+// free slot, evicting another peer if the tier's policy allows it.
+func (sw *Switch) acquireInboundSlot(p Peer) error {
+	tier := sw.PeerTier(p)
+
+	var (
+		max    int
+		inTier []Peer
+	)
+	switch tier {
+	case PeerTierPublic:
+		max = sw.config.MaxNumInboundPeers
+	case PeerTierSentry:
+		max = sw.config.MaxNumInboundSentryPeers
+	default:
+		return nil
+	}
+
+	for _, peer := range sw.peers.List() {
+		if !peer.IsOutbound() && sw.PeerTier(peer) == tier {
+			inTier = append(inTier, peer)
+		}
+	}
+	if len(inTier) < max {
+		return nil
+	}
+
+	if tier == PeerTierSentry && sw.configuredPeerTier(p) == PeerTierSentry {
+		for _, peer := range inTier {
+			if sw.configuredPeerTier(peer) < PeerTierSentry {
+				sw.Logger.Info("Evicting hinted sentry peer for configured one", "peer", peer, "for", p.ID())
+				sw.StopPeerGracefully(peer)
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("already have %d of %d inbound %v peers", len(inTier), max, tier)
+}
+
+//-----------------------------------------------------------------------------
+
+// peerTierHints are the runtime tier hints of the last peers hinted, up to a
+// maximum.
+type peerTierHints struct {
+	mtx   sync.Mutex
+	size  int
+	hints map[ID]*list.Element
+	list  *list.List // of *peerTierHint, to drop the least recently hinted
+}
+
+type peerTierHint struct {
+	id   ID
+	tier PeerTier
+}
+
+func newPeerTierHints(size int) *peerTierHints {
+	return &peerTierHints{
+		size:  size,
+		hints: make(map[ID]*list.Element),
+		list:  list.New(),
+	}
+}
+
+func (h *peerTierHints) get(id ID) (PeerTier, bool) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	e, ok := h.hints[id]
+	if !ok {
+		return PeerTierPublic, false
+	}
+	return e.Value.(*peerTierHint).tier, true
+}
+
+// raise sets the hint of the peer, unless it has a higher one already.
+func (h *peerTierHints) raise(id ID, tier PeerTier) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if e, ok := h.hints[id]; ok {
+		hint := e.Value.(*peerTierHint)
+		if hint.tier < tier {
+			hint.tier = tier
+		}
+		h.list.MoveToBack(e)
+		return
+	}
+	if h.list.Len() >= h.size {
+		oldest := h.list.Front()
+		delete(h.hints, oldest.Value.(*peerTierHint).id)
+		h.list.Remove(oldest)
+	}
+	h.hints[id] = h.list.PushBack(&peerTierHint{id, tier})
+}
+
+func (h *peerTierHints) remove(id ID) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if e, ok := h.hints[id]; ok {
+		delete(h.hints, id)
+		h.list.Remove(e)
+	}
+}
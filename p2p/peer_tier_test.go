@@ -0,0 +1,124 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/p2p/conn"
+)
+
+// tierMockPeer is an inbound, non-persistent mockPeer.
+type tierMockPeer struct {
+	*mockPeer
+}
+
+func (tp tierMockPeer) IsPersistent() bool { return false }
+
+func newTierMockPeer() tierMockPeer {
+	mp := newMockPeer(nil)
+	mp.BaseService = *cmn.NewBaseService(nil, "MockPeer", mp)
+	return tierMockPeer{mp}
+}
+
+func newTierTestSwitch(cfg *config.P2PConfig, options ...SwitchOption) *Switch {
+	transport := NewMultiplexTransport(DefaultNodeInfo{}, NodeKey{}, conn.DefaultMConnConfig())
+	return NewSwitch(cfg, transport, options...)
+}
+
+func TestSwitchPeerTier(t *testing.T) {
+	var (
+		validator = newTierMockPeer()
+		sentry    = newTierMockPeer()
+		public    = newTierMockPeer()
+	)
+	sw := newTierTestSwitch(
+		config.DefaultP2PConfig(),
+		SwitchPeerTier(PeerTierSentry, validator.ID(), sentry.ID()),
+		SwitchPeerTier(PeerTierValidator, validator.ID()),
+		SwitchPeerTier(PeerTierPublic, validator.ID()),
+	)
+
+	assert.Equal(t, PeerTierValidator, sw.PeerTier(validator))
+	assert.Equal(t, PeerTierSentry, sw.PeerTier(sentry))
+	assert.Equal(t, PeerTierPublic, sw.PeerTier(public))
+	assert.Equal(t, PeerTierPersistent, sw.PeerTier(newMockPeer(nil)))
+
+	// Hints only ever raise the tier.
+	sw.SetPeerTierHint(public.ID(), PeerTierSentry)
+	sw.SetPeerTierHint(public.ID(), PeerTierPublic)
+	assert.Equal(t, PeerTierSentry, sw.PeerTier(public))
+	sw.SetPeerTierHint(validator.ID(), PeerTierSentry)
+	assert.Equal(t, PeerTierValidator, sw.PeerTier(validator))
+}
+
+func TestSwitchInboundSlotsPerTier(t *testing.T) {
+	cfg := config.DefaultP2PConfig()
+	cfg.MaxNumInboundPeers = 1
+	cfg.MaxNumInboundSentryPeers = 1
+
+	var (
+		validator = newTierMockPeer()
+		sentry    = newTierMockPeer()
+		hinted    = newTierMockPeer()
+	)
+	sw := newTierTestSwitch(
+		cfg,
+		SwitchPeerTier(PeerTierValidator, validator.ID()),
+		SwitchPeerTier(PeerTierSentry, sentry.ID()),
+	)
+	sw.SetPeerTierHint(hinted.ID(), PeerTierSentry)
+
+	// A full public pool refuses public peers, but not validators.
+	require.NoError(t, sw.peers.Add(newTierMockPeer()))
+	assert.Error(t, sw.acquireInboundSlot(newTierMockPeer()))
+	assert.NoError(t, sw.acquireInboundSlot(validator))
+
+	// A hinted sentry only takes a free sentry slot.
+	assert.NoError(t, sw.acquireInboundSlot(hinted))
+	require.NoError(t, sw.peers.Add(hinted))
+	assert.Error(t, sw.acquireInboundSlot(newHintedSentry(sw)))
+
+	// A configured sentry evicts the hinted one.
+	assert.NoError(t, sw.acquireInboundSlot(sentry))
+	assert.False(t, sw.peers.Has(hinted.ID()))
+	require.NoError(t, sw.peers.Add(sentry))
+
+	// But not another configured sentry.
+	other := newTierMockPeer()
+	SwitchPeerTier(PeerTierSentry, other.ID())(sw)
+	assert.Error(t, sw.acquireInboundSlot(other))
+}
+
+func TestSwitchPeerTierHintsAreBounded(t *testing.T) {
+	sw := newTierTestSwitch(config.DefaultP2PConfig())
+	sw.peerTierHints = newPeerTierHints(2)
+
+	var (
+		a = newTierMockPeer()
+		b = newTierMockPeer()
+		c = newTierMockPeer()
+	)
+	sw.SetPeerTierHint(a.ID(), PeerTierSentry)
+	sw.SetPeerTierHint(b.ID(), PeerTierSentry)
+	sw.SetPeerTierHint(a.ID(), PeerTierSentry)
+	sw.SetPeerTierHint(c.ID(), PeerTierSentry)
+
+	// The least recently hinted peer is dropped.
+	assert.Equal(t, PeerTierSentry, sw.PeerTier(a))
+	assert.Equal(t, PeerTierPublic, sw.PeerTier(b))
+	assert.Equal(t, PeerTierSentry, sw.PeerTier(c))
+
+	// So is a peer stopped for an error.
+	sw.StopPeerForError(c, "error")
+	assert.Equal(t, PeerTierPublic, sw.PeerTier(c))
+}
+
+func newHintedSentry(sw *Switch) Peer {
+	p := newTierMockPeer()
+	sw.SetPeerTierHint(p.ID(), PeerTierSentry)
+	return p
+}
@@ -0,0 +1,132 @@
+package p2p
+
+import (
+	"sort"
+)
+
+// IsPeerPersistent returns true if the switch redials the peer when it
+// disconnects. This is the case if the peer was dialed as persistent or its
+// ID is one of the persistent peers, unless it was removed from them at
+// runtime.
+func (sw *Switch) IsPeerPersistent(peer Peer) bool {
+	id := string(peer.ID())
+	if sw.persistentPeers.Has(id) {
+		return true
+	}
+	if sw.removedPersistentPeers.Has(id) {
+		return false
+	}
+	return peer.IsPersistent()
+}
+
+// PersistentPeers returns the addresses of the persistent peers, sorted by ID.
+func (sw *Switch) PersistentPeers() []*NetAddress {
+	values := sw.persistentPeers.Values()
+	addrs := make([]*NetAddress, 0, len(values))
+	for _, v := range values {
+		addrs = append(addrs, v.(*NetAddress))
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i].ID < addrs[j].ID })
+	return addrs
+}
+
+// AddPersistentPeers makes the peers with the given addresses persistent and
+// dials the ones we aren't connected to. Nothing is changed if any address
+// is invalid.
+func (sw *Switch) AddPersistentPeers(addrs []string) error {
+	if _, errs := NewNetAddressStrings(addrs); len(errs) > 0 {
+		return errs[0]
+	}
+	return sw.DialPeersAsync(sw.addrBook, addrs, true)
+}
+
+// RemovePersistentPeers stops redialing the peers with the given IDs. They
+// are not disconnected, but won't be redialed once they disconnect.
+func (sw *Switch) RemovePersistentPeers(ids []ID) {
+	for _, id := range ids {
+		sw.persistentPeers.Delete(string(id))
+		sw.removedPersistentPeers.Set(string(id), struct{}{})
+		sw.dialScheduler.Cancel(id)
+	}
+}
+
+// SetPersistentPeers replaces the persistent peers with the peers with the
+// given addresses, to apply a changed persistent_peers config at runtime.
+// Nothing is changed if any address is invalid.
+func (sw *Switch) SetPersistentPeers(addrs []string) error {
+	netAddrs, errs := NewNetAddressStrings(addrs)
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	keep := make(map[ID]struct{}, len(netAddrs))
+	for _, addr := range netAddrs {
+		keep[addr.ID] = struct{}{}
+	}
+	var remove []ID
+	for _, addr := range sw.PersistentPeers() {
+		if _, ok := keep[addr.ID]; !ok {
+			remove = append(remove, addr.ID)
+		}
+	}
+	sw.RemovePersistentPeers(remove)
+
+	return sw.DialPeersAsync(sw.addrBook, addrs, true)
+}
+
+// registerPersistentPeers records the addresses as persistent peers. It is
+// called for all addresses dialed as persistent by DialPeersAsync.
+func (sw *Switch) registerPersistentPeers(addrs []*NetAddress) {
+	for _, addr := range addrs {
+		sw.persistentPeers.Set(string(addr.ID), addr)
+		sw.removedPersistentPeers.Delete(string(addr.ID))
+	}
+}
+
+// persistentPeerAddr returns the address to redial the persistent peer at.
+func (sw *Switch) persistentPeerAddr(peer Peer) *NetAddress {
+	if addr, ok := sw.persistentPeers.Get(string(peer.ID())).(*NetAddress); ok {
+		return addr
+	}
+	if addr := peer.OriginalAddr(); addr != nil {
+		return addr
+	}
+	// self-reported address for inbound persistent peers
+	return peer.NodeInfo().NetAddress()
+}
+
+// PrivatePeerIDs returns the IDs of the private peers, sorted.
+func (sw *Switch) PrivatePeerIDs() []ID {
+	keys := sw.privatePeerIDs.Keys()
+	ids := make([]ID, 0, len(keys))
+	for _, key := range keys {
+		ids = append(ids, ID(key))
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// SetPrivatePeerIDs replaces the IDs of the private peers, whose addresses
+// are not gossiped to other peers, to apply a changed private_peer_ids config
+// at runtime.
+func (sw *Switch) SetPrivatePeerIDs(ids []string) {
+	keep := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		keep[id] = struct{}{}
+	}
+	var remove []string
+	for _, id := range sw.privatePeerIDs.Keys() {
+		if _, ok := keep[id]; !ok {
+			remove = append(remove, id)
+			sw.privatePeerIDs.Delete(id)
+		}
+	}
+	for _, id := range ids {
+		sw.privatePeerIDs.Set(id, struct{}{})
+	}
+
+	if sw.addrBook != nil {
+		sw.addrBook.RemovePrivateIDs(remove)
+		sw.addrBook.AddPrivateIDs(ids)
+	}
+}
@@ -0,0 +1,75 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestSwitchSetPersistentPeers(t *testing.T) {
+	cfg := config.DefaultP2PConfig()
+	cfg.TestDialFail = true
+	sw := newTierTestSwitch(cfg)
+	sw.SetNodeInfo(testNodeInfo(newTierMockPeer().ID(), "node"))
+	sw.SetLogger(log.TestingLogger())
+
+	a, b := newTierMockPeer(), newTierMockPeer()
+	addrA := IDAddressString(a.ID(), "127.0.0.1:1")
+	addrB := IDAddressString(b.ID(), "127.0.0.1:2")
+
+	require.NoError(t, sw.SetPersistentPeers([]string{addrA, addrB}))
+	assert.True(t, sw.IsPeerPersistent(a))
+	assert.True(t, sw.IsPeerPersistent(b))
+	assert.Len(t, sw.PersistentPeers(), 2)
+	assert.Equal(t, PeerTierPersistent, sw.PeerTier(a))
+
+	// Removed peers are no longer persistent, even if they were dialed as
+	// persistent.
+	require.NoError(t, sw.SetPersistentPeers([]string{addrB}))
+	assert.False(t, sw.IsPeerPersistent(a))
+	assert.True(t, sw.IsPeerPersistent(b))
+	dialedA := newMockPeer(nil)
+	dialedA.id = a.ID()
+	assert.False(t, sw.IsPeerPersistent(dialedA))
+	assert.True(t, sw.IsPeerPersistent(newMockPeer(nil)))
+
+	// Nothing changes if an address is invalid.
+	assert.Error(t, sw.SetPersistentPeers([]string{addrA, "invalid"}))
+	require.Len(t, sw.PersistentPeers(), 1)
+	assert.Equal(t, b.ID(), sw.PersistentPeers()[0].ID)
+}
+
+func TestSwitchSetPrivatePeerIDs(t *testing.T) {
+	sw := newTierTestSwitch(config.DefaultP2PConfig())
+	book := &privateIDsAddrBook{addrBookMock: &addrBookMock{}, private: make(map[string]struct{})}
+	sw.SetAddrBook(book)
+
+	sw.SetPrivatePeerIDs([]string{"b", "a"})
+	assert.Equal(t, []ID{"a", "b"}, sw.PrivatePeerIDs())
+	assert.Equal(t, map[string]struct{}{"a": {}, "b": {}}, book.private)
+
+	sw.SetPrivatePeerIDs([]string{"c", "a"})
+	assert.Equal(t, []ID{"a", "c"}, sw.PrivatePeerIDs())
+	assert.Equal(t, map[string]struct{}{"a": {}, "c": {}}, book.private)
+}
+
+type privateIDsAddrBook struct {
+	*addrBookMock
+	private map[string]struct{}
+}
+
+func (book *privateIDsAddrBook) AddPrivateIDs(ids []string) {
+	for _, id := range ids {
+		book.private[id] = struct{}{}
+	}
+}
+
+func (book *privateIDsAddrBook) RemovePrivateIDs(ids []string) {
+	for _, id := range ids {
+		delete(book.private, id)
+	}
+}
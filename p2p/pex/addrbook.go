@@ -36,6 +36,7 @@ type AddrBook interface {
 	OurAddress(*p2p.NetAddress) bool
 
 	AddPrivateIDs([]string)
+	RemovePrivateIDs([]string)
 
 	// Add and remove an address
 	AddAddress(addr *p2p.NetAddress, src *p2p.NetAddress) error
@@ -57,6 +58,14 @@ type AddrBook interface {
 	MarkGood(*p2p.NetAddress)
 	MarkAttempt(*p2p.NetAddress)
 	MarkBad(*p2p.NetAddress)
+	MarkBadMessage(*p2p.NetAddress)
+	MarkSeen(*p2p.NetAddress)
+
+	// When we last connected to the address
+	LastSeen(*p2p.NetAddress) time.Time
+
+	// The network group of the address, see SetASNResolver
+	GroupKey(*p2p.NetAddress) string
 
 	IsGood(*p2p.NetAddress) bool
 
@@ -68,6 +77,10 @@ type AddrBook interface {
 	// TODO: remove
 	ListOfKnownAddresses() []*knownAddress
 
+	// Export all entries, and import entries of an export
+	Export() []AddrBookEntry
+	Import([]AddrBookEntry) (added int, errs []error)
+
 	// Persist to disk
 	Save()
 }
@@ -87,6 +100,7 @@ type addrBook struct {
 	// accessed concurrently
 	mtx        sync.Mutex
 	rand       *cmn.Rand
+	asns       ASNResolver
 	ourAddrs   map[string]struct{}
 	privateIDs map[p2p.ID]struct{}
 	addrLookup map[p2p.ID]*knownAddress // new & old
@@ -114,6 +128,16 @@ func NewAddrBook(filePath string, routabilityStrict bool) *addrBook {
 	return am
 }
 
+// SetASNResolver makes the address book group addresses by the AS announcing
+// them, falling back to the default groups for addresses the resolver doesn't
+// know. It only affects the placement of addresses added afterwards, so it
+// should be called before the address book is started.
+func (a *addrBook) SetASNResolver(r ASNResolver) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.asns = r
+}
+
 // Initialize the buckets.
 // When modifying this, don't forget to update loadFromFile()
 func (a *addrBook) init() {
@@ -178,12 +202,28 @@ func (a *addrBook) OurAddress(addr *p2p.NetAddress) bool {
 	return ok
 }
 
+// AddPrivateIDs implements AddrBook - addresses of peers with these IDs are
+// not added to the book, so they are never gossiped. Addresses already in the
+// book are removed.
 func (a *addrBook) AddPrivateIDs(IDs []string) {
 	a.mtx.Lock()
 	defer a.mtx.Unlock()
 
 	for _, id := range IDs {
 		a.privateIDs[p2p.ID(id)] = struct{}{}
+		if ka := a.addrLookup[p2p.ID(id)]; ka != nil {
+			a.removeFromAllBuckets(ka)
+		}
+	}
+}
+
+// RemovePrivateIDs implements AddrBook.
+func (a *addrBook) RemovePrivateIDs(IDs []string) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	for _, id := range IDs {
+		delete(a.privateIDs, p2p.ID(id))
 	}
 }
 
@@ -264,6 +304,24 @@ func (a *addrBook) PickAddress(biasTowardsNewAddrs int) *p2p.NetAddress {
 		biasTowardsNewAddrs = 0
 	}
 
+	// Pick again if we hit an address of a peer which repeatedly sent bad
+	// messages, but fall back to it if there is nothing better.
+	var ka *knownAddress
+	for i := 0; i < maxPicksToAvoidBadAddress; i++ {
+		ka = a.pickAddress(biasTowardsNewAddrs)
+		if ka == nil || !ka.isDeprioritized() {
+			break
+		}
+	}
+	if ka == nil {
+		return nil
+	}
+	return ka.Addr
+}
+
+// pickAddress picks a random address from an old or new bucket.
+// CONTRACT: a.mtx must be held and biasTowardsNewAddrs must be in [0, 100].
+func (a *addrBook) pickAddress(biasTowardsNewAddrs int) *knownAddress {
 	// Bias between new and old addresses.
 	oldCorrelation := math.Sqrt(float64(a.nOld)) * (100.0 - float64(biasTowardsNewAddrs))
 	newCorrelation := math.Sqrt(float64(a.nNew)) * float64(biasTowardsNewAddrs)
@@ -287,7 +345,7 @@ func (a *addrBook) PickAddress(biasTowardsNewAddrs int) *p2p.NetAddress {
 	randIndex := a.rand.Intn(len(bucket))
 	for _, ka := range bucket {
 		if randIndex == 0 {
-			return ka.Addr
+			return ka
 		}
 		randIndex--
 	}
@@ -328,6 +386,45 @@ func (a *addrBook) MarkBad(addr *p2p.NetAddress) {
 	a.RemoveAddress(addr)
 }
 
+// MarkBadMessage implements AddrBook - it records that the peer sent a bad
+// message. Addresses of peers which do so repeatedly are deprioritized by
+// PickAddress.
+func (a *addrBook) MarkBadMessage(addr *p2p.NetAddress) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka := a.addrLookup[addr.ID]
+	if ka == nil {
+		return
+	}
+	ka.markBadMessage()
+}
+
+// MarkSeen implements AddrBook - it records that we connected to the address.
+func (a *addrBook) MarkSeen(addr *p2p.NetAddress) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka := a.addrLookup[addr.ID]
+	if ka == nil {
+		return
+	}
+	ka.markSeen()
+}
+
+// LastSeen implements AddrBook - it returns the last time we connected to
+// the address, or the zero time if we never did or don't know the address.
+func (a *addrBook) LastSeen(addr *p2p.NetAddress) time.Time {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	ka := a.addrLookup[addr.ID]
+	if ka == nil {
+		return time.Time{}
+	}
+	return ka.LastSeen
+}
+
 // GetSelection implements AddrBook.
 // It randomly selects some addresses (old & new). Suitable for peer-exchange protocols.
 // Must never return a nil address.
@@ -824,10 +921,19 @@ func (a *addrBook) calcOldBucket(addr *p2p.NetAddress) int {
 	return int(binary.BigEndian.Uint64(hash2) % oldBucketCount)
 }
 
+// GroupKey implements AddrBook - it returns a string representing the
+// network group of the address.
+func (a *addrBook) GroupKey(na *p2p.NetAddress) string {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	return a.groupKey(na)
+}
+
 // Return a string representing the network group of this address.
-// This is the /16 for IPv4, the /32 (/36 for he.net) for IPv6, the string
-// "local" for a local address and the string "unroutable" for an unroutable
-// address.
+// This is the AS (e.g. "AS64496") if an ASNResolver is set and knows the
+// address. Otherwise it is the /16 for IPv4, the /32 (/36 for he.net) for
+// IPv6, the string "local" for a local address and the string "unroutable"
+// for an unroutable address.
 func (a *addrBook) groupKey(na *p2p.NetAddress) string {
 	if a.routabilityStrict && na.Local() {
 		return "local"
@@ -836,18 +942,24 @@ func (a *addrBook) groupKey(na *p2p.NetAddress) string {
 		return "unroutable"
 	}
 
+	if a.asns != nil {
+		if asn, ok := a.asns.ASN(na.IP); ok {
+			return fmt.Sprintf("AS%d", asn)
+		}
+	}
+
 	if ipv4 := na.IP.To4(); ipv4 != nil {
-		return (&net.IPNet{IP: na.IP, Mask: net.CIDRMask(16, 32)}).String()
+		return ipGroup(na.IP, 16, 32)
 	}
 	if na.RFC6145() || na.RFC6052() {
 		// last four bytes are the ip address
 		ip := net.IP(na.IP[12:16])
-		return (&net.IPNet{IP: ip, Mask: net.CIDRMask(16, 32)}).String()
+		return ipGroup(ip, 16, 32)
 	}
 
 	if na.RFC3964() {
-		ip := net.IP(na.IP[2:7])
-		return (&net.IPNet{IP: ip, Mask: net.CIDRMask(16, 32)}).String()
+		ip := net.IP(na.IP[2:6])
+		return ipGroup(ip, 16, 32)
 
 	}
 	if na.RFC4380() {
@@ -857,7 +969,7 @@ func (a *addrBook) groupKey(na *p2p.NetAddress) string {
 		for i, byte := range na.IP[12:16] {
 			ip[i] = byte ^ 0xff
 		}
-		return (&net.IPNet{IP: ip, Mask: net.CIDRMask(16, 32)}).String()
+		return ipGroup(ip, 16, 32)
 	}
 
 	// OK, so now we know ourselves to be a IPv6 address.
@@ -870,7 +982,14 @@ func (a *addrBook) groupKey(na *p2p.NetAddress) string {
 		bits = 36
 	}
 
-	return (&net.IPNet{IP: na.IP, Mask: net.CIDRMask(bits, 128)}).String()
+	return ipGroup(na.IP, bits, 128)
+}
+
+// ipGroup returns the network of ip with the given prefix length, e.g.
+// 8.8.0.0/16 for 8.8.8.8.
+func ipGroup(ip net.IP, ones, bits int) string {
+	mask := net.CIDRMask(ones, bits)
+	return (&net.IPNet{IP: ip.Mask(mask), Mask: mask}).String()
 }
 
 // doubleSha256 calculates sha256(sha256(b)) and returns the resulting bytes.
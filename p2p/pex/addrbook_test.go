@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math"
+	"net"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -84,6 +86,55 @@ func TestAddrBookSaveLoad(t *testing.T) {
 	assert.Equal(t, 100, book.Size())
 }
 
+func TestAddrBookExportImport(t *testing.T) {
+	book, fname := createAddrBookWithMOldAndNNewAddrs(t, 3, 5)
+	defer deleteTempFile(fname)
+
+	entries := book.Export()
+	require.Len(t, entries, 8)
+	good := 0
+	for _, entry := range entries {
+		if entry.Good {
+			good++
+		}
+	}
+	assert.Equal(t, 3, good)
+
+	exportName := createTempFileName("addrbook_export_test")
+	defer deleteTempFile(exportName)
+	require.NoError(t, WriteAddrBookEntries(exportName, entries))
+	read, err := ReadAddrBookEntries(exportName)
+	require.NoError(t, err)
+
+	// Importing into a fresh book restores the addresses and their buckets.
+	fname2 := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname2)
+	book2 := NewAddrBook(fname2, true)
+	book2.SetLogger(log.TestingLogger())
+	invalid := AddrBookEntry{Address: "127.0.0.1:26656"}
+	added, errs := book2.Import(append(read, invalid))
+	assert.Equal(t, 8, added)
+	assert.Len(t, errs, 1)
+	for _, entry := range entries {
+		addr, err := p2p.NewNetAddressString(entry.Address)
+		require.NoError(t, err)
+		assert.Equal(t, entry.Good, book2.IsGood(addr), entry.Address)
+	}
+
+	// Importing again adds nothing, but marks addresses good.
+	for i := range entries {
+		if !entries[i].Good {
+			entries[i].Good = true
+			break
+		}
+	}
+	added, errs = book2.Import(entries)
+	assert.Zero(t, added)
+	assert.Empty(t, errs)
+	assert.Equal(t, 8, book2.Size())
+	assert.Equal(t, 4, book2.nOld)
+}
+
 func TestAddrBookLookup(t *testing.T) {
 	fname := createTempFileName("addrbook_test")
 	defer deleteTempFile(fname)
@@ -219,6 +270,93 @@ func TestAddrBookRemoveAddress(t *testing.T) {
 	assert.Equal(t, 0, book.Size())
 }
 
+func TestAddrBookMarkBadMessage(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+
+	bad := randIPv4Address(t)
+	book.AddAddress(bad, bad)
+	for i := 0; i < maxBadMessages; i++ {
+		book.MarkBadMessage(bad)
+	}
+	assert.True(t, book.HasAddress(bad), "bad messages should not remove the address")
+
+	// Deprioritized addresses are still picked if there is nothing better.
+	assert.Equal(t, bad, book.PickAddress(50))
+
+	good := randIPv4Address(t)
+	book.AddAddress(good, good)
+
+	// Without deprioritization, both addresses would be picked equally often.
+	picks := 1000
+	badPicks := 0
+	for i := 0; i < picks; i++ {
+		if book.PickAddress(50).Equals(bad) {
+			badPicks++
+		}
+	}
+	assert.True(t, badPicks < picks/4, "deprioritized address picked %d out of %d times", badPicks, picks)
+
+	// Bad messages survive a restart.
+	book.saveToFile(fname)
+	book2 := NewAddrBook(fname, true)
+	book2.SetLogger(log.TestingLogger())
+	book2.loadFromFile(fname)
+	assert.True(t, book2.addrLookup[bad.ID].isDeprioritized())
+	assert.False(t, book2.addrLookup[good.ID].isDeprioritized())
+}
+
+func TestAddrBookMarkSeen(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+
+	addr := randIPv4Address(t)
+	book.AddAddress(addr, addr)
+	assert.True(t, book.LastSeen(addr).IsZero())
+
+	book.MarkAttempt(addr)
+	book.MarkSeen(addr)
+	lastSeen := book.LastSeen(addr)
+	assert.False(t, lastSeen.IsZero())
+	assert.EqualValues(t, 0, book.addrLookup[addr.ID].Attempts)
+	assert.False(t, book.IsGood(addr), "seen addresses should not be marked good")
+
+	// Liveness survives a restart.
+	book.saveToFile(fname)
+	book2 := NewAddrBook(fname, true)
+	book2.SetLogger(log.TestingLogger())
+	book2.loadFromFile(fname)
+	assert.True(t, lastSeen.Equal(book2.LastSeen(addr)))
+
+	assert.True(t, book.LastSeen(randIPv4Address(t)).IsZero())
+}
+
+func TestAddrBookGroupKey(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, true)
+	book.SetLogger(log.TestingLogger())
+
+	addr := func(ip string) *p2p.NetAddress {
+		return p2p.NewNetAddressIPPort(net.ParseIP(ip), 26656)
+	}
+	assert.Equal(t, "8.8.0.0/16", book.GroupKey(addr("8.8.4.4")))
+	assert.Equal(t, "unroutable", book.GroupKey(addr("10.0.0.1")))
+
+	table, err := ReadASNTable(strings.NewReader("8.8.4.0/24 AS15169"))
+	require.NoError(t, err)
+	book.SetASNResolver(table)
+	assert.Equal(t, "AS15169", book.GroupKey(addr("8.8.4.4")))
+	assert.Equal(t, "8.8.0.0/16", book.GroupKey(addr("8.8.8.8")))
+}
+
 func TestAddrBookGetSelectionWithOneMarkedGood(t *testing.T) {
 	// create a book with 10 addresses, 1 good/old and 9 new
 	book, fname := createAddrBookWithMOldAndNNewAddrs(t, 1, 9)
@@ -0,0 +1,102 @@
+package pex
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ASNResolver maps IP addresses to the autonomous system (AS) announcing
+// them. If set on the address book, addresses are grouped by AS instead of
+// by /16, so a single hosting provider can't fill all buckets or outbound
+// slots no matter how many prefixes it owns.
+type ASNResolver interface {
+	// ASN returns the number of the AS announcing ip, or false if unknown.
+	ASN(ip net.IP) (asn uint32, ok bool)
+}
+
+// ASNTable is an ASNResolver backed by a static list of IP prefixes, with
+// longest prefix matching.
+type ASNTable struct {
+	prefixes map[int]map[string]uint32 // prefix length -> masked IP -> ASN
+	lengths  []int                     // prefix lengths, longest first
+}
+
+var _ ASNResolver = (*ASNTable)(nil)
+
+// LoadASNTable reads an ASNTable from the file at path, see ReadASNTable.
+func LoadASNTable(path string) (*ASNTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint: errcheck
+	return ReadASNTable(f)
+}
+
+// ReadASNTable reads an ASNTable with one "prefix ASN" pair per line, e.g.
+// "192.0.2.0/24 AS64496". Empty lines and lines starting with # are ignored.
+func ReadASNTable(r io.Reader) (*ASNTable, error) {
+	t := &ASNTable{prefixes: make(map[int]map[string]uint32)}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected prefix and ASN, got %q", n, line)
+		}
+		_, ipNet, err := net.ParseCIDR(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", n, err)
+		}
+		asn, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(fields[1]), "AS"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid ASN %q", n, fields[1])
+		}
+		t.add(ipNet, uint32(asn))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *ASNTable) add(ipNet *net.IPNet, asn uint32) {
+	ones, bits := ipNet.Mask.Size()
+	if bits == 32 {
+		// Store IPv4 prefixes in their IPv6 form, like net.IP does.
+		ones += 96
+	}
+	byIP, ok := t.prefixes[ones]
+	if !ok {
+		byIP = make(map[string]uint32)
+		t.prefixes[ones] = byIP
+		t.lengths = append(t.lengths, ones)
+		sort.Sort(sort.Reverse(sort.IntSlice(t.lengths)))
+	}
+	byIP[string(ipNet.IP.To16())] = asn
+}
+
+// ASN implements ASNResolver.
+func (t *ASNTable) ASN(ip net.IP) (uint32, bool) {
+	ip = ip.To16()
+	if ip == nil {
+		return 0, false
+	}
+	for _, ones := range t.lengths {
+		masked := ip.Mask(net.CIDRMask(ones, 128))
+		if asn, ok := t.prefixes[ones][string(masked)]; ok {
+			return asn, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,42 @@
+package pex
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestASNTable(t *testing.T) {
+	table, err := ReadASNTable(strings.NewReader(`
+# prefix ASN
+192.0.2.0/24     AS64496
+192.0.0.0/16     64497
+2001:db8::/32    AS64498
+`))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		ip  string
+		asn uint32
+		ok  bool
+	}{
+		{"192.0.2.1", 64496, true},
+		{"192.0.3.1", 64497, true},
+		{"2001:db8::1", 64498, true},
+		{"198.51.100.1", 0, false},
+		{"2001:db9::1", 0, false},
+	}
+	for _, tc := range testCases {
+		asn, ok := table.ASN(net.ParseIP(tc.ip))
+		assert.Equal(t, tc.ok, ok, tc.ip)
+		assert.Equal(t, tc.asn, asn, tc.ip)
+	}
+
+	for _, bad := range []string{"192.0.2.0/24", "192.0.2.0 AS1", "192.0.2.0/24 ASx"} {
+		_, err := ReadASNTable(strings.NewReader(bad))
+		assert.Error(t, err, bad)
+	}
+}
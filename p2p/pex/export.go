@@ -0,0 +1,121 @@
+package pex
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+/* Export & Import */
+
+// AddrBookEntry is an address book entry in the format the address book is
+// exported and imported in, so operators can seed fresh nodes with a curated
+// peer list.
+type AddrBookEntry struct {
+	Address     string    `json:"address"`          // ID@host:port
+	Source      string    `json:"source,omitempty"` // who told us about it
+	Good        bool      `json:"good"`             // ever connected successfully
+	LastSuccess time.Time `json:"last_success"`
+	LastSeen    time.Time `json:"last_seen"`
+}
+
+// Export returns the entries of all addresses in the book, sorted by address.
+func (a *addrBook) Export() []AddrBookEntry {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	entries := make([]AddrBookEntry, 0, len(a.addrLookup))
+	for _, ka := range a.addrLookup {
+		entry := AddrBookEntry{
+			Address:     ka.Addr.String(),
+			Good:        ka.isOld(),
+			LastSuccess: ka.LastSuccess,
+			LastSeen:    ka.LastSeen,
+		}
+		if ka.Src != nil && ka.Src.ID != ka.Addr.ID {
+			entry.Source = ka.Src.String()
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Address < entries[j].Address })
+
+	return entries
+}
+
+// Import adds the addresses of entries to the book and marks the good ones
+// good, as if we just connected to them. Entries without a source are their
+// own source. It returns the number of addresses which weren't in the book
+// before, and an error for every entry which was skipped.
+func (a *addrBook) Import(entries []AddrBookEntry) (added int, errs []error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	for _, entry := range entries {
+		addr, err := p2p.NewNetAddressString(entry.Address)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		src := addr
+		if entry.Source != "" {
+			src, err = p2p.NewNetAddressString(entry.Source)
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "invalid source of %v", addr))
+				continue
+			}
+		}
+
+		existed := a.addrLookup[addr.ID] != nil
+		if err := a.addAddress(addr, src); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ka := a.addrLookup[addr.ID]
+		if ka == nil {
+			continue
+		}
+		if !existed {
+			added++
+		}
+
+		if entry.LastSeen.After(ka.LastSeen) {
+			ka.LastSeen = entry.LastSeen
+		}
+		if entry.Good {
+			ka.markGood()
+			if ka.isNew() {
+				a.moveToOld(ka)
+			}
+		}
+	}
+
+	return added, errs
+}
+
+// ReadAddrBookEntries reads exported address book entries from a JSON file.
+func ReadAddrBookEntries(filePath string) ([]AddrBookEntry, error) {
+	jsonBytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var entries []AddrBookEntry
+	if err := json.Unmarshal(jsonBytes, &entries); err != nil {
+		return nil, errors.Wrapf(err, "error reading address book entries from %s", filePath)
+	}
+	return entries, nil
+}
+
+// WriteAddrBookEntries writes exported address book entries to a JSON file.
+func WriteAddrBookEntries(filePath string, entries []AddrBookEntry) error {
+	jsonBytes, err := json.MarshalIndent(entries, "", "\t")
+	if err != nil {
+		return err
+	}
+	return cmn.WriteFileAtomic(filePath, jsonBytes, 0644)
+}
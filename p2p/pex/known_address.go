@@ -14,6 +14,8 @@ type knownAddress struct {
 	Attempts    int32           `json:"attempts"`
 	LastAttempt time.Time       `json:"last_attempt"`
 	LastSuccess time.Time       `json:"last_success"`
+	LastSeen    time.Time       `json:"last_seen"`
+	BadMessages int32           `json:"bad_messages"`
 	BucketType  byte            `json:"bucket_type"`
 	Buckets     []int           `json:"buckets"`
 }
@@ -40,6 +42,8 @@ func (ka *knownAddress) copy() *knownAddress {
 		Attempts:    ka.Attempts,
 		LastAttempt: ka.LastAttempt,
 		LastSuccess: ka.LastSuccess,
+		LastSeen:    ka.LastSeen,
+		BadMessages: ka.BadMessages,
 		BucketType:  ka.BucketType,
 		Buckets:     ka.Buckets,
 	}
@@ -66,6 +70,25 @@ func (ka *knownAddress) markGood() {
 	ka.LastSuccess = now
 }
 
+// markSeen records that the address was reachable, without vouching for the
+// peer behind it like markGood does.
+func (ka *knownAddress) markSeen() {
+	now := time.Now()
+	ka.LastAttempt = now
+	ka.Attempts = 0
+	ka.LastSeen = now
+}
+
+func (ka *knownAddress) markBadMessage() {
+	ka.BadMessages++
+}
+
+// isDeprioritized returns true if the peer repeatedly sent bad messages, in
+// which case its address should only be dialed if there is nothing better.
+func (ka *knownAddress) isDeprioritized() bool {
+	return ka.BadMessages >= maxBadMessages
+}
+
 func (ka *knownAddress) addBucketRef(bucketIdx int) int {
 	for _, bucket := range ka.Buckets {
 		if bucket == bucketIdx {
@@ -43,6 +43,12 @@ const (
 	// days since the last success before we will consider evicting an address.
 	minBadDays = 7
 
+	// bad messages reported about a peer before its address is deprioritized.
+	maxBadMessages = 3
+
+	// picks PickAddress makes to find an address which isn't deprioritized.
+	maxPicksToAvoidBadAddress = 3
+
 	// % of total addresses known returned by GetSelection.
 	getSelectionPercent = 23
 
@@ -0,0 +1,34 @@
+package pex
+
+import (
+	"github.com/tendermint/tendermint/p2p"
+)
+
+type addrBookPeerBehaviour struct {
+	pb   p2p.PeerBehaviour
+	book AddrBook
+}
+
+// NewAddrBookPeerBehaviour returns a PeerBehaviour which records bad messages
+// in the address book before forwarding all reports to pb. This way addresses
+// of peers which repeatedly send bad messages are deprioritized when picking
+// peers to dial, instead of only being removed from the live peer set.
+func NewAddrBookPeerBehaviour(pb p2p.PeerBehaviour, book AddrBook) p2p.PeerBehaviour {
+	return &addrBookPeerBehaviour{
+		pb:   pb,
+		book: book,
+	}
+}
+
+// Errored records bad messages in the address book and forwards the report.
+func (apb *addrBookPeerBehaviour) Errored(peer Peer, report p2p.ErrorBehaviourReport) {
+	if report.Reason == p2p.ErrorPeerBehaviourBadMessage {
+		apb.book.MarkBadMessage(peer.NodeInfo().NetAddress())
+	}
+	apb.pb.Errored(peer, report)
+}
+
+// Behaved forwards the report.
+func (apb *addrBookPeerBehaviour) Behaved(peer Peer, report p2p.GoodBehaviourReport) {
+	apb.pb.Behaved(peer, report)
+}
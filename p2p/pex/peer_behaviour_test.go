@@ -0,0 +1,40 @@
+package pex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+func TestAddrBookPeerBehaviour(t *testing.T) {
+	fname := createTempFileName("addrbook_test")
+	defer deleteTempFile(fname)
+
+	book := NewAddrBook(fname, false)
+	book.SetLogger(log.TestingLogger())
+
+	var (
+		store = p2p.NewStorePeerBehaviour()
+		pb    = NewAddrBookPeerBehaviour(store, book)
+		peer  = p2p.CreateRandomPeer(false)
+		addr  = peer.NodeInfo().NetAddress()
+	)
+	book.AddAddress(addr, addr)
+
+	for i := 0; i < maxBadMessages; i++ {
+		pb.Errored(peer, p2p.ErrorBehaviourReport{Reason: p2p.ErrorPeerBehaviourMessageOutOfOrder})
+	}
+	assert.False(t, book.addrLookup[addr.ID].isDeprioritized(), "only bad messages should count")
+
+	for i := 0; i < maxBadMessages; i++ {
+		pb.Errored(peer, p2p.ErrorBehaviourReport{Reason: p2p.ErrorPeerBehaviourBadMessage})
+	}
+	assert.True(t, book.addrLookup[addr.ID].isDeprioritized())
+
+	pb.Behaved(peer, p2p.GoodBehaviourReport{Reason: p2p.GoodPeerBehaviourVote})
+	assert.Len(t, store.GetErrored(peer.ID()), 2*maxBadMessages)
+	assert.Len(t, store.GetBehaved(peer.ID()), 1)
+}
@@ -71,7 +71,7 @@ type PEXReactor struct {
 	ensurePeersPeriod time.Duration // TODO: should go in the config
 
 	// maps to prevent abuse
-	requestsSent         *cmn.CMap // ID->struct{}: unanswered send requests
+	requestsSent         *cmn.CMap // ID->time.Time: unanswered send requests
 	lastReceivedRequests *cmn.CMap // ID->time.Time: last time peer requested from us
 
 	seedAddrs []*p2p.NetAddress
@@ -85,6 +85,15 @@ func (r *PEXReactor) minReceiveRequestInterval() time.Duration {
 	return r.ensurePeersPeriod / 3
 }
 
+// requestTimeout is how long we wait for the answer to a pexRequestMessage
+// before requesting again. The request or the answer may have been dropped
+// from a full send queue (see GetChannels).
+func (r *PEXReactor) requestTimeout() time.Duration {
+	// NOTE: must be more than minReceiveRequestInterval, otherwise the peer
+	// will think we're bad!
+	return r.ensurePeersPeriod
+}
+
 // PEXReactorConfig holds reactor specific configuration data.
 type PEXReactorConfig struct {
 	// Seed/Crawler mode
@@ -93,6 +102,20 @@ type PEXReactorConfig struct {
 	// Seeds is a list of addresses reactor may use
 	// if it can't connect to peers in the addrbook.
 	Seeds []string
+
+	// CrawlPeersPeriod is how often a seed dials known addresses to check
+	// they are still alive. Defaults to defaultCrawlPeersPeriod if 0.
+	CrawlPeersPeriod time.Duration
+
+	// CrawlPeerInterval is the minimum time between two dials of the same
+	// address during crawling. Defaults to defaultCrawlPeerInterval if 0.
+	CrawlPeerInterval time.Duration
+
+	// MaxAddressAge makes a seed not hand out the addresses it last
+	// connected to before this period. The addresses it never connected to
+	// (e.g. loaded from an addrbook without liveness) are handed out. If 0,
+	// all known addresses are handed out.
+	MaxAddressAge time.Duration
 }
 
 type _attemptsToDial struct {
@@ -151,6 +174,9 @@ func (r *PEXReactor) GetChannels() []*conn.ChannelDescriptor {
 			ID:                PexChannel,
 			Priority:          1,
 			SendQueueCapacity: 10,
+			// the dropped requests and answers are requested again, see
+			// requestTimeout
+			SendQueuePolicy: conn.SendQueueDropOldest,
 		},
 	}
 }
@@ -161,7 +187,11 @@ func (r *PEXReactor) AddPeer(p Peer) {
 	if p.IsOutbound() {
 		// For outbound peers, the address is already in the books -
 		// either via DialPeersAsync or r.Receive.
-		// Ask it for more peers if we need.
+		// Record that the address is alive and ask it for more peers if we
+		// need.
+		if addr := p.OriginalAddr(); addr != nil {
+			r.book.MarkSeen(addr)
+		}
 		if r.book.NeedMoreAddrs() {
 			r.RequestAddrs(p)
 		}
@@ -227,7 +257,7 @@ func (r *PEXReactor) Receive(chID byte, src Peer, msgBytes []byte) {
 			r.lastReceivedRequests.Set(id, time.Now())
 
 			// Send addrs and disconnect
-			r.SendAddrs(src, r.book.GetSelectionWithBias(biasToSelectNewPeers))
+			r.SendAddrs(src, r.liveAddrs(r.book.GetSelectionWithBias(biasToSelectNewPeers)))
 			go func() {
 				// In a go-routine so it doesn't block .Receive.
 				src.FlushStop()
@@ -288,14 +318,14 @@ func (r *PEXReactor) receiveRequest(src Peer) error {
 }
 
 // RequestAddrs asks peer for more addresses if we do not already
-// have a request out for this peer.
+// have a request out for this peer, which isn't timed out.
 func (r *PEXReactor) RequestAddrs(p Peer) {
 	r.Logger.Debug("Request addrs", "from", p)
 	id := string(p.ID())
-	if r.requestsSent.Has(id) {
+	if sent, ok := r.requestsSent.Get(id).(time.Time); ok && time.Since(sent) < r.requestTimeout() {
 		return
 	}
-	r.requestsSent.Set(id, struct{}{})
+	r.requestsSent.Set(id, time.Now())
 	p.Send(PexChannel, cdc.MustMarshalBinaryBare(&pexRequestMessage{}))
 }
 
@@ -325,6 +355,11 @@ func (r *PEXReactor) ReceiveAddrs(addrs []*p2p.NetAddress, src Peer) error {
 			)
 		}
 
+		// Don't learn about peers we banned.
+		if r.Switch != nil && r.Switch.IsBanned(na.ID, na.IP) {
+			continue
+		}
+
 		// NOTE: we check netAddr validity and routability in book#AddAddress.
 		err = r.book.AddAddress(na, srcAddr)
 		if err != nil {
@@ -413,7 +448,45 @@ func (r *PEXReactor) ensurePeers() {
 	// NOTE: range here is [10, 90]. Too high ?
 	newBias := cmn.MinInt(out, 8)*10 + 10
 
+	toDial := r.pickAddrsToDial(numToDial, newBias)
+
+	// Dial picked addresses
+	for _, addr := range toDial {
+		go r.dialPeer(addr)
+	}
+
+	// If we need more addresses, pick a random peer and ask for more.
+	if r.book.NeedMoreAddrs() {
+		peers := r.Switch.Peers().List()
+		peersCount := len(peers)
+		if peersCount > 0 {
+			peer := peers[cmn.RandInt()%peersCount] // nolint: gas
+			r.Logger.Info("We need more addresses. Sending pexRequest to random peer", "peer", peer)
+			r.RequestAddrs(peer)
+		}
+	}
+
+	// If we are not connected to nor dialing anybody, fallback to dialing a seed.
+	if out+in+dial+len(toDial) == 0 {
+		r.Logger.Info("No addresses to dial nor connected peers. Falling back to seeds")
+		r.dialSeeds()
+	}
+}
+
+// pickAddrsToDial picks up to numToDial addresses from the book. Addresses
+// from network groups we have no outbound peers in are preferred, so peers of
+// a single network (e.g. a hosting provider) can't take all outbound slots.
+// Others are only picked if there aren't enough of those.
+func (r *PEXReactor) pickAddrsToDial(numToDial, newBias int) map[p2p.ID]*p2p.NetAddress {
+	groups := make(map[string]struct{})
+	for _, peer := range r.Switch.Peers().List() {
+		if addr := peer.OriginalAddr(); peer.IsOutbound() && addr != nil {
+			groups[r.book.GroupKey(addr)] = struct{}{}
+		}
+	}
+
 	toDial := make(map[p2p.ID]*p2p.NetAddress)
+	var sameGroup []*p2p.NetAddress
 	// Try maxAttempts times to pick numToDial addresses to dial
 	maxAttempts := numToDial * 3
 
@@ -428,34 +501,33 @@ func (r *PEXReactor) ensurePeers() {
 		if r.Switch.IsDialingOrExistingAddress(try) {
 			continue
 		}
+		if r.Switch.IsBanned(try.ID, try.IP) {
+			continue
+		}
+		group := r.book.GroupKey(try)
+		if _, ok := groups[group]; ok {
+			sameGroup = append(sameGroup, try)
+			continue
+		}
 		// TODO: consider moving some checks from toDial into here
 		// so we don't even consider dialing peers that we want to wait
 		// before dialling again, or have dialed too many times already
-		r.Logger.Info("Will dial address", "addr", try)
+		r.Logger.Info("Will dial address", "addr", try, "group", group)
+		groups[group] = struct{}{}
 		toDial[try.ID] = try
 	}
 
-	// Dial picked addresses
-	for _, addr := range toDial {
-		go r.dialPeer(addr)
-	}
-
-	// If we need more addresses, pick a random peer and ask for more.
-	if r.book.NeedMoreAddrs() {
-		peers := r.Switch.Peers().List()
-		peersCount := len(peers)
-		if peersCount > 0 {
-			peer := peers[cmn.RandInt()%peersCount] // nolint: gas
-			r.Logger.Info("We need more addresses. Sending pexRequest to random peer", "peer", peer)
-			r.RequestAddrs(peer)
+	for _, try := range sameGroup {
+		if len(toDial) >= numToDial {
+			break
 		}
+		if _, selected := toDial[try.ID]; selected {
+			continue
+		}
+		r.Logger.Info("Will dial address", "addr", try, "group", r.book.GroupKey(try))
+		toDial[try.ID] = try
 	}
-
-	// If we are not connected to nor dialing anybody, fallback to dialing a seed.
-	if out+in+dial+len(toDial) == 0 {
-		r.Logger.Info("No addresses to dial nor connected peers. Falling back to seeds")
-		r.dialSeeds()
-	}
+	return toDial
 }
 
 func (r *PEXReactor) dialAttemptsInfo(addr *p2p.NetAddress) (attempts int, lastDialed time.Time) {
@@ -571,7 +643,12 @@ func (r *PEXReactor) crawlPeersRoutine() {
 	r.crawlPeers()
 
 	// Fire periodically
-	ticker := time.NewTicker(defaultCrawlPeersPeriod)
+	period := r.config.CrawlPeersPeriod
+	if period == 0 {
+		period = defaultCrawlPeersPeriod
+	}
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
 
 	for {
 		select {
@@ -638,11 +715,19 @@ func (r *PEXReactor) getPeersToCrawl() []crawlPeerInfo {
 func (r *PEXReactor) crawlPeers() {
 	peerInfos := r.getPeersToCrawl()
 
+	interval := r.config.CrawlPeerInterval
+	if interval == 0 {
+		interval = defaultCrawlPeerInterval
+	}
+
 	now := time.Now()
 	// Use addresses we know of to reach additional peers
 	for _, pi := range peerInfos {
 		// Do not attempt to connect with peers we recently dialed
-		if now.Sub(pi.LastAttempt) < defaultCrawlPeerInterval {
+		if now.Sub(pi.LastAttempt) < interval {
+			continue
+		}
+		if r.Switch.IsBanned(pi.Addr.ID, pi.Addr.IP) {
 			continue
 		}
 		// Otherwise, attempt to connect with the known address
@@ -659,13 +744,62 @@ func (r *PEXReactor) crawlPeers() {
 	}
 }
 
+// liveAddrs drops the addresses we last connected to before MaxAddressAge, so
+// seeds don't hand out addresses of long-dead nodes. The addresses we never
+// connected to are unknown, not dead, and are kept, so that a restarted seed
+// still hands out addresses.
+func (r *PEXReactor) liveAddrs(addrs []*p2p.NetAddress) []*p2p.NetAddress {
+	if r.config.MaxAddressAge == 0 {
+		return addrs
+	}
+	cutoff := time.Now().Add(-r.config.MaxAddressAge)
+	live := make([]*p2p.NetAddress, 0, len(addrs))
+	for _, addr := range addrs {
+		if lastSeen := r.book.LastSeen(addr); lastSeen.IsZero() || lastSeen.After(cutoff) {
+			live = append(live, addr)
+		}
+	}
+	return live
+}
+
+// KnownAddress describes an entry of the address book, along with the
+// address of the peer which told us about it.
+type KnownAddress struct {
+	Addr        *p2p.NetAddress
+	Src         *p2p.NetAddress
+	IsOld       bool
+	Attempts    int32
+	LastAttempt time.Time
+	LastSuccess time.Time
+	LastSeen    time.Time
+}
+
+// KnownAddresses returns all entries of the address book. The sources link
+// the addresses into a graph of the network as far as it is known to us.
+func (r *PEXReactor) KnownAddresses() []KnownAddress {
+	kas := r.book.ListOfKnownAddresses()
+	known := make([]KnownAddress, 0, len(kas))
+	for _, ka := range kas {
+		known = append(known, KnownAddress{
+			Addr:        ka.Addr,
+			Src:         ka.Src,
+			IsOld:       ka.isOld(),
+			Attempts:    ka.Attempts,
+			LastAttempt: ka.LastAttempt,
+			LastSuccess: ka.LastSuccess,
+			LastSeen:    ka.LastSeen,
+		})
+	}
+	return known
+}
+
 // attemptDisconnects checks if we've been with each peer long enough to disconnect
 func (r *PEXReactor) attemptDisconnects() {
 	for _, peer := range r.Switch.Peers().List() {
 		if peer.Status().Duration < defaultSeedDisconnectWaitPeriod {
 			continue
 		}
-		if peer.IsPersistent() {
+		if r.Switch.IsPeerPersistent(peer) {
 			continue
 		}
 		r.Switch.StopPeerGracefully(peer)
@@ -197,6 +197,19 @@ func TestPEXReactorAddrsMessageAbuse(t *testing.T) {
 	assert.False(t, r.requestsSent.Has(id))
 	assert.True(t, sw.Peers().Has(peer.ID()))
 
+	// a request not answered in time, e.g. dropped from a full send queue,
+	// is sent again
+	r.RequestAddrs(peer)
+	sent := r.requestsSent.Get(id).(time.Time)
+	r.RequestAddrs(peer)
+	assert.Equal(t, sent, r.requestsSent.Get(id))
+	r.requestsSent.Set(id, sent.Add(-r.requestTimeout()))
+	r.RequestAddrs(peer)
+	assert.True(t, r.requestsSent.Get(id).(time.Time).After(sent.Add(-r.requestTimeout())))
+	r.Receive(PexChannel, peer, msg)
+	assert.False(t, r.requestsSent.Has(id))
+	assert.True(t, sw.Peers().Has(peer.ID()))
+
 	// receiving more addrs causes a disconnect
 	r.Receive(PexChannel, peer, msg)
 	assert.False(t, sw.Peers().Has(peer.ID()))
@@ -316,6 +329,84 @@ func TestPEXReactorCrawlStatus(t *testing.T) {
 	// TODO: test
 }
 
+func TestPEXReactorSeedServesLiveAddrs(t *testing.T) {
+	pexR, book := createReactor(&PEXReactorConfig{SeedMode: true, MaxAddressAge: time.Hour})
+	defer teardownReactor(book)
+
+	_, live := p2p.CreateRoutableAddr()
+	_, dead := p2p.CreateRoutableAddr()
+	_, unknown := p2p.CreateRoutableAddr()
+	book.AddAddress(live, live)
+	book.AddAddress(dead, live)
+	book.AddAddress(unknown, live)
+	book.MarkSeen(live)
+	book.MarkSeen(dead)
+	book.addrLookup[dead.ID].LastSeen = time.Now().Add(-2 * time.Hour)
+
+	// The addresses never connected to, e.g. after a restart with an old
+	// addrbook, are handed out.
+	assert.Equal(t, []*p2p.NetAddress{live, unknown},
+		pexR.liveAddrs([]*p2p.NetAddress{live, dead, unknown}))
+
+	pexR.config.MaxAddressAge = 0
+	assert.Len(t, pexR.liveAddrs([]*p2p.NetAddress{live, dead, unknown}), 3)
+
+	known := pexR.KnownAddresses()
+	require.Len(t, known, 3)
+	for _, ka := range known {
+		assert.Equal(t, live, ka.Src)
+		assert.Equal(t, ka.Addr.Equals(unknown), ka.LastSeen.IsZero())
+	}
+}
+
+// outboundPeer is an outbound peer dialed at addr.
+type outboundPeer struct {
+	p2p.Peer
+	addr *p2p.NetAddress
+}
+
+func (p outboundPeer) IsOutbound() bool              { return true }
+func (p outboundPeer) OriginalAddr() *p2p.NetAddress { return p.addr }
+
+func TestPEXReactorPrefersDiverseGroups(t *testing.T) {
+	pexR, book := createReactor(&PEXReactorConfig{})
+	defer teardownReactor(book)
+	sw := createSwitchAndAddReactors(pexR)
+	sw.SetAddrBook(book)
+
+	addr := func(ip string) *p2p.NetAddress {
+		_, na := p2p.CreateRoutableAddr()
+		na.IP = net.ParseIP(ip)
+		return na
+	}
+
+	// We have an outbound peer in 8.8.0.0/16 already.
+	peerAddr := addr("8.8.8.8")
+	p2p.AddPeerToSwitch(sw, outboundPeer{p2p.CreateRandomPeer(true), peerAddr})
+
+	sameGroup := addr("8.8.4.4")
+	book.AddAddress(sameGroup, peerAddr)
+	for i := 1; i <= 20; i++ {
+		other := addr(fmt.Sprintf("1.%d.0.1", i))
+		book.AddAddress(other, other)
+	}
+
+	toDial := pexR.pickAddrsToDial(1, 30)
+	require.Len(t, toDial, 1)
+	for _, addr := range toDial {
+		assert.NotEqual(t, book.GroupKey(peerAddr), book.GroupKey(addr))
+	}
+
+	// Addresses of the same group are still dialed if there is nothing else.
+	for _, ka := range book.ListOfKnownAddresses() {
+		if ka.Addr.ID != sameGroup.ID {
+			book.RemoveAddress(ka.Addr)
+		}
+	}
+	toDial = pexR.pickAddrsToDial(1, 30)
+	assert.Equal(t, map[p2p.ID]*p2p.NetAddress{sameGroup.ID: sameGroup}, toDial)
+}
+
 // connect a peer to a seed, wait a bit, then stop it.
 // this should give it time to request addrs and for the seed
 // to call FlushStop, and allows us to test calling Stop concurrently
@@ -474,8 +565,11 @@ func (mp mockPeer) NodeInfo() p2p.NodeInfo {
 }
 func (mockPeer) RemoteIP() net.IP              { return net.ParseIP("127.0.0.1") }
 func (mockPeer) Status() conn.ConnectionStatus { return conn.ConnectionStatus{} }
+func (mockPeer) Latency() time.Duration        { return 0 }
+func (mockPeer) HasFeature(string) bool        { return false }
 func (mockPeer) Send(byte, []byte) bool        { return false }
 func (mockPeer) TrySend(byte, []byte) bool     { return false }
+func (mockPeer) SendQueueSize(byte) int        { return 0 }
 func (mockPeer) Set(string, interface{})       {}
 func (mockPeer) Get(string) interface{}        { return nil }
 func (mockPeer) OriginalAddr() *p2p.NetAddress { return nil }
@@ -0,0 +1,216 @@
+// Package portmux implements a p2p.TransportNetwork which shares its listening
+// port with an HTTP server.
+//
+// It is meant for operators who can only expose a single port, e.g. on some
+// hosting platforms: the node serves both peer connections and the RPC on the
+// p2p port. Every accepted connection is told apart by its first byte. HTTP
+// requests start with the method in upper case ASCII, and HTTPS ones with a
+// TLS handshake record, whereas both secret connection handshakes start with
+// a binary message, so no change is needed on the dialing side.
+package portmux
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+const defaultSniffTimeout = 10 * time.Second
+
+var errListenerClosed = errors.New("listener closed")
+
+// Network is a p2p.TransportNetwork which hands out the HTTP connections
+// accepted on its listening port through HTTPListener. Dialing is left to the
+// wrapped network.
+type Network struct {
+	p2p.TransportNetwork
+
+	sniffTimeout time.Duration
+
+	mtx  sync.Mutex
+	http *listener
+}
+
+var _ p2p.TransportNetwork = (*Network)(nil)
+
+// NewNetwork returns a new Network listening and dialing over network.
+func NewNetwork(network p2p.TransportNetwork) *Network {
+	return &Network{
+		TransportNetwork: network,
+		sniffTimeout:     defaultSniffTimeout,
+	}
+}
+
+// Listen implements p2p.TransportNetwork. The returned listener hands out the
+// peer connections accepted on addr, HTTPListener the HTTP ones. Closing the
+// returned listener closes both.
+func (n *Network) Listen(addr p2p.NetAddress) (net.Listener, error) {
+	ln, err := n.TransportNetwork.Listen(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		p2pLn  = newListener(ln, true)
+		httpLn = newListener(ln, false)
+	)
+
+	n.mtx.Lock()
+	n.http = httpLn
+	n.mtx.Unlock()
+
+	go n.acceptConns(ln, p2pLn, httpLn)
+
+	return p2pLn, nil
+}
+
+// HTTPListener returns the listener handing out the HTTP connections accepted
+// by the last call to Listen, or nil if Listen wasn't called yet. Closing it
+// only stops HTTP connections from being handed out.
+func (n *Network) HTTPListener() net.Listener {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	if n.http == nil {
+		return nil
+	}
+	return n.http
+}
+
+func (n *Network) acceptConns(ln net.Listener, p2pLn, httpLn *listener) {
+	defer p2pLn.close()
+	defer httpLn.close()
+
+	for {
+		c, err := ln.Accept()
+		if err != nil {
+			return
+		}
+
+		// Sniff asynchronously, so slow connections don't block the others.
+		go n.route(c, p2pLn, httpLn)
+	}
+}
+
+// route hands c out on httpLn if it starts with an HTTP request, on p2pLn
+// otherwise.
+func (n *Network) route(c net.Conn, p2pLn, httpLn *listener) {
+	first, err := n.sniff(c)
+	if err != nil {
+		_ = c.Close()
+		return
+	}
+
+	c = &prefixConn{Conn: c, prefix: []byte{first}}
+	if isHTTP(first) {
+		httpLn.handOut(c)
+	} else {
+		p2pLn.handOut(c)
+	}
+}
+
+// sniff reads the first byte of c.
+func (n *Network) sniff(c net.Conn) (byte, error) {
+	if err := c.SetReadDeadline(time.Now().Add(n.sniffTimeout)); err != nil {
+		return 0, err
+	}
+
+	var b [1]byte
+	if _, err := io.ReadFull(c, b[:]); err != nil {
+		return 0, err
+	}
+
+	return b[0], c.SetReadDeadline(time.Time{})
+}
+
+// tlsHandshake is the first byte of a TLS connection, the content type of
+// its first record.
+const tlsHandshake = 0x16
+
+// isHTTP returns true if b may be the first byte of an HTTP request, or of an
+// HTTPS one.
+func isHTTP(b byte) bool {
+	return 'A' <= b && b <= 'Z' || b == tlsHandshake
+}
+
+//-----------------------------------------------------------------------------
+
+// prefixConn replays the sniffed bytes before reading from the connection.
+type prefixConn struct {
+	net.Conn
+	prefix []byte
+}
+
+// Read implements net.Conn.
+func (c *prefixConn) Read(b []byte) (int, error) {
+	if len(c.prefix) > 0 {
+		n := copy(b, c.prefix)
+		c.prefix = c.prefix[n:]
+		return n, nil
+	}
+	return c.Conn.Read(b)
+}
+
+//-----------------------------------------------------------------------------
+
+// listener is a net.Listener handing out one kind of the connections accepted
+// by the shared listener.
+type listener struct {
+	ln        net.Listener
+	ownsLn    bool // whether closing the listener closes ln
+	connc     chan net.Conn
+	closec    chan struct{}
+	closeOnce sync.Once
+}
+
+var _ net.Listener = (*listener)(nil)
+
+func newListener(ln net.Listener, ownsLn bool) *listener {
+	return &listener{
+		ln:     ln,
+		ownsLn: ownsLn,
+		connc:  make(chan net.Conn),
+		closec: make(chan struct{}),
+	}
+}
+
+// Accept implements net.Listener.
+func (l *listener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.connc:
+		return c, nil
+	case <-l.closec:
+		return nil, errListenerClosed
+	}
+}
+
+// Close implements net.Listener.
+func (l *listener) Close() error {
+	l.close()
+	if l.ownsLn {
+		return l.ln.Close()
+	}
+	return nil
+}
+
+// Addr implements net.Listener.
+func (l *listener) Addr() net.Addr {
+	return l.ln.Addr()
+}
+
+func (l *listener) close() {
+	l.closeOnce.Do(func() { close(l.closec) })
+}
+
+// handOut blocks until c is accepted, or closes it if the listener is closed.
+func (l *listener) handOut(c net.Conn) {
+	select {
+	case l.connc <- c:
+	case <-l.closec:
+		_ = c.Close()
+	}
+}
@@ -0,0 +1,88 @@
+package portmux
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+func TestNetwork(t *testing.T) {
+	p2p.RunTransportNetworkTests(t, NewNetwork(p2p.TCPNetwork{}))
+}
+
+func TestNetworkSharesPort(t *testing.T) {
+	n := NewNetwork(p2p.TCPNetwork{})
+	ln, addr := p2p.ListenTransportNetwork(t, n)
+	defer ln.Close()
+
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("rpc"))
+	})}
+	go func() { _ = srv.Serve(n.HTTPListener()) }()
+	defer srv.Close()
+
+	go func() {
+		c, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		_, _ = io.Copy(c, c)
+	}()
+
+	// The connections which don't start like HTTP go to the P2P listener,
+	// with their first byte.
+	c, err := n.Dial(*addr, time.Second)
+	require.NoError(t, err)
+	defer c.Close()
+	_, err = c.Write([]byte{0x00, 'p', 'e', 'e', 'r'})
+	require.NoError(t, err)
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(c, buf)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 'p', 'e', 'e', 'r'}, buf)
+
+	res, err := http.Get("http://" + addr.DialString())
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "rpc", string(body))
+}
+
+func TestNetworkHTTPListenerClose(t *testing.T) {
+	n := NewNetwork(p2p.TCPNetwork{})
+	ln, _ := p2p.ListenTransportNetwork(t, n)
+
+	errc := make(chan error)
+	go func() {
+		_, err := n.HTTPListener().Accept()
+		errc <- err
+	}()
+
+	require.NoError(t, ln.Close())
+
+	select {
+	case err := <-errc:
+		assert.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Accept didn't return after Close")
+	}
+}
+
+func TestIsHTTP(t *testing.T) {
+	for _, method := range []string{"GET", "POST", "HEAD", "OPTIONS", "PUT"} {
+		assert.True(t, isHTTP(method[0]), method)
+	}
+	assert.True(t, isHTTP(tlsHandshake))
+	// First bytes of the STS and Noise handshakes.
+	assert.False(t, isHTTP(0x21))
+	assert.False(t, isHTTP(0x00))
+}
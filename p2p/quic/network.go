@@ -0,0 +1,189 @@
+// +build quic
+
+// Package quic implements a p2p.TransportNetwork on top of QUIC.
+//
+// Every connection is a QUIC session carrying a single bidirectional stream,
+// over which the MultiplexTransport performs the usual authenticated
+// handshake and runs the MConnection. TLS is only used because QUIC requires
+// it: certificates are ephemeral and never verified, peers are authenticated
+// by the SecretConnection established on top of the stream.
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	quicgo "github.com/lucas-clemente/quic-go"
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+const (
+	// nextProto is the ALPN protocol negotiated by both sides.
+	nextProto = "tendermint-p2p"
+
+	defaultHandshakeTimeout = 10 * time.Second
+	defaultIdleTimeout      = 30 * time.Second
+)
+
+// Network is a p2p.TransportNetwork over QUIC.
+type Network struct {
+	tlsConfig  *tls.Config
+	quicConfig *quicgo.Config
+}
+
+var _ p2p.TransportNetwork = (*Network)(nil)
+
+// NewNetwork returns a Network with a freshly generated, self-signed TLS
+// certificate.
+func NewNetwork() (*Network, error) {
+	cert, err := p2p.SelfSignedCertificate()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate TLS certificate")
+	}
+	return &Network{
+		tlsConfig: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			NextProtos:   []string{nextProto},
+			// The remote node is authenticated by the SecretConnection.
+			InsecureSkipVerify: true,
+		},
+		quicConfig: &quicgo.Config{
+			HandshakeTimeout: defaultHandshakeTimeout,
+			IdleTimeout:      defaultIdleTimeout,
+			KeepAlive:        true,
+		},
+	}, nil
+}
+
+// Listen implements p2p.TransportNetwork.
+func (n *Network) Listen(addr p2p.NetAddress) (net.Listener, error) {
+	ln, err := quicgo.ListenAddr(addr.DialString(), n.tlsConfig, n.quicConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &listener{
+		ln:               ln,
+		handshakeTimeout: n.quicConfig.HandshakeTimeout,
+		connc:            make(chan net.Conn),
+		closec:           make(chan struct{}),
+	}
+	go l.acceptRoutine()
+
+	return l, nil
+}
+
+// Dial implements p2p.TransportNetwork.
+func (n *Network) Dial(addr p2p.NetAddress, timeout time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	sess, err := quicgo.DialAddrContext(ctx, addr.DialString(), n.tlsConfig, n.quicConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := sess.OpenStreamSync()
+	if err != nil {
+		_ = sess.Close()
+		return nil, err
+	}
+
+	return &streamConn{Stream: stream, sess: sess}, nil
+}
+
+//-----------------------------------------------------------------------------
+
+// streamConn is a net.Conn backed by a stream of a QUIC session.
+type streamConn struct {
+	quicgo.Stream
+	sess quicgo.Session
+}
+
+var _ net.Conn = (*streamConn)(nil)
+
+// LocalAddr implements net.Conn.
+func (c *streamConn) LocalAddr() net.Addr { return c.sess.LocalAddr() }
+
+// RemoteAddr implements net.Conn.
+func (c *streamConn) RemoteAddr() net.Addr { return c.sess.RemoteAddr() }
+
+// Close implements net.Conn by closing the whole session.
+func (c *streamConn) Close() error { return c.sess.Close() }
+
+//-----------------------------------------------------------------------------
+
+// listener is a net.Listener which accepts QUIC sessions and hands out their
+// first stream.
+type listener struct {
+	ln               quicgo.Listener
+	handshakeTimeout time.Duration
+
+	connc  chan net.Conn
+	closec chan struct{}
+
+	mtx sync.Mutex
+	err error
+}
+
+var _ net.Listener = (*listener)(nil)
+
+// Accept implements net.Listener.
+func (l *listener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.connc:
+		return c, nil
+	case <-l.closec:
+		l.mtx.Lock()
+		defer l.mtx.Unlock()
+		return nil, l.err
+	}
+}
+
+// Close implements net.Listener.
+func (l *listener) Close() error {
+	return l.ln.Close()
+}
+
+// Addr implements net.Listener.
+func (l *listener) Addr() net.Addr {
+	return l.ln.Addr()
+}
+
+func (l *listener) acceptRoutine() {
+	for {
+		sess, err := l.ln.Accept()
+		if err != nil {
+			l.mtx.Lock()
+			l.err = err
+			l.mtx.Unlock()
+			close(l.closec)
+			return
+		}
+
+		// Waiting for the first stream is done asynchronously, so a slow or
+		// malicious remote can't hold up other sessions.
+		go l.acceptStream(sess)
+	}
+}
+
+func (l *listener) acceptStream(sess quicgo.Session) {
+	timer := time.AfterFunc(l.handshakeTimeout, func() { _ = sess.Close() })
+
+	stream, err := sess.AcceptStream()
+	if !timer.Stop() || err != nil {
+		_ = sess.Close()
+		return
+	}
+
+	select {
+	case l.connc <- &streamConn{Stream: stream, sess: sess}:
+	case <-l.closec:
+		_ = sess.Close()
+	}
+}
@@ -0,0 +1,17 @@
+// +build quic
+
+package quic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+func TestNetwork(t *testing.T) {
+	n, err := NewNetwork()
+	require.NoError(t, err)
+	p2p.RunTransportNetworkTests(t, n)
+}
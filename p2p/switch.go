@@ -1,30 +1,22 @@
 package p2p
 
 import (
+	"context"
 	"fmt"
-	"math"
+	"net"
 	"sync"
 	"time"
 
 	"github.com/tendermint/tendermint/config"
 	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/events"
 	"github.com/tendermint/tendermint/p2p/conn"
 )
 
 const (
 	// wait a random amount of time from this interval
-	// before dialing peers or reconnecting to help prevent DoS
+	// before dialing peers to help prevent DoS
 	dialRandomizerIntervalMilliseconds = 3000
-
-	// repeatedly try to reconnect for a few minutes
-	// ie. 5 * 20 = 100s
-	reconnectAttempts = 20
-	reconnectInterval = 5 * time.Second
-
-	// then move into exponential backoff mode for ~1day
-	// ie. 3**10 = 16hrs
-	reconnectBackOffAttempts    = 10
-	reconnectBackOffBaseSeconds = 3
 )
 
 // MConnConfig returns an MConnConfig with fields updated
@@ -35,9 +27,26 @@ func MConnConfig(cfg *config.P2PConfig) conn.MConnConfig {
 	mConfig.SendRate = cfg.SendRate
 	mConfig.RecvRate = cfg.RecvRate
 	mConfig.MaxPacketMsgPayloadSize = cfg.MaxPacketMsgPayloadSize
+	mConfig.ChannelRecvMessageRate = cfg.ChannelRecvMessageRate
+	mConfig.ChannelRecvRate = cfg.ChannelRecvRate
+	// Validated by ValidateBasic.
+	mConfig.ChannelPriorities, _ = cfg.ChannelPriorityOverrides()
+	mConfig.ChannelSendBursts, _ = cfg.ChannelSendBurstOverrides()
+	mConfig.BandwidthCap = cfg.PeerBandwidthCap
+	mConfig.CappedRate = cfg.PeerCappedRate
 	return mConfig
 }
 
+// DialBackoffConfig returns a DialBackoff with fields updated from the
+// P2PConfig.
+func DialBackoffConfig(cfg *config.P2PConfig) DialBackoff {
+	backoff := DefaultDialBackoff()
+	backoff.Base = cfg.ReconnectBackoffBase
+	backoff.Max = cfg.ReconnectBackoffMax
+	backoff.MaxAttempts = cfg.ReconnectMaxAttempts
+	return backoff
+}
+
 //-----------------------------------------------------------------------------
 
 // An AddrBook represents an address book from the pex package, which is used
@@ -46,16 +55,30 @@ type AddrBook interface {
 	AddAddress(addr *NetAddress, src *NetAddress) error
 	AddOurAddress(*NetAddress)
 	OurAddress(*NetAddress) bool
+	AddPrivateIDs([]string)
+	RemovePrivateIDs([]string)
 	MarkGood(*NetAddress)
 	RemoveAddress(*NetAddress)
 	HasAddress(*NetAddress) bool
 	Save()
 }
 
+// PeerFilter is implemented by filter hooks which may reject a new inbound or
+// outbound Peer after it has been fully setup, e.g. based on its NodeInfo, IP
+// or application specific policy.
+type PeerFilter interface {
+	FilterPeer(IPeerSet, Peer) error
+}
+
 // PeerFilterFunc to be implemented by filter hooks after a new Peer has been
 // fully setup.
 type PeerFilterFunc func(IPeerSet, Peer) error
 
+// FilterPeer implements PeerFilter.
+func (f PeerFilterFunc) FilterPeer(ps IPeerSet, p Peer) error {
+	return f(ps, p)
+}
+
 //-----------------------------------------------------------------------------
 
 // Switch handles peer connections and exposes an API to receive incoming messages
@@ -70,16 +93,45 @@ type Switch struct {
 	chDescs      []*conn.ChannelDescriptor
 	reactorsByCh map[byte]Reactor
 	peers        *PeerSet
+	addedAt      *cmn.CMap // ID -> time.Time: when the peer was added
 	dialing      *cmn.CMap
-	reconnecting *cmn.CMap
 	nodeInfo     NodeInfo // our node info
 	nodeKey      *NodeKey // our node privkey
 	addrBook     AddrBook
 
+	// peerBehaviour receives the peer behaviour reported by reactors, through
+	// eventPeerBehaviour which fires EventPeerErrored first.
+	peerBehaviour      PeerBehaviour
+	eventPeerBehaviour PeerBehaviour
+
+	// peerEvents fires the peer lifecycle events, see SubscribePeerEvent.
+	peerEvents events.EventSwitch
+
+	// banList is consulted before dialing and accepting peers. Can be nil.
+	banList *BanList
+
+	// dialScheduler redials persistent peers.
+	dialScheduler *DialScheduler
+
+	// Persistent peers and private peer IDs, which can be changed at
+	// runtime. See IsPeerPersistent.
+	persistentPeers        *cmn.CMap // ID -> *NetAddress
+	removedPersistentPeers *cmn.CMap // ID -> struct{}
+	privatePeerIDs         *cmn.CMap // ID -> struct{}
+
+	// Configured tiers and runtime tier hints, see PeerTier.
+	peerTiers     map[ID]PeerTier
+	peerTierHints *peerTierHints
+
 	transport Transport
 
-	filterTimeout time.Duration
-	peerFilters   []PeerFilterFunc
+	// dialCtx is cancelled by OnStop, which aborts all ongoing dials.
+	dialCtx     context.Context
+	cancelDials context.CancelFunc
+
+	filterTimeout  time.Duration
+	peerFiltersMtx sync.RWMutex
+	peerFilters    []PeerFilter
 
 	rng *cmn.Rand // seed for randomizing dial times and orders
 
@@ -96,21 +148,33 @@ func NewSwitch(
 	options ...SwitchOption,
 ) *Switch {
 	sw := &Switch{
-		config:        cfg,
-		reactors:      make(map[string]Reactor),
-		chDescs:       make([]*conn.ChannelDescriptor, 0),
-		reactorsByCh:  make(map[byte]Reactor),
-		peers:         NewPeerSet(),
-		dialing:       cmn.NewCMap(),
-		reconnecting:  cmn.NewCMap(),
-		metrics:       NopMetrics(),
-		transport:     transport,
-		filterTimeout: defaultFilterTimeout,
+		config:                 cfg,
+		reactors:               make(map[string]Reactor),
+		chDescs:                make([]*conn.ChannelDescriptor, 0),
+		reactorsByCh:           make(map[byte]Reactor),
+		peers:                  NewPeerSet(),
+		addedAt:                cmn.NewCMap(),
+		dialing:                cmn.NewCMap(),
+		persistentPeers:        cmn.NewCMap(),
+		removedPersistentPeers: cmn.NewCMap(),
+		privatePeerIDs:         cmn.NewCMap(),
+		peerTiers:              make(map[ID]PeerTier),
+		peerTierHints:          newPeerTierHints(maxPeerTierHints),
+		metrics:                NopMetrics(),
+		transport:              transport,
+		filterTimeout:          defaultFilterTimeout,
 	}
 
 	// Ensure we have a completely undeterministic PRNG.
 	sw.rng = cmn.NewRand()
 
+	sw.dialCtx, sw.cancelDials = context.WithCancel(context.Background())
+	sw.dialScheduler = NewDialScheduler(DialBackoffConfig(cfg), sw.redialPeer)
+
+	sw.peerBehaviour = NewSwitchPeerBehaviour(sw)
+	sw.eventPeerBehaviour = &eventPeerBehaviour{sw: sw}
+	sw.peerEvents = events.NewEventSwitch()
+
 	sw.BaseService = *cmn.NewBaseService(nil, "P2P Switch", sw)
 
 	for _, option := range options {
@@ -127,7 +191,18 @@ func SwitchFilterTimeout(timeout time.Duration) SwitchOption {
 
 // SwitchPeerFilters sets the filters for rejection of new peers.
 func SwitchPeerFilters(filters ...PeerFilterFunc) SwitchOption {
-	return func(sw *Switch) { sw.peerFilters = filters }
+	return func(sw *Switch) {
+		sw.peerFilters = nil
+		for _, f := range filters {
+			sw.peerFilters = append(sw.peerFilters, f)
+		}
+	}
+}
+
+// SwitchBanList sets the BanList used to refuse connections to and from
+// banned peers.
+func SwitchBanList(banList *BanList) SwitchOption {
+	return func(sw *Switch) { sw.banList = banList }
 }
 
 // WithMetrics sets the metrics.
@@ -153,6 +228,7 @@ func (sw *Switch) AddReactor(name string, reactor Reactor) Reactor {
 		sw.reactorsByCh[chID] = reactor
 	}
 	sw.reactors[name] = reactor
+	sw.subscribeReactor(name, reactor)
 	reactor.SetSwitch(sw)
 	return reactor
 }
@@ -200,6 +276,11 @@ func (sw *Switch) OnStart() error {
 		}
 	}
 
+	sw.dialScheduler.SetLogger(sw.Logger)
+	if err := sw.dialScheduler.Start(); err != nil {
+		return cmn.ErrorWrap(err, "failed to start dial scheduler")
+	}
+
 	// Start accepting Peers.
 	go sw.acceptRoutine()
 
@@ -208,6 +289,10 @@ func (sw *Switch) OnStart() error {
 
 // OnStop implements BaseService. It stops all peers and reactors.
 func (sw *Switch) OnStop() {
+	// Abort dials, so they don't add peers while stopping.
+	sw.dialScheduler.Stop()
+	sw.cancelDials()
+
 	// Stop peers
 	for _, p := range sw.peers.List() {
 		sw.transport.Cleanup(p)
@@ -282,15 +367,10 @@ func (sw *Switch) Peers() IPeerSet {
 func (sw *Switch) StopPeerForError(peer Peer, reason interface{}) {
 	sw.Logger.Error("Stopping peer for error", "peer", peer, "err", reason)
 	sw.stopAndRemovePeer(peer, reason)
+	sw.peerTierHints.remove(peer.ID())
 
-	if peer.IsPersistent() {
-		addr := peer.OriginalAddr()
-		if addr == nil {
-			// FIXME: persistent peers can't be inbound right now.
-			// self-reported address for inbound persistent peers
-			addr = peer.NodeInfo().NetAddress()
-		}
-		go sw.reconnectToPeer(addr)
+	if sw.IsPeerPersistent(peer) {
+		sw.scheduleRedial(sw.persistentPeerAddr(peer))
 	}
 }
 
@@ -303,75 +383,107 @@ func (sw *Switch) StopPeerGracefully(peer Peer) {
 
 func (sw *Switch) stopAndRemovePeer(peer Peer, reason interface{}) {
 	if sw.peers.Remove(peer) {
+		sw.addedAt.Delete(string(peer.ID()))
 		sw.metrics.Peers.Add(float64(-1))
 	}
 	sw.transport.Cleanup(peer)
 	peer.Stop()
-	for _, reactor := range sw.reactors {
-		reactor.RemovePeer(peer, reason)
-	}
+	sw.peerEvents.FireEvent(EventPeerDisconnected, EventDataPeerDisconnected{Peer: peer, Reason: reason})
 }
 
-// reconnectToPeer tries to reconnect to the addr, first repeatedly
-// with a fixed interval, then with exponential backoff.
-// If no success after all that, it stops trying, and leaves it
-// to the PEX/Addrbook to find the peer with the addr again
-// NOTE: this will keep trying even if the handshake or auth fails.
-// TODO: be more explicit with error types so we only retry on certain failures
-//  - ie. if we're getting ErrDuplicatePeer we can stop
-//  	because the addrbook got us the peer back already
-func (sw *Switch) reconnectToPeer(addr *NetAddress) {
-	if sw.reconnecting.Has(string(addr.ID)) {
+// scheduleRedial has the DialScheduler redial the persistent peer at addr,
+// unless it was removed from the persistent peers.
+func (sw *Switch) scheduleRedial(addr *NetAddress) {
+	if sw.removedPersistentPeers.Has(string(addr.ID)) {
 		return
 	}
-	sw.reconnecting.Set(string(addr.ID), addr)
-	defer sw.reconnecting.Delete(string(addr.ID))
+	sw.dialScheduler.Schedule(addr)
+}
 
-	start := time.Now()
-	sw.Logger.Info("Reconnecting to peer", "addr", addr)
-	for i := 0; i < reconnectAttempts; i++ {
-		if !sw.IsRunning() {
-			return
-		}
+// redialPeer is the dial func of the DialScheduler. Peers we are connected
+// to already, e.g. because they dialed us, count as redialed.
+func (sw *Switch) redialPeer(addr *NetAddress) error {
+	if sw.peers.Has(addr.ID) {
+		return nil
+	}
+	if sw.IsDialingOrExistingAddress(addr) {
+		return ErrCurrentlyDialingOrExistingAddress{Addr: addr}
+	}
+	err := sw.DialPeerWithAddress(addr, true)
+	if _, ok := err.(ErrSwitchDuplicatePeerID); ok {
+		return nil
+	}
+	return err
+}
 
-		if sw.IsDialingOrExistingAddress(addr) {
-			sw.Logger.Debug("Peer connection has been established or dialed while we waiting next try", "addr", addr)
-			return
-		}
+// DialStates returns the reconnect states of the persistent peers which
+// were redialed, sorted by ID.
+func (sw *Switch) DialStates() []DialState {
+	return sw.dialScheduler.States()
+}
 
-		err := sw.DialPeerWithAddress(addr, true)
-		if err == nil {
-			return // success
-		}
+// SetAddrBook allows to set address book on Switch.
+func (sw *Switch) SetAddrBook(addrBook AddrBook) {
+	sw.addrBook = addrBook
+}
 
-		sw.Logger.Info("Error reconnecting to peer. Trying again", "tries", i, "err", err, "addr", addr)
-		// sleep a set amount
-		sw.randomSleep(reconnectInterval)
-		continue
-	}
+// SetPeerBehaviour sets the PeerBehaviour handed out to reactors. Defaults to
+// one reporting directly to the Switch.
+// NOTE: Not goroutine safe.
+func (sw *Switch) SetPeerBehaviour(pb PeerBehaviour) {
+	sw.peerBehaviour = pb
+}
 
-	sw.Logger.Error("Failed to reconnect to peer. Beginning exponential backoff",
-		"addr", addr, "elapsed", time.Since(start))
-	for i := 0; i < reconnectBackOffAttempts; i++ {
-		if !sw.IsRunning() {
-			return
-		}
+// PeerBehaviour returns the PeerBehaviour reactors should report peer
+// behaviour to. Reported misbehaviours fire EventPeerErrored.
+func (sw *Switch) PeerBehaviour() PeerBehaviour {
+	return sw.eventPeerBehaviour
+}
 
-		// sleep an exponentially increasing amount
-		sleepIntervalSeconds := math.Pow(reconnectBackOffBaseSeconds, float64(i))
-		sw.randomSleep(time.Duration(sleepIntervalSeconds) * time.Second)
-		err := sw.DialPeerWithAddress(addr, true)
-		if err == nil {
-			return // success
+// reportRateLimitExceeded reports a peer which exceeded the receive rate limit
+// of a channel to the PeerBehaviour.
+func (sw *Switch) reportRateLimitExceeded(peer Peer, err conn.ErrRateLimitExceeded) {
+	var reactorName string
+	for name, reactor := range sw.reactors {
+		if reactor == sw.reactorsByCh[err.ChannelID] {
+			reactorName = name
+			break
 		}
-		sw.Logger.Info("Error reconnecting to peer. Trying again", "tries", i, "err", err, "addr", addr)
 	}
-	sw.Logger.Error("Failed to reconnect to peer. Giving up", "addr", addr, "elapsed", time.Since(start))
+	sw.eventPeerBehaviour.Errored(peer, ErrorBehaviourReport{
+		Reason:  ErrorPeerBehaviourRateLimitExceeded,
+		Reactor: reactorName,
+		Detail:  err.Error(),
+	})
+}
+
+// IsGreylisted returns true if the peer is still on probation, meaning it was
+// added less than GreylistPeriod ago. Persistent peers are never greylisted.
+func (sw *Switch) IsGreylisted(peer Peer) bool {
+	if sw.config.GreylistPeriod <= 0 || sw.IsPeerPersistent(peer) {
+		return false
+	}
+	addedAt, ok := sw.addedAt.Get(string(peer.ID())).(time.Time)
+	return ok && time.Since(addedAt) < sw.config.GreylistPeriod
 }
 
-// SetAddrBook allows to set address book on Switch.
-func (sw *Switch) SetAddrBook(addrBook AddrBook) {
-	sw.addrBook = addrBook
+// IsBanned returns true if the ID or the IP is on the ban list of the
+// Switch, unless the ID is exempt from bans. ip may be nil.
+func (sw *Switch) IsBanned(id ID, ip net.IP) bool {
+	return sw.banList != nil && !sw.isBanExemptID(id) && sw.banList.IsBanned(id, ip)
+}
+
+// IsBanExempt returns true if the peer must never be banned, because the
+// operator configured it: persistent, private and validator peers.
+func (sw *Switch) IsBanExempt(peer Peer) bool {
+	return sw.IsPeerPersistent(peer) || sw.isBanExemptID(peer.ID())
+}
+
+func (sw *Switch) isBanExemptID(id ID) bool {
+	if sw.persistentPeers.Has(string(id)) || sw.privatePeerIDs.Has(string(id)) {
+		return true
+	}
+	return sw.peerTiers[id] >= PeerTierPersistent
 }
 
 // MarkPeerAsGood marks the given peer as good when it did something useful
@@ -395,6 +507,10 @@ func (sw *Switch) DialPeersAsync(addrBook AddrBook, peers []string, persistent b
 		sw.Logger.Error("Error in peer's address", "err", err)
 	}
 
+	if persistent {
+		sw.registerPersistentPeers(netAddrs)
+	}
+
 	ourAddr := sw.nodeInfo.NetAddress()
 
 	// TODO: this code feels like it's in the wrong place.
@@ -449,6 +565,26 @@ func (sw *Switch) DialPeersAsync(addrBook AddrBook, peers []string, persistent b
 	return nil
 }
 
+// DialPeer dials the given peer like DialPeersAsync, but waits for the dial
+// to complete and returns the reason if the peer couldn't be added.
+// If `persistent == true`, the peer is also redialed if this dial fails. The
+// address is only made persistent once it passed validation.
+func (sw *Switch) DialPeer(addr *NetAddress, persistent bool) error {
+	if !addr.Valid() {
+		return ErrNetAddressInvalid{addr.String(), fmt.Errorf("invalid IP or ID")}
+	}
+	if addr.Same(sw.nodeInfo.NetAddress()) {
+		return ErrSwitchConnectToSelf{addr}
+	}
+	if sw.IsDialingOrExistingAddress(addr) {
+		return ErrCurrentlyDialingOrExistingAddress{addr}
+	}
+	if persistent {
+		sw.registerPersistentPeers([]*NetAddress{addr})
+	}
+	return sw.DialPeerWithAddress(addr, persistent)
+}
+
 // DialPeerWithAddress dials the given peer and runs sw.addPeer if it connects and authenticates successfully.
 // If `persistent == true`, the switch will always try to reconnect to this peer if the connection ever fails.
 func (sw *Switch) DialPeerWithAddress(addr *NetAddress, persistent bool) error {
@@ -474,10 +610,11 @@ func (sw *Switch) IsDialingOrExistingAddress(addr *NetAddress) bool {
 func (sw *Switch) acceptRoutine() {
 	for {
 		p, err := sw.transport.Accept(peerConfig{
-			chDescs:      sw.chDescs,
-			onPeerError:  sw.StopPeerForError,
-			reactorsByCh: sw.reactorsByCh,
-			metrics:      sw.metrics,
+			chDescs:             sw.chDescs,
+			onPeerError:         sw.StopPeerForError,
+			onRateLimitExceeded: sw.reportRateLimitExceeded,
+			reactorsByCh:        sw.reactorsByCh,
+			metrics:             sw.metrics,
 		})
 		if err != nil {
 			switch err := err.(type) {
@@ -526,14 +663,12 @@ func (sw *Switch) acceptRoutine() {
 			break
 		}
 
-		// Ignore connection if we already have enough peers.
-		_, in, _ := sw.NumPeers()
-		if in >= sw.config.MaxNumInboundPeers {
+		// Ignore connection if we already have enough peers of its tier.
+		if err := sw.acquireInboundSlot(p); err != nil {
 			sw.Logger.Info(
 				"Ignoring inbound connection: already have enough inbound peers",
 				"address", p.NodeInfo().NetAddress().String(),
-				"have", in,
-				"max", sw.config.MaxNumInboundPeers,
+				"err", err,
 			)
 
 			sw.transport.Cleanup(p)
@@ -557,28 +692,33 @@ func (sw *Switch) acceptRoutine() {
 
 // dial the peer; make secret connection; authenticate against the dialed ID;
 // add the peer.
-// if dialing fails, start the reconnect loop. If handhsake fails, its over.
-// If peer is started succesffuly, reconnectLoop will start when
-// StopPeerForError is called
+// if dialing a persistent peer fails, it's scheduled for a redial. If
+// handshake fails, its over. If the peer is started successfully, it's
+// rescheduled when StopPeerForError is called
 func (sw *Switch) addOutboundPeerWithConfig(
 	addr *NetAddress,
 	cfg *config.P2PConfig,
 	persistent bool,
 ) error {
+	if sw.IsBanned(addr.ID, addr.IP) {
+		return ErrSwitchBannedPeer{ID: addr.ID, IP: addr.IP}
+	}
+
 	sw.Logger.Info("Dialing peer", "address", addr)
 
 	// XXX(xla): Remove the leakage of test concerns in implementation.
 	if cfg.TestDialFail {
-		go sw.reconnectToPeer(addr)
+		sw.scheduleRedial(addr)
 		return fmt.Errorf("dial err (peerConfig.DialFail == true)")
 	}
 
-	p, err := sw.transport.Dial(*addr, peerConfig{
-		chDescs:      sw.chDescs,
-		onPeerError:  sw.StopPeerForError,
-		persistent:   persistent,
-		reactorsByCh: sw.reactorsByCh,
-		metrics:      sw.metrics,
+	p, err := sw.transport.DialContext(sw.dialCtx, *addr, peerConfig{
+		chDescs:             sw.chDescs,
+		onPeerError:         sw.StopPeerForError,
+		onRateLimitExceeded: sw.reportRateLimitExceeded,
+		persistent:          persistent,
+		reactorsByCh:        sw.reactorsByCh,
+		metrics:             sw.metrics,
 	})
 	if err != nil {
 		switch e := err.(type) {
@@ -596,7 +736,7 @@ func (sw *Switch) addOutboundPeerWithConfig(
 		// retry persistent peers after
 		// any dial error besides IsSelf()
 		if persistent {
-			go sw.reconnectToPeer(addr)
+			sw.scheduleRedial(addr)
 		}
 
 		return err
@@ -613,17 +753,34 @@ func (sw *Switch) addOutboundPeerWithConfig(
 	return nil
 }
 
+// AddPeerFilter adds filters for rejection of new peers, in addition to the
+// ones set with SwitchPeerFilters. It can be called at any time, the filters
+// apply to all peers added afterwards.
+func (sw *Switch) AddPeerFilter(filters ...PeerFilter) {
+	sw.peerFiltersMtx.Lock()
+	defer sw.peerFiltersMtx.Unlock()
+	sw.peerFilters = append(sw.peerFilters, filters...)
+}
+
 func (sw *Switch) filterPeer(p Peer) error {
+	if sw.banList != nil && !sw.IsBanExempt(p) && sw.banList.IsBanned(p.ID(), p.RemoteIP()) {
+		return ErrRejected{id: p.ID(), isBanned: true}
+	}
+
 	// Avoid duplicate
 	if sw.peers.Has(p.ID()) {
 		return ErrRejected{id: p.ID(), isDuplicate: true}
 	}
 
-	errc := make(chan error, len(sw.peerFilters))
+	sw.peerFiltersMtx.RLock()
+	filters := sw.peerFilters
+	sw.peerFiltersMtx.RUnlock()
+
+	errc := make(chan error, len(filters))
 
-	for _, f := range sw.peerFilters {
-		go func(f PeerFilterFunc, p Peer, errc chan<- error) {
-			errc <- f(sw.peers, p)
+	for _, f := range filters {
+		go func(f PeerFilter, p Peer, errc chan<- error) {
+			errc <- f.FilterPeer(sw.peers, p)
 		}(f, p, errc)
 	}
 
@@ -674,12 +831,11 @@ func (sw *Switch) addPeer(p Peer) error {
 	if err := sw.peers.Add(p); err != nil {
 		return err
 	}
+	sw.addedAt.Set(string(p.ID()), time.Now())
 	sw.metrics.Peers.Add(float64(1))
 
 	// Start all the reactor protocols on the peer.
-	for _, reactor := range sw.reactors {
-		reactor.AddPeer(p)
-	}
+	sw.peerEvents.FireEvent(EventPeerConnected, EventDataPeerConnected{Peer: p})
 
 	sw.Logger.Info("Added peer", "peer", p)
 
@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -23,6 +24,7 @@ import (
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/p2p/conn"
+	"github.com/tendermint/tendermint/version"
 )
 
 var (
@@ -232,6 +234,179 @@ func TestSwitchPeerFilter(t *testing.T) {
 	}
 }
 
+// appVersionFilter rejects peers with a different app version.
+type appVersionFilter struct {
+	app version.Protocol
+}
+
+func (f appVersionFilter) FilterPeer(_ IPeerSet, p Peer) error {
+	if app := p.NodeInfo().(DefaultNodeInfo).ProtocolVersion.App; app != f.app {
+		return fmt.Errorf("incompatible app version %v", app)
+	}
+	return nil
+}
+
+func TestSwitchAddPeerFilter(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+	err := sw.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sw.Stop()
+
+	addPeer := func() error {
+		rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+		rp.Start()
+		defer rp.Stop()
+
+		p, err := sw.transport.Dial(*rp.Addr(), peerConfig{
+			chDescs:      sw.chDescs,
+			onPeerError:  sw.StopPeerForError,
+			reactorsByCh: sw.reactorsByCh,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sw.addPeer(p)
+	}
+
+	sw.AddPeerFilter(appVersionFilter{app: defaultProtocolVersion.App})
+	if err := addPeer(); err != nil {
+		t.Errorf("expected peer with the same app version to be added, got %v", err)
+	}
+
+	sw.AddPeerFilter(appVersionFilter{app: defaultProtocolVersion.App + 1})
+	err = addPeer()
+	if err, ok := err.(ErrRejected); ok {
+		if !err.IsFiltered() {
+			t.Errorf("expected peer to be filtered")
+		}
+	} else {
+		t.Errorf("expected ErrRejected, got %v", err)
+	}
+}
+
+func TestSwitchBanList(t *testing.T) {
+	banList, err := NewBanList("")
+	require.NoError(t, err)
+
+	sw := MakeSwitch(
+		cfg,
+		1,
+		"testing",
+		"123.123.123",
+		initSwitchFunc,
+		SwitchBanList(banList),
+	)
+	defer sw.Stop()
+
+	// simulate remote peer
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	defer rp.Stop()
+
+	banList.BanID(rp.ID(), time.Hour)
+	assert.True(t, sw.IsBanned(rp.ID(), nil))
+
+	// Outbound connections to banned peers are refused before dialing.
+	err = sw.DialPeerWithAddress(rp.Addr(), false)
+	if _, ok := err.(ErrSwitchBannedPeer); !ok {
+		t.Errorf("expected ErrSwitchBannedPeer, got %v", err)
+	}
+
+	// Inbound peers are refused once their ID is known.
+	p, err := sw.transport.Dial(*rp.Addr(), peerConfig{
+		chDescs:      sw.chDescs,
+		onPeerError:  sw.StopPeerForError,
+		reactorsByCh: sw.reactorsByCh,
+	})
+	require.NoError(t, err)
+	defer sw.transport.Cleanup(p)
+
+	err = sw.addPeer(p)
+	if err, ok := err.(ErrRejected); ok {
+		if !err.IsBanned() {
+			t.Errorf("expected peer to be banned")
+		}
+	} else {
+		t.Errorf("expected ErrRejected, got %v", err)
+	}
+	assert.Zero(t, sw.Peers().Size())
+}
+
+func TestSwitchBanExemptPeers(t *testing.T) {
+	banList, err := NewBanList("")
+	require.NoError(t, err)
+
+	_, persistent := CreateRoutableAddr()
+	_, private := CreateRoutableAddr()
+	_, validator := CreateRoutableAddr()
+	_, public := CreateRoutableAddr()
+
+	sw := MakeSwitch(
+		cfg,
+		1,
+		"testing",
+		"123.123.123",
+		initSwitchFunc,
+		SwitchBanList(banList),
+		SwitchPeerTier(PeerTierValidator, validator.ID),
+	)
+	sw.registerPersistentPeers([]*NetAddress{persistent})
+	sw.SetPrivatePeerIDs([]string{string(private.ID)})
+
+	ip := net.IP{1, 2, 3, 4}
+	banList.BanIP(ip, time.Hour)
+	for _, addr := range []*NetAddress{persistent, private, validator} {
+		banList.BanID(addr.ID, time.Hour)
+		assert.False(t, sw.IsBanned(addr.ID, ip), "%v", addr)
+	}
+	assert.True(t, sw.IsBanned(public.ID, ip))
+	assert.False(t, sw.IsBanned(public.ID, nil))
+}
+
+func TestSwitchGreylistsNewPeers(t *testing.T) {
+	sw1, sw2 := MakeSwitchPair(t, func(i int, sw *Switch) *Switch {
+		p2pCfg := *sw.config
+		p2pCfg.GreylistPeriod = 100 * time.Millisecond
+		sw.config = &p2pCfg
+		return initSwitchFunc(i, sw)
+	})
+	defer sw1.Stop()
+	defer sw2.Stop()
+
+	require.Len(t, sw1.Peers().List(), 1)
+	p := sw1.Peers().List()[0]
+	assert.True(t, sw1.IsGreylisted(p))
+
+	time.Sleep(100 * time.Millisecond)
+	assert.False(t, sw1.IsGreylisted(p))
+
+	// Removed peers are forgotten.
+	sw1.StopPeerGracefully(p)
+	_, ok := sw1.addedAt.Get(string(p.ID())).(time.Time)
+	assert.False(t, ok)
+}
+
+func TestSwitchReportsRateLimitExceeded(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+	store := NewStorePeerBehaviour()
+	sw.SetPeerBehaviour(store)
+
+	peer := newMockPeer(nil)
+	err := conn.ErrRateLimitExceeded{ChannelID: 0x02, Rate: 10, Unit: "messages/s"}
+	sw.reportRateLimitExceeded(peer, err)
+
+	assert.Equal(t,
+		[]ErrorBehaviourReport{{
+			Reason:  ErrorPeerBehaviourRateLimitExceeded,
+			Reactor: "bar",
+			Detail:  err.Error(),
+		}},
+		store.GetErrored(peer.ID()),
+	)
+}
+
 func TestSwitchPeerFilterTimeout(t *testing.T) {
 	var (
 		filters = []PeerFilterFunc{
@@ -436,6 +611,11 @@ func TestSwitchReconnectsToPersistentPeer(t *testing.T) {
 	assert.NotZero(npeers)
 	assert.False(p.IsRunning())
 
+	// The peer was redialed by the dial scheduler.
+	states := sw.DialStates()
+	require.Len(states, 1)
+	assert.Equal(rp.ID(), states[0].ID)
+
 	// simulate another remote peer
 	rp = &remotePeer{
 		PrivKey: ed25519.GenPrivKey(),
@@ -466,6 +646,35 @@ func TestSwitchReconnectsToPersistentPeer(t *testing.T) {
 	assert.EqualValues(2, npeers)
 }
 
+func TestSwitchDialPeer(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+	err := sw.Start()
+	require.NoError(t, err)
+	defer sw.Stop()
+
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	defer rp.Stop()
+
+	require.NoError(t, sw.DialPeer(rp.Addr(), true))
+	assert.NotNil(t, sw.Peers().Get(rp.ID()))
+	assert.True(t, sw.persistentPeers.Has(string(rp.ID())))
+
+	err = sw.DialPeer(rp.Addr(), false)
+	assert.IsType(t, ErrCurrentlyDialingOrExistingAddress{}, err)
+
+	// Rejected addresses are not made persistent.
+	self := sw.NodeInfo().NetAddress()
+	err = sw.DialPeer(self, true)
+	assert.IsType(t, ErrSwitchConnectToSelf{}, err)
+	assert.False(t, sw.persistentPeers.Has(string(self.ID)))
+
+	invalid := &NetAddress{ID: PubKeyToID(ed25519.GenPrivKey().PubKey()), IP: net.IPv4zero, Port: 26656}
+	err = sw.DialPeer(invalid, true)
+	assert.IsType(t, ErrNetAddressInvalid{}, err)
+	assert.False(t, sw.persistentPeers.Has(string(invalid.ID)))
+}
+
 func TestSwitchFullConnectivity(t *testing.T) {
 	switches := MakeConnectedSwitches(cfg, 3, initSwitchFunc, Connect2Switches)
 	defer func() {
@@ -543,6 +752,9 @@ func (et errorTransport) Accept(c peerConfig) (Peer, error) {
 func (errorTransport) Dial(NetAddress, peerConfig) (Peer, error) {
 	panic("not implemented")
 }
+func (errorTransport) DialContext(context.Context, NetAddress, peerConfig) (Peer, error) {
+	panic("not implemented")
+}
 func (errorTransport) Cleanup(Peer) {
 	panic("not implemented")
 }
@@ -626,7 +838,9 @@ func (book *addrBookMock) OurAddress(addr *NetAddress) bool {
 	_, ok := book.ourAddrs[addr.String()]
 	return ok
 }
-func (book *addrBookMock) MarkGood(*NetAddress) {}
+func (book *addrBookMock) AddPrivateIDs([]string)    {}
+func (book *addrBookMock) RemovePrivateIDs([]string) {}
+func (book *addrBookMock) MarkGood(*NetAddress)      {}
 func (book *addrBookMock) HasAddress(addr *NetAddress) bool {
 	_, ok := book.addrs[addr.String()]
 	return ok
@@ -1,8 +1,11 @@
 package p2p
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"net"
+	"testing"
 	"time"
 
 	"github.com/tendermint/tendermint/crypto"
@@ -140,6 +143,7 @@ func (sw *Switch) addPeerWithConnection(conn net.Conn) error {
 		sw.reactorsByCh,
 		sw.chDescs,
 		sw.StopPeerForError,
+		PeerFeatures(sw.nodeInfo),
 	)
 
 	if err = sw.addPeer(p); err != nil {
@@ -188,6 +192,7 @@ func MakeSwitch(
 	sw.SetNodeKey(&nodeKey)
 
 	ni := nodeInfo.(DefaultNodeInfo)
+	ni.Features = SupportedFeatures()
 	for ch := range sw.reactorsByCh {
 		ni.Channels = append(ni.Channels, ch)
 	}
@@ -225,7 +230,7 @@ func testPeerConn(
 	}
 
 	// Encrypt connection
-	conn, err = upgradeSecretConn(conn, cfg.HandshakeTimeout, ourNodePrivKey)
+	conn, err = upgradeSecretConn(conn, cfg.HandshakeTimeout, ourNodePrivKey, handshakeSTS)
 	if err != nil {
 		return pc, cmn.ErrorWrap(err, "Error creating peer")
 	}
@@ -269,3 +274,156 @@ func getFreePort() int {
 	}
 	return port
 }
+
+//----------------------------------------------------------------
+// transport networks
+
+// ListenTransportNetwork listens on a free local port with the network, and
+// returns the listener and its address.
+func ListenTransportNetwork(t *testing.T, n TransportNetwork) (net.Listener, *NetAddress) {
+	addr, err := NewNetAddressStringWithOptionalID("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, err := n.Listen(*addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err = NewNetAddressStringWithOptionalID(ln.Addr().String())
+	if err != nil {
+		ln.Close()
+		t.Fatal(err)
+	}
+	return ln, addr
+}
+
+// RunTransportNetworkTests checks that the network can carry the connections
+// of the MultiplexTransport: the connections it dials to its own listener
+// take a SecretConnection and stream the bytes whatever the writes, their
+// remote addresses are NetAddresses, and its listener stops accepting once
+// closed. The tests specific to a network are left to its package.
+func RunTransportNetworkTests(t *testing.T, n TransportNetwork) {
+	t.Run("SecretConnection", func(t *testing.T) {
+		testTransportNetworkConn(t, n, func(c net.Conn) error {
+			sc, err := conn.MakeSecretConnection(c, ed25519.GenPrivKey())
+			if err != nil {
+				return err
+			}
+			buf := make([]byte, 5)
+			if _, err := io.ReadFull(sc, buf); err != nil {
+				return err
+			}
+			_, err = sc.Write(buf)
+			return err
+		}, func(c net.Conn, addr *NetAddress) error {
+			if a := NewNetAddress("", c.RemoteAddr()); a.String() != addr.String() {
+				return fmt.Errorf("remote address %v, expected %v", a, addr)
+			}
+			sc, err := conn.MakeSecretConnection(c, ed25519.GenPrivKey())
+			if err != nil {
+				return err
+			}
+			if _, err := sc.Write([]byte("hello")); err != nil {
+				return err
+			}
+			buf := make([]byte, 5)
+			if _, err := io.ReadFull(sc, buf); err != nil {
+				return err
+			}
+			if !bytes.Equal(buf, []byte("hello")) {
+				return fmt.Errorf("read %q, expected \"hello\"", buf)
+			}
+			return nil
+		})
+	})
+
+	t.Run("ReadAcrossWrites", func(t *testing.T) {
+		testTransportNetworkConn(t, n, func(c net.Conn) error {
+			buf := make([]byte, len("hello world"))
+			if _, err := io.ReadFull(c, buf); err != nil {
+				return err
+			}
+			if !bytes.Equal(buf, []byte("hello world")) {
+				return fmt.Errorf("read %q, expected \"hello world\"", buf)
+			}
+			return nil
+		}, func(c net.Conn, _ *NetAddress) error {
+			if _, err := c.Write([]byte("hel")); err != nil {
+				return err
+			}
+			_, err := c.Write([]byte("lo world"))
+			return err
+		})
+	})
+
+	t.Run("ListenerClose", func(t *testing.T) {
+		ln, _ := ListenTransportNetwork(t, n)
+
+		errc := make(chan error)
+		go func() {
+			_, err := ln.Accept()
+			errc <- err
+		}()
+
+		if err := ln.Close(); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case err := <-errc:
+			if err == nil {
+				t.Error("Accept didn't fail after Close")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Accept didn't return after Close")
+		}
+	})
+}
+
+// testTransportNetworkConn runs server on the connection accepted by a
+// listener of the network, and client on the one dialed to it.
+func testTransportNetworkConn(
+	t *testing.T,
+	n TransportNetwork,
+	server func(c net.Conn) error,
+	client func(c net.Conn, addr *NetAddress) error,
+) {
+	ln, addr := ListenTransportNetwork(t, n)
+	defer ln.Close()
+
+	var (
+		errc = make(chan error, 1)
+		done = make(chan struct{})
+	)
+	defer close(done)
+
+	go func() {
+		c, err := ln.Accept()
+		if err != nil {
+			errc <- err
+			return
+		}
+		defer func() {
+			// Closing e.g. a QUIC session discards the unsent data, so wait
+			// for the client.
+			<-done
+			c.Close()
+		}()
+		errc <- server(c)
+	}()
+
+	c, err := n.Dial(*addr, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if err := client(c, addr); err != nil {
+		// unblock the server
+		c.Close()
+		ln.Close()
+		t.Error(err)
+	}
+	if err := <-errc; err != nil {
+		t.Error(err)
+	}
+}
@@ -2,11 +2,19 @@ package p2p
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"math/big"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/tendermint/tendermint/crypto"
+	cmn "github.com/tendermint/tendermint/libs/common"
 	"github.com/tendermint/tendermint/p2p/conn"
 )
 
@@ -14,6 +22,10 @@ const (
 	defaultDialTimeout      = time.Second
 	defaultFilterTimeout    = 5 * time.Second
 	defaultHandshakeTimeout = 3 * time.Second
+
+	// maxNoisePeers bounds the number of peers remembered as supporting the
+	// Noise handshake.
+	maxNoisePeers = 1000
 )
 
 // IPResolver is a behaviour subset of net.Resolver.
@@ -38,6 +50,7 @@ type accept struct {
 type peerConfig struct {
 	chDescs              []*conn.ChannelDescriptor
 	onPeerError          func(Peer, interface{})
+	onRateLimitExceeded  func(Peer, conn.ErrRateLimitExceeded)
 	outbound, persistent bool
 	reactorsByCh         map[byte]Reactor
 	metrics              *Metrics
@@ -53,6 +66,10 @@ type Transport interface {
 	// Dial connects to the Peer for the address.
 	Dial(NetAddress, peerConfig) (Peer, error)
 
+	// DialContext connects to the Peer for the address, giving up once the
+	// context is done.
+	DialContext(context.Context, NetAddress, peerConfig) (Peer, error)
+
 	// Cleanup any resources associated with Peer.
 	Cleanup(Peer)
 }
@@ -98,6 +115,19 @@ func MultiplexTransportConnFilters(
 	return func(mt *MultiplexTransport) { mt.connFilters = filters }
 }
 
+// MultiplexTransportDialTimeout sets the timeout for establishing connections
+// to peers.
+func MultiplexTransportDialTimeout(timeout time.Duration) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.dialTimeout = timeout }
+}
+
+// MultiplexTransportHandshakeTimeout sets the timeout for each step of
+// authenticating a connection, i.e. the secret connection handshake and the
+// NodeInfo exchange.
+func MultiplexTransportHandshakeTimeout(timeout time.Duration) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.handshakeTimeout = timeout }
+}
+
 // MultiplexTransportFilterTimeout sets the timeout waited for filter calls to
 // return.
 func MultiplexTransportFilterTimeout(
@@ -106,16 +136,126 @@ func MultiplexTransportFilterTimeout(
 	return func(mt *MultiplexTransport) { mt.filterTimeout = timeout }
 }
 
+// MultiplexTransportNetwork sets the TransportNetwork used to listen for and
+// dial connections, defaults to TCP.
+func MultiplexTransportNetwork(network TransportNetwork) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.network = network }
+}
+
+// MultiplexTransportPeerNetwork sets the TransportNetwork used to dial the
+// peer with the given ID, overriding the default network for that peer.
+func MultiplexTransportPeerNetwork(id ID, network TransportNetwork) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.peerNetworks[id] = network }
+}
+
+// MultiplexTransportInboundConnLimits limits the number of open inbound
+// connections and the rate of new inbound connections per source IP. Excess
+// connections are closed before they are filtered or upgraded. Zero values
+// disable the respective limit.
+func MultiplexTransportInboundConnLimits(
+	maxConnsPerIP int,
+	connRatePerIP float64,
+) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) {
+		mt.connLimiter = nil
+		if maxConnsPerIP > 0 || connRatePerIP > 0 {
+			mt.connLimiter = newConnLimiter(maxConnsPerIP, connRatePerIP)
+		}
+	}
+}
+
+// MultiplexTransportSecretConnRekey rotates the keys of secret connections
+// after every rekeyBytes bytes sent or every rekeyInterval, with peers which
+// support it. Zero values disable the respective limit.
+func MultiplexTransportSecretConnRekey(
+	rekeyBytes int64,
+	rekeyInterval time.Duration,
+) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) {
+		mt.rekeyBytes = rekeyBytes
+		mt.rekeyInterval = rekeyInterval
+	}
+}
+
+// MultiplexTransportDialNoise sets whether peers which advertised FeatureNoise
+// in a previous connection are dialed with the Noise handshake instead of the
+// STS handshake, falling back to STS if the Noise handshake fails. Both are
+// always accepted.
+func MultiplexTransportDialNoise(dialNoise bool) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.dialNoise = dialNoise }
+}
+
 // MultiplexTransportResolver sets the Resolver used for ip lokkups, defaults to
 // net.DefaultResolver.
 func MultiplexTransportResolver(resolver IPResolver) MultiplexTransportOption {
 	return func(mt *MultiplexTransport) { mt.resolver = resolver }
 }
 
-// MultiplexTransport accepts and dials tcp connections and upgrades them to
-// multiplexed peers.
+// TransportNetwork listens for and dials the raw connections which a
+// MultiplexTransport upgrades to peers. Connections are authenticated by the
+// MultiplexTransport, so a TransportNetwork only needs to provide a reliable,
+// ordered byte stream.
+type TransportNetwork interface {
+	Listen(addr NetAddress) (net.Listener, error)
+	Dial(addr NetAddress, timeout time.Duration) (net.Conn, error)
+}
+
+// TCPNetwork is the default TransportNetwork.
+type TCPNetwork struct{}
+
+var _ TransportNetwork = TCPNetwork{}
+
+// Listen implements TransportNetwork.
+func (TCPNetwork) Listen(addr NetAddress) (net.Listener, error) {
+	return net.Listen("tcp", addr.DialString())
+}
+
+// Dial implements TransportNetwork.
+func (TCPNetwork) Dial(addr NetAddress, timeout time.Duration) (net.Conn, error) {
+	return addr.DialTimeout(timeout)
+}
+
+// SelfSignedCertificate returns a freshly generated, self-signed TLS
+// certificate, for the TransportNetworks which tunnel connections over TLS.
+// It's never verified: peers are authenticated by the SecretConnection.
+func SelfSignedCertificate() (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	now := time.Now()
+	tmpl := x509.Certificate{
+		SerialNumber: serial,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(10 * 365 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}, nil
+}
+
+// MultiplexTransport accepts and dials connections over its TransportNetwork
+// and upgrades them to multiplexed peers.
 type MultiplexTransport struct {
-	listener net.Listener
+	listener     net.Listener
+	network      TransportNetwork
+	peerNetworks map[ID]TransportNetwork
+
+	// Additional listeners, see ListenNetwork.
+	mtx       sync.Mutex
+	listeners []net.Listener
 
 	acceptc chan accept
 	closec  chan struct{}
@@ -123,6 +263,11 @@ type MultiplexTransport struct {
 	// Lookup table for duplicate ip and id checks.
 	conns       ConnSet
 	connFilters []ConnFilterFunc
+	connLimiter *connLimiter // can be nil
+
+	// Closes connections stuck in the handshake, started with the first one.
+	reaper     *handshakeReaper
+	reaperOnce sync.Once
 
 	dialTimeout      time.Duration
 	filterTimeout    time.Duration
@@ -131,6 +276,15 @@ type MultiplexTransport struct {
 	nodeKey          NodeKey
 	resolver         IPResolver
 
+	// Key rotation of secret connections, see conn.SecretConnectionRekey.
+	rekeyBytes    int64
+	rekeyInterval time.Duration
+
+	// If dialNoise is set, peers which advertised FeatureNoise are dialed
+	// with the Noise handshake.
+	dialNoise  bool
+	noisePeers *cmn.CMap // ID -> struct{}
+
 	// TODO(xla): This config is still needed as we parameterise peerConn and
 	// peer currently. All relevant configuration should be refactored into options
 	// with sane defaults.
@@ -157,7 +311,11 @@ func NewMultiplexTransport(
 		nodeInfo:         nodeInfo,
 		nodeKey:          nodeKey,
 		conns:            NewConnSet(),
+		network:          TCPNetwork{},
+		peerNetworks:     make(map[ID]TransportNetwork),
 		resolver:         net.DefaultResolver,
+		noisePeers:       cmn.NewCMap(),
+		reaper:           newHandshakeReaper(),
 	}
 }
 
@@ -184,17 +342,27 @@ func (mt *MultiplexTransport) Dial(
 	addr NetAddress,
 	cfg peerConfig,
 ) (Peer, error) {
-	c, err := addr.DialTimeout(mt.dialTimeout)
-	if err != nil {
-		return nil, err
-	}
+	return mt.DialContext(context.Background(), addr, cfg)
+}
 
-	// TODO(xla): Evaluate if we should apply filters if we explicitly dial.
-	if err := mt.filterConn(c); err != nil {
-		return nil, err
+// DialContext implements Transport. If ctx is done before the peer is
+// upgraded, the connection is closed and ctx.Err() returned.
+func (mt *MultiplexTransport) DialContext(
+	ctx context.Context,
+	addr NetAddress,
+	cfg peerConfig,
+) (Peer, error) {
+	network, ok := mt.peerNetworks[addr.ID]
+	if !ok {
+		network = mt.network
 	}
 
-	secretConn, nodeInfo, err := mt.upgrade(c, &addr)
+	secretConn, nodeInfo, err := mt.dialUpgrade(ctx, network, addr)
+	// A peer failing the Noise handshake is forgotten by upgrade, so the
+	// redial falls back to the STS handshake.
+	if e, ok := err.(ErrRejected); ok && e.isNoiseFailure {
+		secretConn, nodeInfo, err = mt.dialUpgrade(ctx, network, addr)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -206,10 +374,38 @@ func (mt *MultiplexTransport) Dial(
 	return p, nil
 }
 
+// dialUpgrade dials addr over network and upgrades the connection.
+func (mt *MultiplexTransport) dialUpgrade(
+	ctx context.Context,
+	network TransportNetwork,
+	addr NetAddress,
+) (*conn.SecretConnection, NodeInfo, error) {
+	c, err := dialContext(ctx, network, addr, mt.dialTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stop := closeOnDone(ctx, c)
+	secretConn, nodeInfo, err := mt.upgradeDialed(c, &addr)
+	if aborted := stop(); aborted {
+		if err == nil {
+			_ = mt.cleanup(c)
+		}
+		return nil, nil, ctx.Err()
+	}
+	return secretConn, nodeInfo, err
+}
+
 // Close implements transportLifecycle.
 func (mt *MultiplexTransport) Close() error {
 	close(mt.closec)
 
+	mt.mtx.Lock()
+	for _, ln := range mt.listeners {
+		_ = ln.Close()
+	}
+	mt.mtx.Unlock()
+
 	if mt.listener != nil {
 		return mt.listener.Close()
 	}
@@ -219,21 +415,39 @@ func (mt *MultiplexTransport) Close() error {
 
 // Listen implements transportLifecycle.
 func (mt *MultiplexTransport) Listen(addr NetAddress) error {
-	ln, err := net.Listen("tcp", addr.DialString())
+	ln, err := mt.network.Listen(addr)
 	if err != nil {
 		return err
 	}
 
 	mt.listener = ln
 
-	go mt.acceptPeers()
+	go mt.acceptPeers(ln)
 
 	return nil
 }
 
-func (mt *MultiplexTransport) acceptPeers() {
+// ListenNetwork accepts connections on addr over the given network, in
+// addition to the listener started by Listen. Peers accepted on either are
+// handed out by Accept.
+func (mt *MultiplexTransport) ListenNetwork(network TransportNetwork, addr NetAddress) error {
+	ln, err := network.Listen(addr)
+	if err != nil {
+		return err
+	}
+
+	mt.mtx.Lock()
+	mt.listeners = append(mt.listeners, ln)
+	mt.mtx.Unlock()
+
+	go mt.acceptPeers(ln)
+
+	return nil
+}
+
+func (mt *MultiplexTransport) acceptPeers(ln net.Listener) {
 	for {
-		c, err := mt.listener.Accept()
+		c, err := ln.Accept()
 		if err != nil {
 			// If Close() has been called, silently exit.
 			select {
@@ -249,6 +463,20 @@ func (mt *MultiplexTransport) acceptPeers() {
 			return
 		}
 
+		c, err = mt.limitConn(c)
+		if err != nil {
+			_ = c.Close()
+
+			// Don't let a flood of connections block the accept loop, the
+			// rejection is only reported if Accept is waiting.
+			select {
+			case mt.acceptc <- accept{err: ErrRejected{conn: c, err: err, isThrottled: true}}:
+			default:
+			}
+
+			continue
+		}
+
 		// Connection upgrade and filtering should be asynchronous to avoid
 		// Head-of-line blocking[0].
 		// Reference:  https://github.com/tendermint/tendermint/issues/2047
@@ -260,10 +488,12 @@ func (mt *MultiplexTransport) acceptPeers() {
 				secretConn *conn.SecretConnection
 			)
 
+			done := mt.trackHandshake(c)
 			err := mt.filterConn(c)
 			if err == nil {
 				secretConn, nodeInfo, err = mt.upgrade(c, nil)
 			}
+			done()
 
 			select {
 			case mt.acceptc <- accept{secretConn, nodeInfo, err}:
@@ -290,6 +520,26 @@ func (mt *MultiplexTransport) cleanup(c net.Conn) error {
 	return c.Close()
 }
 
+// limitConn accounts the inbound connection in the connLimiter. The returned
+// conn releases its slot when closed.
+func (mt *MultiplexTransport) limitConn(c net.Conn) (net.Conn, error) {
+	if mt.connLimiter == nil {
+		return c, nil
+	}
+
+	host, _, err := net.SplitHostPort(c.RemoteAddr().String())
+	if err != nil {
+		return c, err
+	}
+
+	release, err := mt.connLimiter.acquire(host)
+	if err != nil {
+		return c, err
+	}
+
+	return &limitedConn{Conn: c, release: release}, nil
+}
+
 func (mt *MultiplexTransport) filterConn(c net.Conn) (err error) {
 	defer func() {
 		if err != nil {
@@ -333,6 +583,32 @@ func (mt *MultiplexTransport) filterConn(c net.Conn) (err error) {
 	return nil
 }
 
+// upgradeDialed filters and upgrades the dialed connection c.
+func (mt *MultiplexTransport) upgradeDialed(
+	c net.Conn,
+	addr *NetAddress,
+) (*conn.SecretConnection, NodeInfo, error) {
+	defer mt.trackHandshake(c)()
+
+	// TODO(xla): Evaluate if we should apply filters if we explicitly dial.
+	if err := mt.filterConn(c); err != nil {
+		return nil, nil, err
+	}
+
+	return mt.upgrade(c, addr)
+}
+
+// trackHandshake hands c to the reaper until the returned func is called, with
+// a deadline long enough for the filters and both handshake steps.
+func (mt *MultiplexTransport) trackHandshake(c net.Conn) (done func()) {
+	mt.reaperOnce.Do(func() {
+		go mt.reaper.run(handshakeReaperInterval, mt.closec)
+	})
+
+	deadline := time.Now().Add(mt.filterTimeout + 2*mt.handshakeTimeout)
+	return mt.reaper.track(c, deadline)
+}
+
 func (mt *MultiplexTransport) upgrade(
 	c net.Conn,
 	dialedAddr *NetAddress,
@@ -343,12 +619,30 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}()
 
-	secretConn, err = upgradeSecretConn(c, mt.handshakeTimeout, mt.nodeKey.PrivKey)
+	scHandshake := handshakeAccept
+	if dialedAddr != nil {
+		scHandshake = handshakeSTS
+		if mt.dialNoise && mt.noisePeers.Has(string(dialedAddr.ID)) {
+			scHandshake = handshakeNoise
+		}
+	}
+
+	secretConn, err = upgradeSecretConn(
+		c,
+		mt.handshakeTimeout,
+		mt.nodeKey.PrivKey,
+		scHandshake,
+		conn.SecretConnectionRekey(mt.rekeyBytes, mt.rekeyInterval),
+	)
 	if err != nil {
+		if scHandshake == handshakeNoise {
+			mt.noisePeers.Delete(string(dialedAddr.ID))
+		}
 		return nil, nil, ErrRejected{
-			conn:          c,
-			err:           fmt.Errorf("secrect conn failed: %v", err),
-			isAuthFailure: true,
+			conn:           c,
+			err:            fmt.Errorf("secrect conn failed: %v", err),
+			isAuthFailure:  true,
+			isNoiseFailure: scHandshake == handshakeNoise,
 		}
 	}
 
@@ -419,9 +713,24 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
+	// Remember peers accepting the Noise handshake for redialing them.
+	if ni, ok := nodeInfo.(DefaultNodeInfo); ok && hasFeature(ni.Features, FeatureNoise) {
+		mt.rememberNoisePeer(nodeInfo.ID())
+	}
+
 	return secretConn, nodeInfo, nil
 }
 
+// rememberNoisePeer records the peer as supporting the Noise handshake. Once
+// maxNoisePeers are recorded, an arbitrary peer is forgotten to make room,
+// which only costs it an STS handshake on the next dial.
+func (mt *MultiplexTransport) rememberNoisePeer(id ID) {
+	if !mt.noisePeers.Has(string(id)) && mt.noisePeers.Size() >= maxNoisePeers {
+		mt.noisePeers.Delete(mt.noisePeers.Keys()[0])
+	}
+	mt.noisePeers.Set(string(id), struct{}{})
+}
+
 func (mt *MultiplexTransport) wrapPeer(
 	c net.Conn,
 	ni NodeInfo,
@@ -444,6 +753,9 @@ func (mt *MultiplexTransport) wrapPeer(
 		cfg.chDescs,
 		cfg.onPeerError,
 		PeerMetrics(cfg.metrics),
+		PeerOnRateLimitExceeded(cfg.onRateLimitExceeded),
+		PeerCompression(mt.nodeInfo),
+		PeerFeatures(mt.nodeInfo),
 	)
 
 	return p
@@ -488,16 +800,38 @@ func handshake(
 	return peerNodeInfo, c.SetDeadline(time.Time{})
 }
 
+// secretConnHandshake selects the handshake used by upgradeSecretConn.
+type secretConnHandshake int
+
+const (
+	handshakeSTS    secretConnHandshake = iota // STS handshake, on either side
+	handshakeNoise                             // Noise handshake, as the dialer
+	handshakeAccept                            // whichever handshake the dialer chose
+)
+
 func upgradeSecretConn(
 	c net.Conn,
 	timeout time.Duration,
 	privKey crypto.PrivKey,
+	handshake secretConnHandshake,
+	options ...conn.SecretConnectionOption,
 ) (*conn.SecretConnection, error) {
 	if err := c.SetDeadline(time.Now().Add(timeout)); err != nil {
 		return nil, err
 	}
 
-	sc, err := conn.MakeSecretConnection(c, privKey)
+	var (
+		sc  *conn.SecretConnection
+		err error
+	)
+	switch handshake {
+	case handshakeNoise:
+		sc, err = conn.MakeNoiseSecretConnection(c, privKey, options...)
+	case handshakeAccept:
+		sc, err = conn.AcceptSecretConnection(c, privKey, options...)
+	default:
+		sc, err = conn.MakeSecretConnection(c, privKey, options...)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -505,6 +839,72 @@ func upgradeSecretConn(
 	return sc, sc.SetDeadline(time.Time{})
 }
 
+// dialContext dials addr over network, giving up after timeout or once ctx is
+// done.
+func dialContext(
+	ctx context.Context,
+	network TransportNetwork,
+	addr NetAddress,
+	timeout time.Duration,
+) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type dialResult struct {
+		c   net.Conn
+		err error
+	}
+	resc := make(chan dialResult, 1)
+
+	go func() {
+		c, err := network.Dial(addr, timeout)
+		resc <- dialResult{c, err}
+	}()
+
+	select {
+	case res := <-resc:
+		return res.c, res.err
+	case <-ctx.Done():
+		// Don't leak the connection if the dial still succeeds.
+		go func() {
+			if res := <-resc; res.err == nil {
+				_ = res.c.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+// closeOnDone closes c once ctx is done, which aborts whatever is blocked on
+// it, until the returned func is called. That func reports whether c was
+// closed.
+func closeOnDone(ctx context.Context, c net.Conn) (stop func() bool) {
+	if ctx.Done() == nil {
+		// ctx is never done.
+		return func() bool { return false }
+	}
+
+	var (
+		stopc   = make(chan struct{})
+		closedc = make(chan bool, 1)
+	)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = c.Close()
+			closedc <- true
+		case <-stopc:
+			closedc <- false
+		}
+	}()
+
+	return func() bool {
+		close(stopc)
+		return <-closedc
+	}
+}
+
 func resolveIPs(resolver IPResolver, c net.Conn) ([]net.IP, error) {
 	host, _, err := net.SplitHostPort(c.RemoteAddr().String())
 	if err != nil {
@@ -1,10 +1,13 @@
 package p2p
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"math/rand"
 	"net"
 	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -251,7 +254,7 @@ func TestTransportMultiplexAcceptNonBlocking(t *testing.T) {
 			errc <- fmt.Errorf("Fast peer timed out")
 		}
 
-		sc, err := upgradeSecretConn(c, 20*time.Millisecond, ed25519.GenPrivKey())
+		sc, err := upgradeSecretConn(c, 20*time.Millisecond, ed25519.GenPrivKey(), handshakeSTS)
 		if err != nil {
 			errc <- err
 			return
@@ -591,6 +594,284 @@ func TestTransportHandshake(t *testing.T) {
 	}
 }
 
+// testCountingNetwork is a TCPNetwork counting its dials.
+type testCountingNetwork struct {
+	TCPNetwork
+	dials int32
+}
+
+func (n *testCountingNetwork) Dial(addr NetAddress, timeout time.Duration) (net.Conn, error) {
+	atomic.AddInt32(&n.dials, 1)
+	return n.TCPNetwork.Dial(addr, timeout)
+}
+
+func TestTransportMultiplexListenNetwork(t *testing.T) {
+	mt := testSetupMultiplexTransport(t)
+	defer mt.Close()
+
+	laddr, err := NewNetAddressStringWithOptionalID("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mt.ListenNetwork(TCPNetwork{}, *laddr); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		pv      = ed25519.GenPrivKey()
+		network = &testCountingNetwork{}
+		dialer  = newMultiplexTransport(
+			testNodeInfo(PubKeyToID(pv.PubKey()), defaultNodeName),
+			NodeKey{
+				PrivKey: pv,
+			},
+		)
+		errc = make(chan error)
+	)
+	MultiplexTransportPeerNetwork(mt.nodeKey.ID(), network)(dialer)
+
+	go func() {
+		addr, err := NewNetAddressStringWithOptionalID(IDAddressString(mt.nodeKey.ID(), mt.listeners[0].Addr().String()))
+		if err != nil {
+			errc <- err
+			return
+		}
+
+		_, err = dialer.Dial(*addr, peerConfig{})
+		errc <- err
+	}()
+
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := mt.Accept(peerConfig{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := atomic.LoadInt32(&network.dials), int32(1); have != want {
+		t.Errorf("have %v dials over the peer network, want %v", have, want)
+	}
+}
+
+func TestTransportMultiplexInboundConnLimits(t *testing.T) {
+	mt := newMultiplexTransport(
+		emptyNodeInfo(),
+		NodeKey{
+			PrivKey: ed25519.GenPrivKey(),
+		},
+	)
+	MultiplexTransportInboundConnLimits(1, 0)(mt)
+
+	addr, err := NewNetAddressStringWithOptionalID("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mt.Listen(*addr); err != nil {
+		t.Fatal(err)
+	}
+	defer mt.Close()
+
+	laddr, err := NewNetAddressStringWithOptionalID(mt.listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first connection is kept open while handshaking.
+	c1, err := laddr.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c1.Close()
+
+	// The second one from the same IP is closed right away.
+	c2, err := laddr.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c2.Close()
+
+	if err := c2.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c2.Read(make([]byte, 1)); err != io.EOF {
+		t.Errorf("expected second connection to be closed, got %v", err)
+	}
+
+	if err := c1.SetReadDeadline(time.Now().Add(100 * time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c1.Read(make([]byte, 1)); err == io.EOF {
+		t.Errorf("expected first connection to stay open")
+	}
+}
+
+func TestTransportMultiplexDialNoise(t *testing.T) {
+	mt := testSetupMultiplexTransport(t)
+	ni := mt.nodeInfo.(DefaultNodeInfo)
+	ni.Features = []string{FeatureNoise}
+	mt.nodeInfo = ni
+
+	errc := make(chan error)
+	go func() {
+		for i := 0; i < 2; i++ {
+			_, err := mt.Accept(peerConfig{})
+			errc <- err
+		}
+	}()
+
+	pv := ed25519.GenPrivKey()
+	dialer := newMultiplexTransport(testNodeInfo(PubKeyToID(pv.PubKey()), "dialer"), NodeKey{PrivKey: pv})
+	MultiplexTransportDialNoise(true)(dialer)
+	addr, err := NewNetAddressStringWithOptionalID(IDAddressString(mt.nodeKey.ID(), mt.listener.Addr().String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first connection uses the STS handshake, and tells us the peer
+	// supports the Noise handshake, which is used for the second one.
+	for i := 0; i < 2; i++ {
+		p, err := dialer.Dial(*addr, peerConfig{})
+		if err != nil {
+			t.Fatalf("dial %d failed: %v", i, err)
+		}
+		dialer.Cleanup(p)
+		if err := <-errc; err != nil {
+			t.Fatalf("accept %d failed: %v", i, err)
+		}
+		if !dialer.noisePeers.Has(string(mt.nodeKey.ID())) {
+			t.Errorf("expected peer to be recorded as supporting the Noise handshake")
+		}
+	}
+}
+
+func TestTransportMultiplexDialNoiseFallback(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	// The peer only speaks the STS handshake, e.g. because it was downgraded.
+	pv := ed25519.GenPrivKey()
+	ni := testNodeInfo(PubKeyToID(pv.PubKey()), "sts")
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(c net.Conn) {
+				sc, err := upgradeSecretConn(c, time.Second, pv, handshakeSTS)
+				if err != nil {
+					_ = c.Close()
+					return
+				}
+				_, _ = handshake(sc, time.Second, ni)
+			}(c)
+		}
+	}()
+
+	dpv := ed25519.GenPrivKey()
+	dialer := newMultiplexTransport(testNodeInfo(PubKeyToID(dpv.PubKey()), "dialer"), NodeKey{PrivKey: dpv})
+	MultiplexTransportDialNoise(true)(dialer)
+	MultiplexTransportHandshakeTimeout(500 * time.Millisecond)(dialer)
+	dialer.rememberNoisePeer(ni.ID())
+	addr, err := NewNetAddressStringWithOptionalID(IDAddressString(ni.ID(), ln.Addr().String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := dialer.Dial(*addr, peerConfig{})
+	if err != nil {
+		t.Fatalf("expected dial to fall back to the STS handshake, got: %v", err)
+	}
+	dialer.Cleanup(p)
+	if dialer.noisePeers.Has(string(ni.ID())) {
+		t.Errorf("expected peer to be forgotten after failing the Noise handshake")
+	}
+}
+
+func TestTransportMultiplexNoisePeersBounded(t *testing.T) {
+	mt := newMultiplexTransport(emptyNodeInfo(), NodeKey{PrivKey: ed25519.GenPrivKey()})
+	for i := 0; i < maxNoisePeers+10; i++ {
+		mt.rememberNoisePeer(ID(fmt.Sprintf("%040x", i)))
+	}
+	if have := mt.noisePeers.Size(); have != maxNoisePeers {
+		t.Errorf("expected %d noise peers, have %d", maxNoisePeers, have)
+	}
+}
+
+// testSilentListener accepts connections and never writes to them, like a
+// peer stuck in the handshake.
+func testSilentListener(t *testing.T) (*NetAddress, func()) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer c.Close()
+		}
+	}()
+
+	pv := ed25519.GenPrivKey()
+	addr, err := NewNetAddressStringWithOptionalID(IDAddressString(PubKeyToID(pv.PubKey()), ln.Addr().String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return addr, func() { _ = ln.Close() }
+}
+
+func TestTransportMultiplexDialHandshakeTimeout(t *testing.T) {
+	addr, closeListener := testSilentListener(t)
+	defer closeListener()
+
+	dialer := newMultiplexTransport(emptyNodeInfo(), NodeKey{PrivKey: ed25519.GenPrivKey()})
+	MultiplexTransportHandshakeTimeout(50 * time.Millisecond)(dialer)
+
+	start := time.Now()
+	_, err := dialer.Dial(*addr, peerConfig{})
+	if err, ok := err.(ErrRejected); !ok || !err.IsAuthFailure() {
+		t.Fatalf("expected auth failure, got: %v", err)
+	}
+	if took := time.Since(start); took > time.Second {
+		t.Errorf("expected dial to time out after the handshake timeout, took %v", took)
+	}
+}
+
+func TestTransportMultiplexDialContextCancel(t *testing.T) {
+	addr, closeListener := testSilentListener(t)
+	defer closeListener()
+
+	dialer := newMultiplexTransport(emptyNodeInfo(), NodeKey{PrivKey: ed25519.GenPrivKey()})
+	MultiplexTransportHandshakeTimeout(time.Minute)(dialer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	errc := make(chan error)
+	go func() {
+		_, err := dialer.DialContext(ctx, *addr, peerConfig{})
+		errc <- err
+	}()
+
+	select {
+	case err := <-errc:
+		if err != context.Canceled {
+			t.Errorf("expected context.Canceled, got: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected dial to abort once the context is cancelled")
+	}
+}
+
 func testSetupMultiplexTransport(t *testing.T) *MultiplexTransport {
 	var (
 		pv = ed25519.GenPrivKey()
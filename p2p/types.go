@@ -6,3 +6,9 @@ import (
 
 type ChannelDescriptor = conn.ChannelDescriptor
 type ConnectionStatus = conn.ConnectionStatus
+type SendQueuePolicy = conn.SendQueuePolicy
+
+const (
+	SendQueueBlock      = conn.SendQueueBlock
+	SendQueueDropOldest = conn.SendQueueDropOldest
+)
@@ -0,0 +1,180 @@
+package upnp
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// NAT-PMP, see https://tools.ietf.org/html/rfc6886
+const (
+	natPMPPort    = 5351
+	natPMPVersion = 0
+
+	natPMPOpExternalAddress = 0
+	natPMPOpMapUDP          = 1
+	natPMPOpMapTCP          = 2
+
+	// The RFC suggests up to 9 attempts starting at 250ms, which adds up to
+	// more than a minute. Gateways either answer quickly or not at all.
+	natPMPAttempts       = 4
+	natPMPInitialTimeout = 250 * time.Millisecond
+)
+
+type natPMPNAT struct {
+	gateway *net.UDPAddr
+}
+
+// DiscoverNATPMP returns a NAT for the NAT-PMP gateway of the default route,
+// if it responds.
+func DiscoverNATPMP() (nat NAT, err error) {
+	gateway, err := defaultGateway()
+	if err != nil {
+		return nil, err
+	}
+	n := &natPMPNAT{gateway: &net.UDPAddr{IP: gateway, Port: natPMPPort}}
+	if _, err := n.GetExternalAddress(); err != nil {
+		return nil, fmt.Errorf("NAT-PMP gateway %v not responding: %v", gateway, err)
+	}
+	return n, nil
+}
+
+// GetExternalAddress returns the external IP of the gateway.
+func (n *natPMPNAT) GetExternalAddress() (addr net.IP, err error) {
+	res, err := n.request([]byte{natPMPVersion, natPMPOpExternalAddress}, 12)
+	if err != nil {
+		return nil, err
+	}
+	return net.IPv4(res[8], res[9], res[10], res[11]), nil
+}
+
+// AddPortMapping maps externalPort to internalPort for timeout seconds.
+// The gateway may choose a different external port, which is returned.
+func (n *natPMPNAT) AddPortMapping(protocol string, externalPort, internalPort int, description string, timeout int) (mappedExternalPort int, err error) {
+	op, err := natPMPMapOp(protocol)
+	if err != nil {
+		return 0, err
+	}
+
+	msg := make([]byte, 12)
+	msg[0] = natPMPVersion
+	msg[1] = op
+	binary.BigEndian.PutUint16(msg[4:], uint16(internalPort))
+	binary.BigEndian.PutUint16(msg[6:], uint16(externalPort))
+	binary.BigEndian.PutUint32(msg[8:], uint32(timeout))
+
+	res, err := n.request(msg, 16)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(res[10:])), nil
+}
+
+// DeletePortMapping removes the mapping of internalPort.
+func (n *natPMPNAT) DeletePortMapping(protocol string, externalPort, internalPort int) (err error) {
+	// A zero lifetime and external port deletes the mapping.
+	_, err = n.AddPortMapping(protocol, 0, internalPort, "", 0)
+	return err
+}
+
+// request sends msg to the gateway and returns the response, which must be of
+// resLen bytes. The request is retried with doubling timeouts.
+func (n *natPMPNAT) request(msg []byte, resLen int) ([]byte, error) {
+	conn, err := net.DialUDP("udp4", nil, n.gateway)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close() // nolint: errcheck
+
+	res := make([]byte, resLen)
+	timeout := natPMPInitialTimeout
+	for i := 0; i < natPMPAttempts; i++ {
+		if _, err = conn.Write(msg); err != nil {
+			return nil, err
+		}
+		if err = conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, err
+		}
+		timeout *= 2
+
+		var read int
+		read, err = conn.Read(res)
+		if err != nil {
+			if nerr, ok := err.(net.Error); ok && nerr.Timeout() {
+				continue
+			}
+			return nil, err
+		}
+		if read != resLen || res[0] != natPMPVersion || res[1] != msg[1]|0x80 {
+			err = errors.New("unexpected NAT-PMP response")
+			continue
+		}
+		if code := binary.BigEndian.Uint16(res[2:]); code != 0 {
+			return nil, fmt.Errorf("NAT-PMP request failed with result code %d", code)
+		}
+		return res, nil
+	}
+	return nil, err
+}
+
+func natPMPMapOp(protocol string) (byte, error) {
+	switch protocol {
+	case "udp":
+		return natPMPOpMapUDP, nil
+	case "tcp":
+		return natPMPOpMapTCP, nil
+	default:
+		return 0, fmt.Errorf("unsupported protocol %q", protocol)
+	}
+}
+
+// defaultGateway returns the gateway of the default IPv4 route. It is read
+// from the routing table on Linux. Everywhere else, the gateway is assumed to
+// be the first address of the local network, which is the case for most home
+// routers.
+func defaultGateway() (net.IP, error) {
+	if ip, err := linuxDefaultGateway(); err == nil {
+		return ip, nil
+	}
+
+	ip, err := localIPv4()
+	if err != nil {
+		return nil, err
+	}
+	gateway := make(net.IP, len(ip))
+	copy(gateway, ip)
+	gateway[3] = 1
+	return gateway, nil
+}
+
+func linuxDefaultGateway() (net.IP, error) {
+	f, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint: errcheck
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Iface Destination Gateway Flags ..., addresses in little endian hex.
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[1] != "00000000" {
+			continue
+		}
+		b, err := hex.DecodeString(fields[2])
+		if err != nil || len(b) != 4 {
+			continue
+		}
+		return net.IPv4(b[3], b[2], b[1], b[0]), nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("no default route")
+}
@@ -0,0 +1,140 @@
+package upnp
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+)
+
+const (
+	// DefaultPortMappingLease is the lease requested for port mappings. The
+	// lease is refreshed when half of it has passed.
+	DefaultPortMappingLease = 20 * time.Minute
+
+	portMappingDescription = "Tendermint"
+)
+
+// DiscoverNAT returns a NAT for the local gateway, trying UPnP first and
+// NAT-PMP second.
+func DiscoverNAT() (NAT, error) {
+	nat, upnpErr := Discover()
+	if upnpErr == nil {
+		return nat, nil
+	}
+	nat, pmpErr := DiscoverNATPMP()
+	if pmpErr == nil {
+		return nat, nil
+	}
+	return nil, fmt.Errorf("no NAT gateway found (UPnP: %v, NAT-PMP: %v)", upnpErr, pmpErr)
+}
+
+// PortMapping maps a local port on the NAT gateway while it is running. The
+// mapping is added on start, refreshed before its lease expires and deleted
+// on stop.
+type PortMapping struct {
+	cmn.BaseService
+
+	nat      NAT
+	protocol string
+	port     int
+	lease    time.Duration
+
+	mtx        sync.Mutex
+	externalIP net.IP
+	extPort    int
+}
+
+// NewPortMapping returns a PortMapping of port for the protocol ("tcp" or
+// "udp"). The same external port is requested, but the gateway may assign a
+// different one.
+func NewPortMapping(nat NAT, protocol string, port int, lease time.Duration) *PortMapping {
+	pm := &PortMapping{
+		nat:      nat,
+		protocol: protocol,
+		port:     port,
+		lease:    lease,
+	}
+	pm.BaseService = *cmn.NewBaseService(nil, "PortMapping", pm)
+	return pm
+}
+
+// OnStart implements cmn.Service by adding the mapping and starting the
+// refresh routine.
+func (pm *PortMapping) OnStart() error {
+	if err := pm.refresh(); err != nil {
+		return err
+	}
+	go pm.refreshRoutine()
+	return nil
+}
+
+// OnStop implements cmn.Service by deleting the mapping.
+func (pm *PortMapping) OnStop() {
+	pm.mtx.Lock()
+	extPort := pm.extPort
+	pm.mtx.Unlock()
+
+	if err := pm.nat.DeletePortMapping(pm.protocol, extPort, pm.port); err != nil {
+		pm.Logger.Error("Failed to delete port mapping", "err", err)
+	}
+}
+
+// ExternalAddress returns the external IP of the gateway and the external
+// port mapped to the local port.
+func (pm *PortMapping) ExternalAddress() (net.IP, int) {
+	pm.mtx.Lock()
+	defer pm.mtx.Unlock()
+	return pm.externalIP, pm.extPort
+}
+
+// refresh (re)adds the mapping and looks up the external IP.
+func (pm *PortMapping) refresh() error {
+	pm.mtx.Lock()
+	defer pm.mtx.Unlock()
+
+	reqPort := pm.extPort
+	if reqPort == 0 {
+		reqPort = pm.port
+	}
+	extPort, err := pm.nat.AddPortMapping(
+		pm.protocol, reqPort, pm.port, portMappingDescription, int(pm.lease.Seconds()),
+	)
+	if err != nil {
+		return fmt.Errorf("port mapping error: %v", err)
+	}
+	externalIP, err := pm.nat.GetExternalAddress()
+	if err != nil {
+		return fmt.Errorf("external address error: %v", err)
+	}
+
+	if pm.externalIP != nil && (!pm.externalIP.Equal(externalIP) || pm.extPort != extPort) {
+		// Peers already know the old address, there is nothing we can do
+		// except letting the operator know.
+		pm.Logger.Error(
+			"External address changed",
+			"old", fmt.Sprintf("%v:%d", pm.externalIP, pm.extPort),
+			"new", fmt.Sprintf("%v:%d", externalIP, extPort),
+		)
+	}
+	pm.externalIP, pm.extPort = externalIP, extPort
+	return nil
+}
+
+func (pm *PortMapping) refreshRoutine() {
+	ticker := time.NewTicker(pm.lease / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := pm.refresh(); err != nil {
+				pm.Logger.Error("Failed to refresh port mapping", "err", err)
+			}
+		case <-pm.Quit():
+			return
+		}
+	}
+}
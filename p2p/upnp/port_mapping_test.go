@@ -0,0 +1,121 @@
+package upnp
+
+import (
+	"encoding/binary"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNAT struct {
+	mtx      sync.Mutex
+	ip       net.IP
+	added    int
+	deleted  int
+	lastPort int
+}
+
+func (n *testNAT) GetExternalAddress() (net.IP, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	return n.ip, nil
+}
+
+func (n *testNAT) AddPortMapping(protocol string, externalPort, internalPort int, description string, timeout int) (int, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.added++
+	n.lastPort = externalPort
+	// Pretend the internal port is taken on the gateway.
+	if externalPort == internalPort {
+		return externalPort + 1, nil
+	}
+	return externalPort, nil
+}
+
+func (n *testNAT) DeletePortMapping(protocol string, externalPort, internalPort int) error {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.deleted++
+	return nil
+}
+
+func (n *testNAT) counts() (added, deleted, lastPort int) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	return n.added, n.deleted, n.lastPort
+}
+
+func TestPortMappingRefresh(t *testing.T) {
+	nat := &testNAT{ip: net.IPv4(1, 2, 3, 4)}
+	pm := NewPortMapping(nat, "tcp", 26656, 20*time.Millisecond)
+	require.NoError(t, pm.Start())
+
+	ip, port := pm.ExternalAddress()
+	assert.True(t, ip.Equal(nat.ip))
+	assert.Equal(t, 26657, port)
+
+	// The lease is refreshed, keeping the assigned external port.
+	deadline := time.Now().Add(time.Second)
+	for {
+		if added, _, _ := nat.counts(); added > 2 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	added, _, lastPort := nat.counts()
+	assert.True(t, added > 2, "expected the mapping to be refreshed")
+	assert.Equal(t, 26657, lastPort)
+
+	require.NoError(t, pm.Stop())
+	_, deleted, _ := nat.counts()
+	assert.Equal(t, 1, deleted)
+}
+
+func TestNATPMP(t *testing.T) {
+	gateway, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	defer gateway.Close()
+
+	go func() {
+		req := make([]byte, 12)
+		for {
+			n, addr, err := gateway.ReadFromUDP(req)
+			if err != nil {
+				return
+			}
+			var res []byte
+			switch op := req[1]; {
+			case op == natPMPOpExternalAddress && n == 2:
+				res = make([]byte, 12)
+				copy(res[8:], net.IPv4(5, 6, 7, 8).To4())
+			case op == natPMPOpMapTCP && n == 12:
+				res = make([]byte, 16)
+				copy(res[8:10], req[4:6])                                              // internal port
+				binary.BigEndian.PutUint16(res[10:], 40000)                            // mapped port
+				binary.BigEndian.PutUint32(res[12:], binary.BigEndian.Uint32(req[8:])) // lifetime
+			default:
+				continue
+			}
+			res[1] = req[1] | 0x80
+			_, _ = gateway.WriteToUDP(res, addr)
+		}
+	}()
+
+	nat := &natPMPNAT{gateway: gateway.LocalAddr().(*net.UDPAddr)}
+
+	ip, err := nat.GetExternalAddress()
+	require.NoError(t, err)
+	assert.True(t, ip.Equal(net.IPv4(5, 6, 7, 8)))
+
+	port, err := nat.AddPortMapping("tcp", 26656, 26656, "test", 60)
+	require.NoError(t, err)
+	assert.Equal(t, 40000, port)
+
+	_, err = nat.AddPortMapping("sctp", 26656, 26656, "test", 60)
+	assert.Error(t, err)
+}
@@ -0,0 +1,244 @@
+// Package ws implements a p2p.TransportNetwork which tunnels connections over
+// WebSockets.
+//
+// It is meant for nodes behind firewalls which only let HTTP(S) traffic
+// through, e.g. validators in restrictive corporate networks: the remote node
+// listens on port 443 (or 80) and the tunnel is established with a regular
+// HTTP upgrade request, honoring the HTTP_PROXY environment variable when
+// dialing ws:// URLs, and HTTPS_PROXY when dialing wss:// URLs. The
+// MultiplexTransport still performs the usual authenticated handshake over the
+// tunnel, so the WebSocket itself isn't authenticated: with TLS, certificates
+// aren't verified, TLS only lets the tunnel through firewalls and proxies
+// which expect HTTPS traffic.
+package ws
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+const (
+	// Path is the HTTP path tunnels are established on.
+	Path = "/p2p"
+
+	defaultHandshakeTimeout = 10 * time.Second
+)
+
+var errListenerClosed = errors.New("listener closed")
+
+// Network is a p2p.TransportNetwork tunneling connections over WebSockets.
+type Network struct {
+	upgrader websocket.Upgrader
+
+	// TLS configuration of the listener, if the WebSockets are tunneled over
+	// TLS (wss://).
+	tlsConfig *tls.Config
+}
+
+var _ p2p.TransportNetwork = (*Network)(nil)
+
+// NewNetwork returns a new Network, with plain WebSockets (ws://).
+func NewNetwork() *Network {
+	return &Network{
+		upgrader: websocket.Upgrader{
+			HandshakeTimeout: defaultHandshakeTimeout,
+		},
+	}
+}
+
+// NewTLSNetwork returns a new Network, with WebSockets over TLS (wss://). The
+// listener serves the given certificate, or a freshly generated, self-signed
+// one if nil.
+func NewTLSNetwork(cert *tls.Certificate) (*Network, error) {
+	if cert == nil {
+		selfSigned, err := p2p.SelfSignedCertificate()
+		if err != nil {
+			return nil, err
+		}
+		cert = &selfSigned
+	}
+	n := NewNetwork()
+	n.tlsConfig = &tls.Config{Certificates: []tls.Certificate{*cert}}
+	return n, nil
+}
+
+// Listen implements p2p.TransportNetwork by serving WebSocket upgrade requests
+// on addr.
+func (n *Network) Listen(addr p2p.NetAddress) (net.Listener, error) {
+	ln, err := net.Listen("tcp", addr.DialString())
+	if err != nil {
+		return nil, err
+	}
+	if n.tlsConfig != nil {
+		ln = tls.NewListener(ln, n.tlsConfig)
+	}
+
+	l := &listener{
+		ln:     ln,
+		connc:  make(chan net.Conn),
+		closec: make(chan struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(Path, func(w http.ResponseWriter, r *http.Request) {
+		wsConn, err := n.upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// The upgrader already replied with an HTTP error.
+			return
+		}
+
+		select {
+		case l.connc <- newTunnelConn(wsConn):
+		case <-l.closec:
+			_ = wsConn.Close()
+		}
+	})
+	l.srv = &http.Server{Handler: mux}
+
+	go func() {
+		_ = l.srv.Serve(ln)
+		// Unblock Accept, whatever made the server stop.
+		l.closeOnce.Do(func() { close(l.closec) })
+	}()
+
+	return l, nil
+}
+
+// Dial implements p2p.TransportNetwork.
+func (n *Network) Dial(addr p2p.NetAddress, timeout time.Duration) (net.Conn, error) {
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: timeout,
+		NetDial: func(network, addr string) (net.Conn, error) {
+			return net.DialTimeout(network, addr, timeout)
+		},
+	}
+
+	scheme := "ws://"
+	if n.tlsConfig != nil {
+		scheme = "wss://"
+		// The remote node is authenticated by the SecretConnection.
+		dialer.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // nolint: gosec
+	}
+
+	wsConn, _, err := dialer.Dial(scheme+addr.DialString()+Path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTunnelConn(wsConn), nil
+}
+
+//-----------------------------------------------------------------------------
+
+// tunnelConn is a net.Conn backed by a WebSocket. Every Write is sent as a
+// binary message, Read consumes messages as a continuous stream.
+type tunnelConn struct {
+	*websocket.Conn
+
+	// Guards reader. Only a single goroutine may read at a time.
+	readMtx sync.Mutex
+	reader  io.Reader
+
+	// Only a single goroutine may write at a time.
+	writeMtx sync.Mutex
+}
+
+var _ net.Conn = (*tunnelConn)(nil)
+
+func newTunnelConn(wsConn *websocket.Conn) *tunnelConn {
+	return &tunnelConn{Conn: wsConn}
+}
+
+// Read implements net.Conn.
+func (c *tunnelConn) Read(b []byte) (int, error) {
+	c.readMtx.Lock()
+	defer c.readMtx.Unlock()
+
+	for {
+		if c.reader == nil {
+			typ, r, err := c.NextReader()
+			if err != nil {
+				return 0, err
+			}
+			if typ != websocket.BinaryMessage {
+				continue
+			}
+			c.reader = r
+		}
+
+		n, err := c.reader.Read(b)
+		if err == io.EOF {
+			// Continue with the next message.
+			c.reader = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+// Write implements net.Conn.
+func (c *tunnelConn) Write(b []byte) (int, error) {
+	c.writeMtx.Lock()
+	defer c.writeMtx.Unlock()
+
+	if err := c.WriteMessage(websocket.BinaryMessage, b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// SetDeadline implements net.Conn.
+func (c *tunnelConn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.SetWriteDeadline(t)
+}
+
+//-----------------------------------------------------------------------------
+
+// listener is a net.Listener handing out upgraded WebSocket connections.
+type listener struct {
+	ln  net.Listener
+	srv *http.Server
+
+	connc     chan net.Conn
+	closec    chan struct{}
+	closeOnce sync.Once
+}
+
+var _ net.Listener = (*listener)(nil)
+
+// Accept implements net.Listener.
+func (l *listener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.connc:
+		return c, nil
+	case <-l.closec:
+		return nil, errListenerClosed
+	}
+}
+
+// Close implements net.Listener by shutting down the HTTP server.
+func (l *listener) Close() error {
+	l.closeOnce.Do(func() { close(l.closec) })
+	return l.srv.Close()
+}
+
+// Addr implements net.Listener.
+func (l *listener) Addr() net.Addr {
+	return l.ln.Addr()
+}
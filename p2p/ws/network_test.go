@@ -0,0 +1,28 @@
+package ws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+func TestNetwork(t *testing.T) {
+	p2p.RunTransportNetworkTests(t, NewNetwork())
+}
+
+func TestTLSNetwork(t *testing.T) {
+	n, err := NewTLSNetwork(nil)
+	require.NoError(t, err)
+	p2p.RunTransportNetworkTests(t, n)
+
+	ln, addr := p2p.ListenTransportNetwork(t, n)
+	defer ln.Close()
+
+	// A plain WebSocket isn't upgraded.
+	_, err = NewNetwork().Dial(*addr, time.Second)
+	assert.Error(t, err)
+}
@@ -234,11 +234,21 @@ func (pv *FilePV) GetPubKey() crypto.PubKey {
 }
 
 // SignVote signs a canonical representation of the vote, along with the
-// chainID. Implements PrivValidator.
+// chainID, and the extension of a precommit for a block, even if it's empty.
+// Implements PrivValidator.
 func (pv *FilePV) SignVote(chainID string, vote *types.Vote) error {
 	if err := pv.signVote(chainID, vote); err != nil {
 		return fmt.Errorf("error signing vote: %v", err)
 	}
+	if vote.Type == types.PrecommitType && !vote.BlockID.IsZero() {
+		// The extension isn't part of the sign bytes, there is nothing to
+		// double sign.
+		sig, err := pv.Key.PrivKey.Sign(vote.ExtensionSignBytes(chainID))
+		if err != nil {
+			return fmt.Errorf("error signing vote extension: %v", err)
+		}
+		vote.ExtensionSignature = sig
+	}
 	return nil
 }
 
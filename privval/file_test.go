@@ -197,6 +197,39 @@ func TestSignVote(t *testing.T) {
 	assert.Equal(sig, vote.Signature)
 }
 
+func TestSignVoteExtension(t *testing.T) {
+	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
+	require.Nil(t, err)
+	tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.Nil(t, err)
+
+	privVal := GenFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	pubKey := privVal.GetPubKey()
+
+	block := types.BlockID{Hash: []byte{1, 2, 3}, PartsHeader: types.PartSetHeader{}}
+	vote := newVote(privVal.Key.Address, 0, 10, 1, byte(types.PrecommitType), block)
+	vote.Extension = []byte("extension")
+	require.NoError(t, privVal.SignVote("mychainid", vote))
+	assert.NoError(t, vote.Verify("mychainid", pubKey))
+	assert.NoError(t, vote.VerifyExtension("mychainid", pubKey))
+
+	// signing the same vote again, e.g. after a crash, signs its extension
+	vote.Signature, vote.ExtensionSignature = nil, nil
+	require.NoError(t, privVal.SignVote("mychainid", vote))
+	assert.NoError(t, vote.VerifyExtension("mychainid", pubKey))
+
+	// an empty extension is signed too, so it can't be stripped
+	vote = newVote(privVal.Key.Address, 0, 10, 2, byte(types.PrecommitType), block)
+	require.NoError(t, privVal.SignVote("mychainid", vote))
+	assert.NotEmpty(t, vote.ExtensionSignature)
+	assert.NoError(t, vote.VerifyExtension("mychainid", pubKey))
+
+	// unlike a prevote
+	vote = newVote(privVal.Key.Address, 0, 10, 3, byte(types.PrevoteType), block)
+	require.NoError(t, privVal.SignVote("mychainid", vote))
+	assert.Empty(t, vote.ExtensionSignature)
+}
+
 func TestSignProposal(t *testing.T) {
 	assert := assert.New(t)
 
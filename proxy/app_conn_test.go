@@ -151,3 +151,18 @@ func TestInfo(t *testing.T) {
 		t.Error("Expected ResponseInfo with one element '{\"size\":0}' but got something else")
 	}
 }
+
+func TestSetCheckTxConcurrency(t *testing.T) {
+	local := NewAppConnMempool(abcicli.NewLocalClient(nil, kvstore.NewKVStoreApplication()))
+	if err := SetCheckTxConcurrency(local, 1); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := SetCheckTxConcurrency(local, 2); err == nil {
+		t.Error("Expected an error for the local client")
+	}
+
+	grpc := NewAppConnMempool(abcicli.NewGRPCClient("unix:///tmp/unused.sock", false))
+	if err := SetCheckTxConcurrency(grpc, 2); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
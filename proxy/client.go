@@ -6,8 +6,6 @@ import (
 	"github.com/pkg/errors"
 
 	abcicli "github.com/tendermint/tendermint/abci/client"
-	"github.com/tendermint/tendermint/abci/example/counter"
-	"github.com/tendermint/tendermint/abci/example/kvstore"
 	"github.com/tendermint/tendermint/abci/types"
 )
 
@@ -63,20 +61,13 @@ func (r *remoteClientCreator) NewABCIClient() (abcicli.Client, error) {
 //-----------------------------------------------------------------
 // default
 
+// DefaultClientCreator returns a local client creator for the application
+// registered under addr (see RegisterApp), and a remote one connecting to addr
+// otherwise.
 func DefaultClientCreator(addr, transport, dbDir string) ClientCreator {
-	switch addr {
-	case "counter":
-		return NewLocalClientCreator(counter.NewCounterApplication(false))
-	case "counter_serial":
-		return NewLocalClientCreator(counter.NewCounterApplication(true))
-	case "kvstore":
-		return NewLocalClientCreator(kvstore.NewKVStoreApplication())
-	case "persistent_kvstore":
-		return NewLocalClientCreator(kvstore.NewPersistentKVStoreApplication(dbDir))
-	case "noop":
-		return NewLocalClientCreator(types.NewBaseApplication())
-	default:
-		mustConnect := false // loop retrying
-		return NewRemoteClientCreator(addr, transport, mustConnect)
+	if creator, ok := registeredApp(addr); ok {
+		return NewLocalClientCreator(creator(dbDir))
 	}
+	mustConnect := false // loop retrying
+	return NewRemoteClientCreator(addr, transport, mustConnect)
 }
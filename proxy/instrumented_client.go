@@ -0,0 +1,390 @@
+package proxy
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// ErrAppUnresponsive is returned by the ABCI calls of a connection once the
+// app didn't respond to one of them within the call timeout.
+type ErrAppUnresponsive struct {
+	Connection string
+	Method     string
+	Timeout    time.Duration
+}
+
+func (e ErrAppUnresponsive) Error() string {
+	return fmt.Sprintf("the app didn't respond to %s on the %s connection within %v",
+		e.Method, e.Connection, e.Timeout)
+}
+
+// instrumentedClient records the duration and the failures of the ABCI calls
+// made on a connection in the Metrics.
+//
+// If the timeout isn't 0, it also acts as a circuit breaker: once the app
+// doesn't respond to a call within the timeout, the call returns
+// ErrAppUnresponsive, the client is stopped (so the calls blocked on a remote
+// app return), and the following calls fail right away with the same error.
+// The error is also sent on unresponsive, if there's room.
+//
+// The duration of the async calls (CheckTx and DeliverTx) is measured until
+// their response gets to the response callback, in the order of the calls.
+type instrumentedClient struct {
+	abcicli.Client
+
+	metrics      *Metrics
+	connection   string
+	timeout      time.Duration
+	logger       log.Logger
+	unresponsive chan<- ErrAppUnresponsive
+
+	callMtx sync.Mutex // keeps the order of the async calls that of pending
+
+	mtx     sync.Mutex
+	pending map[string]*list.List // the async calls without a response, by method
+	resCb   abcicli.Callback
+	err     *ErrAppUnresponsive // set once the circuit is open
+}
+
+// pendingCall is an async call waiting for its response.
+type pendingCall struct {
+	start time.Time
+	timer *time.Timer // nil without timeout
+}
+
+func newInstrumentedClient(cli abcicli.Client, connection string, metrics *Metrics,
+	timeout time.Duration, unresponsive chan<- ErrAppUnresponsive, logger log.Logger) *instrumentedClient {
+	ic := &instrumentedClient{
+		Client:       cli,
+		metrics:      metrics,
+		connection:   connection,
+		timeout:      timeout,
+		logger:       logger,
+		unresponsive: unresponsive,
+		pending:      make(map[string]*list.List),
+	}
+	cli.SetResponseCallback(ic.didRecvResponse)
+	return ic
+}
+
+func (ic *instrumentedClient) SetResponseCallback(cb abcicli.Callback) {
+	ic.mtx.Lock()
+	ic.resCb = cb
+	ic.mtx.Unlock()
+}
+
+// Error returns ErrAppUnresponsive once the circuit is open, and the error of
+// the client otherwise.
+func (ic *instrumentedClient) Error() error {
+	if err := ic.circuitErr(); err != nil {
+		return err
+	}
+	return ic.Client.Error()
+}
+
+func (ic *instrumentedClient) circuitErr() error {
+	ic.mtx.Lock()
+	defer ic.mtx.Unlock()
+	if ic.err == nil {
+		return nil
+	}
+	return *ic.err
+}
+
+// open opens the circuit, as the app didn't respond to the call of the method
+// in time.
+func (ic *instrumentedClient) open(method string) error {
+	err := ErrAppUnresponsive{Connection: ic.connection, Method: method, Timeout: ic.timeout}
+	ic.metrics.MethodTimeouts.With("connection", ic.connection, "method", method).Add(1)
+
+	ic.mtx.Lock()
+	if ic.err != nil {
+		ic.mtx.Unlock()
+		return err
+	}
+	ic.err = &err
+	ic.mtx.Unlock()
+
+	ic.dropPending()
+	ic.logger.Error("The app is unresponsive, failing the ABCI calls of the connection", "err", err)
+	select {
+	case ic.unresponsive <- err:
+	default:
+	}
+	ic.Client.Stop()
+	return err
+}
+
+// dropPending drops the async calls waiting for their response, which won't
+// come (e.g. the connection was lost).
+func (ic *instrumentedClient) dropPending() {
+	ic.mtx.Lock()
+	pending := ic.pending
+	ic.pending = make(map[string]*list.List)
+	ic.mtx.Unlock()
+
+	for _, calls := range pending {
+		for e := calls.Front(); e != nil; e = e.Next() {
+			if timer := e.Value.(pendingCall).timer; timer != nil {
+				timer.Stop()
+			}
+		}
+	}
+}
+
+func (ic *instrumentedClient) observe(method string, start time.Time, failed bool) {
+	ic.metrics.MethodTiming.With("connection", ic.connection, "method", method).Observe(time.Since(start).Seconds())
+	if failed {
+		ic.metrics.MethodErrors.With("connection", ic.connection, "method", method).Add(1)
+	}
+}
+
+// syncCall makes the call, unless the circuit is open, and returns
+// ErrAppUnresponsive if it doesn't return within the timeout.
+func (ic *instrumentedClient) syncCall(method string, call func() error) error {
+	if err := ic.circuitErr(); err != nil {
+		return err
+	}
+	start := time.Now()
+	if ic.timeout == 0 {
+		err := call()
+		ic.observe(method, start, err != nil)
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- call() }()
+	timer := time.NewTimer(ic.timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		ic.observe(method, start, err != nil)
+		return err
+	case <-timer.C:
+		ic.observe(method, start, true)
+		return ic.open(method)
+	}
+}
+
+// asyncCall makes the call, unless the circuit is open, and opens it if the
+// response doesn't get to the response callback within the timeout.
+func (ic *instrumentedClient) asyncCall(method string, req *types.Request, call func() *abcicli.ReqRes) *abcicli.ReqRes {
+	if ic.circuitErr() != nil {
+		// Like a stopped client: the waiters are released, without a response.
+		reqres := abcicli.NewReqRes(req)
+		reqres.Done()
+		return reqres
+	}
+
+	ic.callMtx.Lock()
+	defer ic.callMtx.Unlock()
+
+	pc := pendingCall{start: time.Now()}
+	if ic.timeout > 0 {
+		pc.timer = time.AfterFunc(ic.timeout, func() { ic.open(method) })
+	}
+	ic.mtx.Lock()
+	calls, ok := ic.pending[method]
+	if !ok {
+		calls = list.New()
+		ic.pending[method] = calls
+	}
+	e := calls.PushBack(pc)
+	ic.mtx.Unlock()
+
+	// NOTE: the local client calls the response callback before returning.
+	reqres := call()
+	if ic.Client.Error() != nil {
+		// The call failed (e.g. the connection was lost), without a response.
+		ic.mtx.Lock()
+		calls.Remove(e)
+		ic.mtx.Unlock()
+		if pc.timer != nil {
+			pc.timer.Stop()
+		}
+	}
+	return reqres
+}
+
+func (ic *instrumentedClient) didRecvResponse(req *types.Request, res *types.Response) {
+	var method string
+	switch req.Value.(type) {
+	case *types.Request_CheckTx:
+		method = "check_tx"
+	case *types.Request_DeliverTx:
+		method = "deliver_tx"
+	}
+
+	ic.mtx.Lock()
+	var call *list.Element
+	if calls, ok := ic.pending[method]; ok {
+		if call = calls.Front(); call != nil {
+			calls.Remove(call)
+		}
+	}
+	cb := ic.resCb
+	ic.mtx.Unlock()
+
+	if call != nil {
+		pc := call.Value.(pendingCall)
+		if pc.timer != nil {
+			pc.timer.Stop()
+		}
+		failed := res == nil
+		switch r := res.GetValue().(type) {
+		case *types.Response_Exception:
+			failed = true
+		case *types.Response_CheckTx:
+			failed = r.CheckTx == nil
+		case *types.Response_DeliverTx:
+			failed = r.DeliverTx == nil
+		}
+		ic.observe(method, pc.start, failed)
+	}
+	if cb != nil {
+		cb(req, res)
+	}
+}
+
+func (ic *instrumentedClient) CheckTxAsync(tx []byte) *abcicli.ReqRes {
+	return ic.asyncCall("check_tx", types.ToRequestCheckTx(tx),
+		func() *abcicli.ReqRes { return ic.Client.CheckTxAsync(tx) })
+}
+
+func (ic *instrumentedClient) DeliverTxAsync(tx []byte) *abcicli.ReqRes {
+	return ic.asyncCall("deliver_tx", types.ToRequestDeliverTx(tx),
+		func() *abcicli.ReqRes { return ic.Client.DeliverTxAsync(tx) })
+}
+
+// NOTE: the results of the calls which time out are dropped, the sync methods
+// return nil with the error.
+
+func (ic *instrumentedClient) FlushSync() error {
+	return ic.syncCall("flush", ic.Client.FlushSync)
+}
+
+func (ic *instrumentedClient) EchoSync(msg string) (*types.ResponseEcho, error) {
+	var res *types.ResponseEcho
+	if err := ic.syncCall("echo", func() (err error) { res, err = ic.Client.EchoSync(msg); return }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ic *instrumentedClient) InfoSync(req types.RequestInfo) (*types.ResponseInfo, error) {
+	var res *types.ResponseInfo
+	if err := ic.syncCall("info", func() (err error) { res, err = ic.Client.InfoSync(req); return }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ic *instrumentedClient) QuerySync(req types.RequestQuery) (*types.ResponseQuery, error) {
+	var res *types.ResponseQuery
+	if err := ic.syncCall("query", func() (err error) { res, err = ic.Client.QuerySync(req); return }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ic *instrumentedClient) InitChainSync(req types.RequestInitChain) (*types.ResponseInitChain, error) {
+	var res *types.ResponseInitChain
+	if err := ic.syncCall("init_chain", func() (err error) { res, err = ic.Client.InitChainSync(req); return }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ic *instrumentedClient) BeginBlockSync(req types.RequestBeginBlock) (*types.ResponseBeginBlock, error) {
+	var res *types.ResponseBeginBlock
+	if err := ic.syncCall("begin_block", func() (err error) { res, err = ic.Client.BeginBlockSync(req); return }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ic *instrumentedClient) EndBlockSync(req types.RequestEndBlock) (*types.ResponseEndBlock, error) {
+	var res *types.ResponseEndBlock
+	if err := ic.syncCall("end_block", func() (err error) { res, err = ic.Client.EndBlockSync(req); return }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ic *instrumentedClient) CommitSync() (*types.ResponseCommit, error) {
+	var res *types.ResponseCommit
+	if err := ic.syncCall("commit", func() (err error) { res, err = ic.Client.CommitSync(); return }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ic *instrumentedClient) ExtendVoteSync(req types.RequestExtendVote) (*types.ResponseExtendVote, error) {
+	var res *types.ResponseExtendVote
+	if err := ic.syncCall("extend_vote", func() (err error) { res, err = ic.Client.ExtendVoteSync(req); return }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ic *instrumentedClient) VerifyVoteExtensionSync(req types.RequestVerifyVoteExtension) (*types.ResponseVerifyVoteExtension, error) {
+	var res *types.ResponseVerifyVoteExtension
+	if err := ic.syncCall("verify_vote_extension", func() (err error) { res, err = ic.Client.VerifyVoteExtensionSync(req); return }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ic *instrumentedClient) PrepareProposalSync(req types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error) {
+	var res *types.ResponsePrepareProposal
+	if err := ic.syncCall("prepare_proposal", func() (err error) { res, err = ic.Client.PrepareProposalSync(req); return }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ic *instrumentedClient) ProcessProposalSync(req types.RequestProcessProposal) (*types.ResponseProcessProposal, error) {
+	var res *types.ResponseProcessProposal
+	if err := ic.syncCall("process_proposal", func() (err error) { res, err = ic.Client.ProcessProposalSync(req); return }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ic *instrumentedClient) ListSnapshotsSync(req types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
+	var res *types.ResponseListSnapshots
+	if err := ic.syncCall("list_snapshots", func() (err error) { res, err = ic.Client.ListSnapshotsSync(req); return }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ic *instrumentedClient) OfferSnapshotSync(req types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
+	var res *types.ResponseOfferSnapshot
+	if err := ic.syncCall("offer_snapshot", func() (err error) { res, err = ic.Client.OfferSnapshotSync(req); return }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ic *instrumentedClient) LoadSnapshotChunkSync(req types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
+	var res *types.ResponseLoadSnapshotChunk
+	if err := ic.syncCall("load_snapshot_chunk", func() (err error) { res, err = ic.Client.LoadSnapshotChunkSync(req); return }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (ic *instrumentedClient) ApplySnapshotChunkSync(req types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
+	var res *types.ResponseApplySnapshotChunk
+	if err := ic.syncCall("apply_snapshot_chunk", func() (err error) { res, err = ic.Client.ApplySnapshotChunkSync(req); return }); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
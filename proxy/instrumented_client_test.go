@@ -0,0 +1,183 @@
+package proxy
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/abci/server"
+	"github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// testObservations records the values observed or added, by label values.
+type testObservations struct {
+	mtx    *sync.Mutex
+	values map[string][]float64
+	lvs    []string
+}
+
+func newTestObservations() *testObservations {
+	return &testObservations{mtx: new(sync.Mutex), values: make(map[string][]float64)}
+}
+
+func (o *testObservations) with(labelValues ...string) *testObservations {
+	return &testObservations{mtx: o.mtx, values: o.values, lvs: append(o.lvs[:len(o.lvs):len(o.lvs)], labelValues...)}
+}
+
+func (o *testObservations) record(value float64) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	key := strings.Join(o.lvs, ",")
+	o.values[key] = append(o.values[key], value)
+}
+
+func (o *testObservations) count(labelValues ...string) int {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	return len(o.values[strings.Join(labelValues, ",")])
+}
+
+type testHistogram struct{ *testObservations }
+
+func (h testHistogram) With(labelValues ...string) metrics.Histogram {
+	return testHistogram{h.with(labelValues...)}
+}
+func (h testHistogram) Observe(value float64) { h.record(value) }
+
+type testCounter struct{ *testObservations }
+
+func (c testCounter) With(labelValues ...string) metrics.Counter {
+	return testCounter{c.with(labelValues...)}
+}
+func (c testCounter) Add(delta float64) { c.record(delta) }
+
+func TestAppConnsMetrics(t *testing.T) {
+	timing, errs, timeouts := newTestObservations(), newTestObservations(), newTestObservations()
+	metrics := &Metrics{
+		MethodTiming:   testHistogram{timing},
+		MethodErrors:   testCounter{errs},
+		MethodTimeouts: testCounter{timeouts},
+	}
+	conns := NewAppConns(NewLocalClientCreator(kvstore.NewKVStoreApplication()),
+		WithMetrics(metrics), WithCallTimeout(time.Minute))
+	require.NoError(t, conns.Start())
+	defer conns.Stop()
+
+	// The response callbacks are still called.
+	var responses int
+	conns.Mempool().SetResponseCallback(func(*types.Request, *types.Response) { responses++ })
+	conns.Mempool().CheckTxAsync([]byte("a=1"))
+	require.NoError(t, conns.Mempool().FlushSync())
+	assert.Equal(t, 1, responses)
+
+	conns.Consensus().DeliverTxAsync([]byte("a=1"))
+	_, err := conns.Consensus().CommitSync()
+	require.NoError(t, err)
+	_, err = conns.Query().InfoSync(RequestInfo)
+	require.NoError(t, err)
+
+	for _, call := range [][]string{
+		{"mempool", "check_tx"},
+		{"mempool", "flush"},
+		{"consensus", "deliver_tx"},
+		{"consensus", "commit"},
+		{"query", "info"},
+	} {
+		lvs := []string{"connection", call[0], "method", call[1]}
+		assert.Equal(t, 1, timing.count(lvs...), "%v", call)
+		assert.Zero(t, timeouts.count(lvs...), "%v", call)
+		assert.Zero(t, errs.count(lvs...), "%v", call)
+	}
+
+	// The local client still can't run CheckTx requests concurrently.
+	assert.Error(t, SetCheckTxConcurrency(conns.Mempool(), 2))
+}
+
+// blockingApp doesn't respond to DeliverTx and Commit until unblocked.
+type blockingApp struct {
+	*kvstore.KVStoreApplication
+	unblock chan struct{}
+}
+
+func (app blockingApp) DeliverTx(tx []byte) types.ResponseDeliverTx {
+	<-app.unblock
+	return app.KVStoreApplication.DeliverTx(tx)
+}
+
+func (app blockingApp) Commit() types.ResponseCommit {
+	<-app.unblock
+	return app.KVStoreApplication.Commit()
+}
+
+// startBlockingApp serves a blockingApp on a socket, and connects to it with
+// the call timeout. The returned function stops them.
+func startBlockingApp(t *testing.T, timeout time.Duration) (AppConns, func()) {
+	sockPath := fmt.Sprintf("unix:///tmp/blocking_%v.sock", cmn.RandStr(6))
+	app := blockingApp{kvstore.NewKVStoreApplication(), make(chan struct{})}
+	s := server.NewSocketServer(sockPath, app)
+	s.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	require.NoError(t, s.Start())
+
+	conns := NewAppConns(NewRemoteClientCreator(sockPath, SOCKET, true), WithCallTimeout(timeout))
+	conns.SetLogger(log.TestingLogger())
+	require.NoError(t, conns.Start())
+	return conns, func() {
+		conns.Stop()
+		close(app.unblock)
+		s.Stop()
+	}
+}
+
+func TestAppConnsCallTimeout(t *testing.T) {
+	timeout := 100 * time.Millisecond
+
+	t.Run("sync", func(t *testing.T) {
+		conns, stop := startBlockingApp(t, timeout)
+		defer stop()
+
+		_, err := conns.Consensus().CommitSync()
+		expected := ErrAppUnresponsive{Connection: "consensus", Method: "commit", Timeout: timeout}
+		require.Equal(t, expected, err)
+		select {
+		case err := <-conns.Unresponsive():
+			assert.Equal(t, expected, err)
+		default:
+			t.Fatal("expected the error on Unresponsive")
+		}
+		assert.Equal(t, expected, conns.Consensus().Error())
+
+		// The following calls fail right away.
+		start := time.Now()
+		_, err = conns.Consensus().EndBlockSync(types.RequestEndBlock{})
+		assert.Equal(t, expected, err)
+		reqres := conns.Consensus().DeliverTxAsync([]byte("a=1"))
+		reqres.Wait()
+		assert.Nil(t, reqres.Response)
+		assert.True(t, time.Since(start) < timeout)
+	})
+
+	t.Run("async", func(t *testing.T) {
+		conns, stop := startBlockingApp(t, timeout)
+		defer stop()
+
+		// The client is stopped, releasing the blocked call.
+		reqres := conns.Consensus().DeliverTxAsync([]byte("a=1"))
+		reqres.Wait()
+		assert.Nil(t, reqres.Response)
+		select {
+		case err := <-conns.Unresponsive():
+			assert.Equal(t, ErrAppUnresponsive{Connection: "consensus", Method: "deliver_tx", Timeout: timeout}, err)
+		case <-time.After(time.Second):
+			t.Fatal("expected the error on Unresponsive")
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package proxy
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "abci_connection"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Duration of the ABCI calls, in seconds, by connection (mempool,
+	// consensus, query or snapshot) and method.
+	MethodTiming metrics.Histogram
+	// Number of ABCI calls which failed (the client returned an error, or the
+	// app an exception), by connection and method.
+	MethodErrors metrics.Counter
+	// Number of ABCI calls which timed out (see WithCallTimeout), by
+	// connection and method.
+	MethodTimeouts metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		MethodTiming: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "method_timing_seconds",
+			Help:      "Duration of the ABCI calls, in seconds, by connection and method.",
+			Buckets:   []float64{.0001, .0004, .002, .009, .02, .1, .65, 2, 6, 25},
+		}, append(labels, "connection", "method")).With(labelsAndValues...),
+		MethodErrors: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "method_errors",
+			Help:      "Number of failed ABCI calls, by connection and method.",
+		}, append(labels, "connection", "method")).With(labelsAndValues...),
+		MethodTimeouts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "method_timeouts",
+			Help:      "Number of ABCI calls which timed out, by connection and method.",
+		}, append(labels, "connection", "method")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		MethodTiming:   discard.NewHistogram(),
+		MethodErrors:   discard.NewCounter(),
+		MethodTimeouts: discard.NewCounter(),
+	}
+}
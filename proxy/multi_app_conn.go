@@ -1,8 +1,11 @@
 package proxy
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 
+	abcicli "github.com/tendermint/tendermint/abci/client"
 	cmn "github.com/tendermint/tendermint/libs/common"
 )
 
@@ -15,16 +18,55 @@ type AppConns interface {
 	Mempool() AppConnMempool
 	Consensus() AppConnConsensus
 	Query() AppConnQuery
+	Snapshot() AppConnSnapshot
+
+	// Unresponsive receives the error of the first ABCI call the app didn't
+	// respond to within the call timeout (see WithCallTimeout).
+	Unresponsive() <-chan ErrAppUnresponsive
+
+	// Reconnected receives when a connection reconnected to the app (see
+	// WithReconnect). The calls of the reconnected connections fail with
+	// abcicli.ErrConnectionLost until they're resumed: ResumeConsensus lets
+	// the consensus and query connections through, to bring the app back to
+	// the state, and ResumeAll all of them once it's done, so that e.g. no tx
+	// is checked against a stale state.
+	Reconnected() <-chan struct{}
+	ResumeConsensus()
+	ResumeAll()
+}
+
+func NewAppConns(clientCreator ClientCreator, options ...AppConnsOption) AppConns {
+	return NewMultiAppConn(clientCreator, options...)
+}
+
+// AppConnsOption sets an optional parameter on the AppConns.
+type AppConnsOption func(*multiAppConn)
+
+// WithMetrics sets the metrics the ABCI calls are recorded in.
+func WithMetrics(metrics *Metrics) AppConnsOption {
+	return func(app *multiAppConn) { app.metrics = metrics }
+}
+
+// WithCallTimeout sets the duration after which an ABCI call fails with
+// ErrAppUnresponsive (0 for none). The connection is then closed, and its
+// following calls fail right away with the same error.
+func WithCallTimeout(timeout time.Duration) AppConnsOption {
+	return func(app *multiAppConn) { app.callTimeout = timeout }
 }
 
-func NewAppConns(clientCreator ClientCreator) AppConns {
-	return NewMultiAppConn(clientCreator)
+// WithReconnect makes the connections to a remote socket app reconnect when
+// they're lost (e.g. the app restarted), rather than failing. The calls in
+// flight and the calls made while reconnecting fail with
+// abcicli.ErrConnectionLost. The connections only resume with ResumeConsensus
+// and ResumeAll.
+func WithReconnect() AppConnsOption {
+	return func(app *multiAppConn) { app.reconnect = true }
 }
 
 //-----------------------------
 // multiAppConn implements AppConns
 
-// a multiAppConn is made of a few appConns (mempool, consensus, query)
+// a multiAppConn is made of a few appConns (mempool, consensus, query, snapshot)
 // and manages their underlying abci clients
 // TODO: on app restart, clients must reboot together
 type multiAppConn struct {
@@ -33,14 +75,30 @@ type multiAppConn struct {
 	mempoolConn   *appConnMempool
 	consensusConn *appConnConsensus
 	queryConn     *appConnQuery
+	snapshotConn  *appConnSnapshot
 
 	clientCreator ClientCreator
+	metrics       *Metrics
+	callTimeout   time.Duration
+	unresponsive  chan ErrAppUnresponsive
+
+	reconnect   bool
+	reconnected chan struct{}
+	// The clients which reconnect, by connection
+	reconnectingClients map[string]abcicli.ReconnectingClient
 }
 
 // Make all necessary abci connections to the application
-func NewMultiAppConn(clientCreator ClientCreator) *multiAppConn {
+func NewMultiAppConn(clientCreator ClientCreator, options ...AppConnsOption) *multiAppConn {
 	multiAppConn := &multiAppConn{
 		clientCreator: clientCreator,
+		unresponsive:  make(chan ErrAppUnresponsive, 1),
+
+		reconnected:         make(chan struct{}, 1),
+		reconnectingClients: make(map[string]abcicli.ReconnectingClient),
+	}
+	for _, option := range options {
+		option(multiAppConn)
 	}
 	multiAppConn.BaseService = *cmn.NewBaseService(nil, "multiAppConn", multiAppConn)
 	return multiAppConn
@@ -61,6 +119,37 @@ func (app *multiAppConn) Query() AppConnQuery {
 	return app.queryConn
 }
 
+// Returns the snapshot Connection
+func (app *multiAppConn) Snapshot() AppConnSnapshot {
+	return app.snapshotConn
+}
+
+// Returns the channel receiving the error of the first unresponsive call
+func (app *multiAppConn) Unresponsive() <-chan ErrAppUnresponsive {
+	return app.unresponsive
+}
+
+// Returns the channel receiving when a connection reconnected
+func (app *multiAppConn) Reconnected() <-chan struct{} {
+	return app.reconnected
+}
+
+// Lets the calls of the reconnected consensus and query connections through
+func (app *multiAppConn) ResumeConsensus() {
+	for _, connection := range []string{"consensus", "query"} {
+		if rc, ok := app.reconnectingClients[connection]; ok {
+			rc.Resume()
+		}
+	}
+}
+
+// Lets the calls of all the reconnected connections through
+func (app *multiAppConn) ResumeAll() {
+	for _, rc := range app.reconnectingClients {
+		rc.Resume()
+	}
+}
+
 func (app *multiAppConn) OnStart() error {
 	// query connection
 	querycli, err := app.clientCreator.NewABCIClient()
@@ -68,17 +157,34 @@ func (app *multiAppConn) OnStart() error {
 		return errors.Wrap(err, "Error creating ABCI client (query connection)")
 	}
 	querycli.SetLogger(app.Logger.With("module", "abci-client", "connection", "query"))
+	querycli = app.instrument(querycli, "query")
+	app.setReconnect(querycli, "query")
 	if err := querycli.Start(); err != nil {
 		return errors.Wrap(err, "Error starting ABCI client (query connection)")
 	}
 	app.queryConn = NewAppConnQuery(querycli)
 
+	// snapshot connection
+	snapshotcli, err := app.clientCreator.NewABCIClient()
+	if err != nil {
+		return errors.Wrap(err, "Error creating ABCI client (snapshot connection)")
+	}
+	snapshotcli.SetLogger(app.Logger.With("module", "abci-client", "connection", "snapshot"))
+	snapshotcli = app.instrument(snapshotcli, "snapshot")
+	app.setReconnect(snapshotcli, "snapshot")
+	if err := snapshotcli.Start(); err != nil {
+		return errors.Wrap(err, "Error starting ABCI client (snapshot connection)")
+	}
+	app.snapshotConn = NewAppConnSnapshot(snapshotcli)
+
 	// mempool connection
 	memcli, err := app.clientCreator.NewABCIClient()
 	if err != nil {
 		return errors.Wrap(err, "Error creating ABCI client (mempool connection)")
 	}
 	memcli.SetLogger(app.Logger.With("module", "abci-client", "connection", "mempool"))
+	memcli = app.instrument(memcli, "mempool")
+	app.setReconnect(memcli, "mempool")
 	if err := memcli.Start(); err != nil {
 		return errors.Wrap(err, "Error starting ABCI client (mempool connection)")
 	}
@@ -90,6 +196,8 @@ func (app *multiAppConn) OnStart() error {
 		return errors.Wrap(err, "Error creating ABCI client (consensus connection)")
 	}
 	concli.SetLogger(app.Logger.With("module", "abci-client", "connection", "consensus"))
+	concli = app.instrument(concli, "consensus")
+	app.setReconnect(concli, "consensus")
 	if err := concli.Start(); err != nil {
 		return errors.Wrap(err, "Error starting ABCI client (consensus connection)")
 	}
@@ -97,3 +205,46 @@ func (app *multiAppConn) OnStart() error {
 
 	return nil
 }
+
+// instrument wraps the client of the connection to record its calls, and
+// fail them after the call timeout, if there are metrics or a call timeout.
+func (app *multiAppConn) instrument(cli abcicli.Client, connection string) abcicli.Client {
+	if app.metrics == nil && app.callTimeout == 0 {
+		return cli
+	}
+	metrics := app.metrics
+	if metrics == nil {
+		metrics = NopMetrics()
+	}
+	return newInstrumentedClient(cli, connection, metrics, app.callTimeout, app.unresponsive,
+		app.Logger.With("connection", connection))
+}
+
+// setReconnect makes the client of the connection reconnect, if WithReconnect
+// was set and it can. Its calls resume with ResumeConsensus or ResumeAll.
+func (app *multiAppConn) setReconnect(cli abcicli.Client, connection string) {
+	if !app.reconnect {
+		return
+	}
+	ic, instrumented := cli.(*instrumentedClient)
+	if instrumented {
+		cli = ic.Client
+	}
+	rc, ok := cli.(abcicli.ReconnectingClient)
+	if !ok {
+		// e.g. an in-process app
+		return
+	}
+
+	var onLost func()
+	if instrumented {
+		onLost = ic.dropPending
+	}
+	app.reconnectingClients[connection] = rc
+	rc.SetReconnect(onLost, func() {
+		select {
+		case app.reconnected <- struct{}{}:
+		default:
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package proxy
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/abci/server"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// reconnectedClientCreator creates socket clients which also notify when they
+// reconnected, so that the test knows when all the connections did.
+type reconnectedClientCreator struct {
+	addr        string
+	reconnected chan struct{}
+}
+
+func (c reconnectedClientCreator) NewABCIClient() (abcicli.Client, error) {
+	return reconnectedClient{abcicli.NewSocketClient(c.addr, true), c.reconnected}, nil
+}
+
+type reconnectedClient struct {
+	abcicli.ReconnectingClient
+	reconnected chan struct{}
+}
+
+func (c reconnectedClient) SetReconnect(onLost, onReconnect func()) {
+	c.ReconnectingClient.SetReconnect(onLost, func() {
+		onReconnect()
+		c.reconnected <- struct{}{}
+	})
+}
+
+func TestAppConnsReconnect(t *testing.T) {
+	port := 20000 + cmn.RandInt32()%10000
+	addr := fmt.Sprintf("localhost:%d", port)
+
+	s, err := server.NewServer(addr, SOCKET, kvstore.NewKVStoreApplication())
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+
+	creator := reconnectedClientCreator{addr, make(chan struct{}, 4)}
+	conns := NewAppConns(creator, WithReconnect())
+	conns.SetLogger(log.TestingLogger())
+	require.NoError(t, conns.Start())
+	defer conns.Stop()
+
+	// The app restarts.
+	s.Stop()
+	s, err = server.NewServer(addr, SOCKET, kvstore.NewKVStoreApplication())
+	require.NoError(t, err)
+	require.NoError(t, s.Start())
+	defer s.Stop()
+	for i := 0; i < 4; i++ {
+		select {
+		case <-creator.reconnected:
+		case <-time.After(10 * time.Second):
+			t.Fatal("expected all the connections to reconnect")
+		}
+	}
+	select {
+	case <-conns.Reconnected():
+	default:
+		t.Fatal("expected the reconnection to be signaled")
+	}
+
+	// The app is being resynced: only the consensus and query connections
+	// are resumed, and CheckTx fails.
+	conns.ResumeConsensus()
+	_, err = conns.Query().EchoSync("hello")
+	require.NoError(t, err)
+	_, err = conns.Consensus().CommitSync()
+	require.NoError(t, err)
+
+	reqres := conns.Mempool().CheckTxAsync([]byte("a=1"))
+	reqres.Wait()
+	assert.Nil(t, reqres.Response)
+	assert.Equal(t, abcicli.ErrConnectionLost, conns.Mempool().Error())
+
+	// The app is resynced.
+	conns.ResumeAll()
+	reqres = conns.Mempool().CheckTxAsync([]byte("a=1"))
+	require.NoError(t, conns.Mempool().FlushSync())
+	reqres.Wait()
+	require.NotNil(t, reqres.Response)
+	assert.True(t, reqres.Response.GetCheckTx().IsOK())
+	assert.NoError(t, conns.Mempool().Error())
+}
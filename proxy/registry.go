@@ -0,0 +1,78 @@
+package proxy
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/tendermint/tendermint/abci/example/counter"
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+// AppCreator creates an in-process application, storing its data in dbDir.
+type AppCreator func(dbDir string) types.Application
+
+var (
+	appsMtx sync.RWMutex
+	apps    = map[string]AppCreator{}
+)
+
+func init() {
+	RegisterApp("counter", func(string) types.Application {
+		return counter.NewCounterApplication(false)
+	})
+	RegisterApp("counter_serial", func(string) types.Application {
+		return counter.NewCounterApplication(true)
+	})
+	RegisterApp("kvstore", func(string) types.Application {
+		return kvstore.NewKVStoreApplication()
+	})
+	RegisterApp("persistent_kvstore", func(dbDir string) types.Application {
+		return kvstore.NewPersistentKVStoreApplication(dbDir)
+	})
+	RegisterApp("noop", func(string) types.Application {
+		return types.NewBaseApplication()
+	})
+}
+
+// RegisterApp registers an in-process application under the name, so that
+// `proxy_app = "<name>"` (e.g. `tendermint node --proxy_app=<name>`) runs it
+// in the node, rather than connecting to an app at that address. It is meant
+// to be called from the init function of a package compiled into the binary.
+//
+// It panics if the name is empty or already registered.
+func RegisterApp(name string, creator AppCreator) {
+	if name == "" {
+		panic("proxy: RegisterApp with an empty name")
+	}
+	if creator == nil {
+		panic(fmt.Sprintf("proxy: RegisterApp %q with a nil creator", name))
+	}
+
+	appsMtx.Lock()
+	defer appsMtx.Unlock()
+	if _, ok := apps[name]; ok {
+		panic(fmt.Sprintf("proxy: app %q registered twice", name))
+	}
+	apps[name] = creator
+}
+
+// RegisteredApps returns the names of the registered applications, sorted.
+func RegisteredApps() []string {
+	appsMtx.RLock()
+	defer appsMtx.RUnlock()
+	names := make([]string, 0, len(apps))
+	for name := range apps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func registeredApp(name string) (AppCreator, bool) {
+	appsMtx.RLock()
+	defer appsMtx.RUnlock()
+	creator, ok := apps[name]
+	return creator, ok
+}
@@ -0,0 +1,48 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+type registryTestApp struct {
+	types.BaseApplication
+	dbDir string
+}
+
+func (app *registryTestApp) Info(req types.RequestInfo) types.ResponseInfo {
+	return types.ResponseInfo{Data: app.dbDir}
+}
+
+func TestRegisterApp(t *testing.T) {
+	RegisterApp("registry_test", func(dbDir string) types.Application {
+		return &registryTestApp{dbDir: dbDir}
+	})
+	assert.Panics(t, func() {
+		RegisterApp("registry_test", func(string) types.Application { return types.NewBaseApplication() })
+	})
+	assert.Panics(t, func() {
+		RegisterApp("", func(string) types.Application { return types.NewBaseApplication() })
+	})
+
+	names := RegisteredApps()
+	for _, name := range []string{"counter", "kvstore", "noop", "persistent_kvstore", "registry_test"} {
+		assert.Contains(t, names, name)
+	}
+
+	// The registered app runs in-process.
+	cc := DefaultClientCreator("registry_test", "socket", "/data")
+	require.IsType(t, &localClientCreator{}, cc)
+	cli, err := cc.NewABCIClient()
+	require.NoError(t, err)
+	res, err := cli.InfoSync(RequestInfo)
+	require.NoError(t, err)
+	assert.Equal(t, "/data", res.Data)
+
+	// Anything else is the address of a remote app.
+	assert.IsType(t, &remoteClientCreator{}, DefaultClientCreator("tcp://127.0.0.1:26658", "socket", "/data"))
+}
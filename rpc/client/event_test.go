@@ -130,6 +130,43 @@ func testTxEventsSent(t *testing.T, broadcastMethod string) {
 	}
 }
 
+func TestEvents(t *testing.T) {
+	for i, c := range GetClients() {
+		_, _, tx := MakeTxKV()
+		bres, err := c.BroadcastTxCommit(tx)
+		require.Nil(t, err, "%d: %+v", i, err)
+		require.True(t, bres.DeliverTx.IsOK())
+
+		// the event of the tx is kept by the node
+		query := fmt.Sprintf("tm.event='Tx' AND tx.hash='%X'", types.Tx(tx).Hash())
+		res, err := c.Events(query, "", 0, 0)
+		require.Nil(t, err, "%d: %+v", i, err)
+		require.Len(t, res.Items, 1)
+		txe, ok := res.Items[0].Data.(types.EventDataTx)
+		require.True(t, ok, "%d: %#v", i, res.Items[0].Data)
+		require.EqualValues(t, tx, txe.Tx)
+		require.NotEmpty(t, res.Items[0].Cursor)
+		require.False(t, res.More)
+		require.False(t, res.Truncated)
+
+		// and none after it
+		res, err = c.Events(query, res.Items[0].Cursor, 0, 0)
+		require.Nil(t, err, "%d: %+v", i, err)
+		require.Empty(t, res.Items)
+		require.False(t, res.Truncated)
+
+		// the next block is waited for
+		res, err = c.Events("tm.event='NewBlock'", res.Newest, 1, 5)
+		require.Nil(t, err, "%d: %+v", i, err)
+		require.Len(t, res.Items, 1)
+		_, ok = res.Items[0].Data.(types.EventDataNewBlock)
+		require.True(t, ok, "%d: %#v", i, res.Items[0].Data)
+
+		_, err = c.Events("", "not a cursor", 0, 0)
+		require.Error(t, err)
+	}
+}
+
 // Test HTTPClient resubscribes upon disconnect && subscription error.
 // Test Local client resubscribes upon subscription error.
 func TestClientsResubscribe(t *testing.T) {
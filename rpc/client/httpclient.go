@@ -52,6 +52,19 @@ func NewHTTP(remote, wsEndpoint string) *HTTP {
 	}
 }
 
+// SetAdminToken sets the token sent as "Authorization: Bearer <token>" with
+// every request, which the admin commands like DialPeer require.
+func (c *HTTP) SetAdminToken(token string) {
+	c.rpc.SetHeader("Authorization", "Bearer "+token)
+}
+
+// SetAuthToken sets the token sent as "Authorization: Bearer <token>" with
+// every request, to authenticate to the nodes with rpc.auth_tokens. It
+// replaces the admin token, which authenticates too.
+func (c *HTTP) SetAuthToken(token string) {
+	c.rpc.SetHeader("Authorization", "Bearer "+token)
+}
+
 var (
 	_ Client        = (*HTTP)(nil)
 	_ NetworkClient = (*HTTP)(nil)
@@ -91,6 +104,15 @@ func (c *HTTP) ABCIQueryWithOptions(path string, data cmn.HexBytes, opts ABCIQue
 	return result, nil
 }
 
+func (c *HTTP) ABCIQueryBatch(queries []ctypes.RequestABCIQuery) (*ctypes.ResultABCIQueryBatch, error) {
+	result := new(ctypes.ResultABCIQueryBatch)
+	_, err := c.rpc.Call("abci_query_batch", map[string]interface{}{"queries": queries}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "abci_query_batch")
+	}
+	return result, nil
+}
+
 func (c *HTTP) BroadcastTxCommit(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
 	result := new(ctypes.ResultBroadcastTxCommit)
 	_, err := c.rpc.Call("broadcast_tx_commit", map[string]interface{}{"tx": tx}, result)
@@ -108,6 +130,42 @@ func (c *HTTP) BroadcastTxSync(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	return c.broadcastTX("broadcast_tx_sync", tx)
 }
 
+func (c *HTTP) BroadcastTxBatch(txs types.Txs) (*ctypes.ResultBroadcastTxBatch, error) {
+	result := new(ctypes.ResultBroadcastTxBatch)
+	_, err := c.rpc.Call("broadcast_tx_batch", map[string]interface{}{"txs": txs}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "broadcast_tx_batch")
+	}
+	return result, nil
+}
+
+func (c *HTTP) BroadcastEvidence(ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
+	result := new(ctypes.ResultBroadcastEvidence)
+	_, err := c.rpc.Call("broadcast_evidence", map[string]interface{}{"evidence": ev}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "broadcast_evidence")
+	}
+	return result, nil
+}
+
+func (c *HTTP) UnconfirmedEvidence(limit int) (*ctypes.ResultUnconfirmedEvidence, error) {
+	result := new(ctypes.ResultUnconfirmedEvidence)
+	_, err := c.rpc.Call("unconfirmed_evidence", map[string]interface{}{"limit": limit}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "unconfirmed_evidence")
+	}
+	return result, nil
+}
+
+func (c *HTTP) Evidence(hash []byte) (*ctypes.ResultEvidence, error) {
+	result := new(ctypes.ResultEvidence)
+	_, err := c.rpc.Call("evidence", map[string]interface{}{"hash": hash}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "evidence")
+	}
+	return result, nil
+}
+
 func (c *HTTP) broadcastTX(route string, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
 	result := new(ctypes.ResultBroadcastTx)
 	_, err := c.rpc.Call(route, map[string]interface{}{"tx": tx}, result)
@@ -135,6 +193,15 @@ func (c *HTTP) NumUnconfirmedTxs() (*ctypes.ResultUnconfirmedTxs, error) {
 	return result, nil
 }
 
+func (c *HTTP) TxStatus(hash []byte) (*ctypes.ResultTxStatus, error) {
+	result := new(ctypes.ResultTxStatus)
+	_, err := c.rpc.Call("tx_status", map[string]interface{}{"hash": hash}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx_status")
+	}
+	return result, nil
+}
+
 func (c *HTTP) NetInfo() (*ctypes.ResultNetInfo, error) {
 	result := new(ctypes.ResultNetInfo)
 	_, err := c.rpc.Call("net_info", map[string]interface{}{}, result)
@@ -144,6 +211,60 @@ func (c *HTTP) NetInfo() (*ctypes.ResultNetInfo, error) {
 	return result, nil
 }
 
+func (c *HTTP) DialPeer(peer string, persistent bool) (*ctypes.ResultDialPeer, error) {
+	result := new(ctypes.ResultDialPeer)
+	_, err := c.rpc.Call("dial_peer", map[string]interface{}{"peer": peer, "persistent": persistent}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "DialPeer")
+	}
+	return result, nil
+}
+
+func (c *HTTP) RemovePeer(peerID string) (*ctypes.ResultRemovePeer, error) {
+	result := new(ctypes.ResultRemovePeer)
+	_, err := c.rpc.Call("remove_peer", map[string]interface{}{"peer_id": peerID}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "RemovePeer")
+	}
+	return result, nil
+}
+
+func (c *HTTP) PeerBehaviour(peerID string) (*ctypes.ResultPeerBehaviour, error) {
+	result := new(ctypes.ResultPeerBehaviour)
+	_, err := c.rpc.Call("peer_behaviour", map[string]interface{}{"peer_id": peerID}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "PeerBehaviour")
+	}
+	return result, nil
+}
+
+func (c *HTTP) NetworkGraph() (*ctypes.ResultNetworkGraph, error) {
+	result := new(ctypes.ResultNetworkGraph)
+	_, err := c.rpc.Call("network_graph", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "NetworkGraph")
+	}
+	return result, nil
+}
+
+func (c *HTTP) SyncStatus() (*ctypes.ResultSyncStatus, error) {
+	result := new(ctypes.ResultSyncStatus)
+	_, err := c.rpc.Call("sync_status", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "SyncStatus")
+	}
+	return result, nil
+}
+
+func (c *HTTP) AppHashMismatch() (*ctypes.ResultAppHashMismatch, error) {
+	result := new(ctypes.ResultAppHashMismatch)
+	_, err := c.rpc.Call("app_hash_mismatch", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "AppHashMismatch")
+	}
+	return result, nil
+}
+
 func (c *HTTP) DumpConsensusState() (*ctypes.ResultDumpConsensusState, error) {
 	result := new(ctypes.ResultDumpConsensusState)
 	_, err := c.rpc.Call("dump_consensus_state", map[string]interface{}{}, result)
@@ -182,6 +303,17 @@ func (c *HTTP) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockch
 	return result, nil
 }
 
+func (c *HTTP) ValidatorChanges(from, to int64) (*ctypes.ResultValidatorChanges, error) {
+	result := new(ctypes.ResultValidatorChanges)
+	_, err := c.rpc.Call("validator_changes",
+		map[string]interface{}{"from": from, "to": to},
+		result)
+	if err != nil {
+		return nil, errors.Wrap(err, "ValidatorChanges")
+	}
+	return result, nil
+}
+
 func (c *HTTP) Genesis() (*ctypes.ResultGenesis, error) {
 	result := new(ctypes.ResultGenesis)
 	_, err := c.rpc.Call("genesis", map[string]interface{}{}, result)
@@ -232,12 +364,26 @@ func (c *HTTP) Tx(hash []byte, prove bool) (*ctypes.ResultTx, error) {
 }
 
 func (c *HTTP) TxSearch(query string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error) {
+	return c.TxSearchWithOptions(query, prove, TxSearchOptions{Page: page, PerPage: perPage})
+}
+
+func (c *HTTP) TxSearchWithOptions(query string, prove bool, opts TxSearchOptions) (*ctypes.ResultTxSearch, error) {
 	result := new(ctypes.ResultTxSearch)
 	params := map[string]interface{}{
 		"query":    query,
 		"prove":    prove,
-		"page":     page,
-		"per_page": perPage,
+		"page":     opts.Page,
+		"per_page": opts.PerPage,
+	}
+	// only sent if set, for the nodes which don't know them
+	if opts.OrderBy != "" {
+		params["order_by"] = opts.OrderBy
+	}
+	if opts.Cursor != "" {
+		params["cursor"] = opts.Cursor
+	}
+	if opts.SkipCount {
+		params["skip_count"] = true
 	}
 	_, err := c.rpc.Call("tx_search", params, result)
 	if err != nil {
@@ -246,6 +392,20 @@ func (c *HTTP) TxSearch(query string, prove bool, page, perPage int) (*ctypes.Re
 	return result, nil
 }
 
+func (c *HTTP) Events(query, after string, maxItems, waitTime int) (*ctypes.ResultEvents, error) {
+	result := new(ctypes.ResultEvents)
+	_, err := c.rpc.Call("events", map[string]interface{}{
+		"query":     query,
+		"after":     after,
+		"max_items": maxItems,
+		"wait_time": waitTime,
+	}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "Events")
+	}
+	return result, nil
+}
+
 func (c *HTTP) Validators(height *int64) (*ctypes.ResultValidators, error) {
 	result := new(ctypes.ResultValidators)
 	_, err := c.rpc.Call("validators", map[string]interface{}{"height": height}, result)
@@ -255,6 +415,15 @@ func (c *HTTP) Validators(height *int64) (*ctypes.ResultValidators, error) {
 	return result, nil
 }
 
+func (c *HTTP) ConsensusParams(height *int64) (*ctypes.ResultConsensusParams, error) {
+	result := new(ctypes.ResultConsensusParams)
+	_, err := c.rpc.Call("consensus_params", map[string]interface{}{"height": height}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "ConsensusParams")
+	}
+	return result, nil
+}
+
 /** websocket event stuff here... **/
 
 type WSEvents struct {
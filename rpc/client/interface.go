@@ -37,11 +37,13 @@ type ABCIClient interface {
 	ABCIQuery(path string, data cmn.HexBytes) (*ctypes.ResultABCIQuery, error)
 	ABCIQueryWithOptions(path string, data cmn.HexBytes,
 		opts ABCIQueryOptions) (*ctypes.ResultABCIQuery, error)
+	ABCIQueryBatch(queries []ctypes.RequestABCIQuery) (*ctypes.ResultABCIQueryBatch, error)
 
 	// Writing to abci app
 	BroadcastTxCommit(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error)
 	BroadcastTxAsync(tx types.Tx) (*ctypes.ResultBroadcastTx, error)
 	BroadcastTxSync(tx types.Tx) (*ctypes.ResultBroadcastTx, error)
+	BroadcastTxBatch(txs types.Txs) (*ctypes.ResultBroadcastTxBatch, error)
 }
 
 // SignClient groups together the interfaces need to get valid
@@ -51,14 +53,17 @@ type SignClient interface {
 	BlockResults(height *int64) (*ctypes.ResultBlockResults, error)
 	Commit(height *int64) (*ctypes.ResultCommit, error)
 	Validators(height *int64) (*ctypes.ResultValidators, error)
+	ConsensusParams(height *int64) (*ctypes.ResultConsensusParams, error)
 	Tx(hash []byte, prove bool) (*ctypes.ResultTx, error)
 	TxSearch(query string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error)
+	TxSearchWithOptions(query string, prove bool, opts TxSearchOptions) (*ctypes.ResultTxSearch, error)
 }
 
 // HistoryClient shows us data from genesis to now in large chunks.
 type HistoryClient interface {
 	Genesis() (*ctypes.ResultGenesis, error)
 	BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error)
+	ValidatorChanges(from, to int64) (*ctypes.ResultValidatorChanges, error)
 }
 
 type StatusClient interface {
@@ -76,6 +81,7 @@ type Client interface {
 	HistoryClient
 	StatusClient
 	EventsClient
+	EvidenceClient
 }
 
 // NetworkClient is general info about the network state.  May not
@@ -85,6 +91,10 @@ type Client interface {
 // by concrete implementations.
 type NetworkClient interface {
 	NetInfo() (*ctypes.ResultNetInfo, error)
+	PeerBehaviour(peerID string) (*ctypes.ResultPeerBehaviour, error)
+	NetworkGraph() (*ctypes.ResultNetworkGraph, error)
+	SyncStatus() (*ctypes.ResultSyncStatus, error)
+	AppHashMismatch() (*ctypes.ResultAppHashMismatch, error)
 	DumpConsensusState() (*ctypes.ResultDumpConsensusState, error)
 	ConsensusState() (*ctypes.ResultConsensusState, error)
 	Health() (*ctypes.ResultHealth, error)
@@ -105,10 +115,23 @@ type EventsClient interface {
 	Unsubscribe(ctx context.Context, subscriber, query string) error
 	// UnsubscribeAll unsubscribes given subscriber from all the queries.
 	UnsubscribeAll(ctx context.Context, subscriber string) error
+	// Events returns up to maxItems events matching the query after the
+	// cursor of the last event received (the oldest events of the node if
+	// empty), waiting up to waitTime seconds for one if there are none.
+	Events(query, after string, maxItems, waitTime int) (*ctypes.ResultEvents, error)
+}
+
+// EvidenceClient is used for submitting the evidence of the malicious
+// behaviour, and inspecting the evidence pool.
+type EvidenceClient interface {
+	BroadcastEvidence(ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error)
+	UnconfirmedEvidence(limit int) (*ctypes.ResultUnconfirmedEvidence, error)
+	Evidence(hash []byte) (*ctypes.ResultEvidence, error)
 }
 
 // MempoolClient shows us data about current mempool state.
 type MempoolClient interface {
 	UnconfirmedTxs(limit int) (*ctypes.ResultUnconfirmedTxs, error)
 	NumUnconfirmedTxs() (*ctypes.ResultUnconfirmedTxs, error)
+	TxStatus(hash []byte) (*ctypes.ResultTxStatus, error)
 }
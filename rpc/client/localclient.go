@@ -11,6 +11,7 @@ import (
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
 	nm "github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/p2p/pex"
 	"github.com/tendermint/tendermint/rpc/core"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
@@ -85,6 +86,10 @@ func (c *Local) ABCIQueryWithOptions(path string, data cmn.HexBytes, opts ABCIQu
 	return core.ABCIQuery(c.ctx, path, data, opts.Height, opts.Prove)
 }
 
+func (c *Local) ABCIQueryBatch(queries []ctypes.RequestABCIQuery) (*ctypes.ResultABCIQueryBatch, error) {
+	return core.ABCIQueryBatch(c.ctx, queries)
+}
+
 func (c *Local) BroadcastTxCommit(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
 	return core.BroadcastTxCommit(c.ctx, tx)
 }
@@ -97,6 +102,22 @@ func (c *Local) BroadcastTxSync(tx types.Tx) (*ctypes.ResultBroadcastTx, error)
 	return core.BroadcastTxSync(c.ctx, tx)
 }
 
+func (c *Local) BroadcastTxBatch(txs types.Txs) (*ctypes.ResultBroadcastTxBatch, error) {
+	return core.BroadcastTxBatch(c.ctx, txs)
+}
+
+func (c *Local) BroadcastEvidence(ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
+	return core.BroadcastEvidence(c.ctx, ev)
+}
+
+func (c *Local) UnconfirmedEvidence(limit int) (*ctypes.ResultUnconfirmedEvidence, error) {
+	return core.UnconfirmedEvidence(c.ctx, limit)
+}
+
+func (c *Local) Evidence(hash []byte) (*ctypes.ResultEvidence, error) {
+	return core.Evidence(c.ctx, hash)
+}
+
 func (c *Local) UnconfirmedTxs(limit int) (*ctypes.ResultUnconfirmedTxs, error) {
 	return core.UnconfirmedTxs(c.ctx, limit)
 }
@@ -105,10 +126,30 @@ func (c *Local) NumUnconfirmedTxs() (*ctypes.ResultUnconfirmedTxs, error) {
 	return core.NumUnconfirmedTxs(c.ctx)
 }
 
+func (c *Local) TxStatus(hash []byte) (*ctypes.ResultTxStatus, error) {
+	return core.TxStatus(c.ctx, hash)
+}
+
 func (c *Local) NetInfo() (*ctypes.ResultNetInfo, error) {
 	return core.NetInfo(c.ctx)
 }
 
+func (c *Local) PeerBehaviour(peerID string) (*ctypes.ResultPeerBehaviour, error) {
+	return core.PeerBehaviour(c.ctx, peerID)
+}
+
+func (c *Local) NetworkGraph() (*ctypes.ResultNetworkGraph, error) {
+	return core.NetworkGraph(c.ctx)
+}
+
+func (c *Local) SyncStatus() (*ctypes.ResultSyncStatus, error) {
+	return core.SyncStatus(c.ctx)
+}
+
+func (c *Local) AppHashMismatch() (*ctypes.ResultAppHashMismatch, error) {
+	return core.AppHashMismatch(c.ctx)
+}
+
 func (c *Local) DumpConsensusState() (*ctypes.ResultDumpConsensusState, error) {
 	return core.DumpConsensusState(c.ctx)
 }
@@ -129,10 +170,50 @@ func (c *Local) DialPeers(peers []string, persistent bool) (*ctypes.ResultDialPe
 	return core.UnsafeDialPeers(c.ctx, peers, persistent)
 }
 
+func (c *Local) SetPersistentPeers(peers []string) (*ctypes.ResultPersistentPeers, error) {
+	return core.UnsafeSetPersistentPeers(c.ctx, peers)
+}
+
+func (c *Local) SetPrivatePeerIDs(ids []string) (*ctypes.ResultPrivatePeerIDs, error) {
+	return core.UnsafeSetPrivatePeerIDs(c.ctx, ids)
+}
+
+func (c *Local) DialPeer(peer string, persistent bool) (*ctypes.ResultDialPeer, error) {
+	return core.DialPeer(c.ctx, peer, persistent)
+}
+
+func (c *Local) RemovePeer(peerID string) (*ctypes.ResultRemovePeer, error) {
+	return core.RemovePeer(c.ctx, peerID)
+}
+
+func (c *Local) DialStates() (*ctypes.ResultDialStates, error) {
+	return core.DialStates(c.ctx)
+}
+
+func (c *Local) AddrBook() (*ctypes.ResultAddrBook, error) {
+	return core.AddrBook(c.ctx)
+}
+
+func (c *Local) AddrBookImport(entries []pex.AddrBookEntry) (*ctypes.ResultAddrBookImport, error) {
+	return core.AddrBookImport(c.ctx, entries)
+}
+
+func (c *Local) AddrBookMarkGood(addr string) (*ctypes.ResultAddrBookMark, error) {
+	return core.AddrBookMarkGood(c.ctx, addr)
+}
+
+func (c *Local) AddrBookMarkBad(addr string) (*ctypes.ResultAddrBookMark, error) {
+	return core.AddrBookMarkBad(c.ctx, addr)
+}
+
 func (c *Local) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
 	return core.BlockchainInfo(c.ctx, minHeight, maxHeight)
 }
 
+func (c *Local) ValidatorChanges(from, to int64) (*ctypes.ResultValidatorChanges, error) {
+	return core.ValidatorChanges(c.ctx, from, to)
+}
+
 func (c *Local) Genesis() (*ctypes.ResultGenesis, error) {
 	return core.Genesis(c.ctx)
 }
@@ -153,16 +234,28 @@ func (c *Local) Validators(height *int64) (*ctypes.ResultValidators, error) {
 	return core.Validators(c.ctx, height)
 }
 
+func (c *Local) ConsensusParams(height *int64) (*ctypes.ResultConsensusParams, error) {
+	return core.ConsensusParams(c.ctx, height)
+}
+
 func (c *Local) Tx(hash []byte, prove bool) (*ctypes.ResultTx, error) {
 	return core.Tx(c.ctx, hash, prove)
 }
 
 func (c *Local) TxSearch(query string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error) {
-	return core.TxSearch(c.ctx, query, prove, page, perPage)
+	return core.TxSearch(c.ctx, query, prove, page, perPage, "", "", false)
+}
+
+func (c *Local) TxSearchWithOptions(query string, prove bool, opts TxSearchOptions) (*ctypes.ResultTxSearch, error) {
+	return core.TxSearch(c.ctx, query, prove, opts.Page, opts.PerPage, opts.OrderBy, opts.Cursor, opts.SkipCount)
+}
+
+func (c *Local) Events(query, after string, maxItems, waitTime int) (*ctypes.ResultEvents, error) {
+	return core.Events(c.ctx, query, after, maxItems, waitTime)
 }
 
 func (c *Local) Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
-	q, err := tmquery.New(query)
+	q, err := tmquery.NewCompat(query)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse query")
 	}
@@ -231,7 +324,7 @@ func (c *Local) resubscribe(subscriber string, q tmpubsub.Query) types.Subscript
 }
 
 func (c *Local) Unsubscribe(ctx context.Context, subscriber, query string) error {
-	q, err := tmquery.New(query)
+	q, err := tmquery.NewCompat(query)
 	if err != nil {
 		return errors.Wrap(err, "failed to parse query")
 	}
@@ -40,6 +40,15 @@ func (a ABCIApp) ABCIQueryWithOptions(path string, data cmn.HexBytes, opts clien
 	return &ctypes.ResultABCIQuery{Response: q}, nil
 }
 
+func (a ABCIApp) ABCIQueryBatch(queries []ctypes.RequestABCIQuery) (*ctypes.ResultABCIQueryBatch, error) {
+	res := &ctypes.ResultABCIQueryBatch{}
+	for _, q := range queries {
+		r, _ := a.ABCIQueryWithOptions(q.Path, q.Data, client.ABCIQueryOptions{Height: q.Height, Prove: q.Prove})
+		res.Responses = append(res.Responses, ctypes.ResultBatchQuery{Response: r.Response})
+	}
+	return res, nil
+}
+
 // NOTE: Caller should call a.App.Commit() separately,
 // this function does not actually wait for a commit.
 // TODO: Make it wait for a commit and set res.Height appropriately.
@@ -72,6 +81,19 @@ func (a ABCIApp) BroadcastTxSync(tx types.Tx) (*ctypes.ResultBroadcastTx, error)
 	return &ctypes.ResultBroadcastTx{Code: c.Code, Data: c.Data, Log: c.Log, Hash: tx.Hash()}, nil
 }
 
+func (a ABCIApp) BroadcastTxBatch(txs types.Txs) (*ctypes.ResultBroadcastTxBatch, error) {
+	res := &ctypes.ResultBroadcastTxBatch{}
+	for _, tx := range txs {
+		c := a.App.CheckTx(tx)
+		// and this gets written in a background thread...
+		if !c.IsErr() {
+			go func(tx types.Tx) { a.App.DeliverTx(tx) }(tx) // nolint: errcheck
+		}
+		res.Txs = append(res.Txs, ctypes.ResultBatchTx{Code: c.Code, Data: c.Data, Log: c.Log, Hash: tx.Hash()})
+	}
+	return res, nil
+}
+
 // ABCIMock will send all abci related request to the named app,
 // so you can test app behavior from a client without needing
 // an entire tendermint node
@@ -103,6 +125,18 @@ func (m ABCIMock) ABCIQueryWithOptions(path string, data cmn.HexBytes, opts clie
 	return &ctypes.ResultABCIQuery{Response: resQuery}, nil
 }
 
+func (m ABCIMock) ABCIQueryBatch(queries []ctypes.RequestABCIQuery) (*ctypes.ResultABCIQueryBatch, error) {
+	batch := &ctypes.ResultABCIQueryBatch{}
+	for _, q := range queries {
+		res, err := m.Query.GetResponse(QueryArgs{q.Path, q.Data, q.Height, q.Prove})
+		if err != nil {
+			return nil, err
+		}
+		batch.Responses = append(batch.Responses, ctypes.ResultBatchQuery{Response: res.(abci.ResponseQuery)})
+	}
+	return batch, nil
+}
+
 func (m ABCIMock) BroadcastTxCommit(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
 	res, err := m.BroadcastCommit.GetResponse(tx)
 	if err != nil {
@@ -127,6 +161,19 @@ func (m ABCIMock) BroadcastTxSync(tx types.Tx) (*ctypes.ResultBroadcastTx, error
 	return res.(*ctypes.ResultBroadcastTx), nil
 }
 
+func (m ABCIMock) BroadcastTxBatch(txs types.Txs) (*ctypes.ResultBroadcastTxBatch, error) {
+	batch := &ctypes.ResultBroadcastTxBatch{}
+	for _, tx := range txs {
+		res, err := m.Broadcast.GetResponse(tx)
+		if err != nil {
+			return nil, err
+		}
+		r := res.(*ctypes.ResultBroadcastTx)
+		batch.Txs = append(batch.Txs, ctypes.ResultBatchTx{Code: r.Code, Data: r.Data, Log: r.Log, Hash: r.Hash})
+	}
+	return batch, nil
+}
+
 // ABCIRecorder can wrap another type (ABCIApp, ABCIMock, or Client)
 // and record all ABCI related calls.
 type ABCIRecorder struct {
@@ -177,6 +224,17 @@ func (r *ABCIRecorder) ABCIQueryWithOptions(path string, data cmn.HexBytes, opts
 	return res, err
 }
 
+func (r *ABCIRecorder) ABCIQueryBatch(queries []ctypes.RequestABCIQuery) (*ctypes.ResultABCIQueryBatch, error) {
+	res, err := r.Client.ABCIQueryBatch(queries)
+	r.addCall(Call{
+		Name:     "abci_query_batch",
+		Args:     queries,
+		Response: res,
+		Error:    err,
+	})
+	return res, err
+}
+
 func (r *ABCIRecorder) BroadcastTxCommit(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
 	res, err := r.Client.BroadcastTxCommit(tx)
 	r.addCall(Call{
@@ -209,3 +267,14 @@ func (r *ABCIRecorder) BroadcastTxSync(tx types.Tx) (*ctypes.ResultBroadcastTx,
 	})
 	return res, err
 }
+
+func (r *ABCIRecorder) BroadcastTxBatch(txs types.Txs) (*ctypes.ResultBroadcastTxBatch, error) {
+	res, err := r.Client.BroadcastTxBatch(txs)
+	r.addCall(Call{
+		Name:     "broadcast_tx_batch",
+		Args:     txs,
+		Response: res,
+		Error:    err,
+	})
+	return res, err
+}
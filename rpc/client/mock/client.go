@@ -18,6 +18,7 @@ import (
 	"reflect"
 
 	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/p2p/pex"
 	"github.com/tendermint/tendermint/rpc/client"
 	"github.com/tendermint/tendermint/rpc/core"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
@@ -36,6 +37,7 @@ type Client struct {
 	client.HistoryClient
 	client.StatusClient
 	client.EventsClient
+	client.EvidenceClient
 	cmn.Service
 }
 
@@ -92,6 +94,10 @@ func (c Client) ABCIQueryWithOptions(path string, data cmn.HexBytes, opts client
 	return core.ABCIQuery(&rpctypes.Context{}, path, data, opts.Height, opts.Prove)
 }
 
+func (c Client) ABCIQueryBatch(queries []ctypes.RequestABCIQuery) (*ctypes.ResultABCIQueryBatch, error) {
+	return core.ABCIQueryBatch(&rpctypes.Context{}, queries)
+}
+
 func (c Client) BroadcastTxCommit(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
 	return core.BroadcastTxCommit(&rpctypes.Context{}, tx)
 }
@@ -104,10 +110,42 @@ func (c Client) BroadcastTxSync(tx types.Tx) (*ctypes.ResultBroadcastTx, error)
 	return core.BroadcastTxSync(&rpctypes.Context{}, tx)
 }
 
+func (c Client) BroadcastTxBatch(txs types.Txs) (*ctypes.ResultBroadcastTxBatch, error) {
+	return core.BroadcastTxBatch(&rpctypes.Context{}, txs)
+}
+
+func (c Client) BroadcastEvidence(ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
+	return core.BroadcastEvidence(&rpctypes.Context{}, ev)
+}
+
+func (c Client) UnconfirmedEvidence(limit int) (*ctypes.ResultUnconfirmedEvidence, error) {
+	return core.UnconfirmedEvidence(&rpctypes.Context{}, limit)
+}
+
+func (c Client) Evidence(hash []byte) (*ctypes.ResultEvidence, error) {
+	return core.Evidence(&rpctypes.Context{}, hash)
+}
+
 func (c Client) NetInfo() (*ctypes.ResultNetInfo, error) {
 	return core.NetInfo(&rpctypes.Context{})
 }
 
+func (c Client) PeerBehaviour(peerID string) (*ctypes.ResultPeerBehaviour, error) {
+	return core.PeerBehaviour(&rpctypes.Context{}, peerID)
+}
+
+func (c Client) NetworkGraph() (*ctypes.ResultNetworkGraph, error) {
+	return core.NetworkGraph(&rpctypes.Context{})
+}
+
+func (c Client) SyncStatus() (*ctypes.ResultSyncStatus, error) {
+	return core.SyncStatus(&rpctypes.Context{})
+}
+
+func (c Client) AppHashMismatch() (*ctypes.ResultAppHashMismatch, error) {
+	return core.AppHashMismatch(&rpctypes.Context{})
+}
+
 func (c Client) DialSeeds(seeds []string) (*ctypes.ResultDialSeeds, error) {
 	return core.UnsafeDialSeeds(&rpctypes.Context{}, seeds)
 }
@@ -116,10 +154,50 @@ func (c Client) DialPeers(peers []string, persistent bool) (*ctypes.ResultDialPe
 	return core.UnsafeDialPeers(&rpctypes.Context{}, peers, persistent)
 }
 
+func (c Client) SetPersistentPeers(peers []string) (*ctypes.ResultPersistentPeers, error) {
+	return core.UnsafeSetPersistentPeers(&rpctypes.Context{}, peers)
+}
+
+func (c Client) SetPrivatePeerIDs(ids []string) (*ctypes.ResultPrivatePeerIDs, error) {
+	return core.UnsafeSetPrivatePeerIDs(&rpctypes.Context{}, ids)
+}
+
+func (c Client) DialPeer(peer string, persistent bool) (*ctypes.ResultDialPeer, error) {
+	return core.DialPeer(&rpctypes.Context{}, peer, persistent)
+}
+
+func (c Client) RemovePeer(peerID string) (*ctypes.ResultRemovePeer, error) {
+	return core.RemovePeer(&rpctypes.Context{}, peerID)
+}
+
+func (c Client) DialStates() (*ctypes.ResultDialStates, error) {
+	return core.DialStates(&rpctypes.Context{})
+}
+
+func (c Client) AddrBook() (*ctypes.ResultAddrBook, error) {
+	return core.AddrBook(&rpctypes.Context{})
+}
+
+func (c Client) AddrBookImport(entries []pex.AddrBookEntry) (*ctypes.ResultAddrBookImport, error) {
+	return core.AddrBookImport(&rpctypes.Context{}, entries)
+}
+
+func (c Client) AddrBookMarkGood(addr string) (*ctypes.ResultAddrBookMark, error) {
+	return core.AddrBookMarkGood(&rpctypes.Context{}, addr)
+}
+
+func (c Client) AddrBookMarkBad(addr string) (*ctypes.ResultAddrBookMark, error) {
+	return core.AddrBookMarkBad(&rpctypes.Context{}, addr)
+}
+
 func (c Client) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
 	return core.BlockchainInfo(&rpctypes.Context{}, minHeight, maxHeight)
 }
 
+func (c Client) ValidatorChanges(from, to int64) (*ctypes.ResultValidatorChanges, error) {
+	return core.ValidatorChanges(&rpctypes.Context{}, from, to)
+}
+
 func (c Client) Genesis() (*ctypes.ResultGenesis, error) {
 	return core.Genesis(&rpctypes.Context{})
 }
@@ -135,3 +213,7 @@ func (c Client) Commit(height *int64) (*ctypes.ResultCommit, error) {
 func (c Client) Validators(height *int64) (*ctypes.ResultValidators, error) {
 	return core.Validators(&rpctypes.Context{}, height)
 }
+
+func (c Client) ConsensusParams(height *int64) (*ctypes.ResultConsensusParams, error) {
+	return core.ConsensusParams(&rpctypes.Context{}, height)
+}
@@ -5,13 +5,22 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	mempl "github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/privval"
 
 	"github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
 	rpctest "github.com/tendermint/tendermint/rpc/test"
 	"github.com/tendermint/tendermint/types"
 )
@@ -82,6 +91,18 @@ func TestNetInfo(t *testing.T) {
 	}
 }
 
+func TestSyncStatus(t *testing.T) {
+	for i, c := range GetClients() {
+		nc, ok := c.(client.NetworkClient)
+		require.True(t, ok, "%d", i)
+		status, err := nc.SyncStatus()
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.False(t, status.Syncing)
+		assert.Equal(t, "0.00", status.BlocksPerSecond)
+		assert.Empty(t, status.Peers)
+	}
+}
+
 func TestDumpConsensusState(t *testing.T) {
 	for i, c := range GetClients() {
 		// FIXME: fix server so it doesn't panic on invalid input
@@ -91,6 +112,7 @@ func TestDumpConsensusState(t *testing.T) {
 		require.Nil(t, err, "%d: %+v", i, err)
 		assert.NotEmpty(t, cons.RoundState)
 		assert.Empty(t, cons.Peers)
+		assert.True(t, cons.Locks.Height > 0)
 	}
 }
 
@@ -136,6 +158,39 @@ func TestGenesisAndValidators(t *testing.T) {
 	}
 }
 
+func TestValidatorChanges(t *testing.T) {
+	for i, c := range GetClients() {
+		err := client.WaitForHeight(c, 1, nil)
+		require.Nil(t, err, "%d: %+v", i, err)
+
+		// the validator set of the test node never changes
+		changes, err := c.ValidatorChanges(1, 1)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.EqualValues(t, 1, changes.From, "%d", i)
+		assert.EqualValues(t, 1, changes.To, "%d", i)
+		assert.Empty(t, changes.Changes, "%d", i)
+
+		// the latest heights by default
+		changes, err = c.ValidatorChanges(0, 0)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.True(t, changes.From >= 1, "%d", i)
+		assert.True(t, changes.To-changes.From < 1000, "%d", i)
+		assert.Empty(t, changes.Changes, "%d", i)
+	}
+}
+
+func TestConsensusParams(t *testing.T) {
+	for i, c := range GetClients() {
+		gen, err := c.Genesis()
+		require.Nil(t, err, "%d: %+v", i, err)
+
+		params, err := c.ConsensusParams(nil)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.True(t, params.BlockHeight > 0, "%d", i)
+		assert.Equal(t, *gen.Genesis.ConsensusParams, params.ConsensusParams)
+	}
+}
+
 func TestABCIQuery(t *testing.T) {
 	for i, c := range GetClients() {
 		// write something
@@ -154,6 +209,33 @@ func TestABCIQuery(t *testing.T) {
 	}
 }
 
+func TestABCIQueryBatch(t *testing.T) {
+	for i, c := range GetClients() {
+		k, v, tx := MakeTxKV()
+		bres, err := c.BroadcastTxCommit(tx)
+		require.Nil(t, err, "%d: %+v", i, err)
+		client.WaitForHeight(c, bres.Height+1, nil)
+
+		res, err := c.ABCIQueryBatch([]ctypes.RequestABCIQuery{
+			{Path: "/key", Data: k},
+			{Path: "/key", Data: []byte("missing")},
+			{Path: "/key", Data: k, Prove: true},
+		})
+		require.Nil(t, err, "%d: %+v", i, err)
+		require.Len(t, res.Responses, 3)
+		for j, r := range res.Responses {
+			assert.Empty(t, r.Error, "%d: %d", i, j)
+			assert.True(t, r.Response.IsOK(), "%d: %d", i, j)
+		}
+		assert.EqualValues(t, v, res.Responses[0].Response.Value)
+		assert.Nil(t, res.Responses[1].Response.Value)
+		assert.EqualValues(t, v, res.Responses[2].Response.Value)
+
+		_, err = c.ABCIQueryBatch(nil)
+		assert.Error(t, err, "%d", i)
+	}
+}
+
 // Make some app checks
 func TestAppCalls(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
@@ -266,6 +348,29 @@ func TestBroadcastTxSync(t *testing.T) {
 	}
 }
 
+func TestBroadcastTxBatch(t *testing.T) {
+	for i, c := range GetClients() {
+		_, _, tx1 := MakeTxKV()
+		_, _, tx2 := MakeTxKV()
+		txs := types.Txs{tx1, tx2, tx1}
+		bres, err := c.BroadcastTxBatch(txs)
+		require.Nil(t, err, "%d: %+v", i, err)
+		require.Len(t, bres.Txs, 3)
+		for j, res := range bres.Txs {
+			assert.EqualValues(t, txs[j].Hash(), res.Hash, "%d: %d", i, j)
+		}
+		assert.EqualValues(t, abci.CodeTypeOK, bres.Txs[0].Code)
+		assert.Empty(t, bres.Txs[0].Error)
+		assert.EqualValues(t, abci.CodeTypeOK, bres.Txs[1].Code)
+		assert.Empty(t, bres.Txs[1].Error)
+		// the same tx twice
+		assert.Equal(t, mempl.ErrTxInCache.Error(), bres.Txs[2].Error)
+
+		_, err = c.BroadcastTxBatch(types.Txs{})
+		assert.Error(t, err, "%d", i)
+	}
+}
+
 func TestBroadcastTxCommit(t *testing.T) {
 	require := require.New(t)
 
@@ -323,6 +428,24 @@ func TestNumUnconfirmedTxs(t *testing.T) {
 	mempool.Flush()
 }
 
+func TestTxStatus(t *testing.T) {
+	bres, err := getHTTPClient().BroadcastTxCommit(types.Tx("committed tx"))
+	require.Nil(t, err, "%+v", err)
+
+	for i, c := range GetClients() {
+		mc, ok := c.(client.MempoolClient)
+		require.True(t, ok, "%d", i)
+		res, err := mc.TxStatus(bres.Hash)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.Equal(t, ctypes.TxStatusCommitted, res.Status)
+		assert.Equal(t, bres.Height, res.Height)
+
+		res, err = mc.TxStatus(types.Tx("a different tx").Hash())
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.Equal(t, ctypes.TxStatusUnknown, res.Status)
+	}
+}
+
 func TestTx(t *testing.T) {
 	// first we broadcast a tx
 	c := getHTTPClient()
@@ -439,5 +562,147 @@ func TestTxSearch(t *testing.T) {
 		result, err = c.TxSearch("app.creator='Cosmoshi Neetowoko'", true, 1, 1)
 		require.Nil(t, err, "%+v", err)
 		require.Len(t, result.Txs, 0)
+
+		// page through the txs in descending order with the cursors, without
+		// counting them
+		var txs []*ctypes.ResultTx
+		opts := client.TxSearchOptions{PerPage: 1, OrderBy: "desc", SkipCount: true}
+		for {
+			result, err = c.TxSearchWithOptions("app.creator='Cosmoshi Netowoko'", false, opts)
+			require.Nil(t, err, "%+v", err)
+			assert.Equal(t, -1, result.TotalCount)
+			txs = append(txs, result.Txs...)
+			if result.NextCursor == "" {
+				break
+			}
+			opts.Cursor = result.NextCursor
+		}
+		result, err = c.TxSearch("app.creator='Cosmoshi Netowoko'", false, 1, 100)
+		require.Nil(t, err, "%+v", err)
+		require.Len(t, txs, len(result.Txs))
+		for i, tx := range txs {
+			assert.Equal(t, result.Txs[len(txs)-1-i].Hash, tx.Hash)
+		}
+	}
+}
+
+func TestPeerBehaviour(t *testing.T) {
+	peer := p2p.CreateRandomPeer(false)
+	node.Switch().PeerBehaviour().Errored(peer, p2p.ErrorBehaviourReport{
+		Reason:  p2p.ErrorPeerBehaviourBadMessage,
+		Reactor: "CONSENSUS",
+		Height:  1,
+		Detail:  "invalid vote signature",
+	})
+
+	// The report is processed asynchronously.
+	local := getLocalClient()
+	for i := 0; i < 100; i++ {
+		if _, err := local.PeerBehaviour(string(peer.ID())); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for i, c := range GetClients() {
+		nc, ok := c.(client.NetworkClient)
+		require.True(t, ok, "%d", i)
+
+		res, err := nc.PeerBehaviour(string(peer.ID()))
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.Equal(t, peer.ID(), res.PeerID)
+		assert.Equal(t, []ctypes.PeerBehaviourCount{{Reason: "BadMessage", Count: 1}}, res.ErrorCounts)
+		assert.Empty(t, res.GoodCounts)
+		require.Len(t, res.RecentEvents, 1)
+		assert.Equal(t, "error", res.RecentEvents[0].Kind)
+		assert.Equal(t, "CONSENSUS", res.RecentEvents[0].Reactor)
+		assert.Equal(t, "invalid vote signature", res.RecentEvents[0].Detail)
+
+		_, err = nc.PeerBehaviour("unknown")
+		assert.Error(t, err, "%d", i)
+	}
+}
+
+// newDuplicateVoteEvidence makes the validator of the node sign two
+// precommits for different blocks at the given height.
+func newDuplicateVoteEvidence(t *testing.T, height int64) *types.DuplicateVoteEvidence {
+	config := rpctest.GetConfig()
+	pv := privval.LoadFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	chainID := config.ChainID()
+
+	vote := func(blockHash []byte) *types.Vote {
+		v := &types.Vote{
+			ValidatorAddress: pv.Key.Address,
+			ValidatorIndex:   0,
+			Height:           height,
+			Round:            0,
+			Type:             types.PrecommitType,
+			Timestamp:        time.Now(),
+			BlockID: types.BlockID{
+				Hash:        blockHash,
+				PartsHeader: types.PartSetHeader{Total: 1, Hash: tmhash.Sum(blockHash)},
+			},
+		}
+		sig, err := pv.Key.PrivKey.Sign(v.SignBytes(chainID))
+		require.NoError(t, err)
+		v.Signature = sig
+		return v
+	}
+	return &types.DuplicateVoteEvidence{
+		PubKey: pv.Key.PubKey,
+		VoteA:  vote(tmhash.Sum(cmn.RandBytes(8))),
+		VoteB:  vote(tmhash.Sum(cmn.RandBytes(8))),
+	}
+}
+
+func TestBroadcastEvidence(t *testing.T) {
+	for i, c := range GetClients() {
+		require.Nil(t, client.WaitForHeight(c, 1, nil))
+		status, err := c.Status()
+		require.Nil(t, err, "%d: %+v", i, err)
+
+		ev := newDuplicateVoteEvidence(t, status.SyncInfo.LatestBlockHeight)
+		res, err := c.BroadcastEvidence(ev)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.Equal(t, ev.Hash(), res.Hash)
+
+		// The evidence is pending, or already committed
+		evRes, err := c.Evidence(ev.Hash())
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.Equal(t, ev.Hash(), evRes.Evidence.Hash())
+		assert.True(t, evRes.VerifiedHeight >= status.SyncInfo.LatestBlockHeight)
+		pending, err := c.UnconfirmedEvidence(10)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.Equal(t, len(pending.Evidence), pending.Count)
+		assert.True(t, pending.Count <= pending.Total)
+
+		_, err = c.Evidence([]byte("unknown"))
+		assert.Error(t, err, "%d", i)
+
+		// The votes must be for different blocks
+		ev.VoteB = ev.VoteA
+		_, err = c.BroadcastEvidence(ev)
+		assert.Error(t, err, "%d", i)
 	}
 }
+
+func TestHTTPAdminCalls(t *testing.T) {
+	c := getHTTPClient()
+	id := string(p2p.CreateRandomPeer(false).ID())
+
+	// Admin calls need the token.
+	_, err := c.RemovePeer(id)
+	require.Error(t, err)
+	assert.Equal(t, ctypes.ErrCodeUnauthorized, errors.Cause(err).(*rpctypes.RPCError).Code)
+
+	c.SetAdminToken(rpctest.GetConfig().RPC.AdminToken)
+	_, err = c.RemovePeer(id)
+	require.Error(t, err)
+	assert.Equal(t, ctypes.ErrCodePeerNotFound, errors.Cause(err).(*rpctypes.RPCError).Code)
+
+	self := p2p.IDAddressString(node.NodeInfo().ID(), "127.0.0.1:26656")
+	_, err = c.DialPeer(self, true)
+	require.Error(t, err)
+	assert.Equal(t, ctypes.ErrCodeConnectToSelf, errors.Cause(err).(*rpctypes.RPCError).Code)
+	assert.Empty(t, node.Switch().PersistentPeers())
+}
@@ -9,3 +9,20 @@ type ABCIQueryOptions struct {
 
 // DefaultABCIQueryOptions are latest height (0) and prove false.
 var DefaultABCIQueryOptions = ABCIQueryOptions{Height: 0, Prove: false}
+
+// TxSearchOptions can be used to provide options for TxSearch call other than
+// the page and the number of txs per page.
+type TxSearchOptions struct {
+	Page    int
+	PerPage int
+
+	// "asc" (the default) or "desc"
+	OrderBy string
+
+	// The NextCursor of the previous page, to get the page after it (Page is
+	// then ignored).
+	Cursor string
+
+	// Don't count the txs (TotalCount is then -1).
+	SkipCount bool
+}
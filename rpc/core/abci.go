@@ -1,6 +1,9 @@
 package core
 
 import (
+	"errors"
+	"fmt"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	"github.com/tendermint/tendermint/proxy"
@@ -67,6 +70,95 @@ func ABCIQuery(ctx *rpctypes.Context, path string, data cmn.HexBytes, height int
 	return &ctypes.ResultABCIQuery{Response: *resQuery}, nil
 }
 
+// maxQueriesPerBatch is the maximum number of queries of /abci_query_batch.
+const maxQueriesPerBatch = 100
+
+// Query the application for a batch of information, in a single request.
+// Each query has the parameters of /abci_query, and the responses are in the
+// order of the queries. The queries which failed have an error, and don't
+// fail the others.
+//
+// ```shell
+// curl -X POST 'localhost:26657' -d '{"jsonrpc":"2.0","id":"","method":"abci_query_batch","params":{"queries":[{"path":"/key","data":"61626364"},{"path":"/key","data":"6E616D65","height":"0","prove":true}]}}'
+// ```
+//
+// ```go
+// client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+// err := client.Start()
+// if err != nil {
+//   // handle error
+// }
+// defer client.Stop()
+// result, err := client.ABCIQueryBatch([]ctypes.RequestABCIQuery{
+// 	{Path: "/key", Data: []byte("abcd")},
+// 	{Path: "/key", Data: []byte("name"), Prove: true},
+// })
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+// 	"error": "",
+// 	"result": {
+// 		"responses": [
+// 			{
+// 				"response": {
+// 					"log": "exists",
+// 					"height": "0",
+// 					"value": "61626364",
+// 					"key": "61626364",
+// 					"index": "-1",
+// 					"code": "0"
+// 				}
+// 			},
+// 			{
+// 				"response": {
+// 					"log": "does not exist",
+// 					"height": "0",
+// 					"key": "6E616D65",
+// 					"index": "-1",
+// 					"code": "0"
+// 				}
+// 			}
+// 		]
+// 	},
+// 	"id": "",
+// 	"jsonrpc": "2.0"
+// }
+// ```
+//
+// ### Query Parameters
+//
+// | Parameter | Type               | Default | Required | Description                  |
+// |-----------+--------------------+---------+----------+------------------------------|
+// | queries   | []RequestABCIQuery | nil     | true     | The queries (max: 100)       |
+func ABCIQueryBatch(ctx *rpctypes.Context, queries []ctypes.RequestABCIQuery) (*ctypes.ResultABCIQueryBatch, error) {
+	if len(queries) == 0 {
+		return nil, errors.New("No queries")
+	}
+	if len(queries) > maxQueriesPerBatch {
+		return nil, fmt.Errorf("Too many queries: %d (max: %d)", len(queries), maxQueriesPerBatch)
+	}
+
+	results := make([]ctypes.ResultBatchQuery, len(queries))
+	for i, q := range queries {
+		resQuery, err := proxyAppQuery.QuerySync(abci.RequestQuery{
+			Path:   q.Path,
+			Data:   q.Data,
+			Height: q.Height,
+			Prove:  q.Prove,
+		})
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Response = *resQuery
+	}
+	logger.Info("ABCIQueryBatch", "queries", len(queries))
+	return &ctypes.ResultABCIQueryBatch{Responses: results}, nil
+}
+
 // Get some info about the application.
 //
 // ```shell
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+)
+
+// Authorize implements rpcserver.Authorizer. If authentication is enabled
+// (rpc.auth_tokens or rpc.tls_client_ca_file), the methods not in
+// rpc.public_methods can only be called by the clients which authenticated,
+// with one of the tokens (or the admin token) as "Authorization: Bearer
+// <token>", or with a certificate signed by the client CA.
+//
+// The admin methods still require the admin token, see authorizeAdmin.
+func Authorize(r *http.Request, method string) error {
+	if !config.IsAuthEnabled() || isPublicMethod(method) || authenticated(r) {
+		return nil
+	}
+	return &rpctypes.RPCError{Code: ctypes.ErrCodeUnauthorized, Message: "Unauthorized",
+		Data: method + " requires authentication"}
+}
+
+func isPublicMethod(method string) bool {
+	for _, m := range config.PublicMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticated returns true if the request carries one of the tokens, or if
+// the client sent a certificate verified against the client CA (the server
+// only verifies the certificates if the client CA is set).
+func authenticated(r *http.Request) bool {
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		return true
+	}
+	if config.AdminToken != "" && hasBearerToken(r, config.AdminToken) {
+		return true
+	}
+	for _, token := range config.AuthTokens {
+		if hasBearerToken(r, token) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasBearerToken returns true if the request carries the token as
+// "Authorization: Bearer <token>".
+func hasBearerToken(r *http.Request, token string) bool {
+	header := r.Header.Get("Authorization")
+	bearer := strings.TrimPrefix(header, "Bearer ")
+	return bearer != header && subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cfg "github.com/tendermint/tendermint/config"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+)
+
+func TestAuthorize(t *testing.T) {
+	defer SetConfig(config)
+	rpcConfig := cfg.DefaultRPCConfig()
+	SetConfig(*rpcConfig)
+
+	request := func(header string, verified bool) *http.Request {
+		req, _ := http.NewRequest("POST", "http://localhost/", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		if verified {
+			req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{}}}
+		}
+		return req
+	}
+
+	// No authentication, everyone is authorized.
+	assert.NoError(t, Authorize(request("", false), "broadcast_tx_sync"))
+
+	rpcConfig.AuthTokens = []string{"secret", "other"}
+	rpcConfig.AdminToken = "admin"
+	rpcConfig.PublicMethods = []string{"status", "block"}
+	SetConfig(*rpcConfig)
+
+	testCases := []struct {
+		req        *http.Request
+		method     string
+		authorized bool
+	}{
+		{request("", false), "status", true},
+		{request("", false), "broadcast_tx_sync", false},
+		{request("Bearer wrong", false), "broadcast_tx_sync", false},
+		{request("secret", false), "broadcast_tx_sync", false},
+		{request("Bearer secret", false), "broadcast_tx_sync", true},
+		{request("Bearer other", false), "broadcast_tx_sync", true},
+		{request("Bearer admin", false), "broadcast_tx_sync", true},
+		// client certificate verified against the client CA
+		{request("", true), "broadcast_tx_sync", true},
+	}
+	for i, tc := range testCases {
+		err := Authorize(tc.req, tc.method)
+		if tc.authorized {
+			assert.NoError(t, err, "#%d", i)
+		} else if assert.Error(t, err, "#%d", i) {
+			assert.Equal(t, ctypes.ErrCodeUnauthorized, err.(*rpctypes.RPCError).Code, "#%d", i)
+		}
+	}
+}
@@ -341,6 +341,12 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 // Results are for the height of the block containing the txs.
 // Thus response.results[5] is the results of executing getBlock(h).Txs[5]
 //
+// The results include the EndBlock response of the app, with the validator
+// updates and the consensus param changes of the block. They are kept for
+// the last `abci_responses_retain_heights` heights (all by default); it
+// returns an error with the lowest retained height for the heights before
+// them, or before the snapshot the state was restored from.
+//
 // ```shell
 // curl 'localhost:26657/block_results?height=10'
 // ```
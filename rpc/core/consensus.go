@@ -2,6 +2,8 @@ package core
 
 import (
 	cm "github.com/tendermint/tendermint/consensus"
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
 	sm "github.com/tendermint/tendermint/state"
@@ -66,6 +68,84 @@ func Validators(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultValidato
 		Validators:  validators.Validators}, nil
 }
 
+// Get the changes of the validator set made by the blocks within a range of
+// heights (inclusive), with the voting power before and after each change.
+// The validator set is updated by the EndBlock of a block at height H from
+// height H+2 on. If from or to are not provided, they default to 1 and to the
+// latest height. At most 1000 heights are scanned, ending at to.
+//
+// ```shell
+// curl 'localhost:26657/validator_changes?from=1&to=1000'
+// ```
+//
+// ```go
+// client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+// err := client.Start()
+// if err != nil {
+//   // handle error
+// }
+// defer client.Stop()
+// changes, err := client.ValidatorChanges(1, 1000)
+// ```
+//
+// The above command returns JSON structured like this:
+//
+// ```json
+// {
+// 	"error": "",
+// 	"result": {
+// 		"from": "1",
+// 		"to": "1000",
+// 		"changes": [
+// 			{
+// 				"height": "42",
+// 				"effective_height": "44",
+// 				"validators": [
+// 					{
+// 						"address": "E89A51D60F68385E09E716D353373B11F8FACD62",
+// 						"pub_key": {
+// 							"type": "tendermint/PubKeyEd25519",
+// 							"value": "aN/afl...="
+// 						},
+// 						"previous_voting_power": "10",
+// 						"voting_power": "0"
+// 					}
+// 				]
+// 			}
+// 		]
+// 	},
+// 	"id": "",
+// 	"jsonrpc": "2.0"
+// }
+// ```
+func ValidatorChanges(ctx *rpctypes.Context, from, to int64) (*ctypes.ResultValidatorChanges, error) {
+	const limit int64 = 1000
+	height := consensusState.GetState().LastBlockHeight
+	from, to, err := filterMinMax(height, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []ctypes.ValidatorSetChange{}
+	for h := from; h <= to; h++ {
+		validators, err := sm.LoadValidatorChanges(stateDB, h)
+		if err != nil {
+			return nil, err
+		}
+		if len(validators) > 0 {
+			changes = append(changes, ctypes.ValidatorSetChange{
+				Height:          h,
+				EffectiveHeight: h + 2,
+				Validators:      validators,
+			})
+		}
+	}
+	return &ctypes.ResultValidatorChanges{
+		From:    from,
+		To:      to,
+		Changes: changes}, nil
+}
+
 // DumpConsensusState dumps consensus state.
 // UNSTABLE
 //
@@ -193,15 +273,38 @@ func Validators(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultValidato
 //             "last_vote_height": "7184",
 //             "votes": "255",
 //             "last_block_part_height": "7184",
-//             "block_parts": "255"
+//             "block_parts": "255",
+//             "last_message_time": "2018-05-12T20:57:28.398541Z"
 //           }
+//         },
+//         "last_message_time": "2018-05-12T20:57:28.398541Z",
+//         "votes": {
+//           "height": "7185",
+//           "round": "0",
+//           "prevotes": [],
+//           "precommits": [],
+//           "last_commit": [
+//             "B5B3D40BE53982AD294EF99FF5A34C0C3E5A3244"
+//           ]
 //         }
 //       }
-//     ]
+//     ],
+//     "locks": {
+//       "height": "7185",
+//       "round": "0",
+//       "step": "RoundStepNewHeight",
+//       "proposal_block_hash": "",
+//       "locked_round": "-1",
+//       "locked_block_hash": "",
+//       "valid_round": "-1",
+//       "valid_block_hash": ""
+//     }
 //   }
 // }
 // ```
 func DumpConsensusState(ctx *rpctypes.Context) (*ctypes.ResultDumpConsensusState, error) {
+	rs := consensusState.GetRoundState()
+
 	// Get Peer consensus states.
 	peers := p2pPeers.Peers().List()
 	peerStates := make([]ctypes.PeerStateInfo, len(peers))
@@ -218,7 +321,9 @@ func DumpConsensusState(ctx *rpctypes.Context) (*ctypes.ResultDumpConsensusState
 			// Peer basic info.
 			NodeAddress: peer.NodeInfo().NetAddress().String(),
 			// Peer consensus state.
-			PeerState: peerStateJSON,
+			PeerState:       peerStateJSON,
+			LastMessageTime: peerState.LastMessageTime(),
+			Votes:           peerVotes(peerState.GetRoundState(), rs),
 		}
 	}
 	// Get self round state.
@@ -228,7 +333,60 @@ func DumpConsensusState(ctx *rpctypes.Context) (*ctypes.ResultDumpConsensusState
 	}
 	return &ctypes.ResultDumpConsensusState{
 		RoundState: roundState,
-		Peers:      peerStates}, nil
+		Peers:      peerStates,
+		Locks: ctypes.ConsensusLocks{
+			Height:            rs.Height,
+			Round:             rs.Round,
+			Step:              rs.Step.String(),
+			ProposalBlockHash: rs.ProposalBlock.Hash(),
+			LockedRound:       rs.LockedRound,
+			LockedBlockHash:   rs.LockedBlock.Hash(),
+			ValidRound:        rs.ValidRound,
+			ValidBlockHash:    rs.ValidBlock.Hash(),
+		}}, nil
+}
+
+// peerVotes resolves the validators of the vote bit arrays of the peer.
+func peerVotes(prs *cstypes.PeerRoundState, rs *cstypes.RoundState) ctypes.PeerVotes {
+	votes := ctypes.PeerVotes{Height: prs.Height, Round: prs.Round}
+
+	var vals, lastVals *types.ValidatorSet
+	if prs.Height == rs.Height {
+		vals, lastVals = rs.Validators, rs.LastValidators
+	} else {
+		vals = loadValidatorsOrNil(prs.Height)
+		lastVals = loadValidatorsOrNil(prs.Height - 1)
+	}
+	votes.Prevotes = votesAddresses(prs.Prevotes, vals)
+	votes.Precommits = votesAddresses(prs.Precommits, vals)
+	votes.LastCommit = votesAddresses(prs.LastCommit, lastVals)
+	return votes
+}
+
+func loadValidatorsOrNil(height int64) *types.ValidatorSet {
+	if height <= 0 {
+		return nil
+	}
+	vals, err := sm.LoadValidators(stateDB, height)
+	if err != nil {
+		return nil
+	}
+	return vals
+}
+
+// votesAddresses returns the addresses of the validators set in votes.
+func votesAddresses(votes *cmn.BitArray, vals *types.ValidatorSet) []types.Address {
+	addrs := []types.Address{}
+	if votes == nil || vals == nil {
+		return addrs
+	}
+	for i := 0; i < votes.Size() && i < vals.Size(); i++ {
+		if votes.GetIndex(i) {
+			addr, _ := vals.GetByIndex(i)
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
 }
 
 // ConsensusState returns a concise summary of the consensus state.
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cstypes "github.com/tendermint/tendermint/consensus/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestPeerVotes(t *testing.T) {
+	vals, _ := types.RandValidatorSet(3, 10)
+	lastVals, _ := types.RandValidatorSet(2, 10)
+	rs := &cstypes.RoundState{Height: 5, Validators: vals, LastValidators: lastVals}
+
+	prevotes := cmn.NewBitArray(3)
+	prevotes.SetIndex(0, true)
+	prevotes.SetIndex(2, true)
+	lastCommit := cmn.NewBitArray(2)
+	lastCommit.SetIndex(1, true)
+	prs := &cstypes.PeerRoundState{
+		Height:     5,
+		Round:      1,
+		Prevotes:   prevotes,
+		Precommits: nil,
+		LastCommit: lastCommit,
+	}
+
+	votes := peerVotes(prs, rs)
+	assert.EqualValues(t, 5, votes.Height)
+	assert.Equal(t, 1, votes.Round)
+	assert.Equal(t, []types.Address{vals.Validators[0].Address, vals.Validators[2].Address}, votes.Prevotes)
+	assert.Equal(t, []types.Address{}, votes.Precommits)
+	assert.Equal(t, []types.Address{lastVals.Validators[1].Address}, votes.LastCommit)
+
+	// A bit array larger than the validator set is cut.
+	assert.Len(t, votesAddresses(cmn.NewBitArray(5), lastVals), 0)
+	full := cmn.NewBitArray(5)
+	for i := 0; i < 5; i++ {
+		full.SetIndex(i, true)
+	}
+	assert.Len(t, votesAddresses(full, lastVals), 2)
+}
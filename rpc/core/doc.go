@@ -60,6 +60,30 @@ JSONRPC requests can be POST'd to the root RPC endpoint via HTTP (e.g. `http://l
 
 JSONRPC requests can be made via websocket. The websocket endpoint is at `/websocket`, e.g. `localhost:26657/websocket`.  Asynchronous RPC functions like event `subscribe` and `unsubscribe` are only available via websockets.
 
+## JSONRPC batches
+
+Several JSONRPC requests can be sent at once, via HTTP or websocket, as an array of requests, e.g. to fetch many blocks in one round trip. They are run in order and answered with the array of their responses, each with the `id` of its request, except the notifications (requests with an empty `id`), which aren't answered.
+
+```json
+[
+	{ "method": "block", "jsonrpc": "2.0", "params": { "height": "1" }, "id": 1 },
+	{ "method": "block", "jsonrpc": "2.0", "params": { "height": "2" }, "id": 2 }
+]
+```
+
+A batch can have up to `rpc.max_batch_size` requests, or is answered with a single error. The requests not run within `rpc.timeout_batch` are answered with an error.
+
+
+## Authentication
+
+To expose a node on a public network, set `rpc.auth_tokens` (or `rpc.tls_client_ca_file` for mutual TLS, with `rpc.tls_cert_file` and `rpc.tls_key_file`) and list the methods anyone can call in `rpc.public_methods`, e.g. the read-only ones. The other methods, like the `broadcast_tx_*` and the unsafe ones, then require the clients to authenticate, with one of the tokens (or the `rpc.admin_token`) as an `Authorization: Bearer <token>` header, or with a certificate signed by the client CA. The websocket calls are authenticated with the request which opened the connection, and `/event_stream` is authorized as the method `event_stream`.
+
+```bash
+curl -H 'Authorization: Bearer <token>' 'localhost:26657/broadcast_tx_sync?tx="abc"'
+curl --cacert ca.pem --cert client.pem --key client-key.pem 'https://localhost:26657/broadcast_tx_sync?tx="abc"'
+```
+
+The other calls are answered with the error code -32001 (Unauthorized). NOTE: The gRPC server (`rpc.grpc_laddr`) doesn't authenticate the clients.
 
 ## More Examples
 
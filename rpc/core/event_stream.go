@@ -0,0 +1,170 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	amino "github.com/tendermint/go-amino"
+
+	"github.com/tendermint/tendermint/libs/eventlog"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// eventStreamRetry is the delay, in milliseconds, before the clients of
+// /event_stream reconnect after the stream ended.
+const eventStreamRetry = 500
+
+// EventStreamHandler returns the handler of /event_stream, which streams the
+// events matching ?query as Server-Sent Events
+// (https://html.spec.whatwg.org/multipage/server-sent-events.html), for the
+// clients which can't use WebSockets. The query and the limits of the
+// subscriptions are those of /subscribe.
+//
+// Each event is sent with the type of the event (tm.event) as its `event`,
+// its cursor as its `id`, if the node keeps an event log, and the
+// ctypes.ResultEvent as JSON as its `data`. The stream ends after
+// streamDuration, which must be shorter than the write timeout of the HTTP
+// server, or when the subscription is cancelled (with an `error` event). The
+// clients then reconnect, with the id of the last event they got as the
+// Last-Event-ID header (or ?after), and are first sent the events they missed
+// from the event log (preceded by a `truncated` event if some of them were
+// pruned already).
+//
+// ```shell
+// curl -N "localhost:26657/event_stream?query=tm.event%3D'NewBlock'"
+// ```
+//
+// ```
+// retry: 500
+//
+// id: 1563389220481923000
+// event: NewBlock
+// data: {"query":"tm.event='NewBlock'","data":{"type":"tendermint/event/NewBlock","value":{...}},"tags":{...},"cursor":"1563389220481923000"}
+// ```
+func EventStreamHandler(cdc *amino.Codec, streamDuration time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming isn't supported", http.StatusInternalServerError)
+			return
+		}
+
+		query := r.URL.Query().Get("query")
+		q, err := tmquery.NewCompat(query)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to parse query: %v", err), http.StatusBadRequest)
+			return
+		}
+		after := r.Header.Get("Last-Event-ID")
+		if after == "" {
+			after = r.URL.Query().Get("after")
+		}
+		var cursor eventlog.Cursor
+		if after != "" {
+			if cursor, err = eventlog.ParseCursor(after); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		addr := r.RemoteAddr
+		logger.Info("Stream events", "remote", addr, "query", query)
+		sub, err := subscribe(r.Context(), addr, q)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		defer func() {
+			if err := eventBus.Unsubscribe(context.Background(), addr, q); err != nil &&
+				err != tmpubsub.ErrSubscriptionNotFound {
+				logger.Error("Failed to unsubscribe from events", "remote", addr, "err", err)
+			}
+		}()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		// disables the buffering of the responses by nginx
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "retry: %d\n\n", eventStreamRetry)
+
+		// the events missed since the last one the client got, subscribed to
+		// beforehand so that none is missed in between
+		if eventLog := eventBus.EventLog(); eventLog != nil && cursor != 0 {
+			for first := true; ; first = false {
+				res := eventLog.After(cursor, q.Matches, maxPerPage)
+				if first && res.Truncated {
+					fmt.Fprint(w, "event: truncated\ndata: {}\n\n")
+				}
+				for _, item := range res.Items {
+					resultEvent := &ctypes.ResultEvent{Query: query, Data: item.Data.(types.TMEventData),
+						Tags: item.Tags, Cursor: item.Cursor.String()}
+					if err := writeEvent(w, cdc, resultEvent); err != nil {
+						return
+					}
+					cursor = item.Cursor
+				}
+				if !res.More {
+					break
+				}
+			}
+		}
+		flusher.Flush()
+
+		var dropped uint64
+		timer := time.NewTimer(streamDuration)
+		defer timer.Stop()
+		for {
+			select {
+			case msg := <-sub.Out():
+				resultEvent := &ctypes.ResultEvent{Query: query, Data: msg.Data(), Tags: msg.Tags(),
+					Cursor: msg.Tags()[types.EventCursorKey]}
+				if c, err := eventlog.ParseCursor(resultEvent.Cursor); err == nil && c <= cursor {
+					// sent from the event log already
+					continue
+				}
+				if n := sub.Dropped(); n > dropped {
+					resultEvent.Dropped = n - dropped
+					dropped = n
+				}
+				if err := writeEvent(w, cdc, resultEvent); err != nil {
+					return
+				}
+				flusher.Flush()
+			case <-sub.Cancelled():
+				reason := "Tendermint exited"
+				if sub.Err() != nil {
+					reason = sub.Err().Error()
+				}
+				fmt.Fprintf(w, "event: error\ndata: %q\n\n", "subscription was cancelled (reason: "+reason+")")
+				flusher.Flush()
+				return
+			case <-timer.C:
+				return
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}
+}
+
+// writeEvent writes the event as a Server-Sent Event.
+func writeEvent(w io.Writer, cdc *amino.Codec, resultEvent *ctypes.ResultEvent) error {
+	bz, err := cdc.MarshalJSON(resultEvent)
+	if err != nil {
+		return err
+	}
+	if resultEvent.Cursor != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", resultEvent.Cursor); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", resultEvent.Tags[types.EventTypeKey], bz)
+	return err
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	amino "github.com/tendermint/go-amino"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/eventlog"
+	"github.com/tendermint/tendermint/libs/log"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestEventStream(t *testing.T) {
+	defer SetConfig(config)
+	SetConfig(*cfg.DefaultRPCConfig())
+	SetLogger(log.TestingLogger())
+	bus := types.NewEventBus()
+	bus.SetEventLog(eventlog.NewLog(time.Minute, 0))
+	require.NoError(t, bus.Start())
+	defer bus.Stop()
+	SetEventBus(bus)
+
+	cdc := amino.NewCodec()
+	ctypes.RegisterAmino(cdc)
+	srv := httptest.NewServer(EventStreamHandler(cdc, 5*time.Second))
+	defer srv.Close()
+
+	publish := func(reason string) {
+		err := bus.PublishEventTxEvicted(types.EventDataTxEvicted{Tx: types.Tx("foo"), Reason: reason})
+		require.NoError(t, err)
+	}
+	publish("expired")
+	publish("full")
+	first := bus.EventLog().After(0, nil, 1).Items[0].Cursor
+
+	resp, err := http.Get(srv.URL + "?query=" + url.QueryEscape("tm.event='Vote'") + "&after=abc")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	req, err := http.NewRequest("GET", srv.URL+"?query="+url.QueryEscape("tm.event='TxEvicted'"), nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", first.String())
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	r := bufio.NewReader(resp.Body)
+	readEvent := func() (id, event string, data ctypes.ResultEvent) {
+		for {
+			line, err := r.ReadString('\n')
+			require.NoError(t, err)
+			line = strings.TrimSuffix(line, "\n")
+			switch {
+			case line == "" && event != "":
+				return
+			case strings.HasPrefix(line, "id: "):
+				id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				require.NoError(t, cdc.UnmarshalJSON([]byte(strings.TrimPrefix(line, "data: ")), &data))
+			}
+		}
+	}
+
+	// the event missed after the last one the client got
+	id, event, data := readEvent()
+	assert.Equal(t, types.EventTxEvicted, event)
+	assert.Equal(t, data.Cursor, id)
+	assert.Equal(t, "full", data.Data.(types.EventDataTxEvicted).Reason)
+
+	// then the new events
+	publish("replaced")
+	id, event, data = readEvent()
+	assert.Equal(t, types.EventTxEvicted, event)
+	assert.NotEmpty(t, id)
+	assert.Equal(t, "replaced", data.Data.(types.EventDataTxEvicted).Reason)
+}
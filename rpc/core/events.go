@@ -3,23 +3,28 @@ package core
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 
+	"github.com/tendermint/tendermint/libs/eventlog"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 // Subscribe for events via WebSocket.
 //
 // To tell which events you want, you need to provide a query. query is a
-// string, which has a form: "condition AND condition ..." (no OR at the
-// moment). condition has a form: "key operation operand". key is a string with
-// a restricted set of possible symbols ( \t\n\r\\()"'=>< are not allowed).
-// operation can be "=", "<", "<=", ">", ">=", "CONTAINS". operand can be a
-// string (escaped with single quotes), number, date or time.
+// string of conditions combined with AND, OR and NOT (in this order of
+// precedence), and grouped with parentheses. condition has a form: "key
+// operation operand" or "key EXISTS". key is a string with a restricted set of
+// possible symbols ( \t\n\r\\()"'=>< are not allowed). operation can be "=",
+// "<", "<=", ">", ">=", "CONTAINS". operand can be a string (escaped with
+// single quotes), number, date or time. Comparing a number, date or time to a
+// key whose value isn't one doesn't match.
 //
 // Examples:
 //		tm.event = 'NewBlock'								# new blocks
@@ -42,6 +47,8 @@ import (
 //		tm.event = 'Tx' AND account.created_at >= TIME 2013-05-03T14:45:00Z
 //		tm.event = 'Tx' AND contract.sign_date = DATE 2017-01-01
 //		tm.event = 'Tx' AND account.owner CONTAINS 'Igor'
+//		tm.event = 'Tx' AND (agent.name = 'K' OR agent.name = 'J')
+//		tm.event = 'Tx' AND NOT account.owner EXISTS
 //
 // See list of all possible events here
 // https://godoc.org/github.com/tendermint/tendermint/types#pkg-constants
@@ -89,40 +96,54 @@ import (
 // |-----------+--------+---------+----------+-------------|
 // | query     | string | ""      | true     | Query       |
 //
+// The events are queued for each subscription (rpc.subscription_buffer_size)
+// while they are written to the connection. If the client doesn't read them
+// fast enough and the queue is full, the subscription is cancelled, or the
+// events are dropped (rpc.subscription_overflow), in which case the `dropped`
+// field of the next event delivered has the number of events dropped. The
+// events have a `cursor`, with which the events missed can be fetched with
+// /events.
+//
 // <aside class="notice">WebSocket only</aside>
 func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
 	addr := ctx.RemoteAddr()
 
-	if eventBus.NumClients() >= config.MaxSubscriptionClients {
-		return nil, fmt.Errorf("max_subscription_clients %d reached", config.MaxSubscriptionClients)
-	} else if eventBus.NumClientSubscriptions(addr) >= config.MaxSubscriptionsPerClient {
-		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", config.MaxSubscriptionsPerClient)
-	}
-
 	logger.Info("Subscribe to query", "remote", addr, "query", query)
 
-	q, err := tmquery.New(query)
+	q, err := tmquery.NewCompat(query)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse query")
 	}
-	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
-	defer cancel()
-	sub, err := eventBus.Subscribe(subCtx, addr, q)
+	sub, err := subscribe(ctx.Context(), addr, q)
 	if err != nil {
 		return nil, err
 	}
 
 	go func() {
+		// the cursor of the last event written, for the client to fetch the
+		// events it missed with /events
+		var lastCursor string
+		var dropped uint64
 		for {
 			select {
 			case msg := <-sub.Out():
-				resultEvent := &ctypes.ResultEvent{Query: query, Data: msg.Data(), Tags: msg.Tags()}
-				ctx.WSConn.TryWriteRPCResponse(
+				resultEvent := &ctypes.ResultEvent{Query: query, Data: msg.Data(), Tags: msg.Tags(),
+					Cursor: msg.Tags()[types.EventCursorKey]}
+				if n := sub.Dropped(); n > dropped {
+					resultEvent.Dropped = n - dropped
+					dropped = n
+				}
+				// block while the connection is busy, the events are queued
+				// meanwhile, up to subscription_buffer_size
+				ctx.WSConn.WriteRPCResponse(
 					rpctypes.NewRPCSuccessResponse(
 						ctx.WSConn.Codec(),
 						rpctypes.JSONRPCStringID(fmt.Sprintf("%v#event", ctx.JSONReq.ID)),
 						resultEvent,
 					))
+				if resultEvent.Cursor != "" {
+					lastCursor = resultEvent.Cursor
+				}
 			case <-sub.Cancelled():
 				if sub.Err() != tmpubsub.ErrUnsubscribed {
 					var reason string
@@ -131,10 +152,15 @@ func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, er
 					} else {
 						reason = sub.Err().Error()
 					}
+					err := fmt.Errorf("subscription was cancelled (reason: %s)", reason)
+					if lastCursor != "" {
+						err = fmt.Errorf("subscription was cancelled (reason: %s), "+
+							"fetch the events after the cursor %s with /events", reason, lastCursor)
+					}
 					ctx.WSConn.TryWriteRPCResponse(
 						rpctypes.RPCServerError(rpctypes.JSONRPCStringID(
 							fmt.Sprintf("%v#event", ctx.JSONReq.ID)),
-							fmt.Errorf("subscription was cancelled (reason: %s)", reason),
+							err,
 						))
 				}
 				return
@@ -145,6 +171,70 @@ func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, er
 	return &ctypes.ResultSubscribe{}, nil
 }
 
+// StreamEvents subscribes the subscriber to the query, within the limits of
+// /subscribe, and sends it the events until the context is done, the
+// subscription is cancelled or send fails. It is meant for the servers of the
+// other protocols, e.g. gRPC.
+func StreamEvents(ctx context.Context, subscriber, query string, send func(*ctypes.ResultEvent) error) error {
+	q, err := tmquery.NewCompat(query)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse query")
+	}
+	logger.Info("Stream events", "remote", subscriber, "query", query)
+	sub, err := subscribe(ctx, subscriber, q)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := eventBus.Unsubscribe(context.Background(), subscriber, q); err != nil &&
+			err != tmpubsub.ErrSubscriptionNotFound {
+			logger.Error("Failed to unsubscribe from events", "remote", subscriber, "err", err)
+		}
+	}()
+
+	var dropped uint64
+	for {
+		select {
+		case msg := <-sub.Out():
+			resultEvent := &ctypes.ResultEvent{Query: query, Data: msg.Data(), Tags: msg.Tags(),
+				Cursor: msg.Tags()[types.EventCursorKey]}
+			if n := sub.Dropped(); n > dropped {
+				resultEvent.Dropped = n - dropped
+				dropped = n
+			}
+			if err := send(resultEvent); err != nil {
+				return err
+			}
+		case <-sub.Cancelled():
+			reason := "Tendermint exited"
+			if sub.Err() != nil {
+				reason = sub.Err().Error()
+			}
+			return fmt.Errorf("subscription was cancelled (reason: %s)", reason)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// subscribe subscribes the client to the query, within the limits of the
+// config, with the buffer size and overflow policy of the config.
+func subscribe(ctx context.Context, addr string, q tmpubsub.Query) (*tmpubsub.Subscription, error) {
+	if eventBus.NumClients() >= config.MaxSubscriptionClients {
+		return nil, fmt.Errorf("max_subscription_clients %d reached", config.MaxSubscriptionClients)
+	} else if eventBus.NumClientSubscriptions(addr) >= config.MaxSubscriptionsPerClient {
+		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", config.MaxSubscriptionsPerClient)
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, SubscribeTimeout)
+	defer cancel()
+	policy := tmpubsub.OverflowCancel
+	if config.SubscriptionOverflow == "drop" {
+		policy = tmpubsub.OverflowDrop
+	}
+	return eventBus.SubscribeWithOverflow(subCtx, addr, q, config.SubscriptionBufferSize, policy)
+}
+
 // Unsubscribe from events via WebSocket.
 //
 // ```go
@@ -178,7 +268,7 @@ func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, er
 func Unsubscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultUnsubscribe, error) {
 	addr := ctx.RemoteAddr()
 	logger.Info("Unsubscribe from query", "remote", addr, "query", query)
-	q, err := tmquery.New(query)
+	q, err := tmquery.NewCompat(query)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse query")
 	}
@@ -222,3 +312,122 @@ func UnsubscribeAll(ctx *rpctypes.Context) (*ctypes.ResultUnsubscribe, error) {
 	}
 	return &ctypes.ResultUnsubscribe{}, nil
 }
+
+// Events returns the events matching the query (all of them if empty) after
+// the cursor of the last event the client got, oldest first, e.g. to fetch
+// the events it missed while it was disconnected, or the ones dropped from its
+// subscription. If there are none, it waits for one for up to ?wait_time
+// seconds (long-polling), so that the events can also be followed without a
+// WebSocket connection.
+//
+// The events are kept for rpc.event_log_window_size, up to
+// rpc.event_log_max_items events. `truncated` is set if events after the
+// cursor were pruned and can't be fetched anymore.
+//
+// ```shell
+// curl "localhost:26657/events?query=\"tm.event='Tx'\"&after=\"1563389219146482000\"&wait_time=5"
+// ```
+//
+// ```go
+// client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+// err := client.Start()
+// if err != nil {
+//   // handle error
+// }
+// defer client.Stop()
+// events, err := client.Events("tm.event='Tx'", "1563389219146482000", 100, 5)
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "items": [
+//       {
+//         "query": "tm.event='Tx'",
+//         "data": {
+//           "type": "tendermint/event/Tx",
+//           "value": {
+//             "TxResult": {
+//               "height": "12",
+//               "tx": "YT1i",
+//               "result": {}
+//             }
+//           }
+//         },
+//         "tags": {
+//           "tm.event": "Tx",
+//           "tx.hash": "CA1A6A2B77D1C05AF9FA2A3C8AFA1E2E3D0AAD5C3E6A6B1DA3F4D5F0B04D2F8B",
+//           "tx.height": "12"
+//         },
+//         "cursor": "1563389220481923000"
+//       }
+//     ],
+//     "more": false,
+//     "truncated": false,
+//     "oldest": "1563389191380011000",
+//     "newest": "1563389221002148000"
+//   }
+// }
+// ```
+//
+// ### Query Parameters
+//
+// | Parameter | Type   | Default | Required | Description                                                   |
+// |-----------+--------+---------+----------+---------------------------------------------------------------|
+// | query     | string | ""      | false    | Query (all the events if empty)                               |
+// | after     | string | ""      | false    | Cursor of the last event received (the oldest events if empty) |
+// | max_items | int    | 30      | false    | Maximum number of events returned (max: 100)                  |
+// | wait_time | int    | 0       | false    | Seconds to wait for an event if there are none (max: rpc.timeout_broadcast_tx_commit) |
+func Events(ctx *rpctypes.Context, query, after string, maxItems, waitTime int) (*ctypes.ResultEvents, error) {
+	eventLog := eventBus.EventLog()
+	if eventLog == nil {
+		return nil, errors.New("The event log is disabled (see rpc.event_log_window_size)")
+	}
+
+	var match func(tags map[string]string) bool
+	if query != "" {
+		q, err := tmquery.NewCompat(query)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse query")
+		}
+		match = q.Matches
+	}
+	var cursor eventlog.Cursor
+	if after != "" {
+		var err error
+		if cursor, err = eventlog.ParseCursor(after); err != nil {
+			return nil, err
+		}
+	}
+
+	// the HTTP write timeout is at least timeout_broadcast_tx_commit
+	wait := time.Duration(waitTime) * time.Second
+	if wait > config.TimeoutBroadcastTxCommit {
+		wait = config.TimeoutBroadcastTxCommit
+	}
+	waitCtx, cancel := context.WithTimeout(ctx.Context(), wait)
+	defer cancel()
+	res := eventLog.WaitAfter(waitCtx, cursor, match, validatePerPage(maxItems))
+
+	result := &ctypes.ResultEvents{
+		Items:     make([]*ctypes.ResultEvent, 0, len(res.Items)),
+		More:      res.More,
+		Truncated: res.Truncated,
+	}
+	for _, item := range res.Items {
+		result.Items = append(result.Items, &ctypes.ResultEvent{
+			Query:  query,
+			Data:   item.Data.(types.TMEventData),
+			Tags:   item.Tags,
+			Cursor: item.Cursor.String(),
+		})
+	}
+	if res.Oldest != 0 {
+		result.Oldest, result.Newest = res.Oldest.String(), res.Newest.String()
+	}
+	return result, nil
+}
@@ -0,0 +1,166 @@
+package core
+
+import (
+	"fmt"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Broadcast evidence of the misbehavior.
+//
+// The evidence is verified and added to the evidence pool, from where it is
+// gossiped to the other nodes and included in a block. Light clients use it
+// to submit the evidence of the forks they detect.
+//
+// ```shell
+// curl 'localhost:26657/broadcast_evidence?evidence={amino-encoded DuplicateVoteEvidence}'
+// ```
+//
+// ```go
+// client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+// err := client.Start()
+// if err != nil {
+//   // handle error
+// }
+// defer client.Stop()
+// result, err := client.BroadcastEvidence(ev)
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+// 	"error": "",
+// 	"result": {
+// 		"hash": "0E2AF9D7E1D8E9C6B9D3E7A6B2E8F3D4A1C2B3D4E5F60718293A4B5C6D7E8F90"
+// 	},
+// 	"id": "",
+// 	"jsonrpc": "2.0"
+// }
+// ```
+//
+// ### Query Parameters
+//
+// | Parameter | Type     | Default | Required | Description                 |
+// |-----------+----------+---------+----------+-----------------------------|
+// | evidence  | Evidence | nil     | true     | Amino-encoded JSON evidence |
+func BroadcastEvidence(ctx *rpctypes.Context, ev types.Evidence) (*ctypes.ResultBroadcastEvidence, error) {
+	if ev == nil {
+		return nil, fmt.Errorf("no evidence was provided")
+	}
+	if err := ev.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("evidence.ValidateBasic failed: %v", err)
+	}
+	if err := evidencePool.AddEvidence(ev); err != nil {
+		return nil, fmt.Errorf("failed to add evidence: %v", err)
+	}
+	return &ctypes.ResultBroadcastEvidence{Hash: ev.Hash()}, nil
+}
+
+// Get the pending evidence (maximum ?limit entries), in the order it's
+// included in the blocks.
+//
+// ```shell
+// curl 'localhost:26657/unconfirmed_evidence?limit=1'
+// ```
+//
+// ```go
+// client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+// err := client.Start()
+// if err != nil {
+//   // handle error
+// }
+// defer client.Stop()
+// result, err := client.UnconfirmedEvidence(1)
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "result" : {
+//       "evidence" : [],
+//       "n_evidence" : "0",
+//       "total" : "0"
+//     },
+//     "jsonrpc" : "2.0",
+//     "id" : ""
+//   }
+// ```
+//
+// ### Query Parameters
+//
+// | Parameter | Type | Default | Required | Description                          |
+// |-----------+------+---------+----------+--------------------------------------|
+// | limit     | int  | 30      | false    | Maximum number of entries (max: 100) |
+func UnconfirmedEvidence(ctx *rpctypes.Context, limit int) (*ctypes.ResultUnconfirmedEvidence, error) {
+	// reuse per_page validator
+	limit = validatePerPage(limit)
+
+	evidence := evidencePool.PendingEvidence(int64(limit))
+	return &ctypes.ResultUnconfirmedEvidence{
+		Count:    len(evidence),
+		Total:    evidencePool.NumPending(),
+		Evidence: evidence}, nil
+}
+
+// Get the evidence with the hash from the evidence pool, pending or
+// committed, with the height of the state it was verified against and the
+// height of the block it was committed in.
+//
+// Expired evidence is forgotten.
+//
+// ```shell
+// curl 'localhost:26657/evidence?hash=0x0E2AF9D7E1D8E9C6B9D3E7A6B2E8F3D4A1C2B3D4E5F60718293A4B5C6D7E8F90'
+// ```
+//
+// ```go
+// client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+// err := client.Start()
+// if err != nil {
+//   // handle error
+// }
+// defer client.Stop()
+// result, err := client.Evidence(hash)
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+// 	"error": "",
+// 	"result": {
+// 		"evidence": {
+// 			"type": "tendermint/DuplicateVoteEvidence",
+// 			"value": {...}
+// 		},
+// 		"committed": true,
+// 		"priority": "10",
+// 		"verified_height": "12",
+// 		"committed_height": "13"
+// 	},
+// 	"id": "",
+// 	"jsonrpc": "2.0"
+// }
+// ```
+//
+// ### Query Parameters
+//
+// | Parameter | Type   | Default | Required | Description          |
+// |-----------+--------+---------+----------+----------------------|
+// | hash      | []byte | nil     | true     | Hash of the evidence |
+func Evidence(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultEvidence, error) {
+	ei := evidencePool.EvidenceInfo(hash)
+	if ei == nil {
+		return nil, fmt.Errorf("evidence %X not found", hash)
+	}
+	return &ctypes.ResultEvidence{
+		Evidence:        ei.Evidence,
+		Committed:       ei.Committed,
+		Priority:        ei.Priority,
+		VerifiedHeight:  ei.VerifiedHeight,
+		CommittedHeight: ei.CommittedHeight,
+	}, nil
+}
@@ -10,6 +10,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	"github.com/tendermint/tendermint/state/txindex/null"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -68,6 +69,8 @@ func BroadcastTxAsync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadca
 }
 
 // Returns with the response from CheckTx. Does not wait for DeliverTx result.
+// Returns an error if the mempool rejects the valid tx (e.g. the mempool is
+// full of txs with a higher priority).
 //
 // Please refer to
 // https://tendermint.com/docs/tendermint-core/using-tendermint.html#formatting
@@ -118,6 +121,9 @@ func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcas
 	}
 	res := <-resCh
 	r := res.GetCheckTx()
+	if r.MempoolError != "" {
+		return nil, errors.New(r.MempoolError)
+	}
 	return &ctypes.ResultBroadcastTx{
 		Code: r.Code,
 		Data: r.Data,
@@ -126,6 +132,121 @@ func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcas
 	}, nil
 }
 
+// maxTxsPerBatch is the maximum number of txs of /broadcast_tx_batch.
+const maxTxsPerBatch = 1000
+
+// Returns with the responses from CheckTx of a batch of transactions, in the
+// order of the transactions. Does not wait for DeliverTx results. The
+// transactions are all checked while the mempool is locked once, which is
+// cheaper than broadcasting them one by one. The transactions rejected by the
+// mempool (e.g. already in the cache, or the mempool is full) have an error,
+// the others are checked like with /broadcast_tx_sync. The transactions not
+// checked within timeout_broadcast_tx_commit have an error too.
+//
+// Please refer to
+// https://tendermint.com/docs/tendermint-core/using-tendermint.html#formatting
+// for formatting/encoding rules.
+//
+// ```shell
+// curl 'localhost:26657/broadcast_tx_batch?txs=["NDU2","Nzg5"]'
+// ```
+//
+// ```go
+// client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+// err := client.Start()
+// if err != nil {
+//   // handle error
+// }
+// defer client.Stop()
+// result, err := client.BroadcastTxBatch(types.Txs{[]byte("456"), []byte("789")})
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+// 	"jsonrpc": "2.0",
+// 	"id": "",
+// 	"result": {
+// 		"txs": [
+// 			{
+// 				"code": "0",
+// 				"data": "",
+// 				"log": "",
+// 				"hash": "B3A8E0E1F9AB1BFE3A36F231F676F78BB30A519D2B21E6C530C0EEE8EBB4A5D0"
+// 			},
+// 			{
+// 				"code": "0",
+// 				"data": "",
+// 				"log": "",
+// 				"hash": "35A9E381B1A27567549B5F8A6F783C167EBF809F1C4D6A9E367240484D8CE281",
+// 				"error": "Tx already exists in cache"
+// 			}
+// 		]
+// 	}
+// }
+// ```
+//
+// ### Query Parameters
+//
+// | Parameter | Type | Default | Required | Description                  |
+// |-----------+------+---------+----------+------------------------------|
+// | txs       | []Tx | nil     | true     | The transactions (max: 1000) |
+func BroadcastTxBatch(ctx *rpctypes.Context, txs []types.Tx) (*ctypes.ResultBroadcastTxBatch, error) {
+	if len(txs) == 0 {
+		return nil, errors.New("No transactions")
+	}
+	if len(txs) > maxTxsPerBatch {
+		return nil, fmt.Errorf("Too many transactions: %d (max: %d)", len(txs), maxTxsPerBatch)
+	}
+
+	type indexedRes struct {
+		i   int
+		res *abci.Response
+	}
+	resCh := make(chan indexedRes, len(txs))
+	errs := mempool.CheckTxs(txs, func(i int, res *abci.Response) {
+		resCh <- indexedRes{i, res}
+	})
+
+	results := make([]ctypes.ResultBatchTx, len(txs))
+	pending := 0
+	for i, err := range errs {
+		results[i].Hash = txs[i].Hash()
+		if err != nil {
+			results[i].Error = err.Error()
+		} else {
+			pending++
+		}
+	}
+	checked := make([]bool, len(txs))
+	timeout := time.After(config.TimeoutBroadcastTxCommit)
+	for ; pending > 0; pending-- {
+		select {
+		case r := <-resCh:
+			checkTx := r.res.GetCheckTx()
+			results[r.i].Code = checkTx.Code
+			results[r.i].Data = checkTx.Data
+			results[r.i].Log = checkTx.Log
+			results[r.i].Error = checkTx.MempoolError
+			checked[r.i] = true
+		case <-ctx.Context().Done():
+			return nil, ctx.Context().Err()
+		case <-timeout:
+			// the txs may still get in the mempool
+			err := errors.New("Timed out waiting for CheckTx")
+			for i := range txs {
+				if errs[i] == nil && !checked[i] {
+					results[i].Error = err.Error()
+				}
+			}
+			logger.Error("Error on broadcastTxBatch", "err", err, "pending", pending)
+			return &ctypes.ResultBroadcastTxBatch{Txs: results}, nil
+		}
+	}
+	return &ctypes.ResultBroadcastTxBatch{Txs: results}, nil
+}
+
 // Returns with the responses from CheckTx and DeliverTx.
 //
 // IMPORTANT: use only for testing and development. In production, use
@@ -219,7 +340,7 @@ func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadc
 	}
 	checkTxResMsg := <-checkTxResCh
 	checkTxRes := checkTxResMsg.GetCheckTx()
-	if checkTxRes.Code != abci.CodeTypeOK {
+	if checkTxRes.Code != abci.CodeTypeOK || checkTxRes.MempoolError != "" {
 		return &ctypes.ResultBroadcastTxCommit{
 			CheckTx:   *checkTxRes,
 			DeliverTx: abci.ResponseDeliverTx{},
@@ -347,3 +468,83 @@ func NumUnconfirmedTxs(ctx *rpctypes.Context) (*ctypes.ResultUnconfirmedTxs, err
 		Total:      mempool.Size(),
 		TotalBytes: mempool.TxsBytes()}, nil
 }
+
+// Get the status of a transaction: in the mempool ("pending"), evicted from
+// the mempool before being committed ("evicted", with the reason and the
+// height of the last block then), committed ("committed", with the height of
+// the block) or "unknown".
+//
+// Only the last evicted transactions are remembered, and the committed
+// transactions are only known if they're indexed (see /tx). A transaction
+// which was just committed may be unknown until it's indexed.
+//
+// ```shell
+// curl 'localhost:26657/tx_status?hash=0x88D4266FD4E6338D13B845FCF289579D209C897823B9217DA3E161936F031589'
+// ```
+//
+// ```go
+// client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+// err := client.Start()
+// if err != nil {
+//   // handle error
+// }
+// defer client.Stop()
+// hashBytes, err := hex.DecodeString("88D4266FD4E6338D13B845FCF289579D209C897823B9217DA3E161936F031589")
+// result, err := client.TxStatus(hashBytes)
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "hash": "88D4266FD4E6338D13B845FCF289579D209C897823B9217DA3E161936F031589",
+//     "status": "evicted",
+//     "reason": "expired",
+//     "height": "52"
+//   }
+// }
+// ```
+//
+// ### Query Parameters
+//
+// | Parameter | Type   | Default | Required | Description          |
+// |-----------+--------+---------+----------+----------------------|
+// | hash      | []byte | nil     | true     | The transaction hash |
+//
+// ### Returns
+//
+// - `hash`: `[]byte` - hash of the transaction
+// - `status`: `string` - "pending", "evicted", "committed" or "unknown"
+// - `reason`: `string` - why the transaction was evicted (see the TxEvicted
+// event)
+// - `height`: `int` - height of the block the transaction was committed in,
+// or of the last block when it was evicted
+func TxStatus(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultTxStatus, error) {
+	if mempool.HasTx(hash) {
+		return &ctypes.ResultTxStatus{Hash: hash, Status: ctypes.TxStatusPending}, nil
+	}
+
+	if _, ok := txIndexer.(*null.TxIndex); !ok {
+		r, err := txIndexer.Get(hash)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			return &ctypes.ResultTxStatus{Hash: hash, Status: ctypes.TxStatusCommitted, Height: r.Height}, nil
+		}
+	}
+
+	if evicted := mempool.EvictedTx(hash); evicted != nil {
+		return &ctypes.ResultTxStatus{
+			Hash:   hash,
+			Status: ctypes.TxStatusEvicted,
+			Reason: evicted.Reason,
+			Height: evicted.Height,
+		}, nil
+	}
+
+	return &ctypes.ResultTxStatus{Hash: hash, Status: ctypes.TxStatusUnknown}, nil
+}
@@ -2,10 +2,12 @@ package core
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/pkg/errors"
 
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/pex"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
 )
@@ -90,6 +92,10 @@ import (
 //   					"TimeRem": "0",
 //   					"Progress": 0
 //   				},
+//   				"BytesSent": "4512",
+//   				"BytesReceived": "4489",
+//   				"Capped": false,
+//   				"Latency": "2150000",
 //   				"Channels": [
 //   					{
 //   						"ID": 48,
@@ -180,6 +186,124 @@ func NetInfo(ctx *rpctypes.Context) (*ctypes.ResultNetInfo, error) {
 	}, nil
 }
 
+// Get the behaviour reported about a peer by the reactors: the number of
+// times each behaviour was reported, and the most recent reports. History is
+// kept after the peer disconnected, so this can be used to find out why a
+// peer keeps getting dropped.
+//
+// ```shell
+// curl 'localhost:26657/peer_behaviour?peer_id="93529da3435c090d02251a050342b6a488d4ab56"'
+// ```
+//
+// ```go
+// client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+// err := client.Start()
+// if err != nil {
+//   // handle error
+// }
+// defer client.Stop()
+// result, err := client.PeerBehaviour("93529da3435c090d02251a050342b6a488d4ab56")
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "peer_id": "93529da3435c090d02251a050342b6a488d4ab56",
+//     "error_counts": [
+//       {
+//         "reason": "BadMessage",
+//         "count": "1"
+//       }
+//     ],
+//     "good_counts": [
+//       {
+//         "reason": "Vote",
+//         "count": "112"
+//       }
+//     ],
+//     "recent_events": [
+//       {
+//         "time": "2019-04-02T12:10:15.946378Z",
+//         "kind": "error",
+//         "reason": "BadMessage",
+//         "reactor": "CONSENSUS",
+//         "height": "1021",
+//         "detail": "invalid vote signature"
+//       }
+//     ]
+//   }
+// }
+// ```
+//
+// ### Query Parameters
+//
+// | Parameter | Type   | Default | Required | Description   |
+// |-----------+--------+---------+----------+---------------|
+// | peer_id   | string | ""      | true     | ID of the peer |
+func PeerBehaviour(ctx *rpctypes.Context, peerID string) (*ctypes.ResultPeerBehaviour, error) {
+	if p2pBehaviour == nil {
+		return nil, errors.New("Peer behaviour history is not available")
+	}
+	if peerID == "" {
+		return nil, errors.New("No peer_id provided")
+	}
+	id := p2p.ID(peerID)
+
+	summary, ok := p2pBehaviour.Summary(id)
+	if !ok {
+		return nil, fmt.Errorf("No behaviour reported about peer %v", id)
+	}
+
+	result := &ctypes.ResultPeerBehaviour{
+		PeerID:       id,
+		ErrorCounts:  make([]ctypes.PeerBehaviourCount, 0, len(summary.ErrorCounts)),
+		GoodCounts:   make([]ctypes.PeerBehaviourCount, 0, len(summary.GoodCounts)),
+		RecentEvents: make([]ctypes.PeerBehaviourEvent, 0, len(summary.Recent)),
+	}
+	for reason, count := range summary.ErrorCounts {
+		result.ErrorCounts = append(result.ErrorCounts, ctypes.PeerBehaviourCount{Reason: reason.String(), Count: count})
+	}
+	for reason, count := range summary.GoodCounts {
+		result.GoodCounts = append(result.GoodCounts, ctypes.PeerBehaviourCount{Reason: reason.String(), Count: count})
+	}
+	sortPeerBehaviourCounts(result.ErrorCounts)
+	sortPeerBehaviourCounts(result.GoodCounts)
+
+	for _, e := range summary.Recent {
+		event := ctypes.PeerBehaviourEvent{Time: e.Time}
+		if e.Error != nil {
+			event.Kind = "error"
+			event.Reason = e.Error.Reason.String()
+			event.Reactor = e.Error.Reactor
+			event.Height = e.Error.Height
+			event.Detail = e.Error.Detail
+		} else {
+			event.Kind = "good"
+			event.Reason = e.Good.Reason.String()
+			event.Reactor = e.Good.Reactor
+			event.Height = e.Good.Height
+			event.Detail = e.Good.Detail
+		}
+		result.RecentEvents = append(result.RecentEvents, event)
+	}
+
+	return result, nil
+}
+
+// sortPeerBehaviourCounts sorts by descending count, then by reason.
+func sortPeerBehaviourCounts(counts []ctypes.PeerBehaviourCount) {
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Reason < counts[j].Reason
+	})
+}
+
 func UnsafeDialSeeds(ctx *rpctypes.Context, seeds []string) (*ctypes.ResultDialSeeds, error) {
 	if len(seeds) == 0 {
 		return &ctypes.ResultDialSeeds{}, errors.New("No seeds provided")
@@ -206,6 +330,379 @@ func UnsafeDialPeers(ctx *rpctypes.Context, peers []string, persistent bool) (*c
 	return &ctypes.ResultDialPeers{Log: "Dialing peers in progress. See /net_info for details"}, nil
 }
 
+// UnsafeSetPersistentPeers replaces the persistent peers, like a changed
+// persistent_peers config would on restart. New persistent peers are dialed.
+// Removed ones stay connected, but aren't redialed anymore.
+func UnsafeSetPersistentPeers(ctx *rpctypes.Context, peers []string) (*ctypes.ResultPersistentPeers, error) {
+	logger.Info("SetPersistentPeers", "peers", peers)
+	if err := p2pPeers.SetPersistentPeers(peers); err != nil {
+		return nil, err
+	}
+	result := &ctypes.ResultPersistentPeers{Peers: []string{}}
+	for _, addr := range p2pPeers.PersistentPeers() {
+		result.Peers = append(result.Peers, addr.String())
+	}
+	return result, nil
+}
+
+// UnsafeSetPrivatePeerIDs replaces the private peer IDs, like a changed
+// private_peer_ids config would on restart.
+func UnsafeSetPrivatePeerIDs(ctx *rpctypes.Context, ids []string) (*ctypes.ResultPrivatePeerIDs, error) {
+	logger.Info("SetPrivatePeerIDs", "ids", ids)
+	p2pPeers.SetPrivatePeerIDs(ids)
+	return &ctypes.ResultPrivatePeerIDs{IDs: p2pPeers.PrivatePeerIDs()}, nil
+}
+
+// Dial a peer and wait until it is connected. Requires the admin token in an
+// "Authorization: Bearer <token>" header. If persistent, the peer is redialed
+// whenever it disconnects, and also if this dial fails.
+//
+// Errors have one of the codes defined in rpc/core/types, e.g.
+// -32003 if the peer is connected already or -32006 if it couldn't be
+// reached, with the reason in the data.
+//
+// ```shell
+// curl -H 'Authorization: Bearer <admin_token>' 'localhost:26657/dial_peer?peer="93529da3435c090d02251a050342b6a488d4ab56@10.0.0.2:26656"&persistent=true'
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "id": "93529da3435c090d02251a050342b6a488d4ab56",
+//     "address": "93529da3435c090d02251a050342b6a488d4ab56@10.0.0.2:26656",
+//     "persistent": true
+//   }
+// }
+// ```
+//
+// ### Query Parameters
+//
+// | Parameter  | Type   | Default | Required | Description                         |
+// |------------+--------+---------+----------+-------------------------------------|
+// | peer       | string | ""      | true     | Address of the peer, ID@host:port   |
+// | persistent | bool   | false   | false    | Redial the peer when it disconnects |
+func DialPeer(ctx *rpctypes.Context, peer string, persistent bool) (*ctypes.ResultDialPeer, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	addr, err := p2p.NewNetAddressString(peer)
+	if err != nil {
+		return nil, adminError(ctypes.ErrCodeInvalidAddress, "Invalid peer address", err)
+	}
+
+	logger.Info("DialPeer", "addr", addr, "persistent", persistent)
+	if err := p2pPeers.DialPeer(addr, persistent); err != nil {
+		return nil, dialError(err)
+	}
+	return &ctypes.ResultDialPeer{ID: addr.ID, Address: addr.String(), Persistent: persistent}, nil
+}
+
+// Disconnect a peer. Requires the admin token in an "Authorization: Bearer
+// <token>" header. The peer is also removed from the persistent peers, so it
+// isn't redialed. Errors with code -32007 if the peer is neither connected
+// nor persistent.
+//
+// ```shell
+// curl -H 'Authorization: Bearer <admin_token>' 'localhost:26657/remove_peer?peer_id="93529da3435c090d02251a050342b6a488d4ab56"'
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "id": "93529da3435c090d02251a050342b6a488d4ab56",
+//     "was_connected": true
+//   }
+// }
+// ```
+//
+// ### Query Parameters
+//
+// | Parameter | Type   | Default | Required | Description    |
+// |-----------+--------+---------+----------+----------------|
+// | peer_id   | string | ""      | true     | ID of the peer |
+func RemovePeer(ctx *rpctypes.Context, peerID string) (*ctypes.ResultRemovePeer, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	id := p2p.ID(peerID)
+
+	persistent := false
+	for _, addr := range p2pPeers.PersistentPeers() {
+		if addr.ID == id {
+			persistent = true
+		}
+	}
+	peer := p2pPeers.Peers().Get(id)
+	if peer == nil && !persistent {
+		return nil, adminError(ctypes.ErrCodePeerNotFound, "Peer not found", fmt.Errorf("no peer with ID %v", id))
+	}
+
+	logger.Info("RemovePeer", "id", id)
+	p2pPeers.RemovePersistentPeers([]p2p.ID{id})
+	if peer != nil {
+		p2pPeers.StopPeerGracefully(peer)
+	}
+	return &ctypes.ResultRemovePeer{ID: id, WasConnected: peer != nil}, nil
+}
+
+// Get the reconnect states of the persistent peers which were redialed after
+// they disconnected or a dial failed, sorted by ID. Requires the admin token
+// in an "Authorization: Bearer <token>" header. Peers back off exponentially
+// while dials keep failing or connections keep dropping, and are given up on
+// after p2p.reconnect_max_attempts failures in a row.
+//
+// ```shell
+// curl -H 'Authorization: Bearer <admin_token>' 'localhost:26657/dial_states'
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "peers": [
+//       {
+//         "id": "93529da3435c090d02251a050342b6a488d4ab56",
+//         "address": "93529da3435c090d02251a050342b6a488d4ab56@10.0.0.2:26656",
+//         "status": "waiting",
+//         "failures": "3",
+//         "last_error": "dial tcp 10.0.0.2:26656: connect: connection refused",
+//         "next_dial": "2019-03-08T10:12:04.018479Z",
+//         "connected_at": "2019-03-08T09:58:41.694437Z"
+//       }
+//     ]
+//   }
+// }
+// ```
+func DialStates(ctx *rpctypes.Context) (*ctypes.ResultDialStates, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultDialStates{Peers: p2pPeers.DialStates()}, nil
+}
+
+// Get all entries of the address book, sorted by address. Requires the admin
+// token in an "Authorization: Bearer <token>" header. The entries can be
+// imported into another node with /addr_book_import or the
+// `tendermint addr_book import` command.
+//
+// ```shell
+// curl -H 'Authorization: Bearer <admin_token>' 'localhost:26657/addr_book'
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "n_addresses": "1",
+//     "addresses": [
+//       {
+//         "address": "93529da3435c090d02251a050342b6a488d4ab56@10.0.0.2:26656",
+//         "source": "5576458aef205977e18fd50b274e9b5d9014525a@10.0.0.3:26656",
+//         "good": true,
+//         "last_success": "2019-04-02T09:01:42.112804Z",
+//         "last_seen": "2019-04-02T12:10:15.946378Z"
+//       }
+//     ]
+//   }
+// }
+// ```
+func AddrBook(ctx *rpctypes.Context) (*ctypes.ResultAddrBook, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	entries := addrBook.Export()
+	return &ctypes.ResultAddrBook{NAddresses: len(entries), Addresses: entries}, nil
+}
+
+// Import address book entries, as returned by /addr_book. Requires the admin
+// token in an "Authorization: Bearer <token>" header. Entries marked good are
+// marked good in the address book, entries without a source are their own
+// source. Entries which can't be added, e.g. because the address is invalid,
+// are skipped and reported in the errors.
+//
+// ```shell
+// curl -H 'Authorization: Bearer <admin_token>' --data-binary '{"jsonrpc":"2.0","id":"","method":"addr_book_import","params":{"entries":[{"address":"93529da3435c090d02251a050342b6a488d4ab56@10.0.0.2:26656","good":true}]}}' 'localhost:26657'
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "n_added": "1",
+//     "errors": []
+//   }
+// }
+// ```
+//
+// ### Query Parameters
+//
+// | Parameter | Type            | Default | Required | Description          |
+// |-----------+-----------------+---------+----------+----------------------|
+// | entries   | []AddrBookEntry | nil     | true     | Entries to import    |
+func AddrBookImport(ctx *rpctypes.Context, entries []pex.AddrBookEntry) (*ctypes.ResultAddrBookImport, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	logger.Info("AddrBookImport", "entries", len(entries))
+	added, errs := addrBook.Import(entries)
+	addrBook.Save()
+
+	result := &ctypes.ResultAddrBookImport{NAdded: added, Errors: []string{}}
+	for _, err := range errs {
+		result.Errors = append(result.Errors, err.Error())
+	}
+	return result, nil
+}
+
+// Mark an address of the address book good, as if we just connected to it.
+// Requires the admin token in an "Authorization: Bearer <token>" header.
+// Errors with code -32007 if the address isn't in the address book.
+//
+// ```shell
+// curl -H 'Authorization: Bearer <admin_token>' 'localhost:26657/addr_book_mark_good?addr="93529da3435c090d02251a050342b6a488d4ab56@10.0.0.2:26656"'
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "address": "93529da3435c090d02251a050342b6a488d4ab56@10.0.0.2:26656"
+//   }
+// }
+// ```
+//
+// ### Query Parameters
+//
+// | Parameter | Type   | Default | Required | Description                          |
+// |-----------+--------+---------+----------+--------------------------------------|
+// | addr      | string | ""      | true     | Address in the book, ID@host:port    |
+func AddrBookMarkGood(ctx *rpctypes.Context, addr string) (*ctypes.ResultAddrBookMark, error) {
+	netAddr, err := addrBookAddress(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("AddrBookMarkGood", "addr", netAddr)
+	addrBook.MarkGood(netAddr)
+	return &ctypes.ResultAddrBookMark{Address: netAddr.String()}, nil
+}
+
+// Mark an address of the address book bad, which removes it from the book.
+// Requires the admin token in an "Authorization: Bearer <token>" header.
+// Errors with code -32007 if the address isn't in the address book.
+//
+// ```shell
+// curl -H 'Authorization: Bearer <admin_token>' 'localhost:26657/addr_book_mark_bad?addr="93529da3435c090d02251a050342b6a488d4ab56@10.0.0.2:26656"'
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "address": "93529da3435c090d02251a050342b6a488d4ab56@10.0.0.2:26656"
+//   }
+// }
+// ```
+//
+// ### Query Parameters
+//
+// | Parameter | Type   | Default | Required | Description                          |
+// |-----------+--------+---------+----------+--------------------------------------|
+// | addr      | string | ""      | true     | Address in the book, ID@host:port    |
+func AddrBookMarkBad(ctx *rpctypes.Context, addr string) (*ctypes.ResultAddrBookMark, error) {
+	netAddr, err := addrBookAddress(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("AddrBookMarkBad", "addr", netAddr)
+	addrBook.MarkBad(netAddr)
+	return &ctypes.ResultAddrBookMark{Address: netAddr.String()}, nil
+}
+
+// addrBookAddress authorizes the request and parses addr, which must be in
+// the address book.
+func addrBookAddress(ctx *rpctypes.Context, addr string) (*p2p.NetAddress, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	netAddr, err := p2p.NewNetAddressString(addr)
+	if err != nil {
+		return nil, adminError(ctypes.ErrCodeInvalidAddress, "Invalid address", err)
+	}
+	if !addrBook.HasAddress(netAddr) {
+		return nil, adminError(ctypes.ErrCodePeerNotFound, "Address not found",
+			fmt.Errorf("%v is not in the address book", netAddr))
+	}
+	return netAddr, nil
+}
+
+// authorizeAdmin checks the request carries the admin token. Calls through
+// the local client are always authorized, websocket calls never are.
+func authorizeAdmin(ctx *rpctypes.Context) error {
+	if ctx.HTTPReq == nil && ctx.WSConn == nil {
+		return nil
+	}
+	if ctx.HTTPReq != nil && config.AdminToken != "" && hasBearerToken(ctx.HTTPReq, config.AdminToken) {
+		return nil
+	}
+	return &rpctypes.RPCError{Code: ctypes.ErrCodeUnauthorized, Message: "Unauthorized"}
+}
+
+// dialError converts an error returned by the switch when dialing to an
+// admin error.
+func dialError(err error) error {
+	switch e := err.(type) {
+	case p2p.ErrNetAddressInvalid:
+		return adminError(ctypes.ErrCodeInvalidAddress, "Invalid peer address", err)
+	case p2p.ErrCurrentlyDialingOrExistingAddress, p2p.ErrSwitchDuplicatePeerID:
+		return adminError(ctypes.ErrCodeAlreadyConnected, "Peer is already connected", err)
+	case p2p.ErrSwitchConnectToSelf:
+		return adminError(ctypes.ErrCodeConnectToSelf, "Cannot dial self", err)
+	case p2p.ErrSwitchBannedPeer, p2p.ErrSwitchAuthenticationFailure, p2p.ErrSwitchDuplicatePeerIP:
+		return adminError(ctypes.ErrCodePeerRejected, "Peer rejected", err)
+	case p2p.ErrRejected:
+		switch {
+		case e.IsSelf():
+			return adminError(ctypes.ErrCodeConnectToSelf, "Cannot dial self", err)
+		case e.IsDuplicate():
+			return adminError(ctypes.ErrCodeAlreadyConnected, "Peer is already connected", err)
+		default:
+			return adminError(ctypes.ErrCodePeerRejected, "Peer rejected", err)
+		}
+	default:
+		return adminError(ctypes.ErrCodeDialFailed, "Dial failed", err)
+	}
+}
+
+func adminError(code int, msg string, err error) *rpctypes.RPCError {
+	return &rpctypes.RPCError{Code: code, Message: msg, Data: err.Error()}
+}
+
 // Get genesis file.
 //
 // ```shell
@@ -251,3 +748,81 @@ func UnsafeDialPeers(ctx *rpctypes.Context, peers []string, persistent bool) (*c
 func Genesis(ctx *rpctypes.Context) (*ctypes.ResultGenesis, error) {
 	return &ctypes.ResultGenesis{Genesis: genDoc}, nil
 }
+
+// Get the addresses known to a seed node. Every address is linked to the
+// address of the peer which told the seed about it, so the result describes
+// the network as far as the seed crawled it. last_seen is the last time the
+// seed connected to the address; seeds only hand out addresses seen within
+// seed_max_address_age.
+//
+// Only available if the node runs in seed mode.
+//
+// ```shell
+// curl 'localhost:26657/network_graph'
+// ```
+//
+// ```go
+// client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+// err := client.Start()
+// if err != nil {
+//   // handle error
+// }
+// defer client.Stop()
+// result, err := client.NetworkGraph()
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "n_addresses": "1",
+//     "addresses": [
+//       {
+//         "id": "93529da3435c090d02251a050342b6a488d4ab56",
+//         "address": "93529da3435c090d02251a050342b6a488d4ab56@192.167.10.3:26656",
+//         "source": "5576458aef205977e18fd50b274e9b5d9014525a@192.167.10.2:26656",
+//         "bucket": "old",
+//         "attempts": 0,
+//         "last_attempt": "2019-04-02T12:10:15.946378Z",
+//         "last_success": "2019-04-02T09:01:42.112804Z",
+//         "last_seen": "2019-04-02T12:10:15.946378Z"
+//       }
+//     ]
+//   }
+// }
+// ```
+func NetworkGraph(ctx *rpctypes.Context) (*ctypes.ResultNetworkGraph, error) {
+	if p2pCrawler == nil {
+		return nil, errors.New("Network graph is only available on seed nodes")
+	}
+
+	known := p2pCrawler.KnownAddresses()
+	addrs := make([]ctypes.KnownAddress, 0, len(known))
+	for _, ka := range known {
+		addr := ctypes.KnownAddress{
+			ID:          ka.Addr.ID,
+			Address:     ka.Addr.String(),
+			Bucket:      "new",
+			Attempts:    ka.Attempts,
+			LastAttempt: ka.LastAttempt,
+			LastSuccess: ka.LastSuccess,
+			LastSeen:    ka.LastSeen,
+		}
+		if ka.Src != nil {
+			addr.Source = ka.Src.String()
+		}
+		if ka.IsOld {
+			addr.Bucket = "old"
+		}
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i].ID < addrs[j].ID })
+
+	return &ctypes.ResultNetworkGraph{
+		NAddresses: len(addrs),
+		Addresses:  addrs,
+	}, nil
+}
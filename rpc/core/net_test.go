@@ -0,0 +1,126 @@
+package core
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/pex"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+)
+
+func TestAuthorizeAdmin(t *testing.T) {
+	defer SetConfig(config)
+	rpcConfig := cfg.DefaultRPCConfig()
+	rpcConfig.AdminToken = "secret"
+	SetConfig(*rpcConfig)
+
+	request := func(header string) *rpctypes.Context {
+		req, _ := http.NewRequest("GET", "http://localhost/dial_peer", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		return &rpctypes.Context{HTTPReq: req}
+	}
+
+	testCases := []struct {
+		ctx        *rpctypes.Context
+		authorized bool
+	}{
+		{request("Bearer secret"), true},
+		{request("Bearer other"), false},
+		{request("secret"), false},
+		{request(""), false},
+		// local client
+		{&rpctypes.Context{}, true},
+	}
+	for i, tc := range testCases {
+		err := authorizeAdmin(tc.ctx)
+		if tc.authorized {
+			assert.NoError(t, err, "#%d", i)
+		} else {
+			assert.Equal(t, ctypes.ErrCodeUnauthorized, err.(*rpctypes.RPCError).Code, "#%d", i)
+		}
+	}
+
+	// No token configured, no one but the local client is authorized.
+	rpcConfig.AdminToken = ""
+	SetConfig(*rpcConfig)
+	assert.Error(t, authorizeAdmin(request("Bearer ")))
+}
+
+func TestDialError(t *testing.T) {
+	addr := &p2p.NetAddress{ID: "93529da3435c090d02251a050342b6a488d4ab56"}
+	testCases := []struct {
+		err  error
+		code int
+	}{
+		{p2p.ErrCurrentlyDialingOrExistingAddress{Addr: addr}, ctypes.ErrCodeAlreadyConnected},
+		{p2p.ErrSwitchDuplicatePeerID{ID: addr.ID}, ctypes.ErrCodeAlreadyConnected},
+		{p2p.ErrSwitchConnectToSelf{Addr: addr}, ctypes.ErrCodeConnectToSelf},
+		{p2p.ErrSwitchBannedPeer{ID: addr.ID}, ctypes.ErrCodePeerRejected},
+		{errors.New("connection refused"), ctypes.ErrCodeDialFailed},
+	}
+	for i, tc := range testCases {
+		err := dialError(tc.err).(*rpctypes.RPCError)
+		assert.Equal(t, tc.code, err.Code, "#%d", i)
+		assert.Equal(t, tc.err.Error(), err.Data, "#%d", i)
+	}
+}
+
+func TestAddrBookAdmin(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addrbook_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	book := pex.NewAddrBook(filepath.Join(dir, "addrbook.json"), false)
+	book.SetLogger(log.TestingLogger())
+	defer SetAddrBook(addrBook)
+	SetAddrBook(book)
+	SetLogger(log.TestingLogger())
+
+	good := "93529da3435c090d02251a050342b6a488d4ab56@10.0.0.2:26656"
+	other := "5576458aef205977e18fd50b274e9b5d9014525a@10.0.0.3:26656"
+	ctx := &rpctypes.Context{}
+
+	imported, err := AddrBookImport(ctx, []pex.AddrBookEntry{
+		{Address: good, Good: true},
+		{Address: other},
+		{Address: "10.0.0.4:26656"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, imported.NAdded)
+	assert.Len(t, imported.Errors, 1)
+
+	result, err := AddrBook(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.NAddresses)
+	assert.Equal(t, other, result.Addresses[0].Address)
+	assert.False(t, result.Addresses[0].Good)
+	assert.Equal(t, good, result.Addresses[1].Address)
+	assert.True(t, result.Addresses[1].Good)
+
+	_, err = AddrBookMarkGood(ctx, other)
+	require.NoError(t, err)
+	_, err = AddrBookMarkBad(ctx, good)
+	require.NoError(t, err)
+	result, err = AddrBook(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.NAddresses)
+	assert.True(t, result.Addresses[0].Good)
+
+	_, err = AddrBookMarkGood(ctx, good)
+	assert.Equal(t, ctypes.ErrCodePeerNotFound, err.(*rpctypes.RPCError).Code)
+	_, err = AddrBookMarkBad(ctx, "10.0.0.4:26656")
+	assert.Equal(t, ctypes.ErrCodeInvalidAddress, err.(*rpctypes.RPCError).Code)
+}
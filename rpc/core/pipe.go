@@ -3,13 +3,18 @@ package core
 import (
 	"time"
 
+	abci "github.com/tendermint/tendermint/abci/types"
+	bc "github.com/tendermint/tendermint/blockchain"
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/consensus"
+	cstypes "github.com/tendermint/tendermint/consensus/types"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/evidence"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 	mempl "github.com/tendermint/tendermint/mempool"
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/pex"
 	"github.com/tendermint/tendermint/proxy"
 	sm "github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/state/txindex"
@@ -33,6 +38,7 @@ type Consensus interface {
 	GetState() sm.State
 	GetValidators() (int64, []*types.Validator)
 	GetLastHeight() int64
+	GetRoundState() *cstypes.RoundState
 	GetRoundStateJSON() ([]byte, error)
 	GetRoundStateSimpleJSON() ([]byte, error)
 }
@@ -45,8 +51,59 @@ type transport interface {
 
 type peers interface {
 	DialPeersAsync(p2p.AddrBook, []string, bool) error
+	DialPeer(*p2p.NetAddress, bool) error
+	StopPeerGracefully(p2p.Peer)
 	NumPeers() (outbound, inbound, dialig int)
 	Peers() p2p.IPeerSet
+	PersistentPeers() []*p2p.NetAddress
+	SetPersistentPeers([]string) error
+	RemovePersistentPeers([]p2p.ID)
+	DialStates() []p2p.DialState
+	PrivatePeerIDs() []p2p.ID
+	SetPrivatePeerIDs([]string)
+}
+
+type peerBehaviour interface {
+	p2p.GettablePeerBehaviour
+	Summary(p2p.ID) (p2p.PeerBehaviourSummary, bool)
+}
+
+type addressBook interface {
+	p2p.AddrBook
+	MarkBad(*p2p.NetAddress)
+	Export() []pex.AddrBookEntry
+	Import([]pex.AddrBookEntry) (int, []error)
+}
+
+type blockchainReactor interface {
+	SyncInfo() bc.SyncInfo
+}
+
+type seedCrawler interface {
+	KnownAddresses() []pex.KnownAddress
+}
+
+// Mempool is the mempool as used by the RPC: a *mempool.Mempool, or a
+// mempool.NopMempool if mempool.type is nop.
+type Mempool interface {
+	sm.Mempool
+	CheckTxs([]types.Tx, func(int, *abci.Response)) []error
+	ReapMaxTxs(max int) types.Txs
+	TxsBytes() int64
+	HasTx(hash []byte) bool
+	EvictedTx(hash []byte) *mempl.EvictedTx
+}
+
+var (
+	_ Mempool = (*mempl.Mempool)(nil)
+	_ Mempool = mempl.NopMempool{}
+)
+
+type inspectableEvidencePool interface {
+	sm.EvidencePool
+	PendingEvidence(maxNum int64) []types.Evidence
+	NumPending() int
+	EvidenceInfo(hash []byte) *evidence.EvidenceInfo
 }
 
 //----------------------------------------------
@@ -60,23 +117,28 @@ var (
 	// interfaces defined in types and above
 	stateDB        dbm.DB
 	blockStore     sm.BlockStore
-	evidencePool   sm.EvidencePool
+	evidencePool   inspectableEvidencePool
 	consensusState Consensus
 	p2pPeers       peers
 	p2pTransport   transport
+	p2pBehaviour   peerBehaviour
+	p2pCrawler     seedCrawler
+	blockSyncer    blockchainReactor
 
 	// objects
 	pubKey           crypto.PubKey
 	genDoc           *types.GenesisDoc // cache the genesis structure
-	addrBook         p2p.AddrBook
+	addrBook         addressBook
 	txIndexer        txindex.TxIndexer
 	consensusReactor *consensus.ConsensusReactor
 	eventBus         *types.EventBus // thread safe
-	mempool          *mempl.Mempool
+	mempool          Mempool
 
 	logger log.Logger
 
 	config cfg.RPCConfig
+
+	appHashMismatchFile string
 )
 
 func SetStateDB(db dbm.DB) {
@@ -87,11 +149,11 @@ func SetBlockStore(bs sm.BlockStore) {
 	blockStore = bs
 }
 
-func SetMempool(mem *mempl.Mempool) {
+func SetMempool(mem Mempool) {
 	mempool = mem
 }
 
-func SetEvidencePool(evpool sm.EvidencePool) {
+func SetEvidencePool(evpool inspectableEvidencePool) {
 	evidencePool = evpool
 }
 
@@ -107,6 +169,18 @@ func SetP2PTransport(t transport) {
 	p2pTransport = t
 }
 
+func SetPeerBehaviour(pb peerBehaviour) {
+	p2pBehaviour = pb
+}
+
+func SetSeedCrawler(c seedCrawler) {
+	p2pCrawler = c
+}
+
+func SetBlockchainReactor(bcR blockchainReactor) {
+	blockSyncer = bcR
+}
+
 func SetPubKey(pk crypto.PubKey) {
 	pubKey = pk
 }
@@ -115,7 +189,7 @@ func SetGenesisDoc(doc *types.GenesisDoc) {
 	genDoc = doc
 }
 
-func SetAddrBook(book p2p.AddrBook) {
+func SetAddrBook(book addressBook) {
 	addrBook = book
 }
 
@@ -139,6 +213,12 @@ func SetEventBus(b *types.EventBus) {
 	eventBus = b
 }
 
+// SetAppHashMismatchFile sets the file the report of an app hash mismatch is
+// read from.
+func SetAppHashMismatchFile(path string) {
+	appHashMismatchFile = path
+}
+
 // SetConfig sets an RPCConfig.
 func SetConfig(c cfg.RPCConfig) {
 	config = c
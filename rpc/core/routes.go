@@ -11,39 +11,55 @@ var Routes = map[string]*rpc.RPCFunc{
 	"subscribe":       rpc.NewWSRPCFunc(Subscribe, "query"),
 	"unsubscribe":     rpc.NewWSRPCFunc(Unsubscribe, "query"),
 	"unsubscribe_all": rpc.NewWSRPCFunc(UnsubscribeAll, ""),
+	"events":          rpc.NewRPCFunc(Events, "query,after,max_items,wait_time"),
 
 	// info API
 	"health":               rpc.NewRPCFunc(Health, ""),
 	"status":               rpc.NewRPCFunc(Status, ""),
+	"sync_status":          rpc.NewRPCFunc(SyncStatus, ""),
+	"app_hash_mismatch":    rpc.NewRPCFunc(AppHashMismatch, ""),
 	"net_info":             rpc.NewRPCFunc(NetInfo, ""),
+	"peer_behaviour":       rpc.NewRPCFunc(PeerBehaviour, "peer_id"),
+	"network_graph":        rpc.NewRPCFunc(NetworkGraph, ""),
 	"blockchain":           rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight"),
 	"genesis":              rpc.NewRPCFunc(Genesis, ""),
 	"block":                rpc.NewRPCFunc(Block, "height"),
 	"block_results":        rpc.NewRPCFunc(BlockResults, "height"),
 	"commit":               rpc.NewRPCFunc(Commit, "height"),
 	"tx":                   rpc.NewRPCFunc(Tx, "hash,prove"),
-	"tx_search":            rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page"),
+	"tx_search":            rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page,order_by,cursor,skip_count"),
 	"validators":           rpc.NewRPCFunc(Validators, "height"),
+	"validator_changes":    rpc.NewRPCFunc(ValidatorChanges, "from,to"),
 	"dump_consensus_state": rpc.NewRPCFunc(DumpConsensusState, ""),
 	"consensus_state":      rpc.NewRPCFunc(ConsensusState, ""),
 	"consensus_params":     rpc.NewRPCFunc(ConsensusParams, "height"),
 	"unconfirmed_txs":      rpc.NewRPCFunc(UnconfirmedTxs, "limit"),
 	"num_unconfirmed_txs":  rpc.NewRPCFunc(NumUnconfirmedTxs, ""),
+	"tx_status":            rpc.NewRPCFunc(TxStatus, "hash"),
 
 	// broadcast API
 	"broadcast_tx_commit": rpc.NewRPCFunc(BroadcastTxCommit, "tx"),
 	"broadcast_tx_sync":   rpc.NewRPCFunc(BroadcastTxSync, "tx"),
 	"broadcast_tx_async":  rpc.NewRPCFunc(BroadcastTxAsync, "tx"),
+	"broadcast_tx_batch":  rpc.NewRPCFunc(BroadcastTxBatch, "txs"),
 
 	// abci API
-	"abci_query": rpc.NewRPCFunc(ABCIQuery, "path,data,height,prove"),
-	"abci_info":  rpc.NewRPCFunc(ABCIInfo, ""),
+	"abci_query":       rpc.NewRPCFunc(ABCIQuery, "path,data,height,prove"),
+	"abci_query_batch": rpc.NewRPCFunc(ABCIQueryBatch, "queries"),
+	"abci_info":        rpc.NewRPCFunc(ABCIInfo, ""),
+
+	// evidence API
+	"broadcast_evidence":   rpc.NewRPCFunc(BroadcastEvidence, "evidence"),
+	"unconfirmed_evidence": rpc.NewRPCFunc(UnconfirmedEvidence, "limit"),
+	"evidence":             rpc.NewRPCFunc(Evidence, "hash"),
 }
 
 func AddUnsafeRoutes() {
 	// control API
 	Routes["dial_seeds"] = rpc.NewRPCFunc(UnsafeDialSeeds, "seeds")
 	Routes["dial_peers"] = rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent")
+	Routes["set_persistent_peers"] = rpc.NewRPCFunc(UnsafeSetPersistentPeers, "peers")
+	Routes["set_private_peer_ids"] = rpc.NewRPCFunc(UnsafeSetPrivatePeerIDs, "ids")
 	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "")
 
 	// profiler API
@@ -51,3 +67,15 @@ func AddUnsafeRoutes() {
 	Routes["unsafe_stop_cpu_profiler"] = rpc.NewRPCFunc(UnsafeStopCPUProfiler, "")
 	Routes["unsafe_write_heap_profile"] = rpc.NewRPCFunc(UnsafeWriteHeapProfile, "filename")
 }
+
+// AddAdminRoutes adds the routes which require the admin token, see
+// authorizeAdmin.
+func AddAdminRoutes() {
+	Routes["dial_peer"] = rpc.NewRPCFunc(DialPeer, "peer,persistent")
+	Routes["remove_peer"] = rpc.NewRPCFunc(RemovePeer, "peer_id")
+	Routes["dial_states"] = rpc.NewRPCFunc(DialStates, "")
+	Routes["addr_book"] = rpc.NewRPCFunc(AddrBook, "")
+	Routes["addr_book_import"] = rpc.NewRPCFunc(AddrBookImport, "entries")
+	Routes["addr_book_mark_good"] = rpc.NewRPCFunc(AddrBookMarkGood, "addr")
+	Routes["addr_book_mark_bad"] = rpc.NewRPCFunc(AddrBookMarkBad, "addr")
+}
@@ -2,6 +2,8 @@ package core
 
 import (
 	"bytes"
+	"os"
+	"strconv"
 	"time"
 
 	cmn "github.com/tendermint/tendermint/libs/common"
@@ -117,6 +119,133 @@ func Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
 	return result, nil
 }
 
+// Get the progress of fast sync: the last synced and the highest known height,
+// the number of blocks verified per second over the last 10 seconds, the
+// estimated time left in nanoseconds and what each peer contributes. recv_rate
+// is in bytes per second. syncing is false once the node switched to
+// consensus.
+//
+// ```shell
+// curl 'localhost:26657/sync_status'
+// ```
+//
+// ```go
+// client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+// err := client.Start()
+// if err != nil {
+//   // handle error
+// }
+// defer client.Stop()
+// result, err := client.SyncStatus()
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "syncing": true,
+//     "start_height": "1",
+//     "height": "1520",
+//     "target_height": "9822",
+//     "blocks_per_second": "81.40",
+//     "eta": "101990171990",
+//     "peers": [
+//       {
+//         "id": "93529da3435c090d02251a050342b6a488d4ab56",
+//         "height": "9822",
+//         "num_pending": 20,
+//         "blocks_received": "1534",
+//         "recv_rate": "248104"
+//       }
+//     ]
+//   }
+// }
+// ```
+func SyncStatus(ctx *rpctypes.Context) (*ctypes.ResultSyncStatus, error) {
+	info := blockSyncer.SyncInfo()
+	peers := make([]ctypes.PeerSyncStatus, 0, len(info.Peers))
+	for _, peer := range info.Peers {
+		peers = append(peers, ctypes.PeerSyncStatus{
+			ID:             peer.ID,
+			Height:         peer.Height,
+			NumPending:     peer.NumPending,
+			BlocksReceived: peer.BlocksReceived,
+			RecvRate:       peer.RecvRate,
+		})
+	}
+	return &ctypes.ResultSyncStatus{
+		Syncing:         info.Syncing,
+		StartHeight:     info.StartHeight,
+		Height:          info.Height,
+		TargetHeight:    info.TargetHeight,
+		BlocksPerSecond: strconv.FormatFloat(info.BlocksPerSecond, 'f', 2, 64),
+		ETA:             info.ETA,
+		Peers:           peers,
+	}, nil
+}
+
+// Get the diagnostic report of the last app hash mismatch: the app hash after
+// executing a block, during the replay of the blocks on start or fast sync,
+// disagreed with the one the chain agreed on. The report is null if there was
+// none.
+//
+// The report has the height of the block, the last height whose app hash
+// agreed (0 if unknown), both app hashes, the hash of the DeliverTx results
+// and the one the chain agreed on (if known), and the responses of the app
+// which differ from the ones retained from a previous execution of the block
+// (if any).
+//
+// ```shell
+// curl 'localhost:26657/app_hash_mismatch'
+// ```
+//
+// ```go
+// client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+// err := client.Start()
+// if err != nil {
+//   // handle error
+// }
+// defer client.Stop()
+// result, err := client.AppHashMismatch()
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "report": {
+//       "time": "2019-06-04T09:38:50.314932Z",
+//       "source": "replay",
+//       "height": "1520",
+//       "last_agreed_height": "1519",
+//       "app_hash": "A1B2C3D4",
+//       "expected_app_hash": "E5F60718",
+//       "results_hash": "7F0CA66B4CEB1A2BCE0A9E1356F46ADB1B8D9E1FF3A2E4B8F8DF0E7C2D1A3B4C",
+//       "expected_results_hash": "7F0CA66B4CEB1A2BCE0A9E1356F46ADB1B8D9E1FF3A2E4B8F8DF0E7C2D1A3B4C",
+//       "responses_diff": null
+//     }
+//   }
+// }
+// ```
+func AppHashMismatch(ctx *rpctypes.Context) (*ctypes.ResultAppHashMismatch, error) {
+	if appHashMismatchFile == "" {
+		return &ctypes.ResultAppHashMismatch{}, nil
+	}
+	report, err := sm.LoadAppHashMismatch(appHashMismatchFile)
+	if os.IsNotExist(err) {
+		return &ctypes.ResultAppHashMismatch{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultAppHashMismatch{Report: report}, nil
+}
+
 func validatorAtHeight(h int64) *types.Validator {
 	privValAddress := pubKey.Address()
 
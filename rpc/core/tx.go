@@ -3,11 +3,10 @@ package core
 import (
 	"fmt"
 
-	cmn "github.com/tendermint/tendermint/libs/common"
-
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/state/txindex/null"
 	"github.com/tendermint/tendermint/types"
 )
@@ -114,7 +113,13 @@ func Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error
 }
 
 // TxSearch allows you to query for multiple transactions results. It returns a
-// list of transactions (maximum ?per_page entries) and the total count.
+// list of transactions (maximum ?per_page entries), ordered by height and
+// index, and the total count.
+//
+// The pages can be selected with ?page, or, so that they aren't shifted by
+// the new txs, with the ?cursor returned as next_cursor by the previous page.
+// Counting the txs can be skipped with ?skip_count, as it's costly for the
+// queries with OR or NOT.
 //
 // ```shell
 // curl "localhost:26657/tx_search?query=\"account.owner='Ivan'\"&prove=true"
@@ -169,12 +174,15 @@ func Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error
 //
 // ### Query Parameters
 //
-// | Parameter | Type   | Default | Required | Description                                               |
-// |-----------+--------+---------+----------+-----------------------------------------------------------|
-// | query     | string | ""      | true     | Query                                                     |
-// | prove     | bool   | false   | false    | Include proofs of the transactions inclusion in the block |
-// | page      | int    | 1       | false    | Page number (1-based)                                     |
-// | per_page  | int    | 30      | false    | Number of entries per page (max: 100)                     |
+// | Parameter  | Type   | Default | Required | Description                                               |
+// |------------+--------+---------+----------+-----------------------------------------------------------|
+// | query      | string | ""      | true     | Query                                                     |
+// | prove      | bool   | false   | false    | Include proofs of the transactions inclusion in the block |
+// | page       | int    | 1       | false    | Page number (1-based)                                     |
+// | per_page   | int    | 30      | false    | Number of entries per page (max: 100)                     |
+// | order_by   | string | "asc"   | false    | Order by height and index, "asc" or "desc"                |
+// | cursor     | string | ""      | false    | next_cursor of the previous page (page is then ignored)   |
+// | skip_count | bool   | false   | false    | Don't count the txs (total_count is then -1)              |
 //
 // ### Returns
 //
@@ -184,32 +192,52 @@ func Tx(ctx *rpctypes.Context, hash []byte, prove bool) (*ctypes.ResultTx, error
 // - `index`: `int` - index of the transaction
 // - `height`: `int` - height of the block where this transaction was in
 // - `hash`: `[]byte` - hash of the transaction
-func TxSearch(ctx *rpctypes.Context, query string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error) {
+func TxSearch(ctx *rpctypes.Context, query string, prove bool, page, perPage int,
+	orderBy, cursor string, skipCount bool) (*ctypes.ResultTxSearch, error) {
 	// if index is disabled, return error
 	if _, ok := txIndexer.(*null.TxIndex); ok {
 		return nil, fmt.Errorf("Transaction indexing is disabled")
 	}
 
-	q, err := tmquery.New(query)
+	q, err := tmquery.NewCompat(query)
 	if err != nil {
 		return nil, err
 	}
 
-	results, err := txIndexer.Search(q)
+	opts := txindex.SearchOptions{Count: !skipCount}
+	switch orderBy {
+	case "", "asc":
+	case "desc":
+		opts.OrderDesc = true
+	default:
+		return nil, fmt.Errorf("Invalid order_by %q, expected asc or desc", orderBy)
+	}
+	perPage = validatePerPage(perPage)
+	opts.Limit = perPage
+	if cursor != "" {
+		if opts.After, err = txindex.ParseCursor(cursor); err != nil {
+			return nil, err
+		}
+	} else {
+		opts.Offset = validateSkipCount(page, perPage)
+	}
+
+	results, err := txIndexer.Search(q, opts)
 	if err != nil {
 		return nil, err
 	}
+	// past the last page, return the last page
+	if cursor == "" && len(results.Txs) == 0 && results.TotalCount > 0 {
+		page = validatePage(page, perPage, results.TotalCount)
+		opts.Offset = validateSkipCount(page, perPage)
+		if results, err = txIndexer.Search(q, opts); err != nil {
+			return nil, err
+		}
+	}
 
-	totalCount := len(results)
-	perPage = validatePerPage(perPage)
-	page = validatePage(page, perPage, totalCount)
-	skipCount := validateSkipCount(page, perPage)
-
-	apiResults := make([]*ctypes.ResultTx, cmn.MinInt(perPage, totalCount-skipCount))
+	apiResults := make([]*ctypes.ResultTx, len(results.Txs))
 	var proof types.TxProof
-	// if there's no tx in the results array, we don't need to loop through the apiResults array
-	for i := 0; i < len(apiResults); i++ {
-		r := results[skipCount+i]
+	for i, r := range results.Txs {
 		height := r.Height
 		index := r.Index
 
@@ -228,5 +256,9 @@ func TxSearch(ctx *rpctypes.Context, query string, prove bool, page, perPage int
 		}
 	}
 
-	return &ctypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}, nil
+	result := &ctypes.ResultTxSearch{Txs: apiResults, TotalCount: results.TotalCount}
+	if results.Next != nil {
+		result.NextCursor = results.Next.String()
+	}
+	return result, nil
 }
@@ -0,0 +1,22 @@
+package core_types
+
+// Error codes returned by the admin endpoints, in the range reserved for
+// implementation-defined server errors by the JSON-RPC 2.0 spec. Clients get
+// them as the Code of the *rpctypes.RPCError wrapped in the returned error.
+const (
+	// The Authorization header is missing or holds the wrong token.
+	ErrCodeUnauthorized = -32001
+	// The peer address can't be parsed.
+	ErrCodeInvalidAddress = -32002
+	// The peer is connected or being dialed already.
+	ErrCodeAlreadyConnected = -32003
+	// The address is our own.
+	ErrCodeConnectToSelf = -32004
+	// The peer was reached, but rejected, e.g. because it is banned, has a
+	// different ID than dialed, or is incompatible.
+	ErrCodePeerRejected = -32005
+	// The peer couldn't be reached.
+	ErrCodeDialFailed = -32006
+	// The peer is neither connected nor persistent.
+	ErrCodePeerNotFound = -32007
+)
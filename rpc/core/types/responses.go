@@ -9,6 +9,7 @@ import (
 	cmn "github.com/tendermint/tendermint/libs/common"
 
 	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/pex"
 	"github.com/tendermint/tendermint/state"
 	"github.com/tendermint/tendermint/types"
 )
@@ -105,6 +106,51 @@ type ResultDialPeers struct {
 	Log string `json:"log"`
 }
 
+// Persistent peers after an update
+type ResultPersistentPeers struct {
+	Peers []string `json:"peers"`
+}
+
+// Private peer IDs after an update
+type ResultPrivatePeerIDs struct {
+	IDs []p2p.ID `json:"ids"`
+}
+
+// Peer dialed by an admin
+type ResultDialPeer struct {
+	ID         p2p.ID `json:"id"`
+	Address    string `json:"address"`
+	Persistent bool   `json:"persistent"`
+}
+
+// Peer removed by an admin
+type ResultRemovePeer struct {
+	ID           p2p.ID `json:"id"`
+	WasConnected bool   `json:"was_connected"`
+}
+
+// Reconnect states of the persistent peers
+type ResultDialStates struct {
+	Peers []p2p.DialState `json:"peers"`
+}
+
+// Entries of the address book
+type ResultAddrBook struct {
+	NAddresses int                 `json:"n_addresses"`
+	Addresses  []pex.AddrBookEntry `json:"addresses"`
+}
+
+// Address book entries imported by an admin
+type ResultAddrBookImport struct {
+	NAdded int      `json:"n_added"`
+	Errors []string `json:"errors"`
+}
+
+// Address book entry marked by an admin
+type ResultAddrBookMark struct {
+	Address string `json:"address"`
+}
+
 // A peer
 type Peer struct {
 	NodeInfo         p2p.DefaultNodeInfo  `json:"node_info"`
@@ -113,12 +159,95 @@ type Peer struct {
 	RemoteIP         string               `json:"remote_ip"`
 }
 
+// Behaviour reported about a peer
+type ResultPeerBehaviour struct {
+	PeerID       p2p.ID               `json:"peer_id"`
+	ErrorCounts  []PeerBehaviourCount `json:"error_counts"`
+	GoodCounts   []PeerBehaviourCount `json:"good_counts"`
+	RecentEvents []PeerBehaviourEvent `json:"recent_events"`
+}
+
+// Number of times a behaviour was reported
+type PeerBehaviourCount struct {
+	Reason string `json:"reason"`
+	Count  int    `json:"count"`
+}
+
+// A single behaviour report. Kind is either "error" or "good".
+type PeerBehaviourEvent struct {
+	Time    time.Time `json:"time"`
+	Kind    string    `json:"kind"`
+	Reason  string    `json:"reason"`
+	Reactor string    `json:"reactor"`
+	Height  int64     `json:"height"`
+	Detail  string    `json:"detail"`
+}
+
+// Fast sync progress. BlocksPerSecond is a decimal string, as amino can't
+// decode floats. ETA is zero while unknown.
+type ResultSyncStatus struct {
+	Syncing         bool             `json:"syncing"`
+	StartHeight     int64            `json:"start_height"`
+	Height          int64            `json:"height"`
+	TargetHeight    int64            `json:"target_height"`
+	BlocksPerSecond string           `json:"blocks_per_second"`
+	ETA             time.Duration    `json:"eta"`
+	Peers           []PeerSyncStatus `json:"peers"`
+}
+
+// The diagnostic report of the last app hash mismatch, nil if there was none.
+type ResultAppHashMismatch struct {
+	Report *state.AppHashMismatch `json:"report"`
+}
+
+// What a peer contributes to fast sync. RecvRate is in bytes per second.
+type PeerSyncStatus struct {
+	ID             p2p.ID `json:"id"`
+	Height         int64  `json:"height"`
+	NumPending     int32  `json:"num_pending"`
+	BlocksReceived int64  `json:"blocks_received"`
+	RecvRate       int64  `json:"recv_rate"`
+}
+
+// Addresses known to a seed node
+type ResultNetworkGraph struct {
+	NAddresses int            `json:"n_addresses"`
+	Addresses  []KnownAddress `json:"addresses"`
+}
+
+// A known address, linked to the address of the peer which told the seed
+// about it. Source is empty if it's unknown.
+type KnownAddress struct {
+	ID          p2p.ID    `json:"id"`
+	Address     string    `json:"address"`
+	Source      string    `json:"source"`
+	Bucket      string    `json:"bucket"`
+	Attempts    int32     `json:"attempts"`
+	LastAttempt time.Time `json:"last_attempt"`
+	LastSuccess time.Time `json:"last_success"`
+	LastSeen    time.Time `json:"last_seen"`
+}
+
 // Validators for a height
 type ResultValidators struct {
 	BlockHeight int64              `json:"block_height"`
 	Validators  []*types.Validator `json:"validators"`
 }
 
+// Validator set changes within a range of heights
+type ResultValidatorChanges struct {
+	From    int64                `json:"from"`
+	To      int64                `json:"to"`
+	Changes []ValidatorSetChange `json:"changes"`
+}
+
+// The changes of voting power made by the EndBlock of a block
+type ValidatorSetChange struct {
+	Height          int64                        `json:"height"`
+	EffectiveHeight int64                        `json:"effective_height"`
+	Validators      []types.ValidatorPowerChange `json:"validators"`
+}
+
 // ConsensusParams for given height
 type ResultConsensusParams struct {
 	BlockHeight     int64                 `json:"block_height"`
@@ -130,12 +259,42 @@ type ResultConsensusParams struct {
 type ResultDumpConsensusState struct {
 	RoundState json.RawMessage `json:"round_state"`
 	Peers      []PeerStateInfo `json:"peers"`
+	Locks      ConsensusLocks  `json:"locks"`
 }
 
 // UNSTABLE
 type PeerStateInfo struct {
 	NodeAddress string          `json:"node_address"`
 	PeerState   json.RawMessage `json:"peer_state"`
+
+	// Zero if the peer never sent us a consensus message.
+	LastMessageTime time.Time `json:"last_message_time"`
+	Votes           PeerVotes `json:"votes"`
+}
+
+// ConsensusLocks are the blocks the node is locked on and considers valid, by
+// hash, unlike the round_state which has the blocks. Empty hashes are nil.
+type ConsensusLocks struct {
+	Height            int64        `json:"height"`
+	Round             int          `json:"round"`
+	Step              string       `json:"step"`
+	ProposalBlockHash cmn.HexBytes `json:"proposal_block_hash"`
+	LockedRound       int          `json:"locked_round"`
+	LockedBlockHash   cmn.HexBytes `json:"locked_block_hash"`
+	ValidRound        int          `json:"valid_round"`
+	ValidBlockHash    cmn.HexBytes `json:"valid_block_hash"`
+}
+
+// PeerVotes are the addresses of the validators whose votes a peer has, at
+// the height and round of the peer, unlike the peer_state which has bit
+// arrays. They are empty if the validators of the height aren't known.
+type PeerVotes struct {
+	Height     int64           `json:"height"`
+	Round      int             `json:"round"`
+	Prevotes   []types.Address `json:"prevotes"`
+	Precommits []types.Address `json:"precommits"`
+	// Precommits of the previous height, at the peer's last commit round.
+	LastCommit []types.Address `json:"last_commit"`
 }
 
 // UNSTABLE
@@ -152,6 +311,21 @@ type ResultBroadcastTx struct {
 	Hash cmn.HexBytes `json:"hash"`
 }
 
+// CheckTx results of a batch of txs, in the order of the txs
+type ResultBroadcastTxBatch struct {
+	Txs []ResultBatchTx `json:"txs"`
+}
+
+// CheckTx result of a tx of a batch. Error is set if the mempool rejected
+// the tx (e.g. it's already in the cache, or the mempool is full)
+type ResultBatchTx struct {
+	Code  uint32       `json:"code"`
+	Data  cmn.HexBytes `json:"data"`
+	Log   string       `json:"log"`
+	Hash  cmn.HexBytes `json:"hash"`
+	Error string       `json:"error,omitempty"`
+}
+
 // CheckTx and DeliverTx results
 type ResultBroadcastTxCommit struct {
 	CheckTx   abci.ResponseCheckTx   `json:"check_tx"`
@@ -170,10 +344,12 @@ type ResultTx struct {
 	Proof    types.TxProof          `json:"proof,omitempty"`
 }
 
-// Result of searching for txs
+// Result of searching for txs. TotalCount is -1 if the txs weren't counted,
+// and NextCursor is set if there are more txs after the last one.
 type ResultTxSearch struct {
 	Txs        []*ResultTx `json:"txs"`
 	TotalCount int         `json:"total_count"`
+	NextCursor string      `json:"next_cursor,omitempty"`
 }
 
 // List of mempool txs
@@ -184,6 +360,23 @@ type ResultUnconfirmedTxs struct {
 	Txs        []types.Tx `json:"txs"`
 }
 
+// The statuses of a tx, see ResultTxStatus
+const (
+	TxStatusPending   = "pending"
+	TxStatusEvicted   = "evicted"
+	TxStatusCommitted = "committed"
+	TxStatusUnknown   = "unknown"
+)
+
+// Status of a tx: in the mempool, evicted from the mempool (with the reason)
+// or committed
+type ResultTxStatus struct {
+	Hash   cmn.HexBytes `json:"hash"`
+	Status string       `json:"status"`
+	Reason string       `json:"reason,omitempty"`
+	Height int64        `json:"height,omitempty"`
+}
+
 // Info abci msg
 type ResultABCIInfo struct {
 	Response abci.ResponseInfo `json:"response"`
@@ -194,6 +387,26 @@ type ResultABCIQuery struct {
 	Response abci.ResponseQuery `json:"response"`
 }
 
+// A query of a batch of /abci_query_batch, with the parameters of /abci_query
+type RequestABCIQuery struct {
+	Path   string       `json:"path"`
+	Data   cmn.HexBytes `json:"data"`
+	Height int64        `json:"height"`
+	Prove  bool         `json:"prove"`
+}
+
+// Responses to a batch of queries, in the order of the queries
+type ResultABCIQueryBatch struct {
+	Responses []ResultBatchQuery `json:"responses"`
+}
+
+// Response to a query of a batch. Error is set if the query failed (e.g. the
+// connection to the app failed, or a light client couldn't verify the proof)
+type ResultBatchQuery struct {
+	Response abci.ResponseQuery `json:"response"`
+	Error    string             `json:"error,omitempty"`
+}
+
 // empty results
 type (
 	ResultUnsafeFlushMempool struct{}
@@ -203,9 +416,46 @@ type (
 	ResultHealth             struct{}
 )
 
-// Event data from a subscription
+// Result of broadcasting evidence
+type ResultBroadcastEvidence struct {
+	Hash []byte `json:"hash"`
+}
+
+// List of pending evidence
+type ResultUnconfirmedEvidence struct {
+	Count    int              `json:"n_evidence"`
+	Total    int              `json:"total"`
+	Evidence []types.Evidence `json:"evidence"`
+}
+
+// Evidence of the evidence pool, with its status
+type ResultEvidence struct {
+	Evidence        types.Evidence `json:"evidence"`
+	Committed       bool           `json:"committed"`
+	Priority        int64          `json:"priority"`
+	VerifiedHeight  int64          `json:"verified_height"`
+	CommittedHeight int64          `json:"committed_height"`
+}
+
+// Event data from a subscription, or from /events. Cursor is set if the node
+// keeps an event log, and Dropped is the number of events of the subscription
+// dropped before this one as the client wasn't reading them fast enough.
 type ResultEvent struct {
-	Query string            `json:"query"`
-	Data  types.TMEventData `json:"data"`
-	Tags  map[string]string `json:"tags"`
+	Query   string            `json:"query"`
+	Data    types.TMEventData `json:"data"`
+	Tags    map[string]string `json:"tags"`
+	Cursor  string            `json:"cursor,omitempty"`
+	Dropped uint64            `json:"dropped,omitempty"`
+}
+
+// Events of the event log after a cursor, oldest first. More is set if there
+// are more events after the last one, and Truncated if events after the
+// cursor were pruned from the log and can't be fetched anymore. Oldest and
+// Newest are the cursors of the oldest and the newest event of the log.
+type ResultEvents struct {
+	Items     []*ResultEvent `json:"items"`
+	More      bool           `json:"more"`
+	Truncated bool           `json:"truncated"`
+	Oldest    string         `json:"oldest,omitempty"`
+	Newest    string         `json:"newest,omitempty"`
 }
@@ -2,10 +2,15 @@ package core_grpc
 
 import (
 	"context"
+	"fmt"
+
+	"google.golang.org/grpc/peer"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	core "github.com/tendermint/tendermint/rpc/core"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 type broadcastAPI struct {
@@ -17,23 +22,202 @@ func (bapi *broadcastAPI) Ping(ctx context.Context, req *RequestPing) (*Response
 }
 
 func (bapi *broadcastAPI) BroadcastTx(ctx context.Context, req *RequestBroadcastTx) (*ResponseBroadcastTx, error) {
+	return broadcastTx(req)
+}
+
+// coreAPI serves the main methods of the RPC (see rpc/core).
+type coreAPI struct {
+}
+
+func (capi *coreAPI) Status(ctx context.Context, req *RequestStatus) (*ResponseStatus, error) {
+	res, err := core.Status(&rpctypes.Context{})
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseStatus{
+		NodeId:               string(res.NodeInfo.ID()),
+		ListenAddr:           res.NodeInfo.ListenAddr,
+		Network:              res.NodeInfo.Network,
+		Version:              res.NodeInfo.Version,
+		Moniker:              res.NodeInfo.Moniker,
+		LatestBlockHash:      res.SyncInfo.LatestBlockHash,
+		LatestAppHash:        res.SyncInfo.LatestAppHash,
+		LatestBlockHeight:    res.SyncInfo.LatestBlockHeight,
+		LatestBlockTime:      res.SyncInfo.LatestBlockTime,
+		CatchingUp:           res.SyncInfo.CatchingUp,
+		ValidatorAddress:     res.ValidatorInfo.Address,
+		ValidatorVotingPower: res.ValidatorInfo.VotingPower,
+	}, nil
+}
+
+func (capi *coreAPI) Block(ctx context.Context, req *RequestBlock) (*ResponseBlock, error) {
+	res, err := core.Block(&rpctypes.Context{}, heightPtr(req.Height))
+	if err != nil {
+		return nil, err
+	}
+	txs := make([][]byte, len(res.Block.Txs))
+	for i, tx := range res.Block.Txs {
+		txs[i] = tx
+	}
+	return &ResponseBlock{
+		BlockId: types.TM2PB.BlockID(res.BlockMeta.BlockID),
+		Header:  types.TM2PB.Header(&res.Block.Header),
+		Txs:     txs,
+	}, nil
+}
+
+func (capi *coreAPI) BlockResults(ctx context.Context, req *RequestBlockResults) (*ResponseBlockResults, error) {
+	res, err := core.BlockResults(&rpctypes.Context{}, heightPtr(req.Height))
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseBlockResults{
+		Height:     res.Height,
+		BeginBlock: res.Results.BeginBlock,
+		DeliverTxs: res.Results.DeliverTx,
+		EndBlock:   res.Results.EndBlock,
+	}, nil
+}
+
+func (capi *coreAPI) Tx(ctx context.Context, req *RequestTx) (*ResponseTx, error) {
+	res, err := core.Tx(&rpctypes.Context{}, req.Hash, req.Prove)
+	if err != nil {
+		return nil, err
+	}
+	resTx := &ResponseTx{
+		Hash:     res.Hash,
+		Height:   res.Height,
+		Index:    res.Index,
+		Tx:       res.Tx,
+		TxResult: res.TxResult,
+	}
+	if req.Prove {
+		resTx.Proof = &TxProof{
+			RootHash: res.Proof.RootHash,
+			Data:     res.Proof.Data,
+			Total:    int64(res.Proof.Proof.Total),
+			Index:    int64(res.Proof.Proof.Index),
+			LeafHash: res.Proof.Proof.LeafHash,
+			Aunts:    res.Proof.Proof.Aunts,
+		}
+	}
+	return resTx, nil
+}
+
+func (capi *coreAPI) BroadcastTx(ctx context.Context, req *RequestBroadcastTx) (*ResponseBroadcastTx, error) {
+	return broadcastTx(req)
+}
+
+func (capi *coreAPI) ABCIQuery(ctx context.Context, req *RequestABCIQuery) (*ResponseABCIQuery, error) {
+	res, err := core.ABCIQuery(&rpctypes.Context{}, req.Path, req.Data, req.Height, req.Prove)
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseABCIQuery{Response: res.Response}, nil
+}
+
+// Subscribe streams the events matching the query, within the limits of the
+// subscriptions of the RPC, until the client cancels the call.
+func (capi *coreAPI) Subscribe(req *RequestSubscribe, stream CoreAPI_SubscribeServer) error {
+	// the subscriptions are per connection
+	var subscriber string
+	if p, ok := peer.FromContext(stream.Context()); ok {
+		subscriber = p.Addr.String()
+	}
+	return core.StreamEvents(stream.Context(), "grpc:"+subscriber, req.Query, func(resultEvent *ctypes.ResultEvent) error {
+		event, err := responseEvent(resultEvent)
+		if err != nil {
+			return err
+		}
+		return stream.Send(event)
+	})
+}
+
+// broadcastTx broadcasts the tx, waiting for what the mode of the request
+// says.
+func broadcastTx(req *RequestBroadcastTx) (*ResponseBroadcastTx, error) {
 	// NOTE: there's no way to get client's remote address
 	// see https://stackoverflow.com/questions/33684570/session-and-remote-ip-address-in-grpc-go
-	res, err := core.BroadcastTxCommit(&rpctypes.Context{}, req.Tx)
+	switch req.Mode {
+	case BroadcastMode_COMMIT:
+		res, err := core.BroadcastTxCommit(&rpctypes.Context{}, req.Tx)
+		if err != nil {
+			return nil, err
+		}
+		return &ResponseBroadcastTx{
+			CheckTx: &abci.ResponseCheckTx{
+				Code: res.CheckTx.Code,
+				Data: res.CheckTx.Data,
+				Log:  res.CheckTx.Log,
+			},
+			DeliverTx: &abci.ResponseDeliverTx{
+				Code: res.DeliverTx.Code,
+				Data: res.DeliverTx.Data,
+				Log:  res.DeliverTx.Log,
+			},
+			Hash:   res.Hash,
+			Height: res.Height,
+		}, nil
+	case BroadcastMode_SYNC, BroadcastMode_ASYNC:
+		broadcast := core.BroadcastTxSync
+		if req.Mode == BroadcastMode_ASYNC {
+			broadcast = core.BroadcastTxAsync
+		}
+		res, err := broadcast(&rpctypes.Context{}, req.Tx)
+		if err != nil {
+			return nil, err
+		}
+		return &ResponseBroadcastTx{
+			CheckTx: &abci.ResponseCheckTx{
+				Code: res.Code,
+				Data: res.Data,
+				Log:  res.Log,
+			},
+			Hash: res.Hash,
+		}, nil
+	default:
+		return nil, fmt.Errorf("Unknown broadcast mode %v", req.Mode)
+	}
+}
+
+// responseEvent returns the event with its data as JSON, and typed for the
+// events of blocks and txs.
+func responseEvent(resultEvent *ctypes.ResultEvent) (*ResponseEvent, error) {
+	data, err := cdc.MarshalJSON(resultEvent.Data)
 	if err != nil {
 		return nil, err
 	}
+	event := &ResponseEvent{
+		Query:   resultEvent.Query,
+		Type:    resultEvent.Tags[types.EventTypeKey],
+		Tags:    resultEvent.Tags,
+		Cursor:  resultEvent.Cursor,
+		Dropped: resultEvent.Dropped,
+		Data:    data,
+	}
+	switch data := resultEvent.Data.(type) {
+	case types.EventDataNewBlock:
+		header := types.TM2PB.Header(&data.Block.Header)
+		event.Header = &header
+	case types.EventDataNewBlockHeader:
+		header := types.TM2PB.Header(&data.Header)
+		event.Header = &header
+	case types.EventDataTx:
+		event.Tx = &ResponseTx{
+			Hash:     data.Tx.Hash(),
+			Height:   data.Height,
+			Index:    data.Index,
+			Tx:       data.Tx,
+			TxResult: data.Result,
+		}
+	}
+	return event, nil
+}
 
-	return &ResponseBroadcastTx{
-		CheckTx: &abci.ResponseCheckTx{
-			Code: res.CheckTx.Code,
-			Data: res.CheckTx.Data,
-			Log:  res.CheckTx.Log,
-		},
-		DeliverTx: &abci.ResponseDeliverTx{
-			Code: res.DeliverTx.Code,
-			Data: res.DeliverTx.Data,
-			Log:  res.DeliverTx.Log,
-		},
-	}, nil
+// heightPtr returns nil for the height 0, i.e. the latest height.
+func heightPtr(height int64) *int64 {
+	if height == 0 {
+		return nil
+	}
+	return &height
 }
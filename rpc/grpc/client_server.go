@@ -14,12 +14,13 @@ type Config struct {
 	MaxOpenConnections int
 }
 
-// StartGRPCServer starts a new gRPC BroadcastAPIServer using the given
-// net.Listener.
+// StartGRPCServer starts a new gRPC BroadcastAPIServer and CoreAPIServer
+// using the given net.Listener.
 // NOTE: This function blocks - you may want to call it in a go-routine.
 func StartGRPCServer(ln net.Listener) error {
 	grpcServer := grpc.NewServer()
 	RegisterBroadcastAPIServer(grpcServer, &broadcastAPI{})
+	RegisterCoreAPIServer(grpcServer, &coreAPI{})
 	return grpcServer.Serve(ln)
 }
 
@@ -33,6 +34,16 @@ func StartGRPCClient(protoAddr string) BroadcastAPIClient {
 	return NewBroadcastAPIClient(conn)
 }
 
+// StartGRPCCoreClient dials the gRPC server using protoAddr and returns a new
+// CoreAPIClient.
+func StartGRPCCoreClient(protoAddr string) CoreAPIClient {
+	conn, err := grpc.Dial(protoAddr, grpc.WithInsecure(), grpc.WithDialer(dialerFunc))
+	if err != nil {
+		panic(err)
+	}
+	return NewCoreAPIClient(conn)
+}
+
 func dialerFunc(addr string, timeout time.Duration) (net.Conn, error) {
 	return cmn.Connect(addr)
 }
@@ -4,12 +4,15 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/abci/example/kvstore"
 	core_grpc "github.com/tendermint/tendermint/rpc/grpc"
 	rpctest "github.com/tendermint/tendermint/rpc/test"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestMain(m *testing.M) {
@@ -30,3 +33,84 @@ func TestBroadcastTx(t *testing.T) {
 	require.EqualValues(t, 0, res.CheckTx.Code)
 	require.EqualValues(t, 0, res.DeliverTx.Code)
 }
+
+func TestBroadcastTxSync(t *testing.T) {
+	res, err := rpctest.GetGRPCClient().BroadcastTx(context.Background(),
+		&core_grpc.RequestBroadcastTx{Tx: []byte("sync=tx"), Mode: core_grpc.BroadcastMode_SYNC})
+	require.NoError(t, err)
+	require.EqualValues(t, 0, res.CheckTx.Code)
+	assert.Nil(t, res.DeliverTx)
+	assert.EqualValues(t, types.Tx("sync=tx").Hash(), res.Hash)
+}
+
+func TestCoreAPI(t *testing.T) {
+	client := rpctest.GetGRPCCoreClient()
+	ctx := context.Background()
+
+	tx := []byte("core=api")
+	bres, err := client.BroadcastTx(ctx, &core_grpc.RequestBroadcastTx{Tx: tx})
+	require.NoError(t, err)
+	require.EqualValues(t, 0, bres.DeliverTx.Code)
+
+	status, err := client.Status(ctx, &core_grpc.RequestStatus{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, status.NodeId)
+	assert.True(t, status.LatestBlockHeight >= bres.Height)
+
+	block, err := client.Block(ctx, &core_grpc.RequestBlock{Height: bres.Height})
+	require.NoError(t, err)
+	assert.Equal(t, bres.Height, block.Header.Height)
+	// the block may have the txs of the other tests too
+	assert.Contains(t, block.Txs, tx)
+
+	results, err := client.BlockResults(ctx, &core_grpc.RequestBlockResults{Height: bres.Height})
+	require.NoError(t, err)
+	require.Len(t, results.DeliverTxs, len(block.Txs))
+	for _, deliverTx := range results.DeliverTxs {
+		assert.EqualValues(t, 0, deliverTx.Code)
+	}
+
+	// the txs are indexed asynchronously
+	var txRes *core_grpc.ResponseTx
+	for i := 0; i < 50; i++ {
+		if txRes, err = client.Tx(ctx, &core_grpc.RequestTx{Hash: bres.Hash, Prove: true}); err == nil {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	require.NoError(t, err)
+	assert.Equal(t, bres.Height, txRes.Height)
+	assert.EqualValues(t, tx, txRes.Tx)
+	require.NotNil(t, txRes.Proof)
+	assert.EqualValues(t, block.Header.DataHash, txRes.Proof.RootHash)
+
+	qres, err := client.ABCIQuery(ctx, &core_grpc.RequestABCIQuery{Path: "/key", Data: []byte("core")})
+	require.NoError(t, err)
+	assert.EqualValues(t, "api", qres.Response.Value)
+}
+
+func TestCoreAPISubscribe(t *testing.T) {
+	client := rpctest.GetGRPCCoreClient()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := client.Subscribe(ctx, &core_grpc.RequestSubscribe{Query: "tm.event='Tx'"})
+	require.NoError(t, err)
+	// wait for the subscription
+	time.Sleep(500 * time.Millisecond)
+
+	tx := []byte("subscribe=grpc")
+	_, err = client.BroadcastTx(ctx, &core_grpc.RequestBroadcastTx{Tx: tx, Mode: core_grpc.BroadcastMode_ASYNC})
+	require.NoError(t, err)
+
+	event, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, types.EventTx, event.Type)
+	require.NotNil(t, event.Tx)
+	assert.EqualValues(t, tx, event.Tx.Tx)
+	assert.NotEmpty(t, event.Data)
+	assert.NotEmpty(t, event.Cursor)
+
+	_, err = client.Subscribe(ctx, &core_grpc.RequestSubscribe{Query: "tm.event="})
+	require.NoError(t, err) // the error is returned by Recv
+}
@@ -86,6 +86,7 @@ type JSONRPCClient struct {
 	address string
 	client  *http.Client
 	cdc     *amino.Codec
+	header  http.Header
 }
 
 // NewJSONRPCClient returns a JSONRPCClient pointed at the given address.
@@ -95,33 +96,112 @@ func NewJSONRPCClient(remote string) *JSONRPCClient {
 		address: address,
 		client:  client,
 		cdc:     amino.NewCodec(),
+		header:  make(http.Header),
 	}
 }
 
+// SetHeader sets a header sent with every request, e.g. an Authorization
+// header. It must not be called concurrently with Call.
+func (c *JSONRPCClient) SetHeader(key, value string) {
+	c.header.Set(key, value)
+}
+
 func (c *JSONRPCClient) Call(method string, params map[string]interface{}, result interface{}) (interface{}, error) {
 	request, err := types.MapToRequest(c.cdc, types.JSONRPCStringID("jsonrpc-client"), method, params)
 	if err != nil {
 		return nil, err
 	}
+	responseBytes, err := c.post(request)
+	if err != nil {
+		return nil, err
+	}
+	// 	log.Info(Fmt("RPC response: %v", string(responseBytes)))
+	return unmarshalResponseBytes(c.cdc, responseBytes, result)
+}
+
+// BatchCall is a call of a batch request.
+type BatchCall struct {
+	Method string
+	Params map[string]interface{}
+	// The result of the call is unmarshalled into Result.
+	Result interface{}
+	// The error of the call, set by CallBatch.
+	Err error
+}
+
+// CallBatch makes the calls in a single JSON-RPC batch request, setting the
+// error of each call. It returns an error if the batch request failed as a
+// whole, e.g. if it has more calls than the server accepts.
+func (c *JSONRPCClient) CallBatch(calls []*BatchCall) error {
+	requests := make([]types.RPCRequest, len(calls))
+	for i, call := range calls {
+		request, err := types.MapToRequest(c.cdc, types.JSONRPCIntID(i), call.Method, call.Params)
+		if err != nil {
+			return err
+		}
+		requests[i] = request
+	}
+	responseBytes, err := c.post(requests)
+	if err != nil {
+		return err
+	}
+
+	var responses []types.RPCResponse
+	if err := json.Unmarshal(responseBytes, &responses); err != nil {
+		// a batch request failing as a whole is answered with a single error
+		var response types.RPCResponse
+		if json.Unmarshal(responseBytes, &response) == nil && response.Error != nil {
+			return errors.Wrap(response.Error, "Response error")
+		}
+		return errors.Errorf("Error unmarshalling rpc responses: %v", err)
+	}
+	answered := make([]bool, len(calls))
+	for _, response := range responses {
+		id, ok := response.ID.(types.JSONRPCIntID)
+		if !ok || int(id) < 0 || int(id) >= len(calls) {
+			return errors.Errorf("Unexpected rpc response ID %v", response.ID)
+		}
+		call := calls[id]
+		answered[id] = true
+		if response.Error != nil {
+			call.Err = errors.Wrap(response.Error, "Response error")
+			continue
+		}
+		if err := c.cdc.UnmarshalJSON(response.Result, call.Result); err != nil {
+			call.Err = errors.Errorf("Error unmarshalling rpc response result: %v", err)
+		}
+	}
+	for i, call := range calls {
+		if !answered[i] {
+			call.Err = errors.New("No response to the call")
+		}
+	}
+	return nil
+}
+
+// post posts the request(s) as JSON and returns the body of the response.
+func (c *JSONRPCClient) post(request interface{}) ([]byte, error) {
 	requestBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, err
 	}
 	// log.Info(string(requestBytes))
 	requestBuf := bytes.NewBuffer(requestBytes)
-	// log.Info(Fmt("RPC request to %v (%v): %v", c.remote, method, string(requestBytes)))
-	httpResponse, err := c.client.Post(c.address, "text/json", requestBuf)
+	httpRequest, err := http.NewRequest(http.MethodPost, c.address, requestBuf)
 	if err != nil {
 		return nil, err
 	}
-	defer httpResponse.Body.Close() // nolint: errcheck
-
-	responseBytes, err := ioutil.ReadAll(httpResponse.Body)
+	for key, values := range c.header {
+		httpRequest.Header[key] = values
+	}
+	httpRequest.Header.Set("Content-Type", "text/json")
+	httpResponse, err := c.client.Do(httpRequest)
 	if err != nil {
 		return nil, err
 	}
-	// 	log.Info(Fmt("RPC response: %v", string(responseBytes)))
-	return unmarshalResponseBytes(c.cdc, responseBytes, result)
+	defer httpResponse.Body.Close() // nolint: errcheck
+
+	return ioutil.ReadAll(httpResponse.Body)
 }
 
 func (c *JSONRPCClient) Codec() *amino.Codec {
@@ -190,7 +270,7 @@ func unmarshalResponseBytes(cdc *amino.Codec, responseBytes []byte, result inter
 		return nil, errors.Errorf("Error unmarshalling rpc response: %v", err)
 	}
 	if response.Error != nil {
-		return nil, errors.Errorf("Response error: %v", response.Error)
+		return nil, errors.Wrap(response.Error, "Response error")
 	}
 	// Unmarshal the RawMessage into the result.
 	err = cdc.UnmarshalJSON(response.Result, result)
@@ -0,0 +1,26 @@
+package rpcclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONRPCClientSetHeader(t *testing.T) {
+	var header http.Header
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":"jsonrpc-client","result":{}}`))
+	}))
+	defer s.Close()
+
+	c := NewJSONRPCClient(s.URL)
+	c.SetHeader("Authorization", "Bearer secret")
+	_, err := c.Call("status", map[string]interface{}{}, new(struct{}))
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer secret", header.Get("Authorization"))
+	assert.Equal(t, "text/json", header.Get("Content-Type"))
+}
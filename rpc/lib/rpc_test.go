@@ -289,6 +289,22 @@ func TestServersAndClientsBasic(t *testing.T) {
 	}
 }
 
+func TestJSONRPCClientCallBatch(t *testing.T) {
+	cl := client.NewJSONRPCClient(tcpAddr)
+	echo, echoInt := new(ResultEcho), new(ResultEchoInt)
+	calls := []*client.BatchCall{
+		{Method: "echo", Params: map[string]interface{}{"arg": "abc"}, Result: echo},
+		{Method: "echo_int", Params: map[string]interface{}{"arg": 42}, Result: echoInt},
+		{Method: "echo_ws", Params: map[string]interface{}{"arg": "abc"}, Result: new(ResultEcho)},
+	}
+	require.NoError(t, cl.CallBatch(calls))
+	require.NoError(t, calls[0].Err)
+	assert.Equal(t, "abc", echo.Value)
+	require.NoError(t, calls[1].Err)
+	assert.Equal(t, 42, echoInt.Value)
+	assert.Error(t, calls[2].Err, "websocket only")
+}
+
 func TestHexStringArg(t *testing.T) {
 	cl := client.NewURIClient(tcpAddr)
 	// should NOT be handled as hex
@@ -26,15 +26,45 @@ import (
 // RegisterRPCFuncs adds a route for each function in the funcMap, as well as general jsonrpc and websocket handlers for all functions.
 // "result" is the interface on which the result objects are registered, and is popualted with every RPCResponse
 func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, cdc *amino.Codec, logger log.Logger) {
+	RegisterRPCFuncsWithBatchLimits(mux, funcMap, cdc, logger, BatchLimits{})
+}
+
+// RegisterRPCFuncsWithBatchLimits does the same as RegisterRPCFuncs, with
+// the JSON-RPC batch requests limited by limits.
+func RegisterRPCFuncsWithBatchLimits(
+	mux *http.ServeMux,
+	funcMap map[string]*RPCFunc,
+	cdc *amino.Codec,
+	logger log.Logger,
+	limits BatchLimits,
+) {
+	RegisterAuthorizedRPCFuncs(mux, funcMap, cdc, logger, limits, nil)
+}
+
+// RegisterAuthorizedRPCFuncs does the same as RegisterRPCFuncsWithBatchLimits,
+// with the calls authorized by authorize, if not nil.
+func RegisterAuthorizedRPCFuncs(
+	mux *http.ServeMux,
+	funcMap map[string]*RPCFunc,
+	cdc *amino.Codec,
+	logger log.Logger,
+	limits BatchLimits,
+	authorize Authorizer,
+) {
 	// HTTP endpoints
 	for funcName, rpcFunc := range funcMap {
-		mux.HandleFunc("/"+funcName, makeHTTPHandler(rpcFunc, cdc, logger))
+		mux.HandleFunc("/"+funcName, makeHTTPHandler(funcName, rpcFunc, cdc, logger, authorize))
 	}
 
 	// JSONRPC endpoints
-	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, cdc, logger)))
+	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, cdc, logger, limits, authorize)))
 }
 
+// Authorizer authorizes the calls: it returns an error, e.g. an
+// *types.RPCError, if the HTTP request may not call the method. The calls
+// over websocket are authorized with the request which opened the connection.
+type Authorizer func(r *http.Request, method string) error
+
 //-------------------------------------
 // function introspection
 
@@ -98,42 +128,102 @@ func funcReturnTypes(f interface{}) []reflect.Type {
 //-----------------------------------------------------------------------------
 // rpc.json
 
-// jsonrpc calls grab the given method's function info and runs reflect.Call
-func makeJSONRPCHandler(funcMap map[string]*RPCFunc, cdc *amino.Codec, logger log.Logger) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		b, err := ioutil.ReadAll(r.Body)
-		if err != nil {
-			WriteRPCResponseHTTP(w, types.RPCInvalidRequestError(types.JSONRPCStringID(""), errors.Wrap(err, "Error reading request body")))
-			return
-		}
-		// if its an empty request (like from a browser),
-		// just display a list of functions
-		if len(b) == 0 {
-			writeListOfEndpoints(w, r, funcMap)
-			return
-		}
+// BatchLimits limits the JSON-RPC batch requests, i.e. the arrays of
+// requests, which are answered with the array of the responses to the
+// requests which aren't notifications.
+type BatchLimits struct {
+	// Maximum number of requests of a batch, unlimited if 0.
+	MaxSize int
+	// How long the requests of a batch may run, unlimited if 0. The requests
+	// not run by then are answered with an error.
+	Timeout time.Duration
+}
 
+// parseBatch returns the requests of a batch request, or nil if b isn't
+// one. It returns an error response if the batch is invalid as a whole.
+func parseBatch(b []byte, limits BatchLimits) ([]json.RawMessage, *types.RPCResponse) {
+	if b = bytes.TrimLeft(b, " \t\r\n"); len(b) == 0 || b[0] != '[' {
+		return nil, nil
+	}
+	var requests []json.RawMessage
+	if err := json.Unmarshal(b, &requests); err != nil {
+		res := types.RPCParseError(types.JSONRPCStringID(""), errors.Wrap(err, "Error unmarshalling batch request"))
+		return nil, &res
+	}
+	if len(requests) == 0 {
+		res := types.RPCInvalidRequestError(types.JSONRPCStringID(""), errors.New("Empty batch request"))
+		return nil, &res
+	}
+	if limits.MaxSize > 0 && len(requests) > limits.MaxSize {
+		res := types.RPCInvalidRequestError(types.JSONRPCStringID(""),
+			errors.Errorf("Batch request of %d requests exceeds the maximum of %d", len(requests), limits.MaxSize))
+		return nil, &res
+	}
+	return requests, nil
+}
+
+// runBatch runs the requests of a batch request in order with handle, which
+// returns nil for the notifications, and returns the responses. The requests
+// not run before ctx is done are answered with an error. handle must run the
+// requests with ctx, for the running one to stop early.
+func runBatch(
+	ctx context.Context,
+	requests []json.RawMessage,
+	handle func(request types.RPCRequest) *types.RPCResponse,
+) []types.RPCResponse {
+	responses := make([]types.RPCResponse, 0, len(requests))
+	for _, b := range requests {
 		var request types.RPCRequest
-		err = json.Unmarshal(b, &request)
-		if err != nil {
-			WriteRPCResponseHTTP(w, types.RPCParseError(types.JSONRPCStringID(""), errors.Wrap(err, "Error unmarshalling request")))
-			return
+		if err := json.Unmarshal(b, &request); err != nil {
+			responses = append(responses, types.RPCInvalidRequestError(types.JSONRPCStringID(""),
+				errors.Wrap(err, "Error unmarshalling request")))
+			continue
 		}
+		if ctx.Err() != nil {
+			if request.ID != types.JSONRPCStringID("") {
+				responses = append(responses, types.RPCServerError(request.ID, errors.New("Batch request timed out")))
+			}
+			continue
+		}
+		if res := handle(request); res != nil {
+			responses = append(responses, *res)
+		}
+	}
+	return responses
+}
+
+// jsonrpc calls grab the given method's function info and runs reflect.Call
+func makeJSONRPCHandler(
+	funcMap map[string]*RPCFunc,
+	cdc *amino.Codec,
+	logger log.Logger,
+	limits BatchLimits,
+	authorize Authorizer,
+) http.HandlerFunc {
+	handle := func(r *http.Request, request types.RPCRequest) *types.RPCResponse {
 		// A Notification is a Request object without an "id" member.
 		// The Server MUST NOT reply to a Notification, including those that are within a batch request.
 		if request.ID == types.JSONRPCStringID("") {
 			logger.Debug("HTTPJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)")
-			return
+			return nil
 		}
+
+		var res types.RPCResponse
 		if len(r.URL.Path) > 1 {
-			WriteRPCResponseHTTP(w, types.RPCInvalidRequestError(request.ID, errors.Errorf("Path %s is invalid", r.URL.Path)))
-			return
+			res = types.RPCInvalidRequestError(request.ID, errors.Errorf("Path %s is invalid", r.URL.Path))
+			return &res
 		}
 
 		rpcFunc := funcMap[request.Method]
 		if rpcFunc == nil || rpcFunc.ws {
-			WriteRPCResponseHTTP(w, types.RPCMethodNotFoundError(request.ID))
-			return
+			res = types.RPCMethodNotFoundError(request.ID)
+			return &res
+		}
+		if authorize != nil {
+			if err := authorize(r, request.Method); err != nil {
+				res = types.RPCFuncError(request.ID, err)
+				return &res
+			}
 		}
 
 		ctx := &types.Context{JSONReq: &request, HTTPReq: r}
@@ -141,8 +231,8 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, cdc *amino.Codec, logger lo
 		if len(request.Params) > 0 {
 			fnArgs, err := jsonParamsToArgs(rpcFunc, cdc, request.Params)
 			if err != nil {
-				WriteRPCResponseHTTP(w, types.RPCInvalidParamsError(request.ID, errors.Wrap(err, "Error converting json params to arguments")))
-				return
+				res = types.RPCInvalidParamsError(request.ID, errors.Wrap(err, "Error converting json params to arguments"))
+				return &res
 			}
 			args = append(args, fnArgs...)
 		}
@@ -152,10 +242,55 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, cdc *amino.Codec, logger lo
 		logger.Info("HTTPJSONRPC", "method", request.Method, "args", args, "returns", returns)
 		result, err := unreflectResult(returns)
 		if err != nil {
-			WriteRPCResponseHTTP(w, types.RPCInternalError(request.ID, err))
+			res = types.RPCFuncError(request.ID, err)
+		} else {
+			res = types.NewRPCSuccessResponse(cdc, request.ID, result)
+		}
+		return &res
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			WriteRPCResponseHTTP(w, types.RPCInvalidRequestError(types.JSONRPCStringID(""), errors.Wrap(err, "Error reading request body")))
+			return
+		}
+		// if its an empty request (like from a browser),
+		// just display a list of functions
+		if len(b) == 0 {
+			writeListOfEndpoints(w, r, funcMap)
+			return
+		}
+
+		requests, errRes := parseBatch(b, limits)
+		if errRes != nil {
+			WriteRPCResponseHTTP(w, *errRes)
+			return
+		}
+		if requests != nil {
+			if limits.Timeout > 0 {
+				ctx, cancel := context.WithTimeout(r.Context(), limits.Timeout)
+				defer cancel()
+				r = r.WithContext(ctx)
+			}
+			responses := runBatch(r.Context(), requests, func(request types.RPCRequest) *types.RPCResponse {
+				return handle(r, request)
+			})
+			if len(responses) > 0 {
+				WriteRPCResponseArrayHTTP(w, responses)
+			}
+			return
+		}
+
+		var request types.RPCRequest
+		err = json.Unmarshal(b, &request)
+		if err != nil {
+			WriteRPCResponseHTTP(w, types.RPCParseError(types.JSONRPCStringID(""), errors.Wrap(err, "Error unmarshalling request")))
 			return
 		}
-		WriteRPCResponseHTTP(w, types.NewRPCSuccessResponse(cdc, request.ID, result))
+		if res := handle(r, request); res != nil {
+			WriteRPCResponseHTTP(w, *res)
+		}
 	}
 }
 
@@ -244,7 +379,13 @@ func jsonParamsToArgs(rpcFunc *RPCFunc, cdc *amino.Codec, raw []byte) ([]reflect
 // rpc.http
 
 // convert from a function name to the http handler
-func makeHTTPHandler(rpcFunc *RPCFunc, cdc *amino.Codec, logger log.Logger) func(http.ResponseWriter, *http.Request) {
+func makeHTTPHandler(
+	funcName string,
+	rpcFunc *RPCFunc,
+	cdc *amino.Codec,
+	logger log.Logger,
+	authorize Authorizer,
+) func(http.ResponseWriter, *http.Request) {
 	// Exception for websocket endpoints
 	if rpcFunc.ws {
 		return func(w http.ResponseWriter, r *http.Request) {
@@ -256,6 +397,13 @@ func makeHTTPHandler(rpcFunc *RPCFunc, cdc *amino.Codec, logger log.Logger) func
 	return func(w http.ResponseWriter, r *http.Request) {
 		logger.Debug("HTTP HANDLER", "req", r)
 
+		if authorize != nil {
+			if err := authorize(r, funcName); err != nil {
+				WriteRPCResponseHTTP(w, types.RPCFuncError(types.JSONRPCStringID(""), err))
+				return
+			}
+		}
+
 		ctx := &types.Context{HTTPReq: r}
 		args := []reflect.Value{reflect.ValueOf(ctx)}
 
@@ -271,7 +419,7 @@ func makeHTTPHandler(rpcFunc *RPCFunc, cdc *amino.Codec, logger log.Logger) func
 		logger.Info("HTTPRestRPC", "method", r.URL.Path, "args", args, "returns", returns)
 		result, err := unreflectResult(returns)
 		if err != nil {
-			WriteRPCResponseHTTP(w, types.RPCInternalError(types.JSONRPCStringID(""), err))
+			WriteRPCResponseHTTP(w, types.RPCFuncError(types.JSONRPCStringID(""), err))
 			return
 		}
 		WriteRPCResponseHTTP(w, types.NewRPCSuccessResponse(cdc, types.JSONRPCStringID(""), result))
@@ -421,11 +569,18 @@ type wsConnection struct {
 
 	remoteAddr string
 	baseConn   *websocket.Conn
-	writeChan  chan types.RPCResponse
+	// types.RPCResponse, or the []types.RPCResponse to a batch request
+	writeChan chan interface{}
 
 	funcMap map[string]*RPCFunc
 	cdc     *amino.Codec
 
+	// limits of the batch requests
+	batchLimits BatchLimits
+
+	// authorizes the calls, if not nil
+	authorize func(method string) error
+
 	// write channel capacity
 	writeChanCapacity int
 
@@ -515,10 +670,18 @@ func PingPeriod(pingPeriod time.Duration) func(*wsConnection) {
 	}
 }
 
+// WSBatchLimits sets the limits of the batch requests.
+// It should only be used in the constructor - not Goroutine-safe.
+func WSBatchLimits(limits BatchLimits) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.batchLimits = limits
+	}
+}
+
 // OnStart implements cmn.Service by starting the read and write routines. It
 // blocks until the connection closes.
 func (wsc *wsConnection) OnStart() error {
-	wsc.writeChan = make(chan types.RPCResponse, wsc.writeChanCapacity)
+	wsc.writeChan = make(chan interface{}, wsc.writeChanCapacity)
 
 	// Read subscriptions/unsubscriptions to events
 	go wsc.readRoutine()
@@ -628,52 +791,110 @@ func (wsc *wsConnection) readRoutine() {
 				return
 			}
 
+			requests, errRes := parseBatch(in, wsc.batchLimits)
+			if errRes != nil {
+				wsc.WriteRPCResponse(*errRes)
+				continue
+			}
+			if requests != nil {
+				wsc.handleBatch(requests)
+				continue
+			}
+
 			var request types.RPCRequest
 			err = json.Unmarshal(in, &request)
 			if err != nil {
 				wsc.WriteRPCResponse(types.RPCParseError(types.JSONRPCStringID(""), errors.Wrap(err, "Error unmarshaling request")))
 				continue
 			}
-
-			// A Notification is a Request object without an "id" member.
-			// The Server MUST NOT reply to a Notification, including those that are within a batch request.
-			if request.ID == types.JSONRPCStringID("") {
-				wsc.Logger.Debug("WSJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)")
-				continue
+			if res := wsc.handleRequest(wsc, request); res != nil {
+				wsc.WriteRPCResponse(*res)
 			}
+		}
+	}
+}
 
-			// Now, fetch the RPCFunc and execute it.
-			rpcFunc := wsc.funcMap[request.Method]
-			if rpcFunc == nil {
-				wsc.WriteRPCResponse(types.RPCMethodNotFoundError(request.ID))
-				continue
-			}
+// handleRequest runs the request on conn, wsc or a wsBatchConn, and returns the
+// response, or nil if the request is a notification.
+func (wsc *wsConnection) handleRequest(conn types.WSRPCConnection, request types.RPCRequest) *types.RPCResponse {
+	// A Notification is a Request object without an "id" member.
+	// The Server MUST NOT reply to a Notification, including those that are within a batch request.
+	if request.ID == types.JSONRPCStringID("") {
+		wsc.Logger.Debug("WSJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)")
+		return nil
+	}
 
-			ctx := &types.Context{JSONReq: &request, WSConn: wsc}
-			args := []reflect.Value{reflect.ValueOf(ctx)}
-			if len(request.Params) > 0 {
-				fnArgs, err := jsonParamsToArgs(rpcFunc, wsc.cdc, request.Params)
-				if err != nil {
-					wsc.WriteRPCResponse(types.RPCInternalError(request.ID, errors.Wrap(err, "Error converting json params to arguments")))
-					continue
-				}
-				args = append(args, fnArgs...)
-			}
+	// Now, fetch the RPCFunc and execute it.
+	var res types.RPCResponse
+	rpcFunc := wsc.funcMap[request.Method]
+	if rpcFunc == nil {
+		res = types.RPCMethodNotFoundError(request.ID)
+		return &res
+	}
+	if wsc.authorize != nil {
+		if err := wsc.authorize(request.Method); err != nil {
+			res = types.RPCFuncError(request.ID, err)
+			return &res
+		}
+	}
 
-			returns := rpcFunc.f.Call(args)
+	ctx := &types.Context{JSONReq: &request, WSConn: conn}
+	args := []reflect.Value{reflect.ValueOf(ctx)}
+	if len(request.Params) > 0 {
+		fnArgs, err := jsonParamsToArgs(rpcFunc, wsc.cdc, request.Params)
+		if err != nil {
+			res = types.RPCInternalError(request.ID, errors.Wrap(err, "Error converting json params to arguments"))
+			return &res
+		}
+		args = append(args, fnArgs...)
+	}
 
-			// TODO: Need to encode args/returns to string if we want to log them
-			wsc.Logger.Info("WSJSONRPC", "method", request.Method)
+	returns := rpcFunc.f.Call(args)
 
-			result, err := unreflectResult(returns)
-			if err != nil {
-				wsc.WriteRPCResponse(types.RPCInternalError(request.ID, err))
-				continue
-			}
+	// TODO: Need to encode args/returns to string if we want to log them
+	wsc.Logger.Info("WSJSONRPC", "method", request.Method)
 
-			wsc.WriteRPCResponse(types.NewRPCSuccessResponse(wsc.cdc, request.ID, result))
-		}
+	result, err := unreflectResult(returns)
+	if err != nil {
+		res = types.RPCFuncError(request.ID, err)
+	} else {
+		res = types.NewRPCSuccessResponse(wsc.cdc, request.ID, result)
 	}
+	return &res
+}
+
+// handleBatch runs the requests of a batch request and pushes the responses
+// to the writeChan, blocking until they are accepted.
+func (wsc *wsConnection) handleBatch(requests []json.RawMessage) {
+	ctx := wsc.Context()
+	if wsc.batchLimits.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, wsc.batchLimits.Timeout)
+		defer cancel()
+	}
+	conn := wsBatchConn{wsc, ctx}
+	responses := runBatch(ctx, requests, func(request types.RPCRequest) *types.RPCResponse {
+		return wsc.handleRequest(conn, request)
+	})
+	if len(responses) == 0 {
+		return
+	}
+	select {
+	case <-wsc.Quit():
+	case wsc.writeChan <- responses:
+	}
+}
+
+// wsBatchConn is the connection of the requests of a batch request, whose
+// context is done once the batch times out.
+type wsBatchConn struct {
+	*wsConnection
+	ctx context.Context
+}
+
+// Context implements types.WSRPCConnection.
+func (c wsBatchConn) Context() context.Context {
+	return c.ctx
 }
 
 // receives on a write channel and writes out on the socket
@@ -747,6 +968,7 @@ type WebsocketManager struct {
 	funcMap       map[string]*RPCFunc
 	cdc           *amino.Codec
 	logger        log.Logger
+	authorizer    Authorizer
 	wsConnOptions []func(*wsConnection)
 }
 
@@ -772,6 +994,12 @@ func (wm *WebsocketManager) SetLogger(l log.Logger) {
 	wm.logger = l
 }
 
+// SetAuthorizer sets the authorizer of the calls, which are authorized with
+// the request which opened the connection.
+func (wm *WebsocketManager) SetAuthorizer(authorizer Authorizer) {
+	wm.authorizer = authorizer
+}
+
 // WebsocketHandler upgrades the request/response (via http.Hijack) and starts
 // the wsConnection.
 func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Request) {
@@ -784,6 +1012,10 @@ func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Requ
 
 	// register connection
 	con := NewWSConnection(wsConn, wm.funcMap, wm.cdc, wm.wsConnOptions...)
+	if wm.authorizer != nil {
+		// the handler runs as long as the connection, the request is valid
+		con.authorize = func(method string) error { return wm.authorizer(r, method) }
+	}
 	con.SetLogger(wm.logger.With("remote", wsConn.RemoteAddr()))
 	wm.logger.Info("New websocket connection", "remote", con.remoteAddr)
 	err = con.Start() // Blocking
@@ -799,6 +1031,9 @@ func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Requ
 func unreflectResult(returns []reflect.Value) (interface{}, error) {
 	errV := returns[1]
 	if errV.Interface() != nil {
+		if rpcErr, ok := errV.Interface().(*types.RPCError); ok {
+			return nil, rpcErr
+		}
 		return nil, errors.Errorf("%v", errV.Interface())
 	}
 	rv := returns[0]
@@ -3,11 +3,13 @@ package rpcserver_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
@@ -29,6 +31,10 @@ import (
 func testMux() *http.ServeMux {
 	funcMap := map[string]*rs.RPCFunc{
 		"c": rs.NewRPCFunc(func(ctx *types.Context, s string, i int) (string, error) { return "foo", nil }, "s,i"),
+		"e": rs.NewRPCFunc(func(ctx *types.Context) (string, error) {
+			return "", &types.RPCError{Code: -32042, Message: "Custom error", Data: "details"}
+		}, ""),
+		"f": rs.NewRPCFunc(func(ctx *types.Context) (string, error) { return "", errors.New("failed") }, ""),
 	}
 	cdc := amino.NewCodec()
 	mux := http.NewServeMux()
@@ -139,6 +145,32 @@ func TestJSONRPCID(t *testing.T) {
 	}
 }
 
+func TestRPCFuncErrors(t *testing.T) {
+	mux := testMux()
+	tests := []struct {
+		payload string
+		want    types.RPCError
+	}{
+		// functions can choose the error code
+		{`{"jsonrpc": "2.0", "method": "e", "id": "0"}`, types.RPCError{Code: -32042, Message: "Custom error", Data: "details"}},
+		// other errors are internal errors
+		{`{"jsonrpc": "2.0", "method": "f", "id": "0"}`, types.RPCError{Code: -32603, Message: "Internal error", Data: "failed"}},
+	}
+
+	for i, tt := range tests {
+		req, _ := http.NewRequest("POST", "http://localhost/", strings.NewReader(tt.payload))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		blob, err := ioutil.ReadAll(rec.Result().Body)
+		require.Nil(t, err, "#%d", i)
+
+		recv := new(types.RPCResponse)
+		require.Nil(t, json.Unmarshal(blob, recv), "#%d", i)
+		require.NotNil(t, recv.Error, "#%d", i)
+		assert.Equal(t, tt.want, *recv.Error, "#%d", i)
+	}
+}
+
 func TestRPCNotification(t *testing.T) {
 	mux := testMux()
 	body := strings.NewReader(`{"jsonrpc": "2.0", "id": ""}`)
@@ -154,6 +186,72 @@ func TestRPCNotification(t *testing.T) {
 	require.Equal(t, len(blob), 0, "a notification SHOULD NOT be responded to by the server")
 }
 
+func TestRPCBatch(t *testing.T) {
+	funcMap := map[string]*rs.RPCFunc{
+		"c": rs.NewRPCFunc(func(ctx *types.Context, s string) (string, error) { return s, nil }, "s"),
+		"sleep": rs.NewRPCFunc(func(ctx *types.Context) (string, error) {
+			select {
+			case <-ctx.Context().Done():
+				return "", ctx.Context().Err()
+			case <-time.After(time.Second):
+				return "", nil
+			}
+		}, ""),
+	}
+	mux := http.NewServeMux()
+	rs.RegisterRPCFuncsWithBatchLimits(mux, funcMap, amino.NewCodec(), log.TestingLogger(),
+		rs.BatchLimits{MaxSize: 3, Timeout: 50 * time.Millisecond})
+
+	post := func(payload string) []byte {
+		req, _ := http.NewRequest("POST", "http://localhost/", strings.NewReader(payload))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		blob, err := ioutil.ReadAll(rec.Result().Body)
+		require.NoError(t, err)
+		return blob
+	}
+
+	// a result or an error for each request, none for the notifications
+	var responses []types.RPCResponse
+	blob := post(`[{"jsonrpc": "2.0", "method": "c", "params": ["a"], "id": 1},
+		{"jsonrpc": "2.0", "method": "c", "params": ["b"], "id": ""},
+		{"jsonrpc": "2.0", "method": "d", "id": "2"}]`)
+	require.NoError(t, json.Unmarshal(blob, &responses), string(blob))
+	require.Len(t, responses, 2)
+	assert.Equal(t, types.JSONRPCIntID(1), responses[0].ID)
+	assert.Nil(t, responses[0].Error)
+	assert.Equal(t, `"a"`, string(responses[0].Result))
+	assert.Equal(t, types.JSONRPCStringID("2"), responses[1].ID)
+	require.NotNil(t, responses[1].Error)
+	assert.Equal(t, "Method not found", responses[1].Error.Message)
+
+	// a batch of notifications isn't responded to
+	assert.Empty(t, post(`[{"jsonrpc": "2.0", "method": "c", "params": ["b"], "id": ""}]`))
+
+	// the request running at the timeout is told to stop, the requests not
+	// run are answered with an error
+	blob = post(`[{"jsonrpc": "2.0", "method": "sleep", "id": 1},
+		{"jsonrpc": "2.0", "method": "c", "params": ["a"], "id": 2}]`)
+	require.NoError(t, json.Unmarshal(blob, &responses), string(blob))
+	require.Len(t, responses, 2)
+	require.NotNil(t, responses[0].Error)
+	assert.Contains(t, responses[0].Error.Data, "deadline exceeded")
+	require.NotNil(t, responses[1].Error)
+	assert.Equal(t, "Batch request timed out", responses[1].Error.Data)
+
+	// the batches which are invalid as a whole are answered with an error
+	for _, payload := range []string{
+		`[]`,
+		`[{"jsonrpc": "2.0", "method": "c", "params": ["a"], "id": 1}`,
+		`[{"id": 1}, {"id": 2}, {"id": 3}, {"id": 4}]`,
+	} {
+		var response types.RPCResponse
+		blob := post(payload)
+		require.NoError(t, json.Unmarshal(blob, &response), payload)
+		assert.NotNil(t, response.Error, payload)
+	}
+}
+
 func TestUnknownRPCPath(t *testing.T) {
 	mux := testMux()
 	req, _ := http.NewRequest("GET", "http://localhost/unknownrpcpath", nil)
@@ -165,6 +263,47 @@ func TestUnknownRPCPath(t *testing.T) {
 	require.Equal(t, http.StatusNotFound, res.StatusCode, "should always return 404")
 }
 
+func TestRPCAuthorizer(t *testing.T) {
+	funcMap := map[string]*rs.RPCFunc{
+		"public":  rs.NewRPCFunc(func(ctx *types.Context) (string, error) { return "public", nil }, ""),
+		"private": rs.NewRPCFunc(func(ctx *types.Context) (string, error) { return "private", nil }, ""),
+	}
+	authorize := func(r *http.Request, method string) error {
+		if method == "public" || r.Header.Get("Authorization") == "Bearer secret" {
+			return nil
+		}
+		return &types.RPCError{Code: -32001, Message: "Unauthorized"}
+	}
+	mux := http.NewServeMux()
+	rs.RegisterAuthorizedRPCFuncs(mux, funcMap, amino.NewCodec(), log.TestingLogger(), rs.BatchLimits{}, authorize)
+
+	call := func(req *http.Request, token string) *types.RPCError {
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		var res types.RPCResponse
+		require.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&res))
+		return res.Error
+	}
+	for _, method := range []string{"public", "private"} {
+		for _, token := range []string{"", "wrong", "secret"} {
+			jsonReq, _ := http.NewRequest("POST", "http://localhost/",
+				strings.NewReader(`{"jsonrpc": "2.0", "method": "`+method+`", "id": "0"}`))
+			uriReq, _ := http.NewRequest("GET", "http://localhost/"+method, nil)
+			for _, req := range []*http.Request{jsonReq, uriReq} {
+				rpcErr := call(req, token)
+				if method == "public" || token == "secret" {
+					assert.Nil(t, rpcErr, "%s with %q", method, token)
+				} else if assert.NotNil(t, rpcErr, "%s with %q", method, token) {
+					assert.Equal(t, -32001, rpcErr.Code)
+				}
+			}
+		}
+	}
+}
+
 //////////////////////////////////////////////////////////////////////////////
 // JSON-RPC over WEBSOCKETS
 
@@ -193,6 +332,109 @@ func TestWebsocketManagerHandler(t *testing.T) {
 	require.Nil(t, resp.Error)
 }
 
+func TestWebsocketManagerBatch(t *testing.T) {
+	s := newWSServer()
+	defer s.Close()
+
+	c, _, err := websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	var requests []types.RPCRequest
+	for i := 0; i < 2; i++ {
+		req, err := types.MapToRequest(amino.NewCodec(), types.JSONRPCIntID(i), "c", map[string]interface{}{"s": "a", "i": i})
+		require.NoError(t, err)
+		requests = append(requests, req)
+	}
+	require.NoError(t, c.WriteJSON(requests))
+
+	var responses []types.RPCResponse
+	require.NoError(t, c.ReadJSON(&responses))
+	require.Len(t, responses, 2)
+	for i, resp := range responses {
+		assert.Equal(t, types.JSONRPCIntID(i), resp.ID)
+		assert.Nil(t, resp.Error)
+	}
+}
+
+func TestWebsocketManagerBatchTimeout(t *testing.T) {
+	funcMap := map[string]*rs.RPCFunc{
+		"sleep": rs.NewWSRPCFunc(func(ctx *types.Context) (string, error) {
+			select {
+			case <-ctx.Context().Done():
+				return "", ctx.Context().Err()
+			case <-time.After(time.Second):
+				return "", nil
+			}
+		}, ""),
+	}
+	wm := rs.NewWebsocketManager(funcMap, amino.NewCodec(),
+		rs.WSBatchLimits(rs.BatchLimits{MaxSize: 3, Timeout: 50 * time.Millisecond}))
+	wm.SetLogger(log.TestingLogger())
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	c, _, err := websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	// the request running at the timeout is told to stop, the requests not
+	// run are answered with an error
+	require.NoError(t, c.WriteJSON([]types.RPCRequest{
+		types.NewRPCRequest(types.JSONRPCIntID(1), "sleep", nil),
+		types.NewRPCRequest(types.JSONRPCIntID(2), "sleep", nil),
+	}))
+	var responses []types.RPCResponse
+	require.NoError(t, c.ReadJSON(&responses))
+	require.Len(t, responses, 2)
+	require.NotNil(t, responses[0].Error)
+	assert.Contains(t, responses[0].Error.Data, "deadline exceeded")
+	require.NotNil(t, responses[1].Error)
+	assert.Equal(t, "Batch request timed out", responses[1].Error.Data)
+
+	// the connection outlives the batch
+	require.NoError(t, c.WriteJSON(types.NewRPCRequest(types.JSONRPCIntID(3), "sleep", nil)))
+	var response types.RPCResponse
+	require.NoError(t, c.ReadJSON(&response))
+	assert.Nil(t, response.Error)
+}
+
+func TestWebsocketManagerAuthorizer(t *testing.T) {
+	funcMap := map[string]*rs.RPCFunc{
+		"c": rs.NewWSRPCFunc(func(ctx *types.Context) (string, error) { return "foo", nil }, ""),
+	}
+	wm := rs.NewWebsocketManager(funcMap, amino.NewCodec())
+	wm.SetLogger(log.TestingLogger())
+	wm.SetAuthorizer(func(r *http.Request, method string) error {
+		if r.Header.Get("Authorization") == "Bearer secret" {
+			return nil
+		}
+		return &types.RPCError{Code: -32001, Message: "Unauthorized"}
+	})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	for _, token := range []string{"wrong", "secret"} {
+		header := http.Header{"Authorization": []string{"Bearer " + token}}
+		c, _, err := websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket", header)
+		require.NoError(t, err)
+
+		require.NoError(t, c.WriteJSON(types.NewRPCRequest(types.JSONRPCStringID("0"), "c", nil)))
+		var resp types.RPCResponse
+		require.NoError(t, c.ReadJSON(&resp))
+		if token == "secret" {
+			assert.Nil(t, resp.Error)
+		} else if assert.NotNil(t, resp.Error) {
+			assert.Equal(t, -32001, resp.Error.Code)
+		}
+		c.Close()
+	}
+}
+
 func newWSServer() *httptest.Server {
 	funcMap := map[string]*rs.RPCFunc{
 		"c": rs.NewWSRPCFunc(func(ctx *types.Context, s string, i int) (string, error) { return "foo", nil }, "s,i"),
@@ -3,6 +3,7 @@ package rpcserver
 
 import (
 	"bufio"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -26,6 +27,9 @@ type Config struct {
 	ReadTimeout time.Duration
 	// mirrors http.Server#WriteTimeout
 	WriteTimeout time.Duration
+	// mirrors http.Server#TLSConfig, used by StartHTTPAndTLSServer, e.g. to
+	// verify the client certificates
+	TLSConfig *tls.Config
 }
 
 // DefaultConfig returns a default configuration.
@@ -79,6 +83,7 @@ func StartHTTPAndTLSServer(
 		ReadTimeout:    config.ReadTimeout,
 		WriteTimeout:   config.WriteTimeout,
 		MaxHeaderBytes: maxHeaderBytes,
+		TLSConfig:      config.TLSConfig,
 	}
 	err := s.ServeTLS(listener, certFile, keyFile)
 
@@ -111,6 +116,18 @@ func WriteRPCResponseHTTP(w http.ResponseWriter, res types.RPCResponse) {
 	w.Write(jsonBytes) // nolint: errcheck, gas
 }
 
+// WriteRPCResponseArrayHTTP writes the responses to a batch request as a JSON
+// array.
+func WriteRPCResponseArrayHTTP(w http.ResponseWriter, res []types.RPCResponse) {
+	jsonBytes, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(jsonBytes) // nolint: errcheck, gas
+}
+
 //-----------------------------------------------------------------------------
 
 // Wraps an HTTP handler, adding error logging.
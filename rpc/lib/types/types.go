@@ -232,6 +232,16 @@ func RPCServerError(id jsonrpcid, err error) RPCResponse {
 	return NewRPCErrorResponse(id, -32000, "Server error", err.Error())
 }
 
+// RPCFuncError returns the response for an error returned by an RPC function.
+// Functions can return an *RPCError to choose the code, other errors are
+// reported as internal errors.
+func RPCFuncError(id jsonrpcid, err error) RPCResponse {
+	if rpcErr, ok := err.(*RPCError); ok {
+		return NewRPCErrorResponse(id, rpcErr.Code, rpcErr.Message, rpcErr.Data)
+	}
+	return RPCInternalError(id, err)
+}
+
 //----------------------------------------
 
 // WSRPCConnection represents a websocket connection.
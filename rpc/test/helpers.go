@@ -89,6 +89,7 @@ func GetConfig() *cfg.Config {
 		globalConfig.RPC.ListenAddress = rpc
 		globalConfig.RPC.CORSAllowedOrigins = []string{"https://tendermint.com/"}
 		globalConfig.RPC.GRPCListenAddress = grpc
+		globalConfig.RPC.AdminToken = "admin"
 		globalConfig.TxIndex.IndexTags = "app.creator,tx.height" // see kvstore application
 	}
 	return globalConfig
@@ -99,6 +100,11 @@ func GetGRPCClient() core_grpc.BroadcastAPIClient {
 	return core_grpc.StartGRPCClient(grpcAddr)
 }
 
+func GetGRPCCoreClient() core_grpc.CoreAPIClient {
+	grpcAddr := globalConfig.RPC.GRPCListenAddress
+	return core_grpc.StartGRPCCoreClient(grpcAddr)
+}
+
 // StartTendermint starts a test tendermint server in a go routine and returns when it is initialized
 func StartTendermint(app abci.Application) *nm.Node {
 	node := NewTendermint(app)
@@ -0,0 +1,158 @@
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/types"
+)
+
+// The sources of an AppHashMismatch.
+const (
+	AppHashMismatchReplay   = "replay"
+	AppHashMismatchFastSync = "fast_sync"
+)
+
+// AppHashMismatch is the diagnostic report of the app hash after executing a
+// block disagreeing with the one the chain agreed on, i.e. the AppHash of the
+// header of the next block (or of the state, after a replay). It's returned
+// as an error.
+type AppHashMismatch struct {
+	Time   time.Time `json:"time"`
+	Source string    `json:"source"`
+
+	// The height of the block whose execution resulted in AppHash, and the
+	// last height whose app hash agreed with the chain (0 if unknown).
+	Height           int64 `json:"height"`
+	LastAgreedHeight int64 `json:"last_agreed_height"`
+
+	AppHash         cmn.HexBytes `json:"app_hash"`
+	ExpectedAppHash cmn.HexBytes `json:"expected_app_hash"`
+
+	// The hash of the DeliverTx results of the block, and the LastResultsHash
+	// of the next header, if known. If they agree too, the app executed the
+	// txs alike but diverged in its state.
+	ResultsHash         cmn.HexBytes `json:"results_hash"`
+	ExpectedResultsHash cmn.HexBytes `json:"expected_results_hash"`
+
+	// The differences between the ABCI responses of the block retained from
+	// its first execution and the ones of its execution which diverged, if
+	// both are known.
+	ResponsesDiff []ABCIResponseDiff `json:"responses_diff"`
+}
+
+// ABCIResponseDiff is a response of the app to the same request which
+// differs between two executions of a block.
+type ABCIResponseDiff struct {
+	Method string `json:"method"` // begin_block, deliver_tx or end_block
+	Index  int    `json:"index"`  // of the tx, for deliver_tx
+
+	Retained string `json:"retained"`
+	Got      string `json:"got"`
+}
+
+func (m *AppHashMismatch) Error() string {
+	return fmt.Sprintf("App hash mismatch after executing block %d (%s): got %X, expected %X (last agreed height %d)",
+		m.Height, m.Source, m.AppHash, m.ExpectedAppHash, m.LastAgreedHeight)
+}
+
+// CheckAppHash returns the report of the app hash of the state disagreeing
+// with the one of the header of the next block, or nil if they agree. The
+// caller sets its Time and Source.
+func CheckAppHash(state State, nextBlock *types.Block) *AppHashMismatch {
+	if bytes.Equal(state.AppHash, nextBlock.AppHash) {
+		return nil
+	}
+	// the app hash of the state's last block was validated against its header
+	lastAgreedHeight := state.LastBlockHeight - 1
+	if lastAgreedHeight < 0 {
+		lastAgreedHeight = 0
+	}
+	return &AppHashMismatch{
+		Height:              state.LastBlockHeight,
+		LastAgreedHeight:    lastAgreedHeight,
+		AppHash:             state.AppHash,
+		ExpectedAppHash:     nextBlock.AppHash,
+		ResultsHash:         state.LastResultsHash,
+		ExpectedResultsHash: nextBlock.LastResultsHash,
+	}
+}
+
+// SetResponses adds the results of the execution of the block at m.Height to
+// the report, and their differences with the retained ones, if any.
+func (m *AppHashMismatch) SetResponses(db dbm.DB, abciResponses *ABCIResponses) {
+	m.ResultsHash = abciResponses.ResultsHash()
+	retained, err := LoadABCIResponses(db, m.Height)
+	if err != nil {
+		return
+	}
+	m.ResponsesDiff = DiffABCIResponses(retained, abciResponses)
+}
+
+// DiffABCIResponses returns the responses which differ between the retained
+// and the got ABCIResponses of a block.
+func DiffABCIResponses(retained, got *ABCIResponses) []ABCIResponseDiff {
+	var diff []ABCIResponseDiff
+	if !retained.BeginBlock.Equal(got.BeginBlock) {
+		diff = append(diff, ABCIResponseDiff{
+			Method:   "begin_block",
+			Retained: retained.BeginBlock.String(),
+			Got:      got.BeginBlock.String(),
+		})
+	}
+	n := cmn.MaxInt(len(retained.DeliverTx), len(got.DeliverTx))
+	for i := 0; i < n; i++ {
+		r, g := deliverTxAt(retained, i), deliverTxAt(got, i)
+		if !r.Equal(g) {
+			diff = append(diff, ABCIResponseDiff{
+				Method:   "deliver_tx",
+				Index:    i,
+				Retained: r.String(),
+				Got:      g.String(),
+			})
+		}
+	}
+	if !retained.EndBlock.Equal(got.EndBlock) {
+		diff = append(diff, ABCIResponseDiff{
+			Method:   "end_block",
+			Retained: retained.EndBlock.String(),
+			Got:      got.EndBlock.String(),
+		})
+	}
+	return diff
+}
+
+func deliverTxAt(abciResponses *ABCIResponses, i int) *abci.ResponseDeliverTx {
+	if i < len(abciResponses.DeliverTx) {
+		return abciResponses.DeliverTx[i]
+	}
+	return nil
+}
+
+// SaveAppHashMismatch writes the report as JSON to the file, replacing the
+// previous one.
+func SaveAppHashMismatch(path string, m *AppHashMismatch) error {
+	bz, err := cdc.MarshalJSONIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return cmn.WriteFileAtomic(path, bz, 0644)
+}
+
+// LoadAppHashMismatch reads the report written to the file.
+func LoadAppHashMismatch(path string) (*AppHashMismatch, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := new(AppHashMismatch)
+	if err := cdc.UnmarshalJSON(bz, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,101 @@
+package state
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestCheckAppHash(t *testing.T) {
+	state := State{LastBlockHeight: 10, AppHash: []byte("app"), LastResultsHash: []byte("results")}
+
+	block := &types.Block{Header: types.Header{Height: 11, AppHash: []byte("app")}}
+	assert.Nil(t, CheckAppHash(state, block))
+
+	block.AppHash = []byte("other")
+	block.LastResultsHash = []byte("results")
+	assert.Equal(t, &AppHashMismatch{
+		Height:              10,
+		LastAgreedHeight:    9,
+		AppHash:             []byte("app"),
+		ExpectedAppHash:     []byte("other"),
+		ResultsHash:         []byte("results"),
+		ExpectedResultsHash: []byte("results"),
+	}, CheckAppHash(state, block))
+}
+
+func TestAppHashMismatchResponses(t *testing.T) {
+	stateDB := dbm.NewMemDB()
+	retained := &ABCIResponses{
+		BeginBlock: &abci.ResponseBeginBlock{},
+		DeliverTx: []*abci.ResponseDeliverTx{
+			{Code: abci.CodeTypeOK, Data: []byte("a")},
+			{Code: abci.CodeTypeOK, Data: []byte("b")},
+		},
+		EndBlock: &abci.ResponseEndBlock{},
+	}
+	saveABCIResponses(stateDB, 5, retained)
+
+	got := &ABCIResponses{
+		BeginBlock: &abci.ResponseBeginBlock{},
+		DeliverTx: []*abci.ResponseDeliverTx{
+			{Code: abci.CodeTypeOK, Data: []byte("a")},
+			{Code: 1, Data: []byte("b")},
+			{Code: abci.CodeTypeOK},
+		},
+		EndBlock: &abci.ResponseEndBlock{ConsensusParamUpdates: &abci.ConsensusParams{
+			Block: &abci.BlockParams{MaxBytes: 100},
+		}},
+	}
+	m := &AppHashMismatch{Height: 5}
+	m.SetResponses(stateDB, got)
+	assert.Equal(t, cmn.HexBytes(got.ResultsHash()), m.ResultsHash)
+	require.Len(t, m.ResponsesDiff, 3)
+	assert.Equal(t, "deliver_tx", m.ResponsesDiff[0].Method)
+	assert.Equal(t, 1, m.ResponsesDiff[0].Index)
+	assert.Equal(t, "deliver_tx", m.ResponsesDiff[1].Method)
+	assert.Equal(t, 2, m.ResponsesDiff[1].Index)
+	assert.Equal(t, "<nil>", m.ResponsesDiff[1].Retained)
+	assert.Equal(t, "end_block", m.ResponsesDiff[2].Method)
+
+	// Without retained responses, there's no diff.
+	m = &AppHashMismatch{Height: 6}
+	m.SetResponses(stateDB, got)
+	assert.Empty(t, m.ResponsesDiff)
+}
+
+func TestAppHashMismatchSaveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "apphash")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "app_hash_mismatch.json")
+
+	_, err = LoadAppHashMismatch(path)
+	assert.True(t, os.IsNotExist(err))
+
+	m := &AppHashMismatch{
+		Time:                time.Now().UTC().Round(0),
+		Source:              AppHashMismatchFastSync,
+		Height:              10,
+		LastAgreedHeight:    9,
+		AppHash:             []byte("app"),
+		ExpectedAppHash:     []byte("other"),
+		ResultsHash:         []byte("results"),
+		ExpectedResultsHash: []byte("results"),
+		ResponsesDiff:       []ABCIResponseDiff{{Method: "deliver_tx", Index: 1, Retained: "code:1", Got: ""}},
+	}
+	require.NoError(t, SaveAppHashMismatch(path, m))
+	loaded, err := LoadAppHashMismatch(path)
+	require.NoError(t, err)
+	assert.Equal(t, m, loaded)
+}
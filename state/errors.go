@@ -1,6 +1,10 @@
 package state
 
-import "fmt"
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
 
 type (
 	ErrInvalidBlock error
@@ -43,6 +47,20 @@ type (
 	ErrNoABCIResponsesForHeight struct {
 		Height int64
 	}
+
+	ErrABCIResponsesPruned struct {
+		Height int64
+		Base   int64
+	}
+
+	ErrVoteExtensionRejected struct {
+		Result abci.ResponseVerifyVoteExtension_Result
+	}
+
+	ErrBlockMaxGasExceeded struct {
+		GasWanted int64
+		MaxGas    int64
+	}
 )
 
 func (e ErrUnknownBlock) Error() string {
@@ -75,3 +93,15 @@ func (e ErrNoConsensusParamsForHeight) Error() string {
 func (e ErrNoABCIResponsesForHeight) Error() string {
 	return fmt.Sprintf("Could not find results for height #%d", e.Height)
 }
+
+func (e ErrABCIResponsesPruned) Error() string {
+	return fmt.Sprintf("Results for height #%d are not retained (the lowest retained height is %d)", e.Height, e.Base)
+}
+
+func (e ErrVoteExtensionRejected) Error() string {
+	return fmt.Sprintf("App rejected the vote extension (%v)", e.Result)
+}
+
+func (e ErrBlockMaxGasExceeded) Error() string {
+	return fmt.Sprintf("Block txs want %d gas, more than the max gas (%d)", e.GasWanted, e.MaxGas)
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/pkg/errors"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/fail"
@@ -28,6 +30,12 @@ type BlockExecutor struct {
 	// events
 	eventBus types.BlockEventPublisher
 
+	// publish an EventTxProvisional for each tx as soon as it's delivered
+	provisionalTxEvents bool
+
+	// number of the last heights whose ABCIResponses are kept (0 for all)
+	abciResponsesRetainHeights int64
+
 	// manage the mempool lock during commit
 	// and update both with block results after commit.
 	mempool Mempool
@@ -46,6 +54,24 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithProvisionalTxEvents makes ApplyBlock publish an
+// EventTxProvisional for each tx as soon as the app returned its DeliverTx
+// result, rather than only an EventTx once the block is committed.
+func BlockExecutorWithProvisionalTxEvents() BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.provisionalTxEvents = true
+	}
+}
+
+// BlockExecutorWithABCIResponsesRetainHeights makes ApplyBlock prune the
+// ABCIResponses of the heights before the last retainHeights ones (0 keeps
+// all of them).
+func BlockExecutorWithABCIResponsesRetainHeights(retainHeights int64) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.abciResponsesRetainHeights = retainHeights
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(db dbm.DB, logger log.Logger, proxyApp proxy.AppConnConsensus, mempool Mempool, evpool EvidencePool, options ...BlockExecutorOption) *BlockExecutor {
@@ -73,9 +99,12 @@ func (blockExec *BlockExecutor) SetEventBus(eventBus types.BlockEventPublisher)
 }
 
 // CreateProposalBlock calls state.MakeBlock with evidence from the evpool
-// and txs from the mempool. The max bytes must be big enough to fit the commit.
-// Up to 1/10th of the block space is allcoated for maximum sized evidence.
-// The rest is given to txs, up to the max gas.
+// and txs from the mempool, as prepared by the app. The max bytes must be big
+// enough to fit the commit. Up to 1/10th of the block space is allcoated for
+// maximum sized evidence. The rest is given to the txs, reaped up to the max
+// gas, which the app may reorder, add to or remove from.
+// The commit may have the vote extensions of its precommits, which are given
+// to the app. They aren't included in the block.
 func (blockExec *BlockExecutor) CreateProposalBlock(
 	height int64,
 	state State, commit *types.Commit,
@@ -86,14 +115,133 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	maxGas := state.ConsensusParams.Block.MaxGas
 
 	// Fetch a limited amount of valid evidence
-	maxNumEvidence, _ := types.MaxEvidencePerBlock(maxBytes)
-	evidence := blockExec.evpool.PendingEvidence(maxNumEvidence)
+	evidence := blockExec.evpool.ReapMaxBytes(state.ConsensusParams.Evidence.MaxBytes)
+	evidenceBytes := types.EvidenceList(evidence).ByteSize()
 
-	// Fetch a limited amount of valid txs
-	maxDataBytes := types.MaxDataBytes(maxBytes, state.Validators.Size(), len(evidence))
+	// Fetch a limited amount of valid txs, and let the app prepare them
+	maxDataBytes := types.MaxDataBytes(maxBytes, state.Validators.Size(), evidenceBytes)
 	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxDataBytes, maxGas)
+	txs = blockExec.prepareProposal(height, state, commit, txs, maxDataBytes)
 
-	return state.MakeBlock(height, txs, commit, evidence, proposerAddr)
+	return state.MakeBlock(height, txs, commit.WithoutExtensions(), evidence, proposerAddr)
+}
+
+// prepareProposal gives the app the txs reaped from the mempool and the vote
+// extensions of the commit, and returns the txs it wants in the proposal, as
+// many as fit in the max bytes. The txs the app adds don't count against the
+// max gas. If the app fails, the reaped txs are proposed.
+func (blockExec *BlockExecutor) prepareProposal(
+	height int64,
+	state State, commit *types.Commit,
+	reapedTxs types.Txs,
+	maxDataBytes int64,
+) types.Txs {
+
+	reqTxs := make([][]byte, len(reapedTxs))
+	for i, tx := range reapedTxs {
+		reqTxs[i] = tx
+	}
+	res, err := blockExec.proxyApp.PrepareProposalSync(abci.RequestPrepareProposal{
+		Height:          height,
+		LocalLastCommit: getExtendedCommitInfo(commit, state.LastValidators),
+		Txs:             reqTxs,
+		MaxTxBytes:      maxDataBytes,
+	})
+	if err != nil {
+		blockExec.logger.Error("Error in proxyAppConn.PrepareProposal, proposing the mempool txs", "err", err)
+		return reapedTxs
+	}
+
+	txs := make(types.Txs, 0, len(res.Txs))
+	var txsBytes int64
+	for _, tx := range res.Txs {
+		txBytes := int64(len(tx)) + types.ComputeAminoOverhead(tx, 1)
+		if txsBytes+txBytes > maxDataBytes {
+			blockExec.logger.Error("The app txs don't fit in the block, dropping some",
+				"height", height, "dropped", len(res.Txs)-len(txs))
+			break
+		}
+		txs = append(txs, tx)
+		txsBytes += txBytes
+	}
+	return txs
+}
+
+// ProcessProposal asks the app whether to accept the proposal block of
+// another validator. The block must have been validated. It returns false if
+// the app rejects it.
+func (blockExec *BlockExecutor) ProcessProposal(state State, block *types.Block) (bool, error) {
+	commitInfo, byzVals := getBeginBlockValidatorInfo(block, state.LastValidators, blockExec.db)
+	txs := make([][]byte, len(block.Data.Txs))
+	for i, tx := range block.Data.Txs {
+		txs[i] = tx
+	}
+	res, err := blockExec.proxyApp.ProcessProposalSync(abci.RequestProcessProposal{
+		Hash:                block.Hash(),
+		Header:              types.TM2PB.Header(&block.Header),
+		Txs:                 txs,
+		ProposedLastCommit:  commitInfo,
+		ByzantineValidators: byzVals,
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.Status == abci.ResponseProcessProposal_ACCEPT, nil
+}
+
+// ExtendVote returns the extension of our precommit for a block, from the
+// app.
+func (blockExec *BlockExecutor) ExtendVote(vote *types.Vote) ([]byte, error) {
+	res, err := blockExec.proxyApp.ExtendVoteSync(abci.RequestExtendVote{
+		Hash:   vote.BlockID.Hash,
+		Height: vote.Height,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.VoteExtension) > types.MaxVoteExtensionSize {
+		return nil, fmt.Errorf("vote extension of %d bytes is too big (max: %d)",
+			len(res.VoteExtension), types.MaxVoteExtensionSize)
+	}
+	return res.VoteExtension, nil
+}
+
+// VerifyVoteExtension has the app verify the extension of a precommit of
+// another validator. It returns an error if the app rejects it.
+func (blockExec *BlockExecutor) VerifyVoteExtension(vote *types.Vote) error {
+	res, err := blockExec.proxyApp.VerifyVoteExtensionSync(abci.RequestVerifyVoteExtension{
+		Hash:             vote.BlockID.Hash,
+		ValidatorAddress: vote.ValidatorAddress,
+		Height:           vote.Height,
+		VoteExtension:    vote.Extension,
+	})
+	if err != nil {
+		return err
+	}
+	if res.Result != abci.ResponseVerifyVoteExtension_ACCEPT {
+		return ErrVoteExtensionRejected{res.Result}
+	}
+	return nil
+}
+
+// ValidateProposalGas returns ErrBlockMaxGasExceeded if the txs of a proposal
+// block want more gas than the max gas, as returned by CheckTx. As our
+// mempool may not have all the txs, this isn't part of ValidateBlock: the
+// gas of the txs we don't have isn't counted.
+func (blockExec *BlockExecutor) ValidateProposalGas(state State, block *types.Block) error {
+	maxGas := state.ConsensusParams.Block.MaxGas
+	if maxGas < 0 {
+		return nil
+	}
+	gasWanted, unknown := blockExec.mempool.GasWanted(block.Txs)
+	if unknown > 0 {
+		blockExec.logger.Debug("The gas of the txs not in the mempool isn't counted",
+			"height", block.Height, "txs", unknown)
+	}
+	if gasWanted > maxGas {
+		return ErrBlockMaxGasExceeded{GasWanted: gasWanted, MaxGas: maxGas}
+	}
+	return nil
 }
 
 // ValidateBlock validates the given block against the given state.
@@ -115,8 +263,20 @@ func (blockExec *BlockExecutor) ApplyBlock(state State, blockID types.BlockID, b
 		return state, ErrInvalidBlock(err)
 	}
 
+	var onDeliverTx func(int, *abci.ResponseDeliverTx)
+	if blockExec.provisionalTxEvents {
+		onDeliverTx = func(i int, res *abci.ResponseDeliverTx) {
+			blockExec.eventBus.PublishEventTxProvisional(types.EventDataTxProvisional{TxResult: types.TxResult{
+				Height: block.Height,
+				Index:  uint32(i),
+				Tx:     block.Txs[i],
+				Result: *res,
+			}})
+		}
+	}
+
 	startTime := time.Now().UnixNano()
-	abciResponses, err := execBlockOnProxyApp(blockExec.logger, blockExec.proxyApp, block, state.LastValidators, blockExec.db)
+	abciResponses, err := execBlockOnProxyApp(blockExec.logger, blockExec.proxyApp, block, state.LastValidators, blockExec.db, onDeliverTx)
 	endTime := time.Now().UnixNano()
 	blockExec.metrics.BlockProcessingTime.Observe(float64(endTime-startTime) / 1000000)
 	if err != nil {
@@ -145,15 +305,20 @@ func (blockExec *BlockExecutor) ApplyBlock(state State, blockID types.BlockID, b
 	}
 
 	// Update the state with the block and responses.
+	prevValidators := state.NextValidators
 	state, err = updateState(state, blockID, &block.Header, abciResponses, validatorUpdates)
 	if err != nil {
 		return state, fmt.Errorf("Commit failed for application: %v", err)
 	}
+	var validatorChanges []types.ValidatorPowerChange
+	if len(validatorUpdates) > 0 {
+		validatorChanges = types.ValidatorPowerChanges(prevValidators, state.NextValidators)
+	}
 
 	// Lock mempool, commit app state, update mempoool.
 	appHash, err := blockExec.Commit(state, block)
 	if err != nil {
-		return state, fmt.Errorf("Commit failed for application: %v", err)
+		return state, errors.Wrap(err, "Commit failed for application")
 	}
 
 	// Update evpool with the block and state.
@@ -167,9 +332,18 @@ func (blockExec *BlockExecutor) ApplyBlock(state State, blockID types.BlockID, b
 
 	fail.Fail() // XXX
 
+	// The responses of the last height are always kept, to recover from a
+	// crash after Commit.
+	if blockExec.abciResponsesRetainHeights > 0 {
+		retainHeight := block.Height - blockExec.abciResponsesRetainHeights + 1
+		if pruned := PruneABCIResponses(blockExec.db, retainHeight); pruned > 0 {
+			blockExec.logger.Debug("Pruned ABCI responses", "pruned", pruned, "base", retainHeight)
+		}
+	}
+
 	// Events are fired after everything else.
 	// NOTE: if we crash between Commit and Save, events wont be fired during replay
-	fireEvents(blockExec.logger, blockExec.eventBus, block, abciResponses, validatorUpdates)
+	fireEvents(blockExec.logger, blockExec.eventBus, block, abciResponses, validatorUpdates, validatorChanges)
 
 	return state, nil
 }
@@ -228,13 +402,15 @@ func (blockExec *BlockExecutor) Commit(
 // Helper functions for executing blocks and updating state
 
 // Executes block's transactions on proxyAppConn.
-// Returns a list of transaction results and updates to the validator set
+// Returns a list of transaction results and updates to the validator set.
+// If not nil, onDeliverTx is called with the result of each tx as it comes.
 func execBlockOnProxyApp(
 	logger log.Logger,
 	proxyAppConn proxy.AppConnConsensus,
 	block *types.Block,
 	lastValSet *types.ValidatorSet,
 	stateDB dbm.DB,
+	onDeliverTx func(int, *abci.ResponseDeliverTx),
 ) (*ABCIResponses, error) {
 	var validTxs, invalidTxs = 0, 0
 
@@ -256,6 +432,9 @@ func execBlockOnProxyApp(
 				invalidTxs++
 			}
 			abciResponses.DeliverTx[txIndex] = txRes
+			if onDeliverTx != nil {
+				onDeliverTx(txIndex, txRes)
+			}
 			txIndex++
 		}
 	}
@@ -296,6 +475,35 @@ func execBlockOnProxyApp(
 	return abciResponses, nil
 }
 
+// getExtendedCommitInfo returns the precommits of the commit with their vote
+// extensions, for the app. Only the extensions of the precommits for the
+// committed block are given. A precommit may lack its extension, e.g. if it
+// was received as part of a block.
+func getExtendedCommitInfo(commit *types.Commit, lastValSet *types.ValidatorSet) abci.ExtendedCommitInfo {
+	if commit.Size() == 0 {
+		return abci.ExtendedCommitInfo{}
+	}
+	voteInfos := make([]abci.ExtendedVoteInfo, len(lastValSet.Validators))
+	for i, val := range lastValSet.Validators {
+		var vote *types.CommitSig
+		if i < len(commit.Precommits) {
+			vote = commit.Precommits[i]
+		}
+		voteInfo := abci.ExtendedVoteInfo{
+			Validator:       types.TM2PB.Validator(val),
+			SignedLastBlock: vote != nil,
+		}
+		if vote != nil && vote.BlockID.Equals(commit.BlockID) {
+			voteInfo.VoteExtension = vote.Extension
+		}
+		voteInfos[i] = voteInfo
+	}
+	return abci.ExtendedCommitInfo{
+		Round: int32(commit.Round()),
+		Votes: voteInfos,
+	}
+}
+
 func getBeginBlockValidatorInfo(block *types.Block, lastValSet *types.ValidatorSet, stateDB dbm.DB) (abci.LastCommitInfo, []abci.Evidence) {
 
 	// Sanity check that commit length matches validator set size -
@@ -329,8 +537,8 @@ func getBeginBlockValidatorInfo(block *types.Block, lastValSet *types.ValidatorS
 		Votes: voteInfos,
 	}
 
-	byzVals := make([]abci.Evidence, len(block.Evidence.Evidence))
-	for i, ev := range block.Evidence.Evidence {
+	byzVals := make([]abci.Evidence, 0, len(block.Evidence.Evidence))
+	for _, ev := range block.Evidence.Evidence {
 		// We need the validator set. We already did this in validateBlock.
 		// TODO: Should we instead cache the valset in the evidence itself and add
 		// `SetValidatorSet()` and `ToABCI` methods ?
@@ -338,7 +546,12 @@ func getBeginBlockValidatorInfo(block *types.Block, lastValSet *types.ValidatorS
 		if err != nil {
 			panic(err) // shouldn't happen
 		}
-		byzVals[i] = types.TM2PB.Evidence(ev, valset, block.Time)
+		// composite evidence is of several byzantine validators
+		if cev, ok := ev.(types.CompositeEvidence); ok {
+			byzVals = append(byzVals, types.TM2PB.CompositeEvidence(cev, valset, block.Time)...)
+			continue
+		}
+		byzVals = append(byzVals, types.TM2PB.Evidence(ev, valset, block.Time))
 	}
 
 	return commitInfo, byzVals
@@ -427,13 +640,15 @@ func updateState(
 		LastHeightConsensusParamsChanged: lastHeightParamsChanged,
 		LastResultsHash:                  abciResponses.ResultsHash(),
 		AppHash:                          nil,
+		AppPendingState:                  abciResponses.EndBlock.PendingState,
 	}, nil
 }
 
 // Fire NewBlock, NewBlockHeader.
 // Fire TxEvent for every tx.
 // NOTE: if Tendermint crashes before commit, some or all of these events may be published again.
-func fireEvents(logger log.Logger, eventBus types.BlockEventPublisher, block *types.Block, abciResponses *ABCIResponses, validatorUpdates []*types.Validator) {
+func fireEvents(logger log.Logger, eventBus types.BlockEventPublisher, block *types.Block, abciResponses *ABCIResponses,
+	validatorUpdates []*types.Validator, validatorChanges []types.ValidatorPowerChange) {
 	eventBus.PublishEventNewBlock(types.EventDataNewBlock{
 		Block:            block,
 		ResultBeginBlock: *abciResponses.BeginBlock,
@@ -455,8 +670,11 @@ func fireEvents(logger log.Logger, eventBus types.BlockEventPublisher, block *ty
 	}
 
 	if len(validatorUpdates) > 0 {
-		eventBus.PublishEventValidatorSetUpdates(
-			types.EventDataValidatorSetUpdates{ValidatorUpdates: validatorUpdates})
+		eventBus.PublishEventValidatorSetUpdates(types.EventDataValidatorSetUpdates{
+			Height:           block.Height,
+			ValidatorUpdates: validatorUpdates,
+			Changes:          validatorChanges,
+		})
 	}
 }
 
@@ -472,17 +690,30 @@ func ExecCommitBlock(
 	lastValSet *types.ValidatorSet,
 	stateDB dbm.DB,
 ) ([]byte, error) {
-	_, err := execBlockOnProxyApp(logger, appConnConsensus, block, lastValSet, stateDB)
+	appHash, _, err := ExecCommitBlockWithResponses(appConnConsensus, block, logger, lastValSet, stateDB)
+	return appHash, err
+}
+
+// ExecCommitBlockWithResponses is ExecCommitBlock, also returning the
+// responses of the app to the block.
+func ExecCommitBlockWithResponses(
+	appConnConsensus proxy.AppConnConsensus,
+	block *types.Block,
+	logger log.Logger,
+	lastValSet *types.ValidatorSet,
+	stateDB dbm.DB,
+) ([]byte, *ABCIResponses, error) {
+	abciResponses, err := execBlockOnProxyApp(logger, appConnConsensus, block, lastValSet, stateDB, nil)
 	if err != nil {
 		logger.Error("Error executing block on proxy app", "height", block.Height, "err", err)
-		return nil, err
+		return nil, nil, err
 	}
 	// Commit block, get hash back
 	res, err := appConnConsensus.CommitSync()
 	if err != nil {
 		logger.Error("Client error during proxyAppConn.CommitSync", "err", res)
-		return nil, err
+		return nil, nil, err
 	}
 	// ResponseCommit has no error or log, just data
-	return res.Data, nil
+	return res.Data, abciResponses, nil
 }
@@ -16,6 +16,8 @@ import (
 	cmn "github.com/tendermint/tendermint/libs/common"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
 	tmtime "github.com/tendermint/tendermint/types/time"
 
 	"github.com/tendermint/tendermint/proxy"
@@ -50,6 +52,59 @@ func TestApplyBlock(t *testing.T) {
 	// TODO check state and mempool
 }
 
+func TestApplyBlockProvisionalTxEvents(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(kvstore.NewKVStoreApplication())
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop()
+
+	state, stateDB := state(1, 1)
+
+	blockExec := NewBlockExecutor(stateDB, log.TestingLogger(), proxyApp.Consensus(),
+		MockMempool{}, MockEvidencePool{}, BlockExecutorWithProvisionalTxEvents())
+
+	eventBus := types.NewEventBus()
+	err = eventBus.Start()
+	require.NoError(t, err)
+	defer eventBus.Stop()
+	blockExec.SetEventBus(eventBus)
+
+	block := makeBlock(state, 1)
+	blockID := types.BlockID{block.Hash(), block.MakePartSet(testPartSize).Header()}
+
+	// Both the TxProvisional and the Tx events have the tx.height tag.
+	query := tmquery.MustParse(fmt.Sprintf("%s=%d", types.TxHeightKey, block.Height))
+	txsSub, err := eventBus.Subscribe(context.Background(), "TestApplyBlockProvisionalTxEvents", query, 2*len(block.Txs))
+	require.NoError(t, err)
+
+	_, err = blockExec.ApplyBlock(state, blockID, block)
+	require.Nil(t, err)
+
+	// The provisional results of all the txs come before the committed ones.
+	for i := 0; i < 2*len(block.Txs); i++ {
+		var msg tmpubsub.Message
+		select {
+		case msg = <-txsSub.Out():
+		case <-time.After(1 * time.Second):
+			t.Fatalf("Did not receive tx event #%d within 1 sec.", i)
+		}
+		index := i % len(block.Txs)
+		if i < len(block.Txs) {
+			event, ok := msg.Data().(types.EventDataTxProvisional)
+			require.True(t, ok, "Expected event of type EventDataTxProvisional, got %T", msg.Data())
+			assert.EqualValues(t, index, event.Index)
+			assert.Equal(t, block.Txs[index], event.Tx)
+			assert.Equal(t, abci.CodeTypeOK, event.Result.Code)
+		} else {
+			event, ok := msg.Data().(types.EventDataTx)
+			require.True(t, ok, "Expected event of type EventDataTx, got %T", msg.Data())
+			assert.EqualValues(t, index, event.Index)
+			assert.Equal(t, block.Txs[index], event.Tx)
+		}
+	}
+}
+
 // TestBeginBlockValidators ensures we send absent validators list.
 func TestBeginBlockValidators(t *testing.T) {
 	app := &testApp{}
@@ -123,6 +178,19 @@ func TestBeginBlockByzantineValidators(t *testing.T) {
 	ev1 := types.NewMockGoodEvidence(height1, idx1, val1)
 	ev2 := types.NewMockGoodEvidence(height2, idx2, val2)
 
+	// both validators signed a conflicting header
+	conflictingBlockID := types.BlockID{Hash: []byte("conflicting")}
+	ev3 := &types.LightClientAttackEvidence{
+		ConflictingHeader: &types.SignedHeader{
+			Header: &types.Header{Height: 9},
+			Commit: types.NewCommit(conflictingBlockID, []*types.CommitSig{
+				(&types.Vote{ValidatorAddress: val1, BlockID: conflictingBlockID}).CommitSig(),
+				(&types.Vote{ValidatorAddress: val2, BlockID: conflictingBlockID}).CommitSig(),
+			}),
+		},
+		CommonHeight: height1,
+	}
+
 	now := tmtime.Now()
 	valSet := state.Validators
 	testCases := []struct {
@@ -135,6 +203,9 @@ func TestBeginBlockByzantineValidators(t *testing.T) {
 		{"multiple byzantine", []types.Evidence{ev1, ev2}, []abci.Evidence{
 			types.TM2PB.Evidence(ev1, valSet, now),
 			types.TM2PB.Evidence(ev2, valSet, now)}},
+		{"light client attack", []types.Evidence{ev1, ev3}, append(
+			[]abci.Evidence{types.TM2PB.Evidence(ev1, valSet, now)},
+			types.TM2PB.CompositeEvidence(ev3, valSet, now)...)},
 	}
 
 	commitSig0 := (&types.Vote{ValidatorIndex: 0, Timestamp: now, Type: types.PrecommitType}).CommitSig()
@@ -383,6 +454,105 @@ func TestEndBlockValidatorUpdatesResultingInEmptySet(t *testing.T) {
 
 }
 
+// TestCreateProposalBlockVoteExtensions ensures the proposer gives the vote
+// extensions to the app, and proposes its txs without the extensions.
+func TestCreateProposalBlockVoteExtensions(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop()
+
+	state, stateDB := state(2, 2)
+	blockExec := NewBlockExecutor(stateDB, log.TestingLogger(), proxyApp.Consensus(),
+		MockMempool{}, MockEvidencePool{})
+
+	blockID := types.BlockID{Hash: []byte("block_hash_012345678901234567890"),
+		PartsHeader: types.PartSetHeader{Total: 1, Hash: []byte("parts_hash_012345678901234567890")}}
+	precommit := &types.Vote{Type: types.PrecommitType, Height: 1, BlockID: blockID, ValidatorIndex: 0,
+		Timestamp: tmtime.Now(), Extension: []byte("extension"), ExtensionSignature: []byte("signature")}
+	commit := types.NewCommit(blockID, []*types.CommitSig{precommit.CommitSig(), nil})
+
+	app.ProposalTxs = [][]byte{[]byte("app tx")}
+	block, _ := blockExec.CreateProposalBlock(2, state, commit, state.Validators.GetProposer().Address)
+	require.NoError(t, block.ValidateBasic())
+	assert.Equal(t, types.Txs{types.Tx("app tx")}, block.Txs)
+	assert.Empty(t, block.LastCommit.Precommits[0].Extension)
+	assert.Empty(t, block.LastCommit.Precommits[0].ExtensionSignature)
+
+	// -> app receives the extensions of the precommits
+	require.Len(t, app.LocalLastCommit.Votes, 2)
+	assert.True(t, app.LocalLastCommit.Votes[0].SignedLastBlock)
+	assert.Equal(t, []byte("extension"), app.LocalLastCommit.Votes[0].VoteExtension)
+	assert.False(t, app.LocalLastCommit.Votes[1].SignedLastBlock)
+	assert.Empty(t, app.LocalLastCommit.Votes[1].VoteExtension)
+
+	// app txs which don't fit in the block are dropped
+	app.ProposalTxs = [][]byte{make([]byte, state.ConsensusParams.Block.MaxBytes)}
+	block, _ = blockExec.CreateProposalBlock(2, state, commit, state.Validators.GetProposer().Address)
+	assert.Empty(t, block.Txs)
+}
+
+type txsMempool struct {
+	MockMempool
+	txs types.Txs
+}
+
+func (mem txsMempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return mem.txs }
+
+func TestCreateProposalBlockPrepareProposal(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop()
+
+	state, stateDB := state(1, 1)
+	mempool := txsMempool{txs: types.Txs{types.Tx("a"), types.Tx("b"), types.Tx("c")}}
+	blockExec := NewBlockExecutor(stateDB, log.TestingLogger(), proxyApp.Consensus(),
+		mempool, MockEvidencePool{})
+
+	// the app reorders, removes and adds txs
+	app.ProposalTxs = [][]byte{[]byte("c"), []byte("app tx"), []byte("a")}
+	block, _ := blockExec.CreateProposalBlock(1, state, new(types.Commit), state.Validators.GetProposer().Address)
+	require.NoError(t, block.ValidateBasic())
+	assert.Equal(t, types.Txs{types.Tx("c"), types.Tx("app tx"), types.Tx("a")}, block.Txs)
+
+	// -> app receives the reaped txs
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b"), []byte("c")}, app.ReapedTxs)
+	assert.Equal(t, types.MaxDataBytes(state.ConsensusParams.Block.MaxBytes, 1, 0), app.MaxTxBytes)
+}
+
+func TestProcessProposal(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop()
+
+	state, stateDB := state(1, 1)
+	blockExec := NewBlockExecutor(stateDB, log.TestingLogger(), proxyApp.Consensus(),
+		MockMempool{}, MockEvidencePool{})
+	block := makeBlock(state, 1)
+
+	accepted, err := blockExec.ProcessProposal(state, block)
+	require.NoError(t, err)
+	assert.True(t, accepted)
+
+	// -> app receives the block
+	assert.Equal(t, block.Hash().Bytes(), app.ProcessedProposal.Hash)
+	assert.Equal(t, block.Height, app.ProcessedProposal.Header.Height)
+	assert.Len(t, app.ProcessedProposal.Txs, len(block.Txs))
+
+	app.RejectProposal = true
+	accepted, err = blockExec.ProcessProposal(state, block)
+	require.NoError(t, err)
+	assert.False(t, accepted)
+}
+
 //----------------------------------------------------------------------------
 
 // make some bogus txs
@@ -436,6 +606,13 @@ type testApp struct {
 	CommitVotes         []abci.VoteInfo
 	ByzantineValidators []abci.Evidence
 	ValidatorUpdates    []abci.ValidatorUpdate
+
+	LocalLastCommit   abci.ExtendedCommitInfo
+	ReapedTxs         [][]byte
+	MaxTxBytes        int64
+	ProposalTxs       [][]byte
+	ProcessedProposal abci.RequestProcessProposal
+	RejectProposal    bool
 }
 
 var _ abci.Application = (*testApp)(nil)
@@ -454,6 +631,21 @@ func (app *testApp) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
 	return abci.ResponseEndBlock{ValidatorUpdates: app.ValidatorUpdates}
 }
 
+func (app *testApp) PrepareProposal(req abci.RequestPrepareProposal) abci.ResponsePrepareProposal {
+	app.LocalLastCommit = req.LocalLastCommit
+	app.ReapedTxs = req.Txs
+	app.MaxTxBytes = req.MaxTxBytes
+	return abci.ResponsePrepareProposal{Txs: app.ProposalTxs}
+}
+
+func (app *testApp) ProcessProposal(req abci.RequestProcessProposal) abci.ResponseProcessProposal {
+	app.ProcessedProposal = req
+	if app.RejectProposal {
+		return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}
+	}
+	return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}
+}
+
 func (app *testApp) DeliverTx(tx []byte) abci.ResponseDeliverTx {
 	return abci.ResponseDeliverTx{Tags: []cmn.KVPair{}}
 }
@@ -0,0 +1,80 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ReindexEvents indexes the blocks of the block store from the height from to
+// the height to (included), and their txs, with the results of the ABCI
+// responses saved in the state DB, as the IndexerService indexed them when
+// they were committed. The blocks whose ABCI responses were pruned (see
+// PruneABCIResponses) can't be indexed.
+func ReindexEvents(idr txindex.TxIndexer, blockStore BlockStoreRPC, stateDB dbm.DB,
+	from, to int64, logger log.Logger) error {
+	if base := LoadABCIResponsesBase(stateDB); from < base {
+		return fmt.Errorf("The ABCI responses below height %d were pruned, the blocks from height %d can't be reindexed",
+			base, from)
+	}
+	if to > blockStore.Height() {
+		return fmt.Errorf("The height of the block store is %d, the blocks up to height %d can't be reindexed",
+			blockStore.Height(), to)
+	}
+
+	for height := from; height <= to; height++ {
+		block := blockStore.LoadBlock(height)
+		if block == nil {
+			return fmt.Errorf("Block %d isn't in the block store", height)
+		}
+		abciResponses, err := LoadABCIResponses(stateDB, height)
+		if err != nil {
+			return err
+		}
+		if len(abciResponses.DeliverTx) != len(block.Data.Txs) {
+			return fmt.Errorf("Block %d has %d txs, but %d DeliverTx responses",
+				height, len(block.Data.Txs), len(abciResponses.DeliverTx))
+		}
+
+		if blockIndexer, ok := idr.(txindex.BlockIndexer); ok {
+			header := types.EventDataNewBlockHeader{Header: block.Header}
+			if abciResponses.BeginBlock != nil {
+				header.ResultBeginBlock = *abciResponses.BeginBlock
+			}
+			if abciResponses.EndBlock != nil {
+				header.ResultEndBlock = *abciResponses.EndBlock
+			}
+			if err := blockIndexer.IndexBlock(header); err != nil {
+				return errors.Wrapf(err, "failed to index block %d", height)
+			}
+		}
+
+		batch := txindex.NewBatch(int64(len(block.Data.Txs)))
+		for i, tx := range block.Data.Txs {
+			result := abciResponses.DeliverTx[i]
+			if result == nil {
+				result = &abci.ResponseDeliverTx{}
+			}
+			err := batch.Add(&types.TxResult{
+				Height: height,
+				Index:  uint32(i),
+				Tx:     tx,
+				Result: *result,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if err := idr.AddBatch(batch); err != nil {
+			return errors.Wrapf(err, "failed to index the txs of block %d", height)
+		}
+		logger.Info("Reindexed block", "height", height, "txs", len(block.Data.Txs))
+	}
+	return nil
+}
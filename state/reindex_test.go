@@ -0,0 +1,69 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/state/txindex/kv"
+	"github.com/tendermint/tendermint/types"
+)
+
+type reindexBlockStore struct {
+	BlockStoreRPC
+	blocks map[int64]*types.Block
+}
+
+func (bs reindexBlockStore) Height() int64 { return int64(len(bs.blocks)) }
+
+func (bs reindexBlockStore) LoadBlock(height int64) *types.Block { return bs.blocks[height] }
+
+func TestReindexEvents(t *testing.T) {
+	stateDB := dbm.NewMemDB()
+	store := reindexBlockStore{blocks: make(map[int64]*types.Block)}
+	for height := int64(1); height <= 3; height++ {
+		txs := types.Txs{types.Tx(cmn.RandBytes(10)), types.Tx(cmn.RandBytes(10))}
+		store.blocks[height] = &types.Block{Header: types.Header{Height: height}, Data: types.Data{Txs: txs}}
+		saveABCIResponses(stateDB, height, &ABCIResponses{
+			BeginBlock: &abci.ResponseBeginBlock{},
+			DeliverTx: []*abci.ResponseDeliverTx{
+				{Code: abci.CodeTypeOK, Tags: []cmn.KVPair{{Key: []byte("account.owner"), Value: []byte("Ivan")}}},
+				{Code: abci.CodeTypeOK},
+			},
+			EndBlock: &abci.ResponseEndBlock{},
+		})
+	}
+	idr := kv.NewTxIndex(dbm.NewMemDB(), kv.IndexAllTags())
+
+	require.NoError(t, ReindexEvents(idr, store, stateDB, 2, 3, log.TestingLogger()))
+	res, err := idr.Search(query.MustParse("account.owner = 'Ivan'"), txindex.SearchOptions{})
+	require.NoError(t, err)
+	require.Len(t, res.Txs, 2)
+	assert.EqualValues(t, 2, res.Txs[0].Height)
+	assert.EqualValues(t, 3, res.Txs[1].Height)
+	result, err := idr.Get(store.blocks[1].Data.Txs[1].Hash())
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	result, err = idr.Get(store.blocks[3].Data.Txs[1].Hash())
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.EqualValues(t, 1, result.Index)
+
+	// Beyond the block store.
+	assert.Error(t, ReindexEvents(idr, store, stateDB, 1, 4, log.TestingLogger()))
+
+	// The responses don't match the block.
+	store.blocks[1].Data.Txs = store.blocks[1].Data.Txs[:1]
+	assert.Error(t, ReindexEvents(idr, store, stateDB, 1, 1, log.TestingLogger()))
+
+	// The responses were pruned.
+	PruneABCIResponses(stateDB, 3)
+	assert.Error(t, ReindexEvents(idr, store, stateDB, 2, 3, log.TestingLogger()))
+}
@@ -24,6 +24,7 @@ type Mempool interface {
 	Size() int
 	CheckTx(types.Tx, func(*abci.Response)) error
 	ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs
+	GasWanted(txs types.Txs) (gasWanted int64, unknown int)
 	Update(int64, types.Txs, mempool.PreCheckFunc, mempool.PostCheckFunc) error
 	Flush()
 	FlushAppConn() error
@@ -42,6 +43,7 @@ func (MockMempool) Unlock()                                          {}
 func (MockMempool) Size() int                                        { return 0 }
 func (MockMempool) CheckTx(_ types.Tx, _ func(*abci.Response)) error { return nil }
 func (MockMempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs          { return types.Txs{} }
+func (MockMempool) GasWanted(txs types.Txs) (int64, int)             { return 0, len(txs) }
 func (MockMempool) Update(
 	_ int64,
 	_ types.Txs,
@@ -82,17 +84,23 @@ type BlockStore interface {
 // EvidencePool defines the EvidencePool interface used by the ConsensusState.
 // Get/Set/Commit
 type EvidencePool interface {
-	PendingEvidence(int64) []types.Evidence
+	// ReapMaxBytes returns the pending evidence to propose, up to the total
+	// size (see types.EvidenceList.ByteSize).
+	ReapMaxBytes(maxBytes int64) []types.Evidence
 	AddEvidence(types.Evidence) error
 	Update(*types.Block, State)
 	// IsCommitted indicates if this evidence was already marked committed in another block.
 	IsCommitted(types.Evidence) bool
+	// Header returns the header committed at the height, nil if we don't have
+	// it. It's needed to verify composite evidence.
+	Header(int64) *types.Header
 }
 
 // MockMempool is an empty implementation of a Mempool, useful for testing.
 type MockEvidencePool struct{}
 
-func (m MockEvidencePool) PendingEvidence(int64) []types.Evidence { return nil }
-func (m MockEvidencePool) AddEvidence(types.Evidence) error       { return nil }
-func (m MockEvidencePool) Update(*types.Block, State)             {}
-func (m MockEvidencePool) IsCommitted(types.Evidence) bool        { return false }
+func (m MockEvidencePool) ReapMaxBytes(int64) []types.Evidence { return nil }
+func (m MockEvidencePool) AddEvidence(types.Evidence) error    { return nil }
+func (m MockEvidencePool) Update(*types.Block, State)          {}
+func (m MockEvidencePool) IsCommitted(types.Evidence) bool     { return false }
+func (m MockEvidencePool) Header(int64) *types.Header          { return nil }
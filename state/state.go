@@ -81,6 +81,11 @@ type State struct {
 
 	// the latest AppHash we've received from calling abci.Commit()
 	AppHash []byte
+
+	// AppPendingState is true if the app reported state changes pending at the
+	// end of the last block, to be committed by the next block even if it has
+	// no txs.
+	AppPendingState bool
 }
 
 // Copy makes a copy of the State for mutating.
@@ -105,6 +110,8 @@ func (state State) Copy() State {
 		AppHash: state.AppHash,
 
 		LastResultsHash: state.LastResultsHash,
+
+		AppPendingState: state.AppPendingState,
 	}
 }
 
@@ -141,12 +148,13 @@ func (state State) MakeBlock(
 	// Build base block with block data.
 	block := types.MakeBlock(height, txs, commit, evidence)
 
-	// Set time.
+	// Set time. The validators only accept a proposal whose block time is
+	// close to their local time (see SynchronyParams.IsTimely).
 	var timestamp time.Time
 	if height == 1 {
 		timestamp = state.LastBlockTime // genesis time
 	} else {
-		timestamp = MedianTime(commit, state.LastValidators)
+		timestamp = state.proposerTime()
 	}
 
 	// Fill rest of header with state data.
@@ -161,24 +169,14 @@ func (state State) MakeBlock(
 	return block, block.MakePartSet(types.BlockPartSizeBytes)
 }
 
-// MedianTime computes a median time for a given Commit (based on Timestamp field of votes messages) and the
-// corresponding validator set. The computed time is always between timestamps of
-// the votes sent by honest processes, i.e., a faulty processes can not arbitrarily increase or decrease the
-// computed value.
-func MedianTime(commit *types.Commit, validators *types.ValidatorSet) time.Time {
-
-	weightedTimes := make([]*tmtime.WeightedTime, len(commit.Precommits))
-	totalVotingPower := int64(0)
-
-	for i, vote := range commit.Precommits {
-		if vote != nil {
-			_, validator := validators.GetByIndex(vote.ValidatorIndex)
-			totalVotingPower += validator.VotingPower
-			weightedTimes[i] = tmtime.NewWeightedTime(vote.Timestamp, validator.VotingPower)
-		}
+// proposerTime returns the time the proposer sets in its block: its local
+// time, or just after the last block time if its clock is behind.
+func (state State) proposerTime() time.Time {
+	now := tmtime.Now()
+	if !now.After(state.LastBlockTime) {
+		return state.LastBlockTime.Add(time.Millisecond)
 	}
-
-	return tmtime.WeightedMedian(weightedTimes, totalVotingPower)
+	return now
 }
 
 //------------------------------------------------------------------------
@@ -81,6 +81,25 @@ func TestStateSaveLoad(t *testing.T) {
 			loadedState, state))
 }
 
+// TestStateSaveLoadCompletesParams tests loading a State saved before the
+// params added after it.
+func TestStateSaveLoadCompletesParams(t *testing.T) {
+	tearDown, stateDB, state := setupTestCase(t)
+	defer tearDown(t)
+
+	state.ConsensusParams.Synchrony = types.SynchronyParams{}
+	state.ConsensusParams.Timeout = types.TimeoutParams{}
+	SaveState(stateDB, state)
+
+	loadedState := LoadState(stateDB)
+	assert.Equal(t, types.DefaultSynchronyParams(), loadedState.ConsensusParams.Synchrony)
+	assert.Equal(t, types.DefaultTimeoutParams(), loadedState.ConsensusParams.Timeout)
+	params, err := LoadConsensusParams(stateDB, state.LastBlockHeight+1)
+	require.NoError(t, err)
+	assert.Equal(t, types.DefaultSynchronyParams(), params.Synchrony)
+	assert.Equal(t, types.DefaultTimeoutParams(), params.Timeout)
+}
+
 // TestABCIResponsesSaveLoad tests saving and loading ABCIResponses.
 func TestABCIResponsesSaveLoad1(t *testing.T) {
 	tearDown, stateDB, state := setupTestCase(t)
@@ -176,6 +195,89 @@ func TestABCIResponsesSaveLoad2(t *testing.T) {
 	}
 }
 
+// TestPruneABCIResponses tests the responses are retained above the pruned
+// heights, also once the db is reopened.
+func TestPruneABCIResponses(t *testing.T) {
+	config := cfg.ResetTestRoot("state_")
+	defer os.RemoveAll(config.RootDir)
+	stateDB := dbm.NewDB("state", dbm.GoLevelDBBackend, config.DBDir())
+
+	n := int64(2*pruneABCIResponsesBatchSize + 10)
+	for h := int64(1); h <= n; h++ {
+		saveABCIResponses(stateDB, h, &ABCIResponses{
+			DeliverTx: []*abci.ResponseDeliverTx{{Data: []byte(fmt.Sprintf("%d", h))}},
+			EndBlock: &abci.ResponseEndBlock{
+				ValidatorUpdates:      []abci.ValidatorUpdate{types.TM2PB.NewValidatorUpdate(ed25519.GenPrivKey().PubKey(), h)},
+				ConsensusParamUpdates: &abci.ConsensusParams{Block: &abci.BlockParams{MaxBytes: h, MaxGas: -1}},
+			},
+		})
+	}
+	assert.EqualValues(t, 1, LoadABCIResponsesBase(stateDB))
+
+	retainHeight := n - 5
+	assert.Equal(t, retainHeight-1, PruneABCIResponses(stateDB, retainHeight))
+	assert.Zero(t, PruneABCIResponses(stateDB, retainHeight))
+	assert.Zero(t, PruneABCIResponses(stateDB, 1))
+
+	stateDB.Close()
+	stateDB = dbm.NewDB("state", dbm.GoLevelDBBackend, config.DBDir())
+	defer stateDB.Close()
+
+	assert.Equal(t, retainHeight, LoadABCIResponsesBase(stateDB))
+	for _, h := range []int64{1, pruneABCIResponsesBatchSize, retainHeight - 1} {
+		_, err := LoadABCIResponses(stateDB, h)
+		assert.Equal(t, ErrABCIResponsesPruned{Height: h, Base: retainHeight}, err)
+		assert.Nil(t, stateDB.Get(calcABCIResponsesKey(h)), "height %d", h)
+	}
+	for h := retainHeight; h <= n; h++ {
+		res, err := LoadABCIResponses(stateDB, h)
+		require.NoError(t, err, "height %d", h)
+		assert.Equal(t, []byte(fmt.Sprintf("%d", h)), res.DeliverTx[0].Data)
+		require.Len(t, res.EndBlock.ValidatorUpdates, 1)
+		assert.Equal(t, h, res.EndBlock.ValidatorUpdates[0].Power)
+		assert.Equal(t, h, res.EndBlock.ConsensusParamUpdates.Block.MaxBytes)
+	}
+	_, err := LoadABCIResponses(stateDB, n+1)
+	assert.Equal(t, ErrNoABCIResponsesForHeight{n + 1}, err)
+}
+
+// TestBootstrapState tests saving a state restored from a snapshot to an
+// empty db.
+func TestBootstrapState(t *testing.T) {
+	stateDB := dbm.NewMemDB()
+	lastVals, _ := types.RandValidatorSet(1, 10)
+	vals, _ := types.RandValidatorSet(2, 10)
+	nextVals, _ := types.RandValidatorSet(3, 10)
+	params := *types.DefaultConsensusParams()
+	params.Block.MaxGas = 1000
+	state := State{
+		ChainID:                          "test",
+		LastBlockHeight:                  100,
+		LastValidators:                   lastVals,
+		Validators:                       vals,
+		NextValidators:                   nextVals,
+		LastHeightValidatorsChanged:      102,
+		ConsensusParams:                  params,
+		LastHeightConsensusParamsChanged: 101,
+	}
+	BootstrapState(stateDB, state)
+
+	loadedState := LoadState(stateDB)
+	assert.True(t, state.Equals(loadedState))
+	for height, expected := range map[int64]*types.ValidatorSet{100: lastVals, 101: vals, 102: nextVals} {
+		loaded, err := LoadValidators(stateDB, height)
+		require.NoError(t, err, "height %d", height)
+		assert.Equal(t, expected.Hash(), loaded.Hash(), "height %d", height)
+	}
+	_, err := LoadValidators(stateDB, 99)
+	assert.Error(t, err)
+	loadedParams, err := LoadConsensusParams(stateDB, 101)
+	require.NoError(t, err)
+	assert.Equal(t, params, loadedParams)
+	_, err = LoadABCIResponses(stateDB, 100)
+	assert.Equal(t, ErrABCIResponsesPruned{Height: 100, Base: 101}, err)
+}
+
 // TestValidatorSimpleSaveLoad tests saving and loading validators.
 func TestValidatorSimpleSaveLoad(t *testing.T) {
 	tearDown, stateDB, state := setupTestCase(t)
@@ -877,6 +979,49 @@ func TestManyValidatorChangesSaveLoad(t *testing.T) {
 	}
 }
 
+func TestLoadValidatorChanges(t *testing.T) {
+	tearDown, stateDB, state := setupTestCase(t)
+	defer tearDown(t)
+	state.Validators = genValSet(3)
+	state.NextValidators = state.Validators.CopyIncrementProposerPriority(1)
+	SaveState(stateDB, state)
+
+	_, valOld := state.NextValidators.GetByIndex(0)
+	pubkey := ed25519.GenPrivKey().PubKey()
+
+	// Swap the first validator with a new one at height 1.
+	header, blockID, responses := makeHeaderPartsResponsesValPubKeyChange(state, 1, pubkey)
+	validatorUpdates, err := types.PB2TM.ValidatorUpdates(responses.EndBlock.ValidatorUpdates)
+	require.NoError(t, err)
+	state, err = updateState(state, blockID, &header, responses, validatorUpdates)
+	require.NoError(t, err)
+	SaveState(stateDB, state)
+
+	// No change at height 2.
+	header, blockID, responses = makeHeaderPartsResponsesValPubKeyChange(state, 2, pubkey)
+	state, err = updateState(state, blockID, &header, responses, nil)
+	require.NoError(t, err)
+	SaveState(stateDB, state)
+
+	changes, err := LoadValidatorChanges(stateDB, 1)
+	require.NoError(t, err)
+	expected := []types.ValidatorPowerChange{
+		{Address: valOld.Address, PubKey: valOld.PubKey, PreviousVotingPower: 10, VotingPower: 0},
+		{Address: pubkey.Address(), PubKey: pubkey, PreviousVotingPower: 0, VotingPower: 10},
+	}
+	if bytes.Compare(pubkey.Address(), valOld.Address) < 0 {
+		expected[0], expected[1] = expected[1], expected[0]
+	}
+	assert.Equal(t, expected, changes)
+
+	changes, err = LoadValidatorChanges(stateDB, 2)
+	require.NoError(t, err)
+	assert.Nil(t, changes)
+
+	_, err = LoadValidatorChanges(stateDB, 3)
+	assert.IsType(t, ErrNoValSetForHeight{}, err)
+}
+
 func genValSet(size int) *types.ValidatorSet {
 	vals := make([]*types.Validator, size)
 	for i := 0; i < size; i++ {
@@ -23,6 +23,14 @@ func calcABCIResponsesKey(height int64) []byte {
 	return []byte(fmt.Sprintf("abciResponsesKey:%v", height))
 }
 
+// abciResponsesBaseKey indexes the lowest height the ABCIResponses are
+// retained for.
+var abciResponsesBaseKey = []byte("abciResponsesBaseKey")
+
+// pruneABCIResponsesBatchSize is the number of heights whose ABCIResponses
+// are deleted in one batch.
+const pruneABCIResponsesBatchSize = 1000
+
 // LoadStateFromDBOrGenesisFile loads the most recent state from the database,
 // or creates a new one from the given genesisFilePath and persists the result
 // to the database.
@@ -75,6 +83,8 @@ func loadState(db dbm.DB, key []byte) (state State) {
                 %v\n`, err))
 	}
 	// TODO: ensure that buf is completely read.
+	// the params added after the state was saved
+	state.ConsensusParams.Complete()
 
 	return state
 }
@@ -101,6 +111,23 @@ func saveState(db dbm.DB, state State, key []byte) {
 	db.SetSync(key, state.Bytes())
 }
 
+// BootstrapState persists a State restored from a snapshot, which has no
+// history, to an empty database. Besides what SaveState saves, it saves the
+// last and current validator sets, which would have been saved by earlier
+// blocks. The state's LastHeightValidatorsChanged must be the height after
+// the next one and its LastHeightConsensusParamsChanged the next height, so
+// the sets and params are saved in full.
+func BootstrapState(db dbm.DB, state State) {
+	height := state.LastBlockHeight
+	if height > 0 {
+		saveValidatorsInfo(db, height, height, state.LastValidators)
+	}
+	saveValidatorsInfo(db, height+1, height+1, state.Validators)
+	// the responses of the blocks up to the restored height aren't known
+	saveABCIResponsesBase(db, height+1)
+	saveState(db, state, stateKey)
+}
+
 //------------------------------------------------------------------------
 
 // ABCIResponses retains the responses
@@ -137,7 +164,13 @@ func (arz *ABCIResponses) ResultsHash() []byte {
 // LoadABCIResponses loads the ABCIResponses for the given height from the database.
 // This is useful for recovering from crashes where we called app.Commit and before we called
 // s.Save(). It can also be used to produce Merkle proofs of the result of txs.
+// It returns ErrABCIResponsesPruned if the height is below the retained ones
+// (see PruneABCIResponses).
 func LoadABCIResponses(db dbm.DB, height int64) (*ABCIResponses, error) {
+	if base := LoadABCIResponsesBase(db); height < base {
+		return nil, ErrABCIResponsesPruned{Height: height, Base: base}
+	}
+
 	buf := db.Get(calcABCIResponsesKey(height))
 	if len(buf) == 0 {
 		return nil, ErrNoABCIResponsesForHeight{height}
@@ -162,6 +195,51 @@ func saveABCIResponses(db dbm.DB, height int64, abciResponses *ABCIResponses) {
 	db.SetSync(calcABCIResponsesKey(height), abciResponses.Bytes())
 }
 
+// LoadABCIResponsesBase returns the lowest height the ABCIResponses are
+// retained for: 1, unless they were pruned or the state was restored from a
+// snapshot.
+func LoadABCIResponsesBase(db dbm.DB) int64 {
+	buf := db.Get(abciResponsesBaseKey)
+	if len(buf) == 0 {
+		return 1
+	}
+	var base int64
+	if err := cdc.UnmarshalBinaryBare(buf, &base); err != nil {
+		// DATA HAS BEEN CORRUPTED OR THE SPEC HAS CHANGED
+		cmn.Exit(fmt.Sprintf(`LoadABCIResponsesBase: Data has been corrupted or its spec has
+                changed: %v\n`, err))
+	}
+	return base
+}
+
+func saveABCIResponsesBase(db dbm.SetDeleter, base int64) {
+	db.Set(abciResponsesBaseKey, cdc.MustMarshalBinaryBare(base))
+}
+
+// PruneABCIResponses deletes the ABCIResponses of the heights below
+// retainHeight, and returns the number of heights pruned. The base is moved
+// along with each batch of deletions, so the retained heights stay
+// contiguous if it's interrupted.
+func PruneABCIResponses(db dbm.DB, retainHeight int64) int64 {
+	base := LoadABCIResponsesBase(db)
+	if retainHeight <= base {
+		return 0
+	}
+	pruned := retainHeight - base
+	for base < retainHeight {
+		end := cmn.MinInt64(base+pruneABCIResponsesBatchSize, retainHeight)
+		batch := db.NewBatch()
+		for h := base; h < end; h++ {
+			batch.Delete(calcABCIResponsesKey(h))
+		}
+		saveABCIResponsesBase(batch, end)
+		batch.WriteSync()
+		batch.Close()
+		base = end
+	}
+	return pruned
+}
+
 //-----------------------------------------------------------------------------
 
 // ValidatorsInfo represents the latest validator set, or the last height it changed
@@ -201,6 +279,24 @@ func LoadValidators(db dbm.DB, height int64) (*types.ValidatorSet, error) {
 	return valInfo.ValidatorSet, nil
 }
 
+// LoadValidatorChanges loads the changes of voting power made by the EndBlock
+// of the block at the given height, effective at height+2. It returns nil if
+// the block didn't update the validator set.
+func LoadValidatorChanges(db dbm.DB, height int64) ([]types.ValidatorPowerChange, error) {
+	valInfo := loadValidatorsInfo(db, height+2)
+	if valInfo == nil {
+		return nil, ErrNoValSetForHeight{height + 2}
+	}
+	if valInfo.LastHeightChanged != height+2 {
+		return nil, nil
+	}
+	prevValidators, err := LoadValidators(db, height+1)
+	if err != nil {
+		return nil, err
+	}
+	return types.ValidatorPowerChanges(prevValidators, valInfo.ValidatorSet), nil
+}
+
 // CONTRACT: Returned ValidatorsInfo can be mutated.
 func loadValidatorsInfo(db dbm.DB, height int64) *ValidatorsInfo {
 	buf := db.Get(calcValidatorsKey(height))
@@ -274,6 +370,7 @@ func LoadConsensusParams(db dbm.DB, height int64) (types.ConsensusParams, error)
 		paramsInfo = paramsInfo2
 	}
 
+	paramsInfo.ConsensusParams.Complete()
 	return paramsInfo.ConsensusParams, nil
 }
 
@@ -17,6 +17,7 @@ import (
 func TestTxFilter(t *testing.T) {
 	genDoc := randomGenesisDoc()
 	genDoc.ConsensusParams.Block.MaxBytes = 3000
+	genDoc.ConsensusParams.Evidence.MaxBytes = 300
 
 	// Max size of Txs is much smaller than size of block,
 	// since we need to account for commits and evidence.
@@ -2,6 +2,8 @@ package txindex
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/tendermint/tendermint/libs/pubsub/query"
 	"github.com/tendermint/tendermint/types"
@@ -20,8 +22,102 @@ type TxIndexer interface {
 	// or stored.
 	Get(hash []byte) (*types.TxResult, error)
 
-	// Search allows you to query for transactions.
-	Search(q *query.Query) ([]*types.TxResult, error)
+	// Search allows you to query for transactions, ordered by height and index.
+	Search(q *query.Query, opts SearchOptions) (*SearchResult, error)
+}
+
+// BlockIndexer is implemented by the TxIndexers which also index the blocks,
+// with the tags of their BeginBlock and EndBlock responses.
+type BlockIndexer interface {
+	// IndexBlock analyzes, indexes and stores a block, before its txs.
+	IndexBlock(header types.EventDataNewBlockHeader) error
+}
+
+//----------------------------------------------------
+// Search
+
+// SearchOptions select the page of the results of a search. The zero value
+// selects all of them, in ascending order, without counting them.
+type SearchOptions struct {
+	// Order the results by descending height and index, rather than ascending.
+	OrderDesc bool
+
+	// If set, the results up to the cursor (included) are skipped. Unlike the
+	// Offset, it's stable when new txs are indexed.
+	After *Cursor
+
+	// The number of results skipped (after the cursor, if set), and the
+	// maximum number of results returned (0 for all of them).
+	Offset int
+	Limit  int
+
+	// Count the results of the query, i.e. set the TotalCount of the
+	// SearchResult. This may be costly for the queries whose results can't
+	// all be found in the index.
+	Count bool
+}
+
+// SearchResult is a page of the results of a search.
+type SearchResult struct {
+	Txs []*types.TxResult
+
+	// The number of results of the query (not only of the page), or -1 if
+	// they weren't counted.
+	TotalCount int
+
+	// The cursor of the last tx of the page, if there are more results after
+	// it, nil otherwise.
+	Next *Cursor
+}
+
+// Cursor is the position of a tx in the results of a search: its height and
+// index in the block.
+type Cursor struct {
+	Height int64
+	Index  uint32
+}
+
+// CursorOf returns the cursor of the tx.
+func CursorOf(result *types.TxResult) *Cursor {
+	return &Cursor{Height: result.Height, Index: result.Index}
+}
+
+// ParseCursor parses a cursor in the format of Cursor.String.
+func ParseCursor(s string) (*Cursor, error) {
+	c := new(Cursor)
+	var rest string
+	if n, _ := fmt.Sscanf(s, "%d.%d%s", &c.Height, &c.Index, &rest); n != 2 || c.Height < 1 {
+		return nil, fmt.Errorf("invalid cursor %q, expected <height>.<index>", s)
+	}
+	return c, nil
+}
+
+// Less returns true if the cursor is before the other one, in ascending order.
+func (c Cursor) Less(other Cursor) bool {
+	if c.Height == other.Height {
+		return c.Index < other.Index
+	}
+	return c.Height < other.Height
+}
+
+// String returns the cursor as "<height>.<index>".
+func (c Cursor) String() string {
+	return fmt.Sprintf("%d.%d", c.Height, c.Index)
+}
+
+//----------------------------------------------------
+// Tags
+
+// TagMatches returns true if the key of a tag is one of the keys, or starts
+// with one of the keys ending with ".*" but for the "*" (e.g. "transfer.*" for
+// all the tags of the transfer events).
+func TagMatches(key string, keys []string) bool {
+	for _, k := range keys {
+		if k == key || (strings.HasSuffix(k, ".*") && strings.HasPrefix(key, k[:len(k)-1])) {
+			return true
+		}
+	}
+	return false
 }
 
 //----------------------------------------------------
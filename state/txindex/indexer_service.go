@@ -2,6 +2,8 @@ package txindex
 
 import (
 	"context"
+	"sync/atomic"
+	"time"
 
 	cmn "github.com/tendermint/tendermint/libs/common"
 
@@ -10,20 +12,81 @@ import (
 
 const (
 	subscriber = "IndexerService"
+
+	// DefaultSinkQueueSize is the number of blocks queued for each sink,
+	// beyond which the new blocks are dropped for the sink.
+	DefaultSinkQueueSize = 1000
+
+	// The number of times the indexing of a block by a sink is retried, and
+	// the delay before the first retry, doubled after each one.
+	sinkRetries      = 5
+	sinkRetryBackoff = time.Second
 )
 
-// IndexerService connects event bus and transaction indexer together in order
+// Sink is a TxIndexer the IndexerService indexes the blocks and txs to, named
+// in the logs and metrics.
+type Sink struct {
+	Name string
+	TxIndexer
+}
+
+// IndexerService connects event bus and transaction indexers together in order
 // to index transactions coming from event bus.
+//
+// The blocks and their txs are indexed by each sink in its own routine, from
+// its own queue, so that a slow or failing sink doesn't hold back the others,
+// nor the event bus. The blocks a sink failed to index, or which were dropped
+// while its queue was full, can be indexed again with ReindexEvents.
 type IndexerService struct {
 	cmn.BaseService
 
-	idr      TxIndexer
+	sinks    []*sinkWorker
 	eventBus *types.EventBus
+
+	queueSize int
+	metrics   *Metrics
+}
+
+// sinkWorker indexes the blocks queued for a sink.
+type sinkWorker struct {
+	Sink
+	queue   chan indexedBlock
+	pending int64 // queued or being indexed, atomic
+}
+
+// indexedBlock is a block and its txs, to be indexed.
+type indexedBlock struct {
+	header types.EventDataNewBlockHeader
+	batch  *Batch
+}
+
+// IndexerServiceOption sets an optional parameter on the IndexerService.
+type IndexerServiceOption func(*IndexerService)
+
+// WithMetrics sets the metrics.
+func WithMetrics(metrics *Metrics) IndexerServiceOption {
+	return func(is *IndexerService) { is.metrics = metrics }
+}
+
+// WithSinkQueueSize sets the number of blocks queued for each sink
+// (DefaultSinkQueueSize by default).
+func WithSinkQueueSize(n int) IndexerServiceOption {
+	return func(is *IndexerService) { is.queueSize = n }
 }
 
-// NewIndexerService returns a new service instance.
-func NewIndexerService(idr TxIndexer, eventBus *types.EventBus) *IndexerService {
-	is := &IndexerService{idr: idr, eventBus: eventBus}
+// NewIndexerService returns a new service instance, indexing to the sinks.
+func NewIndexerService(sinks []Sink, eventBus *types.EventBus, options ...IndexerServiceOption) *IndexerService {
+	is := &IndexerService{
+		eventBus:  eventBus,
+		queueSize: DefaultSinkQueueSize,
+		metrics:   NopMetrics(),
+	}
+	for _, option := range options {
+		option(is)
+	}
+	for _, sink := range sinks {
+		is.sinks = append(is.sinks, &sinkWorker{Sink: sink, queue: make(chan indexedBlock, is.queueSize)})
+	}
 	is.BaseService = *cmn.NewBaseService(nil, "IndexerService", is)
 	return is
 }
@@ -31,6 +94,10 @@ func NewIndexerService(idr TxIndexer, eventBus *types.EventBus) *IndexerService
 // OnStart implements cmn.Service by subscribing for all transactions
 // and indexing them by tags.
 func (is *IndexerService) OnStart() error {
+	if len(is.sinks) == 0 {
+		return nil
+	}
+
 	// Use SubscribeUnbuffered here to ensure both subscriptions does not get
 	// cancelled due to not pulling messages fast enough. Cause this might
 	// sometimes happen when there are no other subscribers.
@@ -45,10 +112,15 @@ func (is *IndexerService) OnStart() error {
 		return err
 	}
 
+	for _, w := range is.sinks {
+		go is.indexRoutine(w)
+	}
+
 	go func() {
 		for {
 			msg := <-blockHeadersSub.Out()
-			header := msg.Data().(types.EventDataNewBlockHeader).Header
+			eventDataHeader := msg.Data().(types.EventDataNewBlockHeader)
+			header := eventDataHeader.Header
 			batch := NewBatch(header.NumTxs)
 			for i := int64(0); i < header.NumTxs; i++ {
 				msg2 := <-txsSub.Out()
@@ -60,11 +132,7 @@ func (is *IndexerService) OnStart() error {
 						"err", err)
 				}
 			}
-			if err = is.idr.AddBatch(batch); err != nil {
-				is.Logger.Error("Failed to index block", "height", header.Height, "err", err)
-			} else {
-				is.Logger.Info("Indexed block", "height", header.Height)
-			}
+			is.enqueue(indexedBlock{header: eventDataHeader, batch: batch})
 		}
 	}()
 	return nil
@@ -76,3 +144,75 @@ func (is *IndexerService) OnStop() {
 		_ = is.eventBus.UnsubscribeAll(context.Background(), subscriber)
 	}
 }
+
+// enqueue queues the block for each sink, or drops it for the sinks whose
+// queue is full.
+func (is *IndexerService) enqueue(b indexedBlock) {
+	for _, w := range is.sinks {
+		pending := atomic.AddInt64(&w.pending, 1)
+		select {
+		case w.queue <- b:
+			is.metrics.SinkLag.With("sink", w.Name).Set(float64(pending))
+		default:
+			atomic.AddInt64(&w.pending, -1)
+			is.metrics.SinkDroppedBlocks.With("sink", w.Name).Add(1)
+			is.Logger.Error("Dropped block, the queue of the sink is full", "sink", w.Name,
+				"height", b.header.Header.Height, "queued", len(w.queue))
+		}
+	}
+}
+
+// indexRoutine indexes the blocks queued for the sink, in order.
+func (is *IndexerService) indexRoutine(w *sinkWorker) {
+	for {
+		select {
+		case b := <-w.queue:
+			if is.indexBlock(w, b) {
+				is.metrics.IndexedHeight.With("sink", w.Name).Set(float64(b.header.Header.Height))
+			} else {
+				is.metrics.SinkDroppedBlocks.With("sink", w.Name).Add(1)
+			}
+			pending := atomic.AddInt64(&w.pending, -1)
+			is.metrics.SinkLag.With("sink", w.Name).Set(float64(pending))
+		case <-is.Quit():
+			return
+		}
+	}
+}
+
+// indexBlock indexes the block (if the sink is a BlockIndexer) and its txs
+// with the sink, retrying what failed with a backoff. It returns false if it
+// gave up, after sinkRetries retries or as the service stopped.
+func (is *IndexerService) indexBlock(w *sinkWorker, b indexedBlock) bool {
+	height := b.header.Header.Height
+	blockIndexer, indexBlock := w.TxIndexer.(BlockIndexer)
+	backoff := sinkRetryBackoff
+	for retry := 0; ; retry++ {
+		var err error
+		if indexBlock {
+			if err = blockIndexer.IndexBlock(b.header); err == nil {
+				indexBlock = false
+			}
+		}
+		if err == nil {
+			if err = w.AddBatch(b.batch); err == nil {
+				is.Logger.Info("Indexed block", "sink", w.Name, "height", height)
+				return true
+			}
+		}
+
+		is.metrics.SinkFailures.With("sink", w.Name).Add(1)
+		if retry == sinkRetries {
+			is.Logger.Error("Failed to index block, giving up", "sink", w.Name, "height", height, "err", err)
+			return false
+		}
+		is.Logger.Error("Failed to index block, retrying", "sink", w.Name, "height", height,
+			"err", err, "backoff", backoff)
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-is.Quit():
+			return false
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package txindex_test
 
 import (
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -8,10 +10,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
 	"github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/state/txindex"
 	"github.com/tendermint/tendermint/state/txindex/kv"
+	"github.com/tendermint/tendermint/state/txindex/null"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -27,7 +31,7 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	store := db.NewMemDB()
 	txIndexer := kv.NewTxIndex(store, kv.IndexAllTags())
 
-	service := txindex.NewIndexerService(txIndexer, eventBus)
+	service := txindex.NewIndexerService([]txindex.Sink{{Name: "kv", TxIndexer: txIndexer}}, eventBus)
 	service.SetLogger(log.TestingLogger())
 	err = service.Start()
 	require.NoError(t, err)
@@ -62,3 +66,129 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, txResult2, res)
 }
+
+// blockIndexer records the blocks it indexes.
+type blockIndexer struct {
+	txindex.TxIndexer
+	mtx     sync.Mutex
+	headers []types.EventDataNewBlockHeader
+}
+
+func (idx *blockIndexer) IndexBlock(header types.EventDataNewBlockHeader) error {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+	idx.headers = append(idx.headers, header)
+	return nil
+}
+
+func TestIndexerServiceIndexesBlockTags(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	err := eventBus.Start()
+	require.NoError(t, err)
+	defer eventBus.Stop()
+
+	txIndexer := &blockIndexer{TxIndexer: kv.NewTxIndex(db.NewMemDB())}
+	service := txindex.NewIndexerService([]txindex.Sink{{Name: "kv", TxIndexer: txIndexer}}, eventBus)
+	service.SetLogger(log.TestingLogger())
+	err = service.Start()
+	require.NoError(t, err)
+	defer service.Stop()
+
+	header := types.EventDataNewBlockHeader{
+		Header:           types.Header{Height: 1},
+		ResultBeginBlock: abci.ResponseBeginBlock{Tags: []cmn.KVPair{{Key: []byte("proposer"), Value: []byte("alice")}}},
+	}
+	eventBus.PublishEventNewBlockHeader(header)
+
+	time.Sleep(100 * time.Millisecond)
+
+	txIndexer.mtx.Lock()
+	defer txIndexer.mtx.Unlock()
+	assert.Equal(t, []types.EventDataNewBlockHeader{header}, txIndexer.headers)
+}
+
+// sink records the heights of the blocks it indexes. It fails to index the
+// first blocks, and waits for unblock, if set.
+type sink struct {
+	null.TxIndex
+	unblock chan struct{}
+
+	mtx     sync.Mutex
+	fail    int
+	heights []int64
+}
+
+func (s *sink) IndexBlock(header types.EventDataNewBlockHeader) error {
+	if s.unblock != nil {
+		<-s.unblock
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.fail > 0 {
+		s.fail--
+		return errors.New("failed")
+	}
+	s.heights = append(s.heights, header.Header.Height)
+	return nil
+}
+
+func (s *sink) indexedHeights() []int64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.heights
+}
+
+func TestIndexerServiceRetriesFailingSink(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	err := eventBus.Start()
+	require.NoError(t, err)
+	defer eventBus.Stop()
+
+	good, failing := &sink{}, &sink{fail: 1}
+	service := txindex.NewIndexerService([]txindex.Sink{{Name: "good", TxIndexer: good}, {Name: "failing", TxIndexer: failing}},
+		eventBus)
+	service.SetLogger(log.TestingLogger())
+	err = service.Start()
+	require.NoError(t, err)
+	defer service.Stop()
+
+	eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{Header: types.Header{Height: 1}})
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, []int64{1}, good.indexedHeights())
+	assert.Empty(t, failing.indexedHeights())
+
+	// retried after a second
+	time.Sleep(time.Second)
+	assert.Equal(t, []int64{1}, failing.indexedHeights())
+}
+
+func TestIndexerServiceDropsBlocksOfSlowSink(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	err := eventBus.Start()
+	require.NoError(t, err)
+	defer eventBus.Stop()
+
+	fast, slow := &sink{}, &sink{unblock: make(chan struct{})}
+	service := txindex.NewIndexerService([]txindex.Sink{{Name: "fast", TxIndexer: fast}, {Name: "slow", TxIndexer: slow}},
+		eventBus, txindex.WithSinkQueueSize(1))
+	service.SetLogger(log.TestingLogger())
+	err = service.Start()
+	require.NoError(t, err)
+	defer service.Stop()
+
+	// the slow sink indexes the block 1, the block 2 is queued and the block
+	// 3 dropped
+	for height := int64(1); height <= 3; height++ {
+		eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{Header: types.Header{Height: height}})
+		time.Sleep(50 * time.Millisecond)
+	}
+	assert.Equal(t, []int64{1, 2, 3}, fast.indexedHeights())
+	assert.Empty(t, slow.indexedHeights())
+
+	close(slow.unblock)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, []int64{1, 2}, slow.indexedHeights())
+}
@@ -0,0 +1,30 @@
+package txindex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCursor(t *testing.T) {
+	c, err := ParseCursor("12.3")
+	require.NoError(t, err)
+	assert.Equal(t, &Cursor{Height: 12, Index: 3}, c)
+	assert.Equal(t, "12.3", c.String())
+
+	for _, s := range []string{"", "12", "12.", "12.3.4", "12.-3", "0.1", "a.b"} {
+		_, err := ParseCursor(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestTagMatches(t *testing.T) {
+	keys := []string{"account.owner", "transfer.*"}
+	assert.True(t, TagMatches("account.owner", keys))
+	assert.True(t, TagMatches("transfer.sender", keys))
+	assert.False(t, TagMatches("account.number", keys))
+	assert.False(t, TagMatches("transfer", keys))
+	assert.False(t, TagMatches("transfers.sender", keys))
+	assert.False(t, TagMatches("account.owner", nil))
+}
@@ -20,27 +20,41 @@ import (
 
 const (
 	tagKeySeparator = "/"
+
+	// defaultMaxScannedTxs is the most txs scanned for a query without a
+	// condition to look up in the indexes, unless set with MaxScannedTxs.
+	defaultMaxScannedTxs = 10000
 )
 
 var _ txindex.TxIndexer = (*TxIndex)(nil)
 
 // TxIndex is the simplest possible indexer, backed by key-value storage (levelDB).
 type TxIndex struct {
-	store        dbm.DB
-	tagsToIndex  []string
-	indexAllTags bool
+	store         dbm.DB
+	tagsToIndex   []string
+	indexAllTags  bool
+	tagsToExclude []string
+
+	maxSearchResults int
+	maxScannedTxs    int
 }
 
 // NewTxIndex creates new KV indexer.
 func NewTxIndex(store dbm.DB, options ...func(*TxIndex)) *TxIndex {
-	txi := &TxIndex{store: store, tagsToIndex: make([]string, 0), indexAllTags: false}
+	txi := &TxIndex{
+		store:         store,
+		tagsToIndex:   make([]string, 0),
+		indexAllTags:  false,
+		maxScannedTxs: defaultMaxScannedTxs,
+	}
 	for _, o := range options {
 		o(txi)
 	}
 	return txi
 }
 
-// IndexTags is an option for setting which tags to index.
+// IndexTags is an option for setting which tags to index, besides the ones
+// marked by the app (see txindex.TagMatches for the ones ending with ".*").
 func IndexTags(tags []string) func(*TxIndex) {
 	return func(txi *TxIndex) {
 		txi.tagsToIndex = tags
@@ -54,6 +68,31 @@ func IndexAllTags() func(*TxIndex) {
 	}
 }
 
+// ExcludeTags is an option for setting which tags not to index, even if
+// they're selected by IndexTags or IndexAllTags, or marked by the app.
+func ExcludeTags(tags []string) func(*TxIndex) {
+	return func(txi *TxIndex) {
+		txi.tagsToExclude = tags
+	}
+}
+
+// MaxSearchResults is an option for failing the searches which look up more
+// than n txs in the index (0 for no limit).
+func MaxSearchResults(n int) func(*TxIndex) {
+	return func(txi *TxIndex) {
+		txi.maxSearchResults = n
+	}
+}
+
+// MaxScannedTxs is an option for failing the searches which scan more than n
+// txs, as the queries without a condition ANDed at their top level do (e.g.
+// "a='x' OR b='y'"), rather than look them up in the indexes (0 for no limit).
+func MaxScannedTxs(n int) func(*TxIndex) {
+	return func(txi *TxIndex) {
+		txi.maxScannedTxs = n
+	}
+}
+
 // Get gets transaction from the TxIndex storage and returns it or nil if the
 // transaction is not found.
 func (txi *TxIndex) Get(hash []byte) (*types.TxResult, error) {
@@ -85,13 +124,13 @@ func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
 
 		// index tx by tags
 		for _, tag := range result.Result.Tags {
-			if txi.indexAllTags || cmn.StringInSlice(string(tag.Key), txi.tagsToIndex) {
+			if txi.indexTag(tag) {
 				storeBatch.Set(keyForTag(tag, result), hash)
 			}
 		}
 
 		// index tx by height
-		if txi.indexAllTags || cmn.StringInSlice(types.TxHeightKey, txi.tagsToIndex) {
+		if txi.indexHeight() {
 			storeBatch.Set(keyForHeight(result), hash)
 		}
 
@@ -116,13 +155,13 @@ func (txi *TxIndex) Index(result *types.TxResult) error {
 
 	// index tx by tags
 	for _, tag := range result.Result.Tags {
-		if txi.indexAllTags || cmn.StringInSlice(string(tag.Key), txi.tagsToIndex) {
+		if txi.indexTag(tag) {
 			b.Set(keyForTag(tag, result), hash)
 		}
 	}
 
 	// index tx by height
-	if txi.indexAllTags || cmn.StringInSlice(types.TxHeightKey, txi.tagsToIndex) {
+	if txi.indexHeight() {
 		b.Set(keyForHeight(result), hash)
 	}
 
@@ -142,10 +181,96 @@ func (txi *TxIndex) Index(result *types.TxResult) error {
 // index. One special use cases here: (1) if "tx.hash" is found, it returns tx
 // result for it (2) for range queries it is better for the client to provide
 // both lower and upper bounds, so we are not performing a full scan. Results
-// from querying indexes are then intersected and returned to the caller.
-func (txi *TxIndex) Search(q *query.Query) ([]*types.TxResult, error) {
-	var hashes [][]byte
-	var hashesInitialized bool
+// from querying indexes are then intersected, ordered and paged.
+//
+// If the query isn't a conjunction (e.g. it has OR or NOT), only the conditions
+// ANDed at its top level are queried in the indexes, and the results are then
+// filtered with the whole query, matched against their indexed tags. If it has
+// no such condition, all the txs are scanned, up to MaxScannedTxs.
+//
+// Only the hashes and the positions of the txs found in the indexes are kept
+// to order and page them: the txs are loaded for the page, and to filter them
+// if the query isn't a conjunction.
+func (txi *TxIndex) Search(q *query.Query, opts txindex.SearchOptions) (*txindex.SearchResult, error) {
+	refs, err := txi.lookUp(q)
+	if err != nil {
+		return nil, err
+	}
+	if txi.maxSearchResults > 0 && len(refs) > txi.maxSearchResults {
+		return nil, fmt.Errorf("The query looks up more than %d txs (max_search_results), narrow it down",
+			txi.maxSearchResults)
+	}
+
+	// sort by height & index
+	sort.Slice(refs, func(i, j int) bool {
+		if opts.OrderDesc {
+			return refs[j].cursor.Less(refs[i].cursor)
+		}
+		return refs[i].cursor.Less(refs[j].cursor)
+	})
+
+	filtered := !q.IsConjunction()
+	result := &txindex.SearchResult{TotalCount: -1}
+	if opts.Count {
+		if filtered {
+			// all the results have to be matched to be counted
+			if refs, err = txi.filter(refs, q); err != nil {
+				return nil, err
+			}
+			filtered = false
+		}
+		result.TotalCount = len(refs)
+	}
+
+	if opts.After != nil {
+		after := *opts.After
+		refs = refs[sort.Search(len(refs), func(i int) bool {
+			if opts.OrderDesc {
+				return refs[i].cursor.Less(after)
+			}
+			return after.Less(refs[i].cursor)
+		}):]
+	}
+
+	skip := opts.Offset
+	if !filtered {
+		refs = refs[cmn.MinInt(skip, len(refs)):]
+		skip = 0
+	}
+	result.Txs = make([]*types.TxResult, 0)
+	for _, ref := range refs {
+		res, err := txi.Get(ref.hash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get Tx{%X}", ref.hash)
+		}
+		if res == nil || (filtered && !q.Matches(txi.indexedTags(res))) {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		if opts.Limit > 0 && len(result.Txs) == opts.Limit {
+			result.Next = txindex.CursorOf(result.Txs[len(result.Txs)-1])
+			break
+		}
+		result.Txs = append(result.Txs, res)
+	}
+
+	return result, nil
+}
+
+// txRef is a tx found in the indexes.
+type txRef struct {
+	hash   []byte
+	cursor txindex.Cursor
+}
+
+// lookUp returns the txs found in the indexes for the conditions of the query,
+// in no particular order.
+func (txi *TxIndex) lookUp(q *query.Query) ([]txRef, error) {
+	var refs []txRef
+	var refsInitialized bool
 
 	// get a list of conditions (like "tx.height > 5")
 	conditions := q.Conditions()
@@ -156,10 +281,13 @@ func (txi *TxIndex) Search(q *query.Query) ([]*types.TxResult, error) {
 		return nil, errors.Wrap(err, "error during searching for a hash in the query")
 	} else if ok {
 		res, err := txi.Get(hash)
+		if err != nil {
+			return nil, errors.Wrap(err, "error while retrieving the result")
+		}
 		if res == nil {
-			return []*types.TxResult{}, nil
+			return []txRef{}, nil
 		}
-		return []*types.TxResult{res}, errors.Wrap(err, "error while retrieving the result")
+		return []txRef{{hash: hash, cursor: *txindex.CursorOf(res)}}, nil
 	}
 
 	// conditions to skip because they're handled before "everything else"
@@ -173,11 +301,11 @@ func (txi *TxIndex) Search(q *query.Query) ([]*types.TxResult, error) {
 		skipIndexes = append(skipIndexes, rangeIndexes...)
 
 		for _, r := range ranges {
-			if !hashesInitialized {
-				hashes = txi.matchRange(r, startKey(r.key))
-				hashesInitialized = true
+			if !refsInitialized {
+				refs = txi.matchRange(r, startKey(r.key))
+				refsInitialized = true
 			} else {
-				hashes = intersect(hashes, txi.matchRange(r, startKey(r.key)))
+				refs = intersect(refs, txi.matchRange(r, startKey(r.key)))
 			}
 		}
 	}
@@ -191,38 +319,39 @@ func (txi *TxIndex) Search(q *query.Query) ([]*types.TxResult, error) {
 			continue
 		}
 
-		if !hashesInitialized {
-			hashes = txi.match(c, startKeyForCondition(c, height))
-			hashesInitialized = true
+		if !refsInitialized {
+			refs = txi.match(c, startKeyForCondition(c, height))
+			refsInitialized = true
 		} else {
-			hashes = intersect(hashes, txi.match(c, startKeyForCondition(c, height)))
+			refs = intersect(refs, txi.match(c, startKeyForCondition(c, height)))
 		}
 	}
 
-	results := make([]*types.TxResult, len(hashes))
-	i := 0
-	for _, h := range hashes {
-		results[i], err = txi.Get(h)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get Tx{%X}", h)
-		}
-		i++
+	// no condition to query the indexes with
+	if !refsInitialized {
+		return txi.allRefs()
 	}
+	return uniqueRefs(refs), nil
+}
 
-	// sort by height & index by default
-	sort.Slice(results, func(i, j int) bool {
-		if results[i].Height == results[j].Height {
-			return results[i].Index < results[j].Index
+// filter returns the txs which match the query.
+func (txi *TxIndex) filter(refs []txRef, q *query.Query) ([]txRef, error) {
+	filtered := make([]txRef, 0, len(refs))
+	for _, ref := range refs {
+		res, err := txi.Get(ref.hash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get Tx{%X}", ref.hash)
 		}
-		return results[i].Height < results[j].Height
-	})
-
-	return results, nil
+		if res != nil && q.Matches(txi.indexedTags(res)) {
+			filtered = append(filtered, ref)
+		}
+	}
+	return filtered, nil
 }
 
 func lookForHash(conditions []query.Condition) (hash []byte, err error, ok bool) {
 	for _, c := range conditions {
-		if c.Tag == types.TxHashKey {
+		if c.Tag == types.TxHashKey && c.Op != query.OpExists {
 			decoded, err := hex.DecodeString(c.Operand.(string))
 			return decoded, err, true
 		}
@@ -334,12 +463,20 @@ func isRangeOperation(op query.Operator) bool {
 	}
 }
 
-func (txi *TxIndex) match(c query.Condition, startKeyBz []byte) (hashes [][]byte) {
+func (txi *TxIndex) match(c query.Condition, startKeyBz []byte) (refs []txRef) {
 	if c.Op == query.OpEqual {
 		it := dbm.IteratePrefix(txi.store, startKeyBz)
 		defer it.Close()
 		for ; it.Valid(); it.Next() {
-			hashes = append(hashes, it.Value())
+			refs = append(refs, refFromKey(it.Key(), it.Value()))
+		}
+	} else if c.Op == query.OpExists {
+		it := dbm.IteratePrefix(txi.store, startKey(c.Tag))
+		defer it.Close()
+		for ; it.Valid(); it.Next() {
+			if isTagKey(it.Key()) {
+				refs = append(refs, refFromKey(it.Key(), it.Value()))
+			}
 		}
 	} else if c.Op == query.OpContains {
 		// XXX: startKey does not apply here.
@@ -352,7 +489,7 @@ func (txi *TxIndex) match(c query.Condition, startKeyBz []byte) (hashes [][]byte
 				continue
 			}
 			if strings.Contains(extractValueFromKey(it.Key()), c.Operand.(string)) {
-				hashes = append(hashes, it.Value())
+				refs = append(refs, refFromKey(it.Key(), it.Value()))
 			}
 		}
 	} else {
@@ -361,9 +498,9 @@ func (txi *TxIndex) match(c query.Condition, startKeyBz []byte) (hashes [][]byte
 	return
 }
 
-func (txi *TxIndex) matchRange(r queryRange, startKey []byte) (hashes [][]byte) {
+func (txi *TxIndex) matchRange(r queryRange, startKey []byte) (refs []txRef) {
 	// create a map to prevent duplicates
-	hashesMap := make(map[string][]byte)
+	refsMap := make(map[string]txRef)
 
 	lowerBound := r.lowerBoundValue()
 	upperBound := r.upperBoundValue()
@@ -389,7 +526,7 @@ LOOP:
 				include = false
 			}
 			if include {
-				hashesMap[fmt.Sprintf("%X", it.Value())] = it.Value()
+				refsMap[string(it.Value())] = refFromKey(it.Key(), it.Value())
 			}
 			// XXX: passing time in a ABCI Tags is not yet implemented
 			// case time.Time:
@@ -399,15 +536,66 @@ LOOP:
 			// 	}
 		}
 	}
-	hashes = make([][]byte, len(hashesMap))
-	i := 0
-	for _, h := range hashesMap {
-		hashes[i] = h
-		i++
+	refs = make([]txRef, 0, len(refsMap))
+	for _, ref := range refsMap {
+		refs = append(refs, ref)
 	}
 	return
 }
 
+// allRefs returns all the indexed txs, or an error if there are more than
+// maxScannedTxs.
+func (txi *TxIndex) allRefs() (refs []txRef, err error) {
+	it := txi.store.Iterator(nil, nil)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		if isTagKey(it.Key()) {
+			continue
+		}
+		txResult := new(types.TxResult)
+		if err := cdc.UnmarshalBinaryBare(it.Value(), &txResult); err != nil {
+			// not a tx, e.g. the key of a tag whose value has a separator
+			continue
+		}
+		if txi.maxScannedTxs > 0 && len(refs) == txi.maxScannedTxs {
+			return nil, fmt.Errorf("The query has no condition ANDed at its top level to look up "+
+				"in the index, and scans more than %d txs, narrow it down (e.g. tx.height > 5 AND (...))",
+				txi.maxScannedTxs)
+		}
+		refs = append(refs, txRef{hash: it.Key(), cursor: *txindex.CursorOf(txResult)})
+	}
+	return refs, nil
+}
+
+// indexTag returns true if the tx is indexed by the tag.
+func (txi *TxIndex) indexTag(tag cmn.KVPair) bool {
+	key := string(tag.Key)
+	if txindex.TagMatches(key, txi.tagsToExclude) {
+		return false
+	}
+	return tag.Index || txi.indexAllTags || txindex.TagMatches(key, txi.tagsToIndex)
+}
+
+// indexHeight returns true if the tx is indexed by its height.
+func (txi *TxIndex) indexHeight() bool {
+	return txi.indexTag(cmn.KVPair{Key: []byte(types.TxHeightKey)})
+}
+
+// indexedTags returns the tags the tx is indexed by, and its hash.
+func (txi *TxIndex) indexedTags(result *types.TxResult) map[string]string {
+	tags := make(map[string]string)
+	for _, tag := range result.Result.Tags {
+		if txi.indexTag(tag) {
+			tags[string(tag.Key)] = string(tag.Value)
+		}
+	}
+	if txi.indexHeight() {
+		tags[types.TxHeightKey] = fmt.Sprintf("%d", result.Height)
+	}
+	tags[types.TxHashKey] = fmt.Sprintf("%X", result.Tx.Hash())
+	return tags
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // Keys
 
@@ -420,6 +608,16 @@ func extractValueFromKey(key []byte) string {
 	return parts[1]
 }
 
+// refFromKey returns the tx of the key of a tag (or height), whose value is
+// the hash of the tx.
+func refFromKey(key, hash []byte) txRef {
+	// the value of the tag may have separators, the height and index don't
+	parts := strings.Split(string(key), tagKeySeparator)
+	height, _ := strconv.ParseInt(parts[len(parts)-2], 10, 64)
+	index, _ := strconv.ParseUint(parts[len(parts)-1], 10, 32)
+	return txRef{hash: hash, cursor: txindex.Cursor{Height: height, Index: uint32(index)}}
+}
+
 func keyForTag(tag cmn.KVPair, result *types.TxResult) []byte {
 	return []byte(fmt.Sprintf("%s/%s/%d/%d",
 		tag.Key,
@@ -456,13 +654,29 @@ func startKey(fields ...interface{}) []byte {
 ///////////////////////////////////////////////////////////////////////////////
 // Utils
 
-func intersect(as, bs [][]byte) [][]byte {
-	i := make([][]byte, 0, cmn.MinInt(len(as), len(bs)))
+// uniqueRefs removes the duplicates, e.g. the txs with several values of a tag
+// found by CONTAINS.
+func uniqueRefs(refs []txRef) []txRef {
+	seen := make(map[string]bool, len(refs))
+	unique := refs[:0]
+	for _, ref := range refs {
+		if !seen[string(ref.hash)] {
+			seen[string(ref.hash)] = true
+			unique = append(unique, ref)
+		}
+	}
+	return unique
+}
+
+func intersect(as, bs []txRef) []txRef {
+	inBs := make(map[string]bool, len(bs))
+	for _, b := range bs {
+		inBs[string(b.hash)] = true
+	}
+	i := make([]txRef, 0, cmn.MinInt(len(as), len(bs)))
 	for _, a := range as {
-		for _, b := range bs {
-			if bytes.Equal(a, b) {
-				i = append(i, a)
-			}
+		if inBs[string(a.hash)] {
+			i = append(i, a)
 		}
 	}
 	return i
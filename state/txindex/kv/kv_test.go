@@ -93,16 +93,34 @@ func TestTxSearch(t *testing.T) {
 		{"account.owner CONTAINS 'Vlad'", 0},
 		// search using the wrong tag (of numeric type) using CONTAINS
 		{"account.number CONTAINS 'Iv'", 0},
+		// search using EXISTS
+		{"account.owner EXISTS", 1},
+		// search for not existing tag using EXISTS
+		{"account.date EXISTS", 0},
+		// search using OR
+		{"account.owner = 'Vlad' OR account.number = 1", 1},
+		// search for non existing values using OR
+		{"account.owner = 'Vlad' OR account.number = 2", 0},
+		// search using NOT
+		{"account.number = 1 AND NOT account.owner = 'Vlad'", 1},
+		{"NOT account.owner = 'Ivan'", 0},
+		// search using NOT on a not allowed tag
+		{"NOT not_allowed = 'Vlad'", 1},
+		// search using parentheses
+		{"account.number >= 1 AND (account.owner = 'Vlad' OR account.date EXISTS)", 0},
+		{"(account.number >= 1 AND account.owner = 'Ivan') OR account.date EXISTS", 1},
+		// search by hash, filtered with the rest of the query
+		{fmt.Sprintf("tx.hash = '%X' AND NOT account.number = 1", hash), 0},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.q, func(t *testing.T) {
-			results, err := indexer.Search(query.MustParse(tc.q))
+			results, err := indexer.Search(query.MustParse(tc.q), txindex.SearchOptions{})
 			assert.NoError(t, err)
 
-			assert.Len(t, results, tc.resultsLength)
+			assert.Len(t, results.Txs, tc.resultsLength)
 			if tc.resultsLength > 0 {
-				assert.Equal(t, []*types.TxResult{txResult}, results)
+				assert.Equal(t, []*types.TxResult{txResult}, results.Txs)
 			}
 		})
 	}
@@ -120,11 +138,11 @@ func TestTxSearchOneTxWithMultipleSameTagsButDifferentValues(t *testing.T) {
 	err := indexer.Index(txResult)
 	require.NoError(t, err)
 
-	results, err := indexer.Search(query.MustParse("account.number >= 1"))
+	results, err := indexer.Search(query.MustParse("account.number >= 1"), txindex.SearchOptions{})
 	assert.NoError(t, err)
 
-	assert.Len(t, results, 1)
-	assert.Equal(t, []*types.TxResult{txResult}, results)
+	assert.Len(t, results.Txs, 1)
+	assert.Equal(t, []*types.TxResult{txResult}, results.Txs)
 }
 
 func TestTxSearchMultipleTxs(t *testing.T) {
@@ -173,11 +191,83 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	err = indexer.Index(txResult4)
 	require.NoError(t, err)
 
-	results, err := indexer.Search(query.MustParse("account.number >= 1"))
+	results, err := indexer.Search(query.MustParse("account.number >= 1"), txindex.SearchOptions{})
 	assert.NoError(t, err)
 
-	require.Len(t, results, 3)
-	assert.Equal(t, []*types.TxResult{txResult3, txResult2, txResult}, results)
+	require.Len(t, results.Txs, 3)
+	assert.Equal(t, []*types.TxResult{txResult3, txResult2, txResult}, results.Txs)
+}
+
+func TestTxSearchPages(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB(), IndexAllTags())
+
+	// 3 txs at each of the heights 1 and 2, indexed in no particular order
+	var txResults []*types.TxResult
+	for _, pos := range [][2]int64{{2, 1}, {1, 0}, {2, 2}, {1, 2}, {2, 0}, {1, 1}} {
+		txResult := txResultWithTags([]cmn.KVPair{
+			{Key: []byte("account.number"), Value: []byte(fmt.Sprintf("%d", pos[0]*10+pos[1]))},
+			{Key: []byte("account.owner"), Value: []byte("Ivan")},
+		})
+		txResult.Tx = types.Tx(fmt.Sprintf("tx %d/%d", pos[0], pos[1]))
+		txResult.Height = pos[0]
+		txResult.Index = uint32(pos[1])
+		require.NoError(t, indexer.Index(txResult))
+		txResults = append(txResults, txResult)
+	}
+	asc := []*types.TxResult{txResults[1], txResults[5], txResults[3], txResults[4], txResults[0], txResults[2]}
+	desc := []*types.TxResult{txResults[2], txResults[0], txResults[4], txResults[3], txResults[5], txResults[1]}
+
+	testCases := []struct {
+		q     string
+		opts  txindex.SearchOptions
+		txs   []*types.TxResult
+		total int
+		next  *txindex.Cursor
+	}{
+		{"account.owner = 'Ivan'", txindex.SearchOptions{Count: true}, asc, 6, nil},
+		{"account.owner = 'Ivan'", txindex.SearchOptions{OrderDesc: true}, desc, -1, nil},
+		{"account.owner = 'Ivan'", txindex.SearchOptions{Limit: 2, Count: true}, asc[:2], 6, &txindex.Cursor{Height: 1, Index: 1}},
+		{"account.owner = 'Ivan'", txindex.SearchOptions{Offset: 2, Limit: 2}, asc[2:4], -1, &txindex.Cursor{Height: 2, Index: 0}},
+		{"account.owner = 'Ivan'", txindex.SearchOptions{Offset: 4, Limit: 2}, asc[4:], -1, nil},
+		{"account.owner = 'Ivan'", txindex.SearchOptions{Offset: 8, Limit: 2, Count: true}, []*types.TxResult{}, 6, nil},
+		{"account.owner = 'Ivan'", txindex.SearchOptions{After: &txindex.Cursor{Height: 1, Index: 1}, Limit: 3}, asc[2:5], -1, &txindex.Cursor{Height: 2, Index: 1}},
+		{"account.owner = 'Ivan'", txindex.SearchOptions{After: &txindex.Cursor{Height: 1, Index: 1}, OrderDesc: true}, desc[5:], -1, nil},
+		// a cursor of a tx which isn't a result
+		{"account.number > 11", txindex.SearchOptions{After: &txindex.Cursor{Height: 1, Index: 1}, Limit: 1}, asc[2:3], -1, &txindex.Cursor{Height: 1, Index: 2}},
+		// the results filtered with the query
+		{"account.owner = 'Ivan' AND NOT account.number = 12", txindex.SearchOptions{Limit: 2, Count: true}, asc[:2], 5, &txindex.Cursor{Height: 1, Index: 1}},
+		{"account.owner = 'Ivan' AND NOT account.number = 12", txindex.SearchOptions{Offset: 2, Limit: 2}, asc[3:5], -1, &txindex.Cursor{Height: 2, Index: 1}},
+		{"account.number = 10 OR account.number = 22", txindex.SearchOptions{OrderDesc: true, Count: true}, []*types.TxResult{desc[0], desc[5]}, 2, nil},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(fmt.Sprintf("%s %+v", tc.q, tc.opts), func(t *testing.T) {
+			result, err := indexer.Search(query.MustParse(tc.q), tc.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.txs, result.Txs)
+			assert.Equal(t, tc.total, result.TotalCount)
+			assert.Equal(t, tc.next, result.Next)
+		})
+	}
+
+	// the searches looking up more txs than the maximum fail
+	indexer.maxSearchResults = 5
+	_, err := indexer.Search(query.MustParse("account.owner = 'Ivan'"), txindex.SearchOptions{Limit: 1})
+	assert.Error(t, err)
+	_, err = indexer.Search(query.MustParse("account.number > 11"), txindex.SearchOptions{Limit: 1})
+	assert.NoError(t, err)
+
+	// the searches scanning more txs than the maximum fail
+	indexer.maxSearchResults = 0
+	indexer.maxScannedTxs = 6
+	_, err = indexer.Search(query.MustParse("account.number = 10 OR account.number = 22"), txindex.SearchOptions{Limit: 1})
+	assert.NoError(t, err)
+	indexer.maxScannedTxs = 5
+	_, err = indexer.Search(query.MustParse("account.number = 10 OR account.number = 22"), txindex.SearchOptions{Limit: 1})
+	assert.Error(t, err)
+	_, err = indexer.Search(query.MustParse("account.owner = 'Ivan' AND NOT account.number = 12"), txindex.SearchOptions{Limit: 1})
+	assert.NoError(t, err)
 }
 
 func TestIndexAllTags(t *testing.T) {
@@ -191,15 +281,54 @@ func TestIndexAllTags(t *testing.T) {
 	err := indexer.Index(txResult)
 	require.NoError(t, err)
 
-	results, err := indexer.Search(query.MustParse("account.number >= 1"))
+	results, err := indexer.Search(query.MustParse("account.number >= 1"), txindex.SearchOptions{})
 	assert.NoError(t, err)
-	assert.Len(t, results, 1)
-	assert.Equal(t, []*types.TxResult{txResult}, results)
+	assert.Len(t, results.Txs, 1)
+	assert.Equal(t, []*types.TxResult{txResult}, results.Txs)
 
-	results, err = indexer.Search(query.MustParse("account.owner = 'Ivan'"))
+	results, err = indexer.Search(query.MustParse("account.owner = 'Ivan'"), txindex.SearchOptions{})
 	assert.NoError(t, err)
-	assert.Len(t, results, 1)
-	assert.Equal(t, []*types.TxResult{txResult}, results)
+	assert.Len(t, results.Txs, 1)
+	assert.Equal(t, []*types.TxResult{txResult}, results.Txs)
+}
+
+func TestIndexSelectedTags(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB(), IndexTags([]string{"account.*", "tx.height"}),
+		ExcludeTags([]string{"account.number", "transfer.*"}))
+
+	txResult := txResultWithTags([]cmn.KVPair{
+		{Key: []byte("account.owner"), Value: []byte("Ivan")},
+		{Key: []byte("account.number"), Value: []byte("1")},
+		{Key: []byte("app.creator"), Value: []byte("Cosmoshi"), Index: true},
+		{Key: []byte("app.key"), Value: []byte("foo")},
+		{Key: []byte("transfer.sender"), Value: []byte("Ivan"), Index: true},
+	})
+	err := indexer.Index(txResult)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		q             string
+		resultsLength int
+	}{
+		// selected by the config
+		{"account.owner = 'Ivan'", 1},
+		{"tx.height = 1", 1},
+		// marked by the app
+		{"app.creator = 'Cosmoshi'", 1},
+		// not selected
+		{"app.key = 'foo'", 0},
+		// excluded, even if marked by the app
+		{"account.number = 1", 0},
+		{"transfer.sender = 'Ivan'", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.q, func(t *testing.T) {
+			results, err := indexer.Search(query.MustParse(tc.q), txindex.SearchOptions{})
+			require.NoError(t, err)
+			assert.Len(t, results.Txs, tc.resultsLength)
+		})
+	}
 }
 
 func txResultWithTags(tags []cmn.KVPair) *types.TxResult {
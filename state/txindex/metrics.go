@@ -0,0 +1,73 @@
+package txindex
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "indexer"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Height of the last block indexed, by sink.
+	IndexedHeight metrics.Gauge
+	// Number of blocks queued for the sink and not indexed yet, by sink.
+	SinkLag metrics.Gauge
+	// Number of attempts to index a block which failed, by sink.
+	SinkFailures metrics.Counter
+	// Number of blocks not indexed, as the queue of the sink was full or
+	// indexing them kept failing, by sink.
+	SinkDroppedBlocks metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		IndexedHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "indexed_height",
+			Help:      "Height of the last block indexed, by sink.",
+		}, append(labels, "sink")).With(labelsAndValues...),
+		SinkLag: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sink_lag",
+			Help:      "Number of blocks queued for the sink and not indexed yet, by sink.",
+		}, append(labels, "sink")).With(labelsAndValues...),
+		SinkFailures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sink_failures",
+			Help:      "Number of attempts to index a block which failed, by sink.",
+		}, append(labels, "sink")).With(labelsAndValues...),
+		SinkDroppedBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sink_dropped_blocks",
+			Help:      "Number of blocks not indexed by the sink, by sink.",
+		}, append(labels, "sink")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		IndexedHeight:     discard.NewGauge(),
+		SinkLag:           discard.NewGauge(),
+		SinkFailures:      discard.NewCounter(),
+		SinkDroppedBlocks: discard.NewCounter(),
+	}
+}
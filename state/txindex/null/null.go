@@ -28,6 +28,11 @@ func (txi *TxIndex) Index(result *types.TxResult) error {
 	return nil
 }
 
-func (txi *TxIndex) Search(q *query.Query) ([]*types.TxResult, error) {
-	return []*types.TxResult{}, nil
+// Search always returns no results.
+func (txi *TxIndex) Search(q *query.Query, opts txindex.SearchOptions) (*txindex.SearchResult, error) {
+	result := &txindex.SearchResult{Txs: []*types.TxResult{}, TotalCount: -1}
+	if opts.Count {
+		result.TotalCount = 0
+	}
+	return result, nil
 }
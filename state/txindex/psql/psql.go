@@ -0,0 +1,209 @@
+package psql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	// registers the "postgres" driver
+	_ "github.com/lib/pq"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+	"github.com/tendermint/tendermint/state/txindex"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	driverName = "postgres"
+
+	// The types of the events, i.e. what returned their tags.
+	eventTypeBeginBlock = "begin_block"
+	eventTypeEndBlock   = "end_block"
+	eventTypeTx         = "tx"
+)
+
+var (
+	_ txindex.TxIndexer    = (*TxIndex)(nil)
+	_ txindex.BlockIndexer = (*TxIndex)(nil)
+)
+
+// ErrSearchNotSupported is returned by Get and Search: the txs indexed in
+// PostgreSQL are queried in the database directly.
+var ErrSearchNotSupported = errors.New("The psql indexer can't be searched, query the database instead")
+
+// TxIndex writes the blocks, the txs and their tags to the tables of a
+// PostgreSQL database (see schema.sql), for external services (e.g. block
+// explorers) to query them with SQL.
+type TxIndex struct {
+	db      *sql.DB
+	chainID string
+
+	tagsToExclude []string
+}
+
+// NewTxIndex returns an indexer writing to the PostgreSQL database of the
+// connection string (see https://godoc.org/github.com/lib/pq), whose tables
+// must have been created with schema.sql. The rows of the blocks have the
+// chain ID.
+func NewTxIndex(connStr, chainID string, options ...func(*TxIndex)) (*TxIndex, error) {
+	db, err := sql.Open(driverName, connStr)
+	if err != nil {
+		return nil, err
+	}
+	txi := &TxIndex{db: db, chainID: chainID}
+	for _, o := range options {
+		o(txi)
+	}
+	return txi, nil
+}
+
+// ExcludeTags is an option for setting which tags not to write (see
+// txindex.TagMatches for the ones ending with ".*"). All the other tags are.
+func ExcludeTags(tags []string) func(*TxIndex) {
+	return func(txi *TxIndex) {
+		txi.tagsToExclude = tags
+	}
+}
+
+// Close closes the connections to the database.
+func (txi *TxIndex) Close() error {
+	return txi.db.Close()
+}
+
+// IndexBlock writes the block and the tags of its BeginBlock and EndBlock
+// responses.
+func (txi *TxIndex) IndexBlock(h types.EventDataNewBlockHeader) error {
+	return txi.runInTransaction(func(dbtx *sql.Tx) error {
+		blockID, err := txi.insertBlock(dbtx, h.Header.Height)
+		if err != nil {
+			return err
+		}
+		if err := insertEvent(dbtx, blockID, nil, eventTypeBeginBlock, txi.indexedTags(h.ResultBeginBlock.Tags)); err != nil {
+			return err
+		}
+		return insertEvent(dbtx, blockID, nil, eventTypeEndBlock, txi.indexedTags(h.ResultEndBlock.Tags))
+	})
+}
+
+// AddBatch writes the txs of the batch and their tags, in a single database
+// transaction. The txs which were already indexed are skipped.
+func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
+	return txi.runInTransaction(func(dbtx *sql.Tx) error {
+		for _, result := range b.Ops {
+			if err := txi.insertTx(dbtx, result); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Index writes the tx and its tags, unless it was already indexed.
+func (txi *TxIndex) Index(result *types.TxResult) error {
+	return txi.runInTransaction(func(dbtx *sql.Tx) error {
+		return txi.insertTx(dbtx, result)
+	})
+}
+
+// Get isn't supported, it returns ErrSearchNotSupported.
+func (txi *TxIndex) Get(hash []byte) (*types.TxResult, error) {
+	return nil, ErrSearchNotSupported
+}
+
+// Search isn't supported, it returns ErrSearchNotSupported.
+func (txi *TxIndex) Search(q *query.Query, opts txindex.SearchOptions) (*txindex.SearchResult, error) {
+	return nil, ErrSearchNotSupported
+}
+
+func (txi *TxIndex) runInTransaction(fn func(*sql.Tx) error) error {
+	dbtx, err := txi.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(dbtx); err != nil {
+		dbtx.Rollback() // nolint: errcheck
+		return err
+	}
+	return dbtx.Commit()
+}
+
+// insertBlock writes the block at the height, if it wasn't yet, and returns
+// its row ID.
+func (txi *TxIndex) insertBlock(dbtx *sql.Tx, height int64) (int64, error) {
+	var blockID int64
+	// the no-op update returns the row ID of an existing block
+	err := dbtx.QueryRow(`
+INSERT INTO blocks (height, chain_id, created_at) VALUES ($1, $2, $3)
+  ON CONFLICT (height, chain_id) DO UPDATE SET height = EXCLUDED.height
+  RETURNING rowid;`,
+		height, txi.chainID, time.Now().UTC()).Scan(&blockID)
+	if err != nil {
+		return 0, fmt.Errorf("Error indexing block %d: %v", height, err)
+	}
+	return blockID, nil
+}
+
+func (txi *TxIndex) insertTx(dbtx *sql.Tx, result *types.TxResult) error {
+	blockID, err := txi.insertBlock(dbtx, result.Height)
+	if err != nil {
+		return err
+	}
+	bz, err := cdc.MarshalBinaryBare(result)
+	if err != nil {
+		return err
+	}
+
+	var txID int64
+	err = dbtx.QueryRow(`
+INSERT INTO tx_results (block_id, index, created_at, tx_hash, tx_result) VALUES ($1, $2, $3, $4, $5)
+  ON CONFLICT DO NOTHING
+  RETURNING rowid;`,
+		blockID, result.Index, time.Now().UTC(), fmt.Sprintf("%X", result.Tx.Hash()), bz).Scan(&txID)
+	if err == sql.ErrNoRows {
+		// already indexed
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error indexing tx %d of block %d: %v", result.Index, result.Height, err)
+	}
+	return insertEvent(dbtx, blockID, &txID, eventTypeTx, txi.indexedTags(result.Result.Tags))
+}
+
+// indexedTags returns the tags which aren't excluded.
+func (txi *TxIndex) indexedTags(tags []cmn.KVPair) []cmn.KVPair {
+	if len(txi.tagsToExclude) == 0 {
+		return tags
+	}
+	indexed := make([]cmn.KVPair, 0, len(tags))
+	for _, tag := range tags {
+		if !txindex.TagMatches(string(tag.Key), txi.tagsToExclude) {
+			indexed = append(indexed, tag)
+		}
+	}
+	return indexed
+}
+
+// insertEvent writes the event of the block (or of the tx, if txID is set)
+// and its tags as attributes, if there are any.
+func insertEvent(dbtx *sql.Tx, blockID int64, txID *int64, typ string, tags []cmn.KVPair) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	var eventID int64
+	err := dbtx.QueryRow(`INSERT INTO events (block_id, tx_id, type) VALUES ($1, $2, $3) RETURNING rowid;`,
+		blockID, txID, typ).Scan(&eventID)
+	if err != nil {
+		return fmt.Errorf("Error indexing %s event: %v", typ, err)
+	}
+	for _, tag := range tags {
+		_, err := dbtx.Exec(`INSERT INTO attributes (event_id, key, value) VALUES ($1, $2, $3);`,
+			eventID, string(tag.Key), string(tag.Value))
+		if err != nil {
+			return fmt.Errorf("Error indexing %s tag %s: %v", typ, tag.Key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+  The tables the psql indexer writes to (see tx_index.indexer = "psql").
+  Create them in the database before starting the node:
+
+    psql -d <database> -f schema.sql
+*/
+
+-- The blocks, with their height and the chain ID (a database can be shared by
+-- the nodes of several chains). created_at is the time the block was indexed.
+CREATE TABLE blocks (
+  rowid      BIGSERIAL PRIMARY KEY,
+
+  height     BIGINT NOT NULL,
+  chain_id   VARCHAR NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+
+  UNIQUE (height, chain_id)
+);
+
+-- The txs of the blocks, with their hex (uppercase) hash and their amino
+-- encoded types.TxResult.
+CREATE TABLE tx_results (
+  rowid      BIGSERIAL PRIMARY KEY,
+
+  block_id   BIGINT NOT NULL REFERENCES blocks(rowid),
+  index      INTEGER NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  tx_hash    VARCHAR NOT NULL,
+  tx_result  BYTEA NOT NULL,
+
+  UNIQUE (block_id, index)
+);
+
+CREATE INDEX idx_tx_results_tx_hash ON tx_results(tx_hash);
+
+-- The events of the blocks (begin_block and end_block) and of the txs (tx),
+-- whose attributes are the tags the app returned.
+CREATE TABLE events (
+  rowid    BIGSERIAL PRIMARY KEY,
+
+  block_id BIGINT NOT NULL REFERENCES blocks(rowid),
+  tx_id    BIGINT NULL REFERENCES tx_results(rowid),
+  type     VARCHAR NOT NULL
+);
+
+CREATE TABLE attributes (
+  event_id BIGINT NOT NULL REFERENCES events(rowid),
+  key      VARCHAR NOT NULL,
+  value    VARCHAR NULL
+);
+
+CREATE INDEX idx_attributes_key_value ON attributes(key, value);
+
+-- The tags of the blocks, by height.
+CREATE VIEW block_events AS
+  SELECT blocks.rowid as block_id, height, chain_id, type, key, value
+  FROM blocks JOIN events ON (blocks.rowid = events.block_id)
+  JOIN attributes ON (events.rowid = attributes.event_id)
+  WHERE events.tx_id IS NULL;
+
+-- The tags of the txs, by height and hash.
+CREATE VIEW tx_events AS
+  SELECT height, index, chain_id, type, key, value, tx_results.created_at, tx_hash
+  FROM blocks JOIN tx_results ON (blocks.rowid = tx_results.block_id)
+  JOIN events ON (tx_results.rowid = events.tx_id)
+  JOIN attributes ON (events.rowid = attributes.event_id);
@@ -0,0 +1,7 @@
+package psql
+
+import (
+	amino "github.com/tendermint/go-amino"
+)
+
+var cdc = amino.NewCodec()
@@ -114,14 +114,6 @@ func validateBlock(evidencePool EvidencePool, stateDB dbm.DB, state State, block
 				state.LastBlockTime,
 			)
 		}
-
-		medianTime := MedianTime(block.LastCommit, state.LastValidators)
-		if !block.Time.Equal(medianTime) {
-			return fmt.Errorf("Invalid block time. Expected %v, got %v",
-				medianTime,
-				block.Time,
-			)
-		}
 	} else if block.Height == 1 {
 		genesisTime := state.LastBlockTime
 		if !block.Time.Equal(genesisTime) {
@@ -132,17 +124,20 @@ func validateBlock(evidencePool EvidencePool, stateDB dbm.DB, state State, block
 		}
 	}
 
-	// Limit the amount of evidence
-	maxNumEvidence, _ := types.MaxEvidencePerBlock(state.ConsensusParams.Block.MaxBytes)
-	numEvidence := int64(len(block.Evidence.Evidence))
-	if numEvidence > maxNumEvidence {
-		return types.NewErrEvidenceOverflow(maxNumEvidence, numEvidence)
-
+	// Limit the size of evidence
+	maxEvidenceBytes := state.ConsensusParams.Evidence.MaxBytes
+	evidenceBytes := block.Evidence.Evidence.ByteSize()
+	if evidenceBytes > maxEvidenceBytes {
+		return types.NewErrEvidenceOverflow(maxEvidenceBytes, evidenceBytes)
 	}
 
 	// Validate all evidence.
 	for _, ev := range block.Evidence.Evidence {
-		if err := VerifyEvidence(stateDB, state, ev); err != nil {
+		var committedHeader *types.Header
+		if cev, ok := ev.(types.CompositeEvidence); ok && evidencePool != nil {
+			committedHeader = evidencePool.Header(cev.ConflictingHeight())
+		}
+		if err := VerifyEvidence(stateDB, state, ev, committedHeader); err != nil {
 			return types.NewErrEvidenceInvalid(ev, err)
 		}
 		if evidencePool != nil && evidencePool.IsCommitted(ev) {
@@ -164,18 +159,20 @@ func validateBlock(evidencePool EvidencePool, stateDB dbm.DB, state State, block
 }
 
 // VerifyEvidence verifies the evidence fully by checking:
-// - it is sufficiently recent (MaxAge)
+// - it is sufficiently recent (MaxAge and MaxAgeDuration)
 // - it is from a key who was a validator at the given height
 // - it is internally consistent
 // - it was properly signed by the alleged equivocator
-func VerifyEvidence(stateDB dbm.DB, state State, evidence types.Evidence) error {
-	height := state.LastBlockHeight
-
-	evidenceAge := height - evidence.Height()
-	maxAge := state.ConsensusParams.Evidence.MaxAge
-	if evidenceAge > maxAge {
-		return fmt.Errorf("Evidence from height %d is too old. Min height is %d",
-			evidence.Height(), height-maxAge)
+//
+// Composite evidence is instead verified against the validators at the given
+// height and committedHeader, the header we committed at the height it
+// conflicts with, which is nil for other evidence.
+func VerifyEvidence(stateDB dbm.DB, state State, evidence types.Evidence, committedHeader *types.Header) error {
+	if IsEvidenceExpired(state, evidence) {
+		params := state.ConsensusParams.Evidence
+		return fmt.Errorf("Evidence from height %d (%v) is too old. Min height is %d, min time is %v",
+			evidence.Height(), evidence.Time(), state.LastBlockHeight-params.MaxAge,
+			state.LastBlockTime.Add(-params.MaxAgeDuration))
 	}
 
 	valset, err := LoadValidators(stateDB, evidence.Height())
@@ -185,6 +182,13 @@ func VerifyEvidence(stateDB dbm.DB, state State, evidence types.Evidence) error
 		return err
 	}
 
+	if cev, ok := evidence.(types.CompositeEvidence); ok {
+		if committedHeader == nil {
+			return fmt.Errorf("Don't have the header committed at the height %v conflicts with", cev)
+		}
+		return cev.VerifyComposite(committedHeader, valset)
+	}
+
 	// The address must have been an active validator at the height.
 	// NOTE: we will ignore evidence from H if the key was not a validator
 	// at H, even if it is a validator at some nearby H'
@@ -203,3 +207,13 @@ func VerifyEvidence(stateDB dbm.DB, state State, evidence types.Evidence) error
 
 	return nil
 }
+
+// IsEvidenceExpired returns true if the evidence is older than both
+// ConsensusParams.Evidence.MaxAge blocks and MaxAgeDuration, relative to the
+// last block of the state.
+func IsEvidenceExpired(state State, evidence types.Evidence) bool {
+	params := state.ConsensusParams.Evidence
+	ageNumBlocks := state.LastBlockHeight - evidence.Height()
+	ageDuration := state.LastBlockTime.Sub(evidence.Time())
+	return ageNumBlocks > params.MaxAge && ageDuration > params.MaxAgeDuration
+}
@@ -107,27 +107,30 @@ func TestValidateBlockEvidence(t *testing.T) {
 	err := blockExec.ValidateBlock(state, block)
 	require.NoError(t, err)
 
-	// A block with too much evidence fails.
-	maxBlockSize := state.ConsensusParams.Block.MaxBytes
-	maxNumEvidence, _ := types.MaxEvidencePerBlock(maxBlockSize)
-	require.True(t, maxNumEvidence > 2)
-	for i := int64(0); i < maxNumEvidence; i++ {
-		block.Evidence.Evidence = append(block.Evidence.Evidence, goodEvidence)
-	}
+	// A block with just enough evidence passes, one more evidence fails.
+	evBytes := types.EvidenceByteSize(goodEvidence)
+	state.ConsensusParams.Evidence.MaxBytes = 3 * evBytes
+	block.Evidence.Evidence = append(block.Evidence.Evidence, goodEvidence)
+	block.EvidenceHash = block.Evidence.Hash()
+	require.NoError(t, blockExec.ValidateBlock(state, block))
+
+	block.Evidence.Evidence = append(block.Evidence.Evidence, goodEvidence)
 	block.EvidenceHash = block.Evidence.Hash()
 	err = blockExec.ValidateBlock(state, block)
 	require.Error(t, err)
-	_, ok := err.(*types.ErrEvidenceOverflow)
+	overflow, ok := err.(*types.ErrEvidenceOverflow)
 	require.True(t, ok)
+	require.EqualValues(t, 4*evBytes, overflow.GotBytes)
 }
 
 // always returns true if asked if any evidence was already committed.
 type mockEvPoolAlwaysCommitted struct{}
 
-func (m mockEvPoolAlwaysCommitted) PendingEvidence(int64) []types.Evidence { return nil }
-func (m mockEvPoolAlwaysCommitted) AddEvidence(types.Evidence) error       { return nil }
-func (m mockEvPoolAlwaysCommitted) Update(*types.Block, State)             {}
-func (m mockEvPoolAlwaysCommitted) IsCommitted(types.Evidence) bool        { return true }
+func (m mockEvPoolAlwaysCommitted) ReapMaxBytes(int64) []types.Evidence { return nil }
+func (m mockEvPoolAlwaysCommitted) AddEvidence(types.Evidence) error    { return nil }
+func (m mockEvPoolAlwaysCommitted) Update(*types.Block, State)          {}
+func (m mockEvPoolAlwaysCommitted) IsCommitted(types.Evidence) bool     { return true }
+func (m mockEvPoolAlwaysCommitted) Header(int64) *types.Header          { return nil }
 
 func TestValidateFailBlockOnCommittedEvidence(t *testing.T) {
 	var height int64 = 1
@@ -146,6 +149,21 @@ func TestValidateFailBlockOnCommittedEvidence(t *testing.T) {
 	require.IsType(t, err, &types.ErrEvidenceInvalid{})
 }
 
+func TestIsEvidenceExpired(t *testing.T) {
+	ev := types.NewMockGoodEvidence(10, 0, []byte("val"))
+	state := State{LastBlockHeight: 20, LastBlockTime: ev.Time().Add(2 * time.Hour)}
+	state.ConsensusParams.Evidence = types.EvidenceParams{MaxAge: 5, MaxAgeDuration: time.Hour}
+	require.True(t, IsEvidenceExpired(state, ev))
+
+	// recent enough in blocks
+	state.ConsensusParams.Evidence.MaxAge = 10
+	require.False(t, IsEvidenceExpired(state, ev))
+
+	// recent enough in time
+	state.ConsensusParams.Evidence = types.EvidenceParams{MaxAge: 5, MaxAgeDuration: 3 * time.Hour}
+	require.False(t, IsEvidenceExpired(state, ev))
+}
+
 /*
 	TODO(#2589):
 	- test unmarshalling BlockParts that are too big into a Block that
@@ -154,3 +172,35 @@ func TestValidateFailBlockOnCommittedEvidence(t *testing.T) {
 */
 func TestValidateBlockSize(t *testing.T) {
 }
+
+type gasMempool struct {
+	MockMempool
+	gasWanted int64
+}
+
+func (mem gasMempool) GasWanted(types.Txs) (int64, int) { return mem.gasWanted, 0 }
+
+func TestValidateProposalGas(t *testing.T) {
+	state, stateDB := state(1, 1)
+	block := makeBlock(state, 1)
+
+	testCases := []struct {
+		maxGas    int64
+		gasWanted int64
+		valid     bool
+	}{
+		{-1, 100, true},
+		{10, 10, true},
+		{10, 11, false},
+	}
+	for i, tc := range testCases {
+		state.ConsensusParams.Block.MaxGas = tc.maxGas
+		blockExec := NewBlockExecutor(stateDB, log.TestingLogger(), nil, gasMempool{gasWanted: tc.gasWanted}, nil)
+		err := blockExec.ValidateProposalGas(state, block)
+		if tc.valid {
+			require.NoError(t, err, "#%d", i)
+		} else {
+			require.Equal(t, ErrBlockMaxGasExceeded{GasWanted: tc.gasWanted, MaxGas: tc.maxGas}, err, "#%d", i)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package statesync
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+// errDone is returned by chunkQueue.Allocate and Next once there are no more
+// chunks to allocate or return.
+var errDone = errors.New("chunk queue has completed")
+
+// chunk is a chunk of a snapshot, sent by a peer.
+type chunk struct {
+	Height int64
+	Format uint32
+	Index  uint32
+	Chunk  []byte
+	Sender p2p.ID
+}
+
+// chunkQueue keeps the fetched chunks of a snapshot in files in a temporary
+// directory, so they don't have to be kept in memory, and returns them in
+// order to be applied. Chunks are allocated to fetchers, so every chunk is
+// fetched once, unless it's discarded or retried.
+type chunkQueue struct {
+	mtx         sync.Mutex
+	snapshot    *snapshot
+	dir         string
+	chunkFiles  map[uint32]string
+	chunkSender map[uint32]p2p.ID
+	allocated   map[uint32]bool
+	returned    map[uint32]bool
+	waiters     map[uint32][]chan<- uint32
+}
+
+// newChunkQueue creates a queue for the chunks of the snapshot, in a new
+// directory in tempDir, or in the system's temp directory if it's empty.
+func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
+	if snapshot.Chunks == 0 {
+		return nil, errors.New("snapshot has no chunks")
+	}
+	dir, err := ioutil.TempDir(tempDir, "tm-statesync")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create temp dir for state sync chunks: %v", err)
+	}
+	return &chunkQueue{
+		snapshot:    snapshot,
+		dir:         dir,
+		chunkFiles:  make(map[uint32]string, snapshot.Chunks),
+		chunkSender: make(map[uint32]p2p.ID, snapshot.Chunks),
+		allocated:   make(map[uint32]bool, snapshot.Chunks),
+		returned:    make(map[uint32]bool, snapshot.Chunks),
+		waiters:     make(map[uint32][]chan<- uint32),
+	}, nil
+}
+
+// Add adds a chunk to the queue, returning true if it's new. Chunks of other
+// snapshots are an error, chunks which were already added are ignored.
+func (q *chunkQueue) Add(chunk *chunk) (bool, error) {
+	if chunk == nil || chunk.Chunk == nil {
+		return false, errors.New("cannot add nil chunk")
+	}
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if q.snapshot == nil {
+		return false, nil // queue is closed
+	}
+	if chunk.Height != q.snapshot.Height {
+		return false, fmt.Errorf("invalid chunk height %v, expected %v", chunk.Height, q.snapshot.Height)
+	}
+	if chunk.Format != q.snapshot.Format {
+		return false, fmt.Errorf("invalid chunk format %v, expected %v", chunk.Format, q.snapshot.Format)
+	}
+	if chunk.Index >= q.snapshot.Chunks {
+		return false, fmt.Errorf("received unexpected chunk %v", chunk.Index)
+	}
+	if q.chunkFiles[chunk.Index] != "" {
+		return false, nil
+	}
+
+	path := filepath.Join(q.dir, strconv.FormatUint(uint64(chunk.Index), 10))
+	if err := ioutil.WriteFile(path, chunk.Chunk, 0600); err != nil {
+		return false, fmt.Errorf("failed to save chunk %v to file %v: %v", chunk.Index, path, err)
+	}
+	q.chunkFiles[chunk.Index] = path
+	q.chunkSender[chunk.Index] = chunk.Sender
+
+	// Signal any waiters that the chunk has arrived.
+	for _, waiter := range q.waiters[chunk.Index] {
+		waiter <- chunk.Index
+		close(waiter)
+	}
+	delete(q.waiters, chunk.Index)
+	return true, nil
+}
+
+// Allocate allocates the next chunk to fetch to the caller. It returns
+// errDone once all chunks were allocated.
+func (q *chunkQueue) Allocate() (uint32, error) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if q.snapshot == nil {
+		return 0, errDone
+	}
+	if uint32(len(q.allocated)) >= q.snapshot.Chunks {
+		return 0, errDone
+	}
+	for i := uint32(0); i < q.snapshot.Chunks; i++ {
+		if !q.allocated[i] {
+			q.allocated[i] = true
+			return i, nil
+		}
+	}
+	return 0, errDone
+}
+
+// Close closes the queue, removing its files and waking up all waiters.
+func (q *chunkQueue) Close() error {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if q.snapshot == nil {
+		return nil
+	}
+	for _, waiters := range q.waiters {
+		for _, waiter := range waiters {
+			close(waiter)
+		}
+	}
+	q.waiters = nil
+	q.snapshot = nil
+	if err := os.RemoveAll(q.dir); err != nil {
+		return fmt.Errorf("failed to clean up state sync tempdir %v: %v", q.dir, err)
+	}
+	return nil
+}
+
+// Discard discards the chunk, so it's fetched and returned again.
+func (q *chunkQueue) Discard(index uint32) error {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return q.discard(index)
+}
+
+// discard discards the chunk. Caller must hold the lock.
+func (q *chunkQueue) discard(index uint32) error {
+	if q.snapshot == nil {
+		return nil
+	}
+	path := q.chunkFiles[index]
+	if path == "" {
+		return nil
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove chunk %v: %v", index, err)
+	}
+	delete(q.chunkFiles, index)
+	delete(q.chunkSender, index)
+	delete(q.allocated, index)
+	delete(q.returned, index)
+	return nil
+}
+
+// DiscardSender discards all the chunks sent by the peer which weren't
+// returned yet, as the ones applied can't be undone.
+func (q *chunkQueue) DiscardSender(peerID p2p.ID) error {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	for index, sender := range q.chunkSender {
+		if sender == peerID && !q.returned[index] {
+			if err := q.discard(index); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetSender returns the peer which sent the chunk, or an empty ID.
+func (q *chunkQueue) GetSender(index uint32) p2p.ID {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return q.chunkSender[index]
+}
+
+// Has returns true if the chunk is in the queue.
+func (q *chunkQueue) Has(index uint32) bool {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return q.chunkFiles[index] != ""
+}
+
+// load loads the chunk from its file. Caller must hold the lock.
+func (q *chunkQueue) load(index uint32) (*chunk, error) {
+	path := q.chunkFiles[index]
+	if path == "" {
+		return nil, nil
+	}
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chunk %v: %v", index, err)
+	}
+	return &chunk{
+		Height: q.snapshot.Height,
+		Format: q.snapshot.Format,
+		Index:  index,
+		Chunk:  body,
+		Sender: q.chunkSender[index],
+	}, nil
+}
+
+// Next returns the next chunk to apply, waiting up to timeout for it to be
+// fetched. It returns errDone once all chunks were returned or the queue was
+// closed, and errTimeout if the chunk didn't arrive in time.
+func (q *chunkQueue) Next(timeout time.Duration) (*chunk, error) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		var chunk *chunk
+		q.mtx.Lock()
+		index, err := q.nextUp()
+		if err == nil {
+			chunk, err = q.load(index)
+			if err == nil && chunk != nil {
+				q.returned[index] = true
+			}
+		}
+		q.mtx.Unlock()
+		if chunk != nil || err != nil {
+			return chunk, err
+		}
+
+		// The chunk may be discarded again before it's loaded, so check
+		// again once it arrived.
+		select {
+		case <-q.WaitFor(index):
+		case <-deadline.C:
+			return nil, errTimeout
+		}
+	}
+}
+
+// nextUp returns the index of the next chunk to return, or errDone if all
+// were returned. Caller must hold the lock.
+func (q *chunkQueue) nextUp() (uint32, error) {
+	if q.snapshot == nil {
+		return 0, errDone
+	}
+	for i := uint32(0); i < q.snapshot.Chunks; i++ {
+		if !q.returned[i] {
+			return i, nil
+		}
+	}
+	return 0, errDone
+}
+
+// Retry makes the chunk be returned again, e.g. if applying it failed.
+func (q *chunkQueue) Retry(index uint32) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	delete(q.returned, index)
+}
+
+// RetryAll makes all chunks be returned again.
+func (q *chunkQueue) RetryAll() {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.returned = make(map[uint32]bool)
+}
+
+// Unallocate makes the chunk be allocated again if it wasn't fetched, e.g.
+// if the request for it timed out.
+func (q *chunkQueue) Unallocate(index uint32) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if q.chunkFiles[index] == "" {
+		delete(q.allocated, index)
+	}
+}
+
+// Size returns the number of chunks of the snapshot.
+func (q *chunkQueue) Size() uint32 {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if q.snapshot == nil {
+		return 0
+	}
+	return q.snapshot.Chunks
+}
+
+// WaitFor returns a channel which receives the index of the chunk once it's
+// added, and is closed then or when the queue is closed.
+func (q *chunkQueue) WaitFor(index uint32) <-chan uint32 {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	ch := make(chan uint32, 1)
+	if q.snapshot == nil || index >= q.snapshot.Chunks {
+		close(ch)
+		return ch
+	}
+	if q.chunkFiles[index] != "" {
+		ch <- index
+		close(ch)
+		return ch
+	}
+	q.waiters[index] = append(q.waiters[index], ch)
+	return ch
+}
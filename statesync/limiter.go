@@ -0,0 +1,64 @@
+package statesync
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+// peerRateLimiter limits the chunk requests served to each peer with a token
+// bucket per peer, filled at rate tokens per second up to a burst of rate.
+type peerRateLimiter struct {
+	rate float64 // 0 for no limit
+
+	mtx   sync.Mutex
+	peers map[p2p.ID]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func newPeerRateLimiter(rate int) *peerRateLimiter {
+	return &peerRateLimiter{
+		rate:  float64(rate),
+		peers: make(map[p2p.ID]*tokenBucket),
+	}
+}
+
+// Allow returns whether a request of the peer can be served at now, taking a
+// token of its bucket if so.
+func (l *peerRateLimiter) Allow(peerID p2p.ID, now time.Time) bool {
+	if l.rate == 0 {
+		return true
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	bucket, ok := l.peers[peerID]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.rate, updated: now}
+		l.peers[peerID] = bucket
+	}
+	if elapsed := now.Sub(bucket.updated); elapsed > 0 {
+		bucket.tokens += elapsed.Seconds() * l.rate
+		if bucket.tokens > l.rate {
+			bucket.tokens = l.rate
+		}
+		bucket.updated = now
+	}
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// RemovePeer forgets the bucket of the peer.
+func (l *peerRateLimiter) RemovePeer(peerID p2p.ID) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	delete(l.peers, peerID)
+}
@@ -0,0 +1,43 @@
+package statesync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+func TestPeerRateLimiter(t *testing.T) {
+	l := newPeerRateLimiter(2)
+	now := time.Now()
+
+	// A peer can make rate requests at once
+	assert.True(t, l.Allow("a", now))
+	assert.True(t, l.Allow("a", now))
+	assert.False(t, l.Allow("a", now))
+
+	// Other peers have their own bucket
+	assert.True(t, l.Allow("b", now))
+
+	// The bucket fills up again over time, but never above rate
+	assert.True(t, l.Allow("a", now.Add(500*time.Millisecond)))
+	assert.False(t, l.Allow("a", now.Add(500*time.Millisecond)))
+	later := now.Add(time.Minute)
+	assert.True(t, l.Allow("a", later))
+	assert.True(t, l.Allow("a", later))
+	assert.False(t, l.Allow("a", later))
+
+	// A removed peer starts with a full bucket
+	l.RemovePeer("a")
+	assert.True(t, l.Allow("a", later))
+}
+
+func TestPeerRateLimiter_NoLimit(t *testing.T) {
+	l := newPeerRateLimiter(0)
+	now := time.Now()
+	for i := 0; i < 100; i++ {
+		assert.True(t, l.Allow(p2p.ID("a"), now))
+	}
+}
@@ -0,0 +1,134 @@
+package statesync
+
+import (
+	"errors"
+	"fmt"
+
+	amino "github.com/tendermint/go-amino"
+)
+
+const (
+	// SnapshotChannel exchanges the snapshots which peers have
+	SnapshotChannel = byte(0x60)
+	// ChunkChannel exchanges the chunks of the snapshots
+	ChunkChannel = byte(0x61)
+
+	// number of snapshots a peer is told about
+	recentSnapshots = 10
+
+	snapshotMsgSize = int(4e6)
+	chunkMsgSize    = int(16e6)
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	RegisterStateSyncMessages(cdc)
+}
+
+// StateSyncMessage is a message sent on the state sync channels.
+type StateSyncMessage interface {
+	ValidateBasic() error
+}
+
+func RegisterStateSyncMessages(cdc *amino.Codec) {
+	cdc.RegisterInterface((*StateSyncMessage)(nil), nil)
+	cdc.RegisterConcrete(&snapshotsRequestMessage{}, "tendermint/statesync/SnapshotsRequest", nil)
+	cdc.RegisterConcrete(&snapshotsResponseMessage{}, "tendermint/statesync/SnapshotsResponse", nil)
+	cdc.RegisterConcrete(&chunkRequestMessage{}, "tendermint/statesync/ChunkRequest", nil)
+	cdc.RegisterConcrete(&chunkResponseMessage{}, "tendermint/statesync/ChunkResponse", nil)
+}
+
+func decodeMsg(bz []byte) (msg StateSyncMessage, err error) {
+	if len(bz) > chunkMsgSize {
+		return msg, fmt.Errorf("Msg exceeds max size (%d > %d)", len(bz), chunkMsgSize)
+	}
+	err = cdc.UnmarshalBinaryBare(bz, &msg)
+	return
+}
+
+//-------------------------------------
+
+// snapshotsRequestMessage asks a peer for its recent snapshots.
+type snapshotsRequestMessage struct{}
+
+// ValidateBasic performs basic validation.
+func (m *snapshotsRequestMessage) ValidateBasic() error {
+	return nil
+}
+
+func (m *snapshotsRequestMessage) String() string {
+	return "[snapshotsRequestMessage]"
+}
+
+// snapshotsResponseMessage tells a peer about one snapshot, there is one
+// message per snapshot.
+type snapshotsResponseMessage struct {
+	Height   int64
+	Format   uint32
+	Chunks   uint32
+	Hash     []byte
+	Metadata []byte
+}
+
+// ValidateBasic performs basic validation.
+func (m *snapshotsResponseMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return errors.New("Non-positive Height")
+	}
+	if m.Chunks == 0 {
+		return errors.New("No Chunks")
+	}
+	if len(m.Hash) == 0 {
+		return errors.New("Empty Hash")
+	}
+	return nil
+}
+
+func (m *snapshotsResponseMessage) String() string {
+	return fmt.Sprintf("[snapshotsResponseMessage %v/%v %X]", m.Height, m.Format, m.Hash)
+}
+
+// chunkRequestMessage asks a peer for a chunk of a snapshot.
+type chunkRequestMessage struct {
+	Height int64
+	Format uint32
+	Index  uint32
+}
+
+// ValidateBasic performs basic validation.
+func (m *chunkRequestMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return errors.New("Non-positive Height")
+	}
+	return nil
+}
+
+func (m *chunkRequestMessage) String() string {
+	return fmt.Sprintf("[chunkRequestMessage %v/%v %v]", m.Height, m.Format, m.Index)
+}
+
+// chunkResponseMessage carries a chunk of a snapshot, or tells it's missing
+// if the peer doesn't have it.
+type chunkResponseMessage struct {
+	Height  int64
+	Format  uint32
+	Index   uint32
+	Chunk   []byte
+	Missing bool
+}
+
+// ValidateBasic performs basic validation.
+func (m *chunkResponseMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return errors.New("Non-positive Height")
+	}
+	if m.Missing && len(m.Chunk) > 0 {
+		return errors.New("Missing chunk has contents")
+	}
+	return nil
+}
+
+func (m *chunkResponseMessage) String() string {
+	return fmt.Sprintf("[chunkResponseMessage %v/%v %v missing=%v]", m.Height, m.Format, m.Index, m.Missing)
+}
@@ -0,0 +1,304 @@
+package statesync
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+)
+
+// name the reactor is added to the Switch with, reported with peer behaviour
+const reactorName = "STATESYNC"
+
+// Reactor handles state sync, both restoring the app state from the
+// snapshots of peers and serving the local app's snapshots to peers.
+type Reactor struct {
+	p2p.BaseReactor
+
+	config    *cfg.StateSyncConfig
+	conn      proxy.AppConnSnapshot
+	connQuery proxy.AppConnQuery
+
+	// Limit the chunk requests served at once, with a slot of servingSlots
+	// taken for each, and to each peer
+	servingSlots chan struct{}
+	peerLimiter  *peerRateLimiter
+
+	// This will only be set when a state sync is in progress. It is used to
+	// feed received snapshots and chunks into the sync.
+	mtx    sync.RWMutex
+	syncer *syncer
+}
+
+// NewReactor creates a new state sync reactor, restoring and serving the
+// snapshots of the app as configured by config.
+func NewReactor(config *cfg.StateSyncConfig, conn proxy.AppConnSnapshot,
+	connQuery proxy.AppConnQuery) *Reactor {
+
+	r := &Reactor{
+		config:       config,
+		conn:         conn,
+		connQuery:    connQuery,
+		servingSlots: make(chan struct{}, config.MaxConcurrentChunkRequests),
+		peerLimiter:  newPeerRateLimiter(config.PeerChunkRequestRate),
+	}
+	r.BaseReactor = *p2p.NewBaseReactor("StateSyncReactor", r)
+	return r
+}
+
+// GetChannels implements p2p.Reactor.
+func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
+	return []*p2p.ChannelDescriptor{
+		{
+			ID:                  SnapshotChannel,
+			Priority:            3,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: snapshotMsgSize,
+		},
+		{
+			ID:                  ChunkChannel,
+			Priority:            r.config.ChunkPriority,
+			SendQueueCapacity:   4,
+			RecvMessageCapacity: chunkMsgSize,
+		},
+	}
+}
+
+// AddPeer implements p2p.Reactor by asking the peer for its snapshots, if a
+// state sync is in progress.
+func (r *Reactor) AddPeer(peer p2p.Peer) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.syncer != nil {
+		r.syncer.AddPeer(peer)
+	}
+}
+
+// RemovePeer implements p2p.Reactor.
+func (r *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
+	r.peerLimiter.RemovePeer(peer.ID())
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.syncer != nil {
+		r.syncer.RemovePeer(peer)
+	}
+}
+
+// Receive implements p2p.Reactor.
+func (r *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
+	if !r.IsRunning() {
+		return
+	}
+
+	msg, err := decodeMsg(msgBytes)
+	if err != nil {
+		r.Logger.Error("Error decoding message", "src", src, "chId", chID, "msg", msg, "err", err, "bytes", msgBytes)
+		r.reportError(src, 0, err)
+		return
+	}
+	if err = msg.ValidateBasic(); err != nil {
+		r.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
+		r.reportError(src, 0, err)
+		return
+	}
+
+	switch chID {
+	case SnapshotChannel:
+		switch msg := msg.(type) {
+		case *snapshotsRequestMessage:
+			snapshots, err := r.recentSnapshots(recentSnapshots)
+			if err != nil {
+				r.Logger.Error("Failed to fetch snapshots", "err", err)
+				return
+			}
+			for _, snapshot := range snapshots {
+				r.Logger.Debug("Advertising snapshot", "height", snapshot.Height,
+					"format", snapshot.Format, "peer", src.ID())
+				src.Send(chID, cdc.MustMarshalBinaryBare(&snapshotsResponseMessage{
+					Height:   snapshot.Height,
+					Format:   snapshot.Format,
+					Chunks:   snapshot.Chunks,
+					Hash:     snapshot.Hash,
+					Metadata: snapshot.Metadata,
+				}))
+			}
+
+		case *snapshotsResponseMessage:
+			r.mtx.RLock()
+			defer r.mtx.RUnlock()
+			if r.syncer == nil {
+				r.Logger.Debug("Received unexpected snapshot, no state sync in progress")
+				return
+			}
+			r.Logger.Debug("Received snapshot", "height", msg.Height, "format", msg.Format, "peer", src.ID())
+			_, err := r.syncer.AddSnapshot(src, &snapshot{
+				Height:   msg.Height,
+				Format:   msg.Format,
+				Chunks:   msg.Chunks,
+				Hash:     msg.Hash,
+				Metadata: msg.Metadata,
+			})
+			if err != nil {
+				r.Logger.Error("Failed to add snapshot", "height", msg.Height, "format", msg.Format,
+					"peer", src.ID(), "err", err)
+				return
+			}
+
+		default:
+			r.Logger.Error("Received unknown message on SnapshotChannel", "msg", msg)
+			r.reportError(src, 0, errors.New("unknown message on SnapshotChannel"))
+		}
+
+	case ChunkChannel:
+		switch msg := msg.(type) {
+		case *chunkRequestMessage:
+			r.Logger.Debug("Received chunk request", "height", msg.Height, "format", msg.Format,
+				"chunk", msg.Index, "peer", src.ID())
+			r.serveChunk(src, msg)
+
+		case *chunkResponseMessage:
+			r.mtx.RLock()
+			defer r.mtx.RUnlock()
+			if r.syncer == nil {
+				r.Logger.Debug("Received unexpected chunk, no state sync in progress", "peer", src.ID())
+				return
+			}
+			r.Logger.Debug("Received chunk, adding to sync", "height", msg.Height, "format", msg.Format,
+				"chunk", msg.Index, "peer", src.ID())
+			if msg.Missing {
+				// The fetcher asks another peer once the request times out.
+				return
+			}
+			_, err := r.syncer.AddChunk(&chunk{
+				Height: msg.Height,
+				Format: msg.Format,
+				Index:  msg.Index,
+				Chunk:  msg.Chunk,
+				Sender: src.ID(),
+			})
+			if err != nil {
+				r.Logger.Error("Failed to add chunk", "height", msg.Height, "format", msg.Format,
+					"chunk", msg.Index, "err", err)
+				return
+			}
+
+		default:
+			r.Logger.Error("Received unknown message on ChunkChannel", "msg", msg)
+			r.reportError(src, 0, errors.New("unknown message on ChunkChannel"))
+		}
+
+	default:
+		r.Logger.Error("Received message on invalid channel", "chID", chID)
+	}
+}
+
+// serveChunk answers a chunk request of the peer with the chunk loaded from
+// the app. Requests over the limits of the config are answered with a missing
+// chunk, without asking the app.
+func (r *Reactor) serveChunk(src p2p.Peer, msg *chunkRequestMessage) {
+	resp := &chunkResponseMessage{Height: msg.Height, Format: msg.Format, Index: msg.Index}
+
+	select {
+	case r.servingSlots <- struct{}{}:
+		defer func() { <-r.servingSlots }()
+	default:
+		r.Logger.Debug("Too many chunk requests, not serving chunk", "height", msg.Height,
+			"format", msg.Format, "chunk", msg.Index, "peer", src.ID())
+		resp.Missing = true
+		src.TrySend(ChunkChannel, cdc.MustMarshalBinaryBare(resp))
+		return
+	}
+	if !r.peerLimiter.Allow(src.ID(), time.Now()) {
+		r.Logger.Debug("Peer is over the chunk request rate, not serving chunk", "height", msg.Height,
+			"format", msg.Format, "chunk", msg.Index, "peer", src.ID())
+		resp.Missing = true
+		src.TrySend(ChunkChannel, cdc.MustMarshalBinaryBare(resp))
+		return
+	}
+
+	loaded, err := r.conn.LoadSnapshotChunkSync(abci.RequestLoadSnapshotChunk{
+		Height: msg.Height,
+		Format: msg.Format,
+		Chunk:  msg.Index,
+	})
+	if err != nil {
+		r.Logger.Error("Failed to load chunk", "height", msg.Height, "format", msg.Format,
+			"chunk", msg.Index, "err", err)
+		return
+	}
+	r.Logger.Debug("Sending chunk", "height", msg.Height, "format", msg.Format,
+		"chunk", msg.Index, "peer", src.ID())
+	resp.Chunk = loaded.Chunk
+	resp.Missing = loaded.Chunk == nil
+	src.Send(ChunkChannel, cdc.MustMarshalBinaryBare(resp))
+}
+
+// recentSnapshots fetches the n most recent snapshots from the app.
+func (r *Reactor) recentSnapshots(n uint32) ([]*abci.Snapshot, error) {
+	resp, err := r.conn.ListSnapshotsSync(abci.RequestListSnapshots{})
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]*abci.Snapshot, 0, len(resp.Snapshots))
+	for _, snapshot := range resp.Snapshots {
+		if snapshot != nil {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		a, b := snapshots[i], snapshots[j]
+		switch {
+		case a.Height != b.Height:
+			return a.Height > b.Height
+		default:
+			return a.Format > b.Format
+		}
+	})
+	if uint32(len(snapshots)) > n {
+		snapshots = snapshots[:n]
+	}
+	return snapshots, nil
+}
+
+// Sync restores the app state from a snapshot of the peers, verifying it with
+// the state provider, and returns the state at the snapshot's height. It
+// waits discoveryTime for snapshots before restoring the best one.
+func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration) (sm.State, error) {
+	r.mtx.Lock()
+	if r.syncer != nil {
+		r.mtx.Unlock()
+		return sm.State{}, errors.New("a state sync is already in progress")
+	}
+	syncer := newSyncer(r.Logger, r.conn, r.connQuery, stateProvider, r.Switch.PeerBehaviour(),
+		r.config.TempDir, r.config.ChunkFetchers, r.config.ChunkRequestTimeout)
+	r.syncer = syncer
+	r.mtx.Unlock()
+
+	requestSnapshots := func() {
+		r.Switch.Broadcast(SnapshotChannel, cdc.MustMarshalBinaryBare(&snapshotsRequestMessage{}))
+	}
+	requestSnapshots()
+
+	state, err := syncer.SyncAny(discoveryTime, requestSnapshots)
+	r.mtx.Lock()
+	r.syncer = nil
+	r.mtx.Unlock()
+	return state, err
+}
+
+// reportError reports a peer which sent a bad message to the PeerBehaviour
+// of the Switch, which decides whether to stop the peer.
+func (r *Reactor) reportError(peer p2p.Peer, height int64, err error) {
+	r.Switch.PeerBehaviour().Errored(peer, p2p.ErrorBehaviourReport{
+		Reason:  p2p.ErrorPeerBehaviourBadMessage,
+		Reactor: reactorName,
+		Height:  height,
+		Detail:  err.Error(),
+	})
+}
@@ -0,0 +1,172 @@
+package statesync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+)
+
+func setupReactor(t *testing.T, cfg *config.StateSyncConfig, app *testApp) (*Reactor, p2p.GettablePeerBehaviour, func()) {
+	r := NewReactor(cfg, app, app)
+	pb := p2p.NewStorePeerBehaviour()
+	sw := p2p.NewSwitch(config.DefaultP2PConfig(), nil)
+	sw.SetPeerBehaviour(pb)
+	r.SetSwitch(sw)
+	r.SetLogger(log.TestingLogger())
+	require.NoError(t, r.Start())
+	return r, pb, func() {
+		require.NoError(t, r.Stop())
+	}
+}
+
+func TestReactor_Receive_SnapshotsRequest(t *testing.T) {
+	app := &testApp{snapshots: []*abci.Snapshot{
+		{Height: 1, Format: 2, Chunks: 7, Hash: []byte{1, 2}, Metadata: []byte{1}},
+		{Height: 2, Format: 2, Chunks: 7, Hash: []byte{2, 2}, Metadata: []byte{2}},
+		{Height: 3, Format: 1, Chunks: 7, Hash: []byte{3, 1}, Metadata: []byte{3}},
+		{Height: 3, Format: 2, Chunks: 7, Hash: []byte{3, 2}, Metadata: []byte{3}},
+	}}
+	r, _, teardown := setupReactor(t, config.DefaultStateSyncConfig(), app)
+	defer teardown()
+
+	peer := newTestPeer("a")
+	r.Receive(SnapshotChannel, peer, cdc.MustMarshalBinaryBare(&snapshotsRequestMessage{}))
+
+	// The snapshots are advertised highest first
+	expect := []*snapshotsResponseMessage{
+		{Height: 3, Format: 2, Chunks: 7, Hash: []byte{3, 2}, Metadata: []byte{3}},
+		{Height: 3, Format: 1, Chunks: 7, Hash: []byte{3, 1}, Metadata: []byte{3}},
+		{Height: 2, Format: 2, Chunks: 7, Hash: []byte{2, 2}, Metadata: []byte{2}},
+		{Height: 1, Format: 2, Chunks: 7, Hash: []byte{1, 2}, Metadata: []byte{1}},
+	}
+	for _, msg := range expect {
+		sent := <-peer.sent
+		assert.Equal(t, SnapshotChannel, sent.chID)
+		assert.Equal(t, msg, sent.msg)
+	}
+	assert.Empty(t, peer.sent)
+}
+
+func TestReactor_Receive_ChunkRequest(t *testing.T) {
+	app := &testApp{chunks: map[uint32][]byte{1: {1, 2, 3}}}
+	r, _, teardown := setupReactor(t, config.DefaultStateSyncConfig(), app)
+	defer teardown()
+
+	peer := newTestPeer("a")
+	r.Receive(ChunkChannel, peer, cdc.MustMarshalBinaryBare(&chunkRequestMessage{Height: 1, Format: 1, Index: 1}))
+	sent := <-peer.sent
+	assert.Equal(t, ChunkChannel, sent.chID)
+	assert.Equal(t, &chunkResponseMessage{Height: 1, Format: 1, Index: 1, Chunk: []byte{1, 2, 3}}, sent.msg)
+
+	// A chunk the app doesn't have is sent as missing
+	r.Receive(ChunkChannel, peer, cdc.MustMarshalBinaryBare(&chunkRequestMessage{Height: 1, Format: 1, Index: 2}))
+	sent = <-peer.sent
+	assert.Equal(t, &chunkResponseMessage{Height: 1, Format: 1, Index: 2, Missing: true}, sent.msg)
+}
+
+func TestReactor_Receive_ChunkRequest_PeerRate(t *testing.T) {
+	cfg := config.DefaultStateSyncConfig()
+	cfg.PeerChunkRequestRate = 1
+	app := &testApp{chunks: map[uint32][]byte{1: {1, 2, 3}}}
+	r, pb, teardown := setupReactor(t, cfg, app)
+	defer teardown()
+
+	// The second request of a peer within a second is answered as missing,
+	// while other peers are still served
+	peer := newTestPeer("a")
+	req := cdc.MustMarshalBinaryBare(&chunkRequestMessage{Height: 1, Format: 1, Index: 1})
+	r.Receive(ChunkChannel, peer, req)
+	assert.Equal(t, []byte{1, 2, 3}, (<-peer.sent).msg.(*chunkResponseMessage).Chunk)
+	r.Receive(ChunkChannel, peer, req)
+	assert.Equal(t, &chunkResponseMessage{Height: 1, Format: 1, Index: 1, Missing: true}, (<-peer.sent).msg)
+
+	other := newTestPeer("b")
+	r.Receive(ChunkChannel, other, req)
+	assert.Equal(t, []byte{1, 2, 3}, (<-other.sent).msg.(*chunkResponseMessage).Chunk)
+
+	// Peers over the rate aren't reported
+	assert.Empty(t, pb.GetErrored(peer.ID()))
+}
+
+func TestReactor_Receive_ChunkRequest_Concurrency(t *testing.T) {
+	cfg := config.DefaultStateSyncConfig()
+	cfg.MaxConcurrentChunkRequests = 1
+	loading := make(chan struct{})
+	release := make(chan struct{})
+	app := &testApp{load: func(abci.RequestLoadSnapshotChunk) abci.ResponseLoadSnapshotChunk {
+		close(loading)
+		<-release
+		return abci.ResponseLoadSnapshotChunk{Chunk: []byte{1}}
+	}}
+	r, _, teardown := setupReactor(t, cfg, app)
+	defer teardown()
+
+	req := cdc.MustMarshalBinaryBare(&chunkRequestMessage{Height: 1, Format: 1, Index: 1})
+	peerA := newTestPeer("a")
+	done := make(chan struct{})
+	go func() {
+		r.Receive(ChunkChannel, peerA, req)
+		close(done)
+	}()
+	<-loading
+
+	// While the app loads the chunk for one peer, others are answered with a
+	// missing chunk
+	peerB := newTestPeer("b")
+	r.Receive(ChunkChannel, peerB, req)
+	assert.Equal(t, &chunkResponseMessage{Height: 1, Format: 1, Index: 1, Missing: true}, (<-peerB.sent).msg)
+
+	close(release)
+	<-done
+	assert.Equal(t, []byte{1}, (<-peerA.sent).msg.(*chunkResponseMessage).Chunk)
+
+	// Once the request is served, the next one is too
+	app.load = nil
+	app.chunks = map[uint32][]byte{1: {2}}
+	r.Receive(ChunkChannel, peerB, req)
+	assert.Equal(t, []byte{2}, (<-peerB.sent).msg.(*chunkResponseMessage).Chunk)
+}
+
+func TestReactor_GetChannels_ChunkPriority(t *testing.T) {
+	cfg := config.DefaultStateSyncConfig()
+	cfg.ChunkPriority = 2
+	r := NewReactor(cfg, &testApp{}, &testApp{})
+	for _, ch := range r.GetChannels() {
+		if ch.ID == ChunkChannel {
+			assert.Equal(t, 2, ch.Priority)
+		}
+	}
+}
+
+func TestReactor_Receive_BadMessage(t *testing.T) {
+	r, pb, teardown := setupReactor(t, config.DefaultStateSyncConfig(), &testApp{})
+	defer teardown()
+
+	testcases := map[string]struct {
+		chID byte
+		msg  []byte
+	}{
+		"undecodable":     {ChunkChannel, []byte{0xff, 0x01}},
+		"invalid":         {ChunkChannel, cdc.MustMarshalBinaryBare(&chunkRequestMessage{Height: 0})},
+		"wrong channel":   {SnapshotChannel, cdc.MustMarshalBinaryBare(&chunkRequestMessage{Height: 1})},
+		"missing content": {ChunkChannel, cdc.MustMarshalBinaryBare(&chunkResponseMessage{Height: 1, Chunk: []byte{1}, Missing: true})},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			peer := newTestPeer(name)
+			r.Receive(tc.chID, peer, tc.msg)
+			reports := pb.GetErrored(peer.ID())
+			require.Len(t, reports, 1)
+			assert.Equal(t, p2p.ErrorPeerBehaviourBadMessage, reports[0].Reason)
+			assert.Equal(t, reactorName, reports[0].Reactor)
+			assert.Empty(t, peer.sent)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package statesync
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/lite"
+	lerr "github.com/tendermint/tendermint/lite/errors"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/lib/client"
+	"github.com/tendermint/tendermint/types"
+)
+
+// rpcClient is the part of the RPC API the state provider uses. The node
+// imports this package, so it can't use rpc/client or lite/client, which
+// import the node.
+type rpcClient interface {
+	Status() (*ctypes.ResultStatus, error)
+	Commit(height *int64) (*ctypes.ResultCommit, error)
+	Validators(height *int64) (*ctypes.ResultValidators, error)
+	ConsensusParams(height *int64) (*ctypes.ResultConsensusParams, error)
+}
+
+// httpClient is an rpcClient calling an RPC server over HTTP.
+type httpClient struct {
+	rpc *rpcclient.JSONRPCClient
+}
+
+func newHTTPClient(remote string) *httpClient {
+	rc := rpcclient.NewJSONRPCClient(remote)
+	cdc := rc.Codec()
+	ctypes.RegisterAmino(cdc)
+	rc.SetCodec(cdc)
+	return &httpClient{rpc: rc}
+}
+
+func (c *httpClient) Status() (*ctypes.ResultStatus, error) {
+	result := new(ctypes.ResultStatus)
+	_, err := c.rpc.Call("status", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "Status")
+	}
+	return result, nil
+}
+
+func (c *httpClient) Commit(height *int64) (*ctypes.ResultCommit, error) {
+	result := new(ctypes.ResultCommit)
+	_, err := c.rpc.Call("commit", map[string]interface{}{"height": height}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "Commit")
+	}
+	return result, nil
+}
+
+func (c *httpClient) Validators(height *int64) (*ctypes.ResultValidators, error) {
+	result := new(ctypes.ResultValidators)
+	_, err := c.rpc.Call("validators", map[string]interface{}{"height": height}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "Validators")
+	}
+	return result, nil
+}
+
+func (c *httpClient) ConsensusParams(height *int64) (*ctypes.ResultConsensusParams, error) {
+	result := new(ctypes.ResultConsensusParams)
+	_, err := c.rpc.Call("consensus_params", map[string]interface{}{"height": height}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "ConsensusParams")
+	}
+	return result, nil
+}
+
+// rpcProvider is a lite.Provider fetching the full commits from an
+// rpcClient, like the provider of lite/client. It does no validation.
+type rpcProvider struct {
+	logger  log.Logger
+	chainID string
+	client  rpcClient
+}
+
+func newRPCProvider(chainID string, client rpcClient) *rpcProvider {
+	return &rpcProvider{
+		logger:  log.NewNopLogger(),
+		chainID: chainID,
+		client:  client,
+	}
+}
+
+// SetLogger implements lite.Provider.
+func (p *rpcProvider) SetLogger(logger log.Logger) {
+	p.logger = logger.With("module", "statesync/provider")
+}
+
+// LatestFullCommit implements lite.Provider.
+func (p *rpcProvider) LatestFullCommit(chainID string, minHeight, maxHeight int64) (lite.FullCommit, error) {
+	if chainID != p.chainID {
+		return lite.FullCommit{}, fmt.Errorf("expected chainID %s, got %s", p.chainID, chainID)
+	}
+	if maxHeight != 0 && maxHeight < minHeight {
+		return lite.FullCommit{}, fmt.Errorf("need maxHeight == 0 or minHeight <= maxHeight, got min %v and max %v",
+			minHeight, maxHeight)
+	}
+	status, err := p.client.Status()
+	if err != nil {
+		return lite.FullCommit{}, err
+	}
+	if status.SyncInfo.LatestBlockHeight < minHeight {
+		return lite.FullCommit{}, fmt.Errorf("provider is at %v but require minHeight=%v",
+			status.SyncInfo.LatestBlockHeight, minHeight)
+	}
+	if maxHeight == 0 || status.SyncInfo.LatestBlockHeight < maxHeight {
+		maxHeight = status.SyncInfo.LatestBlockHeight
+	}
+	commit, err := p.client.Commit(&maxHeight)
+	if err != nil {
+		return lite.FullCommit{}, err
+	}
+
+	valset, err := p.ValidatorSet(chainID, commit.Height)
+	if err != nil {
+		return lite.FullCommit{}, err
+	}
+	nextValset, err := p.ValidatorSet(chainID, commit.Height+1)
+	if err != nil {
+		return lite.FullCommit{}, err
+	}
+	return lite.NewFullCommit(commit.SignedHeader, valset, nextValset), nil
+}
+
+// ValidatorSet implements lite.Provider.
+func (p *rpcProvider) ValidatorSet(chainID string, height int64) (*types.ValidatorSet, error) {
+	if chainID != p.chainID {
+		return nil, fmt.Errorf("expected chainID %s, got %s", p.chainID, chainID)
+	}
+	if height < 1 {
+		return nil, fmt.Errorf("expected height >= 1, got height %v", height)
+	}
+	res, err := p.client.Validators(&height)
+	if err != nil {
+		return nil, lerr.ErrUnknownValidators(chainID, height)
+	}
+	return types.NewValidatorSet(res.Validators), nil
+}
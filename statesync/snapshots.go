@@ -0,0 +1,247 @@
+package statesync
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/tendermint/tendermint/p2p"
+)
+
+// snapshotKey uniquely identifies a snapshot by all of its fields.
+type snapshotKey [sha256.Size]byte
+
+// snapshot is a snapshot of the app state offered by peers.
+type snapshot struct {
+	Height   int64
+	Format   uint32
+	Chunks   uint32
+	Hash     []byte
+	Metadata []byte
+
+	trustedAppHash []byte // populated by the light client
+}
+
+// Key returns the key of the snapshot. Peers may offer snapshots with the
+// same height and format but different contents, which are told apart by the
+// other fields.
+func (s *snapshot) Key() snapshotKey {
+	hasher := sha256.New()
+	binary.Write(hasher, binary.BigEndian, s.Height) // nolint: errcheck
+	binary.Write(hasher, binary.BigEndian, s.Format) // nolint: errcheck
+	binary.Write(hasher, binary.BigEndian, s.Chunks) // nolint: errcheck
+	hasher.Write(s.Hash)                             // nolint: errcheck
+	hasher.Write(s.Metadata)                         // nolint: errcheck
+	var key snapshotKey
+	copy(key[:], hasher.Sum(nil))
+	return key
+}
+
+// snapshotPool keeps track of the snapshots offered by peers, and of the
+// snapshots, formats and peers which were rejected.
+type snapshotPool struct {
+	stateProvider StateProvider
+
+	mtx           sync.Mutex
+	snapshots     map[snapshotKey]*snapshot
+	snapshotPeers map[snapshotKey]map[p2p.ID]p2p.Peer
+	peerIndex     map[p2p.ID]map[snapshotKey]bool
+
+	formatBlacklist   map[uint32]bool
+	peerBlacklist     map[p2p.ID]bool
+	snapshotBlacklist map[snapshotKey]bool
+}
+
+func newSnapshotPool(stateProvider StateProvider) *snapshotPool {
+	return &snapshotPool{
+		stateProvider:     stateProvider,
+		snapshots:         make(map[snapshotKey]*snapshot),
+		snapshotPeers:     make(map[snapshotKey]map[p2p.ID]p2p.Peer),
+		peerIndex:         make(map[p2p.ID]map[snapshotKey]bool),
+		formatBlacklist:   make(map[uint32]bool),
+		peerBlacklist:     make(map[p2p.ID]bool),
+		snapshotBlacklist: make(map[snapshotKey]bool),
+	}
+}
+
+// Add adds a snapshot offered by the peer, returning true if it's new. The
+// app hash of a new snapshot is fetched and verified with the light client.
+func (p *snapshotPool) Add(peer p2p.Peer, s *snapshot) (bool, error) {
+	key := s.Key()
+
+	p.mtx.Lock()
+	if p.formatBlacklist[s.Format] || p.peerBlacklist[peer.ID()] || p.snapshotBlacklist[key] {
+		p.mtx.Unlock()
+		return false, nil
+	}
+	if _, ok := p.snapshots[key]; ok {
+		p.addPeer(key, peer)
+		p.mtx.Unlock()
+		return false, nil
+	}
+	p.mtx.Unlock()
+
+	// The light client may make requests, so don't hold the lock.
+	appHash, err := p.stateProvider.AppHash(s.Height)
+	if err != nil {
+		return false, err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.snapshotBlacklist[key] || p.peerBlacklist[peer.ID()] {
+		return false, nil
+	}
+	_, known := p.snapshots[key]
+	if !known {
+		s.trustedAppHash = appHash
+		p.snapshots[key] = s
+	}
+	p.addPeer(key, peer)
+	return !known, nil
+}
+
+func (p *snapshotPool) addPeer(key snapshotKey, peer p2p.Peer) {
+	if p.snapshotPeers[key] == nil {
+		p.snapshotPeers[key] = make(map[p2p.ID]p2p.Peer)
+	}
+	p.snapshotPeers[key][peer.ID()] = peer
+	if p.peerIndex[peer.ID()] == nil {
+		p.peerIndex[peer.ID()] = make(map[snapshotKey]bool)
+	}
+	p.peerIndex[peer.ID()][key] = true
+}
+
+// Best returns the best snapshot, or nil if there are none.
+func (p *snapshotPool) Best() *snapshot {
+	ranked := p.Ranked()
+	if len(ranked) == 0 {
+		return nil
+	}
+	return ranked[0]
+}
+
+// Ranked returns the snapshots from the best to the worst: the highest first,
+// then the highest format, then the one with the most peers.
+func (p *snapshotPool) Ranked() []*snapshot {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	candidates := make([]*snapshot, 0, len(p.snapshots))
+	for _, s := range p.snapshots {
+		candidates = append(candidates, s)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		switch {
+		case a.Height != b.Height:
+			return a.Height > b.Height
+		case a.Format != b.Format:
+			return a.Format > b.Format
+		default:
+			return len(p.snapshotPeers[a.Key()]) > len(p.snapshotPeers[b.Key()])
+		}
+	})
+	return candidates
+}
+
+// GetPeer returns a random peer which has the snapshot, or nil if none has.
+func (p *snapshotPool) GetPeer(s *snapshot) p2p.Peer {
+	peers := p.GetPeers(s)
+	if len(peers) == 0 {
+		return nil
+	}
+	return peers[rand.Intn(len(peers))] // nolint: gosec
+}
+
+// GetPeers returns the peers which have the snapshot.
+func (p *snapshotPool) GetPeers(s *snapshot) []p2p.Peer {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	peers := make([]p2p.Peer, 0, len(p.snapshotPeers[s.Key()]))
+	for _, peer := range p.snapshotPeers[s.Key()] {
+		peers = append(peers, peer)
+	}
+	// Sort them, so the peers of a snapshot are picked deterministically in
+	// tests.
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].ID() < peers[j].ID()
+	})
+	return peers
+}
+
+// getPeer returns the peer with the ID if it has the snapshot, or nil.
+func (p *snapshotPool) getPeer(s *snapshot, peerID p2p.ID) p2p.Peer {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.snapshotPeers[s.Key()][peerID]
+}
+
+// Reject rejects the snapshot, so it's not offered again.
+func (p *snapshotPool) Reject(s *snapshot) {
+	key := s.Key()
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.snapshotBlacklist[key] = true
+	p.removeSnapshot(key)
+}
+
+// RejectFormat rejects the snapshots of the format.
+func (p *snapshotPool) RejectFormat(format uint32) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.formatBlacklist[format] = true
+	for key, s := range p.snapshots {
+		if s.Format == format {
+			p.removeSnapshot(key)
+		}
+	}
+}
+
+// RejectPeer rejects the peer, so its snapshots and chunks are ignored.
+func (p *snapshotPool) RejectPeer(peerID p2p.ID) {
+	if peerID == "" {
+		return
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.removePeer(peerID)
+	p.peerBlacklist[peerID] = true
+}
+
+// RemovePeer removes the peer, e.g. when it disconnects.
+func (p *snapshotPool) RemovePeer(peerID p2p.ID) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.removePeer(peerID)
+}
+
+// removePeer removes the peer from its snapshots, and the snapshots no other
+// peer has. Caller must hold the lock.
+func (p *snapshotPool) removePeer(peerID p2p.ID) {
+	for key := range p.peerIndex[peerID] {
+		delete(p.snapshotPeers[key], peerID)
+		if len(p.snapshotPeers[key]) == 0 {
+			p.removeSnapshot(key)
+		}
+	}
+	delete(p.peerIndex, peerID)
+}
+
+// removeSnapshot removes the snapshot. Caller must hold the lock.
+func (p *snapshotPool) removeSnapshot(key snapshotKey) {
+	if _, ok := p.snapshots[key]; !ok {
+		return
+	}
+	delete(p.snapshots, key)
+	for peerID := range p.snapshotPeers[key] {
+		delete(p.peerIndex[peerID], key)
+	}
+	delete(p.snapshotPeers, key)
+}
@@ -0,0 +1,275 @@
+package statesync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/lite"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
+)
+
+// StateProvider provides the verified app hash and state at the height of a
+// snapshot.
+type StateProvider interface {
+	// AppHash returns the app hash after the block at the height was
+	// committed, which is in the header of the next block.
+	AppHash(height int64) ([]byte, error)
+	// State returns the state after the block at the height was committed.
+	State(height int64) (sm.State, error)
+}
+
+// lightClientStateProvider verifies the headers with the lite client, from
+// the trusted header on, and fetches them and the validators and consensus
+// params from the RPC servers, trying them in turn.
+type lightClientStateProvider struct {
+	mtx         sync.Mutex // the verifier is used for one height at a time
+	chainID     string
+	trustHeight int64
+	clients     []rpcClient
+	trusted     lite.PersistentProvider
+	source      lite.Provider
+	verifier    *lite.DynamicVerifier
+	logger      log.Logger
+}
+
+// NewLightClientStateProvider returns a StateProvider which verifies the
+// headers with the lite client, trusting the header at trustHeight with the
+// hash trustHash, and fetches them from the given RPC servers.
+func NewLightClientStateProvider(chainID string, servers []string, trustHeight int64, trustHash []byte,
+	logger log.Logger) (StateProvider, error) {
+
+	clients := make([]rpcClient, 0, len(servers))
+	for _, server := range servers {
+		clients = append(clients, newHTTPClient(server))
+	}
+	return newLightClientStateProvider(chainID, clients, trustHeight, trustHash, logger)
+}
+
+func newLightClientStateProvider(chainID string, clients []rpcClient, trustHeight int64,
+	trustHash []byte, logger log.Logger) (*lightClientStateProvider, error) {
+
+	if len(clients) == 0 {
+		return nil, errors.New("at least one RPC server is required")
+	}
+	providers := make([]lite.Provider, 0, len(clients))
+	for _, client := range clients {
+		providers = append(providers, newRPCProvider(chainID, client))
+	}
+	source := &fallbackProvider{providers}
+	trusted := lite.NewDBProvider("trusted", dbm.NewMemDB())
+
+	fc, err := source.LatestFullCommit(chainID, trustHeight, trustHeight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the trusted header at height %v: %v", trustHeight, err)
+	}
+	if fc.Height() != trustHeight {
+		return nil, fmt.Errorf("no header at the trusted height %v, got %v", trustHeight, fc.Height())
+	}
+	if !bytes.Equal(fc.SignedHeader.Hash(), trustHash) {
+		return nil, fmt.Errorf("header at the trusted height %v has hash %X, not the trusted %X",
+			trustHeight, fc.SignedHeader.Hash(), trustHash)
+	}
+	if err := fc.ValidateFull(chainID); err != nil {
+		return nil, fmt.Errorf("invalid trusted header: %v", err)
+	}
+	if err := trusted.SaveFullCommit(fc); err != nil {
+		return nil, err
+	}
+
+	verifier := lite.NewDynamicVerifier(chainID, trusted, source)
+	verifier.SetLogger(logger)
+	return &lightClientStateProvider{
+		chainID:     chainID,
+		trustHeight: trustHeight,
+		clients:     clients,
+		trusted:     trusted,
+		source:      source,
+		verifier:    verifier,
+		logger:      logger,
+	}, nil
+}
+
+// AppHash implements StateProvider.
+func (p *lightClientStateProvider) AppHash(height int64) ([]byte, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	fc, err := p.verifiedFullCommit(height + 1)
+	if err != nil {
+		return nil, err
+	}
+	return fc.SignedHeader.AppHash, nil
+}
+
+// State implements StateProvider. The validator sets are fetched with their
+// proposer priorities, which the lite client doesn't keep.
+func (p *lightClientStateProvider) State(height int64) (sm.State, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	fc, err := p.verifiedFullCommit(height)
+	if err != nil {
+		return sm.State{}, err
+	}
+	nextFC, err := p.verifiedFullCommit(height + 1)
+	if err != nil {
+		return sm.State{}, err
+	}
+	header, nextHeader := fc.SignedHeader.Header, nextFC.SignedHeader.Header
+
+	lastValidators, err := p.validators(height, header.ValidatorsHash)
+	if err != nil {
+		return sm.State{}, err
+	}
+	validators, err := p.validators(height+1, nextHeader.ValidatorsHash)
+	if err != nil {
+		return sm.State{}, err
+	}
+	if _, proposer := validators.GetByAddress(nextHeader.ProposerAddress); proposer != nil {
+		validators.Proposer = proposer
+	}
+	nextValidators, err := p.validators(height+2, nextHeader.NextValidatorsHash)
+	if err != nil {
+		return sm.State{}, err
+	}
+	params, err := p.consensusParams(height+1, nextHeader.ConsensusHash)
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	return sm.State{
+		Version: sm.Version{
+			Consensus: nextHeader.Version,
+			Software:  version.TMCoreSemVer,
+		},
+		ChainID:          p.chainID,
+		LastBlockHeight:  height,
+		LastBlockTotalTx: header.TotalTxs,
+		LastBlockID:      nextHeader.LastBlockID,
+		LastBlockTime:    header.Time,
+
+		LastValidators: lastValidators,
+		Validators:     validators,
+		NextValidators: nextValidators,
+		// The sets are saved in full at these heights by sm.BootstrapState.
+		LastHeightValidatorsChanged: height + 2,
+
+		ConsensusParams:                  params,
+		LastHeightConsensusParamsChanged: height + 1,
+
+		LastResultsHash: nextHeader.LastResultsHash,
+		AppHash:         nextHeader.AppHash,
+	}, nil
+}
+
+// verifiedFullCommit returns the full commit at the height, verified by the
+// lite client. Caller must hold the lock.
+func (p *lightClientStateProvider) verifiedFullCommit(height int64) (lite.FullCommit, error) {
+	if height < p.trustHeight {
+		return lite.FullCommit{}, fmt.Errorf("height %v is below the trusted height %v", height, p.trustHeight)
+	}
+	if fc, err := p.trusted.LatestFullCommit(p.chainID, height, height); err == nil {
+		return fc, nil
+	}
+
+	fc, err := p.source.LatestFullCommit(p.chainID, height, height)
+	if err != nil {
+		return lite.FullCommit{}, fmt.Errorf("failed to fetch header at height %v: %v", height, err)
+	}
+	if fc.Height() != height {
+		return lite.FullCommit{}, fmt.Errorf("no header at height %v yet", height)
+	}
+	if err := p.verifier.Verify(fc.SignedHeader); err != nil {
+		return lite.FullCommit{}, fmt.Errorf("failed to verify header at height %v: %v", height, err)
+	}
+	if err := fc.ValidateFull(p.chainID); err != nil {
+		return lite.FullCommit{}, fmt.Errorf("invalid header at height %v: %v", height, err)
+	}
+	return fc, nil
+}
+
+// validators fetches the validator set at the height, with the proposer
+// priorities, from the first RPC server whose set has the verified hash.
+func (p *lightClientStateProvider) validators(height int64, hash []byte) (*types.ValidatorSet, error) {
+	err := errors.New("no RPC servers")
+	for _, client := range p.clients {
+		res, cerr := client.Validators(&height)
+		if cerr != nil {
+			err = cerr
+			continue
+		}
+		vals, verr := types.ValidatorSetFromExistingValidators(res.Validators)
+		if verr != nil {
+			err = verr
+			continue
+		}
+		if !bytes.Equal(vals.Hash(), hash) {
+			err = fmt.Errorf("validators at height %v have hash %X, expected %X", height, vals.Hash(), hash)
+			continue
+		}
+		return vals, nil
+	}
+	return nil, fmt.Errorf("failed to fetch validators at height %v: %v", height, err)
+}
+
+// consensusParams fetches the consensus params at the height from the first
+// RPC server whose params have the verified hash.
+func (p *lightClientStateProvider) consensusParams(height int64, hash []byte) (types.ConsensusParams, error) {
+	err := errors.New("no RPC servers")
+	for _, client := range p.clients {
+		res, cerr := client.ConsensusParams(&height)
+		if cerr != nil {
+			err = cerr
+			continue
+		}
+		params := res.ConsensusParams
+		if !bytes.Equal(params.Hash(), hash) {
+			err = fmt.Errorf("consensus params at height %v have hash %X, expected %X",
+				height, params.Hash(), hash)
+			continue
+		}
+		return params, nil
+	}
+	return types.ConsensusParams{}, fmt.Errorf("failed to fetch consensus params at height %v: %v", height, err)
+}
+
+// fallbackProvider is a lite.Provider which asks its providers in turn until
+// one succeeds.
+type fallbackProvider struct {
+	providers []lite.Provider
+}
+
+// LatestFullCommit implements lite.Provider.
+func (fp *fallbackProvider) LatestFullCommit(chainID string, minHeight, maxHeight int64) (fc lite.FullCommit, err error) {
+	for _, provider := range fp.providers {
+		fc, err = provider.LatestFullCommit(chainID, minHeight, maxHeight)
+		if err == nil {
+			return fc, nil
+		}
+	}
+	return fc, err
+}
+
+// ValidatorSet implements lite.Provider.
+func (fp *fallbackProvider) ValidatorSet(chainID string, height int64) (valset *types.ValidatorSet, err error) {
+	for _, provider := range fp.providers {
+		valset, err = provider.ValidatorSet(chainID, height)
+		if err == nil {
+			return valset, nil
+		}
+	}
+	return valset, err
+}
+
+// SetLogger implements lite.Provider.
+func (fp *fallbackProvider) SetLogger(logger log.Logger) {
+	for _, provider := range fp.providers {
+		provider.SetLogger(logger)
+	}
+}
@@ -0,0 +1,132 @@
+package statesync
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/lite"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+const testChainID = "statesync-chain"
+
+// testChainClient serves a chain of headers signed by one validator set, and
+// the validators and consensus params of every height.
+type testChainClient struct {
+	headers    map[int64]types.SignedHeader
+	validators *types.ValidatorSet
+	params     types.ConsensusParams
+	height     int64
+}
+
+func newTestChainClient(height int64) *testChainClient {
+	keys := lite.GenSecpPrivKeys(4)
+	vals := keys.ToValidators(10, 0)
+	params := *types.DefaultConsensusParams()
+	c := &testChainClient{
+		headers:    make(map[int64]types.SignedHeader),
+		validators: vals,
+		params:     params,
+		height:     height,
+	}
+	for h := int64(1); h <= height; h++ {
+		c.headers[h] = keys.GenSignedHeader(testChainID, h, nil, vals, vals,
+			[]byte(fmt.Sprintf("app_hash_%v", h)), params.Hash(), []byte("results_hash"), 0, len(keys))
+	}
+	return c
+}
+
+func (c *testChainClient) Status() (*ctypes.ResultStatus, error) {
+	return &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: c.height}}, nil
+}
+
+func (c *testChainClient) Commit(height *int64) (*ctypes.ResultCommit, error) {
+	sh, ok := c.headers[*height]
+	if !ok {
+		return nil, fmt.Errorf("no header at height %v", *height)
+	}
+	return ctypes.NewResultCommit(sh.Header, sh.Commit, true), nil
+}
+
+func (c *testChainClient) Validators(height *int64) (*ctypes.ResultValidators, error) {
+	if *height < 1 || *height > c.height+1 {
+		return nil, fmt.Errorf("no validators at height %v", *height)
+	}
+	return &ctypes.ResultValidators{BlockHeight: *height, Validators: c.validators.Copy().Validators}, nil
+}
+
+func (c *testChainClient) ConsensusParams(height *int64) (*ctypes.ResultConsensusParams, error) {
+	return &ctypes.ResultConsensusParams{BlockHeight: *height, ConsensusParams: c.params}, nil
+}
+
+func TestLightClientStateProvider(t *testing.T) {
+	client := newTestChainClient(5)
+	trustHash := client.headers[1].Hash()
+
+	sp, err := newLightClientStateProvider(testChainID, []rpcClient{client}, 1, trustHash,
+		log.TestingLogger())
+	require.NoError(t, err)
+
+	// The app hash at a height is in the header of the next height
+	appHash, err := sp.AppHash(3)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("app_hash_4"), appHash)
+
+	state, err := sp.State(3)
+	require.NoError(t, err)
+	assert.Equal(t, testChainID, state.ChainID)
+	assert.EqualValues(t, 3, state.LastBlockHeight)
+	assert.Equal(t, []byte("app_hash_4"), state.AppHash)
+	assert.Equal(t, []byte("results_hash"), state.LastResultsHash)
+	assert.Equal(t, client.headers[3].Time, state.LastBlockTime)
+	assert.Equal(t, client.validators.Hash(), state.LastValidators.Hash())
+	assert.Equal(t, client.validators.Hash(), state.Validators.Hash())
+	assert.Equal(t, client.validators.Hash(), state.NextValidators.Hash())
+	assert.Equal(t, client.params, state.ConsensusParams)
+	assert.EqualValues(t, 5, state.LastHeightValidatorsChanged)
+	assert.EqualValues(t, 4, state.LastHeightConsensusParamsChanged)
+
+	// Heights below the trusted height, or without a next header, fail
+	_, err = sp.State(0)
+	require.Error(t, err)
+	_, err = sp.State(5)
+	require.Error(t, err)
+}
+
+func TestLightClientStateProvider_TrustHash(t *testing.T) {
+	client := newTestChainClient(3)
+
+	_, err := newLightClientStateProvider(testChainID, []rpcClient{client}, 1,
+		client.headers[2].Hash(), log.TestingLogger())
+	require.Error(t, err)
+
+	_, err = newLightClientStateProvider(testChainID, []rpcClient{client}, 9,
+		client.headers[1].Hash(), log.TestingLogger())
+	require.Error(t, err)
+
+	_, err = newLightClientStateProvider(testChainID, nil, 1, client.headers[1].Hash(), log.TestingLogger())
+	require.Error(t, err)
+}
+
+func TestLightClientStateProvider_Validators(t *testing.T) {
+	client := newTestChainClient(3)
+	sp, err := newLightClientStateProvider(testChainID, []rpcClient{client}, 1,
+		client.headers[1].Hash(), log.TestingLogger())
+	require.NoError(t, err)
+
+	// The validators are returned with their proposer priorities
+	client.validators.IncrementProposerPriority(3)
+	expect := client.validators.Copy()
+	vals, err := sp.validators(2, expect.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, expect.Validators, vals.Validators)
+
+	// A set which doesn't have the verified hash is rejected
+	_, err = sp.validators(2, []byte("other_hash"))
+	require.Error(t, err)
+}
@@ -0,0 +1,494 @@
+package statesync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/proxy"
+	sm "github.com/tendermint/tendermint/state"
+)
+
+const (
+	// how long to wait before asking the peers for snapshots again, when
+	// none could be restored
+	discoveryRetryInterval = 10 * time.Second
+
+	// how long a fetcher waits before allocating a chunk again, once all
+	// were allocated, as applied chunks may be discarded to be fetched again
+	fetchRetryInterval = 2 * time.Second
+
+	// how long to wait for the next chunk to apply, over all the requests
+	// made for it, before giving up on the snapshot
+	chunkTimeout = 2 * time.Minute
+)
+
+var (
+	// errAbort is returned by Sync when the app aborts state sync.
+	errAbort = errors.New("state sync aborted")
+	// errRetrySnapshot is returned by Sync when the app asks to restore the
+	// snapshot again.
+	errRetrySnapshot = errors.New("retry snapshot")
+	// errRejectSnapshot is returned by Sync when the app rejects the snapshot.
+	errRejectSnapshot = errors.New("snapshot was rejected")
+	// errRejectFormat is returned by Sync when the app rejects the format.
+	errRejectFormat = errors.New("snapshot format was rejected")
+	// errRejectSender is returned by Sync when the app rejects the peers of
+	// the snapshot.
+	errRejectSender = errors.New("snapshot sender was rejected")
+	// errVerifyFailed is returned by Sync when the restored app doesn't have
+	// the trusted app hash.
+	errVerifyFailed = errors.New("verification failed")
+	// errTimeout is returned by Sync when a chunk didn't arrive in time.
+	errTimeout = errors.New("timed out waiting for chunk")
+	// errNoSnapshots is returned by SyncAny when there are no snapshots to
+	// restore.
+	errNoSnapshots = errors.New("no suitable snapshots found")
+)
+
+// syncer restores the app state from the snapshots of peers. The app is
+// offered the best snapshot it didn't reject yet, and its chunks are fetched
+// from the peers which have it and applied in order. Peers which send chunks
+// the app rejects are rejected and reported with
+// p2p.ErrorPeerBehaviourBadSnapshotChunk, and their chunks are fetched from
+// the other peers.
+type syncer struct {
+	logger        log.Logger
+	stateProvider StateProvider
+	conn          proxy.AppConnSnapshot
+	connQuery     proxy.AppConnQuery
+	behaviour     p2p.PeerBehaviour
+	snapshots     *snapshotPool
+	tempDir       string
+	chunkFetchers int
+	// how long a fetcher waits for a chunk before asking another peer
+	chunkRequestTimeout time.Duration
+
+	mtx      sync.RWMutex
+	snapshot *snapshot
+	chunks   *chunkQueue
+}
+
+// newSyncer creates a new syncer.
+func newSyncer(logger log.Logger, conn proxy.AppConnSnapshot, connQuery proxy.AppConnQuery,
+	stateProvider StateProvider, behaviour p2p.PeerBehaviour, tempDir string,
+	chunkFetchers int, chunkRequestTimeout time.Duration) *syncer {
+
+	return &syncer{
+		logger:              logger,
+		stateProvider:       stateProvider,
+		conn:                conn,
+		connQuery:           connQuery,
+		behaviour:           behaviour,
+		snapshots:           newSnapshotPool(stateProvider),
+		tempDir:             tempDir,
+		chunkFetchers:       chunkFetchers,
+		chunkRequestTimeout: chunkRequestTimeout,
+	}
+}
+
+// AddChunk adds a chunk to the queue of the snapshot being restored,
+// returning true if it's new. Chunks from peers which don't have the
+// snapshot, e.g. because they were rejected, are ignored.
+func (s *syncer) AddChunk(chunk *chunk) (bool, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	if s.chunks == nil {
+		return false, errors.New("no state sync in progress")
+	}
+	if s.snapshots.getPeer(s.snapshot, chunk.Sender) == nil {
+		s.logger.Debug("Ignoring chunk from peer without the snapshot", "height", chunk.Height,
+			"format", chunk.Format, "chunk", chunk.Index, "peer", chunk.Sender)
+		return false, nil
+	}
+	added, err := s.chunks.Add(chunk)
+	if err != nil {
+		return false, err
+	}
+	if added {
+		s.logger.Debug("Added chunk to queue", "height", chunk.Height, "format", chunk.Format,
+			"chunk", chunk.Index)
+	} else {
+		s.logger.Debug("Ignoring duplicate chunk in queue", "height", chunk.Height, "format", chunk.Format,
+			"chunk", chunk.Index)
+	}
+	return added, nil
+}
+
+// AddSnapshot adds a snapshot offered by a peer, returning true if it's new.
+func (s *syncer) AddSnapshot(peer p2p.Peer, snapshot *snapshot) (bool, error) {
+	added, err := s.snapshots.Add(peer, snapshot)
+	if err != nil {
+		return false, err
+	}
+	if added {
+		s.logger.Info("Discovered new snapshot", "height", snapshot.Height, "format", snapshot.Format,
+			"hash", fmt.Sprintf("%X", snapshot.Hash))
+	}
+	return added, nil
+}
+
+// AddPeer asks the peer for its snapshots.
+func (s *syncer) AddPeer(peer p2p.Peer) {
+	s.logger.Debug("Requesting snapshots from peer", "peer", peer.ID())
+	peer.Send(SnapshotChannel, cdc.MustMarshalBinaryBare(&snapshotsRequestMessage{}))
+}
+
+// RemovePeer removes the peer and its snapshots.
+func (s *syncer) RemovePeer(peer p2p.Peer) {
+	s.logger.Debug("Removing peer from sync", "peer", peer.ID())
+	s.snapshots.RemovePeer(peer.ID())
+}
+
+// SyncAny restores the best snapshot which can be restored, waiting
+// discoveryTime for snapshots first. It asks the peers for snapshots again
+// whenever none is left; with a discoveryTime of 0 it gives up instead.
+func (s *syncer) SyncAny(discoveryTime time.Duration, requestSnapshots func()) (sm.State, error) {
+	if discoveryTime > 0 {
+		s.logger.Info(fmt.Sprintf("Discovering snapshots for %v", discoveryTime))
+		time.Sleep(discoveryTime)
+	}
+
+	// The app may ask to restore the same snapshot again, and the chunks
+	// fetched so far are kept for it.
+	var (
+		snapshot *snapshot
+		chunks   *chunkQueue
+		err      error
+	)
+	for {
+		if snapshot == nil {
+			snapshot = s.snapshots.Best()
+			chunks = nil
+		}
+		if snapshot == nil {
+			if discoveryTime == 0 {
+				return sm.State{}, errNoSnapshots
+			}
+			requestSnapshots()
+			s.logger.Info(fmt.Sprintf("No snapshots found, discovering for %v", discoveryRetryInterval))
+			time.Sleep(discoveryRetryInterval)
+			continue
+		}
+		if chunks == nil {
+			chunks, err = newChunkQueue(snapshot, s.tempDir)
+			if err != nil {
+				return sm.State{}, fmt.Errorf("failed to create chunk queue: %v", err)
+			}
+		}
+
+		state, err := s.Sync(snapshot, chunks)
+		switch {
+		case err == nil:
+			s.closeChunks(chunks)
+			return state, nil
+
+		case err == errAbort:
+			s.closeChunks(chunks)
+			return sm.State{}, err
+
+		case err == errRetrySnapshot:
+			chunks.RetryAll()
+			s.logger.Info("Retrying snapshot", "height", snapshot.Height, "format", snapshot.Format,
+				"hash", fmt.Sprintf("%X", snapshot.Hash))
+			continue
+
+		case err == errTimeout:
+			s.snapshots.Reject(snapshot)
+			s.logger.Error("Timed out waiting for snapshot chunks, rejected snapshot",
+				"height", snapshot.Height, "format", snapshot.Format, "hash", fmt.Sprintf("%X", snapshot.Hash))
+
+		case err == errRejectSnapshot, err == errVerifyFailed:
+			s.snapshots.Reject(snapshot)
+			s.logger.Info("Snapshot rejected", "height", snapshot.Height, "format", snapshot.Format,
+				"hash", fmt.Sprintf("%X", snapshot.Hash), "err", err)
+
+		case err == errRejectFormat:
+			s.snapshots.RejectFormat(snapshot.Format)
+			s.logger.Info("Snapshot format rejected", "format", snapshot.Format)
+
+		case err == errRejectSender:
+			s.logger.Info("Snapshot senders rejected", "height", snapshot.Height, "format", snapshot.Format,
+				"hash", fmt.Sprintf("%X", snapshot.Hash))
+			for _, peer := range s.snapshots.GetPeers(snapshot) {
+				s.snapshots.RejectPeer(peer.ID())
+				s.logger.Info("Snapshot sender rejected", "peer", peer.ID())
+			}
+
+		default:
+			s.closeChunks(chunks)
+			return sm.State{}, fmt.Errorf("snapshot restoration failed: %v", err)
+		}
+
+		// Discard the snapshot's chunks, and try the next snapshot.
+		s.closeChunks(chunks)
+		snapshot = nil
+		chunks = nil
+	}
+}
+
+// closeChunks closes the chunk queue, removing its files.
+func (s *syncer) closeChunks(chunks *chunkQueue) {
+	if err := chunks.Close(); err != nil {
+		s.logger.Error("Failed to clean up chunk queue", "err", err)
+	}
+}
+
+// Sync restores the snapshot, fetching its chunks into the queue, and
+// returns the state at its height.
+func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, error) {
+	s.mtx.Lock()
+	if s.chunks != nil {
+		s.mtx.Unlock()
+		return sm.State{}, errors.New("a state sync is already in progress")
+	}
+	s.snapshot, s.chunks = snapshot, chunks
+	s.mtx.Unlock()
+	defer func() {
+		s.mtx.Lock()
+		s.snapshot, s.chunks = nil, nil
+		s.mtx.Unlock()
+	}()
+
+	// Offer the snapshot to the app.
+	if err := s.offerSnapshot(snapshot); err != nil {
+		return sm.State{}, err
+	}
+
+	// Spawn the chunk fetchers. They stop once the sync is done.
+	quit := make(chan struct{})
+	defer close(quit)
+	for i := 0; i < s.chunkFetchers; i++ {
+		go s.fetchChunks(snapshot, chunks, quit)
+	}
+
+	// Fetch the state while the chunks are fetched. the light client
+	// verified the app hash of the snapshot before, so the headers are
+	// likely known.
+	state, err := s.stateProvider.State(snapshot.Height)
+	if err != nil {
+		return sm.State{}, fmt.Errorf("failed to build new state: %v", err)
+	}
+	if !bytes.Equal(state.AppHash, snapshot.trustedAppHash) {
+		return sm.State{}, fmt.Errorf("app hash %X of the state doesn't match the trusted %X",
+			state.AppHash, snapshot.trustedAppHash)
+	}
+
+	if err := s.applyChunks(snapshot, chunks); err != nil {
+		return sm.State{}, err
+	}
+
+	// Make sure the app restored the trusted state.
+	if err := s.verifyApp(snapshot); err != nil {
+		return sm.State{}, err
+	}
+
+	s.logger.Info("Snapshot restored", "height", snapshot.Height, "format", snapshot.Format,
+		"hash", fmt.Sprintf("%X", snapshot.Hash))
+	return state, nil
+}
+
+// offerSnapshot offers the snapshot to the app, returning an error unless
+// the app accepts it.
+func (s *syncer) offerSnapshot(snapshot *snapshot) error {
+	s.logger.Info("Offering snapshot to ABCI app", "height", snapshot.Height,
+		"format", snapshot.Format, "hash", fmt.Sprintf("%X", snapshot.Hash))
+	resp, err := s.conn.OfferSnapshotSync(abci.RequestOfferSnapshot{
+		Snapshot: &abci.Snapshot{
+			Height:   snapshot.Height,
+			Format:   snapshot.Format,
+			Chunks:   snapshot.Chunks,
+			Hash:     snapshot.Hash,
+			Metadata: snapshot.Metadata,
+		},
+		AppHash: snapshot.trustedAppHash,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to offer snapshot: %v", err)
+	}
+	switch resp.Result {
+	case abci.ResponseOfferSnapshot_ACCEPT:
+		s.logger.Info("Snapshot accepted, restoring", "height", snapshot.Height,
+			"format", snapshot.Format, "hash", fmt.Sprintf("%X", snapshot.Hash))
+		return nil
+	case abci.ResponseOfferSnapshot_ABORT:
+		return errAbort
+	case abci.ResponseOfferSnapshot_REJECT:
+		return errRejectSnapshot
+	case abci.ResponseOfferSnapshot_REJECT_FORMAT:
+		return errRejectFormat
+	case abci.ResponseOfferSnapshot_REJECT_SENDER:
+		return errRejectSender
+	default:
+		return fmt.Errorf("unknown ResponseOfferSnapshot result %v", resp.Result)
+	}
+}
+
+// applyChunks applies the chunks of the queue to the app in order, until all
+// were applied or an error occurs.
+func (s *syncer) applyChunks(snapshot *snapshot, chunks *chunkQueue) error {
+	for {
+		chunk, err := chunks.Next(chunkTimeout)
+		if err == errDone {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		resp, err := s.conn.ApplySnapshotChunkSync(abci.RequestApplySnapshotChunk{
+			Index:  chunk.Index,
+			Chunk:  chunk.Chunk,
+			Sender: string(chunk.Sender),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply chunk %v: %v", chunk.Index, err)
+		}
+		s.logger.Info("Applied snapshot chunk to ABCI app", "height", chunk.Height,
+			"format", chunk.Format, "chunk", chunk.Index, "total", chunks.Size())
+
+		// Discard and refetch any chunks as requested by the app.
+		for _, index := range resp.RefetchChunks {
+			if err := chunks.Discard(index); err != nil {
+				return fmt.Errorf("failed to discard chunk %v: %v", index, err)
+			}
+		}
+
+		// Reject any senders as requested by the app, and refetch their
+		// chunks from other peers.
+		for _, sender := range resp.RejectSenders {
+			if sender == "" {
+				continue
+			}
+			peerID := p2p.ID(sender)
+			s.reportBadChunk(snapshot, peerID, chunk.Index)
+			s.snapshots.RejectPeer(peerID)
+			if err := chunks.DiscardSender(peerID); err != nil {
+				return fmt.Errorf("failed to reject sender: %v", err)
+			}
+		}
+
+		switch resp.Result {
+		case abci.ResponseApplySnapshotChunk_ACCEPT:
+			s.reportGoodChunk(snapshot, chunk.Sender)
+		case abci.ResponseApplySnapshotChunk_ABORT:
+			return errAbort
+		case abci.ResponseApplySnapshotChunk_RETRY:
+			chunks.Retry(chunk.Index)
+		case abci.ResponseApplySnapshotChunk_RETRY_SNAPSHOT:
+			return errRetrySnapshot
+		case abci.ResponseApplySnapshotChunk_REJECT_SNAPSHOT:
+			return errRejectSnapshot
+		default:
+			return fmt.Errorf("unknown ResponseApplySnapshotChunk result %v", resp.Result)
+		}
+	}
+}
+
+// fetchChunks requests the chunks allocated to it from random peers which
+// have the snapshot, and asks another peer when one doesn't send the chunk in
+// time. It stops when quit is closed.
+func (s *syncer) fetchChunks(snapshot *snapshot, chunks *chunkQueue, quit <-chan struct{}) {
+	for {
+		index, err := chunks.Allocate()
+		if err == errDone {
+			// Discarded chunks become allocatable again, so keep checking.
+			select {
+			case <-quit:
+				return
+			case <-time.After(fetchRetryInterval):
+				continue
+			}
+		}
+		if err != nil {
+			s.logger.Error("Failed to allocate chunk from queue", "err", err)
+			return
+		}
+
+		s.logger.Info("Fetching snapshot chunk", "height", snapshot.Height,
+			"format", snapshot.Format, "chunk", index, "total", chunks.Size())
+		s.requestChunk(snapshot, index)
+
+		timer := time.NewTimer(s.chunkRequestTimeout)
+		select {
+		case <-chunks.WaitFor(index):
+		case <-timer.C:
+			// Let another fetcher, or this one, ask a random peer again.
+			chunks.Unallocate(index)
+		case <-quit:
+			timer.Stop()
+			return
+		}
+		timer.Stop()
+	}
+}
+
+// requestChunk requests the chunk from a random peer which has the snapshot.
+func (s *syncer) requestChunk(snapshot *snapshot, index uint32) {
+	peer := s.snapshots.GetPeer(snapshot)
+	if peer == nil {
+		s.logger.Error("No valid peers found for snapshot", "height", snapshot.Height,
+			"format", snapshot.Format, "hash", fmt.Sprintf("%X", snapshot.Hash))
+		return
+	}
+	s.logger.Debug("Requesting snapshot chunk", "height", snapshot.Height,
+		"format", snapshot.Format, "chunk", index, "peer", peer.ID())
+	peer.Send(ChunkChannel, cdc.MustMarshalBinaryBare(&chunkRequestMessage{
+		Height: snapshot.Height,
+		Format: snapshot.Format,
+		Index:  index,
+	}))
+}
+
+// verifyApp checks that the app restored the snapshot's height and the
+// trusted app hash.
+func (s *syncer) verifyApp(snapshot *snapshot) error {
+	resp, err := s.connQuery.InfoSync(proxy.RequestInfo)
+	if err != nil {
+		return fmt.Errorf("failed to query ABCI app for app hash: %v", err)
+	}
+	if !bytes.Equal(snapshot.trustedAppHash, resp.LastBlockAppHash) {
+		s.logger.Error("App hash verification failed", "expected", fmt.Sprintf("%X", snapshot.trustedAppHash),
+			"actual", fmt.Sprintf("%X", resp.LastBlockAppHash))
+		return errVerifyFailed
+	}
+	if uint64(resp.LastBlockHeight) != uint64(snapshot.Height) {
+		s.logger.Error("App height verification failed", "expected", snapshot.Height,
+			"actual", resp.LastBlockHeight)
+		return errVerifyFailed
+	}
+	s.logger.Info("Verified ABCI app", "height", snapshot.Height,
+		"appHash", fmt.Sprintf("%X", snapshot.trustedAppHash))
+	return nil
+}
+
+// reportBadChunk reports the peer which sent a chunk the app rejected.
+func (s *syncer) reportBadChunk(snapshot *snapshot, peerID p2p.ID, index uint32) {
+	peer := s.snapshots.getPeer(snapshot, peerID)
+	if peer == nil {
+		return
+	}
+	s.behaviour.Errored(peer, p2p.ErrorBehaviourReport{
+		Reason:  p2p.ErrorPeerBehaviourBadSnapshotChunk,
+		Reactor: reactorName,
+		Height:  snapshot.Height,
+		Detail:  fmt.Sprintf("app rejected the sender of a chunk of snapshot %X, at chunk %v", snapshot.Hash, index),
+	})
+}
+
+// reportGoodChunk reports the peer which sent a chunk the app accepted.
+func (s *syncer) reportGoodChunk(snapshot *snapshot, peerID p2p.ID) {
+	peer := s.snapshots.getPeer(snapshot, peerID)
+	if peer == nil {
+		return
+	}
+	s.behaviour.Behaved(peer, p2p.GoodBehaviourReport{
+		Reason:  p2p.GoodPeerBehaviourSnapshotChunk,
+		Reactor: reactorName,
+		Height:  snapshot.Height,
+	})
+}
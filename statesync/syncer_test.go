@@ -0,0 +1,443 @@
+package statesync
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/p2p/dummy"
+	sm "github.com/tendermint/tendermint/state"
+)
+
+// testPeer is a peer which records the messages sent to it.
+type testPeer struct {
+	p2p.Peer
+	id   p2p.ID
+	sent chan testMessage
+}
+
+type testMessage struct {
+	chID byte
+	msg  StateSyncMessage
+}
+
+func newTestPeer(id string) *testPeer {
+	return &testPeer{
+		Peer: dummy.NewPeer(),
+		id:   p2p.ID(id),
+		sent: make(chan testMessage, 100),
+	}
+}
+
+func (p *testPeer) ID() p2p.ID {
+	return p.id
+}
+
+func (p *testPeer) Send(chID byte, msgBytes []byte) bool {
+	msg, err := decodeMsg(msgBytes)
+	if err != nil {
+		panic(err)
+	}
+	p.sent <- testMessage{chID, msg}
+	return true
+}
+
+func (p *testPeer) TrySend(chID byte, msgBytes []byte) bool {
+	return p.Send(chID, msgBytes)
+}
+
+// mockAppHash returns the app hash the mock state provider trusts at the
+// height.
+func mockAppHash(height int64) []byte {
+	return []byte{'a', 'p', 'p', byte(height)}
+}
+
+type mockStateProvider struct {
+	appHashErr error
+}
+
+func newMockStateProvider() *mockStateProvider {
+	return &mockStateProvider{}
+}
+
+func (sp *mockStateProvider) AppHash(height int64) ([]byte, error) {
+	if sp.appHashErr != nil {
+		return nil, sp.appHashErr
+	}
+	return mockAppHash(height), nil
+}
+
+func (sp *mockStateProvider) State(height int64) (sm.State, error) {
+	return sm.State{LastBlockHeight: height, AppHash: mockAppHash(height)}, nil
+}
+
+// testApp is an ABCI app serving and restoring snapshots, which answers with
+// the given fields and functions and records the chunks applied to it.
+type testApp struct {
+	mtx       sync.Mutex
+	snapshots []*abci.Snapshot
+	chunks    map[uint32][]byte
+	offer     func(abci.RequestOfferSnapshot) abci.ResponseOfferSnapshot
+	load      func(abci.RequestLoadSnapshotChunk) abci.ResponseLoadSnapshotChunk
+	apply     func(abci.RequestApplySnapshotChunk) abci.ResponseApplySnapshotChunk
+	applied   []abci.RequestApplySnapshotChunk
+	info      abci.ResponseInfo
+}
+
+func (app *testApp) Error() error { return nil }
+
+func (app *testApp) ListSnapshotsSync(abci.RequestListSnapshots) (*abci.ResponseListSnapshots, error) {
+	return &abci.ResponseListSnapshots{Snapshots: app.snapshots}, nil
+}
+
+func (app *testApp) OfferSnapshotSync(req abci.RequestOfferSnapshot) (*abci.ResponseOfferSnapshot, error) {
+	resp := app.offer(req)
+	return &resp, nil
+}
+
+func (app *testApp) LoadSnapshotChunkSync(req abci.RequestLoadSnapshotChunk) (*abci.ResponseLoadSnapshotChunk, error) {
+	if app.load != nil {
+		resp := app.load(req)
+		return &resp, nil
+	}
+	return &abci.ResponseLoadSnapshotChunk{Chunk: app.chunks[req.Chunk]}, nil
+}
+
+func (app *testApp) ApplySnapshotChunkSync(req abci.RequestApplySnapshotChunk) (*abci.ResponseApplySnapshotChunk, error) {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.applied = append(app.applied, req)
+	resp := abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}
+	if app.apply != nil {
+		resp = app.apply(req)
+	}
+	return &resp, nil
+}
+
+func (app *testApp) EchoSync(msg string) (*abci.ResponseEcho, error) {
+	return &abci.ResponseEcho{Message: msg}, nil
+}
+
+func (app *testApp) InfoSync(abci.RequestInfo) (*abci.ResponseInfo, error) {
+	return &app.info, nil
+}
+
+func (app *testApp) QuerySync(abci.RequestQuery) (*abci.ResponseQuery, error) {
+	return &abci.ResponseQuery{}, nil
+}
+
+func (app *testApp) appliedIndexes() []uint32 {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	indexes := make([]uint32, 0, len(app.applied))
+	for _, req := range app.applied {
+		indexes = append(indexes, req.Index)
+	}
+	return indexes
+}
+
+func acceptOffer(abci.RequestOfferSnapshot) abci.ResponseOfferSnapshot {
+	return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}
+}
+
+func setupSyncer(app *testApp, behaviour p2p.PeerBehaviour) *syncer {
+	return newSyncer(log.TestingLogger(), app, app, newMockStateProvider(), behaviour, "", 2, 500*time.Millisecond)
+}
+
+// serveChunks answers the chunk requests sent to the peer, until stop is
+// closed.
+func serveChunks(s *syncer, peer *testPeer, stop <-chan struct{}) {
+	for {
+		select {
+		case msg := <-peer.sent:
+			req, ok := msg.msg.(*chunkRequestMessage)
+			if !ok || msg.chID != ChunkChannel {
+				continue
+			}
+			s.AddChunk(&chunk{ // nolint: errcheck
+				Height: req.Height,
+				Format: req.Format,
+				Index:  req.Index,
+				Chunk:  []byte{byte(req.Index)},
+				Sender: peer.ID(),
+			})
+		case <-stop:
+			return
+		}
+	}
+}
+
+func TestSyncer_SyncAny(t *testing.T) {
+	app := &testApp{
+		offer: acceptOffer,
+		info:  abci.ResponseInfo{LastBlockHeight: 3, LastBlockAppHash: mockAppHash(3)},
+	}
+	behaviour := p2p.NewStorePeerBehaviour()
+	syncer := setupSyncer(app, behaviour)
+
+	peerA := newTestPeer("a")
+	peerB := newTestPeer("b")
+	stop := make(chan struct{})
+	defer close(stop)
+
+	// Adding a peer asks it for snapshots
+	syncer.AddPeer(peerA)
+	msg := <-peerA.sent
+	assert.Equal(t, SnapshotChannel, msg.chID)
+	assert.Equal(t, &snapshotsRequestMessage{}, msg.msg)
+
+	s := &snapshot{Height: 3, Format: 1, Chunks: 3, Hash: []byte{1, 2, 3}}
+	added, err := syncer.AddSnapshot(peerA, s)
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = syncer.AddSnapshot(peerB, s)
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	go serveChunks(syncer, peerA, stop)
+	go serveChunks(syncer, peerB, stop)
+
+	state, err := syncer.SyncAny(0, func() {})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, state.LastBlockHeight)
+	assert.Equal(t, mockAppHash(3), state.AppHash)
+	assert.Equal(t, []uint32{0, 1, 2}, app.appliedIndexes())
+
+	// Every applied chunk was reported as good
+	good := len(behaviour.GetBehaved(peerA.ID())) + len(behaviour.GetBehaved(peerB.ID()))
+	assert.Equal(t, 3, good)
+	assert.Empty(t, behaviour.GetErrored(peerA.ID()))
+	assert.Empty(t, behaviour.GetErrored(peerB.ID()))
+
+	// Chunks are ignored once the sync is done
+	_, err = syncer.AddChunk(&chunk{Height: 3, Format: 1, Index: 0, Chunk: []byte{0}})
+	require.Error(t, err)
+}
+
+func TestSyncer_SyncAny_noSnapshots(t *testing.T) {
+	syncer := setupSyncer(&testApp{offer: acceptOffer}, p2p.NewStorePeerBehaviour())
+	_, err := syncer.SyncAny(0, func() {})
+	assert.Equal(t, errNoSnapshots, err)
+}
+
+func TestSyncer_SyncAny_abort(t *testing.T) {
+	app := &testApp{offer: func(abci.RequestOfferSnapshot) abci.ResponseOfferSnapshot {
+		return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ABORT}
+	}}
+	syncer := setupSyncer(app, p2p.NewStorePeerBehaviour())
+	_, err := syncer.AddSnapshot(newTestPeer("a"), &snapshot{Height: 1, Format: 1, Chunks: 3, Hash: []byte{1}})
+	require.NoError(t, err)
+
+	_, err = syncer.SyncAny(0, func() {})
+	assert.Equal(t, errAbort, err)
+}
+
+func TestSyncer_SyncAny_reject(t *testing.T) {
+	testcases := map[string]struct {
+		result abci.ResponseOfferSnapshot_Result
+		// the snapshot which is accepted, and the offers expected until then
+		accept       string
+		expectOffers []string
+	}{
+		"reject snapshot": {abci.ResponseOfferSnapshot_REJECT, "1/1", []string{"3/2", "2/2", "2/1", "1/1"}},
+		"reject format":   {abci.ResponseOfferSnapshot_REJECT_FORMAT, "2/1", []string{"3/2", "2/1"}},
+		"reject sender":   {abci.ResponseOfferSnapshot_REJECT_SENDER, "1/1", []string{"3/2", "1/1"}},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var offers []string
+			app := &testApp{
+				offer: func(req abci.RequestOfferSnapshot) abci.ResponseOfferSnapshot {
+					key := fmt.Sprintf("%v/%v", req.Snapshot.Height, req.Snapshot.Format)
+					offers = append(offers, key)
+					if key == tc.accept {
+						return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}
+					}
+					return abci.ResponseOfferSnapshot{Result: tc.result}
+				},
+			}
+			syncer := setupSyncer(app, p2p.NewStorePeerBehaviour())
+
+			peerA := newTestPeer("a")
+			peerB := newTestPeer("b")
+			stop := make(chan struct{})
+			defer close(stop)
+			_, err := syncer.AddSnapshot(peerA, &snapshot{Height: 3, Format: 2, Chunks: 1, Hash: []byte{1}})
+			require.NoError(t, err)
+			_, err = syncer.AddSnapshot(peerA, &snapshot{Height: 2, Format: 2, Chunks: 1, Hash: []byte{1}})
+			require.NoError(t, err)
+			_, err = syncer.AddSnapshot(peerA, &snapshot{Height: 2, Format: 1, Chunks: 1, Hash: []byte{1}})
+			require.NoError(t, err)
+			_, err = syncer.AddSnapshot(peerB, &snapshot{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}})
+			require.NoError(t, err)
+			go serveChunks(syncer, peerA, stop)
+			go serveChunks(syncer, peerB, stop)
+
+			height := int64(tc.accept[0] - '0')
+			app.info = abci.ResponseInfo{LastBlockHeight: height, LastBlockAppHash: mockAppHash(height)}
+			state, err := syncer.SyncAny(0, func() {})
+			require.NoError(t, err)
+			assert.Equal(t, height, state.LastBlockHeight)
+			assert.Equal(t, tc.expectOffers, offers)
+		})
+	}
+}
+
+func TestSyncer_applyChunks_rejectSenders(t *testing.T) {
+	// The app rejects the sender of chunk 1 when applying it, and refetches
+	// the chunk, which makes it and the other chunks of that sender be
+	// fetched from the other peer.
+	rejected := false
+	app := &testApp{
+		offer: acceptOffer,
+		apply: func(req abci.RequestApplySnapshotChunk) abci.ResponseApplySnapshotChunk {
+			if req.Index == 1 && !rejected {
+				rejected = true
+				return abci.ResponseApplySnapshotChunk{
+					Result:        abci.ResponseApplySnapshotChunk_RETRY,
+					RefetchChunks: []uint32{1},
+					RejectSenders: []string{req.Sender},
+				}
+			}
+			return abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}
+		},
+		info: abci.ResponseInfo{LastBlockHeight: 3, LastBlockAppHash: mockAppHash(3)},
+	}
+	behaviour := p2p.NewStorePeerBehaviour()
+	syncer := setupSyncer(app, behaviour)
+
+	peerA := newTestPeer("a")
+	peerB := newTestPeer("b")
+	stop := make(chan struct{})
+	defer close(stop)
+	s := &snapshot{Height: 3, Format: 1, Chunks: 3, Hash: []byte{1}}
+	_, err := syncer.AddSnapshot(peerA, s)
+	require.NoError(t, err)
+	_, err = syncer.AddSnapshot(peerB, s)
+	require.NoError(t, err)
+	go serveChunks(syncer, peerA, stop)
+	go serveChunks(syncer, peerB, stop)
+
+	_, err = syncer.SyncAny(0, func() {})
+	require.NoError(t, err)
+
+	indexes := app.appliedIndexes()
+	assert.Equal(t, []uint32{0, 1, 1, 2}, indexes)
+
+	// Exactly one sender was rejected and reported, and it sent none of the
+	// chunks applied after the rejection.
+	var rejectedPeer p2p.ID
+	for _, id := range []p2p.ID{peerA.ID(), peerB.ID()} {
+		if reports := behaviour.GetErrored(id); len(reports) > 0 {
+			require.Empty(t, rejectedPeer)
+			require.Len(t, reports, 1)
+			assert.Equal(t, p2p.ErrorPeerBehaviourBadSnapshotChunk, reports[0].Reason)
+			assert.Equal(t, reactorName, reports[0].Reactor)
+			rejectedPeer = id
+		}
+	}
+	require.NotEmpty(t, rejectedPeer)
+	app.mtx.Lock()
+	for _, req := range app.applied[2:] {
+		assert.NotEqual(t, string(rejectedPeer), req.Sender)
+	}
+	app.mtx.Unlock()
+}
+
+func TestSyncer_applyChunks_results(t *testing.T) {
+	testcases := map[string]struct {
+		result    abci.ResponseApplySnapshotChunk_Result
+		expectErr error
+	}{
+		"abort":           {abci.ResponseApplySnapshotChunk_ABORT, errAbort},
+		"retry snapshot":  {abci.ResponseApplySnapshotChunk_RETRY_SNAPSHOT, errRetrySnapshot},
+		"reject snapshot": {abci.ResponseApplySnapshotChunk_REJECT_SNAPSHOT, errRejectSnapshot},
+		"unknown":         {abci.ResponseApplySnapshotChunk_UNKNOWN, nil},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			app := &testApp{
+				apply: func(abci.RequestApplySnapshotChunk) abci.ResponseApplySnapshotChunk {
+					return abci.ResponseApplySnapshotChunk{Result: tc.result}
+				},
+			}
+			syncer := setupSyncer(app, p2p.NewStorePeerBehaviour())
+			s := &snapshot{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}}
+			queue, err := newChunkQueue(s, "")
+			require.NoError(t, err)
+			defer queue.Close() // nolint: errcheck
+			_, err = queue.Add(&chunk{Height: 1, Format: 1, Index: 0, Chunk: []byte{0}})
+			require.NoError(t, err)
+
+			err = syncer.applyChunks(s, queue)
+			require.Error(t, err)
+			if tc.expectErr != nil {
+				assert.Equal(t, tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestSyncer_verifyApp(t *testing.T) {
+	s := &snapshot{Height: 3, Format: 1, Chunks: 5, Hash: []byte{1, 2, 3}, trustedAppHash: []byte("app_hash")}
+
+	testcases := map[string]struct {
+		info      abci.ResponseInfo
+		expectErr error
+	}{
+		"verified": {abci.ResponseInfo{LastBlockHeight: 3, LastBlockAppHash: []byte("app_hash")}, nil},
+		"invalid height": {abci.ResponseInfo{
+			LastBlockHeight:  5,
+			LastBlockAppHash: []byte("app_hash"),
+		}, errVerifyFailed},
+		"invalid hash": {abci.ResponseInfo{
+			LastBlockHeight:  3,
+			LastBlockAppHash: []byte("xxx"),
+		}, errVerifyFailed},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			syncer := setupSyncer(&testApp{info: tc.info}, p2p.NewStorePeerBehaviour())
+			err := syncer.verifyApp(s)
+			assert.Equal(t, tc.expectErr, err)
+		})
+	}
+}
+
+func TestSyncer_Sync_stateProviderError(t *testing.T) {
+	app := &testApp{offer: acceptOffer}
+	sp := &failingStateProvider{err: errors.New("boom")}
+	syncer := newSyncer(log.TestingLogger(), app, app, sp, p2p.NewStorePeerBehaviour(), "", 1, time.Second)
+
+	s := &snapshot{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}, trustedAppHash: mockAppHash(1)}
+	queue, err := newChunkQueue(s, "")
+	require.NoError(t, err)
+	defer queue.Close() // nolint: errcheck
+
+	_, err = syncer.Sync(s, queue)
+	require.Error(t, err)
+	assert.Empty(t, app.appliedIndexes())
+}
+
+type failingStateProvider struct {
+	err error
+}
+
+func (sp *failingStateProvider) AppHash(height int64) ([]byte, error) {
+	return mockAppHash(height), nil
+}
+
+func (sp *failingStateProvider) State(height int64) (sm.State, error) {
+	return sm.State{}, sp.err
+}
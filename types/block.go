@@ -110,6 +110,9 @@ func (b *Block) ValidateBasic() error {
 		if err := b.LastCommit.ValidateBasic(); err != nil {
 			return fmt.Errorf("Wrong LastCommit")
 		}
+		if b.LastCommit.hasExtensions() {
+			return errors.New("LastCommit has vote extensions")
+		}
 	}
 	if err := ValidateHash(b.LastCommitHash); err != nil {
 		return fmt.Errorf("Wrong Header.LastCommitHash: %v", err)
@@ -300,15 +303,16 @@ func (b *Block) Unmarshal(bs []byte) error {
 
 //-----------------------------------------------------------------------------
 
-// MaxDataBytes returns the maximum size of block's data.
+// MaxDataBytes returns the maximum size of block's data, given the size of
+// its evidence (see EvidenceList.ByteSize).
 //
 // XXX: Panics on negative result.
-func MaxDataBytes(maxBytes int64, valsCount, evidenceCount int) int64 {
+func MaxDataBytes(maxBytes int64, valsCount int, evidenceBytes int64) int64 {
 	maxDataBytes := maxBytes -
 		MaxAminoOverheadForBlock -
 		MaxHeaderBytes -
 		int64(valsCount)*MaxVoteBytes -
-		int64(evidenceCount)*MaxEvidenceBytes
+		evidenceBytes
 
 	if maxDataBytes < 0 {
 		panic(fmt.Sprintf(
@@ -323,12 +327,11 @@ func MaxDataBytes(maxBytes int64, valsCount, evidenceCount int) int64 {
 }
 
 // MaxDataBytesUnknownEvidence returns the maximum size of block's data when
-// evidence count is unknown. MaxEvidencePerBlock will be used for the size
-// of evidence.
+// evidence is unknown. maxEvidenceBytes (EvidenceParams.MaxBytes) will be used
+// for the size of evidence.
 //
 // XXX: Panics on negative result.
-func MaxDataBytesUnknownEvidence(maxBytes int64, valsCount int) int64 {
-	_, maxEvidenceBytes := MaxEvidencePerBlock(maxBytes)
+func MaxDataBytesUnknownEvidence(maxBytes int64, valsCount int, maxEvidenceBytes int64) int64 {
 	maxDataBytes := maxBytes -
 		MaxAminoOverheadForBlock -
 		MaxHeaderBytes -
@@ -647,6 +650,32 @@ func (commit *Commit) ValidateBasic() error {
 	return nil
 }
 
+// WithoutExtensions returns a copy of the commit without the vote extensions
+// of its precommits, as included in blocks. Returns the commit itself if it
+// has none.
+func (commit *Commit) WithoutExtensions() *Commit {
+	if !commit.hasExtensions() {
+		return commit
+	}
+	precommits := make([]*CommitSig, len(commit.Precommits))
+	for i, precommit := range commit.Precommits {
+		precommits[i] = precommit.toVote().WithoutExtension().CommitSig()
+	}
+	return NewCommit(commit.BlockID, precommits)
+}
+
+func (commit *Commit) hasExtensions() bool {
+	if commit == nil {
+		return false
+	}
+	for _, precommit := range commit.Precommits {
+		if precommit != nil && (len(precommit.Extension) > 0 || len(precommit.ExtensionSignature) > 0) {
+			return true
+		}
+	}
+	return false
+}
+
 // Hash returns the hash of the commit
 func (commit *Commit) Hash() cmn.HexBytes {
 	if commit == nil {
@@ -81,6 +81,14 @@ func TestBlockValidateBasic(t *testing.T) {
 		{"Tampered EvidenceHash", func(blk *Block) {
 			blk.EvidenceHash = []byte("something else")
 		}, true},
+		{"LastCommit with vote extensions", func(blk *Block) {
+			precommits := append([]*CommitSig{}, blk.LastCommit.Precommits...)
+			extended := *precommits[0]
+			extended.Extension, extended.ExtensionSignature = []byte("ext"), []byte("sig")
+			precommits[0] = &extended
+			blk.LastCommit = NewCommit(blk.LastCommit.BlockID, precommits)
+			blk.LastCommitHash = blk.LastCommit.Hash()
+		}, true},
 	}
 	for i, tc := range testCases {
 		t.Run(tc.testName, func(t *testing.T) {
@@ -210,7 +218,7 @@ func TestCommit(t *testing.T) {
 	require.NotNil(t, commit.BitArray())
 	assert.Equal(t, cmn.NewBitArray(10).Size(), commit.BitArray().Size())
 
-	assert.Equal(t, voteSet.GetByIndex(0), commit.GetByIndex(0))
+	assert.Equal(t, voteSet.GetByIndex(0).WithoutExtension(), commit.GetByIndex(0))
 	assert.True(t, commit.IsCommit())
 }
 
@@ -290,7 +298,7 @@ func TestBlockMaxDataBytes(t *testing.T) {
 	testCases := []struct {
 		maxBytes      int64
 		valsCount     int
-		evidenceCount int
+		evidenceBytes int64
 		panics        bool
 		result        int64
 	}{
@@ -299,17 +307,19 @@ func TestBlockMaxDataBytes(t *testing.T) {
 		2: {886, 1, 0, true, 0},
 		3: {887, 1, 0, false, 0},
 		4: {888, 1, 0, false, 1},
+		5: {988, 1, 100, false, 1},
+		6: {986, 1, 100, true, 0},
 	}
 
 	for i, tc := range testCases {
 		if tc.panics {
 			assert.Panics(t, func() {
-				MaxDataBytes(tc.maxBytes, tc.valsCount, tc.evidenceCount)
+				MaxDataBytes(tc.maxBytes, tc.valsCount, tc.evidenceBytes)
 			}, "#%v", i)
 		} else {
 			assert.Equal(t,
 				tc.result,
-				MaxDataBytes(tc.maxBytes, tc.valsCount, tc.evidenceCount),
+				MaxDataBytes(tc.maxBytes, tc.valsCount, tc.evidenceBytes),
 				"#%v", i)
 		}
 	}
@@ -317,27 +327,28 @@ func TestBlockMaxDataBytes(t *testing.T) {
 
 func TestBlockMaxDataBytesUnknownEvidence(t *testing.T) {
 	testCases := []struct {
-		maxBytes  int64
-		valsCount int
-		panics    bool
-		result    int64
+		maxBytes         int64
+		valsCount        int
+		maxEvidenceBytes int64
+		panics           bool
+		result           int64
 	}{
-		0: {-10, 1, true, 0},
-		1: {10, 1, true, 0},
-		2: {984, 1, true, 0},
-		3: {985, 1, false, 0},
-		4: {986, 1, false, 1},
+		0: {-10, 1, 0, true, 0},
+		1: {10, 1, 0, true, 0},
+		2: {984, 1, 98, true, 0},
+		3: {985, 1, 98, false, 0},
+		4: {986, 1, 98, false, 1},
 	}
 
 	for i, tc := range testCases {
 		if tc.panics {
 			assert.Panics(t, func() {
-				MaxDataBytesUnknownEvidence(tc.maxBytes, tc.valsCount)
+				MaxDataBytesUnknownEvidence(tc.maxBytes, tc.valsCount, tc.maxEvidenceBytes)
 			}, "#%v", i)
 		} else {
 			assert.Equal(t,
 				tc.result,
-				MaxDataBytesUnknownEvidence(tc.maxBytes, tc.valsCount),
+				MaxDataBytesUnknownEvidence(tc.maxBytes, tc.valsCount, tc.maxEvidenceBytes),
 				"#%v", i)
 		}
 	}
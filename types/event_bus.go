@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/eventlog"
 	"github.com/tendermint/tendermint/libs/log"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 )
@@ -31,7 +32,8 @@ type Subscription interface {
 // EventBus to ensure correct data types.
 type EventBus struct {
 	cmn.BaseService
-	pubsub *tmpubsub.Server
+	pubsub   *tmpubsub.Server
+	eventLog *eventlog.Log
 }
 
 // NewEventBus returns a new event bus.
@@ -53,6 +55,18 @@ func (b *EventBus) SetLogger(l log.Logger) {
 	b.pubsub.SetLogger(l.With("module", "pubsub"))
 }
 
+// SetEventLog sets the log the events are added to before being published,
+// with their cursor as the EventCursorKey tag. It must be called before the
+// event bus is started.
+func (b *EventBus) SetEventLog(lg *eventlog.Log) {
+	b.eventLog = lg
+}
+
+// EventLog returns the event log, or nil if there is none.
+func (b *EventBus) EventLog() *eventlog.Log {
+	return b.eventLog
+}
+
 func (b *EventBus) OnStart() error {
 	return b.pubsub.Start()
 }
@@ -73,6 +87,14 @@ func (b *EventBus) Subscribe(ctx context.Context, subscriber string, query tmpub
 	return b.pubsub.Subscribe(ctx, subscriber, query, outCapacity...)
 }
 
+// SubscribeWithOverflow subscribes with the policy telling what happens to the
+// events published while the channel of the subscription is full. See
+// tmpubsub.Server#SubscribeWithOverflow.
+func (b *EventBus) SubscribeWithOverflow(ctx context.Context, subscriber string, query tmpubsub.Query,
+	outCapacity int, policy tmpubsub.OverflowPolicy) (*tmpubsub.Subscription, error) {
+	return b.pubsub.SubscribeWithOverflow(ctx, subscriber, query, outCapacity, policy)
+}
+
 // This method can be used for a local consensus explorer and synchronous
 // testing. Do not use for for public facing / untrusted subscriptions!
 func (b *EventBus) SubscribeUnbuffered(ctx context.Context, subscriber string, query tmpubsub.Query) (Subscription, error) {
@@ -90,10 +112,26 @@ func (b *EventBus) UnsubscribeAll(ctx context.Context, subscriber string) error
 func (b *EventBus) Publish(eventType string, eventData TMEventData) error {
 	// no explicit deadline for publishing events
 	ctx := context.Background()
-	b.pubsub.PublishWithTags(ctx, eventData, map[string]string{EventTypeKey: eventType})
+	b.publish(ctx, eventData, map[string]string{EventTypeKey: eventType})
 	return nil
 }
 
+// publish adds the event to the event log, if any, and publishes it with the
+// tags and its cursor.
+func (b *EventBus) publish(ctx context.Context, data TMEventData, tags map[string]string) {
+	if b.eventLog != nil {
+		cursor := b.eventLog.Add(data, tags)
+		// the log keeps the tags, copy them
+		tagsWithCursor := make(map[string]string, len(tags)+1)
+		for k, v := range tags {
+			tagsWithCursor[k] = v
+		}
+		tagsWithCursor[EventCursorKey] = cursor.String()
+		tags = tagsWithCursor
+	}
+	b.pubsub.PublishWithTags(ctx, data, tags)
+}
+
 func (b *EventBus) validateAndStringifyTags(tags []cmn.KVPair, logger log.Logger) map[string]string {
 	result := make(map[string]string)
 	for _, tag := range tags {
@@ -118,7 +156,7 @@ func (b *EventBus) PublishEventNewBlock(data EventDataNewBlock) error {
 	logIfTagExists(EventTypeKey, tags, b.Logger)
 	tags[EventTypeKey] = EventNewBlock
 
-	b.pubsub.PublishWithTags(ctx, data, tags)
+	b.publish(ctx, data, tags)
 	return nil
 }
 
@@ -134,7 +172,7 @@ func (b *EventBus) PublishEventNewBlockHeader(data EventDataNewBlockHeader) erro
 	logIfTagExists(EventTypeKey, tags, b.Logger)
 	tags[EventTypeKey] = EventNewBlockHeader
 
-	b.pubsub.PublishWithTags(ctx, data, tags)
+	b.publish(ctx, data, tags)
 	return nil
 }
 
@@ -150,22 +188,32 @@ func (b *EventBus) PublishEventValidBlock(data EventDataRoundState) error {
 // predefined tags (EventTypeKey, TxHashKey). Existing tags with the same names
 // will be overwritten.
 func (b *EventBus) PublishEventTx(data EventDataTx) error {
+	return b.publishTxResult(EventTx, data, data.TxResult)
+}
+
+// PublishEventTxProvisional publishes the provisional result of a tx with the
+// same tags as PublishEventTx.
+func (b *EventBus) PublishEventTxProvisional(data EventDataTxProvisional) error {
+	return b.publishTxResult(EventTxProvisional, data, data.TxResult)
+}
+
+func (b *EventBus) publishTxResult(eventType string, data TMEventData, txResult TxResult) error {
 	// no explicit deadline for publishing events
 	ctx := context.Background()
 
-	tags := b.validateAndStringifyTags(data.Result.Tags, b.Logger.With("tx", data.Tx))
+	tags := b.validateAndStringifyTags(txResult.Result.Tags, b.Logger.With("tx", txResult.Tx))
 
 	// add predefined tags
 	logIfTagExists(EventTypeKey, tags, b.Logger)
-	tags[EventTypeKey] = EventTx
+	tags[EventTypeKey] = eventType
 
 	logIfTagExists(TxHashKey, tags, b.Logger)
-	tags[TxHashKey] = fmt.Sprintf("%X", data.Tx.Hash())
+	tags[TxHashKey] = fmt.Sprintf("%X", txResult.Tx.Hash())
 
 	logIfTagExists(TxHeightKey, tags, b.Logger)
-	tags[TxHeightKey] = fmt.Sprintf("%d", data.Height)
+	tags[TxHeightKey] = fmt.Sprintf("%d", txResult.Height)
 
-	b.pubsub.PublishWithTags(ctx, data, tags)
+	b.publish(ctx, data, tags)
 	return nil
 }
 
@@ -209,6 +257,21 @@ func (b *EventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpd
 	return b.Publish(EventValidatorSetUpdates, data)
 }
 
+// PublishEventTxEvicted publishes the evicted tx with the predefined tags
+// EventTypeKey and TxHashKey, so the evictions of a tx can be queried by its
+// hash.
+func (b *EventBus) PublishEventTxEvicted(data EventDataTxEvicted) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+
+	tags := map[string]string{
+		EventTypeKey: EventTxEvicted,
+		TxHashKey:    fmt.Sprintf("%X", data.Tx.Hash()),
+	}
+	b.publish(ctx, data, tags)
+	return nil
+}
+
 func logIfTagExists(tag string, tags map[string]string, logger log.Logger) {
 	if value, ok := tags[tag]; ok {
 		logger.Error("Found predefined tag (value will be overwritten)", "tag", tag, "value", value)
@@ -285,3 +348,11 @@ func (NopEventBus) PublishEventLock(data EventDataRoundState) error {
 func (NopEventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpdates) error {
 	return nil
 }
+
+func (NopEventBus) PublishEventTxEvicted(data EventDataTxEvicted) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventTxProvisional(data EventDataTxProvisional) error {
+	return nil
+}
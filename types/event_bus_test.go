@@ -11,6 +11,7 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/eventlog"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
 )
@@ -55,6 +56,84 @@ func TestEventBusPublishEventTx(t *testing.T) {
 	}
 }
 
+func TestEventBusPublishEventTxProvisional(t *testing.T) {
+	eventBus := NewEventBus()
+	err := eventBus.Start()
+	require.NoError(t, err)
+	defer eventBus.Stop()
+
+	tx := Tx("foo")
+	result := abci.ResponseDeliverTx{Data: []byte("bar"), Tags: []cmn.KVPair{{Key: []byte("baz"), Value: []byte("1")}}}
+
+	// The same tags as the Tx event
+	query := fmt.Sprintf("tm.event='TxProvisional' AND tx.height=1 AND tx.hash='%X' AND baz=1", tx.Hash())
+	txsSub, err := eventBus.Subscribe(context.Background(), "test", tmquery.MustParse(query))
+	require.NoError(t, err)
+
+	data := EventDataTxProvisional{TxResult{Height: 1, Index: 0, Tx: tx, Result: result}}
+	err = eventBus.PublishEventTxProvisional(data)
+	assert.NoError(t, err)
+
+	select {
+	case msg := <-txsSub.Out():
+		assert.Equal(t, data, msg.Data())
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive a provisional transaction after 1 sec.")
+	}
+}
+
+func TestEventBusPublishEventTxEvicted(t *testing.T) {
+	eventBus := NewEventBus()
+	err := eventBus.Start()
+	require.NoError(t, err)
+	defer eventBus.Stop()
+
+	tx := Tx("foo")
+	query := fmt.Sprintf("tm.event='TxEvicted' AND tx.hash='%X'", tx.Hash())
+	txsSub, err := eventBus.Subscribe(context.Background(), "test", tmquery.MustParse(query))
+	require.NoError(t, err)
+
+	err = eventBus.PublishEventTxEvicted(EventDataTxEvicted{Tx: tx, Reason: "expired"})
+	assert.NoError(t, err)
+
+	select {
+	case msg := <-txsSub.Out():
+		assert.Equal(t, EventDataTxEvicted{Tx: tx, Reason: "expired"}, msg.Data())
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive an evicted transaction after 1 sec.")
+	}
+}
+
+func TestEventBusEventLog(t *testing.T) {
+	eventBus := NewEventBus()
+	eventBus.SetEventLog(eventlog.NewLog(time.Minute, 0))
+	err := eventBus.Start()
+	require.NoError(t, err)
+	defer eventBus.Stop()
+
+	tx := Tx("foo")
+	query := fmt.Sprintf("tm.event='TxEvicted' AND tx.hash='%X'", tx.Hash())
+	txsSub, err := eventBus.Subscribe(context.Background(), "test", tmquery.MustParse(query))
+	require.NoError(t, err)
+
+	data := EventDataTxEvicted{Tx: tx, Reason: "expired"}
+	err = eventBus.PublishEventTxEvicted(data)
+	assert.NoError(t, err)
+
+	res := eventBus.EventLog().After(0, nil, 0)
+	require.Len(t, res.Items, 1)
+	assert.Equal(t, data, res.Items[0].Data)
+	assert.Equal(t, EventTxEvicted, res.Items[0].Tags[EventTypeKey])
+
+	select {
+	case msg := <-txsSub.Out():
+		assert.Equal(t, data, msg.Data())
+		assert.Equal(t, res.Items[0].Cursor.String(), msg.Tags()[EventCursorKey])
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive an evicted transaction after 1 sec.")
+	}
+}
+
 func TestEventBusPublishEventNewBlock(t *testing.T) {
 	eventBus := NewEventBus()
 	err := eventBus.Start()
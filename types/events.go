@@ -21,6 +21,17 @@ const (
 	EventTx                  = "Tx"
 	EventValidatorSetUpdates = "ValidatorSetUpdates"
 
+	// Mempool events.
+	// Fired when the mempool evicts a tx before it's committed (e.g. it has
+	// expired).
+	EventTxEvicted = "TxEvicted"
+
+	// Block execution events.
+	// Fired for each tx as soon as the app delivered it, before the block is
+	// committed (if enabled, see BlockExecutorWithProvisionalTxEvents). The
+	// result is provisional: the Tx event confirms it once committed.
+	EventTxProvisional = "TxProvisional"
+
 	// Internal consensus events.
 	// These are used for testing the consensus state machine.
 	// They can also be used to build real-time consensus visualizers.
@@ -56,6 +67,8 @@ func RegisterEventDatas(cdc *amino.Codec) {
 	cdc.RegisterConcrete(EventDataCompleteProposal{}, "tendermint/event/CompleteProposal", nil)
 	cdc.RegisterConcrete(EventDataVote{}, "tendermint/event/Vote", nil)
 	cdc.RegisterConcrete(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates", nil)
+	cdc.RegisterConcrete(EventDataTxEvicted{}, "tendermint/event/TxEvicted", nil)
+	cdc.RegisterConcrete(EventDataTxProvisional{}, "tendermint/event/TxProvisional", nil)
 	cdc.RegisterConcrete(EventDataString(""), "tendermint/event/ProposalString", nil)
 }
 
@@ -116,8 +129,28 @@ type EventDataVote struct {
 
 type EventDataString string
 
+// EventDataValidatorSetUpdates is fired when the EndBlock of the block at
+// Height updates the validator set, effective at Height+2.
 type EventDataValidatorSetUpdates struct {
+	Height           int64        `json:"height"`
 	ValidatorUpdates []*Validator `json:"validator_updates"`
+
+	// The changes of voting power the updates made.
+	Changes []ValidatorPowerChange `json:"changes"`
+}
+
+// EventDataTxEvicted is fired for each tx the mempool evicts, with the
+// reason of the eviction (e.g. "expired").
+type EventDataTxEvicted struct {
+	Tx     Tx     `json:"tx"`
+	Reason string `json:"reason"`
+}
+
+// EventDataTxProvisional is fired for each tx of a block being executed, with
+// its DeliverTx result, before the block is committed. If the block isn't
+// committed (e.g. the node crashed), no Tx event follows.
+type EventDataTxProvisional struct {
+	TxResult
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -133,6 +166,10 @@ const (
 	// TxHeightKey is a reserved key, used to specify transaction block's height.
 	// see EventBus#PublishEventTx
 	TxHeightKey = "tx.height"
+	// EventCursorKey is a reserved key, used to specify the cursor of the
+	// event in the event log, if the event bus has one.
+	// see EventBus#SetEventLog
+	EventCursorKey = "tm.cursor"
 )
 
 var (
@@ -147,6 +184,8 @@ var (
 	EventQueryTimeoutPropose      = QueryForEvent(EventTimeoutPropose)
 	EventQueryTimeoutWait         = QueryForEvent(EventTimeoutWait)
 	EventQueryTx                  = QueryForEvent(EventTx)
+	EventQueryTxEvicted           = QueryForEvent(EventTxEvicted)
+	EventQueryTxProvisional       = QueryForEvent(EventTxProvisional)
 	EventQueryUnlock              = QueryForEvent(EventUnlock)
 	EventQueryValidatorSetUpdates = QueryForEvent(EventValidatorSetUpdates)
 	EventQueryValidBlock          = QueryForEvent(EventValidBlock)
@@ -166,9 +205,15 @@ type BlockEventPublisher interface {
 	PublishEventNewBlock(block EventDataNewBlock) error
 	PublishEventNewBlockHeader(header EventDataNewBlockHeader) error
 	PublishEventTx(EventDataTx) error
+	PublishEventTxProvisional(EventDataTxProvisional) error
 	PublishEventValidatorSetUpdates(EventDataValidatorSetUpdates) error
 }
 
 type TxEventPublisher interface {
 	PublishEventTx(EventDataTx) error
 }
+
+// TxEvictedEventPublisher publishes the txs evicted from the mempool.
+type TxEvictedEventPublisher interface {
+	PublishEventTxEvicted(EventDataTxEvicted) error
+}
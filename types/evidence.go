@@ -3,6 +3,7 @@ package types
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/tendermint/tendermint/crypto/tmhash"
@@ -11,10 +12,12 @@ import (
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/merkle"
+	cmn "github.com/tendermint/tendermint/libs/common"
 )
 
 const (
-	// MaxEvidenceBytes is a maximum size of any evidence (including amino overhead).
+	// MaxEvidenceBytes is the maximum size of a DuplicateVoteEvidence
+	// (including amino overhead).
 	MaxEvidenceBytes int64 = 484
 )
 
@@ -36,8 +39,8 @@ func (err *ErrEvidenceInvalid) Error() string {
 
 // ErrEvidenceOverflow is for when there is too much evidence in a block.
 type ErrEvidenceOverflow struct {
-	MaxNum int64
-	GotNum int64
+	MaxBytes int64
+	GotBytes int64
 }
 
 // NewErrEvidenceOverflow returns a new ErrEvidenceOverflow where got > max.
@@ -47,7 +50,7 @@ func NewErrEvidenceOverflow(max, got int64) *ErrEvidenceOverflow {
 
 // Error returns a string representation of the error.
 func (err *ErrEvidenceOverflow) Error() string {
-	return fmt.Sprintf("Too much evidence: Max %d, got %d", err.MaxNum, err.GotNum)
+	return fmt.Sprintf("Too much evidence: Max %d bytes, got %d bytes", err.MaxBytes, err.GotBytes)
 }
 
 //-------------------------------------------
@@ -55,6 +58,7 @@ func (err *ErrEvidenceOverflow) Error() string {
 // Evidence represents any provable malicious activity by a validator
 type Evidence interface {
 	Height() int64                                     // height of the equivocation
+	Time() time.Time                                   // time of the equivocation
 	Address() []byte                                   // address of the equivocating validator
 	Bytes() []byte                                     // bytes which compromise the evidence
 	Hash() []byte                                      // hash of the evidence
@@ -65,9 +69,24 @@ type Evidence interface {
 	String() string
 }
 
+// CompositeEvidence is evidence of the misbehaviour of several validators at
+// once, whose Address is nil. It's verified against the header we committed
+// at the height it conflicts with, and the validators at Height.
+type CompositeEvidence interface {
+	Evidence
+
+	// height of the header the evidence conflicts with
+	ConflictingHeight() int64
+	// verify the evidence against the committed header and the validators
+	VerifyComposite(committedHeader *Header, valSet *ValidatorSet) error
+	// validators of valSet which misbehaved
+	ByzantineValidators(valSet *ValidatorSet) []*Validator
+}
+
 func RegisterEvidences(cdc *amino.Codec) {
 	cdc.RegisterInterface((*Evidence)(nil), nil)
 	cdc.RegisterConcrete(&DuplicateVoteEvidence{}, "tendermint/DuplicateVoteEvidence", nil)
+	cdc.RegisterConcrete(&LightClientAttackEvidence{}, "tendermint/LightClientAttackEvidence", nil)
 }
 
 func RegisterMockEvidences(cdc *amino.Codec) {
@@ -76,21 +95,6 @@ func RegisterMockEvidences(cdc *amino.Codec) {
 	cdc.RegisterConcrete(MockBadEvidence{}, "tendermint/MockBadEvidence", nil)
 }
 
-const (
-	MaxEvidenceBytesDenominator = 10
-)
-
-// MaxEvidencePerBlock returns the maximum number of evidences
-// allowed in the block and their maximum total size (limitted to 1/10th
-// of the maximum block size).
-// TODO: change to a constant, or to a fraction of the validator set size.
-// See https://github.com/tendermint/tendermint/issues/2590
-func MaxEvidencePerBlock(blockMaxBytes int64) (int64, int64) {
-	maxBytes := blockMaxBytes / MaxEvidenceBytesDenominator
-	maxNum := maxBytes / MaxEvidenceBytes
-	return maxNum, maxBytes
-}
-
 //-------------------------------------------
 
 // DuplicateVoteEvidence contains evidence a validator signed two conflicting
@@ -114,6 +118,11 @@ func (dve *DuplicateVoteEvidence) Height() int64 {
 	return dve.VoteA.Height
 }
 
+// Time returns the time of the first vote, as signed by the validator.
+func (dve *DuplicateVoteEvidence) Time() time.Time {
+	return dve.VoteA.Timestamp
+}
+
 // Address returns the address of the validator.
 func (dve *DuplicateVoteEvidence) Address() []byte {
 	return dve.PubKey.Address()
@@ -203,6 +212,155 @@ func (dve *DuplicateVoteEvidence) ValidateBasic() error {
 
 //-----------------------------------------------------------------
 
+// LightClientAttackEvidence contains evidence validators signed a header
+// conflicting with the one we committed at the same height, e.g. to fool light
+// clients into trusting another chain. CommonHeight is the last height the
+// chains agree on: the validators at CommonHeight which signed the
+// conflicting header are byzantine. There are enough of them to fool a light
+// client if they hold more than 1/3 of the voting power at CommonHeight.
+type LightClientAttackEvidence struct {
+	ConflictingHeader *SignedHeader
+	ConflictingVals   *ValidatorSet // validators of the conflicting header
+	CommonHeight      int64
+}
+
+var _ CompositeEvidence = &LightClientAttackEvidence{}
+
+// lightClientAttackTrustLevel is the fraction of the voting power at the
+// common height which must have signed the conflicting header, i.e. the
+// default trust level of light clients.
+var lightClientAttackTrustLevel = cmn.Fraction{Numerator: 1, Denominator: 3}
+
+// String returns a string representation of the evidence.
+func (lcae *LightClientAttackEvidence) String() string {
+	if lcae.ConflictingHeader == nil || lcae.ConflictingHeader.Header == nil {
+		return fmt.Sprintf("LightClientAttackEvidence{ConflictingHeader: nil, CommonHeight: %d}", lcae.CommonHeight)
+	}
+	return fmt.Sprintf("LightClientAttackEvidence{ConflictingHeader: %v #%X, CommonHeight: %d}",
+		lcae.ConflictingHeader.Height, lcae.ConflictingHeader.Hash(), lcae.CommonHeight)
+}
+
+// Height returns the common height, which the byzantine validators were
+// bonded at.
+func (lcae *LightClientAttackEvidence) Height() int64 {
+	return lcae.CommonHeight
+}
+
+// Time returns the time of the conflicting header.
+func (lcae *LightClientAttackEvidence) Time() time.Time {
+	return lcae.ConflictingHeader.Time
+}
+
+// ConflictingHeight returns the height of the conflicting header.
+func (lcae *LightClientAttackEvidence) ConflictingHeight() int64 {
+	return lcae.ConflictingHeader.Height
+}
+
+// Address returns nil, the evidence is of several validators. See
+// ByzantineValidators.
+func (lcae *LightClientAttackEvidence) Address() []byte {
+	return nil
+}
+
+// Bytes returns the amino encoded evidence.
+func (lcae *LightClientAttackEvidence) Bytes() []byte {
+	return cdcEncode(lcae)
+}
+
+// Hash returns the hash of the evidence.
+func (lcae *LightClientAttackEvidence) Hash() []byte {
+	return tmhash.Sum(cdcEncode(lcae))
+}
+
+// Verify always fails, the evidence is verified with VerifyComposite.
+func (lcae *LightClientAttackEvidence) Verify(chainID string, pubKey crypto.PubKey) error {
+	return errors.New("LightClientAttackEvidence must be verified with VerifyComposite")
+}
+
+// VerifyComposite returns an error if the conflicting header doesn't conflict
+// with committedHeader, the header we committed at the same height, or isn't
+// signed by its validators and by more than 1/3 of valSet, the validators at
+// the common height.
+func (lcae *LightClientAttackEvidence) VerifyComposite(committedHeader *Header, valSet *ValidatorSet) error {
+	h := lcae.ConflictingHeader
+	if committedHeader.Height != h.Height {
+		return fmt.Errorf("LightClientAttackEvidence Error: expected committed header at height %d, got %d",
+			h.Height, committedHeader.Height)
+	}
+	if committedHeader.ChainID != h.ChainID {
+		return fmt.Errorf("LightClientAttackEvidence Error: conflicting header is from chain %q, not %q",
+			h.ChainID, committedHeader.ChainID)
+	}
+	if bytes.Equal(committedHeader.Hash(), h.Hash()) {
+		return errors.New("LightClientAttackEvidence Error: the conflicting header was committed - not a real attack")
+	}
+
+	if !bytes.Equal(h.ValidatorsHash, lcae.ConflictingVals.Hash()) {
+		return fmt.Errorf("LightClientAttackEvidence Error: expected validators hash %X, got %X",
+			h.ValidatorsHash, lcae.ConflictingVals.Hash())
+	}
+	if err := lcae.ConflictingVals.VerifyCommit(h.ChainID, h.Commit.BlockID, h.Height, h.Commit); err != nil {
+		return fmt.Errorf("LightClientAttackEvidence Error verifying the conflicting commit: %v", err)
+	}
+	if err := valSet.VerifyCommitTrusting(h.ChainID, h.Commit.BlockID, h.Height, h.Commit,
+		lightClientAttackTrustLevel); err != nil {
+		return fmt.Errorf("LightClientAttackEvidence Error: not enough validators of the common height signed: %v", err)
+	}
+
+	return nil
+}
+
+// ByzantineValidators returns the validators of valSet, the validators at
+// the common height, which signed the conflicting header.
+func (lcae *LightClientAttackEvidence) ByzantineValidators(valSet *ValidatorSet) []*Validator {
+	var (
+		commit = lcae.ConflictingHeader.Commit
+		vals   []*Validator
+	)
+	for _, precommit := range commit.Precommits {
+		if precommit == nil || !precommit.BlockID.Equals(commit.BlockID) {
+			continue
+		}
+		if _, val := valSet.GetByAddress(precommit.ValidatorAddress); val != nil {
+			vals = append(vals, val)
+		}
+	}
+	return vals
+}
+
+// Equal checks if two pieces of evidence are equal.
+func (lcae *LightClientAttackEvidence) Equal(ev Evidence) bool {
+	if _, ok := ev.(*LightClientAttackEvidence); !ok {
+		return false
+	}
+
+	// just check their hashes
+	return bytes.Equal(lcae.Hash(), ev.Hash())
+}
+
+// ValidateBasic performs basic validation.
+func (lcae *LightClientAttackEvidence) ValidateBasic() error {
+	if lcae.ConflictingHeader == nil || lcae.ConflictingHeader.Header == nil {
+		return errors.New("Empty ConflictingHeader")
+	}
+	if err := lcae.ConflictingHeader.ValidateBasic(lcae.ConflictingHeader.ChainID); err != nil {
+		return fmt.Errorf("Invalid ConflictingHeader: %v", err)
+	}
+	if lcae.ConflictingVals.IsNilOrEmpty() {
+		return errors.New("Empty ConflictingVals")
+	}
+	if lcae.CommonHeight <= 0 {
+		return fmt.Errorf("Invalid CommonHeight %d", lcae.CommonHeight)
+	}
+	if lcae.CommonHeight > lcae.ConflictingHeader.Height {
+		return fmt.Errorf("CommonHeight %d is above the conflicting header (%d)",
+			lcae.CommonHeight, lcae.ConflictingHeader.Height)
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------
+
 // UNSTABLE
 type MockRandomGoodEvidence struct {
 	MockGoodEvidence
@@ -235,7 +393,11 @@ func NewMockGoodEvidence(height int64, idx int, address []byte) MockGoodEvidence
 	return MockGoodEvidence{height, address}
 }
 
+// mockEvidenceTime is the time of all the mock evidence.
+var mockEvidenceTime = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
 func (e MockGoodEvidence) Height() int64   { return e.Height_ }
+func (e MockGoodEvidence) Time() time.Time { return mockEvidenceTime }
 func (e MockGoodEvidence) Address() []byte { return e.Address_ }
 func (e MockGoodEvidence) Hash() []byte {
 	return []byte(fmt.Sprintf("%d-%x", e.Height_, e.Address_))
@@ -289,6 +451,24 @@ func (evl EvidenceList) Hash() []byte {
 	return merkle.SimpleHashFromByteSlices(evidenceBzs)
 }
 
+// ByteSize returns the size of the evidence in a block, including the amino
+// overhead of the list. It's limited by EvidenceParams.MaxBytes.
+func (evl EvidenceList) ByteSize() int64 {
+	var size int64
+	for _, ev := range evl {
+		size += EvidenceByteSize(ev)
+	}
+	return size
+}
+
+// EvidenceByteSize returns the size of the evidence in a block, including the
+// amino overhead of its field in the list.
+func EvidenceByteSize(ev Evidence) int64 {
+	bz := cdc.MustMarshalBinaryLengthPrefixed(ev)
+	// 1 byte for the field key
+	return int64(len(bz)) + 1
+}
+
 func (evl EvidenceList) String() string {
 	s := ""
 	for _, e := range evl {
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmtime "github.com/tendermint/tendermint/types/time"
 )
 
 type voteData struct {
@@ -93,6 +94,11 @@ func TestEvidenceList(t *testing.T) {
 	assert.NotNil(t, evl.Hash())
 	assert.True(t, evl.Has(ev))
 	assert.False(t, evl.Has(&DuplicateVoteEvidence{}))
+
+	// The size of the evidence in a block
+	evl = append(evl, randomDuplicatedVoteEvidence())
+	bz := cdc.MustMarshalBinaryBare(EvidenceData{Evidence: evl})
+	assert.EqualValues(t, len(bz), evl.ByteSize())
 }
 
 func TestMaxEvidenceBytes(t *testing.T) {
@@ -157,3 +163,89 @@ func TestDuplicateVoteEvidenceValidation(t *testing.T) {
 		})
 	}
 }
+
+// makeSignedHeader returns a header at the height, committed by all the
+// privVals, who must be the validators of vals.
+func makeSignedHeader(t *testing.T, chainID string, height int64, appHash []byte,
+	vals *ValidatorSet, privVals []PrivValidator) *SignedHeader {
+
+	header := &Header{
+		ChainID:            chainID,
+		Height:             height,
+		Time:               tmtime.Now(),
+		ValidatorsHash:     vals.Hash(),
+		NextValidatorsHash: vals.Hash(),
+		AppHash:            appHash,
+	}
+	// the commit is by validator index
+	sorted := make([]PrivValidator, len(privVals))
+	for _, pv := range privVals {
+		idx, _ := vals.GetByAddress(pv.GetPubKey().Address())
+		sorted[idx] = pv
+	}
+	blockID := BlockID{Hash: header.Hash()}
+	commit, err := MakeCommit(blockID, height, 0, NewVoteSet(chainID, height, 0, PrecommitType, vals), sorted)
+	require.NoError(t, err)
+	return &SignedHeader{Header: header, Commit: commit}
+}
+
+func TestLightClientAttackEvidence(t *testing.T) {
+	const chainID = "mychain"
+	height := int64(10)
+	vals, privVals := RandValidatorSet(4, 10)
+	committed := makeSignedHeader(t, chainID, height, []byte("app_hash"), vals, privVals)
+
+	// Two of the validators and a phantom validator signed another header
+	phantom := NewMockPV()
+	conflictingPrivVals := []PrivValidator{privVals[0], privVals[1], phantom}
+	conflictingVals := NewValidatorSet([]*Validator{
+		vals.Validators[0].Copy(), vals.Validators[1].Copy(), NewValidator(phantom.GetPubKey(), 10),
+	})
+	ev := &LightClientAttackEvidence{
+		ConflictingHeader: makeSignedHeader(t, chainID, height, []byte("other_app_hash"), conflictingVals, conflictingPrivVals),
+		ConflictingVals:   conflictingVals,
+		CommonHeight:      height - 1,
+	}
+	require.NoError(t, ev.ValidateBasic())
+	assert.NoError(t, ev.VerifyComposite(committed.Header, vals))
+	assert.Nil(t, ev.Address())
+	assert.Equal(t, height-1, ev.Height())
+	assert.Equal(t, height, ev.ConflictingHeight())
+	assert.Equal(t, []*Validator{vals.Validators[0], vals.Validators[1]}, ev.ByzantineValidators(vals))
+
+	// The committed header isn't an attack
+	notAttack := &LightClientAttackEvidence{ConflictingHeader: committed, ConflictingVals: vals, CommonHeight: height}
+	assert.Error(t, notAttack.VerifyComposite(committed.Header, vals))
+
+	// A committed header at another height
+	other := makeSignedHeader(t, chainID, height+1, []byte("app_hash"), vals, privVals)
+	assert.Error(t, ev.VerifyComposite(other.Header, vals))
+
+	// Not enough validators of the common height signed
+	fewVals := NewValidatorSet([]*Validator{vals.Validators[0].Copy(), NewValidator(phantom.GetPubKey(), 30)})
+	few := &LightClientAttackEvidence{
+		ConflictingHeader: makeSignedHeader(t, chainID, height, []byte("other_app_hash"), fewVals,
+			[]PrivValidator{privVals[0], phantom}),
+		ConflictingVals: fewVals,
+		CommonHeight:    height - 1,
+	}
+	assert.Error(t, few.VerifyComposite(committed.Header, vals))
+
+	// The validators don't match the conflicting header
+	badVals := &LightClientAttackEvidence{ConflictingHeader: ev.ConflictingHeader, ConflictingVals: vals, CommonHeight: height - 1}
+	assert.Error(t, badVals.VerifyComposite(committed.Header, vals))
+
+	// ValidateBasic
+	assert.Error(t, (&LightClientAttackEvidence{ConflictingVals: vals, CommonHeight: 1}).ValidateBasic())
+	assert.Error(t, (&LightClientAttackEvidence{ConflictingHeader: committed, CommonHeight: 1}).ValidateBasic())
+	assert.Error(t, (&LightClientAttackEvidence{ConflictingHeader: committed, ConflictingVals: vals}).ValidateBasic())
+	assert.Error(t, (&LightClientAttackEvidence{ConflictingHeader: committed, ConflictingVals: vals,
+		CommonHeight: height + 1}).ValidateBasic())
+
+	// The evidence survives the encoding
+	bz, err := cdc.MarshalBinaryBare(Evidence(ev))
+	require.NoError(t, err)
+	var decoded Evidence
+	require.NoError(t, cdc.UnmarshalBinaryBare(bz, &decoded))
+	assert.True(t, ev.Equal(decoded))
+}
@@ -73,6 +73,7 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 	if genDoc.ConsensusParams == nil {
 		genDoc.ConsensusParams = DefaultConsensusParams()
 	} else {
+		genDoc.ConsensusParams.Complete()
 		if err := genDoc.ConsensusParams.Validate(); err != nil {
 			return err
 		}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	cmn "github.com/tendermint/tendermint/libs/common"
@@ -20,6 +22,8 @@ type ConsensusParams struct {
 	Block     BlockParams     `json:"block"`
 	Evidence  EvidenceParams  `json:"evidence"`
 	Validator ValidatorParams `json:"validator"`
+	Synchrony SynchronyParams `json:"synchrony"`
+	Timeout   TimeoutParams   `json:"timeout"`
 }
 
 // HashedParams is a subset of ConsensusParams.
@@ -43,6 +47,16 @@ type BlockParams struct {
 // EvidenceParams determine how we handle evidence of malfeasance.
 type EvidenceParams struct {
 	MaxAge int64 `json:"max_age"` // only accept new evidence more recent than this
+
+	// Evidence is only expired once it's older than both MaxAge blocks and
+	// MaxAgeDuration (according to the block times). 0 means evidence expires
+	// by height only.
+	MaxAgeDuration time.Duration `json:"max_age_duration"`
+
+	// Maximum total size of the evidence of a block (see
+	// EvidenceList.ByteSize). Must be greater than 0 and not greater than
+	// Block.MaxBytes.
+	MaxBytes int64 `json:"max_bytes"`
 }
 
 // ValidatorParams restrict the public key types validators can use.
@@ -51,12 +65,34 @@ type ValidatorParams struct {
 	PubKeyTypes []string `json:"pub_key_types"`
 }
 
+// SynchronyParams bound the clock drift and the network delay the validators
+// tolerate when checking the time the proposer set in its block (see
+// Proposal.IsTimely). Not exposed to the application.
+type SynchronyParams struct {
+	// Maximum difference between the clocks of two correct validators.
+	Precision time.Duration `json:"precision"`
+	// Maximum time for a proposal to reach the correct validators.
+	MessageDelay time.Duration `json:"message_delay"`
+}
+
+// TimeoutParams are the increase of the consensus timeouts with every round,
+// so that the validators eventually wait long enough for each other to make
+// progress. Being consensus params, the application can change them in
+// EndBlock, and all the validators use the same.
+type TimeoutParams struct {
+	ProposeDelta   time.Duration `json:"propose_delta"`
+	PrevoteDelta   time.Duration `json:"prevote_delta"`
+	PrecommitDelta time.Duration `json:"precommit_delta"`
+}
+
 // DefaultConsensusParams returns a default ConsensusParams.
 func DefaultConsensusParams() *ConsensusParams {
 	return &ConsensusParams{
 		DefaultBlockParams(),
 		DefaultEvidenceParams(),
 		DefaultValidatorParams(),
+		DefaultSynchronyParams(),
+		DefaultTimeoutParams(),
 	}
 }
 
@@ -72,7 +108,9 @@ func DefaultBlockParams() BlockParams {
 // DefaultEvidenceParams Params returns a default EvidenceParams.
 func DefaultEvidenceParams() EvidenceParams {
 	return EvidenceParams{
-		MaxAge: 100000, // 27.8 hrs at 1block/s
+		MaxAge:         100000, // 27.8 hrs at 1block/s
+		MaxAgeDuration: 48 * time.Hour,
+		MaxBytes:       1048576, // 1MB
 	}
 }
 
@@ -82,6 +120,50 @@ func DefaultValidatorParams() ValidatorParams {
 	return ValidatorParams{[]string{ABCIPubKeyTypeEd25519}}
 }
 
+// DefaultSynchronyParams returns a default SynchronyParams.
+func DefaultSynchronyParams() SynchronyParams {
+	return SynchronyParams{
+		Precision:    500 * time.Millisecond,
+		MessageDelay: 3 * time.Second,
+	}
+}
+
+// IsTimely returns true if a block with the given time, whose proposal was
+// received at recvTime, could have been proposed by a correct proposer:
+// recvTime - MessageDelay - Precision <= blockTime <= recvTime + Precision.
+func (params SynchronyParams) IsTimely(blockTime, recvTime time.Time) bool {
+	lowerBound := recvTime.Add(-params.MessageDelay - params.Precision)
+	upperBound := recvTime.Add(params.Precision)
+	return !blockTime.Before(lowerBound) && !blockTime.After(upperBound)
+}
+
+// DefaultTimeoutParams returns a default TimeoutParams.
+func DefaultTimeoutParams() TimeoutParams {
+	return TimeoutParams{
+		ProposeDelta:   500 * time.Millisecond,
+		PrevoteDelta:   500 * time.Millisecond,
+		PrecommitDelta: 500 * time.Millisecond,
+	}
+}
+
+// ProposeTimeout returns the time to wait for a proposal in the round, given
+// the time to wait in the first round.
+func (params TimeoutParams) ProposeTimeout(timeout time.Duration, round int) time.Duration {
+	return timeout + params.ProposeDelta*time.Duration(round)
+}
+
+// PrevoteTimeout returns the time to wait for straggler prevotes in the
+// round, given the time to wait in the first round.
+func (params TimeoutParams) PrevoteTimeout(timeout time.Duration, round int) time.Duration {
+	return timeout + params.PrevoteDelta*time.Duration(round)
+}
+
+// PrecommitTimeout returns the time to wait for straggler precommits in the
+// round, given the time to wait in the first round.
+func (params TimeoutParams) PrecommitTimeout(timeout time.Duration, round int) time.Duration {
+	return timeout + params.PrecommitDelta*time.Duration(round)
+}
+
 func (params *ValidatorParams) IsValidPubkeyType(pubkeyType string) bool {
 	for i := 0; i < len(params.PubKeyTypes); i++ {
 		if params.PubKeyTypes[i] == pubkeyType {
@@ -91,6 +173,30 @@ func (params *ValidatorParams) IsValidPubkeyType(pubkeyType string) bool {
 	return false
 }
 
+// Complete fills the params added after the chain started, which are zero in
+// its genesis file and its stored state, with their defaults.
+func (params *ConsensusParams) Complete() {
+	if params.Evidence.MaxBytes == 0 {
+		params.Evidence.MaxBytes = DefaultEvidenceParams().MaxBytes
+		if params.Evidence.MaxBytes > params.Block.MaxBytes {
+			params.Evidence.MaxBytes = params.Block.MaxBytes
+		}
+	}
+	if params.Synchrony == (SynchronyParams{}) {
+		params.Synchrony = DefaultSynchronyParams()
+	}
+	defaultTimeout := DefaultTimeoutParams()
+	if params.Timeout.ProposeDelta == 0 {
+		params.Timeout.ProposeDelta = defaultTimeout.ProposeDelta
+	}
+	if params.Timeout.PrevoteDelta == 0 {
+		params.Timeout.PrevoteDelta = defaultTimeout.PrevoteDelta
+	}
+	if params.Timeout.PrecommitDelta == 0 {
+		params.Timeout.PrecommitDelta = defaultTimeout.PrecommitDelta
+	}
+}
+
 // Validate validates the ConsensusParams to ensure all values are within their
 // allowed limits, and returns an error if they are not.
 func (params *ConsensusParams) Validate() error {
@@ -118,6 +224,20 @@ func (params *ConsensusParams) Validate() error {
 			params.Evidence.MaxAge)
 	}
 
+	if params.Evidence.MaxAgeDuration < 0 {
+		return cmn.NewError("EvidenceParams.MaxAgeDuration must not be negative. Got %v",
+			params.Evidence.MaxAgeDuration)
+	}
+
+	if params.Evidence.MaxBytes <= 0 {
+		return cmn.NewError("EvidenceParams.MaxBytes must be greater than 0. Got %d",
+			params.Evidence.MaxBytes)
+	}
+	if params.Evidence.MaxBytes > params.Block.MaxBytes {
+		return cmn.NewError("EvidenceParams.MaxBytes is greater than Block.MaxBytes. %d > %d",
+			params.Evidence.MaxBytes, params.Block.MaxBytes)
+	}
+
 	if len(params.Validator.PubKeyTypes) == 0 {
 		return cmn.NewError("len(Validator.PubKeyTypes) must be greater than 0")
 	}
@@ -131,6 +251,31 @@ func (params *ConsensusParams) Validate() error {
 		}
 	}
 
+	if params.Synchrony.Precision <= 0 {
+		return cmn.NewError("Synchrony.Precision must be greater than 0. Got %v",
+			params.Synchrony.Precision)
+	}
+
+	if params.Synchrony.MessageDelay <= 0 {
+		return cmn.NewError("Synchrony.MessageDelay must be greater than 0. Got %v",
+			params.Synchrony.MessageDelay)
+	}
+
+	if params.Timeout.ProposeDelta <= 0 {
+		return cmn.NewError("Timeout.ProposeDelta must be greater than 0. Got %v",
+			params.Timeout.ProposeDelta)
+	}
+
+	if params.Timeout.PrevoteDelta <= 0 {
+		return cmn.NewError("Timeout.PrevoteDelta must be greater than 0. Got %v",
+			params.Timeout.PrevoteDelta)
+	}
+
+	if params.Timeout.PrecommitDelta <= 0 {
+		return cmn.NewError("Timeout.PrecommitDelta must be greater than 0. Got %v",
+			params.Timeout.PrecommitDelta)
+	}
+
 	return nil
 }
 
@@ -154,7 +299,9 @@ func (params *ConsensusParams) Hash() []byte {
 func (params *ConsensusParams) Equals(params2 *ConsensusParams) bool {
 	return params.Block == params2.Block &&
 		params.Evidence == params2.Evidence &&
-		cmn.StringSliceEqual(params.Validator.PubKeyTypes, params2.Validator.PubKeyTypes)
+		cmn.StringSliceEqual(params.Validator.PubKeyTypes, params2.Validator.PubKeyTypes) &&
+		params.Synchrony == params2.Synchrony &&
+		params.Timeout == params2.Timeout
 }
 
 // Update returns a copy of the params with updates from the non-zero fields of p2.
@@ -173,11 +320,28 @@ func (params ConsensusParams) Update(params2 *abci.ConsensusParams) ConsensusPar
 	}
 	if params2.Evidence != nil {
 		res.Evidence.MaxAge = params2.Evidence.MaxAge
+		res.Evidence.MaxAgeDuration = params2.Evidence.MaxAgeDuration
+		// 0 if the app doesn't know about it
+		if params2.Evidence.MaxBytes != 0 {
+			res.Evidence.MaxBytes = params2.Evidence.MaxBytes
+		}
 	}
 	if params2.Validator != nil {
 		// Copy params2.Validator.PubkeyTypes, and set result's value to the copy.
 		// This avoids having to initialize the slice to 0 values, and then write to it again.
 		res.Validator.PubKeyTypes = append([]string{}, params2.Validator.PubKeyTypes...)
 	}
+	if params2.Timeout != nil {
+		// 0 if the app doesn't set it
+		if params2.Timeout.ProposeDelta != 0 {
+			res.Timeout.ProposeDelta = params2.Timeout.ProposeDelta
+		}
+		if params2.Timeout.PrevoteDelta != 0 {
+			res.Timeout.PrevoteDelta = params2.Timeout.PrevoteDelta
+		}
+		if params2.Timeout.PrecommitDelta != 0 {
+			res.Timeout.PrecommitDelta = params2.Timeout.PrecommitDelta
+		}
+	}
 	return res
 }
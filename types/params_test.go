@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -44,6 +45,40 @@ func TestConsensusParamsValidation(t *testing.T) {
 			assert.Errorf(t, tc.params.Validate(), "expected error for non valid params (#%d)", i)
 		}
 	}
+
+	// test evidence max bytes
+	params := makeParams(100, 0, 10, 1, valEd25519)
+	for maxBytes, valid := range map[int64]bool{-1: false, 0: false, 100: true, 101: false} {
+		params.Evidence.MaxBytes = maxBytes
+		if valid {
+			assert.NoError(t, params.Validate(), "%d", maxBytes)
+		} else {
+			assert.Error(t, params.Validate(), "%d", maxBytes)
+		}
+	}
+
+	// test synchrony params
+	params = makeParams(100, 0, 10, 1, valEd25519)
+	for precision, valid := range map[time.Duration]bool{-time.Second: false, 0: false, time.Millisecond: true} {
+		params.Synchrony.Precision = precision
+		params.Synchrony.MessageDelay = precision
+		if valid {
+			assert.NoError(t, params.Validate(), "%v", precision)
+		} else {
+			assert.Error(t, params.Validate(), "%v", precision)
+		}
+	}
+
+	// test timeout params
+	params = makeParams(100, 0, 10, 1, valEd25519)
+	for delta, valid := range map[time.Duration]bool{-time.Millisecond: false, 0: false, time.Second: true} {
+		params.Timeout = TimeoutParams{ProposeDelta: delta, PrevoteDelta: delta, PrecommitDelta: delta}
+		if valid {
+			assert.NoError(t, params.Validate(), "%v", delta)
+		} else {
+			assert.Error(t, params.Validate(), "%v", delta)
+		}
+	}
 }
 
 func makeParams(
@@ -59,11 +94,14 @@ func makeParams(
 			TimeIotaMs: blockTimeIotaMs,
 		},
 		Evidence: EvidenceParams{
-			MaxAge: evidenceAge,
+			MaxAge:   evidenceAge,
+			MaxBytes: blockBytes,
 		},
 		Validator: ValidatorParams{
 			PubKeyTypes: pubkeyTypes,
 		},
+		Synchrony: DefaultSynchronyParams(),
+		Timeout:   DefaultTimeoutParams(),
 	}
 }
 
@@ -115,7 +153,8 @@ func TestConsensusParamsUpdate(t *testing.T) {
 					MaxGas:   200,
 				},
 				Evidence: &abci.EvidenceParams{
-					MaxAge: 300,
+					MaxAge:   300,
+					MaxBytes: 100,
 				},
 				Validator: &abci.ValidatorParams{
 					PubKeyTypes: valSecp256k1,
@@ -123,8 +162,115 @@ func TestConsensusParamsUpdate(t *testing.T) {
 			},
 			makeParams(100, 200, 10, 300, valSecp256k1),
 		},
+		// the evidence max bytes of the apps which don't know about it
+		{
+			makeParams(1, 2, 10, 3, valEd25519),
+			&abci.ConsensusParams{
+				Evidence: &abci.EvidenceParams{
+					MaxAge: 300,
+				},
+			},
+			makeParams(1, 2, 10, 300, valEd25519),
+		},
+		// timeout updates
+		{
+			makeParams(1, 2, 10, 3, valEd25519),
+			&abci.ConsensusParams{
+				Timeout: &abci.TimeoutParams{
+					ProposeDelta:   time.Second,
+					PrevoteDelta:   2 * time.Second,
+					PrecommitDelta: 3 * time.Second,
+				},
+			},
+			func() ConsensusParams {
+				params := makeParams(1, 2, 10, 3, valEd25519)
+				params.Timeout = TimeoutParams{time.Second, 2 * time.Second, 3 * time.Second}
+				return params
+			}(),
+		},
+		// the timeout deltas which the app doesn't set
+		{
+			makeParams(1, 2, 10, 3, valEd25519),
+			&abci.ConsensusParams{
+				Timeout: &abci.TimeoutParams{
+					PrevoteDelta: 2 * time.Second,
+				},
+			},
+			func() ConsensusParams {
+				params := makeParams(1, 2, 10, 3, valEd25519)
+				params.Timeout.PrevoteDelta = 2 * time.Second
+				return params
+			}(),
+		},
 	}
 	for _, tc := range testCases {
 		assert.Equal(t, tc.updatedParams, tc.params.Update(tc.updates))
 	}
 }
+
+func TestConsensusParamsComplete(t *testing.T) {
+	// the params of a chain started before the evidence max bytes
+	params := makeParams(22020096, 0, 10, 1, valEd25519)
+	params.Evidence.MaxBytes = 0
+	params.Complete()
+	assert.Equal(t, DefaultEvidenceParams().MaxBytes, params.Evidence.MaxBytes)
+	assert.NoError(t, params.Validate())
+
+	params = makeParams(1000, 0, 10, 1, valEd25519)
+	params.Evidence.MaxBytes = 0
+	params.Complete()
+	assert.EqualValues(t, 1000, params.Evidence.MaxBytes, "not greater than the block max bytes")
+
+	params.Evidence.MaxBytes = 10
+	params.Complete()
+	assert.EqualValues(t, 10, params.Evidence.MaxBytes)
+
+	// the params of a chain started before the synchrony params
+	params.Synchrony = SynchronyParams{}
+	params.Complete()
+	assert.Equal(t, DefaultSynchronyParams(), params.Synchrony)
+
+	params.Synchrony = SynchronyParams{Precision: 1, MessageDelay: 2}
+	params.Complete()
+	assert.Equal(t, SynchronyParams{Precision: 1, MessageDelay: 2}, params.Synchrony)
+
+	// the params of a chain started before the timeout params
+	params.Timeout = TimeoutParams{PrevoteDelta: time.Second}
+	params.Complete()
+	assert.Equal(t, TimeoutParams{
+		ProposeDelta:   DefaultTimeoutParams().ProposeDelta,
+		PrevoteDelta:   time.Second,
+		PrecommitDelta: DefaultTimeoutParams().PrecommitDelta,
+	}, params.Timeout)
+}
+
+func TestTimeoutParams(t *testing.T) {
+	params := TimeoutParams{
+		ProposeDelta:   100 * time.Millisecond,
+		PrevoteDelta:   200 * time.Millisecond,
+		PrecommitDelta: 300 * time.Millisecond,
+	}
+	assert.Equal(t, time.Second, params.ProposeTimeout(time.Second, 0))
+	assert.Equal(t, 1200*time.Millisecond, params.ProposeTimeout(time.Second, 2))
+	assert.Equal(t, 1400*time.Millisecond, params.PrevoteTimeout(time.Second, 2))
+	assert.Equal(t, 1600*time.Millisecond, params.PrecommitTimeout(time.Second, 2))
+}
+
+func TestSynchronyParamsIsTimely(t *testing.T) {
+	params := SynchronyParams{Precision: time.Second, MessageDelay: 2 * time.Second}
+	recvTime := time.Now()
+
+	testCases := []struct {
+		blockTime time.Time
+		timely    bool
+	}{
+		0: {recvTime, true},
+		1: {recvTime.Add(time.Second), true},
+		2: {recvTime.Add(time.Second + 1), false},
+		3: {recvTime.Add(-3 * time.Second), true},
+		4: {recvTime.Add(-3*time.Second - 1), false},
+	}
+	for i, tc := range testCases {
+		assert.Equal(t, tc.timely, params.IsTimely(tc.blockTime, recvTime), "#%d", i)
+	}
+}
@@ -76,6 +76,13 @@ func (pv *MockPV) SignVote(chainID string, vote *Vote) error {
 		return err
 	}
 	vote.Signature = sig
+	if vote.Type == PrecommitType && !vote.BlockID.IsZero() {
+		extSig, err := pv.privKey.Sign(vote.ExtensionSignBytes(useChainID))
+		if err != nil {
+			return err
+		}
+		vote.ExtensionSignature = extSig
+	}
 	return nil
 }
 
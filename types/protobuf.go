@@ -15,8 +15,9 @@ import (
 // Use strings to distinguish types in ABCI messages
 
 const (
-	ABCIEvidenceTypeDuplicateVote = "duplicate/vote"
-	ABCIEvidenceTypeMockGood      = "mock/good"
+	ABCIEvidenceTypeDuplicateVote     = "duplicate/vote"
+	ABCIEvidenceTypeLightClientAttack = "light_client/attack"
+	ABCIEvidenceTypeMockGood          = "mock/good"
 )
 
 const (
@@ -130,11 +131,18 @@ func (tm2pb) ConsensusParams(params *ConsensusParams) *abci.ConsensusParams {
 			MaxGas:   params.Block.MaxGas,
 		},
 		Evidence: &abci.EvidenceParams{
-			MaxAge: params.Evidence.MaxAge,
+			MaxAge:         params.Evidence.MaxAge,
+			MaxAgeDuration: params.Evidence.MaxAgeDuration,
+			MaxBytes:       params.Evidence.MaxBytes,
 		},
 		Validator: &abci.ValidatorParams{
 			PubKeyTypes: params.Validator.PubKeyTypes,
 		},
+		Timeout: &abci.TimeoutParams{
+			ProposeDelta:   params.Timeout.ProposeDelta,
+			PrevoteDelta:   params.Timeout.PrevoteDelta,
+			PrecommitDelta: params.Timeout.PrecommitDelta,
+		},
 	}
 }
 
@@ -169,6 +177,32 @@ func (tm2pb) Evidence(ev Evidence, valSet *ValidatorSet, evTime time.Time) abci.
 	}
 }
 
+// CompositeEvidence returns the ABCI Evidence of each of the byzantine
+// validators of the evidence, from valSet, the validators at its height.
+// XXX: panics on unknown evidence type
+func (tm2pb) CompositeEvidence(ev CompositeEvidence, valSet *ValidatorSet, evTime time.Time) []abci.Evidence {
+	var evType string
+	switch ev.(type) {
+	case *LightClientAttackEvidence:
+		evType = ABCIEvidenceTypeLightClientAttack
+	default:
+		panic(fmt.Sprintf("Unknown evidence type: %v %v", ev, reflect.TypeOf(ev)))
+	}
+
+	byzVals := ev.ByzantineValidators(valSet)
+	evidence := make([]abci.Evidence, len(byzVals))
+	for i, val := range byzVals {
+		evidence[i] = abci.Evidence{
+			Type:             evType,
+			Validator:        TM2PB.Validator(val),
+			Height:           ev.Height(),
+			Time:             evTime,
+			TotalVotingPower: valSet.TotalVotingPower(),
+		}
+	}
+	return evidence
+}
+
 // XXX: panics on nil or unknown pubkey type
 func (tm2pb) NewValidatorUpdate(pubkey crypto.PubKey, power int64) abci.ValidatorUpdate {
 	pubkeyABCI := TM2PB.PubKey(pubkey)
@@ -221,13 +255,18 @@ func (pb2tm) ValidatorUpdates(vals []abci.ValidatorUpdate) ([]*Validator, error)
 	return tmVals, nil
 }
 
-// BlockParams.TimeIotaMs is not exposed to the application. Therefore a caller
-// must provide it.
-func (pb2tm) ConsensusParams(csp *abci.ConsensusParams, blockTimeIotaMs int64) ConsensusParams {
+// BlockParams.TimeIotaMs and SynchronyParams are not exposed to the
+// application. Therefore a caller must provide them.
+func (pb2tm) ConsensusParams(
+	csp *abci.ConsensusParams,
+	blockTimeIotaMs int64,
+	synchrony SynchronyParams,
+) ConsensusParams {
 	params := ConsensusParams{
 		Block:     BlockParams{},
 		Evidence:  EvidenceParams{},
 		Validator: ValidatorParams{},
+		Synchrony: synchrony,
 	}
 
 	// we must defensively consider any structs may be nil
@@ -241,7 +280,9 @@ func (pb2tm) ConsensusParams(csp *abci.ConsensusParams, blockTimeIotaMs int64) C
 
 	if csp.Evidence != nil {
 		params.Evidence = EvidenceParams{
-			MaxAge: csp.Evidence.MaxAge,
+			MaxAge:         csp.Evidence.MaxAge,
+			MaxAgeDuration: csp.Evidence.MaxAgeDuration,
+			MaxBytes:       csp.Evidence.MaxBytes,
 		}
 	}
 
@@ -251,5 +292,13 @@ func (pb2tm) ConsensusParams(csp *abci.ConsensusParams, blockTimeIotaMs int64) C
 		}
 	}
 
+	if csp.Timeout != nil {
+		params.Timeout = TimeoutParams{
+			ProposeDelta:   csp.Timeout.ProposeDelta,
+			PrevoteDelta:   csp.Timeout.PrevoteDelta,
+			PrecommitDelta: csp.Timeout.PrecommitDelta,
+		}
+	}
+
 	return params
 }
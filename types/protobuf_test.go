@@ -6,6 +6,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	amino "github.com/tendermint/go-amino"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -64,7 +65,7 @@ func TestABCIValidators(t *testing.T) {
 func TestABCIConsensusParams(t *testing.T) {
 	cp := DefaultConsensusParams()
 	abciCP := TM2PB.ConsensusParams(cp)
-	cp2 := PB2TM.ConsensusParams(abciCP, cp.Block.TimeIotaMs)
+	cp2 := PB2TM.ConsensusParams(abciCP, cp.Block.TimeIotaMs, cp.Synchrony)
 
 	assert.Equal(t, *cp, cp2)
 }
@@ -156,6 +157,26 @@ func TestABCIEvidence(t *testing.T) {
 	assert.Equal(t, "duplicate/vote", abciEv.Type)
 }
 
+func TestABCICompositeEvidence(t *testing.T) {
+	const chainID = "mychain"
+	vals, privVals := RandValidatorSet(4, 10)
+	byzVals := NewValidatorSet([]*Validator{vals.Validators[0].Copy(), vals.Validators[1].Copy()})
+	ev := &LightClientAttackEvidence{
+		ConflictingHeader: makeSignedHeader(t, chainID, 10, []byte("app_hash"), byzVals, privVals[:2]),
+		ConflictingVals:   byzVals,
+		CommonHeight:      9,
+	}
+	abciEv := TM2PB.CompositeEvidence(ev, vals, time.Now())
+
+	require.Len(t, abciEv, 2)
+	for i, e := range abciEv {
+		assert.Equal(t, "light_client/attack", e.Type)
+		assert.Equal(t, vals.Validators[i].Address.Bytes(), e.Validator.Address)
+		assert.EqualValues(t, 9, e.Height)
+		assert.EqualValues(t, 40, e.TotalVotingPower)
+	}
+}
+
 type pubKeyEddie struct{}
 
 func (pubKeyEddie) Address() Address                        { return []byte{} }
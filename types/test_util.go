@@ -27,7 +27,8 @@ func MakeCommit(blockID BlockID, height int64, round int,
 		}
 	}
 
-	return voteSet.MakeCommit(), nil
+	// like the commits of the blocks, without the vote extensions
+	return voteSet.MakeCommit().WithoutExtensions(), nil
 }
 
 func signAddVote(privVal PrivValidator, vote *Vote, voteSet *VoteSet) (signed bool, err error) {
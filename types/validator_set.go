@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/merkle"
 	cmn "github.com/tendermint/tendermint/libs/common"
 )
@@ -63,6 +64,34 @@ func NewValidatorSet(valz []*Validator) *ValidatorSet {
 	return vals
 }
 
+// ValidatorSetFromExistingValidators initializes a ValidatorSet with the
+// validators of a set kept elsewhere, e.g. fetched from another node. Unlike
+// NewValidatorSet, which starts the proposer priorities afresh, it keeps their
+// priorities. The proposer is the validator with the highest priority.
+func ValidatorSetFromExistingValidators(valz []*Validator) (*ValidatorSet, error) {
+	if len(valz) == 0 {
+		return nil, errors.New("validator set is empty")
+	}
+	vals := &ValidatorSet{Validators: validatorListCopy(valz)}
+	sort.Sort(ValidatorsByAddress(vals.Validators))
+	sum := int64(0)
+	for i, val := range vals.Validators {
+		if val.VotingPower <= 0 {
+			return nil, fmt.Errorf("validator %v has non-positive voting power", val)
+		}
+		if i > 0 && bytes.Equal(val.Address, vals.Validators[i-1].Address) {
+			return nil, fmt.Errorf("duplicate validator %v", val)
+		}
+		sum = safeAddClip(sum, val.VotingPower)
+		if sum > MaxTotalVotingPower {
+			return nil, fmt.Errorf("total voting power exceeds %v", MaxTotalVotingPower)
+		}
+	}
+	vals.totalVotingPower = sum
+	vals.Proposer = vals.findProposer()
+	return vals, nil
+}
+
 // Nil or empty validator sets are invalid.
 func (vals *ValidatorSet) IsNilOrEmpty() bool {
 	return vals == nil || len(vals.Validators) == 0
@@ -714,6 +743,56 @@ func (vals *ValidatorSet) VerifyFutureCommit(newSet *ValidatorSet, chainID strin
 	return nil
 }
 
+// VerifyCommitTrusting verifies that more than trustLevel of the voting power
+// of vals signed the commit, which can be by another validator set. It is
+// used by the light client to trust a header signed by an unknown validator
+// set, as long as enough of a trusted set signed it.
+//
+// NOTE: It doesn't check that the commit is valid for the validator set which
+// signed it, which the caller must do with VerifyCommit.
+func (vals *ValidatorSet) VerifyCommitTrusting(chainID string, blockID BlockID,
+	height int64, commit *Commit, trustLevel cmn.Fraction) error {
+
+	if trustLevel.Numerator <= 0 || trustLevel.Denominator <= 0 || trustLevel.Numerator > trustLevel.Denominator {
+		return fmt.Errorf("Invalid trust level %v", trustLevel)
+	}
+	if height != commit.Height() {
+		return fmt.Errorf("Invalid commit -- wrong height: %v vs %v", height, commit.Height())
+	}
+
+	talliedVotingPower := int64(0)
+	seen := map[int]bool{}
+	for _, precommit := range commit.Precommits {
+		if precommit == nil {
+			continue
+		}
+		// See if this validator is in vals.
+		idx, val := vals.GetByAddress(precommit.ValidatorAddress)
+		if val == nil || seen[idx] {
+			continue // missing or double vote...
+		}
+		seen[idx] = true
+
+		precommitSignBytes := commit.VoteSignBytes(chainID, precommit)
+		if !val.PubKey.VerifyBytes(precommitSignBytes, precommit.Signature) {
+			return fmt.Errorf("Invalid commit -- invalid signature: %v", precommit)
+		}
+		if blockID.Equals(precommit.BlockID) {
+			talliedVotingPower += val.VotingPower
+		}
+	}
+
+	// Compare talliedVotingPower/total > numerator/denominator without
+	// rounding, and without overflowing for large fractions.
+	tallied := new(big.Int).Mul(big.NewInt(talliedVotingPower), big.NewInt(trustLevel.Denominator))
+	total := new(big.Int).Mul(big.NewInt(vals.TotalVotingPower()), big.NewInt(trustLevel.Numerator))
+	if tallied.Cmp(total) > 0 {
+		return nil
+	}
+	needed := total.Div(total, big.NewInt(trustLevel.Denominator)).Int64() + 1
+	return errTooMuchChange{talliedVotingPower, needed}
+}
+
 //-----------------
 // ErrTooMuchChange
 
@@ -766,6 +845,53 @@ func (vals *ValidatorSet) StringIndented(indent string) string {
 
 }
 
+//-------------------------------------
+
+// ValidatorPowerChange is a change of the voting power of a validator. The
+// validator was added if PreviousVotingPower is 0, and removed if VotingPower
+// is 0.
+type ValidatorPowerChange struct {
+	Address             Address       `json:"address"`
+	PubKey              crypto.PubKey `json:"pub_key"`
+	PreviousVotingPower int64         `json:"previous_voting_power"`
+	VotingPower         int64         `json:"voting_power"`
+}
+
+// ValidatorPowerChanges returns the validators whose voting power differs
+// between the two sets, sorted by address.
+func ValidatorPowerChanges(prev, next *ValidatorSet) []ValidatorPowerChange {
+	var changes []ValidatorPowerChange
+	next.Iterate(func(_ int, val *Validator) bool {
+		var prevPower int64
+		if _, prevVal := prev.GetByAddress(val.Address); prevVal != nil {
+			prevPower = prevVal.VotingPower
+		}
+		if prevPower != val.VotingPower {
+			changes = append(changes, ValidatorPowerChange{
+				Address:             val.Address,
+				PubKey:              val.PubKey,
+				PreviousVotingPower: prevPower,
+				VotingPower:         val.VotingPower,
+			})
+		}
+		return false
+	})
+	prev.Iterate(func(_ int, val *Validator) bool {
+		if !next.HasAddress(val.Address) {
+			changes = append(changes, ValidatorPowerChange{
+				Address:             val.Address,
+				PubKey:              val.PubKey,
+				PreviousVotingPower: val.VotingPower,
+			})
+		}
+		return false
+	})
+	sort.Slice(changes, func(i, j int) bool {
+		return bytes.Compare(changes[i].Address, changes[j].Address) < 0
+	})
+	return changes
+}
+
 //-------------------------------------
 // Implements sort for sorting validators by address.
 
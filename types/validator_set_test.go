@@ -10,6 +10,7 @@ import (
 	"testing/quick"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
@@ -89,6 +90,26 @@ func TestCopy(t *testing.T) {
 	}
 }
 
+func TestValidatorSetFromExistingValidators(t *testing.T) {
+	vset := randValidatorSet(10)
+	vset.IncrementProposerPriority(3)
+
+	existing, err := ValidatorSetFromExistingValidators(vset.Copy().Validators)
+	require.NoError(t, err)
+	assert.Equal(t, vset.Hash(), existing.Hash())
+	assert.Equal(t, vset.Validators, existing.Validators)
+	assert.Equal(t, vset.TotalVotingPower(), existing.TotalVotingPower())
+	assert.NotEqual(t, NewValidatorSet(vset.Validators).Validators, existing.Validators,
+		"NewValidatorSet resets the priorities")
+
+	_, err = ValidatorSetFromExistingValidators(nil)
+	assert.Error(t, err)
+	duplicate := vset.Copy().Validators
+	duplicate[1] = duplicate[0]
+	_, err = ValidatorSetFromExistingValidators(duplicate)
+	assert.Error(t, err)
+}
+
 // Test that IncrementProposerPriority requires positive times.
 func TestIncrementProposerPriorityPositiveTimes(t *testing.T) {
 	vset := NewValidatorSet([]*Validator{
@@ -587,6 +608,44 @@ func TestValidatorSetVerifyCommit(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestValidatorSetVerifyCommitTrusting(t *testing.T) {
+	chainID := "mychainID"
+	height := int64(5)
+	blockID := makeBlockIDRandom()
+	vals, privVals := RandValidatorSet(3, 10)
+	voteSet := NewVoteSet(chainID, height, 0, PrecommitType, vals)
+	commit, err := MakeCommit(blockID, height, 0, voteSet, privVals)
+	require.NoError(t, err)
+
+	// A set with one of the three signers has a third of the power signed
+	other1, _ := RandValidator(false, 10)
+	other2, _ := RandValidator(false, 10)
+	otherVals := NewValidatorSet([]*Validator{vals.Validators[0].Copy(), other1, other2})
+
+	testCases := []struct {
+		vals       *ValidatorSet
+		height     int64
+		trustLevel cmn.Fraction
+		expectErr  bool
+	}{
+		{vals, height, cmn.Fraction{Numerator: 1, Denominator: 3}, false},
+		{vals, height, cmn.Fraction{Numerator: 1, Denominator: 1}, true},
+		{otherVals, height, cmn.Fraction{Numerator: 1, Denominator: 3}, true},
+		{otherVals, height, cmn.Fraction{Numerator: 1, Denominator: 4}, false},
+		{vals, height + 1, cmn.Fraction{Numerator: 1, Denominator: 3}, true},
+		{vals, height, cmn.Fraction{Numerator: 0, Denominator: 3}, true},
+		{vals, height, cmn.Fraction{Numerator: 4, Denominator: 3}, true},
+	}
+	for i, tc := range testCases {
+		err := tc.vals.VerifyCommitTrusting(chainID, blockID, tc.height, commit, tc.trustLevel)
+		if tc.expectErr {
+			assert.Error(t, err, i)
+		} else {
+			assert.NoError(t, err, i)
+		}
+	}
+}
+
 func TestEmptySet(t *testing.T) {
 
 	var valList []*Validator
@@ -1241,6 +1300,28 @@ func (tvals testValsByAddress) Swap(i, j int) {
 	tvals[j] = it
 }
 
+func TestValidatorPowerChanges(t *testing.T) {
+	prev := NewValidatorSet([]*Validator{
+		newValidator([]byte("v1"), 10),
+		newValidator([]byte("v2"), 20),
+		newValidator([]byte("v3"), 30),
+	})
+	next := prev.Copy()
+	assert.Nil(t, ValidatorPowerChanges(prev, next))
+
+	require.NoError(t, next.UpdateWithChangeSet([]*Validator{
+		newValidator([]byte("v0"), 5),
+		newValidator([]byte("v2"), 0),
+		newValidator([]byte("v3"), 35),
+		newValidator([]byte("v1"), 10),
+	}))
+	assert.Equal(t, []ValidatorPowerChange{
+		{Address: []byte("v0"), PreviousVotingPower: 0, VotingPower: 5},
+		{Address: []byte("v2"), PreviousVotingPower: 20, VotingPower: 0},
+		{Address: []byte("v3"), PreviousVotingPower: 30, VotingPower: 35},
+	}, ValidatorPowerChanges(prev, next))
+}
+
 //-------------------------------------
 // Benchmark tests
 //
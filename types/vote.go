@@ -13,6 +13,10 @@ import (
 const (
 	// MaxVoteBytes is a maximum vote size (including amino overhead).
 	MaxVoteBytes int64 = 223
+
+	// MaxVoteExtensionSize is the maximum size of the application data a
+	// precommit can be extended with.
+	MaxVoteExtensionSize = 8 * 1024
 )
 
 var (
@@ -20,6 +24,8 @@ var (
 	ErrVoteInvalidValidatorIndex     = errors.New("Invalid validator index")
 	ErrVoteInvalidValidatorAddress   = errors.New("Invalid validator address")
 	ErrVoteInvalidSignature          = errors.New("Invalid signature")
+	ErrVoteInvalidExtensionSignature = errors.New("Invalid extension signature")
+	ErrVoteMissingExtension          = errors.New("Missing extension")
 	ErrVoteInvalidBlockHash          = errors.New("Invalid block hash")
 	ErrVoteNonDeterministicSignature = errors.New("Non-deterministic signature")
 	ErrVoteNil                       = errors.New("Nil vote")
@@ -37,8 +43,8 @@ func NewConflictingVoteError(val *Validator, voteA, voteB *Vote) *ErrVoteConflic
 	return &ErrVoteConflictingVotes{
 		&DuplicateVoteEvidence{
 			PubKey: val.PubKey,
-			VoteA:  voteA,
-			VoteB:  voteB,
+			VoteA:  voteA.WithoutExtension(),
+			VoteB:  voteB.WithoutExtension(),
 		},
 	}
 }
@@ -57,6 +63,11 @@ type Vote struct {
 	ValidatorAddress Address       `json:"validator_address"`
 	ValidatorIndex   int           `json:"validator_index"`
 	Signature        []byte        `json:"signature"`
+
+	// Application data a precommit for a block can be extended with, see
+	// ABCI ExtendVote. Signed separately, it isn't part of the SignBytes.
+	Extension          []byte `json:"extension,omitempty"`
+	ExtensionSignature []byte `json:"extension_signature,omitempty"`
 }
 
 // CommitSig converts the Vote to a CommitSig.
@@ -77,6 +88,29 @@ func (vote *Vote) SignBytes(chainID string) []byte {
 	return bz
 }
 
+// ExtensionSignBytes returns the bytes the validator signs for the extension
+// of the vote.
+func (vote *Vote) ExtensionSignBytes(chainID string) []byte {
+	bz, err := cdc.MarshalBinaryLengthPrefixed(CanonicalizeVoteExtension(chainID, vote))
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// WithoutExtension returns a copy of the vote without its extension, as
+// included in the commits of the blocks. Returns the vote itself if it has
+// no extension.
+func (vote *Vote) WithoutExtension() *Vote {
+	if vote == nil || (len(vote.Extension) == 0 && len(vote.ExtensionSignature) == 0) {
+		return vote
+	}
+	voteCopy := vote.Copy()
+	voteCopy.Extension = nil
+	voteCopy.ExtensionSignature = nil
+	return voteCopy
+}
+
 func (vote *Vote) Copy() *Vote {
 	voteCopy := *vote
 	return &voteCopy
@@ -120,6 +154,26 @@ func (vote *Vote) Verify(chainID string, pubKey crypto.PubKey) error {
 	return nil
 }
 
+// VerifyExtension checks the signature of the extension of the vote. The
+// precommits for a block have one, even if their extension is empty, as the
+// extension isn't part of the sign bytes: it could be stripped otherwise.
+// The other votes must not have an extension signature.
+func (vote *Vote) VerifyExtension(chainID string, pubKey crypto.PubKey) error {
+	if vote.Type != PrecommitType || vote.BlockID.IsZero() {
+		if len(vote.Extension) != 0 || len(vote.ExtensionSignature) != 0 {
+			return ErrVoteInvalidExtensionSignature
+		}
+		return nil
+	}
+	if len(vote.ExtensionSignature) == 0 {
+		return ErrVoteMissingExtension
+	}
+	if !pubKey.VerifyBytes(vote.ExtensionSignBytes(chainID), vote.ExtensionSignature) {
+		return ErrVoteInvalidExtensionSignature
+	}
+	return nil
+}
+
 // ValidateBasic performs basic validation.
 func (vote *Vote) ValidateBasic() error {
 	if !IsVoteTypeValid(vote.Type) {
@@ -157,5 +211,19 @@ func (vote *Vote) ValidateBasic() error {
 	if len(vote.Signature) > MaxSignatureSize {
 		return fmt.Errorf("Signature is too big (max: %d)", MaxSignatureSize)
 	}
+	if len(vote.Extension) > 0 || len(vote.ExtensionSignature) > 0 {
+		if vote.Type != PrecommitType || vote.BlockID.IsZero() {
+			return errors.New("Only precommits for a block can have an extension")
+		}
+		if len(vote.Extension) > MaxVoteExtensionSize {
+			return fmt.Errorf("Extension is too big (max: %d)", MaxVoteExtensionSize)
+		}
+		if len(vote.ExtensionSignature) == 0 {
+			return errors.New("Extension signature is missing")
+		}
+		if len(vote.ExtensionSignature) > MaxSignatureSize {
+			return fmt.Errorf("Extension signature is too big (max: %d)", MaxSignatureSize)
+		}
+	}
 	return nil
 }
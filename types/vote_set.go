@@ -123,7 +123,8 @@ func (voteSet *VoteSet) Size() int {
 // Otherwise returns err=ErrVote[
 //		UnexpectedStep | InvalidIndex | InvalidAddress |
 //		InvalidSignature | InvalidBlockHash | ConflictingVotes ]
-// Duplicate votes return added=false, err=nil.
+// Duplicate votes return added=false, err=nil. A duplicate precommit with
+// the extension the known one lacks replaces it.
 // Conflicting votes return added=*, err=ErrVoteConflictingVotes.
 // NOTE: vote should not be mutated after adding.
 // NOTE: VoteSet must not be nil
@@ -180,6 +181,13 @@ func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 	// If we already know of this vote, return false.
 	if existing, ok := voteSet.getVote(valIndex, blockKey); ok {
 		if bytes.Equal(existing.Signature, vote.Signature) {
+			// Keep the extension of a precommit we only had from a commit.
+			if len(existing.ExtensionSignature) == 0 && len(vote.ExtensionSignature) != 0 {
+				if err := vote.VerifyExtension(voteSet.chainID, val.PubKey); err != nil {
+					return false, errors.Wrapf(err, "Failed to verify vote extension with ChainID %s and PubKey %s", voteSet.chainID, val.PubKey)
+				}
+				voteSet.replaceVote(vote, blockKey)
+			}
 			return false, nil // duplicate
 		}
 		return false, errors.Wrapf(ErrVoteNonDeterministicSignature, "Existing vote: %v; New vote: %v", existing, vote)
@@ -212,6 +220,18 @@ func (voteSet *VoteSet) getVote(valIndex int, blockKey string) (vote *Vote, ok b
 	return nil, false
 }
 
+// Replaces the known vote of the validator for blockKey by the same vote,
+// with its extension.
+func (voteSet *VoteSet) replaceVote(vote *Vote, blockKey string) {
+	valIndex := vote.ValidatorIndex
+	if existing := voteSet.votes[valIndex]; existing != nil && existing.BlockID.Key() == blockKey {
+		voteSet.votes[valIndex] = vote
+	}
+	if votesByBlock, ok := voteSet.votesByBlock[blockKey]; ok && votesByBlock.votes[valIndex] != nil {
+		votesByBlock.votes[valIndex] = vote
+	}
+}
+
 // Assumes signature is valid.
 // If conflicting vote exists, returns it.
 func (voteSet *VoteSet) addVerifiedVote(vote *Vote, blockKey string, votingPower int64) (added bool, conflicting *Vote) {
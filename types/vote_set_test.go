@@ -528,3 +528,55 @@ func TestMakeCommit(t *testing.T) {
 	}
 
 }
+
+func TestAddVoteReplacesStrippedExtension(t *testing.T) {
+	height, round := int64(1), 0
+	voteSet, _, privValidators := randVoteSet(height, round, PrecommitType, 4, 1)
+	blockHash, blockPartsHeader := crypto.CRandBytes(32), PartSetHeader{123, crypto.CRandBytes(32)}
+
+	vote := &Vote{
+		ValidatorAddress: privValidators[0].GetPubKey().Address(),
+		ValidatorIndex:   0,
+		Height:           height,
+		Round:            round,
+		Timestamp:        tmtime.Now(),
+		Type:             PrecommitType,
+		BlockID:          BlockID{blockHash, blockPartsHeader},
+		Extension:        []byte("extension"),
+	}
+	if err := privValidators[0].SignVote(voteSet.ChainID(), vote); err != nil {
+		t.Fatal(err)
+	}
+
+	added, err := voteSet.AddVote(vote.WithoutExtension())
+	if !added || err != nil {
+		t.Fatalf("Expected to add the stripped vote, got %v, %v", added, err)
+	}
+
+	// A tampered extension is rejected.
+	tampered := vote.Copy()
+	tampered.Extension = []byte("tampered")
+	if _, err := voteSet.AddVote(tampered); err == nil {
+		t.Errorf("Expected an error adding a vote with a tampered extension")
+	}
+	if ext := voteSet.GetByIndex(0).Extension; len(ext) != 0 {
+		t.Errorf("Expected the vote without extension to be kept, got %X", ext)
+	}
+
+	// The extended vote is a duplicate, which replaces the stripped one.
+	added, err = voteSet.AddVote(vote)
+	if added || err != nil {
+		t.Fatalf("Expected a duplicate, got %v, %v", added, err)
+	}
+	if ext := voteSet.GetByIndex(0).Extension; !bytes.Equal(ext, vote.Extension) {
+		t.Errorf("Expected the extended vote to replace the stripped one, got %X", ext)
+	}
+
+	// But not the other way around.
+	if added, err := voteSet.AddVote(vote.WithoutExtension()); added || err != nil {
+		t.Fatalf("Expected a duplicate, got %v, %v", added, err)
+	}
+	if ext := voteSet.GetByIndex(0).Extension; !bytes.Equal(ext, vote.Extension) {
+		t.Errorf("Expected the extended vote to be kept, got %X", ext)
+	}
+}
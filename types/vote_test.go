@@ -282,6 +282,23 @@ func TestVoteValidateBasic(t *testing.T) {
 		{"Invalid ValidatorIndex", func(v *Vote) { v.ValidatorIndex = -1 }, true},
 		{"Invalid Signature", func(v *Vote) { v.Signature = nil }, true},
 		{"Too big Signature", func(v *Vote) { v.Signature = make([]byte, MaxSignatureSize+1) }, true},
+		{"Extension", func(v *Vote) { v.Extension, v.ExtensionSignature = []byte("ext"), []byte("sig") }, false},
+		{"Extension of a nil precommit", func(v *Vote) {
+			v.BlockID = BlockID{}
+			v.Extension, v.ExtensionSignature = []byte("ext"), []byte("sig")
+		}, true},
+		{"Extension of a prevote", func(v *Vote) {
+			v.Type = PrevoteType
+			v.Extension, v.ExtensionSignature = []byte("ext"), []byte("sig")
+		}, true},
+		{"Too big Extension", func(v *Vote) {
+			v.Extension, v.ExtensionSignature = make([]byte, MaxVoteExtensionSize+1), []byte("sig")
+		}, true},
+		{"Empty Extension", func(v *Vote) { v.Extension = nil }, false},
+		{"Missing Extension Signature", func(v *Vote) { v.Extension, v.ExtensionSignature = []byte("ext"), nil }, true},
+		{"Too big Extension Signature", func(v *Vote) {
+			v.Extension, v.ExtensionSignature = []byte("ext"), make([]byte, MaxSignatureSize+1)
+		}, true},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.testName, func(t *testing.T) {
@@ -293,3 +310,59 @@ func TestVoteValidateBasic(t *testing.T) {
 		})
 	}
 }
+
+func TestVoteExtension(t *testing.T) {
+	privVal := NewMockPV()
+	pubkey := privVal.GetPubKey()
+
+	vote := examplePrecommit()
+	vote.ValidatorAddress = pubkey.Address()
+	vote.Extension = []byte("extension")
+	require.NoError(t, privVal.SignVote("test_chain_id", vote))
+	require.NotEmpty(t, vote.ExtensionSignature)
+
+	// The extension isn't part of the sign bytes.
+	assert.Equal(t, vote.WithoutExtension().SignBytes("test_chain_id"), vote.SignBytes("test_chain_id"))
+	assert.NoError(t, vote.Verify("test_chain_id", pubkey))
+	assert.NoError(t, vote.VerifyExtension("test_chain_id", pubkey))
+	assert.Equal(t, ErrVoteInvalidExtensionSignature, vote.VerifyExtension("other_chain_id", pubkey))
+
+	tampered := vote.Copy()
+	tampered.Extension = []byte("tampered")
+	assert.Equal(t, ErrVoteInvalidExtensionSignature, tampered.VerifyExtension("test_chain_id", pubkey))
+	assert.NoError(t, tampered.Verify("test_chain_id", pubkey))
+
+	// A precommit for a block stripped of its extension is still valid, but
+	// its extension is missing.
+	stripped := vote.WithoutExtension()
+	assert.Empty(t, stripped.Extension)
+	assert.Empty(t, stripped.ExtensionSignature)
+	assert.NotEmpty(t, vote.Extension)
+	assert.NoError(t, stripped.Verify("test_chain_id", pubkey))
+	assert.Equal(t, ErrVoteMissingExtension, stripped.VerifyExtension("test_chain_id", pubkey))
+	stripped.ExtensionSignature = vote.ExtensionSignature
+	assert.Equal(t, ErrVoteInvalidExtensionSignature, stripped.VerifyExtension("test_chain_id", pubkey))
+
+	// An empty extension is signed too.
+	empty := examplePrecommit()
+	empty.ValidatorAddress = pubkey.Address()
+	require.NoError(t, privVal.SignVote("test_chain_id", empty))
+	require.NotEmpty(t, empty.ExtensionSignature)
+	assert.NoError(t, empty.ValidateBasic())
+	assert.NoError(t, empty.VerifyExtension("test_chain_id", pubkey))
+
+	// Unlike a nil precommit.
+	nilPrecommit := examplePrecommit()
+	nilPrecommit.ValidatorAddress = pubkey.Address()
+	nilPrecommit.BlockID = BlockID{}
+	require.NoError(t, privVal.SignVote("test_chain_id", nilPrecommit))
+	assert.Empty(t, nilPrecommit.ExtensionSignature)
+	assert.NoError(t, nilPrecommit.VerifyExtension("test_chain_id", pubkey))
+
+	// Nor is it in the commits of the blocks.
+	commit := NewCommit(vote.BlockID, []*CommitSig{vote.CommitSig(), nil})
+	assert.True(t, commit.hasExtensions())
+	assert.False(t, commit.WithoutExtensions().hasExtensions())
+	assert.Equal(t, vote.WithoutExtension().CommitSig(), commit.WithoutExtensions().Precommits[0])
+	assert.NotEqual(t, commit.Hash(), commit.WithoutExtensions().Hash())
+}